@@ -0,0 +1,28 @@
+/*
+Package bench holds reproducible benchmarks for the packages of this module,
+intended for tracking performance regressions. It has no API; all benchmarks
+live in test files and are run with
+
+	go test -run '^$' -bench . -benchmem ./bench
+
+Benchmarks operate on the fonts in testdata/fonts and on synthetic fonts built
+in the benchmark code, so results are comparable between machines running the
+same revision. They cover
+
+  - parsing of whole fonts and of single tables (BenchmarkParse, BenchmarkParseTable),
+  - resolution of layout features (BenchmarkFontFeatures),
+  - application of ligature and kerning features (BenchmarkApplyFeature),
+  - end-to-end shaping, one sub-benchmark per script (BenchmarkShape).
+
+All benchmarks report allocations. Shaping of scripts without a shaping engine
+in package otshape, e.g. Devanagari, is not covered yet; sub-benchmarks are to
+be added together with the engines.
+
+Comparing runs with benchstat is recommended:
+
+	go test -run '^$' -bench . -count 10 ./bench > old.txt
+	# … apply changes …
+	go test -run '^$' -bench . -count 10 ./bench > new.txt
+	benchstat old.txt new.txt
+*/
+package bench
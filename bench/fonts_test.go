@@ -0,0 +1,178 @@
+package bench
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+)
+
+// benchFonts are the fonts of testdata/fonts used for benchmarks. Calibri has
+// large kerning tables, Gentium has many ligatures and mark positioning lookups.
+var benchFonts = []string{"Calibri.ttf", "GentiumPlus-R.ttf"}
+
+// fontBinary reads a font of testdata/fonts.
+func fontBinary(tb testing.TB, name string) []byte {
+	tb.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "testdata", "fonts", name))
+	if err != nil {
+		tb.Fatalf("cannot read font %s: %v", name, err)
+	}
+	return data
+}
+
+// loadFont reads and parses a font of testdata/fonts.
+func loadFont(tb testing.TB, name string) *ot.Font {
+	tb.Helper()
+	otf, err := ot.Parse(fontBinary(tb, name))
+	if err != nil {
+		tb.Fatalf("cannot parse font %s: %v", name, err)
+	}
+	return otf
+}
+
+// mapText maps the characters of text to glyphs of otf, without shaping.
+func mapText(otf *ot.Font, text string) []ot.GlyphIndex {
+	glyphs := make([]ot.GlyphIndex, 0, len(text))
+	for _, r := range text {
+		glyphs = append(glyphs, otquery.GlyphIndex(otf, r))
+	}
+	return glyphs
+}
+
+// tableTags returns the tags of the table directory of font binary data.
+func tableTags(data []byte) []string {
+	n := int(binary.BigEndian.Uint16(data[4:]))
+	tags := make([]string, n)
+	for i := range n {
+		tags[i] = string(data[12+16*i : 16+16*i])
+	}
+	return tags
+}
+
+// subsetTables returns a copy of font binary data with only the tables
+// listed in keep. Checksums are copied, not re-calculated.
+func subsetTables(data []byte, keep []string) []byte {
+	type record struct {
+		tag       []byte
+		checksum  uint32
+		data      []byte
+		newOffset uint32
+	}
+	var recs []record
+	for i := range int(binary.BigEndian.Uint16(data[4:])) {
+		r := data[12+16*i : 28+16*i]
+		if !slices.Contains(keep, string(r[:4])) {
+			continue
+		}
+		off, size := binary.BigEndian.Uint32(r[8:]), binary.BigEndian.Uint32(r[12:])
+		recs = append(recs, record{tag: r[:4], checksum: binary.BigEndian.Uint32(r[4:]), data: data[off : off+size]})
+	}
+	offset := uint32(12 + 16*len(recs))
+	for i := range recs {
+		recs[i].newOffset = offset
+		offset += (uint32(len(recs[i].data)) + 3) &^ 3
+	}
+	out := make([]byte, offset)
+	copy(out, data[:12])
+	binary.BigEndian.PutUint16(out[4:], uint16(len(recs)))
+	for i, rec := range recs {
+		r := out[12+16*i:]
+		copy(r, rec.tag)
+		binary.BigEndian.PutUint32(r[4:], rec.checksum)
+		binary.BigEndian.PutUint32(r[8:], rec.newOffset)
+		binary.BigEndian.PutUint32(r[12:], uint32(len(rec.data)))
+		copy(out[rec.newOffset:], rec.data)
+	}
+	return out
+}
+
+// Arabic letters of the synthetic Arabic font, by name of the isolated form.
+// Dual-joining letters have forms .init, .medi and .fina, right-joining
+// letters have a form .fina.
+var (
+	arabicDual  = map[string]rune{"beh": 'ب', "hah": 'ح', "seen": 'س', "lam": 'ل', "meem": 'م', "noon": 'ن', "heh": 'ه'}
+	arabicRight = map[string]rune{"alef": 'ا', "reh": 'ر'}
+)
+
+// arabicFont builds a synthetic Arabic font with joining forms, lam-alef
+// ligatures, kerning and a fatha attaching to the letters. There is no font
+// for Arabic in testdata/fonts.
+func arabicFont(tb testing.TB) *ot.Font {
+	tb.Helper()
+	names := []string{"space", "fatha", "lam_alef", "lam_alef.fina"}
+	var dual, right []string
+	for name := range arabicDual {
+		dual = append(dual, name)
+	}
+	for name := range arabicRight {
+		right = append(right, name)
+	}
+	slices.Sort(dual)
+	slices.Sort(right)
+	for _, name := range dual {
+		names = append(names, name, name+".init", name+".medi", name+".fina")
+	}
+	for _, name := range right {
+		names = append(names, name, name+".fina")
+	}
+	b := testfont.New(len(names) + 1)
+	for i, name := range names {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	glyph := func(name string) ot.GlyphIndex {
+		return ot.GlyphIndex(slices.Index(names, name) + 1)
+	}
+	b.Map(' ', glyph("space")).Map('َ', glyph("fatha"))
+	for name, r := range arabicDual {
+		b.Map(r, glyph(name))
+	}
+	for name, r := range arabicRight {
+		b.Map(r, glyph(name))
+	}
+	var fea strings.Builder
+	fea.WriteString("languagesystem arab dflt;\n")
+	fmt.Fprintf(&fea, "table GDEF { GlyphClassDef [%s], [lam_alef lam_alef.fina], [fatha], ; } GDEF;\n",
+		strings.Join(names[4:], " "))
+	for _, form := range []string{"init", "medi", "fina"} {
+		fmt.Fprintf(&fea, "feature %s {\n", form)
+		for _, name := range dual {
+			fmt.Fprintf(&fea, "  sub %s by %s.%s;\n", name, name, form)
+		}
+		if form == "fina" {
+			for _, name := range right {
+				fmt.Fprintf(&fea, "  sub %s by %s.fina;\n", name, name)
+			}
+		}
+		fmt.Fprintf(&fea, "} %s;\n", form)
+	}
+	fea.WriteString(`feature rlig {
+  sub lam.init alef.fina by lam_alef;
+  sub lam.medi alef.fina by lam_alef.fina;
+} rlig;
+feature kern {
+  pos [reh reh.fina] [beh.fina noon.fina] <-40 0 -40 0>;
+} kern;
+markClass fatha <anchor 0 700> @TOP;
+feature mark {
+`)
+	for _, name := range names[2:] {
+		fmt.Fprintf(&fea, "  pos base %s <anchor 250 650> mark @TOP;\n", name)
+	}
+	fea.WriteString("} mark;\n")
+	if err := b.Features(fea.String()); err != nil {
+		tb.Fatalf("cannot compile features of synthetic Arabic font: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		tb.Fatalf("cannot parse synthetic Arabic font: %v", err)
+	}
+	return otf
+}
@@ -0,0 +1,93 @@
+package bench
+
+import (
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+)
+
+// latinText is a Latin sample with ligatures and kerning pairs.
+const latinText = "Efficient offices flow; AVATAR, Yoyo, Taffy and WAVE fill the official first floor."
+
+func BenchmarkFontFeatures(b *testing.B) {
+	latn := ot.T("latn")
+	for _, name := range benchFonts {
+		data := fontBinary(b, name)
+		b.Run(name+"/cold", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				b.StopTimer()
+				otf, err := ot.Parse(data)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.StartTimer()
+				if _, _, err := otlayout.FontFeatures(otf, latn, ot.DFLT); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+		b.Run(name+"/cached", func(b *testing.B) {
+			otf := loadFont(b, name)
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, _, err := otlayout.FontFeatures(otf, latn, ot.DFLT); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// latinFeature returns the Latin feature of otf with the given tag, or nil.
+func latinFeature(tb testing.TB, otf *ot.Font, tag ot.Tag) otlayout.Feature {
+	tb.Helper()
+	gsub, gpos, err := otlayout.FontFeatures(otf, ot.T("latn"), ot.DFLT)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	for _, feat := range append(gsub, gpos...) {
+		if feat != nil && feat.Tag() == tag {
+			return feat
+		}
+	}
+	return nil
+}
+
+// BenchmarkApplyFeature applies a feature at every position of latinText,
+// without a shaping pipeline around. As in package otshape, GSUB features are
+// applied without a position buffer.
+func BenchmarkApplyFeature(b *testing.B) {
+	for _, name := range benchFonts {
+		otf := loadFont(b, name)
+		glyphs := mapText(otf, latinText)
+		for _, tag := range []ot.Tag{ot.T("liga"), ot.T("kern")} {
+			feat := latinFeature(b, otf, tag)
+			if feat == nil {
+				continue
+			}
+			b.Run(name+"/"+tag.String(), func(b *testing.B) {
+				buf := make(otlayout.GlyphBuffer, len(glyphs))
+				pos := make(otlayout.PosBuffer, len(glyphs))
+				st := &otlayout.BufferState{}
+				b.ReportAllocs()
+				for b.Loop() {
+					copy(buf, glyphs)
+					clear(pos)
+					*st = otlayout.BufferState{Glyphs: buf[:len(glyphs)]}
+					if feat.Type() == otlayout.GPosFeatureType {
+						st.Pos = pos[:len(glyphs)]
+					}
+					for st.Index < st.Len() {
+						index, n := st.Index, st.Len()
+						_, ok := otlayout.ApplyFeature(otf, feat, st, 0)
+						if !ok || st.Index == index && st.Len() == n {
+							st.Index++
+						}
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package bench
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// baseTables are the tables needed to parse a font at all (with option
+// ot.IsTestfont), see BenchmarkParseTable.
+var baseTables = []string{"cmap", "head", "hhea", "maxp"}
+
+func BenchmarkParse(b *testing.B) {
+	for _, name := range benchFonts {
+		data := fontBinary(b, name)
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			b.SetBytes(int64(len(data)))
+			for b.Loop() {
+				if _, err := ot.Parse(data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkParseTable parses fonts reduced to the base tables plus a single
+// table. The sub-benchmark "base" parses the base tables only; the cost of
+// parsing a table is the difference to "base".
+func BenchmarkParseTable(b *testing.B) {
+	for _, name := range benchFonts {
+		data := fontBinary(b, name)
+		tags := append([]string{"base"}, tableTags(data)...)
+		for _, tag := range tags {
+			if slices.Contains(baseTables, tag) {
+				continue
+			}
+			keep := append(slices.Clone(baseTables), tag)
+			subset := subsetTables(data, keep)
+			if _, err := ot.Parse(subset, ot.IsTestfont); err != nil {
+				b.Logf("skipping table %s of %s: %v", tag, name, err)
+				continue
+			}
+			b.Run(name+"/"+tag, func(b *testing.B) {
+				b.ReportAllocs()
+				for b.Loop() {
+					if _, err := ot.Parse(subset, ot.IsTestfont); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package bench
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otarabic"
+	"github.com/npillmayer/opentype/otshape/otcore"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// shapeCase is an end-to-end shaping benchmark for a script.
+type shapeCase struct {
+	name   string
+	font   func(testing.TB) *ot.Font
+	script string
+	lang   language.Tag
+	dir    bidi.Direction
+	text   string
+}
+
+func testdataFont(name string) func(testing.TB) *ot.Font {
+	return func(tb testing.TB) *ot.Font {
+		return loadFont(tb, name)
+	}
+}
+
+var shapeCases = []shapeCase{
+	{"Latin/Calibri", testdataFont("Calibri.ttf"), "Latn", language.English, bidi.LeftToRight, latinText},
+	{"Latin/Gentium", testdataFont("GentiumPlus-R.ttf"), "Latn", language.English, bidi.LeftToRight, latinText},
+	{"Greek/Gentium", testdataFont("GentiumPlus-R.ttf"), "Grek", language.Greek, bidi.LeftToRight,
+		"Ἐν ἀρχῇ ἦν ὁ λόγος, καὶ ὁ λόγος ἦν πρὸς τὸν θεόν."},
+	{"Cyrillic/Gentium", testdataFont("GentiumPlus-R.ttf"), "Cyrl", language.Russian, bidi.LeftToRight,
+		"Съешь же ещё этих мягких французских булок, да выпей чаю."},
+	{"Arabic/synthetic", arabicFont, "Arab", language.Arabic, bidi.RightToLeft,
+		"بسم الله الرحمن سلام حسن بَحر نهر"},
+}
+
+type countingSink struct{ n int }
+
+func (s *countingSink) WriteGlyph(otshape.GlyphRecord) error { s.n++; return nil }
+
+// shapeFunc returns a function which shapes the text of c with a re-used
+// shaper, as servers are expected to do.
+func shapeFunc(tb testing.TB, c shapeCase) (func() error, *countingSink) {
+	params := otshape.Params{
+		Font:      c.font(tb),
+		Direction: c.dir,
+		Script:    language.MustParseScript(c.script),
+		Language:  c.lang,
+	}
+	shaper := otshape.NewShaper(otarabic.New(), otcore.New())
+	src := strings.NewReader("")
+	sink := &countingSink{}
+	return func() error {
+		src.Reset(c.text)
+		sink.n = 0
+		return shaper.Shape(params, src, sink, otshape.BufferOptions{})
+	}, sink
+}
+
+func BenchmarkShape(b *testing.B) {
+	for _, c := range shapeCases {
+		b.Run(c.name, func(b *testing.B) {
+			shape, _ := shapeFunc(b, c)
+			b.ReportAllocs()
+			b.SetBytes(int64(len(c.text)))
+			for b.Loop() {
+				if err := shape(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// TestBenchmarkInputs checks that the fonts cover the texts of the benchmarks,
+// which would otherwise measure shaping of .notdef glyphs.
+func TestBenchmarkInputs(t *testing.T) {
+	for _, c := range shapeCases {
+		otf := c.font(t)
+		for i, g := range mapText(otf, c.text) {
+			if g == 0 {
+				t.Errorf("%s: font does not map character #%d of text", c.name, i)
+			}
+		}
+		shape, sink := shapeFunc(t, c)
+		if err := shape(); err != nil {
+			t.Errorf("%s: shaping failed: %v", c.name, err)
+		} else if sink.n == 0 {
+			t.Errorf("%s: shaping produced no glyphs", c.name)
+		}
+	}
+}
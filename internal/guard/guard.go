@@ -0,0 +1,74 @@
+/*
+Package guard keeps panics out of the public API of the packages of this module.
+
+Internal assertions of packages ot, otlayout and otshape, as well as runtime
+errors caused by conditions the code has not been prepared for, result in
+panics. By default, the entry points of these packages (e.g. ot.Parse,
+otlayout.ApplyFeature or otshape's Shaper.Shape) recover such panics, trace
+them, and report them as errors of type *Error. Fonts and text from untrusted
+sources therefore cannot crash a client.
+
+Building with tag 'otassert' disables recovering, to get stack traces of
+failed assertions while debugging or testing:
+
+	go test -tags otassert ./...
+*/
+package guard
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/npillmayer/schuko/tracing"
+)
+
+// Error reports a panic which has been recovered by Recover.
+type Error struct {
+	Op    string // operation which has been aborted, e.g. "ot.Parse"
+	Value any    // value passed to panic
+	Kind  error  // sentinel error the error matches, or nil
+	Stack []byte // stack trace of the panicking goroutine
+}
+
+func (e *Error) Error() string {
+	if e.Kind != nil {
+		return fmt.Sprintf("%v: %s: internal error: %v", e.Kind, e.Op, e.Value)
+	}
+	return fmt.Sprintf("%s: internal error: %v", e.Op, e.Value)
+}
+
+// Is reports whether target is the sentinel error e.Kind.
+func (e *Error) Is(target error) bool {
+	return e.Kind != nil && target == e.Kind
+}
+
+// Unwrap returns the value passed to panic if it is an error, e.g. a
+// runtime.Error, and nil otherwise.
+func (e *Error) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
+
+// Recover recovers a panic of the calling goroutine, traces it and stores it as
+// an *Error in *errp, overwriting any previous error. kind, if non-nil, is
+// matched by the stored error (see Error.Is). Recover has to be deferred
+// directly:
+//
+//	defer guard.Recover(&err, tracer(), "ot.Parse", ErrFontFormat)
+//
+// With build tag 'otassert', Recover does nothing and panics propagate.
+func Recover(errp *error, trace tracing.Trace, op string, kind error) {
+	if Panics {
+		return
+	}
+	r := recover()
+	if r == nil {
+		return
+	}
+	err := &Error{Op: op, Value: r, Kind: kind, Stack: debug.Stack()}
+	trace.Errorf("%v", err)
+	trace.Debugf("%s", err.Stack)
+	*errp = err
+}
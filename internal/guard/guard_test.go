@@ -0,0 +1,36 @@
+package guard
+
+import (
+	"errors"
+	"runtime"
+	"testing"
+
+	"github.com/npillmayer/schuko/tracing"
+)
+
+var errKind = errors.New("test kind")
+
+func failing(index int) (err error) {
+	defer Recover(&err, tracing.Select("opentype.test"), "guard.failing", errKind)
+	var a []int
+	_ = a[index]
+	return nil
+}
+
+func TestRecover(t *testing.T) {
+	if Panics {
+		t.Skip("built with tag otassert")
+	}
+	err := failing(3)
+	var gerr *Error
+	if !errors.As(err, &gerr) || gerr.Op != "guard.failing" || len(gerr.Stack) == 0 {
+		t.Fatalf("expected recovered panic, have %v", err)
+	}
+	if !errors.Is(err, errKind) {
+		t.Errorf("expected error to match its kind")
+	}
+	var rerr runtime.Error
+	if !errors.As(err, &rerr) {
+		t.Errorf("expected error to wrap the runtime error")
+	}
+}
@@ -0,0 +1,7 @@
+//go:build !otassert
+
+package guard
+
+// Panics reports whether panics propagate out of the public API, i.e., whether
+// the module has been built with tag 'otassert'.
+const Panics = false
@@ -14,6 +14,7 @@ import (
 	"github.com/npillmayer/opentype/otshape/otarabic"
 	"github.com/npillmayer/opentype/otshape/otcore"
 	"github.com/npillmayer/opentype/otshape/othebrew"
+	"github.com/npillmayer/opentype/otshape/ottibetan"
 	"golang.org/x/text/language"
 	"golang.org/x/text/unicode/bidi"
 )
@@ -179,6 +180,7 @@ func shapeFixture(f fixture) ([]hbShapedGlyph, error) {
 		otcore.New(),
 		otarabic.New(),
 		othebrew.New(),
+		ottibetan.New(),
 	)
 	sink := &glyphCollector{}
 	if err := shaper.Shape(
@@ -269,6 +271,29 @@ func parseFeatures(spec []string) ([]otshape.FeatureRange, error) {
 }
 
 func loadFixtureFont(name string, isTestFont bool) (*ot.Font, error) {
+	path, err := findFixtureFont(name)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var font *ot.Font
+	if isTestFont {
+		font, err = ot.Parse(raw, ot.IsTestfont)
+	} else {
+		font, err = ot.Parse(raw)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse font %s: %w", path, err)
+	}
+	return font, nil
+}
+
+// findFixtureFont locates a fixture font file, looking in the repository's
+// testdata folders for relative names.
+func findFixtureFont(name string) (string, error) {
 	var candidates []string
 	if filepath.IsAbs(name) {
 		candidates = append(candidates, name)
@@ -283,32 +308,52 @@ func loadFixtureFont(name string, isTestFont bool) (*ot.Font, error) {
 			name,
 		)
 	}
-	var (
-		raw  []byte
-		path string
-	)
 	for _, p := range candidates {
-		b, err := os.ReadFile(p)
-		if err == nil {
-			raw = b
-			path = p
-			break
+		if info, err := os.Stat(p); err == nil && !info.IsDir() {
+			return p, nil
 		}
 	}
-	if raw == nil {
-		return nil, fmt.Errorf("fixture font not found: %q", name)
+	return "", fmt.Errorf("fixture font not found: %q", name)
+}
+
+// passRates counts passing fixtures per script.
+type passRates map[string]*passRate
+
+type passRate struct {
+	passed, total int
+}
+
+func (pr passRates) add(script string, passed bool) {
+	r := pr[script]
+	if r == nil {
+		r = &passRate{}
+		pr[script] = r
 	}
-	var (
-		font *ot.Font
-		err  error
-	)
-	if isTestFont {
-		font, err = ot.Parse(raw, ot.IsTestfont)
-	} else {
-		font, err = ot.Parse(raw)
+	r.total++
+	if passed {
+		r.passed++
 	}
-	if err != nil {
-		return nil, fmt.Errorf("parse font %s: %w", path, err)
+}
+
+// String returns a report of pass rates, one line per script, followed by
+// a line with the overall pass rate.
+func (pr passRates) String() string {
+	scripts := make([]string, 0, len(pr))
+	for script := range pr {
+		scripts = append(scripts, script)
 	}
-	return font, nil
+	sort.Strings(scripts)
+	var sb strings.Builder
+	all := passRate{}
+	line := func(label string, r passRate) {
+		fmt.Fprintf(&sb, "%-6s %4d/%-4d %6.1f%%\n", label, r.passed, r.total,
+			100*float64(r.passed)/float64(max(r.total, 1)))
+	}
+	for _, script := range scripts {
+		line(script, *pr[script])
+		all.passed += pr[script].passed
+		all.total += pr[script].total
+	}
+	line("total", all)
+	return sb.String()
 }
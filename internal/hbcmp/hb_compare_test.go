@@ -2,6 +2,7 @@ package hbcmp
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -15,9 +16,10 @@ func TestHarfBuzzParityFixtures(t *testing.T) {
 	if len(fixtures) == 0 {
 		t.Fatalf("no hbcmp fixtures found in testdata")
 	}
+	rates := passRates{}
 	for i, fx := range fixtures {
 		name := fixtureName(i, fx)
-		t.Run(name, func(t *testing.T) {
+		passed := t.Run(name, func(t *testing.T) {
 			got, err := shapeFixture(fx)
 			if err != nil {
 				t.Fatalf("shape fixture: %v", err)
@@ -26,6 +28,53 @@ func TestHarfBuzzParityFixtures(t *testing.T) {
 				t.Fatalf("%v\ngot=%#v\nwant=%#v", err, got, fx.Output)
 			}
 		})
+		rates.add(fx.Context.Script, passed)
+	}
+	t.Logf("HarfBuzz parity per script:\n%s", rates)
+}
+
+func TestHarfBuzzCorpusRecorded(t *testing.T) {
+	corpus, err := loadCorpus(filepath.Join("testdata", "corpus", "corpus.json"))
+	if err != nil {
+		t.Fatalf("load corpus: %v", err)
+	}
+	var missing []string
+	for _, entry := range corpus {
+		if _, err := os.Stat(filepath.Join("testdata", entry.Name+".json")); err != nil {
+			missing = append(missing, entry.Name)
+		}
+	}
+	if len(missing) > 0 {
+		t.Errorf("%d of %d corpus entries have no recorded fixture (run tests with -tags hbshape): %s",
+			len(missing), len(corpus), strings.Join(missing, ", "))
+	}
+}
+
+func TestPassRatesReport(t *testing.T) {
+	rates := passRates{}
+	rates.add("Latn", true)
+	rates.add("Latn", false)
+	rates.add("Arab", true)
+	want := "Arab      1/1     100.0%\n" +
+		"Latn      1/2      50.0%\n" +
+		"total     2/3      66.7%\n"
+	if got := rates.String(); got != want {
+		t.Errorf("unexpected report:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestHBShapeArgs(t *testing.T) {
+	entry := corpusEntry{
+		Name: "x",
+		Text: "fi",
+		Context: fixtureContext{Font: "f.ttf", Script: "Latn", Language: "en", Dir: "LTR",
+			Features: []string{"-liga", "kern"}},
+	}
+	got := strings.Join(hbShapeArgs(entry.fixture(), "/fonts/f.ttf"), " ")
+	want := "--font-file=/fonts/f.ttf --output-format=json --no-glyph-names --script=Latn " +
+		"--language=en --direction=ltr --unicodes=U+0066,U+0069 --features=-liga,kern"
+	if got != want {
+		t.Errorf("unexpected hb-shape arguments:\n%s\nwant:\n%s", got, want)
 	}
 }
 
@@ -0,0 +1,39 @@
+//go:build hbshape
+
+package hbcmp
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestRecordHarfBuzzFixtures shapes the corpus with hb-shape and (re-)writes
+// the fixture files in testdata. The hb-shape executable is taken from
+// environment variable HB_SHAPE, or searched for in PATH.
+//
+//	go test -tags hbshape -run TestRecordHarfBuzzFixtures ./internal/hbcmp
+func TestRecordHarfBuzzFixtures(t *testing.T) {
+	hbShape := os.Getenv("HB_SHAPE")
+	if hbShape == "" {
+		var err error
+		if hbShape, err = exec.LookPath("hb-shape"); err != nil {
+			t.Skip("hb-shape not found; set HB_SHAPE or install HarfBuzz utilities")
+		}
+	}
+	corpus, err := loadCorpus(filepath.Join("testdata", "corpus", "corpus.json"))
+	if err != nil {
+		t.Fatalf("load corpus: %v", err)
+	}
+	for _, entry := range corpus {
+		f, err := recordFixture(hbShape, entry)
+		if err != nil {
+			t.Errorf("record %s: %v", entry.Name, err)
+			continue
+		}
+		if err := writeFixture("testdata", entry.Name, f); err != nil {
+			t.Errorf("write %s: %v", entry.Name, err)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package hbcmp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// corpusEntry is a text/font pair to be shaped by HarfBuzz. Recording a corpus
+// entry produces a fixture file <name>.json (see TestRecordHarfBuzzFixtures).
+type corpusEntry struct {
+	Name    string         `json:"name"`
+	Text    string         `json:"text"`
+	Context fixtureContext `json:"context"`
+}
+
+func loadCorpus(path string) ([]corpusEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var corpus []corpusEntry
+	if err := json.Unmarshal(data, &corpus); err != nil {
+		return nil, err
+	}
+	for i, entry := range corpus {
+		if entry.Name == "" || entry.Text == "" {
+			return nil, fmt.Errorf("corpus entry %d: name and text are required", i)
+		}
+	}
+	return corpus, nil
+}
+
+// fixture creates an unrecorded fixture (without output) for a corpus entry.
+func (e corpusEntry) fixture() fixture {
+	f := fixture{SchemaVersion: 1, Context: e.Context}
+	for _, r := range e.Text {
+		f.Input = append(f.Input, uint32(r))
+	}
+	return f
+}
+
+// hbShapeArgs returns the command line arguments for hb-shape to shape the
+// input of fixture f with font file fontPath.
+func hbShapeArgs(f fixture, fontPath string) []string {
+	unicodes := make([]string, len(f.Input))
+	for i, cp := range f.Input {
+		unicodes[i] = fmt.Sprintf("U+%04X", cp)
+	}
+	args := []string{
+		"--font-file=" + fontPath,
+		"--output-format=json",
+		"--no-glyph-names",
+		"--script=" + f.Context.Script,
+		"--language=" + f.Context.Language,
+		"--direction=" + strings.ToLower(f.Context.Dir),
+		"--unicodes=" + strings.Join(unicodes, ","),
+	}
+	if len(f.Context.Features) > 0 {
+		args = append(args, "--features="+strings.Join(f.Context.Features, ","))
+	}
+	return args
+}
+
+// recordFixture shapes the input of corpus entry e by invoking the hb-shape
+// executable hbShape, and returns a fixture with HarfBuzz's output.
+func recordFixture(hbShape string, e corpusEntry) (fixture, error) {
+	f := e.fixture()
+	if err := f.validate(); err != nil {
+		return f, err
+	}
+	fontPath, err := findFixtureFont(f.Context.Font)
+	if err != nil {
+		return f, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(hbShape, hbShapeArgs(f, fontPath)...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return f, fmt.Errorf("hb-shape %s: %w: %s", e.Name, err, strings.TrimSpace(stderr.String()))
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &f.Output); err != nil {
+		return f, fmt.Errorf("hb-shape %s: cannot decode output: %w", e.Name, err)
+	}
+	return f, nil
+}
+
+// writeFixture writes fixture f to dir/<name>.json.
+func writeFixture(dir, name string, f fixture) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name+".json"), append(data, '\n'), 0o644)
+}
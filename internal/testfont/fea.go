@@ -0,0 +1,985 @@
+package testfont
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Features compiles lookups and features written in Adobe feature file syntax
+// and adds them to the font's GSUB and GPOS tables. It understands the subset
+// of the syntax needed to write test cases declaratively, which includes the
+// output of package otfea for these constructs:
+//
+//   - languagesystem statements, glyph class and mark class definitions
+//   - named lookup blocks (optionally with useExtension), lookupflag and
+//     subtable statements
+//   - feature blocks with rules, lookup references, script and language
+//     statements (including exclude_dflt)
+//   - GSUB rules: single, multiple, alternate and ligature substitution,
+//     chained contextual substitution with lookup references, ignore rules
+//     and reverse chaining substitution
+//   - GPOS rules: single and pair positioning, mark-to-base and mark-to-mark
+//     attachment, chained contextual positioning with lookup references
+//   - a GDEF table block with a GlyphClassDef statement
+//
+// Glyphs are referenced by names set with Name, by glyph index ('\12'), as
+// '.notdef', 'glyph00012', by code-point ('uni0041', 'u1F600') or by a single
+// character ('f'), with code-points mapped through the character map.
+//
+// Rules of a feature block which are not contained in a lookup block form
+// anonymous lookups; a new lookup is started whenever the lookup type changes.
+// Without languagesystem statements, features apply to the scripts the
+// builder registers by default (see Layout).
+func (b *Builder) Features(fea string) (err error) {
+	p := &feaParser{
+		b:           b,
+		toks:        feaTokens(fea),
+		classes:     make(map[string][]ot.GlyphIndex),
+		markClasses: make(map[string][]feaMark),
+		lookups:     make(map[string]feaLookupRef),
+		markAttach:  make(map[string]uint16),
+		markSets:    make(map[string]uint16),
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			ferr, ok := r.(feaError)
+			if !ok {
+				panic(r)
+			}
+			err = ferr
+		}
+	}()
+	p.parse()
+	p.finish()
+	return nil
+}
+
+// --- Tokens ----------------------------------------------------------------
+
+type feaToken struct {
+	text string
+	line int
+}
+
+// feaTokens splits feature file source into tokens. Punctuation characters
+// are tokens of their own; all other tokens are delimited by whitespace or
+// punctuation. Comments are dropped.
+func feaTokens(src string) []feaToken {
+	var toks []feaToken
+	line := 1
+	for i := 0; i < len(src); {
+		c := src[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case c == '#':
+			for i < len(src) && src[i] != '\n' {
+				i++
+			}
+		case strings.IndexByte(feaPunctuation, c) >= 0:
+			toks = append(toks, feaToken{text: src[i : i+1], line: line})
+			i++
+		default:
+			j := i
+			for j < len(src) && !strings.ContainsRune(" \t\r\n#"+feaPunctuation, rune(src[j])) {
+				j++
+			}
+			toks = append(toks, feaToken{text: src[i:j], line: line})
+			i = j
+		}
+	}
+	return toks
+}
+
+const feaPunctuation = "{}[]<>;',="
+
+// feaError is raised (as a panic) by the parser and recovered by Features.
+type feaError struct {
+	error
+}
+
+// --- Parser ----------------------------------------------------------------
+
+type feaParser struct {
+	b    *Builder
+	toks []feaToken
+	pos  int
+
+	classes     map[string][]ot.GlyphIndex // named glyph classes, including mark classes
+	markClasses map[string][]feaMark
+	lookups     map[string]feaLookupRef // named lookups
+	langsys     []feaLangSys            // from languagesystem statements
+	features    [2][]*feaFeature        // features of GSUB and GPOS
+	markAttach  map[string]uint16       // GDEF mark attachment classes by glyphs
+	markSets    map[string]uint16       // GDEF mark glyph sets by glyphs
+
+	// state within a feature block
+	feature *[2]*feaFeature
+	scope   []feaLangSys // language systems lookups are registered for
+	script  string
+	flag    ot.LayoutTableLookupFlag
+	markSet uint16
+	anon    *feaLookup // open anonymous lookup
+}
+
+type feaMark struct {
+	glyph  ot.GlyphIndex
+	anchor Anchor
+}
+
+type feaLookupRef struct {
+	gpos  bool
+	index int
+}
+
+// feaLangSys is a language system. The zero value stands for all language
+// systems, if no languagesystem statement is given.
+type feaLangSys struct {
+	script, lang string
+}
+
+// feaFeature collects the lookups of a feature tag per language system.
+type feaFeature struct {
+	tag     string
+	langsys []feaLangSys // in order of first registration
+	lookups map[feaLangSys][]int
+}
+
+func (p *feaParser) fail(format string, args ...any) {
+	line := 0
+	if p.pos > 0 && p.pos <= len(p.toks) {
+		line = p.toks[p.pos-1].line
+	} else if len(p.toks) > 0 {
+		line = p.toks[len(p.toks)-1].line
+	}
+	panic(feaError{fmt.Errorf("fea: line %d: %s", line, fmt.Sprintf(format, args...))})
+}
+
+func (p *feaParser) peek() string {
+	if p.pos < len(p.toks) {
+		return p.toks[p.pos].text
+	}
+	return ""
+}
+
+func (p *feaParser) next() string {
+	if p.pos >= len(p.toks) {
+		p.fail("unexpected end of input")
+	}
+	p.pos++
+	return p.toks[p.pos-1].text
+}
+
+func (p *feaParser) expect(text string) {
+	if t := p.next(); t != text {
+		p.fail("expected %q, have %q", text, t)
+	}
+}
+
+func (p *feaParser) number() int {
+	t := p.next()
+	n, err := strconv.Atoi(t)
+	if err != nil {
+		p.fail("expected number, have %q", t)
+	}
+	return n
+}
+
+func (p *feaParser) parse() {
+	for p.pos < len(p.toks) {
+		switch t := p.next(); {
+		case t == "languagesystem":
+			ls := feaLangSys{script: p.next(), lang: p.next()}
+			p.expect(";")
+			p.langsys = append(p.langsys, ls)
+		case t == "lookup":
+			p.lookupBlock(p.next())
+		case t == "feature":
+			p.featureBlock(p.next())
+		case t == "table":
+			p.tableBlock(p.next())
+		default:
+			if !p.definition(t) {
+				p.fail("unexpected %q", t)
+			}
+		}
+	}
+}
+
+// definition parses glyph class and mark class definitions, which may occur
+// at the top level as well as in blocks.
+func (p *feaParser) definition(t string) bool {
+	switch {
+	case strings.HasPrefix(t, "@"):
+		p.expect("=")
+		p.classes[t] = p.glyphs()
+		p.expect(";")
+	case t == "markClass":
+		glyphs := p.glyphs()
+		a := p.anchor()
+		if a == nil {
+			p.fail("mark class anchor must not be NULL")
+		}
+		name := p.next()
+		p.expect(";")
+		for _, g := range glyphs {
+			p.markClasses[name] = append(p.markClasses[name], feaMark{glyph: g, anchor: *a})
+		}
+		p.classes[name] = append(p.classes[name], glyphs...)
+	default:
+		return false
+	}
+	return true
+}
+
+func (p *feaParser) tableBlock(tag string) {
+	if tag != "GDEF" {
+		p.fail("table %s is not supported", tag)
+	}
+	p.expect("{")
+	p.expect("GlyphClassDef")
+	for class := uint16(1); ; {
+		if t := p.peek(); t != "," && t != ";" {
+			for _, g := range p.glyphs() {
+				p.b.GlyphClass(g, class)
+			}
+		}
+		if p.next() == ";" {
+			break
+		}
+		class++
+	}
+	p.expect("}")
+	p.expect(tag)
+	p.expect(";")
+}
+
+// --- Glyphs ----------------------------------------------------------------
+
+// glyphs parses a glyph, a named glyph class or an inline glyph class.
+func (p *feaParser) glyphs() []ot.GlyphIndex {
+	t := p.next()
+	switch {
+	case t == "[":
+		var glyphs []ot.GlyphIndex
+		for p.peek() != "]" {
+			glyphs = append(glyphs, p.glyphs()...)
+		}
+		p.next()
+		return glyphs
+	case strings.HasPrefix(t, "@"):
+		glyphs, ok := p.classes[t]
+		if !ok {
+			p.fail("unknown glyph class %s", t)
+		}
+		return glyphs
+	}
+	return []ot.GlyphIndex{p.glyph(t)}
+}
+
+func (p *feaParser) glyph(name string) ot.GlyphIndex {
+	g, ok := p.b.glyphNames[name]
+	if !ok {
+		g, ok = p.resolveGlyph(name)
+	}
+	if !ok || int(g) >= p.b.NumGlyphs {
+		p.fail("unknown glyph %q", name)
+	}
+	return g
+}
+
+func (p *feaParser) resolveGlyph(name string) (ot.GlyphIndex, bool) {
+	if name == ".notdef" {
+		return 0, true
+	}
+	index := func(s string) (ot.GlyphIndex, bool) {
+		n, err := strconv.ParseUint(s, 10, 16)
+		return ot.GlyphIndex(n), err == nil
+	}
+	mapped := func(s string) (ot.GlyphIndex, bool) {
+		r, err := strconv.ParseUint(s, 16, 32)
+		if err != nil {
+			return 0, false
+		}
+		g, ok := p.b.cmap[rune(r)]
+		return g, ok
+	}
+	switch {
+	case strings.HasPrefix(name, `\`):
+		return index(name[1:])
+	case strings.HasPrefix(name, "glyph") && len(name) > 5:
+		return index(name[5:])
+	case strings.HasPrefix(name, "uni") && len(name) == 7:
+		return mapped(name[3:])
+	case strings.HasPrefix(name, "u") && len(name) >= 5 && len(name) <= 7:
+		return mapped(name[1:])
+	case utf8.RuneCountInString(name) == 1:
+		r, _ := utf8.DecodeRuneInString(name)
+		g, ok := p.b.cmap[r]
+		return g, ok
+	}
+	return 0, false
+}
+
+// classKey identifies a glyph class by its glyphs.
+func classKey(glyphs []ot.GlyphIndex) string {
+	gs := slices.Clone(glyphs)
+	slices.Sort(gs)
+	return fmt.Sprint(slices.Compact(gs))
+}
+
+// anchor parses an anchor, returning nil for a NULL anchor.
+func (p *feaParser) anchor() *Anchor {
+	p.expect("<")
+	p.expect("anchor")
+	if p.peek() == "NULL" {
+		p.next()
+		p.expect(">")
+		return nil
+	}
+	a := &Anchor{X: int16(p.number()), Y: int16(p.number())}
+	p.expect(">")
+	return a
+}
+
+// valueRecord parses a value record, either as a single advance or as a
+// record of four values.
+func (p *feaParser) valueRecord() ValueRecord {
+	if p.peek() != "<" {
+		return ValueRecord{XAdvance: int16(p.number())}
+	}
+	p.next()
+	v := ValueRecord{XPlacement: int16(p.number()), YPlacement: int16(p.number()),
+		XAdvance: int16(p.number()), YAdvance: int16(p.number())}
+	p.expect(">")
+	return v
+}
+
+// --- Lookups ---------------------------------------------------------------
+
+// feaLookup is a lookup under construction. Rules are collected per subtable
+// and encoded when the subtable is complete.
+type feaLookup struct {
+	name      string // empty for anonymous lookups
+	gpos      bool
+	typ       ot.LayoutTableLookupType
+	flag      ot.LayoutTableLookupFlag
+	markSet   uint16
+	extension bool
+	subtables [][]byte
+
+	// rules of the current subtable
+	single     map[ot.GlyphIndex]ot.GlyphIndex
+	sequences  map[ot.GlyphIndex][]ot.GlyphIndex // multiple or alternate substitution
+	ligatures  []Ligature
+	values     []ValueRecord // single positioning, in order of first use
+	valueSets  map[ValueRecord][]ot.GlyphIndex
+	pairs      map[Pair]ValueRecord
+	marks      []Mark
+	markInx    map[string]uint16 // mark class name → mark class index
+	bases      map[ot.GlyphIndex][]*Anchor
+	hasPending bool
+}
+
+// flush encodes the rules of the current subtable.
+func (lu *feaLookup) flush() {
+	if !lu.hasPending {
+		return
+	}
+	switch {
+	case !lu.gpos && lu.typ == ot.GSubLookupTypeSingle:
+		lu.subtables = append(lu.subtables, SingleSubst(lu.single))
+	case !lu.gpos && lu.typ == ot.GSubLookupTypeMultiple:
+		lu.subtables = append(lu.subtables, MultipleSubst(lu.sequences))
+	case !lu.gpos && lu.typ == ot.GSubLookupTypeAlternate:
+		lu.subtables = append(lu.subtables, AlternateSubst(lu.sequences))
+	case !lu.gpos && lu.typ == ot.GSubLookupTypeLigature:
+		lu.subtables = append(lu.subtables, LigatureSubst(lu.ligatures...))
+	case lu.gpos && lu.typ == ot.GPosLookupTypeSingle:
+		for _, v := range lu.values {
+			lu.subtables = append(lu.subtables, SinglePos(v, lu.valueSets[v]...))
+		}
+	case lu.gpos && lu.typ == ot.GPosLookupTypePair:
+		lu.subtables = append(lu.subtables, PairPos(lu.pairs))
+	case lu.gpos && lu.typ == ot.GPosLookupTypeMarkToBase:
+		lu.subtables = append(lu.subtables, MarkBasePos(lu.marks, lu.bases))
+	case lu.gpos && lu.typ == ot.GPosLookupTypeMarkToMark:
+		lu.subtables = append(lu.subtables, MarkMarkPos(lu.marks, lu.bases))
+	}
+	*lu = feaLookup{name: lu.name, gpos: lu.gpos, typ: lu.typ, flag: lu.flag, markSet: lu.markSet,
+		extension: lu.extension, subtables: lu.subtables}
+}
+
+func (p *feaParser) lookupBlock(name string) {
+	lu := &feaLookup{name: name}
+	if p.peek() == "useExtension" {
+		p.next()
+		lu.extension = true
+	}
+	p.expect("{")
+	for p.peek() != "}" {
+		switch t := p.next(); t {
+		case "lookupflag":
+			lu.flag, lu.markSet = p.lookupFlag()
+		case "subtable":
+			p.expect(";")
+			lu.flush()
+		default:
+			if !p.definition(t) && !p.rule(t, func(gpos bool, typ ot.LayoutTableLookupType) *feaLookup {
+				if lu.typ != 0 && (lu.gpos != gpos || lu.typ != typ) {
+					p.fail("lookup %s mixes lookup types", name)
+				}
+				lu.gpos, lu.typ = gpos, typ
+				return lu
+			}) {
+				p.fail("unexpected %q in lookup %s", t, name)
+			}
+		}
+	}
+	p.next()
+	p.expect(name)
+	p.expect(";")
+	if lu.typ == 0 {
+		p.fail("lookup %s has no rules", name)
+	}
+	p.lookups[name] = feaLookupRef{gpos: lu.gpos, index: p.closeLookup(lu)}
+}
+
+// closeLookup adds a lookup to the font and returns its index in the lookup
+// list.
+func (p *feaParser) closeLookup(lu *feaLookup) int {
+	lu.flush()
+	layout, typ, subtables := p.b.GSUB(), lu.typ, lu.subtables
+	if lu.gpos {
+		layout = p.b.GPOS()
+	}
+	if lu.extension {
+		subtables = make([][]byte, len(lu.subtables))
+		for i, sub := range lu.subtables {
+			subtables[i] = Extension(lu.typ, sub)
+		}
+		typ = ot.GSubLookupTypeExtensionSubs
+		if lu.gpos {
+			typ = ot.GPosLookupTypeExtensionPos
+		}
+	}
+	if lu.flag&ot.LOOKUP_FLAG_USE_MARK_FILTERING_SET != 0 {
+		return layout.FilteredLookup(typ, lu.flag, lu.markSet, subtables...)
+	}
+	return layout.Lookup(typ, lu.flag, subtables...)
+}
+
+// lookupFlag parses the arguments of a lookupflag statement. Glyph classes of
+// MarkAttachmentType and UseMarkFilteringSet are added to GDEF.
+func (p *feaParser) lookupFlag() (flag ot.LayoutTableLookupFlag, markSet uint16) {
+	if n, err := strconv.Atoi(p.peek()); err == nil {
+		p.next()
+		p.expect(";")
+		return ot.LayoutTableLookupFlag(n), 0
+	}
+	for t := p.next(); t != ";"; t = p.next() {
+		switch t {
+		case "RightToLeft":
+			flag |= ot.LOOKUP_FLAG_RIGHT_TO_LEFT
+		case "IgnoreBaseGlyphs":
+			flag |= ot.LOOKUP_FLAG_IGNORE_BASE_GLYPHS
+		case "IgnoreLigatures":
+			flag |= ot.LOOKUP_FLAG_IGNORE_LIGATURES
+		case "IgnoreMarks":
+			flag |= ot.LOOKUP_FLAG_IGNORE_MARKS
+		case "MarkAttachmentType":
+			glyphs := p.glyphs()
+			class, ok := p.markAttach[classKey(glyphs)]
+			if !ok {
+				class = uint16(len(p.markAttach) + 1)
+				p.markAttach[classKey(glyphs)] = class
+				for _, g := range glyphs {
+					p.b.MarkAttachClass(g, class)
+				}
+			}
+			flag |= ot.LayoutTableLookupFlag(class << 8)
+		case "UseMarkFilteringSet":
+			glyphs := p.glyphs()
+			set, ok := p.markSets[classKey(glyphs)]
+			if !ok {
+				set = p.b.MarkGlyphSet(glyphs...)
+				p.markSets[classKey(glyphs)] = set
+			}
+			flag |= ot.LOOKUP_FLAG_USE_MARK_FILTERING_SET
+			markSet = set
+		default:
+			p.fail("unknown lookup flag %q", t)
+		}
+	}
+	return flag, markSet
+}
+
+// --- Features --------------------------------------------------------------
+
+func (p *feaParser) featureBlock(tag string) {
+	p.feature = &[2]*feaFeature{p.featureFor(0, tag), p.featureFor(1, tag)}
+	p.scope = p.langsys
+	if len(p.scope) == 0 {
+		p.scope = []feaLangSys{{}}
+	}
+	p.script, p.flag, p.markSet = "", 0, 0
+	p.expect("{")
+	for p.peek() != "}" {
+		switch t := p.next(); t {
+		case "script":
+			p.closeAnonymous()
+			p.script = p.next()
+			p.expect(";")
+			p.scope = []feaLangSys{{script: p.script, lang: "dflt"}}
+		case "language":
+			p.closeAnonymous()
+			p.language(p.next())
+		case "lookupflag":
+			p.closeAnonymous()
+			p.flag, p.markSet = p.lookupFlag()
+		case "subtable":
+			p.expect(";")
+			if p.anon != nil {
+				p.anon.flush()
+			}
+		case "lookup":
+			p.closeAnonymous()
+			name := p.next()
+			if p.peek() != ";" {
+				p.lookupBlock(name)
+			} else {
+				p.next()
+			}
+			ref, ok := p.lookups[name]
+			if !ok {
+				p.fail("unknown lookup %s", name)
+			}
+			p.register(ref.gpos, ref.index)
+		default:
+			if !p.definition(t) && !p.rule(t, p.anonymous) {
+				p.fail("unexpected %q in feature %s", t, tag)
+			}
+		}
+	}
+	p.next()
+	p.closeAnonymous()
+	p.expect(tag)
+	p.expect(";")
+	p.feature = nil
+}
+
+// language switches to a language of the current script. Unless excluded,
+// lookups registered for the script's default language are inherited.
+func (p *feaParser) language(lang string) {
+	script := p.script
+	if script == "" {
+		script = "DFLT"
+	}
+	include := true
+	for t := p.next(); t != ";"; t = p.next() {
+		switch t {
+		case "exclude_dflt":
+			include = false
+		case "include_dflt":
+			include = true
+		default:
+			p.fail("unsupported language option %q", t)
+		}
+	}
+	p.scope = []feaLangSys{{script: script, lang: lang}}
+	if include && lang != "dflt" {
+		for i, f := range p.feature {
+			for _, inx := range f.lookups[feaLangSys{script: script, lang: "dflt"}] {
+				p.register(i == 1, inx)
+			}
+		}
+	}
+}
+
+func (p *feaParser) featureFor(table int, tag string) *feaFeature {
+	for _, f := range p.features[table] {
+		if f.tag == tag {
+			return f
+		}
+	}
+	f := &feaFeature{tag: tag, lookups: make(map[feaLangSys][]int)}
+	p.features[table] = append(p.features[table], f)
+	return f
+}
+
+// register adds a lookup to the current feature for the language systems in
+// scope.
+func (p *feaParser) register(gpos bool, index int) {
+	f := p.feature[0]
+	if gpos {
+		f = p.feature[1]
+	}
+	for _, ls := range p.scope {
+		if _, ok := f.lookups[ls]; !ok {
+			f.langsys = append(f.langsys, ls)
+		}
+		f.lookups[ls] = append(f.lookups[ls], index)
+	}
+}
+
+// anonymous returns the open anonymous lookup of the current feature block,
+// starting a new one if the lookup type changes.
+func (p *feaParser) anonymous(gpos bool, typ ot.LayoutTableLookupType) *feaLookup {
+	if p.anon != nil && (p.anon.gpos != gpos || p.anon.typ != typ) {
+		p.closeAnonymous()
+	}
+	if p.anon == nil {
+		p.anon = &feaLookup{gpos: gpos, typ: typ, flag: p.flag, markSet: p.markSet}
+	}
+	return p.anon
+}
+
+func (p *feaParser) closeAnonymous() {
+	if p.anon != nil {
+		p.register(p.anon.gpos, p.closeLookup(p.anon))
+		p.anon = nil
+	}
+}
+
+// finish adds the features to the layout tables. Language systems with the
+// same lookups share a feature.
+func (p *feaParser) finish() {
+	for table, layout := range []*Layout{p.b.GSUB(), p.b.GPOS()} {
+		for _, f := range p.features[table] {
+			done := make(map[feaLangSys]bool)
+			for i, ls := range f.langsys {
+				if done[ls] {
+					continue
+				}
+				inx := layout.Feature(f.tag, f.lookups[ls]...)
+				for _, other := range f.langsys[i:] {
+					if !done[other] && slices.Equal(f.lookups[other], f.lookups[ls]) {
+						done[other] = true
+						if other != (feaLangSys{}) {
+							layout.Script(other.script, langTag(other.lang), inx)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func langTag(lang string) string {
+	if lang == "dflt" {
+		return DefaultLang
+	}
+	return lang
+}
+
+// --- Rules -----------------------------------------------------------------
+
+// feaElement is an element of a rule: a glyph or glyph class, possibly marked
+// as input of a contextual rule, with lookups to apply or a value record.
+type feaElement struct {
+	glyphs  []ot.GlyphIndex
+	marked  bool
+	lookups []int
+	value   *ValueRecord
+}
+
+// rule parses a rule starting with keyword t. target returns the lookup the
+// rule is added to, given its lookup type. rule returns false if t does not
+// start a rule.
+func (p *feaParser) rule(t string, target func(gpos bool, typ ot.LayoutTableLookupType) *feaLookup) bool {
+	switch t {
+	case "sub", "substitute":
+		p.substitution(target)
+	case "rsub", "reversesub":
+		p.reverseSubstitution(target)
+	case "pos", "position":
+		p.positioning(target)
+	case "ignore":
+		switch p.next() {
+		case "sub", "substitute":
+			p.contextual(false, p.elements(false), target)
+		case "pos", "position":
+			p.contextual(true, p.elements(true), target)
+		default:
+			p.fail("expected sub or pos after ignore")
+		}
+		p.expect(";")
+	default:
+		return false
+	}
+	return true
+}
+
+// elements parses the elements of a rule up to 'by', 'from' or ';'.
+func (p *feaParser) elements(gpos bool) []feaElement {
+	var elems []feaElement
+	for {
+		switch t := p.peek(); {
+		case t == ";" || t == "by" || t == "from":
+			return elems
+		case len(elems) > 0 && t == "'":
+			p.next()
+			elems[len(elems)-1].marked = true
+		case len(elems) > 0 && t == "lookup":
+			p.next()
+			name := p.next()
+			ref, ok := p.lookups[name]
+			if !ok || ref.gpos != gpos {
+				p.fail("unknown lookup %s", name)
+			}
+			elems[len(elems)-1].lookups = append(elems[len(elems)-1].lookups, ref.index)
+		case len(elems) > 0 && gpos && (t == "<" || isNumber(t)):
+			v := p.valueRecord()
+			elems[len(elems)-1].value = &v
+		default:
+			elems = append(elems, feaElement{glyphs: p.glyphs()})
+		}
+	}
+}
+
+func isNumber(t string) bool {
+	_, err := strconv.Atoi(t)
+	return err == nil
+}
+
+// contextual adds a chained contextual rule. Rules without lookups are ignore
+// rules.
+func (p *feaParser) contextual(gpos bool, elems []feaElement,
+	target func(gpos bool, typ ot.LayoutTableLookupType) *feaLookup) {
+	//
+	var backtrack, input, lookahead [][]ot.GlyphIndex
+	var records []ot.SequenceLookupRecord
+	for _, e := range elems {
+		if e.value != nil || (!e.marked && len(e.lookups) > 0) {
+			p.fail("lookups and values of contextual rules have to be given for marked glyphs")
+		}
+		switch {
+		case e.marked:
+			if len(lookahead) > 0 {
+				p.fail("marked glyphs of contextual rules have to be contiguous")
+			}
+			for _, inx := range e.lookups {
+				records = append(records, ot.SequenceLookupRecord{
+					SequenceIndex:   uint16(len(input)),
+					LookupListIndex: uint16(inx),
+				})
+			}
+			input = append(input, e.glyphs)
+		case len(input) == 0:
+			backtrack = append(backtrack, e.glyphs)
+		default:
+			lookahead = append(lookahead, e.glyphs)
+		}
+	}
+	if len(input) == 0 {
+		p.fail("contextual rule without marked glyphs")
+	}
+	typ := ot.GSubLookupTypeChainingContext
+	if gpos {
+		typ = ot.GPosLookupTypeChainedContextPos
+	}
+	lu := target(gpos, typ)
+	lu.subtables = append(lu.subtables, ChainContext(backtrack, input, lookahead, records...))
+}
+
+// pending prepares a lookup to collect rules for its current subtable.
+func (lu *feaLookup) pending() *feaLookup {
+	if !lu.hasPending {
+		lu.single = make(map[ot.GlyphIndex]ot.GlyphIndex)
+		lu.sequences = make(map[ot.GlyphIndex][]ot.GlyphIndex)
+		lu.valueSets = make(map[ValueRecord][]ot.GlyphIndex)
+		lu.pairs = make(map[Pair]ValueRecord)
+		lu.markInx = make(map[string]uint16)
+		lu.bases = make(map[ot.GlyphIndex][]*Anchor)
+		lu.hasPending = true
+	}
+	return lu
+}
+
+func (p *feaParser) substitution(target func(gpos bool, typ ot.LayoutTableLookupType) *feaLookup) {
+	in := p.elements(false)
+	if slices.ContainsFunc(in, func(e feaElement) bool { return e.marked }) {
+		if p.next() != ";" {
+			p.fail("contextual substitutions have to reference lookups")
+		}
+		p.contextual(false, in, target)
+		return
+	}
+	kw := p.next()
+	if len(in) == 0 || (kw != "by" && kw != "from") {
+		p.fail("expected substitution rule")
+	}
+	var out [][]ot.GlyphIndex
+	for p.peek() != ";" {
+		if p.peek() == "NULL" {
+			p.next()
+			continue
+		}
+		out = append(out, p.glyphs())
+	}
+	p.next()
+	switch {
+	case kw == "from":
+		if len(in) != 1 || len(out) != 1 {
+			p.fail("alternate substitution needs a glyph and a class of alternates")
+		}
+		lu := target(false, ot.GSubLookupTypeAlternate).pending()
+		for _, g := range in[0].glyphs {
+			lu.sequences[g] = out[0]
+		}
+	case len(in) == 1 && len(out) == 1:
+		src, dst := in[0].glyphs, out[0]
+		if len(dst) != 1 && len(dst) != len(src) {
+			p.fail("single substitution needs the same number of glyphs on both sides")
+		}
+		lu := target(false, ot.GSubLookupTypeSingle).pending()
+		for i, g := range src {
+			lu.single[g] = dst[min(i, len(dst)-1)]
+		}
+	case len(in) == 1:
+		seq := make([]ot.GlyphIndex, len(out))
+		for i, o := range out {
+			if len(o) != 1 {
+				p.fail("multiple substitution has to replace a glyph by a sequence of glyphs")
+			}
+			seq[i] = o[0]
+		}
+		lu := target(false, ot.GSubLookupTypeMultiple).pending()
+		for _, g := range in[0].glyphs {
+			lu.sequences[g] = seq
+		}
+	case len(out) == 1 && len(out[0]) == 1:
+		sets := make([][]ot.GlyphIndex, len(in))
+		for i, e := range in {
+			sets[i] = e.glyphs
+		}
+		lu := target(false, ot.GSubLookupTypeLigature).pending()
+		for _, components := range combinations(sets) {
+			lu.ligatures = append(lu.ligatures, Ligature{Components: components, Glyph: out[0][0]})
+		}
+	default:
+		p.fail("unsupported substitution rule")
+	}
+}
+
+// combinations returns all sequences taking one glyph of each set.
+func combinations(sets [][]ot.GlyphIndex) [][]ot.GlyphIndex {
+	combs := [][]ot.GlyphIndex{nil}
+	for _, set := range sets {
+		var next [][]ot.GlyphIndex
+		for _, c := range combs {
+			for _, g := range set {
+				next = append(next, append(slices.Clone(c), g))
+			}
+		}
+		combs = next
+	}
+	return combs
+}
+
+func (p *feaParser) reverseSubstitution(target func(gpos bool, typ ot.LayoutTableLookupType) *feaLookup) {
+	elems := p.elements(false)
+	p.expect("by")
+	dst := p.glyphs()
+	p.expect(";")
+	var backtrack, lookahead [][]ot.GlyphIndex
+	var src []ot.GlyphIndex
+	for _, e := range elems {
+		switch {
+		case e.marked && src == nil:
+			src = e.glyphs
+		case e.marked || len(e.lookups) > 0:
+			p.fail("reverse chaining substitution needs exactly one marked glyph")
+		case src == nil:
+			backtrack = append(backtrack, e.glyphs)
+		default:
+			lookahead = append(lookahead, e.glyphs)
+		}
+	}
+	if src == nil || (len(dst) != 1 && len(dst) != len(src)) {
+		p.fail("reverse chaining substitution needs a marked glyph and its replacement")
+	}
+	m := make(map[ot.GlyphIndex]ot.GlyphIndex, len(src))
+	for i, g := range src {
+		m[g] = dst[min(i, len(dst)-1)]
+	}
+	lu := target(false, ot.GSubLookupTypeReverseChaining)
+	lu.subtables = append(lu.subtables, ReverseChainSubst(m, backtrack, lookahead))
+}
+
+func (p *feaParser) positioning(target func(gpos bool, typ ot.LayoutTableLookupType) *feaLookup) {
+	switch p.peek() {
+	case "base", "mark":
+		typ := ot.GPosLookupTypeMarkToBase
+		if p.next() == "mark" {
+			typ = ot.GPosLookupTypeMarkToMark
+		}
+		p.markAttachment(target(true, typ).pending())
+		return
+	case "cursive", "ligature":
+		p.fail("%s attachment is not supported", p.peek())
+	}
+	elems := p.elements(true)
+	p.expect(";")
+	if slices.ContainsFunc(elems, func(e feaElement) bool { return e.marked }) {
+		p.contextual(true, elems, target)
+		return
+	}
+	switch {
+	case len(elems) == 1 && elems[0].value != nil:
+		lu := target(true, ot.GPosLookupTypeSingle).pending()
+		v := *elems[0].value
+		if _, ok := lu.valueSets[v]; !ok {
+			lu.values = append(lu.values, v)
+		}
+		lu.valueSets[v] = append(lu.valueSets[v], elems[0].glyphs...)
+	case len(elems) == 2 && elems[0].value == nil && elems[1].value != nil:
+		lu := target(true, ot.GPosLookupTypePair).pending()
+		for _, first := range elems[0].glyphs {
+			for _, second := range elems[1].glyphs {
+				lu.pairs[Pair{first, second}] = *elems[1].value
+			}
+		}
+	default:
+		p.fail("unsupported positioning rule")
+	}
+}
+
+// markAttachment parses the base glyphs and anchors of a mark-to-base or
+// mark-to-mark rule. Mark classes are numbered in order of first use within
+// the subtable.
+func (p *feaParser) markAttachment(lu *feaLookup) {
+	bases := p.glyphs()
+	for p.peek() != ";" {
+		a := p.anchor()
+		p.expect("mark")
+		name := p.next()
+		marks, ok := p.markClasses[name]
+		if !ok {
+			p.fail("unknown mark class %s", name)
+		}
+		class, ok := lu.markInx[name]
+		if !ok {
+			class = uint16(len(lu.markInx))
+			lu.markInx[name] = class
+			for _, m := range marks {
+				lu.marks = append(lu.marks, Mark{Glyph: m.glyph, Class: class, Anchor: m.anchor})
+			}
+		}
+		for _, g := range bases {
+			for len(lu.bases[g]) <= int(class) {
+				lu.bases[g] = append(lu.bases[g], nil)
+			}
+			lu.bases[g][class] = a
+		}
+	}
+	p.next()
+}
@@ -0,0 +1,108 @@
+package testfont
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otfea"
+)
+
+var feaGlyphNames = []string{".notdef", "f", "i", "f_i", "a", "a.alt", "b", "acutecomb"}
+
+func feaTestFont() *Builder {
+	b := New(len(feaGlyphNames))
+	for g, name := range feaGlyphNames[1:] {
+		b.Name(ot.GlyphIndex(g+1), name)
+	}
+	return b
+}
+
+func TestFeatures(t *testing.T) {
+	b := feaTestFont()
+	err := b.Features(`
+languagesystem DFLT dflt;
+languagesystem latn dflt;
+
+table GDEF {
+    GlyphClassDef [f i f_i a a.alt b], , [acutecomb], ;
+} GDEF;
+
+lookup ALT {
+    sub a by a.alt;
+} ALT;
+
+markClass acutecomb <anchor 0 500> @TOP;
+
+feature calt {
+    sub a' lookup ALT b;
+} calt;
+
+feature liga {
+    lookupflag IgnoreMarks;
+    sub f i by f_i;
+} liga;
+
+feature kern {
+    pos f [a b] <0 0 -50 0>;
+} kern;
+
+feature mark {
+    pos base [a b] <anchor 250 600> mark @TOP;
+} mark;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	fea := decompileFeatures(t, otf)
+	for _, rule := range []string{
+		"sub a by a.alt;",
+		"sub a' lookup GSUB_0 b;",
+		"lookupflag IgnoreMarks;\n    sub f i by f_i;",
+		"pos f a <0 0 -50 0>;",
+		"pos f b <0 0 -50 0>;",
+		"markClass acutecomb <anchor 0 500>",
+		"pos base a <anchor 250 600> mark",
+	} {
+		if !strings.Contains(fea, rule) {
+			t.Errorf("expected decompiled features to contain %q, have:\n%s", rule, fea)
+		}
+	}
+	// the decompiled feature file compiles to the same lookups
+	b = feaTestFont()
+	if err := b.Features(fea); err != nil {
+		t.Fatalf("cannot compile decompiled features: %v", err)
+	}
+	if otf, err = b.Parse(); err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	if again := decompileFeatures(t, otf); again != fea {
+		t.Errorf("round trip changed features:\n%s", again)
+	}
+}
+
+func decompileFeatures(t *testing.T, otf *ot.Font) string {
+	t.Helper()
+	var sb strings.Builder
+	if err := otfea.Decompile(otf, &sb, func(g ot.GlyphIndex) string { return feaGlyphNames[g] }); err != nil {
+		t.Fatalf("decompilation failed: %v", err)
+	}
+	return sb.String()
+}
+
+func TestFeaturesErrors(t *testing.T) {
+	for _, fea := range []string{
+		`feature liga { sub f i by f_i } liga;`,
+		`feature liga { sub x by f_i; } liga;`,
+		`feature kern { pos cursive f <anchor 0 0> <anchor NULL>; } kern;`,
+		`lookup L { sub f by i; pos f <0 0 10 0>; } L;`,
+	} {
+		if err := feaTestFont().Features(fea); err == nil {
+			t.Errorf("expected error for %q", fea)
+		}
+	}
+}
@@ -0,0 +1,572 @@
+package testfont
+
+import (
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Layout builds a GSUB or GPOS table.
+//
+// If no script is registered explicitly, the table will contain scripts 'DFLT'
+// and 'latn', each with a default language system referencing all features.
+type Layout struct {
+	lookups  []lookup
+	features []feature
+	scripts  map[ot.Tag]map[ot.Tag][]int // script → language → feature indices
+}
+
+type lookup struct {
+	typ       ot.LayoutTableLookupType
+	flag      ot.LayoutTableLookupFlag
+	markSet   uint16
+	subtables [][]byte
+}
+
+type feature struct {
+	tag     ot.Tag
+	lookups []int
+	params  []byte
+}
+
+// DefaultLang denotes the default language system of a script.
+const DefaultLang = ""
+
+// Lookup adds a lookup with the given type, flags and raw subtables, and
+// returns its index in the lookup list. Subtables are not checked in any way.
+func (l *Layout) Lookup(typ ot.LayoutTableLookupType, flag ot.LayoutTableLookupFlag,
+	subtables ...[]byte) int {
+	//
+	l.lookups = append(l.lookups, lookup{
+		typ:       typ,
+		flag:      flag &^ ot.LOOKUP_FLAG_USE_MARK_FILTERING_SET,
+		subtables: subtables,
+	})
+	return len(l.lookups) - 1
+}
+
+// FilteredLookup adds a lookup which uses the GDEF mark glyph set markSet
+// as its mark filtering set, and returns its index in the lookup list.
+func (l *Layout) FilteredLookup(typ ot.LayoutTableLookupType, flag ot.LayoutTableLookupFlag,
+	markSet uint16, subtables ...[]byte) int {
+	//
+	l.lookups = append(l.lookups, lookup{
+		typ:       typ,
+		flag:      flag | ot.LOOKUP_FLAG_USE_MARK_FILTERING_SET,
+		markSet:   markSet,
+		subtables: subtables,
+	})
+	return len(l.lookups) - 1
+}
+
+// Feature adds a feature referencing lookups and returns its index in the
+// feature list. Features with the same tag may be added more than once.
+func (l *Layout) Feature(tag string, lookups ...int) int {
+	l.features = append(l.features, feature{tag: ot.T(tag), lookups: lookups})
+	return len(l.features) - 1
+}
+
+// FeatureParams sets the raw feature parameters of the feature at index
+// feature, as returned by Feature. Parameters are not checked in any way.
+func (l *Layout) FeatureParams(feature int, params []byte) *Layout {
+	l.features[feature].params = params
+	return l
+}
+
+// Script registers a language system lang (DefaultLang for the default language
+// system) for script, referencing features. Registering a language system
+// more than once appends features.
+func (l *Layout) Script(script, lang string, features ...int) *Layout {
+	if l.scripts == nil {
+		l.scripts = make(map[ot.Tag]map[ot.Tag][]int)
+	}
+	st := ot.T(script)
+	if l.scripts[st] == nil {
+		l.scripts[st] = make(map[ot.Tag][]int)
+	}
+	var lt ot.Tag
+	if lang != DefaultLang {
+		lt = ot.T(lang)
+	}
+	l.scripts[st][lt] = append(l.scripts[st][lt], features...)
+	return l
+}
+
+// Bytes serializes the layout table.
+//
+// Package ot rejects layout tables without features, so a layout without any
+// feature is given a placeholder feature 'none' without lookups.
+func (l *Layout) Bytes() []byte {
+	if len(l.features) == 0 {
+		l = &Layout{lookups: l.lookups, features: []feature{{tag: ot.T("none")}}, scripts: l.scripts}
+	}
+	scripts := l.scripts
+	if scripts == nil {
+		all := make([]int, len(l.features))
+		for i := range all {
+			all[i] = i
+		}
+		scripts = map[ot.Tag]map[ot.Tag][]int{
+			ot.T("DFLT"): {0: all},
+			ot.T("latn"): {0: all},
+		}
+	}
+	scriptList := l.scriptList(scripts)
+	featureList := l.featureList()
+	w := &writer{}
+	w.u16(1)
+	w.u16(0)
+	w.u16(10)
+	w.u16(uint16(10 + len(scriptList)))
+	w.u16(uint16(10 + len(scriptList) + len(featureList)))
+	w.bytes(scriptList)
+	w.bytes(featureList)
+	w.bytes(l.lookupList())
+	return w.buf
+}
+
+func (l *Layout) scriptList(scripts map[ot.Tag]map[ot.Tag][]int) []byte {
+	tags := sortedKeys(scripts)
+	w := &writer{}
+	w.u16(uint16(len(tags)))
+	body := &writer{}
+	for _, tag := range tags {
+		w.u32(uint32(tag))
+		w.u16(uint16(2 + 6*len(tags) + len(body.buf)))
+		body.bytes(scriptTable(scripts[tag]))
+	}
+	w.bytes(body.buf)
+	return w.buf
+}
+
+func scriptTable(langs map[ot.Tag][]int) []byte {
+	tags := slices.DeleteFunc(sortedKeys(langs), func(t ot.Tag) bool { return t == 0 })
+	headerSize := 4 + 6*len(tags)
+	w := &writer{}
+	body := &writer{}
+	if dflt, ok := langs[0]; ok {
+		w.u16(uint16(headerSize))
+		body.bytes(langSysTable(dflt))
+	} else {
+		w.u16(0)
+	}
+	w.u16(uint16(len(tags)))
+	for _, tag := range tags {
+		w.u32(uint32(tag))
+		w.u16(uint16(headerSize + len(body.buf)))
+		body.bytes(langSysTable(langs[tag]))
+	}
+	w.bytes(body.buf)
+	return w.buf
+}
+
+func langSysTable(features []int) []byte {
+	w := &writer{}
+	w.u16(0)      // lookup order, reserved
+	w.u16(0xffff) // no required feature
+	w.u16(uint16(len(features)))
+	for _, f := range features {
+		w.u16(uint16(f))
+	}
+	return w.buf
+}
+
+func (l *Layout) featureList() []byte {
+	w := &writer{}
+	w.u16(uint16(len(l.features)))
+	body := &writer{}
+	for _, f := range l.features {
+		w.u32(uint32(f.tag))
+		w.u16(uint16(2 + 6*len(l.features) + len(body.buf)))
+		if len(f.params) > 0 { // feature params follow the lookup indices
+			body.u16(uint16(4 + 2*len(f.lookups)))
+		} else {
+			body.u16(0)
+		}
+		body.u16(uint16(len(f.lookups)))
+		for _, inx := range f.lookups {
+			body.u16(uint16(inx))
+		}
+		body.bytes(f.params)
+	}
+	w.bytes(body.buf)
+	return w.buf
+}
+
+func (l *Layout) lookupList() []byte {
+	w := &writer{}
+	w.u16(uint16(len(l.lookups)))
+	body := &writer{}
+	for _, lu := range l.lookups {
+		w.u16(uint16(2 + 2*len(l.lookups) + len(body.buf)))
+		body.bytes(lu.bytes())
+	}
+	w.bytes(body.buf)
+	return w.buf
+}
+
+func (lu lookup) bytes() []byte {
+	headerSize := 6 + 2*len(lu.subtables)
+	if lu.flag&ot.LOOKUP_FLAG_USE_MARK_FILTERING_SET != 0 {
+		headerSize += 2
+	}
+	w := &writer{}
+	w.u16(uint16(lu.typ))
+	w.u16(uint16(lu.flag))
+	w.u16(uint16(len(lu.subtables)))
+	off := headerSize
+	for _, sub := range lu.subtables {
+		w.u16(uint16(off))
+		off += len(sub)
+	}
+	if lu.flag&ot.LOOKUP_FLAG_USE_MARK_FILTERING_SET != 0 {
+		w.u16(lu.markSet)
+	}
+	for _, sub := range lu.subtables {
+		w.bytes(sub)
+	}
+	return w.buf
+}
+
+// --- Subtables -------------------------------------------------------------
+
+// subtable writes a subtable consisting of a fixed-size header, followed by
+// variable-size parts. Header fields which are offsets to parts are written
+// with offsetTo.
+type subtable struct {
+	head  writer
+	parts writer
+	size  int // size of the header
+}
+
+func newSubtable(headerSize int) *subtable {
+	return &subtable{size: headerSize}
+}
+
+// offsetTo writes an offset to data, which is appended to the parts.
+func (s *subtable) offsetTo(data []byte) {
+	s.head.u16(uint16(s.size + len(s.parts.buf)))
+	s.parts.bytes(data)
+}
+
+func (s *subtable) bytes() []byte {
+	if len(s.head.buf) != s.size {
+		panic("testfont: subtable header size mismatch")
+	}
+	return append(s.head.buf, s.parts.buf...)
+}
+
+// SingleSubstDelta encodes a single substitution subtable (GSUB type 1, format 1),
+// substituting glyph g+delta (modulo 65536) for each of glyphs.
+func SingleSubstDelta(delta int16, glyphs ...ot.GlyphIndex) []byte {
+	s := newSubtable(6)
+	s.head.u16(1)
+	s.offsetTo(Coverage(glyphs...))
+	s.head.u16(uint16(delta))
+	return s.bytes()
+}
+
+// SingleSubst encodes a single substitution subtable (GSUB type 1, format 2).
+func SingleSubst(m map[ot.GlyphIndex]ot.GlyphIndex) []byte {
+	glyphs := sortedKeys(m)
+	s := newSubtable(6 + 2*len(glyphs))
+	s.head.u16(2)
+	s.offsetTo(Coverage(glyphs...))
+	s.head.u16(uint16(len(glyphs)))
+	for _, g := range glyphs {
+		s.head.u16(uint16(m[g]))
+	}
+	return s.bytes()
+}
+
+// MultipleSubst encodes a multiple substitution subtable (GSUB type 2, format 1).
+func MultipleSubst(m map[ot.GlyphIndex][]ot.GlyphIndex) []byte {
+	return sequenceSubst(m)
+}
+
+// AlternateSubst encodes an alternate substitution subtable (GSUB type 3, format 1).
+func AlternateSubst(m map[ot.GlyphIndex][]ot.GlyphIndex) []byte {
+	return sequenceSubst(m)
+}
+
+// sequenceSubst encodes the common layout of GSUB types 2 and 3.
+func sequenceSubst(m map[ot.GlyphIndex][]ot.GlyphIndex) []byte {
+	glyphs := sortedKeys(m)
+	s := newSubtable(6 + 2*len(glyphs))
+	s.head.u16(1)
+	s.offsetTo(Coverage(glyphs...))
+	s.head.u16(uint16(len(glyphs)))
+	for _, g := range glyphs {
+		seq := &writer{}
+		seq.u16(uint16(len(m[g])))
+		for _, out := range m[g] {
+			seq.u16(uint16(out))
+		}
+		s.offsetTo(seq.buf)
+	}
+	return s.bytes()
+}
+
+// Ligature is a ligature glyph together with the sequence of glyphs it replaces.
+type Ligature struct {
+	Components []ot.GlyphIndex // at least two glyphs
+	Glyph      ot.GlyphIndex
+}
+
+// LigatureSubst encodes a ligature substitution subtable (GSUB type 4,
+// format 1). Ligatures starting with the same glyph are kept in the order given.
+func LigatureSubst(ligatures ...Ligature) []byte {
+	sets := make(map[ot.GlyphIndex][]Ligature)
+	for _, lig := range ligatures {
+		first := lig.Components[0]
+		sets[first] = append(sets[first], lig)
+	}
+	firsts := sortedKeys(sets)
+	s := newSubtable(6 + 2*len(firsts))
+	s.head.u16(1)
+	s.offsetTo(Coverage(firsts...))
+	s.head.u16(uint16(len(firsts)))
+	for _, first := range firsts {
+		set := newSubtable(2 + 2*len(sets[first]))
+		set.head.u16(uint16(len(sets[first])))
+		for _, lig := range sets[first] {
+			w := &writer{}
+			w.u16(uint16(lig.Glyph))
+			w.u16(uint16(len(lig.Components)))
+			for _, c := range lig.Components[1:] {
+				w.u16(uint16(c))
+			}
+			set.offsetTo(w.buf)
+		}
+		s.offsetTo(set.bytes())
+	}
+	return s.bytes()
+}
+
+// ReverseChainSubst encodes a reverse chaining single substitution subtable
+// (GSUB type 8, format 1). backtrack and lookahead hold one set of glyphs per
+// context position, backtrack in logical order (i.e., the glyph sets before
+// the input glyph from left to right).
+func ReverseChainSubst(m map[ot.GlyphIndex]ot.GlyphIndex, backtrack, lookahead [][]ot.GlyphIndex) []byte {
+	glyphs := sortedKeys(m)
+	s := newSubtable(10 + 2*len(backtrack) + 2*len(lookahead) + 2*len(glyphs))
+	s.head.u16(1)
+	s.offsetTo(Coverage(glyphs...))
+	s.head.u16(uint16(len(backtrack)))
+	for i := len(backtrack) - 1; i >= 0; i-- { // stored in reverse order
+		s.offsetTo(Coverage(backtrack[i]...))
+	}
+	s.head.u16(uint16(len(lookahead)))
+	for _, set := range lookahead {
+		s.offsetTo(Coverage(set...))
+	}
+	s.head.u16(uint16(len(glyphs)))
+	for _, g := range glyphs {
+		s.head.u16(uint16(m[g]))
+	}
+	return s.bytes()
+}
+
+// Value format flags for ValueRecords.
+const (
+	XPlacement uint16 = 0x0001
+	YPlacement uint16 = 0x0002
+	XAdvance   uint16 = 0x0004
+	YAdvance   uint16 = 0x0008
+)
+
+// ValueRecord holds the design-unit adjustments of a GPOS value record.
+// Device tables are not supported.
+type ValueRecord struct {
+	XPlacement, YPlacement, XAdvance, YAdvance int16
+}
+
+// Format returns the minimal value format for v.
+func (v ValueRecord) Format() uint16 {
+	var format uint16
+	if v.XPlacement != 0 {
+		format |= XPlacement
+	}
+	if v.YPlacement != 0 {
+		format |= YPlacement
+	}
+	if v.XAdvance != 0 {
+		format |= XAdvance
+	}
+	if v.YAdvance != 0 {
+		format |= YAdvance
+	}
+	return format
+}
+
+func (v ValueRecord) write(w *writer, format uint16) {
+	for _, f := range []struct {
+		flag  uint16
+		value int16
+	}{{XPlacement, v.XPlacement}, {YPlacement, v.YPlacement}, {XAdvance, v.XAdvance}, {YAdvance, v.YAdvance}} {
+		if format&f.flag != 0 {
+			w.u16(uint16(f.value))
+		}
+	}
+}
+
+// SinglePos encodes a single positioning subtable (GPOS type 1, format 1),
+// applying the same value record to all glyphs.
+func SinglePos(v ValueRecord, glyphs ...ot.GlyphIndex) []byte {
+	format := v.Format()
+	w := &writer{}
+	v.write(w, format)
+	s := newSubtable(6 + len(w.buf))
+	s.head.u16(1)
+	s.offsetTo(Coverage(glyphs...))
+	s.head.u16(format)
+	s.head.bytes(w.buf)
+	return s.bytes()
+}
+
+// Pair is a pair of glyphs for pair positioning.
+type Pair [2]ot.GlyphIndex
+
+// PairPos encodes a pair positioning subtable (GPOS type 2, format 1),
+// applying value records to the first glyph of each pair.
+func PairPos(pairs map[Pair]ValueRecord) []byte {
+	var format uint16
+	sets := make(map[ot.GlyphIndex][]ot.GlyphIndex)
+	for p, v := range pairs {
+		format |= v.Format()
+		sets[p[0]] = append(sets[p[0]], p[1])
+	}
+	firsts := sortedKeys(sets)
+	s := newSubtable(10 + 2*len(firsts))
+	s.head.u16(1)
+	s.offsetTo(Coverage(firsts...))
+	s.head.u16(format)
+	s.head.u16(0) // value format 2
+	s.head.u16(uint16(len(firsts)))
+	for _, first := range firsts {
+		seconds := sets[first]
+		slices.Sort(seconds)
+		set := &writer{}
+		set.u16(uint16(len(seconds)))
+		for _, second := range seconds {
+			set.u16(uint16(second))
+			pairs[Pair{first, second}].write(set, format)
+		}
+		s.offsetTo(set.buf)
+	}
+	return s.bytes()
+}
+
+// ChainContext encodes a chained contextual subtable (GSUB type 6 or GPOS
+// type 8, format 3). backtrack, input and lookahead hold one set of glyphs per
+// context position, backtrack in logical order. records tell which lookups to
+// apply at which positions of the input sequence.
+func ChainContext(backtrack, input, lookahead [][]ot.GlyphIndex, records ...ot.SequenceLookupRecord) []byte {
+	s := newSubtable(10 + 2*(len(backtrack)+len(input)+len(lookahead)) + 4*len(records))
+	s.head.u16(3)
+	s.head.u16(uint16(len(backtrack)))
+	for i := len(backtrack) - 1; i >= 0; i-- { // stored in reverse order
+		s.offsetTo(Coverage(backtrack[i]...))
+	}
+	s.head.u16(uint16(len(input)))
+	for _, set := range input {
+		s.offsetTo(Coverage(set...))
+	}
+	s.head.u16(uint16(len(lookahead)))
+	for _, set := range lookahead {
+		s.offsetTo(Coverage(set...))
+	}
+	s.head.u16(uint16(len(records)))
+	for _, rec := range records {
+		s.head.u16(rec.SequenceIndex)
+		s.head.u16(rec.LookupListIndex)
+	}
+	return s.bytes()
+}
+
+// Anchor is an attachment point in design units (anchor format 1).
+type Anchor struct {
+	X, Y int16
+}
+
+func (a Anchor) bytes() []byte {
+	w := &writer{}
+	w.u16(1)
+	w.u16(uint16(a.X))
+	w.u16(uint16(a.Y))
+	return w.buf
+}
+
+// Mark is a mark glyph of a mark class, together with its anchor.
+type Mark struct {
+	Glyph  ot.GlyphIndex
+	Class  uint16
+	Anchor Anchor
+}
+
+// MarkBasePos encodes a mark-to-base attachment subtable (GPOS type 4,
+// format 1). bases holds the anchors of each base glyph, indexed by mark class;
+// nil anchors are omitted.
+func MarkBasePos(marks []Mark, bases map[ot.GlyphIndex][]*Anchor) []byte {
+	return markAttachPos(marks, bases)
+}
+
+// MarkMarkPos encodes a mark-to-mark attachment subtable (GPOS type 6,
+// format 1). bases holds the anchors of the marks other marks attach to,
+// indexed by mark class; nil anchors are omitted.
+func MarkMarkPos(marks []Mark, bases map[ot.GlyphIndex][]*Anchor) []byte {
+	return markAttachPos(marks, bases)
+}
+
+// markAttachPos encodes the common layout of GPOS types 4 and 6.
+func markAttachPos(marks []Mark, bases map[ot.GlyphIndex][]*Anchor) []byte {
+	marks = slices.Clone(marks)
+	slices.SortFunc(marks, func(a, b Mark) int { return int(a.Glyph) - int(b.Glyph) })
+	classCount := 0
+	markGlyphs := make([]ot.GlyphIndex, len(marks))
+	for i, m := range marks {
+		markGlyphs[i] = m.Glyph
+		classCount = max(classCount, int(m.Class)+1)
+	}
+	for _, anchors := range bases {
+		classCount = max(classCount, len(anchors))
+	}
+	markArray := newSubtable(2 + 4*len(marks))
+	markArray.head.u16(uint16(len(marks)))
+	for _, m := range marks {
+		markArray.head.u16(m.Class)
+		markArray.offsetTo(m.Anchor.bytes())
+	}
+	baseGlyphs := sortedKeys(bases)
+	baseArray := newSubtable(2 + 2*classCount*len(baseGlyphs))
+	baseArray.head.u16(uint16(len(baseGlyphs)))
+	for _, g := range baseGlyphs {
+		for class := range classCount {
+			if class < len(bases[g]) && bases[g][class] != nil {
+				baseArray.offsetTo(bases[g][class].bytes())
+			} else {
+				baseArray.head.u16(0)
+			}
+		}
+	}
+	s := newSubtable(12)
+	s.head.u16(1)
+	s.offsetTo(Coverage(markGlyphs...))
+	s.offsetTo(Coverage(baseGlyphs...))
+	s.head.u16(uint16(classCount))
+	s.offsetTo(markArray.bytes())
+	s.offsetTo(baseArray.bytes())
+	return s.bytes()
+}
+
+// Extension encodes an extension subtable (GSUB type 7 or GPOS type 9,
+// format 1) wrapping a subtable of lookup type typ, which directly follows
+// the extension subtable.
+func Extension(typ ot.LayoutTableLookupType, sub []byte) []byte {
+	w := &writer{}
+	w.u16(1)
+	w.u16(uint16(typ))
+	w.u32(8)
+	w.bytes(sub)
+	return w.buf
+}
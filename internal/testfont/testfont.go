@@ -0,0 +1,500 @@
+/*
+Package testfont builds minimal OpenType fonts for tests.
+
+Fonts are assembled from a glyph count, a character map, horizontal metrics,
+GDEF glyph classes and GSUB/GPOS lookups, and serialized as SFNT binaries which
+package ot is able to parse without relaxation. Glyphs have no outlines.
+
+Lookup subtables are given as raw bytes, which allows tests to construct lookups
+of any type and format, including damaged ones. Helpers for common subtables
+(coverage tables, class definitions, single and ligature substitution, single
+and pair positioning, mark attachment, chained contexts, extensions) are provided.
+
+	b := testfont.New(10)
+	b.Map('f', 1).Map('i', 2).Advance(1, 300)
+	gsub := b.GSUB()
+	lig := gsub.Lookup(ot.GSubLookupTypeLigature, 0, testfont.LigatureSubst(
+		testfont.Ligature{Components: []ot.GlyphIndex{1, 2}, Glyph: 3}))
+	gsub.Feature("liga", lig)
+	otf, err := b.Parse()
+
+Alternatively, lookups and features may be written declaratively, in a subset of
+the Adobe feature file syntax (see Features):
+
+	b := testfont.New(10)
+	b.Map('f', 1).Map('i', 2).Name(3, "f_i")
+	err := b.Features(`feature liga { sub f i by f_i; } liga;`)
+*/
+package testfont
+
+import (
+	"encoding/binary"
+	"slices"
+	"sort"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Builder collects the contents of a synthetic font.
+type Builder struct {
+	NumGlyphs  int    // number of glyphs, including .notdef
+	UnitsPerEm uint16 // defaults to 1000
+	FamilyName string // defaults to "Testfont"
+
+	cmap          map[rune]ot.GlyphIndex
+	glyphNames    map[string]ot.GlyphIndex
+	advances      []uint16
+	glyphClasses  map[ot.GlyphIndex]uint16
+	markClasses   map[ot.GlyphIndex]uint16
+	markGlyphSets [][]ot.GlyphIndex
+	gsub, gpos    *Layout
+	tables        map[ot.Tag][]byte
+}
+
+// DefaultAdvance is the advance width of glyphs without an explicit advance.
+const DefaultAdvance = 500
+
+// New creates a builder for a font with numGlyphs glyphs (including glyph 0,
+// .notdef).
+func New(numGlyphs int) *Builder {
+	if numGlyphs < 1 {
+		numGlyphs = 1
+	}
+	b := &Builder{
+		NumGlyphs:  numGlyphs,
+		UnitsPerEm: 1000,
+		FamilyName: "Testfont",
+		cmap:       make(map[rune]ot.GlyphIndex),
+		advances:   make([]uint16, numGlyphs),
+		tables:     make(map[ot.Tag][]byte),
+	}
+	for i := range b.advances {
+		b.advances[i] = DefaultAdvance
+	}
+	return b
+}
+
+// Map maps code-point r to glyph g.
+func (b *Builder) Map(r rune, g ot.GlyphIndex) *Builder {
+	b.cmap[r] = g
+	return b
+}
+
+// MapRange maps code-points from…to (inclusive) to consecutive glyphs,
+// starting at glyph g.
+func (b *Builder) MapRange(from, to rune, g ot.GlyphIndex) *Builder {
+	for r := from; r <= to; r++ {
+		b.cmap[r] = g
+		g++
+	}
+	return b
+}
+
+// Name sets the name of glyph g, by which g may be referenced in feature
+// definitions (see Features). Names are not written to the font.
+func (b *Builder) Name(g ot.GlyphIndex, name string) *Builder {
+	if b.glyphNames == nil {
+		b.glyphNames = make(map[string]ot.GlyphIndex)
+	}
+	b.glyphNames[name] = g
+	return b
+}
+
+// Advance sets the advance width of glyph g.
+func (b *Builder) Advance(g ot.GlyphIndex, advance uint16) *Builder {
+	if int(g) < len(b.advances) {
+		b.advances[g] = advance
+	}
+	return b
+}
+
+// GlyphClass sets the GDEF glyph class of glyph g (1 = base, 2 = ligature,
+// 3 = mark, 4 = component). Setting any glyph class adds a GDEF table.
+func (b *Builder) GlyphClass(g ot.GlyphIndex, class uint16) *Builder {
+	if b.glyphClasses == nil {
+		b.glyphClasses = make(map[ot.GlyphIndex]uint16)
+	}
+	b.glyphClasses[g] = class
+	return b
+}
+
+// MarkAttachClass sets the GDEF mark attachment class of glyph g.
+func (b *Builder) MarkAttachClass(g ot.GlyphIndex, class uint16) *Builder {
+	if b.markClasses == nil {
+		b.markClasses = make(map[ot.GlyphIndex]uint16)
+	}
+	b.markClasses[g] = class
+	return b
+}
+
+// MarkGlyphSet adds a GDEF mark glyph set and returns its index.
+func (b *Builder) MarkGlyphSet(glyphs ...ot.GlyphIndex) uint16 {
+	b.markGlyphSets = append(b.markGlyphSets, glyphs)
+	return uint16(len(b.markGlyphSets) - 1)
+}
+
+// Table adds a table with raw content data. Tables added this way replace
+// tables generated by the builder.
+func (b *Builder) Table(tag string, data []byte) *Builder {
+	b.tables[ot.T(tag)] = data
+	return b
+}
+
+// GSUB returns the builder for the font's GSUB table, creating it if necessary.
+func (b *Builder) GSUB() *Layout {
+	if b.gsub == nil {
+		b.gsub = &Layout{}
+	}
+	return b.gsub
+}
+
+// GPOS returns the builder for the font's GPOS table, creating it if necessary.
+func (b *Builder) GPOS() *Layout {
+	if b.gpos == nil {
+		b.gpos = &Layout{}
+	}
+	return b.gpos
+}
+
+// Parse serializes the font and parses it with ot.Parse.
+func (b *Builder) Parse(options ...ot.ParseOption) (*ot.Font, error) {
+	return ot.Parse(b.Bytes(), options...)
+}
+
+// Bytes serializes the font to an SFNT binary.
+func (b *Builder) Bytes() []byte {
+	tables := map[ot.Tag][]byte{
+		ot.T("cmap"): b.cmapTable(),
+		ot.T("head"): b.headTable(),
+		ot.T("hhea"): b.hheaTable(),
+		ot.T("hmtx"): b.hmtxTable(),
+		ot.T("maxp"): b.maxpTable(),
+		ot.T("name"): b.nameTable(),
+		ot.T("OS/2"): b.os2Table(),
+		ot.T("post"): b.postTable(),
+	}
+	if b.glyphClasses != nil || b.markClasses != nil || b.markGlyphSets != nil {
+		tables[ot.T("GDEF")] = b.gdefTable()
+	}
+	// package ot insists on both GSUB and GPOS, so we always add them
+	tables[ot.T("GSUB")] = b.GSUB().Bytes()
+	tables[ot.T("GPOS")] = b.GPOS().Bytes()
+	for tag, data := range b.tables {
+		tables[tag] = data
+	}
+	data, err := ot.AssembleFont(ot.FontTypeTrueType, tables)
+	if err != nil { // cannot happen, as required tables are always present
+		panic("testfont: " + err.Error())
+	}
+	return data
+}
+
+// --- Required tables -------------------------------------------------------
+
+func (b *Builder) cmapTable() []byte {
+	runes := make([]rune, 0, len(b.cmap))
+	for r := range b.cmap {
+		runes = append(runes, r)
+	}
+	slices.Sort(runes)
+	type group struct {
+		from, to rune
+		g        ot.GlyphIndex
+	}
+	var groups []group
+	for _, r := range runes {
+		g := b.cmap[r]
+		if k := len(groups) - 1; k >= 0 && groups[k].to+1 == r && groups[k].g+ot.GlyphIndex(r-groups[k].from) == g {
+			groups[k].to = r
+			continue
+		}
+		groups = append(groups, group{from: r, to: r, g: g})
+	}
+	w := &writer{}
+	w.u16(0) // version
+	w.u16(1) // one encoding record
+	w.u16(3) // platform Windows
+	w.u16(10)
+	w.u32(12)
+	// format 12 subtable
+	w.u16(12)
+	w.u16(0)
+	w.u32(uint32(16 + 12*len(groups)))
+	w.u32(0) // language
+	w.u32(uint32(len(groups)))
+	for _, gr := range groups {
+		w.u32(uint32(gr.from))
+		w.u32(uint32(gr.to))
+		w.u32(uint32(gr.g))
+	}
+	return w.buf
+}
+
+func (b *Builder) headTable() []byte {
+	w := &writer{}
+	w.u16(1) // major version
+	w.u16(0)
+	w.u32(0x00010000) // font revision
+	w.u32(0)          // checksum adjustment, set by assemble
+	w.u32(0x5F0F3CF5) // magic number
+	w.u16(0)          // flags
+	w.u16(b.UnitsPerEm)
+	w.zeros(16) // created, modified
+	w.u16(0)    // xMin
+	w.u16(uint16(0xffff - 199))
+	w.u16(b.maxAdvance())
+	w.u16(800)
+	w.u16(0) // mac style
+	w.u16(8) // lowest rec. PPEM
+	w.u16(2) // font direction hint
+	w.u16(0) // index to loc format
+	w.u16(0) // glyph data format
+	return w.buf
+}
+
+func (b *Builder) hheaTable() []byte {
+	w := &writer{}
+	w.u16(1)
+	w.u16(0)
+	w.u16(800)                  // ascender
+	w.u16(uint16(0xffff - 199)) // descender -200
+	w.u16(0)                    // line gap
+	w.u16(b.maxAdvance())
+	w.zeros(6) // min LSB, min RSB, max extent
+	w.u16(1)   // caret slope rise
+	w.u16(0)   // caret slope run
+	w.u16(0)   // caret offset
+	w.zeros(8) // reserved
+	w.u16(0)   // metric data format
+	w.u16(uint16(b.NumGlyphs))
+	return w.buf
+}
+
+func (b *Builder) hmtxTable() []byte {
+	w := &writer{}
+	for _, adv := range b.advances {
+		w.u16(adv)
+		w.u16(0) // left side bearing
+	}
+	return w.buf
+}
+
+func (b *Builder) maxpTable() []byte {
+	w := &writer{}
+	w.u32(0x00005000) // version 0.5
+	w.u16(uint16(b.NumGlyphs))
+	return w.buf
+}
+
+func (b *Builder) nameTable() []byte {
+	family := utf16BE(b.FamilyName)
+	sub := utf16BE("Regular")
+	w := &writer{}
+	w.u16(0) // format
+	w.u16(2) // count
+	w.u16(6 + 2*12)
+	for i, s := range [][]byte{family, sub} {
+		w.u16(3)      // platform Windows
+		w.u16(1)      // encoding Unicode BMP
+		w.u16(0x0409) // language en-US
+		w.u16(uint16(i + 1))
+		w.u16(uint16(len(s)))
+		w.u16(uint16(i * len(family)))
+	}
+	w.bytes(family)
+	w.bytes(sub)
+	return w.buf
+}
+
+func (b *Builder) os2Table() []byte {
+	w := &writer{}
+	w.u16(4) // version
+	w.u16(DefaultAdvance)
+	w.u16(400) // weight class
+	w.u16(5)   // width class
+	w.zeros(64)
+	w.u16(800)                  // typo ascender
+	w.u16(uint16(0xffff - 199)) // typo descender
+	w.u16(0)                    // typo line gap
+	w.u16(800)                  // win ascent
+	w.u16(200)                  // win descent
+	w.zeros(18)
+	return w.buf
+}
+
+func (b *Builder) postTable() []byte {
+	w := &writer{}
+	w.u32(0x00030000)
+	w.zeros(28)
+	return w.buf
+}
+
+func (b *Builder) maxAdvance() uint16 {
+	return slices.Max(b.advances)
+}
+
+func utf16BE(s string) []byte {
+	w := &writer{}
+	for _, r := range s {
+		w.u16(uint16(r))
+	}
+	return w.buf
+}
+
+// --- GDEF ------------------------------------------------------------------
+
+func (b *Builder) gdefTable() []byte {
+	const headerSize = 14
+	w := &writer{}
+	w.u16(1)
+	w.u16(2) // version 1.2, includes mark glyph sets
+	var glyphClassDef, markClassDef, markSets []byte
+	if b.glyphClasses != nil {
+		glyphClassDef = ClassDef(b.glyphClasses)
+	}
+	if b.markClasses != nil {
+		markClassDef = ClassDef(b.markClasses)
+	}
+	if b.markGlyphSets != nil {
+		sets := &writer{}
+		sets.u16(1)
+		sets.u16(uint16(len(b.markGlyphSets)))
+		off := 4 + 4*len(b.markGlyphSets)
+		covs := &writer{}
+		for _, set := range b.markGlyphSets {
+			sets.u32(uint32(off + len(covs.buf)))
+			covs.bytes(Coverage(set...))
+		}
+		sets.bytes(covs.buf)
+		markSets = sets.buf
+	}
+	body := &writer{}
+	offsetOf := func(data []byte) uint16 {
+		if data == nil {
+			return 0
+		}
+		off := headerSize + len(body.buf)
+		body.bytes(data)
+		return uint16(off)
+	}
+	w.u16(offsetOf(glyphClassDef))
+	w.u16(0) // attachment point list
+	w.u16(0) // ligature caret list
+	w.u16(offsetOf(markClassDef))
+	w.u16(offsetOf(markSets))
+	w.bytes(body.buf)
+	return w.buf
+}
+
+// Coverage encodes a coverage table (format 1) for glyphs. Glyphs are sorted
+// and duplicates removed; the coverage index of a glyph is its position in the
+// sorted list.
+func Coverage(glyphs ...ot.GlyphIndex) []byte {
+	gs := slices.Clone(glyphs)
+	slices.Sort(gs)
+	gs = slices.Compact(gs)
+	w := &writer{}
+	w.u16(1)
+	w.u16(uint16(len(gs)))
+	for _, g := range gs {
+		w.u16(uint16(g))
+	}
+	return w.buf
+}
+
+// ClassDef encodes a class definition table (format 1). Glyphs not contained in
+// classes are in class 0.
+func ClassDef(classes map[ot.GlyphIndex]uint16) []byte {
+	glyphs := classGlyphs(classes)
+	w := &writer{}
+	w.u16(1)
+	if len(glyphs) == 0 {
+		w.u16(0)
+		w.u16(0)
+		return w.buf
+	}
+	first, last := glyphs[0], glyphs[len(glyphs)-1]
+	w.u16(uint16(first))
+	w.u16(uint16(last - first + 1))
+	for g := first; g <= last; g++ {
+		w.u16(classes[g])
+	}
+	return w.buf
+}
+
+// ClassDefRanges encodes a class definition table (format 2), merging
+// consecutive glyphs of the same class into class ranges.
+func ClassDefRanges(classes map[ot.GlyphIndex]uint16) []byte {
+	type rng struct {
+		from, to ot.GlyphIndex
+		class    uint16
+	}
+	var ranges []rng
+	for _, g := range classGlyphs(classes) {
+		c := classes[g]
+		if k := len(ranges) - 1; k >= 0 && ranges[k].to+1 == g && ranges[k].class == c {
+			ranges[k].to = g
+			continue
+		}
+		ranges = append(ranges, rng{from: g, to: g, class: c})
+	}
+	w := &writer{}
+	w.u16(2)
+	w.u16(uint16(len(ranges)))
+	for _, r := range ranges {
+		w.u16(uint16(r.from))
+		w.u16(uint16(r.to))
+		w.u16(r.class)
+	}
+	return w.buf
+}
+
+// classGlyphs returns the glyphs with a non-zero class, in ascending order.
+func classGlyphs(classes map[ot.GlyphIndex]uint16) []ot.GlyphIndex {
+	glyphs := make([]ot.GlyphIndex, 0, len(classes))
+	for g, c := range classes {
+		if c != 0 {
+			glyphs = append(glyphs, g)
+		}
+	}
+	slices.Sort(glyphs)
+	return glyphs
+}
+
+// --- Writer ----------------------------------------------------------------
+
+type writer struct {
+	buf []byte
+}
+
+func (w *writer) u16(v uint16) {
+	w.buf = binary.BigEndian.AppendUint16(w.buf, v)
+}
+
+func (w *writer) u32(v uint32) {
+	w.buf = binary.BigEndian.AppendUint32(w.buf, v)
+}
+
+func (w *writer) bytes(b []byte) {
+	w.buf = append(w.buf, b...)
+}
+
+func (w *writer) zeros(n int) {
+	w.buf = append(w.buf, make([]byte, n)...)
+}
+
+func (w *writer) align4() {
+	for len(w.buf)%4 != 0 {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[K ~uint16 | ~uint32, V any](m map[K]V) []K {
+	keys := make([]K, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
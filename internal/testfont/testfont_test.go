@@ -0,0 +1,169 @@
+package testfont
+
+import (
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestBuildMinimalFont(t *testing.T) {
+	b := New(5)
+	b.MapRange('a', 'c', 1).Map('€', 4).Advance(2, 321)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	if otf.HasCriticalErrors() {
+		t.Fatalf("synthetic font has critical errors: %v", otf.CriticalErrors())
+	}
+	cmap := otf.CMapTable()
+	glyphs := make([]ot.GlyphIndex, 5)
+	cmap.GlyphIndexes([]rune{'a', 'b', 'c', '€', 'x'}, glyphs)
+	for i, want := range []ot.GlyphIndex{1, 2, 3, 4, 0} {
+		if glyphs[i] != want {
+			t.Errorf("cmap: expected glyph %d at position %d, got %d", want, i, glyphs[i])
+		}
+	}
+	if adv := otf.HorizontalMetrics().Advance(2); adv != 321 {
+		t.Errorf("expected advance 321 for glyph 2, got %d", adv)
+	}
+	if adv := otf.HorizontalMetrics().Advance(3); adv != DefaultAdvance {
+		t.Errorf("expected default advance for glyph 3, got %d", adv)
+	}
+	if upem := otf.FontHead().UnitsPerEm; upem != 1000 {
+		t.Errorf("expected 1000 units per em, got %d", upem)
+	}
+	if m := otf.VerifyChecksums(); len(m) != 0 {
+		t.Errorf("expected valid checksums, have %v", m)
+	}
+}
+
+func TestBuildGDEF(t *testing.T) {
+	b := New(8)
+	b.GlyphClass(1, 1).GlyphClass(2, 2).GlyphClass(5, 3).GlyphClass(6, 3)
+	b.MarkAttachClass(5, 1).MarkAttachClass(6, 2)
+	set := b.MarkGlyphSet(6)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	gdef := otf.GDef()
+	if gdef == nil {
+		t.Fatal("expected GDEF table")
+	}
+	for g, want := range map[ot.GlyphIndex]int{0: 0, 1: 1, 2: 2, 3: 0, 5: 3, 6: 3, 7: 0} {
+		if clz := gdef.GlyphClassDef.Lookup(g); clz != want {
+			t.Errorf("expected glyph class %d for glyph %d, got %d", want, g, clz)
+		}
+	}
+	if clz := gdef.MarkAttachmentClassDef.Lookup(6); clz != 2 {
+		t.Errorf("expected mark attachment class 2 for glyph 6, got %d", clz)
+	}
+	if len(gdef.MarkGlyphSets) != 1 {
+		t.Fatalf("expected 1 mark glyph set, got %d", len(gdef.MarkGlyphSets))
+	}
+	cov := ot.Coverage{GlyphRange: gdef.MarkGlyphSets[set]}
+	if !cov.Contains(6) || cov.Contains(5) {
+		t.Errorf("expected mark glyph set to contain glyph 6 only")
+	}
+}
+
+func TestBuildGSUB(t *testing.T) {
+	b := New(10)
+	gsub := b.GSUB()
+	single := gsub.Lookup(ot.GSubLookupTypeSingle, 0, SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 7, 3: 8}))
+	lig := gsub.Lookup(ot.GSubLookupTypeLigature, 0, LigatureSubst(
+		Ligature{Components: []ot.GlyphIndex{1, 2}, Glyph: 9},
+		Ligature{Components: []ot.GlyphIndex{1, 2, 3}, Glyph: 8},
+	))
+	ext := gsub.Lookup(ot.GSubLookupTypeExtensionSubs, 0, Extension(ot.GSubLookupTypeMultiple,
+		MultipleSubst(map[ot.GlyphIndex][]ot.GlyphIndex{4: {5, 6}})))
+	gsub.Feature("smcp", single)
+	gsub.Feature("liga", lig, ext)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	table := otf.GSub()
+	if err := table.LookupGraph().Error(); err != nil {
+		t.Fatalf("lookup list has error: %v", err)
+	}
+	if n := table.FeatureGraph().Len(); n != 2 {
+		t.Errorf("expected 2 features, got %d", n)
+	}
+	if table.ScriptGraph().Script(ot.T("latn")) == nil {
+		t.Errorf("expected default script 'latn'")
+	}
+	//
+	node := table.LookupGraph().Lookup(single).Subtable(0)
+	if node.Error() != nil || node.Format != 2 {
+		t.Fatalf("unexpected single substitution subtable: format %d, error %v", node.Format, node.Error())
+	}
+	if inx, ok := node.Coverage.Match(3); !ok || node.GSubPayload().SingleFmt2.SubstituteGlyphIDs[inx] != 8 {
+		t.Errorf("expected glyph 3 to be substituted by glyph 8")
+	}
+	//
+	node = table.LookupGraph().Lookup(lig).Subtable(0)
+	if node.Error() != nil {
+		t.Fatalf("ligature subtable has error: %v", node.Error())
+	}
+	sets := node.GSubPayload().LigatureFmt1.LigatureSets
+	if len(sets) != 1 || len(sets[0]) != 2 || sets[0][1].Ligature != 8 {
+		t.Errorf("unexpected ligature sets: %v", sets)
+	}
+	//
+	node = table.LookupGraph().Lookup(ext).Subtable(0)
+	if node.Wrapper() == nil || node.LookupType != ot.GSubLookupTypeMultiple {
+		t.Fatalf("expected resolved extension subtable, got type %d", node.LookupType)
+	}
+	if seq := node.GSubPayload().MultipleFmt1.Sequences; len(seq) != 1 || len(seq[0]) != 2 {
+		t.Errorf("unexpected multiple substitution sequences: %v", seq)
+	}
+}
+
+func TestBuildGPOS(t *testing.T) {
+	b := New(10)
+	set := b.MarkGlyphSet(5)
+	gpos := b.GPOS()
+	single := gpos.Lookup(ot.GPosLookupTypeSingle, 0, SinglePos(ValueRecord{XAdvance: -20}, 1, 2))
+	pair := gpos.FilteredLookup(ot.GPosLookupTypePair, 0, set, PairPos(map[Pair]ValueRecord{
+		{1, 2}: {XAdvance: -50},
+		{1, 3}: {XPlacement: 10, XAdvance: -30},
+	}))
+	gpos.Feature("kern", single, pair)
+	gpos.Script("latn", DefaultLang, 0).Script("latn", "DEU", 0)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	table := otf.GPos()
+	if table.ScriptGraph().Script(ot.T("DFLT")) != nil {
+		t.Errorf("did not expect script 'DFLT'")
+	}
+	if lang := table.ScriptGraph().Script(ot.T("latn")).LangSys(ot.T("DEU")); lang == nil {
+		t.Errorf("expected language system 'DEU'")
+	}
+	node := table.LookupGraph().Lookup(single).Subtable(0)
+	if p := node.GPosPayload().SingleFmt1; p == nil || p.Value.XAdvance != -20 {
+		t.Errorf("unexpected single positioning payload: %+v", p)
+	}
+	lt := table.LookupGraph().Lookup(pair)
+	if lt.MarkFilteringSet() != set {
+		t.Errorf("expected mark filtering set %d, got %d", set, lt.MarkFilteringSet())
+	}
+	p := lt.Subtable(0).GPosPayload().PairFmt1
+	if p == nil || len(p.PairSets) != 1 || len(p.PairSets[0]) != 2 {
+		t.Fatalf("unexpected pair positioning payload: %+v", p)
+	}
+	if rec := p.PairSets[0][1]; rec.SecondGlyph != 3 || rec.Value1.XPlacement != 10 || rec.Value1.XAdvance != -30 {
+		t.Errorf("unexpected pair value record: %+v", rec)
+	}
+}
+
+func TestTableReplacement(t *testing.T) {
+	b := New(2)
+	b.Table("cmap", []byte{0, 0, 0, 0})
+	if _, err := b.Parse(); err == nil {
+		t.Errorf("expected font with empty cmap to be rejected")
+	}
+}
@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"sort"
+	"os"
 	"strings"
 
 	"github.com/npillmayer/opentype"
@@ -29,7 +29,6 @@ func runFontCommand(args map[string]commando.ArgValue, flags map[string]commando
 	}
 
 	tags := otf.TableTags()
-	sort.Slice(tags, func(i, j int) bool { return tags[i] < tags[j] })
 	fmt.Printf("Tables (%d):", len(tags))
 	for _, tag := range tags {
 		fmt.Printf(" %s", tag.String())
@@ -37,7 +36,6 @@ func runFontCommand(args map[string]commando.ArgValue, flags map[string]commando
 	fmt.Println()
 
 	layoutTables := otquery.LayoutTables(otf)
-	sort.Strings(layoutTables)
 	fmt.Printf("Layout: %s\n", strings.Join(layoutTables, ","))
 
 	errs := otf.Errors()
@@ -46,7 +44,7 @@ func runFontCommand(args map[string]commando.ArgValue, flags map[string]commando
 	fmt.Printf("Issues: errors=%d warnings=%d critical=%d\n", len(errs), len(warns), len(crit))
 
 	if len(args["tables"].Value) > 0 {
-		printSelectedTables(otf, args["tables"].Value)
+		printSelectedTables(otf, args["tables"].Value, mustFlagBool(flags["json"], "json"))
 	}
 	showIssues, err := flags["errors"].GetBool()
 	if err != nil {
@@ -62,7 +60,7 @@ func runFontCommand(args map[string]commando.ArgValue, flags map[string]commando
 	}
 }
 
-func printSelectedTables(otf *ot.Font, raw string) {
+func printSelectedTables(otf *ot.Font, raw string, asJSON bool) {
 	requested := splitCSVSpace(raw)
 	for _, t := range requested {
 		tagName := strings.TrimSpace(t)
@@ -77,5 +75,10 @@ func printSelectedTables(otf *ot.Font, raw string) {
 		}
 		off, size := table.Extent()
 		fmt.Printf("table %s: offset=%d size=%d\n", tagName, off, size)
+		if asJSON {
+			if err := ot.ExportJSON(table, os.Stdout, ot.ExportIndented); err != nil {
+				fatalf("cannot export table %s: %v", tagName, err)
+			}
+		}
 	}
 }
@@ -0,0 +1,277 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/thatisuday/commando"
+	"golang.org/x/image/font/sfnt"
+)
+
+func runCoverageCommand(args map[string]commando.ArgValue, flags map[string]commando.FlagValue) {
+	otf, names := mustLoadGlyphSetFont(args, flags)
+	nodes := mustSelectSubtables(otf, args["table"].Value, args["location"].Value)
+	for _, n := range nodes {
+		n.printHeader()
+		for _, c := range subtableCoverages(n.node) {
+			glyphs := slices.Collect(c.coverage.Glyphs())
+			fmt.Printf("  %s (%d glyphs)\n", c.name, len(glyphs))
+			printGlyphRanges(glyphs, names, "    ")
+		}
+	}
+}
+
+func runClassesCommand(args map[string]commando.ArgValue, flags map[string]commando.FlagValue) {
+	otf, names := mustLoadGlyphSetFont(args, flags)
+	tableName := strings.TrimSpace(args["table"].Value)
+	if tableName == "GDEF" {
+		gdef := otf.GDef()
+		if gdef == nil {
+			fatalf("font has no table GDEF")
+		}
+		printClassDef("GlyphClassDef", &gdef.GlyphClassDef, names, "")
+		printClassDef("MarkAttachmentClassDef", &gdef.MarkAttachmentClassDef, names, "")
+		return
+	}
+	nodes := mustSelectSubtables(otf, tableName, args["location"].Value)
+	for _, n := range nodes {
+		n.printHeader()
+		for _, cd := range subtableClassDefs(n.node) {
+			printClassDef(cd.name, cd.classes, names, "  ")
+		}
+	}
+}
+
+// mustLoadGlyphSetFont loads the font of a coverage or classes command, and a
+// function returning glyph names from table 'post' (empty if unavailable).
+func mustLoadGlyphSetFont(args map[string]commando.ArgValue, flags map[string]commando.FlagValue) (
+	*ot.Font, func(ot.GlyphIndex) string) {
+	//
+	fontPath := strings.TrimSpace(args["font"].Value)
+	if fontPath == "" {
+		fatalf("font path is required")
+	}
+	otf := mustLoadFont(fontPath, mustFlagBool(flags["testfont"], "testfont"))
+	names := func(ot.GlyphIndex) string { return "" }
+	if sf, err := parseSFNT(fontPath); err == nil {
+		var buf sfnt.Buffer
+		names = func(g ot.GlyphIndex) string {
+			name, err := sf.GlyphName(&buf, sfnt.GlyphIndex(g))
+			if err != nil {
+				return ""
+			}
+			return name
+		}
+	}
+	return otf, names
+}
+
+// selectedSubtable is a lookup subtable selected by a location argument.
+type selectedSubtable struct {
+	table            string
+	lookup, subtable int
+	node             *ot.LookupNode
+}
+
+// mustSelectSubtables selects the subtables of table GSUB or GPOS, given by a
+// location of the form <lookup#>/<subtable#> or <lookup#> for all subtables of
+// a lookup.
+func mustSelectSubtables(otf *ot.Font, tableName, location string) []selectedSubtable {
+	tableName = strings.TrimSpace(tableName)
+	var lt *ot.LayoutTable
+	switch tableName {
+	case "GSUB":
+		if gsub := otf.GSub(); gsub != nil {
+			lt = &gsub.LayoutTable
+		}
+	case "GPOS":
+		if gpos := otf.GPos(); gpos != nil {
+			lt = &gpos.LayoutTable
+		}
+	default:
+		fatalf("table must be GSUB or GPOS, is %q", tableName)
+	}
+	if lt == nil {
+		fatalf("font has no table %s", tableName)
+	}
+	lookupPart, subPart, hasSub := strings.Cut(strings.TrimSpace(location), "/")
+	inx, err := strconv.Atoi(lookupPart)
+	if err != nil {
+		fatalf("invalid location %q, expected <lookup#>/<subtable#>", location)
+	}
+	lookup := lt.LookupGraph().Lookup(inx)
+	if lookup == nil {
+		fatalf("lookup %d out of range (lookups: %d)", inx, lt.LookupGraph().Len())
+	}
+	var selected []selectedSubtable
+	for i, node := range lookup.Range() {
+		selected = append(selected, selectedSubtable{table: tableName, lookup: inx, subtable: i, node: node})
+	}
+	if !hasSub {
+		return selected
+	}
+	sub, err := strconv.Atoi(subPart)
+	if err != nil || sub < 0 || sub >= len(selected) {
+		fatalf("invalid subtable %q of lookup %d (subtables: %d)", subPart, inx, len(selected))
+	}
+	return selected[sub : sub+1]
+}
+
+func (s selectedSubtable) printHeader() {
+	typ := s.node.LookupType
+	name := typ.GSubString()
+	if ot.IsGPosLookupType(typ) {
+		typ = ot.GPosLookupType(typ)
+		name = typ.GPosString()
+	}
+	fmt.Printf("%s lookup %d subtable %d: type %d (%s), format %d\n",
+		s.table, s.lookup, s.subtable, typ, name, s.node.Format)
+	if err := s.node.Error(); err != nil {
+		fmt.Printf("  error: %v\n", err)
+	}
+}
+
+type namedCoverage struct {
+	name     string
+	coverage ot.Coverage
+}
+
+// subtableCoverages returns the coverage tables of a lookup subtable, starting
+// with its primary coverage.
+func subtableCoverages(node *ot.LookupNode) []namedCoverage {
+	if node == nil {
+		return nil
+	}
+	covs := []namedCoverage{{"Coverage", node.Coverage}}
+	add := func(name string, c ot.Coverage) {
+		covs = append(covs, namedCoverage{name, c})
+	}
+	addAll := func(name string, cs []ot.Coverage) {
+		for i, c := range cs {
+			add(fmt.Sprintf("%s[%d]", name, i), c)
+		}
+	}
+	if p := node.GSub; p != nil {
+		switch {
+		case p.ContextFmt3 != nil:
+			addAll("InputCoverage", p.ContextFmt3.InputCoverages)
+		case p.ChainingContextFmt3 != nil:
+			addAll("BacktrackCoverage", p.ChainingContextFmt3.BacktrackCoverages)
+			addAll("InputCoverage", p.ChainingContextFmt3.InputCoverages)
+			addAll("LookaheadCoverage", p.ChainingContextFmt3.LookaheadCoverages)
+		case p.ReverseChainingFmt1 != nil:
+			addAll("BacktrackCoverage", p.ReverseChainingFmt1.BacktrackCoverages)
+			addAll("LookaheadCoverage", p.ReverseChainingFmt1.LookaheadCoverages)
+		}
+	}
+	if p := node.GPos; p != nil {
+		switch {
+		case p.MarkToBaseFmt1 != nil:
+			add("BaseCoverage", p.MarkToBaseFmt1.BaseCoverage)
+		case p.MarkToLigatureFmt1 != nil:
+			add("LigatureCoverage", p.MarkToLigatureFmt1.LigatureCoverage)
+		case p.MarkToMarkFmt1 != nil:
+			add("Mark2Coverage", p.MarkToMarkFmt1.Mark2Coverage)
+		case p.ContextFmt3 != nil:
+			addAll("InputCoverage", p.ContextFmt3.InputCoverages)
+		case p.ChainingContextFmt3 != nil:
+			addAll("BacktrackCoverage", p.ChainingContextFmt3.BacktrackCoverages)
+			addAll("InputCoverage", p.ChainingContextFmt3.InputCoverages)
+			addAll("LookaheadCoverage", p.ChainingContextFmt3.LookaheadCoverages)
+		}
+	}
+	return covs
+}
+
+type namedClassDef struct {
+	name    string
+	classes *ot.ClassDefinitions
+}
+
+// subtableClassDefs returns the class definition tables of a lookup subtable.
+func subtableClassDefs(node *ot.LookupNode) []namedClassDef {
+	if node == nil {
+		return nil
+	}
+	if p := node.GSub; p != nil {
+		switch {
+		case p.ContextFmt2 != nil:
+			return []namedClassDef{{"ClassDef", &p.ContextFmt2.ClassDef}}
+		case p.ChainingContextFmt2 != nil:
+			return []namedClassDef{
+				{"BacktrackClassDef", &p.ChainingContextFmt2.BacktrackClassDef},
+				{"InputClassDef", &p.ChainingContextFmt2.InputClassDef},
+				{"LookaheadClassDef", &p.ChainingContextFmt2.LookaheadClassDef},
+			}
+		}
+	}
+	if p := node.GPos; p != nil {
+		switch {
+		case p.PairFmt2 != nil:
+			return []namedClassDef{
+				{"ClassDef1", &p.PairFmt2.ClassDef1},
+				{"ClassDef2", &p.PairFmt2.ClassDef2},
+			}
+		case p.ContextFmt2 != nil:
+			return []namedClassDef{{"ClassDef", &p.ContextFmt2.ClassDef}}
+		case p.ChainingContextFmt2 != nil:
+			return []namedClassDef{
+				{"BacktrackClassDef", &p.ChainingContextFmt2.BacktrackClassDef},
+				{"InputClassDef", &p.ChainingContextFmt2.InputClassDef},
+				{"LookaheadClassDef", &p.ChainingContextFmt2.LookaheadClassDef},
+			}
+		}
+	}
+	return nil
+}
+
+// printClassDef prints the glyphs of each class of a class definition table.
+// Class 0 holds all glyphs not listed and is not printed.
+func printClassDef(name string, cdef *ot.ClassDefinitions, names func(ot.GlyphIndex) string, indent string) {
+	byClass := map[int][]ot.GlyphIndex{}
+	for g, clz := range cdef.Classes() {
+		byClass[clz] = append(byClass[clz], g)
+	}
+	classes := make([]int, 0, len(byClass))
+	for clz := range byClass {
+		classes = append(classes, clz)
+	}
+	slices.Sort(classes)
+	fmt.Printf("%s%s (%d classes besides class 0)\n", indent, name, len(classes))
+	for _, clz := range classes {
+		glyphs := byClass[clz]
+		slices.Sort(glyphs)
+		fmt.Printf("%s  class %d (%d glyphs)\n", indent, clz, len(glyphs))
+		printGlyphRanges(glyphs, names, indent+"    ")
+	}
+}
+
+// printGlyphRanges prints glyphs as ranges of consecutive glyph IDs, one range
+// per line, followed by the glyph names (if any).
+func printGlyphRanges(glyphs []ot.GlyphIndex, names func(ot.GlyphIndex) string, indent string) {
+	for start := 0; start < len(glyphs); {
+		end := start + 1
+		for end < len(glyphs) && glyphs[end] == glyphs[end-1]+1 {
+			end++
+		}
+		ids := strconv.Itoa(int(glyphs[start]))
+		if end-start > 1 {
+			ids += "-" + strconv.Itoa(int(glyphs[end-1]))
+		}
+		var glyphNames []string
+		for _, g := range glyphs[start:end] {
+			if name := names(g); name != "" {
+				glyphNames = append(glyphNames, name)
+			}
+		}
+		if len(glyphNames) > 0 {
+			fmt.Printf("%s%-12s %s\n", indent, ids, strings.Join(glyphNames, " "))
+		} else {
+			fmt.Printf("%s%s\n", indent, ids)
+		}
+		start = end
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/thatisuday/commando"
+)
+
+func runNavCommand(args map[string]commando.ArgValue, flags map[string]commando.FlagValue) {
+	fontPath := strings.TrimSpace(args["font"].Value)
+	if fontPath == "" {
+		fatalf("font path is required")
+	}
+	otf := mustLoadFont(fontPath, mustFlagBool(flags["testfont"], "testfont"))
+	tableName := strings.TrimSpace(args["table"].Value)
+	table := otf.Table(ot.T(tableName))
+	if table == nil {
+		fatalf("font has no table %s", tableName)
+	}
+	locs, err := ot.NavigatePath(table, args["path"].Value)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	for _, loc := range locs {
+		fmt.Println(loc.String())
+	}
+}
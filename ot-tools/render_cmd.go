@@ -0,0 +1,86 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otarabic"
+	"github.com/npillmayer/opentype/otshape/otcore"
+	"github.com/npillmayer/opentype/otshape/othebrew"
+	"github.com/npillmayer/opentype/otshape/ottibetan"
+	"github.com/npillmayer/opentype/otsvg"
+	"github.com/thatisuday/commando"
+)
+
+func runRenderCommand(args map[string]commando.ArgValue, flags map[string]commando.FlagValue) {
+	fontPath := strings.TrimSpace(args["font"].Value)
+	if fontPath == "" {
+		fatalf("font path is required")
+	}
+	otf := mustLoadFont(fontPath, mustFlagBool(flags["testfont"], "testfont"))
+
+	script, lang, dir, err := parseTypesetFlags(flags)
+	if err != nil {
+		fatalf("%v", err)
+	}
+	features, err := parseFeatureList(flags["features"])
+	if err != nil {
+		fatalf("%v", err)
+	}
+	input, err := parseShapeInput(args["text"], flags["codepoints"])
+	if err != nil {
+		fatalf("%v", err)
+	}
+	if input == "" {
+		fatalf("input text is empty")
+	}
+	outPath, err := flags["output"].GetString()
+	if err != nil {
+		fatalf("invalid --output flag: %v", err)
+	}
+	outPath = strings.TrimSpace(outPath)
+	if outPath == "" {
+		fatalf("output path is empty")
+	}
+	opts := otsvg.Options{
+		ClusterColors: mustFlagBool(flags["clusters"], "clusters"),
+		Anchors:       mustFlagBool(flags["anchors"], "anchors"),
+	}
+
+	sink := &glyphCollector{}
+	params := otshape.Params{
+		Font:      otf,
+		Direction: dir,
+		Script:    script,
+		Language:  lang,
+		Features:  features,
+	}
+	options := otshape.BufferOptions{
+		FlushBoundary: otshape.FlushOnRunBoundary,
+	}
+	shaper := otshape.NewShaper(otarabic.New(), othebrew.New(), ottibetan.New(), otcore.New())
+	if err := shaper.Shape(params, strings.NewReader(input), sink, options); err != nil {
+		fatalf("shape failed: %v", err)
+	}
+	if len(sink.glyphs) == 0 {
+		fatalf("shaping produced no glyphs")
+	}
+
+	if dir := filepath.Dir(outPath); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			fatalf("cannot create output directory: %v", err)
+		}
+	}
+	f, err := os.Create(outPath)
+	if err != nil {
+		fatalf("cannot create output file: %v", err)
+	}
+	defer f.Close()
+	if err := otsvg.Render(f, otf, sink.glyphs, opts); err != nil {
+		fatalf("render failed: %v", err)
+	}
+	fmt.Printf("wrote %s (glyphs=%d)\n", outPath, len(sink.glyphs))
+}
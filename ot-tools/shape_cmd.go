@@ -6,11 +6,11 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/npillmayer/opentype/ot"
 	"github.com/npillmayer/opentype/otshape"
 	"github.com/npillmayer/opentype/otshape/otarabic"
 	"github.com/npillmayer/opentype/otshape/otcore"
 	"github.com/npillmayer/opentype/otshape/othebrew"
+	"github.com/npillmayer/opentype/otshape/ottibetan"
 	"github.com/thatisuday/commando"
 )
 
@@ -69,6 +69,7 @@ func doShape(io IO, params otshape.Params, bufOpts otshape.BufferOptions) error
 		otcore.New(),
 		otarabic.New(),
 		othebrew.New(),
+		ottibetan.New(),
 	}
 	shaper := otshape.NewShaper(engines...)
 	err := shaper.Shape(params, io.Source, io.Sink, bufOpts)
@@ -123,59 +124,11 @@ func parseFeatureList(flag commando.FlagValue) ([]otshape.FeatureRange, error) {
 	if spec == "" {
 		return nil, nil
 	}
-	parts := splitCSVSpace(spec)
-	out := make([]otshape.FeatureRange, 0, len(parts))
-	for _, p := range parts {
-		f, err := parseFeatureItem(p)
-		if err != nil {
-			return nil, err
-		}
-		out = append(out, f)
-	}
-	return out, nil
-}
-
-var noFeatures = otshape.FeatureRange{}
-
-// We try to follow Harfbuzz's `hb-shape` features parameter syntax, which is
-// unfortunately not well documented.
-//
-// Disable ligatures:      --features="-liga"
-// or                      --features="liga=0"
-//
-// Feature ranges are not supported yet ("liga[3:5]").
-func parseFeatureItem(item string) (otshape.FeatureRange, error) {
-	if item = strings.TrimSpace(item); item == "" {
-		return noFeatures, errors.New("empty feature entry in --features")
-	}
-	on, isMinus := true, false
-	if item, on = strings.CutPrefix(item, "+"); !on {
-		if item, isMinus = strings.CutPrefix(item, "-"); isMinus {
-			on = false
-		}
-	}
-	tagPart, value, arg := item, "", 1
-	hasEqual := false
-	if tagPart, value, hasEqual = strings.Cut(item, "="); hasEqual {
-		if value == "" {
-			return noFeatures, fmt.Errorf("empty feature value in %q", item)
-		}
-		n, err := strconv.Atoi(value)
-		if err != nil {
-			return noFeatures, fmt.Errorf("invalid feature value in %q: %w", item, err)
-		}
-		arg = n
-		on = n != 0
-	}
-	tagPart = strings.TrimSpace(tagPart)
-	if len(tagPart) != 4 {
-		return noFeatures, fmt.Errorf("feature tag %q is not 4 characters", tagPart)
+	features, err := otshape.ParseFeatureString(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid --features flag: %w", err)
 	}
-	return otshape.FeatureRange{
-		Feature: ot.T(tagPart),
-		Arg:     arg,
-		On:      on,
-	}, nil
+	return features, nil
 }
 
 func parseCodepoints(spec string) ([]rune, error) {
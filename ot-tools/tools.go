@@ -31,7 +31,7 @@ func main() {
 		AddFlag("script,s", "script (ISO 15924, e.g. Latn, Arab, Hebr)", commando.String, "Latn").
 		AddFlag("lang,l", "language tag (BCP 47, e.g. en, ar, he)", commando.String, "en").
 		AddFlag("direction,d", "direction: ltr|rtl", commando.String, "ltr").
-		AddFlag("features,f", "feature list (e.g. liga=1,kern=0,+rlig,-calt)", commando.String, "-").
+		AddFlag("features,f", "feature list in Harfbuzz syntax (e.g. liga=1,kern=0,+rlig,-calt,aalt[3:5]=2)", commando.String, "-").
 		AddFlag("codepoints,c", "codepoints instead of text (comma/space separated, e.g. U+0627,U+0644)", commando.String, "-").
 		AddFlag("testfont,t", "parse font as relaxed test font fixture", commando.Bool, nil).
 		AddFlag("flush", "flush mode: run|cluster", commando.String, "run").
@@ -49,7 +49,7 @@ func main() {
 		AddFlag("script,s", "script (ISO 15924, e.g. Latn, Arab, Hebr)", commando.String, "Latn").
 		AddFlag("lang,l", "language tag (BCP 47, e.g. en, ar, he)", commando.String, "en").
 		AddFlag("direction,d", "direction: ltr|rtl", commando.String, "ltr").
-		AddFlag("features,f", "feature list (e.g. liga=1,kern=0,+rlig,-calt)", commando.String, "-").
+		AddFlag("features,f", "feature list in Harfbuzz syntax (e.g. liga=1,kern=0,+rlig,-calt,aalt[3:5]=2)", commando.String, "-").
 		AddFlag("codepoints,c", "codepoints instead of text (comma/space separated, e.g. U+0627,U+0644)", commando.String, "-").
 		AddFlag("testfont,t", "parse font as relaxed test font fixture", commando.Bool, nil).
 		AddFlag("output,o", "output PNG file", commando.String, "ot-tools-view.png").
@@ -61,6 +61,23 @@ func main() {
 		AddFlag("height,H", "image height in pixels", commando.Int, 240).
 		SetAction(runViewCommand)
 
+	commando.
+		Register("render").
+		SetDescription("Render shaped text to an SVG file, showing glyph outlines at their shaped positions.").
+		SetShortDescription("shape to SVG").
+		AddArgument("font", "OpenType font file path", "").
+		AddArgument("text...", "text to shape and render", "").
+		AddFlag("script,s", "script (ISO 15924, e.g. Latn, Arab, Hebr)", commando.String, "Latn").
+		AddFlag("lang,l", "language tag (BCP 47, e.g. en, ar, he)", commando.String, "en").
+		AddFlag("direction,d", "direction: ltr|rtl", commando.String, "ltr").
+		AddFlag("features,f", "feature list in Harfbuzz syntax (e.g. liga=1,kern=0,+rlig,-calt,aalt[3:5]=2)", commando.String, "-").
+		AddFlag("codepoints,c", "codepoints instead of text (comma/space separated, e.g. U+0627,U+0644)", commando.String, "-").
+		AddFlag("testfont,t", "parse font as relaxed test font fixture", commando.Bool, nil).
+		AddFlag("output,o", "output SVG file", commando.String, "ot-tools-render.svg").
+		AddFlag("clusters,C", "color glyphs by cluster", commando.Bool, nil).
+		AddFlag("anchors,A", "mark attachment points of marks and cursive glyphs", commando.Bool, nil).
+		SetAction(runRenderCommand)
+
 	commando.
 		Register("font").
 		SetDescription("Print diagnostics and table information for an OpenType font.").
@@ -69,8 +86,39 @@ func main() {
 		AddArgument("tables...", "optional list of table tags (e.g. GSUB,GPOS,head)", "").
 		AddFlag("testfont,t", "parse font as relaxed test font fixture", commando.Bool, nil).
 		AddFlag("errors,e", "print parse errors and warnings", commando.Bool, nil).
+		AddFlag("json,j", "export selected tables as JSON", commando.Bool, nil).
 		SetAction(runFontCommand)
 
+	commando.
+		Register("coverage").
+		SetDescription("Print the coverage tables of a GSUB or GPOS lookup subtable as glyph ranges, with glyph names from table 'post'. Location is <lookup#>/<subtable#>, or <lookup#> for all subtables.").
+		SetShortDescription("print coverage tables").
+		AddArgument("font", "OpenType font file path", "").
+		AddArgument("table", "layout table tag (GSUB or GPOS)", "").
+		AddArgument("location", "lookup and subtable index (e.g. 3/0)", "").
+		AddFlag("testfont,t", "parse font as relaxed test font fixture", commando.Bool, nil).
+		SetAction(runCoverageCommand)
+
+	commando.
+		Register("classes").
+		SetDescription("Print class definition tables as glyph ranges per class, with glyph names from table 'post': the glyph and mark attachment classes of GDEF, or the class definitions of a GSUB or GPOS lookup subtable at location <lookup#>/<subtable#> (or <lookup#> for all subtables).").
+		SetShortDescription("print class definitions").
+		AddArgument("font", "OpenType font file path", "").
+		AddArgument("table", "table tag (GDEF, GSUB or GPOS)", "").
+		AddArgument("location", "lookup and subtable index (e.g. 3/0); not used for GDEF", "-").
+		AddFlag("testfont,t", "parse font as relaxed test font fixture", commando.Bool, nil).
+		SetAction(runClassesCommand)
+
+	commando.
+		Register("nav").
+		SetDescription("Navigate the layout graph of a GSUB or GPOS table by path, e.g. ScriptList/latn/dflt/featureIndices[3]. Segment '*' or index [*] lists all children.").
+		SetShortDescription("navigate layout tables").
+		AddArgument("font", "OpenType font file path", "").
+		AddArgument("table", "layout table tag (GSUB or GPOS)", "").
+		AddArgument("path", "path expression (e.g. ScriptList/*, FeatureList/liga/lookupIndices)", "").
+		AddFlag("testfont,t", "parse font as relaxed test font fixture", commando.Bool, nil).
+		SetAction(runNavCommand)
+
 	commando.Parse(nil)
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/npillmayer/opentype/otshape/otarabic"
 	"github.com/npillmayer/opentype/otshape/otcore"
 	"github.com/npillmayer/opentype/otshape/othebrew"
+	"github.com/npillmayer/opentype/otshape/ottibetan"
 	"github.com/thatisuday/commando"
 	"golang.org/x/image/font"
 	"golang.org/x/image/font/sfnt"
@@ -83,6 +84,7 @@ func runViewCommand(args map[string]commando.ArgValue, flags map[string]commando
 	engines := []otshape.ShapingEngine{
 		otarabic.New(),
 		othebrew.New(),
+		ottibetan.New(),
 		otcore.New(),
 	}
 	shaper := otshape.NewShaper(engines...)
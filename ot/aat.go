@@ -0,0 +1,215 @@
+package ot
+
+import "fmt"
+
+// --- AAT lookup tables -----------------------------------------------------
+
+// AATLookup is a lookup table of the Apple Advanced Typography (AAT) tables,
+// such as 'morx' and 'kerx', which maps glyphs to values. Values are 16 or 32
+// bits wide, depending on the table using the lookup.
+//
+// See also
+// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6Tables.html
+type AATLookup struct {
+	raw       binarySegm
+	valueSize int // byte size of values of formats 0, 4 and 8
+}
+
+// viewAATLookup projects a lookup table at offset of b, with values of
+// valueSize bytes.
+func viewAATLookup(b binarySegm, offset int, valueSize int) (AATLookup, bool) {
+	if offset <= 0 || offset+2 > len(b) {
+		return AATLookup{}, false
+	}
+	return AATLookup{raw: b[offset:], valueSize: valueSize}, true
+}
+
+// Format returns the format of the lookup table: 0 (simple array), 2 (segment
+// single), 4 (segment array), 6 (single table), 8 (trimmed array) or 10
+// (extended trimmed array).
+func (l AATLookup) Format() uint16 {
+	return l.raw.U16(0)
+}
+
+// Lookup returns the value for glyph g, if g is covered by the lookup table.
+func (l AATLookup) Lookup(g GlyphIndex) (uint32, bool) {
+	if len(l.raw) < 2 {
+		return 0, false
+	}
+	switch l.raw.U16(0) {
+	case 0:
+		return l.value(2+int(g)*l.valueSize, l.valueSize)
+	case 2, 4:
+		unitSize, n := int(l.raw.U16(2)), int(l.raw.U16(4))
+		if unitSize < 6 {
+			return 0, false
+		}
+		// segments are sorted by their last glyph
+		lo, hi := 0, n
+		for lo < hi {
+			h := lo + (hi-lo)/2
+			if l.raw.U16(12+h*unitSize) < uint16(g) {
+				lo = h + 1
+			} else {
+				hi = h
+			}
+		}
+		seg := 12 + lo*unitSize
+		if lo == n || seg+unitSize > len(l.raw) || uint16(g) < l.raw.U16(seg+2) {
+			return 0, false
+		}
+		if l.raw.U16(0) == 2 {
+			return l.value(seg+4, unitSize-4)
+		}
+		first := l.raw.U16(seg + 2)
+		return l.value(int(l.raw.U16(seg+4))+int(uint16(g)-first)*l.valueSize, l.valueSize)
+	case 6:
+		unitSize, n := int(l.raw.U16(2)), int(l.raw.U16(4))
+		if unitSize < 4 {
+			return 0, false
+		}
+		lo, hi := 0, n
+		for lo < hi {
+			h := lo + (hi-lo)/2
+			switch glyph := l.raw.U16(12 + h*unitSize); {
+			case glyph < uint16(g):
+				lo = h + 1
+			case glyph > uint16(g):
+				hi = h
+			default:
+				return l.value(12+h*unitSize+2, unitSize-2)
+			}
+		}
+	case 8:
+		first, n := l.raw.U16(2), int(l.raw.U16(4))
+		if uint16(g) >= first && int(uint16(g)-first) < n {
+			return l.value(6+int(uint16(g)-first)*l.valueSize, l.valueSize)
+		}
+	case 10:
+		unitSize, first, n := int(l.raw.U16(2)), l.raw.U16(4), int(l.raw.U16(6))
+		if uint16(g) >= first && int(uint16(g)-first) < n {
+			return l.value(8+int(uint16(g)-first)*unitSize, unitSize)
+		}
+	}
+	return 0, false
+}
+
+// value reads an unsigned value of size bytes at offset at.
+func (l AATLookup) value(at int, size int) (uint32, bool) {
+	if at < 0 || at+size > len(l.raw) {
+		return 0, false
+	}
+	switch size {
+	case 1:
+		return uint32(l.raw[at]), true
+	case 2:
+		return uint32(l.raw.U16(at)), true
+	case 4:
+		return l.raw.U32(at), true
+	}
+	return 0, false
+}
+
+// --- AAT extended state tables ---------------------------------------------
+
+// Glyph classes with a fixed meaning in AAT state tables. Classes of glyphs
+// start at 4.
+const (
+	AATClassEndOfText    = 0
+	AATClassOutOfBounds  = 1 // glyphs not contained in the class lookup table
+	AATClassDeletedGlyph = 2 // glyph 0xFFFF, which marks deleted glyphs
+	AATClassEndOfLine    = 3
+)
+
+// AATStateTable is an extended state table, which drives the finite state
+// machines of 'morx' subtables and contextual 'kerx' subtables. For a state and
+// the class of the current glyph, the state table selects an entry, which holds
+// the next state, flags and subtable-specific data.
+type AATStateTable struct {
+	raw        binarySegm // starting at the state table header
+	classCount int
+	classes    AATLookup
+	states     binarySegm
+	entries    binarySegm
+	entrySize  int
+}
+
+// AATStateEntry is an entry of a state table. The meaning of flags and
+// arguments depends on the type of subtable: e.g., for a ligature subtable of
+// 'morx', Args[0] is the index of the first ligature action.
+type AATStateEntry struct {
+	NewState uint16
+	Flags    uint16
+	Args     [2]uint16
+}
+
+// viewAATStateTable projects an extended state table at the start of b, with
+// entries of argCount 16-bit arguments.
+func viewAATStateTable(b binarySegm, argCount int) (*AATStateTable, error) {
+	if len(b) < 16 {
+		return nil, errFontFormat("AAT state table header out of bounds")
+	}
+	st := &AATStateTable{raw: b, classCount: int(b.U32(0)), entrySize: 4 + 2*argCount}
+	classOffset, stateOffset, entryOffset := int(b.U32(4)), int(b.U32(8)), int(b.U32(12))
+	if stateOffset > len(b) || entryOffset > len(b) || st.classCount < 4 {
+		return nil, errFontFormat(fmt.Sprintf("AAT state table with %d classes out of bounds", st.classCount))
+	}
+	var ok bool
+	if st.classes, ok = viewAATLookup(b, classOffset, 2); !ok {
+		return nil, errFontFormat("AAT state table class lookup out of bounds")
+	}
+	st.states, st.entries = b[stateOffset:], b[entryOffset:]
+	return st, nil
+}
+
+// ClassCount returns the number of glyph classes of the state table, including
+// the 4 predefined classes.
+func (st *AATStateTable) ClassCount() int {
+	if st == nil {
+		return 0
+	}
+	return st.classCount
+}
+
+// Class returns the class of glyph g.
+func (st *AATStateTable) Class(g GlyphIndex) uint16 {
+	if st == nil {
+		return AATClassOutOfBounds
+	}
+	if g == 0xffff {
+		return AATClassDeletedGlyph
+	}
+	if c, ok := st.classes.Lookup(g); ok {
+		return uint16(c)
+	}
+	return AATClassOutOfBounds
+}
+
+// Entry returns the entry for a state and a glyph class.
+func (st *AATStateTable) Entry(state, class uint16) (AATStateEntry, bool) {
+	if st == nil || int(class) >= st.classCount {
+		return AATStateEntry{}, false
+	}
+	at := 2 * (int(state)*st.classCount + int(class))
+	if at+2 > len(st.states) {
+		return AATStateEntry{}, false
+	}
+	at = int(st.states.U16(at)) * st.entrySize
+	if at+st.entrySize > len(st.entries) {
+		return AATStateEntry{}, false
+	}
+	e := AATStateEntry{NewState: st.entries.U16(at), Flags: st.entries.U16(at + 2)}
+	for i := 0; 4+2*i < st.entrySize; i++ {
+		e.Args[i] = st.entries.U16(at + 4 + 2*i)
+	}
+	return e, true
+}
+
+// aatU16 reads the 16-bit value with index i of an array located at offset of b.
+func aatU16(b binarySegm, offset, i int) (uint16, bool) {
+	at := offset + 2*i
+	if offset <= 0 || i < 0 || at+2 > len(b) {
+		return 0, false
+	}
+	return b.U16(at), true
+}
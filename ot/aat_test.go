@@ -0,0 +1,229 @@
+package ot
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func aatU16s(vs ...int) []byte {
+	var b []byte
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint16(b, uint16(v))
+	}
+	return b
+}
+
+func aatU32s(vs ...int) []byte {
+	var b []byte
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint32(b, uint32(v))
+	}
+	return b
+}
+
+func TestAATLookup(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		data     []byte
+		expected map[GlyphIndex]uint32 // glyphs not covered are missing
+	}{
+		{"simple array", aatU16s(0, 0, 10, 20, 30, 40, 50, 60, 70, 80, 90),
+			map[GlyphIndex]uint32{0: 0, 1: 10, 2: 20, 3: 30, 4: 40, 5: 50, 6: 60, 7: 70, 8: 80, 9: 90}},
+		{"segment single", aatU16s(2, 6, 3, 0, 0, 0, 6, 5, 50, 9, 9, 90, 0xffff, 0xffff, 0),
+			map[GlyphIndex]uint32{5: 50, 6: 50, 9: 90}},
+		{"segment array", aatU16s(4, 6, 2, 0, 0, 0, 6, 5, 24, 9, 9, 28, 50, 60, 90),
+			map[GlyphIndex]uint32{5: 50, 6: 60, 9: 90}},
+		{"single table", aatU16s(6, 4, 2, 0, 0, 0, 5, 50, 9, 90),
+			map[GlyphIndex]uint32{5: 50, 9: 90}},
+		{"trimmed array", aatU16s(8, 5, 2, 50, 60),
+			map[GlyphIndex]uint32{5: 50, 6: 60}},
+		{"extended trimmed array", append(aatU16s(10, 1, 5, 2), 50, 60),
+			map[GlyphIndex]uint32{5: 50, 6: 60}},
+	} {
+		lookup, ok := viewAATLookup(append([]byte{0, 0}, c.data...), 2, 2)
+		if !ok {
+			t.Fatalf("%s: cannot view lookup table", c.name)
+		}
+		for g := range GlyphIndex(12) {
+			v, ok := lookup.Lookup(g)
+			if expected, covered := c.expected[g]; ok != covered || v != expected {
+				t.Errorf("%s: expected (%d, %v) for glyph %d, have (%d, %v)", c.name, expected, covered, g, v, ok)
+			}
+		}
+	}
+}
+
+// buildMorx creates a 'morx' table with a single chain, containing a
+// noncontextual, a rearrangement and a contextual subtable.
+func buildMorx() []byte {
+	subtable := func(typ int, data []byte) []byte {
+		return append(aatU32s(12+len(data), 0x20000000|typ, 1), data...)
+	}
+	noncontextual := subtable(4, aatU16s(8, 5, 1, 7)) // glyph 5 → 7
+	// 5 classes (glyph 3 is of class 4) and 2 states; in both states, class 4
+	// leads to entry 1
+	stx := aatU32s(5, 16, 32, 52)
+	stx = append(stx, aatU16s(6, 4, 1, 0, 0, 0, 3, 4)...)
+	stx = append(stx, aatU16s(0, 0, 0, 0, 1, 0, 0, 0, 0, 1)...)
+	stx = append(stx, aatU16s(0, 0, 1, 0x8000)...)
+	rearrangement := subtable(0, stx)
+	contextual := subtable(1, append(aatU32s(4, 24, 24, 24, 20, 4), aatU16s(8, 5, 1, 8)...))
+	var chain []byte
+	chain = append(chain, aatU32s(1, 0, 1, 3)...) // length is patched below
+	chain = append(chain, aatU16s(1, 0)...)
+	chain = append(chain, aatU32s(1, -1)...)
+	chain = append(chain, noncontextual...)
+	chain = append(chain, rearrangement...)
+	chain = append(chain, contextual...)
+	binary.BigEndian.PutUint32(chain[4:], uint32(len(chain)))
+	return append(append(aatU16s(2, 0), aatU32s(1)...), chain...)
+}
+
+func TestParseMorx(t *testing.T) {
+	b := buildMorx()
+	ec := &errorCollector{}
+	table, err := parseMorx(T("morx"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	morx := table.Self().AsMorx()
+	if morx.Error() != nil || len(ec.errors) != 0 {
+		t.Fatalf("unexpected errors %v", ec.errors)
+	}
+	chains := morx.Chains()
+	if len(chains) != 1 || len(chains[0].Features) != 1 || len(chains[0].Subtables) != 3 {
+		t.Fatalf("expected 1 chain with 1 feature and 3 subtables, have %+v", chains)
+	}
+	if f := chains[0].Features[0]; f.Type != 1 || f.EnableFlags != 1 || f.DisableFlags != 0xffffffff {
+		t.Errorf("unexpected feature %+v", f)
+	}
+	subs := chains[0].Subtables
+	if subs[0].Type != MorxNoncontextual || subs[0].Coverage != MorxAnyOrientation || subs[0].SubFeatureFlags != 1 {
+		t.Errorf("unexpected subtable header %+v", subs[0])
+	}
+	if lookup, ok := subs[0].NoncontextualLookup(); !ok {
+		t.Errorf("expected noncontextual lookup")
+	} else if g, ok := lookup.Lookup(5); !ok || g != 7 {
+		t.Errorf("expected glyph 5 to be substituted by 7, have %d", g)
+	}
+	st, err := subs[1].StateTable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.ClassCount() != 5 || st.Class(3) != 4 || st.Class(8) != AATClassOutOfBounds || st.Class(0xffff) != AATClassDeletedGlyph {
+		t.Errorf("unexpected glyph classes of state table")
+	}
+	if e, ok := st.Entry(1, 4); !ok || e.NewState != 1 || e.Flags != 0x8000 {
+		t.Errorf("unexpected entry %+v for state 1 and class 4", e)
+	}
+	if e, ok := st.Entry(0, 3); !ok || e.NewState != 0 || e.Flags != 0 {
+		t.Errorf("unexpected entry %+v for state 0 and class 3", e)
+	}
+	if _, ok := st.Entry(0, 5); ok {
+		t.Errorf("expected no entry for class beyond class count")
+	}
+	if g, ok := subs[2].ContextualSubstitution(0, 5); !ok || g != 8 {
+		t.Errorf("expected contextual substitution of glyph 5 by 8, have %d", g)
+	}
+	if _, err := subs[0].StateTable(); err == nil {
+		t.Errorf("expected noncontextual subtable to have no state table")
+	}
+	b[7] = 2 // claim a second chain
+	table, _ = parseMorx(T("morx"), b, 0, uint32(len(b)), ec)
+	if table.Self().AsMorx().Error() == nil || len(ec.errors) == 0 {
+		t.Errorf("expected error for chain out of bounds")
+	}
+}
+
+func TestParseKerx(t *testing.T) {
+	subtable := func(format int, data []byte) []byte {
+		return append(aatU32s(12+len(data), format, 0), data...)
+	}
+	format0 := subtable(0, append(aatU32s(2, 12, 1, 0), aatU16s(1, 2, -50, 3, 4, 30)...))
+	// glyph 1 is in row 1 (of 2 columns), glyph 2 in column 1
+	format2 := subtable(2, append(aatU32s(4, 28, 36, 44), aatU16s(8, 1, 1, 2, 8, 2, 1, 1, 0, 0, 0, -70)...))
+	b := append(aatU16s(2, 0), aatU32s(2)...)
+	b = append(append(b, format0...), format2...)
+	ec := &errorCollector{}
+	table, err := parseKerx(T("kerx"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kerx := table.Self().AsKerx()
+	subs := kerx.Subtables()
+	if kerx.Error() != nil || len(subs) != 2 || subs[0].Format != 0 || subs[1].Format != 2 {
+		t.Fatalf("unexpected subtables %+v, error %v", subs, kerx.Error())
+	}
+	for _, c := range []struct {
+		sub         int
+		left, right GlyphIndex
+		value       int
+		ok          bool
+	}{
+		{0, 1, 2, -50, true}, {0, 3, 4, 30, true}, {0, 2, 1, 0, false},
+		{1, 1, 2, -70, true}, {1, 1, 3, 0, true}, {1, 4, 2, 0, true},
+	} {
+		if v, ok := subs[c.sub].Kern(c.left, c.right); v != c.value || ok != c.ok {
+			t.Errorf("subtable %d: expected (%d, %v) for pair (%d, %d), have (%d, %v)",
+				c.sub, c.value, c.ok, c.left, c.right, v, ok)
+		}
+	}
+	if _, err := subs[0].StateTable(); err == nil {
+		t.Errorf("expected format 0 subtable to have no state table")
+	}
+}
+
+func TestParseOpbd(t *testing.T) {
+	data := append(aatU32s(0x00010000), aatU16s(0)...)
+	data = append(data, aatU16s(8, 5, 2, 16, 24)...) // lookup: glyphs 5 and 6
+	data = append(data, aatU16s(40, 0, 0, 0)...)
+	data = append(data, aatU16s(0, 0, 0xffe0, 0)...)
+	ec := &errorCollector{}
+	table, err := parseOpbd(T("opbd"), data, 0, uint32(len(data)), ec)
+	if err != nil {
+		t.Fatalf("cannot parse opbd: %v", err)
+	}
+	opbd := table.Self().AsOpbd()
+	if opbd == nil || opbd.Error() != nil || opbd.Format != OpbdDistances {
+		t.Fatalf("unexpected opbd table %+v", opbd)
+	}
+	if b, ok := opbd.Bounds(5); !ok || b != (OpticalBounds{Left: 40}) {
+		t.Errorf("unexpected optical bounds for glyph 5: %+v, %v", b, ok)
+	}
+	if b, ok := opbd.Bounds(6); !ok || b != (OpticalBounds{Right: -32}) {
+		t.Errorf("unexpected optical bounds for glyph 6: %+v, %v", b, ok)
+	}
+	if _, ok := opbd.Bounds(7); ok {
+		t.Errorf("did not expect optical bounds for glyph 7")
+	}
+	data[5] = 7 // unknown format
+	table, err = parseOpbd(T("opbd"), data, 0, uint32(len(data)), ec)
+	if err != nil || table.Self().AsOpbd().Error() == nil {
+		t.Errorf("expected opbd of unknown format to carry an error")
+	}
+}
+
+func TestParseLcar(t *testing.T) {
+	data := append(aatU32s(0x00010000), aatU16s(0)...)
+	data = append(data, aatU16s(8, 5, 2, 16, 22)...) // lookup: glyphs 5 and 6
+	data = append(data, aatU16s(2, 300, 600)...)
+	data = append(data, aatU16s(9, 500)...) // count out of bounds
+	ec := &errorCollector{}
+	table, err := parseLcar(T("lcar"), data, 0, uint32(len(data)), ec)
+	if err != nil {
+		t.Fatalf("cannot parse lcar: %v", err)
+	}
+	lcar := table.Self().AsLcar()
+	if lcar == nil || lcar.Error() != nil || lcar.Format != LcarDistances {
+		t.Fatalf("unexpected lcar table %+v", lcar)
+	}
+	if carets := lcar.Carets(5); len(carets) != 2 || carets[0] != 300 || carets[1] != 600 {
+		t.Errorf("unexpected carets for glyph 5: %v", carets)
+	}
+	if carets := lcar.Carets(6); carets != nil {
+		t.Errorf("did not expect carets for damaged glyph 6, have %v", carets)
+	}
+	if carets := lcar.Carets(4); carets != nil {
+		t.Errorf("did not expect carets for glyph 4, have %v", carets)
+	}
+}
@@ -0,0 +1,73 @@
+package ot
+
+// Rect is a rectangle in font design units, e.g. the bounding box of a glyph.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY FWord
+}
+
+// Dx returns the width of r.
+func (r Rect) Dx() int {
+	return int(r.MaxX) - int(r.MinX)
+}
+
+// Dy returns the height of r.
+func (r Rect) Dy() int {
+	return int(r.MaxY) - int(r.MinY)
+}
+
+// glyphBox is a cached bounding box; ok is false for glyphs without outline.
+type glyphBox struct {
+	rect Rect
+	ok   bool
+}
+
+// GlyphBBox returns the bounding box of the outline of glyph gid. For glyphs
+// without an outline, e.g. spaces, for glyph indices out of range and for
+// fonts without TrueType outlines, false is returned. Fonts with CFF outlines
+// are not supported yet.
+//
+// Bounding boxes of all glyphs are read on the first call and then cached with
+// the font.
+func (otf *Font) GlyphBBox(gid GlyphIndex) (Rect, bool) {
+	if otf == nil {
+		return Rect{}, false
+	}
+	otf.bboxOnce.Do(func() {
+		otf.bboxes = otf.readGlyphBoxes()
+	})
+	if int(gid) >= len(otf.bboxes) {
+		return Rect{}, false
+	}
+	return otf.bboxes[gid].rect, otf.bboxes[gid].ok
+}
+
+// readGlyphBoxes reads the bounding boxes from the glyph headers of table
+// 'glyf'. Composite glyphs record the bounding box of their components in
+// their header as well.
+func (otf *Font) readGlyphBoxes() []glyphBox {
+	glyf := otf.Table(T("glyf"))
+	loca, ok := TableOf[*LocaTable](otf)
+	if glyf == nil || !ok || loca.locCnt == 0 {
+		return nil
+	}
+	b := binarySegm(glyf.Binary())
+	boxes := make([]glyphBox, loca.locCnt-1)
+	for gid := range boxes {
+		// glyphs without outline have no data in table glyf
+		loc, next := loca.IndexToLocation(GlyphIndex(gid)), loca.IndexToLocation(GlyphIndex(gid+1))
+		if next < loc+10 || int(loc)+10 > len(b) {
+			continue
+		}
+		h := b[loc:]
+		boxes[gid] = glyphBox{
+			rect: Rect{
+				MinX: FWord(h.U16(2)),
+				MinY: FWord(h.U16(4)),
+				MaxX: FWord(h.U16(6)),
+				MaxY: FWord(h.U16(8)),
+			},
+			ok: true,
+		}
+	}
+	return boxes
+}
@@ -0,0 +1,22 @@
+package ot
+
+import "testing"
+
+func TestGlyphBBox(t *testing.T) {
+	otf := loadCalibri(t)
+	gidA := otf.CMap.GlyphIndexMap.Lookup('A')
+	r, ok := otf.GlyphBBox(gidA)
+	_, lsb, _ := otf.HorizontalMetrics().HMetrics(gidA)
+	if !ok || r.Dx() <= 0 || r.Dy() <= 0 || int16(r.MinX) != lsb {
+		t.Errorf("expected bounding box of 'A' to start at its left side bearing %d, have %+v (%v)", lsb, r, ok)
+	}
+	if r2, _ := otf.GlyphBBox(gidA); r2 != r {
+		t.Errorf("expected cached bounding box %+v, have %+v", r, r2)
+	}
+	if r, ok := otf.GlyphBBox(otf.CMap.GlyphIndexMap.Lookup(' ')); ok {
+		t.Errorf("did not expect a bounding box for space, have %+v", r)
+	}
+	if _, ok := otf.GlyphBBox(0xffff); ok {
+		t.Errorf("did not expect a bounding box for glyph index out of range")
+	}
+}
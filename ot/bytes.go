@@ -133,6 +133,15 @@ func (b binarySegm) u32(i int) (uint32, error) {
 	return u32(buf), nil
 }
 
+// u64 returns the uint64 in b at the relative offset i.
+func (b binarySegm) u64(i int) (uint64, error) {
+	buf, err := b.view(i, 8)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(u32(buf))<<32 | uint64(u32(buf[4:])), nil
+}
+
 // --- Ranges of glyphs ------------------------------------------------------
 
 // GlyphRange is a type frequently used by sub-tables of layout tables (GPOS and GSUB).
@@ -148,15 +157,43 @@ type glyphRangeArray struct {
 	count    int // number of glyph keys
 	data     binarySegm
 	byteSize int
+	sorted   bool // keys are in ascending order, as required by the spec
+}
+
+func newGlyphRangeArray(count int, data binarySegm, byteSize int) *glyphRangeArray {
+	r := &glyphRangeArray{count: count, data: data, byteSize: byteSize}
+	r.sorted = count*2 <= len(data)
+	for i := 1; i < count && r.sorted; i++ {
+		r.sorted = data.U16(2*i-2) < data.U16(2*i)
+	}
+	return r
 }
 
 // glyphRangeArrays have entries stored as a block of consecutive keys.
 // glyphRangeArrays return the index of the key in the range table.
 // 0 is a valid return value.
+//
+// Keys are searched by binary search, unless the keys of a malformed table are
+// not sorted.
 func (r *glyphRangeArray) Match(g GlyphIndex) (int, bool) {
 	if r.count <= 0 {
 		return 0, false
 	}
+	if r.sorted {
+		lo, hi := 0, r.count
+		for lo < hi {
+			m := int(uint(lo+hi) >> 1)
+			switch k := GlyphIndex(r.data.U16(2 * m)); {
+			case k < g:
+				lo = m + 1
+			case k > g:
+				hi = m
+			default:
+				return m, true
+			}
+		}
+		return 0, false
+	}
 	for i := 0; i < r.count; i++ {
 		k, err := r.data.u16(i * 2)
 		if err != nil {
@@ -178,6 +215,16 @@ func (r *glyphRangeArray) ByteSize() int {
 	return r.byteSize
 }
 
+// all yields the glyphs of the array together with their indices, in index order.
+func (r *glyphRangeArray) all(yield func(GlyphIndex, int) bool) {
+	for i := 0; i < r.count; i++ {
+		k, err := r.data.u16(i * 2)
+		if err != nil || !yield(GlyphIndex(k), i) {
+			return
+		}
+	}
+}
+
 // Type    | Name               |Description
 // --------+--------------------+--------------------------------------------
 // uint16  | startGlyphID       | First glyph ID in the range.
@@ -187,18 +234,67 @@ type glyphRangeRecords struct {
 	count    int // number of range records
 	data     binarySegm
 	byteSize int
+	sorted   bool // records are disjoint and in ascending order, as required by the spec
+}
+
+func newGlyphRangeRecords(count int, data binarySegm, byteSize int) *glyphRangeRecords {
+	return &glyphRangeRecords{count: count, data: data, byteSize: byteSize,
+		sorted: rangeRecordsSorted(data, count)}
+}
+
+// rangeRecordsSorted reports whether n range records of 6 bytes, starting with
+// a start and an end glyph, are disjoint and sorted in ascending order.
+func rangeRecordsSorted(data binarySegm, n int) bool {
+	if n*6 > len(data) {
+		return false
+	}
+	for i := range n {
+		from, to := data.U16(6*i), data.U16(6*i+2)
+		if from > to || i > 0 && from <= data.U16(6*i-4) {
+			return false
+		}
+	}
+	return true
+}
+
+// searchRangeRecords returns the index of the range record of n sorted range
+// records of 6 bytes, starting with a start and an end glyph, which contains
+// glyph g.
+func searchRangeRecords(data binarySegm, n int, g GlyphIndex) (int, bool) {
+	lo, hi := 0, n
+	for lo < hi {
+		m := int(uint(lo+hi) >> 1)
+		switch {
+		case g < GlyphIndex(data.U16(6*m)):
+			hi = m
+		case g > GlyphIndex(data.U16(6*m+2)):
+			lo = m + 1
+		default:
+			return m, true
+		}
+	}
+	return 0, false
 }
 
 // glyphRangeRecords have entries stored as range records.
 // glyphRangeRecords return the index of the key in the range table.
 // 0 is a valid return value.
+//
+// Records are searched by binary search, unless the records of a malformed
+// table are not sorted.
 func (r *glyphRangeRecords) Match(g GlyphIndex) (int, bool) {
-	tracer().Debugf("glyph range lookup of glyph ID %d", g)
 	if r.count <= 0 {
 		return 0, false
 	}
+	if r.sorted {
+		i, ok := searchRangeRecords(r.data, r.count, g)
+		if !ok {
+			return 0, false
+		}
+		from, index := r.data.U16(6*i), r.data.U16(6*i+4)
+		return int(index + uint16(g-GlyphIndex(from))), true
+	}
 	record := rangeRecord{}
-	tracer().Debugf("range of %d records", r.count)
 	for i := range r.count {
 		k, err := r.data.u16(i * (2 + 2 + 2))
 		if err != nil {
@@ -209,7 +305,6 @@ func (r *glyphRangeRecords) Match(g GlyphIndex) (int, bool) {
 		record.to = GlyphIndex(k)
 		k, _ = r.data.u16(i*(2+2+2) + 4)
 		record.index = k
-		tracer().Debugf("from %d to %d => %d...", record.from, record.to, record.index)
 		if record.from <= g && g <= record.to {
 			return int(record.index + uint16(g-record.from)), true
 		}
@@ -221,6 +316,22 @@ func (r *glyphRangeRecords) ByteSize() int {
 	return r.byteSize
 }
 
+// all yields the glyphs of all range records together with their indices, in
+// record order.
+func (r *glyphRangeRecords) all(yield func(GlyphIndex, int) bool) {
+	for i := range r.count {
+		if (i+1)*6 > len(r.data) {
+			return
+		}
+		from, to, index := r.data.U16(i*6), r.data.U16(i*6+2), int(r.data.U16(i*6+4))
+		for g := int(from); g <= int(to); g++ {
+			if !yield(GlyphIndex(g), index+g-int(from)) {
+				return
+			}
+		}
+	}
+}
+
 // --- Link ------------------------------------------------------------------
 
 // navLink is a type to represent an offset jump from one segment to another.
@@ -280,14 +391,16 @@ func (l16 link16) Base() binarySegm {
 }
 
 func (l16 link16) jump() binarySegm {
-	tracer().Debugf("jump to %s", l16.target)
+	if traceDebug() {
+		tracer().Debugf("jump to %s", l16.target)
+	}
 	if l16.err != nil {
 		return binarySegm{}
 	}
-	if l16.offset > uint16(len(l16.base)) {
-		tracer().Debugf("base has size %d", len(l16.base))
-		tracer().Debugf("link to %d", l16.offset)
-		tracer().Debugf("offset16 location out of table bounds")
+	if int(l16.offset) > len(l16.base) {
+		if traceDebug() {
+			tracer().Debugf("offset16 location %d out of table bounds (size %d)", l16.offset, len(l16.base))
+		}
 		return binarySegm{}
 	}
 	return l16.base[l16.offset:]
@@ -332,14 +445,16 @@ func (l32 link32) Base() binarySegm {
 }
 
 func (l32 link32) jump() binarySegm {
-	tracer().Debugf("jump to %s", l32.target)
+	if traceDebug() {
+		tracer().Debugf("jump to %s", l32.target)
+	}
 	if l32.err != nil {
 		return binarySegm{}
 	}
 	if l32.offset > uint32(len(l32.base)) {
-		tracer().Debugf("base has size %d", len(l32.base))
-		tracer().Debugf("link to %d", l32.offset)
-		tracer().Debugf("offset32 location out of table bounds")
+		if traceDebug() {
+			tracer().Debugf("offset32 location %d out of table bounds (size %d)", l32.offset, len(l32.base))
+		}
 		return binarySegm{}
 	}
 	return l32.base[l32.offset:]
@@ -426,7 +541,9 @@ func parseArray16(b binarySegm, offset int, name, target string) (array, error)
 
 func viewArray(b binarySegm, recordSize int) array {
 	N := b.Size() / recordSize
-	tracer().Debugf("view array[%d](%d)", N, recordSize)
+	if traceDebug() {
+		tracer().Debugf("view array[%d](%d)", N, recordSize)
+	}
 	return array{
 		recordSize: recordSize,
 		length:     N,
@@ -0,0 +1,20 @@
+package ot
+
+import "testing"
+
+func TestLink16IntoLargeSegment(t *testing.T) {
+	// a subtable larger than 64K, e.g. a kerning subtable with a class
+	// definition at an offset beyond the size of the subtable modulo 64K
+	base := make(binarySegm, 0x10000+100)
+	base[5000] = 42
+	b := make(binarySegm, 2)
+	putU16(b, 0, 5000)
+	link, err := parseLink16(b, 0, base, "ClassDef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := link.jump()
+	if len(target) != len(base)-5000 || target[0] != 42 {
+		t.Errorf("expected link to point to offset 5000 of large segment, have segment of size %d", len(target))
+	}
+}
@@ -0,0 +1,144 @@
+package ot
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"unicode/utf16"
+)
+
+// CacheKey identifies a font for caches of layout or shaping results which are
+// shared between processes, e.g. on disk. In contrast to UniqueID, which is
+// meant for caches within a process, a CacheKey includes a digest of the font's
+// content and the font's self-reported version, which is helpful when
+// inspecting cache entries.
+//
+// Two fonts have equal cache keys if they consist of identical tables. This
+// holds for a font in a file of its own as well as for the same font as a
+// member of a collection, or for a font rebuilt from its tables.
+type CacheKey struct {
+	Revision uint32            // head.fontRevision, 16.16 fixed-point
+	Version  string            // version string of table 'name' (name ID 5), may be empty
+	Digest   [sha256.Size]byte // SHA-256 digest of the font's tables
+}
+
+// String returns k in a form suitable as a key for external caches.
+func (k CacheKey) String() string {
+	return fmt.Sprintf("%08x:%q:%x", k.Revision, k.Version, k.Digest)
+}
+
+// cacheDigestKey identifies the content digest of a font, see Derived.
+type cacheDigestKey struct{}
+
+// CacheKey returns a key identifying otf for caches which outlive a process.
+// The digest of the font's tables is computed on the first call and cached
+// with the font.
+func (otf *Font) CacheKey() CacheKey {
+	if otf == nil {
+		return CacheKey{}
+	}
+	k := CacheKey{Version: otf.nameString(nameIDVersion)}
+	if otf.Head != nil {
+		k.Revision = otf.Head.FontRevision
+	}
+	k.Digest = otf.Derived(cacheDigestKey{}, func() any {
+		return otf.tablesDigest()
+	}).([sha256.Size]byte)
+	return k
+}
+
+// tablesDigest computes a SHA-256 digest over the tags and contents of the
+// tables of otf, in directory order, i.e. in tag order.
+// head.checkSumAdjustment depends on the layout of the font file and is taken
+// as 0.
+func (otf *Font) tablesDigest() [sha256.Size]byte {
+	h := sha256.New()
+	var rec [8]byte
+	for _, tag := range otf.tableOrder {
+		data := otf.Table(tag).Binary()
+		binary.BigEndian.PutUint32(rec[:4], uint32(tag))
+		binary.BigEndian.PutUint32(rec[4:], uint32(len(data)))
+		h.Write(rec[:])
+		if tag == T("head") && len(data) >= 12 {
+			h.Write(data[:8])
+			h.Write([]byte{0, 0, 0, 0})
+			data = data[12:]
+		}
+		h.Write(data)
+	}
+	var digest [sha256.Size]byte
+	h.Sum(digest[:0])
+	return digest
+}
+
+// nameIDVersion is the name ID of the version string in table 'name'.
+const nameIDVersion = 5
+
+// nameString returns the string of table 'name' for name ID id, or "" if the
+// font has no such string in a supported encoding. Windows Unicode strings
+// for US English are preferred over other Unicode strings, which are
+// preferred over Macintosh Roman strings, of which only ASCII is decoded.
+func (otf *Font) nameString(id uint16) string {
+	table := otf.Table(T("name"))
+	if table == nil {
+		return ""
+	}
+	b := binarySegm(table.Binary())
+	count, err := b.u16(2)
+	if err != nil {
+		return ""
+	}
+	storage, _ := b.u16(4)
+	var best string
+	bestRank := 0
+	for i := range int(count) {
+		rec := 6 + i*12
+		if rec+12 > len(b) {
+			break
+		}
+		platform, encoding := b.U16(rec), b.U16(rec+2)
+		language, nameID := b.U16(rec+4), b.U16(rec+6)
+		length, offset := int(b.U16(rec+8)), int(b.U16(rec+10))
+		start := int(storage) + offset
+		if nameID != id || start+length > len(b) {
+			continue
+		}
+		var rank int
+		switch {
+		case platform == 3 && (encoding == 1 || encoding == 10) && language == 0x409:
+			rank = 4
+		case platform == 3 && (encoding == 1 || encoding == 10):
+			rank = 3
+		case platform == 0:
+			rank = 2
+		case platform == 1 && encoding == 0:
+			rank = 1
+		}
+		if rank <= bestRank {
+			continue
+		}
+		if s := decodeNameString(b[start:start+length], rank > 1); s != "" {
+			best, bestRank = s, rank
+		}
+	}
+	return best
+}
+
+// decodeNameString decodes a name string, either as UTF-16BE or as ASCII, with
+// other characters replaced by U+FFFD.
+func decodeNameString(b []byte, utf16BE bool) string {
+	if !utf16BE {
+		r := make([]rune, len(b))
+		for i, c := range b {
+			if r[i] = rune(c); c >= 0x80 {
+				r[i] = '\uFFFD'
+			}
+		}
+		return string(r)
+	}
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.BigEndian.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(u))
+}
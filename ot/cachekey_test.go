@@ -0,0 +1,69 @@
+package ot
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCacheKey(t *testing.T) {
+	calibri := loadCalibri(t)
+	key := calibri.CacheKey()
+	if key.Revision != u32(calibri.Head.Binary()[4:8]) || key.Revision == 0 {
+		t.Errorf("expected revision from head, have %08x", key.Revision)
+	}
+	if !strings.HasPrefix(key.Version, "Version ") {
+		t.Errorf("expected version string from name, have %q", key.Version)
+	}
+	if key != calibri.CacheKey() {
+		t.Errorf("expected cache key to be stable")
+	}
+	rebuilt, err := calibri.Rebuild(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otf, err := Parse(rebuilt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otf.CacheKey() != key {
+		t.Errorf("expected a font rebuilt from its tables to keep its cache key")
+	}
+	post := append([]byte(nil), calibri.Table(T("post")).Binary()...)
+	post[len(post)-1] ^= 1
+	modified, err := calibri.Rebuild(map[Tag][]byte{T("post"): post})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if otf, err = Parse(modified); err != nil {
+		t.Fatal(err)
+	}
+	if k := otf.CacheKey(); k.Digest == key.Digest || k.Revision != key.Revision || k.Version != key.Version {
+		t.Errorf("expected modified table to change the digest only, have %v", k)
+	}
+	if (*Font)(nil).CacheKey() != (CacheKey{}) {
+		t.Errorf("expected zero cache key for nil font")
+	}
+}
+
+func TestCacheKeyOfCollectionMember(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/fonts/Go-Regular.otf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := Parse(raw, IsTestfont)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tables := map[Tag][]byte{}
+	for _, tag := range single.TableTags() {
+		tables[tag] = single.Table(tag).Binary()
+	}
+	fonts, err := ParseCollection(buildCollection(single.Header.FontType, []map[Tag][]byte{tables}), IsTestfont)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fonts[0].CacheKey() != single.CacheKey() {
+		t.Errorf("expected collection member to have the cache key of the single font")
+	}
+}
@@ -0,0 +1,90 @@
+package ot
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ChecksumMismatch describes a checksum of a font which does not match the font's
+// data.
+type ChecksumMismatch struct {
+	Table    Tag    // table with a wrong checksum; for the font checksum adjustment, 'head'
+	Font     bool   // mismatch of head.checkSumAdjustment, i.e., of the whole font
+	Stored   uint32 // checksum stored in the font
+	Computed uint32 // checksum calculated from the font data
+}
+
+func (m ChecksumMismatch) String() string {
+	what := "table checksum"
+	if m.Font {
+		what = "font checksum adjustment"
+	}
+	return fmt.Sprintf("%s mismatch for %s: stored %#08x, computed %#08x",
+		what, m.Table, m.Stored, m.Computed)
+}
+
+// checkSumAdjustmentMagic is the constant from which head.checkSumAdjustment
+// is calculated.
+const checkSumAdjustmentMagic = 0xB1B0AFBA
+
+// VerifyChecksums checks the checksums of all tables, as stored in the table
+// directory, and the checksum adjustment of table 'head', which covers the whole
+// font. It returns a list of mismatches, sorted by table tag, with the font
+// checksum adjustment last. Checksum mismatches usually do not affect the
+// usability of a font, but hint at fonts which have been modified by
+// tools not aware of OpenType.
+//
+// Checksums are not verified during parsing, unless option VerifyChecksums is
+// given to Parse, in which case mismatches are reported as errors of severity
+// SeverityMinor.
+func (otf *Font) VerifyChecksums() []ChecksumMismatch {
+	if otf == nil {
+		return nil
+	}
+	var mismatches []ChecksumMismatch
+	tags := make([]Tag, 0, len(otf.checksums))
+	for tag := range otf.checksums {
+		tags = append(tags, tag)
+	}
+	slices.Sort(tags)
+	for _, tag := range tags {
+		t := otf.tables[tag]
+		if t == nil {
+			continue
+		}
+		sum := tableChecksum(t.Binary())
+		if tag == T("head") && len(t.Binary()) >= 12 {
+			sum -= u32(t.Binary()[8:12]) // checkSumAdjustment is taken as 0
+		}
+		if stored := otf.checksums[tag]; stored != sum {
+			mismatches = append(mismatches, ChecksumMismatch{Table: tag, Stored: stored, Computed: sum})
+		}
+	}
+	// the checksum adjustment is not verified for members of font collections
+	if head := otf.tables[T("head")]; head != nil && len(head.Binary()) >= 12 && otf.dirOffset == 0 {
+		stored := u32(head.Binary()[8:12])
+		computed := uint32(checkSumAdjustmentMagic) - (tableChecksum(otf.raw) - stored)
+		if stored != computed {
+			mismatches = append(mismatches, ChecksumMismatch{
+				Table: T("head"), Font: true, Stored: stored, Computed: computed,
+			})
+		}
+	}
+	return mismatches
+}
+
+// tableChecksum calculates an OpenType checksum, i.e., the sum of the big-endian
+// uint32 values of b, with b padded to a multiple of 4 bytes with zeros.
+func tableChecksum(b []byte) uint32 {
+	var sum uint32
+	n := len(b) &^ 3
+	for i := 0; i < n; i += 4 {
+		sum += u32(b[i : i+4])
+	}
+	if n < len(b) {
+		var last [4]byte
+		copy(last[:], b[n:])
+		sum += u32(last[:])
+	}
+	return sum
+}
@@ -0,0 +1,67 @@
+package ot
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/schuko/tracing/gotestingadapter"
+)
+
+func TestVerifyChecksums(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	if m := otf.VerifyChecksums(); len(m) != 0 {
+		t.Fatalf("expected Calibri checksums to be valid, have %v", m)
+	}
+	// corrupt one byte of table 'post'
+	data := slices.Clone(otf.Binary())
+	off, _ := otf.Table(T("post")).Extent()
+	data[off+4]++
+	corrupt, err := Parse(data, VerifyChecksums)
+	if err != nil {
+		t.Fatalf("checksum mismatches must not be fatal: %v", err)
+	}
+	m := corrupt.VerifyChecksums()
+	if len(m) != 2 {
+		t.Fatalf("expected 2 mismatches, have %v", m)
+	}
+	if m[0].Table != T("post") || m[0].Font || m[0].Computed != m[0].Stored+1<<24 {
+		t.Errorf("expected table checksum mismatch for 'post', have %v", m[0])
+	}
+	if !m[1].Font || m[1].Table != T("head") {
+		t.Errorf("expected font checksum adjustment mismatch, have %v", m[1])
+	}
+	n := 0
+	for _, e := range corrupt.Errors() {
+		if e.Section == "Checksum" {
+			n++
+			if e.Severity != SeverityMinor {
+				t.Errorf("expected checksum errors to be minor, have %v", e)
+			}
+		}
+	}
+	if n != 2 {
+		t.Errorf("expected 2 checksum errors to be reported during parsing, have %d", n)
+	}
+	// without the option, checksums are not verified during parsing
+	unchecked, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range unchecked.Errors() {
+		if e.Section == "Checksum" {
+			t.Errorf("did not expect checksum errors without option VerifyChecksums: %v", e)
+		}
+	}
+}
+
+func TestTableChecksumPadding(t *testing.T) {
+	if sum := tableChecksum([]byte{0, 0, 0, 1, 0xff}); sum != 0xff000001 {
+		t.Errorf("expected padded checksum 0xff000001, have %#x", sum)
+	}
+	if sum := tableChecksum([]byte{0xff, 0xff, 0xff, 0xff, 0, 0, 0, 2}); sum != 1 {
+		t.Errorf("expected checksum to wrap around, have %#x", sum)
+	}
+}
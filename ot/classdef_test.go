@@ -0,0 +1,25 @@
+package ot
+
+import "testing"
+
+func TestClassDefFormat2EndInclusive(t *testing.T) {
+	// format=2, 2 ranges: glyph 5 → class 2, glyphs 10–12 → class 1
+	b := make([]byte, 16)
+	putU16(b, 0, 2)
+	putU16(b, 2, 2)
+	putU16(b, 4, 5)
+	putU16(b, 6, 5)
+	putU16(b, 8, 2)
+	putU16(b, 10, 10)
+	putU16(b, 12, 12)
+	putU16(b, 14, 1)
+	cdef, err := parseClassDefinitions(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for g, clz := range map[GlyphIndex]int{4: 0, 5: 2, 6: 0, 9: 0, 10: 1, 11: 1, 12: 1, 13: 0} {
+		if c := cdef.Lookup(g); c != clz {
+			t.Errorf("expected class %d for glyph %d, have %d", clz, g, c)
+		}
+	}
+}
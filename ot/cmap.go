@@ -1,6 +1,11 @@
 package ot
 
-import "fmt"
+import (
+	"fmt"
+	"iter"
+	"sync"
+	"unicode"
+)
 
 /*
 We replicate some of the code of the Go core team here, available from
@@ -28,6 +33,10 @@ type CMapTable struct {
 	tableBase
 	GlyphIndexMap CMapGlyphIndex
 	NumGlyphs     int // Maximum valid glyph index + 1 (from maxp table)
+	reverseOnce   sync.Once
+	reverse       map[GlyphIndex]rune // lazily built by RuneFor
+	coverageOnce  sync.Once
+	coverage      *unicode.RangeTable // lazily built by CoverageBitmap
 }
 
 func newCMapTable(tag Tag, b binarySegm, offset, size uint32) *CMapTable {
@@ -43,6 +52,175 @@ func newCMapTable(tag Tag, b binarySegm, offset, size uint32) *CMapTable {
 	return t
 }
 
+// GlyphIndexes maps a slice of code-points to glyph indices in one go, storing
+// the glyph for runes[i] in out[i]. Unmapped code-points result in glyph 0.
+// If out is shorter than runes, only the first len(out) runes are mapped.
+// For cmap subtables of format 4 and 12, consecutive code-points falling into
+// the same segment are mapped without searching the segments again.
+func (t *CMapTable) GlyphIndexes(runes []rune, out []GlyphIndex) {
+	if len(out) < len(runes) {
+		runes = runes[:len(out)]
+	}
+	if t == nil || t.GlyphIndexMap == nil {
+		clear(out[:len(runes)])
+		return
+	}
+	switch gim := t.GlyphIndexMap.(type) {
+	case format4GlyphIndex:
+		gim.lookupAll(runes, out)
+	case format12GlyphIndex:
+		gim.lookupAll(runes, out)
+	default:
+		for i, r := range runes {
+			out[i] = gim.Lookup(r)
+		}
+	}
+}
+
+// RuneFor returns a code-point which maps to glyph gid. If more than one code-point
+// maps to gid, the smallest one is returned. The reverse mapping is built on first
+// use, which makes RuneFor suitable for debugging output or for generating
+// ToUnicode maps for PDF files.
+func (t *CMapTable) RuneFor(gid GlyphIndex) (rune, bool) {
+	if t == nil || t.GlyphIndexMap == nil || gid == 0 {
+		return 0, false
+	}
+	t.reverseOnce.Do(func() {
+		t.reverse = make(map[GlyphIndex]rune)
+		for r, g := range cmapMappings(t.GlyphIndexMap) {
+			if _, ok := t.reverse[g]; !ok {
+				t.reverse[g] = r
+			}
+		}
+	})
+	if r, ok := t.reverse[gid]; ok {
+		return r, true
+	}
+	if !isConcreteGlyphIndex(t.GlyphIndexMap) { // cannot enumerate, fall back to slow path
+		r := t.GlyphIndexMap.ReverseLookup(gid)
+		return r, r != 0
+	}
+	return 0, false
+}
+
+// Codepoints iterates over all code-points mapped to a glyph (other than the
+// 'missing character' glyph 0), in ascending order.
+func (t *CMapTable) Codepoints() iter.Seq[rune] {
+	return func(yield func(rune) bool) {
+		if t == nil || t.GlyphIndexMap == nil {
+			return
+		}
+		for r := range cmapMappings(t.GlyphIndexMap) {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// CoverageBitmap returns the set of code-points supported by the font as a
+// Unicode range table, suitable for use with unicode.Is and unicode.In.
+// Font-matching code may use it to check whether a font supports a string without
+// issuing a cmap lookup per rune. The table is built on first use and
+// must not be modified by clients.
+func (t *CMapTable) CoverageBitmap() *unicode.RangeTable {
+	if t == nil {
+		return &unicode.RangeTable{}
+	}
+	t.coverageOnce.Do(func() {
+		t.coverage = buildRangeTable(t.Codepoints())
+	})
+	return t.coverage
+}
+
+// buildRangeTable collects a sequence of ascending code-points into a range table.
+func buildRangeTable(runes iter.Seq[rune]) *unicode.RangeTable {
+	rt := &unicode.RangeTable{}
+	var lo, hi rune = -1, -1
+	flush := func() {
+		if lo < 0 {
+			return
+		}
+		if hi <= 0xffff {
+			rt.R16 = append(rt.R16, unicode.Range16{Lo: uint16(lo), Hi: uint16(hi), Stride: 1})
+			if hi <= unicode.MaxLatin1 {
+				rt.LatinOffset++
+			}
+		} else if lo > 0xffff {
+			rt.R32 = append(rt.R32, unicode.Range32{Lo: uint32(lo), Hi: uint32(hi), Stride: 1})
+		} else { // range crosses the BMP boundary
+			rt.R16 = append(rt.R16, unicode.Range16{Lo: uint16(lo), Hi: 0xffff, Stride: 1})
+			rt.R32 = append(rt.R32, unicode.Range32{Lo: 0x10000, Hi: uint32(hi), Stride: 1})
+		}
+	}
+	for r := range runes {
+		if r == hi+1 && lo >= 0 {
+			hi = r
+			continue
+		}
+		flush()
+		lo, hi = r, r
+	}
+	flush()
+	return rt
+}
+
+// cmapMappings iterates over all code-points of a glyph index map which map to
+// a glyph other than 0, in ascending order of code-points. Glyph index maps not
+// implemented in this package cannot be enumerated and yield nothing.
+func cmapMappings(gim CMapGlyphIndex) iter.Seq2[rune, GlyphIndex] {
+	return func(yield func(rune, GlyphIndex) bool) {
+		switch gim := gim.(type) {
+		case format4GlyphIndex:
+			// for symbol fonts, first enumerate code-points folded into U+F0xx
+			low := uint32(0) // code-points below low have been enumerated
+			if gim.symbol {
+				for ; low <= 0xff; low++ {
+					if g := gim.Lookup(rune(low)); g != 0 && !yield(rune(low), g) {
+						return
+					}
+				}
+			}
+			for _, entry := range gim.entries {
+				if entry.end < entry.start {
+					continue
+				}
+				for c := max(low, uint32(entry.start)); c <= uint32(entry.end); c++ {
+					if c == 0xffff {
+						break
+					}
+					if g := gim.Lookup(rune(c)); g != 0 && !yield(rune(c), g) {
+						return
+					}
+				}
+			}
+		case format12GlyphIndex:
+			for _, entry := range gim.entries {
+				if entry.end < entry.start || entry.end > 0x10ffff {
+					continue
+				}
+				for c := entry.start; c <= entry.end; c++ {
+					g := GlyphIndex(c - entry.start + entry.delta)
+					if gim.numGlyphs > 0 && int(g) >= gim.numGlyphs {
+						break
+					}
+					if g != 0 && !yield(rune(c), g) {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+func isConcreteGlyphIndex(gim CMapGlyphIndex) bool {
+	switch gim.(type) {
+	case format4GlyphIndex, format12GlyphIndex:
+		return true
+	}
+	return false
+}
+
 // platformEncodingWidth returns the number of bytes per character assumed by
 // the given Platform ID and Platform Specific ID.
 //
@@ -65,6 +243,8 @@ func platformEncodingWidth(pid, psid uint16) int {
 		}
 	case 3: // Windows platform
 		switch psid {
+		case 0: // Symbol, selected only if no Unicode subtable is present
+			return 1
 		case 1: // Unicode BMP
 			return 2
 		case 10: // Unicode full
@@ -90,6 +270,7 @@ func platformEncodingWidth(pid, psid uint16) int {
 //
 //	0 (Unicode)  3    4   Unicode BMB
 //	0 (Unicode)  4    12  Unicode full  (10 from FontForge, error)
+//	3 (Win)      0    4   Symbol
 //	3 (Win)      1    4   Unicode BMP
 //	3 (Win)      10   12  Unicode full
 //
@@ -100,6 +281,7 @@ func supportedCmapFormat(format, pid, psid uint16) bool {
 	tracer().Debugf("checking supported cmap format (%d | %d | %d)", pid, psid, format)
 	return (pid == 0 && psid == 3 && format == 4) ||
 		(pid == 0 && psid == 4 && format == 12) ||
+		(pid == 3 && psid == 0 && format == 4) ||
 		(pid == 3 && psid == 1 && format == 4) ||
 		(pid == 3 && psid == 10 && format == 12)
 }
@@ -109,11 +291,17 @@ func makeGlyphIndex(which encodingRecord, tag Tag, offset uint32, ec *errorColle
 	subtable := which.link.jump()
 	switch which.format {
 	case 4:
-		return makeGlyphIndexFormat4(subtable.Bytes(), tag, offset, ec)
+		gim, err := makeGlyphIndexFormat4(subtable.Bytes(), tag, offset, ec)
+		if f4, ok := gim.(format4GlyphIndex); ok && which.platformId == 3 && which.encodingId == 0 {
+			f4.symbol = true
+			gim = f4
+		}
+		return gim, err
 	case 12:
 		return makeGlyphIndexFormat12(subtable.Bytes(), tag, offset, ec)
 	}
-	panic("unreachable") // unsupported formats should have been weeded out beforehand
+	// unsupported formats should have been weeded out beforehand
+	return nil, errUnsupported(fmt.Sprintf("cmap format %d", which.format))
 }
 
 // CMapGlyphIndex represents a CMap table index to receive a glyph index from
@@ -134,9 +322,18 @@ type format4GlyphIndex struct {
 	segCnt    int
 	entries   []cmapEntry16
 	glyphIds  array
-	numGlyphs int // Maximum valid glyph index + 1 (from maxp table)
+	numGlyphs int  // Maximum valid glyph index + 1 (from maxp table)
+	symbol    bool // subtable of a symbol font (platform 3, encoding 0)
 }
 
+// Symbol fonts (platform 3, encoding 0) by convention encode their glyphs in the
+// private use range U+F000…U+F0FF, with the low byte of the code-point being the
+// character code of the font's legacy 8-bit encoding. Text usually refers to
+// these glyphs by the 8-bit codes, e.g. 'A' for the glyph at U+F041. Lookup
+// therefore folds code-points U+0000…U+00FF into the U+F0xx range if they are
+// not mapped directly.
+const symbolFontBase = 0xf000
+
 // Format 4 holds four parallel arrays to describe the segments (one segment for
 // each contiguous range of codes).
 // see https://docs.microsoft.com/en-us/typography/opentype/spec/cmap#format-4-segment-mapping-to-delta-values
@@ -148,80 +345,127 @@ func (f4 format4GlyphIndex) Lookup(r rune) GlyphIndex {
 	if uint32(r) > 0xffff { // format 4 is for BMP code-points only
 		return 0 // return index for 'missing character'
 	}
-	c := uint16(r)
-	N := len(f4.entries)
-	//trace().Debugf("lookup codepoint %d in %d cmap-ranges", r, N)
-	for i, j := 0, N; i < j; {
+	g := f4.lookup(uint16(r))
+	if g == 0 && f4.symbol && r <= 0xff {
+		g = f4.lookup(symbolFontBase | uint16(r))
+	}
+	return g
+}
+
+// lookupAll maps runes to glyphs like Lookup, storing the glyph for runes[i] in
+// out[i]. Runs of text usually stay within a segment for a while, so the segment
+// of the previous code-point is tried first before searching all segments.
+func (f4 format4GlyphIndex) lookupAll(runes []rune, out []GlyphIndex) {
+	h := -1
+	for i, r := range runes {
+		if uint32(r) > 0xffff {
+			out[i] = 0
+			continue
+		}
+		c := uint16(r)
+		if h < 0 || c < f4.entries[h].start || f4.entries[h].end < c {
+			h = f4.segment(c)
+		}
+		var g GlyphIndex
+		if h >= 0 {
+			g = f4.glyph(h, c)
+		}
+		if g == 0 && f4.symbol && r <= 0xff {
+			g = f4.lookup(symbolFontBase | c)
+		}
+		out[i] = g
+	}
+}
+
+func (f4 format4GlyphIndex) lookup(c uint16) GlyphIndex {
+	if h := f4.segment(c); h >= 0 {
+		return f4.glyph(h, c)
+	}
+	return GlyphIndex(0)
+}
+
+// segment returns the index of the segment containing c, or -1.
+func (f4 format4GlyphIndex) segment(c uint16) int {
+	//trace().Debugf("lookup codepoint %d in %d cmap-ranges", r, len(f4.entries))
+	for i, j := 0, len(f4.entries); i < j; {
 		h := i + (j-i)/2 // do a binary search on f4.entries (which may get large)
 		entry := &f4.entries[h]
 		if c < entry.start {
 			j = h
 		} else if entry.end < c {
 			i = h + 1
-		} else if entry.offset == 0 {
-			//tracer().Debugf("direct access of glyph ID as delta = %d", c+entry.delta)
-			gid := GlyphIndex(c + entry.delta)
-			// Validate glyph index
-			if f4.numGlyphs > 0 && int(gid) >= f4.numGlyphs {
-				tracer().Errorf("cmap format4: glyph index %d exceeds numGlyphs %d", gid, f4.numGlyphs)
-				return 0
-			}
-			return gid
 		} else {
-			// The spec describes the calculation the find the link into the glyph ID array
-			// as follows:
-			// “The character code offset from startCode is added to the idRangeOffset value.
-			//  This sum is used as an offset from the current location within idRangeOffset
-			//  itself to index out the correct glyphIdArray value. This obscure indexing
-			//  trick works because glyphIdArray immediately follows idRangeOffset in the
-			//  font file.”
-			// We already sliced the cmap into sub-segments, so this will not work for us
-			// (intentionally–I'm not a big fan of 'obscure' tricks). Instead, we will
-			// calculate a clean index into the glyph ID array. Unfortunately this requires
-			// us to reverse some of the magic pre-calculations in the font—a procedure which
-			// one may consider obscure as well, but that's life…
-			//
-			// First cut off the part off the trailing part of offset which results from
-			// skipping over to the start of the glyph ID array:
-			//
-			// --- for now leave traces in as next bug will surely wait...
-			// eprev := &f4.entries[h-1]
-			// trace().Debugf("segment #%d = { start=%d, end=%d, delta=%d, offset=%d }",
-			// 	h-1, eprev.start, eprev.end, eprev.delta, eprev.offset)
-			// enext := &f4.entries[h+1]
-			// trace().Debugf("segment #%d = { start=%d, end=%d, delta=%d, offset=%d }",
-			// 	h+1, enext.start, enext.end, enext.delta, enext.offset)
-			deltaToEndOfEntries := (N - h) * 2 // 2 = byte size of offset array entry
-			//trace().Debugf("N = %d, N*2 = %d, h = %d, h*2=%d", N, N*2, h, h*2)
-			offset := int(entry.offset) - deltaToEndOfEntries
-			// Now normalize the index into the glyph ID array
-			index := offset / 2 // offset is in bytes, we need an array index
-			index += int(c - entry.start)
-			glyphInx := f4.glyphIds.Get(index).U16(0)
-			// trace().Debugf("segment #%d = { start=%d, end=%d, delta=%d, offset=%d }",
-			// 	h, entry.start, entry.end, entry.delta, entry.offset)
-			// trace().Debugf("skip = %d, offset = %d, rest = %d", deltaToEndOfEntries, offset, index)
-			// trace().Debugf("looking up code-point in segment %d, is %d", h, glyphInx)
-			if glyphInx > 0 {
-				// If the value obtained from the indexing operation is not 0 (which indicates
-				// missingGlyph), idDelta[i] is added to it to get the glyph index
-				glyphInx += entry.delta
-			}
-			// Validate glyph index
-			if f4.numGlyphs > 0 && int(glyphInx) >= f4.numGlyphs {
-				tracer().Errorf("cmap format4: glyph index %d exceeds numGlyphs %d", glyphInx, f4.numGlyphs)
-				return 0
-			}
-			// g2 := f4.glyphIds.UnsafeGet(index + 1).U16(0)
-			// trace().Debugf("next glyph ID = %d", g2)
-			// g2 = f4.glyphIds.UnsafeGet(index + 2).U16(0)
-			// trace().Debugf("next glyph ID = %d", g2)
-			// g2 = f4.glyphIds.UnsafeGet(index + 3).U16(0)
-			// trace().Debugf("next glyph ID = %d", g2)
-			return GlyphIndex(glyphInx) // will be 0 in case of indexing error
+			return h
 		}
 	}
-	return GlyphIndex(0)
+	return -1
+}
+
+// glyph returns the glyph for c, which has to be in segment h.
+func (f4 format4GlyphIndex) glyph(h int, c uint16) GlyphIndex {
+	N := len(f4.entries)
+	entry := &f4.entries[h]
+	if entry.offset == 0 {
+		//tracer().Debugf("direct access of glyph ID as delta = %d", c+entry.delta)
+		gid := GlyphIndex(c + entry.delta)
+		// Validate glyph index
+		if f4.numGlyphs > 0 && int(gid) >= f4.numGlyphs {
+			tracer().Errorf("cmap format4: glyph index %d exceeds numGlyphs %d", gid, f4.numGlyphs)
+			return 0
+		}
+		return gid
+	}
+	// The spec describes the calculation the find the link into the glyph ID array
+	// as follows:
+	// “The character code offset from startCode is added to the idRangeOffset value.
+	//  This sum is used as an offset from the current location within idRangeOffset
+	//  itself to index out the correct glyphIdArray value. This obscure indexing
+	//  trick works because glyphIdArray immediately follows idRangeOffset in the
+	//  font file.”
+	// We already sliced the cmap into sub-segments, so this will not work for us
+	// (intentionally–I'm not a big fan of 'obscure' tricks). Instead, we will
+	// calculate a clean index into the glyph ID array. Unfortunately this requires
+	// us to reverse some of the magic pre-calculations in the font—a procedure which
+	// one may consider obscure as well, but that's life…
+	//
+	// First cut off the part off the trailing part of offset which results from
+	// skipping over to the start of the glyph ID array:
+	//
+	// --- for now leave traces in as next bug will surely wait...
+	// eprev := &f4.entries[h-1]
+	// trace().Debugf("segment #%d = { start=%d, end=%d, delta=%d, offset=%d }",
+	// 	h-1, eprev.start, eprev.end, eprev.delta, eprev.offset)
+	// enext := &f4.entries[h+1]
+	// trace().Debugf("segment #%d = { start=%d, end=%d, delta=%d, offset=%d }",
+	// 	h+1, enext.start, enext.end, enext.delta, enext.offset)
+	deltaToEndOfEntries := (N - h) * 2 // 2 = byte size of offset array entry
+	//trace().Debugf("N = %d, N*2 = %d, h = %d, h*2=%d", N, N*2, h, h*2)
+	offset := int(entry.offset) - deltaToEndOfEntries
+	// Now normalize the index into the glyph ID array
+	index := offset / 2 // offset is in bytes, we need an array index
+	index += int(c - entry.start)
+	glyphInx := f4.glyphIds.Get(index).U16(0)
+	// trace().Debugf("segment #%d = { start=%d, end=%d, delta=%d, offset=%d }",
+	// 	h, entry.start, entry.end, entry.delta, entry.offset)
+	// trace().Debugf("skip = %d, offset = %d, rest = %d", deltaToEndOfEntries, offset, index)
+	// trace().Debugf("looking up code-point in segment %d, is %d", h, glyphInx)
+	if glyphInx > 0 {
+		// If the value obtained from the indexing operation is not 0 (which indicates
+		// missingGlyph), idDelta[i] is added to it to get the glyph index
+		glyphInx += entry.delta
+	}
+	// Validate glyph index
+	if f4.numGlyphs > 0 && int(glyphInx) >= f4.numGlyphs {
+		tracer().Errorf("cmap format4: glyph index %d exceeds numGlyphs %d", glyphInx, f4.numGlyphs)
+		return 0
+	}
+	// g2 := f4.glyphIds.UnsafeGet(index + 1).U16(0)
+	// trace().Debugf("next glyph ID = %d", g2)
+	// g2 = f4.glyphIds.UnsafeGet(index + 2).U16(0)
+	// trace().Debugf("next glyph ID = %d", g2)
+	// g2 = f4.glyphIds.UnsafeGet(index + 3).U16(0)
+	// trace().Debugf("next glyph ID = %d", g2)
+	return GlyphIndex(glyphInx) // will be 0 in case of indexing error
 }
 
 // ReverseLookup retrieves a code-point for a given glyph. The Cmap tables do not
@@ -287,9 +531,6 @@ func makeGlyphIndexFormat4(b binarySegm, tag Tag, offset uint32, ec *errorCollec
 			delta:  deltas.Get(i).U16(0),
 			offset: offsets.Get(i).U16(0),
 		}
-		if entries[i].offset > 0 && entries[i].delta > 0 {
-			panic("Hurray! Font with cmap format 4, offset > 0 and delta > 0, detected!")
-		}
 	}
 	glyphTable := viewArray16(b[next:])
 	tracer().Debugf("cmap format 4 glyph table starts at offset %d", next)
@@ -313,7 +554,31 @@ type format12GlyphIndex struct {
 }
 
 func (f12 format12GlyphIndex) Lookup(r rune) GlyphIndex {
-	c := uint32(r)
+	if h := f12.segment(uint32(r)); h >= 0 {
+		return f12.glyph(h, uint32(r))
+	}
+	return 0
+}
+
+// lookupAll maps runes to glyphs like Lookup, storing the glyph for runes[i] in
+// out[i]. The group of the previous code-point is tried first before searching
+// all groups.
+func (f12 format12GlyphIndex) lookupAll(runes []rune, out []GlyphIndex) {
+	h := -1
+	for i, r := range runes {
+		c := uint32(r)
+		if h < 0 || c < f12.entries[h].start || f12.entries[h].end < c {
+			if h = f12.segment(c); h < 0 {
+				out[i] = 0
+				continue
+			}
+		}
+		out[i] = f12.glyph(h, c)
+	}
+}
+
+// segment returns the index of the group containing c, or -1.
+func (f12 format12GlyphIndex) segment(c uint32) int {
 	for i, j := 0, len(f12.entries); i < j; {
 		h := i + (j-i)/2 // do a binary search on f12.entries (which may get large)
 		entry := &f12.entries[h]
@@ -322,16 +587,22 @@ func (f12 format12GlyphIndex) Lookup(r rune) GlyphIndex {
 		} else if entry.end < c {
 			i = h + 1
 		} else {
-			gid := GlyphIndex(c - entry.start + entry.delta)
-			// Validate glyph index
-			if f12.numGlyphs > 0 && int(gid) >= f12.numGlyphs {
-				tracer().Errorf("cmap format12: glyph index %d exceeds numGlyphs %d", gid, f12.numGlyphs)
-				return 0
-			}
-			return gid
+			return h
 		}
 	}
-	return 0
+	return -1
+}
+
+// glyph returns the glyph for c, which has to be in group h.
+func (f12 format12GlyphIndex) glyph(h int, c uint32) GlyphIndex {
+	entry := &f12.entries[h]
+	gid := GlyphIndex(c - entry.start + entry.delta)
+	// Validate glyph index
+	if f12.numGlyphs > 0 && int(gid) >= f12.numGlyphs {
+		tracer().Errorf("cmap format12: glyph index %d exceeds numGlyphs %d", gid, f12.numGlyphs)
+		return 0
+	}
+	return gid
 }
 
 // ReverseLookup retrieves a code-point for a given glyph. The Cmap tables do not
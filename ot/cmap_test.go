@@ -0,0 +1,119 @@
+package ot
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+// buildSymbolCMap creates a cmap table with a single format 4 subtable for
+// platform 3, encoding 0, mapping U+F041…U+F043 to glyphs 1…3 and U+0020 to
+// glyph 4.
+func buildSymbolCMap() []byte {
+	u16 := func(b []byte, v int) []byte { return binary.BigEndian.AppendUint16(b, uint16(v)) }
+	var b []byte
+	b = u16(u16(b, 0), 1)                    // version, one encoding record
+	b = u16(u16(b, 3), 0)                    // platform Windows, encoding Symbol
+	b = binary.BigEndian.AppendUint32(b, 12) // subtable offset
+	segments := []struct{ start, end, delta int }{
+		{0x20, 0x20, 4 - 0x20}, {0xf041, 0xf043, 1 - 0xf041}, {0xffff, 0xffff, 1},
+	}
+	b = u16(u16(u16(b, 4), 14+8*len(segments)+2), 0) // format, length, language
+	b = u16(u16(u16(u16(b, 2*len(segments)), 4), 1), 2)
+	for _, s := range segments {
+		b = u16(b, s.end)
+	}
+	b = u16(b, 0) // reserved padding
+	for _, s := range segments {
+		b = u16(b, s.start)
+	}
+	for _, s := range segments {
+		b = u16(b, s.delta)
+	}
+	for range segments {
+		b = u16(b, 0)
+	}
+	return b
+}
+
+func TestSymbolCMap(t *testing.T) {
+	b := buildSymbolCMap()
+	ec := &errorCollector{}
+	table, err := parseCMap(T("cmap"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatalf("cannot parse symbol cmap: %v", err)
+	}
+	cmap := table.Self().AsCMap()
+	for r, expected := range map[rune]GlyphIndex{
+		0xf041: 1, 'A': 1, 'C': 3, 0xf043: 3, ' ': 4, 0xf020: 0, 'D': 0, 0x141: 0,
+	} {
+		if g := cmap.GlyphIndexMap.Lookup(r); g != expected {
+			t.Errorf("expected glyph %d for code-point %U, have %d", expected, r, g)
+		}
+	}
+	codepoints := slices.Collect(cmap.Codepoints())
+	if expected := []rune{' ', 'A', 'B', 'C', 0xf041, 0xf042, 0xf043}; !slices.Equal(codepoints, expected) {
+		t.Errorf("expected code-points %U, have %U", expected, codepoints)
+	}
+	if r, ok := cmap.RuneFor(2); !ok || r != 'B' {
+		t.Errorf("expected glyph 2 to map back to 'B', have %U", r)
+	}
+	runes := []rune{'A', 'B', 0xf043, ' ', 'D', 'C'}
+	glyphs := make([]GlyphIndex, len(runes))
+	cmap.GlyphIndexes(runes, glyphs)
+	if expected := []GlyphIndex{1, 2, 3, 4, 0, 3}; !slices.Equal(glyphs, expected) {
+		t.Errorf("expected glyphs %v, have %v", expected, glyphs)
+	}
+}
+
+// TestCMapGlyphIndexes checks the batch lookups of cmap subtables of format 4
+// (Calibri) and format 12 (GentiumPlus) against looking up single code-points.
+func TestCMapGlyphIndexes(t *testing.T) {
+	for _, name := range []string{"Calibri", "GentiumPlus-R"} {
+		cmap := loadTestdataFont(t, name).CMap
+		switch cmap.GlyphIndexMap.(type) {
+		case format4GlyphIndex, format12GlyphIndex:
+		default:
+			t.Fatalf("%s: unexpected cmap subtable %T", name, cmap.GlyphIndexMap)
+		}
+		// ascending, descending and out-of-range code-points
+		var runes []rune
+		for r := rune(0); r < 0x20000; r += 3 {
+			runes = append(runes, r)
+		}
+		runes = append(runes, 0x10ffff, 0x110000, -1)
+		for r := rune(0x3000); r > 0; r -= 7 {
+			runes = append(runes, r)
+		}
+		glyphs := make([]GlyphIndex, len(runes))
+		cmap.GlyphIndexes(runes, glyphs)
+		for i, r := range runes {
+			if g := cmap.GlyphIndexMap.Lookup(r); glyphs[i] != g {
+				t.Errorf("%s: expected glyph %d for code-point %U, have %d", name, g, r, glyphs[i])
+			}
+		}
+	}
+}
+
+// BenchmarkCMapGlyphIndexes compares the batch lookups of cmap subtables to
+// looking up each code-point of a text on its own.
+func BenchmarkCMapGlyphIndexes(b *testing.B) {
+	text := []rune(`Unter den Linden steht das Denkmal Friedrichs des Großen, gegenüber
+der Humboldt-Universität. Ça coûte 12,50 € — «très cher», n’est-ce pas?`)
+	glyphs := make([]GlyphIndex, len(text))
+	for _, name := range []string{"Calibri", "GentiumPlus-R"} {
+		cmap := loadTestdataFont(b, name).CMap
+		b.Run(name+"/loop", func(b *testing.B) {
+			for b.Loop() {
+				for i, r := range text {
+					glyphs[i] = cmap.GlyphIndexMap.Lookup(r)
+				}
+			}
+		})
+		b.Run(name+"/batch", func(b *testing.B) {
+			for b.Loop() {
+				cmap.GlyphIndexes(text, glyphs)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package ot
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/npillmayer/opentype/internal/guard"
+)
+
+// fontTypeCollection is the tag of font collection files, 'ttcf'.
+const fontTypeCollection uint32 = 0x74746366
+
+// IsCollection reports whether data is the binary of an OpenType font
+// collection (a .ttc or .otc file).
+func IsCollection(data []byte) bool {
+	return len(data) >= 4 && u32(data) == fontTypeCollection
+}
+
+// ParseCollection parses all fonts of an OpenType font collection, in the
+// order of the collection header. For convenience, the binary of a single
+// font is accepted as well and results in a slice with one font.
+//
+// Member fonts of a collection often share tables, e.g. glyph outlines and
+// layout tables of the members of a family. Shared tables are parsed only
+// once, and member fonts refer to the same Table instances. Tables which are
+// completed with data of other tables after parsing (e.g. 'hmtx', which
+// depends on 'hhea') are shared only if the tables they depend on are shared
+// as well. As tables are immutable after parsing, member fonts may be used
+// concurrently.
+//
+// Like Parse, ParseCollection needs ongoing access to data after it returns.
+// The Binary of each member font is the binary of the whole collection.
+func ParseCollection(data []byte, options ...ParseOption) (_ []*Font, err error) {
+	defer guard.Recover(&err, tracer(), "ot.ParseCollection", ErrFontFormat)
+	if !IsCollection(data) {
+		otf, err := Parse(data, options...)
+		if err != nil {
+			return nil, err
+		}
+		return []*Font{otf}, nil
+	}
+	src := binarySegm(data)
+	if len(src) < 12 {
+		return nil, errFontFormat("font collection header too small")
+	}
+	major := src.U16(4)
+	if major != 1 && major != 2 {
+		return nil, errUnsupported(fmt.Sprintf("font collection version %d", major))
+	}
+	count := int(src.U32(8))
+	if count == 0 || 12+count*4 > len(src) {
+		return nil, errFontFormat(fmt.Sprintf("invalid number of fonts in collection: %d", count))
+	}
+	shared := &tableCache{tables: make(map[tableKey]*cachedTable)}
+	fonts := make([]*Font, count)
+	for i := range fonts {
+		otf, err := parseFontAt(src, src.U32(12+i*4), shared, options)
+		if err != nil {
+			return nil, fmt.Errorf("font #%d of collection: %w", i, err)
+		}
+		fonts[i] = otf
+	}
+	return fonts, nil
+}
+
+// tableRecord is an entry of the table directory of a font.
+type tableRecord struct {
+	tag          Tag
+	offset, size uint32
+}
+
+// tableDependencies lists the tables which are completed with data of other
+// tables after parsing (see extractLayoutInfo), together with these tables.
+var tableDependencies = map[Tag][maxTableDependencies]Tag{
+	T("cmap"): {T("maxp")},
+	T("hmtx"): {T("hhea")},
+	T("loca"): {T("head"), T("maxp")},
+	T("GSUB"): {T("maxp")},
+	T("GPOS"): {T("head"), T("maxp"), T("GDEF")},
+}
+
+const maxTableDependencies = 3
+
+// tableKey identifies a table of a font collection by its location and the
+// locations of the tables it depends on.
+type tableKey struct {
+	tag          Tag
+	offset, size uint32
+	dependencies [maxTableDependencies]tableRecord
+}
+
+// cachedTable is a parsed table, shared between members of a collection,
+// together with the diagnostics its parsing produced.
+type cachedTable struct {
+	table    Table
+	errors   []FontError
+	warnings []FontWarning
+}
+
+// tableCache holds the tables parsed for members of a font collection.
+type tableCache struct {
+	tables map[tableKey]*cachedTable
+}
+
+// parseTable returns the table for rec, parsing it only if no member font
+// parsed before refers to the same table. records is the table directory of the
+// font rec belongs to. Diagnostics of parsing are reported to ec for every
+// font sharing the table.
+func (c *tableCache) parseTable(rec tableRecord, records []tableRecord, data binarySegm, ec *errorCollector) (Table, error) {
+	key := tableKey{tag: rec.tag, offset: rec.offset, size: rec.size}
+	for i, dep := range tableDependencies[rec.tag] {
+		for _, r := range records {
+			if dep != 0 && r.tag == dep {
+				key.dependencies[i] = r
+			}
+		}
+	}
+	if cached, ok := c.tables[key]; ok {
+		ec.errors = append(ec.errors, cached.errors...)
+		ec.warnings = append(ec.warnings, cached.warnings...)
+		return cached.table, nil
+	}
+	nerr, nwarn := len(ec.errors), len(ec.warnings)
+	t, err := parseTable(rec.tag, data, rec.offset, rec.size, ec)
+	if err != nil {
+		return nil, err
+	}
+	c.tables[key] = &cachedTable{
+		table:    t,
+		errors:   slices.Clone(ec.errors[nerr:]),
+		warnings: slices.Clone(ec.warnings[nwarn:]),
+	}
+	return t, nil
+}
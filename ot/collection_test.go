@@ -0,0 +1,109 @@
+package ot
+
+import (
+	"encoding/binary"
+	"maps"
+	"os"
+	"slices"
+	"testing"
+)
+
+// buildCollection assembles a font collection from the tables of its members.
+// Table data slices shared between members are stored only once.
+func buildCollection(fontType uint32, members []map[Tag][]byte) []byte {
+	dirSize := func(m map[Tag][]byte) int { return 12 + 16*len(m) }
+	size := 12 + 4*len(members)
+	for _, m := range members {
+		size += dirSize(m)
+	}
+	out := make([]byte, size)
+	copy(out, "ttcf")
+	binary.BigEndian.PutUint16(out[4:], 1)
+	binary.BigEndian.PutUint32(out[8:], uint32(len(members)))
+	offsets := map[*byte]uint32{}
+	dir := 12 + 4*len(members)
+	for i, m := range members {
+		binary.BigEndian.PutUint32(out[12+4*i:], uint32(dir))
+		binary.BigEndian.PutUint32(out[dir:], fontType)
+		binary.BigEndian.PutUint16(out[dir+4:], uint16(len(m)))
+		for j, tag := range slices.Sorted(maps.Keys(m)) {
+			data := m[tag]
+			off, ok := offsets[&data[0]]
+			if !ok {
+				off = uint32(len(out))
+				offsets[&data[0]] = off
+				out = append(out, data...)
+				for len(out)%4 != 0 {
+					out = append(out, 0)
+				}
+			}
+			rec := out[dir+12+16*j:]
+			binary.BigEndian.PutUint32(rec, uint32(tag))
+			sum := tableChecksum(data)
+			if tag == T("head") {
+				sum -= u32(data[8:12]) // checkSumAdjustment is taken as 0
+			}
+			binary.BigEndian.PutUint32(rec[4:], sum)
+			binary.BigEndian.PutUint32(rec[8:], off)
+			binary.BigEndian.PutUint32(rec[12:], uint32(len(data)))
+		}
+		dir += dirSize(m)
+	}
+	return out
+}
+
+func TestParseCollectionSharesTables(t *testing.T) {
+	raw, err := os.ReadFile("../testdata/fonts/Go-Regular.otf")
+	if err != nil {
+		t.Fatal(err)
+	}
+	single, err := Parse(raw, IsTestfont)
+	if err != nil {
+		t.Fatal(err)
+	}
+	regular := map[Tag][]byte{}
+	for _, tag := range single.TableTags() {
+		regular[tag] = single.Table(tag).Binary()
+	}
+	variant := maps.Clone(regular)
+	variant[T("hhea")] = slices.Clone(regular[T("hhea")])
+	variant[T("name")] = slices.Clone(regular[T("name")])
+	ttc := buildCollection(single.Header.FontType, []map[Tag][]byte{regular, variant})
+	if !IsCollection(ttc) || IsCollection(raw) {
+		t.Fatalf("IsCollection does not detect font collections")
+	}
+	if _, err := Parse(ttc); err == nil {
+		t.Errorf("expected Parse to reject a font collection")
+	}
+	fonts, err := ParseCollection(ttc, IsTestfont)
+	if err != nil {
+		t.Fatalf("ParseCollection failed: %v", err)
+	}
+	if len(fonts) != 2 {
+		t.Fatalf("expected 2 fonts in collection, have %d", len(fonts))
+	}
+	for _, tag := range []string{"cmap", "head", "maxp", "CFF "} {
+		if fonts[0].Table(T(tag)) == nil || fonts[0].Table(T(tag)) != fonts[1].Table(T(tag)) {
+			t.Errorf("expected table %s to be shared between members", tag)
+		}
+	}
+	for _, tag := range []string{"name", "hhea", "hmtx"} {
+		if fonts[0].Table(T(tag)) == fonts[1].Table(T(tag)) {
+			t.Errorf("expected table %s not to be shared between members", tag)
+		}
+	}
+	if fonts[0].UniqueID() == fonts[1].UniqueID() {
+		t.Errorf("expected members of a collection to have different UniqueIDs")
+	}
+	for i, otf := range fonts {
+		if otf.CMapTable().GlyphIndexMap.Lookup('A') != single.CMapTable().GlyphIndexMap.Lookup('A') {
+			t.Errorf("font #%d maps 'A' differently from the single font", i)
+		}
+		if mm := otf.VerifyChecksums(); len(mm) != 0 {
+			t.Errorf("font #%d: unexpected checksum mismatches %v", i, mm)
+		}
+	}
+	if fonts, err := ParseCollection(raw, IsTestfont); err != nil || len(fonts) != 1 {
+		t.Errorf("expected ParseCollection to accept a single font, have %d fonts, %v", len(fonts), err)
+	}
+}
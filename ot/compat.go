@@ -0,0 +1,185 @@
+package ot
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// CompatReport describes the differences between two fonts which affect the
+// layout of text, as found by Compatible. It is intended for detecting
+// whether documents have to be re-flowed after swapping a font for another
+// version of it.
+type CompatReport struct {
+	UnitsPerEm      [2]uint16       // units per em of fonts a and b
+	NumGlyphs       [2]int          // number of glyphs of fonts a and b
+	Advances        []AdvanceChange // code-points mapped by both fonts with different advances
+	AddedRunes      []rune          // code-points mapped by b, but not by a
+	RemovedRunes    []rune          // code-points mapped by a, but not by b
+	AddedFeatures   []FeatureKey    // layout features of b missing in a
+	RemovedFeatures []FeatureKey    // layout features of a missing in b
+}
+
+// AdvanceChange is a code-point which maps to glyphs of different advance
+// widths in two fonts. Advances are in font units of the respective font.
+type AdvanceChange struct {
+	Rune     rune
+	Glyphs   [2]GlyphIndex
+	Advances [2]uint16
+}
+
+// FeatureKey identifies a layout feature of a font for a script and language.
+// Lang is 'dflt' for the default language system of a script.
+type FeatureKey struct {
+	Table   Tag // GSUB or GPOS
+	Script  Tag
+	Lang    Tag
+	Feature Tag
+}
+
+func (k FeatureKey) String() string {
+	return fmt.Sprintf("%s/%s/%s/%s", k.Table, k.Script, k.Lang, k.Feature)
+}
+
+// MetricCompatible reports whether text keeps its advance widths when set
+// with font b instead of font a, i.e., whether no code-point changed its
+// advance (relative to the em square) and no code-point has been dropped.
+// Glyph counts and added code-points do not affect metric compatibility.
+func (r CompatReport) MetricCompatible() bool {
+	return len(r.Advances) == 0 && len(r.RemovedRunes) == 0
+}
+
+// LayoutCompatible reports whether the fonts are metric compatible and offer
+// the same layout features. Lookups of features present in both fonts are not
+// compared.
+func (r CompatReport) LayoutCompatible() bool {
+	return r.MetricCompatible() && len(r.AddedFeatures) == 0 && len(r.RemovedFeatures) == 0
+}
+
+func (r CompatReport) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "units per em %d/%d, glyphs %d/%d", r.UnitsPerEm[0], r.UnitsPerEm[1],
+		r.NumGlyphs[0], r.NumGlyphs[1])
+	fmt.Fprintf(&sb, ", %d advance changes, %d code-points added, %d removed",
+		len(r.Advances), len(r.AddedRunes), len(r.RemovedRunes))
+	fmt.Fprintf(&sb, ", %d features added, %d removed", len(r.AddedFeatures), len(r.RemovedFeatures))
+	return sb.String()
+}
+
+// Compatible compares font a with font b, e.g. two versions of a font, for
+// differences which affect the layout of text. It compares units per em,
+// glyph counts, the advance widths of the glyphs of code-points mapped by both
+// fonts, the code-points covered by the character maps and the inventories of
+// GSUB and GPOS features per script and language. Advances of fonts with
+// different units per em are compared relative to the em square.
+//
+// Glyphs are compared by code-point, not by glyph index, as glyph orders may
+// differ between versions of a font.
+func Compatible(a, b *Font) CompatReport {
+	r := CompatReport{
+		UnitsPerEm: [2]uint16{unitsPerEm(a), unitsPerEm(b)},
+		NumGlyphs:  [2]int{numGlyphs(a), numGlyphs(b)},
+	}
+	cmapA, cmapB := a.CMapTable(), b.CMapTable()
+	for c := range cmapA.Codepoints() {
+		gb := cmapB.lookup(c)
+		if gb == 0 {
+			r.RemovedRunes = append(r.RemovedRunes, c)
+			continue
+		}
+		ga := cmapA.lookup(c)
+		advA, advB := a.HorizontalMetrics().Advance(ga), b.HorizontalMetrics().Advance(gb)
+		if uint32(advA)*uint32(r.UnitsPerEm[1]) != uint32(advB)*uint32(r.UnitsPerEm[0]) {
+			r.Advances = append(r.Advances, AdvanceChange{
+				Rune:     c,
+				Glyphs:   [2]GlyphIndex{ga, gb},
+				Advances: [2]uint16{advA, advB},
+			})
+		}
+	}
+	for c := range cmapB.Codepoints() {
+		if cmapA.lookup(c) == 0 {
+			r.AddedRunes = append(r.AddedRunes, c)
+		}
+	}
+	featsA, featsB := featureInventory(a), featureInventory(b)
+	r.RemovedFeatures = missingFeatures(featsA, featsB)
+	r.AddedFeatures = missingFeatures(featsB, featsA)
+	return r
+}
+
+func unitsPerEm(otf *Font) uint16 {
+	if head := otf.FontHead(); head != nil {
+		return head.UnitsPerEm
+	}
+	return 0
+}
+
+func numGlyphs(otf *Font) int {
+	if t := otf.Table(T("maxp")); t != nil {
+		return t.Self().AsMaxP().NumGlyphs
+	}
+	return 0
+}
+
+// lookup returns the glyph for code-point c, or 0 if t is nil.
+func (t *CMapTable) lookup(c rune) GlyphIndex {
+	if t == nil || t.GlyphIndexMap == nil {
+		return 0
+	}
+	return t.GlyphIndexMap.Lookup(c)
+}
+
+// featureInventory collects the features of the GSUB and GPOS tables of otf
+// for all scripts and language systems, including required features.
+func featureInventory(otf *Font) map[FeatureKey]struct{} {
+	inv := make(map[FeatureKey]struct{})
+	collect := func(table Tag, lyt *LayoutTable) {
+		fl := lyt.FeatureGraph()
+		add := func(script, lang Tag, ls *LangSys) {
+			if ls == nil {
+				return
+			}
+			addIndex := func(inx int) {
+				if tag, ok := fl.TagAt(inx); ok {
+					inv[FeatureKey{Table: table, Script: script, Lang: lang, Feature: tag}] = struct{}{}
+				}
+			}
+			for inx := range ls.Range() {
+				addIndex(inx)
+			}
+			if req, ok := ls.RequiredFeatureIndex(); ok {
+				addIndex(int(req))
+			}
+		}
+		for stag, script := range lyt.ScriptGraph().Range() {
+			add(stag, T("dflt"), script.DefaultLangSys())
+			for ltag, ls := range script.Range() {
+				add(stag, ltag, ls)
+			}
+		}
+	}
+	if gsub := otf.GSub(); gsub != nil {
+		collect(T("GSUB"), &gsub.LayoutTable)
+	}
+	if gpos := otf.GPos(); gpos != nil {
+		collect(T("GPOS"), &gpos.LayoutTable)
+	}
+	return inv
+}
+
+// missingFeatures returns the keys of from which are missing in to, sorted.
+func missingFeatures(from, to map[FeatureKey]struct{}) []FeatureKey {
+	var missing []FeatureKey
+	for k := range from {
+		if _, ok := to[k]; !ok {
+			missing = append(missing, k)
+		}
+	}
+	slices.SortFunc(missing, func(x, y FeatureKey) int {
+		return cmp.Or(cmp.Compare(x.Table, y.Table), cmp.Compare(x.Script, y.Script),
+			cmp.Compare(x.Lang, y.Lang), cmp.Compare(x.Feature, y.Feature))
+	})
+	return missing
+}
@@ -0,0 +1,78 @@
+package ot
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+)
+
+func TestCompatibleSameFont(t *testing.T) {
+	otf := loadTestdataFont(t, "Calibri")
+	r := Compatible(otf, otf)
+	if !r.LayoutCompatible() {
+		t.Errorf("expected font to be compatible with itself, have %v", r)
+	}
+	if r.NumGlyphs[0] == 0 || r.NumGlyphs[0] != r.NumGlyphs[1] {
+		t.Errorf("expected equal glyph counts, have %v", r.NumGlyphs)
+	}
+}
+
+func TestCompatibleChangedAdvance(t *testing.T) {
+	otf := loadTestdataFont(t, "Calibri")
+	gid := otf.CMapTable().lookup('A')
+	if int(gid) >= len(otf.HorizontalMetrics().LongMetrics()) {
+		t.Fatalf("expected glyph of 'A' to have a long metrics record")
+	}
+	hmtx := slices.Clone(otf.Table(T("hmtx")).Binary())
+	adv := otf.HorizontalMetrics().Advance(gid)
+	binary.BigEndian.PutUint16(hmtx[4*int(gid):], adv+10)
+	data, err := otf.Rebuild(map[Tag][]byte{T("hmtx"): hmtx})
+	if err != nil {
+		t.Fatal(err)
+	}
+	changed, err := Parse(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r := Compatible(otf, changed)
+	if r.MetricCompatible() {
+		t.Fatalf("expected changed advance to be flagged")
+	}
+	i := slices.IndexFunc(r.Advances, func(c AdvanceChange) bool { return c.Rune == 'A' })
+	if i < 0 {
+		t.Fatalf("expected advance change for 'A', have %v", r.Advances)
+	}
+	if c := r.Advances[i]; c.Advances != [2]uint16{adv, adv + 10} || c.Glyphs != [2]GlyphIndex{gid, gid} {
+		t.Errorf("unexpected advance change %+v", c)
+	}
+	for _, c := range r.Advances {
+		if c.Glyphs[0] != gid {
+			t.Errorf("unexpected advance change for %U", c.Rune)
+		}
+	}
+	if len(r.AddedFeatures)+len(r.RemovedFeatures)+len(r.AddedRunes)+len(r.RemovedRunes) != 0 {
+		t.Errorf("expected only advances to differ, have %v", r)
+	}
+}
+
+func TestCompatibleDifferentFonts(t *testing.T) {
+	a, b := loadTestdataFont(t, "Calibri"), loadTestdataFont(t, "GentiumPlus-R")
+	r := Compatible(a, b)
+	if r.MetricCompatible() || r.LayoutCompatible() {
+		t.Errorf("expected different fonts to be incompatible, have %v", r)
+	}
+	if len(r.AddedRunes) == 0 || len(r.RemovedRunes) == 0 {
+		t.Errorf("expected differences in coverage, have %v", r)
+	}
+	if !slices.IsSorted(r.RemovedRunes) {
+		t.Errorf("expected removed code-points to be sorted")
+	}
+	if len(r.AddedFeatures) == 0 && len(r.RemovedFeatures) == 0 {
+		t.Errorf("expected differences in features, have %v", r)
+	}
+	for _, k := range append(r.AddedFeatures, r.RemovedFeatures...) {
+		if k.Table != T("GSUB") && k.Table != T("GPOS") {
+			t.Errorf("unexpected feature key %v", k)
+		}
+	}
+}
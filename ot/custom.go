@@ -0,0 +1,94 @@
+package ot
+
+import (
+	"fmt"
+	"sync"
+)
+
+// TableParser parses a table which package ot does not interpret, e.g. the
+// Graphite table 'Sill' or the AAT table 'ankr'. It receives the binary data
+// of the table and a report to record problems with it. The value returned is
+// accessible through CustomTable.Value.
+//
+// Parsers should treat data as read-only and must not retain a reference to
+// report. If a parser returns an error, the error is recorded and the table is
+// kept as an uninterpreted table; it does not abort parsing of the font.
+type TableParser func(tag Tag, data []byte, report TableReport) (any, error)
+
+// TableReport lets a TableParser record errors and warnings, which are then
+// reported by Font.Errors and Font.Warnings, respectively.
+type TableReport struct {
+	ec     *errorCollector
+	tag    Tag
+	offset uint32 // offset of the table within the font
+}
+
+// Error records an error found in section of the table, at byte offset offset
+// relative to the start of the table.
+func (r TableReport) Error(section, issue string, severity ErrorSeverity, offset uint32) {
+	r.ec.addError(r.tag, section, issue, severity, r.offset+offset)
+}
+
+// Warning records a warning for the table, at byte offset offset relative to
+// the start of the table.
+func (r TableReport) Warning(issue string, offset uint32) {
+	r.ec.addWarning(r.tag, issue, r.offset+offset)
+}
+
+var customParsers = struct {
+	sync.RWMutex
+	parsers map[Tag]TableParser
+}{parsers: make(map[Tag]TableParser)}
+
+// RegisterTableParser registers fn as the parser for tables with tag tag, to be
+// invoked by Parse and ParseCollection. Registering a nil parser removes the
+// parser for tag. Parsers for tables interpreted by package ot itself are never
+// invoked.
+//
+// Parsers are registered globally, usually from the init function of the
+// package handling the table. RegisterTableParser is safe for concurrent use.
+func RegisterTableParser(tag Tag, fn TableParser) {
+	customParsers.Lock()
+	defer customParsers.Unlock()
+	if fn == nil {
+		delete(customParsers.parsers, tag)
+		return
+	}
+	customParsers.parsers[tag] = fn
+}
+
+// CustomTable is a table parsed by a parser registered with RegisterTableParser.
+// As tables of different tags may be custom tables, clients should access them
+// with otf.Table(tag).Self().AsCustom() rather than with TableOf.
+type CustomTable struct {
+	tableBase
+	Value any // result of the table parser
+}
+
+// AsCustom returns this table as a table parsed by a registered table parser,
+// or nil.
+func (tself TableSelf) AsCustom() *CustomTable {
+	if k, ok := safeSelf(tself).(*CustomTable); ok {
+		return k
+	}
+	return nil
+}
+
+// parseCustomTable invokes the parser registered for tag t, if any. It returns
+// nil if no parser is registered or if the parser fails.
+func parseCustomTable(t Tag, b binarySegm, offset, size uint32, ec *errorCollector) Table {
+	customParsers.RLock()
+	parse := customParsers.parsers[t]
+	customParsers.RUnlock()
+	if parse == nil {
+		return nil
+	}
+	v, err := parse(t, b, TableReport{ec: ec, tag: t, offset: offset})
+	if err != nil {
+		ec.addError(t, "Custom", fmt.Sprintf("table parser failed: %v", err), SeverityMajor, offset)
+		return nil
+	}
+	ct := &CustomTable{tableBase: tableBase{data: b, name: t, offset: offset, length: size}, Value: v}
+	ct.self = ct
+	return ct
+}
@@ -0,0 +1,62 @@
+package ot
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestRegisterTableParser(t *testing.T) {
+	tag := T("Xtst")
+	RegisterTableParser(tag, func(tag Tag, data []byte, report TableReport) (any, error) {
+		r := NewReader(data)
+		version, count := r.ReadU16(), r.ReadU16()
+		if version != 1 {
+			return nil, errors.New("unsupported version")
+		}
+		if count == 0 {
+			report.Warning("no entries", 2)
+		}
+		return count, r.Err()
+	})
+	t.Cleanup(func() { RegisterTableParser(tag, nil) })
+	calibri := loadCalibri(t)
+	for _, c := range []struct {
+		data     []byte
+		value    any
+		warnings int
+		errors   int
+	}{
+		{[]byte{0, 1, 0, 7}, uint16(7), 0, 0},
+		{[]byte{0, 1, 0, 0}, uint16(0), 1, 0},
+		{[]byte{0, 2, 0, 0}, nil, 0, 1},
+	} {
+		data, err := calibri.Rebuild(map[Tag][]byte{tag: c.data})
+		if err != nil {
+			t.Fatal(err)
+		}
+		otf, err := Parse(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		table := otf.Table(tag)
+		if ct := table.Self().AsCustom(); c.value != nil && (ct == nil || ct.Value != c.value) {
+			t.Errorf("expected custom table with value %v, have %v", c.value, ct)
+		} else if c.value == nil && ct != nil {
+			t.Errorf("expected failing table parser to leave table uninterpreted")
+		}
+		var warnings, errs int
+		for _, w := range otf.Warnings() {
+			if w.Table == tag && w.Issue == "no entries" {
+				warnings++
+			}
+		}
+		for _, e := range otf.Errors() {
+			if e.Table == tag {
+				errs++
+			}
+		}
+		if warnings != c.warnings || errs != c.errors {
+			t.Errorf("expected %d warnings and %d errors, have %d and %d", c.warnings, c.errors, warnings, errs)
+		}
+	}
+}
@@ -35,6 +35,21 @@ upon the OT specification (for example, Calibri has an overflow in a 'kern' tabl
 but an application using it should not fail because of recoverable errors.
 Package `ot` will try to circumvent known bugs in common fonts.
 
+Tables which package `ot` does not interpret are accessible as raw bytes through
+Table.Binary. Clients handling such tables may read them with a Reader, which
+does the bounds checking, and may have them parsed together with the font by
+registering a TableParser (see RegisterTableParser).
+
+# Iteration order
+
+Lists returned or iterated by package `ot` have a defined order, which makes
+output of tools and golden tests reproducible. Font.TableTags returns tables in
+the order of the table directory, which is sorted by tag. Scripts, language
+systems and features are iterated (Range) in the order they are declared in the
+font; the OpenType specification requires it to be alphabetical, but fonts do
+not always comply. ScriptList.Tags, Script.LangTags and FeatureList.Tags return
+distinct tags in alphabetical order regardless of the font.
+
 # Status
 
 Work in progress. Handling fonts is fiddly and fonts have become complex software
@@ -42,7 +57,9 @@ applications in their own right. I often need a break from the vast desert of
 bytes (without any sign posts), which is what font data files are at their core. A break
 where I talk to myself and ask, this is what you do in your spare time? Really?
 
-No font collections nor variable fonts are supported yet, but will be in time.
+Font collections (.ttc, .otc) are parsed with ParseCollection, which parses tables
+shared between member fonts only once. Variable fonts are handled by package
+otvar.
 
 # License
 
@@ -113,6 +130,15 @@ func tracer() tracing.Trace {
 	return tracing.Select("font.opentype")
 }
 
+// traceDebug reports whether debug-level tracing is enabled. Code run lazily
+// after parsing, e.g., parsing of lookup subtables, checks it before tracing.
+func traceDebug() bool {
+	return tracer().GetTraceLevel() >= tracing.LevelDebug
+}
+
+// Assertions panic if violated. Panics are recovered by Parse and
+// ParseCollection and reported as errors, see package internal/guard.
+
 func assertEqualInt(name string, a, b int) {
 	if a != b {
 		panic(fmt.Sprintf("assertion [%s] failed: %d != %d", name, a, b))
@@ -1,6 +1,67 @@
 package ot
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by Parse and ParseCollection make a font unusable; issues a
+// font can be used despite of are collected and reported by Font.Errors and
+// Font.Warnings. Returned errors may be inspected with errors.Is and errors.As:
+// all of them match ErrFontFormat, fonts using table versions or formats not
+// supported by package ot match ErrUnsupportedVersion, and errors concerning
+// specific tables or locations are of types ErrMissingTable and ErrBounds.
+var (
+	// ErrFontFormat is matched by all errors reporting unusable font data.
+	ErrFontFormat = errors.New("OpenType font format")
+	// ErrUnsupportedVersion is matched by errors reporting a font type, table
+	// version or table format package ot does not support.
+	ErrUnsupportedVersion = errors.New("unsupported version")
+)
+
+// ErrMissingTable reports a table which is required, but missing from a font.
+type ErrMissingTable struct {
+	Tag Tag // tag of the missing table
+}
+
+func (e *ErrMissingTable) Error() string {
+	return fmt.Sprintf("%v: missing required table %s", ErrFontFormat, e.Tag)
+}
+
+// Is reports whether target is ErrFontFormat.
+func (e *ErrMissingTable) Is(target error) bool {
+	return target == ErrFontFormat
+}
+
+// ErrBounds reports data of a font exceeding the bounds of the font binary or
+// of the table containing it.
+type ErrBounds struct {
+	Table  Tag    // table containing the data, or 0 for the table directory
+	Offset uint32 // offset of the data in the font binary, or 0 if unknown
+	Issue  string // description of the violation
+}
+
+func (e *ErrBounds) Error() string {
+	where := "table directory"
+	if e.Table != 0 {
+		where = "table " + e.Table.String()
+	}
+	if e.Offset > 0 {
+		return fmt.Sprintf("%v: %s at offset %d: %s", ErrFontFormat, where, e.Offset, e.Issue)
+	}
+	return fmt.Sprintf("%v: %s: %s", ErrFontFormat, where, e.Issue)
+}
+
+// Is reports whether target is ErrFontFormat.
+func (e *ErrBounds) Is(target error) bool {
+	return target == ErrFontFormat
+}
+
+// errUnsupported returns an error matching ErrFontFormat and
+// ErrUnsupportedVersion.
+func errUnsupported(message string) error {
+	return fmt.Errorf("%w: %w: %s", ErrFontFormat, ErrUnsupportedVersion, message)
+}
 
 // ErrorSeverity represents the severity level of a font parsing error.
 //
@@ -1,6 +1,11 @@
 package ot
 
-import "testing"
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
 
 // TestErrorSeverity verifies the ErrorSeverity String() method.
 func TestErrorSeverity(t *testing.T) {
@@ -249,3 +254,109 @@ func TestFontErrorMethods(t *testing.T) {
 		t.Error("Empty font should not have critical errors")
 	}
 }
+
+// TestParseErrorsMatchTypes verifies that errors returned by Parse may be
+// inspected with errors.Is and errors.As.
+func TestParseErrorsMatchTypes(t *testing.T) {
+	calibri := loadCalibri(t)
+	noCmap, err := calibri.Rebuild(map[Tag][]byte{T("cmap"): nil})
+	if err != nil {
+		t.Fatal(err)
+	}
+	badType := slices.Clone(calibri.Binary())
+	copy(badType, "abcd")
+	//
+	_, err = Parse(calibri.Binary()[:40])
+	var eb *ErrBounds
+	if !errors.Is(err, ErrFontFormat) || !errors.As(err, &eb) {
+		t.Errorf("truncated font: expected ErrBounds, got %v", err)
+	}
+	_, err = Parse(noCmap)
+	var em *ErrMissingTable
+	if !errors.Is(err, ErrFontFormat) || !errors.As(err, &em) || em.Tag != T("cmap") {
+		t.Errorf("font without cmap: expected ErrMissingTable{cmap}, got %v", err)
+	}
+	_, err = Parse(badType)
+	if !errors.Is(err, ErrFontFormat) || !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("unknown font type: expected ErrUnsupportedVersion, got %v", err)
+	}
+	if errors.As(err, &eb) || errors.As(err, &em) {
+		t.Errorf("unknown font type: unexpected error type %T", err)
+	}
+}
+
+// TestErrorTypesFormatting verifies the messages of ErrBounds and ErrMissingTable.
+func TestErrorTypesFormatting(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&ErrMissingTable{Tag: T("maxp")}, "OpenType font format: missing required table maxp"},
+		{&ErrBounds{Offset: 12, Issue: "too small"}, "OpenType font format: table directory at offset 12: too small"},
+		{&ErrBounds{Table: T("loca"), Issue: "too small"}, "OpenType font format: table loca: too small"},
+		{errUnsupported("cmap format 99"), "OpenType font format: unsupported version: cmap format 99"},
+	}
+	for _, tt := range tests {
+		if tt.err.Error() != tt.expected {
+			t.Errorf("Error() = %q; want %q", tt.err.Error(), tt.expected)
+		}
+	}
+}
+
+// TestLookupSubtableErrors verifies that damaged lookup subtables, which are
+// parsed on first access, are reported to the font's error list.
+func TestLookupSubtableErrors(t *testing.T) {
+	calibri := loadCalibri(t)
+	gsub := slices.Clone(calibri.Table(T("GSUB")).Binary())
+	lookupList := int(u16(gsub[8:]))
+	// damage the first subtable of lookup 1, following an extension subtable
+	const inx = 1
+	lookup := lookupList + int(u16(gsub[lookupList+2+2*inx:]))
+	sub := lookup + int(u16(gsub[lookup+6:]))
+	if LayoutTableLookupType(u16(gsub[lookup:])) == GSubLookupTypeExtensionSubs {
+		sub += int(u32(gsub[sub+4:]))
+	}
+	putU16(gsub, sub, 9) // unknown subtable format
+	b, err := calibri.Rebuild(map[Tag][]byte{T("GSUB"): gsub})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otf, err := Parse(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n := len(otf.Errors())
+	lt := otf.Layout.GSub.LookupGraph().Lookup(inx)
+	for range 2 {
+		if err := lt.Subtable(0).Error(); !errors.Is(err, ErrUnsupportedVersion) {
+			t.Errorf("expected subtable to be flagged as unsupported, have %v", err)
+		}
+	}
+	errs := otf.Errors()[n:]
+	if len(errs) != 1 {
+		t.Fatalf("expected subtable error to be reported once, have %v", errs)
+	}
+	want := fmt.Sprintf("Lookup[%d].Subtable[0]", inx)
+	if errs[0].Table != T("GSUB") || errs[0].Section != want || errs[0].Severity != SeverityMajor {
+		t.Errorf("expected major error for GSUB/%s, have %v", want, errs[0])
+	}
+}
+
+// TestLookupSubtableLargeOffsets verifies that subtables larger than 64K may
+// link to structures at offsets beyond the size of the subtable modulo 64K:
+// Calibri has a kerning subtable with such a class definition.
+func TestLookupSubtableLargeOffsets(t *testing.T) {
+	calibri := loadCalibri(t)
+	for i, lt := range calibri.Layout.GPos.LookupGraph().Range() {
+		for j, node := range lt.Range() {
+			if err := node.Error(); err != nil {
+				t.Errorf("GPOS lookup %d, subtable %d: %v", i, j, err)
+			}
+		}
+	}
+	for _, e := range calibri.Errors() {
+		if e.Table == T("GPOS") {
+			t.Errorf("unexpected GPOS error: %v", e)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+package ot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// ExportOption influences the output of ExportJSON.
+type ExportOption int
+
+const (
+	ExportIndented ExportOption = iota + 1 // pretty-print the JSON output
+	ExportNoGlyphs                         // omit glyph lists (coverages, class definitions)
+)
+
+// ExportJSON writes a JSON representation of the typed view of table t to w.
+// It is intended for tooling, e.g., for diffing fonts or for web-based font
+// inspectors, and does not round-trip to the binary format.
+//
+// Every table is exported with its tag, offset and size. Typed content is
+// exported for the following tables:
+//
+//   - cmap: number of glyphs and mapped code-points, as ranges
+//   - GDEF: glyph classes, mark attachment classes, mark glyph sets and
+//     ligature caret count
+//   - GSUB, GPOS: scripts with language systems, features and lookups with
+//     their subtables. Extension subtables are resolved, and coverages are
+//     exported as lists of glyph indices.
+//
+// The JSON structure is not yet considered stable.
+func ExportJSON(t Table, w io.Writer, opts ...ExportOption) error {
+	if t == nil {
+		return fmt.Errorf("export: table is nil")
+	}
+	x := jsonExporter{}
+	enc := json.NewEncoder(w)
+	for _, opt := range opts {
+		switch opt {
+		case ExportIndented:
+			enc.SetIndent("", "  ")
+		case ExportNoGlyphs:
+			x.noGlyphs = true
+		}
+	}
+	off, size := t.Extent()
+	jt := jsonTable{
+		Tag:    navTagName(t.Self().NameTag()),
+		Offset: off,
+		Size:   size,
+	}
+	switch {
+	case t.Self().AsCMap() != nil:
+		jt.CMap = x.cmap(t.Self().AsCMap())
+	case t.Self().AsGDef() != nil:
+		jt.GDef = x.gdef(t.Self().AsGDef())
+	case t.Self().AsGSub() != nil:
+		jt.Layout = x.layout(&t.Self().AsGSub().LayoutTable)
+	case t.Self().AsGPos() != nil:
+		jt.Layout = x.layout(&t.Self().AsGPos().LayoutTable)
+	}
+	return enc.Encode(jt)
+}
+
+type jsonTable struct {
+	Tag    string      `json:"tag"`
+	Offset uint32      `json:"offset"`
+	Size   uint32      `json:"size"`
+	CMap   *jsonCMap   `json:"cmap,omitempty"`
+	GDef   *jsonGDef   `json:"gdef,omitempty"`
+	Layout *jsonLayout `json:"layout,omitempty"`
+}
+
+type jsonCMap struct {
+	NumGlyphs  int       `json:"numGlyphs"`
+	Codepoints int       `json:"codepoints"`
+	Ranges     [][2]rune `json:"ranges,omitempty"`
+}
+
+type jsonGDef struct {
+	GlyphClasses      []jsonClassRange `json:"glyphClasses,omitempty"`
+	MarkAttachClasses []jsonClassRange `json:"markAttachClasses,omitempty"`
+	MarkGlyphSets     [][]GlyphIndex   `json:"markGlyphSets,omitempty"`
+	LigatureCarets    int              `json:"ligatureCarets"`
+}
+
+// jsonClassRange is a range of consecutive glyphs of the same class.
+type jsonClassRange struct {
+	First GlyphIndex `json:"first"`
+	Last  GlyphIndex `json:"last"`
+	Class int        `json:"class"`
+}
+
+type jsonLayout struct {
+	Scripts  []jsonScript  `json:"scripts"`
+	Features []jsonFeature `json:"features"`
+	Lookups  []jsonLookup  `json:"lookups"`
+	Error    string        `json:"error,omitempty"`
+}
+
+type jsonScript struct {
+	Tag     string        `json:"tag"`
+	Default *jsonLangSys  `json:"default,omitempty"`
+	Langs   []jsonLangSys `json:"langs,omitempty"`
+}
+
+type jsonLangSys struct {
+	Tag      string `json:"tag,omitempty"`
+	Required *int   `json:"required,omitempty"`
+	Features []int  `json:"features"`
+}
+
+type jsonFeature struct {
+	Index   int    `json:"index"`
+	Tag     string `json:"tag"`
+	Lookups []int  `json:"lookups"`
+}
+
+type jsonLookup struct {
+	Index            int            `json:"index"`
+	Type             int            `json:"type"`
+	Flag             uint16         `json:"flag"`
+	MarkFilteringSet *uint16        `json:"markFilteringSet,omitempty"`
+	Subtables        []jsonSubtable `json:"subtables"`
+	Error            string         `json:"error,omitempty"`
+}
+
+type jsonSubtable struct {
+	Type      int          `json:"type"`
+	Format    uint16       `json:"format"`
+	Extension bool         `json:"extension,omitempty"`
+	Coverage  []GlyphIndex `json:"coverage,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
+
+type jsonExporter struct {
+	noGlyphs bool
+}
+
+func (x jsonExporter) cmap(t *CMapTable) *jsonCMap {
+	jc := &jsonCMap{NumGlyphs: t.NumGlyphs}
+	for range t.Codepoints() {
+		jc.Codepoints++
+	}
+	if x.noGlyphs {
+		return jc
+	}
+	rt := t.CoverageBitmap()
+	for _, r := range rt.R16 {
+		jc.Ranges = append(jc.Ranges, [2]rune{rune(r.Lo), rune(r.Hi)})
+	}
+	for _, r := range rt.R32 {
+		jc.Ranges = append(jc.Ranges, [2]rune{rune(r.Lo), rune(r.Hi)})
+	}
+	return jc
+}
+
+func (x jsonExporter) gdef(t *GDefTable) *jsonGDef {
+	jg := &jsonGDef{LigatureCarets: int(t.LigCaretList.Count)}
+	if x.noGlyphs {
+		return jg
+	}
+	jg.GlyphClasses = classRanges(&t.GlyphClassDef)
+	jg.MarkAttachClasses = classRanges(&t.MarkAttachmentClassDef)
+	for _, set := range t.MarkGlyphSets {
+		jg.MarkGlyphSets = append(jg.MarkGlyphSets, x.glyphs(Coverage{GlyphRange: set}))
+	}
+	return jg
+}
+
+func (x jsonExporter) layout(t *LayoutTable) *jsonLayout {
+	jl := &jsonLayout{
+		Scripts:  []jsonScript{},
+		Features: []jsonFeature{},
+		Lookups:  []jsonLookup{},
+	}
+	if err := t.ScriptGraph().Error(); err != nil {
+		jl.Error = err.Error()
+	}
+	for tag, script := range t.ScriptGraph().Range() {
+		js := jsonScript{Tag: navTagName(tag)}
+		if dflt := script.DefaultLangSys(); dflt != nil {
+			jls := exportLangSys(dflt)
+			js.Default = &jls
+		}
+		for ltag, lang := range script.Range() {
+			jls := exportLangSys(lang)
+			jls.Tag = navTagName(ltag)
+			js.Langs = append(js.Langs, jls)
+		}
+		jl.Scripts = append(jl.Scripts, js)
+	}
+	fl := t.FeatureGraph()
+	for i := range fl.Len() {
+		tag, _ := fl.TagAt(i)
+		jf := jsonFeature{Index: i, Tag: navTagName(tag), Lookups: []int{}}
+		if f := fl.featureAtIndex(i); f != nil {
+			for j := range f.LookupCount() {
+				jf.Lookups = append(jf.Lookups, f.LookupIndex(j))
+			}
+		}
+		jl.Features = append(jl.Features, jf)
+	}
+	for i, lookup := range t.LookupGraph().Range() {
+		jl.Lookups = append(jl.Lookups, x.lookup(i, lookup))
+	}
+	return jl
+}
+
+func exportLangSys(ls *LangSys) jsonLangSys {
+	jls := jsonLangSys{Features: []int{}}
+	if req, ok := ls.RequiredFeatureIndex(); ok {
+		r := int(req)
+		jls.Required = &r
+	}
+	for inx := range ls.Range() {
+		jls.Features = append(jls.Features, inx)
+	}
+	return jls
+}
+
+func (x jsonExporter) lookup(i int, lt *LookupTable) jsonLookup {
+	jl := jsonLookup{Index: i, Subtables: []jsonSubtable{}}
+	if lt == nil {
+		jl.Error = "lookup missing"
+		return jl
+	}
+	jl.Type, jl.Flag = int(lt.Type), uint16(lt.Flag)
+	if mfs, ok := lt.UsesMarkFilteringSet(); ok {
+		jl.MarkFilteringSet = &mfs
+	}
+	if lt.err != nil {
+		jl.Error = lt.err.Error()
+	}
+	for _, node := range lt.Range() {
+		jl.Subtables = append(jl.Subtables, x.subtable(node))
+	}
+	return jl
+}
+
+func (x jsonExporter) subtable(node *LookupNode) jsonSubtable {
+	if node == nil {
+		return jsonSubtable{Error: "subtable missing"}
+	}
+	node = node.Unwrap()
+	js := jsonSubtable{
+		Type:      int(node.LookupType),
+		Format:    node.Format,
+		Extension: node.Wrapper() != nil,
+	}
+	if node.err != nil {
+		js.Error = node.err.Error()
+		return js
+	}
+	if cov, ok := firstCoverage(node); ok && !x.noGlyphs {
+		js.Coverage = x.glyphs(cov)
+	}
+	return js
+}
+
+func (x jsonExporter) glyphs(cov Coverage) []GlyphIndex {
+	glyphs := []GlyphIndex{}
+	forCoverageGlyphs(cov, func(g GlyphIndex) {
+		glyphs = append(glyphs, g)
+	})
+	return glyphs
+}
+
+// classRanges returns the non-zero classes of a class definition table as ranges
+// of consecutive glyphs.
+func classRanges(cdef *ClassDefinitions) []jsonClassRange {
+	var ranges []jsonClassRange
+	add := func(g GlyphIndex, clz int) {
+		if clz == 0 {
+			return
+		}
+		if n := len(ranges); n > 0 && ranges[n-1].Class == clz && ranges[n-1].Last+1 == g {
+			ranges[n-1].Last = g
+			return
+		}
+		ranges = append(ranges, jsonClassRange{First: g, Last: g, Class: clz})
+	}
+	switch r := cdef.records.(type) {
+	case *classDefinitionsFormat1:
+		for i := range r.count {
+			add(r.start+GlyphIndex(i), int(r.valueArray.Get(i).U16(0)))
+		}
+	case *classDefinitionsFormat2:
+		for i := range r.count {
+			rec := r.classRanges.Get(i)
+			from, to, clz := uint32(rec.U16(0)), uint32(rec.U16(2)), int(rec.U16(4))
+			for g := from; g <= to; g++ {
+				add(GlyphIndex(g), clz)
+			}
+		}
+	}
+	return ranges
+}
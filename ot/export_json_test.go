@@ -0,0 +1,82 @@
+package ot
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/npillmayer/schuko/tracing/gotestingadapter"
+)
+
+func TestExportJSON(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	var buf bytes.Buffer
+	if err := ExportJSON(otf.Table(T("GSUB")), &buf); err != nil {
+		t.Fatal(err)
+	}
+	var gsub jsonTable
+	if err := json.Unmarshal(buf.Bytes(), &gsub); err != nil {
+		t.Fatalf("cannot decode exported GSUB: %v", err)
+	}
+	if gsub.Tag != "GSUB" || gsub.Layout == nil {
+		t.Fatalf("expected GSUB layout export, have tag=%q", gsub.Tag)
+	}
+	lytt := &otf.Layout.GSub.LayoutTable
+	if len(gsub.Layout.Lookups) != lytt.LookupGraph().Len() {
+		t.Errorf("expected %d lookups, have %d", lytt.LookupGraph().Len(), len(gsub.Layout.Lookups))
+	}
+	if len(gsub.Layout.Features) != lytt.FeatureGraph().Len() {
+		t.Errorf("expected %d features, have %d", lytt.FeatureGraph().Len(), len(gsub.Layout.Features))
+	}
+	covered := 0
+	for _, lookup := range gsub.Layout.Lookups {
+		for _, st := range lookup.Subtables {
+			if st.Error != "" {
+				t.Errorf("lookup %d: unexpected subtable error %q", lookup.Index, st.Error)
+			}
+			covered += len(st.Coverage)
+		}
+	}
+	if covered == 0 {
+		t.Errorf("expected resolved coverages in GSUB export")
+	}
+	//
+	buf.Reset()
+	if err := ExportJSON(otf.Table(T("GDEF")), &buf, ExportIndented); err != nil {
+		t.Fatal(err)
+	}
+	var gdef jsonTable
+	if err := json.Unmarshal(buf.Bytes(), &gdef); err != nil {
+		t.Fatalf("cannot decode exported GDEF: %v", err)
+	}
+	if gdef.GDef == nil || len(gdef.GDef.GlyphClasses) == 0 {
+		t.Fatalf("expected glyph classes in GDEF export")
+	}
+	marks := 0
+	for i, r := range gdef.GDef.GlyphClasses {
+		if r.Last < r.First || (i > 0 && r.First <= gdef.GDef.GlyphClasses[i-1].Last) {
+			t.Errorf("glyph class ranges not ordered at %d: %+v", i, r)
+		}
+		if r.Class == 3 { // GDEF glyph class 3: mark
+			marks += int(r.Last-r.First) + 1
+		}
+	}
+	if marks == 0 {
+		t.Errorf("expected mark glyphs in GDEF export")
+	}
+	//
+	buf.Reset()
+	if err := ExportJSON(otf.Table(T("cmap")), &buf, ExportNoGlyphs); err != nil {
+		t.Fatal(err)
+	}
+	var cmap jsonTable
+	if err := json.Unmarshal(buf.Bytes(), &cmap); err != nil {
+		t.Fatalf("cannot decode exported cmap: %v", err)
+	}
+	if cmap.CMap == nil || cmap.CMap.Codepoints == 0 || len(cmap.CMap.Ranges) != 0 {
+		t.Errorf("expected cmap summary without ranges, have %+v", cmap.CMap)
+	}
+}
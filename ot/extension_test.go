@@ -0,0 +1,98 @@
+package ot
+
+import "testing"
+
+// synthExtensionLayout builds a GSUB/GPOS table with empty script and feature
+// lists and a single lookup of type extType, with one extension subtable. The
+// extension wraps subtable wrapped (of type wrappedType), placed gap bytes after
+// the extension subtable.
+func synthExtensionLayout(extType, wrappedType uint16, wrapped []byte, gap int) []byte {
+	const extAt = 28
+	b := make([]byte, extAt+8+gap+len(wrapped))
+	putU16(b, 0, 1)  // major version
+	putU16(b, 4, 10) // ScriptList (empty)
+	putU16(b, 6, 12) // FeatureList (empty)
+	putU16(b, 8, 14) // LookupList
+	putU16(b, 14, 1) // lookup count
+	putU16(b, 16, 6) // lookup offset
+	putU16(b, 20, extType)
+	putU16(b, 24, 1)        // subtable count
+	putU16(b, 26, extAt-20) // subtable offset
+	putU16(b, extAt, 1)     // extension format
+	putU16(b, extAt+2, wrappedType)
+	putU32(b, extAt+4, uint32(8+gap))
+	copy(b[extAt+8+gap:], wrapped)
+	return b
+}
+
+func TestGSubExtensionOffsetBeyond64K(t *testing.T) {
+	single := make([]byte, 12)
+	putU16(single, 0, 1) // format
+	putU16(single, 2, 6) // coverage offset
+	putU16(single, 4, 3) // delta
+	copy(single[6:], coverageFmt1(42))
+	gap := 70000 - 8
+	b := synthExtensionLayout(uint16(GSubLookupTypeExtensionSubs), uint16(GSubLookupTypeSingle), single, gap)
+	//
+	ec := &errorCollector{}
+	table, err := parseGSub(T("GSUB"), b, 0, uint32(len(b)), ec)
+	if err != nil || len(ec.errors) > 0 {
+		t.Fatalf("cannot parse GSUB: %v %v", err, ec.errors)
+	}
+	sub := table.Self().AsGSub().LookupGraph().Lookup(0).Subtable(0)
+	if sub.Error() != nil || sub.LookupType != GSubLookupTypeSingle {
+		t.Fatalf("expected resolved single substitution, have type %d, err=%v", sub.LookupType, sub.Error())
+	}
+	info, ok := sub.Extension()
+	if !ok || info.WrappedType != GSubLookupTypeSingle || info.Offset != 70000 {
+		t.Fatalf("unexpected extension info %+v (ok=%v)", info, ok)
+	}
+	if winfo, _ := sub.Wrapper().Extension(); winfo != info {
+		t.Errorf("expected wrapper to report the same extension info, have %+v", winfo)
+	}
+	if _, ok := sub.Coverage.Match(42); !ok {
+		t.Errorf("expected glyph 42 to be covered by the wrapped subtable")
+	}
+	if p := sub.GSubPayload().SingleFmt1; p == nil || p.DeltaGlyphID != 3 {
+		t.Errorf("expected single substitution with delta 3, have %+v", p)
+	}
+}
+
+func TestGPosExtensionOffsetBeyond64K(t *testing.T) {
+	single := make([]byte, 14)
+	putU16(single, 0, 1) // format
+	putU16(single, 2, 8) // coverage offset
+	putU16(single, 4, uint16(ValueFormatXAdvance))
+	putU16(single, 6, uint16(0xffce)) // -50
+	copy(single[8:], coverageFmt1(7))
+	gap := 1<<17 - 8
+	b := synthExtensionLayout(9, uint16(GPosLookupTypeSingle), single, gap)
+	//
+	ec := &errorCollector{}
+	table, err := parseGPos(T("GPOS"), b, 0, uint32(len(b)), ec)
+	if err != nil || len(ec.errors) > 0 {
+		t.Fatalf("cannot parse GPOS: %v %v", err, ec.errors)
+	}
+	sub := table.Self().AsGPos().LookupGraph().Lookup(0).Subtable(0)
+	if sub.Error() != nil || GPosLookupType(sub.LookupType) != GPosLookupTypeSingle {
+		t.Fatalf("expected resolved single positioning, have type %d, err=%v", sub.LookupType, sub.Error())
+	}
+	info, ok := sub.Extension()
+	if !ok || GPosLookupType(info.WrappedType) != GPosLookupTypeSingle || info.Offset != 1<<17 {
+		t.Fatalf("unexpected extension info %+v (ok=%v)", info, ok)
+	}
+	if p := sub.GPosPayload().SingleFmt1; p == nil || p.Value.XAdvance != -50 {
+		t.Errorf("expected single positioning with x-advance -50, have %+v", p)
+	}
+	// offset pointing past the end of the table
+	putU32(b, 28+4, uint32(len(b)))
+	ec = &errorCollector{}
+	table, _ = parseGPos(T("GPOS"), b, 0, uint32(len(b)), ec)
+	if len(ec.errors) != 1 {
+		t.Errorf("expected out-of-bounds extension offset to be reported, have %v", ec.errors)
+	}
+	sub = table.Self().AsGPos().LookupGraph().Lookup(0).Subtable(0)
+	if _, ok := sub.Extension(); ok || sub.Error() == nil {
+		t.Errorf("expected unresolved extension subtable to be flagged")
+	}
+}
@@ -0,0 +1,71 @@
+package ot
+
+import "math"
+
+// Number types of the OpenType specification, see
+// https://learn.microsoft.com/en-us/typography/opentype/spec/otff#data-types
+//
+// Values computed from font data, e.g. by applying variation deltas or by
+// scaling, have to be rounded back to these types. All conversions round with
+// Round, so results match those of other font tools and shaping engines.
+
+// F2Dot14 is a signed 2.14 fixed-point number, as used for normalized
+// variation coordinates. It holds values from -2 to almost +2 in steps of
+// 1/16384.
+type F2Dot14 int16
+
+// F2Dot14From converts x to the nearest F2Dot14, clamping values out of range.
+func F2Dot14From(x float64) F2Dot14 {
+	return F2Dot14(clampRound(x*(1<<14), math.MinInt16, math.MaxInt16))
+}
+
+// Float returns f as a floating-point number.
+func (f F2Dot14) Float() float64 {
+	return float64(f) / (1 << 14)
+}
+
+// Fixed is a signed 16.16 fixed-point number, as used for the axis values of
+// table 'fvar'.
+type Fixed int32
+
+// FixedFrom converts x to the nearest Fixed, clamping values out of range.
+func FixedFrom(x float64) Fixed {
+	return Fixed(clampRound(x*(1<<16), math.MinInt32, math.MaxInt32))
+}
+
+// Float returns f as a floating-point number.
+func (f Fixed) Float() float64 {
+	return float64(f) / (1 << 16)
+}
+
+// FWord is a signed quantity in font design units, e.g. a coordinate or a
+// positioning adjustment.
+type FWord int16
+
+// FWordFrom converts x to the nearest FWord, clamping values out of range.
+func FWordFrom(x float64) FWord {
+	return FWord(clampRound(x, math.MinInt16, math.MaxInt16))
+}
+
+// UFWord is an unsigned quantity in font design units, e.g. an advance width.
+type UFWord uint16
+
+// UFWordFrom converts x to the nearest UFWord, clamping values out of range.
+func UFWordFrom(x float64) UFWord {
+	return UFWord(clampRound(x, 0, math.MaxUint16))
+}
+
+// Round rounds x to the nearest integer, rounding half-way values towards
+// positive infinity, as fontTools and HarfBuzz do. Note that math.Round rounds
+// half-way values away from zero, which differs for negative values: Round
+// maps -2.5 to -2, math.Round to -3.
+func Round(x float64) int {
+	return int(math.Floor(x + 0.5))
+}
+
+func clampRound(x float64, lo, hi int) int {
+	if math.IsNaN(x) {
+		return 0
+	}
+	return Round(max(float64(lo), min(float64(hi), x)))
+}
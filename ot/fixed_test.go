@@ -0,0 +1,40 @@
+package ot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFixedPointConversions(t *testing.T) {
+	for _, tc := range []struct{ x, want float64 }{
+		{0.5, 0.5}, {-1, -1}, {1.99999, 1.99993896484375}, {3, 1.99993896484375}, {-3, -2},
+		{-1.0 / 32768, 0}, {1.0 / 32768, 1.0 / 16384},
+	} {
+		if have := F2Dot14From(tc.x).Float(); have != tc.want {
+			t.Errorf("F2Dot14 of %v: expected %v, have %v", tc.x, tc.want, have)
+		}
+	}
+	if f := FixedFrom(-12.25); f != -12*65536-16384 || f.Float() != -12.25 {
+		t.Errorf("expected Fixed of -12.25 to be exact, have %d", f)
+	}
+	if f := FixedFrom(math.NaN()); f != 0 {
+		t.Errorf("expected Fixed of NaN to be 0, have %d", f)
+	}
+	for _, tc := range []struct {
+		x    float64
+		want int
+	}{{2.5, 3}, {-2.5, -2}, {-2.51, -3}, {0.49, 0}} {
+		if have := Round(tc.x); have != tc.want {
+			t.Errorf("Round(%v): expected %d, have %d", tc.x, tc.want, have)
+		}
+	}
+	if w := FWordFrom(40000); w != math.MaxInt16 {
+		t.Errorf("expected FWord to clamp, have %d", w)
+	}
+	if w := FWordFrom(-0.5); w != 0 {
+		t.Errorf("expected FWord of -0.5 to round towards positive infinity, have %d", w)
+	}
+	if w := UFWordFrom(-3); w != 0 {
+		t.Errorf("expected UFWord to clamp at 0, have %d", w)
+	}
+}
@@ -0,0 +1,62 @@
+package ot
+
+import "testing"
+
+func TestGDefLigatureCarets(t *testing.T) {
+	b := make([]byte, 54)
+	putU16(b, 0, 1)  // major version
+	putU16(b, 8, 12) // offset to LigCaretList
+	// LigCaretList at 12
+	putU16(b, 12, 6)  // coverage offset
+	putU16(b, 14, 1)  // ligature glyph count
+	putU16(b, 16, 12) // offset to LigGlyph #0
+	copy(b[18:], coverageFmt1(77))
+	// LigGlyph at 24
+	putU16(b, 24, 3)
+	putU16(b, 26, 8)
+	putU16(b, 28, 12)
+	putU16(b, 30, 16)
+	putU16(b, 32, 1) // CaretValue format 1
+	putU16(b, 34, 500)
+	putU16(b, 36, 2) // CaretValue format 2
+	putU16(b, 38, 7)
+	putU16(b, 40, 3) // CaretValue format 3
+	putU16(b, 42, 1000)
+	putU16(b, 44, 6) // offset to Device table
+	putU16(b, 46, 12)
+	putU16(b, 48, 14)
+	putU16(b, 50, DeltaFormatLocal4BitDeltas)
+	putU16(b, 52, 0x1f20) // deltas +1, -1, +2
+	//
+	ec := &errorCollector{}
+	table, err := parseGDef(T("GDEF"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatalf("cannot parse GDEF: %v", err)
+	}
+	gdef := table.Self().AsGDef()
+	if carets := gdef.LigatureCarets(78); carets != nil {
+		t.Errorf("did not expect carets for glyph 78, have %v", carets)
+	}
+	carets := gdef.LigatureCarets(77)
+	if len(carets) != 3 {
+		t.Fatalf("expected 3 carets for glyph 77, have %d", len(carets))
+	}
+	if carets[0].Format != CaretValueFormat1 || carets[0].Coordinate != 500 {
+		t.Errorf("unexpected caret #0: %+v", carets[0])
+	}
+	if carets[1].Format != CaretValueFormat2 || carets[1].PointIndex != 7 {
+		t.Errorf("unexpected caret #1: %+v", carets[1])
+	}
+	if carets[2].Format != CaretValueFormat3 || carets[2].Coordinate != 1000 || carets[2].Device == nil {
+		t.Fatalf("unexpected caret #2: %+v", carets[2])
+	}
+	dev := carets[2].Device
+	for ppem, delta := range map[uint16]int{11: 0, 12: 1, 13: -1, 14: 2, 15: 0} {
+		if d := dev.Delta(ppem); d != delta {
+			t.Errorf("expected device delta %d at %d ppem, have %d", delta, ppem, d)
+		}
+	}
+	if _, _, ok := dev.VariationIndex(); ok {
+		t.Errorf("did not expect device table to be a variation index")
+	}
+}
@@ -0,0 +1,21 @@
+package ot
+
+import "testing"
+
+func TestGlyphClassDefEnumValues(t *testing.T) {
+	b := make([]byte, 12)
+	putU16(b, 0, 1)  // major version
+	putU16(b, 4, 12) // offset to GlyphClassDef
+	b = append(b, classDefFmt1(1, 1, 2, 3, 4)...)
+	ec := &errorCollector{}
+	table, err := parseGDef(T("GDEF"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatalf("cannot parse GDEF: %v", err)
+	}
+	gdef := table.Self().AsGDef()
+	for g, class := range []GlyphClassDefEnum{0, BaseGlyph, LigatureGlyph, MarkGlyph, ComponentGlyph, 0} {
+		if c := GlyphClassDefEnum(gdef.GlyphClassDef.Lookup(GlyphIndex(g))); c != class {
+			t.Errorf("glyph %d: expected GDEF glyph class %d, have %d", g, class, c)
+		}
+	}
+}
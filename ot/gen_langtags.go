@@ -0,0 +1,135 @@
+//go:build ignore
+
+// gen_langtags generates langtags_table.go from the OpenType language system
+// tag registry:
+//
+//	go run gen_langtags.go -registry languagetags.htm
+//
+// The registry may be downloaded from
+// https://learn.microsoft.com/en-us/typography/opentype/spec/languagetags.
+// If an ISO 639 code is listed for more than one language system, the first
+// one in registry order is used, unless overridden by preferred.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"html"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// preferred resolves ISO 639 codes mapped to more than one language system.
+var preferred = map[string]string{
+	"ell": "ELL ", // not Polytonic Greek
+	"gle": "IRI ", // not Irish Traditional
+	"hye": "HYE ", // not Armenian East
+	"mal": "MAL ", // not Malayalam Reformed
+	"zho": "ZHS ", // Chinese variants are resolved by script and region
+	"cmn": "ZHS ",
+	"nor": "NOR ", // macrolanguage of Bokmål and Nynorsk
+}
+
+// iso639Alpha2 maps ISO 639-1 codes, used as primary language subtags in
+// BCP 47, to ISO 639-3 codes.
+var iso639Alpha2 = map[string]string{
+	"aa": "aar", "ab": "abk", "ae": "ave", "af": "afr", "ak": "aka", "am": "amh",
+	"an": "arg", "ar": "ara", "as": "asm", "av": "ava", "ay": "aym", "az": "aze",
+	"ba": "bak", "be": "bel", "bg": "bul", "bi": "bis", "bm": "bam", "bn": "ben",
+	"bo": "bod", "br": "bre", "bs": "bos", "ca": "cat", "ce": "che", "ch": "cha",
+	"co": "cos", "cr": "cre", "cs": "ces", "cu": "chu", "cv": "chv", "cy": "cym",
+	"da": "dan", "de": "deu", "dv": "div", "dz": "dzo", "ee": "ewe", "el": "ell",
+	"en": "eng", "eo": "epo", "es": "spa", "et": "est", "eu": "eus", "fa": "fas",
+	"ff": "ful", "fi": "fin", "fj": "fij", "fo": "fao", "fr": "fra", "fy": "fry",
+	"ga": "gle", "gd": "gla", "gl": "glg", "gn": "grn", "gu": "guj", "gv": "glv",
+	"ha": "hau", "he": "heb", "hi": "hin", "ho": "hmo", "hr": "hrv", "ht": "hat",
+	"hu": "hun", "hy": "hye", "hz": "her", "ia": "ina", "id": "ind", "ie": "ile",
+	"ig": "ibo", "ii": "iii", "ik": "ipk", "io": "ido", "is": "isl", "it": "ita",
+	"iu": "iku", "ja": "jpn", "jv": "jav", "ka": "kat", "kg": "kon", "ki": "kik",
+	"kj": "kua", "kk": "kaz", "kl": "kal", "km": "khm", "kn": "kan", "ko": "kor",
+	"kr": "kau", "ks": "kas", "ku": "kur", "kv": "kom", "kw": "cor", "ky": "kir",
+	"la": "lat", "lb": "ltz", "lg": "lug", "li": "lim", "ln": "lin", "lo": "lao",
+	"lt": "lit", "lu": "lub", "lv": "lav", "mg": "mlg", "mh": "mah", "mi": "mri",
+	"mk": "mkd", "ml": "mal", "mn": "mon", "mr": "mar", "ms": "msa", "mt": "mlt",
+	"my": "mya", "na": "nau", "nb": "nob", "nd": "nde", "ne": "nep", "ng": "ndo",
+	"nl": "nld", "nn": "nno", "no": "nor", "nr": "nbl", "nv": "nav", "ny": "nya",
+	"oc": "oci", "oj": "oji", "om": "orm", "or": "ori", "os": "oss", "pa": "pan",
+	"pi": "pli", "pl": "pol", "ps": "pus", "pt": "por", "qu": "que", "rm": "roh",
+	"rn": "run", "ro": "ron", "ru": "rus", "rw": "kin", "sa": "san", "sc": "srd",
+	"sd": "snd", "se": "sme", "sg": "sag", "si": "sin", "sk": "slk", "sl": "slv",
+	"sm": "smo", "sn": "sna", "so": "som", "sq": "sqi", "sr": "srp", "ss": "ssw",
+	"st": "sot", "su": "sun", "sv": "swe", "sw": "swa", "ta": "tam", "te": "tel",
+	"tg": "tgk", "th": "tha", "ti": "tir", "tk": "tuk", "tl": "tgl", "tn": "tsn",
+	"to": "ton", "tr": "tur", "ts": "tso", "tt": "tat", "tw": "twi", "ty": "tah",
+	"ug": "uig", "uk": "ukr", "ur": "urd", "uz": "uzb", "ve": "ven", "vi": "vie",
+	"vo": "vol", "wa": "wln", "wo": "wol", "xh": "xho", "yi": "yid", "yo": "yor",
+	"za": "zha", "zh": "zho", "zu": "zul",
+}
+
+var rowRE = regexp.MustCompile(`<td>([^<]*)</td>\s*<td>'([^']{4})'</td>\s*<td>([^<]*)</td>`)
+
+func main() {
+	registry := flag.String("registry", "languagetags.htm", "path of the OpenType language tag registry page")
+	out := flag.String("o", "langtags_table.go", "output file")
+	flag.Parse()
+	page, err := os.ReadFile(*registry)
+	if err != nil {
+		log.Fatal(err)
+	}
+	tags := map[string]string{}
+	for _, m := range rowRE.FindAllStringSubmatch(string(page), -1) {
+		tag := m[2]
+		for _, code := range strings.Split(html.UnescapeString(m[3]), ",") {
+			code = strings.TrimSpace(code)
+			if len(code) != 3 {
+				continue
+			}
+			if _, ok := tags[code]; !ok {
+				tags[code] = tag
+			}
+		}
+	}
+	if len(tags) == 0 {
+		log.Fatalf("no language system tags found in %s", *registry)
+	}
+	for code, tag := range preferred {
+		tags[code] = tag
+	}
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_langtags.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package ot\n\n")
+	fmt.Fprintf(&b, "// languageSystemTags maps ISO 639-3 codes to OpenType language system tags.\n")
+	fmt.Fprintf(&b, "var languageSystemTags = map[string]string{\n")
+	codes := make([]string, 0, len(tags))
+	for code := range tags {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	for _, code := range codes {
+		fmt.Fprintf(&b, "\t%q: %q,\n", code, tags[code])
+	}
+	fmt.Fprintf(&b, "}\n\n")
+	fmt.Fprintf(&b, "// iso639Alpha2 maps ISO 639-1 codes to ISO 639-3 codes.\n")
+	fmt.Fprintf(&b, "var iso639Alpha2 = map[string]string{\n")
+	codes = codes[:0]
+	for code := range iso639Alpha2 {
+		codes = append(codes, code)
+	}
+	slices.Sort(codes)
+	for _, code := range codes {
+		fmt.Fprintf(&b, "\t%q: %q,\n", code, iso639Alpha2[code])
+	}
+	fmt.Fprintf(&b, "}\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
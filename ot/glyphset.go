@@ -0,0 +1,204 @@
+package ot
+
+import (
+	"iter"
+	"math/bits"
+)
+
+// GlyphSet is a set of glyphs, represented as a bitset. As glyph IDs are
+// 16-bit values, a set occupies at most 8 KB, and set operations work on
+// 64 glyphs at a time.
+//
+// The zero value is an empty set ready to use. A nil *GlyphSet is treated as
+// an empty set by all methods which do not modify the set.
+type GlyphSet struct {
+	bits []uint64
+}
+
+// NewGlyphSet creates a set containing glyphs.
+func NewGlyphSet(glyphs ...GlyphIndex) *GlyphSet {
+	s := &GlyphSet{}
+	for _, g := range glyphs {
+		s.Add(g)
+	}
+	return s
+}
+
+// GlyphSet returns the set of glyphs covered by the coverage table.
+func (c Coverage) GlyphSet() *GlyphSet {
+	s := &GlyphSet{}
+	s.AddCoverage(c)
+	return s
+}
+
+// Add adds glyph g to the set.
+func (s *GlyphSet) Add(g GlyphIndex) {
+	w := int(g >> 6)
+	if w >= len(s.bits) {
+		s.bits = append(s.bits, make([]uint64, w+1-len(s.bits))...)
+	}
+	s.bits[w] |= 1 << (g & 63)
+}
+
+// AddRange adds the glyphs from, …, to (inclusive) to the set.
+func (s *GlyphSet) AddRange(from, to GlyphIndex) {
+	for g := int(from); g <= int(to); g++ {
+		s.Add(GlyphIndex(g))
+	}
+}
+
+// AddCoverage adds all glyphs of a coverage table to the set. It returns false
+// if the coverage table could not be enumerated completely.
+func (s *GlyphSet) AddCoverage(cov Coverage) bool {
+	return forCoverageGlyphs(cov, s.Add)
+}
+
+// Remove removes glyph g from the set.
+func (s *GlyphSet) Remove(g GlyphIndex) {
+	if w := int(g >> 6); w < len(s.bits) {
+		s.bits[w] &^= 1 << (g & 63)
+	}
+}
+
+// Contains reports whether glyph g is contained in the set.
+func (s *GlyphSet) Contains(g GlyphIndex) bool {
+	if s == nil {
+		return false
+	}
+	w := int(g >> 6)
+	return w < len(s.bits) && s.bits[w]&(1<<(g&63)) != 0
+}
+
+// Len returns the number of glyphs in the set.
+func (s *GlyphSet) Len() int {
+	if s == nil {
+		return 0
+	}
+	n := 0
+	for _, w := range s.bits {
+		n += bits.OnesCount64(w)
+	}
+	return n
+}
+
+// IsEmpty reports whether the set contains no glyphs.
+func (s *GlyphSet) IsEmpty() bool {
+	if s == nil {
+		return true
+	}
+	for _, w := range s.bits {
+		if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Glyphs iterates over the glyphs of the set in ascending order.
+func (s *GlyphSet) Glyphs() iter.Seq[GlyphIndex] {
+	return func(yield func(GlyphIndex) bool) {
+		if s == nil {
+			return
+		}
+		for i, w := range s.bits {
+			for w != 0 {
+				b := bits.TrailingZeros64(w)
+				if !yield(GlyphIndex(i<<6 + b)) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Clone returns a copy of the set.
+func (s *GlyphSet) Clone() *GlyphSet {
+	if s == nil {
+		return &GlyphSet{}
+	}
+	return &GlyphSet{bits: append([]uint64(nil), s.bits...)}
+}
+
+// Equal reports whether s and t contain the same glyphs.
+func (s *GlyphSet) Equal(t *GlyphSet) bool {
+	a, b := s.words(), t.words()
+	if len(a) < len(b) {
+		a, b = b, a
+	}
+	for i, w := range a {
+		if i < len(b) {
+			if w != b[i] {
+				return false
+			}
+		} else if w != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersects reports whether s and t have at least one glyph in common.
+func (s *GlyphSet) Intersects(t *GlyphSet) bool {
+	a, b := s.words(), t.words()
+	for i := range min(len(a), len(b)) {
+		if a[i]&b[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Union returns a new set containing the glyphs of s and of t.
+func (s *GlyphSet) Union(t *GlyphSet) *GlyphSet {
+	u := s.Clone()
+	u.UnionWith(t)
+	return u
+}
+
+// Intersection returns a new set containing the glyphs contained in both s and t.
+func (s *GlyphSet) Intersection(t *GlyphSet) *GlyphSet {
+	a, b := s.words(), t.words()
+	n := min(len(a), len(b))
+	u := &GlyphSet{bits: make([]uint64, n)}
+	for i := range n {
+		u.bits[i] = a[i] & b[i]
+	}
+	return u
+}
+
+// Difference returns a new set containing the glyphs of s which are not
+// contained in t.
+func (s *GlyphSet) Difference(t *GlyphSet) *GlyphSet {
+	u := s.Clone()
+	b := t.words()
+	for i := range min(len(u.bits), len(b)) {
+		u.bits[i] &^= b[i]
+	}
+	return u
+}
+
+// UnionWith adds the glyphs of t to s. It reports whether s changed, which is
+// convenient for computations iterating to a fixed point, such as glyph closures.
+func (s *GlyphSet) UnionWith(t *GlyphSet) bool {
+	b := t.words()
+	if len(b) > len(s.bits) {
+		s.bits = append(s.bits, make([]uint64, len(b)-len(s.bits))...)
+	}
+	changed := false
+	for i, w := range b {
+		if w&^s.bits[i] != 0 {
+			s.bits[i] |= w
+			changed = true
+		}
+	}
+	return changed
+}
+
+// words returns the bitset words of s, tolerating a nil set.
+func (s *GlyphSet) words() []uint64 {
+	if s == nil {
+		return nil
+	}
+	return s.bits
+}
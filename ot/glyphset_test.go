@@ -0,0 +1,61 @@
+package ot
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGlyphSetAlgebra(t *testing.T) {
+	a := NewGlyphSet(1, 5, 64, 300)
+	b := parseCoverage(rangeTableFmt2([3]uint16{4, 6, 0}, [3]uint16{300, 301, 3})).GlyphSet()
+	if b.Len() != 5 {
+		t.Fatalf("expected coverage set of 5 glyphs, have %v", slices.Collect(b.Glyphs()))
+	}
+	check := func(name string, s *GlyphSet, expected ...GlyphIndex) {
+		t.Helper()
+		if glyphs := slices.Collect(s.Glyphs()); !slices.Equal(glyphs, expected) {
+			t.Errorf("%s: expected %v, have %v", name, expected, glyphs)
+		}
+	}
+	check("union", a.Union(b), 1, 4, 5, 6, 64, 300, 301)
+	check("intersection", a.Intersection(b), 5, 300)
+	check("difference", a.Difference(b), 1, 64)
+	check("operands unchanged", a, 1, 5, 64, 300)
+	if !a.Intersects(b) || a.Intersects(NewGlyphSet(2, 3, 65)) {
+		t.Errorf("unexpected result for Intersects")
+	}
+	if !a.Union(b).Equal(b.Union(a)) || a.Equal(b) {
+		t.Errorf("unexpected result for Equal")
+	}
+	if !NewGlyphSet(7).Equal(NewGlyphSet(7, 1000).Difference(NewGlyphSet(1000))) {
+		t.Errorf("expected sets to be equal regardless of trailing empty words")
+	}
+	c := NewGlyphSet(5)
+	if !c.UnionWith(a) || c.UnionWith(a) {
+		t.Errorf("expected UnionWith to report a change exactly once")
+	}
+	c.Remove(64)
+	c.AddRange(65534, 65535)
+	check("remove and add range", c, 1, 5, 300, 65534, 65535)
+	var empty *GlyphSet
+	if !empty.IsEmpty() || empty.Len() != 0 || empty.Contains(1) || a.Intersects(empty) {
+		t.Errorf("expected nil set to behave as empty set")
+	}
+	check("union with nil", a.Union(empty), 1, 5, 64, 300)
+	check("intersection with nil", a.Intersection(empty))
+}
+
+func TestFirstGlyphSetIntersectsSet(t *testing.T) {
+	s := &FirstGlyphSet{}
+	s.glyphs.Add(10)
+	if !s.IntersectsSet(NewGlyphSet(3, 10)) || s.IntersectsSet(NewGlyphSet(3, 11)) {
+		t.Errorf("unexpected result for IntersectsSet")
+	}
+	if s.Glyphs().Len() != 1 {
+		t.Errorf("expected first-glyph set to contain 1 glyph")
+	}
+	s.universal = true
+	if !s.IntersectsSet(nil) || s.Glyphs() != nil {
+		t.Errorf("expected universal set to intersect everything and have no glyph set")
+	}
+}
@@ -0,0 +1,306 @@
+package ot
+
+import "fmt"
+
+// --- Graphite tables -------------------------------------------------------
+//
+// SIL Graphite is a smart-font technology, an alternative to OpenType layout
+// for complex scripts. A Graphite font contains the tables 'Silf' (rules),
+// 'Glat' and 'Gloc' (glyph attributes and their locations) and, optionally,
+// 'Feat' (features) and 'Sill' (language defaults).
+//
+// Package ot does not run Graphite rules, but parses the table headers, so
+// that clients may recognize Graphite fonts and route them to a Graphite
+// engine.
+//
+// See also
+// https://github.com/silnrsi/graphite/blob/master/doc/TableFormats.md
+
+// graphiteCompressed reports whether the table of b, with a version which
+// supports compression, is compressed. The compression scheme is held in the
+// top 5 bits of the 32-bit word following the version.
+func graphiteCompressed(b binarySegm) bool {
+	return len(b) >= 8 && b.U32(4)>>27 != 0
+}
+
+// SilfTable, the Graphite rules table ('Silf'), holds the passes of rules
+// which transform and position glyphs. It consists of subtables, usually one,
+// for different writing systems.
+type SilfTable struct {
+	tableBase
+	Version         uint32 // 16.16 fixed-point version, e.g. 0x00030000
+	CompilerVersion uint32 // version of the compiler producing the rules; 0 before version 3
+	Compressed      bool   // table data is compressed; subtables are not available
+	subtables       []SilfSubtable
+	err             error
+}
+
+func newSilfTable(tag Tag, b binarySegm, offset, size uint32) *SilfTable {
+	t := &SilfTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+// SilfSubtable is the header of a subtable of a 'Silf' table. Passes are
+// applied in order: substitution passes first, followed by positioning and
+// justification passes.
+type SilfSubtable struct {
+	MaxGlyphID     uint16
+	NumPasses      uint8
+	SubstPass      uint8 // index of the first substitution pass
+	PosPass        uint8 // index of the first positioning pass
+	JustPass       uint8 // index of the first justification pass
+	BidiPass       uint8 // index of the pass preceding bidi reordering; 0xff for none
+	Flags          uint8
+	MaxPreContext  uint8
+	MaxPostContext uint8
+}
+
+// Subtables returns the subtable headers of the 'Silf' table.
+func (t *SilfTable) Subtables() []SilfSubtable {
+	if t == nil {
+		return nil
+	}
+	return t.subtables
+}
+
+// Error returns parser/validation errors attached to this Silf table view.
+func (t *SilfTable) Error() error {
+	if t == nil {
+		return nil
+	}
+	return t.err
+}
+
+func parseSilf(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	silf := newSilfTable(tag, b, offset, size)
+	if len(b) < 8 {
+		ec.addError(tag, "Header", fmt.Sprintf("Silf table too small: %d bytes (need at least 8)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("Silf table header too small")
+	}
+	silf.Version = b.U32(0)
+	major := silf.Version >> 16
+	if major < 2 || major > 5 {
+		ec.addError(tag, "Version", fmt.Sprintf("unsupported Silf version 0x%08x", silf.Version), SeverityMajor, offset)
+		silf.err = fmt.Errorf("unsupported Silf version 0x%08x", silf.Version)
+		return silf, nil
+	}
+	if major >= 5 && graphiteCompressed(b) {
+		silf.Compressed = true
+		return silf, nil
+	}
+	at, header := 4, 0 // header: size of subtable fields preceding maxGlyphID
+	if major >= 3 {
+		silf.CompilerVersion = b.U32(4)
+		at, header = 8, 8
+	}
+	if at+4 > len(b) {
+		ec.addError(tag, "Header", "Silf table header out of bounds", SeverityMajor, offset)
+		silf.err = errFontFormat("Silf table header out of bounds")
+		return silf, nil
+	}
+	n := int(b.U16(at))
+	at += 4
+	for i := range n {
+		sub := 0
+		if at+4*i+4 <= len(b) {
+			sub = int(b.U32(at + 4*i))
+		}
+		if sub == 0 || sub+header+14 > len(b) {
+			issue := fmt.Sprintf("subtable #%d out of bounds", i)
+			ec.addError(tag, "Subtable", issue, SeverityMajor, offset+uint32(at+4*i))
+			silf.err = errFontFormat("Silf " + issue)
+			return silf, nil
+		}
+		h := sub + header
+		silf.subtables = append(silf.subtables, SilfSubtable{
+			MaxGlyphID:     b.U16(h),
+			NumPasses:      b[h+6],
+			SubstPass:      b[h+7],
+			PosPass:        b[h+8],
+			JustPass:       b[h+9],
+			BidiPass:       b[h+10],
+			Flags:          b[h+11],
+			MaxPreContext:  b[h+12],
+			MaxPostContext: b[h+13],
+		})
+	}
+	return silf, nil
+}
+
+// GlatTable, the Graphite glyph attributes table ('Glat'), holds the
+// attributes of glyphs, located by table 'Gloc'.
+type GlatTable struct {
+	tableBase
+	Version    uint32 // 16.16 fixed-point version, e.g. 0x00020000
+	Compressed bool   // table data is compressed
+}
+
+func newGlatTable(tag Tag, b binarySegm, offset, size uint32) *GlatTable {
+	t := &GlatTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+func parseGlat(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	if len(b) < 4 {
+		ec.addError(tag, "Header", fmt.Sprintf("Glat table too small: %d bytes (need at least 4)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("Glat table header too small")
+	}
+	glat := newGlatTable(tag, b, offset, size)
+	glat.Version = b.U32(0)
+	glat.Compressed = glat.Version>>16 >= 3 && graphiteCompressed(b)
+	return glat, nil
+}
+
+// GlocTable, the Graphite glyph attribute location table ('Gloc'), holds the
+// offsets of the attributes of each glyph into table 'Glat'.
+type GlocTable struct {
+	tableBase
+	Version           uint32 // 16.16 fixed-point version, i.e. 0x00010000
+	LongOffsets       bool   // offsets are 32 bits wide
+	HasAttributeNames bool   // table contains the name IDs of attributes
+	NumAttributes     uint16
+}
+
+func newGlocTable(tag Tag, b binarySegm, offset, size uint32) *GlocTable {
+	t := &GlocTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+func parseGloc(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	if len(b) < 8 {
+		ec.addError(tag, "Header", fmt.Sprintf("Gloc table too small: %d bytes (need at least 8)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("Gloc table header too small")
+	}
+	gloc := newGlocTable(tag, b, offset, size)
+	gloc.Version = b.U32(0)
+	flags := b.U16(4)
+	gloc.LongOffsets = flags&0x1 != 0
+	gloc.HasAttributeNames = flags&0x2 != 0
+	gloc.NumAttributes = b.U16(6)
+	return gloc, nil
+}
+
+// FeatTable, the Graphite features table ('Feat'), lists the features a
+// client may set for a Graphite font, together with their settings.
+type FeatTable struct {
+	tableBase
+	Version  uint32 // 16.16 fixed-point version, e.g. 0x00020000
+	features []GraphiteFeature
+	err      error
+}
+
+func newFeatTable(tag Tag, b binarySegm, offset, size uint32) *FeatTable {
+	t := &FeatTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+// GraphiteFeature is a feature of a Graphite font. Its first setting is the
+// default setting.
+type GraphiteFeature struct {
+	ID       uint32 // feature ID; often a tag, such as 'smcp'
+	Flags    uint16
+	Label    uint16 // name ID of the feature's label
+	Settings []GraphiteFeatureSetting
+}
+
+// GraphiteFeatureSetting is a value of a Graphite feature.
+type GraphiteFeatureSetting struct {
+	Value int16
+	Label uint16 // name ID of the setting's label
+}
+
+// Features returns the features of the 'Feat' table, in table order.
+func (t *FeatTable) Features() []GraphiteFeature {
+	if t == nil {
+		return nil
+	}
+	return t.features
+}
+
+// Error returns parser/validation errors attached to this Feat table view.
+func (t *FeatTable) Error() error {
+	if t == nil {
+		return nil
+	}
+	return t.err
+}
+
+func parseFeat(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	feat := newFeatTable(tag, b, offset, size)
+	if len(b) < 12 {
+		ec.addError(tag, "Header", fmt.Sprintf("Feat table too small: %d bytes (need at least 12)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("Feat table header too small")
+	}
+	feat.Version = b.U32(0)
+	recSize := 12
+	if feat.Version>>16 >= 2 {
+		recSize = 16
+	}
+	fail := func(section, issue string, at int) (Table, error) {
+		ec.addError(tag, section, issue, SeverityMajor, offset+uint32(at))
+		feat.err = errFontFormat("Feat " + issue)
+		return feat, nil
+	}
+	n := int(b.U16(4))
+	if 12+n*recSize > len(b) {
+		return fail("Feature", fmt.Sprintf("%d features out of bounds", n), 12)
+	}
+	feat.features = make([]GraphiteFeature, n)
+	for i := range feat.features {
+		at := 12 + i*recSize
+		f := &feat.features[i]
+		var nSettings, settings int
+		if recSize == 16 {
+			f.ID = b.U32(at)
+			nSettings, settings = int(b.U16(at+4)), int(b.U32(at+8))
+			f.Flags, f.Label = b.U16(at+12), b.U16(at+14)
+		} else {
+			f.ID = uint32(b.U16(at))
+			nSettings, settings = int(b.U16(at+2)), int(b.U32(at+4))
+			f.Flags, f.Label = b.U16(at+8), b.U16(at+10)
+		}
+		if settings+4*nSettings > len(b) {
+			return fail("Setting", fmt.Sprintf("settings of feature #%d out of bounds", i), at)
+		}
+		f.Settings = make([]GraphiteFeatureSetting, nSettings)
+		for k := range f.Settings {
+			f.Settings[k] = GraphiteFeatureSetting{
+				Value: int16(b.U16(settings + 4*k)),
+				Label: b.U16(settings + 4*k + 2),
+			}
+		}
+	}
+	return feat, nil
+}
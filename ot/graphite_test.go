@@ -0,0 +1,90 @@
+package ot
+
+import "testing"
+
+func TestParseSilf(t *testing.T) {
+	// version 3 header with one subtable; subtable fields preceding the pass
+	// counts: ruleVersion, passOffset, pseudosOffset, maxGlyphID, extra
+	// ascent and descent
+	sub := append(aatU32s(0x00030000), aatU16s(0, 0, 99, 0, 0)...)
+	sub = append(sub, 4, 1, 3, 4, 2, 0, 1, 2)
+	b := append(aatU32s(0x00030000, 0x00040001), aatU16s(1, 0)...)
+	b = append(append(b, aatU32s(16)...), sub...)
+	ec := &errorCollector{}
+	table, err := parseSilf(T("Silf"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	silf := table.Self().AsSilf()
+	if silf.Error() != nil || silf.CompilerVersion != 0x00040001 || len(silf.Subtables()) != 1 {
+		t.Fatalf("unexpected Silf table %+v, error %v", silf, silf.Error())
+	}
+	want := SilfSubtable{MaxGlyphID: 99, NumPasses: 4, SubstPass: 1, PosPass: 3, JustPass: 4,
+		BidiPass: 2, MaxPreContext: 1, MaxPostContext: 2}
+	if s := silf.Subtables()[0]; s != want {
+		t.Errorf("expected subtable %+v, have %+v", want, s)
+	}
+	// subtable offset beyond the end of the table
+	b = append(aatU32s(0x00020000), aatU16s(1, 0)...)
+	b = append(b, aatU32s(64)...)
+	table, _ = parseSilf(T("Silf"), b, 0, uint32(len(b)), ec)
+	if silf := table.Self().AsSilf(); silf.Error() == nil || len(ec.errors) == 0 {
+		t.Errorf("expected error for Silf subtable out of bounds")
+	}
+	// compressed version 5 table
+	b = aatU32s(0x00050000, 1<<27|1000)
+	table, _ = parseSilf(T("Silf"), b, 0, uint32(len(b)), &errorCollector{})
+	if silf := table.Self().AsSilf(); !silf.Compressed || silf.Error() != nil {
+		t.Errorf("expected compressed Silf table, have %+v", silf)
+	}
+}
+
+func TestParseFeat(t *testing.T) {
+	// version 2: one feature 'smcp' with settings 0 and 1
+	b := append(aatU32s(0x00020000), aatU16s(1, 0)...)
+	b = append(b, aatU32s(0, 0x736d6370)...)
+	b = append(b, aatU16s(2, 0)...)
+	b = append(b, aatU32s(28)...)
+	b = append(b, aatU16s(0x0800, 256, 0, 257, 1, 258)...)
+	ec := &errorCollector{}
+	table, err := parseFeat(T("Feat"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	feat := table.Self().AsFeat()
+	if feat.Error() != nil || len(feat.Features()) != 1 {
+		t.Fatalf("unexpected Feat table %+v, error %v", feat, feat.Error())
+	}
+	f := feat.Features()[0]
+	if Tag(f.ID) != T("smcp") || f.Flags != 0x0800 || f.Label != 256 || len(f.Settings) != 2 ||
+		f.Settings[1] != (GraphiteFeatureSetting{Value: 1, Label: 258}) {
+		t.Errorf("unexpected feature %+v", f)
+	}
+	// version 1: settings out of bounds
+	b = append(aatU32s(0x00010000), aatU16s(1, 0)...)
+	b = append(b, aatU32s(0)...)
+	b = append(b, aatU16s(7, 3)...)
+	b = append(b, aatU32s(24)...)
+	b = append(b, aatU16s(0, 256)...)
+	table, _ = parseFeat(T("Feat"), b, 0, uint32(len(b)), ec)
+	if feat := table.Self().AsFeat(); feat.Error() == nil {
+		t.Errorf("expected error for Feat settings out of bounds")
+	}
+}
+
+func TestParseGlatGloc(t *testing.T) {
+	ec := &errorCollector{}
+	b := aatU32s(0x00030000, 0)
+	table, _ := parseGlat(T("Glat"), b, 0, uint32(len(b)), ec)
+	if glat := table.Self().AsGlat(); glat.Version != 0x00030000 || glat.Compressed {
+		t.Errorf("unexpected Glat table %+v", glat)
+	}
+	b = append(aatU32s(0x00010000), aatU16s(3, 12, 0, 4, 8)...)
+	table, _ = parseGloc(T("Gloc"), b, 0, uint32(len(b)), ec)
+	if gloc := table.Self().AsGloc(); !gloc.LongOffsets || !gloc.HasAttributeNames || gloc.NumAttributes != 12 {
+		t.Errorf("unexpected Gloc table %+v", gloc)
+	}
+	if _, err := parseGloc(T("Gloc"), b[:6], 0, 6, ec); err == nil {
+		t.Errorf("expected error for truncated Gloc table")
+	}
+}
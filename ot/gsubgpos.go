@@ -1,8 +1,6 @@
 package ot
 
-import (
-	"strconv"
-)
+import "strconv"
 
 // ----------------------------------------------------------------------
 // GSUB
@@ -145,6 +143,10 @@ const (
 // ValueRecord represents a positioning adjustment for a glyph.
 // The actual fields present depend on the ValueFormat bitmask.
 // https://docs.microsoft.com/en-us/typography/opentype/spec/gpos#value-record
+//
+// Device tables adjust the design-unit values, either for specific sizes
+// (hinted fonts) or for positions in the design space (variable fonts).
+// Use Resolve to fold them into the design-unit values.
 type ValueRecord struct {
 	XPlacement int16  // Horizontal adjustment for placement, in design units
 	YPlacement int16  // Vertical adjustment for placement, in design units
@@ -154,6 +156,35 @@ type ValueRecord struct {
 	YPlaDevice uint16 // Offset to Device table for vertical placement (may be NULL)
 	XAdvDevice uint16 // Offset to Device table for horizontal advance (may be NULL)
 	YAdvDevice uint16 // Offset to Device table for vertical advance (may be NULL)
+
+	XPlaDeviceTable *DeviceTable // Device or VariationIndex table at XPlaDevice, if any
+	YPlaDeviceTable *DeviceTable // Device or VariationIndex table at YPlaDevice, if any
+	XAdvDeviceTable *DeviceTable // Device or VariationIndex table at XAdvDevice, if any
+	YAdvDeviceTable *DeviceTable // Device or VariationIndex table at YAdvDevice, if any
+}
+
+// Resolve returns vr with the adjustments of its device tables folded into the
+// design-unit values, for text set at ppem pixels per em and, for variable fonts,
+// at normalized design-space coordinates coords (one per axis, see package otvar).
+// The device tables of the result are cleared.
+//
+// Size-specific adjustments are ignored if ppem is 0. Variation adjustments
+// require the item variation store of table GDEF; with coords being nil, they
+// resolve to the default instance.
+func (vr ValueRecord) Resolve(ppem uint16, coords []float64) ValueRecord {
+	adjust := func(v int16, d *DeviceTable) int16 {
+		if d == nil {
+			return v
+		}
+		return int16(FWordFrom(float64(v) + d.Adjustment(ppem, coords)))
+	}
+	vr.XPlacement = adjust(vr.XPlacement, vr.XPlaDeviceTable)
+	vr.YPlacement = adjust(vr.YPlacement, vr.YPlaDeviceTable)
+	vr.XAdvance = adjust(vr.XAdvance, vr.XAdvDeviceTable)
+	vr.YAdvance = adjust(vr.YAdvance, vr.YAdvDeviceTable)
+	vr.XPlaDevice, vr.YPlaDevice, vr.XAdvDevice, vr.YAdvDevice = 0, 0, 0, 0
+	vr.XPlaDeviceTable, vr.YPlaDeviceTable, vr.XAdvDeviceTable, vr.YAdvDeviceTable = nil, nil, nil, nil
+	return vr
 }
 
 // AnchorFormat represents the format of an Anchor table.
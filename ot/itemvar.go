@@ -0,0 +1,163 @@
+package ot
+
+import "fmt"
+
+// ItemVariationStore holds variation deltas for values of variable fonts, such
+// as the VariationIndex tables of GPOS value records. Deltas are addressed by
+// an outer index (selecting an item variation data subtable) and an inner index
+// (selecting a delta set within it).
+// https://learn.microsoft.com/en-us/typography/opentype/spec/otvarcommonformats#item-variation-store
+type ItemVariationStore struct {
+	regions [][]variationRegionAxis // variation regions, each with one entry per axis
+	data    []itemVariationData
+}
+
+// variationRegionAxis is the extent of a variation region along a single axis,
+// in normalized coordinates.
+type variationRegionAxis struct {
+	start, peak, end float64
+}
+
+// itemVariationData holds rows of deltas, one row per item, one column per
+// referenced region.
+type itemVariationData struct {
+	regionIndexes []uint16
+	deltas        [][]int32
+}
+
+// ParseItemVariationStore reads an item variation store located at the start
+// of b. Tables other than GDEF, such as 'HVAR' or 'MVAR' of variable fonts,
+// embed item variation stores as well.
+func ParseItemVariationStore(data []byte) (*ItemVariationStore, error) {
+	b := binarySegm(data)
+	if len(b) < 8 {
+		return nil, errBufferBounds
+	}
+	if format := b.U16(0); format != 1 {
+		return nil, fmt.Errorf("unsupported item variation store format %d", format)
+	}
+	regionListOffset := int(b.U32(2))
+	dataCount := int(b.U16(6))
+	if 8+dataCount*4 > len(b) || regionListOffset+4 > len(b) {
+		return nil, errBufferBounds
+	}
+	store := &ItemVariationStore{}
+	axisCount, regionCount := int(b.U16(regionListOffset)), int(b.U16(regionListOffset+2))
+	if regionListOffset+4+regionCount*axisCount*6 > len(b) {
+		return nil, errBufferBounds
+	}
+	f2dot14 := func(at int) float64 { return F2Dot14(b.U16(at)).Float() }
+	store.regions = make([][]variationRegionAxis, regionCount)
+	at := regionListOffset + 4
+	for i := range store.regions {
+		region := make([]variationRegionAxis, axisCount)
+		for a := range region {
+			region[a] = variationRegionAxis{start: f2dot14(at), peak: f2dot14(at + 2), end: f2dot14(at + 4)}
+			at += 6
+		}
+		store.regions[i] = region
+	}
+	store.data = make([]itemVariationData, dataCount)
+	for i := range store.data {
+		off := int(b.U32(8 + i*4))
+		if off == 0 {
+			continue
+		}
+		ivd, err := parseItemVariationData(b, off, regionCount)
+		if err != nil {
+			return nil, fmt.Errorf("item variation data #%d: %w", i, err)
+		}
+		store.data[i] = ivd
+	}
+	return store, nil
+}
+
+func parseItemVariationData(b binarySegm, off, regionCount int) (itemVariationData, error) {
+	if off+6 > len(b) {
+		return itemVariationData{}, errBufferBounds
+	}
+	itemCount, wordCount := int(b.U16(off)), b.U16(off+2)
+	longWords := wordCount&0x8000 != 0
+	words := int(wordCount & 0x7fff)
+	ivd := itemVariationData{regionIndexes: make([]uint16, b.U16(off+4))}
+	at := off + 6
+	if at+len(ivd.regionIndexes)*2 > len(b) {
+		return itemVariationData{}, errBufferBounds
+	}
+	for k := range ivd.regionIndexes {
+		if ivd.regionIndexes[k] = b.U16(at); int(ivd.regionIndexes[k]) >= regionCount {
+			return itemVariationData{}, fmt.Errorf("region index %d out of range", ivd.regionIndexes[k])
+		}
+		at += 2
+	}
+	wordSize, byteSize := 2, 1
+	if longWords {
+		wordSize, byteSize = 4, 2
+	}
+	rowSize := words*wordSize + (len(ivd.regionIndexes)-words)*byteSize
+	if words > len(ivd.regionIndexes) || at+itemCount*rowSize > len(b) {
+		return itemVariationData{}, errBufferBounds
+	}
+	ivd.deltas = make([][]int32, itemCount)
+	for j := range ivd.deltas {
+		row := make([]int32, len(ivd.regionIndexes))
+		for k := range row {
+			switch {
+			case longWords && k < words:
+				row[k] = int32(b.U32(at))
+				at += 4
+			case longWords || k < words:
+				row[k] = int32(int16(b.U16(at)))
+				at += 2
+			default:
+				row[k] = int32(int8(b[at]))
+				at++
+			}
+		}
+		ivd.deltas[j] = row
+	}
+	return ivd, nil
+}
+
+// Delta returns the interpolated delta for delta set (outer, inner) at
+// normalized design space coordinates coords, one per axis of the font.
+// Out-of-range indexes result in a delta of 0.
+func (s *ItemVariationStore) Delta(outer, inner uint16, coords []float64) float64 {
+	if s == nil || int(outer) >= len(s.data) || int(inner) >= len(s.data[outer].deltas) {
+		return 0
+	}
+	ivd := s.data[outer]
+	sum := 0.0
+	for k, d := range ivd.deltas[inner] {
+		if d == 0 {
+			continue
+		}
+		sum += float64(d) * regionScalar(s.regions[ivd.regionIndexes[k]], coords)
+	}
+	return sum
+}
+
+// regionScalar returns the factor with which deltas for a region are applied
+// at normalized coordinates coords.
+func regionScalar(region []variationRegionAxis, coords []float64) float64 {
+	s := 1.0
+	for i, ra := range region {
+		if ra.peak == 0 || ra.start > ra.peak || ra.peak > ra.end || (ra.start < 0 && ra.end > 0) {
+			continue
+		}
+		v := 0.0
+		if i < len(coords) {
+			v = coords[i]
+		}
+		switch {
+		case v == ra.peak:
+		case v <= ra.start || v >= ra.end:
+			return 0
+		case v < ra.peak:
+			s *= (v - ra.start) / (ra.peak - ra.start)
+		default:
+			s *= (ra.end - v) / (ra.end - ra.peak)
+		}
+	}
+	return s
+}
@@ -0,0 +1,285 @@
+package ot
+
+import "fmt"
+
+// --- JSTF table ------------------------------------------------------------
+
+// JstfTable, the Justification table (JSTF), provides data to shrink or extend
+// a line of text. For each script and language system, it lists justification
+// priorities, each consisting of GSUB and GPOS lookups to enable or disable.
+// Clients apply one priority after the other until the line is justified.
+//
+// See also
+// https://learn.microsoft.com/en-us/typography/opentype/spec/jstf
+type JstfTable struct {
+	tableBase
+	versionHeader
+	scripts baseTagOffset16View
+	err     error
+}
+
+func newJstfTable(tag Tag, b binarySegm, offset, size uint32) *JstfTable {
+	t := &JstfTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+// JstfScript is a projected view over a JstfScript table.
+type JstfScript struct {
+	raw            binarySegm
+	extenderOffset uint16
+	defaultLangSys uint16
+	langSys        baseTagOffset16View
+}
+
+// JstfLangSys is a projected view over a JstfLangSys table, i.e. the list of
+// justification priorities of a language system.
+type JstfLangSys struct {
+	raw   binarySegm
+	count int
+}
+
+// JstfPriority holds the lookup modifications of a justification priority.
+// Lookup indices refer to the lookup lists of GSUB and GPOS, respectively.
+//
+// JstfMax tables, which hold GPOS lookups limiting the adjustment of a
+// priority, are not interpreted; see ShrinkageMaxCount and ExtensionMaxCount.
+type JstfPriority struct {
+	ShrinkageEnableGSUB  []uint16 // GSUB lookups to enable for shrinking
+	ShrinkageDisableGSUB []uint16 // GSUB lookups to disable for shrinking
+	ShrinkageEnableGPOS  []uint16 // GPOS lookups to enable for shrinking
+	ShrinkageDisableGPOS []uint16 // GPOS lookups to disable for shrinking
+	ExtensionEnableGSUB  []uint16 // GSUB lookups to enable for extension
+	ExtensionDisableGSUB []uint16 // GSUB lookups to disable for extension
+	ExtensionEnableGPOS  []uint16 // GPOS lookups to enable for extension
+	ExtensionDisableGPOS []uint16 // GPOS lookups to disable for extension
+	shrinkageMax         int
+	extensionMax         int
+}
+
+// Version returns major and minor JSTF table version numbers.
+func (t *JstfTable) Version() (uint16, uint16) {
+	if t == nil {
+		return 0, 0
+	}
+	return t.Major, t.Minor
+}
+
+// ScriptTags returns the tags of the scripts with justification data.
+func (t *JstfTable) ScriptTags() []Tag {
+	if t == nil {
+		return nil
+	}
+	return t.scripts.tags()
+}
+
+// Script returns the justification data for a script.
+func (t *JstfTable) Script(tag Tag) (*JstfScript, bool) {
+	if t == nil {
+		return nil, false
+	}
+	off, ok := t.scripts.LookupOffset(tag)
+	if !ok {
+		return nil, false
+	}
+	return viewJstfScript(t.data, off)
+}
+
+// Error returns parser/validation errors attached to this JSTF table view.
+func (t *JstfTable) Error() error {
+	if t == nil {
+		return nil
+	}
+	return t.err
+}
+
+// ExtenderGlyphs returns the glyphs, such as kashida, which may be inserted to
+// extend a line of the script.
+func (s *JstfScript) ExtenderGlyphs() []GlyphIndex {
+	if s == nil || s.extenderOffset == 0 {
+		return nil
+	}
+	list := jstfU16List(s.raw, int(s.extenderOffset))
+	glyphs := make([]GlyphIndex, len(list))
+	for i, g := range list {
+		glyphs[i] = GlyphIndex(g)
+	}
+	return glyphs
+}
+
+// DefaultLangSys returns the justification priorities of the default language
+// system, if present.
+func (s *JstfScript) DefaultLangSys() (*JstfLangSys, bool) {
+	if s == nil {
+		return nil, false
+	}
+	return viewJstfLangSys(s.raw, s.defaultLangSys)
+}
+
+// LangSys returns the justification priorities of a language system.
+func (s *JstfScript) LangSys(tag Tag) (*JstfLangSys, bool) {
+	if s == nil {
+		return nil, false
+	}
+	off, ok := s.langSys.LookupOffset(tag)
+	if !ok {
+		return nil, false
+	}
+	return viewJstfLangSys(s.raw, off)
+}
+
+// LangSysTags returns the tags of the language systems with specific
+// justification data.
+func (s *JstfScript) LangSysTags() []Tag {
+	if s == nil {
+		return nil
+	}
+	return s.langSys.tags()
+}
+
+// PriorityCount returns the number of justification priorities.
+func (l *JstfLangSys) PriorityCount() int {
+	if l == nil {
+		return 0
+	}
+	return l.count
+}
+
+// Priority returns justification priority i, where priority 0 is the first
+// one to apply.
+func (l *JstfLangSys) Priority(i int) (*JstfPriority, bool) {
+	if l == nil || i < 0 || i >= l.count {
+		return nil, false
+	}
+	off := int(l.raw.U16(2 + 2*i))
+	if off == 0 || off+20 > len(l.raw) {
+		return nil, false
+	}
+	raw := l.raw[off:]
+	list := func(at int) []uint16 {
+		return jstfU16List(raw, int(raw.U16(at)))
+	}
+	p := &JstfPriority{
+		ShrinkageEnableGSUB:  list(0),
+		ShrinkageDisableGSUB: list(2),
+		ShrinkageEnableGPOS:  list(4),
+		ShrinkageDisableGPOS: list(6),
+		ExtensionEnableGSUB:  list(10),
+		ExtensionDisableGSUB: list(12),
+		ExtensionEnableGPOS:  list(14),
+		ExtensionDisableGPOS: list(16),
+	}
+	if m := int(raw.U16(8)); m != 0 && m+2 <= len(raw) {
+		p.shrinkageMax = int(raw.U16(m))
+	}
+	if m := int(raw.U16(18)); m != 0 && m+2 <= len(raw) {
+		p.extensionMax = int(raw.U16(m))
+	}
+	return p, true
+}
+
+// ShrinkageMaxCount returns the number of lookups of the JstfMax table limiting
+// shrinkage.
+func (p *JstfPriority) ShrinkageMaxCount() int {
+	if p == nil {
+		return 0
+	}
+	return p.shrinkageMax
+}
+
+// ExtensionMaxCount returns the number of lookups of the JstfMax table limiting
+// extension.
+func (p *JstfPriority) ExtensionMaxCount() int {
+	if p == nil {
+		return 0
+	}
+	return p.extensionMax
+}
+
+func viewJstfScript(jstf binarySegm, offset uint16) (*JstfScript, bool) {
+	if offset == 0 || int(offset)+6 > len(jstf) {
+		return nil, false
+	}
+	raw := jstf[offset:]
+	langs, err := parseTagOffset16View(raw, 4)
+	if err != nil {
+		return nil, false
+	}
+	return &JstfScript{
+		raw:            raw,
+		extenderOffset: raw.U16(0),
+		defaultLangSys: raw.U16(2),
+		langSys:        langs,
+	}, true
+}
+
+func viewJstfLangSys(script binarySegm, offset uint16) (*JstfLangSys, bool) {
+	if offset == 0 || int(offset)+2 > len(script) {
+		return nil, false
+	}
+	raw := script[offset:]
+	count := int(raw.U16(0))
+	if 2+2*count > len(raw) {
+		return nil, false
+	}
+	return &JstfLangSys{raw: raw, count: count}, true
+}
+
+// jstfU16List reads a list of uint16 values, preceded by a count, at offset.
+// It is used for extender glyphs as well as for JstfModList tables.
+func jstfU16List(raw binarySegm, offset int) []uint16 {
+	if offset == 0 || offset+2 > len(raw) {
+		return nil
+	}
+	count := int(raw.U16(offset))
+	if offset+2+2*count > len(raw) {
+		count = (len(raw) - offset - 2) / 2
+	}
+	list := make([]uint16, count)
+	for i := range list {
+		list[i] = raw.U16(offset + 2 + 2*i)
+	}
+	return list
+}
+
+func (v baseTagOffset16View) tags() []Tag {
+	tags := make([]Tag, 0, v.count)
+	for i := 0; i < v.count; i++ {
+		tag, _, _ := v.Record(i)
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// parseJstf parses the header and script list of a JSTF table. Script and
+// language system data is projected on access.
+func parseJstf(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	jstf := newJstfTable(tag, b, offset, size)
+	if len(b) < 6 {
+		ec.addError(tag, "Header", fmt.Sprintf("JSTF table too small: %d bytes (need at least 6)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("JSTF table header too small")
+	}
+	jstf.Major = b.U16(0)
+	jstf.Minor = b.U16(2)
+	if jstf.Major != 1 {
+		ec.addError(tag, "Version", fmt.Sprintf("unsupported JSTF major version %d", jstf.Major), SeverityMajor, offset)
+		jstf.err = fmt.Errorf("unsupported JSTF major version %d", jstf.Major)
+	}
+	scripts, err := parseTagOffset16View(b, 4)
+	if err != nil {
+		ec.addError(tag, "ScriptList", "JSTF script records out of bounds", SeverityMajor, offset)
+		if jstf.err == nil {
+			jstf.err = errFontFormat("JSTF script records out of bounds")
+		}
+		return jstf, nil
+	}
+	jstf.scripts = scripts
+	return jstf, nil
+}
@@ -0,0 +1,90 @@
+package ot
+
+import (
+	"slices"
+	"testing"
+)
+
+// buildJstf creates a JSTF table for script 'arab' with an extender glyph and
+// a default language system with one priority.
+func buildJstf() []byte {
+	b := make([]byte, 64)
+	putU16(b, 0, 1) // version 1.0
+	putU16(b, 4, 1) // one script
+	copy(b[6:], "arab")
+	putU16(b, 10, 12) // JstfScript
+	script := b[12:]
+	putU16(script, 0, 6)  // extender glyphs
+	putU16(script, 2, 10) // default JstfLangSys
+	putU16(script[6:], 0, 1)
+	putU16(script[6:], 2, 7) // extender glyph 7
+	langSys := script[10:]
+	putU16(langSys, 0, 1) // one priority
+	putU16(langSys, 2, 4)
+	prio := langSys[4:]
+	putU16(prio, 0, 20)  // shrinkage enable GSUB
+	putU16(prio, 14, 26) // extension enable GPOS
+	putU16(prio, 18, 30) // extension JstfMax
+	putU16(prio, 20, 2)
+	putU16(prio, 22, 3)
+	putU16(prio, 24, 4)
+	putU16(prio, 26, 1)
+	putU16(prio, 28, 5)
+	putU16(prio, 30, 1) // one JstfMax lookup
+	return b
+}
+
+func TestParseJstf(t *testing.T) {
+	ec := &errorCollector{}
+	table, err := parseJstf(T("JSTF"), buildJstf(), 0, 64, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	jstf := table.Self().AsJstf()
+	if jstf == nil || jstf.Error() != nil || len(ec.errors) > 0 {
+		t.Fatalf("expected JSTF table without errors, have %v", ec.errors)
+	}
+	if tags := jstf.ScriptTags(); !slices.Equal(tags, []Tag{T("arab")}) {
+		t.Errorf("script tags = %v, want [arab]", tags)
+	}
+	if _, ok := jstf.Script(T("latn")); ok {
+		t.Errorf("expected no justification data for script latn")
+	}
+	script, ok := jstf.Script(T("arab"))
+	if !ok {
+		t.Fatalf("expected justification data for script arab")
+	}
+	if glyphs := script.ExtenderGlyphs(); !slices.Equal(glyphs, []GlyphIndex{7}) {
+		t.Errorf("extender glyphs = %v, want [7]", glyphs)
+	}
+	langSys, ok := script.DefaultLangSys()
+	if !ok || langSys.PriorityCount() != 1 {
+		t.Fatalf("expected default language system with 1 priority")
+	}
+	prio, ok := langSys.Priority(0)
+	if !ok {
+		t.Fatalf("cannot read priority 0")
+	}
+	if !slices.Equal(prio.ShrinkageEnableGSUB, []uint16{3, 4}) || !slices.Equal(prio.ExtensionEnableGPOS, []uint16{5}) {
+		t.Errorf("unexpected lookup lists %v and %v", prio.ShrinkageEnableGSUB, prio.ExtensionEnableGPOS)
+	}
+	if prio.ShrinkageDisableGPOS != nil || prio.ExtensionMaxCount() != 1 || prio.ShrinkageMaxCount() != 0 {
+		t.Errorf("unexpected priority %+v", prio)
+	}
+	if _, ok := langSys.Priority(1); ok {
+		t.Errorf("expected priority 1 to be absent")
+	}
+}
+
+func TestParseJstfTruncated(t *testing.T) {
+	ec := &errorCollector{}
+	b := buildJstf()
+	putU16(b, 4, 20) // script count exceeds table
+	table, err := parseJstf(T("JSTF"), b[:20], 0, 20, ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if jstf := table.Self().AsJstf(); jstf.Error() == nil || len(ec.errors) == 0 {
+		t.Errorf("expected error for truncated script records")
+	}
+}
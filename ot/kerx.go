@@ -0,0 +1,181 @@
+package ot
+
+import "fmt"
+
+// --- kerx table ------------------------------------------------------------
+
+// KerxTable, the extended kerning table (kerx) of Apple Advanced Typography,
+// holds kerning data for AAT shaping. It consists of subtables of different
+// formats: ordered lists of kerning pairs (format 0), class-based kerning
+// (formats 2 and 6) and state machines for contextual kerning (format 1) and
+// for attaching glyphs at control points or anchors (format 4).
+//
+// Package ot does not apply 'kerx' tables, but makes their structure available
+// for inspection. Kerning values of formats 0, 2 and 6 may be queried with
+// KerxSubtable.Kern; variations of kerning values are not interpreted.
+//
+// See also
+// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6kerx.html
+type KerxTable struct {
+	tableBase
+	Version   uint16
+	subtables []KerxSubtable
+	err       error
+}
+
+func newKerxTable(tag Tag, b binarySegm, offset, size uint32) *KerxTable {
+	t := &KerxTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+// Coverage flags of 'kerx' subtables.
+const (
+	KerxVertical    uint32 = 0x80000000 // kerning values are for vertical text
+	KerxCrossStream uint32 = 0x40000000 // kerning is perpendicular to the flow of text
+	KerxVariation   uint32 = 0x20000000 // kerning values vary with font variations
+)
+
+// KerxSubtable is a subtable of a 'kerx' table. Access to the data of a
+// subtable depends on its format; methods for other formats report false.
+type KerxSubtable struct {
+	Format     uint8
+	Coverage   uint32 // coverage flags, see KerxVertical etc.
+	TupleCount uint32 // number of variation tuples; 0 for fonts without variations
+	raw        binarySegm
+}
+
+// Subtables returns the subtables of the 'kerx' table.
+func (t *KerxTable) Subtables() []KerxSubtable {
+	if t == nil {
+		return nil
+	}
+	return t.subtables
+}
+
+// Error returns parser/validation errors attached to this kerx table view.
+func (t *KerxTable) Error() error {
+	if t == nil {
+		return nil
+	}
+	return t.err
+}
+
+// Kern returns the kerning value for a pair of glyphs, in font units, for
+// subtables of formats 0, 2 and 6.
+func (s KerxSubtable) Kern(left, right GlyphIndex) (int, bool) {
+	const header = 12
+	switch s.Format {
+	case 0:
+		n := int(s.raw.U32(header))
+		key := uint32(left)<<16 | uint32(right)
+		lo, hi := 0, n
+		for lo < hi {
+			h := lo + (hi-lo)/2
+			at := header + 16 + 6*h
+			if at+6 > len(s.raw) {
+				return 0, false
+			}
+			switch pair := s.raw.U32(at); {
+			case pair < key:
+				lo = h + 1
+			case pair > key:
+				hi = h
+			default:
+				return int(int16(s.raw.U16(at + 4))), true
+			}
+		}
+	case 2:
+		leftClasses, ok1 := viewAATLookup(s.raw, int(s.raw.U32(header+4)), 2)
+		rightClasses, ok2 := viewAATLookup(s.raw, int(s.raw.U32(header+8)), 2)
+		if !ok1 || !ok2 {
+			return 0, false
+		}
+		l, _ := leftClasses.Lookup(left)
+		r, _ := rightClasses.Lookup(right)
+		v, ok := aatU16(s.raw, int(s.raw.U32(header+12)), int(l+r))
+		return int(int16(v)), ok
+	case 6:
+		valueSize := 2
+		if s.raw.U32(header)&1 != 0 { // values are long
+			valueSize = 4
+		}
+		rows, ok1 := viewAATLookup(s.raw, int(s.raw.U32(header+8)), valueSize)
+		columns, ok2 := viewAATLookup(s.raw, int(s.raw.U32(header+12)), valueSize)
+		if !ok1 || !ok2 {
+			return 0, false
+		}
+		l, _ := rows.Lookup(left)
+		r, _ := columns.Lookup(right)
+		array := int(s.raw.U32(header + 16))
+		at := array + int(l+r)*valueSize
+		if array <= 0 || at+valueSize > len(s.raw) {
+			return 0, false
+		}
+		if valueSize == 4 {
+			return int(int32(s.raw.U32(at))), true
+		}
+		return int(int16(s.raw.U16(at))), true
+	}
+	return 0, false
+}
+
+// StateTable returns the state table of a subtable of format 1 (contextual
+// kerning) or 4 (control point or anchor attachment).
+func (s KerxSubtable) StateTable() (*AATStateTable, error) {
+	if s.Format != 1 && s.Format != 4 {
+		return nil, fmt.Errorf("kerx subtable of format %d has no state table", s.Format)
+	}
+	return viewAATStateTable(s.raw[12:], 1)
+}
+
+// KerningValue returns entry i of the kerning value table of a format 1
+// subtable. State table entries refer to the value table by index.
+func (s KerxSubtable) KerningValue(i int) (int, bool) {
+	if s.Format != 1 || len(s.raw) < 12+20 {
+		return 0, false
+	}
+	v, ok := aatU16(s.raw[12:], int(s.raw.U32(12+16)), i)
+	return int(int16(v)), ok
+}
+
+// parseKerx parses the subtable headers of a 'kerx' table. Subtable data is
+// projected on access.
+func parseKerx(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	kerx := newKerxTable(tag, b, offset, size)
+	if len(b) < 8 {
+		ec.addError(tag, "Header", fmt.Sprintf("kerx table too small: %d bytes (need at least 8)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("kerx table header too small")
+	}
+	kerx.Version = b.U16(0)
+	if kerx.Version < 2 || kerx.Version > 4 {
+		ec.addError(tag, "Version", fmt.Sprintf("unsupported kerx version %d", kerx.Version), SeverityMajor, offset)
+		kerx.err = fmt.Errorf("unsupported kerx version %d", kerx.Version)
+		return kerx, nil
+	}
+	at := 8
+	for i := range int(b.U32(4)) {
+		if at+12 > len(b) || int(b.U32(at)) < 12 || at+int(b.U32(at)) > len(b) {
+			issue := fmt.Sprintf("subtable #%d out of bounds", i)
+			ec.addError(tag, "Subtable", issue, SeverityMajor, offset+uint32(at))
+			kerx.err = errFontFormat("kerx " + issue)
+			return kerx, nil
+		}
+		length, coverage := int(b.U32(at)), b.U32(at+4)
+		kerx.subtables = append(kerx.subtables, KerxSubtable{
+			Format:     uint8(coverage & 0xff),
+			Coverage:   coverage &^ 0xff,
+			TupleCount: b.U32(at + 8),
+			raw:        b[at : at+length],
+		})
+		at += length
+	}
+	return kerx, nil
+}
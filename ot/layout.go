@@ -12,6 +12,7 @@ These tables use some of the same data formats.
 import (
 	"fmt"
 	"iter"
+	"slices"
 )
 
 // --- Layout tables ---------------------------------------------------------
@@ -61,7 +62,9 @@ func (t *LayoutTable) Header() LayoutHeader {
 }
 
 // ScriptGraph returns the concrete shared-script graph for this layout table.
-// During transition this may be nil when not yet parsed/instantiated.
+// For tables created by the parser it is never nil; if the corresponding part of
+// the table could not be parsed, the graph is empty and reports the problem
+// with Error().
 func (t *LayoutTable) ScriptGraph() *ScriptList {
 	if t == nil {
 		return nil
@@ -70,7 +73,9 @@ func (t *LayoutTable) ScriptGraph() *ScriptList {
 }
 
 // FeatureGraph returns the concrete shared-feature graph for this layout table.
-// During transition this may be nil when not yet parsed/instantiated.
+// For tables created by the parser it is never nil; if the corresponding part of
+// the table could not be parsed, the graph is empty and reports the problem
+// with Error().
 func (t *LayoutTable) FeatureGraph() *FeatureList {
 	if t == nil {
 		return nil
@@ -79,7 +84,9 @@ func (t *LayoutTable) FeatureGraph() *FeatureList {
 }
 
 // LookupGraph returns the concrete lookup graph for this layout table.
-// During transition this may be nil when not yet parsed/instantiated.
+// For tables created by the parser it is never nil; if the corresponding part of
+// the table could not be parsed, the graph is empty and reports the problem
+// with Error().
 func (t *LayoutTable) LookupGraph() *LookupListGraph {
 	if t == nil {
 		return nil
@@ -87,6 +94,77 @@ func (t *LayoutTable) LookupGraph() *LookupListGraph {
 	return t.lookupGraph
 }
 
+// completeGraphs makes sure that script, feature and lookup graphs are in place,
+// even if parsing of the layout table failed partially. Missing graphs are
+// replaced by empty ones, flagged with err (or a generic format error).
+func (t *LayoutTable) completeGraphs(b binarySegm, err error) {
+	if err == nil {
+		err = errFontFormat("layout table section not parsed")
+	}
+	if t.featureGraph == nil {
+		t.featureGraph = &FeatureList{err: err}
+	}
+	if t.scriptGraph == nil {
+		t.scriptGraph = &ScriptList{featureGraph: t.featureGraph, err: err}
+	}
+	if t.lookupGraph == nil {
+		t.lookupGraph = &LookupListGraph{raw: b, err: err}
+	}
+}
+
+// Scripts returns the tags of all scripts of this layout table, in declaration order.
+func (t *LayoutTable) Scripts() []Tag {
+	if t == nil || t.scriptGraph == nil {
+		return nil
+	}
+	return slices.Clone(t.scriptGraph.scriptOrder)
+}
+
+// Languages returns the tags of all language systems of a script, in declaration
+// order. The default language system of the script is not included.
+func (t *LayoutTable) Languages(script Tag) []Tag {
+	if t == nil {
+		return nil
+	}
+	s := t.scriptGraph.Script(script)
+	if s == nil {
+		return nil
+	}
+	return slices.Clone(s.langOrder)
+}
+
+// Features returns the tags of all features of a language system, with the
+// required feature (if any) first and the others in link order. Duplicate tags are
+// reported once. lang may be DFLT to query the script's default language system.
+func (t *LayoutTable) Features(script, lang Tag) []Tag {
+	if t == nil {
+		return nil
+	}
+	s := t.scriptGraph.Script(script)
+	var lsys *LangSys
+	if lang == DFLT {
+		lsys = s.DefaultLangSys()
+	} else {
+		lsys = s.LangSys(lang)
+	}
+	if lsys == nil {
+		return nil
+	}
+	var tags []Tag
+	add := func(inx int) {
+		if tag, ok := t.featureGraph.TagAt(inx); ok && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	if req, ok := lsys.RequiredFeatureIndex(); ok {
+		add(int(req))
+	}
+	for _, inx := range lsys.featureIndices {
+		add(int(inx))
+	}
+	return tags
+}
+
 // LayoutHeader represents header information common to the layout tables.
 type LayoutHeader struct {
 	versionHeader
@@ -204,6 +282,8 @@ type GDefTable struct {
 	AttachmentPointList    AttachmentPointList
 	MarkAttachmentClassDef ClassDefinitions
 	MarkGlyphSets          []GlyphRange
+	LigCaretList           LigCaretList
+	varStore               *ItemVariationStore
 }
 
 func newGDefTable(tag Tag, b binarySegm, offset, size uint32) *GDefTable {
@@ -224,6 +304,16 @@ func (t *GDefTable) Header() GDefHeader {
 	return t.header
 }
 
+// VariationStore returns the item variation store of a GDEF table of version
+// 1.3, which holds the deltas for VariationIndex tables of variable fonts.
+// It returns nil if there is no item variation store.
+func (t *GDefTable) VariationStore() *ItemVariationStore {
+	if t == nil {
+		return nil
+	}
+	return t.varStore
+}
+
 // GDefHeader contains general information for a Glyph Definition table (GDEF).
 type GDefHeader struct {
 	gDefHeader
@@ -336,6 +426,7 @@ func newBaseTable(tag Tag, b binarySegm, offset, size uint32) *BaseTable {
 type BaseAxis struct {
 	raw          binarySegm
 	baselineTags baseTagListView
+	scriptList   binarySegm // BaseScriptList, base of BaseScript offsets
 	scripts      baseTagOffset16View
 	err          error
 }
@@ -362,11 +453,13 @@ type MinMax struct {
 	raw             binarySegm
 	minCoordOffset  uint16
 	maxCoordOffset  uint16
-	featureMinMaxes baseTagOffset16View
+	featureMinMaxes binarySegm // FeatMinMaxRecords, 8 bytes each
+	featureCount    int
 	err             error
 }
 
-// FeatureMinMax is a projected view over a FeatureTableMinMaxValue table.
+// FeatureMinMax is a projected view over a FeatMinMaxRecord. Its coordinate
+// offsets are relative to the enclosing MinMax table.
 type FeatureMinMax struct {
 	raw            binarySegm
 	minCoordOffset uint16
@@ -446,7 +539,7 @@ func (a *BaseAxis) Script(tag Tag) (*BaseScript, bool) {
 	if !ok || off == 0 {
 		return nil, false
 	}
-	return viewBaseScript(a.raw, off)
+	return viewBaseScript(a.scriptList, off)
 }
 
 // ScriptAt returns script record i as (tag, script, ok).
@@ -458,7 +551,7 @@ func (a *BaseAxis) ScriptAt(i int) (Tag, *BaseScript, bool) {
 	if !ok || off == 0 {
 		return 0, nil, false
 	}
-	s, ok := viewBaseScript(a.raw, off)
+	s, ok := viewBaseScript(a.scriptList, off)
 	return tag, s, ok
 }
 
@@ -626,11 +719,12 @@ func (m *MinMax) Feature(tag Tag) (*FeatureMinMax, bool) {
 	if m == nil {
 		return nil, false
 	}
-	off, ok := m.featureMinMaxes.LookupOffset(tag)
-	if !ok || off == 0 || int(off) >= len(m.raw) {
-		return nil, false
+	for i := 0; i < m.featureCount; i++ {
+		if Tag(m.featureMinMaxes.U32(i*8)) == tag {
+			return viewFeatureMinMax(m.raw, m.featureMinMaxes[i*8:])
+		}
 	}
-	return viewFeatureMinMax(m.raw[off:])
+	return nil, false
 }
 
 // FeatureCount returns the number of feature-specific min/max records.
@@ -638,7 +732,7 @@ func (m *MinMax) FeatureCount() int {
 	if m == nil {
 		return 0
 	}
-	return m.featureMinMaxes.count
+	return m.featureCount
 }
 
 // FeatureAt returns feature record i as (featureTag, minmax, ok).
@@ -646,12 +740,12 @@ func (m *MinMax) FeatureAt(i int) (Tag, *FeatureMinMax, bool) {
 	if m == nil {
 		return 0, nil, false
 	}
-	tag, off, ok := m.featureMinMaxes.Record(i)
-	if !ok || off == 0 || int(off) >= len(m.raw) {
+	if i < 0 || i >= m.featureCount {
 		return 0, nil, false
 	}
-	fmm, ok := viewFeatureMinMax(m.raw[off:])
-	return tag, fmm, ok
+	rec := m.featureMinMaxes[i*8:]
+	fmm, ok := viewFeatureMinMax(m.raw, rec)
+	return Tag(rec.U32(0)), fmm, ok
 }
 
 // RangeFeatures iterates feature-specific MinMax records.
@@ -660,7 +754,7 @@ func (m *MinMax) RangeFeatures() iter.Seq2[Tag, *FeatureMinMax] {
 		if m == nil {
 			return
 		}
-		for i := 0; i < m.featureMinMaxes.count; i++ {
+		for i := 0; i < m.featureCount; i++ {
 			tag, fmm, ok := m.FeatureAt(i)
 			if !ok {
 				continue
@@ -871,6 +965,9 @@ func viewBaseAxis(base binarySegm, offset uint16) (*BaseAxis, bool) {
 		}
 	}
 	axis.scripts, err = parseTagOffset16View(raw, scriptListOffset)
+	if scriptListOffset < len(raw) {
+		axis.scriptList = raw[scriptListOffset:]
+	}
 	if err != nil {
 		if axis.err == nil {
 			axis.err = err
@@ -917,25 +1014,30 @@ func viewMinMax(raw binarySegm) (*MinMax, bool) {
 	if len(raw) < 6 {
 		return &MinMax{raw: raw, err: fmt.Errorf("BASE MinMax table too small")}, false
 	}
-	features, err := parseTagOffset16View(raw, 4)
 	m := &MinMax{
-		raw:             raw,
-		minCoordOffset:  raw.U16(0),
-		maxCoordOffset:  raw.U16(2),
-		featureMinMaxes: features,
-		err:             err,
+		raw:            raw,
+		minCoordOffset: raw.U16(0),
+		maxCoordOffset: raw.U16(2),
+		featureCount:   int(raw.U16(4)),
+	}
+	if 6+8*m.featureCount > len(raw) {
+		m.featureCount = 0
+		m.err = fmt.Errorf("BASE FeatMinMax records out of bounds")
+		return m, false
 	}
-	return m, err == nil
+	m.featureMinMaxes = raw[6 : 6+8*m.featureCount]
+	return m, true
 }
 
-func viewFeatureMinMax(raw binarySegm) (*FeatureMinMax, bool) {
-	if len(raw) < 4 {
-		return &FeatureMinMax{raw: raw, err: fmt.Errorf("BASE FeatureMinMax table too small")}, false
+// viewFeatureMinMax projects a FeatMinMaxRecord rec of MinMax table minmax.
+func viewFeatureMinMax(minmax, rec binarySegm) (*FeatureMinMax, bool) {
+	if len(rec) < 8 {
+		return &FeatureMinMax{raw: minmax, err: fmt.Errorf("BASE FeatMinMax record too small")}, false
 	}
 	f := &FeatureMinMax{
-		raw:            raw,
-		minCoordOffset: raw.U16(0),
-		maxCoordOffset: raw.U16(2),
+		raw:            minmax,
+		minCoordOffset: rec.U16(4),
+		maxCoordOffset: rec.U16(6),
 	}
 	return f, true
 }
@@ -995,39 +1097,48 @@ func (c Coverage) Contains(g GlyphIndex) bool {
 	return ok
 }
 
+// Glyphs iterates over the glyphs of the coverage, in order of their coverage
+// index, independent of the format of the coverage table. For well-formed
+// coverage tables, this is ascending glyph order.
+func (c Coverage) Glyphs() iter.Seq[GlyphIndex] {
+	return func(yield func(GlyphIndex) bool) {
+		if r, ok := c.GlyphRange.(interface {
+			all(func(GlyphIndex, int) bool)
+		}); ok {
+			r.all(func(g GlyphIndex, _ int) bool { return yield(g) })
+		}
+	}
+}
+
 type coverageHeader struct {
 	CoverageFormat uint16
 	Count          uint16
 }
 
 func buildGlyphRangeFromCoverage(chead coverageHeader, b binarySegm) GlyphRange {
-	tracer().Debugf("coverage format = %d, count = %d", chead.CoverageFormat, chead.Count)
-	if chead.CoverageFormat == 1 {
-		return &glyphRangeArray{
-			//is32:     false,                  // entries are uint16
-			count:    int(chead.Count),       // number of entries
-			data:     b[4:],                  // header of format 1 coverage table is 4 bytes long
-			byteSize: int(4 + chead.Count*2), // header is 4, entries are 2 bytes
-		}
+	if traceDebug() {
+		tracer().Debugf("coverage format = %d, count = %d", chead.CoverageFormat, chead.Count)
 	}
-	return &glyphRangeRecords{
-		count:    int(chead.Count),       // number of records
-		data:     b[4:],                  // header of format 2 coverage table is 4 bytes long
-		byteSize: int(4 + chead.Count*6), // header is 4, entries are 6 bytes
+	if chead.CoverageFormat == 1 {
+		// header of format 1 coverage table is 4 bytes long, entries are 2 bytes
+		return newGlyphRangeArray(int(chead.Count), b[4:], int(4+chead.Count*2))
 	}
+	// header of format 2 coverage table is 4 bytes long, entries are 6 bytes
+	return newGlyphRangeRecords(int(chead.Count), b[4:], int(4+chead.Count*6))
 }
 
 // --- Class definition tables -----------------------------------------------
 
 // GlyphClassDefEnum lists the glyph classes for ClassDefinitions
-// ('GlyphClassDef'-table).
+// ('GlyphClassDef'-table). Values are those of the GDEF table, starting at 1;
+// class 0 is the default class of glyphs not listed.
 type GlyphClassDefEnum uint16
 
 const (
-	BaseGlyph      GlyphClassDefEnum = iota //single character, spacing glyph
-	LigatureGlyph                           //multiple character, spacing glyph
-	MarkGlyph                               //non-spacing combining glyph
-	ComponentGlyph                          //part of single character, spacing glyph
+	BaseGlyph      GlyphClassDefEnum = iota + 1 //single character, spacing glyph
+	LigatureGlyph                               //multiple character, spacing glyph
+	MarkGlyph                                   //non-spacing combining glyph
+	ComponentGlyph                              //part of single character, spacing glyph
 )
 
 // ClassDefinitions groups glyphs into classes, denoted as integer values.
@@ -1055,6 +1166,7 @@ func (cdef *ClassDefinitions) setRecords(recs array, startGlyphID GlyphIndex) {
 		cdef.records = &classDefinitionsFormat2{
 			count:       recs.length,
 			classRanges: recs,
+			sorted:      rangeRecordsSorted(recs.loc, recs.length),
 		}
 	default:
 		tracer().Errorf("Unsupported ClassDef format %d", cdef.format)
@@ -1064,6 +1176,7 @@ func (cdef *ClassDefinitions) setRecords(recs array, startGlyphID GlyphIndex) {
 
 type classDefVariant interface {
 	Lookup(GlyphIndex) int
+	all(yield func(GlyphIndex, int) bool)
 }
 
 type classDefinitionsFormat1 struct {
@@ -1080,24 +1193,54 @@ func (cdf *classDefinitionsFormat1) Lookup(glyph GlyphIndex) int {
 	return int(clz)
 }
 
+func (cdf *classDefinitionsFormat1) all(yield func(GlyphIndex, int) bool) {
+	for i := 0; i < cdf.count; i++ {
+		if clz := int(cdf.valueArray.Get(i).U16(0)); clz != 0 && !yield(cdf.start+GlyphIndex(i), clz) {
+			return
+		}
+	}
+}
+
 type classDefinitionsFormat2 struct {
 	count       int   // number of records
 	classRanges array // array of ClassRangeRecords — ordered by startGlyphID
+	sorted      bool  // ranges are disjoint and ordered, enabling binary search
 }
 
 func (cdf *classDefinitionsFormat2) Lookup(glyph GlyphIndex) int {
+	if cdf.sorted {
+		if i, ok := searchRangeRecords(cdf.classRanges.loc, cdf.count, glyph); ok {
+			return int(cdf.classRanges.loc.U16(6*i + 4))
+		}
+		return 0
+	}
 	for i := 0; i < cdf.count; i++ {
 		rec := cdf.classRanges.Get(i)
 		if glyph < GlyphIndex(rec.U16(0)) {
 			return 0
 		}
-		if glyph < GlyphIndex(rec.U16(2)) {
+		if glyph <= GlyphIndex(rec.U16(2)) { // endGlyphID is inclusive
 			return int(rec.U16(4))
 		}
 	}
 	return 0
 }
 
+func (cdf *classDefinitionsFormat2) all(yield func(GlyphIndex, int) bool) {
+	for i := 0; i < cdf.count; i++ {
+		rec := cdf.classRanges.Get(i)
+		clz := int(rec.U16(4))
+		if clz == 0 {
+			continue
+		}
+		for g := int(rec.U16(0)); g <= int(rec.U16(2)); g++ {
+			if !yield(GlyphIndex(g), clz) {
+				return
+			}
+		}
+	}
+}
+
 func (cdef *ClassDefinitions) makeArray(b binarySegm, numEntries int, format uint16) array {
 	var size, recsize int
 	switch format {
@@ -1117,7 +1260,12 @@ func (cdef *ClassDefinitions) makeArray(b binarySegm, numEntries int, format uin
 }
 
 // Lookup returns the class defined for a glyph, or 0 (= default class).
+// Absent class definitions, e.g. of a GDEF table without glyph classes, put
+// every glyph into class 0.
 func (cdef *ClassDefinitions) Lookup(glyph GlyphIndex) int {
+	if cdef == nil || cdef.records == nil {
+		return 0
+	}
 	return cdef.records.Lookup(glyph)
 }
 
@@ -1126,6 +1274,18 @@ func (cdef *ClassDefinitions) Class(glyph GlyphIndex) int {
 	return cdef.Lookup(glyph)
 }
 
+// Classes iterates over the glyphs assigned to a class other than the default
+// class 0, together with their class, in the order of the class definition
+// table, independent of its format. Glyphs not listed belong to class 0.
+func (cdef *ClassDefinitions) Classes() iter.Seq2[GlyphIndex, int] {
+	return func(yield func(GlyphIndex, int) bool) {
+		if cdef == nil || cdef.records == nil {
+			return
+		}
+		cdef.records.all(yield)
+	}
+}
+
 // --- Attachment point list -------------------------------------------------
 
 // An AttachmentPointList consists of a count of the attachment points on a single
@@ -1137,6 +1297,155 @@ type AttachmentPointList struct {
 	attachPointOffsets binarySegm
 }
 
+// LigCaretList defines caret positions for ligature glyphs, to be used for
+// text editing and cursor positioning within ligatures.
+type LigCaretList struct {
+	Coverage        GlyphRange
+	Count           int
+	ligGlyphOffsets binarySegm // offsets to LigGlyph tables, in coverage index order
+	base            binarySegm // start of the LigCaretList table
+}
+
+// CaretValueFormat identifies one of the three formats of a caret value.
+type CaretValueFormat uint16
+
+const (
+	CaretValueFormat1 CaretValueFormat = 1 // Design units only
+	CaretValueFormat2 CaretValueFormat = 2 // Contour point
+	CaretValueFormat3 CaretValueFormat = 3 // Design units plus Device table
+)
+
+// CaretValue is a caret position within a ligature glyph.
+// https://learn.microsoft.com/en-us/typography/opentype/spec/gdef#caret-value-tables
+type CaretValue struct {
+	Format     CaretValueFormat // Format identifier
+	Coordinate int16            // X or Y value, in design units (formats 1 and 3)
+	PointIndex uint16           // Contour point index on the ligature glyph (format 2)
+	Device     *DeviceTable     // Device or variation index table (format 3, may be nil)
+}
+
+// LigatureCarets returns the caret positions within ligature glyph gid, in
+// logical order. If gid is not covered by the ligature caret list, or the
+// caret data of gid is damaged, nil is returned.
+func (t *GDefTable) LigatureCarets(gid GlyphIndex) []CaretValue {
+	if t == nil || t.LigCaretList.Coverage == nil {
+		return nil
+	}
+	inx, ok := t.LigCaretList.Coverage.Match(gid)
+	if !ok || inx >= t.LigCaretList.Count {
+		return nil
+	}
+	return t.LigCaretList.carets(inx)
+}
+
+func (l LigCaretList) carets(inx int) []CaretValue {
+	ligOffset, err := l.ligGlyphOffsets.u16(inx * 2)
+	if err != nil || int(ligOffset) >= len(l.base) {
+		return nil
+	}
+	lig := l.base[ligOffset:]
+	count, err := lig.u16(0)
+	if err != nil || len(lig) < 2+int(count)*2 {
+		return nil
+	}
+	carets := make([]CaretValue, 0, count)
+	for i := range int(count) {
+		off := lig.U16(2 + i*2)
+		cv, ok := parseCaretValue(lig, int(off))
+		if !ok {
+			return nil
+		}
+		carets = append(carets, cv)
+	}
+	return carets
+}
+
+// DeviceTable holds size-specific adjustments of a design-unit value or, for
+// variable fonts, an index into the item variation store.
+// https://learn.microsoft.com/en-us/typography/opentype/spec/chapter2#device-and-variationindex-tables
+type DeviceTable struct {
+	StartSize   uint16 // smallest size to correct, in ppem (outer index for VariationIndex)
+	EndSize     uint16 // largest size to correct, in ppem (inner index for VariationIndex)
+	DeltaFormat uint16 // format of deltas, or DeltaFormatVariationIndex
+	deltas      binarySegm
+	devices     *deviceEnv // context for Adjustment, may be nil
+}
+
+// deviceEnv holds font-wide data needed to resolve the device tables of a
+// layout table. It is shared by all device tables of the table and completed
+// after all tables of a font have been parsed.
+type deviceEnv struct {
+	unitsPerEm uint16              // from table 'head'
+	store      *ItemVariationStore // from table 'GDEF', for variable fonts
+}
+
+// Delta formats of a Device table.
+const (
+	DeltaFormatLocal2BitDeltas uint16 = 1      // signed 2-bit values, 8 per uint16
+	DeltaFormatLocal4BitDeltas uint16 = 2      // signed 4-bit values, 4 per uint16
+	DeltaFormatLocal8BitDeltas uint16 = 3      // signed 8-bit values, 2 per uint16
+	DeltaFormatVariationIndex  uint16 = 0x8000 // table is a VariationIndex table
+)
+
+// Delta returns the adjustment in pixels for a size of ppem pixels per em.
+// For sizes outside of the device table's range, and for VariationIndex tables,
+// 0 is returned.
+func (d *DeviceTable) Delta(ppem uint16) int {
+	if d == nil || ppem < d.StartSize || ppem > d.EndSize {
+		return 0
+	}
+	var bits uint
+	switch d.DeltaFormat {
+	case DeltaFormatLocal2BitDeltas:
+		bits = 2
+	case DeltaFormatLocal4BitDeltas:
+		bits = 4
+	case DeltaFormatLocal8BitDeltas:
+		bits = 8
+	default:
+		return 0
+	}
+	perWord := 16 / bits
+	i := uint(ppem - d.StartSize)
+	word, err := d.deltas.u16(int(i/perWord) * 2)
+	if err != nil {
+		return 0
+	}
+	shift := 16 - bits*(i%perWord+1)
+	v := int(word>>shift) & (1<<bits - 1)
+	if v >= 1<<(bits-1) { // sign-extend
+		v -= 1 << bits
+	}
+	return v
+}
+
+// Adjustment returns the adjustment of d in design units, for text set at ppem
+// pixels per em or, for VariationIndex tables, at normalized design-space
+// coordinates coords. Adjustment returns 0 for device tables not belonging to a
+// GPOS value record, as the units per em and the item variation store of the
+// font are unknown for them.
+func (d *DeviceTable) Adjustment(ppem uint16, coords []float64) float64 {
+	if d == nil || d.devices == nil {
+		return 0
+	}
+	if outer, inner, ok := d.VariationIndex(); ok {
+		return d.devices.store.Delta(outer, inner, coords)
+	}
+	if ppem == 0 || d.devices.unitsPerEm == 0 {
+		return 0
+	}
+	return float64(d.Delta(ppem)) * float64(d.devices.unitsPerEm) / float64(ppem)
+}
+
+// VariationIndex returns the outer and inner index into an item variation store,
+// if d is a VariationIndex table.
+func (d *DeviceTable) VariationIndex() (outer, inner uint16, ok bool) {
+	if d == nil || d.DeltaFormat != DeltaFormatVariationIndex {
+		return 0, 0, false
+	}
+	return d.StartSize, d.EndSize, true
+}
+
 // --- Lookup type helpers ---------------------------------------------------
 
 func GSubLookupType(ltype LayoutTableLookupType) LayoutTableLookupType {
@@ -0,0 +1,100 @@
+package ot
+
+// Feature parameter tables are defined for a few features only. The feature
+// table does not identify the format of its parameters, so clients select the
+// interpretation matching the feature's tag.
+//
+// See https://learn.microsoft.com/en-us/typography/opentype/spec/features_pt#tag-size
+// and https://learn.microsoft.com/en-us/typography/opentype/spec/features_ae#tag-cv01--cv99
+
+// SizeParams holds the parameters of GPOS feature 'size'. Sizes are given in
+// decipoints, i.e., tenths of a point.
+type SizeParams struct {
+	DesignSize      uint16 // size the font has been designed for
+	SubfamilyID     uint16 // identifies fonts of a family differing in optical size only; 0 if not set
+	SubfamilyNameID uint16 // name ID of the subfamily name; 0 if not set
+	RangeStart      uint16 // sizes the font is intended for are > RangeStart …
+	RangeEnd        uint16 // … and ≤ RangeEnd; both are 0 if not set
+}
+
+// StylisticSetParams holds the parameters of GSUB features 'ss01'…'ss20'.
+type StylisticSetParams struct {
+	UINameID uint16 // name ID of the set's user-interface label
+}
+
+// CharacterVariantParams holds the parameters of GSUB features
+// 'cv01'…'cv99'. Name IDs of 0 denote strings which are not set.
+type CharacterVariantParams struct {
+	UINameID           uint16 // name ID of the feature's user-interface label
+	TooltipNameID      uint16 // name ID of a tooltip text
+	SampleTextNameID   uint16 // name ID of a sample text
+	NumNamedParameters uint16 // number of named variants
+	FirstParamUINameID uint16 // name ID of the label of the first variant; others follow consecutively
+	Characters         []rune // characters affected by the feature
+}
+
+// ParamUINameID returns the name ID of the label of variant i, starting at
+// 0, or false if variant i is not named.
+func (p CharacterVariantParams) ParamUINameID(i int) (uint16, bool) {
+	if i < 0 || i >= int(p.NumNamedParameters) || p.FirstParamUINameID == 0 {
+		return 0, false
+	}
+	return p.FirstParamUINameID + uint16(i), true
+}
+
+// SizeParams interprets the parameters of f as those of feature 'size'. It
+// returns false if f has no parameters, if they are too short or if they
+// state no design size.
+func (f *Feature) SizeParams() (SizeParams, bool) {
+	b := binarySegm(f.Params())
+	if len(b) < 10 || b.U16(0) == 0 {
+		return SizeParams{}, false
+	}
+	return SizeParams{
+		DesignSize:      b.U16(0),
+		SubfamilyID:     b.U16(2),
+		SubfamilyNameID: b.U16(4),
+		RangeStart:      b.U16(6),
+		RangeEnd:        b.U16(8),
+	}, true
+}
+
+// StylisticSetParams interprets the parameters of f as those of a stylistic
+// set feature. It returns false if f has no parameters or if their version is
+// unknown.
+func (f *Feature) StylisticSetParams() (StylisticSetParams, bool) {
+	b := binarySegm(f.Params())
+	if len(b) < 4 || b.U16(0) != 0 {
+		return StylisticSetParams{}, false
+	}
+	return StylisticSetParams{UINameID: b.U16(2)}, true
+}
+
+// CharacterVariantParams interprets the parameters of f as those of a
+// character variant feature. It returns false if f has no parameters, if their
+// format is unknown or if they are truncated.
+func (f *Feature) CharacterVariantParams() (CharacterVariantParams, bool) {
+	b := binarySegm(f.Params())
+	if len(b) < 14 || b.U16(0) != 0 {
+		return CharacterVariantParams{}, false
+	}
+	n := int(b.U16(12))
+	if 14+3*n > len(b) {
+		return CharacterVariantParams{}, false
+	}
+	p := CharacterVariantParams{
+		UINameID:           b.U16(2),
+		TooltipNameID:      b.U16(4),
+		SampleTextNameID:   b.U16(6),
+		NumNamedParameters: b.U16(8),
+		FirstParamUINameID: b.U16(10),
+	}
+	if n > 0 {
+		p.Characters = make([]rune, n)
+		for i := range p.Characters {
+			at := 14 + 3*i
+			p.Characters[i] = rune(b[at])<<16 | rune(b[at+1])<<8 | rune(b[at+2])
+		}
+	}
+	return p, true
+}
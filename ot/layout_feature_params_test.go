@@ -0,0 +1,62 @@
+package ot
+
+import "testing"
+
+func featureWithParams(params []byte) *Feature {
+	raw := append(make([]byte, 4), params...) // params offset, lookup count
+	putU16(raw, 0, 4)
+	return &Feature{featureParamsOffset: 4, raw: raw}
+}
+
+func TestFeatureSizeParams(t *testing.T) {
+	f := featureWithParams([]byte{0, 90, 0, 1, 1, 0, 0, 60, 0, 100})
+	p, ok := f.SizeParams()
+	if want := (SizeParams{90, 1, 256, 60, 100}); !ok || p != want {
+		t.Errorf("expected size params %+v, have %+v", want, p)
+	}
+	if _, ok := featureWithParams([]byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0}).SizeParams(); ok {
+		t.Errorf("did not expect size params without a design size")
+	}
+	if _, ok := (&Feature{}).SizeParams(); ok {
+		t.Errorf("did not expect size params for feature without parameters")
+	}
+}
+
+func TestFeatureStylisticSetParams(t *testing.T) {
+	p, ok := featureWithParams([]byte{0, 0, 1, 2}).StylisticSetParams()
+	if !ok || p.UINameID != 258 {
+		t.Errorf("expected stylistic set name ID 258, have %+v", p)
+	}
+	if _, ok := featureWithParams([]byte{0, 1, 1, 2}).StylisticSetParams(); ok {
+		t.Errorf("did not expect stylistic set params of unknown version")
+	}
+}
+
+func TestFeatureCharacterVariantParams(t *testing.T) {
+	params := []byte{
+		0, 0, // format
+		1, 0, 1, 1, 0, 0, // label, tooltip, no sample text
+		0, 3, 1, 2, // 3 named parameters, starting at name ID 258
+		0, 2, // 2 characters
+		0x00, 0x00, 0x67, 0x01, 0xf6, 0x00,
+	}
+	p, ok := featureWithParams(params).CharacterVariantParams()
+	if !ok {
+		t.Fatalf("cannot interpret character variant params")
+	}
+	if p.UINameID != 256 || p.TooltipNameID != 257 || p.SampleTextNameID != 0 || p.NumNamedParameters != 3 {
+		t.Errorf("unexpected character variant params %+v", p)
+	}
+	if len(p.Characters) != 2 || p.Characters[0] != 'g' || p.Characters[1] != 0x1f600 {
+		t.Errorf("expected characters 'g' and U+1F600, have %v", p.Characters)
+	}
+	if id, ok := p.ParamUINameID(2); !ok || id != 260 {
+		t.Errorf("expected name ID 260 for variant 2, have %d", id)
+	}
+	if _, ok := p.ParamUINameID(3); ok {
+		t.Errorf("did not expect a name for variant 3")
+	}
+	if _, ok := featureWithParams(params[:19]).CharacterVariantParams(); ok {
+		t.Errorf("did not expect truncated character variant params")
+	}
+}
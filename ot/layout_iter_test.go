@@ -0,0 +1,112 @@
+package ot
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+// rangeTableFmt2 builds a format 2 coverage or class definition table from
+// (start, end, value) range records; both share the same layout.
+func rangeTableFmt2(ranges ...[3]uint16) []byte {
+	out := make([]byte, 4+len(ranges)*6)
+	putU16(out, 0, 2)
+	putU16(out, 2, uint16(len(ranges)))
+	for i, r := range ranges {
+		putU16(out, 4+i*6, r[0])
+		putU16(out, 6+i*6, r[1])
+		putU16(out, 8+i*6, r[2])
+	}
+	return out
+}
+
+func TestCoverageGlyphs(t *testing.T) {
+	cov := parseCoverage(coverageFmt1(3, 7, 9))
+	if glyphs := slices.Collect(cov.Glyphs()); !slices.Equal(glyphs, []GlyphIndex{3, 7, 9}) {
+		t.Errorf("format 1: expected glyphs [3 7 9], have %v", glyphs)
+	}
+	cov = parseCoverage(rangeTableFmt2([3]uint16{4, 6, 0}, [3]uint16{10, 10, 3}))
+	glyphs := slices.Collect(cov.Glyphs())
+	if !slices.Equal(glyphs, []GlyphIndex{4, 5, 6, 10}) {
+		t.Fatalf("format 2: expected glyphs [4 5 6 10], have %v", glyphs)
+	}
+	for i, g := range glyphs {
+		if inx, ok := cov.Match(g); !ok || inx != i {
+			t.Errorf("format 2: expected glyph %d at coverage index %d, have %d/%v", g, i, inx, ok)
+		}
+	}
+	for g := range cov.Glyphs() {
+		if g != 4 {
+			t.Errorf("expected iteration to stop after first glyph, have %d", g)
+		}
+		break
+	}
+	if glyphs := slices.Collect(Coverage{}.Glyphs()); len(glyphs) != 0 {
+		t.Errorf("expected empty coverage to yield no glyphs, have %v", glyphs)
+	}
+}
+
+func TestClassDefinitionsClasses(t *testing.T) {
+	cdef, err := parseClassDefinitions(classDefFmt1(5, 1, 0, 2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	classes := maps.Collect(cdef.Classes())
+	if !maps.Equal(classes, map[GlyphIndex]int{5: 1, 7: 2}) {
+		t.Errorf("format 1: expected classes {5:1 7:2}, have %v", classes)
+	}
+	cdef, err = parseClassDefinitions(rangeTableFmt2([3]uint16{2, 4, 3}, [3]uint16{8, 8, 1}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	classes = maps.Collect(cdef.Classes())
+	if !maps.Equal(classes, map[GlyphIndex]int{2: 3, 3: 3, 4: 3, 8: 1}) {
+		t.Errorf("format 2: expected classes {2:3 3:3 4:3 8:1}, have %v", classes)
+	}
+	for g := GlyphIndex(0); g < 10; g++ {
+		if clz := cdef.Lookup(g); clz != classes[g] {
+			t.Errorf("format 2: glyph %d: Lookup returns class %d, Classes yields %d", g, clz, classes[g])
+		}
+	}
+	var empty *ClassDefinitions
+	for g := range empty.Classes() {
+		t.Errorf("expected nil class definitions to yield nothing, have %d", g)
+	}
+}
+
+func TestOrderedTags(t *testing.T) {
+	tags := func(s ...string) []Tag {
+		var out []Tag
+		for _, x := range s {
+			out = append(out, T(x))
+		}
+		return out
+	}
+	sl := &ScriptList{scriptOrder: tags("latn", "DFLT", "cyrl")}
+	if have := sl.Tags(); !slices.Equal(have, tags("DFLT", "cyrl", "latn")) {
+		t.Errorf("expected scripts in alphabetical order, have %v", have)
+	}
+	script := &Script{langOrder: tags("TRK ", "DEU ")}
+	if have := script.LangTags(); !slices.Equal(have, tags("DEU ", "TRK ")) {
+		t.Errorf("expected language systems in alphabetical order, have %v", have)
+	}
+	fl := &FeatureList{featureOrder: tags("liga", "kern", "liga", "calt")}
+	if have := fl.Tags(); !slices.Equal(have, tags("calt", "kern", "liga")) {
+		t.Errorf("expected distinct features in alphabetical order, have %v", have)
+	}
+	if (*FeatureList)(nil).Tags() != nil || (*ScriptList)(nil).Tags() != nil || (*Script)(nil).LangTags() != nil {
+		t.Errorf("expected nil lists to have no tags")
+	}
+}
+
+func TestTableTagsInDirectoryOrder(t *testing.T) {
+	otf := loadCalibri(t)
+	tags := otf.TableTags()
+	if len(tags) == 0 || !slices.IsSorted(tags) {
+		t.Errorf("expected table tags in directory order, have %v", tags)
+	}
+	tags[0] = 0
+	if otf.TableTags()[0] == 0 {
+		t.Errorf("expected TableTags to return a copy")
+	}
+}
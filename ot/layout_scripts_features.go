@@ -2,6 +2,7 @@ package ot
 
 import (
 	"iter"
+	"slices"
 	"sync"
 )
 
@@ -120,7 +121,9 @@ func (sl *ScriptList) Script(tag Tag) *Script {
 	return script
 }
 
-// Range iterates scripts in declaration order.
+// Range iterates scripts in declaration order. The OpenType specification
+// requires script records to be sorted alphabetically by tag, but fonts do not
+// always comply; see Tags for a guaranteed order.
 func (sl *ScriptList) Range() iter.Seq2[Tag, *Script] {
 	return func(yield func(Tag, *Script) bool) {
 		if sl == nil {
@@ -134,6 +137,14 @@ func (sl *ScriptList) Range() iter.Seq2[Tag, *Script] {
 	}
 }
 
+// Tags returns the script tags of the list in alphabetical order.
+func (sl *ScriptList) Tags() []Tag {
+	if sl == nil {
+		return nil
+	}
+	return sortedTags(sl.scriptOrder)
+}
+
 // Error returns an accumulated error for the list.
 func (sl *ScriptList) Error() error {
 	if sl == nil {
@@ -195,7 +206,9 @@ func (s *Script) LangSys(tag Tag) *LangSys {
 	return lsys
 }
 
-// Range iterates language-systems in declaration order.
+// Range iterates language-systems in declaration order. Like script records,
+// language-system records are required to be sorted alphabetically by tag;
+// see LangTags for a guaranteed order.
 func (s *Script) Range() iter.Seq2[Tag, *LangSys] {
 	return func(yield func(Tag, *LangSys) bool) {
 		if s == nil {
@@ -209,6 +222,15 @@ func (s *Script) Range() iter.Seq2[Tag, *LangSys] {
 	}
 }
 
+// LangTags returns the tags of the language systems of the script, other than
+// the default language system, in alphabetical order.
+func (s *Script) LangTags() []Tag {
+	if s == nil {
+		return nil
+	}
+	return sortedTags(s.langOrder)
+}
+
 // Error returns an accumulated error for the script.
 func (s *Script) Error() error {
 	if s == nil {
@@ -237,6 +259,22 @@ func (ls *LangSys) FeatureAt(i int) *Feature {
 	return ls.features[i]
 }
 
+// Range iterates the features of the language system in link order, yielding
+// the index into the feature list together with the resolved feature. The
+// required feature (see RequiredFeatureIndex) is not included.
+func (ls *LangSys) Range() iter.Seq2[int, *Feature] {
+	return func(yield func(int, *Feature) bool) {
+		if ls == nil {
+			return
+		}
+		for i, inx := range ls.featureIndices {
+			if !yield(int(inx), ls.FeatureAt(i)) {
+				return
+			}
+		}
+	}
+}
+
 // Features returns resolved features in language-system link order.
 func (ls *LangSys) Features() []*Feature {
 	if ls == nil || len(ls.featureIndices) == 0 {
@@ -280,6 +318,8 @@ func (fl *FeatureList) Len() int {
 }
 
 // Range iterates features in declaration order and preserves duplicate tags.
+// Features are required to be sorted alphabetically by tag, with features of
+// equal tags in no particular order; see Tags for a guaranteed order.
 func (fl *FeatureList) Range() iter.Seq2[Tag, *Feature] {
 	return func(yield func(Tag, *Feature) bool) {
 		if fl == nil {
@@ -293,6 +333,14 @@ func (fl *FeatureList) Range() iter.Seq2[Tag, *Feature] {
 	}
 }
 
+// Tags returns the distinct feature tags of the list in alphabetical order.
+func (fl *FeatureList) Tags() []Tag {
+	if fl == nil {
+		return nil
+	}
+	return sortedTags(fl.featureOrder)
+}
+
 // Indices returns all indices matching a feature tag.
 func (fl *FeatureList) Indices(tag Tag) []int {
 	if fl == nil || fl.indicesByTag == nil {
@@ -365,6 +413,18 @@ func (f *Feature) LookupIndex(i int) int {
 	return int(f.lookupListIndices[i])
 }
 
+// Params returns the raw bytes of the feature's parameters table, starting at
+// the table and extending to the end of the feature list. It returns nil if the
+// feature has no parameters. Parameter tables are defined for features 'size',
+// 'ss01'…'ss20' and 'cv01'…'cv99'; see SizeParams, StylisticSetParams and
+// CharacterVariantParams for typed access.
+func (f *Feature) Params() []byte {
+	if f == nil || f.featureParamsOffset == 0 || int(f.featureParamsOffset) >= len(f.raw) {
+		return nil
+	}
+	return f.raw[f.featureParamsOffset:]
+}
+
 // Error returns an accumulated error for the feature.
 func (f *Feature) Error() error {
 	if f == nil {
@@ -373,6 +433,15 @@ func (f *Feature) Error() error {
 	return f.err
 }
 
+// TagAt returns the tag of the feature at index i of the feature list, i.e. the
+// feature referenced by feature index i of a language system.
+func (fl *FeatureList) TagAt(i int) (Tag, bool) {
+	if fl == nil || i < 0 || i >= len(fl.featureOrder) {
+		return 0, false
+	}
+	return fl.featureOrder[i], true
+}
+
 func (fl *FeatureList) featureAtIndex(i int) *Feature {
 	if fl == nil || i < 0 || i >= len(fl.featureOffsetsByIndex) {
 		return nil
@@ -403,3 +472,11 @@ func (fl *FeatureList) featureAtIndex(i int) *Feature {
 	fl.featuresByIndex[i] = feature
 	return feature
 }
+
+// sortedTags returns the distinct tags of tags in ascending order.
+func sortedTags(tags []Tag) []Tag {
+	if len(tags) == 0 {
+		return nil
+	}
+	return slices.Compact(slices.Sorted(slices.Values(tags)))
+}
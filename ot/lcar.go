@@ -0,0 +1,87 @@
+package ot
+
+import "fmt"
+
+// --- lcar table ------------------------------------------------------------
+
+// LcarTable, the ligature caret table (lcar) of Apple Advanced Typography,
+// holds the positions of carets within ligature glyphs. It corresponds to the
+// ligature caret list of table GDEF.
+//
+// See also
+// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6lcar.html
+type LcarTable struct {
+	tableBase
+	Version uint32 // 16.16 fixed-point version, i.e. 0x00010000
+	Format  uint16 // LcarDistances or LcarControlPoints
+	carets  AATLookup
+	err     error
+}
+
+func newLcarTable(tag Tag, b binarySegm, offset, size uint32) *LcarTable {
+	t := &LcarTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+// Formats of 'lcar' tables.
+const (
+	LcarDistances     uint16 = 0 // caret positions are distances in font units
+	LcarControlPoints uint16 = 1 // caret positions are given by control points of the glyph outline
+)
+
+// Carets returns the caret positions within ligature glyph g, in logical
+// order: distances from the glyph origin in font units for tables of format
+// LcarDistances, control point indices for format LcarControlPoints. If g is
+// not covered by the table, nil is returned.
+func (t *LcarTable) Carets(g GlyphIndex) []int16 {
+	if t == nil || t.err != nil {
+		return nil
+	}
+	at, ok := t.carets.Lookup(g)
+	if !ok || int(at)+2 > len(t.data) {
+		return nil
+	}
+	n := int(t.data.U16(int(at)))
+	if int(at)+2+2*n > len(t.data) {
+		return nil
+	}
+	carets := make([]int16, n)
+	for i := range carets {
+		carets[i] = int16(t.data.U16(int(at) + 2 + 2*i))
+	}
+	return carets
+}
+
+// Error returns parser/validation errors attached to this lcar table view.
+func (t *LcarTable) Error() error {
+	if t == nil {
+		return nil
+	}
+	return t.err
+}
+
+func parseLcar(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	if len(b) < 8 {
+		ec.addError(tag, "Header", fmt.Sprintf("lcar table too small: %d bytes (need at least 8)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("lcar table header too small")
+	}
+	lcar := newLcarTable(tag, b, offset, size)
+	lcar.Version, lcar.Format = b.U32(0), b.U16(4)
+	if lcar.Version != 0x00010000 || lcar.Format > LcarControlPoints {
+		issue := fmt.Sprintf("unsupported lcar version 0x%08x, format %d", lcar.Version, lcar.Format)
+		ec.addError(tag, "Version", issue, SeverityMajor, offset)
+		lcar.err = errFontFormat(issue)
+		return lcar, nil
+	}
+	// values of the lookup table are offsets from the start of the lcar table
+	lcar.carets, _ = viewAATLookup(b, 6, 2)
+	return lcar, nil
+}
@@ -12,6 +12,9 @@ type LookupListGraph struct {
 	lookupTables  []*LookupTable
 	lookupOnce    []sync.Once
 	isGPos        bool
+	numGlyphs     int // number of glyphs from table 'maxp', 0 if unknown
+	devices       *deviceEnv
+	issues        *lookupIssues
 
 	raw binarySegm
 	err error
@@ -23,11 +26,20 @@ type LookupTable struct {
 	Flag             LayoutTableLookupFlag
 	SubTableCount    uint16
 	markFilteringSet uint16
+	hasFilteringSet  bool // flag USE_MARK_FILTERING_SET is set and the set index present
 
 	subtableOffsets []uint16
 	subtables       []*LookupNode
 	subtableOnce    []sync.Once
 
+	firstOnce   sync.Once
+	firstGlyphs *FirstGlyphSet
+
+	numGlyphs int // number of glyphs from table 'maxp', 0 if unknown
+	devices   *deviceEnv
+	index     int // index of the lookup in the lookup list
+	issues    *lookupIssues
+
 	raw binarySegm
 	err error
 }
@@ -41,6 +53,10 @@ type LookupNode struct {
 	GSub       *GSubLookupPayload
 	GPos       *GPosLookupPayload
 
+	wrapper  *LookupNode // extension subtable this node has been resolved from
+	extDepth int         // number of extension subtables enclosing this node
+	devices  *deviceEnv  // context for device tables of value records
+
 	raw binarySegm
 	err error
 }
@@ -65,6 +81,10 @@ func (lg *LookupListGraph) Lookup(i int) *LookupTable {
 			return
 		}
 		lg.lookupTables[i] = parseConcreteLookupTable(lg.raw[off:], lg.isGPos)
+		lg.lookupTables[i].numGlyphs = lg.numGlyphs
+		lg.lookupTables[i].devices = lg.devices
+		lg.lookupTables[i].index = i
+		lg.lookupTables[i].issues = lg.issues
 	})
 	return lg.lookupTables[i]
 }
@@ -83,6 +103,15 @@ func (lg *LookupListGraph) Range() iter.Seq2[int, *LookupTable] {
 	}
 }
 
+// NumGlyphs returns the number of glyphs of the font, as stated by table 'maxp',
+// or 0 if unknown. Glyph IDs at or above NumGlyphs are invalid.
+func (lg *LookupListGraph) NumGlyphs() int {
+	if lg == nil {
+		return 0
+	}
+	return lg.numGlyphs
+}
+
 // Error returns an accumulated parse/validation error for the lookup list graph.
 func (lg *LookupListGraph) Error() error {
 	if lg == nil {
@@ -91,7 +120,8 @@ func (lg *LookupListGraph) Error() error {
 	return lg.err
 }
 
-// MarkFilteringSet returns the optional mark-filtering-set index.
+// MarkFilteringSet returns the optional mark-filtering-set index, or 0 if the
+// lookup does not use a mark filtering set. See UsesMarkFilteringSet.
 func (lt *LookupTable) MarkFilteringSet() uint16 {
 	if lt == nil {
 		return 0
@@ -99,7 +129,51 @@ func (lt *LookupTable) MarkFilteringSet() uint16 {
 	return lt.markFilteringSet
 }
 
+// UsesMarkFilteringSet returns the index of the mark glyph set (see
+// GDefTable.MarkGlyphSets) lt filters marks by, if lt has flag
+// USE_MARK_FILTERING_SET. The bool result is false for lookups without the
+// flag and for lookups with the flag, but truncated before the set index.
+//
+// The set index is not guaranteed to be valid; parsing reports lookups
+// referencing sets the font does not define.
+func (lt *LookupTable) UsesMarkFilteringSet() (uint16, bool) {
+	if lt == nil || !lt.hasFilteringSet {
+		return 0, false
+	}
+	return lt.markFilteringSet, true
+}
+
+// IsReverse reports whether lt is a GSUB reverse chaining single substitution
+// lookup (type 8), either directly or by means of extension subtables. Reverse
+// chaining lookups are applied from the end of a glyph sequence to its start.
+func (lt *LookupTable) IsReverse() bool {
+	if lt == nil || IsGPosLookupType(lt.Type) {
+		return false
+	}
+	switch GSubLookupType(lt.Type) {
+	case GSubLookupTypeReverseChaining:
+		return true
+	case GSubLookupTypeExtensionSubs:
+		sub := lt.Subtable(0)
+		return sub != nil && sub.LookupType == GSubLookupTypeReverseChaining
+	}
+	return false
+}
+
 // Subtable returns a concrete lookup-subtable node by index, lazily instantiated.
+//
+// Extension subtables (GSUB type 7, GPOS type 9) are resolved: Subtable returns
+// the node of the extension's target subtable, with Wrapper() referring to the
+// extension subtable. If an extension cannot be resolved, the extension
+// subtable itself is returned, flagged with an error.
+//
+// Substitute glyph IDs of GSUB subtables are validated against the number of
+// glyphs of the font; a subtable referencing a non-existent glyph is flagged
+// with an error.
+//
+// Subtables flagged with an error are reported to the font's error list (see
+// Font.Errors) on first access. Clients applying lookups should skip them, as
+// their payload may be incomplete.
 func (lt *LookupTable) Subtable(i int) *LookupNode {
 	if lt == nil || i < 0 || i >= len(lt.subtableOffsets) {
 		return nil
@@ -108,9 +182,13 @@ func (lt *LookupTable) Subtable(i int) *LookupNode {
 		off := int(lt.subtableOffsets[i])
 		if off <= 0 || off >= len(lt.raw) {
 			lt.subtables[i] = &LookupNode{err: errBufferBounds}
+			lt.issues.report(lt.index, i, lt.subtables[i])
 			return
 		}
-		lt.subtables[i] = parseConcreteLookupNode(lt.raw[off:], lt.Type)
+		node := parseConcreteLookupNodeWithDepth(lt.raw[off:], lt.Type, 0, lt.devices).Unwrap()
+		checkGSubGlyphs(node, lt.numGlyphs)
+		lt.subtables[i] = node
+		lt.issues.report(lt.index, i, node)
 	})
 	return lt.subtables[i]
 }
@@ -145,6 +223,74 @@ func (ln *LookupNode) Error() error {
 	return ln.err
 }
 
+// ExtensionInfo describes an extension subtable (GSUB lookup type 7, GPOS lookup
+// type 9), which wraps a subtable of another lookup type using a 32-bit offset.
+type ExtensionInfo struct {
+	WrappedType LayoutTableLookupType // lookup type of the wrapped subtable
+	Offset      uint32                // offset32 from the extension subtable to the wrapped subtable
+}
+
+// Extension returns information about the extension subtable wrapping this node.
+// For an extension subtable itself (see Wrapper), its own information is returned.
+// If the node is not related to an extension subtable, or if the extension could
+// not be resolved, ok is false.
+func (ln *LookupNode) Extension() (info ExtensionInfo, ok bool) {
+	if ln == nil {
+		return ExtensionInfo{}, false
+	}
+	ext := ln
+	if ln.wrapper != nil {
+		ext = ln.wrapper
+	}
+	if ext.GSub != nil && ext.GSub.ExtensionFmt1 != nil {
+		p := ext.GSub.ExtensionFmt1
+		return ExtensionInfo{WrappedType: p.ResolvedType, Offset: p.Offset}, p.Resolved != nil
+	}
+	if ext.GPos != nil && ext.GPos.ExtensionFmt1 != nil {
+		p := ext.GPos.ExtensionFmt1
+		return ExtensionInfo{WrappedType: p.ResolvedType, Offset: p.Offset}, p.Resolved != nil
+	}
+	return ExtensionInfo{}, false
+}
+
+// Unwrap returns the target node of an extension subtable, following nested
+// extensions. For other nodes, and for extensions which could not be resolved,
+// the node itself is returned.
+func (ln *LookupNode) Unwrap() *LookupNode {
+	for ln != nil {
+		var target *LookupNode
+		if ln.GSub != nil && ln.GSub.ExtensionFmt1 != nil {
+			target = ln.GSub.ExtensionFmt1.Resolved
+		} else if ln.GPos != nil && ln.GPos.ExtensionFmt1 != nil {
+			target = ln.GPos.ExtensionFmt1.Resolved
+		}
+		if target == nil {
+			break
+		}
+		ln = target
+	}
+	return ln
+}
+
+// Wrapper returns the extension subtable this node has been resolved from, or nil
+// if the node is not the target of an extension subtable.
+func (ln *LookupNode) Wrapper() *LookupNode {
+	if ln == nil {
+		return nil
+	}
+	return ln.wrapper
+}
+
+// ExtensionDepth returns the number of extension subtables enclosing this node,
+// i.e., 0 for subtables referenced directly from a lookup table and 1 for the
+// target of an extension subtable.
+func (ln *LookupNode) ExtensionDepth() int {
+	if ln == nil {
+		return 0
+	}
+	return ln.extDepth
+}
+
 // GSubPayload returns the typed GSUB payload scaffold for this node, if applicable.
 func (ln *LookupNode) GSubPayload() *GSubLookupPayload {
 	if ln == nil {
@@ -0,0 +1,155 @@
+package ot
+
+// FirstGlyphSet is the set of glyphs which may start a match for a lookup.
+// It is the union of the (input) coverages of all subtables of a lookup.
+//
+// Clients may use it to skip lookups early: if none of the glyphs of a buffer
+// is contained in the set, the lookup cannot apply anywhere in the buffer.
+// If the set cannot be determined reliably (e.g., because of a damaged subtable),
+// the set is flagged as universal and will report every glyph as contained.
+type FirstGlyphSet struct {
+	glyphs    GlyphSet
+	universal bool
+}
+
+// Contains reports whether glyph g may start a match.
+func (s *FirstGlyphSet) Contains(g GlyphIndex) bool {
+	if s == nil || s.universal {
+		return true
+	}
+	return s.glyphs.Contains(g)
+}
+
+// Universal reports whether the set has to be treated as containing every glyph.
+func (s *FirstGlyphSet) Universal() bool {
+	return s == nil || s.universal
+}
+
+// Glyphs returns the glyphs which may start a match, or nil if the set is
+// universal. The returned set must not be modified.
+func (s *FirstGlyphSet) Glyphs() *GlyphSet {
+	if s.Universal() {
+		return nil
+	}
+	return &s.glyphs
+}
+
+// Intersects reports whether at least one glyph of glyphs is contained in the set.
+func (s *FirstGlyphSet) Intersects(glyphs []GlyphIndex) bool {
+	if s.Universal() {
+		return true
+	}
+	for _, g := range glyphs {
+		if s.Contains(g) {
+			return true
+		}
+	}
+	return false
+}
+
+// IntersectsSet reports whether at least one glyph of glyphs is contained in
+// the set. For repeated tests of the same glyphs against many lookups, this is
+// faster than Intersects.
+func (s *FirstGlyphSet) IntersectsSet(glyphs *GlyphSet) bool {
+	if s.Universal() {
+		return true
+	}
+	return s.glyphs.Intersects(glyphs)
+}
+
+// forCoverageGlyphs calls f for every glyph of a coverage table, in coverage order.
+// It returns false if the coverage could not be enumerated.
+func forCoverageGlyphs(cov Coverage, f func(GlyphIndex)) bool {
+	switch r := cov.GlyphRange.(type) {
+	case *glyphRangeArray:
+		for i := range r.count {
+			k, err := r.data.u16(i * 2)
+			if err != nil {
+				return false
+			}
+			f(GlyphIndex(k))
+		}
+	case *glyphRangeRecords:
+		for i := range r.count {
+			from, err := r.data.u16(i * 6)
+			if err != nil {
+				return false
+			}
+			to, err := r.data.u16(i*6 + 2)
+			if err != nil || to < from {
+				return false
+			}
+			for g := uint32(from); g <= uint32(to); g++ {
+				f(GlyphIndex(g))
+			}
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// FirstGlyphs returns the set of glyphs which may start a match for this lookup.
+// The set is computed on first use and cached afterwards.
+func (lt *LookupTable) FirstGlyphs() *FirstGlyphSet {
+	if lt == nil {
+		return nil
+	}
+	lt.firstOnce.Do(func() {
+		lt.firstGlyphs = lt.collectFirstGlyphs()
+	})
+	return lt.firstGlyphs
+}
+
+func (lt *LookupTable) collectFirstGlyphs() *FirstGlyphSet {
+	set := &FirstGlyphSet{}
+	if lt.err != nil {
+		set.universal = true
+		return set
+	}
+	for _, node := range lt.Range() {
+		cov, ok := firstCoverage(node)
+		if !ok || !set.glyphs.AddCoverage(cov) {
+			set.universal = true
+			set.glyphs = GlyphSet{}
+			return set
+		}
+	}
+	return set
+}
+
+// firstCoverage returns the coverage table which governs the first input glyph
+// of a lookup subtable. Extension subtables are resolved to their target.
+func firstCoverage(node *LookupNode) (Coverage, bool) {
+	if node == nil || node.err != nil {
+		return Coverage{}, false
+	}
+	if p := node.GSub; p != nil {
+		switch {
+		case p.ExtensionFmt1 != nil:
+			return firstCoverage(p.ExtensionFmt1.Resolved)
+		case p.ContextFmt3 != nil:
+			return firstInputCoverage(p.ContextFmt3.InputCoverages)
+		case p.ChainingContextFmt3 != nil:
+			return firstInputCoverage(p.ChainingContextFmt3.InputCoverages)
+		}
+	}
+	if p := node.GPos; p != nil {
+		switch {
+		case p.ExtensionFmt1 != nil:
+			return firstCoverage(p.ExtensionFmt1.Resolved)
+		case p.ContextFmt3 != nil:
+			return firstInputCoverage(p.ContextFmt3.InputCoverages)
+		case p.ChainingContextFmt3 != nil:
+			return firstInputCoverage(p.ChainingContextFmt3.InputCoverages)
+		}
+	}
+	return node.Coverage, node.Coverage.GlyphRange != nil
+}
+
+func firstInputCoverage(covs []Coverage) (Coverage, bool) {
+	if len(covs) == 0 || covs[0].GlyphRange == nil {
+		return Coverage{}, false
+	}
+	return covs[0], true
+}
@@ -0,0 +1,64 @@
+package ot
+
+import "testing"
+
+func TestLookupFirstGlyphs(t *testing.T) {
+	b := make([]byte, 40)
+	putU16(b, 0, uint16(GSubLookupTypeSingle))
+	putU16(b, 4, 2)  // subtable count
+	putU16(b, 6, 10) // offset to subtable #0
+	putU16(b, 8, 24) // offset to subtable #1
+	// GSUB1/1 with coverage format 1
+	putU16(b, 10, 1)
+	putU16(b, 12, 6)
+	putU16(b, 14, 1)
+	copy(b[16:], coverageFmt1(5, 300))
+	// GSUB1/1 with coverage format 2
+	putU16(b, 24, 1)
+	putU16(b, 26, 6)
+	putU16(b, 28, 1)
+	putU16(b, 30, 2)  // coverage format
+	putU16(b, 32, 1)  // range count
+	putU16(b, 34, 10) // start glyph
+	putU16(b, 36, 12) // end glyph
+	//
+	lt := parseConcreteLookupTable(b, false)
+	if lt.Error() != nil {
+		t.Fatalf("unexpected lookup error: %v", lt.Error())
+	}
+	set := lt.FirstGlyphs()
+	if set.Universal() {
+		t.Fatalf("expected first-glyph set to be bounded")
+	}
+	for _, g := range []GlyphIndex{5, 10, 11, 12, 300} {
+		if !set.Contains(g) {
+			t.Errorf("expected glyph %d to be contained in first-glyph set", g)
+		}
+	}
+	for _, g := range []GlyphIndex{0, 4, 9, 13, 299, 301, 5000} {
+		if set.Contains(g) {
+			t.Errorf("did not expect glyph %d in first-glyph set", g)
+		}
+	}
+	if set.Intersects([]GlyphIndex{1, 2, 3}) {
+		t.Errorf("did not expect first-glyph set to intersect [1 2 3]")
+	}
+	if !set.Intersects([]GlyphIndex{1, 11, 3}) {
+		t.Errorf("expected first-glyph set to intersect [1 11 3]")
+	}
+	if lt.FirstGlyphs() != set {
+		t.Errorf("expected first-glyph set to be cached")
+	}
+}
+
+func TestLookupFirstGlyphsUniversalOnDamage(t *testing.T) {
+	b := make([]byte, 10)
+	putU16(b, 0, uint16(GSubLookupTypeSingle))
+	putU16(b, 4, 1)
+	putU16(b, 6, 40) // subtable offset out of bounds
+	lt := parseConcreteLookupTable(b, false)
+	set := lt.FirstGlyphs()
+	if !set.Universal() || !set.Contains(1234) {
+		t.Errorf("expected damaged lookup to produce a universal first-glyph set")
+	}
+}
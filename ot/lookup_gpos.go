@@ -1,5 +1,7 @@
 package ot
 
+import "slices"
+
 // GPosLookupPayload is a typed payload scaffold for GPOS lookup-subtable variants.
 // Exactly one pointer field is expected to be non-nil for a concrete GPOS node.
 type GPosLookupPayload struct {
@@ -30,12 +32,31 @@ type GPosSingleFmt2Payload struct {
 	Values      []ValueRecord
 }
 
+// GPosPairFmt1Payload holds the pair sets of a GPOS pair adjustment subtable
+// of format 1, one per glyph of the coverage. The records of a pair set are
+// sorted by their second glyph.
 type GPosPairFmt1Payload struct {
 	ValueFormat1 ValueFormat
 	ValueFormat2 ValueFormat
 	PairSets     [][]PairValueRecord
 }
 
+// Pair returns the record of pair set inx for second glyph second, using a
+// binary search.
+func (p *GPosPairFmt1Payload) Pair(inx int, second GlyphIndex) (PairValueRecord, bool) {
+	if p == nil || inx < 0 || inx >= len(p.PairSets) {
+		return PairValueRecord{}, false
+	}
+	set := p.PairSets[inx]
+	i, ok := slices.BinarySearchFunc(set, second, func(rec PairValueRecord, g GlyphIndex) int {
+		return int(rec.SecondGlyph) - int(g)
+	})
+	if !ok {
+		return PairValueRecord{}, false
+	}
+	return set[i], true
+}
+
 type GPosClass2ValueRecord struct {
 	Value1 ValueRecord
 	Value2 ValueRecord
@@ -212,5 +233,6 @@ type GPosChainingContextFmt3Payload struct {
 
 type GPosExtensionFmt1Payload struct {
 	ResolvedType LayoutTableLookupType
+	Offset       uint32 // offset32 from the extension subtable to the wrapped subtable
 	Resolved     *LookupNode
 }
@@ -101,6 +101,7 @@ type GSubChainingContextFmt3Payload struct {
 
 type GSubExtensionFmt1Payload struct {
 	ResolvedType LayoutTableLookupType
+	Offset       uint32 // offset32 from the extension subtable to the wrapped subtable
 	Resolved     *LookupNode
 }
 
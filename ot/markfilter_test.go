@@ -0,0 +1,48 @@
+package ot_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestMarkFilteringSetValidation(t *testing.T) {
+	b := testfont.New(4)
+	set := b.MarkGlyphSet(3)
+	gsub := b.GSUB()
+	subst := testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 2})
+	plain := gsub.Lookup(ot.GSubLookupTypeSingle, 0, subst)
+	valid := gsub.FilteredLookup(ot.GSubLookupTypeSingle, 0, set, subst)
+	invalid := gsub.FilteredLookup(ot.GSubLookupTypeSingle, 0, set+1, subst)
+	gsub.Script("latn", testfont.DefaultLang, gsub.Feature("liga", plain, valid, invalid))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("expected invalid mark filtering set to be tolerated, have %v", err)
+	}
+	lookups := otf.Layout.GSub.LookupGraph()
+	for _, c := range []struct {
+		lookup int
+		set    uint16
+		ok     bool
+	}{
+		{plain, 0, false},
+		{valid, set, true},
+		{invalid, set + 1, true},
+	} {
+		if s, ok := lookups.Lookup(c.lookup).UsesMarkFilteringSet(); s != c.set || ok != c.ok {
+			t.Errorf("lookup %d: expected mark filtering set %d/%v, have %d/%v", c.lookup, c.set, c.ok, s, ok)
+		}
+	}
+	var reported []ot.FontError
+	for _, e := range otf.Errors() {
+		if e.Table == ot.T("GSUB") && strings.Contains(e.Issue, "mark filtering set") {
+			reported = append(reported, e)
+		}
+	}
+	if len(reported) != 1 || !strings.Contains(reported[0].Issue, "lookup 2 ") ||
+		reported[0].Severity != ot.SeverityMajor {
+		t.Errorf("expected a major error for lookup %d only, have %v", invalid, reported)
+	}
+}
@@ -0,0 +1,175 @@
+package ot
+
+import (
+	"fmt"
+	"strings"
+)
+
+// --- meta table ------------------------------------------------------------
+
+// MetaTable, the metadata table ('meta'), holds data maps of various kinds of
+// metadata, identified by tags. Registered tags are 'dlng', listing the
+// languages a font has been designed for, and 'slng', listing the languages a
+// font is capable of supporting.
+//
+// See also
+// https://learn.microsoft.com/en-us/typography/opentype/spec/meta
+type MetaTable struct {
+	tableBase
+	Version uint32
+	Flags   uint32
+	tags    []Tag
+	maps    map[Tag]binarySegm
+}
+
+func newMetaTable(tag Tag, b binarySegm, offset, size uint32) *MetaTable {
+	t := &MetaTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+// Tags returns the tags of the data maps of t, in table order.
+func (t *MetaTable) Tags() []Tag {
+	if t == nil {
+		return nil
+	}
+	return append([]Tag(nil), t.tags...)
+}
+
+// Data returns the data of the data map tagged tag. It is a view into the font
+// data and should be treated as read-only by clients.
+func (t *MetaTable) Data(tag Tag) ([]byte, bool) {
+	if t == nil {
+		return nil, false
+	}
+	data, ok := t.maps[tag]
+	return data, ok
+}
+
+// DesignLanguages returns the languages and scripts a font has been designed
+// for, i.e. for which it has been designed to be used in a stylistically
+// appropriate way, as stated by data map 'dlng'.
+func (t *MetaTable) DesignLanguages() []ScriptLangTag {
+	data, _ := t.Data(T("dlng"))
+	return ParseScriptLangTags(string(data))
+}
+
+// SupportedLanguages returns the languages and scripts a font is capable of
+// supporting, i.e. for which it provides functional support, as stated by data
+// map 'slng'.
+func (t *MetaTable) SupportedLanguages() []ScriptLangTag {
+	data, _ := t.Data(T("slng"))
+	return ParseScriptLangTags(string(data))
+}
+
+func parseMeta(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	meta := newMetaTable(tag, b, offset, size)
+	if len(b) < 16 {
+		ec.addError(tag, "Header", fmt.Sprintf("meta table too small: %d bytes (need at least 16)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("meta table header too small")
+	}
+	meta.Version = b.U32(0)
+	meta.Flags = b.U32(4)
+	if meta.Version != 1 {
+		ec.addError(tag, "Version", fmt.Sprintf("unsupported meta version %d", meta.Version), SeverityMajor, offset)
+		return meta, nil
+	}
+	count := int(b.U32(12))
+	if 16+count*12 > len(b) {
+		ec.addError(tag, "DataMaps", "meta data map records out of bounds", SeverityMajor, offset)
+		return meta, nil
+	}
+	meta.maps = make(map[Tag]binarySegm, count)
+	for i := range count {
+		rec := 16 + i*12
+		mapTag, off, n := Tag(b.U32(rec)), int(b.U32(rec+4)), int(b.U32(rec+8))
+		if off < 0 || n < 0 || off+n > len(b) {
+			ec.addError(tag, "DataMaps", fmt.Sprintf("data of meta data map '%s' out of bounds", mapTag), SeverityMinor, offset)
+			continue
+		}
+		meta.tags = append(meta.tags, mapTag)
+		meta.maps[mapTag] = b[off : off+n]
+	}
+	return meta, nil
+}
+
+// --- ScriptLangTag ---------------------------------------------------------
+
+// ScriptLangTag identifies a language, a script, or a language written in a
+// script, optionally for a region. ScriptLangTags are a subset of BCP 47
+// language tags, e.g. "en-Latn" or "zh-Hant-HK", which may as well consist of
+// a script subtag only, e.g. "Cyrl".
+type ScriptLangTag struct {
+	Language string // ISO 639 language code, e.g. "en", or empty
+	Script   string // ISO 15924 script code, e.g. "Latn", or empty
+	Region   string // ISO 3166 region code or UN M.49 area code, or empty
+}
+
+// String returns t in BCP 47 notation.
+func (t ScriptLangTag) String() string {
+	parts := make([]string, 0, 3)
+	for _, s := range []string{t.Language, t.Script, t.Region} {
+		if s != "" {
+			parts = append(parts, s)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+// ParseScriptLangTags parses a comma-separated list of ScriptLangTags, as found
+// in the data maps 'dlng' and 'slng' of table 'meta'. Subtags are normalized
+// to their conventional case. Malformed entries are skipped; subtags following
+// the region subtag are ignored.
+func ParseScriptLangTags(s string) []ScriptLangTag {
+	var tags []ScriptLangTag
+	for _, entry := range strings.Split(s, ",") {
+		if t, ok := parseScriptLangTag(strings.TrimSpace(entry)); ok {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+func parseScriptLangTag(s string) (ScriptLangTag, bool) {
+	subtags := strings.FieldsFunc(s, func(r rune) bool { return r == '-' || r == '_' })
+	if len(subtags) == 0 {
+		return ScriptLangTag{}, false
+	}
+	var t ScriptLangTag
+	if n := len(subtags[0]); n >= 2 && n <= 3 && isAlpha(subtags[0]) {
+		t.Language = strings.ToLower(subtags[0])
+		subtags = subtags[1:]
+	}
+	if len(subtags) > 0 && len(subtags[0]) == 4 && isAlpha(subtags[0]) {
+		t.Script = strings.ToUpper(subtags[0][:1]) + strings.ToLower(subtags[0][1:])
+		subtags = subtags[1:]
+	}
+	if t.Language == "" && t.Script == "" {
+		return ScriptLangTag{}, false
+	}
+	if len(subtags) > 0 {
+		switch r := subtags[0]; {
+		case len(r) == 2 && isAlpha(r):
+			t.Region = strings.ToUpper(r)
+		case len(r) == 3 && strings.Trim(r, "0123456789") == "":
+			t.Region = r
+		}
+	}
+	return t, true
+}
+
+func isAlpha(s string) bool {
+	for _, c := range s {
+		if (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,69 @@
+package ot
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func buildMeta(maps map[string]string, order ...string) []byte {
+	u32 := func(b []byte, v int) []byte { return binary.BigEndian.AppendUint32(b, uint32(v)) }
+	b := u32(u32(u32(u32(nil, 1), 0), 0), len(order))
+	off := 16 + 12*len(order)
+	for _, tag := range order {
+		b = append(b, tag...)
+		b = u32(u32(b, off), len(maps[tag]))
+		off += len(maps[tag])
+	}
+	for _, tag := range order {
+		b = append(b, maps[tag]...)
+	}
+	return b
+}
+
+func TestParseMeta(t *testing.T) {
+	b := buildMeta(map[string]string{
+		"dlng": "ja-Jpan, zh-hant-hk",
+		"slng": "Latn,Cyrl, en-Latn-US, und-Zsye, -, 1234",
+	}, "dlng", "slng")
+	ec := &errorCollector{}
+	table, err := parseMeta(T("meta"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	meta := table.Self().AsMeta()
+	if meta == nil || len(meta.Tags()) != 2 {
+		t.Fatalf("expected meta table with 2 data maps")
+	}
+	check := func(name string, tags []ScriptLangTag, want ...string) {
+		if len(tags) != len(want) {
+			t.Errorf("%s: expected %v, have %v", name, want, tags)
+			return
+		}
+		for i := range tags {
+			if tags[i].String() != want[i] {
+				t.Errorf("%s: expected %q at #%d, have %q", name, want[i], i, tags[i])
+			}
+		}
+	}
+	check("dlng", meta.DesignLanguages(), "ja-Jpan", "zh-Hant-HK")
+	check("slng", meta.SupportedLanguages(), "Latn", "Cyrl", "en-Latn-US", "und-Zsye")
+	if _, ok := meta.Data(T("appl")); ok {
+		t.Errorf("expected no data map 'appl'")
+	}
+}
+
+func TestParseMetaDamaged(t *testing.T) {
+	b := buildMeta(map[string]string{"dlng": "en"}, "dlng")
+	binary.BigEndian.PutUint32(b[20:], 1000) // data offset out of bounds
+	ec := &errorCollector{}
+	table, err := parseMeta(T("meta"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tags := table.Self().AsMeta().DesignLanguages(); len(tags) != 0 {
+		t.Errorf("expected no design languages for damaged data map, have %v", tags)
+	}
+	if len(ec.errors) == 0 {
+		t.Errorf("expected damaged data map to be reported")
+	}
+}
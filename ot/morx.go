@@ -0,0 +1,252 @@
+package ot
+
+import "fmt"
+
+// --- morx table ------------------------------------------------------------
+
+// MorxTable, the extended glyph metamorphosis table (morx) of Apple Advanced
+// Typography, holds the glyph transformations of fonts for AAT shaping, which
+// many macOS fonts use instead of GSUB. The table consists of chains of
+// subtables; features (identified by AAT feature types and settings) switch
+// subtables on and off by setting the flags of a chain.
+//
+// Package ot does not apply 'morx' tables, but makes their structure available
+// for inspection. Subtable glyph coverage tables of version 3 are not
+// interpreted.
+//
+// See also
+// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6morx.html
+type MorxTable struct {
+	tableBase
+	Version uint16
+	chains  []MorxChain
+	err     error
+}
+
+func newMorxTable(tag Tag, b binarySegm, offset, size uint32) *MorxTable {
+	t := &MorxTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+// MorxChain is a chain of 'morx' subtables. A subtable is applied if its
+// SubFeatureFlags share a bit with the chain's flags, which start out as
+// DefaultFlags and are modified by the features requested.
+type MorxChain struct {
+	DefaultFlags uint32
+	Features     []MorxFeature
+	Subtables    []MorxSubtable
+}
+
+// MorxFeature maps an AAT feature setting to the modification of the flags
+// of a chain: flags = (flags & DisableFlags) | EnableFlags, i.e., DisableFlags
+// is the complement of the flags to clear.
+type MorxFeature struct {
+	Type         uint16 // AAT feature type, e.g. 1 for ligatures
+	Setting      uint16 // AAT feature selector
+	EnableFlags  uint32
+	DisableFlags uint32
+}
+
+// MorxSubtableType is the type of a 'morx' subtable.
+type MorxSubtableType uint8
+
+// Types of 'morx' subtables.
+const (
+	MorxRearrangement MorxSubtableType = 0
+	MorxContextual    MorxSubtableType = 1
+	MorxLigature      MorxSubtableType = 2
+	MorxNoncontextual MorxSubtableType = 4
+	MorxInsertion     MorxSubtableType = 5
+)
+
+// Coverage flags of 'morx' subtables.
+const (
+	MorxVertical       uint32 = 0x80000000 // applies to vertical text only
+	MorxDescending     uint32 = 0x40000000 // processes glyphs in descending order
+	MorxAnyOrientation uint32 = 0x20000000 // applies to horizontal and vertical text
+	MorxLogicalOrder   uint32 = 0x10000000 // descending refers to logical order
+)
+
+// MorxSubtable is a subtable of a 'morx' chain. Access to the data of a
+// subtable depends on its type; methods for other types report false.
+type MorxSubtable struct {
+	Type            MorxSubtableType
+	Coverage        uint32 // coverage flags, see MorxVertical etc.
+	SubFeatureFlags uint32
+	raw             binarySegm // subtable data after the subtable header
+}
+
+// Chains returns the chains of the 'morx' table.
+func (t *MorxTable) Chains() []MorxChain {
+	if t == nil {
+		return nil
+	}
+	return t.chains
+}
+
+// Error returns parser/validation errors attached to this morx table view.
+func (t *MorxTable) Error() error {
+	if t == nil {
+		return nil
+	}
+	return t.err
+}
+
+// StateTable returns the state table of a rearrangement, contextual, ligature
+// or insertion subtable.
+func (s MorxSubtable) StateTable() (*AATStateTable, error) {
+	switch s.Type {
+	case MorxRearrangement:
+		return viewAATStateTable(s.raw, 0)
+	case MorxLigature:
+		return viewAATStateTable(s.raw, 1)
+	case MorxContextual, MorxInsertion:
+		return viewAATStateTable(s.raw, 2)
+	}
+	return nil, fmt.Errorf("morx subtable of type %d has no state table", s.Type)
+}
+
+// NoncontextualLookup returns the glyph substitutions of a noncontextual
+// subtable, mapping glyphs to glyphs.
+func (s MorxSubtable) NoncontextualLookup() (AATLookup, bool) {
+	if s.Type != MorxNoncontextual || len(s.raw) < 2 {
+		return AATLookup{}, false
+	}
+	return AATLookup{raw: s.raw, valueSize: 2}, true
+}
+
+// ContextualSubstitution returns the substitution of glyph g by substitution
+// table index of a contextual subtable. Entries of the state table refer to
+// substitution tables for the marked and the current glyph.
+func (s MorxSubtable) ContextualSubstitution(index uint16, g GlyphIndex) (GlyphIndex, bool) {
+	if s.Type != MorxContextual || len(s.raw) < 20 {
+		return 0, false
+	}
+	tables := int(s.raw.U32(16))
+	if tables <= 0 || tables+4*int(index)+4 > len(s.raw) {
+		return 0, false
+	}
+	lookup, ok := viewAATLookup(s.raw, tables+int(s.raw.U32(tables+4*int(index))), 2)
+	if !ok {
+		return 0, false
+	}
+	v, ok := lookup.Lookup(g)
+	return GlyphIndex(v), ok
+}
+
+// LigatureAction returns ligature action i of a ligature subtable. An action
+// holds flags (last, store) in its 2 high bits and a 30-bit signed offset into
+// the component table in its low bits.
+func (s MorxSubtable) LigatureAction(i int) (uint32, bool) {
+	if s.Type != MorxLigature || len(s.raw) < 28 {
+		return 0, false
+	}
+	at := int(s.raw.U32(16)) + 4*i
+	if i < 0 || at+4 > len(s.raw) {
+		return 0, false
+	}
+	return s.raw.U32(at), true
+}
+
+// LigatureComponent returns entry i of the component table of a ligature
+// subtable. Accumulated components select an entry of the ligature table.
+func (s MorxSubtable) LigatureComponent(i int) (uint16, bool) {
+	if s.Type != MorxLigature || len(s.raw) < 28 {
+		return 0, false
+	}
+	return aatU16(s.raw, int(s.raw.U32(20)), i)
+}
+
+// LigatureGlyph returns entry i of the ligature table of a ligature subtable.
+func (s MorxSubtable) LigatureGlyph(i int) (GlyphIndex, bool) {
+	if s.Type != MorxLigature || len(s.raw) < 28 {
+		return 0, false
+	}
+	g, ok := aatU16(s.raw, int(s.raw.U32(24)), i)
+	return GlyphIndex(g), ok
+}
+
+// InsertionGlyphs returns count glyphs of the insertion glyph table of an
+// insertion subtable, starting at index. Index and count are taken from a
+// state table entry.
+func (s MorxSubtable) InsertionGlyphs(index, count int) ([]GlyphIndex, bool) {
+	if s.Type != MorxInsertion || len(s.raw) < 20 || count < 0 {
+		return nil, false
+	}
+	glyphs := make([]GlyphIndex, count)
+	for i := range glyphs {
+		g, ok := aatU16(s.raw, int(s.raw.U32(16)), index+i)
+		if !ok {
+			return nil, false
+		}
+		glyphs[i] = GlyphIndex(g)
+	}
+	return glyphs, true
+}
+
+// parseMorx parses the chains of a 'morx' table, together with their features
+// and subtable headers. Subtable data is projected on access.
+func parseMorx(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	morx := newMorxTable(tag, b, offset, size)
+	if len(b) < 8 {
+		ec.addError(tag, "Header", fmt.Sprintf("morx table too small: %d bytes (need at least 8)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("morx table header too small")
+	}
+	morx.Version = b.U16(0)
+	if morx.Version != 2 && morx.Version != 3 {
+		ec.addError(tag, "Version", fmt.Sprintf("unsupported morx version %d", morx.Version), SeverityMajor, offset)
+		morx.err = fmt.Errorf("unsupported morx version %d", morx.Version)
+		return morx, nil
+	}
+	fail := func(section, issue string, at int) (Table, error) {
+		ec.addError(tag, section, issue, SeverityMajor, offset+uint32(at))
+		morx.err = errFontFormat("morx " + issue)
+		return morx, nil
+	}
+	at := 8
+	for i := range int(b.U32(4)) {
+		if at+16 > len(b) {
+			return fail("Chain", fmt.Sprintf("chain #%d out of bounds", i), at)
+		}
+		length := int(b.U32(at + 4))
+		nFeatures, nSubtables := int(b.U32(at+8)), int(b.U32(at+12))
+		if length < 16 || at+length > len(b) || 16+12*nFeatures > length {
+			return fail("Chain", fmt.Sprintf("chain #%d out of bounds", i), at)
+		}
+		chain := MorxChain{DefaultFlags: b.U32(at), Features: make([]MorxFeature, nFeatures)}
+		for k := range chain.Features {
+			f := at + 16 + 12*k
+			chain.Features[k] = MorxFeature{
+				Type:         b.U16(f),
+				Setting:      b.U16(f + 2),
+				EnableFlags:  b.U32(f + 4),
+				DisableFlags: b.U32(f + 8),
+			}
+		}
+		sub := at + 16 + 12*nFeatures
+		for k := range nSubtables {
+			if sub+12 > at+length || int(b.U32(sub)) < 12 || sub+int(b.U32(sub)) > at+length {
+				return fail("Subtable", fmt.Sprintf("subtable #%d of chain #%d out of bounds", k, i), sub)
+			}
+			coverage := b.U32(sub + 4)
+			chain.Subtables = append(chain.Subtables, MorxSubtable{
+				Type:            MorxSubtableType(coverage & 0xff),
+				Coverage:        coverage &^ 0xff,
+				SubFeatureFlags: b.U32(sub + 8),
+				raw:             b[sub+12 : sub+int(b.U32(sub))],
+			})
+			sub += int(b.U32(sub))
+		}
+		morx.chains = append(morx.chains, chain)
+		at += length
+	}
+	return morx, nil
+}
@@ -38,6 +38,62 @@ func TestNavigation1(t *testing.T) {
 	}
 }
 
+func TestNavigatePath(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := loadCalibri(t)
+	gsub := otf.Table(T("GSUB"))
+	locs, err := NavigatePath(gsub, "ScriptList/latn/TRK/featureIndices[0]")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locs) != 1 {
+		t.Fatalf("expected exactly one location, have %d", len(locs))
+	}
+	lang := gsub.Self().AsGSub().ScriptGraph().Script(T("latn")).LangSys(T("TRK"))
+	f, ok := locs[0].Value.(*Feature)
+	if !ok || f != lang.FeatureAt(0) {
+		t.Fatalf("expected first feature of latn/TRK, have %v", locs[0])
+	}
+	if tag, _ := gsub.Self().AsGSub().FeatureGraph().TagAt(locs[0].Index); tag != locs[0].Tag {
+		t.Errorf("expected location tag to match feature index, have %s vs %s", locs[0].Tag, tag)
+	}
+	t.Logf("%s", locs[0])
+	// wildcard listing
+	locs, err = NavigatePath(gsub, "ScriptList/latn/*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locs) < 2 || locs[0].Path != "ScriptList/latn/dflt" {
+		t.Errorf("expected default LangSys to be listed first, have %v", locs)
+	}
+	locs, err = NavigatePath(gsub, "FeatureList/liga/lookupIndices[*]/subtables")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(locs) == 0 {
+		t.Fatalf("expected subtables for feature 'liga'")
+	}
+	for _, loc := range locs {
+		if node, ok := loc.Value.(*LookupNode); !ok || node == nil {
+			t.Errorf("expected lookup subtable at %s", loc.Path)
+		}
+	}
+	locs, err = NavigatePath(otf.Table(T("GPOS")), "LookupList[0]")
+	if err != nil || len(locs) != 1 || locs[0].Index != 0 {
+		t.Errorf("expected GPOS lookup 0, have %v, %v", locs, err)
+	}
+	for _, bad := range []string{"", "Foo", "ScriptList/xxxx", "LookupList[99999]", "ScriptList[1]", "LookupList[x]"} {
+		if _, err := NavigatePath(gsub, bad); err == nil {
+			t.Errorf("expected error for path %q", bad)
+		}
+	}
+	if _, err := NavigatePath(otf.Table(T("head")), "ScriptList"); err == nil {
+		t.Errorf("expected error for non-layout table")
+	}
+}
+
 // ---------------------------------------------------------------------------
 
 func loadCalibri(t *testing.T) *Font {
@@ -0,0 +1,350 @@
+package ot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NavLocation is a position in the layout graph of a GSUB or GPOS table, as
+// resolved by NavigatePath.
+//
+// Value holds the graph node at the location, which is one of
+// *ScriptList, *Script, *LangSys, *FeatureList, *Feature, *LookupListGraph,
+// *LookupTable or *LookupNode.
+type NavLocation struct {
+	Path  string // path of the location, with wildcards resolved
+	Tag   Tag    // tag of the node, if it has one
+	Index int    // index of the node in its list (feature list, lookup list, …), or -1
+	Value any    // graph node at this location
+}
+
+// NavigatePath resolves a path expression within the layout graph of a GSUB or
+// GPOS table. Paths consist of segments separated by '/', starting at one of
+// the table's lists:
+//
+//	ScriptList/<script>/<lang>/featureIndices[i]/lookupIndices[j]/subtables[k]
+//	FeatureList[i]/lookupIndices[j]/subtables[k]
+//	FeatureList/<feature>/…
+//	LookupList[i]/subtables[k]
+//
+// Tags are given without padding, e.g. "TRK" for tag 'TRK '. Language "dflt"
+// (or "DFLT") selects the default language system of a script.
+//
+// A segment "*" or an index "[*]" matches all children, and a list segment
+// without an index ("featureIndices", "lookupIndices", "subtables") is treated
+// the same way. NavigatePath therefore returns a list of locations; without
+// wildcards it contains exactly one location. For features referenced by a
+// language system and for lookups referenced by a feature, Index is the
+// referenced index into the feature list or lookup list, respectively, while
+// the path contains the position of the link.
+func NavigatePath(table Table, path string) ([]NavLocation, error) {
+	lytt := navLayoutTable(table)
+	if lytt == nil {
+		return nil, fmt.Errorf("navigate %q: table is not a GSUB or GPOS table", path)
+	}
+	path = strings.Trim(path, "/")
+	if path == "" {
+		return nil, fmt.Errorf("navigate: empty path")
+	}
+	locs := []NavLocation{{Index: -1}} // root
+	for _, seg := range strings.Split(path, "/") {
+		name, sel, err := splitNavSegment(seg)
+		if err != nil {
+			return nil, fmt.Errorf("navigate %q: %w", path, err)
+		}
+		var next []NavLocation
+		for _, loc := range locs {
+			children, err := lytt.navStep(loc, name, sel)
+			if err != nil {
+				return nil, fmt.Errorf("navigate %q: %w", path, err)
+			}
+			next = append(next, children...)
+		}
+		locs = next
+	}
+	return locs, nil
+}
+
+// String returns the path of the location together with a short description
+// of the node at the location.
+func (loc NavLocation) String() string {
+	var desc string
+	if navIsNil(loc.Value) {
+		return loc.Path + "  (missing)"
+	}
+	switch v := loc.Value.(type) {
+	case *ScriptList:
+		desc = fmt.Sprintf("ScriptList scripts=%d", v.Len())
+	case *Script:
+		desc = fmt.Sprintf("Script langs=%d default=%t", len(v.langOrder), v.DefaultLangSys() != nil)
+	case *LangSys:
+		desc = fmt.Sprintf("LangSys features=%d", len(v.featureIndices))
+		if req, ok := v.RequiredFeatureIndex(); ok {
+			desc += fmt.Sprintf(" required=%d", req)
+		}
+	case *FeatureList:
+		desc = fmt.Sprintf("FeatureList features=%d", v.Len())
+	case *Feature:
+		desc = fmt.Sprintf("Feature[%d] '%s' lookups=%d", loc.Index, loc.Tag, v.LookupCount())
+	case *LookupListGraph:
+		desc = fmt.Sprintf("LookupList lookups=%d", v.Len())
+	case *LookupTable:
+		desc = fmt.Sprintf("Lookup[%d] type=%d flag=0x%04x subtables=%d", loc.Index, v.Type, uint16(v.Flag), v.SubTableCount)
+	case *LookupNode:
+		desc = fmt.Sprintf("Subtable type=%d format=%d", v.LookupType, v.Format)
+	default:
+		desc = "(none)"
+	}
+	return loc.Path + "  " + desc
+}
+
+func navLayoutTable(table Table) *LayoutTable {
+	if table == nil {
+		return nil
+	}
+	if gsub := table.Self().AsGSub(); gsub != nil {
+		return &gsub.LayoutTable
+	}
+	if gpos := table.Self().AsGPos(); gpos != nil {
+		return &gpos.LayoutTable
+	}
+	return nil
+}
+
+// splitNavSegment splits a path segment "name[sel]" into its parts. sel is
+// empty if the segment has no index.
+func splitNavSegment(seg string) (name, sel string, err error) {
+	open := strings.IndexByte(seg, '[')
+	if open < 0 {
+		return seg, "", nil
+	}
+	if !strings.HasSuffix(seg, "]") || open == 0 {
+		return "", "", fmt.Errorf("malformed path segment %q", seg)
+	}
+	sel = seg[open+1 : len(seg)-1]
+	if sel == "" {
+		return "", "", fmt.Errorf("empty index in path segment %q", seg)
+	}
+	return seg[:open], sel, nil
+}
+
+// navSelect returns the list positions in [0…n) selected by sel.
+func navSelect(sel string, n int) ([]int, error) {
+	if sel == "" || sel == "*" {
+		all := make([]int, n)
+		for i := range all {
+			all[i] = i
+		}
+		return all, nil
+	}
+	i, err := strconv.Atoi(sel)
+	if err != nil {
+		return nil, fmt.Errorf("invalid index [%s]", sel)
+	}
+	if i < 0 || i >= n {
+		return nil, fmt.Errorf("index [%d] out of range [0…%d)", i, n)
+	}
+	return []int{i}, nil
+}
+
+// navIsNil checks if v is nil or a nil graph node.
+func navIsNil(v any) bool {
+	switch n := v.(type) {
+	case *ScriptList:
+		return n == nil
+	case *Script:
+		return n == nil
+	case *LangSys:
+		return n == nil
+	case *FeatureList:
+		return n == nil
+	case *Feature:
+		return n == nil
+	case *LookupListGraph:
+		return n == nil
+	case *LookupTable:
+		return n == nil
+	case *LookupNode:
+		return n == nil
+	}
+	return v == nil
+}
+
+func navTagName(tag Tag) string {
+	return strings.TrimRight(tag.String(), " ")
+}
+
+// navStep resolves one path segment relative to loc.
+func (t *LayoutTable) navStep(loc NavLocation, name, sel string) ([]NavLocation, error) {
+	child := func(seg string, tag Tag, index int, value any) NavLocation {
+		if loc.Path != "" {
+			seg = loc.Path + "/" + seg
+		}
+		return NavLocation{Path: seg, Tag: tag, Index: index, Value: value}
+	}
+	noIndex := func() error {
+		if sel != "" {
+			return fmt.Errorf("%s: unexpected index [%s] for %q", loc.Path, sel, name)
+		}
+		return nil
+	}
+	if loc.Path != "" && navIsNil(loc.Value) {
+		return nil, fmt.Errorf("%s: node is missing or could not be parsed", loc.Path)
+	}
+	switch v := loc.Value.(type) {
+	case nil: // root
+		switch name {
+		case "ScriptList":
+			if err := noIndex(); err != nil {
+				return nil, err
+			}
+			return []NavLocation{child(name, 0, -1, t.ScriptGraph())}, nil
+		case "FeatureList":
+			fl := t.FeatureGraph()
+			if sel == "" {
+				return []NavLocation{child(name, 0, -1, fl)}, nil
+			}
+			return t.navFeatures(sel)
+		case "LookupList":
+			lg := t.LookupGraph()
+			if sel == "" {
+				return []NavLocation{child(name, 0, -1, lg)}, nil
+			}
+			inx, err := navSelect(sel, lg.Len())
+			if err != nil {
+				return nil, fmt.Errorf("LookupList: %w", err)
+			}
+			locs := make([]NavLocation, len(inx))
+			for k, i := range inx {
+				locs[k] = child(fmt.Sprintf("LookupList[%d]", i), 0, i, lg.Lookup(i))
+			}
+			return locs, nil
+		}
+		return nil, fmt.Errorf("unknown root %q, expected ScriptList, FeatureList or LookupList", name)
+	case *ScriptList:
+		if err := noIndex(); err != nil {
+			return nil, err
+		}
+		if name == "*" {
+			var locs []NavLocation
+			for tag, script := range v.Range() {
+				locs = append(locs, child(navTagName(tag), tag, -1, script))
+			}
+			return locs, nil
+		}
+		tag := T(name)
+		script := v.Script(tag)
+		if script == nil {
+			return nil, fmt.Errorf("%s: no script '%s'", loc.Path, name)
+		}
+		return []NavLocation{child(name, tag, -1, script)}, nil
+	case *Script:
+		if err := noIndex(); err != nil {
+			return nil, err
+		}
+		if name == "*" {
+			var locs []NavLocation
+			if dflt := v.DefaultLangSys(); dflt != nil {
+				locs = append(locs, child("dflt", 0, -1, dflt))
+			}
+			for tag, lang := range v.Range() {
+				locs = append(locs, child(navTagName(tag), tag, -1, lang))
+			}
+			return locs, nil
+		}
+		if name == "dflt" || name == "DFLT" {
+			dflt := v.DefaultLangSys()
+			if dflt == nil {
+				return nil, fmt.Errorf("%s: script has no default language system", loc.Path)
+			}
+			return []NavLocation{child(name, 0, -1, dflt)}, nil
+		}
+		tag := T(name)
+		lang := v.LangSys(tag)
+		if lang == nil {
+			return nil, fmt.Errorf("%s: no language system '%s'", loc.Path, name)
+		}
+		return []NavLocation{child(name, tag, -1, lang)}, nil
+	case *LangSys:
+		if name != "featureIndices" {
+			return nil, fmt.Errorf("%s: unknown child %q, expected featureIndices", loc.Path, name)
+		}
+		pos, err := navSelect(sel, len(v.featureIndices))
+		if err != nil {
+			return nil, fmt.Errorf("%s/featureIndices: %w", loc.Path, err)
+		}
+		locs := make([]NavLocation, len(pos))
+		for k, i := range pos {
+			inx := int(v.featureIndices[i])
+			tag, _ := t.FeatureGraph().TagAt(inx)
+			locs[k] = child(fmt.Sprintf("featureIndices[%d]", i), tag, inx, v.FeatureAt(i))
+		}
+		return locs, nil
+	case *FeatureList:
+		if err := noIndex(); err != nil {
+			return nil, err
+		}
+		if name == "*" {
+			return t.navFeatures("*")
+		}
+		tag := T(name)
+		indices := v.Indices(tag)
+		if len(indices) == 0 {
+			return nil, fmt.Errorf("%s: no feature '%s'", loc.Path, name)
+		}
+		locs := make([]NavLocation, len(indices))
+		for k, i := range indices {
+			// Feature tags may occur more than once, use the unambiguous index form.
+			locs[k] = NavLocation{Path: fmt.Sprintf("FeatureList[%d]", i), Tag: tag, Index: i, Value: v.featureAtIndex(i)}
+		}
+		return locs, nil
+	case *Feature:
+		if name != "lookupIndices" {
+			return nil, fmt.Errorf("%s: unknown child %q, expected lookupIndices", loc.Path, name)
+		}
+		pos, err := navSelect(sel, v.LookupCount())
+		if err != nil {
+			return nil, fmt.Errorf("%s/lookupIndices: %w", loc.Path, err)
+		}
+		locs := make([]NavLocation, len(pos))
+		for k, i := range pos {
+			inx := v.LookupIndex(i)
+			locs[k] = child(fmt.Sprintf("lookupIndices[%d]", i), 0, inx, t.LookupGraph().Lookup(inx))
+		}
+		return locs, nil
+	case *LookupListGraph:
+		return nil, fmt.Errorf("%s: lookups are addressed as LookupList[i]", loc.Path)
+	case *LookupTable:
+		if name != "subtables" {
+			return nil, fmt.Errorf("%s: unknown child %q, expected subtables", loc.Path, name)
+		}
+		pos, err := navSelect(sel, len(v.subtableOffsets))
+		if err != nil {
+			return nil, fmt.Errorf("%s/subtables: %w", loc.Path, err)
+		}
+		locs := make([]NavLocation, len(pos))
+		for k, i := range pos {
+			locs[k] = child(fmt.Sprintf("subtables[%d]", i), 0, i, v.Subtable(i))
+		}
+		return locs, nil
+	case *LookupNode:
+		return nil, fmt.Errorf("%s: lookup subtables have no children", loc.Path)
+	}
+	return nil, fmt.Errorf("%s: cannot navigate from %T", loc.Path, loc.Value)
+}
+
+// navFeatures selects features of the feature list by index.
+func (t *LayoutTable) navFeatures(sel string) ([]NavLocation, error) {
+	fl := t.FeatureGraph()
+	inx, err := navSelect(sel, fl.Len())
+	if err != nil {
+		return nil, fmt.Errorf("FeatureList: %w", err)
+	}
+	locs := make([]NavLocation, len(inx))
+	for k, i := range inx {
+		tag, _ := fl.TagAt(i)
+		locs[k] = NavLocation{Path: fmt.Sprintf("FeatureList[%d]", i), Tag: tag, Index: i, Value: fl.featureAtIndex(i)}
+	}
+	return locs, nil
+}
@@ -0,0 +1,93 @@
+package ot
+
+import "fmt"
+
+// --- opbd table ------------------------------------------------------------
+
+// OpbdTable, the optical bounds table (opbd) of Apple Advanced Typography,
+// holds the optical edges of glyphs. Glyphs such as hyphens or quotes may then
+// protrude into the margins of justified text, which makes the margins appear
+// straight (optical margin alignment).
+//
+// See also
+// https://developer.apple.com/fonts/TrueType-Reference-Manual/RM06/Chap6opbd.html
+type OpbdTable struct {
+	tableBase
+	Version uint32 // 16.16 fixed-point version, i.e. 0x00010000
+	Format  uint16 // OpbdDistances or OpbdControlPoints
+	bounds  AATLookup
+	err     error
+}
+
+func newOpbdTable(tag Tag, b binarySegm, offset, size uint32) *OpbdTable {
+	t := &OpbdTable{}
+	base := tableBase{
+		data:   b,
+		name:   tag,
+		offset: offset,
+		length: size,
+	}
+	t.tableBase = base
+	t.self = t
+	return t
+}
+
+// Formats of 'opbd' tables.
+const (
+	OpbdDistances     uint16 = 0 // optical bounds are distances in font units
+	OpbdControlPoints uint16 = 1 // optical bounds are given by control points of the glyph outline
+)
+
+// OpticalBounds holds the optical bounds of a glyph. For tables of format
+// OpbdDistances, the values are the distances of the optical edges from the
+// left, top, right and bottom edges of the glyph, in font units; positive
+// values move the edges to the right or upwards. For tables of format
+// OpbdControlPoints, the values are indices of control points, with -1 for
+// edges which coincide with the glyph's edges.
+type OpticalBounds struct {
+	Left, Top, Right, Bottom int16
+}
+
+// Bounds returns the optical bounds of glyph g, if g is covered by the table.
+func (t *OpbdTable) Bounds(g GlyphIndex) (OpticalBounds, bool) {
+	if t == nil || t.err != nil {
+		return OpticalBounds{}, false
+	}
+	at, ok := t.bounds.Lookup(g)
+	if !ok || int(at)+8 > len(t.data) {
+		return OpticalBounds{}, false
+	}
+	b := t.data[at:]
+	return OpticalBounds{
+		Left:   int16(b.U16(0)),
+		Top:    int16(b.U16(2)),
+		Right:  int16(b.U16(4)),
+		Bottom: int16(b.U16(6)),
+	}, true
+}
+
+// Error returns parser/validation errors attached to this opbd table view.
+func (t *OpbdTable) Error() error {
+	if t == nil {
+		return nil
+	}
+	return t.err
+}
+
+func parseOpbd(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
+	if len(b) < 8 {
+		ec.addError(tag, "Header", fmt.Sprintf("opbd table too small: %d bytes (need at least 8)", len(b)), SeverityCritical, offset)
+		return nil, errFontFormat("opbd table header too small")
+	}
+	opbd := newOpbdTable(tag, b, offset, size)
+	opbd.Version, opbd.Format = b.U32(0), b.U16(4)
+	if opbd.Version != 0x00010000 || opbd.Format > OpbdControlPoints {
+		issue := fmt.Sprintf("unsupported opbd version 0x%08x, format %d", opbd.Version, opbd.Format)
+		ec.addError(tag, "Version", issue, SeverityMajor, offset)
+		opbd.err = errFontFormat(issue)
+		return opbd, nil
+	}
+	// values of the lookup table are offsets from the start of the opbd table
+	opbd.bounds, _ = viewAATLookup(b, 6, 2)
+	return opbd, nil
+}
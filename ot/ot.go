@@ -1,7 +1,12 @@
 package ot
 
 import (
+	"encoding/binary"
 	"fmt"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"time"
 )
 
 // Font represents the internal structure of an OpenType font.
@@ -11,21 +16,29 @@ import (
 // GSUB, GPOS, etc.
 type Font struct {
 	raw           binarySegm
+	dirOffset     uint32 // offset of the table directory; non-zero for members of collections
 	Header        *FontHeader
 	tables        map[Tag]Table
-	CMap          *CMapTable    // CMAP table is mandatory
-	HHea          *HHeaTable    // typed access to hhea
-	HMtx          *HMtxTable    // typed access to hmtx
-	OS2           *OS2Table     // typed access to OS/2
-	parseErrors   []FontError   // Errors accumulated during parsing
-	parseWarnings []FontWarning // Warnings accumulated during parsing
-	parseOptions  []ParseOption // Options to guide the parsing process
-	Layout        struct {      // OpenType core layout tables
-		GSub *GSubTable // OpenType layout GSUB
-		GPos *GPosTable // OpenType layout GPOS
-		GDef *GDefTable // OpenType layout GDEF
-		Base *BaseTable // OpenType layout BASE
-		// TODO JSTF
+	tableOrder    []Tag          // table tags in the order of the table directory
+	checksums     map[Tag]uint32 // table checksums from the table directory
+	CMap          *CMapTable     // CMAP table is mandatory
+	Head          *HeadTable     // typed access to head
+	HHea          *HHeaTable     // typed access to hhea
+	HMtx          *HMtxTable     // typed access to hmtx
+	OS2           *OS2Table      // typed access to OS/2
+	parseErrors   []FontError    // Errors accumulated during parsing
+	parseWarnings []FontWarning  // Warnings accumulated during parsing
+	issuesMutex   sync.Mutex     // guards parseErrors, which grows on lazy parsing
+	parseOptions  []ParseOption  // Options to guide the parsing process
+	derived       sync.Map       // values derived by client packages, see Derived
+	bboxOnce      sync.Once      // guards bboxes
+	bboxes        []glyphBox     // glyph bounding boxes, see GlyphBBox
+	Layout        struct {       // OpenType core layout tables
+		GSub         *GSubTable // OpenType layout GSUB
+		GPos         *GPosTable // OpenType layout GPOS
+		GDef         *GDefTable // OpenType layout GDEF
+		Base         *BaseTable // OpenType layout BASE
+		Jstf         *JstfTable // OpenType layout JSTF
 		Requirements LayoutRequirements
 	}
 }
@@ -37,6 +50,7 @@ const (
 	IsTestfont        ParseOption = iota // relaxes a number of cross-checks that are normally enforced
 	relaxConsistency                     // relax conistency between tables (e.g, GSUB + GDEF)
 	relaxCompleteness                    // aceept missing tables
+	VerifyChecksums                      // report table and font checksum mismatches as errors
 )
 
 // FontHeader is a directory of the top-level tables in a font. If the font file
@@ -48,7 +62,8 @@ const (
 // for the FontType. OpenType fonts containing CFF data (version 1 or 2) should
 // use 0x4F54544F ('OTTO', when re-interpreted as a Tag).
 // The Apple specification for TrueType fonts allows for 'true' and 'typ1',
-// but these version tags should not be used for OpenType fonts.
+// but these version tags should not be used for OpenType fonts. Package ot
+// accepts them nevertheless, see constants FontType….
 type FontHeader struct {
 	FontType   uint32
 	TableCount uint16
@@ -68,6 +83,11 @@ type FontHeader struct {
 //	os2  := otf.Table(ot.T("OS/2"))
 //	loca := otf.Table(ot.T("loca")).Self().AsLoca()
 //
+// Self() must not be called on a nil table. Prefer the typed getters (GSub, GPos,
+// GDef, CMapTable, …) or TableOf, which are safe for missing tables:
+//
+//	loca, ok := ot.TableOf[*ot.LocaTable](otf)
+//
 // Table tag names are case-sensitive, following the names in the OpenType specification,
 // i.e., one of:
 //
@@ -81,16 +101,16 @@ func (otf *Font) Table(tag Tag) Table {
 	return nil
 }
 
-// TableTags returns a list of tags, one for each table contained in the font.
+// TableTags returns a list of tags, one for each table contained in the font,
+// in the order of the font's table directory. The OpenType specification
+// requires table records to be sorted in ascending order by tag, which Parse
+// enforces. The result is a copy, which clients may modify.
 func (otf *Font) TableTags() []Tag {
-	var tags = make([]Tag, 0, len(otf.tables))
-	for tag := range otf.tables {
-		tags = append(tags, tag)
-	}
-	return tags
+	return slices.Clone(otf.tableOrder)
 }
 
-// Binary returns the raw bytes of this font.
+// Binary returns the raw bytes of this font. For members of a font collection,
+// these are the bytes of the whole collection; see ParseCollection.
 // The returned bytes must be treated as read-only by callers.
 func (otf *Font) Binary() []byte {
 	if otf == nil {
@@ -99,6 +119,59 @@ func (otf *Font) Binary() []byte {
 	return otf.raw
 }
 
+// Derived returns a value derived from otf and identified by key. On the first
+// request for key, build is called to create the value, which is then cached
+// with the font. This lets packages building on top of package ot cache expensive
+// navigation results per font. Keys should be of an unexported type private
+// to the client package, similar to context keys.
+//
+// If concurrent first requests race, build may be called more than once, but
+// all callers will receive the same value.
+func (otf *Font) Derived(key any, build func() any) any {
+	if v, ok := otf.derived.Load(key); ok {
+		return v
+	}
+	v, _ := otf.derived.LoadOrStore(key, build())
+	return v
+}
+
+// Forget drops the value derived from otf for key, if any, so that the next
+// call of Derived for key builds it again. Clients invalidate cached values
+// with it, e.g. after changing data the values have been derived from.
+func (otf *Font) Forget(key any) {
+	otf.derived.Delete(key)
+}
+
+// UniqueID returns a 64-bit identity for the font, suitable as a key for caches
+// of derived data (e.g., shaped glyph runs). It is computed from the head table,
+// which contains the checksum adjustment of the font file, the font revision and
+// creation/modification timestamps, and from the size of the font binary.
+// Two fonts with the same UniqueID may be expected to be identical.
+// For caches shared between processes, see CacheKey.
+func (otf *Font) UniqueID() uint64 {
+	if otf == nil {
+		return 0
+	}
+	h := fnv.New64a()
+	if otf.Head != nil {
+		h.Write(otf.Head.data)
+	}
+	n := uint64(len(otf.raw))
+	if otf.dirOffset != 0 { // members of a collection may share their head table
+		n ^= uint64(otf.dirOffset) << 32
+	}
+	h.Write(binary.LittleEndian.AppendUint64(nil, n))
+	return h.Sum64()
+}
+
+// FontHead returns the parsed head table, if present.
+func (otf *Font) FontHead() *HeadTable {
+	if otf == nil {
+		return nil
+	}
+	return otf.Head
+}
+
 // HorizontalHeader returns the parsed hhea table, if present.
 func (otf *Font) HorizontalHeader() *HHeaTable {
 	if otf == nil {
@@ -123,14 +196,103 @@ func (otf *Font) OS2Metrics() *OS2Table {
 	return otf.OS2
 }
 
+// CMapTable returns the parsed cmap table.
+func (otf *Font) CMapTable() *CMapTable {
+	if otf == nil {
+		return nil
+	}
+	return otf.CMap
+}
+
+// GSub returns the parsed GSUB table, if present.
+func (otf *Font) GSub() *GSubTable {
+	if otf == nil {
+		return nil
+	}
+	return otf.Layout.GSub
+}
+
+// GPos returns the parsed GPOS table, if present.
+func (otf *Font) GPos() *GPosTable {
+	if otf == nil {
+		return nil
+	}
+	return otf.Layout.GPos
+}
+
+// GDef returns the parsed GDEF table, if present.
+func (otf *Font) GDef() *GDefTable {
+	if otf == nil {
+		return nil
+	}
+	return otf.Layout.GDef
+}
+
+// Base returns the parsed BASE table, if present.
+func (otf *Font) Base() *BaseTable {
+	if otf == nil {
+		return nil
+	}
+	return otf.Layout.Base
+}
+
+// Jstf returns the parsed JSTF table, if present.
+func (otf *Font) Jstf() *JstfTable {
+	if otf == nil {
+		return nil
+	}
+	return otf.Layout.Jstf
+}
+
+// TableOf returns the table of otf with concrete type T, e.g.
+//
+//	loca, ok := ot.TableOf[*ot.LocaTable](otf)
+//
+// Most table types of package ot correspond to a single table tag, but some do
+// not: tables package ot does not interpret share a generic type, and tables
+// parsed by registered table parsers share type *CustomTable. If more than one
+// table of otf has type T, TableOf returns the first one in the order of the
+// table directory. If otf has no table of type T, TableOf returns the zero
+// value of T and false.
+// In contrast to otf.Table(tag).Self().As…(), TableOf is safe to call for
+// missing tables.
+func TableOf[T Table](otf *Font) (T, bool) {
+	var zero T
+	if otf == nil {
+		return zero, false
+	}
+	for _, tag := range otf.tableOrder {
+		t := otf.tables[tag]
+		if t == nil {
+			continue
+		}
+		if typed, ok := safeSelf(t.Self()).(T); ok {
+			return typed, true
+		}
+	}
+	return zero, false
+}
+
 // Errors returns all errors encountered during font parsing.
 // These errors represent issues that were found but did not prevent parsing from completing.
 // Clients can inspect these errors to determine if the font is suitable for their use case.
+//
+// Lookup subtables are parsed on first access; errors of damaged subtables are
+// appended when they are accessed, e.g., during text shaping.
 func (otf *Font) Errors() []FontError {
+	otf.issuesMutex.Lock()
+	defer otf.issuesMutex.Unlock()
 	if otf.parseErrors == nil {
 		return []FontError{}
 	}
-	return otf.parseErrors
+	return otf.parseErrors[:len(otf.parseErrors):len(otf.parseErrors)]
+}
+
+// addError appends an error found after parsing of the font has completed.
+func (otf *Font) addError(e FontError) {
+	otf.issuesMutex.Lock()
+	defer otf.issuesMutex.Unlock()
+	otf.parseErrors = append(otf.parseErrors, e)
 }
 
 // Warnings returns all warnings encountered during font parsing.
@@ -146,7 +308,7 @@ func (otf *Font) Warnings() []FontWarning {
 // Critical errors indicate severe problems that may make the font unreliable.
 func (otf *Font) CriticalErrors() []FontError {
 	critical := make([]FontError, 0)
-	for _, err := range otf.parseErrors {
+	for _, err := range otf.Errors() {
 		if err.Severity == SeverityCritical {
 			critical = append(critical, err)
 		}
@@ -157,7 +319,7 @@ func (otf *Font) CriticalErrors() []FontError {
 // HasCriticalErrors returns true if any critical errors were encountered during parsing.
 // Fonts with critical errors may be unreliable or unusable.
 func (otf *Font) HasCriticalErrors() bool {
-	for _, err := range otf.parseErrors {
+	for _, err := range otf.Errors() {
 		if err.Severity == SeverityCritical {
 			return true
 		}
@@ -338,6 +500,86 @@ func (tself TableSelf) AsBase() *BaseTable {
 	return nil
 }
 
+// AsJstf returns this table as a JSTF table, or nil.
+func (tself TableSelf) AsJstf() *JstfTable {
+	if k, ok := safeSelf(tself).(*JstfTable); ok {
+		return k
+	}
+	return nil
+}
+
+// AsMeta returns this table as a meta table, or nil.
+func (tself TableSelf) AsMeta() *MetaTable {
+	if k, ok := safeSelf(tself).(*MetaTable); ok {
+		return k
+	}
+	return nil
+}
+
+// AsMorx returns this table as a morx table, or nil.
+func (tself TableSelf) AsMorx() *MorxTable {
+	if k, ok := safeSelf(tself).(*MorxTable); ok {
+		return k
+	}
+	return nil
+}
+
+// AsKerx returns this table as a kerx table, or nil.
+func (tself TableSelf) AsKerx() *KerxTable {
+	if k, ok := safeSelf(tself).(*KerxTable); ok {
+		return k
+	}
+	return nil
+}
+
+// AsSilf returns this table as a Graphite Silf table, or nil.
+func (tself TableSelf) AsSilf() *SilfTable {
+	if k, ok := safeSelf(tself).(*SilfTable); ok {
+		return k
+	}
+	return nil
+}
+
+// AsGlat returns this table as a Graphite Glat table, or nil.
+func (tself TableSelf) AsGlat() *GlatTable {
+	if k, ok := safeSelf(tself).(*GlatTable); ok {
+		return k
+	}
+	return nil
+}
+
+// AsGloc returns this table as a Graphite Gloc table, or nil.
+func (tself TableSelf) AsGloc() *GlocTable {
+	if k, ok := safeSelf(tself).(*GlocTable); ok {
+		return k
+	}
+	return nil
+}
+
+// AsFeat returns this table as a Graphite Feat table, or nil.
+func (tself TableSelf) AsFeat() *FeatTable {
+	if k, ok := safeSelf(tself).(*FeatTable); ok {
+		return k
+	}
+	return nil
+}
+
+// AsOpbd returns this table as an opbd table, or nil.
+func (tself TableSelf) AsOpbd() *OpbdTable {
+	if k, ok := safeSelf(tself).(*OpbdTable); ok {
+		return k
+	}
+	return nil
+}
+
+// AsLcar returns this table as an lcar table, or nil.
+func (tself TableSelf) AsLcar() *LcarTable {
+	if k, ok := safeSelf(tself).(*LcarTable); ok {
+		return k
+	}
+	return nil
+}
+
 // AsLoca returns this table as a kern table, or nil.
 func (tself TableSelf) AsLoca() *LocaTable {
 	if k, ok := safeSelf(tself).(*LocaTable); ok {
@@ -393,9 +635,56 @@ func (tself TableSelf) AsHMtx() *HMtxTable {
 // needed for consistency-checks.
 type HeadTable struct {
 	tableBase
-	Flags            uint16 // see https://docs.microsoft.com/en-us/typography/opentype/spec/head
-	UnitsPerEm       uint16 // values 16 … 16384 are valid
-	IndexToLocFormat uint16 // needed to interpret loca table
+	FontRevision       uint32    // 16.16 fixed-point revision, set by the font manufacturer
+	ChecksumAdjustment uint32    // checksum over the whole font file
+	Flags              uint16    // see https://docs.microsoft.com/en-us/typography/opentype/spec/head
+	UnitsPerEm         uint16    // values 16 … 16384 are valid
+	Created            time.Time // creation time of the font
+	Modified           time.Time // time of last modification of the font
+	XMin               int16     // bounding box of all glyphs
+	YMin               int16     // bounding box of all glyphs
+	XMax               int16     // bounding box of all glyphs
+	YMax               int16     // bounding box of all glyphs
+	MacStyle           uint16    // bold, italic, etc.; see constants MacStyleBold, …
+	LowestRecPPEM      uint16    // smallest readable size in pixels
+	IndexToLocFormat   uint16    // needed to interpret loca table
+}
+
+// Flags for HeadTable.MacStyle.
+const (
+	MacStyleBold      uint16 = 1 << 0
+	MacStyleItalic    uint16 = 1 << 1
+	MacStyleUnderline uint16 = 1 << 2
+	MacStyleOutline   uint16 = 1 << 3
+	MacStyleShadow    uint16 = 1 << 4
+	MacStyleCondensed uint16 = 1 << 5
+	MacStyleExtended  uint16 = 1 << 6
+)
+
+// BoundingBox returns the bounding box of all glyphs of the font, in font units.
+func (t *HeadTable) BoundingBox() (xMin, yMin, xMax, yMax int16) {
+	if t == nil {
+		return 0, 0, 0, 0
+	}
+	return t.XMin, t.YMin, t.XMax, t.YMax
+}
+
+// IsBold reports whether the font is flagged as bold in field MacStyle.
+func (t *HeadTable) IsBold() bool {
+	return t != nil && t.MacStyle&MacStyleBold != 0
+}
+
+// IsItalic reports whether the font is flagged as italic in field MacStyle.
+func (t *HeadTable) IsItalic() bool {
+	return t != nil && t.MacStyle&MacStyleItalic != 0
+}
+
+// fontEpoch is the reference date for OpenType LONGDATETIME values.
+var fontEpoch = time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// longDateTime converts seconds since 1904-01-01 00:00 UTC to a time value.
+func longDateTime(secs int64) time.Time {
+	return fontEpoch.Add(time.Duration(secs) * time.Second)
 }
 
 func newHeadTable(tag Tag, b binarySegm, offset, size uint32) *HeadTable {
@@ -504,6 +793,24 @@ type HHeaTable struct {
 	NumberOfHMetrics    int
 }
 
+// LineHeight returns the default line spacing in font units, i.e.,
+// ascender - descender + line gap.
+func (t *HHeaTable) LineHeight() int {
+	if t == nil {
+		return 0
+	}
+	return int(t.Ascender) - int(t.Descender) + int(t.LineGap)
+}
+
+// CaretSlope returns rise and run of the slope of the cursor. A value of
+// (1, 0) denotes a vertical caret.
+func (t *HHeaTable) CaretSlope() (rise, run int16) {
+	if t == nil {
+		return 0, 0
+	}
+	return t.CaretSlopeRise, t.CaretSlopeRun
+}
+
 func newHHeaTable(tag Tag, b binarySegm, offset, size uint32) *HHeaTable {
 	t := &HHeaTable{}
 	base := tableBase{
@@ -674,6 +981,23 @@ func (t *HMtxTable) HMetrics(g GlyphIndex) (uint16, int16, bool) {
 	return t.longMetrics[len(t.longMetrics)-1].AdvanceWidth, t.leftSideBearings[i], true
 }
 
+// Advance returns the advance width of a glyph in font design units.
+// Glyphs beyond NumberOfHMetrics share the advance width of the last long
+// metrics record, as is customary for the monospaced tail of a font.
+// For glyph indices out of range, 0 is returned.
+func (t *HMtxTable) Advance(g GlyphIndex) uint16 {
+	a, _, _ := t.HMetrics(g)
+	return a
+}
+
+// LeftSideBearing returns the left side bearing of a glyph in font design units.
+// For glyphs beyond NumberOfHMetrics, the value is taken from the trailing
+// array of left side bearings. For glyph indices out of range, 0 is returned.
+func (t *HMtxTable) LeftSideBearing(g GlyphIndex) int16 {
+	_, l, _ := t.HMetrics(g)
+	return l
+}
+
 // hMetrics returns the advance width and left side bearing of a glyph.
 // TODO: call from font or from HMtx ?
 func (t *HMtxTable) hMetrics(g GlyphIndex) (uint16, int16) {
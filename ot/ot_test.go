@@ -55,7 +55,7 @@ func TestTableName(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-func loadTestdataFont(t *testing.T, pattern string) *Font {
+func loadTestdataFont(t testing.TB, pattern string) *Font {
 	level := tracer().GetTraceLevel()
 	tracer().SetTraceLevel(tracing.LevelInfo)
 	defer tracer().SetTraceLevel(level)
@@ -103,3 +103,30 @@ func loadTestFont(t *testing.T, pattern string) *Font {
 	return otf
 }
 */
+
+func TestTypedTableGetters(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	if otf.GSub() == nil || otf.GPos() == nil || otf.GDef() == nil || otf.CMapTable() == nil {
+		t.Fatalf("expected Calibri to have typed GSUB, GPOS, GDEF and cmap tables")
+	}
+	if otf.GSub() != otf.Table(T("GSUB")).Self().AsGSub() {
+		t.Errorf("expected GSub() to return the GSUB table")
+	}
+	loca, ok := TableOf[*LocaTable](otf)
+	if !ok || loca != otf.Table(T("loca")).Self().AsLoca() {
+		t.Errorf("expected TableOf to return the loca table")
+	}
+	if _, ok := TableOf[*BaseTable](otf); ok != (otf.Base() != nil) {
+		t.Errorf("expected TableOf[*BaseTable] to agree with Base()")
+	}
+	var none *Font
+	if none.GSub() != nil || none.CMapTable() != nil {
+		t.Errorf("expected typed getters of nil font to return nil")
+	}
+	if _, ok := TableOf[*GSubTable](none); ok {
+		t.Errorf("expected TableOf on nil font to fail")
+	}
+}
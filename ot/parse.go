@@ -7,6 +7,8 @@ import (
 	"io"
 	"math"
 	"slices"
+
+	"github.com/npillmayer/opentype/internal/guard"
 )
 
 // Code comment often will cite passage from the
@@ -88,11 +90,11 @@ func checkedAddUint32(a, b uint32) (uint32, error) {
 
 // ---------------------------------------------------------------------------
 
-// errFontFormat produces user level errors for font parsing.
-// This is a compatibility helper that returns a simple error.
-// In the future, this will be replaced with proper error collection.
+// errFontFormat produces user level errors for font parsing, matching
+// ErrFontFormat. Errors concerning missing tables, bounds or unsupported
+// versions use the more specific error types of errors.go.
 func errFontFormat(message string) error {
-	return fmt.Errorf("OpenType font format: %s", message)
+	return fmt.Errorf("%w: %s", ErrFontFormat, message)
 }
 
 // ---------------------------------------------------------------------------
@@ -100,9 +102,27 @@ func errFontFormat(message string) error {
 // Parse parses an OpenType font from a byte slice.
 // An ot.Font needs ongoing access to the fonts byte-data after the Parse function returns.
 // Its elements are assumed immutable while the ot.Font remains in use.
-func Parse(font []byte, options ...ParseOption) (*Font, error) {
+//
+// Parse does not panic on malformed input: internal errors are recovered and
+// returned as errors matching ErrFontFormat, unless the module is built with
+// tag 'otassert'.
+//
+// Font collections are parsed with ParseCollection.
+func Parse(font []byte, options ...ParseOption) (otf *Font, err error) {
+	defer guard.Recover(&err, tracer(), "ot.Parse", ErrFontFormat)
+	return parseFontAt(binarySegm(font), 0, nil, options)
+}
+
+// parseFontAt parses the font with the table directory at offset dirOffset of
+// src. src is the binary of a single font (dirOffset 0) or of a font
+// collection. If shared is non-nil, tables are looked up in and added to
+// shared, to parse tables shared between members of a collection only once.
+func parseFontAt(src binarySegm, dirOffset uint32, shared *tableCache, options []ParseOption) (*Font, error) {
 	// https://www.microsoft.com/typography/otspec/otff.htm: Offset Table is 12 bytes.
-	r := bytes.NewReader(font)
+	if int(dirOffset) > len(src) {
+		return nil, &ErrBounds{Offset: dirOffset, Issue: "table directory offset out of bounds"}
+	}
+	r := bytes.NewReader(src[dirOffset:])
 	h := FontHeader{}
 	if err := binary.Read(r, binary.BigEndian, &h); err != nil {
 		return nil, err
@@ -112,14 +132,19 @@ func Parse(font []byte, options ...ParseOption) (*Font, error) {
 	// Create error collector for accumulating errors during parsing
 	ec := &errorCollector{}
 
-	if !(h.FontType == 0x4f54544f || // OTTO
-		h.FontType == 0x00010000 || // TrueType
-		h.FontType == 0x74727565) { // true
-		ec.addError(T(""), "Header", fmt.Sprintf("font type not supported: %x", h.FontType), SeverityCritical, 0)
-		return nil, errFontFormat(fmt.Sprintf("font type not supported: %x", h.FontType))
+	if h.FontType == fontTypeCollection && shared == nil {
+		ec.addError(T(""), "Header", "font collection, use ParseCollection", SeverityCritical, 0)
+		return nil, errFontFormat("font collection, use ParseCollection")
+	}
+	if !(h.FontType == FontTypeCFF ||
+		h.FontType == FontTypeTrueType ||
+		h.FontType == FontTypeAppleTrue ||
+		h.FontType == FontTypeAppleTyp1) {
+		ec.addError(T(""), "Header", fmt.Sprintf("font type not supported: %x", h.FontType), SeverityCritical, dirOffset)
+		return nil, errUnsupported(fmt.Sprintf("font type not supported: %x", h.FontType))
 	}
-	src := binarySegm(font)
-	otf := &Font{raw: src, Header: &h, tables: make(map[Tag]Table)}
+	otf := &Font{raw: src, Header: &h, tables: make(map[Tag]Table), checksums: make(map[Tag]uint32)}
+	otf.dirOffset = dirOffset
 	configureWithOptions(otf, options)
 	// "The Offset Table is followed immediately by the Table Record entries …
 	// sorted in ascending order by tag", 16 bytes each.
@@ -127,19 +152,20 @@ func Parse(font []byte, options ...ParseOption) (*Font, error) {
 	// Check for arithmetic overflow in table record size calculation
 	tableRecordsSize, err := checkedMulInt(16, int(h.TableCount))
 	if err != nil {
-		ec.addError(T(""), "TableRecords", fmt.Sprintf("table count too large: %v", err), SeverityCritical, 12)
+		ec.addError(T(""), "TableRecords", fmt.Sprintf("table count too large: %v", err), SeverityCritical, dirOffset+12)
 		return nil, errFontFormat(fmt.Sprintf("table count too large: %v", err))
 	}
 
-	buf, err := src.view(12, tableRecordsSize)
+	buf, err := src.view(int(dirOffset)+12, tableRecordsSize)
 	if err != nil {
-		ec.addError(T(""), "TableRecords", "table record entries", SeverityCritical, 12)
-		return nil, errFontFormat("table record entries")
+		ec.addError(T(""), "TableRecords", "table record entries", SeverityCritical, dirOffset+12)
+		return nil, &ErrBounds{Offset: dirOffset + 12, Issue: "table record entries exceed font size"}
 	}
+	records := make([]tableRecord, 0, h.TableCount)
 	for b, prevTag := buf, Tag(0); len(b) > 0; b = b[16:] {
 		tag := MakeTag(b)
 		if tag < prevTag {
-			ec.addError(T(""), "TableRecords", "table order", SeverityCritical, 12)
+			ec.addError(T(""), "TableRecords", "table order", SeverityCritical, dirOffset+12)
 			return nil, errFontFormat("table order")
 		}
 		prevTag = tag
@@ -157,11 +183,22 @@ func Parse(font []byte, options ...ParseOption) (*Font, error) {
 		}
 		if off > uint32(len(src)) || tableEnd > uint32(len(src)) {
 			ec.addError(tag, "Bounds", fmt.Sprintf("bounds [%d:%d] exceed font size %d", off, tableEnd, len(src)), SeverityCritical, off)
-			return nil, errFontFormat(fmt.Sprintf("table %s: bounds [%d:%d] exceed font size %d",
-				tag, off, tableEnd, len(src)))
+			return nil, &ErrBounds{Table: tag, Offset: off,
+				Issue: fmt.Sprintf("bounds [%d:%d] exceed font size %d", off, tableEnd, len(src))}
+		}
+		otf.checksums[tag] = u32(b[4:8])
+		records = append(records, tableRecord{tag: tag, offset: off, size: size})
+		if n := len(otf.tableOrder); n == 0 || otf.tableOrder[n-1] != tag { // duplicates are adjacent
+			otf.tableOrder = append(otf.tableOrder, tag)
+		}
+	}
+	for _, rec := range records {
+		data := src[rec.offset : rec.offset+rec.size]
+		if shared == nil {
+			otf.tables[rec.tag], err = parseTable(rec.tag, data, rec.offset, rec.size, ec)
+		} else {
+			otf.tables[rec.tag], err = shared.parseTable(rec, records, data, ec)
 		}
-
-		otf.tables[tag], err = parseTable(tag, src[off:tableEnd], off, size, ec)
 		if err != nil {
 			return nil, err
 		}
@@ -189,13 +226,21 @@ func Parse(font []byte, options ...ParseOption) (*Font, error) {
 			}
 			if ma := otf.Table(T("maxp")); ma != nil {
 				maxp := ma.Self().AsMaxP()
-				loca.locCnt = maxp.NumGlyphs
+				loca.locCnt = maxp.NumGlyphs + 1 // including the end of the last glyph
 			}
 		}
 	}
 
-	// Transfer accumulated errors and warnings to the Font
-	otf.parseErrors = ec.errors
+	if slices.Contains(options, VerifyChecksums) {
+		for _, m := range otf.VerifyChecksums() {
+			ec.addError(m.Table, "Checksum", m.String(), SeverityMinor, 0)
+		}
+	}
+	// Transfer accumulated errors and warnings to the Font, keeping errors of
+	// lookup subtables accessed during parsing
+	otf.issuesMutex.Lock()
+	otf.parseErrors = append(ec.errors, otf.parseErrors...)
+	otf.issuesMutex.Unlock()
 	otf.parseWarnings = ec.warnings
 
 	return otf, nil
@@ -230,15 +275,18 @@ func extractLayoutInfo(otf *Font, ec *errorCollector) error {
 		if h == nil {
 			ec.addError(T(tag), "Missing", "missing required table", SeverityCritical, 0)
 			if !slices.Contains(otf.parseOptions, relaxCompleteness) {
-				return errFontFormat("missing required table " + tag)
+				return &ErrMissingTable{Tag: T(tag)}
 			}
 		}
 	}
 	if otf.tables[T("cmap")] == nil { // cmap is always required, even in test-fonts
 		ec.addError(T("cmap"), "Missing", "missing required table", SeverityCritical, 0)
-		return errFontFormat("missing required table cmap")
+		return &ErrMissingTable{Tag: T("cmap")}
 	}
 	otf.CMap = otf.tables[T("cmap")].Self().AsCMap()
+	if headTable := otf.Table(T("head")); headTable != nil {
+		otf.Head = headTable.Self().AsHead()
+	}
 	if hheaTable := otf.Table(T("hhea")); hheaTable != nil {
 		otf.HHea = hheaTable.Self().AsHHea()
 	}
@@ -271,7 +319,7 @@ func extractLayoutInfo(otf *Font, ec *errorCollector) error {
 		if h == nil {
 			ec.addError(T(tag), "Missing", "missing advanced layout table", SeverityCritical, 0)
 			if !slices.Contains(otf.parseOptions, relaxCompleteness) {
-				return errFontFormat("missing advanced layout table " + tag)
+				return &ErrMissingTable{Tag: T(tag)}
 			}
 		}
 	}
@@ -288,7 +336,41 @@ func extractLayoutInfo(otf *Font, ec *errorCollector) error {
 	if baseTable := otf.tables[T("BASE")]; baseTable != nil {
 		otf.Layout.Base = baseTable.Self().AsBase()
 	}
-	//otf.Layout.Jstf = otf.tables[T("JSTF")].Self().AsJstf()
+	// device tables of GPOS value records need units per em and GDEF's variation store
+	if otf.Layout.GPos != nil && otf.Layout.GPos.lookupGraph != nil {
+		if devices := otf.Layout.GPos.lookupGraph.devices; devices != nil {
+			if otf.Head != nil {
+				devices.unitsPerEm = otf.Head.UnitsPerEm
+			}
+			devices.store = otf.Layout.GDef.VariationStore()
+		}
+	}
+	// lookups validate substitute glyph IDs against maxp.NumGlyphs
+	if maxpTable := otf.Table(T("maxp")); maxpTable != nil {
+		numGlyphs := maxpTable.Self().AsMaxP().NumGlyphs
+		if otf.Layout.GSub != nil && otf.Layout.GSub.lookupGraph != nil {
+			otf.Layout.GSub.lookupGraph.numGlyphs = numGlyphs
+		}
+		if otf.Layout.GPos != nil && otf.Layout.GPos.lookupGraph != nil {
+			otf.Layout.GPos.lookupGraph.numGlyphs = numGlyphs
+		}
+	}
+	// errors of lookup subtables, which are parsed lazily, go to the font's error list
+	var graphs []*LookupListGraph
+	if otf.Layout.GSub != nil {
+		graphs = append(graphs, otf.Layout.GSub.lookupGraph)
+	}
+	if otf.Layout.GPos != nil {
+		graphs = append(graphs, otf.Layout.GPos.lookupGraph)
+	}
+	for _, lg := range graphs {
+		if lg != nil && lg.issues != nil {
+			lg.issues.addErr = otf.addError
+		}
+	}
+	if jstfTable := otf.tables[T("JSTF")]; jstfTable != nil {
+		otf.Layout.Jstf = jstfTable.Self().AsJstf()
+	}
 
 	// Collect layout requirements from parsed GSUB/GPOS lookup flags.
 	otf.Layout.Requirements = LayoutRequirements{}
@@ -304,7 +386,7 @@ func extractLayoutInfo(otf *Font, ec *errorCollector) error {
 		major, minor := otf.Layout.GDef.Header().Version()
 		if major != 1 || minor > 3 {
 			ec.addError(T("GDEF"), "Version", fmt.Sprintf("unsupported GDEF version %d.%d", major, minor), SeverityCritical, 0)
-			return errFontFormat("unsupported GDEF version")
+			return errUnsupported(fmt.Sprintf("GDEF version %d.%d", major, minor))
 		}
 	}
 
@@ -315,7 +397,7 @@ func extractLayoutInfo(otf *Font, ec *errorCollector) error {
 	if doCheck && (req.NeedGlyphClassDef || req.NeedMarkAttachClassDef || req.NeedMarkGlyphSets) {
 		if otf.Layout.GDef == nil {
 			ec.addError(T("GDEF"), "Missing", "missing required GDEF table", SeverityCritical, 0)
-			return errFontFormat("missing required GDEF table")
+			return &ErrMissingTable{Tag: T("GDEF")}
 		}
 		if req.NeedGlyphClassDef && otf.Layout.GDef.Header().offsetFor(GDefGlyphClassDefSection) == 0 {
 			ec.addError(T("GDEF"), "GlyphClassDef", "missing required GDEF GlyphClassDef", SeverityCritical, 0)
@@ -330,6 +412,7 @@ func extractLayoutInfo(otf *Font, ec *errorCollector) error {
 			return errFontFormat("missing required GDEF MarkGlyphSetsDef")
 		}
 	}
+	validateMarkFilteringSets(otf, ec)
 	// GSUB/GPOS must have ScriptList, FeatureList, and LookupList
 	if gsub := otf.Layout.GSub; gsub != nil {
 		sg := gsub.ScriptGraph()
@@ -358,6 +441,49 @@ func extractLayoutInfo(otf *Font, ec *errorCollector) error {
 	return nil
 }
 
+// validateMarkFilteringSets reports lookups with flag USE_MARK_FILTERING_SET
+// which lack a mark filtering set index or reference a mark glyph set not
+// defined in GDEF. Such lookups do not make the font unusable: at layout time,
+// marks are skipped as if they were not in the set.
+func validateMarkFilteringSets(otf *Font, ec *errorCollector) {
+	if !otf.Layout.Requirements.NeedMarkGlyphSets {
+		return
+	}
+	var setCount int
+	if otf.Layout.GDef != nil {
+		setCount = len(otf.Layout.GDef.MarkGlyphSets)
+	}
+	tables := map[Tag]*LayoutTable{}
+	if otf.Layout.GSub != nil {
+		tables[T("GSUB")] = &otf.Layout.GSub.LayoutTable
+	}
+	if otf.Layout.GPos != nil {
+		tables[T("GPOS")] = &otf.Layout.GPos.LayoutTable
+	}
+	for _, tag := range []Tag{T("GSUB"), T("GPOS")} {
+		lytt := tables[tag]
+		if lytt == nil || !lytt.Requirements.NeedMarkGlyphSets {
+			continue
+		}
+		for i, lt := range lytt.LookupGraph().Range() {
+			if lt == nil || lt.Flag&LOOKUP_FLAG_USE_MARK_FILTERING_SET == 0 {
+				continue
+			}
+			set, ok := lt.UsesMarkFilteringSet()
+			switch {
+			case !ok:
+				ec.addError(tag, "LookupList",
+					fmt.Sprintf("lookup %d uses a mark filtering set, but lacks the set index", i),
+					SeverityMajor, 0)
+			case int(set) >= setCount:
+				ec.addError(tag, "LookupList",
+					fmt.Sprintf("lookup %d references mark filtering set %d, but GDEF defines %d sets", i, set, setCount),
+					SeverityMajor, 0)
+			}
+		}
+	}
+}
+
 // validateCrossTableConsistency performs cross-table validation to ensure
 // internal consistency between related tables.
 func validateCrossTableConsistency(otf *Font, ec *errorCollector) error {
@@ -365,7 +491,7 @@ func validateCrossTableConsistency(otf *Font, ec *errorCollector) error {
 	maxpTable := otf.Table(T("maxp"))
 	if maxpTable == nil {
 		ec.addError(T("maxp"), "Missing", "maxp table required for validation", SeverityCritical, 0)
-		return errFontFormat("maxp table required for validation")
+		return &ErrMissingTable{Tag: T("maxp")}
 	}
 	maxp := maxpTable.Self().AsMaxP()
 	numGlyphs := maxp.NumGlyphs
@@ -412,8 +538,8 @@ func validateCrossTableConsistency(otf *Font, ec *errorCollector) error {
 			ec.addError(T("hmtx"), "Size",
 				fmt.Sprintf("table size %d insufficient for %d glyphs (need %d)", hmtx.length, numGlyphs, requiredSize),
 				SeverityCritical, 0)
-			return errFontFormat(fmt.Sprintf("hmtx table size (%d) insufficient for %d glyphs (need %d)",
-				hmtx.length, numGlyphs, requiredSize))
+			return &ErrBounds{Table: T("hmtx"), Offset: hmtx.offset,
+				Issue: fmt.Sprintf("table size (%d) insufficient for %d glyphs (need %d)", hmtx.length, numGlyphs, requiredSize)}
 		}
 		if err := hmtx.parseAll(numGlyphs, hhea.NumberOfHMetrics); err != nil {
 			ec.addError(T("hmtx"), "Decode",
@@ -440,8 +566,9 @@ func validateCrossTableConsistency(otf *Font, ec *errorCollector) error {
 			}
 			if int(loca.length) < expectedLocaSize {
 				ec.addError(T("loca"), "Size", fmt.Sprintf("table size (%d) insufficient for %d glyphs in short format (need %d)", loca.length, numGlyphs, expectedLocaSize), SeverityCritical, 0)
-				return errFontFormat(fmt.Sprintf("loca table size (%d) insufficient for %d glyphs in short format (need %d)",
-					loca.length, numGlyphs, expectedLocaSize))
+				return &ErrBounds{Table: T("loca"), Offset: loca.offset,
+					Issue: fmt.Sprintf("table size (%d) insufficient for %d glyphs in short format (need %d)",
+						loca.length, numGlyphs, expectedLocaSize)}
 			}
 		case 1: // Long format: (numGlyphs + 1) * 4 bytes
 			expectedLocaSize, err := checkedMulInt(numGlyphs+1, 4)
@@ -451,8 +578,9 @@ func validateCrossTableConsistency(otf *Font, ec *errorCollector) error {
 			}
 			if int(loca.length) < expectedLocaSize {
 				ec.addError(T("loca"), "Size", fmt.Sprintf("table size (%d) insufficient for %d glyphs in long format (need %d)", loca.length, numGlyphs, expectedLocaSize), SeverityCritical, 0)
-				return errFontFormat(fmt.Sprintf("loca table size (%d) insufficient for %d glyphs in long format (need %d)",
-					loca.length, numGlyphs, expectedLocaSize))
+				return &ErrBounds{Table: T("loca"), Offset: loca.offset,
+					Issue: fmt.Sprintf("table size (%d) insufficient for %d glyphs in long format (need %d)",
+						loca.length, numGlyphs, expectedLocaSize)}
 			}
 		default:
 			ec.addError(T("head"), "IndexToLocFormat", fmt.Sprintf("invalid value: %d (must be 0 or 1)", head.IndexToLocFormat), SeverityCritical, 0)
@@ -477,6 +605,12 @@ func parseTable(t Tag, b binarySegm, offset, size uint32, ec *errorCollector) (T
 		return parseBase(t, b, offset, size, ec)
 	case T("cmap"):
 		return parseCMap(t, b, offset, size, ec)
+	case T("Feat"):
+		return parseFeat(t, b, offset, size, ec)
+	case T("Glat"):
+		return parseGlat(t, b, offset, size, ec)
+	case T("Gloc"):
+		return parseGloc(t, b, offset, size, ec)
 	case T("head"):
 		return parseHead(t, b, offset, size, ec)
 	case T("GDEF"):
@@ -489,12 +623,29 @@ func parseTable(t Tag, b binarySegm, offset, size uint32, ec *errorCollector) (T
 		return parseHHea(t, b, offset, size, ec)
 	case T("hmtx"):
 		return parseHMtx(t, b, offset, size, ec)
+	case T("JSTF"):
+		return parseJstf(t, b, offset, size, ec)
+	case T("kerx"):
+		return parseKerx(t, b, offset, size, ec)
+	case T("lcar"):
+		return parseLcar(t, b, offset, size, ec)
 	case T("loca"):
 		return parseLoca(t, b, offset, size, ec)
 	case T("maxp"):
 		return parseMaxP(t, b, offset, size, ec)
+	case T("meta"):
+		return parseMeta(t, b, offset, size, ec)
+	case T("morx"):
+		return parseMorx(t, b, offset, size, ec)
+	case T("opbd"):
+		return parseOpbd(t, b, offset, size, ec)
 	case T("OS/2"):
 		return parseOS2(t, b, offset, size, ec)
+	case T("Silf"):
+		return parseSilf(t, b, offset, size, ec)
+	}
+	if ct := parseCustomTable(t, b, offset, size, ec); ct != nil {
+		return ct, nil
 	}
 	tracer().Infof("font contains table (%s), will not be interpreted", t)
 	// Record as minor warning - not parsed but not a problem
@@ -510,8 +661,21 @@ func parseHead(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (
 		return nil, errFontFormat("size of head table")
 	}
 	t := newHeadTable(tag, b, offset, size)
+	t.FontRevision, _ = b.u32(4)
+	t.ChecksumAdjustment, _ = b.u32(8)
 	t.Flags, _ = b.u16(16)      // flags
 	t.UnitsPerEm, _ = b.u16(18) // units per em
+	created, _ := b.u64(20)
+	modified, _ := b.u64(28)
+	t.Created = longDateTime(int64(created))
+	t.Modified = longDateTime(int64(modified))
+	xmin, _ := b.u16(36)
+	ymin, _ := b.u16(38)
+	xmax, _ := b.u16(40)
+	ymax, _ := b.u16(42)
+	t.XMin, t.YMin, t.XMax, t.YMax = int16(xmin), int16(ymin), int16(xmax), int16(ymax)
+	t.MacStyle, _ = b.u16(44)
+	t.LowestRecPPEM, _ = b.u16(46)
 	// IndexToLocFormat is needed to interpret the loca table:
 	// 0 for short offsets, 1 for long
 	t.IndexToLocFormat, _ = b.u16(50)
@@ -607,6 +771,7 @@ func parseBase(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (
 //
 //	0 (Unicode)  3    4   Unicode BMB
 //	0 (Unicode)  4    12  Unicode full
+//	3 (Win)      0    4   Symbol
 //	3 (Win)      1    4   Unicode BMP
 //	3 (Win)      10   12  Unicode full
 func parseCMap(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (Table, error) {
@@ -648,6 +813,8 @@ func parseCMap(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (
 		format := subtable.U16(0)
 		tracer().Debugf("cmap table contains subtable with format %d", format)
 		if supportedCmapFormat(format, pid, psid) {
+			enc.platformId = pid
+			enc.encodingId = psid
 			enc.width = width
 			enc.format = format
 			enc.link = link
@@ -655,7 +822,7 @@ func parseCMap(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (
 	}
 	if enc.width == 0 {
 		ec.addError(tag, "Format", "no supported cmap format found", SeverityMajor, offset)
-		return nil, errFontFormat("no supported cmap format found")
+		return nil, errUnsupported("no supported cmap format found")
 	}
 	t.GlyphIndexMap, err = makeGlyphIndex(enc, tag, offset, ec)
 	if err != nil {
@@ -810,12 +977,10 @@ func parseGDef(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (
 	err = parseGDefHeader(gdef, b, err, tag, offset, ec)
 	err = parseGlyphClassDefinitions(gdef, b, err)
 	err = parseAttachmentPointList(gdef, b, err, tag, offset, ec)
-	// We do not parse the Ligature Caret List Table (used for text editing/cursor positioning).
-	// This is not needed for layout analysis and glyph metrics extraction.
+	err = parseLigCaretList(gdef, b, err, tag, offset, ec)
 	err = parseMarkAttachmentClassDef(gdef, b, err)
 	err = parseMarkGlyphSets(gdef, b, err, tag, offset, ec)
-	// We do not parse the Item Variation Store (GDEF v1.3, variable fonts only).
-	// Variable font support may be added in the future.
+	parseGDefVariationStore(gdef, b, err, tag, offset, ec)
 	if err != nil {
 		tracer().Errorf("error parsing GDEF table: %v", err)
 		return gdef, err
@@ -904,6 +1069,25 @@ func parseGDefHeader(gdef *GDefTable, b binarySegm, err error, tag Tag, offset u
 	return err
 }
 
+// parseGDefVariationStore parses the Item Variation Store of GDEF v1.3, present
+// in variable fonts only. A damaged store is reported, but does not invalidate
+// the GDEF table.
+func parseGDefVariationStore(gdef *GDefTable, b binarySegm, err error, tag Tag, offset uint32, ec *errorCollector) {
+	if err != nil {
+		return
+	}
+	off := gdef.Header().offsetFor(GDefItemVarStoreSection)
+	if off == 0 || off >= len(b) {
+		return
+	}
+	store, err := ParseItemVariationStore(b[off:])
+	if err != nil {
+		ec.addError(tag, "ItemVarStore", err.Error(), SeverityMajor, offset+uint32(off))
+		return
+	}
+	gdef.varStore = store
+}
+
 // This table uses the same format as the Class Definition table (defined in the
 // OpenType Layout Common Table Formats chapter).
 func parseGlyphClassDefinitions(gdef *GDefTable, b binarySegm, err error) error {
@@ -988,6 +1172,111 @@ func parseAttachmentPointList(gdef *GDefTable, b binarySegm, err error, tag Tag,
 	return nil
 }
 
+/*
+LigCaretList:
+Type      Name                             Description
+---------+--------------------------------+-----------------------
+Offset16  coverageOffset                   Offset to Coverage table - from beginning of LigCaretList table
+uint16    ligGlyphCount                    Number of ligature glyphs
+Offset16  ligGlyphOffsets[ligGlyphCount]   Array of offsets to LigGlyph tables, from beginning of
+
+	LigCaretList table—in Coverage Index order
+
+LigGlyph tables and caret values are decoded on demand, see GDefTable.LigatureCarets.
+*/
+func parseLigCaretList(gdef *GDefTable, b binarySegm, err error, tag Tag, tableOffset uint32, ec *errorCollector) error {
+	if err != nil {
+		return err
+	}
+	offset := gdef.Header().offsetFor(GDefLigCaretListSection)
+	if offset == 0 {
+		return nil
+	}
+	if offset >= len(b) {
+		return io.ErrUnexpectedEOF
+	}
+	b = b[offset:]
+	if len(b) < 4 {
+		ec.addError(tag, "LigCaretList", "ligature caret list header too small", SeverityMajor, tableOffset+uint32(offset))
+		return nil // caret positions are not needed for layout, continue parsing
+	}
+	count := b.U16(2)
+	if 4+int(count)*2 > len(b) {
+		ec.addError(tag, "LigCaretList", fmt.Sprintf("ligature count %d exceeds table size", count), SeverityMajor, tableOffset+uint32(offset))
+		return nil
+	}
+	covOffset := b.U16(0)
+	if int(covOffset) >= len(b) {
+		ec.addError(tag, "LigCaretList", "coverage offset out of bounds", SeverityMajor, tableOffset+uint32(offset))
+		return nil
+	}
+	coverage := parseCoverage(b[covOffset:])
+	if coverage.GlyphRange == nil {
+		ec.addError(tag, "LigCaretList", "coverage table unreadable", SeverityMajor, tableOffset+uint32(offset)+uint32(covOffset))
+		return nil
+	}
+	gdef.LigCaretList = LigCaretList{
+		Coverage:        coverage.GlyphRange,
+		Count:           int(count),
+		ligGlyphOffsets: b[4 : 4+int(count)*2],
+		base:            b,
+	}
+	return nil
+}
+
+// parseCaretValue decodes a caret value table at offset off of b (which is a
+// LigGlyph table).
+func parseCaretValue(b binarySegm, off int) (CaretValue, bool) {
+	if off <= 0 || off+4 > len(b) {
+		return CaretValue{}, false
+	}
+	cv := CaretValue{Format: CaretValueFormat(b.U16(off))}
+	switch cv.Format {
+	case CaretValueFormat1:
+		cv.Coordinate = int16(b.U16(off + 2))
+	case CaretValueFormat2:
+		cv.PointIndex = b.U16(off + 2)
+	case CaretValueFormat3:
+		if off+6 > len(b) {
+			return CaretValue{}, false
+		}
+		cv.Coordinate = int16(b.U16(off + 2))
+		if devOffset := int(b.U16(off + 4)); devOffset != 0 {
+			cv.Device = parseDeviceTable(b[off:], devOffset)
+		}
+	default:
+		return CaretValue{}, false
+	}
+	return cv, true
+}
+
+// parseDeviceTable decodes a Device or VariationIndex table at offset off of b.
+// It returns nil if the table is damaged.
+func parseDeviceTable(b binarySegm, off int) *DeviceTable {
+	if off+6 > len(b) {
+		return nil
+	}
+	d := &DeviceTable{
+		StartSize:   b.U16(off),
+		EndSize:     b.U16(off + 2),
+		DeltaFormat: b.U16(off + 4),
+	}
+	if d.DeltaFormat == DeltaFormatVariationIndex {
+		return d
+	}
+	if d.DeltaFormat < DeltaFormatLocal2BitDeltas || d.DeltaFormat > DeltaFormatLocal8BitDeltas ||
+		d.EndSize < d.StartSize {
+		return nil
+	}
+	perWord := 8 >> (d.DeltaFormat - 1) // 8, 4 or 2 deltas per uint16
+	n := (int(d.EndSize-d.StartSize) + perWord) / perWord
+	if off+6+n*2 > len(b) {
+		return nil
+	}
+	d.deltas = b[off+6 : off+6+n*2]
+	return d
+}
+
 // A Mark Attachment Class Definition Table defines the class to which a mark glyph may
 // belong. This table uses the same format as the Class Definition table.
 func parseMarkAttachmentClassDef(gdef *GDefTable, b binarySegm, err error) error {
@@ -1437,6 +1726,7 @@ func parseLookupList(lytt *LayoutTable, b binarySegm, err error, isGPos bool, ta
 		return perr
 	}
 	lytt.lookupGraph = parseConcreteLookupListGraph(b, isGPos)
+	lytt.lookupGraph.issues = &lookupIssues{table: tableTag}
 
 	// Collect GDEF requirements from lookup flags during the first parse pass.
 	for i := 0; i < lookupOffsets.Len(); i++ {
@@ -1446,10 +1736,11 @@ func parseLookupList(lytt *LayoutTable, b binarySegm, err error, isGPos bool, ta
 		}
 		if off+4 > len(b) {
 			ec.addError(tableTag, "LookupList", fmt.Sprintf("lookup offset %d out of bounds (size %d)", off, len(b)), SeverityCritical, 0)
-			return errFontFormat("lookup offset out of bounds")
+			return &ErrBounds{Table: tableTag, Issue: fmt.Sprintf("lookup offset %d out of bounds (size %d)", off, len(b))}
 		}
 		flag := LayoutTableLookupFlag(b.U16(off + 2))
 		lytt.Requirements.AddFromLookupFlag(flag)
+		checkExtensionSubtables(b[off:], isGPos, tableTag, uint32(lloffset+off), i, ec)
 	}
 
 	return nil
@@ -1461,7 +1752,6 @@ func parseLookupList(lytt *LayoutTable, b binarySegm, err error, isGPos bool, ta
 // consecutive glyph indices to different classes, or one that puts groups of consecutive
 // glyph indices into the same class.
 func parseClassDefinitions(b binarySegm) (ClassDefinitions, error) {
-	tracer().Debugf("HELLO, parsing a ClassDef")
 	if len(b) < 4 {
 		return ClassDefinitions{}, errFontFormat("ClassDef table too small")
 	}
@@ -1475,7 +1765,9 @@ func parseClassDefinitions(b binarySegm) (ClassDefinitions, error) {
 	var n, g uint16
 	switch cdef.format {
 	case 1:
-		tracer().Debugf("parsing a ClassDef of format 1")
+		if traceDebug() {
+			tracer().Debugf("parsing a ClassDef of format 1")
+		}
 		if len(b) < 6 {
 			return cdef, errFontFormat("ClassDef format 1 header incomplete")
 		}
@@ -1490,7 +1782,9 @@ func parseClassDefinitions(b binarySegm) (ClassDefinitions, error) {
 				preludeLen+int(n)*entrySz, len(b))
 		}
 	case 2:
-		tracer().Debugf("parsing a ClassDef of format 2")
+		if traceDebug() {
+			tracer().Debugf("parsing a ClassDef of format 2")
+		}
 		const preludeLen = 4 // prelude length in ClassDef format 1
 		if len(b) < preludeLen {
 			return cdef, errFontFormat("ClassDef format 2 header incomplete")
@@ -1503,7 +1797,7 @@ func parseClassDefinitions(b binarySegm) (ClassDefinitions, error) {
 				preludeLen+int(n)*recordLen, len(b))
 		}
 	default:
-		return cdef, errFontFormat(fmt.Sprintf("unknown ClassDef format %d", cdef.format))
+		return cdef, errUnsupported(fmt.Sprintf("ClassDef format %d", cdef.format))
 	}
 	records := cdef.makeArray(b, int(n), cdef.format)
 	cdef.setRecords(records, GlyphIndex(g))
@@ -1516,11 +1810,12 @@ func parseClassDefinitions(b binarySegm) (ClassDefinitions, error) {
 // A Coverage table defines a unique index value, the Coverage Index, for each
 // covered glyph.
 func parseCoverage(b binarySegm) Coverage {
-	tracer().Debugf("parsing Coverage")
 	h := coverageHeader{}
 	h.CoverageFormat = b.U16(0)
 	h.Count = b.U16(2)
-	tracer().Debugf("coverage header format %d has count = %d ", h.CoverageFormat, h.Count)
+	if traceDebug() {
+		tracer().Debugf("coverage header format %d has count = %d ", h.CoverageFormat, h.Count)
+	}
 
 	// Validate based on format
 	switch h.CoverageFormat {
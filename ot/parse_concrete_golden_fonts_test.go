@@ -309,7 +309,13 @@ func TestConcreteGoldenFontsGSUBStructure(t *testing.T) {
 				if sub == nil {
 					t.Fatalf("%s: lookup[%d]/subtable[%d] is nil", fontName, i, j)
 				}
-				if sub.LookupType != lookup.Type {
+				if w := sub.Wrapper(); w != nil { // resolved extension
+					if w.LookupType != lookup.Type || sub.ExtensionDepth() != 1 {
+						t.Fatalf("%s: lookup[%d]/subtable[%d] extension mismatch lookup=%d wrapper=%d depth=%d",
+							fontName, i, j, lookup.Type, w.LookupType, sub.ExtensionDepth())
+					}
+					validateGoldenGSubNode(t, w)
+				} else if sub.LookupType != lookup.Type {
 					t.Fatalf("%s: lookup[%d]/subtable[%d] type mismatch lookup=%d sub=%d",
 						fontName, i, j, lookup.Type, sub.LookupType)
 				}
@@ -361,7 +367,13 @@ func TestConcreteGoldenFontsGPOSStructure(t *testing.T) {
 				if sub == nil {
 					t.Fatalf("%s: lookup[%d]/subtable[%d] is nil", fontName, i, j)
 				}
-				if sub.LookupType != lookup.Type {
+				if w := sub.Wrapper(); w != nil { // resolved extension
+					if w.LookupType != lookup.Type || sub.ExtensionDepth() != 1 {
+						t.Fatalf("%s: lookup[%d]/subtable[%d] extension mismatch lookup=%d wrapper=%d depth=%d",
+							fontName, i, j, lookup.Type, w.LookupType, sub.ExtensionDepth())
+					}
+					validateGoldenGPosNode(t, w)
+				} else if sub.LookupType != lookup.Type {
 					t.Fatalf("%s: lookup[%d]/subtable[%d] type mismatch lookup=%d sub=%d",
 						fontName, i, j, lookup.Type, sub.LookupType)
 				}
@@ -8,6 +8,7 @@ func parseGSub(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (
 	err = parseLookupList(&gsub.LayoutTable, b, err, false, tag, ec) // false = GSUB
 	err = parseFeatureList(&gsub.LayoutTable, b, err)
 	err = parseScriptList(&gsub.LayoutTable, b, err)
+	gsub.LayoutTable.completeGraphs(b, err)
 	if err != nil {
 		tracer().Errorf("error parsing GSUB table: %v", err)
 		return gsub, err
@@ -28,6 +29,7 @@ func parseGPos(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (
 	err = parseLookupList(&gpos.LayoutTable, b, err, true, tag, ec) // true = GPOS
 	err = parseFeatureList(&gpos.LayoutTable, b, err)
 	err = parseScriptList(&gpos.LayoutTable, b, err)
+	gpos.LayoutTable.completeGraphs(b, err)
 	if err != nil {
 		tracer().Errorf("error parsing GPOS table: %v", err)
 		return gpos, err
@@ -41,9 +43,10 @@ func parseGPos(tag Tag, b binarySegm, offset, size uint32, ec *errorCollector) (
 }
 
 // parseValueRecord reads a ValueRecord from binary data based on the ValueFormat bitmask.
-// Returns the parsed ValueRecord and the number of bytes consumed.
+// Offsets to device tables are relative to the start of b, the parent table of the
+// value record. Returns the parsed ValueRecord and the number of bytes consumed.
 // https://docs.microsoft.com/en-us/typography/opentype/spec/gpos#value-record
-func parseValueRecord(b binarySegm, offset int, format ValueFormat) (ValueRecord, int) {
+func parseValueRecord(b binarySegm, offset int, format ValueFormat, devices *deviceEnv) (ValueRecord, int) {
 	vr := ValueRecord{}
 	pos := offset
 
@@ -65,24 +68,41 @@ func parseValueRecord(b binarySegm, offset int, format ValueFormat) (ValueRecord
 	}
 	if format&ValueFormatXPlaDevice != 0 {
 		vr.XPlaDevice = b.U16(pos)
+		vr.XPlaDeviceTable = parseValueDevice(b, vr.XPlaDevice, devices)
 		pos += 2
 	}
 	if format&ValueFormatYPlaDevice != 0 {
 		vr.YPlaDevice = b.U16(pos)
+		vr.YPlaDeviceTable = parseValueDevice(b, vr.YPlaDevice, devices)
 		pos += 2
 	}
 	if format&ValueFormatXAdvDevice != 0 {
 		vr.XAdvDevice = b.U16(pos)
+		vr.XAdvDeviceTable = parseValueDevice(b, vr.XAdvDevice, devices)
 		pos += 2
 	}
 	if format&ValueFormatYAdvDevice != 0 {
 		vr.YAdvDevice = b.U16(pos)
+		vr.YAdvDeviceTable = parseValueDevice(b, vr.YAdvDevice, devices)
 		pos += 2
 	}
 
 	return vr, pos - offset
 }
 
+// parseValueDevice decodes the device table of a value record. It returns nil
+// for NULL offsets and damaged device tables.
+func parseValueDevice(b binarySegm, off uint16, devices *deviceEnv) *DeviceTable {
+	if off == 0 {
+		return nil
+	}
+	d := parseDeviceTable(b, int(off))
+	if d != nil {
+		d.devices = devices
+	}
+	return d
+}
+
 // valueRecordSize returns the size in bytes of a ValueRecord based on its format.
 func valueRecordSize(format ValueFormat) int {
 	size := 0
@@ -8,9 +8,10 @@ import (
 func parseConcreteLookupListGraph(lookupList binarySegm, isGPos bool) *LookupListGraph {
 	lookupArray, err := parseArray16(lookupList, 0, "LookupList", "Lookup")
 	lg := &LookupListGraph{
-		isGPos: isGPos,
-		raw:    lookupList,
-		err:    err,
+		isGPos:  isGPos,
+		devices: &deviceEnv{},
+		raw:     lookupList,
+		err:     err,
 	}
 	if err != nil {
 		return lg
@@ -34,6 +35,90 @@ func parseConcreteLookupListGraph(lookupList binarySegm, isGPos bool) *LookupLis
 	return lg
 }
 
+// checkExtensionSubtables reports extension subtables of lookup table b which
+// cannot be resolved, either because they are damaged or because they are
+// nested (which the OpenType specification forbids) or exceed MaxExtensionDepth.
+// offset is the offset of b within the layout table.
+func checkExtensionSubtables(b binarySegm, isGPos bool, tableTag Tag, offset uint32, lookupIndex int, ec *errorCollector) {
+	extType := GSubLookupTypeExtensionSubs
+	if isGPos {
+		extType = GPosLookupTypeExtensionPos // as stored in the lookup table, unmasked
+	}
+	if len(b) < 6 || LayoutTableLookupType(b.U16(0)) != extType {
+		return
+	}
+	subtables, err := parseArray16(b, 4, "Lookup", "Lookup-Subtables")
+	if err != nil {
+		return // reported by the lookup graph
+	}
+	for j := 0; j < subtables.Len(); j++ {
+		off := int(subtables.Get(j).U16(0))
+		if off == 0 || off >= len(b) {
+			continue // reported by the lookup graph
+		}
+		depth, err := extensionChainDepth(b[off:], extType)
+		section := fmt.Sprintf("Lookup[%d].Subtable[%d]", lookupIndex, j)
+		if err != nil {
+			ec.addError(tableTag, section, err.Error(), SeverityMajor, offset+uint32(off))
+		} else if depth > 1 {
+			ec.addError(tableTag, section, fmt.Sprintf("nested extension subtables (depth %d) are not allowed", depth),
+				SeverityMajor, offset+uint32(off))
+		}
+	}
+}
+
+// lookupIssues reports lookup subtables flagged with an error to the error
+// list of a font. Subtables are parsed lazily, so their errors surface on first
+// access, after parsing of the font has completed.
+type lookupIssues struct {
+	table  Tag             // GSUB or GPOS
+	addErr func(FontError) // nil until the lookup list is attached to a font
+}
+
+// report records the error of subtable node, if any, as subtable j of lookup i.
+// Unresolved extension subtables have already been reported during parsing, see
+// checkExtensionSubtables.
+func (li *lookupIssues) report(i, j int, node *LookupNode) {
+	if li == nil || li.addErr == nil || node == nil || node.err == nil {
+		return
+	}
+	switch node.LookupType {
+	case GSubLookupTypeExtensionSubs, MaskGPosLookupType(GPosLookupTypeExtensionPos):
+		return
+	}
+	li.addErr(FontError{
+		Table:    li.table,
+		Section:  fmt.Sprintf("Lookup[%d].Subtable[%d]", i, j),
+		Issue:    node.err.Error(),
+		Severity: SeverityMajor,
+	})
+}
+
+// extensionChainDepth follows a chain of extension subtables of type extType,
+// starting at b, and returns the number of extension subtables in the chain.
+func extensionChainDepth(b binarySegm, extType LayoutTableLookupType) (int, error) {
+	depth := 0
+	for {
+		if len(b) < 8 {
+			return depth, errBufferBounds
+		}
+		if format := b.U16(0); format != 1 {
+			return depth, fmt.Errorf("extension subtable has unsupported format %d", format)
+		}
+		if depth++; depth > MaxExtensionDepth {
+			return depth, fmt.Errorf("extension subtables exceed maximum nesting depth %d", MaxExtensionDepth)
+		}
+		link, err := parseLink32(b, 4, b, "Extension")
+		if err != nil {
+			return depth, err
+		}
+		if LayoutTableLookupType(b.U16(2)) != extType {
+			return depth, nil
+		}
+		b = link.jump().Bytes()
+	}
+}
+
 func validateConcreteLookupTable(b binarySegm) error {
 	if len(b) < 6 {
 		return errBufferBounds
@@ -73,19 +158,24 @@ func parseConcreteLookupTable(b binarySegm, isGPos bool) *LookupTable {
 			}
 		}
 	}
-	if len(b) >= 4+subtables.Size()+2 {
-		lt.markFilteringSet = b.U16(4 + subtables.Size())
+	// the mark filtering set follows the subtable offsets, which start at byte 6
+	if lt.Flag&LOOKUP_FLAG_USE_MARK_FILTERING_SET != 0 && len(b) >= 6+subtables.Size()+2 {
+		lt.markFilteringSet = b.U16(6 + subtables.Size())
+		lt.hasFilteringSet = true
 	}
 	return lt
 }
 
 func parseConcreteLookupNode(b binarySegm, lookupType LayoutTableLookupType) *LookupNode {
-	return parseConcreteLookupNodeWithDepth(b, lookupType, 0)
+	return parseConcreteLookupNodeWithDepth(b, lookupType, 0, nil)
 }
 
-func parseConcreteLookupNodeWithDepth(b binarySegm, lookupType LayoutTableLookupType, depth int) *LookupNode {
+func parseConcreteLookupNodeWithDepth(b binarySegm, lookupType LayoutTableLookupType, depth int,
+	devices *deviceEnv) *LookupNode {
 	node := &LookupNode{
 		LookupType: lookupType,
+		extDepth:   depth,
+		devices:    devices,
 		raw:        b,
 	}
 	if len(b) < 4 {
@@ -96,9 +186,17 @@ func parseConcreteLookupNodeWithDepth(b binarySegm, lookupType LayoutTableLookup
 	if IsGPosLookupType(lookupType) {
 		gposType := GPosLookupType(lookupType)
 		node.GPos = parseConcreteGPosPayloadScaffold(gposType, node.Format)
+		if *node.GPos == (GPosLookupPayload{}) {
+			node.err = errUnsupported(fmt.Sprintf("GPOS lookup type %d format %d", gposType, node.Format))
+			return node
+		}
 		parseConcreteGPosPayload(node, depth)
 	} else {
 		node.GSub = parseConcreteGSubPayloadScaffold(lookupType, node.Format)
+		if *node.GSub == (GSubLookupPayload{}) {
+			node.err = errUnsupported(fmt.Sprintf("GSUB lookup type %d format %d", lookupType, node.Format))
+			return node
+		}
 		parseConcreteGSubPayload(node, depth)
 	}
 	return node
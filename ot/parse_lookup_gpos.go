@@ -1,6 +1,9 @@
 package ot
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+)
 
 func parseConcreteGPosPayload(node *LookupNode, depth int) {
 	if node == nil || node.GPos == nil || len(node.raw) < 4 {
@@ -63,7 +66,7 @@ func parseConcreteGPosType1(node *LookupNode) {
 			setLookupNodeError(node, errBufferBounds)
 			return
 		}
-		vr, _ := parseValueRecord(node.raw, 6, valueFormat)
+		vr, _ := parseValueRecord(node.raw, 6, valueFormat, node.devices)
 		node.GPos.SingleFmt1.ValueFormat = valueFormat
 		node.GPos.SingleFmt1.Value = vr
 	case 2:
@@ -84,7 +87,7 @@ func parseConcreteGPosType1(node *LookupNode) {
 		values := make([]ValueRecord, valueCount)
 		offset := 8
 		for i := range valueCount {
-			vr, n := parseValueRecord(node.raw, offset, valueFormat)
+			vr, n := parseValueRecord(node.raw, offset, valueFormat, node.devices)
 			values[i] = vr
 			offset += n
 		}
@@ -117,11 +120,16 @@ func parseConcreteGPosType2(node *LookupNode) {
 				setLookupNodeError(node, fmt.Errorf("GPOS2/1 pair-set offset out of bounds: %d (size %d)", off, len(node.raw)))
 				continue
 			}
-			records, err := parseGPosPairSet(node.raw[off:], valueFormat1, valueFormat2)
+			records, err := parseGPosPairSet(node.raw[off:], valueFormat1, valueFormat2, node.devices)
 			if err != nil {
 				setLookupNodeError(node, err)
 				continue
 			}
+			// malformed fonts may have unsorted pair sets; the first record of
+			// a second glyph takes precedence, as for a linear search
+			slices.SortStableFunc(records, func(a, b PairValueRecord) int {
+				return int(a.SecondGlyph) - int(b.SecondGlyph)
+			})
 			pairSets[i] = records
 		}
 		node.GPos.PairFmt1.ValueFormat1 = valueFormat1
@@ -161,9 +169,9 @@ func parseConcreteGPosType2(node *LookupNode) {
 		for i := range class1Count {
 			row := make([]GPosClass2ValueRecord, class2Count)
 			for j := range class2Count {
-				v1, n1 := parseValueRecord(node.raw, offset, valueFormat1)
+				v1, n1 := parseValueRecord(node.raw, offset, valueFormat1, node.devices)
 				offset += n1
-				v2, n2 := parseValueRecord(node.raw, offset, valueFormat2)
+				v2, n2 := parseValueRecord(node.raw, offset, valueFormat2, node.devices)
 				offset += n2
 				row[j] = GPosClass2ValueRecord{
 					Value1: v1,
@@ -587,10 +595,12 @@ func parseConcreteGPosType9(node *LookupNode, depth int) {
 		return
 	}
 	resolvedType := MaskGPosLookupType(actualType)
-	resolved := parseConcreteLookupNodeWithDepth(link.jump().Bytes(), resolvedType, depth+1)
+	resolved := parseConcreteLookupNodeWithDepth(link.jump().Bytes(), resolvedType, depth+1, node.devices)
 	node.GPos.ExtensionFmt1.ResolvedType = resolvedType
 	node.GPos.ExtensionFmt1.Resolved = resolved
+	node.GPos.ExtensionFmt1.Offset, _ = node.raw.u32(4)
 	if resolved != nil {
+		resolved.wrapper = node
 		node.Coverage = resolved.Coverage
 		if resolved.err != nil {
 			setLookupNodeError(node, resolved.err)
@@ -598,7 +608,7 @@ func parseConcreteGPosType9(node *LookupNode, depth int) {
 	}
 }
 
-func parseGPosPairSet(b binarySegm, format1, format2 ValueFormat) ([]PairValueRecord, error) {
+func parseGPosPairSet(b binarySegm, format1, format2 ValueFormat, devices *deviceEnv) ([]PairValueRecord, error) {
 	if len(b) < 2 {
 		return nil, errBufferBounds
 	}
@@ -612,9 +622,9 @@ func parseGPosPairSet(b binarySegm, format1, format2 ValueFormat) ([]PairValueRe
 	for i := range pairValueCount {
 		second := b.U16(offset)
 		offset += 2
-		v1, n1 := parseValueRecord(b, offset, format1)
+		v1, n1 := parseValueRecord(b, offset, format1, devices)
 		offset += n1
-		v2, n2 := parseValueRecord(b, offset, format2)
+		v2, n2 := parseValueRecord(b, offset, format2, devices)
 		offset += n2
 		records[i] = PairValueRecord{
 			SecondGlyph: second,
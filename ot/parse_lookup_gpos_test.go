@@ -225,3 +225,63 @@ func TestParseConcreteGPosType9ExtensionFormat1(t *testing.T) {
 		t.Fatalf("expected extension node coverage forwarded from resolved payload")
 	}
 }
+
+func TestValueRecordResolve(t *testing.T) {
+	// GPOS1/1 with XPlacement=5 adjusted by a Device table (ppem 10…12: +1, -2, +3)
+	// and XAdvance=7 adjusted by a VariationIndex table (delta set 0/0)
+	b := make([]byte, 34)
+	putU16(b, 0, 1)
+	putU16(b, 2, 14)
+	putU16(b, 4, uint16(ValueFormatXPlacement|ValueFormatXAdvance|ValueFormatXPlaDevice|ValueFormatXAdvDevice))
+	putU16(b, 6, 5)
+	putU16(b, 8, 7)
+	putU16(b, 10, 20)
+	putU16(b, 12, 28)
+	copy(b[14:], coverageFmt1(10))
+	putU16(b, 20, 10)
+	putU16(b, 22, 12)
+	putU16(b, 24, DeltaFormatLocal4BitDeltas)
+	putU16(b, 26, 0x1e30)
+	putU16(b, 32, DeltaFormatVariationIndex)
+	// item variation store with a single region (wght 0…1) and a delta of 40
+	s := make([]byte, 31)
+	putU16(s, 0, 1)
+	putU16(s, 4, 12) // region list offset
+	putU16(s, 6, 1)
+	putU16(s, 10, 22) // item variation data offset
+	putU16(s, 12, 1)
+	putU16(s, 14, 1)
+	putU16(s, 18, 0x4000)
+	putU16(s, 20, 0x4000)
+	putU16(s, 22, 1)
+	putU16(s, 26, 1)
+	s[30] = 40
+	store, err := ParseItemVariationStore(s)
+	if err != nil {
+		t.Fatalf("cannot parse item variation store: %v", err)
+	}
+	devices := &deviceEnv{unitsPerEm: 1000, store: store}
+	node := parseConcreteLookupNodeWithDepth(b, MaskGPosLookupType(GPosLookupTypeSingle), 0, devices)
+	if node.Error() != nil {
+		t.Fatalf("unexpected error: %v", node.Error())
+	}
+	vr := node.GPosPayload().SingleFmt1.Value
+	if vr.XPlaDeviceTable == nil || vr.XAdvDeviceTable == nil || vr.YPlaDeviceTable != nil {
+		t.Fatalf("expected device tables for XPlacement and XAdvance, have %+v", vr)
+	}
+	for _, c := range []struct {
+		ppem       uint16
+		coords     []float64
+		xpla, xadv int16
+	}{
+		{0, nil, 5, 7},
+		{11, []float64{0.5}, -177, 27}, // 5 - 2*1000/11, 7 + 40*0.5
+		{20, []float64{1}, 5, 47},
+	} {
+		r := vr.Resolve(c.ppem, c.coords)
+		if r.XPlacement != c.xpla || r.XAdvance != c.xadv || r.XAdvDeviceTable != nil {
+			t.Errorf("resolve at %d ppem, %v: expected (%d, %d), have %+v", c.ppem, c.coords,
+				c.xpla, c.xadv, r)
+		}
+	}
+}
@@ -398,10 +398,12 @@ func parseConcreteGSubType7(node *LookupNode, depth int) {
 		setLookupNodeError(node, err)
 		return
 	}
-	resolved := parseConcreteLookupNodeWithDepth(link.jump().Bytes(), actualType, depth+1)
+	resolved := parseConcreteLookupNodeWithDepth(link.jump().Bytes(), actualType, depth+1, node.devices)
 	node.GSub.ExtensionFmt1.ResolvedType = actualType
 	node.GSub.ExtensionFmt1.Resolved = resolved
+	node.GSub.ExtensionFmt1.Offset, _ = node.raw.u32(4)
 	if resolved != nil {
+		resolved.wrapper = node
 		node.Coverage = resolved.Coverage
 		if resolved.err != nil {
 			setLookupNodeError(node, resolved.err)
@@ -806,6 +808,53 @@ func parseInputClassList(b binarySegm, at int) ([]uint16, int, error) {
 	return out, off + inputCount*2, nil
 }
 
+// checkGSubGlyphs flags GSUB subtable node with an error if one of its
+// substitute glyph IDs is not a valid glyph ID of a font with numGlyphs glyphs.
+// Single substitutions of format 1 can only be checked during application. If
+// numGlyphs is 0, no check is performed.
+func checkGSubGlyphs(node *LookupNode, numGlyphs int) {
+	if node == nil || node.GSub == nil || numGlyphs <= 0 {
+		return
+	}
+	check := func(glyphs ...GlyphIndex) bool {
+		for _, g := range glyphs {
+			if int(g) >= numGlyphs {
+				setLookupNodeError(node, fmt.Errorf("GSUB%d substitute glyph %d exceeds maxp.NumGlyphs %d",
+					node.LookupType, g, numGlyphs))
+				return false
+			}
+		}
+		return true
+	}
+	p := node.GSub
+	switch {
+	case p.SingleFmt2 != nil:
+		check(p.SingleFmt2.SubstituteGlyphIDs...)
+	case p.MultipleFmt1 != nil:
+		for _, seq := range p.MultipleFmt1.Sequences {
+			if !check(seq...) {
+				return
+			}
+		}
+	case p.AlternateFmt1 != nil:
+		for _, alts := range p.AlternateFmt1.Alternates {
+			if !check(alts...) {
+				return
+			}
+		}
+	case p.LigatureFmt1 != nil:
+		for _, set := range p.LigatureFmt1.LigatureSets {
+			for _, rule := range set {
+				if !check(rule.Ligature) {
+					return
+				}
+			}
+		}
+	case p.ReverseChainingFmt1 != nil:
+		check(p.ReverseChainingFmt1.SubstituteGlyphIDs...)
+	}
+}
+
 func setLookupNodeError(node *LookupNode, err error) {
 	if node != nil && err != nil && node.err == nil {
 		node.err = err
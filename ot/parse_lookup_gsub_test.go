@@ -461,6 +461,82 @@ func TestParseConcreteGSubType7ExtensionFormat1(t *testing.T) {
 	}
 }
 
+func TestGSubExtensionUnwrapAndDiagnostics(t *testing.T) {
+	b := make([]byte, 84)
+	putU16(b, 0, 1)  // major version
+	putU16(b, 4, 10) // ScriptList (empty)
+	putU16(b, 6, 12) // FeatureList (empty)
+	putU16(b, 8, 14) // LookupList
+	putU16(b, 14, 2) // lookup count
+	putU16(b, 16, 6)
+	putU16(b, 18, 34)
+	// lookup 0 at 20: extension → single
+	putU16(b, 20, uint16(GSubLookupTypeExtensionSubs))
+	putU16(b, 24, 1)
+	putU16(b, 26, 8)
+	putU16(b, 28, 1) // extension format
+	putU16(b, 30, uint16(GSubLookupTypeSingle))
+	putU32(b, 32, 8)
+	putU16(b, 36, 1) // single format 1
+	putU16(b, 38, 6)
+	putU16(b, 40, 1)
+	copy(b[42:], coverageFmt1(5))
+	// lookup 1 at 48: extension → extension → single
+	putU16(b, 48, uint16(GSubLookupTypeExtensionSubs))
+	putU16(b, 52, 1)
+	putU16(b, 54, 8)
+	putU16(b, 56, 1)
+	putU16(b, 58, uint16(GSubLookupTypeExtensionSubs))
+	putU32(b, 60, 8)
+	putU16(b, 64, 1)
+	putU16(b, 66, uint16(GSubLookupTypeSingle))
+	putU32(b, 68, 8)
+	putU16(b, 72, 1)
+	putU16(b, 74, 6)
+	putU16(b, 76, 1)
+	copy(b[78:], coverageFmt1(5))
+	//
+	ec := &errorCollector{}
+	table, err := parseGSub(T("GSUB"), b, 0, uint32(len(b)), ec)
+	if err != nil {
+		t.Fatalf("cannot parse GSUB: %v", err)
+	}
+	if len(ec.errors) != 1 || ec.errors[0].Section != "Lookup[1].Subtable[0]" {
+		t.Fatalf("expected one error for nested extension of lookup 1, have %v", ec.errors)
+	}
+	graph := table.Self().AsGSub().LookupGraph()
+	sub := graph.Lookup(0).Subtable(0)
+	if sub.LookupType != GSubLookupTypeSingle || sub.ExtensionDepth() != 1 {
+		t.Fatalf("expected resolved single substitution at depth 1, have type %d at depth %d",
+			sub.LookupType, sub.ExtensionDepth())
+	}
+	if w := sub.Wrapper(); w == nil || w.LookupType != GSubLookupTypeExtensionSubs {
+		t.Fatalf("expected extension wrapper for resolved subtable")
+	}
+	nested := graph.Lookup(1).Subtable(0)
+	if nested.LookupType != GSubLookupTypeExtensionSubs || nested.Error() == nil {
+		t.Fatalf("expected unresolved nested extension to be flagged with an error")
+	}
+}
+
+func TestExtensionChainDepth(t *testing.T) {
+	levels := MaxExtensionDepth + 2
+	b := make([]byte, levels*8)
+	for i := range levels {
+		putU16(b, i*8, 1)
+		putU16(b, i*8+2, uint16(GSubLookupTypeExtensionSubs))
+		putU32(b, i*8+4, 8)
+	}
+	putU16(b, 2, uint16(GSubLookupTypeSingle)) // first level only
+	if depth, err := extensionChainDepth(b, GSubLookupTypeExtensionSubs); err != nil || depth != 1 {
+		t.Errorf("expected depth 1, have %d, %v", depth, err)
+	}
+	putU16(b, 2, uint16(GSubLookupTypeExtensionSubs))
+	if depth, err := extensionChainDepth(b, GSubLookupTypeExtensionSubs); err == nil || depth != MaxExtensionDepth+1 {
+		t.Errorf("expected error for chain exceeding MaxExtensionDepth, have %d, %v", depth, err)
+	}
+}
+
 func TestParseGSubType8(t *testing.T) {
 	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
 	defer teardown()
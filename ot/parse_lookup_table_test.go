@@ -0,0 +1,46 @@
+package ot
+
+import "testing"
+
+// lookupTableBytes builds a lookup table header with a single subtable offset,
+// an optional mark filtering set field and a single-substitution subtable.
+func lookupTableBytes(flag LayoutTableLookupFlag, markSet uint16, withMarkSet bool) []byte {
+	headerSize := 8
+	if withMarkSet {
+		headerSize += 2
+	}
+	subtable := make([]byte, 6)
+	putU16(subtable, 0, 1) // format 1
+	putU16(subtable, 2, 6) // coverage offset
+	subtable = append(subtable, coverageFmt1(5)...)
+	b := make([]byte, headerSize, headerSize+len(subtable))
+	putU16(b, 0, uint16(GSubLookupTypeSingle))
+	putU16(b, 2, uint16(flag))
+	putU16(b, 4, 1) // subtable count
+	putU16(b, 6, uint16(headerSize))
+	if withMarkSet {
+		putU16(b, 8, markSet)
+	}
+	return append(b, subtable...)
+}
+
+func TestLookupTableMarkFilteringSet(t *testing.T) {
+	lt := parseConcreteLookupTable(lookupTableBytes(LOOKUP_FLAG_USE_MARK_FILTERING_SET, 7, true), false)
+	if lt.Error() != nil {
+		t.Fatalf("unexpected error: %v", lt.Error())
+	}
+	if set := lt.MarkFilteringSet(); set != 7 {
+		t.Errorf("expected mark filtering set 7, have %d", set)
+	}
+	// without the flag, the field is absent and the subtable follows
+	lt = parseConcreteLookupTable(lookupTableBytes(0, 0, false), false)
+	if lt.Error() != nil {
+		t.Fatalf("unexpected error: %v", lt.Error())
+	}
+	if set := lt.MarkFilteringSet(); set != 0 {
+		t.Errorf("expected no mark filtering set without lookup flag, have %d", set)
+	}
+	if node := lt.Subtable(0); node == nil || !node.Coverage.Contains(5) {
+		t.Errorf("expected subtable covering glyph 5")
+	}
+}
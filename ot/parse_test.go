@@ -2,8 +2,10 @@ package ot
 
 import (
 	"os"
+	"slices"
 	"sync"
 	"testing"
+	"unicode"
 
 	"github.com/npillmayer/schuko/tracing/gotestingadapter"
 )
@@ -351,6 +353,69 @@ func TestCMapTableGlyphIndex(t *testing.T) {
 	}
 }
 
+func TestCMapTableBulkAndReverse(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	cmap := otf.CMap
+	runes := []rune("Ab\u00e9 \U0010FFFF")
+	out := make([]GlyphIndex, len(runes))
+	cmap.GlyphIndexes(runes, out)
+	for i, r := range runes {
+		if g := cmap.GlyphIndexMap.Lookup(r); out[i] != g {
+			t.Errorf("bulk lookup of %q: expected glyph %d, got %d", r, g, out[i])
+		}
+	}
+	if out[0] != 4 {
+		t.Errorf("expected glyph for 'A' to be 4, got %d", out[0])
+	}
+	for _, r := range runes[:4] {
+		g := cmap.GlyphIndexMap.Lookup(r)
+		rr, ok := cmap.RuneFor(g)
+		if !ok || cmap.GlyphIndexMap.Lookup(rr) != g {
+			t.Errorf("reverse lookup of glyph %d (from %q): got %q, %v", g, r, rr, ok)
+		}
+	}
+	if _, ok := cmap.RuneFor(0); ok {
+		t.Errorf("did not expect a code-point for glyph 0")
+	}
+}
+
+func TestCMapTableCoverage(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	cmap := otf.CMap
+	rt := cmap.CoverageBitmap()
+	n, prev := 0, rune(-1)
+	for r := range cmap.Codepoints() {
+		if r <= prev {
+			t.Fatalf("expected code-points in ascending order, have %#x after %#x", r, prev)
+		}
+		if !unicode.Is(rt, r) {
+			t.Errorf("expected coverage to contain %#x", r)
+		}
+		prev = r
+		n++
+	}
+	if n == 0 {
+		t.Fatalf("expected Calibri to map code-points")
+	}
+	for _, r := range "Hello World" {
+		if !unicode.Is(rt, r) {
+			t.Errorf("expected coverage to contain %q", r)
+		}
+	}
+	if unicode.Is(rt, 0x10ffff) {
+		t.Errorf("did not expect coverage to contain U+10FFFF")
+	}
+	if cmap.CoverageBitmap() != rt {
+		t.Errorf("expected coverage table to be cached")
+	}
+}
+
 func TestParseGPos(t *testing.T) {
 	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
 	defer teardown()
@@ -444,6 +509,85 @@ func TestParseGSub(t *testing.T) {
 	// }
 }
 
+func TestLayoutTableEnumeration(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	gsub := otf.Layout.GSub
+	scripts := gsub.Scripts()
+	if !slices.Contains(scripts, T("latn")) {
+		t.Fatalf("expected Calibri GSUB to support script latn, have %v", scripts)
+	}
+	langs := gsub.Languages(T("latn"))
+	if !slices.Contains(langs, T("TRK")) {
+		t.Errorf("expected Calibri GSUB to support language TRK for latn, have %v", langs)
+	}
+	if gsub.Languages(T("xxxx")) != nil {
+		t.Errorf("did not expect languages for unknown script")
+	}
+	feats := gsub.Features(T("latn"), DFLT)
+	if !slices.Contains(feats, T("liga")) {
+		t.Errorf("expected default language system of latn to include liga, have %v", feats)
+	}
+	for i, f := range feats {
+		if slices.Index(feats, f) != i {
+			t.Errorf("expected features to be reported once, have %v", feats)
+		}
+	}
+	if len(gsub.Features(T("latn"), T("TRK"))) == 0 {
+		t.Errorf("expected features for latn/TRK")
+	}
+	if gsub.Features(T("latn"), T("XXX")) != nil {
+		t.Errorf("did not expect features for unknown language")
+	}
+}
+
+func TestLangSysRange(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	gsub := otf.Layout.GSub
+	n := 0
+	for stag, script := range gsub.ScriptGraph().Range() {
+		for ltag, lsys := range script.Range() {
+			features := lsys.Features()
+			i := 0
+			for inx, f := range lsys.Range() {
+				if f != features[i] {
+					t.Errorf("%s/%s: feature #%d differs from Features()", stag, ltag, i)
+				}
+				if _, ok := gsub.FeatureGraph().TagAt(inx); !ok {
+					t.Errorf("%s/%s: feature index %d has no tag", stag, ltag, inx)
+				}
+				i++
+				n++
+			}
+			if i != len(features) {
+				t.Errorf("%s/%s: expected %d features, iterated %d", stag, ltag, len(features), i)
+			}
+		}
+	}
+	if n == 0 {
+		t.Errorf("expected to iterate feature links of Calibri GSUB")
+	}
+}
+
+func TestLayoutGraphsCompleted(t *testing.T) {
+	lytt := &LayoutTable{}
+	lytt.completeGraphs(nil, nil)
+	if lytt.ScriptGraph() == nil || lytt.FeatureGraph() == nil || lytt.LookupGraph() == nil {
+		t.Fatalf("expected all layout graphs to be present")
+	}
+	if lytt.ScriptGraph().Error() == nil || lytt.LookupGraph().Error() == nil {
+		t.Errorf("expected substituted graphs to report an error")
+	}
+	if lytt.ScriptGraph().Len() != 0 || lytt.LookupGraph().Lookup(0) != nil || lytt.Scripts() != nil {
+		t.Errorf("expected substituted graphs to be empty")
+	}
+}
+
 func TestFeatureGraphLazyConcurrent(t *testing.T) {
 	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
 	defer teardown()
@@ -687,6 +831,19 @@ func TestParseOtherTables(t *testing.T) {
 	if loca == nil {
 		t.Fatalf("cannot find a maxp table")
 	}
+	head := otf.FontHead()
+	if head == nil {
+		t.Fatalf("expected typed font accessor for head")
+	}
+	if xmin, ymin, xmax, ymax := head.BoundingBox(); xmin >= xmax || ymin >= ymax {
+		t.Errorf("expected a non-empty font bounding box, have (%d,%d,%d,%d)", xmin, ymin, xmax, ymax)
+	}
+	if head.Created.Year() < 1990 || head.Modified.Before(head.Created) {
+		t.Errorf("implausible font timestamps: created %v, modified %v", head.Created, head.Modified)
+	}
+	if head.LowestRecPPEM == 0 || head.IsItalic() {
+		t.Errorf("unexpected head values: lowestRecPPEM=%d, macStyle=%#x", head.LowestRecPPEM, head.MacStyle)
+	}
 	hhea := otf.tables[T("hhea")].Self().AsHHea()
 	if hhea == nil {
 		t.Fatalf("cannot find a hhea table")
@@ -736,6 +893,34 @@ func TestParseOtherTables(t *testing.T) {
 	}
 }
 
+func TestHMtxAdvanceAndLSB(t *testing.T) {
+	b := make([]byte, 12)
+	putU16(b, 0, 500)
+	putU16(b, 2, 10)
+	putU16(b, 4, 600)
+	putU16(b, 6, uint16(0xfffb)) // -5
+	putU16(b, 8, 7)
+	putU16(b, 10, uint16(0xfffd)) // -3
+	hmtx := newHMtxTable(T("hmtx"), b, 0, uint32(len(b)))
+	if err := hmtx.parseAll(4, 2); err != nil {
+		t.Fatalf("cannot parse hmtx: %v", err)
+	}
+	for _, c := range []struct {
+		g   GlyphIndex
+		adv uint16
+		lsb int16
+	}{
+		{0, 500, 10}, {1, 600, -5}, {2, 600, 7}, {3, 600, -3}, {4, 0, 0},
+	} {
+		if adv := hmtx.Advance(c.g); adv != c.adv {
+			t.Errorf("glyph %d: expected advance %d, have %d", c.g, c.adv, adv)
+		}
+		if lsb := hmtx.LeftSideBearing(c.g); lsb != c.lsb {
+			t.Errorf("glyph %d: expected lsb %d, have %d", c.g, c.lsb, lsb)
+		}
+	}
+}
+
 func TestParseMaxPVersion05Size6(t *testing.T) {
 	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
 	defer teardown()
@@ -829,22 +1014,28 @@ func TestParseGSubLookups(t *testing.T) {
 	if csub == nil {
 		t.Fatalf("expected concrete subtable[0]")
 	}
-	// Concrete lookup graph keeps extension as explicit type-7 node and resolves type via payload.
-	if csub.GSubPayload() == nil || csub.GSubPayload().ExtensionFmt1 == nil {
-		t.Fatalf("expected concrete GSUB extension payload for lookup[0]/subtable[0]")
+	// Concrete lookup graph resolves extensions and keeps the type-7 node as wrapper.
+	if csub.LookupType != GSubLookupTypeSingle {
+		t.Fatalf("expected resolved subtable type 1, got %d", csub.LookupType)
+	}
+	if csub.ExtensionDepth() != 1 {
+		t.Fatalf("expected extension depth 1, got %d", csub.ExtensionDepth())
+	}
+	wrapper := csub.Wrapper()
+	if wrapper == nil || wrapper.GSubPayload() == nil || wrapper.GSubPayload().ExtensionFmt1 == nil {
+		t.Fatalf("expected concrete GSUB extension wrapper for lookup[0]/subtable[0]")
 	}
-	if csub.LookupType != GSubLookupTypeExtensionSubs {
-		t.Fatalf("expected concrete subtable type 7 (extension), got %d", csub.LookupType)
+	if wrapper.LookupType != GSubLookupTypeExtensionSubs {
+		t.Fatalf("expected wrapper subtable type 7 (extension), got %d", wrapper.LookupType)
 	}
-	if csub.GSubPayload().ExtensionFmt1.ResolvedType != GSubLookupTypeSingle {
-		t.Fatalf("extension resolved-type mismatch: want=%d concrete=%d", GSubLookupTypeSingle, csub.GSubPayload().ExtensionFmt1.ResolvedType)
+	if wrapper.GSubPayload().ExtensionFmt1.ResolvedType != GSubLookupTypeSingle {
+		t.Fatalf("extension resolved-type mismatch: want=%d concrete=%d", GSubLookupTypeSingle, wrapper.GSubPayload().ExtensionFmt1.ResolvedType)
 	}
-	if csub.GSubPayload().ExtensionFmt1.Resolved == nil {
-		t.Fatalf("expected concrete extension resolved node")
+	if wrapper.GSubPayload().ExtensionFmt1.Resolved != csub {
+		t.Fatalf("expected wrapper to resolve to subtable[0]")
 	}
-	if csub.GSubPayload().ExtensionFmt1.Resolved.LookupType != GSubLookupTypeSingle {
-		t.Fatalf("extension resolved node type mismatch: want=%d concrete=%d",
-			GSubLookupTypeSingle, csub.GSubPayload().ExtensionFmt1.Resolved.LookupType)
+	if wrapper.Unwrap() != csub {
+		t.Fatalf("expected Unwrap of wrapper to return subtable[0]")
 	}
 }
 
@@ -0,0 +1,132 @@
+package ot
+
+import (
+	"fmt"
+)
+
+// Reader reads big-endian values from the binary data of a table. It is intended
+// for clients handling tables which package ot does not interpret:
+//
+//	r := ot.NewReader(otf.Table(ot.T("LTSH")).Binary())
+//	version, numGlyphs := r.ReadU16(), r.ReadU16()
+//	yPels := r.ReadBytes(int(numGlyphs))
+//	if err := r.Err(); err != nil {
+//		…
+//	}
+//
+// Reads are bounds-checked. The first read (or skip) beyond the end of the data
+// sets an error, after which all reads return zero values. Clients therefore
+// check Err once after a sequence of reads, instead of after every read.
+type Reader struct {
+	b   []byte
+	pos int
+	err error
+}
+
+// NewReader creates a reader for b, positioned at the start of b. b is not
+// copied.
+func NewReader(b []byte) *Reader {
+	return &Reader{b: b}
+}
+
+// Err returns the error of the first read beyond the end of the data, or nil.
+func (r *Reader) Err() error {
+	return r.err
+}
+
+// Pos returns the current read position, as a byte offset from the start of the
+// data.
+func (r *Reader) Pos() int {
+	return r.pos
+}
+
+// Remaining returns the number of bytes after the current read position.
+func (r *Reader) Remaining() int {
+	if r.err != nil {
+		return 0
+	}
+	return len(r.b) - r.pos
+}
+
+// Seek sets the read position to byte offset pos, e.g. to follow an offset read
+// from the data. Positions outside of the data set the error state.
+func (r *Reader) Seek(pos int) {
+	if r.err != nil {
+		return
+	}
+	if pos < 0 || pos > len(r.b) {
+		r.err = errFontFormat(fmt.Sprintf("seek to offset %d outside of %d bytes of data", pos, len(r.b)))
+		return
+	}
+	r.pos = pos
+}
+
+// Skip advances the read position by n bytes.
+func (r *Reader) Skip(n int) {
+	r.take(n)
+}
+
+// take returns the next n bytes and advances the read position, or sets the
+// error state.
+func (r *Reader) take(n int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	if n < 0 || n > len(r.b)-r.pos {
+		r.err = errFontFormat(fmt.Sprintf("read of %d bytes at offset %d exceeds %d bytes of data",
+			n, r.pos, len(r.b)))
+		return nil
+	}
+	b := r.b[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+// ReadBytes returns the next n bytes, as a view into the data.
+func (r *Reader) ReadBytes(n int) []byte {
+	return r.take(n)
+}
+
+// ReadU8 reads an unsigned 8-bit integer.
+func (r *Reader) ReadU8() uint8 {
+	if b := r.take(1); b != nil {
+		return b[0]
+	}
+	return 0
+}
+
+// ReadU16 reads an unsigned 16-bit integer.
+func (r *Reader) ReadU16() uint16 {
+	if b := r.take(2); b != nil {
+		return u16(b)
+	}
+	return 0
+}
+
+// ReadI16 reads a signed 16-bit integer.
+func (r *Reader) ReadI16() int16 {
+	return int16(r.ReadU16())
+}
+
+// ReadU32 reads an unsigned 32-bit integer.
+func (r *Reader) ReadU32() uint32 {
+	if b := r.take(4); b != nil {
+		return u32(b)
+	}
+	return 0
+}
+
+// ReadI32 reads a signed 32-bit integer.
+func (r *Reader) ReadI32() int32 {
+	return int32(r.ReadU32())
+}
+
+// ReadTag reads a 4-byte tag.
+func (r *Reader) ReadTag() Tag {
+	return Tag(r.ReadU32())
+}
+
+// ReadGlyph reads a 16-bit glyph index.
+func (r *Reader) ReadGlyph() GlyphIndex {
+	return GlyphIndex(r.ReadU16())
+}
@@ -0,0 +1,38 @@
+package ot
+
+import (
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	b := []byte{0, 1, 0xff, 0xfe, 'G', 'S', 'U', 'B', 0, 0, 0, 8, 0, 42}
+	r := NewReader(b)
+	if v := r.ReadU16(); v != 1 {
+		t.Errorf("expected 1, have %d", v)
+	}
+	if v := r.ReadI16(); v != -2 {
+		t.Errorf("expected -2, have %d", v)
+	}
+	if tag := r.ReadTag(); tag != T("GSUB") {
+		t.Errorf("expected tag GSUB, have %s", tag)
+	}
+	off := r.ReadU32()
+	r.Seek(int(off) + 4)
+	if g := r.ReadGlyph(); g != 42 || r.Remaining() != 0 || r.Err() != nil {
+		t.Errorf("expected glyph 42 at end of data, have %d, error %v", g, r.Err())
+	}
+	r.Seek(2)
+	if r.Skip(10); r.Pos() != 12 || r.Err() != nil {
+		t.Errorf("expected position 12 after skip, have %d", r.Pos())
+	}
+	if v := r.ReadU32(); v != 0 || r.Err() == nil {
+		t.Errorf("expected error for read beyond end of data, have %d", v)
+	}
+	if v := r.ReadU8(); v != 0 || r.Pos() != 12 {
+		t.Errorf("expected reads to stop after error, have %d at position %d", v, r.Pos())
+	}
+	r = NewReader(b)
+	if r.Seek(len(b) + 1); r.Err() == nil {
+		t.Errorf("expected error for seek beyond end of data")
+	}
+}
@@ -0,0 +1,226 @@
+package ot
+
+import "testing"
+
+// rangeRecordsTable returns a coverage or class definition table of format 2
+// with records of start glyph, end glyph and coverage index or class.
+func rangeRecordsTable(records ...[3]uint16) []byte {
+	out := make([]byte, 4+6*len(records))
+	putU16(out, 0, 2)
+	putU16(out, 2, uint16(len(records)))
+	for i, rec := range records {
+		for j, v := range rec {
+			putU16(out, 4+6*i+2*j, v)
+		}
+	}
+	return out
+}
+
+// firstMatches returns the index yielded first for every glyph by all, as
+// found by a linear walk.
+func firstMatches(all func(yield func(GlyphIndex, int) bool)) map[GlyphIndex]int {
+	m := make(map[GlyphIndex]int)
+	all(func(g GlyphIndex, inx int) bool {
+		if _, ok := m[g]; !ok {
+			m[g] = inx
+		}
+		return true
+	})
+	return m
+}
+
+func TestCoverageSearch(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		sorted bool
+	}{
+		{"format 1", coverageFmt1(3, 5, 9, 200), true},
+		{"format 1, unsorted", coverageFmt1(9, 3, 200, 5, 3), false},
+		{"format 2", rangeRecordsTable([3]uint16{3, 5, 0}, [3]uint16{9, 9, 3}, [3]uint16{100, 120, 4}), true},
+		{"format 2, overlapping", rangeRecordsTable([3]uint16{3, 10, 0}, [3]uint16{9, 12, 8}), false},
+		{"format 2, unsorted", rangeRecordsTable([3]uint16{100, 120, 0}, [3]uint16{3, 5, 21}), false},
+	}
+	for _, tt := range tests {
+		cov := parseCoverage(tt.data)
+		r := cov.GlyphRange.(interface {
+			all(func(GlyphIndex, int) bool)
+		})
+		want := firstMatches(r.all)
+		for g := range GlyphIndex(300) {
+			inx, ok := cov.Match(g)
+			if w, found := want[g]; ok != found || ok && inx != w {
+				t.Errorf("%s: expected glyph %d to match %d/%v, have %d/%v", tt.name, g, w, found, inx, ok)
+			}
+		}
+		var sorted bool
+		switch r := cov.GlyphRange.(type) {
+		case *glyphRangeArray:
+			sorted = r.sorted
+		case *glyphRangeRecords:
+			sorted = r.sorted
+		}
+		if sorted != tt.sorted {
+			t.Errorf("%s: expected sorted = %v", tt.name, tt.sorted)
+		}
+	}
+}
+
+func TestClassDefSearch(t *testing.T) {
+	for _, data := range [][]byte{
+		rangeRecordsTable([3]uint16{3, 5, 1}, [3]uint16{9, 9, 2}, [3]uint16{100, 120, 3}),
+		rangeRecordsTable([3]uint16{3, 5, 1}, [3]uint16{4, 6, 2}, [3]uint16{100, 120, 3}), // overlapping
+	} {
+		cdef, err := parseClassDefinitions(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := firstMatches(cdef.records.all)
+		for g := range GlyphIndex(300) {
+			if clz := cdef.Lookup(g); clz != want[g] {
+				t.Errorf("expected class %d for glyph %d, have %d", want[g], g, clz)
+			}
+		}
+	}
+}
+
+// sortedRangeRecords derives sorted and disjoint range records from arbitrary
+// bytes, taking triples of gap to the previous range, length and index.
+func sortedRangeRecords(b []byte) [][3]uint16 {
+	var records [][3]uint16
+	next := 0
+	for ; len(b) >= 3; b = b[3:] {
+		from := next + int(b[0])
+		to := from + int(b[1]%16)
+		if to > 0xffff {
+			break
+		}
+		records = append(records, [3]uint16{uint16(from), uint16(to), uint16(b[2])})
+		next = to + 1
+	}
+	return records
+}
+
+// FuzzRangeRecordSearch checks that binary searches of coverage and class
+// definition tables of format 2 find the same entries as linear walks.
+func FuzzRangeRecordSearch(f *testing.F) {
+	f.Add([]byte{3, 2, 0, 4, 0, 3, 90, 15, 4}, false)
+	f.Add([]byte{0, 0, 1, 0, 0, 2, 255, 15, 3, 0, 1, 4}, false)
+	f.Add(rangeRecordsTable([3]uint16{100, 120, 0}, [3]uint16{3, 5, 21})[4:], true)
+	f.Fuzz(func(t *testing.T, b []byte, raw bool) {
+		b = b[:min(len(b), 600)] // glyphs are checked against all records
+		data := rangeRecordsTable(sortedRangeRecords(b)...)
+		if raw { // arbitrary records, which may or may not be sorted
+			data = append(data[:4:4], b[:len(b)/6*6]...)
+			putU16(data, 2, uint16(len(b)/6))
+		}
+		var glyphs []GlyphIndex
+		for i := 4; i+4 <= len(data); i += 6 {
+			for _, g := range []uint16{u16(data[i:]), u16(data[i+2:])} {
+				glyphs = append(glyphs, GlyphIndex(g-1), GlyphIndex(g), GlyphIndex(g+1))
+			}
+		}
+		glyphs = append(glyphs, 0, 0xffff)
+
+		cov := parseCoverage(data)
+		binary, ok := cov.GlyphRange.(*glyphRangeRecords)
+		if !ok {
+			t.Fatalf("expected range records, have %T", cov.GlyphRange)
+		}
+		if !raw && !binary.sorted {
+			t.Fatalf("expected range records %v to be sorted", data)
+		}
+		linear := *binary
+		linear.sorted = false
+		cdef, err := parseClassDefinitions(data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		binaryCdef := cdef.records.(*classDefinitionsFormat2)
+		linearCdef := *binaryCdef
+		linearCdef.sorted = false
+		for _, g := range glyphs {
+			inx, ok := binary.Match(g)
+			if want, found := linear.Match(g); ok != found || inx != want {
+				t.Errorf("coverage: expected glyph %d to match %d/%v, have %d/%v", g, want, found, inx, ok)
+			}
+			if !binary.sorted {
+				continue
+			}
+			if clz, want := binaryCdef.Lookup(g), linearCdef.Lookup(g); clz != want {
+				t.Errorf("class definitions: expected class %d for glyph %d, have %d", want, g, clz)
+			}
+		}
+	})
+}
+
+func TestPairSetSearch(t *testing.T) {
+	p := &GPosPairFmt1Payload{PairSets: [][]PairValueRecord{
+		{{SecondGlyph: 3}, {SecondGlyph: 7, Value1: ValueRecord{XAdvance: -10}}, {SecondGlyph: 7}, {SecondGlyph: 12}},
+	}}
+	if rec, ok := p.Pair(0, 7); !ok || rec.Value1.XAdvance != -10 {
+		t.Errorf("expected first record for glyph 7, have %+v/%v", rec, ok)
+	}
+	for _, g := range []GlyphIndex{0, 4, 13} {
+		if _, ok := p.Pair(0, g); ok {
+			t.Errorf("did not expect a record for glyph %d", g)
+		}
+	}
+	if _, ok := p.Pair(1, 3); ok {
+		t.Errorf("did not expect a record for pair set out of range")
+	}
+}
+
+// BenchmarkPairAdjustmentSearch compares binary searches to linear walks for
+// the coverage and class definition tables of the pair adjustment subtables of
+// Calibri, which has large kerning tables.
+func BenchmarkPairAdjustmentSearch(b *testing.B) {
+	otf := loadTestdataFont(b, "Calibri")
+	var covs []Coverage
+	var cdefs []ClassDefinitions
+	for _, lt := range otf.Layout.GPos.LookupGraph().Range() {
+		for _, node := range lt.Range() {
+			if ext := node.GPosPayload().ExtensionFmt1; ext != nil && ext.Resolved != nil {
+				node = ext.Resolved
+			}
+			if node.LookupType != MaskGPosLookupType(GPosLookupTypePair) {
+				continue
+			}
+			covs = append(covs, node.Coverage)
+			if p := node.GPosPayload().PairFmt2; p != nil {
+				cdefs = append(cdefs, p.ClassDef1, p.ClassDef2)
+			}
+		}
+	}
+	n := GlyphIndex(otf.Layout.GPos.LookupGraph().NumGlyphs())
+	setSorted := func(sorted bool) {
+		for _, cov := range covs {
+			switch r := cov.GlyphRange.(type) {
+			case *glyphRangeArray:
+				r.sorted = sorted
+			case *glyphRangeRecords:
+				r.sorted = sorted
+			}
+		}
+		for _, cdef := range cdefs {
+			if r, ok := cdef.records.(*classDefinitionsFormat2); ok {
+				r.sorted = sorted
+			}
+		}
+	}
+	for _, search := range []string{"linear", "binary"} {
+		b.Run(search, func(b *testing.B) {
+			setSorted(search == "binary")
+			for b.Loop() {
+				for g := range n {
+					for _, cov := range covs {
+						cov.Match(g)
+					}
+					for i := range cdefs {
+						cdefs[i].Lookup(g)
+					}
+				}
+			}
+		})
+	}
+}
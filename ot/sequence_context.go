@@ -0,0 +1,197 @@
+package ot
+
+import "slices"
+
+// SequenceContext is a table-independent view of a contextual lookup subtable
+// (GSUB lookup type 5, GPOS lookup type 7) or chained contextual lookup
+// subtable (GSUB lookup type 6, GPOS lookup type 8), for tools analysing
+// lookups, e.g., glyph closure computation or decompilers.
+//
+// Other than the typed payloads of lookup nodes, which mirror the binary
+// structure of the subtables, SequenceContext lists each rule with its
+// complete context:
+//
+//   - Input sequences include the first input glyph (format 1) or class
+//     (format 2), which the binary rules leave implicit.
+//   - Backtrack sequences are in logical order, i.e., the glyph directly
+//     preceding the input sequence comes last. (Binary subtables store
+//     backtrack sequences in reverse order.)
+//
+// Rules are listed in the order in which they are tried when applying the
+// subtable, with rules of the same first glyph or class grouped together.
+type SequenceContext struct {
+	Format   uint16   // subtable format 1 (glyphs), 2 (classes) or 3 (coverages)
+	Chained  bool     // true for chained contextual subtables
+	Coverage Coverage // coverage of the first input glyph
+
+	// Class definitions of format 2 subtables. Non-chained subtables use
+	// InputClassDef only.
+	BacktrackClassDef, InputClassDef, LookaheadClassDef ClassDefinitions
+
+	Rules []SequenceContextRule
+}
+
+// SequenceContextRule is a single rule of a SequenceContext. Depending on the
+// format of the subtable, sequences are given as glyphs, as classes or as
+// coverage tables; fields of the other formats are nil. Backtrack and
+// lookahead sequences are empty for non-chained subtables.
+type SequenceContextRule struct {
+	Backtrack, Input, Lookahead                            []GlyphIndex // format 1
+	BacktrackClasses, InputClasses, LookaheadClasses       []uint16     // format 2
+	BacktrackCoverages, InputCoverages, LookaheadCoverages []Coverage   // format 3
+
+	// Records are the lookups to apply at positions of the input sequence
+	// if the rule matches.
+	Records []SequenceLookupRecord
+}
+
+// SequenceContext returns a table-independent view of a contextual or chained
+// contextual lookup subtable. Extension subtables are resolved. If the node is
+// not a contextual subtable, ok is false.
+func (ln *LookupNode) SequenceContext() (sc *SequenceContext, ok bool) {
+	ln = ln.Unwrap()
+	if ln == nil {
+		return nil, false
+	}
+	if p := ln.GSub; p != nil {
+		switch {
+		case p.ContextFmt1 != nil:
+			sc = glyphSequenceContext(ln.Coverage, false, len(p.ContextFmt1.RuleSets), func(i int) []SequenceContextRule {
+				return mapRules(p.ContextFmt1.RuleSets[i], func(r GSubSequenceRule) SequenceContextRule {
+					return SequenceContextRule{Input: r.InputGlyphs, Records: r.Records}
+				})
+			})
+		case p.ContextFmt2 != nil:
+			sc = classSequenceContext(ln.Coverage, false, len(p.ContextFmt2.RuleSets), func(i int) []SequenceContextRule {
+				return mapRules(p.ContextFmt2.RuleSets[i], func(r GSubClassSequenceRule) SequenceContextRule {
+					return SequenceContextRule{InputClasses: r.InputClasses, Records: r.Records}
+				})
+			})
+			sc.InputClassDef = p.ContextFmt2.ClassDef
+		case p.ContextFmt3 != nil:
+			sc = coverageSequenceContext(false, nil, p.ContextFmt3.InputCoverages, nil, p.ContextFmt3.Records)
+		case p.ChainingContextFmt1 != nil:
+			sc = glyphSequenceContext(ln.Coverage, true, len(p.ChainingContextFmt1.RuleSets), func(i int) []SequenceContextRule {
+				return mapRules(p.ChainingContextFmt1.RuleSets[i], func(r GSubChainedSequenceRule) SequenceContextRule {
+					return SequenceContextRule{Backtrack: r.Backtrack, Input: r.Input, Lookahead: r.Lookahead, Records: r.Records}
+				})
+			})
+		case p.ChainingContextFmt2 != nil:
+			q := p.ChainingContextFmt2
+			sc = classSequenceContext(ln.Coverage, true, len(q.RuleSets), func(i int) []SequenceContextRule {
+				return mapRules(q.RuleSets[i], func(r GSubChainedClassRule) SequenceContextRule {
+					return SequenceContextRule{BacktrackClasses: r.Backtrack, InputClasses: r.Input,
+						LookaheadClasses: r.Lookahead, Records: r.Records}
+				})
+			})
+			sc.BacktrackClassDef, sc.InputClassDef, sc.LookaheadClassDef = q.BacktrackClassDef, q.InputClassDef, q.LookaheadClassDef
+		case p.ChainingContextFmt3 != nil:
+			q := p.ChainingContextFmt3
+			sc = coverageSequenceContext(true, q.BacktrackCoverages, q.InputCoverages, q.LookaheadCoverages, q.Records)
+		}
+	} else if p := ln.GPos; p != nil {
+		switch {
+		case p.ContextFmt1 != nil:
+			sc = glyphSequenceContext(ln.Coverage, false, len(p.ContextFmt1.RuleSets), func(i int) []SequenceContextRule {
+				return mapRules(p.ContextFmt1.RuleSets[i], func(r GPosSequenceRule) SequenceContextRule {
+					return SequenceContextRule{Input: r.InputGlyphs, Records: r.Records}
+				})
+			})
+		case p.ContextFmt2 != nil:
+			sc = classSequenceContext(ln.Coverage, false, len(p.ContextFmt2.RuleSets), func(i int) []SequenceContextRule {
+				return mapRules(p.ContextFmt2.RuleSets[i], func(r GPosClassSequenceRule) SequenceContextRule {
+					return SequenceContextRule{InputClasses: r.InputClasses, Records: r.Records}
+				})
+			})
+			sc.InputClassDef = p.ContextFmt2.ClassDef
+		case p.ContextFmt3 != nil:
+			sc = coverageSequenceContext(false, nil, p.ContextFmt3.InputCoverages, nil, p.ContextFmt3.Records)
+		case p.ChainingContextFmt1 != nil:
+			sc = glyphSequenceContext(ln.Coverage, true, len(p.ChainingContextFmt1.RuleSets), func(i int) []SequenceContextRule {
+				return mapRules(p.ChainingContextFmt1.RuleSets[i], func(r GPosChainedSequenceRule) SequenceContextRule {
+					return SequenceContextRule{Backtrack: r.Backtrack, Input: r.Input, Lookahead: r.Lookahead, Records: r.Records}
+				})
+			})
+		case p.ChainingContextFmt2 != nil:
+			q := p.ChainingContextFmt2
+			sc = classSequenceContext(ln.Coverage, true, len(q.RuleSets), func(i int) []SequenceContextRule {
+				return mapRules(q.RuleSets[i], func(r GPosChainedClassRule) SequenceContextRule {
+					return SequenceContextRule{BacktrackClasses: r.Backtrack, InputClasses: r.Input,
+						LookaheadClasses: r.Lookahead, Records: r.Records}
+				})
+			})
+			sc.BacktrackClassDef, sc.InputClassDef, sc.LookaheadClassDef = q.BacktrackClassDef, q.InputClassDef, q.LookaheadClassDef
+		case p.ChainingContextFmt3 != nil:
+			q := p.ChainingContextFmt3
+			sc = coverageSequenceContext(true, q.BacktrackCoverages, q.InputCoverages, q.LookaheadCoverages, q.Records)
+		}
+	}
+	return sc, sc != nil
+}
+
+func mapRules[R any](rules []R, f func(R) SequenceContextRule) []SequenceContextRule {
+	out := make([]SequenceContextRule, len(rules))
+	for i, r := range rules {
+		out[i] = f(r)
+	}
+	return out
+}
+
+// glyphSequenceContext collects the rules of a format 1 subtable. Rule set i
+// belongs to the glyph with coverage index i.
+func glyphSequenceContext(cov Coverage, chained bool, n int, ruleSet func(int) []SequenceContextRule) *SequenceContext {
+	sc := &SequenceContext{Format: 1, Chained: chained, Coverage: cov}
+	i := 0
+	for first := range cov.Glyphs() {
+		if i >= n {
+			break
+		}
+		for _, r := range ruleSet(i) {
+			r.Input = append([]GlyphIndex{first}, r.Input...)
+			r.Backtrack = reversed(r.Backtrack)
+			sc.Rules = append(sc.Rules, r)
+		}
+		i++
+	}
+	return sc
+}
+
+// classSequenceContext collects the rules of a format 2 subtable. Rule set i
+// belongs to input class i.
+func classSequenceContext(cov Coverage, chained bool, n int, ruleSet func(int) []SequenceContextRule) *SequenceContext {
+	sc := &SequenceContext{Format: 2, Chained: chained, Coverage: cov}
+	for i := range n {
+		for _, r := range ruleSet(i) {
+			r.InputClasses = append([]uint16{uint16(i)}, r.InputClasses...)
+			r.BacktrackClasses = reversed(r.BacktrackClasses)
+			sc.Rules = append(sc.Rules, r)
+		}
+	}
+	return sc
+}
+
+// coverageSequenceContext wraps the single rule of a format 3 subtable.
+func coverageSequenceContext(chained bool, backtrack, input, lookahead []Coverage,
+	records []SequenceLookupRecord) *SequenceContext {
+	sc := &SequenceContext{Format: 3, Chained: chained}
+	if len(input) > 0 {
+		sc.Coverage = input[0]
+	}
+	sc.Rules = []SequenceContextRule{{
+		BacktrackCoverages: reversed(backtrack),
+		InputCoverages:     input,
+		LookaheadCoverages: lookahead,
+		Records:            records,
+	}}
+	return sc
+}
+
+// reversed returns a reversed copy of s, or nil for an empty s.
+func reversed[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	r := slices.Clone(s)
+	slices.Reverse(r)
+	return r
+}
@@ -0,0 +1,132 @@
+package ot
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSequenceContextGSubChained(t *testing.T) {
+	// GSUB6/1: coverage=[100], one rule: back=[99 98] (stored reversed), input=[102],
+	// lookahead=[103], record=(1,9)
+	b := make([]byte, 38)
+	putU16(b, 0, 1)
+	putU16(b, 2, 8)
+	putU16(b, 4, 1)
+	putU16(b, 6, 14)
+	copy(b[8:], coverageFmt1(100))
+	putU16(b, 14, 1)
+	putU16(b, 16, 4)
+	putU16(b, 18, 2)
+	putU16(b, 20, 99)
+	putU16(b, 22, 98)
+	putU16(b, 24, 2)
+	putU16(b, 26, 102)
+	putU16(b, 28, 1)
+	putU16(b, 30, 103)
+	putU16(b, 32, 1)
+	putU16(b, 34, 1)
+	putU16(b, 36, 9)
+	node := parseConcreteLookupNode(b, GSubLookupTypeChainingContext)
+	sc, ok := node.SequenceContext()
+	if !ok || sc.Format != 1 || !sc.Chained || len(sc.Rules) != 1 {
+		t.Fatalf("unexpected sequence context for GSUB6/1: %+v", sc)
+	}
+	expected := SequenceContextRule{
+		Backtrack: []GlyphIndex{98, 99},
+		Input:     []GlyphIndex{100, 102},
+		Lookahead: []GlyphIndex{103},
+		Records:   []SequenceLookupRecord{{SequenceIndex: 1, LookupListIndex: 9}},
+	}
+	if !reflect.DeepEqual(sc.Rules[0], expected) {
+		t.Errorf("expected rule %+v, have %+v", expected, sc.Rules[0])
+	}
+	// GSUB6/3: back=[cov201 cov200], input=[cov202], lookahead=[], record=(0,13)
+	b = make([]byte, 38)
+	putU16(b, 0, 3)
+	putU16(b, 2, 2)
+	putU16(b, 4, 20)
+	putU16(b, 6, 26)
+	putU16(b, 8, 1)
+	putU16(b, 10, 32)
+	putU16(b, 12, 0)
+	putU16(b, 14, 1)
+	putU16(b, 16, 0)
+	putU16(b, 18, 13)
+	copy(b[20:], coverageFmt1(201))
+	copy(b[26:], coverageFmt1(200))
+	copy(b[32:], coverageFmt1(202))
+	node = parseConcreteLookupNode(b, GSubLookupTypeChainingContext)
+	sc, ok = node.SequenceContext()
+	if !ok || sc.Format != 3 || len(sc.Rules) != 1 {
+		t.Fatalf("unexpected sequence context for GSUB6/3: %+v", sc)
+	}
+	r := sc.Rules[0]
+	if len(r.BacktrackCoverages) != 2 || !r.BacktrackCoverages[0].Contains(200) || !r.BacktrackCoverages[1].Contains(201) {
+		t.Errorf("expected backtrack coverages in logical order")
+	}
+	if len(r.InputCoverages) != 1 || !sc.Coverage.Contains(202) || len(r.LookaheadCoverages) != 0 {
+		t.Errorf("unexpected input/lookahead coverages for GSUB6/3")
+	}
+}
+
+func TestSequenceContextGPosContext(t *testing.T) {
+	// GPOS7/1: coverage=[10], one rule: input=[11], record=(1,7)
+	b := make([]byte, 28)
+	putU16(b, 0, 1)
+	putU16(b, 2, 8)
+	putU16(b, 4, 1)
+	putU16(b, 6, 14)
+	copy(b[8:], coverageFmt1(10))
+	putU16(b, 14, 1)
+	putU16(b, 16, 4)
+	putU16(b, 18, 2)
+	putU16(b, 20, 1)
+	putU16(b, 22, 11)
+	putU16(b, 24, 1)
+	putU16(b, 26, 7)
+	node := parseConcreteLookupNode(b, MaskGPosLookupType(GPosLookupTypeContextPos))
+	sc, ok := node.SequenceContext()
+	if !ok || sc.Format != 1 || sc.Chained || len(sc.Rules) != 1 {
+		t.Fatalf("unexpected sequence context for GPOS7/1: %+v", sc)
+	}
+	expected := SequenceContextRule{
+		Input:   []GlyphIndex{10, 11},
+		Records: []SequenceLookupRecord{{SequenceIndex: 1, LookupListIndex: 7}},
+	}
+	if !reflect.DeepEqual(sc.Rules[0], expected) {
+		t.Errorf("expected rule %+v, have %+v", expected, sc.Rules[0])
+	}
+	// GPOS7/2: coverage=[15], class def {20:1, 21:2}, rule set for class 0: input=[2], record=(0,9)
+	b = make([]byte, 40)
+	putU16(b, 0, 2)
+	putU16(b, 2, 10)
+	putU16(b, 4, 16)
+	putU16(b, 6, 1)
+	putU16(b, 8, 26)
+	copy(b[10:], coverageFmt1(15))
+	copy(b[16:], classDefFmt1(20, 1, 2))
+	putU16(b, 26, 1)
+	putU16(b, 28, 4)
+	putU16(b, 30, 2)
+	putU16(b, 32, 1)
+	putU16(b, 34, 2)
+	putU16(b, 36, 0)
+	putU16(b, 38, 9)
+	node = parseConcreteLookupNode(b, MaskGPosLookupType(GPosLookupTypeContextPos))
+	sc, ok = node.SequenceContext()
+	if !ok || sc.Format != 2 || len(sc.Rules) != 1 || sc.InputClassDef.Lookup(21) != 2 {
+		t.Fatalf("unexpected sequence context for GPOS7/2: %+v", sc)
+	}
+	if classes := sc.Rules[0].InputClasses; !reflect.DeepEqual(classes, []uint16{0, 2}) {
+		t.Errorf("expected input classes [0 2], have %v", classes)
+	}
+	// GSUB1/1 is not a contextual subtable
+	b = make([]byte, 12)
+	putU16(b, 0, 1)
+	putU16(b, 2, 6)
+	putU16(b, 4, 3)
+	copy(b[6:], coverageFmt1(5))
+	if _, ok := parseConcreteLookupNode(b, GSubLookupTypeSingle).SequenceContext(); ok {
+		t.Errorf("expected GSUB1/1 to have no sequence context")
+	}
+}
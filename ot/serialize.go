@@ -0,0 +1,120 @@
+package ot
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+)
+
+// Font types, as found in the header of a font. See FontHeader.
+const (
+	FontTypeTrueType  uint32 = 0x00010000 // TrueType outlines
+	FontTypeCFF       uint32 = 0x4f54544f // 'OTTO', CFF outlines
+	FontTypeAppleTrue uint32 = 0x74727565 // 'true', Apple TrueType
+	FontTypeAppleTyp1 uint32 = 0x74797031 // 'typ1', Apple PostScript Type 1 in an SFNT wrapper
+)
+
+// Bytes returns the binary of the font, exactly as it has been parsed.
+// The returned bytes must be treated as read-only by callers.
+func (otf *Font) Bytes() []byte {
+	return otf.Binary()
+}
+
+// WriteTo writes the binary of the font to w, exactly as it has been parsed.
+// It implements io.WriterTo.
+func (otf *Font) WriteTo(w io.Writer) (int64, error) {
+	n, err := w.Write(otf.Binary())
+	return int64(n), err
+}
+
+// Rebuild creates a new font binary from the tables of otf, with tables in
+// replace substituted. A nil entry in replace removes a table from the font.
+// Tables are laid out in tag order, and checksums as well as
+// head.checkSumAdjustment are re-calculated; see AssembleFont.
+//
+// Rebuild is the basis for writing modified fonts, e.g., subsets or instances of
+// variable fonts.
+func (otf *Font) Rebuild(replace map[Tag][]byte) ([]byte, error) {
+	if otf == nil || otf.Header == nil {
+		return nil, errors.New("cannot rebuild empty font")
+	}
+	tables := make(map[Tag][]byte, len(otf.tables))
+	for tag, t := range otf.tables {
+		tables[tag] = t.Binary()
+	}
+	for tag, data := range replace {
+		if data == nil {
+			delete(tables, tag)
+		} else {
+			tables[tag] = data
+		}
+	}
+	return AssembleFont(otf.Header.FontType, tables)
+}
+
+// AssembleFont creates a font binary with font type fontType (e.g., FontTypeTrueType)
+// from table data. Tables are written in ascending order of their tags, 4-byte
+// aligned and zero-padded, following a table directory with freshly calculated
+// table checksums. If a 'head' table is present, its checkSumAdjustment field is
+// re-calculated; the table data passed in is not modified.
+//
+// AssembleFont is deterministic: assembling the same tables twice results in
+// identical binaries.
+func AssembleFont(fontType uint32, tables map[Tag][]byte) ([]byte, error) {
+	n := len(tables)
+	if n == 0 {
+		return nil, errors.New("cannot assemble font without tables")
+	}
+	if n > 0xffff {
+		return nil, fmt.Errorf("too many tables: %d", n)
+	}
+	tags := slices.Sorted(maps.Keys(tables))
+	size := 12 + 16*n
+	for _, tag := range tags {
+		size += (len(tables[tag]) + 3) &^ 3
+	}
+	if uint64(size) > 0xffffffff {
+		return nil, fmt.Errorf("font too large: %d bytes", size)
+	}
+	out := make([]byte, 12, size)
+	binary.BigEndian.PutUint32(out[0:], fontType)
+	binary.BigEndian.PutUint16(out[4:], uint16(n))
+	searchRange, entrySelector := 1, 0
+	for searchRange*2 <= n {
+		searchRange, entrySelector = searchRange*2, entrySelector+1
+	}
+	binary.BigEndian.PutUint16(out[6:], uint16(16*searchRange))
+	binary.BigEndian.PutUint16(out[8:], uint16(entrySelector))
+	binary.BigEndian.PutUint16(out[10:], uint16(16*(n-searchRange)))
+	out = out[:12+16*n]
+	offset := 12 + 16*n
+	headOffset := -1
+	for i, tag := range tags {
+		data := tables[tag]
+		sum := tableChecksum(data)
+		if tag == T("head") && len(data) >= 12 {
+			sum -= u32(data[8:12]) // checkSumAdjustment is taken as 0
+			headOffset = offset
+		}
+		rec := out[12+16*i:]
+		binary.BigEndian.PutUint32(rec[0:], uint32(tag))
+		binary.BigEndian.PutUint32(rec[4:], sum)
+		binary.BigEndian.PutUint32(rec[8:], uint32(offset))
+		binary.BigEndian.PutUint32(rec[12:], uint32(len(data)))
+		offset += (len(data) + 3) &^ 3
+	}
+	for _, tag := range tags {
+		out = append(out, tables[tag]...)
+		for len(out)%4 != 0 {
+			out = append(out, 0)
+		}
+	}
+	if headOffset >= 0 {
+		binary.BigEndian.PutUint32(out[headOffset+8:], 0)
+		binary.BigEndian.PutUint32(out[headOffset+8:], uint32(checkSumAdjustmentMagic)-tableChecksum(out))
+	}
+	return out, nil
+}
@@ -0,0 +1,108 @@
+package ot
+
+import (
+	"bytes"
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/schuko/tracing/gotestingadapter"
+)
+
+func TestWriteUnmodified(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	var buf bytes.Buffer
+	n, err := otf.WriteTo(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(otf.Binary())) || !bytes.Equal(buf.Bytes(), otf.Bytes()) {
+		t.Errorf("expected WriteTo to reproduce the font binary")
+	}
+}
+
+func TestRebuild(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	data, err := otf.Rebuild(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rebuilt, err := Parse(data, VerifyChecksums)
+	if err != nil {
+		t.Fatalf("cannot parse rebuilt font: %v", err)
+	}
+	if m := rebuilt.VerifyChecksums(); len(m) != 0 {
+		t.Errorf("expected valid checksums for rebuilt font, have %v", m)
+	}
+	if len(rebuilt.TableTags()) != len(otf.TableTags()) {
+		t.Errorf("expected %d tables, have %d", len(otf.TableTags()), len(rebuilt.TableTags()))
+	}
+	for _, tag := range otf.TableTags() {
+		orig, b := otf.Table(tag).Binary(), rebuilt.Table(tag).Binary()
+		if tag == T("head") { // checkSumAdjustment depends on the table layout
+			orig, b = slices.Concat(orig[:8], orig[12:]), slices.Concat(b[:8], b[12:])
+		}
+		if !bytes.Equal(orig, b) {
+			t.Errorf("table %s differs after rebuild", tag)
+		}
+	}
+	again, err := rebuilt.Rebuild(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Errorf("expected rebuilding a rebuilt font to be bit-identical")
+	}
+	// remove the (now invalid) signature and replace 'name'
+	name := slices.Clone(otf.Table(T("name")).Binary())
+	name = append(name, 0, 0, 0)
+	data, err = otf.Rebuild(map[Tag][]byte{T("DSIG"): nil, T("name"): name})
+	if err != nil {
+		t.Fatal(err)
+	}
+	modified, err := Parse(data)
+	if err != nil {
+		t.Fatalf("cannot parse modified font: %v", err)
+	}
+	if modified.Table(T("DSIG")) != nil {
+		t.Errorf("expected table 'DSIG' to be removed")
+	}
+	if !bytes.Equal(modified.Table(T("name")).Binary(), name) {
+		t.Errorf("expected table 'name' to be replaced")
+	}
+	if m := modified.VerifyChecksums(); len(m) != 0 {
+		t.Errorf("expected valid checksums for modified font, have %v", m)
+	}
+}
+
+func TestQuirkFontTypes(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	for _, typ := range []uint32{FontTypeAppleTrue, FontTypeAppleTyp1} {
+		data := slices.Clone(otf.Binary())
+		binary.BigEndian.PutUint32(data, typ)
+		quirk, err := Parse(data)
+		if err != nil {
+			t.Errorf("expected font type %x to be accepted: %v", typ, err)
+			continue
+		}
+		if quirk.Header.FontType != typ {
+			t.Errorf("expected font type %x, have %x", typ, quirk.Header.FontType)
+		}
+		rebuilt, err := quirk.Rebuild(nil)
+		if err != nil || binary.BigEndian.Uint32(rebuilt) != typ {
+			t.Errorf("expected rebuilt font to keep font type %x", typ)
+		}
+	}
+	if _, err := Parse([]byte("abcdefghijklmnop")); err == nil {
+		t.Errorf("expected unknown font type to be rejected")
+	}
+}
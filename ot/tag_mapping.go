@@ -0,0 +1,131 @@
+package ot
+
+import "strings"
+
+//go:generate go run gen_langtags.go -registry languagetags.htm
+
+// unicodeScripts lists the ISO 15924 codes of the scripts of Unicode 15.1,
+// plus some codes for script variants. Unless listed in scriptTagExceptions,
+// the OpenType script tag of a script is its ISO 15924 code in lowercase.
+var unicodeScripts = strings.Fields(`
+	Adlm Aghb Ahom Arab Aran Armi Armn Avst Bali Bamu Bass Batk Beng Bhks Bopo
+	Brah Brai Bugi Buhd Cakm Cans Cari Cham Cher Chrs Copt Cpmn Cprt Cyrl Cyrs
+	Deva Diak Dogr Dsrt Dupl Egyp Elba Elym Ethi Geok Geor Glag Gong Gonm Goth
+	Gran Grek Gujr Guru Hang Hani Hano Hans Hant Hatr Hebr Hira Hluw Hmng Hmnp
+	Hrkt Hung Ital Java Kali Kana Kawi Khar Khmr Khoj Kits Knda Kthi Lana Laoo
+	Latf Latg Latn Lepc Limb Lina Linb Lisu Lyci Lydi Mahj Maka Mand Mani Marc
+	Medf Mend Merc Mero Mlym Modi Mong Mroo Mtei Mult Mymr Nagm Nand Narb Nbat
+	Newa Nkoo Nshu Ogam Olck Orkh Orya Osge Osma Ougr Palm Pauc Perm Phag Phli
+	Phlp Phnx Plrd Prti Rjng Rohg Runr Samr Sarb Saur Sgnw Shaw Shrd Sidd Sind
+	Sinh Sogd Sogo Sora Soyo Sund Sylo Syrc Syre Syrj Syrn Tagb Takr Tale Talu
+	Taml Tang Tavt Telu Tfng Tglg Thaa Thai Tibt Tirh Tnsa Toto Ugar Vaii Vith
+	Wara Wcho Xpeo Xsux Yezi Yiii Zanb Zinh Zmth Zyyy Zzzz
+`)
+
+// scriptTagExceptions holds the OpenType script tags of scripts for which the
+// tag is not simply the lowercase ISO 15924 code, in order of preference.
+// Indic scripts have been given new tags ("v.2") with a revised shaping model;
+// fonts may support either one.
+var scriptTagExceptions = map[string][]Tag{
+	"Aran": {T("arab")},
+	"Beng": {T("bng2"), T("beng")},
+	"Cyrs": {T("cyrl")},
+	"Deva": {T("dev2"), T("deva")},
+	"Geok": {T("geor")},
+	"Gujr": {T("gjr2"), T("gujr")},
+	"Guru": {T("gur2"), T("guru")},
+	"Hans": {T("hani")},
+	"Hant": {T("hani")},
+	"Hira": {T("kana")},
+	"Hrkt": {T("kana")},
+	"Knda": {T("knd2"), T("knda")},
+	"Laoo": {T("lao ")},
+	"Latf": {T("latn")},
+	"Latg": {T("latn")},
+	"Mlym": {T("mlm2"), T("mlym")},
+	"Mymr": {T("mym2"), T("mymr")},
+	"Nkoo": {T("nko ")},
+	"Orya": {T("ory2"), T("orya")},
+	"Syre": {T("syrc")},
+	"Syrj": {T("syrc")},
+	"Syrn": {T("syrc")},
+	"Taml": {T("tml2"), T("taml")},
+	"Telu": {T("tel2"), T("telu")},
+	"Vaii": {T("vai ")},
+	"Yiii": {T("yi  ")},
+	"Zinh": {DFLT},
+	"Zmth": {T("math")},
+	"Zyyy": {DFLT},
+	"Zzzz": {DFLT},
+}
+
+// ScriptTagsForUnicodeScript returns the OpenType script tags for a Unicode
+// script, given by its ISO 15924 code (e.g. "Deva"), in order of preference.
+// Clients should use the first tag supported by a font. For Indic scripts, the
+// tag of the revised shaping model (e.g. 'dev2') is returned before the old
+// one (e.g. 'deva').
+//
+// Scripts Common, Inherited and Unknown map to 'DFLT'. For codes not denoting a
+// script of Unicode, nil is returned. Matching of code is case-insensitive.
+func ScriptTagsForUnicodeScript(s string) []Tag {
+	if len(s) != 4 {
+		return nil
+	}
+	s = strings.ToUpper(s[:1]) + strings.ToLower(s[1:])
+	if tags, ok := scriptTagExceptions[s]; ok {
+		return append([]Tag(nil), tags...)
+	}
+	for _, code := range unicodeScripts {
+		if code == s {
+			return []Tag{T(strings.ToLower(s))}
+		}
+	}
+	return nil
+}
+
+// LanguageTag returns the OpenType language system tag for a BCP 47 language
+// tag, e.g. 'DEU ' for "de-CH". Subtags other than the primary language subtag
+// are only considered for Chinese, where script and region select between
+// 'ZHS ', 'ZHT ' and 'ZHH '. Underscores are accepted as subtag separators.
+//
+// For undetermined or unknown languages, LanguageTag returns 'DFLT'.
+func LanguageTag(bcp47 string) Tag {
+	subtags := strings.FieldsFunc(strings.ToLower(bcp47), func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	if len(subtags) == 0 {
+		return DFLT
+	}
+	lang := subtags[0]
+	if code, ok := iso639Alpha2[lang]; ok {
+		lang = code
+	}
+	switch lang {
+	case "zho", "cmn":
+		return chineseLanguageTag(subtags[1:])
+	case "yue":
+		return T("ZHH")
+	}
+	if tag, ok := languageSystemTags[lang]; ok {
+		return T(tag)
+	}
+	return DFLT
+}
+
+// chineseLanguageTag selects the language system tag for Chinese by script
+// and region subtags.
+func chineseLanguageTag(subtags []string) Tag {
+	traditional := false
+	for _, st := range subtags {
+		switch st {
+		case "hk", "mo":
+			return T("ZHH")
+		case "tw", "hant":
+			traditional = true
+		}
+	}
+	if traditional {
+		return T("ZHT")
+	}
+	return T("ZHS")
+}
@@ -0,0 +1,63 @@
+package ot
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestScriptTagsForUnicodeScript(t *testing.T) {
+	for _, c := range []struct {
+		script string
+		tags   []Tag
+	}{
+		{"Latn", []Tag{T("latn")}},
+		{"latn", []Tag{T("latn")}},
+		{"Deva", []Tag{T("dev2"), T("deva")}},
+		{"Mymr", []Tag{T("mym2"), T("mymr")}},
+		{"Hira", []Tag{T("kana")}},
+		{"Laoo", []Tag{T("lao ")}},
+		{"Yiii", []Tag{T("yi  ")}},
+		{"Zyyy", []Tag{DFLT}},
+		{"Adlm", []Tag{T("adlm")}},
+		{"Xxxx", nil},
+		{"Latin", nil},
+	} {
+		if tags := ScriptTagsForUnicodeScript(c.script); !slices.Equal(tags, c.tags) {
+			t.Errorf("script %q: expected %v, have %v", c.script, c.tags, tags)
+		}
+	}
+}
+
+func TestLanguageTag(t *testing.T) {
+	for _, c := range []struct {
+		bcp47 string
+		tag   string
+	}{
+		{"de", "DEU "},
+		{"de-CH", "DEU "},
+		{"DE_ch", "DEU "},
+		{"deu", "DEU "},
+		{"en-US", "ENG "},
+		{"nl", "NLD "},
+		{"no", "NOR "},
+		{"nb", "NOR "},
+		{"nn", "NYN "},
+		{"el", "ELL "},
+		{"ga", "IRI "},
+		{"tr", "TRK "},
+		{"fil", "PIL "},
+		{"zh", "ZHS "},
+		{"zh-Hans-CN", "ZHS "},
+		{"zh-Hant", "ZHT "},
+		{"zh-TW", "ZHT "},
+		{"zh-HK", "ZHH "},
+		{"yue", "ZHH "},
+		{"und", "DFLT"},
+		{"", "DFLT"},
+		{"qaa", "DFLT"},
+	} {
+		if tag := LanguageTag(c.bcp47); tag != T(c.tag) {
+			t.Errorf("language %q: expected %q, have %q", c.bcp47, c.tag, tag)
+		}
+	}
+}
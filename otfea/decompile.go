@@ -0,0 +1,378 @@
+package otfea
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Decompile writes the layout tables GSUB and GPOS of otf to w, as text in
+// Adobe feature file syntax. Glyphs are named by names; if names is nil,
+// DefaultGlyphNames is used.
+//
+// Lookups are named after their table and their index in the lookup list,
+// e.g., 'GSUB_3' for the fourth lookup of table GSUB. Errors of individual
+// lookups or subtables do not stop decompilation, but are reported as comments.
+// Decompile returns an error if otf is nil or if writing to w fails.
+func Decompile(otf *ot.Font, w io.Writer, names GlyphNames) error {
+	if otf == nil {
+		return errors.New("font is nil")
+	}
+	if names == nil {
+		names = DefaultGlyphNames(otf)
+	}
+	d := &decompiler{w: w, names: names}
+	var layouts []*layoutTable
+	if t := otf.Table(ot.T("GSUB")); t != nil && t.Self().AsGSub() != nil {
+		layouts = append(layouts, &layoutTable{name: "GSUB", rule: "sub", LayoutTable: &t.Self().AsGSub().LayoutTable})
+	}
+	if t := otf.Table(ot.T("GPOS")); t != nil && t.Self().AsGPos() != nil {
+		layouts = append(layouts, &layoutTable{name: "GPOS", rule: "pos", LayoutTable: &t.Self().AsGPos().LayoutTable})
+	}
+	for _, lyt := range layouts {
+		d.numGlyphs = max(d.numGlyphs, lyt.LookupGraph().NumGlyphs())
+	}
+	if d.numGlyphs > 0 {
+		d.allGlyphs = &ot.GlyphSet{}
+		d.allGlyphs.AddRange(0, ot.GlyphIndex(d.numGlyphs-1))
+	}
+	d.languageSystems(layouts)
+	if t := otf.Table(ot.T("GDEF")); t != nil && t.Self().AsGDef() != nil {
+		d.gdef(t.Self().AsGDef())
+	}
+	for _, lyt := range layouts {
+		d.layout(lyt)
+	}
+	return d.err
+}
+
+// decompiler writes a feature file. The first error writing to w is recorded
+// and stops all further output.
+type decompiler struct {
+	w         io.Writer
+	err       error
+	names     GlyphNames
+	numGlyphs int          // number of glyphs from table 'maxp', 0 if unknown
+	allGlyphs *ot.GlyphSet // all glyphs of the font, nil if unknown
+
+	lookup  string          // name of the lookup currently decompiled
+	defs    strings.Builder // class definitions for the current lookup
+	body    strings.Builder // statements of the current lookup
+	classes map[string]bool // classes defined for the current lookup
+}
+
+// layoutTable is a GSUB or GPOS table to decompile.
+type layoutTable struct {
+	*ot.LayoutTable
+	name string // table tag, used as prefix for lookup names
+	rule string // keyword of rules, 'sub' or 'pos'
+}
+
+func (d *decompiler) printf(format string, args ...any) {
+	if d.err == nil {
+		_, d.err = fmt.Fprintf(d.w, format, args...)
+	}
+}
+
+// --- Language systems and GDEF ---------------------------------------------
+
+// languageSystems writes the language systems of all layout tables, with
+// 'DFLT dflt' first, as required by the feature file syntax.
+func (d *decompiler) languageSystems(layouts []*layoutTable) {
+	var langsys [][2]string
+	for _, lyt := range layouts {
+		for script, s := range lyt.ScriptGraph().Range() {
+			if s.DefaultLangSys() != nil {
+				langsys = append(langsys, [2]string{tagName(script), "dflt"})
+			}
+			for lang := range s.Range() {
+				langsys = append(langsys, [2]string{tagName(script), tagName(lang)})
+			}
+		}
+	}
+	if len(langsys) == 0 {
+		return
+	}
+	dflt := [2]string{"DFLT", "dflt"}
+	written := map[[2]string]bool{}
+	if slices.Contains(langsys, dflt) {
+		langsys = append([][2]string{dflt}, langsys...)
+	}
+	for _, ls := range langsys {
+		if !written[ls] {
+			d.printf("languagesystem %s %s;\n", ls[0], ls[1])
+			written[ls] = true
+		}
+	}
+	d.printf("\n")
+}
+
+// GDEF glyph classes, in the order of the GlyphClassDef statement.
+var gdefGlyphClasses = []string{"GDEF_Base", "GDEF_Ligature", "GDEF_Mark", "GDEF_Component"}
+
+// gdef writes the glyph classes of table GDEF: mark attachment classes and mark
+// glyph sets as named classes, to be referenced by lookup flags, and glyph
+// classes as a GDEF table block.
+func (d *decompiler) gdef(gdef *ot.GDefTable) {
+	attach := make(map[int]*ot.GlyphSet)
+	for g, class := range gdef.MarkAttachmentClassDef.Classes() {
+		if attach[class] == nil {
+			attach[class] = &ot.GlyphSet{}
+		}
+		attach[class].Add(g)
+	}
+	for _, class := range sortedKeys(attach) {
+		d.printf("@%s = %s;\n", markAttachClassName(class), d.glyphClass(attach[class].Glyphs()))
+	}
+	for i, set := range gdef.MarkGlyphSets {
+		d.printf("@%s = %s;\n", markGlyphSetName(i), d.glyphClass(ot.Coverage{GlyphRange: set}.Glyphs()))
+	}
+	glyphClasses := make([]ot.GlyphSet, len(gdefGlyphClasses))
+	empty := true
+	for g, class := range gdef.GlyphClassDef.Classes() {
+		if class >= 1 && class <= len(glyphClasses) {
+			glyphClasses[class-1].Add(g)
+			empty = false
+		}
+	}
+	if empty {
+		if len(attach) > 0 || len(gdef.MarkGlyphSets) > 0 {
+			d.printf("\n")
+		}
+		return
+	}
+	refs := make([]string, len(glyphClasses))
+	for i := range glyphClasses {
+		if !glyphClasses[i].IsEmpty() {
+			d.printf("@%s = %s;\n", gdefGlyphClasses[i], d.glyphClass(glyphClasses[i].Glyphs()))
+			refs[i] = "@" + gdefGlyphClasses[i]
+		}
+	}
+	d.printf("\ntable GDEF {\n    GlyphClassDef %s;\n} GDEF;\n\n", strings.Join(refs, ", "))
+}
+
+func markAttachClassName(class int) string {
+	return fmt.Sprintf("GDEF_MarkAttachClass%d", class)
+}
+
+func markGlyphSetName(set int) string {
+	return fmt.Sprintf("GDEF_MarkGlyphSet%d", set)
+}
+
+// --- Layout tables ----------------------------------------------------------
+
+// layout writes the lookups and features of a layout table.
+func (d *decompiler) layout(lyt *layoutTable) {
+	d.printf("# %s\n\n", lyt.name)
+	if err := lyt.ScriptGraph().Error(); err != nil {
+		d.printf("# %v\n\n", err)
+	}
+	lookups := lyt.LookupGraph()
+	for _, i := range lookupOrder(lookups) {
+		d.lookupBlock(lyt, i, lookups.Lookup(i))
+	}
+	d.features(lyt)
+}
+
+// lookupName returns the name of the lookup at index i of the lookup list.
+func lookupName(lyt *layoutTable, i int) string {
+	return fmt.Sprintf("%s_%d", lyt.name, i)
+}
+
+// lookupOrder returns the indices of the lookups of a lookup list in the order
+// in which they are written. Feature files require lookups to be defined before
+// they are referenced, so lookups called from contextual rules are moved in
+// front of the first lookup calling them. Otherwise, the order of the lookup
+// list is kept.
+func lookupOrder(lookups *ot.LookupListGraph) []int {
+	order := make([]int, 0, lookups.Len())
+	visited := make([]bool, lookups.Len())
+	var visit func(int)
+	visit = func(i int) {
+		if i < 0 || i >= len(visited) || visited[i] {
+			return
+		}
+		visited[i] = true
+		for _, node := range lookups.Lookup(i).Range() {
+			if sc, ok := node.SequenceContext(); ok {
+				for _, rule := range sc.Rules {
+					for _, rec := range rule.Records {
+						visit(int(rec.LookupListIndex))
+					}
+				}
+			}
+		}
+		order = append(order, i)
+	}
+	for i := range lookups.Len() {
+		visit(i)
+	}
+	return order
+}
+
+// lookupBlock writes a lookup with its lookup flag and subtables. Class
+// definitions needed by the rules of the lookup are written in front of it.
+func (d *decompiler) lookupBlock(lyt *layoutTable, i int, lt *ot.LookupTable) {
+	name := lookupName(lyt, i)
+	if lt == nil {
+		d.printf("# lookup %s is missing\n\n", name)
+		return
+	}
+	d.lookup = name
+	d.defs.Reset()
+	d.body.Reset()
+	d.classes = make(map[string]bool)
+	if flag := d.lookupFlag(lt); flag != "" {
+		d.statement("lookupflag %s;", flag)
+	}
+	if err := lt.Error(); err != nil {
+		d.statement("# %v", err)
+	}
+	extension := false
+	for j, node := range lt.Range() {
+		if j > 0 {
+			d.statement("subtable;")
+		}
+		if node == nil {
+			d.statement("# subtable %d is missing", j)
+			continue
+		}
+		extension = extension || node.Wrapper() != nil
+		if err := node.Error(); err != nil {
+			d.statement("# subtable %d: %v", j, err)
+			continue
+		}
+		d.subtable(lyt, j, node)
+	}
+	useExtension := ""
+	if extension {
+		useExtension = " useExtension"
+	}
+	d.printf("%slookup %s%s {\n%s} %s;\n\n", d.defs.String(), name, useExtension, d.body.String(), name)
+}
+
+// statement adds a statement to the body of the current lookup.
+func (d *decompiler) statement(format string, args ...any) {
+	d.body.WriteString("    ")
+	fmt.Fprintf(&d.body, format, args...)
+	d.body.WriteByte('\n')
+}
+
+// define adds a definition in front of the current lookup.
+func (d *decompiler) define(format string, args ...any) {
+	fmt.Fprintf(&d.defs, format, args...)
+	d.defs.WriteByte('\n')
+}
+
+// lookupFlag formats the flags of a lookup, or returns an empty string if no
+// flag is set.
+func (d *decompiler) lookupFlag(lt *ot.LookupTable) string {
+	var flags []string
+	for _, f := range []struct {
+		flag ot.LayoutTableLookupFlag
+		name string
+	}{
+		{ot.LOOKUP_FLAG_RIGHT_TO_LEFT, "RightToLeft"},
+		{ot.LOOKUP_FLAG_IGNORE_BASE_GLYPHS, "IgnoreBaseGlyphs"},
+		{ot.LOOKUP_FLAG_IGNORE_LIGATURES, "IgnoreLigatures"},
+		{ot.LOOKUP_FLAG_IGNORE_MARKS, "IgnoreMarks"},
+	} {
+		if lt.Flag&f.flag != 0 {
+			flags = append(flags, f.name)
+		}
+	}
+	if class := int(lt.Flag&ot.LOOKUP_FLAG_MARK_ATTACHMENT_TYPE_MASK) >> 8; class != 0 {
+		flags = append(flags, "MarkAttachmentType @"+markAttachClassName(class))
+	}
+	if set, ok := lt.UsesMarkFilteringSet(); ok {
+		flags = append(flags, "UseMarkFilteringSet @"+markGlyphSetName(int(set)))
+	}
+	return strings.Join(flags, " ")
+}
+
+// --- Features ---------------------------------------------------------------
+
+// langSysRef is a reference from a language system to a feature.
+type langSysRef struct {
+	script, lang string
+	feature      int // index into the feature list
+	required     bool
+}
+
+// features writes a feature block for every feature tag referenced by a
+// language system, in the order of the feature list. Within a feature block,
+// lookups are listed per script and language. Non-default languages exclude
+// the lookups of the default language, which states the lookups of every
+// language system explicitly.
+func (d *decompiler) features(lyt *layoutTable) {
+	fl := lyt.FeatureGraph()
+	var features []*ot.Feature
+	for _, f := range fl.Range() {
+		features = append(features, f)
+	}
+	refs := make(map[ot.Tag][]langSysRef)
+	addRefs := func(script, lang string, ls *ot.LangSys) {
+		if req, ok := ls.RequiredFeatureIndex(); ok {
+			if tag, ok := fl.TagAt(int(req)); ok {
+				refs[tag] = append(refs[tag], langSysRef{script, lang, int(req), true})
+			}
+		}
+		for inx := range ls.Range() {
+			if tag, ok := fl.TagAt(inx); ok {
+				refs[tag] = append(refs[tag], langSysRef{script, lang, inx, false})
+			}
+		}
+	}
+	for script, s := range lyt.ScriptGraph().Range() {
+		if dflt := s.DefaultLangSys(); dflt != nil {
+			addRefs(tagName(script), "dflt", dflt)
+		}
+		for lang, ls := range s.Range() {
+			addRefs(tagName(script), tagName(lang), ls)
+		}
+	}
+	for i := range fl.Len() {
+		tag, _ := fl.TagAt(i)
+		if refs[tag] == nil {
+			continue
+		}
+		name := tagName(tag)
+		d.printf("feature %s {\n", name)
+		script := ""
+		for _, ref := range refs[tag] {
+			if ref.script != script {
+				d.printf("    script %s;\n", ref.script)
+				script = ref.script
+			}
+			lang := "    language " + ref.lang
+			if ref.lang != "dflt" {
+				lang += " exclude_dflt"
+			}
+			if ref.required {
+				lang += " required"
+			}
+			d.printf("%s;\n", lang)
+			if ref.feature < len(features) {
+				f := features[ref.feature]
+				for j := range f.LookupCount() {
+					d.printf("        lookup %s;\n", lookupName(lyt, f.LookupIndex(j)))
+				}
+			}
+		}
+		d.printf("} %s;\n\n", name)
+		delete(refs, tag)
+	}
+}
+
+func sortedKeys[V any](m map[int]V) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,144 @@
+package otfea
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+var testGlyphNames = []string{".notdef", "f", "i", "f_i", "a", "a.alt", "b", "acutecomb"}
+
+func testNames(g ot.GlyphIndex) string {
+	return testGlyphNames[g]
+}
+
+// chainContextSubst encodes a chained contextual substitution subtable (GSUB
+// type 6, format 3) with an input glyph and a lookahead glyph, applying lookup
+// at the input glyph.
+func chainContextSubst(input, lookahead ot.GlyphIndex, lookup uint16) []byte {
+	b := []byte{0, 3, 0, 0, 0, 1, 0, 18, 0, 1, 0, 24, 0, 1, 0, 0, 0, byte(lookup)}
+	b = append(b, testfont.Coverage(input)...)
+	return append(b, testfont.Coverage(lookahead)...)
+}
+
+func TestDecompile(t *testing.T) {
+	b := testfont.New(len(testGlyphNames))
+	b.Map('f', 1).Map('i', 2).GlyphClass(7, 3)
+	gsub := b.GSUB()
+	calt := gsub.Lookup(ot.GSubLookupTypeChainingContext, 0, chainContextSubst(4, 6, 2))
+	liga := gsub.Lookup(ot.GSubLookupTypeLigature, ot.LOOKUP_FLAG_IGNORE_MARKS, testfont.LigatureSubst(
+		testfont.Ligature{Components: []ot.GlyphIndex{1, 2}, Glyph: 3}))
+	salt := gsub.Lookup(ot.GSubLookupTypeExtensionSubs, 0, testfont.Extension(ot.GSubLookupTypeSingle,
+		testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{4: 5})))
+	gsub.Script("DFLT", testfont.DefaultLang, gsub.Feature("calt", calt), gsub.Feature("liga", liga))
+	gsub.Script("latn", testfont.DefaultLang, 1)
+	gsub.Script("latn", "TRK", gsub.Feature("salt", salt))
+	gpos := b.GPOS()
+	gpos.Feature("kern", gpos.Lookup(ot.GPosLookupTypePair, 0, testfont.PairPos(
+		map[testfont.Pair]testfont.ValueRecord{{1, 4}: {XAdvance: -50}})))
+	gpos.Feature("mark", gpos.Lookup(ot.GPosLookupTypeSingle, 0, testfont.SinglePos(
+		testfont.ValueRecord{YPlacement: 10}, 7)))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	var sb strings.Builder
+	if err := Decompile(otf, &sb, testNames); err != nil {
+		t.Fatalf("decompilation failed: %v", err)
+	}
+	expected := `languagesystem DFLT dflt;
+languagesystem latn dflt;
+languagesystem latn TRK;
+
+@GDEF_Mark = [acutecomb];
+
+table GDEF {
+    GlyphClassDef , , @GDEF_Mark, ;
+} GDEF;
+
+# GSUB
+
+lookup GSUB_2 useExtension {
+    sub a by a.alt;
+} GSUB_2;
+
+lookup GSUB_0 {
+    sub a' lookup GSUB_2 b;
+} GSUB_0;
+
+lookup GSUB_1 {
+    lookupflag IgnoreMarks;
+    sub f i by f_i;
+} GSUB_1;
+
+feature calt {
+    script DFLT;
+    language dflt;
+        lookup GSUB_0;
+} calt;
+
+feature liga {
+    script DFLT;
+    language dflt;
+        lookup GSUB_1;
+    script latn;
+    language dflt;
+        lookup GSUB_1;
+} liga;
+
+feature salt {
+    script latn;
+    language TRK exclude_dflt;
+        lookup GSUB_2;
+} salt;
+
+# GPOS
+
+lookup GPOS_0 {
+    pos f a <0 0 -50 0>;
+} GPOS_0;
+
+lookup GPOS_1 {
+    pos acutecomb <0 10 0 0>;
+} GPOS_1;
+
+feature kern {
+    script DFLT;
+    language dflt;
+        lookup GPOS_0;
+    script latn;
+    language dflt;
+        lookup GPOS_0;
+} kern;
+
+feature mark {
+    script DFLT;
+    language dflt;
+        lookup GPOS_1;
+    script latn;
+    language dflt;
+        lookup GPOS_1;
+} mark;
+
+`
+	if sb.String() != expected {
+		t.Errorf("unexpected feature file:\n%s", sb.String())
+	}
+}
+
+func TestDefaultGlyphNames(t *testing.T) {
+	b := testfont.New(4)
+	b.Map('A', 1).Map(0x1F600, 2)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	names := DefaultGlyphNames(otf)
+	for g, expected := range []string{".notdef", "uni0041", "u1F600", "glyph00003"} {
+		if name := names(ot.GlyphIndex(g)); name != expected {
+			t.Errorf("expected name %q for glyph %d, have %q", expected, g, name)
+		}
+	}
+}
@@ -0,0 +1,43 @@
+/*
+Package otfea decompiles OpenType layout tables into Adobe feature file syntax.
+
+Feature files (.fea) are the human-readable source format from which font
+editors and compilers (AFDKO makeotf, fontTools feaLib, fontmake) build tables
+GSUB and GPOS. Package otfea goes the opposite way: it converts the typed lookup
+graph of a parsed font back into feature file text, which lets font engineers
+inspect the layout rules of a binary font:
+
+	otf, err := ot.Parse(data)
+	…
+	err = otfea.Decompile(otf, os.Stdout, nil)
+
+The decompiled feature file contains:
+
+▪︎ language systems of tables GSUB and GPOS,
+
+▪︎ glyph classes, mark attachment classes and mark glyph sets of table GDEF,
+
+▪︎ a named lookup block for every lookup, with named glyph classes for the class
+definitions of class-based subtables and mark classes for mark attachment,
+
+▪︎ a feature block for every feature tag, listing the lookups of the feature per
+script and language.
+
+Glyphs are named by a GlyphNames function; see DefaultGlyphNames.
+
+# Status
+
+Decompiled feature files are meant to be read, not to round-trip byte by byte.
+Lookups referenced from contextual rules are placed before the lookups referencing
+them, as feature file syntax requires, and contextual subtables are rendered as
+chaining rules. Device tables of value records and anchors, as well as feature
+parameters, are omitted.
+
+# License
+
+Governed by a 3-Clause BSD license. License file may be found in the root
+folder of this module.
+
+Copyright © Norbert Pillmayer <norbert@pillmayer.com>
+*/
+package otfea
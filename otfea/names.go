@@ -0,0 +1,86 @@
+package otfea
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// GlyphNames maps glyph indices to glyph names. Names have to be unique within
+// a font to produce a meaningful feature file.
+type GlyphNames func(ot.GlyphIndex) string
+
+// DefaultGlyphNames returns glyph names derived from the 'cmap' table of otf:
+// glyph 0 is named '.notdef', glyphs mapped from a code-point are named after
+// it, following the conventions of the Adobe Glyph List ('uni0041',
+// 'u1F600'), and all other glyphs are named by their index ('glyph00042').
+func DefaultGlyphNames(otf *ot.Font) GlyphNames {
+	var cmap *ot.CMapTable
+	if otf != nil {
+		cmap = otf.CMapTable()
+	}
+	return func(g ot.GlyphIndex) string {
+		if g == 0 {
+			return ".notdef"
+		}
+		if r, ok := cmap.RuneFor(g); ok {
+			if r <= 0xffff {
+				return fmt.Sprintf("uni%04X", r)
+			}
+			return fmt.Sprintf("u%05X", r)
+		}
+		return fmt.Sprintf("glyph%05d", g)
+	}
+}
+
+// glyphClass formats glyphs as an inline glyph class, e.g., '[a b c]'.
+func (d *decompiler) glyphClass(glyphs iter.Seq[ot.GlyphIndex]) string {
+	var sb strings.Builder
+	sb.WriteByte('[')
+	for g := range glyphs {
+		if sb.Len() > 1 {
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(d.names(g))
+	}
+	sb.WriteByte(']')
+	return sb.String()
+}
+
+// coverage formats the glyphs of a coverage table as a glyph name if it covers
+// a single glyph, and as an inline glyph class otherwise.
+func (d *decompiler) coverage(cov ot.Coverage) string {
+	var first ot.GlyphIndex
+	n := 0
+	for g := range cov.Glyphs() {
+		if n++; n > 1 {
+			break
+		}
+		first = g
+	}
+	if n == 1 {
+		return d.names(first)
+	}
+	return d.glyphClass(cov.Glyphs())
+}
+
+// glyphSequence formats glyphs as a sequence of glyph names.
+func (d *decompiler) glyphSequence(glyphs []ot.GlyphIndex) string {
+	return strings.Join(d.names.all(glyphs), " ")
+}
+
+// all returns the names of glyphs.
+func (names GlyphNames) all(glyphs []ot.GlyphIndex) []string {
+	s := make([]string, len(glyphs))
+	for i, g := range glyphs {
+		s[i] = names(g)
+	}
+	return s
+}
+
+// tagName returns a tag without trailing spaces, as written in feature files.
+func tagName(tag ot.Tag) string {
+	return strings.TrimRight(tag.String(), " ")
+}
@@ -0,0 +1,327 @@
+package otfea
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// subtable writes the rules of subtable j of the current lookup. Node has
+// been resolved from extension subtables.
+func (d *decompiler) subtable(lyt *layoutTable, j int, node *ot.LookupNode) {
+	if sc, ok := node.SequenceContext(); ok {
+		d.sequenceContext(lyt, j, sc)
+		return
+	}
+	if p := node.GSub; p != nil {
+		switch {
+		case p.SingleFmt1 != nil:
+			for g := range node.Coverage.Glyphs() {
+				d.statement("sub %s by %s;", d.names(g), d.names(ot.GlyphIndex(int(g)+int(p.SingleFmt1.DeltaGlyphID))))
+			}
+		case p.SingleFmt2 != nil:
+			d.forCoverage(node.Coverage, len(p.SingleFmt2.SubstituteGlyphIDs), func(g ot.GlyphIndex, i int) {
+				d.statement("sub %s by %s;", d.names(g), d.names(p.SingleFmt2.SubstituteGlyphIDs[i]))
+			})
+		case p.MultipleFmt1 != nil:
+			d.forCoverage(node.Coverage, len(p.MultipleFmt1.Sequences), func(g ot.GlyphIndex, i int) {
+				seq := p.MultipleFmt1.Sequences[i]
+				if len(seq) == 0 {
+					d.statement("sub %s by NULL;", d.names(g))
+					return
+				}
+				d.statement("sub %s by %s;", d.names(g), d.glyphSequence(seq))
+			})
+		case p.AlternateFmt1 != nil:
+			d.forCoverage(node.Coverage, len(p.AlternateFmt1.Alternates), func(g ot.GlyphIndex, i int) {
+				d.statement("sub %s from [%s];", d.names(g), d.glyphSequence(p.AlternateFmt1.Alternates[i]))
+			})
+		case p.LigatureFmt1 != nil:
+			d.forCoverage(node.Coverage, len(p.LigatureFmt1.LigatureSets), func(g ot.GlyphIndex, i int) {
+				for _, lig := range p.LigatureFmt1.LigatureSets[i] {
+					components := append([]ot.GlyphIndex{g}, lig.Components...)
+					d.statement("sub %s by %s;", d.glyphSequence(components), d.names(lig.Ligature))
+				}
+			})
+		case p.ReverseChainingFmt1 != nil:
+			d.reverseChaining(node, p.ReverseChainingFmt1)
+		default:
+			d.statement("# subtable %d: unsupported GSUB subtable (type %d, format %d)", j, node.LookupType, node.Format)
+		}
+		return
+	}
+	if p := node.GPos; p != nil {
+		switch {
+		case p.SingleFmt1 != nil:
+			d.statement("pos %s %s;", d.coverage(node.Coverage), valueRecord(p.SingleFmt1.Value))
+		case p.SingleFmt2 != nil:
+			d.forCoverage(node.Coverage, len(p.SingleFmt2.Values), func(g ot.GlyphIndex, i int) {
+				d.statement("pos %s %s;", d.names(g), valueRecord(p.SingleFmt2.Values[i]))
+			})
+		case p.PairFmt1 != nil:
+			d.forCoverage(node.Coverage, len(p.PairFmt1.PairSets), func(g ot.GlyphIndex, i int) {
+				for _, pair := range p.PairFmt1.PairSets[i] {
+					d.pair(d.names(g), d.names(ot.GlyphIndex(pair.SecondGlyph)), pair.Value1, pair.Value2,
+						p.PairFmt1.ValueFormat2 != 0)
+				}
+			})
+		case p.PairFmt2 != nil:
+			d.classPairs(j, node, p.PairFmt2)
+		case p.CursiveFmt1 != nil:
+			d.forCoverage(node.Coverage, len(p.CursiveFmt1.Entries), func(g ot.GlyphIndex, i int) {
+				e := p.CursiveFmt1.Entries[i]
+				d.statement("pos cursive %s %s %s;", d.names(g), anchor(e.Entry), anchor(e.Exit))
+			})
+		case p.MarkToBaseFmt1 != nil:
+			q := p.MarkToBaseFmt1
+			classes := d.markClasses(j, node.Coverage, q.MarkRecords)
+			d.forCoverage(q.BaseCoverage, len(q.BaseRecords), func(g ot.GlyphIndex, i int) {
+				if marks := markAnchors(q.BaseRecords[i].Anchors, classes); marks != "" {
+					d.statement("pos base %s %s;", d.names(g), marks)
+				}
+			})
+		case p.MarkToLigatureFmt1 != nil:
+			q := p.MarkToLigatureFmt1
+			classes := d.markClasses(j, node.Coverage, q.MarkRecords)
+			d.forCoverage(q.LigatureCoverage, len(q.LigatureRecords), func(g ot.GlyphIndex, i int) {
+				var components []string
+				for _, anchors := range q.LigatureRecords[i].ComponentAnchors {
+					marks := markAnchors(anchors, classes)
+					if marks == "" {
+						marks = anchor(nil)
+					}
+					components = append(components, marks)
+				}
+				if len(components) > 0 {
+					d.statement("pos ligature %s %s;", d.names(g), strings.Join(components, " ligComponent "))
+				}
+			})
+		case p.MarkToMarkFmt1 != nil:
+			q := p.MarkToMarkFmt1
+			classes := d.markClasses(j, node.Coverage, q.Mark1Records)
+			d.forCoverage(q.Mark2Coverage, len(q.Mark2Records), func(g ot.GlyphIndex, i int) {
+				if marks := markAnchors(q.Mark2Records[i].Anchors, classes); marks != "" {
+					d.statement("pos mark %s %s;", d.names(g), marks)
+				}
+			})
+		default:
+			d.statement("# subtable %d: unsupported GPOS subtable (type %d, format %d)", j,
+				ot.GPosLookupType(node.LookupType), node.Format)
+		}
+	}
+}
+
+// forCoverage calls f for every glyph of a coverage table together with its
+// coverage index, as long as the index is less than n, the number of records
+// of the subtable.
+func (d *decompiler) forCoverage(cov ot.Coverage, n int, f func(ot.GlyphIndex, int)) {
+	i := 0
+	for g := range cov.Glyphs() {
+		if i >= n {
+			return
+		}
+		f(g, i)
+		i++
+	}
+}
+
+// --- GSUB -------------------------------------------------------------------
+
+// reverseChaining writes the rule of a reverse chaining single substitution
+// subtable. Backtrack coverages are stored in reverse order.
+func (d *decompiler) reverseChaining(node *ot.LookupNode, p *ot.GSubReverseChainingFmt1Payload) {
+	var rule []string
+	for i := len(p.BacktrackCoverages) - 1; i >= 0; i-- {
+		rule = append(rule, d.coverage(p.BacktrackCoverages[i]))
+	}
+	rule = append(rule, d.coverage(node.Coverage)+"'")
+	for _, cov := range p.LookaheadCoverages {
+		rule = append(rule, d.coverage(cov))
+	}
+	subst := d.glyphSequence(p.SubstituteGlyphIDs)
+	if len(p.SubstituteGlyphIDs) != 1 {
+		subst = "[" + subst + "]"
+	}
+	d.statement("rsub %s by %s;", strings.Join(rule, " "), subst)
+}
+
+// --- GPOS -------------------------------------------------------------------
+
+// valueRecord formats the design-unit values of a value record.
+func valueRecord(v ot.ValueRecord) string {
+	return fmt.Sprintf("<%d %d %d %d>", v.XPlacement, v.YPlacement, v.XAdvance, v.YAdvance)
+}
+
+// anchor formats an anchor, or a NULL anchor for a nil anchor.
+func anchor(a *ot.Anchor) string {
+	if a == nil {
+		return "<anchor NULL>"
+	}
+	if a.Format == ot.AnchorFormat2 {
+		return fmt.Sprintf("<anchor %d %d contourpoint %d>", a.XCoordinate, a.YCoordinate, a.AnchorPoint)
+	}
+	return fmt.Sprintf("<anchor %d %d>", a.XCoordinate, a.YCoordinate)
+}
+
+// pair writes a pair positioning rule. The value record of the second glyph is
+// only written if the subtable has one.
+func (d *decompiler) pair(first, second string, v1, v2 ot.ValueRecord, hasValue2 bool) {
+	if hasValue2 {
+		d.statement("pos %s %s %s %s;", first, valueRecord(v1), second, valueRecord(v2))
+		return
+	}
+	d.statement("pos %s %s %s;", first, second, valueRecord(v1))
+}
+
+// classPairs writes the rules of a class-based pair positioning subtable, one
+// for every pair of classes with non-zero adjustments.
+func (d *decompiler) classPairs(j int, node *ot.LookupNode, p *ot.GPosPairFmt2Payload) {
+	first, second := newClassSets(&p.ClassDef1), newClassSets(&p.ClassDef2)
+	covered := node.Coverage.GlyphSet()
+	var zero ot.ValueRecord
+	for c1, records := range p.ClassRecords {
+		for c2, rec := range records {
+			if rec.Value1 == zero && rec.Value2 == zero {
+				continue
+			}
+			g1 := first.glyphs(c1, covered)
+			if g1.IsEmpty() {
+				break
+			}
+			d.pair(d.class(j, "first", c1, g1), d.class(j, "second", c2, second.glyphs(c2, d.allGlyphs)),
+				rec.Value1, rec.Value2, p.ValueFormat2 != 0)
+		}
+	}
+}
+
+// markClasses writes a mark class definition for every mark of a mark
+// attachment subtable and returns the names of the mark classes.
+func (d *decompiler) markClasses(j int, cov ot.Coverage, marks []ot.GPosMarkAttachRecord) map[int]string {
+	classes := make(map[int]string)
+	d.forCoverage(cov, len(marks), func(g ot.GlyphIndex, i int) {
+		class := int(marks[i].Class)
+		name := fmt.Sprintf("@%s_%d_mark%d", d.lookup, j, class)
+		classes[class] = name
+		d.define("markClass %s %s %s;", d.names(g), anchor(marks[i].Anchor), name)
+	})
+	return classes
+}
+
+// markAnchors formats the anchors of a base, ligature component or mark glyph
+// for the mark classes they attach, or returns an empty string if there are
+// none.
+func markAnchors(anchors []*ot.Anchor, classes map[int]string) string {
+	var parts []string
+	for class, a := range anchors {
+		if name, ok := classes[class]; ok && a != nil {
+			parts = append(parts, anchor(a)+" mark "+name)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// --- Contextual rules -------------------------------------------------------
+
+// sequenceContext writes the rules of a contextual or chained contextual
+// subtable. Rules without lookup records are written as 'ignore' rules.
+func (d *decompiler) sequenceContext(lyt *layoutTable, j int, sc *ot.SequenceContext) {
+	var back, input, ahead *classSets
+	if sc.Format == 2 {
+		back, input, ahead = newClassSets(&sc.BacktrackClassDef), newClassSets(&sc.InputClassDef),
+			newClassSets(&sc.LookaheadClassDef)
+	}
+	covered := sc.Coverage.GlyphSet()
+	for _, rule := range sc.Rules {
+		var backtrack, in, lookahead []string
+		switch sc.Format {
+		case 1:
+			backtrack, in, lookahead = d.names.all(rule.Backtrack), d.names.all(rule.Input), d.names.all(rule.Lookahead)
+		case 2:
+			for _, c := range rule.BacktrackClasses {
+				backtrack = append(backtrack, d.class(j, "back", int(c), back.glyphs(int(c), d.allGlyphs)))
+			}
+			for i, c := range rule.InputClasses {
+				if i == 0 {
+					in = append(in, d.class(j, "first", int(c), input.glyphs(int(c), covered)))
+				} else {
+					in = append(in, d.class(j, "in", int(c), input.glyphs(int(c), d.allGlyphs)))
+				}
+			}
+			for _, c := range rule.LookaheadClasses {
+				lookahead = append(lookahead, d.class(j, "ahead", int(c), ahead.glyphs(int(c), d.allGlyphs)))
+			}
+		case 3:
+			backtrack, in, lookahead = d.coverages(rule.BacktrackCoverages), d.coverages(rule.InputCoverages),
+				d.coverages(rule.LookaheadCoverages)
+		}
+		for i := range in {
+			in[i] += "'"
+		}
+		for _, rec := range rule.Records {
+			if i := int(rec.SequenceIndex); i < len(in) {
+				in[i] += " lookup " + lookupName(lyt, int(rec.LookupListIndex))
+			}
+		}
+		ignore := ""
+		if len(rule.Records) == 0 {
+			ignore = "ignore "
+		}
+		seq := append(append(backtrack, in...), lookahead...)
+		d.statement("%s%s %s;", ignore, lyt.rule, strings.Join(seq, " "))
+	}
+}
+
+func (d *decompiler) coverages(covs []ot.Coverage) []string {
+	s := make([]string, len(covs))
+	for i, cov := range covs {
+		s[i] = d.coverage(cov)
+	}
+	return s
+}
+
+// --- Classes ----------------------------------------------------------------
+
+// class returns the name of a glyph class for class c of subtable j of the
+// current lookup, defining it in front of the lookup on first use. Kind tells
+// the role of the class in the rules of the subtable.
+func (d *decompiler) class(j int, kind string, c int, glyphs *ot.GlyphSet) string {
+	name := fmt.Sprintf("@%s_%d_%s%d", d.lookup, j, kind, c)
+	if !d.classes[name] {
+		d.classes[name] = true
+		d.define("%s = %s;", name, d.glyphClass(glyphs.Glyphs()))
+	}
+	return name
+}
+
+// classSets holds the glyphs of the classes of a class definition table.
+type classSets struct {
+	sets     map[int]*ot.GlyphSet
+	assigned ot.GlyphSet // glyphs with a non-zero class
+}
+
+func newClassSets(cdef *ot.ClassDefinitions) *classSets {
+	cs := &classSets{sets: make(map[int]*ot.GlyphSet)}
+	for g, c := range cdef.Classes() {
+		if cs.sets[c] == nil {
+			cs.sets[c] = &ot.GlyphSet{}
+		}
+		cs.sets[c].Add(g)
+		cs.assigned.Add(g)
+	}
+	return cs
+}
+
+// glyphs returns the glyphs of class c within a set of glyphs. Class 0
+// contains all glyphs of the set which are not assigned to another class.
+// A nil set stands for all glyphs; class 0 is empty then, as the number of
+// glyphs of the font is unknown.
+func (cs *classSets) glyphs(c int, within *ot.GlyphSet) *ot.GlyphSet {
+	if c == 0 {
+		return within.Difference(&cs.assigned)
+	}
+	if within == nil {
+		return cs.sets[c]
+	}
+	return cs.sets[c].Intersection(within)
+}
@@ -0,0 +1,228 @@
+package otfind
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Weight is a font weight, as in OS/2.usWeightClass.
+type Weight int
+
+// Common font weights.
+const (
+	Thin       Weight = 100
+	ExtraLight Weight = 200
+	Light      Weight = 300
+	Regular    Weight = 400
+	Medium     Weight = 500
+	SemiBold   Weight = 600
+	Bold       Weight = 700
+	ExtraBold  Weight = 800
+	Black      Weight = 900
+)
+
+// FontInfo describes a font file found during a scan.
+type FontInfo struct {
+	Path     string // path of the font file
+	Index    int    // index of the font within a font collection, 0 for single fonts
+	Family   string // typographic family name, e.g. "Source Sans 3"
+	Style    string // typographic subfamily name, e.g. "Semibold Italic"
+	FullName string // full font name
+	Weight   Weight // OS/2 weight class
+	Width    int    // OS/2 width class, 1 (ultra-condensed) … 9 (ultra-expanded), 5 = normal
+	Italic   bool   // italic or oblique
+}
+
+func (fi FontInfo) String() string {
+	return fmt.Sprintf("%s [%s, %d%s] %s", fi.Family, fi.Style, fi.Weight,
+		map[bool]string{true: ", italic"}[fi.Italic], fi.Path)
+}
+
+// Load reads and parses the font file. For font collections, the font at
+// position Index of the collection is returned.
+func (fi FontInfo) Load(options ...ot.ParseOption) (*ot.Font, error) {
+	data, err := os.ReadFile(fi.Path)
+	if err != nil {
+		return nil, err
+	}
+	if !ot.IsCollection(data) {
+		return ot.Parse(data, options...)
+	}
+	fonts, err := ot.ParseCollection(data, options...)
+	if err != nil {
+		return nil, err
+	}
+	if fi.Index < 0 || fi.Index >= len(fonts) {
+		return nil, fmt.Errorf("no font #%d in collection %s", fi.Index, fi.Path)
+	}
+	return fonts[fi.Index], nil
+}
+
+// Catalog holds the fonts found in a set of directories.
+type Catalog struct {
+	fonts []FontInfo
+}
+
+// fontExtensions are the file extensions of font files considered by Scan.
+var fontExtensions = []string{".otf", ".ttf", ".otc", ".ttc"}
+
+// Scan walks directories dirs recursively and collects information about the
+// font files found. Files which cannot be read or are not OpenType fonts are
+// skipped. Scan returns an error only if none of the directories can be read.
+func Scan(dirs ...string) (*Catalog, error) {
+	cat := &Catalog{}
+	var errs []error
+	readable := 0
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if path == dir {
+					return err
+				}
+				return nil // skip unreadable sub-directories
+			}
+			if d.IsDir() || !slices.Contains(fontExtensions, strings.ToLower(filepath.Ext(path))) {
+				return nil
+			}
+			infos, err := scanFile(path)
+			if err != nil {
+				tracer().Debugf("skipping font file %s: %v", path, err)
+				return nil
+			}
+			cat.fonts = append(cat.fonts, infos...)
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		readable++
+	}
+	if readable == 0 && len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	slices.SortFunc(cat.fonts, func(a, b FontInfo) int {
+		if c := strings.Compare(strings.ToLower(a.Family), strings.ToLower(b.Family)); c != 0 {
+			return c
+		}
+		if a.Weight != b.Weight {
+			return int(a.Weight - b.Weight)
+		}
+		if c := strings.Compare(a.Path, b.Path); c != 0 {
+			return c
+		}
+		return a.Index - b.Index
+	})
+	return cat, nil
+}
+
+func scanFile(path string) ([]FontInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	infos, err := readFaceInfos(f)
+	for i := range infos {
+		infos[i].Path = path
+	}
+	return infos, err
+}
+
+// Fonts returns all fonts of the catalog, ordered by family and weight.
+func (cat *Catalog) Fonts() []FontInfo {
+	return slices.Clone(cat.fonts)
+}
+
+// Families returns the family names of the catalog, in alphabetical order.
+func (cat *Catalog) Families() []string {
+	var families []string
+	for _, fi := range cat.fonts {
+		if n := len(families); n == 0 || !strings.EqualFold(families[n-1], fi.Family) {
+			families = append(families, fi.Family)
+		}
+	}
+	return families
+}
+
+// Family returns the fonts of a family, with the family name compared
+// case-insensitively.
+func (cat *Catalog) Family(family string) []FontInfo {
+	var faces []FontInfo
+	for _, fi := range cat.fonts {
+		if strings.EqualFold(fi.Family, family) {
+			faces = append(faces, fi)
+		}
+	}
+	return faces
+}
+
+// Query describes a font to look for.
+type Query struct {
+	Family string // family name, compared case-insensitively
+	Weight Weight // preferred weight; 0 means Regular
+	Italic bool   // prefer italic/oblique fonts
+	Width  int    // preferred width class; 0 means normal
+}
+
+// Match returns the font of the catalog which best fits query q. The family must
+// match; among the fonts of a family, fonts with matching slant are preferred,
+// then fonts with the closest width, then fonts with the closest weight, following
+// the CSS font matching algorithm in spirit. If no font of the family is found,
+// Match returns false.
+func (cat *Catalog) Match(q Query) (FontInfo, bool) {
+	faces := cat.Family(q.Family)
+	if len(faces) == 0 {
+		return FontInfo{}, false
+	}
+	if q.Weight == 0 {
+		q.Weight = Regular
+	}
+	if q.Width == 0 {
+		q.Width = 5
+	}
+	best := slices.MinFunc(faces, func(a, b FontInfo) int {
+		return matchDistance(a, q) - matchDistance(b, q)
+	})
+	return best, true
+}
+
+// matchDistance rates how far off a font is from a query. Slant mismatches weigh
+// more than width differences, which weigh more than weight differences.
+func matchDistance(fi FontInfo, q Query) int {
+	d := 0
+	if fi.Italic != q.Italic {
+		d += 1_000_000
+	}
+	d += 10_000 * abs(fi.Width-q.Width)
+	w := int(fi.Weight - q.Weight)
+	if w < 0 && q.Weight > Medium { // for bold requests, prefer bolder over lighter
+		w = -2 * w
+	} else if w > 0 && q.Weight <= Medium { // for light requests, prefer lighter over bolder
+		w = 2 * w
+	}
+	return d + abs(w)
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// Find matches query q and loads the resulting font.
+func (cat *Catalog) Find(q Query, options ...ot.ParseOption) (*ot.Font, error) {
+	fi, ok := cat.Match(q)
+	if !ok {
+		return nil, fmt.Errorf("no font found for family %q", q.Family)
+	}
+	return fi.Load(options...)
+}
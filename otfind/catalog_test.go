@@ -0,0 +1,152 @@
+package otfind
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/schuko/tracing/gotestingadapter"
+)
+
+func scanTestdata(t *testing.T) *Catalog {
+	t.Helper()
+	cat, err := Scan(filepath.Join("..", "testdata", "fonts"))
+	if err != nil {
+		t.Fatalf("cannot scan test fonts: %v", err)
+	}
+	return cat
+}
+
+func TestScanTestdata(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	cat := scanTestdata(t)
+	families := cat.Families()
+	want := []string{"Calibri", "Gentium Plus", "Go", "Go Mono"}
+	if len(families) != len(want) {
+		t.Fatalf("expected families %v, have %v", want, families)
+	}
+	for i := range want {
+		if families[i] != want[i] {
+			t.Errorf("expected family #%d to be %q, have %q", i, want[i], families[i])
+		}
+	}
+	if n := len(cat.Family("go")); n != 5 {
+		t.Errorf("expected 5 fonts of family 'Go', have %d", n)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	cat := scanTestdata(t)
+	for _, tc := range []struct {
+		q    Query
+		file string
+	}{
+		{Query{Family: "Go", Weight: Bold}, "Go-Bold.otf"},
+		{Query{Family: "Go", Weight: Bold, Italic: true}, "Go-Bold-Italic.otf"},
+		{Query{Family: "Go", Italic: true}, "Go-Italic.otf"},
+		{Query{Family: "Go", Weight: Light, Italic: true}, "Go-Italic.otf"},
+		{Query{Family: "calibri", Weight: Black}, "Calibri.ttf"},
+	} {
+		fi, ok := cat.Match(tc.q)
+		if !ok {
+			t.Errorf("no match for %+v", tc.q)
+			continue
+		}
+		if filepath.Base(fi.Path) != tc.file {
+			t.Errorf("expected %+v to match %s, have %s", tc.q, tc.file, fi.Path)
+		}
+	}
+	if _, ok := cat.Match(Query{Family: "Helvetica"}); ok {
+		t.Errorf("did not expect a match for family 'Helvetica'")
+	}
+	otf, err := cat.Find(Query{Family: "Gentium Plus"})
+	if err != nil || otf == nil {
+		t.Fatalf("cannot load 'Gentium Plus': %v", err)
+	}
+}
+
+func TestScanSkipsInvalidFiles(t *testing.T) {
+	dir := t.TempDir()
+	b := testfont.New(3)
+	b.FamilyName = "Synthetic Sans"
+	if err := os.WriteFile(filepath.Join(dir, "synth.otf"), b.Bytes(), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "broken.ttf"), []byte("not a font"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "readme.txt"), []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cat, err := Scan(dir, filepath.Join(dir, "does-not-exist"))
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	fonts := cat.Fonts()
+	if len(fonts) != 1 || fonts[0].Family != "Synthetic Sans" || fonts[0].Style != "Regular" {
+		t.Errorf("expected to find synthetic font only, have %v", fonts)
+	}
+	if _, err := Scan(filepath.Join(dir, "does-not-exist")); err == nil {
+		t.Errorf("expected scan of missing directory to fail")
+	}
+}
+
+// buildCollection assembles a font collection from the binaries of single
+// fonts, relocating their table offsets.
+func buildCollection(fonts ...[]byte) []byte {
+	out := make([]byte, 12+4*len(fonts))
+	copy(out, "ttcf")
+	binary.BigEndian.PutUint16(out[4:], 1)
+	binary.BigEndian.PutUint32(out[8:], uint32(len(fonts)))
+	for i, font := range fonts {
+		base := uint32(len(out))
+		binary.BigEndian.PutUint32(out[12+4*i:], base)
+		font = slices.Clone(font)
+		for j := range int(binary.BigEndian.Uint16(font[4:6])) {
+			rec := font[12+16*j:]
+			binary.BigEndian.PutUint32(rec[8:], binary.BigEndian.Uint32(rec[8:])+base)
+		}
+		out = append(out, font...)
+	}
+	return out
+}
+
+func TestScanCollection(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	sans, serif := testfont.New(3), testfont.New(3)
+	sans.FamilyName, serif.FamilyName = "Synthetic Sans", "Synthetic Serif"
+	sans.Map('a', 1)
+	serif.Map('a', 2)
+	dir := t.TempDir()
+	ttc := buildCollection(sans.Bytes(), serif.Bytes())
+	if err := os.WriteFile(filepath.Join(dir, "synth.ttc"), ttc, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cat, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("scan failed: %v", err)
+	}
+	fonts := cat.Fonts()
+	if len(fonts) != 2 || fonts[0].Family != "Synthetic Sans" || fonts[1].Family != "Synthetic Serif" ||
+		fonts[0].Index != 0 || fonts[1].Index != 1 {
+		t.Fatalf("expected both members of the collection to be found, have %v", fonts)
+	}
+	otf, err := cat.Find(Query{Family: "synthetic serif"}, ot.IsTestfont)
+	if err != nil {
+		t.Fatalf("cannot load member of collection: %v", err)
+	}
+	if g := otf.CMapTable().GlyphIndexMap.Lookup('a'); g != 2 {
+		t.Errorf("expected second member of the collection to be loaded, maps 'a' to %d", g)
+	}
+}
@@ -0,0 +1,168 @@
+package otfind
+
+import (
+	"encoding/xml"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// SystemDirs returns the font directories of the current platform. Directories
+// which do not exist are omitted.
+func SystemDirs() []string {
+	home, _ := os.UserHomeDir()
+	var dirs []string
+	switch runtime.GOOS {
+	case "windows":
+		windir := os.Getenv("WINDIR")
+		if windir == "" {
+			windir = `C:\Windows`
+		}
+		dirs = append(dirs, filepath.Join(windir, "Fonts"))
+		if local := os.Getenv("LOCALAPPDATA"); local != "" {
+			dirs = append(dirs, filepath.Join(local, "Microsoft", "Windows", "Fonts"))
+		}
+	case "darwin", "ios":
+		dirs = append(dirs, "/System/Library/Fonts", "/Library/Fonts")
+		if home != "" {
+			dirs = append(dirs, filepath.Join(home, "Library", "Fonts"))
+		}
+	default:
+		dirs = append(dirs, fontconfigDirs("/etc/fonts/fonts.conf", home)...)
+		dirs = append(dirs, xdgFontDirs(home)...)
+	}
+	return existingDirs(dirs)
+}
+
+// xdgFontDirs returns the font directories of the XDG base directory specification,
+// plus the legacy ~/.fonts.
+func xdgFontDirs(home string) []string {
+	var dirs []string
+	dataHome := os.Getenv("XDG_DATA_HOME")
+	if dataHome == "" && home != "" {
+		dataHome = filepath.Join(home, ".local", "share")
+	}
+	if dataHome != "" {
+		dirs = append(dirs, filepath.Join(dataHome, "fonts"))
+	}
+	dataDirs := os.Getenv("XDG_DATA_DIRS")
+	if dataDirs == "" {
+		dataDirs = "/usr/local/share:/usr/share"
+	}
+	for _, d := range filepath.SplitList(dataDirs) {
+		dirs = append(dirs, filepath.Join(d, "fonts"))
+	}
+	if home != "" {
+		dirs = append(dirs, filepath.Join(home, ".fonts"))
+	}
+	return dirs
+}
+
+// fontconfigDirs reads the <dir> entries of a fontconfig configuration file,
+// following <include> elements. Errors are ignored, as fontconfig does.
+func fontconfigDirs(conf, home string) []string {
+	var dirs []string
+	seen := make(map[string]bool)
+	var read func(path string, depth int)
+	read = func(path string, depth int) {
+		if depth > 8 || seen[path] {
+			return
+		}
+		seen[path] = true
+		info, err := os.Stat(path)
+		if err != nil {
+			return
+		}
+		if info.IsDir() { // included directories hold *.conf files
+			matches, _ := filepath.Glob(filepath.Join(path, "*.conf"))
+			for _, m := range matches {
+				read(m, depth+1)
+			}
+			return
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return
+		}
+		dir, includes := parseFontconfig(data)
+		base := filepath.Dir(path)
+		for _, d := range dir {
+			dirs = append(dirs, d.expand(base, home))
+		}
+		for _, inc := range includes {
+			read(inc.expand(base, home), depth+1)
+		}
+	}
+	read(conf, 0)
+	return dirs
+}
+
+// fontconfigPath is a <dir> or <include> entry of a fontconfig configuration.
+type fontconfigPath struct {
+	Prefix string `xml:"prefix,attr"`
+	Path   string `xml:",chardata"`
+}
+
+// parseFontconfig extracts the <dir> and <include> entries of a fontconfig
+// configuration file.
+func parseFontconfig(data []byte) (dirs []fontconfigPath, includes []fontconfigPath) {
+	var conf struct {
+		Dirs     []fontconfigPath `xml:"dir"`
+		Includes []fontconfigPath `xml:"include"`
+	}
+	dec := xml.NewDecoder(strings.NewReader(string(data)))
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	if err := dec.Decode(&conf); err != nil {
+		tracer().Debugf("cannot parse fontconfig file: %v", err)
+		return nil, nil
+	}
+	trim := func(paths []fontconfigPath) []fontconfigPath {
+		var out []fontconfigPath
+		for _, p := range paths {
+			if p.Path = strings.TrimSpace(p.Path); p.Path != "" {
+				out = append(out, p)
+			}
+		}
+		return out
+	}
+	return trim(conf.Dirs), trim(conf.Includes)
+}
+
+// expand resolves '~' and relative paths of a fontconfig entry. Relative paths
+// with prefix "xdg" are relative to $XDG_DATA_HOME (for <dir>), others are
+// relative to the directory of the configuration file.
+func (p fontconfigPath) expand(base, home string) string {
+	switch {
+	case p.Path == "~":
+		return home
+	case strings.HasPrefix(p.Path, "~/"):
+		return filepath.Join(home, p.Path[2:])
+	case filepath.IsAbs(p.Path):
+		return p.Path
+	case p.Prefix == "xdg":
+		dataHome := os.Getenv("XDG_DATA_HOME")
+		if dataHome == "" {
+			dataHome = filepath.Join(home, ".local", "share")
+		}
+		return filepath.Join(dataHome, p.Path)
+	}
+	return filepath.Join(base, p.Path)
+}
+
+func existingDirs(dirs []string) []string {
+	var out []string
+	seen := make(map[string]bool)
+	for _, d := range dirs {
+		d = filepath.Clean(d)
+		if seen[d] {
+			continue
+		}
+		seen[d] = true
+		if info, err := os.Stat(d); err == nil && info.IsDir() {
+			out = append(out, d)
+		}
+	}
+	return out
+}
@@ -0,0 +1,49 @@
+package otfind
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFontconfigDirs(t *testing.T) {
+	dir := t.TempDir()
+	confd := filepath.Join(dir, "conf.d")
+	if err := os.Mkdir(confd, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	main := `<?xml version="1.0"?>
+<!DOCTYPE fontconfig SYSTEM "urn:fontconfig:fonts.dtd">
+<fontconfig>
+	<dir>/usr/share/fonts</dir>
+	<dir prefix="xdg">fonts</dir>
+	<dir>~/.fonts</dir>
+	<include ignore_missing="yes">conf.d</include>
+</fontconfig>`
+	extra := `<fontconfig><dir>/opt/fonts</dir></fontconfig>`
+	if err := os.WriteFile(filepath.Join(dir, "fonts.conf"), []byte(main), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(confd, "10-extra.conf"), []byte(extra), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("XDG_DATA_HOME", "")
+	dirs := fontconfigDirs(filepath.Join(dir, "fonts.conf"), "/home/user")
+	want := []string{"/usr/share/fonts", "/home/user/.local/share/fonts", "/home/user/.fonts", "/opt/fonts"}
+	if len(dirs) != len(want) {
+		t.Fatalf("expected directories %v, have %v", want, dirs)
+	}
+	for i := range want {
+		if dirs[i] != want[i] {
+			t.Errorf("expected directory #%d to be %q, have %q", i, want[i], dirs[i])
+		}
+	}
+}
+
+func TestSystemDirsExist(t *testing.T) {
+	for _, d := range SystemDirs() {
+		if info, err := os.Stat(d); err != nil || !info.IsDir() {
+			t.Errorf("system font directory %q does not exist", d)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Package otfind locates fonts installed on a system.
+
+Package otfind scans the platform's font directories for OpenType and TrueType
+font files and answers family/style queries, returning parsed ot.Font handles.
+Applications using package ot thus do not need a separate font-locator dependency.
+
+Scanning is cheap: for each font file, only the table directory and the tables
+'name' and 'OS/2' are read. Fonts are parsed in full only when loaded.
+
+	cat, err := otfind.Scan(otfind.SystemDirs()...)
+	…
+	otf, err := cat.Find(otfind.Query{Family: "Noto Sans", Weight: otfind.Bold})
+
+Font directories are taken from the following places:
+
+▪︎ Linux and other Unix systems: fontconfig configuration (/etc/fonts/fonts.conf and
+files included from it), the XDG data directories and ~/.fonts
+
+▪︎ Windows: %WINDIR%\Fonts and the per-user font folder in %LOCALAPPDATA%. The
+font registry is not consulted, as fonts registered there live in these folders.
+
+▪︎ macOS: /System/Library/Fonts, /Library/Fonts and ~/Library/Fonts
+
+Font collections (TTC/OTC) are scanned as well, with one catalog entry per
+member font. Loading such an entry parses the collection with
+ot.ParseCollection and returns the member font.
+
+# License
+
+Governed by a 3-Clause BSD license. License file may be found in the root
+folder of this module.
+
+Copyright © Norbert Pillmayer <norbert@pillmayer.com>
+*/
+package otfind
+
+import (
+	"github.com/npillmayer/schuko/tracing"
+)
+
+// tracer writes to trace with key 'tyse.fonts'
+func tracer() tracing.Trace {
+	return tracing.Select("tyse.fonts")
+}
@@ -0,0 +1,171 @@
+package otfind
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"unicode/utf16"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Name IDs used for font matching.
+const (
+	nameFamily          = 1
+	nameSubfamily       = 2
+	nameFullName        = 4
+	nameTypoFamily      = 16
+	nameTypoSubfamily   = 17
+	maxHeaderTableBytes = 1 << 20 // sanity limit for 'name' and 'OS/2'
+	maxCollectionFonts  = 1 << 12 // sanity limit for the number of fonts in a collection
+)
+
+// readFaceInfos reads the table directories of a font file and decodes the
+// tables 'name' and 'OS/2' of every font in it, without reading the rest of
+// the file. Font collections (TTC/OTC) result in one entry per member font.
+func readFaceInfos(r io.ReaderAt) ([]FontInfo, error) {
+	var header [12]byte
+	if _, err := r.ReadAt(header[:], 0); err != nil {
+		return nil, err
+	}
+	if string(header[:4]) != "ttcf" {
+		info, err := readFaceInfo(r, 0)
+		if err != nil {
+			return nil, err
+		}
+		return []FontInfo{info}, nil
+	}
+	count := binary.BigEndian.Uint32(header[8:12])
+	if count == 0 || count > maxCollectionFonts {
+		return nil, fmt.Errorf("invalid number of fonts in collection: %d", count)
+	}
+	offsets := make([]byte, 4*count)
+	if _, err := r.ReadAt(offsets, 12); err != nil {
+		return nil, fmt.Errorf("cannot read collection header: %w", err)
+	}
+	infos := make([]FontInfo, count)
+	for i := range infos {
+		info, err := readFaceInfo(r, int64(binary.BigEndian.Uint32(offsets[4*i:])))
+		if err != nil {
+			return nil, fmt.Errorf("font #%d of collection: %w", i, err)
+		}
+		info.Index = i
+		infos[i] = info
+	}
+	return infos, nil
+}
+
+// readFaceInfo reads the table directory located at offset off of a font file
+// and decodes the tables 'name' and 'OS/2'.
+func readFaceInfo(r io.ReaderAt, off int64) (FontInfo, error) {
+	var info FontInfo
+	var header [12]byte
+	if _, err := r.ReadAt(header[:], off); err != nil {
+		return info, err
+	}
+	switch binary.BigEndian.Uint32(header[:4]) {
+	case ot.FontTypeTrueType, ot.FontTypeCFF, ot.FontTypeAppleTrue, ot.FontTypeAppleTyp1:
+	default:
+		return info, errors.New("not an OpenType font")
+	}
+	numTables := int(binary.BigEndian.Uint16(header[4:6]))
+	dir := make([]byte, 16*numTables)
+	if _, err := r.ReadAt(dir, off+12); err != nil {
+		return info, fmt.Errorf("cannot read table directory: %w", err)
+	}
+	var name, os2 []byte
+	for i := range numTables {
+		rec := dir[16*i : 16*(i+1)]
+		tag := string(rec[:4])
+		if tag != "name" && tag != "OS/2" {
+			continue
+		}
+		offset := int64(binary.BigEndian.Uint32(rec[8:12]))
+		length := binary.BigEndian.Uint32(rec[12:16])
+		if length > maxHeaderTableBytes {
+			return info, fmt.Errorf("table %q too large", tag)
+		}
+		data := make([]byte, length)
+		if _, err := r.ReadAt(data, offset); err != nil {
+			return info, fmt.Errorf("cannot read table %q: %w", tag, err)
+		}
+		if tag == "name" {
+			name = data
+		} else {
+			os2 = data
+		}
+	}
+	if name == nil {
+		return info, errors.New("font has no 'name' table")
+	}
+	names := decodeNames(name)
+	info.Family = firstOf(names[nameTypoFamily], names[nameFamily])
+	info.Style = firstOf(names[nameTypoSubfamily], names[nameSubfamily], "Regular")
+	info.FullName = firstOf(names[nameFullName], info.Family+" "+info.Style)
+	if info.Family == "" {
+		return info, errors.New("font has no family name")
+	}
+	info.Weight, info.Width = Regular, 5
+	if len(os2) >= 64 {
+		info.Weight = Weight(binary.BigEndian.Uint16(os2[4:6]))
+		info.Width = int(binary.BigEndian.Uint16(os2[6:8]))
+		info.Italic = binary.BigEndian.Uint16(os2[62:64])&0x0201 != 0 // italic or oblique
+	}
+	return info, nil
+}
+
+// decodeNames decodes the Unicode and Windows Unicode entries of a 'name' table.
+// English (US) entries take precedence over entries in other languages.
+func decodeNames(b []byte) map[uint16]string {
+	names := make(map[uint16]string)
+	if len(b) < 6 {
+		return names
+	}
+	count := int(binary.BigEndian.Uint16(b[2:4]))
+	storage := int(binary.BigEndian.Uint16(b[4:6]))
+	english := make(map[uint16]bool)
+	for i := range count {
+		if 6+12*(i+1) > len(b) {
+			break
+		}
+		rec := b[6+12*i : 6+12*(i+1)]
+		platform := binary.BigEndian.Uint16(rec[0:2])
+		encoding := binary.BigEndian.Uint16(rec[2:4])
+		lang := binary.BigEndian.Uint16(rec[4:6])
+		id := binary.BigEndian.Uint16(rec[6:8])
+		if platform != 0 && !(platform == 3 && (encoding == 1 || encoding == 10)) {
+			continue
+		}
+		isEnglish := platform == 0 || lang == 0x0409
+		if _, ok := names[id]; ok && (english[id] || !isEnglish) {
+			continue
+		}
+		length := int(binary.BigEndian.Uint16(rec[8:10]))
+		start := storage + int(binary.BigEndian.Uint16(rec[10:12]))
+		if start+length > len(b) {
+			continue
+		}
+		if s := decodeUTF16BE(b[start : start+length]); s != "" {
+			names[id], english[id] = s, isEnglish
+		}
+	}
+	return names
+}
+
+func decodeUTF16BE(b []byte) string {
+	u := make([]uint16, len(b)/2)
+	for i := range u {
+		u[i] = binary.BigEndian.Uint16(b[2*i:])
+	}
+	return string(utf16.Decode(u))
+}
+
+func firstOf(s ...string) string {
+	for _, x := range s {
+		if x != "" {
+			return x
+		}
+	}
+	return ""
+}
@@ -0,0 +1,47 @@
+/*
+Package otjustify distributes extra line width over shaped glyph runs.
+
+Input is the output of package otshape: glyph records in logical order, together
+with the text they have been shaped from (glyph clusters index into the text).
+Justify adds the extra width in stages, each stage using one kind of
+justification opportunity:
+
+  - WordSpace widens inter-word spaces.
+  - Kashida elongates joined Arabic and Syriac letters by inserting tatweel
+    glyphs, using a heuristic to select one insertion point per word.
+  - LetterSpace adds space between clusters, at points where this neither
+    breaks mark attachment, cursive attachment nor joining.
+
+Stages are used in order, each one up to an optional per-point limit, until the
+extra width is used up.
+
+	glyphs, rest := otjustify.Justify(font, text, glyphs, 1200, otjustify.DefaultStages)
+
+# JSTF
+
+Fonts may provide justification data in their JSTF table: per script and
+language system, a list of priorities, each enabling or disabling GSUB and GPOS
+lookups to shrink or extend a line. JustifyJSTF applies these priorities one
+after the other and reports the width achieved by each step:
+
+	steps, err := otjustify.JustifyJSTF(font, ot.T("arab"), 0, glyphs, target, reshape)
+
+Remaining width may then be distributed with Justify.
+
+# Optical Margins
+
+For optical margin alignment, punctuation such as hyphens, full stops and quotes
+hangs into the margins. MarginProtrusion reports how far the glyphs at the ends of a
+line may protrude, in font units, from the AAT table 'opbd' if the font has one, and
+from heuristics on the bounding boxes of punctuation glyphs otherwise:
+
+	p := otjustify.MarginProtrusion(font, text, glyphs, bidi.LeftToRight)
+
+Paragraph layout then widens the line by p.Left+p.Right and shifts it by -p.Left.
+
+# Status
+
+Kashida points are found heuristically; extender glyphs of the JSTF table are
+not used for kashida insertion. JstfMax tables are not interpreted.
+*/
+package otjustify
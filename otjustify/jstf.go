@@ -0,0 +1,221 @@
+package otjustify
+
+import (
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"github.com/npillmayer/opentype/otquery"
+	"github.com/npillmayer/opentype/otshape"
+)
+
+// JSTFPriorities returns the justification priorities of the JSTF table of font
+// for a script and language system, in order of application. If the font has
+// no justification data for lang, the default language system of the script is
+// used. If there is no justification data for script, script 'DFLT' is tried.
+func JSTFPriorities(font *ot.Font, script, lang ot.Tag) []*ot.JstfPriority {
+	jstf := font.Jstf()
+	if jstf == nil {
+		return nil
+	}
+	js, ok := jstf.Script(script)
+	if !ok {
+		if js, ok = jstf.Script(ot.T("DFLT")); !ok {
+			return nil
+		}
+	}
+	langSys, ok := js.LangSys(lang)
+	if !ok {
+		if langSys, ok = js.DefaultLangSys(); !ok {
+			return nil
+		}
+	}
+	prios := make([]*ot.JstfPriority, 0, langSys.PriorityCount())
+	for i := 0; i < langSys.PriorityCount(); i++ {
+		if p, ok := langSys.Priority(i); ok {
+			prios = append(prios, p)
+		}
+	}
+	return prios
+}
+
+// JSTFStep reports the outcome of applying a justification priority.
+type JSTFStep struct {
+	Priority int                   // index of the priority
+	Width    int32                 // line width after applying the priority, in font units
+	Glyphs   []otshape.GlyphRecord // glyphs after applying the priority
+	Partial  bool                  // lookups to disable have been ignored, see Reshaper
+}
+
+// Reshaper shapes a line again, leaving out the given GSUB and GPOS lookups.
+// Package otshape has no notion of disabling single lookups, therefore
+// callers of JustifyJSTF have to provide one if they want lookups to be
+// disabled.
+type Reshaper func(gsub, gpos []uint16) ([]otshape.GlyphRecord, error)
+
+// JustifyJSTF shrinks or extends a line of shaped glyphs towards a target
+// width, using the justification priorities of the font's JSTF table for
+// script and lang (see JSTFPriorities).
+//
+// Whether the line is shrunk or extended depends on the current width of
+// glyphs compared to target. Priorities are applied one after the other, each
+// one on top of the previous ones, until the target width is reached or the
+// priorities are exhausted. For each priority with lookups for the direction
+// at hand, a step is reported, holding the width achieved and the resulting
+// glyphs. Clients select the step which suits them best, usually the last one,
+// and may distribute remaining space with Justify.
+//
+// Lookups to enable are applied to the shaped glyphs, GSUB lookups before GPOS
+// lookups. Lookups to disable need the line to be shaped again, which is
+// delegated to reshape. If reshape is nil, lookups to disable are ignored and
+// the steps are flagged as partial. JstfMax tables are not interpreted.
+func JustifyJSTF(font *ot.Font, script, lang ot.Tag, glyphs []otshape.GlyphRecord,
+	target int32, reshape Reshaper) ([]JSTFStep, error) {
+	//
+	width := lineWidth(glyphs)
+	if width == target || len(glyphs) == 0 {
+		return nil, nil
+	}
+	shrink := width > target
+	var steps []JSTFStep
+	var enableGSUB, disableGSUB, enableGPOS, disableGPOS []uint16
+	base, partial := glyphs, false
+	for i, p := range JSTFPriorities(font, script, lang) {
+		eGSUB, dGSUB, eGPOS, dGPOS := p.ExtensionEnableGSUB, p.ExtensionDisableGSUB,
+			p.ExtensionEnableGPOS, p.ExtensionDisableGPOS
+		if shrink {
+			eGSUB, dGSUB, eGPOS, dGPOS = p.ShrinkageEnableGSUB, p.ShrinkageDisableGSUB,
+				p.ShrinkageEnableGPOS, p.ShrinkageDisableGPOS
+		}
+		if len(eGSUB)+len(dGSUB)+len(eGPOS)+len(dGPOS) == 0 {
+			continue
+		}
+		enableGSUB, enableGPOS = append(enableGSUB, eGSUB...), append(enableGPOS, eGPOS...)
+		if len(dGSUB)+len(dGPOS) > 0 {
+			disableGSUB, disableGPOS = append(disableGSUB, dGSUB...), append(disableGPOS, dGPOS...)
+			if reshape == nil {
+				partial = true
+			} else {
+				var err error
+				if base, err = reshape(slices.Clone(disableGSUB), slices.Clone(disableGPOS)); err != nil {
+					return steps, err
+				}
+			}
+		}
+		out := applyLookups(font, base, enableGSUB, enableGPOS)
+		width = lineWidth(out)
+		steps = append(steps, JSTFStep{Priority: i, Width: width, Glyphs: out, Partial: partial})
+		if (shrink && width <= target) || (!shrink && width >= target) {
+			break
+		}
+	}
+	return steps, nil
+}
+
+func lineWidth(glyphs []otshape.GlyphRecord) int32 {
+	var w int32
+	for _, g := range glyphs {
+		w += g.Pos.XAdvance
+	}
+	return w
+}
+
+// jstfLookup is a pseudo-feature for applying a single lookup of a JSTF priority.
+type jstfLookup struct {
+	typ     otlayout.LayoutTagType
+	index   int
+	lookups *ot.LookupListGraph
+}
+
+func (l jstfLookup) Tag() ot.Tag                  { return ot.T("JSTF") }
+func (l jstfLookup) Type() otlayout.LayoutTagType { return l.typ }
+func (l jstfLookup) LookupCount() int             { return 1 }
+func (l jstfLookup) LookupIndex(int) int          { return l.index }
+func (l jstfLookup) AlternateCount(g ot.GlyphIndex) int {
+	return otlayout.AlternateCount(l.lookups, l, g)
+}
+
+// applyLookups applies GSUB and GPOS lookups, each in lookup list order, to a
+// copy of glyphs.
+func applyLookups(font *ot.Font, glyphs []otshape.GlyphRecord, gsub, gpos []uint16) []otshape.GlyphRecord {
+	if len(gsub)+len(gpos) == 0 {
+		return slices.Clone(glyphs)
+	}
+	// Positions hold GPOS deltas only, i.e. without the glyphs' default advances.
+	// Field Cluster tracks the originating record (plus 1, with 0 for new items).
+	buf := make(otlayout.GlyphBuffer, len(glyphs))
+	pos := make(otlayout.PosBuffer, len(glyphs))
+	for i, g := range glyphs {
+		buf[i] = g.GID
+		pos[i] = g.Pos
+		pos[i].XAdvance -= advance(font, g.GID)
+		pos[i].Cluster = uint32(i + 1)
+	}
+	st := otlayout.NewBufferState(buf, pos)
+	apply := func(typ otlayout.LayoutTagType, lookups []uint16) {
+		lookups = slices.Clone(lookups)
+		slices.Sort(lookups)
+		var graph *ot.LookupListGraph
+		if gsub := font.GSub(); typ == otlayout.GSubFeatureType && gsub != nil {
+			graph = gsub.LookupGraph()
+		} else if gpos := font.GPos(); typ == otlayout.GPosFeatureType && gpos != nil {
+			graph = gpos.LookupGraph()
+		}
+		for _, inx := range slices.Compact(lookups) {
+			f := jstfLookup{typ: typ, index: int(inx), lookups: graph}
+			for st.Index = 0; st.Index < st.Len(); {
+				at, n := st.Index, st.Len()
+				origin := st.Pos[at].Cluster
+				_, applied := otlayout.ApplyFeature(font, f, st, 0)
+				if st.Len() != n {
+					for k := at; k < st.Index && k < st.Len(); k++ {
+						if st.Pos[k].Cluster == 0 {
+							st.Pos[k].Cluster = origin
+						}
+					}
+				}
+				if !applied || st.Index == at {
+					st.Index = at + 1
+				}
+			}
+		}
+	}
+	apply(otlayout.GSubFeatureType, gsub)
+	if st.Len() != len(glyphs) {
+		// attachments from shaping refer to glyph positions before GSUB
+		newIndex := make([]int32, len(glyphs))
+		for i := range newIndex {
+			newIndex[i] = -1
+		}
+		for i := st.Len() - 1; i >= 0; i-- {
+			if origin := st.Pos[i].Cluster; origin > 0 {
+				newIndex[origin-1] = int32(i)
+			}
+		}
+		for i := 1; i < len(newIndex); i++ {
+			if newIndex[i] < 0 { // glyph has been merged into a ligature
+				newIndex[i] = newIndex[i-1]
+			}
+		}
+		for i := range st.Pos {
+			if to := st.Pos[i].AttachTo; to >= 0 && int(to) < len(newIndex) {
+				st.Pos[i].AttachTo = newIndex[to]
+			}
+		}
+	}
+	apply(otlayout.GPosFeatureType, gpos)
+	out := make([]otshape.GlyphRecord, st.Len())
+	for i := range out {
+		origin := max(int(st.Pos[i].Cluster)-1, 0)
+		out[i] = glyphs[origin]
+		out[i].GID = st.Glyphs[i]
+		out[i].Pos = st.Pos[i]
+		out[i].Pos.XAdvance += advance(font, st.Glyphs[i])
+		out[i].Pos.Cluster = glyphs[origin].Pos.Cluster
+	}
+	return out
+}
+
+func advance(font *ot.Font, gid ot.GlyphIndex) int32 {
+	return int32(otquery.GlyphMetrics(font, gid).Advance)
+}
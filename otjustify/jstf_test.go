@@ -0,0 +1,138 @@
+package otjustify
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"github.com/npillmayer/opentype/otshape"
+)
+
+// jstfTable creates a JSTF table for script 'latn' with a default language
+// system. Each priority is given as 10 lookup lists, in the order of the
+// offsets of a JstfPriority table (JstfMax lists must be empty).
+func jstfTable(prios ...[10][]uint16) []byte {
+	be := binary.BigEndian
+	prioData := [][]byte{}
+	for _, p := range prios {
+		b := make([]byte, 20)
+		for i, list := range p {
+			if len(list) == 0 {
+				continue
+			}
+			be.PutUint16(b[2*i:], uint16(len(b)))
+			b = be.AppendUint16(b, uint16(len(list)))
+			for _, inx := range list {
+				b = be.AppendUint16(b, inx)
+			}
+		}
+		prioData = append(prioData, b)
+	}
+	langSys := be.AppendUint16(nil, uint16(len(prios)))
+	off := 2 + 2*len(prios)
+	for _, p := range prioData {
+		langSys = be.AppendUint16(langSys, uint16(off))
+		off += len(p)
+	}
+	for _, p := range prioData {
+		langSys = append(langSys, p...)
+	}
+	script := []byte{0, 0, 0, 6, 0, 0} // no extender glyphs, default JstfLangSys at 6
+	script = append(script, langSys...)
+	b := []byte{0, 1, 0, 0, 0, 1, 'l', 'a', 't', 'n', 0, 12}
+	return append(b, script...)
+}
+
+func jstfFont(t *testing.T, prios ...[10][]uint16) *ot.Font {
+	t.Helper()
+	b := testfont.New(4)
+	b.Map('a', 1).Map('b', 2).Advance(3, 800)
+	b.GSUB().Lookup(ot.GSubLookupTypeSingle, 0, testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 3}))
+	b.GPOS().Lookup(ot.GPosLookupTypeSingle, 0, testfont.SinglePos(testfont.ValueRecord{XAdvance: 100}, 1))
+	b.Table("JSTF", jstfTable(prios...))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return otf
+}
+
+func glyphRecords(gids ...ot.GlyphIndex) []otshape.GlyphRecord {
+	glyphs := make([]otshape.GlyphRecord, len(gids))
+	for i, gid := range gids {
+		glyphs[i] = otshape.GlyphRecord{
+			GID:     gid,
+			Cluster: uint32(i),
+			Pos:     otlayout.PosItem{XAdvance: testfont.DefaultAdvance, AttachTo: -1},
+		}
+	}
+	return glyphs
+}
+
+func TestJSTFPriorities(t *testing.T) {
+	var p [10][]uint16
+	p[7] = []uint16{0} // extension enable GPOS
+	otf := jstfFont(t, p, p)
+	if prios := JSTFPriorities(otf, ot.T("latn"), ot.T("DEU ")); len(prios) != 2 {
+		t.Errorf("expected 2 priorities for latn/DEU, have %d", len(prios))
+	}
+	if prios := JSTFPriorities(otf, ot.T("arab"), 0); prios != nil {
+		t.Errorf("expected no priorities for arab, have %d", len(prios))
+	}
+}
+
+func TestJustifyJSTFExtension(t *testing.T) {
+	var p0, p1 [10][]uint16
+	p0[7] = []uint16{0} // extension enable GPOS: +100 for glyph a
+	p1[5] = []uint16{0} // extension enable GSUB: a → wide glyph
+	otf := jstfFont(t, p0, p1)
+	glyphs := glyphRecords(1, 2)
+	steps, err := JustifyJSTF(otf, ot.T("latn"), 0, glyphs, 1150, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(steps) != 2 {
+		t.Fatalf("expected 2 steps, have %d", len(steps))
+	}
+	if steps[0].Width != 1100 || steps[0].Glyphs[0].Pos.XAdvance != 600 {
+		t.Errorf("step 0: width = %d, want 1100", steps[0].Width)
+	}
+	// GSUB applies before GPOS, therefore the wide glyph is not adjusted
+	if steps[1].Width != 1300 || steps[1].Glyphs[0].GID != 3 || steps[1].Glyphs[0].Cluster != 0 {
+		t.Errorf("step 1: width = %d, glyph = %d, want 1300 and glyph 3", steps[1].Width, steps[1].Glyphs[0].GID)
+	}
+	if glyphs[0].GID != 1 || glyphs[0].Pos.XAdvance != 500 {
+		t.Errorf("input glyphs have been modified")
+	}
+	// shrinking has no priorities
+	if steps, _ := JustifyJSTF(otf, ot.T("latn"), 0, glyphs, 900, nil); len(steps) != 0 {
+		t.Errorf("expected no shrinking steps, have %d", len(steps))
+	}
+}
+
+func TestJustifyJSTFDisable(t *testing.T) {
+	var p [10][]uint16
+	p[3] = []uint16{0} // shrinkage disable GPOS
+	otf := jstfFont(t, p)
+	shaped := glyphRecords(1, 2)
+	shaped[0].Pos.XAdvance += 100 // as if shaped with GPOS lookup 0
+	steps, err := JustifyJSTF(otf, ot.T("latn"), 0, shaped, 1000, nil)
+	if err != nil || len(steps) != 1 || !steps[0].Partial || steps[0].Width != 1100 {
+		t.Fatalf("expected one partial step without effect, have %+v (%v)", steps, err)
+	}
+	var disabled []uint16
+	reshape := func(gsub, gpos []uint16) ([]otshape.GlyphRecord, error) {
+		disabled = gpos
+		return glyphRecords(1, 2), nil
+	}
+	steps, err = JustifyJSTF(otf, ot.T("latn"), 0, shaped, 1000, reshape)
+	if err != nil || len(steps) != 1 || steps[0].Partial || steps[0].Width != 1000 {
+		t.Fatalf("expected one step reaching 1000, have %+v (%v)", steps, err)
+	}
+	if !slices.Equal(disabled, []uint16{0}) {
+		t.Errorf("reshaped with GPOS lookups %v disabled, want [0]", disabled)
+	}
+}
@@ -0,0 +1,265 @@
+package otjustify
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"github.com/npillmayer/opentype/otquery"
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otarabic"
+)
+
+// Opportunity is a kind of justification opportunity.
+type Opportunity uint8
+
+const (
+	WordSpace   Opportunity = iota // widen inter-word spaces
+	Kashida                        // elongate joined Arabic letters with tatweel glyphs
+	LetterSpace                    // add space between clusters
+)
+
+func (o Opportunity) String() string {
+	switch o {
+	case WordSpace:
+		return "word-space"
+	case Kashida:
+		return "kashida"
+	case LetterSpace:
+		return "letter-space"
+	}
+	return "unknown"
+}
+
+// Stage is a step of justification, using one kind of opportunity.
+type Stage struct {
+	Opportunity Opportunity
+	Max         int32 // maximum width to add per point, in font units; 0 means no limit
+}
+
+// DefaultStages prefers kashida elongation over wider inter-word spaces, and
+// uses letter spacing as a last resort only.
+var DefaultStages = []Stage{
+	{Opportunity: Kashida},
+	{Opportunity: WordSpace},
+	{Opportunity: LetterSpace},
+}
+
+// Justify distributes extra font units of width over a line of shaped glyphs.
+//
+// glyphs are glyph records in logical order, as produced by package otshape,
+// and text is the input they have been shaped from: the cluster of a glyph is
+// the index of its first rune in text. font is the font used for shaping; it is
+// needed for kashida insertion and may be nil otherwise.
+//
+// stages are applied in order. Each stage distributes the remaining width evenly
+// over the points of its opportunity, up to the stage's limit per point.
+// Justify returns the adjusted glyphs and the width it could not distribute.
+// Kashida insertion adds glyphs and therefore returns a new slice; otherwise
+// the advances of glyphs are adjusted in place.
+func Justify(font *ot.Font, text []rune, glyphs []otshape.GlyphRecord, extra int32,
+	stages []Stage) ([]otshape.GlyphRecord, int32) {
+	//
+	if extra <= 0 || len(glyphs) == 0 {
+		return glyphs, extra
+	}
+	l := newLine(font, text, glyphs)
+	rest := extra
+	for _, st := range stages {
+		if rest <= 0 {
+			break
+		}
+		var points []*int32
+		switch st.Opportunity {
+		case WordSpace:
+			points = l.wordSpacePoints()
+		case Kashida:
+			points = l.kashidaPoints()
+		case LetterSpace:
+			points = l.letterSpacePoints()
+		}
+		rest -= distribute(points, rest, st.Max)
+	}
+	return l.materialize(), rest
+}
+
+// distribute adds up to rest evenly to points, respecting a limit per point,
+// and returns the width used.
+func distribute(points []*int32, rest int32, limit int32) int32 {
+	n := int64(len(points))
+	if n == 0 {
+		return 0
+	}
+	amount := int64(rest)
+	if limit > 0 && amount > int64(limit)*n {
+		amount = int64(limit) * n
+	}
+	each, r := int32(amount/n), amount%n
+	for i, p := range points {
+		*p += each
+		if int64(i) < r {
+			*p++
+		}
+	}
+	return int32(amount)
+}
+
+// cluster is a cluster of a line, with its joining properties.
+type cluster struct {
+	first, last int  // first and last glyph
+	letter      rune // first non-transparent rune of the cluster, or 0
+	form        otarabic.JoiningForm
+	space       bool // cluster is a word separator
+}
+
+// line collects the adjustments for a line of glyphs.
+type line struct {
+	font     *ot.Font
+	glyphs   []otshape.GlyphRecord
+	clusters []cluster
+	end      int     // number of clusters without trailing spaces
+	space    []int32 // extra advance per glyph
+	kashida  []int32 // width of kashida after glyph
+}
+
+func newLine(font *ot.Font, text []rune, glyphs []otshape.GlyphRecord) *line {
+	l := &line{
+		font:    font,
+		glyphs:  glyphs,
+		space:   make([]int32, len(glyphs)),
+		kashida: make([]int32, len(glyphs)),
+	}
+	for i, g := range glyphs {
+		if n := len(l.clusters); n > 0 && glyphs[l.clusters[n-1].first].Cluster == g.Cluster {
+			l.clusters[n-1].last = i
+			continue
+		}
+		l.clusters = append(l.clusters, cluster{first: i, last: i, form: otarabic.NoForm})
+	}
+	forms := otarabic.JoiningForms(text)
+	for k := range l.clusters {
+		c := &l.clusters[k]
+		from, to := int(glyphs[c.first].Cluster), len(text)
+		if k+1 < len(l.clusters) {
+			if next := int(glyphs[l.clusters[k+1].first].Cluster); next > from {
+				to = min(next, to)
+			}
+		}
+		for j := from; j < to; j++ {
+			if t, _ := otarabic.Joining(text[j]); t != otarabic.Transparent {
+				c.letter, c.form = text[j], forms[j]
+				break
+			}
+		}
+		c.space = isWordSeparator(c.letter)
+	}
+	l.end = len(l.clusters)
+	for l.end > 0 && l.clusters[l.end-1].space {
+		l.end--
+	}
+	return l
+}
+
+// isWordSeparator reports if r is a word separator in the sense of CSS Text.
+func isWordSeparator(r rune) bool {
+	switch r {
+	case ' ', '\u00A0', '\u1361', '\U00010100', '\U00010101', '\U0001039F', '\U0001091F':
+		return true
+	}
+	return false
+}
+
+// joinsFollowing reports if a cluster's letter connects to the following one.
+func (c cluster) joinsFollowing() bool {
+	switch c.form {
+	case otarabic.Initial, otarabic.Medial, otarabic.Medial2:
+		return true
+	}
+	return false
+}
+
+func (l *line) wordSpacePoints() []*int32 {
+	var points []*int32
+	for _, c := range l.clusters[:l.end] {
+		if c.space {
+			points = append(points, &l.space[c.last])
+		}
+	}
+	return points
+}
+
+func (l *line) letterSpacePoints() []*int32 {
+	var points []*int32
+	for i := 0; i+1 < l.end; i++ {
+		c, next := l.clusters[i], l.clusters[i+1]
+		if c.joinsFollowing() {
+			continue
+		}
+		if l.glyphs[c.last].Pos.AttachKind == otlayout.AttachCursive ||
+			l.glyphs[next.first].Pos.AttachKind != otlayout.AttachNone {
+			continue
+		}
+		points = append(points, &l.space[c.last])
+	}
+	return points
+}
+
+// tatweel returns the kashida glyph of the font and its advance.
+func (l *line) tatweel() (ot.GlyphIndex, int32) {
+	if l.font == nil {
+		return 0, 0
+	}
+	gid := otquery.GlyphIndex(l.font, '\u0640')
+	if gid == 0 {
+		return 0, 0
+	}
+	return gid, int32(otquery.GlyphMetrics(l.font, gid).Advance)
+}
+
+// materialize returns the glyphs with all adjustments applied.
+func (l *line) materialize() []otshape.GlyphRecord {
+	tatweel, tw := l.tatweel()
+	inserted := 0
+	for _, k := range l.kashida {
+		if k > 0 {
+			inserted += int((k + tw - 1) / tw)
+		}
+	}
+	if inserted == 0 {
+		for i := range l.glyphs {
+			l.glyphs[i].Pos.XAdvance += l.space[i]
+		}
+		return l.glyphs
+	}
+	index := make([]int32, len(l.glyphs)) // new index of each glyph
+	j := int32(0)
+	for i, k := range l.kashida {
+		index[i] = j
+		j++
+		if k > 0 {
+			j += (k + tw - 1) / tw
+		}
+	}
+	out := make([]otshape.GlyphRecord, 0, len(l.glyphs)+inserted)
+	for i, g := range l.glyphs {
+		g.Pos.XAdvance += l.space[i]
+		if g.Pos.AttachTo >= 0 && int(g.Pos.AttachTo) < len(index) {
+			g.Pos.AttachTo = index[g.Pos.AttachTo]
+		}
+		out = append(out, g)
+		if k := l.kashida[i]; k > 0 {
+			n := (k + tw - 1) / tw
+			overlap := n*tw - k
+			for m := range n {
+				adv := tw - overlap/n
+				if m < overlap%n {
+					adv--
+				}
+				out = append(out, otshape.GlyphRecord{
+					GID:     tatweel,
+					Cluster: g.Cluster,
+					Pos:     otlayout.PosItem{XAdvance: adv, AttachTo: -1},
+				})
+			}
+		}
+	}
+	return out
+}
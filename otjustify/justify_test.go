@@ -0,0 +1,101 @@
+package otjustify
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"github.com/npillmayer/opentype/otshape"
+)
+
+// records creates one glyph record per rune, with glyph IDs equal to runes.
+func records(text []rune, advance int32) []otshape.GlyphRecord {
+	glyphs := make([]otshape.GlyphRecord, len(text))
+	for i, r := range text {
+		glyphs[i] = otshape.GlyphRecord{
+			GID:     ot.GlyphIndex(r),
+			Cluster: uint32(i),
+			Pos:     otlayout.PosItem{XAdvance: advance, AttachTo: -1},
+		}
+	}
+	return glyphs
+}
+
+func advances(glyphs []otshape.GlyphRecord) []int32 {
+	adv := make([]int32, len(glyphs))
+	for i, g := range glyphs {
+		adv[i] = g.Pos.XAdvance
+	}
+	return adv
+}
+
+func TestJustifyWordSpaces(t *testing.T) {
+	text := []rune("ab cd ef ")
+	glyphs, rest := Justify(nil, text, records(text, 500), 101, DefaultStages)
+	if rest != 0 {
+		t.Errorf("expected all width to be distributed, have rest %d", rest)
+	}
+	want := []int32{500, 500, 551, 500, 500, 550, 500, 500, 500}
+	if adv := advances(glyphs); !slices.Equal(adv, want) {
+		t.Errorf("advances = %v, want %v", adv, want)
+	}
+}
+
+func TestJustifyStageLimits(t *testing.T) {
+	text := []rune("ab cd")
+	stages := []Stage{{Opportunity: WordSpace, Max: 10}, {Opportunity: LetterSpace, Max: 20}}
+	glyphs, rest := Justify(nil, text, records(text, 500), 100, stages)
+	if rest != 10 {
+		t.Errorf("expected rest 10, have %d", rest)
+	}
+	want := []int32{520, 520, 530, 520, 500}
+	if adv := advances(glyphs); !slices.Equal(adv, want) {
+		t.Errorf("advances = %v, want %v", adv, want)
+	}
+}
+
+func TestJustifyLetterSpacingSkipsMarks(t *testing.T) {
+	text := []rune("ab")
+	glyphs := records(text, 500)
+	glyphs[1].Pos.AttachKind = otlayout.AttachMarkToBase
+	glyphs[1].Pos.AttachTo = 0
+	_, rest := Justify(nil, text, glyphs, 100, []Stage{{Opportunity: LetterSpace}})
+	if rest != 100 {
+		t.Errorf("expected no letter spacing before an attached mark, have rest %d", rest)
+	}
+}
+
+func TestJustifyKashida(t *testing.T) {
+	b := testfont.New(6)
+	b.Map('\u0633', 1).Map('\u0628', 2).Map('\u064E', 3).Map('\u0627', 4).Map('\u0640', 5)
+	b.Advance(5, 200)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// seen, beh + fatha, alef: kashida goes after seen
+	text := []rune{'\u0633', '\u0628', '\u064E', '\u0627'}
+	glyphs := records(text, 500)
+	glyphs[2].Cluster = 1
+	glyphs[2].Pos = otlayout.PosItem{AttachKind: otlayout.AttachMarkToBase, AttachTo: 1}
+	glyphs, rest := Justify(otf, text, glyphs, 450, DefaultStages)
+	if rest != 0 {
+		t.Errorf("expected all width to be distributed, have rest %d", rest)
+	}
+	gids := make([]ot.GlyphIndex, len(glyphs))
+	for i, g := range glyphs {
+		gids[i] = g.GID
+	}
+	wantGIDs := []ot.GlyphIndex{'\u0633', 5, 5, 5, '\u0628', '\u064E', '\u0627'}
+	if !slices.Equal(gids, wantGIDs) {
+		t.Fatalf("glyphs = %v, want %v", gids, wantGIDs)
+	}
+	if adv := advances(glyphs[1:4]); !slices.Equal(adv, []int32{150, 150, 150}) {
+		t.Errorf("kashida advances = %v, want 3 x 150", adv)
+	}
+	if glyphs[5].Pos.AttachTo != 4 {
+		t.Errorf("mark attachment = %d, want 4", glyphs[5].Pos.AttachTo)
+	}
+}
@@ -0,0 +1,72 @@
+package otjustify
+
+import (
+	"slices"
+
+	"github.com/npillmayer/opentype/otshape/otarabic"
+)
+
+// kashidaPoints selects one kashida insertion point per word.
+//
+// Candidates are connections between two joined letters. They are ranked by a
+// heuristic similar to the one used by common word processors, preferring
+// connections after seen and sad, then before final letters which look good
+// when elongated. Of the best-ranked candidates of a word, the last one is used.
+func (l *line) kashidaPoints() []*int32 {
+	if _, tw := l.tatweel(); tw <= 0 {
+		return nil
+	}
+	var points []*int32
+	best, bestRank := -1, 0
+	prev := -1 // previous cluster with a letter, within the current word
+	for i, c := range l.clusters[:l.end] {
+		if c.space {
+			if best >= 0 {
+				points = append(points, &l.kashida[l.clusters[best].last])
+			}
+			best, prev = -1, -1
+			continue
+		}
+		if c.letter == 0 {
+			continue
+		}
+		if prev >= 0 && l.clusters[prev].joinsFollowing() {
+			if rank := kashidaRank(l.clusters[prev], c); best < 0 || rank <= bestRank {
+				best, bestRank = prev, rank
+			}
+		}
+		prev = i
+	}
+	if best >= 0 {
+		points = append(points, &l.kashida[l.clusters[best].last])
+	}
+	return points
+}
+
+// Joining groups of final letters preferred for kashida, by rank.
+var (
+	kashidaFinals2 = []otarabic.JoiningGroup{otarabic.GroupTehMarbuta, otarabic.GroupHeh, otarabic.GroupHah, otarabic.GroupDal}
+	kashidaFinals3 = []otarabic.JoiningGroup{otarabic.GroupAlef, otarabic.GroupTah, otarabic.GroupLam, otarabic.GroupKaf, otarabic.GroupGaf}
+	kashidaFinals4 = []otarabic.JoiningGroup{otarabic.GroupReh, otarabic.GroupYeh, otarabic.GroupFarsiYeh} // after beh
+	kashidaFinals5 = []otarabic.JoiningGroup{otarabic.GroupWaw, otarabic.GroupAin, otarabic.GroupQaf, otarabic.GroupFeh}
+)
+
+// kashidaRank ranks the connection between clusters a and b, lower is better.
+func kashidaRank(a, b cluster) int {
+	_, ga := otarabic.Joining(a.letter)
+	_, gb := otarabic.Joining(b.letter)
+	final := b.form == otarabic.Final || b.form == otarabic.Final2 || b.form == otarabic.Final3
+	switch {
+	case ga == otarabic.GroupSeen || ga == otarabic.GroupSad:
+		return 1
+	case final && slices.Contains(kashidaFinals2, gb):
+		return 2
+	case final && slices.Contains(kashidaFinals3, gb):
+		return 3
+	case final && ga == otarabic.GroupBeh && slices.Contains(kashidaFinals4, gb):
+		return 4
+	case final && slices.Contains(kashidaFinals5, gb):
+		return 5
+	}
+	return 6
+}
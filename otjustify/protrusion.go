@@ -0,0 +1,106 @@
+package otjustify
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+	"github.com/npillmayer/opentype/otshape"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Protrusion is the width by which glyphs may protrude into the left and right
+// margins of a line, in font units. Hanging punctuation and similar glyphs
+// into the margins makes the margins of justified text appear straight
+// (optical margin alignment).
+type Protrusion struct {
+	Left, Right int32
+}
+
+// protrusionFactors holds the protrusion of punctuation at the left and right
+// margins, in thousandths of the glyph's ink width. The values follow common
+// typesetting practice, e.g., the defaults of pdfTeX and microtype.
+var protrusionFactors = map[rune][2]int32{
+	'.': {0, 700}, ',': {0, 700}, ':': {0, 500}, ';': {0, 300},
+	'!': {0, 200}, '?': {0, 200}, '…': {0, 200}, // ellipsis
+	'-': {0, 700}, '\u00AD': {0, 700}, '\u2010': {0, 700}, '\u2011': {0, 700}, // hyphens
+	'–': {200, 300}, '—': {150, 200}, // en and em dash
+	'\'': {500, 500}, '"': {500, 500},
+	'‘': {700, 700}, '’': {700, 700}, '‚': {700, 700}, // single quotes
+	'“': {500, 500}, '”': {500, 500}, '„': {500, 500}, // double quotes
+	'‹': {400, 400}, '›': {400, 400}, // single guillemets
+	'«': {500, 500}, '»': {500, 500}, // double guillemets
+}
+
+// GlyphProtrusion returns how far glyph gid, shaped from rune r, may protrude
+// into the margins. Optical bounds of the font's 'opbd' table take precedence.
+// Otherwise, punctuation is hung heuristically: the side bearing of the glyph
+// plus a fraction of its ink, as given by its bounding box. For fonts without
+// bounding boxes, the fraction is taken of the glyph's advance. Other glyphs do
+// not protrude.
+func GlyphProtrusion(font *ot.Font, gid ot.GlyphIndex, r rune) Protrusion {
+	if font == nil {
+		return Protrusion{}
+	}
+	if edges, ok := otquery.OpticalEdgesOf(font, gid); ok {
+		return Protrusion{Left: int32(edges.Left), Right: int32(edges.Right)}
+	}
+	factors, ok := protrusionFactors[r]
+	if !ok {
+		return Protrusion{}
+	}
+	m := otquery.GlyphMetrics(font, gid)
+	if m.BBox.IsEmpty() {
+		return Protrusion{
+			Left:  factors[0] * int32(m.Advance) / 1000,
+			Right: factors[1] * int32(m.Advance) / 1000,
+		}
+	}
+	ink := int32(m.BBox.Dx())
+	p := Protrusion{
+		Left:  int32(m.BBox.MinX) + factors[0]*ink/1000,
+		Right: int32(m.Advance-m.BBox.MaxX) + factors[1]*ink/1000,
+	}
+	if factors[0] == 0 {
+		p.Left = 0
+	}
+	if factors[1] == 0 {
+		p.Right = 0
+	}
+	return p
+}
+
+// MarginProtrusion returns how far a line of shaped glyphs may protrude into
+// the margins: the left protrusion of the glyph at the left margin and the
+// right protrusion of the glyph at the right margin. Word separators at the
+// ends of the line are skipped, as are marks following the glyph at the start
+// of a cluster.
+//
+// glyphs are glyph records in logical order, shaped from text, as for Justify.
+// For right-to-left lines, the logically first glyph is placed at the right
+// margin.
+func MarginProtrusion(font *ot.Font, text []rune, glyphs []otshape.GlyphRecord, dir bidi.Direction) Protrusion {
+	runeOf := func(g otshape.GlyphRecord) rune {
+		if int(g.Cluster) < len(text) {
+			return text[g.Cluster]
+		}
+		return 0
+	}
+	first, last := 0, len(glyphs)-1
+	for first <= last && isWordSeparator(runeOf(glyphs[first])) {
+		first++
+	}
+	for last >= first && isWordSeparator(runeOf(glyphs[last])) {
+		last--
+	}
+	if first > last {
+		return Protrusion{}
+	}
+	for last > first && glyphs[last-1].Cluster == glyphs[last].Cluster {
+		last--
+	}
+	start := GlyphProtrusion(font, glyphs[first].GID, runeOf(glyphs[first]))
+	end := GlyphProtrusion(font, glyphs[last].GID, runeOf(glyphs[last]))
+	if dir == bidi.RightToLeft {
+		return Protrusion{Left: end.Left, Right: start.Right}
+	}
+	return Protrusion{Left: start.Left, Right: end.Right}
+}
@@ -0,0 +1,76 @@
+package otjustify
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"github.com/npillmayer/opentype/otshape"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// protrusionFont creates a font with optical bounds for the hyphen (glyph 4)
+// only. It has no bounding boxes, so heuristics use the advance of glyphs.
+func protrusionFont(t *testing.T) *ot.Font {
+	t.Helper()
+	be := binary.BigEndian
+	opbd := be.AppendUint32(nil, 0x00010000)
+	for _, v := range []int16{0, 8, 4, 1, 14, 0, 0, -150, 0} { // format, lookup, bounds
+		opbd = be.AppendUint16(opbd, uint16(v))
+	}
+	b := testfont.New(6)
+	b.Map('„', 1).Map('a', 2).Map('.', 3).Map('-', 4).Map(' ', 5)
+	b.Advance(1, 400).Advance(3, 200)
+	b.Table("opbd", opbd)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return otf
+}
+
+func shapedLine(otf *ot.Font, text []rune) []otshape.GlyphRecord {
+	glyphs := make([]otshape.GlyphRecord, len(text))
+	for i, r := range text {
+		glyphs[i] = otshape.GlyphRecord{
+			GID:     otf.CMap.GlyphIndexMap.Lookup(r),
+			Cluster: uint32(i),
+			Pos:     otlayout.PosItem{XAdvance: testfont.DefaultAdvance, AttachTo: -1},
+		}
+	}
+	return glyphs
+}
+
+func TestGlyphProtrusion(t *testing.T) {
+	otf := protrusionFont(t)
+	if p := GlyphProtrusion(otf, 3, '.'); p != (Protrusion{Right: 140}) {
+		t.Errorf("expected full stop to protrude 140 units to the right, have %+v", p)
+	}
+	if p := GlyphProtrusion(otf, 4, '-'); p != (Protrusion{Right: 150}) {
+		t.Errorf("expected optical bounds of hyphen to take precedence, have %+v", p)
+	}
+	if p := GlyphProtrusion(otf, 2, 'a'); p != (Protrusion{}) {
+		t.Errorf("did not expect letter to protrude, have %+v", p)
+	}
+}
+
+func TestMarginProtrusion(t *testing.T) {
+	otf := protrusionFont(t)
+	for _, c := range []struct {
+		text     string
+		dir      bidi.Direction
+		expected Protrusion
+	}{
+		{"„a.", bidi.LeftToRight, Protrusion{Left: 200, Right: 140}},
+		{"„a- ", bidi.LeftToRight, Protrusion{Left: 200, Right: 150}},
+		{"„a.", bidi.RightToLeft, Protrusion{Left: 0, Right: 200}},
+		{"  ", bidi.LeftToRight, Protrusion{}},
+	} {
+		text := []rune(c.text)
+		if p := MarginProtrusion(otf, text, shapedLine(otf, text), c.dir); p != c.expected {
+			t.Errorf("%q: expected protrusion %+v, have %+v", c.text, c.expected, p)
+		}
+	}
+}
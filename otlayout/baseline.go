@@ -0,0 +1,165 @@
+package otlayout
+
+import "github.com/npillmayer/opentype/ot"
+
+// Baseline tags registered for the BASE table.
+var (
+	BaselineHanging     = ot.T("hang") // hanging baseline, e.g. Devanagari
+	BaselineIdeoBottom  = ot.T("ideo") // ideographic em-box bottom edge
+	BaselineIdeoTop     = ot.T("idtp") // ideographic em-box top edge
+	BaselineIdeoCharBot = ot.T("icfb") // ideographic character face bottom edge
+	BaselineIdeoCharTop = ot.T("icft") // ideographic character face top edge
+	BaselineMath        = ot.T("math") // math characters
+	BaselineRoman       = ot.T("romn") // alphabetic scripts, e.g. Latin
+)
+
+// Baseline returns the coordinate of a baseline for script, in font units,
+// as specified by the horizontal axis of the font's BASE table.
+//
+// If the BASE table has no entry for script, the entry for script 'DFLT' is
+// used. Baseline returns false if the font has no BASE table or no value for
+// the baseline. BaseCoord formats 2 and 3 are resolved to their design
+// coordinate, i.e. neither contour points nor device adjustments are applied.
+func Baseline(otf *ot.Font, script, baseline ot.Tag) (int16, bool) {
+	axis, bs, ok := baseScript(otf, script)
+	if !ok {
+		return 0, false
+	}
+	values, ok := bs.BaseValues()
+	if !ok {
+		return 0, false
+	}
+	for i, tag := range axis.BaselineTags() {
+		if tag == baseline {
+			coord, ok := values.CoordAt(i)
+			if !ok {
+				return 0, false
+			}
+			return coord.Coordinate(), true
+		}
+	}
+	return 0, false
+}
+
+// DominantBaseline returns the default baseline of script, as specified by the
+// font's BASE table.
+func DominantBaseline(otf *ot.Font, script ot.Tag) (ot.Tag, bool) {
+	axis, bs, ok := baseScript(otf, script)
+	if !ok {
+		return 0, false
+	}
+	values, ok := bs.BaseValues()
+	if !ok {
+		return 0, false
+	}
+	tags := axis.BaselineTags()
+	inx := int(values.DefaultBaselineIndex())
+	if inx >= len(tags) {
+		return 0, false
+	}
+	return tags[inx], true
+}
+
+// BaselineShift returns the vertical offset to apply to glyphs of toFont to
+// align them with glyphs of fromFont, for text in script set on a line with
+// fromFont. Glyphs are aligned at the dominant baseline of script in fromFont
+// (or in toFont, if fromFont does not specify one). The result is in units of
+// toFont, with fromFont's coordinates scaled to toFont's units per em, assuming
+// both fonts are set at the same size. Positive values raise glyphs of toFont.
+//
+// BaselineShift returns false if the baseline position cannot be resolved
+// for both fonts.
+func BaselineShift(fromFont, toFont *ot.Font, script ot.Tag) (int32, bool) {
+	baseline, ok := DominantBaseline(fromFont, script)
+	if !ok {
+		if baseline, ok = DominantBaseline(toFont, script); !ok {
+			return 0, false
+		}
+	}
+	from, ok := Baseline(fromFont, script, baseline)
+	if !ok {
+		return 0, false
+	}
+	to, ok := Baseline(toFont, script, baseline)
+	if !ok {
+		return 0, false
+	}
+	fromUnits, toUnits := unitsPerEm(fromFont), unitsPerEm(toFont)
+	if fromUnits == 0 || toUnits == 0 {
+		return 0, false
+	}
+	scaled := int32(from) * toUnits / fromUnits
+	return scaled - int32(to), true
+}
+
+// Extent returns the minimum and maximum extent of glyphs of script, in font
+// units, as specified by the MinMax tables of the font's BASE table. Values for
+// language system lang override the default values of script, and values for
+// feature override both. Use 0 for lang or feature if not applicable.
+//
+// Clients use Extent to compute the line height of lines mixing fonts. Extent
+// returns false if the font does not specify both a minimum and a maximum
+// extent.
+func Extent(otf *ot.Font, script, lang, feature ot.Tag) (int16, int16, bool) {
+	_, bs, ok := baseScript(otf, script)
+	if !ok {
+		return 0, 0, false
+	}
+	var minC, maxC *ot.BaseCoord
+	override := func(m interface {
+		Min() (*ot.BaseCoord, bool)
+		Max() (*ot.BaseCoord, bool)
+	}) {
+		if c, ok := m.Min(); ok {
+			minC = c
+		}
+		if c, ok := m.Max(); ok {
+			maxC = c
+		}
+	}
+	mm, ok := bs.DefaultMinMax()
+	if ok {
+		override(mm)
+	}
+	if lang != 0 {
+		if lmm, ok := bs.LangSysMinMax(lang); ok {
+			mm = lmm
+			override(mm)
+		}
+	}
+	if feature != 0 && mm != nil {
+		if fmm, ok := mm.Feature(feature); ok {
+			override(fmm)
+		}
+	}
+	if minC == nil || maxC == nil {
+		return 0, 0, false
+	}
+	return minC.Coordinate(), maxC.Coordinate(), true
+}
+
+// baseScript returns the BaseScript for script from the horizontal axis of the
+// font's BASE table, falling back to script 'DFLT'.
+func baseScript(otf *ot.Font, script ot.Tag) (*ot.BaseAxis, *ot.BaseScript, bool) {
+	if otf == nil {
+		return nil, nil, false
+	}
+	axis := otf.Base().Horizontal()
+	if axis == nil {
+		return nil, nil, false
+	}
+	bs, ok := axis.Script(script)
+	if !ok {
+		if bs, ok = axis.Script(ot.T("DFLT")); !ok {
+			return nil, nil, false
+		}
+	}
+	return axis, bs, true
+}
+
+func unitsPerEm(otf *ot.Font) int32 {
+	if head := otf.FontHead(); head != nil {
+		return int32(head.UnitsPerEm)
+	}
+	return 0
+}
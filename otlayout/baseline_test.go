@@ -0,0 +1,114 @@
+package otlayout
+
+import (
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// baseTable creates a BASE table with a horizontal axis for baselines 'hang',
+// 'ideo' and 'romn', and a single script. The script has a default MinMax
+// (-200…800, with feature 'sups' raising the maximum to 900) and a MinMax for
+// language system 'DEU ' with a minimum of -250 only.
+func baseTable(script string, defaultBaseline uint16, hang, ideo, romn int16) []byte {
+	u16 := func(v uint16) []byte { return []byte{byte(v >> 8), byte(v)} }
+	coord := func(v int16) []byte { return append(u16(1), u16(uint16(v))...) }
+	cat := func(parts ...[]byte) []byte {
+		var b []byte
+		for _, p := range parts {
+			b = append(b, p...)
+		}
+		return b
+	}
+	baseValues := cat(u16(defaultBaseline), u16(3), u16(10), u16(14), u16(18),
+		coord(hang), coord(ideo), coord(romn))
+	defaultMinMax := cat(u16(14), u16(18), u16(1), []byte("sups"), u16(0), u16(22),
+		coord(-200), coord(800), coord(900))
+	langMinMax := cat(u16(6), u16(0), u16(0), coord(-250))
+	baseScript := cat(u16(12), u16(uint16(12+len(baseValues))), u16(1), []byte("DEU "),
+		u16(uint16(12+len(baseValues)+len(defaultMinMax))),
+		baseValues, defaultMinMax, langMinMax)
+	axis := cat(u16(4), u16(18), u16(3), []byte("hangideoromn"),
+		u16(1), []byte(script), u16(8), baseScript)
+	return cat(u16(1), u16(0), u16(8), u16(0), axis)
+}
+
+func baselineFont(t *testing.T, upem uint16, base []byte) *ot.Font {
+	t.Helper()
+	b := testfont.New(2)
+	b.UnitsPerEm = upem
+	if base != nil {
+		b.Table("BASE", base)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func TestBaseline(t *testing.T) {
+	otf := baselineFont(t, 1000, baseTable("latn", 2, 600, -120, 0))
+	for _, c := range []struct {
+		script, baseline ot.Tag
+		value            int16
+		ok               bool
+	}{
+		{ot.T("latn"), BaselineHanging, 600, true},
+		{ot.T("latn"), BaselineIdeoBottom, -120, true},
+		{ot.T("latn"), BaselineRoman, 0, true},
+		{ot.T("latn"), BaselineMath, 0, false},
+		{ot.T("cyrl"), BaselineRoman, 0, false},
+	} {
+		v, ok := Baseline(otf, c.script, c.baseline)
+		if v != c.value || ok != c.ok {
+			t.Errorf("baseline %s/%s: expected (%d, %v), have (%d, %v)",
+				c.script, c.baseline, c.value, c.ok, v, ok)
+		}
+	}
+	if tag, ok := DominantBaseline(otf, ot.T("latn")); !ok || tag != BaselineRoman {
+		t.Errorf("expected dominant baseline 'romn' for latn, have %s", tag)
+	}
+	dflt := baselineFont(t, 1000, baseTable("DFLT", 0, 600, -120, 0))
+	if v, ok := Baseline(dflt, ot.T("deva"), BaselineHanging); !ok || v != 600 {
+		t.Errorf("expected fallback to script DFLT, have (%d, %v)", v, ok)
+	}
+}
+
+func TestBaselineShift(t *testing.T) {
+	from := baselineFont(t, 1000, baseTable("deva", 0, 600, -120, 0))
+	to := baselineFont(t, 2000, baseTable("deva", 2, 1400, -240, 100))
+	// aligned at the hanging baseline of from: 2*600 - 1400
+	if shift, ok := BaselineShift(from, to, ot.T("deva")); !ok || shift != -200 {
+		t.Errorf("expected shift -200, have (%d, %v)", shift, ok)
+	}
+	// aligned at the roman baseline of to
+	if shift, ok := BaselineShift(to, from, ot.T("deva")); !ok || shift != 50 {
+		t.Errorf("expected shift 50, have (%d, %v)", shift, ok)
+	}
+	plain := baselineFont(t, 1000, nil)
+	if _, ok := BaselineShift(from, plain, ot.T("deva")); ok {
+		t.Errorf("expected no baseline shift for font without BASE table")
+	}
+}
+
+func TestExtent(t *testing.T) {
+	otf := baselineFont(t, 1000, baseTable("latn", 2, 600, -120, 0))
+	for _, c := range []struct {
+		lang, feature ot.Tag
+		min, max      int16
+	}{
+		{0, 0, -200, 800},
+		{ot.T("DEU "), 0, -250, 800},
+		{ot.T("TRK "), 0, -200, 800},
+		{0, ot.T("sups"), -200, 900},
+		{ot.T("DEU "), ot.T("sups"), -250, 800},
+	} {
+		lo, hi, ok := Extent(otf, ot.T("latn"), c.lang, c.feature)
+		if !ok || lo != c.min || hi != c.max {
+			t.Errorf("extent %s/%s: expected %d…%d, have %d…%d (%v)",
+				c.lang, c.feature, c.min, c.max, lo, hi, ok)
+		}
+	}
+}
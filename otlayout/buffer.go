@@ -35,6 +35,19 @@ func (b GlyphBuffer) Replace(i, j int, repl []ot.GlyphIndex) GlyphBuffer {
 	return GlyphBuffer(out)
 }
 
+// replaceInPlace is like Replace, but re-uses the backing array of b if its
+// capacity suffices. Clients must own b exclusively.
+func (b GlyphBuffer) replaceInPlace(i, j int, repl []ot.GlyphIndex) GlyphBuffer {
+	n := len(b) - (j - i) + len(repl)
+	if n > cap(b) {
+		return b.Replace(i, j, repl)
+	}
+	out := b[:n]
+	copy(out[i+len(repl):], b[j:])
+	copy(out[i:], repl)
+	return out
+}
+
 func (b GlyphBuffer) Insert(i int, glyphs []ot.GlyphIndex) GlyphBuffer {
 	out := append(b[:i:i], glyphs...)
 	out = append(out, b[i:]...)
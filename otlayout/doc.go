@@ -25,7 +25,13 @@ func errFontFormat(message string) error {
 	return fmt.Errorf("OpenType font format: %s", message)
 }
 
-// tracer writes to trace with key 'font.opentype'
+// tracer writes to trace with key 'tyse.fonts'
 func tracer() tracing.Trace {
 	return tracing.Select("tyse.fonts")
 }
+
+// traceDebug reports whether debug-level tracing is enabled. Hot paths check it
+// before tracing, to avoid boxing trace arguments when tracing is off.
+func traceDebug() bool {
+	return tracer().GetTraceLevel() >= tracing.LevelDebug
+}
@@ -3,7 +3,11 @@ package otlayout
 import (
 	"errors"
 	"fmt"
+	"math/bits"
+	"slices"
+	"sync"
 
+	"github.com/npillmayer/opentype/internal/guard"
 	"github.com/npillmayer/opentype/ot"
 )
 
@@ -23,11 +27,16 @@ import (
 // A feature uses ‘lookups’ to do operations on glyphs. GSUB and GPOS tables store lookups in a
 // LookupList, into which Features link by maintaining a list of indices into the LookupList.
 // The order of the lookup indices matters.
+//
+// AlternateCount returns the number of alternates the feature offers for a
+// glyph, e.g. the number of variants of a character variant feature 'cvXX'.
+// Implementations will usually delegate to the function AlternateCount.
 type Feature interface {
-	Tag() ot.Tag         // e.g., 'liga'
-	Type() LayoutTagType // GSUB or GPOS ?
-	LookupCount() int    // number of Lookups for this feature
-	LookupIndex(int) int // get index of lookup #i
+	Tag() ot.Tag                      // e.g., 'liga'
+	Type() LayoutTagType              // GSUB or GPOS ?
+	LookupCount() int                 // number of Lookups for this feature
+	LookupIndex(int) int              // get index of lookup #i
+	AlternateCount(ot.GlyphIndex) int // number of alternates for a glyph
 }
 
 // feature is the default implementation of Feature. Other, more spezialized Feature
@@ -36,6 +45,7 @@ type feature struct {
 	typ           LayoutTagType
 	tag           ot.Tag
 	lookupIndices []int
+	lookups       *ot.LookupListGraph
 }
 
 // FontFeature looks up OpenType layout features in OpenType font otf, i.e. it trys to
@@ -47,7 +57,19 @@ type feature struct {
 // Returns GSUB features, GPOS features and a possible error condition.
 // The features at index 0 of each slice are the mandatory features (for a script), and may
 // be nil.
+//
+// Results are cached with the font, see FontFeatureSet.
 func FontFeatures(otf *ot.Font, script, lang ot.Tag) ([]Feature, []Feature, error) {
+	fs, err := FontFeatureSet(otf, script, lang)
+	if err != nil {
+		return nil, nil, err
+	}
+	return slices.Clone(fs.GSub), slices.Clone(fs.GPos), nil
+}
+
+// collectFontFeatures navigates the script and feature graphs of GSUB and GPOS
+// of font otf. See FontFeatures.
+func collectFontFeatures(otf *ot.Font, script, lang ot.Tag) ([]Feature, []Feature, error) {
 	lytTables, err := getLayoutTables(otf) // get GSUB and GPOS table for font otf
 	if err != nil {
 		return nil, nil, err
@@ -72,7 +94,9 @@ func FontFeatures(otf *ot.Font, script, lang ot.Tag) ([]Feature, []Feature, erro
 			feats[i] = []Feature{}
 			continue
 		}
-		tracer().Debugf("found script table for '%s'", script)
+		if traceDebug() {
+			tracer().Debugf("found script table for '%s'", script)
+		}
 		var lsys *ot.LangSys
 		if lang != 0 {
 			lsys = scr.LangSys(lang)
@@ -94,7 +118,7 @@ func FontFeatures(otf *ot.Font, script, lang ot.Tag) ([]Feature, []Feature, erro
 		feats[i] = make([]Feature, 0, 1+len(concreteFeatures))
 		if reqInx, ok := lsys.RequiredFeatureIndex(); ok {
 			cf, tag := featureAtConcreteIndex(fg, int(reqInx))
-			feats[i] = append(feats[i], wrapConcreteFeature(cf, tag, i))
+			feats[i] = append(feats[i], wrapConcreteFeature(cf, tag, i, t.LookupGraph()))
 		} else {
 			feats[i] = append(feats[i], nil) // mandatory feature slot
 		}
@@ -104,15 +128,17 @@ func FontFeatures(otf *ot.Font, script, lang ot.Tag) ([]Feature, []Feature, erro
 				continue
 			}
 			tag := featureByPtr[cf]
-			wrapped := wrapConcreteFeature(cf, tag, i)
+			wrapped := wrapConcreteFeature(cf, tag, i, t.LookupGraph())
 			feats[i] = append(feats[i], wrapped)
-			tracer().Debugf("%2d: feat[%v] ", j+1, wrapped.Tag())
+			if traceDebug() {
+				tracer().Debugf("%2d: feat[%v] ", j+1, wrapped.Tag())
+			}
 		}
 	}
 	return feats[0], feats[1], nil
 }
 
-func wrapConcreteFeature(cf *ot.Feature, tag ot.Tag, which int) Feature {
+func wrapConcreteFeature(cf *ot.Feature, tag ot.Tag, which int, graph *ot.LookupListGraph) Feature {
 	if cf == nil {
 		return nil
 	}
@@ -123,6 +149,7 @@ func wrapConcreteFeature(cf *ot.Feature, tag ot.Tag, which int) Feature {
 	f := feature{
 		tag:           tag,
 		lookupIndices: lookups,
+		lookups:       graph,
 	}
 	if which == 0 {
 		f.typ = GSubFeatureType
@@ -169,6 +196,43 @@ func (f feature) LookupIndex(i int) int {
 	return f.lookupIndices[i]
 }
 
+// AlternateCount returns the number of alternates the feature offers for glyph g.
+func (f feature) AlternateCount(g ot.GlyphIndex) int {
+	return AlternateCount(f.lookups, f, g)
+}
+
+// AlternateCount returns the number of alternate glyphs which the alternate
+// substitution lookups (GSUB lookup type 3) of feature feat offer for glyph g.
+// Lookup indices of feat refer to lookup list lookups. If more than one lookup
+// of feat covers g, the largest number of alternates is returned. Features
+// without alternate substitutions for g, including GPOS features, return 0.
+func AlternateCount(lookups *ot.LookupListGraph, feat Feature, g ot.GlyphIndex) int {
+	if lookups == nil || feat == nil || feat.Type() != GSubFeatureType {
+		return 0
+	}
+	n := 0
+	for i := range feat.LookupCount() {
+		lookup := lookups.Lookup(feat.LookupIndex(i))
+		if lookup == nil {
+			continue
+		}
+		for _, node := range lookup.Range() {
+			node = node.Unwrap()
+			if node == nil || ot.GSubLookupType(node.LookupType) != ot.GSubLookupTypeAlternate {
+				continue
+			}
+			p := node.GSubPayload()
+			if p == nil || p.AlternateFmt1 == nil {
+				continue
+			}
+			if inx, ok := node.Coverage.Match(g); ok && inx < len(p.AlternateFmt1.Alternates) {
+				n = max(n, len(p.AlternateFmt1.Alternates[inx]))
+			}
+		}
+	}
+	return n
+}
+
 // --- Feature application ---------------------------------------------------
 
 // ApplyFeature will apply a feature to one or more glyphs of buffer buf, starting at
@@ -176,43 +240,158 @@ func (f feature) LookupIndex(i int) int {
 //
 // If a feature is unsuited for the glyph at pos, ApplyFeature will do nothing and return pos.
 //
-// Attention: It is a requirement that font otf contains the appropriate layout table (either GSUB or
-// GPOS) for the feature. Having the table missing may result in a crash. This should never happen, as
-// extracting the feature will have required the layout table in the first place. Presence of the
-// layout table is not checked again.
+// Parameter alt selects the glyph substituted by alternate substitutions
+// (GSUB lookup type 3), as a 0-based index into the alternates of a glyph;
+// -1 selects the last alternate. Clients implementing feature values, e.g. for
+// character variants 'cvXX', where value n selects the n-th variant, pass n-1.
+// If alt is out of range for a glyph (see Feature.AlternateCount), the glyph
+// is left unchanged.
+//
+// Internal errors, e.g. for malformed fonts, do not crash the client, but are
+// recorded in st.Err (see BufferState); ApplyFeature reports false then.
 func ApplyFeature(otf *ot.Font, feat Feature, st *BufferState, alt int) (int, bool) {
 	if feat == nil { // this is legal for unused mandatory feature slots
 		return st.Index, false
-	} else if st == nil || st.Glyphs == nil || st.Index < 0 || st.Index >= len(st.Glyphs) {
+	} else if st == nil || st.Glyphs == nil || st.Index < 0 || st.Index >= len(st.Glyphs) || st.Err != nil {
 		tracer().Infof("application of font-feature requested for unusable buffer condition")
 		if st != nil {
 			return st.Index, false
 		}
 		return 0, false
 	}
-	var lytTable *ot.LayoutTable
-	if feat.Type() == GSubFeatureType {
-		lytTable = &otf.Table(ot.T("GSUB")).Self().AsGSub().LayoutTable
-	} else {
-		lytTable = &otf.Table(ot.T("GPOS")).Self().AsGPos().LayoutTable
-	}
+	defer guard.Recover(&st.Err, tracer(), "otlayout.ApplyFeature", nil)
 	var applied, ok bool
-	gdef := otf.Layout.GDef
-	lookupGraph := lytTable.LookupGraph()
+	gdef := otf.GDef()
+	lookupGraph := featureLookupGraph(otf, feat)
 	if lookupGraph == nil {
 		tracer().Errorf("lookup graph missing for feature %s", feat.Tag())
 		return st.Index, false
 	}
 	for i := 0; i < feat.LookupCount(); i++ { // lookups have to be applied in sequence
 		inx := feat.LookupIndex(i)
-		tracer().Debugf("feature %s lookup #%d => index %d", feat.Tag(), i, inx)
+		if traceDebug() {
+			tracer().Debugf("feature %s lookup #%d => index %d", feat.Tag(), i, inx)
+		}
 		clookup := lookupGraph.Lookup(inx)
+		if !clookup.FirstGlyphs().Intersects(st.Glyphs[st.Index:]) {
+			continue
+		}
 		_, ok, _ = applyLookupConcrete(clookup, lookupGraph, feat, st, alt, gdef)
 		applied = applied || ok
 	}
 	return st.Index, applied
 }
 
+// ApplyFeatureReverse applies the GSUB reverse chaining single substitution
+// lookups (type 8) of feat to the glyph at position st.Index only. Lookups of
+// other types are skipped. It returns true if a substitution has been made.
+//
+// Other than ApplyFeature, which searches for a match from st.Index onwards,
+// ApplyFeatureReverse does not search. The OpenType specification requires
+// reverse chaining lookups to be applied to every glyph from the end of the
+// buffer to its start, with the substitutions made before being visible as
+// lookahead context; clients therefore call ApplyFeatureReverse for each
+// position in descending order. Reverse chaining substitutions replace single
+// glyphs, so the length of the buffer does not change.
+func ApplyFeatureReverse(otf *ot.Font, feat Feature, st *BufferState) bool {
+	if feat == nil || feat.Type() != GSubFeatureType {
+		return false
+	} else if st == nil || st.Index < 0 || st.Index >= len(st.Glyphs) || st.Err != nil {
+		return false
+	}
+	defer guard.Recover(&st.Err, tracer(), "otlayout.ApplyFeatureReverse", nil)
+	lookupGraph := featureLookupGraph(otf, feat)
+	if lookupGraph == nil {
+		tracer().Errorf("lookup graph missing for feature %s", feat.Tag())
+		return false
+	}
+	applied := false
+	pos := st.Index
+	for i := 0; i < feat.LookupCount(); i++ {
+		clookup := lookupGraph.Lookup(feat.LookupIndex(i))
+		if !clookup.IsReverse() || !clookup.FirstGlyphs().Contains(st.Glyphs[pos]) {
+			continue
+		}
+		st.Index = pos
+		_, ok, _ := applyLookupMode(clookup, lookupGraph, feat, st, 0, otf.GDef(), true)
+		applied = applied || ok
+	}
+	st.Index = pos
+	return applied
+}
+
+// ApplyLookup applies a single lookup to buffer state st, starting at position
+// st.Index, without resolving features. The lookup is identified by its index
+// lookupIndex into the lookup list of table GSUB (for table GSubFeatureType) or
+// GPOS (for table GPosFeatureType). ApplyLookup returns the position after
+// application of the lookup and whether it has been applied. Parameter alt
+// selects alternate glyphs as for ApplyFeature.
+//
+// ApplyLookup is intended for test harnesses and debugging tools exercising
+// specific lookups. Lookups nested in contextual lookups are applied as well,
+// but lookup flags and mark filtering are the only context considered: lookups
+// meant to be referenced by contextual lookups only will usually match more
+// glyphs when applied directly.
+func ApplyLookup(otf *ot.Font, table LayoutTagType, lookupIndex int, st *BufferState, alt int) (int, bool) {
+	if st == nil {
+		return 0, false
+	} else if st.Glyphs == nil || st.Index < 0 || st.Index >= len(st.Glyphs) || st.Err != nil {
+		tracer().Infof("application of lookup requested for unusable buffer condition")
+		return st.Index, false
+	}
+	defer guard.Recover(&st.Err, tracer(), "otlayout.ApplyLookup", nil)
+	if table != GSubFeatureType && table != GPosFeatureType {
+		tracer().Errorf("cannot apply lookup of layout tag type %d", table)
+		return st.Index, false
+	}
+	feat := feature{typ: table, lookupIndices: []int{lookupIndex}}
+	feat.lookups = featureLookupGraph(otf, feat)
+	clookup := feat.lookups.Lookup(lookupIndex)
+	if clookup == nil {
+		tracer().Errorf("lookup %d not found in lookup list", lookupIndex)
+		return st.Index, false
+	}
+	_, ok, _ := applyLookupConcrete(clookup, feat.lookups, feat, st, alt, otf.GDef())
+	return st.Index, ok
+}
+
+// FeatureMayApply reports whether at least one lookup of feat may start a match
+// at one of the glyphs. If it returns false, applying feat to a buffer consisting
+// of these glyphs is guaranteed to be a no-op, and clients may skip it altogether.
+//
+// The check is based on the union of the subtable coverages of each lookup,
+// computed on first use and cached with the lookup.
+func FeatureMayApply(otf *ot.Font, feat Feature, glyphs []ot.GlyphIndex) bool {
+	if feat == nil || len(glyphs) == 0 {
+		return false
+	}
+	lookupGraph := featureLookupGraph(otf, feat)
+	if lookupGraph == nil {
+		return true // let ApplyFeature report the problem
+	}
+	for i := 0; i < feat.LookupCount(); i++ {
+		if clookup := lookupGraph.Lookup(feat.LookupIndex(i)); clookup != nil {
+			if clookup.FirstGlyphs().Intersects(glyphs) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// featureLookupGraph returns the lookup graph of the layout table (GSUB or GPOS)
+// a feature belongs to.
+func featureLookupGraph(otf *ot.Font, feat Feature) *ot.LookupListGraph {
+	if feat.Type() == GSubFeatureType {
+		if gsub := otf.GSub(); gsub != nil {
+			return gsub.LookupGraph()
+		}
+	} else if gpos := otf.GPos(); gpos != nil {
+		return gpos.LookupGraph()
+	}
+	return nil
+}
+
 // applyCtx bundles immutable lookup state for dispatch and helpers.
 type applyCtx struct {
 	feat        Feature                  // active feature for alternate selection and tracing
@@ -220,30 +399,182 @@ type applyCtx struct {
 	lookupGraph *ot.LookupListGraph      // concrete lookup graph for nested lookups
 	buf         *BufferState             // buffer state (glyphs + positions)
 	pos         int                      // current glyph position in buffer
-	alt         int                      // alternate index (1..n) for substitution selection
+	alt         int                      // 0-based alternate index for substitution selection, -1 for the last
 	flag        ot.LayoutTableLookupFlag // lookup flags for ignore/mark filtering
 	gdef        *ot.GDefTable            // GDEF table for glyph classification, if present
 	subnode     *ot.LookupNode           // effective concrete node for current subtable dispatch
+	ints        []int                    // scratch storage for match positions
+	nested      BufferState              // buffer state for nested sequence lookups
+	reverse     bool                     // match reverse chaining subtables at pos only
+}
+
+// applyCtxPool recycles lookup contexts together with their scratch storage,
+// so that applying lookups does not allocate in the steady state.
+var applyCtxPool = sync.Pool{
+	New: func() any { return &applyCtx{} },
+}
+
+// release clears ctx and returns it to the pool.
+func (ctx *applyCtx) release() {
+	ints := ctx.ints[:0]
+	*ctx = applyCtx{ints: ints}
+	applyCtxPool.Put(ctx)
+}
+
+// scratchInts returns a slice of n ints from the scratch storage of ctx.
+// Slices handed out stay valid until ctx is released.
+func (ctx *applyCtx) scratchInts(n int) []int {
+	if len(ctx.ints)+n > cap(ctx.ints) {
+		// earlier slices keep referencing the old backing array
+		ctx.ints = make([]int, 0, max(2*cap(ctx.ints), len(ctx.ints)+n, 16))
+	}
+	out := ctx.ints[len(ctx.ints) : len(ctx.ints)+n]
+	ctx.ints = ctx.ints[:len(ctx.ints)+n]
+	return out
 }
 
 // EditSpan describes a buffer mutation so contextual/chaining lookups can
-// re-map lookup-record positions after a replacement/insertion.
+// re-map lookup-record positions after a replacement/insertion, and position
+// buffers can re-map attachments (see MapIndex).
 type EditSpan struct {
 	From int // start index (inclusive) of the replaced range
 	To   int // end index (exclusive) of the replaced range
 	Len  int // length of the replacement segment
+	// Components is non-zero if the edit forms a ligature (GSUB type 4). Bit i
+	// is set if the glyph at From+i has been a component of the ligature;
+	// glyphs skipped while matching, e.g. marks, have no bit set.
+	Components uint64
+}
+
+// LigatureComponents returns the number of components of a ligature formed by
+// edit, or 0 if edit does not form a ligature.
+func (edit EditSpan) LigatureComponents() int {
+	return bits.OnesCount64(edit.Components)
 }
 
+// MapIndex maps index i of a buffer before edit to the index of the same glyph
+// after edit. Glyphs of the replaced range map to the first glyph replacing
+// them, e.g. the components of a ligature map to the ligature. MapIndex
+// returns false if the glyph at i has been deleted without replacement.
+func (edit EditSpan) MapIndex(i int) (int, bool) {
+	switch {
+	case i < edit.From:
+		return i, true
+	case i >= edit.To:
+		return i + edit.Len - (edit.To - edit.From), true
+	case edit.Len == 0:
+		return -1, false
+	}
+	return edit.From, true
+}
+
+// EditLog records edits of a glyph buffer for clients which keep per-glyph
+// data aligned with the buffer, e.g. clusters. Only edits which change the
+// length of the buffer or form ligatures are recorded, in order of their
+// application; single substitutions leave the alignment intact.
+type EditLog []EditSpan
+
 // BufferState bundles glyph and position buffers with a current index.
 // Position buffer may be nil when only GSUB is applied.
 // Copy-on-write is implemented via shared flags; mutating methods will clone
 // backing slices when necessary.
+//
+// If NumGlyphs is set, Set and ReplaceGlyphs reject glyph IDs not below
+// NumGlyphs, which may be produced by lookups of malformed fonts. Applying a
+// lookup sets NumGlyphs from table 'maxp' of the font, if not set by the client.
+//
+// If Budget is set, lookup applications on the buffer state are counted and
+// limited by it (see [LookupBudget]). Independent of the budget, multiple
+// substitutions never grow the glyph buffer beyond its growth limit, which is
+// set relative to the length of the buffer when the first lookup is applied
+// to the buffer state. If Log is set, edits of the glyph buffer
+// are appended to it, including edits of lookups nested in contextual lookups.
+//
+// If applying a lookup fails with an internal error, e.g. for a malformed font
+// which has not been rejected by the parser, the error is recorded in Err and
+// the contents of the buffers are undefined. Lookups are not applied to a
+// buffer state with Err set.
 type BufferState struct {
 	Glyphs       GlyphBuffer
 	Pos          PosBuffer
 	Index        int
+	NumGlyphs    int           // number of glyphs of the font; 0 disables glyph ID validation
+	Budget       *LookupBudget // limits for lookup applications, or nil
+	Log          *EditLog      // log of edits, or nil
+	Err          error         // internal error which aborted applying a lookup, or nil
 	glyphsShared bool
 	posShared    bool
+	depth        int      // nesting depth of sequence lookups
+	maxLen       int      // maximum length of Glyphs, 0 if not yet set
+	edit         EditSpan // last edit, handed out to avoid allocating spans
+}
+
+// DefaultMaxNesting is the maximum nesting depth of sequence lookups, i.e.
+// lookups invoked from contextual or chaining contextual lookups, if not
+// configured otherwise by a [LookupBudget]. Deeper nested lookups are not
+// applied, which guards against recursive lookups of malformed fonts.
+const DefaultMaxNesting = 64
+
+// DefaultMaxGrowthFactor is the maximum factor by which multiple substitutions
+// may grow a glyph buffer, if not configured otherwise by a [LookupBudget].
+// Substitutions which would grow a buffer further are not applied, which guards
+// against fonts decomposing glyphs recursively without bounds.
+const DefaultMaxGrowthFactor = 64
+
+// LookupBudget limits the work done by applying lookups to a [BufferState],
+// protecting clients from fonts with pathological lookups. A budget may be
+// shared by several buffer states, e.g., for all runs of a paragraph.
+//
+// Once a limit has been hit, lookups are not applied any more (or not nested
+// any deeper) and the corresponding flag is set. Clients are expected to check
+// the flags after applying a feature and to report an error.
+type LookupBudget struct {
+	MaxLookups int // maximum number of lookup applications; 0 for unlimited
+	MaxNesting int // maximum nesting depth of sequence lookups; 0 for DefaultMaxNesting
+	// maximum factor by which multiple substitutions may grow a buffer; 0 for DefaultMaxGrowthFactor
+	MaxGrowthFactor int
+
+	Lookups         int  // number of lookup applications so far, including nested ones
+	LookupsExceeded bool // set if a lookup has been refused because of MaxLookups
+	NestingExceeded bool // set if a nested lookup has been refused because of MaxNesting
+	GrowthExceeded  bool // set if a substitution has been refused because of MaxGrowthFactor
+}
+
+// Exceeded reports whether one of the limits of b has been hit.
+func (b *LookupBudget) Exceeded() bool {
+	return b != nil && (b.LookupsExceeded || b.NestingExceeded || b.GrowthExceeded)
+}
+
+// charge counts a lookup application. It returns false if the application
+// would exceed b.MaxLookups. A nil budget is unlimited.
+func (b *LookupBudget) charge() bool {
+	if b == nil {
+		return true
+	}
+	if b.MaxLookups > 0 && b.Lookups >= b.MaxLookups {
+		b.LookupsExceeded = true
+		return false
+	}
+	b.Lookups++
+	return true
+}
+
+// maxNesting returns the effective nesting limit of b. A nil budget uses
+// DefaultMaxNesting.
+func (b *LookupBudget) maxNesting() int {
+	if b == nil || b.MaxNesting <= 0 {
+		return DefaultMaxNesting
+	}
+	return b.MaxNesting
+}
+
+// maxGrowthFactor returns the effective growth factor of b. A nil budget uses
+// DefaultMaxGrowthFactor.
+func (b *LookupBudget) maxGrowthFactor() int {
+	if b == nil || b.MaxGrowthFactor <= 0 {
+		return DefaultMaxGrowthFactor
+	}
+	return b.MaxGrowthFactor
 }
 
 // NewBufferState constructs a buffer state with index 0.
@@ -271,6 +602,10 @@ func (b *BufferState) CloneShared() *BufferState {
 		Glyphs:       b.Glyphs,
 		Pos:          b.Pos,
 		Index:        b.Index,
+		Budget:       b.Budget,
+		Log:          b.Log,
+		depth:        b.depth,
+		maxLen:       b.maxLen,
 		glyphsShared: true,
 		posShared:    true,
 	}
@@ -312,9 +647,43 @@ func (b *BufferState) At(i int) ot.GlyphIndex {
 	return b.Glyphs.At(i)
 }
 
-func (b *BufferState) Set(i int, g ot.GlyphIndex) {
+// Set replaces the glyph at index i with g. It returns false and leaves the
+// buffer unchanged if g is not a valid glyph ID (see NumGlyphs).
+func (b *BufferState) Set(i int, g ot.GlyphIndex) bool {
+	if !b.validGlyphs(g) {
+		return false
+	}
 	b.ensureUniqueGlyphs()
 	b.Glyphs.Set(i, g)
+	return true
+}
+
+// validGlyphs reports whether all glyphs are valid glyph IDs with respect to
+// NumGlyphs. Invalid glyph IDs are reported as errors.
+func (b *BufferState) validGlyphs(glyphs ...ot.GlyphIndex) bool {
+	if b == nil || b.NumGlyphs <= 0 {
+		return true
+	}
+	for _, g := range glyphs {
+		if int(g) >= b.NumGlyphs {
+			tracer().Errorf("rejecting glyph ID %d, exceeds maxp.NumGlyphs %d", g, b.NumGlyphs)
+			return false
+		}
+	}
+	return true
+}
+
+// mayGrow reports whether the glyph buffer may grow by n glyphs without
+// exceeding its growth limit. If it may not, the budget is flagged.
+func (b *BufferState) mayGrow(n int) bool {
+	if b == nil || b.maxLen == 0 || len(b.Glyphs)+n <= b.maxLen {
+		return true
+	}
+	tracer().Infof("refusing to grow glyph buffer beyond %d glyphs", b.maxLen)
+	if b.Budget != nil {
+		b.Budget.GrowthExceeded = true
+	}
+	return false
 }
 
 // ApplyEdit mirrors a GSUB edit onto the position buffer to keep alignment.
@@ -346,16 +715,31 @@ func (b *BufferState) EnsurePos() {
 }
 
 // ReplaceGlyphs replaces the range [i:j) with repl and mirrors the edit into Pos when present.
+// If repl contains an invalid glyph ID (see NumGlyphs), ReplaceGlyphs leaves the
+// buffer unchanged and returns nil.
 func (b *BufferState) ReplaceGlyphs(i, j int, repl []ot.GlyphIndex) *EditSpan {
+	return b.replaceGlyphs(i, j, repl, 0)
+}
+
+// replaceGlyphs replaces the range [i:j) with repl. components marks the
+// ligature components within [i:j) if repl is a ligature (see EditSpan).
+func (b *BufferState) replaceGlyphs(i, j int, repl []ot.GlyphIndex, components uint64) *EditSpan {
 	if b == nil {
 		return nil
 	}
 	if i < 0 || j < i || j > len(b.Glyphs) {
 		panic("BufferState.ReplaceGlyphs: invalid range")
 	}
+	if !b.validGlyphs(repl...) {
+		return nil
+	}
 	b.ensureUniqueGlyphs()
-	b.Glyphs = b.Glyphs.Replace(i, j, repl)
-	edit := &EditSpan{From: i, To: j, Len: len(repl)}
+	b.Glyphs = b.Glyphs.replaceInPlace(i, j, repl)
+	edit := b.recordEdit(i, j, len(repl))
+	edit.Components = components
+	if b.Log != nil && (components != 0 || len(repl) != j-i) {
+		*b.Log = append(*b.Log, *edit)
+	}
 	if b.Pos != nil {
 		b.ensureUniquePos()
 		b.Pos = b.Pos.ApplyEdit(edit)
@@ -363,6 +747,13 @@ func (b *BufferState) ReplaceGlyphs(i, j int, repl []ot.GlyphIndex) *EditSpan {
 	return edit
 }
 
+// recordEdit returns an edit span for [from:to) replaced by n glyphs. The span
+// is owned by the buffer state and is valid until the next edit.
+func (b *BufferState) recordEdit(from, to, n int) *EditSpan {
+	b.edit = EditSpan{From: from, To: to, Len: n}
+	return &b.edit
+}
+
 // InsertGlyphs inserts glyphs before index i.
 func (b *BufferState) InsertGlyphs(i int, glyphs []ot.GlyphIndex) *EditSpan {
 	return b.ReplaceGlyphs(i, i, glyphs)
@@ -411,6 +802,18 @@ type PosItem struct {
 	AttachClass uint16
 	AnchorRef   AnchorRef
 
+	// Scale is the factor by which a synthesized glyph, e.g. a fallback small
+	// capital, is scaled relative to its outline; advances and offsets already
+	// include the scaling. 0 means the glyph is not scaled.
+	Scale float32
+	// Embolden is the stroke delta in font units by which the outline of a
+	// glyph of a synthetic bold style is to be emboldened; the advance of
+	// spacing glyphs already includes it. Shear is the horizontal shear of
+	// the outline of a glyph of a synthetic oblique style (x' = x + Shear*y);
+	// the offsets already include it.
+	Embolden int32
+	Shear    float32
+
 	Cluster uint32 // potentially used for shaping
 	Flags   uint16 // TODO
 }
@@ -447,6 +850,12 @@ func (pb PosBuffer) ResizeLike(buf GlyphBuffer) PosBuffer {
 }
 
 // ApplyEdit mirrors a GSUB edit to keep positional data aligned with glyph indices.
+//
+// Items of glyphs outside the edited range are kept, with attachments re-mapped
+// by edit.MapIndex: glyphs attached to a glyph of the replaced range are
+// attached to the first glyph replacing it, and are detached if the range has
+// been deleted. Items of replacing glyphs are reset, but inherit the lowest
+// cluster of the replaced range.
 func (pb PosBuffer) ApplyEdit(edit *EditSpan) PosBuffer {
 	if edit == nil {
 		return pb
@@ -458,11 +867,115 @@ func (pb PosBuffer) ApplyEdit(edit *EditSpan) PosBuffer {
 	for i := range repl {
 		repl[i].AttachTo = -1
 	}
+	if edit.To > edit.From {
+		cluster := pb[edit.From].Cluster
+		for _, item := range pb[edit.From+1 : edit.To] {
+			cluster = min(cluster, item.Cluster)
+		}
+		for i := range repl {
+			repl[i].Cluster = cluster
+		}
+	}
 	out := append(pb[:edit.From:edit.From], repl...)
 	out = append(out, pb[edit.To:]...)
+	for i := range out {
+		if i >= edit.From && i < edit.From+edit.Len || out[i].AttachTo < 0 {
+			continue
+		}
+		if j, ok := edit.MapIndex(int(out[i].AttachTo)); ok {
+			out[i].AttachTo = int32(j)
+		} else {
+			out[i].AttachTo, out[i].AttachKind = -1, AttachNone
+			out[i].AnchorRef = AnchorRef{}
+		}
+	}
 	return out
 }
 
+// ResolveAttachments makes the offsets of marks attached by GPOS lookups
+// relative to the origin of the mark.
+//
+// Applying a mark attachment lookup sets the offsets of the mark relative to
+// the origin of the glyph it is attached to. For a mark attached to a mark
+// (mkmk), which may in turn be attached to a base, the offsets of the glyph it
+// is attached to are resolved first, so stacked marks are positioned along the
+// complete attachment chain. advance returns the advance of glyph i, including
+// any adjustments; backward is true for glyphs in logical order to be laid out
+// right to left.
+//
+// ResolveAttachments is to be called once, after all GPOS lookups have been
+// applied. Cursive attachments are left unchanged.
+func (pb PosBuffer) ResolveAttachments(advance func(i int) (x, y int32), backward bool) {
+	ordered := true // marks are attached to preceding glyphs only
+	for i := range pb {
+		if j, ok := pb.markParent(i); ok && j > i {
+			ordered = false
+			break
+		}
+	}
+	if ordered {
+		for i := range pb {
+			if j, ok := pb.markParent(i); ok {
+				pb.resolveAttachment(i, j, advance, backward)
+			}
+		}
+		return
+	}
+	const (
+		unresolved = iota
+		resolving
+		resolved
+	)
+	state := make([]uint8, len(pb))
+	var resolve func(i int)
+	resolve = func(i int) {
+		if state[i] != unresolved {
+			return // resolved, or a cycle of attachments
+		}
+		state[i] = resolving
+		if j, ok := pb.markParent(i); ok {
+			resolve(j)
+			pb.resolveAttachment(i, j, advance, backward)
+		}
+		state[i] = resolved
+	}
+	for i := range pb {
+		resolve(i)
+	}
+}
+
+// markParent returns the index of the glyph mark i is attached to, if glyph i
+// is a mark attached by a mark attachment lookup.
+func (pb PosBuffer) markParent(i int) (int, bool) {
+	switch pb[i].AttachKind {
+	case AttachMarkToBase, AttachMarkToLigature, AttachMarkToMark:
+		j := int(pb[i].AttachTo)
+		return j, j >= 0 && j < len(pb) && j != i
+	}
+	return -1, false
+}
+
+// resolveAttachment makes the offsets of mark i relative to its origin, with
+// the offsets of glyph j, which mark i is attached to, already resolved.
+func (pb PosBuffer) resolveAttachment(i, j int, advance func(i int) (x, y int32), backward bool) {
+	p := &pb[i]
+	p.XOffset += pb[j].XOffset
+	p.YOffset += pb[j].YOffset
+	// move from the origin of glyph j to the origin of glyph i
+	from, to, sign := j, i, int32(-1)
+	if j > i {
+		from, to, sign = i, j, 1
+	}
+	if backward {
+		from, to, sign = from+1, to+1, -sign
+	}
+	for k := from; k < to; k++ {
+		x, y := advance(k)
+		p.XOffset += sign * x
+		p.YOffset += sign * y
+	}
+}
+
 func applyLookupConcrete(
 	clookup *ot.LookupTable,
 	lookupGraph *ot.LookupListGraph,
@@ -470,6 +983,20 @@ func applyLookupConcrete(
 	st *BufferState,
 	alt int,
 	gdef *ot.GDefTable,
+) (int, bool, *EditSpan) {
+	return applyLookupMode(clookup, lookupGraph, feat, st, alt, gdef, false)
+}
+
+// applyLookupMode applies a lookup at st.Index. If reverse is set, reverse
+// chaining subtables are matched at st.Index only (see ApplyFeatureReverse).
+func applyLookupMode(
+	clookup *ot.LookupTable,
+	lookupGraph *ot.LookupListGraph,
+	feat Feature,
+	st *BufferState,
+	alt int,
+	gdef *ot.GDefTable,
+	reverse bool,
 ) (int, bool, *EditSpan) {
 	if clookup == nil {
 		if st != nil {
@@ -477,17 +1004,27 @@ func applyLookupConcrete(
 		}
 		return 0, false, nil
 	}
-	ctx := applyCtx{
-		feat:        feat,
-		clookup:     clookup,
-		lookupGraph: lookupGraph,
-		buf:         st,
-		pos:         st.Index,
-		alt:         alt,
-		flag:        clookup.Flag,
-		gdef:        gdef,
-	}
-	pos, ok, buf, pbuf, edit := dispatchLookup(&ctx)
+	if st != nil && st.NumGlyphs == 0 {
+		st.NumGlyphs = lookupGraph.NumGlyphs()
+	}
+	if st != nil && st.maxLen == 0 {
+		st.maxLen = st.Budget.maxGrowthFactor() * max(len(st.Glyphs), 1)
+	}
+	if st != nil && !st.Budget.charge() {
+		return st.Index, false, nil
+	}
+	ctx := applyCtxPool.Get().(*applyCtx)
+	defer ctx.release()
+	ctx.feat = feat
+	ctx.clookup = clookup
+	ctx.lookupGraph = lookupGraph
+	ctx.buf = st
+	ctx.pos = st.Index
+	ctx.alt = alt
+	ctx.flag = clookup.Flag
+	ctx.gdef = gdef
+	ctx.reverse = reverse
+	pos, ok, buf, pbuf, edit := dispatchLookup(ctx)
 	if st != nil {
 		if buf != nil {
 			st.Glyphs = buf
@@ -495,9 +1032,7 @@ func applyLookupConcrete(
 		if pbuf != nil {
 			st.Pos = pbuf
 		}
-		if edit != nil {
-			st.ApplyEdit(edit)
-		}
+		// edits have been mirrored onto st.Pos by st.ReplaceGlyphs
 		st.Index = pos
 	}
 	return pos, ok, edit
@@ -512,21 +1047,70 @@ func dispatchLookup(ctx *applyCtx) (int, bool, GlyphBuffer, PosBuffer, *EditSpan
 	if isGPos {
 		lookupType = ot.GPosLookupType(ctx.clookup.Type)
 	}
-	tracer().Debugf("applying lookup '%s'/%d flags=0x%04x", ctx.feat.Tag(), lookupType, uint16(ctx.clookup.Flag))
+	if traceDebug() {
+		tracer().Debugf("applying lookup '%s'/%d flags=0x%04x", ctx.feat.Tag(), lookupType, uint16(ctx.clookup.Flag))
+	}
+	if ctx.reverse {
+		return dispatchSubtables(ctx, isGPos)
+	}
+	if ctx.buf.depth > 0 {
+		// Lookups invoked by sequence lookup records apply at the position of
+		// the record only. Searching ahead would apply them outside of the
+		// matched context, and lets recursive lookups fan out.
+		if ctx.clookup.FirstGlyphs().Contains(ctx.buf.Glyphs.At(ctx.pos)) {
+			if pos, ok, buf, pbuf, edit := dispatchSubtables(ctx, isGPos); ok {
+				return pos, ok, buf, pbuf, edit
+			}
+		}
+		return ctx.pos, false, ctx.buf.Glyphs, ctx.buf.Pos, nil
+	}
+	// Search for the first position at which one of the subtables matches.
+	// Subtables are tried in order at each position, so that an earlier
+	// subtable matching further ahead does not hide a later subtable
+	// matching at the current position.
+	start, first := ctx.pos, ctx.clookup.FirstGlyphs()
+	for p := start; p < ctx.buf.Glyphs.Len(); {
+		mpos, ok := nextMatchable(ctx, ctx.buf.Glyphs, p)
+		if !ok {
+			break
+		}
+		if first.Contains(ctx.buf.Glyphs.At(mpos)) {
+			ctx.pos = mpos
+			if pos, ok, buf, pbuf, edit := dispatchSubtables(ctx, isGPos); ok {
+				return pos, ok, buf, pbuf, edit
+			}
+		}
+		p = mpos + 1
+	}
+	ctx.pos = start
+	return ctx.pos, false, ctx.buf.Glyphs, ctx.buf.Pos, nil
+}
+
+// dispatchSubtables tries the subtables of the current lookup, in order, at
+// ctx.pos and returns the result of the first one matching.
+func dispatchSubtables(ctx *applyCtx, isGPos bool) (int, bool, GlyphBuffer, PosBuffer, *EditSpan) {
 	for i := 0; i < int(ctx.clookup.SubTableCount) && ctx.pos < ctx.buf.Glyphs.Len(); i++ {
-		tracer().Debugf("-------------------- pos = %d", ctx.pos)
-		subnode := effectiveLookupNode(ctx.clookup.Subtable(i))
+		subnode := ctx.clookup.Subtable(i).Unwrap()
 		ctx.subnode = subnode
 		if subnode == nil {
 			continue
 		}
+		if err := subnode.Error(); err != nil {
+			// Partially parsed subtable; the font error has been reported on first access.
+			if traceDebug() {
+				tracer().Debugf("skipping damaged subtable #%d: %v", i, err)
+			}
+			continue
+		}
 		subType := subnode.LookupType
 		if isGPos {
 			subType = ot.GPosLookupType(subType)
 		} else {
 			subType = ot.GSubLookupType(subType)
 		}
-		tracer().Debugf("subtable #%d type %d format %d", i, subType, subnode.Format)
+		if traceDebug() {
+			tracer().Debugf("subtable #%d type %d format %d at pos %d", i, subType, subnode.Format, ctx.pos)
+		}
 		var (
 			pos  int
 			ok   bool
@@ -585,7 +1169,10 @@ func dispatchGSubLookup(ctx *applyCtx, sub *ot.LookupNode) (int, bool, GlyphBuff
 			pos, ok, buf, edit = gsubLookupType6Fmt3(ctx, sub, ctx.buf.Glyphs, ctx.pos)
 		}
 	case ot.GSubLookupTypeExtensionSubs:
-		tracer().Errorf("GSUB extension subtable reached dispatch; extension should be unwrapped during parsing")
+		// Unresolved extension subtable; the font error has been reported during parsing.
+		if traceDebug() {
+			tracer().Debugf("skipping unresolved GSUB extension subtable: %v", sub.Error())
+		}
 	case ot.GSubLookupTypeReverseChaining:
 		switch sub.Format {
 		case 1:
@@ -668,11 +1255,12 @@ func dispatchGPosLookup(ctx *applyCtx, sub *ot.LookupNode) (int, bool, GlyphBuff
 		}
 		//tracer().Errorf("GPOS lookup type %d/%d not implemented", sub.LookupType, sub.Format)
 	case ot.GPosLookupTypeExtensionPos:
-		tracer().Errorf("GPOS extension subtable reached dispatch; extension should be unwrapped during parsing")
-		panic("GPOS extension subtable reached dispatch, should be unwrapped during parsing")
+		// Unresolved extension subtable; the font error has been reported during parsing.
+		if traceDebug() {
+			tracer().Debugf("skipping unresolved GPOS extension subtable: %v", sub.Error())
+		}
 	default:
 		tracer().Errorf("unknown GPOS lookup type %d/%d", subType, sub.Format)
-		panic("unknown GPOS lookup type")
 	}
 	return pos, ok, buf, ctx.buf.Pos, edit
 }
@@ -700,8 +1288,8 @@ func skipGlyph(ctx *applyCtx, g ot.GlyphIndex) bool {
 	}
 	if class == ot.MarkGlyph {
 		if ctx.flag&ot.LOOKUP_FLAG_USE_MARK_FILTERING_SET != 0 {
-			setIndex := ctx.clookup.MarkFilteringSet()
-			if !inMarkFilteringSet(ctx.gdef, setIndex, g) {
+			setIndex, ok := ctx.clookup.UsesMarkFilteringSet()
+			if !ok || !inMarkFilteringSet(ctx.gdef, setIndex, g) {
 				return true
 			}
 		}
@@ -735,18 +1323,17 @@ func prevMatchable(ctx *applyCtx, buf GlyphBuffer, pos int) (int, bool) {
 type singleMatchFn func(ctx *applyCtx, buf GlyphBuffer, pos int) (int, bool)
 type matchSeqFn func(ctx *applyCtx, buf GlyphBuffer, pos int) ([]int, bool)
 
+// matchCoverageForward matches cov against the first glyph at or after pos
+// which is not skipped by the lookup flags. Searching for a matching position
+// is left to dispatchLookup.
 func matchCoverageForward(ctx *applyCtx, buf GlyphBuffer, pos int, cov ot.Coverage) (mpos, inx int, ok bool) {
-	for i := pos; i < buf.Len(); {
-		mpos, ok = nextMatchable(ctx, buf, i)
-		if !ok {
-			return 0, 0, false
-		}
-		if inx, ok = cov.Match(buf.At(mpos)); ok {
-			return
-		}
-		i = mpos + 1
+	if mpos, ok = nextMatchable(ctx, buf, pos); !ok {
+		return 0, 0, false
+	}
+	if inx, ok = cov.Match(buf.At(mpos)); !ok {
+		return 0, 0, false
 	}
-	return 0, 0, false
+	return mpos, inx, true
 }
 
 type matchingCoveraveCtx struct {
@@ -818,7 +1405,7 @@ func matchCoverageSequence(ctx *applyCtx, buf GlyphBuffer, matchCtx matchingCove
 	if len(matchCtx.covs) == 0 {
 		return nil, false
 	}
-	out := make([]int, len(matchCtx.covs))
+	out := ctx.scratchInts(len(matchCtx.covs))
 	cur := matchCtx.pos + matchCtx.offset
 	for i, cov := range matchCtx.covs {
 		mpos, ok := matchCtx.matcher(ctx, buf, cur)
@@ -834,17 +1421,23 @@ func matchCoverageSequence(ctx *applyCtx, buf GlyphBuffer, matchCtx matchingCove
 	return out, true
 }
 
-func buildInputMap(matchPositions []int) []int {
-	out := make([]int, len(matchPositions))
+func buildInputMap(ctx *applyCtx, matchPositions []int) []int {
+	out := ctx.scratchInts(len(matchPositions))
 	copy(out, matchPositions)
 	return out
 }
 
+// glyphClass returns the GDEF glyph class of gid. Like Harfbuzz, glyphs
+// without a class assigned are treated as base glyphs.
 func glyphClass(gdef *ot.GDefTable, gid ot.GlyphIndex) ot.GlyphClassDefEnum {
 	if gdef == nil {
 		return 0
 	}
-	return ot.GlyphClassDefEnum(gdef.GlyphClassDef.Lookup(gid))
+	class := ot.GlyphClassDefEnum(gdef.GlyphClassDef.Lookup(gid))
+	if class == 0 {
+		return ot.BaseGlyph
+	}
+	return class
 }
 
 func markAttachClass(gdef *ot.GDefTable, gid ot.GlyphIndex) uint16 {
@@ -873,19 +1466,6 @@ func markAttachmentType(flag ot.LayoutTableLookupFlag) uint16 {
 	return uint16((flag & ot.LOOKUP_FLAG_MARK_ATTACHMENT_TYPE_MASK) >> 8)
 }
 
-func effectiveLookupNode(n *ot.LookupNode) *ot.LookupNode {
-	if n == nil {
-		return nil
-	}
-	if p := n.GSubPayload(); p != nil && p.ExtensionFmt1 != nil && p.ExtensionFmt1.Resolved != nil {
-		return p.ExtensionFmt1.Resolved
-	}
-	if p := n.GPosPayload(); p != nil && p.ExtensionFmt1 != nil && p.ExtensionFmt1.Resolved != nil {
-		return p.ExtensionFmt1.Resolved
-	}
-	return n
-}
-
 type matchingGlyphCtx struct {
 	glyphs  []ot.GlyphIndex
 	pos     int
@@ -954,7 +1534,7 @@ func matchGlyphSequence(ctx *applyCtx, buf GlyphBuffer, matchCtx matchingGlyphCt
 	if len(matchCtx.glyphs) == 0 {
 		return nil, false
 	}
-	out := make([]int, len(matchCtx.glyphs))
+	out := ctx.scratchInts(len(matchCtx.glyphs))
 	cur := matchCtx.pos + matchCtx.offset
 	for i, gid := range matchCtx.glyphs {
 		mpos, ok := matchCtx.matcher(ctx, buf, cur)
@@ -1038,7 +1618,7 @@ func matchClassSequence(ctx *applyCtx, buf GlyphBuffer, matchCtx matchingClassCt
 	if len(matchCtx.classes) == 0 {
 		return nil, false
 	}
-	out := make([]int, len(matchCtx.classes))
+	out := ctx.scratchInts(len(matchCtx.classes))
 	cur := matchCtx.pos + matchCtx.dir
 	for i, clz := range matchCtx.classes {
 		mpos, ok := matchCtx.matcher(ctx, buf, cur)
@@ -1074,23 +1654,32 @@ func matchChainedForward(ctx *applyCtx, buf GlyphBuffer, pos int, backtrack, inp
 }
 
 func applySequenceLookupRecords(
+	ctx *applyCtx,
 	buf GlyphBuffer,
 	posBuf PosBuffer,
 	matchPositions []int,
 	records []ot.SequenceLookupRecord,
-	lookupGraph *ot.LookupListGraph,
-	feat Feature,
-	alt int,
-	gdef *ot.GDefTable,
 ) (GlyphBuffer, PosBuffer, bool) {
-	mapIdx := buildInputMap(matchPositions)
-	if lookupGraph == nil || len(mapIdx) == 0 {
+	mapIdx := buildInputMap(ctx, matchPositions)
+	if ctx.lookupGraph == nil || len(mapIdx) == 0 {
+		return buf, posBuf, false
+	}
+	budget, depth := ctx.buf.Budget, ctx.buf.depth+1
+	if depth > budget.maxNesting() {
+		if traceDebug() {
+			tracer().Debugf("sequence lookups nested too deeply, depth=%d", depth)
+		}
+		if budget != nil {
+			budget.NestingExceeded = true
+		}
 		return buf, posBuf, false
 	}
 
 	applied := false
 	for _, rec := range records {
-		tracer().Debugf("sequence lookup record: seq=%d lookup=%d", rec.SequenceIndex, rec.LookupListIndex)
+		if traceDebug() {
+			tracer().Debugf("sequence lookup record: seq=%d lookup=%d", rec.SequenceIndex, rec.LookupListIndex)
+		}
 		seqIndex := int(rec.SequenceIndex)
 		if seqIndex < 0 || seqIndex >= len(mapIdx) {
 			continue
@@ -1099,11 +1688,22 @@ func applySequenceLookupRecords(
 		if targetPos < 0 || targetPos >= buf.Len() {
 			continue
 		}
-		tracer().Debugf("sequence lookup record: target position %d", targetPos)
-		clookup := lookupGraph.Lookup(int(rec.LookupListIndex))
-		st := NewBufferState(buf, posBuf)
-		st.Index = targetPos
-		_, ok, edit := applyLookupConcrete(clookup, lookupGraph, feat, st, alt, gdef)
+		clookup := ctx.lookupGraph.Lookup(int(rec.LookupListIndex))
+		st := &ctx.nested
+		*st = BufferState{
+			Glyphs:    buf,
+			Pos:       posBuf,
+			Index:     targetPos,
+			NumGlyphs: ctx.buf.NumGlyphs,
+			Budget:    budget,
+			Log:       ctx.buf.Log,
+			depth:     depth,
+			maxLen:    ctx.buf.maxLen,
+		}
+		if posBuf != nil && len(posBuf) != len(buf) {
+			st.Pos = posBuf.ResizeLike(buf)
+		}
+		_, ok, edit := applyLookupConcrete(clookup, ctx.lookupGraph, ctx.feat, st, ctx.alt, ctx.gdef)
 		if !ok {
 			continue
 		}
@@ -1113,19 +1713,9 @@ func applySequenceLookupRecords(
 		if edit == nil {
 			continue
 		}
-		delta := edit.Len - (edit.To - edit.From)
 		for i := range mapIdx {
-			if mapIdx[i] < 0 {
-				continue
-			}
-			if mapIdx[i] >= edit.To {
-				mapIdx[i] += delta
-			} else if mapIdx[i] >= edit.From {
-				if edit.Len == 0 {
-					mapIdx[i] = -1
-				} else {
-					mapIdx[i] = edit.From
-				}
+			if mapIdx[i] >= 0 {
+				mapIdx[i], _ = edit.MapIndex(mapIdx[i])
 			}
 		}
 	}
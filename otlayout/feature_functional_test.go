@@ -13,10 +13,11 @@ type testFeature struct {
 	typ LayoutTagType
 }
 
-func (f testFeature) Tag() ot.Tag         { return f.tag }
-func (f testFeature) Type() LayoutTagType { return f.typ }
-func (f testFeature) LookupCount() int    { return 0 }
-func (f testFeature) LookupIndex(int) int { return 0 }
+func (f testFeature) Tag() ot.Tag                      { return f.tag }
+func (f testFeature) Type() LayoutTagType              { return f.typ }
+func (f testFeature) LookupCount() int                 { return 0 }
+func (f testFeature) LookupIndex(int) int              { return 0 }
+func (f testFeature) AlternateCount(ot.GlyphIndex) int { return 0 }
 
 func loadTestFont(t *testing.T, filename string) *ot.Font {
 	t.Helper()
@@ -37,17 +38,11 @@ func applyGSUBLookup(t *testing.T, otf *ot.Font, lookupIndex int, input []ot.Gly
 	if otf.Layout.GSub == nil {
 		t.Fatalf("font has no GSUB table")
 	}
-	var clookup *ot.LookupTable
-	if graph := otf.Layout.GSub.LookupGraph(); graph != nil {
-		clookup = graph.Lookup(lookupIndex)
-	}
-	feat := testFeature{tag: ot.T("test"), typ: GSubFeatureType}
 	buf := append(GlyphBuffer(nil), input...)
 	st := NewBufferState(buf, NewPosBuffer(len(buf)))
 	st.Index = pos
-	_, ok, _ := applyLookupConcrete(clookup, otf.Layout.GSub.LookupGraph(), feat, st, alt, otf.Layout.GDef)
-	out := st.Glyphs
-	return out, ok
+	_, ok := ApplyLookup(otf, GSubFeatureType, lookupIndex, st, alt)
+	return st.Glyphs, ok
 }
 
 func TestGSUBAlternateSimple(t *testing.T) {
@@ -54,3 +54,81 @@ func TestDispatchGSubLookupSingleFmt1Routing(t *testing.T) {
 		t.Fatalf("expected glyph 12, got %d", buf[0])
 	}
 }
+
+func TestEditSpanMapIndex(t *testing.T) {
+	tests := []struct {
+		edit EditSpan
+		in   []int
+		out  []int // -1 for deleted
+	}{
+		{EditSpan{From: 1, To: 3, Len: 1}, []int{0, 1, 2, 3, 4}, []int{0, 1, 1, 2, 3}},
+		{EditSpan{From: 1, To: 2, Len: 3}, []int{0, 1, 2}, []int{0, 1, 4}},
+		{EditSpan{From: 1, To: 1, Len: 2}, []int{0, 1, 2}, []int{0, 3, 4}},
+		{EditSpan{From: 1, To: 3, Len: 0}, []int{0, 1, 2, 3}, []int{0, -1, -1, 1}},
+	}
+	for _, tt := range tests {
+		for k, i := range tt.in {
+			j, ok := tt.edit.MapIndex(i)
+			if ok != (tt.out[k] >= 0) || ok && j != tt.out[k] {
+				t.Errorf("%+v: expected index %d to map to %d, have %d/%v", tt.edit, i, tt.out[k], j, ok)
+			}
+		}
+	}
+}
+
+func TestPosBufferApplyEditDetachesDeleted(t *testing.T) {
+	pb := NewPosBuffer(4)
+	pb[1].AttachTo, pb[1].AttachKind = 0, AttachCursive
+	pb[3].AttachTo, pb[3].AttachKind = 2, AttachMarkToBase
+	pb[3].AnchorRef = AnchorRef{MarkAnchor: 1, BaseAnchor: 2}
+	out := pb.ApplyEdit(&EditSpan{From: 2, To: 3, Len: 0})
+	if len(out) != 3 {
+		t.Fatalf("expected 3 positions, have %d", len(out))
+	}
+	if out[1].AttachTo != 0 || out[1].AttachKind != AttachCursive {
+		t.Errorf("expected attachment before the edit to be kept, have %+v", out[1])
+	}
+	if out[2].AttachTo != -1 || out[2].AttachKind != AttachNone || out[2].AnchorRef != (AnchorRef{}) {
+		t.Errorf("expected mark of deleted base to be detached, have %+v", out[2])
+	}
+}
+
+func TestPosBufferResolveAttachments(t *testing.T) {
+	// base, mark attached to base, mark attached to mark, spacing glyph
+	chain := func() PosBuffer {
+		pb := NewPosBuffer(4)
+		pb[1].AttachTo, pb[1].AttachKind = 0, AttachMarkToBase
+		pb[1].XOffset, pb[1].YOffset = 150, 100
+		pb[2].AttachTo, pb[2].AttachKind = 1, AttachMarkToMark
+		pb[2].XOffset, pb[2].YOffset = 10, 300
+		pb[3].AttachTo, pb[3].AttachKind = 2, AttachCursive
+		return pb
+	}
+	advances := []int32{500, 20, 0, 400}
+	advance := func(i int) (int32, int32) { return advances[i], 0 }
+	pb := chain()
+	pb.ResolveAttachments(advance, false)
+	want := [][2]int32{{0, 0}, {-350, 100}, {-360, 400}, {0, 0}}
+	for i, w := range want {
+		if pb[i].XOffset != w[0] || pb[i].YOffset != w[1] {
+			t.Errorf("left to right: expected offsets %v of glyph %d, have (%d, %d)", w, i, pb[i].XOffset, pb[i].YOffset)
+		}
+	}
+	// right to left, the base is right of its marks
+	pb = chain()
+	pb.ResolveAttachments(advance, true)
+	want = [][2]int32{{0, 0}, {170, 100}, {180, 400}, {0, 0}}
+	for i, w := range want {
+		if pb[i].XOffset != w[0] || pb[i].YOffset != w[1] {
+			t.Errorf("right to left: expected offsets %v of glyph %d, have (%d, %d)", w, i, pb[i].XOffset, pb[i].YOffset)
+		}
+	}
+	// a mark attached to a following mark is resolved after it
+	pb = NewPosBuffer(3)
+	pb[1].AttachTo, pb[1].AttachKind, pb[1].XOffset = 2, AttachMarkToMark, 5
+	pb[2].AttachTo, pb[2].AttachKind, pb[2].XOffset = 0, AttachMarkToBase, 100
+	pb.ResolveAttachments(advance, false)
+	if pb[2].XOffset != -420 || pb[1].XOffset != -395 {
+		t.Errorf("expected offsets -395 and -420, have %d and %d", pb[1].XOffset, pb[2].XOffset)
+	}
+}
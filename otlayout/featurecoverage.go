@@ -0,0 +1,101 @@
+package otlayout
+
+import (
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// FeatureCoverage is an inverted coverage index of a font: it maps glyphs to the
+// GSUB and GPOS features with a lookup which may start a match at the glyph.
+// Font editors and inspection tools use it to tell which features may affect a
+// glyph, without walking all lookups for every glyph.
+//
+// Features are indexed regardless of the scripts and languages they are
+// registered for. Lookups with damaged subtables, for which the glyphs cannot
+// be determined, are taken to cover every glyph.
+type FeatureCoverage struct {
+	glyphs    [2]map[ot.GlyphIndex][]ot.Tag // features covering a glyph, for GSUB and GPOS
+	universal [2][]ot.Tag                   // features covering every glyph, for GSUB and GPOS
+}
+
+type featureCoverageKey struct{}
+
+// FontFeatureCoverage returns the feature coverage index of font otf. The index
+// is built on first request and cached with the font, so repeated calls do not
+// walk the lookups of the font again. Clients changing a font drop the index with
+// InvalidateFeatureCoverage.
+func FontFeatureCoverage(otf *ot.Font) *FeatureCoverage {
+	if otf == nil {
+		return &FeatureCoverage{}
+	}
+	return otf.Derived(featureCoverageKey{}, func() any {
+		return newFeatureCoverage(otf)
+	}).(*FeatureCoverage)
+}
+
+// InvalidateFeatureCoverage drops the feature coverage index cached with font
+// otf. The next call of FontFeatureCoverage builds it again.
+func InvalidateFeatureCoverage(otf *ot.Font) {
+	if otf != nil {
+		otf.Forget(featureCoverageKey{})
+	}
+}
+
+func newFeatureCoverage(otf *ot.Font) *FeatureCoverage {
+	fc := &FeatureCoverage{}
+	var tables [2]*ot.LayoutTable
+	if gsub := otf.GSub(); gsub != nil {
+		tables[0] = &gsub.LayoutTable
+	}
+	if gpos := otf.GPos(); gpos != nil {
+		tables[1] = &gpos.LayoutTable
+	}
+	for i, table := range tables {
+		fc.glyphs[i] = make(map[ot.GlyphIndex][]ot.Tag)
+		if table == nil || table.FeatureGraph() == nil || table.LookupGraph() == nil {
+			continue
+		}
+		lookups := table.LookupGraph()
+		for tag, feat := range table.FeatureGraph().Range() {
+			if feat == nil {
+				continue
+			}
+			for j := range feat.LookupCount() {
+				first := lookups.Lookup(feat.LookupIndex(j)).FirstGlyphs()
+				if first.Universal() {
+					fc.universal[i] = append(fc.universal[i], tag)
+					continue
+				}
+				for g := range first.Glyphs().Glyphs() {
+					fc.glyphs[i][g] = append(fc.glyphs[i][g], tag)
+				}
+			}
+		}
+		for g, tags := range fc.glyphs[i] {
+			slices.Sort(tags)
+			fc.glyphs[i][g] = slices.Clip(slices.Compact(tags))
+		}
+		slices.Sort(fc.universal[i])
+		fc.universal[i] = slices.Clip(slices.Compact(fc.universal[i]))
+	}
+	return fc
+}
+
+// Features returns the tags of the GSUB and GPOS features covering glyph g,
+// sorted and without duplicates. The returned slices must not be modified.
+func (fc *FeatureCoverage) Features(g ot.GlyphIndex) (gsub, gpos []ot.Tag) {
+	features := func(i int) []ot.Tag {
+		tags := fc.glyphs[i][g]
+		if len(fc.universal[i]) == 0 {
+			return tags
+		}
+		if len(tags) == 0 {
+			return fc.universal[i]
+		}
+		merged := slices.Concat(tags, fc.universal[i])
+		slices.Sort(merged)
+		return slices.Compact(merged)
+	}
+	return features(0), features(1)
+}
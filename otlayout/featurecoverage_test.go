@@ -0,0 +1,56 @@
+package otlayout
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestFeatureCoverage(t *testing.T) {
+	b := testfont.New(5)
+	b.Name(1, "a").Name(2, "b").Name(3, "a.sc").Name(4, "f")
+	if err := b.Features(`languagesystem latn dflt;
+feature smcp { sub a by a.sc; } smcp;
+feature c2sc { sub b by a.sc; } c2sc;
+feature salt { sub a by a.sc; } salt;
+feature kern { pos a b -50; } kern;
+`); err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	fc := FontFeatureCoverage(otf)
+	if fc != FontFeatureCoverage(otf) {
+		t.Errorf("expected feature coverage to be cached with the font")
+	}
+	for _, tc := range []struct {
+		g          ot.GlyphIndex
+		gsub, gpos []ot.Tag
+	}{
+		{1, []ot.Tag{ot.T("salt"), ot.T("smcp")}, []ot.Tag{ot.T("kern")}},
+		{2, []ot.Tag{ot.T("c2sc")}, nil},
+		{4, nil, nil},
+	} {
+		gsub, gpos := fc.Features(tc.g)
+		if !slices.Equal(gsub, tc.gsub) || !slices.Equal(gpos, tc.gpos) {
+			t.Errorf("glyph %d: expected features %v/%v, have %v/%v", tc.g, tc.gsub, tc.gpos, gsub, gpos)
+		}
+	}
+	InvalidateFeatureCoverage(otf)
+	if again := FontFeatureCoverage(otf); again == fc {
+		t.Errorf("expected feature coverage to be rebuilt after invalidation")
+	}
+}
+
+func TestFeatureCoverageCalibri(t *testing.T) {
+	otf := parseFont(t, "Calibri")
+	f := otf.CMap.GlyphIndexMap.Lookup('f')
+	gsub, gpos := FontFeatureCoverage(otf).Features(f)
+	if !slices.Contains(gsub, ot.T("liga")) || !slices.Contains(gpos, ot.T("kern")) {
+		t.Errorf("expected 'f' to be covered by liga and kern, have %v/%v", gsub, gpos)
+	}
+}
@@ -0,0 +1,183 @@
+package otlayout
+
+import (
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// FeatureSet is the set of layout features a font provides for a script/language
+// pair. GSub and GPos hold the features in the order of the font's LangSys
+// table, with the required feature at index 0 (which may be nil), as returned
+// by FontFeatures.
+//
+// Feature sets retrieved by FontFeatureSet are shared and must not be modified.
+// Use Filter or Union to derive new sets.
+type FeatureSet struct {
+	Script ot.Tag    // script the set has been requested for
+	Lang   ot.Tag    // language the set has been requested for
+	GSub   []Feature // GSUB features, required feature at index 0
+	GPos   []Feature // GPOS features, required feature at index 0
+	gsubLx []int     // sorted, unique lookup indices of GSub
+	gposLx []int     // sorted, unique lookup indices of GPos
+	err    error     // error condition while collecting features
+}
+
+type featureSetKey struct {
+	script, lang ot.Tag
+}
+
+// FontFeatureSet returns the layout features of font otf for a script/language
+// pair. Feature sets are computed on first request and cached with the font,
+// so repeated calls do not navigate the font's script and feature graphs again.
+// Setting script to 0 will look for a DFLT feature set.
+func FontFeatureSet(otf *ot.Font, script, lang ot.Tag) (*FeatureSet, error) {
+	if otf == nil {
+		return nil, errFontFormat("font is nil")
+	}
+	if script == 0 {
+		script = ot.DFLT
+	}
+	fs := otf.Derived(featureSetKey{script: script, lang: lang}, func() any {
+		gsub, gpos, err := collectFontFeatures(otf, script, lang)
+		return newFeatureSet(script, lang, gsub, gpos, err)
+	}).(*FeatureSet)
+	if fs.err != nil {
+		return nil, fs.err
+	}
+	return fs, nil
+}
+
+func newFeatureSet(script, lang ot.Tag, gsub, gpos []Feature, err error) *FeatureSet {
+	return &FeatureSet{
+		Script: script,
+		Lang:   lang,
+		GSub:   gsub,
+		GPos:   gpos,
+		gsubLx: collectLookupIndices(gsub),
+		gposLx: collectLookupIndices(gpos),
+		err:    err,
+	}
+}
+
+func collectLookupIndices(feats []Feature) []int {
+	var lx []int
+	for _, f := range feats {
+		if f == nil {
+			continue
+		}
+		for i := range f.LookupCount() {
+			lx = append(lx, f.LookupIndex(i))
+		}
+	}
+	slices.Sort(lx)
+	return slices.Compact(lx)
+}
+
+// LookupIndices returns the indices of all lookups referenced by the GSUB or GPOS
+// features of the set (depending on typ), in ascending order and without duplicates.
+// This is the order in which lookups are to be applied if all features are active.
+// The returned slice must not be modified.
+func (fs *FeatureSet) LookupIndices(typ LayoutTagType) []int {
+	if fs == nil {
+		return nil
+	}
+	if typ == GPosFeatureType {
+		return fs.gposLx
+	}
+	return fs.gsubLx
+}
+
+// All returns the non-nil features of the set, GSUB features first.
+func (fs *FeatureSet) All() []Feature {
+	if fs == nil {
+		return nil
+	}
+	all := make([]Feature, 0, len(fs.GSub)+len(fs.GPos))
+	for _, f := range slices.Concat(fs.GSub, fs.GPos) {
+		if f != nil {
+			all = append(all, f)
+		}
+	}
+	return all
+}
+
+// Tags returns the tags of all features of the set, each tag reported once,
+// GSUB features first.
+func (fs *FeatureSet) Tags() []ot.Tag {
+	var tags []ot.Tag
+	for _, f := range fs.All() {
+		if !slices.Contains(tags, f.Tag()) {
+			tags = append(tags, f.Tag())
+		}
+	}
+	return tags
+}
+
+// Filter returns a new feature set containing only features with one of the
+// given tags. The required-feature slots are kept, but set to nil if the
+// required feature's tag is not contained in tags.
+func (fs *FeatureSet) Filter(tags ...ot.Tag) *FeatureSet {
+	if fs == nil {
+		return nil
+	}
+	keep := func(feats []Feature) []Feature {
+		out := make([]Feature, 0, len(feats))
+		for i, f := range feats {
+			if f != nil && slices.Contains(tags, f.Tag()) {
+				out = append(out, f)
+			} else if i == 0 {
+				out = append(out, nil) // keep required-feature slot
+			}
+		}
+		return out
+	}
+	return newFeatureSet(fs.Script, fs.Lang, keep(fs.GSub), keep(fs.GPos), nil)
+}
+
+// Union returns a new feature set containing the features of fs, followed by
+// the features of other not already contained in fs. Required features of fs take
+// precedence over those of other.
+func (fs *FeatureSet) Union(other *FeatureSet) *FeatureSet {
+	if fs == nil {
+		return other
+	} else if other == nil {
+		return fs
+	}
+	merge := func(a, b []Feature) []Feature {
+		out := slices.Clone(a)
+		if len(out) == 0 {
+			out = append(out, nil)
+		}
+		if out[0] == nil && len(b) > 0 && b[0] != nil {
+			out[0] = b[0]
+		}
+		for _, f := range b {
+			if f == nil || containsFeature(out, f) {
+				continue
+			}
+			out = append(out, f)
+		}
+		return out
+	}
+	return newFeatureSet(fs.Script, fs.Lang, merge(fs.GSub, other.GSub), merge(fs.GPos, other.GPos), nil)
+}
+
+func containsFeature(feats []Feature, f Feature) bool {
+	for _, g := range feats {
+		if g != nil && g.Tag() == f.Tag() && g.Type() == f.Type() &&
+			g.LookupCount() == f.LookupCount() && sameLookups(g, f) {
+			return true
+		}
+	}
+	return false
+}
+
+func sameLookups(f, g Feature) bool {
+	for i := range f.LookupCount() {
+		if f.LookupIndex(i) != g.LookupIndex(i) {
+			return false
+		}
+	}
+	return true
+}
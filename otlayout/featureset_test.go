@@ -0,0 +1,65 @@
+package otlayout
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/schuko/tracing/gotestingadapter"
+)
+
+func TestFontFeatureSetCaching(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	fs, err := FontFeatureSet(otf, ot.T("latn"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, _ := FontFeatureSet(otf, ot.T("latn"), 0)
+	if fs != again {
+		t.Errorf("expected feature set to be cached with the font")
+	}
+	gsubFeats, gposFeats, _ := FontFeatures(otf, ot.T("latn"), 0)
+	if len(gsubFeats) != len(fs.GSub) || len(gposFeats) != len(fs.GPos) {
+		t.Errorf("expected FontFeatures to agree with feature set")
+	}
+	lx := fs.LookupIndices(GSubFeatureType)
+	if len(lx) == 0 || !slices.IsSorted(lx) {
+		t.Errorf("expected sorted GSUB lookup indices, have %v", lx)
+	}
+	if len(fs.All()) == 0 || !slices.Contains(fs.Tags(), ot.T("kern")) {
+		t.Errorf("expected union of GSUB and GPOS features to contain kern, have %v", fs.Tags())
+	}
+}
+
+func TestFeatureSetFilterAndUnion(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	fs, err := FontFeatureSet(otf, ot.T("latn"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	liga := fs.Filter(ot.T("liga"), ot.T("kern"))
+	if tags := liga.Tags(); !slices.Equal(tags, []ot.Tag{ot.T("liga"), ot.T("kern")}) {
+		t.Errorf("expected filtered set to contain [liga kern], have %v", tags)
+	}
+	if len(liga.GSub) == 0 || len(liga.GPos) == 0 {
+		t.Fatalf("expected required-feature slots to be kept")
+	}
+	for _, inx := range liga.LookupIndices(GSubFeatureType) {
+		if !slices.Contains(fs.LookupIndices(GSubFeatureType), inx) {
+			t.Errorf("filtered set references lookup %d not in original set", inx)
+		}
+	}
+	u := liga.Union(fs)
+	if len(u.All()) != len(fs.All()) {
+		t.Errorf("expected union with original set to have %d features, have %d", len(fs.All()), len(u.All()))
+	}
+	if !slices.Equal(u.LookupIndices(GPosFeatureType), fs.LookupIndices(GPosFeatureType)) {
+		t.Errorf("expected union to reference the same GPOS lookups as the original set")
+	}
+}
@@ -0,0 +1,40 @@
+package otlayout
+
+import (
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestUnclassifiedGlyphsAreBaseGlyphs(t *testing.T) {
+	b := testfont.New(3)
+	b.Map('a', 1).Map('m', 2)
+	b.GlyphClass(2, uint16(ot.MarkGlyph))
+	gpos := b.GPOS()
+	kern := gpos.Lookup(ot.GPosLookupTypePair, ot.LOOKUP_FLAG_IGNORE_BASE_GLYPHS, testfont.PairPos(
+		map[testfont.Pair]testfont.ValueRecord{{2, 2}: {XAdvance: -50}}))
+	gpos.Feature("kern", kern)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	if c := glyphClass(otf.GDef(), 1); c != ot.BaseGlyph {
+		t.Errorf("expected glyph without GDEF class to be a base glyph, have class %d", c)
+	}
+	if c := glyphClass(otf.GDef(), 2); c != ot.MarkGlyph {
+		t.Errorf("expected glyph 'm' to be a mark glyph, have class %d", c)
+	}
+	if c := glyphClass(nil, 1); c != 0 {
+		t.Errorf("expected no glyph class without GDEF, have class %d", c)
+	}
+	_, gposFeats, err := FontFeatures(otf, ot.T("latn"), 0)
+	if err != nil || len(gposFeats) != 2 {
+		t.Fatalf("expected synthetic font to have a single GPOS feature 'kern'")
+	}
+	in := prepareGlyphBuffer("mam", otf, t)
+	st := NewBufferState(in, NewPosBuffer(len(in)))
+	if _, applied := ApplyFeature(otf, gposFeats[1], st, 0); !applied || st.Pos[0].XAdvance != -50 {
+		t.Errorf("expected unclassified glyph 'a' to be skipped, have advance %d", st.Pos[0].XAdvance)
+	}
+}
@@ -79,20 +79,16 @@ func gposLookupType2Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		tracer().Errorf("GPOS 2|1 missing concrete payload")
 		return pos, false, buf, nil
 	}
-	if inx < 0 || inx >= len(payload.PairSets) {
+	rec, ok := payload.Pair(inx, buf.At(next))
+	if !ok {
 		return pos, false, buf, nil
 	}
-	for _, rec := range payload.PairSets[inx] {
-		if ot.GlyphIndex(rec.SecondGlyph) == buf.At(next) {
-			ctx.buf.EnsurePos()
-			if ctx.buf.Pos == nil || mpos >= len(ctx.buf.Pos) || next >= len(ctx.buf.Pos) {
-				return pos, false, buf, nil
-			}
-			applyValueRecordPair(&ctx.buf.Pos[mpos], &ctx.buf.Pos[next], rec.Value1, payload.ValueFormat1, rec.Value2, payload.ValueFormat2)
-			return mpos + 1, true, buf, nil
-		}
+	ctx.buf.EnsurePos()
+	if ctx.buf.Pos == nil || mpos >= len(ctx.buf.Pos) || next >= len(ctx.buf.Pos) {
+		return pos, false, buf, nil
 	}
-	return pos, false, buf, nil
+	applyValueRecordPair(&ctx.buf.Pos[mpos], &ctx.buf.Pos[next], rec.Value1, payload.ValueFormat1, rec.Value2, payload.ValueFormat2)
+	return mpos + 1, true, buf, nil
 }
 
 // GPOS Lookup Type 2, Format 2: Pair Adjustment (class-based).
@@ -184,7 +180,8 @@ func gposLookupType4Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 				MarkAnchor: markAnchor,
 				BaseAnchor: baseAnchor,
 			}
-			setMarkAttachment(&ctx.buf.Pos[mpos], basePos, AttachMarkToBase, markRec.Class, ref)
+			setMarkAttachment(&ctx.buf.Pos[mpos], basePos, AttachMarkToBase, markRec.Class, ref,
+				markRec.Anchor, baseRec.Anchors[class])
 			return mpos + 1, true, buf, nil
 		}
 	}
@@ -252,7 +249,8 @@ func gposLookupType5Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 				BaseAnchor:   baseAnchor,
 				LigatureComp: uint16(compIndex),
 			}
-			setMarkAttachment(&ctx.buf.Pos[mpos], ligPos, AttachMarkToLigature, markRec.Class, ref)
+			setMarkAttachment(&ctx.buf.Pos[mpos], ligPos, AttachMarkToLigature, markRec.Class, ref,
+				markRec.Anchor, lig.ComponentAnchors[compIndex][class])
 			return mpos + 1, true, buf, nil
 		}
 	}
@@ -311,7 +309,8 @@ func gposLookupType6Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 				MarkAnchor: markAnchor,
 				BaseAnchor: baseAnchor,
 			}
-			setMarkAttachment(&ctx.buf.Pos[mpos], mark2Pos, AttachMarkToMark, markRec.Class, ref)
+			setMarkAttachment(&ctx.buf.Pos[mpos], mark2Pos, AttachMarkToMark, markRec.Class, ref,
+				markRec.Anchor, mark2Rec.Anchors[class])
 			return mpos + 1, true, buf, nil
 		}
 	}
@@ -345,13 +344,13 @@ func gposLookupType7Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if !ok {
 			continue
 		}
-		matchPositions := make([]int, 0, 1+len(restPos))
+		matchPositions := ctx.scratchInts(1 + len(restPos))[:0]
 		matchPositions = append(matchPositions, mpos)
 		matchPositions = append(matchPositions, restPos...)
 		if len(rule.Records) == 0 || ctx.lookupGraph == nil {
 			continue
 		}
-		out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, matchPositions, rule.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+		out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, matchPositions, rule.Records)
 		ctx.buf.Pos = outPosBuf
 		if applied {
 			return mpos, true, out, nil
@@ -387,13 +386,13 @@ func gposLookupType7Fmt2(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if !ok {
 			continue
 		}
-		matchPositions := make([]int, 0, 1+len(restPos))
+		matchPositions := ctx.scratchInts(1 + len(restPos))[:0]
 		matchPositions = append(matchPositions, mpos)
 		matchPositions = append(matchPositions, restPos...)
 		if len(rule.Records) == 0 || ctx.lookupGraph == nil {
 			continue
 		}
-		out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, matchPositions, rule.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+		out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, matchPositions, rule.Records)
 		ctx.buf.Pos = outPosBuf
 		if applied {
 			return mpos, true, out, nil
@@ -425,7 +424,7 @@ func gposLookupType7Fmt3(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 	if len(payload.Records) == 0 || ctx.lookupGraph == nil {
 		return pos, false, buf, nil
 	}
-	out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, inputPos, payload.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+	out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, inputPos, payload.Records)
 	ctx.buf.Pos = outPosBuf
 	if applied {
 		return pos, true, out, nil
@@ -462,7 +461,7 @@ func gposLookupType8Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if !ok {
 			continue
 		}
-		matchPositions := make([]int, 0, 1+len(inputPos))
+		matchPositions := ctx.scratchInts(1 + len(inputPos))[:0]
 		matchPositions = append(matchPositions, mpos)
 		matchPositions = append(matchPositions, inputPos...)
 		if len(rule.Backtrack) > 0 {
@@ -479,7 +478,7 @@ func gposLookupType8Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if ctx.lookupGraph == nil {
 			return pos, false, buf, nil
 		}
-		out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, matchPositions, rule.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+		out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, matchPositions, rule.Records)
 		ctx.buf.Pos = outPosBuf
 		if applied {
 			return mpos, true, out, nil
@@ -517,7 +516,7 @@ func gposLookupType8Fmt2(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if !ok {
 			continue
 		}
-		matchPositions := make([]int, 0, 1+len(inputPos))
+		matchPositions := ctx.scratchInts(1 + len(inputPos))[:0]
 		matchPositions = append(matchPositions, mpos)
 		matchPositions = append(matchPositions, inputPos...)
 		if len(rule.Backtrack) > 0 {
@@ -534,7 +533,7 @@ func gposLookupType8Fmt2(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if ctx.lookupGraph == nil {
 			return pos, false, buf, nil
 		}
-		out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, matchPositions, rule.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+		out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, matchPositions, rule.Records)
 		ctx.buf.Pos = outPosBuf
 		if applied {
 			return mpos, true, out, nil
@@ -584,7 +583,7 @@ func gposLookupType8Fmt3(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 	if ctx.lookupGraph == nil {
 		return pos, false, buf, nil
 	}
-	out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, inputPos, payload.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+	out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, inputPos, payload.Records)
 	ctx.buf.Pos = outPosBuf
 	if applied {
 		return pos, true, out, nil
@@ -3,7 +3,8 @@ package otlayout
 import "github.com/npillmayer/opentype/ot"
 
 // applyValueRecord applies a ValueRecord to a position item according to format.
-// Device table offsets are currently ignored.
+// Device tables are ignored, as buffers carry neither a size nor design-space
+// coordinates; clients may apply them using ot.ValueRecord.Resolve.
 func applyValueRecord(pos *PosItem, vr ot.ValueRecord, format ot.ValueFormat) {
 	if pos == nil {
 		return
@@ -28,11 +29,19 @@ func applyValueRecordPair(p1, p2 *PosItem, v1 ot.ValueRecord, f1 ot.ValueFormat,
 	applyValueRecord(p2, v2, f2)
 }
 
-// setMarkAttachment records a mark attachment without resolving anchor coordinates.
-func setMarkAttachment(pos *PosItem, baseIndex int, kind AttachKind, class uint16, ref AnchorRef) {
+// setMarkAttachment records a mark attachment and sets the offsets of the mark
+// to place anchor mark of the mark onto anchor base of the glyph it attaches
+// to. The offsets are relative to the origin of that glyph; they are made
+// relative to the mark by ResolveAttachments.
+func setMarkAttachment(pos *PosItem, baseIndex int, kind AttachKind, class uint16, ref AnchorRef,
+	mark, base *ot.Anchor) {
 	if pos == nil {
 		return
 	}
+	if mark != nil && base != nil {
+		pos.XOffset = int32(base.XCoordinate) - int32(mark.XCoordinate)
+		pos.YOffset = int32(base.YCoordinate) - int32(mark.YCoordinate)
+	}
 	pos.AttachTo = int32(baseIndex)
 	pos.AttachKind = kind
 	pos.AttachClass = class
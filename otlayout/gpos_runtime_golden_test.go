@@ -3,6 +3,7 @@ package otlayout
 import (
 	"testing"
 
+	"github.com/npillmayer/opentype/internal/testfont"
 	"github.com/npillmayer/opentype/ot"
 )
 
@@ -27,10 +28,8 @@ func maxGlyphCount(otf *ot.Font) int {
 	if otf == nil {
 		return ot.MaxGlyphCount
 	}
-	if maxp := otf.Table(ot.T("maxp")); maxp != nil {
-		if t := maxp.Self().AsMaxP(); t != nil && t.NumGlyphs > 0 {
-			return t.NumGlyphs
-		}
+	if maxp, ok := ot.TableOf[*ot.MaxPTable](otf); ok && maxp.NumGlyphs > 0 {
+		return maxp.NumGlyphs
 	}
 	return ot.MaxGlyphCount
 }
@@ -329,3 +328,29 @@ func TestGPOSMarkAttachmentRuntimeGolden(t *testing.T) {
 		}
 	})
 }
+
+func TestGPOSSubtablesAppliedInOrderPerPosition(t *testing.T) {
+	b := testfont.New(5)
+	for i, name := range []string{"a", "W", "comma", "space"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('a', 1).Map('W', 2).Map(',', 3).Map(' ', 4)
+	// the first subtable matches further ahead than the second one
+	err := b.Features(`languagesystem latn dflt;
+feature kern { lookup KERN { pos comma space 10; subtable; pos W a -80; } KERN; } kern;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	st, ok := applyGPOSLookup(t, otf, 0, []ot.GlyphIndex{1, 2, 1, 3, 4}, 0)
+	if !ok {
+		t.Fatal("expected kerning lookup to apply")
+	}
+	if st.Pos[1].XAdvance != -80 || st.Pos[3].XAdvance != 0 || st.Index != 2 {
+		t.Errorf("expected pair W a to be kerned first, have %+v at index %d", st.Pos, st.Index)
+	}
+}
@@ -30,12 +30,15 @@ func gsubLookupType1Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		tracer().Errorf("GSUB 1|1 missing concrete payload")
 		return pos, false, buf, nil
 	}
-	delta := int(payload.DeltaGlyphID)
-	newGlyph := int(buf.At(mpos)) + delta
-	tracer().Debugf("OT lookup GSUB 1/1: subst %d for %d", newGlyph, buf.At(mpos))
-	// TODO: check bounds against max glyph ID
-	ctx.buf.Set(mpos, ot.GlyphIndex(newGlyph))
-	return mpos + 1, true, ctx.buf.Glyphs, &EditSpan{From: mpos, To: mpos + 1, Len: 1}
+	// addition of DeltaGlyphID is modulo 65536
+	newGlyph := ot.GlyphIndex(uint16(int(buf.At(mpos)) + int(payload.DeltaGlyphID)))
+	if traceDebug() {
+		tracer().Debugf("OT lookup GSUB 1/1: subst %d for %d", newGlyph, buf.At(mpos))
+	}
+	if !ctx.buf.Set(mpos, newGlyph) {
+		return pos, false, buf, nil
+	}
+	return mpos + 1, true, ctx.buf.Glyphs, ctx.buf.recordEdit(mpos, mpos+1, 1)
 }
 
 // GSUB LookupSubtable Type 1 Format 2 provides an array of output glyph indices
@@ -64,9 +67,13 @@ func gsubLookupType1Fmt2(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		return pos, false, buf, nil
 	}
 	glyph := payload.SubstituteGlyphIDs[inx]
-	tracer().Debugf("OT lookup GSUB 1/2 (concrete): subst %d for %d", glyph, buf.At(mpos))
-	ctx.buf.Set(mpos, glyph)
-	return mpos + 1, true, ctx.buf.Glyphs, &EditSpan{From: mpos, To: mpos + 1, Len: 1}
+	if traceDebug() {
+		tracer().Debugf("OT lookup GSUB 1/2 (concrete): subst %d for %d", glyph, buf.At(mpos))
+	}
+	if !ctx.buf.Set(mpos, glyph) {
+		return pos, false, buf, nil
+	}
+	return mpos + 1, true, ctx.buf.Glyphs, ctx.buf.recordEdit(mpos, mpos+1, 1)
 }
 
 // LookupType 2: Multiple Substitution Subtable
@@ -101,11 +108,16 @@ func gsubLookupType2Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		return pos, false, buf, nil
 	}
 	glyphs := payload.Sequences[inx]
-	if len(glyphs) == 0 {
+	if len(glyphs) == 0 || !ctx.buf.mayGrow(len(glyphs)-1) {
 		return pos, false, buf, nil
 	}
-	tracer().Debugf("OT lookup GSUB 2/1 (concrete): subst %v for %d", glyphs, buf.At(mpos))
+	if traceDebug() {
+		tracer().Debugf("OT lookup GSUB 2/1 (concrete): subst %v for %d", glyphs, buf.At(mpos))
+	}
 	edit := ctx.buf.ReplaceGlyphs(mpos, mpos+1, glyphs)
+	if edit == nil {
+		return pos, false, buf, nil
+	}
 	return mpos + len(glyphs), true, ctx.buf.Glyphs, edit
 }
 
@@ -152,9 +164,13 @@ func gsubLookupType3Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 	if alt >= len(glyphs) {
 		return pos, false, buf, nil
 	}
-	tracer().Debugf("OT lookup GSUB 3/1 (concrete): subst %v for %d", glyphs[alt], buf.At(mpos))
-	ctx.buf.Set(mpos, glyphs[alt])
-	return mpos + 1, true, ctx.buf.Glyphs, &EditSpan{From: mpos, To: mpos + 1, Len: 1}
+	if traceDebug() {
+		tracer().Debugf("OT lookup GSUB 3/1 (concrete): subst %v for %d", glyphs[alt], buf.At(mpos))
+	}
+	if !ctx.buf.Set(mpos, glyphs[alt]) {
+		return pos, false, buf, nil
+	}
+	return mpos + 1, true, ctx.buf.Glyphs, ctx.buf.recordEdit(mpos, mpos+1, 1)
 }
 
 // LookupType 4: Ligature Substitution Subtable
@@ -191,6 +207,7 @@ func gsubLookupType4Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 	for _, rule := range payload.LigatureSets[inx] {
 		match := true
 		cur := mpos
+		components := uint64(1) // bit set of matched glyphs, relative to mpos
 		for _, g := range rule.Components {
 			next, ok := nextMatchable(ctx, buf, cur+1)
 			if !ok || g != buf.At(next) {
@@ -198,10 +215,19 @@ func gsubLookupType4Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 				break
 			}
 			cur = next
+			if next-mpos < 64 {
+				components |= 1 << (next - mpos)
+			}
 		}
 		if match {
-			edit := ctx.buf.ReplaceGlyphs(mpos, cur+1, []ot.GlyphIndex{rule.Ligature})
-			tracer().Debugf("OT lookup GSUB 4/1 (concrete): subst %d for %d", rule.Ligature, buf.At(mpos))
+			lig := [1]ot.GlyphIndex{rule.Ligature}
+			edit := ctx.buf.replaceGlyphs(mpos, cur+1, lig[:], components)
+			if edit == nil {
+				return pos, false, buf, nil
+			}
+			if traceDebug() {
+				tracer().Debugf("OT lookup GSUB 4/1 (concrete): subst %d for %d", rule.Ligature, buf.At(mpos))
+			}
 			return mpos + 1, true, ctx.buf.Glyphs, edit
 		}
 	}
@@ -248,13 +274,13 @@ func gsubLookupType5Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if !ok {
 			continue
 		}
-		matchPositions := make([]int, 0, 1+len(restPos))
+		matchPositions := ctx.scratchInts(1 + len(restPos))[:0]
 		matchPositions = append(matchPositions, mpos)
 		matchPositions = append(matchPositions, restPos...)
 		if len(rule.Records) == 0 || ctx.lookupGraph == nil {
 			continue
 		}
-		out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, matchPositions, rule.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+		out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, matchPositions, rule.Records)
 		ctx.buf.Pos = outPosBuf
 		if applied {
 			return pos, true, out, nil
@@ -289,13 +315,13 @@ func gsubLookupType5Fmt2(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if !ok {
 			continue
 		}
-		matchPositions := make([]int, 0, 1+len(restPos))
+		matchPositions := ctx.scratchInts(1 + len(restPos))[:0]
 		matchPositions = append(matchPositions, mpos)
 		matchPositions = append(matchPositions, restPos...)
 		if len(rule.Records) == 0 || ctx.lookupGraph == nil {
 			continue
 		}
-		out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, matchPositions, rule.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+		out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, matchPositions, rule.Records)
 		ctx.buf.Pos = outPosBuf
 		if applied {
 			return pos, true, out, nil
@@ -326,7 +352,7 @@ func gsubLookupType5Fmt3(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 	if len(payload.Records) == 0 || ctx.lookupGraph == nil {
 		return pos, false, buf, nil
 	}
-	out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, inputPos, payload.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+	out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, inputPos, payload.Records)
 	ctx.buf.Pos = outPosBuf
 	if applied {
 		return pos, true, out, nil
@@ -362,7 +388,7 @@ func gsubLookupType6Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if !ok {
 			continue
 		}
-		matchPositions := make([]int, 0, 1+len(inputPos))
+		matchPositions := ctx.scratchInts(1 + len(inputPos))[:0]
 		matchPositions = append(matchPositions, mpos)
 		matchPositions = append(matchPositions, inputPos...)
 		if len(rule.Backtrack) > 0 {
@@ -382,7 +408,7 @@ func gsubLookupType6Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if len(rule.Records) == 0 || ctx.lookupGraph == nil {
 			continue
 		}
-		out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, matchPositions, rule.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+		out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, matchPositions, rule.Records)
 		ctx.buf.Pos = outPosBuf
 		if applied {
 			return pos, true, out, nil
@@ -419,7 +445,7 @@ func gsubLookupType6Fmt2(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if !ok {
 			continue
 		}
-		matchPositions := make([]int, 0, 1+len(inputPos))
+		matchPositions := ctx.scratchInts(1 + len(inputPos))[:0]
 		matchPositions = append(matchPositions, mpos)
 		matchPositions = append(matchPositions, inputPos...)
 		if len(rule.Backtrack) > 0 {
@@ -439,7 +465,7 @@ func gsubLookupType6Fmt2(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		if len(rule.Records) == 0 || ctx.lookupGraph == nil {
 			continue
 		}
-		out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, matchPositions, rule.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+		out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, matchPositions, rule.Records)
 		ctx.buf.Pos = outPosBuf
 		if applied {
 			return pos, true, out, nil
@@ -496,7 +522,7 @@ func gsubLookupType6Fmt3(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 	if ctx.lookupGraph == nil {
 		return pos, false, buf, nil
 	}
-	out, outPosBuf, applied := applySequenceLookupRecords(buf, ctx.buf.Pos, inputPos, payload.Records, ctx.lookupGraph, ctx.feat, ctx.alt, ctx.gdef)
+	out, outPosBuf, applied := applySequenceLookupRecords(ctx, buf, ctx.buf.Pos, inputPos, payload.Records)
 	ctx.buf.Pos = outPosBuf
 	if applied {
 		return pos, true, out, nil
@@ -505,6 +531,9 @@ func gsubLookupType6Fmt3(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 }
 
 // GSUB LookupType 8: Reverse Chaining Single Substitution Subtable
+//
+// In reverse mode (see ApplyFeatureReverse), the subtable is matched at pos only.
+// Otherwise, the subtable is matched at the last matching position not before pos.
 func gsubLookupType8Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos int) (
 	int, bool, GlyphBuffer, *EditSpan) {
 	var payload *ot.GSubReverseChainingFmt1Payload
@@ -517,45 +546,68 @@ func gsubLookupType8Fmt1(ctx *applyCtx, sub *ot.LookupNode, buf GlyphBuffer, pos
 		tracer().Errorf("GSUB 8|1 missing concrete payload")
 		return pos, false, buf, nil
 	}
+	if ctx.reverse {
+		if pos < 0 || pos >= buf.Len() || skipGlyph(ctx, buf.At(pos)) {
+			return pos, false, buf, nil
+		}
+		if !reverseChainSubst(ctx, sub, payload, buf, pos) {
+			return pos, false, buf, nil
+		}
+		return pos, true, ctx.buf.Glyphs, ctx.buf.recordEdit(pos, pos+1, 1)
+	}
 	minPos := max(0, pos)
-	// if minPos < 0 {
-	// 	minPos = 0
-	// }
 	for i := buf.Len() - 1; i >= minPos; {
 		mpos, ok := prevMatchable(ctx, buf, i)
 		if !ok || mpos < minPos {
 			break
 		}
+		if reverseChainSubst(ctx, sub, payload, buf, mpos) {
+			return mpos + 1, true, ctx.buf.Glyphs, ctx.buf.recordEdit(mpos, mpos+1, 1)
+		}
+		i = mpos - 1
+	}
+	return pos, false, buf, nil
+}
+
+// reverseChainSubst matches a reverse chaining subtable at mpos and, on success,
+// substitutes the glyph at mpos.
+func reverseChainSubst(ctx *applyCtx, sub *ot.LookupNode, payload *ot.GSubReverseChainingFmt1Payload,
+	buf GlyphBuffer, mpos int) bool {
+	if traceDebug() {
 		tracer().Debugf("GSUB 8|1 candidate pos=%d glyph=%d", mpos, buf.At(mpos))
-		inx, ok := sub.Coverage.Match(buf.At(mpos))
-		if !ok {
+	}
+	inx, ok := sub.Coverage.Match(buf.At(mpos))
+	if !ok {
+		if traceDebug() {
 			tracer().Debugf("GSUB 8|1 coverage did not match at pos %d", mpos)
-			i = mpos - 1
-			continue
 		}
-		if len(payload.BacktrackCoverages) > 0 {
-			if _, ok := matchCoverageSequenceBackward(ctx, buf, mpos, payload.BacktrackCoverages); !ok {
+		return false
+	}
+	if len(payload.BacktrackCoverages) > 0 {
+		if _, ok := matchCoverageSequenceBackward(ctx, buf, mpos, payload.BacktrackCoverages); !ok {
+			if traceDebug() {
 				tracer().Debugf("GSUB 8|1 backtrack did not match at pos %d", mpos)
-				i = mpos - 1
-				continue
 			}
+			return false
 		}
-		if len(payload.LookaheadCoverages) > 0 {
-			if _, ok := matchCoverageSequenceForward(ctx, buf, mpos+1, payload.LookaheadCoverages); !ok {
+	}
+	if len(payload.LookaheadCoverages) > 0 {
+		if _, ok := matchCoverageSequenceForward(ctx, buf, mpos+1, payload.LookaheadCoverages); !ok {
+			if traceDebug() {
 				tracer().Debugf("GSUB 8|1 lookahead did not match at pos %d", mpos)
-				i = mpos - 1
-				continue
 			}
+			return false
 		}
-		if inx < 0 || inx >= len(payload.SubstituteGlyphIDs) {
+	}
+	if inx < 0 || inx >= len(payload.SubstituteGlyphIDs) {
+		if traceDebug() {
 			tracer().Debugf("GSUB 8|1 substitute index %d out of range", inx)
-			i = mpos - 1
-			continue
 		}
-		subst := payload.SubstituteGlyphIDs[inx]
+		return false
+	}
+	subst := payload.SubstituteGlyphIDs[inx]
+	if traceDebug() {
 		tracer().Debugf("GSUB 8|1 subst %d for %d at pos %d", subst, buf.At(mpos), mpos)
-		ctx.buf.Set(mpos, subst)
-		return mpos + 1, true, ctx.buf.Glyphs, &EditSpan{From: mpos, To: mpos + 1, Len: 1}
 	}
-	return pos, false, buf, nil
+	return ctx.buf.Set(mpos, subst)
 }
@@ -1,10 +1,15 @@
 package otlayout
 
 import (
+	"errors"
 	"fmt"
+	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/npillmayer/opentype/internal/fontload"
+	"github.com/npillmayer/opentype/internal/guard"
+	"github.com/npillmayer/opentype/internal/testfont"
 	"github.com/npillmayer/opentype/ot"
 	"github.com/npillmayer/schuko/tracing"
 	"github.com/npillmayer/schuko/tracing/gotestingadapter"
@@ -29,7 +34,7 @@ func TestCalibriCMap(t *testing.T) {
 	//
 	otf := parseFont(t, "Calibri")
 	t.Logf("Using test font for cmap check")
-	cmap := otf.Table(ot.T("cmap")).Self().AsCMap()
+	cmap := otf.CMapTable()
 	r, pos := rune('A'), ot.GlyphIndex(4)
 	glyphID := cmap.GlyphIndexMap.Lookup(r)
 	t.Logf("found glyph ID %#x for %#U", glyphID, r)
@@ -105,6 +110,177 @@ func TestFeatureCase(t *testing.T) {
 	}
 }
 
+func TestFeatureLigaSynthetic(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	b := testfont.New(5)
+	b.Map('f', 1).Map('i', 2).Map('l', 3)
+	gsub := b.GSUB()
+	liga := gsub.Lookup(ot.GSubLookupTypeLigature, 0, testfont.LigatureSubst(
+		testfont.Ligature{Components: []ot.GlyphIndex{1, 2}, Glyph: 4}))
+	gsub.Feature("liga", liga)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	gsubFeats, _, err := FontFeatures(otf, ot.T("latn"), 0)
+	// slot #0 is reserved for the required feature
+	if err != nil || len(gsubFeats) != 2 || gsubFeats[1].Tag() != ot.T("liga") {
+		t.Fatalf("expected synthetic font to have a single GSUB feature 'liga'")
+	}
+	in := prepareGlyphBuffer("fil", otf, t)
+	st := NewBufferState(in, NewPosBuffer(len(in)))
+	st.Log = &EditLog{}
+	_, applied := ApplyFeature(otf, gsubFeats[1], st, 0)
+	if !applied {
+		t.Fatal("feature 'liga' not applied")
+	}
+	if len(st.Glyphs) != 2 || st.Glyphs[0] != 4 || st.Glyphs[1] != 3 {
+		t.Errorf("expected 'fi' to be replaced by ligature glyph 4, have %v", st.Glyphs)
+	}
+	if log := *st.Log; len(log) != 1 || log[0].From != 0 || log[0].To != 2 || log[0].LigatureComponents() != 2 {
+		t.Errorf("expected ligature of 2 components to be logged, have %+v", log)
+	}
+}
+
+func TestFeatureAlternatesSynthetic(t *testing.T) {
+	b := testfont.New(5)
+	b.Map('a', 1)
+	gsub := b.GSUB()
+	gsub.Feature("cv01", gsub.Lookup(ot.GSubLookupTypeAlternate, 0, testfont.AlternateSubst(
+		map[ot.GlyphIndex][]ot.GlyphIndex{1: {2, 3, 4}})))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	gsubFeats, _, err := FontFeatures(otf, ot.T("latn"), 0)
+	if err != nil || len(gsubFeats) != 2 || gsubFeats[1].Tag() != ot.T("cv01") {
+		t.Fatalf("expected synthetic font to have a single GSUB feature 'cv01'")
+	}
+	cv01 := gsubFeats[1]
+	if n := cv01.AlternateCount(1); n != 3 {
+		t.Errorf("expected 3 alternates for glyph 1, have %d", n)
+	}
+	if n := cv01.AlternateCount(2); n != 0 {
+		t.Errorf("expected no alternates for glyph 2, have %d", n)
+	}
+	for alt, want := range map[int]ot.GlyphIndex{0: 2, 2: 4, -1: 4, 3: 1} {
+		st := NewBufferState(GlyphBuffer{1}, nil)
+		ApplyFeature(otf, cv01, st, alt)
+		if st.Glyphs[0] != want {
+			t.Errorf("expected alternate %d to yield glyph %d, have %d", alt, want, st.Glyphs[0])
+		}
+	}
+}
+
+func TestFeatureReverseChainingSynthetic(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	b := testfont.New(4)
+	b.Map('b', 1).Map('n', 2)
+	gsub := b.GSUB()
+	rev := gsub.Lookup(ot.GSubLookupTypeReverseChaining, 0, testfont.ReverseChainSubst(
+		map[ot.GlyphIndex]ot.GlyphIndex{1: 3}, nil, [][]ot.GlyphIndex{{2, 3}}))
+	gsub.Feature("calt", rev)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	if !otf.Layout.GSub.LookupGraph().Lookup(rev).IsReverse() {
+		t.Fatalf("expected lookup to be a reverse chaining lookup")
+	}
+	gsubFeats, _, err := FontFeatures(otf, ot.T("latn"), 0)
+	if err != nil || len(gsubFeats) != 2 {
+		t.Fatalf("expected synthetic font to have a single GSUB feature 'calt'")
+	}
+	in := prepareGlyphBuffer("bbbn", otf, t)
+	st := NewBufferState(in, nil)
+	st.Index = 0
+	if ApplyFeatureReverse(otf, gsubFeats[1], st) {
+		t.Errorf("expected no substitution at position 0 before its lookahead has been substituted")
+	}
+	for i := st.Len() - 1; i >= 0; i-- {
+		st.Index = i
+		ApplyFeatureReverse(otf, gsubFeats[1], st)
+		if st.Index != i {
+			t.Errorf("expected index to stay at %d, have %d", i, st.Index)
+		}
+	}
+	if !slices.Equal(st.Glyphs, GlyphBuffer{3, 3, 3, 2}) {
+		t.Errorf("expected cascading substitution 'BBBn', have %v", st.Glyphs)
+	}
+}
+
+func TestSubstituteGlyphOutOfRange(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	b := testfont.New(4)
+	b.Map('a', 1).Map('b', 2)
+	gsub := b.GSUB()
+	single := gsub.Lookup(ot.GSubLookupTypeSingle, 0, testfont.SingleSubst(
+		map[ot.GlyphIndex]ot.GlyphIndex{1: 3, 2: 17}))
+	multiple := gsub.Lookup(ot.GSubLookupTypeMultiple, 0, testfont.MultipleSubst(
+		map[ot.GlyphIndex][]ot.GlyphIndex{1: {3, 99}}))
+	gsub.Feature("salt", single)
+	gsub.Feature("ccmp", multiple)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	gsubFeats, _, err := FontFeatures(otf, ot.T("latn"), 0)
+	if err != nil || len(gsubFeats) != 3 {
+		t.Fatalf("expected synthetic font to have GSUB features 'salt' and 'ccmp'")
+	}
+	lookup := otf.Layout.GSub.LookupGraph().Lookup(single)
+	if lookup.Subtable(0).Error() == nil {
+		t.Errorf("expected substitute glyph 17 to be reported for font with 4 glyphs")
+	}
+	for _, feat := range gsubFeats[1:] {
+		in := prepareGlyphBuffer("ab", otf, t)
+		st := NewBufferState(in, nil)
+		st.Index = 1
+		_, applied := ApplyFeature(otf, feat, st, 0)
+		if applied || st.Glyphs[1] != 2 {
+			t.Errorf("feature '%s': expected substitution of 'b' to be rejected, have %v", feat.Tag(), st.Glyphs)
+		}
+		if feat.Tag() != ot.T("ccmp") {
+			continue
+		}
+		st.Index = 0
+		if _, applied = ApplyFeature(otf, feat, st, 0); applied || len(st.Glyphs) != 2 {
+			t.Errorf("expected multiple substitution of 'a' to be rejected, have %v", st.Glyphs)
+		}
+	}
+}
+
+func TestSingleSubstDeltaWraps(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	b := testfont.New(40001)
+	b.Map('a', 40000)
+	gsub := b.GSUB()
+	// 40000 + 30000 = 70000, which wraps to 4464
+	single := gsub.Lookup(ot.GSubLookupTypeSingle, 0, testfont.SingleSubstDelta(30000, 40000))
+	gsub.Feature("salt", single)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	gsubFeats, _, err := FontFeatures(otf, ot.T("latn"), 0)
+	if err != nil || len(gsubFeats) != 2 {
+		t.Fatalf("expected synthetic font to have a single GSUB feature 'salt'")
+	}
+	in := prepareGlyphBuffer("a", otf, t)
+	st := NewBufferState(in, nil)
+	if _, applied := ApplyFeature(otf, gsubFeats[1], st, 0); !applied || st.Glyphs[0] != 4464 {
+		t.Errorf("expected glyph delta to wrap around to glyph 4464, have %v", st.Glyphs)
+	}
+}
+
 /*
 Calibri:
 
@@ -219,7 +395,7 @@ func TestFeatureCCMPGentium(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func prepareGlyphBuffer(s string, otf *ot.Font, t *testing.T) []ot.GlyphIndex {
-	cmap := otf.Table(ot.T("cmap")).Self().AsCMap()
+	cmap := otf.CMapTable()
 	runes := []rune(s)
 	buf := make([]ot.GlyphIndex, len(runes))
 	for i, r := range runes {
@@ -280,3 +456,198 @@ func loadTestFont(t *testing.T, pattern string) *ot.Font {
 	return otf
 }
 */
+
+func TestContextualEditsRemapPositions(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	b := testfont.New(7)
+	for i, name := range []string{"f", "i", "x", "acute", "fi", "y"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('f', 1).Map('i', 2).Map('x', 3).Map('́', 4)
+	err := b.Features(`languagesystem latn dflt;
+table GDEF { GlyphClassDef [f i x fi y], , [acute], ; } GDEF;
+lookup LIGA { sub f i by fi; } LIGA;
+lookup MULT { sub x by x y; } MULT;
+feature calt { sub f' lookup LIGA i' x' lookup MULT; } calt;
+markClass acute <anchor 0 500> @TOP;
+feature mark { pos base x <anchor 250 600> mark @TOP; } mark;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	gsubFeats, gposFeats, err := FontFeatures(otf, ot.T("latn"), 0)
+	if err != nil || len(gsubFeats) != 2 || len(gposFeats) != 2 {
+		t.Fatalf("expected synthetic font to have features 'calt' and 'mark', have %v", err)
+	}
+	in := prepareGlyphBuffer("fix́", otf, t)
+	st := NewBufferState(in, NewPosBuffer(len(in)))
+	for i := range st.Pos {
+		st.Pos[i].Cluster = uint32(i)
+	}
+	st.Pos[0].XAdvance = 7 // reset by the ligature
+	// GPOS records are present before GSUB edits shift the glyphs
+	if _, applied := ApplyFeature(otf, gposFeats[1], st, 0); !applied {
+		t.Fatal("feature 'mark' not applied")
+	}
+	mark := st.Pos[3]
+	if mark.AttachTo != 2 || mark.AttachKind != AttachMarkToBase {
+		t.Fatalf("expected acute to be attached to x, have %+v", mark)
+	}
+	st.Index = 0
+	if _, applied := ApplyFeature(otf, gsubFeats[1], st, 0); !applied {
+		t.Fatal("feature 'calt' not applied")
+	}
+	if want := (GlyphBuffer{5, 3, 6, 4}); !slices.Equal(st.Glyphs, want) {
+		t.Fatalf("expected glyphs %v, have %v", want, st.Glyphs)
+	}
+	if len(st.Pos) != len(st.Glyphs) {
+		t.Fatalf("expected positions to stay aligned with glyphs, have %d for %d glyphs", len(st.Pos), len(st.Glyphs))
+	}
+	if p := st.Pos[3]; p.AttachTo != 1 || p.AttachKind != mark.AttachKind || p.XOffset != mark.XOffset ||
+		p.YOffset != mark.YOffset || p.Cluster != 3 {
+		t.Errorf("expected acute to stay attached to x at index 1, have %+v", p)
+	}
+	var clusters []uint32
+	for _, p := range st.Pos {
+		clusters = append(clusters, p.Cluster)
+	}
+	if want := []uint32{0, 2, 2, 3}; !slices.Equal(clusters, want) {
+		t.Errorf("expected clusters %v, have %v", want, clusters)
+	}
+	if st.Pos[0].XAdvance != 0 {
+		t.Errorf("expected position of ligature to be reset, have %+v", st.Pos[0])
+	}
+}
+
+func TestApplyLookupByIndex(t *testing.T) {
+	b := testfont.New(5)
+	b.Map('f', 1).Map('i', 2).Map('l', 3)
+	gsub := b.GSUB()
+	single := gsub.Lookup(ot.GSubLookupTypeSingle, 0, testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{3: 4}))
+	gsub.Feature("salt", single)
+	// referenced by no feature
+	liga := gsub.Lookup(ot.GSubLookupTypeLigature, 0, testfont.LigatureSubst(
+		testfont.Ligature{Components: []ot.GlyphIndex{1, 2}, Glyph: 4}))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	st := NewBufferState(GlyphBuffer{3, 1, 2}, nil)
+	pos, ok := ApplyLookup(otf, GSubFeatureType, liga, st, 0)
+	if !ok || pos != 2 || !slices.Equal(st.Glyphs, GlyphBuffer{3, 4}) {
+		t.Errorf("expected ligature lookup to form 'fi' after position 0, have %v at %d (%v)", st.Glyphs, pos, ok)
+	}
+	st.Index = 0
+	if _, ok := ApplyLookup(otf, GSubFeatureType, single, st, 0); !ok || st.Glyphs[0] != 4 {
+		t.Errorf("expected single substitution lookup to be applied, have %v", st.Glyphs)
+	}
+	st.Index = 0
+	for _, c := range []struct {
+		table  LayoutTagType
+		lookup int
+	}{
+		{GSubFeatureType, 2},
+		{GSubFeatureType, -1},
+		{GPosFeatureType, 0},
+		{ScriptType, 0},
+	} {
+		if pos, ok := ApplyLookup(otf, c.table, c.lookup, st, 0); ok || pos != 0 {
+			t.Errorf("expected lookup %d of table type %d not to be applied", c.lookup, c.table)
+		}
+	}
+}
+
+// panickingFeature fails with a runtime error when its lookups are accessed.
+type panickingFeature struct{ feature }
+
+func (f panickingFeature) LookupIndex(i int) int {
+	var indices []int
+	return indices[i]
+}
+
+func TestApplyFeatureRecordsInternalError(t *testing.T) {
+	if guard.Panics {
+		t.Skip("built with tag otassert")
+	}
+	b := testfont.New(3)
+	b.Map('a', 1)
+	gsub := b.GSUB()
+	gsub.Feature("salt", gsub.Lookup(ot.GSubLookupTypeSingle, 0,
+		testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 2})))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	feat := panickingFeature{feature{typ: GSubFeatureType, lookupIndices: []int{0}}}
+	st := NewBufferState(GlyphBuffer{1, 1}, nil)
+	if _, ok := ApplyFeature(otf, feat, st, 0); ok {
+		t.Errorf("expected failing feature not to be applied")
+	}
+	var rerr runtime.Error
+	if !errors.As(st.Err, &rerr) {
+		t.Fatalf("expected runtime error to be recorded, have %v", st.Err)
+	}
+	// lookups are not applied to a failed buffer state any more
+	if ok := ApplyFeatureReverse(otf, feat, st); ok || !slices.Equal(st.Glyphs, GlyphBuffer{1, 1}) {
+		t.Errorf("expected failed buffer state to be left alone, have %v", st.Glyphs)
+	}
+	if _, ok := ApplyLookup(otf, GSubFeatureType, 0, st, 0); ok {
+		t.Errorf("expected lookup not to be applied to failed buffer state")
+	}
+}
+
+func TestMultipleSubstitutionGrowthLimit(t *testing.T) {
+	b := testfont.New(2)
+	b.Map('a', 1)
+	gsub := b.GSUB()
+	// lookup 0 decomposes 'a' into 'a a' and calls itself for the first 'a'
+	recursive := gsub.Lookup(ot.GSubLookupTypeChainingContext, 0, testfont.ChainContext(nil, [][]ot.GlyphIndex{{1}}, nil,
+		ot.SequenceLookupRecord{SequenceIndex: 0, LookupListIndex: 1},
+		ot.SequenceLookupRecord{SequenceIndex: 0, LookupListIndex: 0}))
+	gsub.Lookup(ot.GSubLookupTypeMultiple, 0, testfont.MultipleSubst(map[ot.GlyphIndex][]ot.GlyphIndex{1: {1, 1}}))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	st := NewBufferState(GlyphBuffer{1}, nil)
+	if _, ok := ApplyLookup(otf, GSubFeatureType, recursive, st, 0); !ok {
+		t.Fatalf("expected recursive lookup to be applied")
+	}
+	if n := st.Len(); n > DefaultMaxGrowthFactor {
+		t.Errorf("expected buffer to grow to at most %d glyphs, have %d", DefaultMaxGrowthFactor, n)
+	}
+	budget := &LookupBudget{MaxGrowthFactor: 8}
+	st = NewBufferState(GlyphBuffer{1, 1}, nil)
+	st.Budget = budget
+	if _, ok := ApplyLookup(otf, GSubFeatureType, recursive, st, 0); !ok {
+		t.Fatalf("expected recursive lookup to be applied")
+	}
+	if st.Len() != 16 || !budget.GrowthExceeded || !budget.Exceeded() {
+		t.Errorf("expected buffer of 2 glyphs to grow to 16 glyphs and the budget to be exceeded, have %d glyphs", st.Len())
+	}
+}
+
+func TestNestedLookupAppliesAtRecordPosition(t *testing.T) {
+	b := testfont.New(4)
+	b.Map('a', 1).Map('b', 2)
+	gsub := b.GSUB()
+	// lookup 1 substitutes 'b' only, and must not be applied to the 'b' after the context
+	chain := gsub.Lookup(ot.GSubLookupTypeChainingContext, 0, testfont.ChainContext(nil, [][]ot.GlyphIndex{{1}}, nil,
+		ot.SequenceLookupRecord{SequenceIndex: 0, LookupListIndex: 1}))
+	gsub.Lookup(ot.GSubLookupTypeSingle, 0, testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{2: 3}))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	st := NewBufferState(GlyphBuffer{1, 2}, nil)
+	ApplyLookup(otf, GSubFeatureType, chain, st, 0)
+	if !slices.Equal(st.Glyphs, GlyphBuffer{1, 2}) {
+		t.Errorf("expected nested lookup not to be applied outside of its context, have %v", st.Glyphs)
+	}
+}
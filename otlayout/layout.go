@@ -61,7 +61,8 @@ func GetLookupGraph(table ot.Table) (*ot.LookupListGraph, error) {
 	return lyt.LookupGraph(), nil
 }
 
-// ScriptTags returns script tags in declaration order.
+// ScriptTags returns script tags in declaration order. Clients needing a
+// guaranteed order, e.g. for reproducible output, use ot.ScriptList.Tags.
 func ScriptTags(scriptGraph *ot.ScriptList) []ot.Tag {
 	if scriptGraph == nil || scriptGraph.Len() == 0 {
 		return nil
@@ -74,6 +75,8 @@ func ScriptTags(scriptGraph *ot.ScriptList) []ot.Tag {
 }
 
 // FeatureTags returns feature tags in declaration order (including duplicates).
+// Clients needing a guaranteed order use ot.FeatureList.Tags, which returns
+// distinct tags in alphabetical order.
 func FeatureTags(featureGraph *ot.FeatureList) []ot.Tag {
 	if featureGraph == nil || featureGraph.Len() == 0 {
 		return nil
@@ -147,16 +150,16 @@ func getLayoutTables(otf *ot.Font) ([]*ot.LayoutTable, error) {
 	if otf == nil {
 		return nil, errFontFormat("font is nil")
 	}
-	var table ot.Table
 	var lytt = make([]*ot.LayoutTable, 2)
 	fontname := "<font>"
-	if table = otf.Table(ot.T("GSUB")); table == nil {
+	gsub, gpos := otf.GSub(), otf.GPos()
+	if gsub == nil {
 		return nil, errFontFormat(fmt.Sprintf("font %s has no GSUB table", fontname))
 	}
-	lytt[0] = &table.Self().AsGSub().LayoutTable
-	if table = otf.Table(ot.T("GPOS")); table == nil {
+	lytt[0] = &gsub.LayoutTable
+	if gpos == nil {
 		return nil, errFontFormat(fmt.Sprintf("font %s has no GPOS table", fontname))
 	}
-	lytt[1] = &table.Self().AsGPos().LayoutTable
+	lytt[1] = &gpos.LayoutTable
 	return lytt, nil
 }
@@ -0,0 +1,229 @@
+package otlayout
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// PlanStage is a named group of features which are applied together. Within a
+// stage, lookups are applied in the order of their lookup index, regardless of
+// the feature they belong to. Stages are applied one after the other.
+type PlanStage struct {
+	Name     string        // descriptive name, e.g., "ligate"
+	Table    LayoutTagType // GSubFeatureType or GPosFeatureType
+	Features []ot.Tag      // features active by default in this stage
+}
+
+// Standard feature application stages. Features not listed here, but requested by
+// clients, are added to the last stage of their layout table.
+//
+// The grouping follows the stages commonly used by OpenType shapers for
+// scripts without complex shaping requirements.
+var standardStages = []PlanStage{
+	{Name: "prepare", Table: GSubFeatureType, Features: []ot.Tag{
+		ot.T("rvrn"),
+	}},
+	{Name: "compose", Table: GSubFeatureType, Features: []ot.Tag{
+		ot.T("ccmp"), ot.T("locl"),
+	}},
+	{Name: "ligate", Table: GSubFeatureType, Features: []ot.Tag{
+		ot.T("rlig"), ot.T("rclt"), ot.T("calt"), ot.T("clig"), ot.T("liga"),
+	}},
+	{Name: "position", Table: GPosFeatureType, Features: []ot.Tag{
+		ot.T("abvm"), ot.T("blwm"), ot.T("curs"), ot.T("dist"), ot.T("kern"),
+		ot.T("mark"), ot.T("mkmk"),
+	}},
+}
+
+// Direction-dependent features, added to stage "prepare" (GSUB). As in other
+// shapers, they are applied before locl and ccmp.
+var (
+	ltrFeatures = []ot.Tag{ot.T("ltra"), ot.T("ltrm")}
+	rtlFeatures = []ot.Tag{ot.T("rtla"), ot.T("rtlm")}
+)
+
+// StandardStages returns a copy of the standard feature application stages used
+// by NewPlan, without direction-dependent features.
+func StandardStages() []PlanStage {
+	stages := make([]PlanStage, len(standardStages))
+	for i, st := range standardStages {
+		stages[i] = st
+		stages[i].Features = slices.Clone(st.Features)
+	}
+	return stages
+}
+
+// FeatureSetting is a client request to switch a feature on or off.
+// Value 0 switches a feature off, 1 switches it on. Values > 1 select an
+// alternate for features which support alternates.
+type FeatureSetting struct {
+	Tag   ot.Tag
+	Value int
+}
+
+// PlanStep is one step of an ordered shaping plan: a run of lookups of a single
+// feature, to be applied in the given order.
+type PlanStep struct {
+	Stage    int     // index into Plan.Stages
+	Feature  Feature // the feature the lookups belong to
+	Value    int     // feature value, see FeatureSetting
+	Required bool    // feature is the required feature of the language system
+	Lookups  []int   // lookup indices, ascending
+}
+
+// Plan is an ordered list of feature application steps for a font, resolved for
+// a script, language and direction and a set of client feature settings.
+// Clients execute a plan by applying the lookups of all steps in order.
+type Plan struct {
+	Script    ot.Tag
+	Lang      ot.Tag
+	Direction bidi.Direction
+	Stages    []PlanStage // stages, with features active in this plan
+	Steps     []PlanStep  // steps in order of execution
+}
+
+// NewPlan resolves the features of font otf for a script/language pair into an
+// ordered plan. Standard features for the stages (see StandardStages) are active
+// unless switched off by settings; other features are active if requested by
+// settings. Required features cannot be switched off and are applied first within
+// their layout table. Requests for features the font does not provide are ignored.
+func NewPlan(otf *ot.Font, script, lang ot.Tag, dir bidi.Direction, settings []FeatureSetting) (*Plan, error) {
+	fs, err := FontFeatureSet(otf, script, lang)
+	if err != nil {
+		return nil, err
+	}
+	p := &Plan{
+		Script:    fs.Script,
+		Lang:      lang,
+		Direction: dir,
+		Stages:    StandardStages(),
+	}
+	dirFeats := ltrFeatures
+	if dir == bidi.RightToLeft {
+		dirFeats = rtlFeatures
+	}
+	p.Stages[0].Features = append(p.Stages[0].Features, dirFeats...)
+	values := make(map[ot.Tag]int, len(settings))
+	for _, s := range settings {
+		values[s.Tag] = s.Value // later settings override earlier ones
+	}
+	p.addTableSteps(fs.GSub, GSubFeatureType, values)
+	p.addTableSteps(fs.GPos, GPosFeatureType, values)
+	return p, nil
+}
+
+// addTableSteps schedules the features of one layout table. feats is expected to
+// hold the required feature at index 0 (which may be nil).
+func (p *Plan) addTableSteps(feats []Feature, table LayoutTagType, values map[ot.Tag]int) {
+	first, last := -1, -1
+	for i, st := range p.Stages {
+		if st.Table == table {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	type scheduled struct {
+		feat     Feature
+		value    int
+		required bool
+	}
+	byStage := make([][]scheduled, len(p.Stages))
+	var requiredTag ot.Tag
+	for i, f := range feats {
+		if f == nil {
+			continue
+		}
+		tag := f.Tag()
+		if i == 0 {
+			byStage[first] = append(byStage[first], scheduled{feat: f, value: 1, required: true})
+			requiredTag = tag
+			continue
+		} else if tag == requiredTag {
+			continue // already scheduled as required feature
+		}
+		stage := p.stageOf(tag, table)
+		value, requested := values[tag]
+		switch {
+		case requested && value == 0:
+			continue
+		case !requested && stage < 0:
+			continue // not a default feature
+		case !requested:
+			value = 1
+		}
+		if stage < 0 {
+			stage = last
+			p.Stages[stage].Features = append(p.Stages[stage].Features, tag)
+		}
+		byStage[stage] = append(byStage[stage], scheduled{feat: f, value: value})
+	}
+	for stage, sched := range byStage {
+		// Collect lookups of the stage; the first feature referencing a lookup owns it.
+		owner := make(map[int]int)
+		var lookups []int
+		for k, s := range sched {
+			for j := range s.feat.LookupCount() {
+				inx := s.feat.LookupIndex(j)
+				if _, ok := owner[inx]; ok || inx < 0 {
+					continue
+				}
+				owner[inx] = k
+				lookups = append(lookups, inx)
+			}
+		}
+		slices.Sort(lookups)
+		// Required feature lookups go first, then all others in lookup order.
+		slices.SortStableFunc(lookups, func(a, b int) int {
+			ra, rb := sched[owner[a]].required, sched[owner[b]].required
+			switch {
+			case ra && !rb:
+				return -1
+			case rb && !ra:
+				return 1
+			}
+			return 0
+		})
+		prev := -1 // owner of the previous lookup
+		for _, inx := range lookups {
+			k := owner[inx]
+			if k == prev {
+				p.Steps[len(p.Steps)-1].Lookups = append(p.Steps[len(p.Steps)-1].Lookups, inx)
+				continue
+			}
+			prev = k
+			s := sched[k]
+			p.Steps = append(p.Steps, PlanStep{
+				Stage:    stage,
+				Feature:  s.feat,
+				Value:    s.value,
+				Required: s.required,
+				Lookups:  []int{inx},
+			})
+		}
+	}
+}
+
+// stageOf returns the index of the stage of table which lists tag, or -1.
+func (p *Plan) stageOf(tag ot.Tag, table LayoutTagType) int {
+	for i, st := range p.Stages {
+		if st.Table == table && slices.Contains(st.Features, tag) {
+			return i
+		}
+	}
+	return -1
+}
+
+// String returns a compact, human-readable representation of the plan,
+// e.g. for debugging.
+func (p *Plan) String() string {
+	s := fmt.Sprintf("plan(%s/%s)", p.Script, p.Lang)
+	for _, step := range p.Steps {
+		s += fmt.Sprintf(" %s:%s%v", p.Stages[step.Stage].Name, step.Feature.Tag(), step.Lookups)
+	}
+	return s
+}
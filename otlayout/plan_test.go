@@ -0,0 +1,139 @@
+package otlayout
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/schuko/tracing/gotestingadapter"
+	"golang.org/x/text/unicode/bidi"
+)
+
+func TestPlanOrdering(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	otf := parseFont(t, "Calibri")
+	p, err := NewPlan(otf, ot.T("latn"), 0, bidi.LeftToRight, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Logf("%s", p)
+	stageOf := func(p *Plan, tag string) int {
+		for _, step := range p.Steps {
+			if step.Feature.Tag() == ot.T(tag) {
+				return step.Stage
+			}
+		}
+		return -1
+	}
+	ccmp, liga, kern := stageOf(p, "ccmp"), stageOf(p, "liga"), stageOf(p, "kern")
+	if ccmp < 0 || liga < 0 || kern < 0 {
+		t.Fatalf("expected ccmp, liga and kern to be scheduled, have %s", p)
+	}
+	if !(ccmp < liga && liga < kern) {
+		t.Errorf("expected stage order ccmp < liga < kern, have %d, %d, %d", ccmp, liga, kern)
+	}
+	if stageOf(p, "smcp") >= 0 {
+		t.Errorf("did not expect non-default feature smcp to be scheduled")
+	}
+	seen := make(map[[2]int]bool)
+	lastStage := 0
+	for _, step := range p.Steps {
+		if step.Stage < lastStage {
+			t.Errorf("expected steps to be ordered by stage")
+		}
+		lastStage = step.Stage
+		if !slices.IsSorted(step.Lookups) {
+			t.Errorf("expected lookups of step to be ascending, have %v", step.Lookups)
+		}
+		for _, inx := range step.Lookups {
+			key := [2]int{step.Stage, inx}
+			if seen[key] {
+				t.Errorf("lookup %d scheduled twice in stage %d", inx, step.Stage)
+			}
+			seen[key] = true
+		}
+	}
+	//
+	p, err = NewPlan(otf, ot.T("latn"), 0, bidi.LeftToRight, []FeatureSetting{
+		{Tag: ot.T("liga"), Value: 0},
+		{Tag: ot.T("smcp"), Value: 1},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stageOf(p, "liga") >= 0 {
+		t.Errorf("expected liga to be switched off")
+	}
+	if stageOf(p, "smcp") != liga {
+		t.Errorf("expected smcp to be scheduled in the last GSUB stage, have %s", p)
+	}
+}
+
+func TestPlanMirroringSubstitution(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	b := testfont.New(4)
+	b.Name(1, "parenleft").Name(2, "parenright").Name(3, "ccmp.alt")
+	b.Map('(', 1).Map(')', 2)
+	err := b.Features(`
+languagesystem DFLT dflt;
+languagesystem arab dflt;
+
+feature rtlm {
+    sub parenleft by parenright;
+} rtlm;
+
+feature ccmp {
+    sub parenright by ccmp.alt;
+} ccmp;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	p, err := NewPlan(otf, ot.T("arab"), 0, bidi.RightToLeft, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rtlm, ccmp = -1, -1
+	for _, step := range p.Steps {
+		switch step.Feature.Tag() {
+		case ot.T("rtlm"):
+			rtlm = step.Stage
+		case ot.T("ccmp"):
+			ccmp = step.Stage
+		}
+	}
+	if rtlm < 0 || p.Stages[rtlm].Table != GSubFeatureType {
+		t.Fatalf("expected rtlm to be scheduled in a GSUB stage, have %s", p)
+	}
+	if ccmp < 0 || rtlm >= ccmp {
+		t.Fatalf("expected rtlm to be scheduled before ccmp, have %s", p)
+	}
+	st := NewBufferState(GlyphBuffer{1}, NewPosBuffer(1))
+	for _, step := range p.Steps {
+		for _, inx := range step.Lookups {
+			st.Index = 0
+			ApplyLookup(otf, p.Stages[step.Stage].Table, inx, st, step.Value)
+		}
+	}
+	if len(st.Glyphs) != 1 || st.Glyphs[0] != 3 {
+		t.Errorf("expected parenleft to be mirrored by rtlm, then replaced by ccmp, have %v", st.Glyphs)
+	}
+	p, err = NewPlan(otf, ot.T("arab"), 0, bidi.LeftToRight, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range p.Steps {
+		if step.Feature.Tag() == ot.T("rtlm") {
+			t.Errorf("did not expect rtlm to be scheduled for left-to-right text")
+		}
+	}
+}
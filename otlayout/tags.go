@@ -1,6 +1,12 @@
 package otlayout
 
-import "github.com/npillmayer/opentype/ot"
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
 
 // LayoutTagType denotes the type an OpenType layout tag as registered here:
 // https://docs.microsoft.com/en-us/typography/opentype/spec/ttoreg
@@ -14,138 +20,238 @@ const (
 	BaselineType    LayoutTagType = 5
 )
 
-// RegisteredFeatureTags is a list of all the layout features currently (Dec 2020)
-// registered at
-// https://docs.microsoft.com/en-us/typography/opentype/spec/featurelist.
+// RegisteredFeatureTags maps the layout features currently registered at
+// https://learn.microsoft.com/en-us/typography/opentype/spec/featurelist
+// to the table usually implementing them. It is derived from the feature
+// registry, see FeatureInfo.
 //
 // Please note: features 'cv01'–'cv99' and features 'ss01'–'ss20' are not
 // listed here, but will be found in a font by other means.
 // Also, some features are not strictly required to exclusively be in GSUB or GPOS,
 // but allow for implementations in either table. This will be taken into account
 // by `FontFeature(…)` as well.
-var RegisteredFeatureTags = map[ot.Tag]LayoutTagType{
-	ot.T("aalt"): GSubFeatureType, // Access All Alternates
-	ot.T("abvf"): GSubFeatureType, // Above-base Forms
-	ot.T("abvm"): GPosFeatureType, // Above-base Mark Positioning
-	ot.T("abvs"): GSubFeatureType, // Above-base Substitutions
-	ot.T("afrc"): GSubFeatureType, // Alternative Fractions
-	ot.T("akhn"): GSubFeatureType, // Akhands
-	ot.T("blwf"): GSubFeatureType, // Below-base Forms
-	ot.T("blwm"): GPosFeatureType, // Below-base Mark Positioning
-	ot.T("blws"): GSubFeatureType, // Below-base Substitutions
-	ot.T("calt"): GSubFeatureType, // Contextual Alternates
-	ot.T("case"): GSubFeatureType, // Case-Sensitive Forms
-	ot.T("ccmp"): GSubFeatureType, // Glyph Composition / Decomposition
-	ot.T("cfar"): GSubFeatureType, // Conjunct Form After Ro
-	ot.T("chws"): GSubFeatureType, // Contextual Half-width Spacing
-	ot.T("cjct"): GSubFeatureType, // Conjunct Forms
-	ot.T("clig"): GSubFeatureType, // Contextual Ligatures
-	ot.T("cpct"): GPosFeatureType, // Centered CJK Punctuation
-	ot.T("cpsp"): GPosFeatureType, // Capital Spacing
-	ot.T("cswh"): GSubFeatureType, // Contextual Swash
-	ot.T("curs"): GPosFeatureType, // Cursive Positioning
-	// cv01 - cv99
-	ot.T("c2pc"): GSubFeatureType, // Petite Capitals From Capitals
-	ot.T("c2sc"): GSubFeatureType, // Small Capitals From Capitals
-	ot.T("dist"): GPosFeatureType, // Distances
-	ot.T("dlig"): GSubFeatureType, // Discretionary Ligatures
-	ot.T("dnom"): GSubFeatureType, // Denominators
-	ot.T("dtls"): GSubFeatureType, // Dotless Forms
-	ot.T("expt"): GSubFeatureType, // Expert Forms
-	ot.T("falt"): GSubFeatureType, // Final Glyph on Line Alternates
-	ot.T("fin2"): GSubFeatureType, // Terminal Forms #2
-	ot.T("fin3"): GSubFeatureType, // Terminal Forms #3
-	ot.T("fina"): GSubFeatureType, // Terminal Forms
-	ot.T("flac"): GSubFeatureType, // Flattened accent forms
-	ot.T("frac"): GSubFeatureType, // Fractions
-	ot.T("fwid"): GSubFeatureType, // Full Widths
-	ot.T("half"): GSubFeatureType, // Half Forms
-	ot.T("haln"): GSubFeatureType, // Halant Forms
-	ot.T("halt"): GSubFeatureType, // Alternate Half Widths
-	ot.T("hist"): GSubFeatureType, // Historical Forms
-	ot.T("hkna"): GSubFeatureType, // Horizontal Kana Alternates
-	ot.T("hlig"): GSubFeatureType, // Historical Ligatures
-	ot.T("hngl"): GSubFeatureType, // Hangul
-	ot.T("hojo"): GSubFeatureType, // Hojo Kanji Forms (JIS X 0212-1990 Kanji Forms)
-	ot.T("hwid"): GSubFeatureType, // Half Widths  // not strictly GSUB !
-	ot.T("init"): GSubFeatureType, // Initial Forms
-	ot.T("isol"): GSubFeatureType, // Isolated Forms
-	ot.T("ital"): GSubFeatureType, // Italics
-	ot.T("jalt"): GSubFeatureType, // Justification Alternates
-	ot.T("jp78"): GSubFeatureType, // JIS78 Forms
-	ot.T("jp83"): GSubFeatureType, // JIS83 Forms
-	ot.T("jp90"): GSubFeatureType, // JIS90 Forms
-	ot.T("jp04"): GSubFeatureType, // JIS2004 Forms
-	ot.T("kern"): GPosFeatureType, // Kerning
-	ot.T("lfbd"): GPosFeatureType, // Left Bounds
-	ot.T("liga"): GSubFeatureType, // Standard Ligatures
-	ot.T("ljmo"): GSubFeatureType, // Leading Jamo Forms
-	ot.T("lnum"): GSubFeatureType, // Lining Figures
-	ot.T("locl"): GSubFeatureType, // Localized Forms
-	ot.T("ltra"): GSubFeatureType, // Left-to-right alternates
-	ot.T("ltrm"): GSubFeatureType, // Left-to-right mirrored forms
-	ot.T("mark"): GPosFeatureType, // Mark Positioning
-	ot.T("med2"): GSubFeatureType, // Medial Forms #2
-	ot.T("medi"): GSubFeatureType, // Medial Forms
-	ot.T("mgrk"): GSubFeatureType, // Mathematical Greek
-	ot.T("mkmk"): GPosFeatureType, // Mark to Mark Positioning
-	ot.T("mset"): GSubFeatureType, // Mark Positioning via Substitution
-	ot.T("nalt"): GSubFeatureType, // Alternate Annotation Forms
-	ot.T("nlck"): GSubFeatureType, // NLC Kanji Forms
-	ot.T("nukt"): GSubFeatureType, // Nukta Forms
-	ot.T("numr"): GSubFeatureType, // Numerators
-	ot.T("onum"): GSubFeatureType, // Oldstyle Figures
-	ot.T("opbd"): GPosFeatureType, // Optical Bounds
-	ot.T("ordn"): GSubFeatureType, // Ordinals
-	ot.T("ornm"): GSubFeatureType, // Ornaments
-	ot.T("palt"): GSubFeatureType, // Proportional Alternate Widths
-	ot.T("pcap"): GSubFeatureType, // Petite Capitals
-	ot.T("pkna"): GSubFeatureType, // Proportional Kana
-	ot.T("pnum"): GSubFeatureType, // Proportional Figures
-	ot.T("pref"): GSubFeatureType, // Pre-Base Forms
-	ot.T("pres"): GSubFeatureType, // Pre-base Substitutions
-	ot.T("pstf"): GSubFeatureType, // Post-base Forms
-	ot.T("psts"): GSubFeatureType, // Post-base Substitutions
-	ot.T("pwid"): GSubFeatureType, // Proportional Widths
-	ot.T("qwid"): GSubFeatureType, // Quarter Widths
-	ot.T("rand"): GSubFeatureType, // Randomize
-	ot.T("rclt"): GSubFeatureType, // Required Contextual Alternates
-	ot.T("rkrf"): GSubFeatureType, // Rakar Forms
-	ot.T("rlig"): GSubFeatureType, // Required Ligatures
-	ot.T("rphf"): GSubFeatureType, // Reph Forms
-	ot.T("rtbd"): GPosFeatureType, // Right Bounds
-	ot.T("rtla"): GSubFeatureType, // Right-to-left alternates
-	ot.T("rtlm"): GSubFeatureType, // Right-to-left mirrored forms
-	ot.T("ruby"): GSubFeatureType, // Ruby Notation Forms
-	ot.T("rvrn"): GSubFeatureType, // Required Variation Alternates
-	ot.T("salt"): GSubFeatureType, // Stylistic Alternates
-	ot.T("sinf"): GSubFeatureType, // Scientific Inferiors
-	ot.T("size"): GPosFeatureType, // Optical size
-	ot.T("smcp"): GSubFeatureType, // Small Capitals
-	ot.T("smpl"): GSubFeatureType, // Simplified Forms
-	// ss01 - ss20
-	ot.T("ssty"): GSubFeatureType, // Math script style alternates
-	ot.T("stch"): GSubFeatureType, // Stretching Glyph Decomposition
-	ot.T("subs"): GSubFeatureType, // Subscript
-	ot.T("sups"): GSubFeatureType, // Superscript
-	ot.T("swsh"): GSubFeatureType, // Swash
-	ot.T("titl"): GSubFeatureType, // Titling
-	ot.T("tjmo"): GSubFeatureType, // Trailing Jamo Forms
-	ot.T("tnam"): GSubFeatureType, // Traditional Name Forms
-	ot.T("tnum"): GSubFeatureType, // Tabular Figures
-	ot.T("trad"): GSubFeatureType, // Traditional Forms
-	ot.T("twid"): GSubFeatureType, // Third Widths   // not strictly GSUB !
-	ot.T("unic"): GSubFeatureType, // Unicase
-	ot.T("valt"): GSubFeatureType, // Alternate Vertical Metrics
-	ot.T("vatu"): GSubFeatureType, // Vattu Variants
-	ot.T("vchw"): GPosFeatureType, // Vertical Contextual Half-width Spacing
-	ot.T("vert"): GSubFeatureType, // Vertical Writing
-	ot.T("vhal"): GSubFeatureType, // Alternate Vertical Half Metrics
-	ot.T("vjmo"): GSubFeatureType, // Vowel Jamo Forms
-	ot.T("vkna"): GSubFeatureType, // Vertical Kana Alternates
-	ot.T("vkrn"): GPosFeatureType, // Vertical Kerning
-	ot.T("vpal"): GSubFeatureType, // Proportional Alternate Vertical Metrics
-	ot.T("vrt2"): GSubFeatureType, // Vertical Alternates and Rotation
-	ot.T("vrtr"): GSubFeatureType, // Vertical Alternates for Rotation
-	ot.T("zero"): GSubFeatureType, // Slashed Zero
+var RegisteredFeatureTags = map[ot.Tag]LayoutTagType{}
+
+func init() {
+	for _, f := range registeredFeatures {
+		RegisteredFeatureTags[f.Tag] = f.Type
+	}
+}
+
+// FeatureKind tells how a feature is meant to be used by applications.
+type FeatureKind uint8
+
+const (
+	Discretionary FeatureKind = iota // off by default, may be turned on by users
+	DefaultOn                        // on by default, may be turned off by users
+	Required                         // always on, needed to render a script correctly
+)
+
+func (k FeatureKind) String() string {
+	switch k {
+	case Discretionary:
+		return "discretionary"
+	case DefaultOn:
+		return "default-on"
+	case Required:
+		return "required"
+	}
+	return "unknown"
+}
+
+// FeatureMeta describes a registered layout feature, e.g. for presenting the
+// features of a font in a user interface.
+type FeatureMeta struct {
+	Tag         ot.Tag
+	Name        string        // human-readable name, as registered
+	Type        LayoutTagType // table usually implementing the feature
+	EitherTable bool          // feature may be implemented in GSUB or GPOS
+	Kind        FeatureKind   // usage of the feature, for the scripts in Scripts
+	Scripts     []ot.Tag      // scripts the feature is meant for; nil for any script
+	Vertical    bool          // feature applies to vertical text layout only
+}
+
+// FeatureInfo returns metadata for a registered feature, including the
+// stylistic sets 'ss01'–'ss20' and character variants 'cv01'–'cv99'.
+func FeatureInfo(tag ot.Tag) (FeatureMeta, bool) {
+	if n, ok := numberedFeature(tag, "ss"); ok && n >= 1 && n <= 20 {
+		return FeatureMeta{Tag: tag, Name: fmt.Sprintf("Stylistic Set %d", n),
+			Type: GSubFeatureType, Kind: Discretionary}, true
+	}
+	if n, ok := numberedFeature(tag, "cv"); ok && n >= 1 {
+		return FeatureMeta{Tag: tag, Name: fmt.Sprintf("Character Variant %d", n),
+			Type: GSubFeatureType, Kind: Discretionary}, true
+	}
+	i, ok := slices.BinarySearchFunc(registeredFeatures, tag, func(f FeatureMeta, tag ot.Tag) int {
+		return cmp.Compare(f.Tag, tag)
+	})
+	if !ok {
+		return FeatureMeta{}, false
+	}
+	return registeredFeatures[i], true
+}
+
+// RegisteredFeatures returns metadata for all registered features except
+// stylistic sets and character variants, ordered by tag.
+func RegisteredFeatures() []FeatureMeta {
+	return slices.Clone(registeredFeatures)
+}
+
+// AppliesTo reports whether a feature is meant for script. Features without
+// a list of scripts apply to any script.
+func (f FeatureMeta) AppliesTo(script ot.Tag) bool {
+	return f.Scripts == nil || slices.Contains(f.Scripts, script)
+}
+
+// numberedFeature extracts the number of features like 'ss01' or 'cv42'.
+func numberedFeature(tag ot.Tag, prefix string) (int, bool) {
+	s := tag.String()
+	if s[:2] != prefix || s[2] < '0' || s[2] > '9' || s[3] < '0' || s[3] > '9' {
+		return 0, false
+	}
+	return int(s[2]-'0')*10 + int(s[3]-'0'), true
+}
+
+// Script groups for feature metadata.
+var (
+	arabicScripts  = []ot.Tag{ot.T("arab")}
+	syriacScripts  = []ot.Tag{ot.T("syrc")}
+	hangulScripts  = []ot.Tag{ot.T("hang")}
+	khmerScripts   = []ot.Tag{ot.T("khmr")}
+	joiningScripts = []ot.Tag{
+		ot.T("arab"), ot.T("syrc"), ot.T("mong"), ot.T("nko "), ot.T("phag"), ot.T("mand"),
+		ot.T("mani"), ot.T("phlp"), ot.T("adlm"), ot.T("rohg"), ot.T("sogd"), ot.T("chrs"),
+	}
+	indicScripts = []ot.Tag{
+		ot.T("deva"), ot.T("dev2"), ot.T("beng"), ot.T("bng2"), ot.T("guru"), ot.T("gur2"),
+		ot.T("gujr"), ot.T("gjr2"), ot.T("orya"), ot.T("ory2"), ot.T("taml"), ot.T("tml2"),
+		ot.T("telu"), ot.T("tel2"), ot.T("knda"), ot.T("knd2"), ot.T("mlym"), ot.T("mlm2"),
+		ot.T("sinh"),
+	}
+)
+
+// registeredFeatures is the feature registry, ordered by tag.
+var registeredFeatures = []FeatureMeta{
+	{Tag: ot.T("aalt"), Name: "Access All Alternates", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("abvf"), Name: "Above-base Forms", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("abvm"), Name: "Above-base Mark Positioning", Type: GPosFeatureType, Kind: Required},
+	{Tag: ot.T("abvs"), Name: "Above-base Substitutions", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("afrc"), Name: "Alternative Fractions", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("akhn"), Name: "Akhand", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("blwf"), Name: "Below-base Forms", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("blwm"), Name: "Below-base Mark Positioning", Type: GPosFeatureType, Kind: Required},
+	{Tag: ot.T("blws"), Name: "Below-base Substitutions", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("c2pc"), Name: "Petite Capitals From Capitals", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("c2sc"), Name: "Small Capitals From Capitals", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("calt"), Name: "Contextual Alternates", Type: GSubFeatureType, Kind: DefaultOn},
+	{Tag: ot.T("case"), Name: "Case-Sensitive Forms", Type: GSubFeatureType, EitherTable: true, Kind: Discretionary},
+	{Tag: ot.T("ccmp"), Name: "Glyph Composition / Decomposition", Type: GSubFeatureType, Kind: Required},
+	{Tag: ot.T("cfar"), Name: "Conjunct Form After Ro", Type: GSubFeatureType, Kind: Required, Scripts: khmerScripts},
+	{Tag: ot.T("chws"), Name: "Contextual Half-width Spacing", Type: GPosFeatureType, Kind: Discretionary},
+	{Tag: ot.T("cjct"), Name: "Conjunct Forms", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("clig"), Name: "Contextual Ligatures", Type: GSubFeatureType, Kind: DefaultOn},
+	{Tag: ot.T("cpct"), Name: "Centered CJK Punctuation", Type: GPosFeatureType, Kind: Discretionary},
+	{Tag: ot.T("cpsp"), Name: "Capital Spacing", Type: GPosFeatureType, Kind: Discretionary},
+	{Tag: ot.T("cswh"), Name: "Contextual Swash", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("curs"), Name: "Cursive Positioning", Type: GPosFeatureType, Kind: Required},
+	{Tag: ot.T("dist"), Name: "Distances", Type: GPosFeatureType, Kind: Required},
+	{Tag: ot.T("dlig"), Name: "Discretionary Ligatures", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("dnom"), Name: "Denominators", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("dtls"), Name: "Dotless Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("expt"), Name: "Expert Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("falt"), Name: "Final Glyph on Line Alternates", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("fin2"), Name: "Terminal Forms #2", Type: GSubFeatureType, Kind: Required, Scripts: syriacScripts},
+	{Tag: ot.T("fin3"), Name: "Terminal Forms #3", Type: GSubFeatureType, Kind: Required, Scripts: syriacScripts},
+	{Tag: ot.T("fina"), Name: "Terminal Forms", Type: GSubFeatureType, Kind: Required, Scripts: joiningScripts},
+	{Tag: ot.T("flac"), Name: "Flattened Accent Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("frac"), Name: "Fractions", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("fwid"), Name: "Full Widths", Type: GSubFeatureType, EitherTable: true, Kind: Discretionary},
+	{Tag: ot.T("half"), Name: "Half Forms", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("haln"), Name: "Halant Forms", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("halt"), Name: "Alternate Half Widths", Type: GPosFeatureType, Kind: Discretionary},
+	{Tag: ot.T("hist"), Name: "Historical Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("hkna"), Name: "Horizontal Kana Alternates", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("hlig"), Name: "Historical Ligatures", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("hngl"), Name: "Hangul", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("hojo"), Name: "Hojo Kanji Forms (JIS X 0212-1990 Kanji Forms)", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("hwid"), Name: "Half Widths", Type: GSubFeatureType, EitherTable: true, Kind: Discretionary},
+	{Tag: ot.T("init"), Name: "Initial Forms", Type: GSubFeatureType, Kind: Required, Scripts: joiningScripts},
+	{Tag: ot.T("isol"), Name: "Isolated Forms", Type: GSubFeatureType, Kind: Required, Scripts: joiningScripts},
+	{Tag: ot.T("ital"), Name: "Italics", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("jalt"), Name: "Justification Alternates", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("jp04"), Name: "JIS2004 Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("jp78"), Name: "JIS78 Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("jp83"), Name: "JIS83 Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("jp90"), Name: "JIS90 Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("kern"), Name: "Kerning", Type: GPosFeatureType, Kind: DefaultOn},
+	{Tag: ot.T("lfbd"), Name: "Left Bounds", Type: GPosFeatureType, Kind: Discretionary},
+	{Tag: ot.T("liga"), Name: "Standard Ligatures", Type: GSubFeatureType, Kind: DefaultOn},
+	{Tag: ot.T("ljmo"), Name: "Leading Jamo Forms", Type: GSubFeatureType, Kind: Required, Scripts: hangulScripts},
+	{Tag: ot.T("lnum"), Name: "Lining Figures", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("locl"), Name: "Localized Forms", Type: GSubFeatureType, Kind: Required},
+	{Tag: ot.T("ltra"), Name: "Left-to-right Alternates", Type: GSubFeatureType, Kind: Required},
+	{Tag: ot.T("ltrm"), Name: "Left-to-right Mirrored Forms", Type: GSubFeatureType, Kind: Required},
+	{Tag: ot.T("mark"), Name: "Mark Positioning", Type: GPosFeatureType, Kind: Required},
+	{Tag: ot.T("med2"), Name: "Medial Forms #2", Type: GSubFeatureType, Kind: Required, Scripts: syriacScripts},
+	{Tag: ot.T("medi"), Name: "Medial Forms", Type: GSubFeatureType, Kind: Required, Scripts: joiningScripts},
+	{Tag: ot.T("mgrk"), Name: "Mathematical Greek", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("mkmk"), Name: "Mark to Mark Positioning", Type: GPosFeatureType, Kind: Required},
+	{Tag: ot.T("mset"), Name: "Mark Positioning via Substitution", Type: GSubFeatureType, Kind: Required, Scripts: arabicScripts},
+	{Tag: ot.T("nalt"), Name: "Alternate Annotation Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("nlck"), Name: "NLC Kanji Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("nukt"), Name: "Nukta Forms", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("numr"), Name: "Numerators", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("onum"), Name: "Oldstyle Figures", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("opbd"), Name: "Optical Bounds", Type: GPosFeatureType, Kind: Discretionary},
+	{Tag: ot.T("ordn"), Name: "Ordinals", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("ornm"), Name: "Ornaments", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("palt"), Name: "Proportional Alternate Widths", Type: GPosFeatureType, Kind: Discretionary},
+	{Tag: ot.T("pcap"), Name: "Petite Capitals", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("pkna"), Name: "Proportional Kana", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("pnum"), Name: "Proportional Figures", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("pref"), Name: "Pre-base Forms", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("pres"), Name: "Pre-base Substitutions", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("pstf"), Name: "Post-base Forms", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("psts"), Name: "Post-base Substitutions", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("pwid"), Name: "Proportional Widths", Type: GSubFeatureType, EitherTable: true, Kind: Discretionary},
+	{Tag: ot.T("qwid"), Name: "Quarter Widths", Type: GSubFeatureType, EitherTable: true, Kind: Discretionary},
+	{Tag: ot.T("rand"), Name: "Randomize", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("rclt"), Name: "Required Contextual Alternates", Type: GSubFeatureType, Kind: Required},
+	{Tag: ot.T("rkrf"), Name: "Rakar Forms", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("rlig"), Name: "Required Ligatures", Type: GSubFeatureType, Kind: Required},
+	{Tag: ot.T("rphf"), Name: "Reph Form", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("rtbd"), Name: "Right Bounds", Type: GPosFeatureType, Kind: Discretionary},
+	{Tag: ot.T("rtla"), Name: "Right-to-left Alternates", Type: GSubFeatureType, Kind: Required},
+	{Tag: ot.T("rtlm"), Name: "Right-to-left Mirrored Forms", Type: GSubFeatureType, Kind: Required},
+	{Tag: ot.T("ruby"), Name: "Ruby Notation Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("rvrn"), Name: "Required Variation Alternates", Type: GSubFeatureType, Kind: Required},
+	{Tag: ot.T("salt"), Name: "Stylistic Alternates", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("sinf"), Name: "Scientific Inferiors", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("size"), Name: "Optical size", Type: GPosFeatureType, Kind: DefaultOn},
+	{Tag: ot.T("smcp"), Name: "Small Capitals", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("smpl"), Name: "Simplified Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("ssty"), Name: "Math Script-style Alternates", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("stch"), Name: "Stretching Glyph Decomposition", Type: GSubFeatureType, Kind: Required, Scripts: syriacScripts},
+	{Tag: ot.T("subs"), Name: "Subscript", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("sups"), Name: "Superscript", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("swsh"), Name: "Swash", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("titl"), Name: "Titling", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("tjmo"), Name: "Trailing Jamo Forms", Type: GSubFeatureType, Kind: Required, Scripts: hangulScripts},
+	{Tag: ot.T("tnam"), Name: "Traditional Name Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("tnum"), Name: "Tabular Figures", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("trad"), Name: "Traditional Forms", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("twid"), Name: "Third Widths", Type: GSubFeatureType, EitherTable: true, Kind: Discretionary},
+	{Tag: ot.T("unic"), Name: "Unicase", Type: GSubFeatureType, Kind: Discretionary},
+	{Tag: ot.T("valt"), Name: "Alternate Vertical Metrics", Type: GPosFeatureType, Kind: Discretionary, Vertical: true},
+	{Tag: ot.T("vatu"), Name: "Vattu Variants", Type: GSubFeatureType, Kind: Required, Scripts: indicScripts},
+	{Tag: ot.T("vchw"), Name: "Vertical Contextual Half-width Spacing", Type: GPosFeatureType, Kind: Discretionary, Vertical: true},
+	{Tag: ot.T("vert"), Name: "Vertical Alternates", Type: GSubFeatureType, Kind: Required, Vertical: true},
+	{Tag: ot.T("vhal"), Name: "Alternate Vertical Half Metrics", Type: GPosFeatureType, Kind: Discretionary, Vertical: true},
+	{Tag: ot.T("vjmo"), Name: "Vowel Jamo Forms", Type: GSubFeatureType, Kind: Required, Scripts: hangulScripts},
+	{Tag: ot.T("vkna"), Name: "Vertical Kana Alternates", Type: GSubFeatureType, Kind: Discretionary, Vertical: true},
+	{Tag: ot.T("vkrn"), Name: "Vertical Kerning", Type: GPosFeatureType, Kind: DefaultOn, Vertical: true},
+	{Tag: ot.T("vpal"), Name: "Proportional Alternate Vertical Metrics", Type: GPosFeatureType, Kind: Discretionary, Vertical: true},
+	{Tag: ot.T("vrt2"), Name: "Vertical Alternates and Rotation", Type: GSubFeatureType, Kind: DefaultOn, Vertical: true},
+	{Tag: ot.T("vrtr"), Name: "Vertical Alternates for Rotation", Type: GSubFeatureType, Kind: DefaultOn, Vertical: true},
+	{Tag: ot.T("zero"), Name: "Slashed Zero", Type: GSubFeatureType, Kind: Discretionary},
 }
@@ -0,0 +1,45 @@
+package otlayout
+
+import (
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestFeatureInfo(t *testing.T) {
+	for _, c := range []struct {
+		tag    string
+		name   string
+		typ    LayoutTagType
+		either bool
+		kind   FeatureKind
+	}{
+		{"kern", "Kerning", GPosFeatureType, false, DefaultOn},
+		{"liga", "Standard Ligatures", GSubFeatureType, false, DefaultOn},
+		{"palt", "Proportional Alternate Widths", GPosFeatureType, false, Discretionary},
+		{"hwid", "Half Widths", GSubFeatureType, true, Discretionary},
+		{"ss05", "Stylistic Set 5", GSubFeatureType, false, Discretionary},
+		{"cv12", "Character Variant 12", GSubFeatureType, false, Discretionary},
+	} {
+		info, ok := FeatureInfo(ot.T(c.tag))
+		if !ok {
+			t.Errorf("expected feature '%s' to be registered", c.tag)
+			continue
+		}
+		if info.Name != c.name || info.Type != c.typ || info.EitherTable != c.either || info.Kind != c.kind {
+			t.Errorf("feature '%s': unexpected metadata %+v", c.tag, info)
+		}
+	}
+	for _, tag := range []string{"ss21", "cv00", "xxxx"} {
+		if _, ok := FeatureInfo(ot.T(tag)); ok {
+			t.Errorf("expected '%s' not to be registered", tag)
+		}
+	}
+	init, _ := FeatureInfo(ot.T("init"))
+	if init.Kind != Required || !init.AppliesTo(ot.T("arab")) || init.AppliesTo(ot.T("latn")) {
+		t.Errorf("expected 'init' to be required for joining scripts only, have %+v", init)
+	}
+	if len(RegisteredFeatures()) != len(RegisteredFeatureTags) {
+		t.Errorf("registry and RegisteredFeatureTags differ in size")
+	}
+}
@@ -0,0 +1,91 @@
+package otmerge
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// errTruncated is reported for data exceeding the bounds of a table.
+var errTruncated = errors.New("table data truncated")
+
+// blob is table data accessed at absolute offsets. Reads check bounds; writes
+// are expected to be preceded by a check of the region written.
+type blob []byte
+
+// check returns an error if the n bytes at offset off exceed the bounds of b.
+func (b blob) check(off, n int) error {
+	if off < 0 || n < 0 || off+n > len(b) {
+		return errTruncated
+	}
+	return nil
+}
+
+func (b blob) u16(off int) (uint16, error) {
+	if err := b.check(off, 2); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint16(b[off:]), nil
+}
+
+func (b blob) u32(off int) (uint32, error) {
+	if err := b.check(off, 4); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint32(b[off:]), nil
+}
+
+// offset reads a 16-bit offset at position at and returns it relative to
+// base. A NULL offset results in 0.
+func (b blob) offset(base, at int) (int, error) {
+	off, err := b.u16(at)
+	if err != nil || off == 0 {
+		return 0, err
+	}
+	return base + int(off), nil
+}
+
+func (b blob) put16(off int, v uint16) {
+	binary.BigEndian.PutUint16(b[off:], v)
+}
+
+// writer appends big-endian values to a buffer.
+type writer struct {
+	buf []byte
+}
+
+func (w *writer) pos() int {
+	return len(w.buf)
+}
+
+func (w *writer) u16(v uint16) {
+	w.buf = binary.BigEndian.AppendUint16(w.buf, v)
+}
+
+func (w *writer) u32(v uint32) {
+	w.buf = binary.BigEndian.AppendUint32(w.buf, v)
+}
+
+func (w *writer) bytes(b []byte) {
+	w.buf = append(w.buf, b...)
+}
+
+func (w *writer) zeros(n int) {
+	w.buf = append(w.buf, make([]byte, n)...)
+}
+
+// align4 pads the buffer with zeros to a multiple of 4 bytes.
+func (w *writer) align4() {
+	for len(w.buf)%4 != 0 {
+		w.buf = append(w.buf, 0)
+	}
+}
+
+// patch16 overwrites the 16-bit value at position at.
+func (w *writer) patch16(at int, v uint16) {
+	binary.BigEndian.PutUint16(w.buf[at:], v)
+}
+
+// patch32 overwrites the 32-bit value at position at.
+func (w *writer) patch32(at int, v uint32) {
+	binary.BigEndian.PutUint32(w.buf[at:], v)
+}
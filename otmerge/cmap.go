@@ -0,0 +1,141 @@
+package otmerge
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// mergeCMap creates table 'cmap' from the mappings of both fonts, with the
+// mappings of the base font taking precedence.
+//
+// The table has a format 4 subtable for the BMP (platform 3, encoding 1) and, if
+// code-points outside the BMP are mapped or the BMP mappings do not fit into a
+// format 4 subtable, a format 12 subtable (platform 3, encoding 10).
+func (m *merger) mergeCMap() {
+	m.cmap = make(map[rune]ot.GlyphIndex)
+	if cmap := m.fallback.CMapTable(); cmap != nil {
+		for r := range cmap.Codepoints() {
+			m.cmap[r] = m.glyph(cmap.GlyphIndexMap.Lookup(r))
+		}
+	}
+	if cmap := m.base.CMapTable(); cmap != nil {
+		for r := range cmap.Codepoints() {
+			m.cmap[r] = cmap.GlyphIndexMap.Lookup(r)
+		}
+	}
+	runes := slices.Sorted(maps.Keys(m.cmap))
+	format4 := cmapFormat4(runes, m.cmap)
+	var format12 []byte
+	if format4 == nil || (len(runes) > 0 && runes[len(runes)-1] > 0xffff) {
+		format12 = cmapFormat12(runes, m.cmap)
+	}
+	w := &writer{}
+	w.u16(0) // version
+	n := 0
+	if format4 != nil {
+		n++
+	}
+	if format12 != nil {
+		n++
+	}
+	w.u16(uint16(n))
+	offset := 4 + 8*n
+	if format4 != nil {
+		w.u16(3) // platform Windows
+		w.u16(1) // encoding Unicode BMP
+		w.u32(uint32(offset))
+		offset += len(format4)
+	}
+	if format12 != nil {
+		w.u16(3)  // platform Windows
+		w.u16(10) // encoding Unicode full repertoire
+		w.u32(uint32(offset))
+	}
+	w.bytes(format4)
+	w.bytes(format12)
+	m.tables[ot.T("cmap")] = w.buf
+}
+
+// cmapSegment is a range of code-points mapped to consecutive glyphs.
+type cmapSegment struct {
+	start, end rune
+	glyph      ot.GlyphIndex // glyph of start
+}
+
+// cmapSegments groups the mappings of sorted code-points runes into segments.
+func cmapSegments(runes []rune, cmap map[rune]ot.GlyphIndex) []cmapSegment {
+	var segs []cmapSegment
+	for _, r := range runes {
+		g := cmap[r]
+		if k := len(segs) - 1; k >= 0 && segs[k].end+1 == r && segs[k].glyph+ot.GlyphIndex(r-segs[k].start) == g {
+			segs[k].end = r
+			continue
+		}
+		segs = append(segs, cmapSegment{start: r, end: r, glyph: g})
+	}
+	return segs
+}
+
+// cmapFormat4 returns a format 4 subtable for the BMP code-points of runes, or
+// nil if the subtable would exceed the maximum size of the format.
+func cmapFormat4(runes []rune, cmap map[rune]ot.GlyphIndex) []byte {
+	bmp := runes[:0:0]
+	for _, r := range runes {
+		if r < 0xffff {
+			bmp = append(bmp, r)
+		}
+	}
+	segs := append(cmapSegments(bmp, cmap), cmapSegment{start: 0xffff, end: 0xffff, glyph: 0})
+	size := 16 + 8*len(segs)
+	if size > 0xffff {
+		return nil
+	}
+	segCount := len(segs)
+	searchRange, entrySelector := 1, 0
+	for searchRange*2 <= segCount {
+		searchRange, entrySelector = searchRange*2, entrySelector+1
+	}
+	w := &writer{}
+	w.u16(4)
+	w.u16(uint16(size))
+	w.u16(0) // language
+	w.u16(uint16(2 * segCount))
+	w.u16(uint16(2 * searchRange))
+	w.u16(uint16(entrySelector))
+	w.u16(uint16(2 * (segCount - searchRange)))
+	for _, s := range segs {
+		w.u16(uint16(s.end))
+	}
+	w.u16(0) // reserved pad
+	for _, s := range segs {
+		w.u16(uint16(s.start))
+	}
+	for _, s := range segs {
+		if s.start == 0xffff {
+			w.u16(1) // the final segment maps to glyph 0
+			continue
+		}
+		w.u16(uint16(s.glyph) - uint16(s.start))
+	}
+	w.zeros(2 * segCount) // idRangeOffsets
+	return w.buf
+}
+
+// cmapFormat12 returns a format 12 subtable for runes.
+func cmapFormat12(runes []rune, cmap map[rune]ot.GlyphIndex) []byte {
+	segs := cmapSegments(runes, cmap)
+	w := &writer{}
+	w.u16(12)
+	w.u16(0)
+	w.u32(uint32(16 + 12*len(segs)))
+	w.u32(0) // language
+	w.u32(uint32(len(segs)))
+	for _, s := range segs {
+		w.u32(uint32(s.start))
+		w.u32(uint32(s.end))
+		w.u32(uint32(s.glyph))
+	}
+	return w.buf
+}
@@ -0,0 +1,53 @@
+/*
+Package otmerge merges OpenType fonts into fallback-composite fonts.
+
+A composite font combines the glyphs of a base font with the glyphs of a
+fallback font, e.g. a Latin text font with a font covering a script or symbols
+the text font lacks. PDF generators commonly embed a single font per run of
+text; merging fonts lets them shape and embed mixed-coverage text as one font:
+
+	data, err := otmerge.Merge(base, fallback)
+	…
+	composite, err := ot.Parse(data)
+
+Glyph IDs of the base font are retained, and glyphs of the fallback font are
+appended: glyph g of the fallback font is glyph NumGlyphs(base) + g of the
+merged font. Code-points mapped by both fonts are mapped to the glyph of the
+base font.
+
+Layout tables GSUB and GPOS are merged with the lookups of the fallback font
+appended to the lookups of the base font, and features of both fonts are
+combined per script and language system. GDEF glyph classes, mark attachment
+classes and mark glyph sets are merged as well. Lookups of the fallback font
+which cannot be re-mapped to the glyph IDs of the merged font, e.g. because
+they are malformed, are dropped. If layout tables cannot be merged at all, the
+merged font keeps the layout tables of the base font.
+
+# Status
+
+Only fonts with TrueType outlines ('glyf') or without outlines can be merged;
+CFF fonts and variable fonts are rejected (instantiate variable fonts with
+package otvar first). Both fonts have to have the same units per em. Hinting
+instructions of the fallback font's glyphs are removed, global tables such as
+'name', 'OS/2', 'kern' and the hinting programs are taken from the base font.
+Tables not understood by otmerge are dropped, as are feature parameters,
+feature variations, GDEF attachment points, ligature carets and item variation
+stores, and cmap subtables for variation sequences.
+
+# License
+
+Governed by a 3-Clause BSD license. License file may be found in the root
+folder of this module.
+
+Copyright © Norbert Pillmayer <norbert@pillmayer.com>
+*/
+package otmerge
+
+import (
+	"github.com/npillmayer/schuko/tracing"
+)
+
+// tracer writes to trace with key 'font.opentype'
+func tracer() tracing.Trace {
+	return tracing.Select("font.opentype")
+}
@@ -0,0 +1,277 @@
+package otmerge
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// gdefTable holds the parts of a GDEF table which are merged.
+type gdefTable struct {
+	glyphClasses []classRange
+	markClasses  []classRange
+	markSets     [][]uint16 // glyphs of the mark glyph sets
+}
+
+// classRange assigns class to glyphs start…end (inclusive).
+type classRange struct {
+	start, end, class uint16
+}
+
+// mergeGDef creates table GDEF of the merged font, with the glyph classes,
+// mark attachment classes and mark glyph sets of both fonts, and returns how
+// the layout tables of the fallback font have to be re-mapped. If the fallback
+// font has no GDEF table, the table of the base font is returned unchanged.
+func (m *merger) mergeGDef() ([]byte, layoutShift, error) {
+	shift := layoutShift{glyphs: m.nBase}
+	btable, ftable := m.base.Table(ot.T("GDEF")), m.fallback.Table(ot.T("GDEF"))
+	base, fallback := &gdefTable{}, &gdefTable{}
+	var err error
+	if btable != nil {
+		if base, err = parseGDef(blob(btable.Binary())); err != nil {
+			return nil, shift, fmt.Errorf("GDEF of base font: %w", err)
+		}
+	}
+	shift.markSets = len(base.markSets)
+	for _, r := range base.markClasses {
+		shift.markClass = max(shift.markClass, int(r.class))
+	}
+	if ftable == nil {
+		if btable == nil {
+			return nil, shift, nil
+		}
+		return btable.Binary(), shift, nil
+	}
+	if fallback, err = parseGDef(blob(ftable.Binary())); err != nil {
+		return nil, shift, fmt.Errorf("GDEF of fallback font: %w", err)
+	}
+	merged := &gdefTable{
+		glyphClasses: slices.Clone(base.glyphClasses),
+		markClasses:  slices.Clone(base.markClasses),
+		markSets:     slices.Clone(base.markSets),
+	}
+	g := uint16(m.nBase)
+	for _, r := range fallback.glyphClasses {
+		merged.glyphClasses = append(merged.glyphClasses, classRange{r.start + g, r.end + g, r.class})
+	}
+	for _, r := range fallback.markClasses {
+		class := int(r.class) + shift.markClass
+		if class > 0xff {
+			return nil, shift, errors.New("too many mark attachment classes")
+		}
+		merged.markClasses = append(merged.markClasses, classRange{r.start + g, r.end + g, uint16(class)})
+	}
+	for _, set := range fallback.markSets {
+		glyphs := make([]uint16, len(set))
+		for i, gid := range set {
+			glyphs[i] = gid + g
+		}
+		merged.markSets = append(merged.markSets, glyphs)
+	}
+	return merged.bytes(), shift, nil
+}
+
+// parseGDef parses the glyph classes, mark attachment classes and mark glyph
+// sets of a GDEF table.
+func parseGDef(b blob) (*gdefTable, error) {
+	major, err := b.u16(0)
+	if err != nil || major != 1 {
+		return nil, errors.New("unsupported GDEF version")
+	}
+	minor, _ := b.u16(2)
+	t := &gdefTable{}
+	if at, err := b.offset(0, 4); err != nil {
+		return nil, err
+	} else if t.glyphClasses, err = parseClassDef(b, at); err != nil {
+		return nil, fmt.Errorf("glyph classes: %w", err)
+	}
+	if at, err := b.offset(0, 10); err != nil {
+		return nil, err
+	} else if t.markClasses, err = parseClassDef(b, at); err != nil {
+		return nil, fmt.Errorf("mark attachment classes: %w", err)
+	}
+	if minor < 2 {
+		return t, nil
+	}
+	at, err := b.offset(0, 12)
+	if err != nil || at == 0 {
+		return t, err
+	}
+	n, err := b.u16(at + 2)
+	if err != nil {
+		return nil, err
+	}
+	for i := range int(n) {
+		off, err := b.u32(at + 4 + 4*i)
+		if err != nil {
+			return nil, err
+		}
+		glyphs, err := parseCoverage(b, at+int(off))
+		if err != nil {
+			return nil, fmt.Errorf("mark glyph set %d: %w", i, err)
+		}
+		t.markSets = append(t.markSets, glyphs)
+	}
+	return t, nil
+}
+
+// parseClassDef returns the class ranges of the class definition table at
+// offset at, or nil if at is 0.
+func parseClassDef(b blob, at int) ([]classRange, error) {
+	if at == 0 {
+		return nil, nil
+	}
+	format, err := b.u16(at)
+	if err != nil {
+		return nil, err
+	}
+	var ranges []classRange
+	switch format {
+	case 1:
+		start, err := b.u16(at + 2)
+		if err != nil {
+			return nil, err
+		}
+		n, err := b.u16(at + 4)
+		if err != nil {
+			return nil, err
+		}
+		for i := range int(n) {
+			class, err := b.u16(at + 6 + 2*i)
+			if err != nil {
+				return nil, err
+			}
+			if class != 0 {
+				g := start + uint16(i)
+				ranges = append(ranges, classRange{g, g, class})
+			}
+		}
+	case 2:
+		n, err := b.u16(at + 2)
+		if err != nil {
+			return nil, err
+		}
+		for i := range int(n) {
+			rec := at + 4 + 6*i
+			start, err := b.u16(rec)
+			if err != nil {
+				return nil, err
+			}
+			end, _ := b.u16(rec + 2)
+			class, err := b.u16(rec + 4)
+			if err != nil {
+				return nil, err
+			}
+			if class != 0 && start <= end {
+				ranges = append(ranges, classRange{start, end, class})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("class definition format %d", format)
+	}
+	return ranges, nil
+}
+
+// parseCoverage returns the glyphs of the coverage table at offset at.
+func parseCoverage(b blob, at int) ([]uint16, error) {
+	format, err := b.u16(at)
+	if err != nil {
+		return nil, err
+	}
+	n, err := b.u16(at + 2)
+	if err != nil {
+		return nil, err
+	}
+	var glyphs []uint16
+	switch format {
+	case 1:
+		for i := range int(n) {
+			g, err := b.u16(at + 4 + 2*i)
+			if err != nil {
+				return nil, err
+			}
+			glyphs = append(glyphs, g)
+		}
+	case 2:
+		for i := range int(n) {
+			rec := at + 4 + 6*i
+			start, err := b.u16(rec)
+			if err != nil {
+				return nil, err
+			}
+			end, err := b.u16(rec + 2)
+			if err != nil {
+				return nil, err
+			}
+			for g := int(start); g <= int(end); g++ {
+				glyphs = append(glyphs, uint16(g))
+			}
+		}
+	default:
+		return nil, fmt.Errorf("coverage format %d", format)
+	}
+	return glyphs, nil
+}
+
+// bytes serializes t as a GDEF table, version 1.2 if it has mark glyph sets,
+// and 1.0 otherwise.
+func (t *gdefTable) bytes() []byte {
+	w := &writer{}
+	w.u16(1)
+	headerSize := 12
+	if len(t.markSets) > 0 {
+		w.u16(2)
+		headerSize = 14
+	} else {
+		w.u16(0)
+	}
+	w.zeros(headerSize - 4)
+	if len(t.glyphClasses) > 0 {
+		w.patch16(4, uint16(w.pos()))
+		writeClassDef(w, t.glyphClasses)
+	}
+	if len(t.markClasses) > 0 {
+		w.patch16(10, uint16(w.pos()))
+		writeClassDef(w, t.markClasses)
+	}
+	if len(t.markSets) > 0 {
+		start := w.pos()
+		w.patch16(12, uint16(start))
+		w.u16(1) // format
+		w.u16(uint16(len(t.markSets)))
+		w.zeros(4 * len(t.markSets))
+		for i, set := range t.markSets {
+			w.patch32(start+4+4*i, uint32(w.pos()-start))
+			writeCoverage(w, set)
+		}
+	}
+	return w.buf
+}
+
+// writeClassDef writes a class definition table of format 2.
+func writeClassDef(w *writer, ranges []classRange) {
+	ranges = slices.SortedFunc(slices.Values(ranges), func(a, b classRange) int {
+		return cmp.Compare(a.start, b.start)
+	})
+	w.u16(2)
+	w.u16(uint16(len(ranges)))
+	for _, r := range ranges {
+		w.u16(r.start)
+		w.u16(r.end)
+		w.u16(r.class)
+	}
+}
+
+// writeCoverage writes a coverage table of format 1.
+func writeCoverage(w *writer, glyphs []uint16) {
+	glyphs = slices.Sorted(slices.Values(glyphs))
+	glyphs = slices.Compact(glyphs)
+	w.u16(1)
+	w.u16(uint16(len(glyphs)))
+	for _, g := range glyphs {
+		w.u16(g)
+	}
+}
@@ -0,0 +1,171 @@
+package otmerge
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Flags of composite glyph components.
+const (
+	argsAreWords     = 0x0001
+	haveScale        = 0x0008
+	moreComponents   = 0x0020
+	haveXYScale      = 0x0040
+	haveTwoByTwo     = 0x0080
+	haveInstructions = 0x0100
+)
+
+// outlineStats holds the maxp statistics of outlines which may change by
+// merging fonts.
+type outlineStats struct {
+	points, contours                   uint16
+	compositePoints, compositeContours uint16
+	componentElements, componentDepth  uint16
+}
+
+// mergeOutlines creates tables 'glyf' and 'loca' (in long format) from the
+// glyphs of both fonts. Glyphs of the base font are copied unchanged. Glyphs
+// of the fallback font lose their hinting instructions, as the merged font has
+// the hinting programs of the base font, and components of composite glyphs
+// are re-mapped.
+func (m *merger) mergeOutlines() error {
+	glyf, loca := &writer{}, &writer{}
+	for i, otf := range []*ot.Font{m.base, m.fallback} {
+		n := m.nBase
+		if i == 1 {
+			n = m.nFallback
+		}
+		offsets, err := readLoca(otf, n)
+		if err != nil {
+			return err
+		}
+		data := otf.Table(ot.T("glyf")).Binary()
+		for g := range n {
+			start, end := offsets[g], offsets[g+1]
+			if start > end || int(end) > len(data) {
+				return fmt.Errorf("'loca' entry of glyph %d out of bounds", g)
+			}
+			loca.u32(uint32(glyf.pos()))
+			gdata := data[start:end]
+			if i == 1 && len(gdata) > 0 {
+				if gdata, err = m.fallbackGlyph(gdata); err != nil {
+					return fmt.Errorf("glyph %d of fallback font: %w", g, err)
+				}
+			}
+			glyf.bytes(gdata)
+			glyf.align4()
+		}
+	}
+	loca.u32(uint32(glyf.pos()))
+	m.outlines = fallbackOutlineStats(m.fallback)
+	m.tables[ot.T("glyf")] = glyf.buf
+	m.tables[ot.T("loca")] = loca.buf
+	return nil
+}
+
+// readLoca returns the n+1 glyph offsets of table 'loca' of otf.
+func readLoca(otf *ot.Font, n int) ([]uint32, error) {
+	loca := otf.Table(ot.T("loca"))
+	if loca == nil {
+		return nil, errors.New("font with 'glyf' outlines lacks table 'loca'")
+	}
+	b := blob(loca.Binary())
+	offsets := make([]uint32, n+1)
+	long := otf.FontHead().IndexToLocFormat == 1
+	for i := range offsets {
+		if long {
+			v, err := b.u32(4 * i)
+			if err != nil {
+				return nil, fmt.Errorf("table 'loca': %w", err)
+			}
+			offsets[i] = v
+		} else {
+			v, err := b.u16(2 * i)
+			if err != nil {
+				return nil, fmt.Errorf("table 'loca': %w", err)
+			}
+			offsets[i] = 2 * uint32(v)
+		}
+	}
+	return offsets, nil
+}
+
+// fallbackGlyph returns the data of a glyph of the fallback font for the merged
+// font, without instructions and with components re-mapped.
+func (m *merger) fallbackGlyph(b blob) ([]byte, error) {
+	contours, err := b.u16(0)
+	if err != nil {
+		return nil, err
+	}
+	if int16(contours) >= 0 {
+		return stripInstructions(b, int(contours))
+	}
+	out := append([]byte(nil), b...)
+	pos := 10
+	for {
+		flags, err := b.u16(pos)
+		if err != nil {
+			return nil, err
+		}
+		comp, err := b.u16(pos + 2)
+		if err != nil {
+			return nil, err
+		}
+		binary.BigEndian.PutUint16(out[pos+2:], uint16(m.glyph(ot.GlyphIndex(comp))))
+		size := 4 + 4
+		if flags&argsAreWords == 0 {
+			size = 4 + 2
+		}
+		switch {
+		case flags&haveScale != 0:
+			size += 2
+		case flags&haveXYScale != 0:
+			size += 4
+		case flags&haveTwoByTwo != 0:
+			size += 8
+		}
+		if err := b.check(pos, size); err != nil {
+			return nil, err
+		}
+		if flags&moreComponents == 0 {
+			binary.BigEndian.PutUint16(out[pos:], flags&^haveInstructions)
+			return out[:pos+size], nil
+		}
+		pos += size
+	}
+}
+
+// stripInstructions returns the data of simple glyph b, which has n contours,
+// without instructions.
+func stripInstructions(b blob, n int) ([]byte, error) {
+	at := 10 + 2*n
+	length, err := b.u16(at)
+	if err != nil {
+		return nil, err
+	}
+	rest := at + 2 + int(length)
+	if err := b.check(rest, 0); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, len(b)-int(length))
+	out = append(out, b[:at]...)
+	out = append(out, 0, 0)
+	return append(out, b[rest:]...), nil
+}
+
+// fallbackOutlineStats returns the outline statistics of table 'maxp' of otf.
+func fallbackOutlineStats(otf *ot.Font) outlineStats {
+	maxp := blob(otf.Table(ot.T("maxp")).Binary())
+	u16 := func(at int) uint16 {
+		v, _ := maxp.u16(at)
+		return v
+	}
+	return outlineStats{
+		points: u16(6), contours: u16(8),
+		compositePoints: u16(10), compositeContours: u16(12),
+		componentElements: u16(28), componentDepth: u16(30),
+	}
+}
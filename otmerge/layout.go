@@ -0,0 +1,652 @@
+package otmerge
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Lookup flags relevant for merging.
+const (
+	useMarkFilteringSet = 0x0010
+	markAttachmentType  = 0xff00
+)
+
+// layoutTable is the raw structure of a GSUB or GPOS table, as far as it is
+// relevant for merging. Lookup subtables are kept in data.
+type layoutTable struct {
+	data     blob
+	scripts  map[ot.Tag]*script
+	features []feature
+	lookups  []lookup
+}
+
+type script struct {
+	dflt  *langSys
+	langs map[ot.Tag]*langSys
+}
+
+type langSys struct {
+	required int   // index of the required feature, or -1
+	features []int // feature indices
+}
+
+type feature struct {
+	tag     ot.Tag
+	lookups []int
+}
+
+type lookup struct {
+	typ       uint16 // lookup type, with extension lookups resolved
+	flag      uint16
+	markSet   uint16 // mark filtering set, if flag has useMarkFilteringSet set
+	subtables []int  // offsets of the subtables in the table data
+}
+
+// layoutShift describes how the lookups of a layout table of the fallback font
+// are re-mapped for the merged font.
+type layoutShift struct {
+	glyphs    int // number of glyphs of the base font
+	lookups   int // number of lookups of the base font's table
+	markSets  int // number of mark glyph sets of the base font
+	markClass int // largest mark attachment class of the base font
+}
+
+// extensionType returns the lookup type of extension lookups of table tag.
+func extensionType(tag ot.Tag) uint16 {
+	if tag == ot.T("GPOS") {
+		return 9
+	}
+	return 7
+}
+
+// mergeLayout creates the layout tables of the merged font. If the fallback
+// font does not have a layout table, or if it cannot be merged, the table of
+// the base font is used unchanged.
+func (m *merger) mergeLayout() {
+	gdef, shift, err := m.mergeGDef()
+	if err != nil {
+		tracer().Infof("otmerge: keeping layout tables of base font: %v", err)
+		for _, tag := range []string{"GDEF", "GSUB", "GPOS"} {
+			if t := m.base.Table(ot.T(tag)); t != nil {
+				m.tables[ot.T(tag)] = t.Binary()
+			}
+		}
+		return
+	}
+	if gdef != nil {
+		m.tables[ot.T("GDEF")] = gdef
+	}
+	for _, tag := range []ot.Tag{ot.T("GSUB"), ot.T("GPOS")} {
+		btable, ftable := m.base.Table(tag), m.fallback.Table(tag)
+		if ftable == nil {
+			if btable != nil {
+				m.tables[tag] = btable.Binary()
+			}
+			continue
+		}
+		data, err := mergeLayoutTable(tag, btable, ftable, shift)
+		if err != nil {
+			tracer().Infof("otmerge: keeping table %s of base font: %v", tag, err)
+			if btable != nil {
+				m.tables[tag] = btable.Binary()
+			}
+			continue
+		}
+		m.tables[tag] = data
+	}
+}
+
+// mergeLayoutTable merges layout tables btable (which may be nil) and ftable.
+func mergeLayoutTable(tag ot.Tag, btable, ftable ot.Table, shift layoutShift) ([]byte, error) {
+	ext := extensionType(tag)
+	base := &layoutTable{scripts: make(map[ot.Tag]*script)}
+	if btable != nil {
+		var err error
+		if base, err = parseLayoutTable(blob(btable.Binary()), ext); err != nil {
+			return nil, fmt.Errorf("base font: %w", err)
+		}
+	}
+	fallback, err := parseLayoutTable(blob(slices.Clone(ftable.Binary())), ext)
+	if err != nil {
+		return nil, fmt.Errorf("fallback font: %w", err)
+	}
+	shift.lookups = len(base.lookups)
+	r := newRemapper(fallback.data, tag == ot.T("GPOS"), shift)
+	for i := range fallback.lookups {
+		lu := &fallback.lookups[i]
+		if err := r.remapLookup(lu); err != nil {
+			tracer().Infof("otmerge: dropping lookup %d of table %s of fallback font: %v", i, tag, err)
+			lu.subtables = nil
+		}
+	}
+	return writeLayoutTable(base, fallback, shift, ext)
+}
+
+// --- Parsing ---------------------------------------------------------------
+
+// parseLayoutTable parses the script, feature and lookup lists of a layout
+// table, with extension type ext.
+func parseLayoutTable(b blob, ext uint16) (*layoutTable, error) {
+	t := &layoutTable{data: b, scripts: make(map[ot.Tag]*script)}
+	if major, err := b.u16(0); err != nil || major != 1 {
+		return nil, errors.New("unsupported layout table version")
+	}
+	scriptList, err := b.offset(0, 4)
+	if err != nil {
+		return nil, err
+	}
+	featureList, err := b.offset(0, 6)
+	if err != nil {
+		return nil, err
+	}
+	lookupList, err := b.offset(0, 8)
+	if err != nil {
+		return nil, err
+	}
+	if err := t.parseScripts(scriptList); err != nil {
+		return nil, fmt.Errorf("script list: %w", err)
+	}
+	if err := t.parseFeatures(featureList); err != nil {
+		return nil, fmt.Errorf("feature list: %w", err)
+	}
+	if err := t.parseLookups(lookupList, ext); err != nil {
+		return nil, fmt.Errorf("lookup list: %w", err)
+	}
+	return t, nil
+}
+
+func (t *layoutTable) parseScripts(at int) error {
+	if at == 0 {
+		return nil
+	}
+	count, err := t.data.u16(at)
+	if err != nil {
+		return err
+	}
+	for i := range int(count) {
+		rec := at + 2 + 6*i
+		tag, err := t.data.u32(rec)
+		if err != nil {
+			return err
+		}
+		off, err := t.data.offset(at, rec+4)
+		if err != nil || off == 0 {
+			return cmp.Or(err, errTruncated)
+		}
+		s := &script{langs: make(map[ot.Tag]*langSys)}
+		if dflt, err := t.data.offset(off, off); err != nil {
+			return err
+		} else if dflt != 0 {
+			if s.dflt, err = t.parseLangSys(dflt); err != nil {
+				return err
+			}
+		}
+		n, err := t.data.u16(off + 2)
+		if err != nil {
+			return err
+		}
+		for j := range int(n) {
+			lrec := off + 4 + 6*j
+			ltag, err := t.data.u32(lrec)
+			if err != nil {
+				return err
+			}
+			loff, err := t.data.offset(off, lrec+4)
+			if err != nil || loff == 0 {
+				return cmp.Or(err, errTruncated)
+			}
+			if s.langs[ot.Tag(ltag)], err = t.parseLangSys(loff); err != nil {
+				return err
+			}
+		}
+		t.scripts[ot.Tag(tag)] = s
+	}
+	return nil
+}
+
+func (t *layoutTable) parseLangSys(at int) (*langSys, error) {
+	req, err := t.data.u16(at + 2)
+	if err != nil {
+		return nil, err
+	}
+	n, err := t.data.u16(at + 4)
+	if err != nil {
+		return nil, err
+	}
+	ls := &langSys{required: -1}
+	if req != 0xffff {
+		ls.required = int(req)
+	}
+	for i := range int(n) {
+		inx, err := t.data.u16(at + 6 + 2*i)
+		if err != nil {
+			return nil, err
+		}
+		ls.features = append(ls.features, int(inx))
+	}
+	return ls, nil
+}
+
+func (t *layoutTable) parseFeatures(at int) error {
+	if at == 0 {
+		return nil
+	}
+	count, err := t.data.u16(at)
+	if err != nil {
+		return err
+	}
+	t.features = make([]feature, count)
+	for i := range t.features {
+		rec := at + 2 + 6*i
+		tag, err := t.data.u32(rec)
+		if err != nil {
+			return err
+		}
+		off, err := t.data.offset(at, rec+4)
+		if err != nil || off == 0 {
+			return cmp.Or(err, errTruncated)
+		}
+		n, err := t.data.u16(off + 2)
+		if err != nil {
+			return err
+		}
+		t.features[i].tag = ot.Tag(tag)
+		for j := range int(n) {
+			inx, err := t.data.u16(off + 4 + 2*j)
+			if err != nil {
+				return err
+			}
+			t.features[i].lookups = append(t.features[i].lookups, int(inx))
+		}
+	}
+	return nil
+}
+
+func (t *layoutTable) parseLookups(at int, ext uint16) error {
+	if at == 0 {
+		return nil
+	}
+	count, err := t.data.u16(at)
+	if err != nil {
+		return err
+	}
+	t.lookups = make([]lookup, count)
+	for i := range t.lookups {
+		off, err := t.data.offset(at, at+2+2*i)
+		if err != nil || off == 0 {
+			return cmp.Or(err, errTruncated)
+		}
+		lu := &t.lookups[i]
+		if lu.typ, err = t.data.u16(off); err != nil {
+			return err
+		}
+		if lu.flag, err = t.data.u16(off + 2); err != nil {
+			return err
+		}
+		n, err := t.data.u16(off + 4)
+		if err != nil {
+			return err
+		}
+		if lu.flag&useMarkFilteringSet != 0 {
+			if lu.markSet, err = t.data.u16(off + 6 + 2*int(n)); err != nil {
+				return err
+			}
+		}
+		for j := range int(n) {
+			sub, err := t.data.offset(off, off+6+2*j)
+			if err != nil || sub == 0 {
+				return cmp.Or(err, errTruncated)
+			}
+			if lu.typ == ext {
+				typ, err := t.data.u16(sub + 2)
+				if err != nil {
+					return err
+				}
+				target, err := t.data.u32(sub + 4)
+				if err != nil {
+					return err
+				}
+				if j == 0 {
+					lu.typ = typ
+				}
+				sub += int(target)
+			}
+			lu.subtables = append(lu.subtables, sub)
+		}
+	}
+	return nil
+}
+
+// --- Merging of script and feature lists -----------------------------------
+
+// mergedFeature is a feature of the merged font.
+type mergedFeature struct {
+	tag     ot.Tag
+	lookups []int
+}
+
+// key identifies features with identical tag and lookups.
+func (f mergedFeature) key() string {
+	return fmt.Sprintf("%s%v", f.tag, f.lookups)
+}
+
+// featureMerger collects the features of the merged font.
+type featureMerger struct {
+	base, fallback *layoutTable
+	shift          int // lookup index of the first lookup of the fallback font
+	features       map[string]*mergedFeature
+}
+
+// feature returns the merged feature with tag and the lookups of the
+// features base and fallback of the respective fonts.
+func (fm *featureMerger) feature(tag ot.Tag, base, fallback []int) *mergedFeature {
+	f := &mergedFeature{tag: tag}
+	for _, inx := range base {
+		if inx >= 0 && inx < len(fm.base.features) {
+			f.lookups = append(f.lookups, fm.base.features[inx].lookups...)
+		}
+	}
+	for _, inx := range fallback {
+		if inx >= 0 && inx < len(fm.fallback.features) {
+			for _, lu := range fm.fallback.features[inx].lookups {
+				f.lookups = append(f.lookups, lu+fm.shift)
+			}
+		}
+	}
+	slices.Sort(f.lookups)
+	f.lookups = slices.Compact(f.lookups)
+	if known, ok := fm.features[f.key()]; ok {
+		return known
+	}
+	fm.features[f.key()] = f
+	return f
+}
+
+// mergedLangSys is a language system of the merged font.
+type mergedLangSys struct {
+	required *mergedFeature
+	features []*mergedFeature
+}
+
+// langSys merges language systems base and fallback, which may be nil. Features
+// with identical tags are merged into one feature.
+func (fm *featureMerger) langSys(base, fallback *langSys) *mergedLangSys {
+	ls := &mergedLangSys{}
+	byTag := make(map[ot.Tag][2][]int)
+	var req [2][]int
+	var reqTag ot.Tag
+	for i, l := range []*langSys{base, fallback} {
+		if l == nil {
+			continue
+		}
+		for _, inx := range l.features {
+			tag := fm.featureTag(i, inx)
+			entry := byTag[tag]
+			entry[i] = append(entry[i], inx)
+			byTag[tag] = entry
+		}
+		if l.required >= 0 {
+			req[i] = []int{l.required}
+			if reqTag == 0 {
+				reqTag = fm.featureTag(i, l.required)
+			}
+		}
+	}
+	if reqTag != 0 {
+		ls.required = fm.feature(reqTag, req[0], req[1])
+	}
+	for _, tag := range slices.Sorted(maps.Keys(byTag)) {
+		entry := byTag[tag]
+		ls.features = append(ls.features, fm.feature(tag, entry[0], entry[1]))
+	}
+	return ls
+}
+
+// featureTag returns the tag of feature inx of the base font (font 0) or of
+// the fallback font (font 1).
+func (fm *featureMerger) featureTag(font, inx int) ot.Tag {
+	features := fm.base.features
+	if font == 1 {
+		features = fm.fallback.features
+	}
+	if inx < 0 || inx >= len(features) {
+		return 0
+	}
+	return features[inx].tag
+}
+
+// mergedScript is a script of the merged font.
+type mergedScript struct {
+	tag   ot.Tag
+	dflt  *mergedLangSys
+	langs map[ot.Tag]*mergedLangSys
+}
+
+// mergeScripts merges the script lists of base and fallback. For a script
+// supported by one of the fonts only, the other font contributes the features
+// of its default script 'DFLT', if any, as a shaper would use them for the
+// script. Likewise, language systems missing in one of the fonts are
+// substituted by the script's default language system.
+func (fm *featureMerger) mergeScripts() []*mergedScript {
+	tags := slices.Collect(maps.Keys(fm.base.scripts))
+	tags = append(tags, slices.Collect(maps.Keys(fm.fallback.scripts))...)
+	slices.Sort(tags)
+	tags = slices.Compact(tags)
+	pick := func(t *layoutTable, tag ot.Tag) *script {
+		if s, ok := t.scripts[tag]; ok {
+			return s
+		}
+		return t.scripts[ot.T("DFLT")]
+	}
+	var scripts []*mergedScript
+	for _, tag := range tags {
+		bs, fs := pick(fm.base, tag), pick(fm.fallback, tag)
+		ms := &mergedScript{tag: tag, langs: make(map[ot.Tag]*mergedLangSys)}
+		var bdflt, fdflt *langSys
+		if bs != nil {
+			bdflt = bs.dflt
+		}
+		if fs != nil {
+			fdflt = fs.dflt
+		}
+		if bdflt != nil || fdflt != nil {
+			ms.dflt = fm.langSys(bdflt, fdflt)
+		}
+		for _, s := range []*script{bs, fs} {
+			if s == nil {
+				continue
+			}
+			for lang := range s.langs {
+				if _, ok := ms.langs[lang]; ok {
+					continue
+				}
+				bl, fl := bdflt, fdflt
+				if bs != nil && bs.langs[lang] != nil {
+					bl = bs.langs[lang]
+				}
+				if fs != nil && fs.langs[lang] != nil {
+					fl = fs.langs[lang]
+				}
+				ms.langs[lang] = fm.langSys(bl, fl)
+			}
+		}
+		scripts = append(scripts, ms)
+	}
+	return scripts
+}
+
+// --- Writing ---------------------------------------------------------------
+
+// writeLayoutTable writes a layout table with the merged script and feature
+// lists of base and fallback. Lookups are written as extension lookups, which
+// point to the subtables of the original tables, appended to the merged table.
+func writeLayoutTable(base, fallback *layoutTable, shift layoutShift, ext uint16) ([]byte, error) {
+	fm := &featureMerger{base: base, fallback: fallback, shift: shift.lookups,
+		features: make(map[string]*mergedFeature)}
+	scripts := fm.mergeScripts()
+	features := slices.SortedFunc(maps.Values(fm.features), func(a, b *mergedFeature) int {
+		return cmp.Or(cmp.Compare(a.tag, b.tag), cmp.Compare(a.key(), b.key()))
+	})
+	index := make(map[*mergedFeature]int, len(features))
+	for i, f := range features {
+		index[f] = i
+	}
+	w := &writer{}
+	w.u16(1) // version 1.0
+	w.u16(0)
+	w.zeros(6) // offsets of script, feature and lookup list
+	w.patch16(4, uint16(w.pos()))
+	writeScriptList(w, scripts, index)
+	if w.pos() > 0xffff {
+		return nil, errors.New("merged script list too large")
+	}
+	w.patch16(6, uint16(w.pos()))
+	writeFeatureList(w, features)
+	if w.pos() > 0xffff {
+		return nil, errors.New("merged feature list too large")
+	}
+	w.patch16(8, uint16(w.pos()))
+	exts, err := writeLookupList(w, base, fallback, shift, ext)
+	if err != nil {
+		return nil, err
+	}
+	// append the original tables and let the extension subtables point to them
+	w.align4()
+	baseStart := w.pos()
+	w.bytes(base.data)
+	w.align4()
+	fallbackStart := w.pos()
+	w.bytes(fallback.data)
+	for _, e := range exts {
+		target := baseStart + e.subtable
+		if e.fallback {
+			target = fallbackStart + e.subtable
+		}
+		w.patch32(e.at+4, uint32(target-e.at))
+	}
+	return w.buf, nil
+}
+
+func writeScriptList(w *writer, scripts []*mergedScript, index map[*mergedFeature]int) {
+	list := w.pos()
+	w.u16(uint16(len(scripts)))
+	for _, s := range scripts {
+		w.u32(uint32(s.tag))
+		w.u16(0) // offset, patched below
+	}
+	for i, s := range scripts {
+		start := w.pos()
+		w.patch16(list+2+6*i+4, uint16(start-list))
+		langs := slices.Sorted(maps.Keys(s.langs))
+		w.u16(0) // default language system
+		w.u16(uint16(len(langs)))
+		for _, lang := range langs {
+			w.u32(uint32(lang))
+			w.u16(0)
+		}
+		if s.dflt != nil {
+			w.patch16(start, uint16(w.pos()-start))
+			writeLangSys(w, s.dflt, index)
+		}
+		for j, lang := range langs {
+			w.patch16(start+4+6*j+4, uint16(w.pos()-start))
+			writeLangSys(w, s.langs[lang], index)
+		}
+	}
+}
+
+func writeLangSys(w *writer, ls *mergedLangSys, index map[*mergedFeature]int) {
+	w.u16(0) // lookup order
+	if ls.required != nil {
+		w.u16(uint16(index[ls.required]))
+	} else {
+		w.u16(0xffff)
+	}
+	features := make([]int, len(ls.features))
+	for i, f := range ls.features {
+		features[i] = index[f]
+	}
+	slices.Sort(features)
+	w.u16(uint16(len(features)))
+	for _, inx := range features {
+		w.u16(uint16(inx))
+	}
+}
+
+func writeFeatureList(w *writer, features []*mergedFeature) {
+	list := w.pos()
+	w.u16(uint16(len(features)))
+	for _, f := range features {
+		w.u32(uint32(f.tag))
+		w.u16(0)
+	}
+	for i, f := range features {
+		w.patch16(list+2+6*i+4, uint16(w.pos()-list))
+		w.u16(0) // feature params
+		w.u16(uint16(len(f.lookups)))
+		for _, inx := range f.lookups {
+			w.u16(uint16(inx))
+		}
+	}
+}
+
+// extensionRef is an extension subtable of the merged lookup list, at position
+// at, which points to subtable of the base font's or the fallback font's
+// original table.
+type extensionRef struct {
+	at       int
+	subtable int
+	fallback bool
+}
+
+// writeLookupList writes the lookups of base and fallback as extension lookups.
+func writeLookupList(w *writer, base, fallback *layoutTable, shift layoutShift, ext uint16) ([]extensionRef, error) {
+	list := w.pos()
+	n := len(base.lookups) + len(fallback.lookups)
+	if n > 0xffff {
+		return nil, errors.New("too many lookups")
+	}
+	w.u16(uint16(n))
+	w.zeros(2 * n)
+	var exts []extensionRef
+	i := 0
+	for k, t := range []*layoutTable{base, fallback} {
+		for _, lu := range t.lookups {
+			if w.pos()-list > 0xffff {
+				return nil, errors.New("merged lookup list too large")
+			}
+			w.patch16(list+2+2*i, uint16(w.pos()-list))
+			i++
+			start := w.pos()
+			flag, markSet := lu.flag, lu.markSet
+			if k == 1 {
+				if class := flag & markAttachmentType; class != 0 {
+					flag = flag&^markAttachmentType | (class + uint16(shift.markClass)<<8)
+				}
+				if flag&useMarkFilteringSet != 0 {
+					markSet += uint16(shift.markSets)
+				}
+			}
+			w.u16(ext)
+			w.u16(flag)
+			w.u16(uint16(len(lu.subtables)))
+			w.zeros(2 * len(lu.subtables))
+			if flag&useMarkFilteringSet != 0 {
+				w.u16(markSet)
+			}
+			for j, sub := range lu.subtables {
+				w.patch16(start+6+2*j, uint16(w.pos()-start))
+				exts = append(exts, extensionRef{at: w.pos(), subtable: sub, fallback: k == 1})
+				w.u16(1) // format
+				w.u16(lu.typ)
+				w.u32(0) // offset, patched when the original tables are appended
+			}
+		}
+	}
+	return exts, nil
+}
@@ -0,0 +1,271 @@
+package otmerge
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// baseTables are taken unchanged from the base font, if present.
+var baseTables = []string{"name", "cvt ", "fpgm", "prep", "gasp", "kern"}
+
+// Merge creates a font consisting of the glyphs of base, followed by the
+// glyphs of fallback, and returns its binary, which may be parsed with
+// ot.Parse. Glyph g of fallback is glyph NumGlyphs(base) + g of the merged
+// font. Code-points mapped by both fonts are mapped to the glyph of base.
+//
+// Merge returns an error if one of the fonts has CFF outlines or is a variable
+// font, if only one of them has TrueType outlines, if the fonts differ in units
+// per em, or if the merged font would have more than 65535 glyphs.
+func Merge(base, fallback *ot.Font) ([]byte, error) {
+	if base == nil || fallback == nil {
+		return nil, errors.New("cannot merge nil font")
+	}
+	m := &merger{base: base, fallback: fallback, tables: make(map[ot.Tag][]byte)}
+	if err := m.check(); err != nil {
+		return nil, err
+	}
+	for _, tag := range baseTables {
+		if t := base.Table(ot.T(tag)); t != nil {
+			m.tables[ot.T(tag)] = t.Binary()
+		}
+	}
+	if base.Table(ot.T("glyf")) != nil {
+		if err := m.mergeOutlines(); err != nil {
+			return nil, err
+		}
+	}
+	m.mergeCMap()
+	if err := m.mergeMetrics(); err != nil {
+		return nil, err
+	}
+	m.mergeHead()
+	m.mergeMaxP()
+	m.mergePost()
+	m.mergeOS2()
+	m.mergeLayout()
+	for _, tag := range base.TableTags() {
+		if _, ok := m.tables[tag]; !ok {
+			tracer().Infof("otmerge: dropping table %s of base font", tag)
+		}
+	}
+	return ot.AssembleFont(base.Header.FontType, m.tables)
+}
+
+// merger holds the state of merging two fonts.
+type merger struct {
+	base, fallback *ot.Font
+	nBase          int               // number of glyphs of base
+	nFallback      int               // number of glyphs of fallback
+	tables         map[ot.Tag][]byte // tables of the merged font
+	outlines       outlineStats      // maxp statistics of the merged outlines
+	cmap           map[rune]ot.GlyphIndex
+}
+
+// check verifies that the fonts can be merged.
+func (m *merger) check() error {
+	for _, otf := range []*ot.Font{m.base, m.fallback} {
+		if otf.Table(ot.T("CFF ")) != nil || otf.Table(ot.T("CFF2")) != nil {
+			return errors.New("merging fonts with CFF outlines is not supported")
+		}
+		if otf.Table(ot.T("fvar")) != nil {
+			return errors.New("merging variable fonts is not supported; instantiate them first")
+		}
+		if otf.FontHead() == nil || otf.HorizontalMetrics() == nil || otf.Table(ot.T("hhea")) == nil {
+			return errors.New("font lacks one of tables 'head', 'hhea' or 'hmtx'")
+		}
+	}
+	if (m.base.Table(ot.T("glyf")) == nil) != (m.fallback.Table(ot.T("glyf")) == nil) {
+		return errors.New("cannot merge a font with TrueType outlines and a font without outlines")
+	}
+	if b, f := m.base.FontHead().UnitsPerEm, m.fallback.FontHead().UnitsPerEm; b != f {
+		return fmt.Errorf("fonts differ in units per em: %d and %d", b, f)
+	}
+	m.nBase, m.nFallback = numGlyphs(m.base), numGlyphs(m.fallback)
+	if m.nBase == 0 || m.nFallback == 0 {
+		return errors.New("font lacks a valid 'maxp' table")
+	}
+	if n := m.nBase + m.nFallback; n > 0xffff {
+		return fmt.Errorf("merged font would have %d glyphs, more than 65535", n)
+	}
+	return nil
+}
+
+func numGlyphs(otf *ot.Font) int {
+	maxp := otf.Table(ot.T("maxp"))
+	if maxp == nil {
+		return 0
+	}
+	return maxp.Self().AsMaxP().NumGlyphs
+}
+
+// glyph returns the glyph of the merged font for glyph g of fallback.
+func (m *merger) glyph(g ot.GlyphIndex) ot.GlyphIndex {
+	return ot.GlyphIndex(m.nBase) + g
+}
+
+// table returns a writable copy of table tag of the base font, padded to at
+// least size bytes. It returns nil if the base font does not contain the table.
+func (m *merger) table(tag string, size int) []byte {
+	t := m.base.Table(ot.T(tag))
+	if t == nil {
+		return nil
+	}
+	b := slices.Clone(t.Binary())
+	if len(b) < size {
+		b = append(b, make([]byte, size-len(b))...)
+	}
+	m.tables[ot.T(tag)] = b
+	return b
+}
+
+// --- Metrics ---------------------------------------------------------------
+
+// mergeMetrics creates table 'hmtx' with long metrics for all glyphs, and
+// table 'hhea' with the metrics summary of both fonts.
+func (m *merger) mergeMetrics() error {
+	w := &writer{}
+	var advMax uint16
+	for i, otf := range []*ot.Font{m.base, m.fallback} {
+		hmtx, n := otf.HorizontalMetrics(), m.nBase
+		if i == 1 {
+			n = m.nFallback
+		}
+		for g := range n {
+			adv, lsb, ok := hmtx.HMetrics(ot.GlyphIndex(g))
+			if !ok && g > 0 {
+				return fmt.Errorf("no horizontal metrics for glyph %d", g)
+			}
+			w.u16(adv)
+			w.u16(uint16(lsb))
+			advMax = max(advMax, adv)
+		}
+	}
+	m.tables[ot.T("hmtx")] = w.buf
+	hhea := m.table("hhea", 36)
+	fhhea := m.fallback.HorizontalHeader()
+	binary.BigEndian.PutUint16(hhea[10:], advMax)
+	if fhhea != nil {
+		minLSB := min(int16(binary.BigEndian.Uint16(hhea[12:])), fhhea.MinLeftSideBearing)
+		minRSB := min(int16(binary.BigEndian.Uint16(hhea[14:])), fhhea.MinRightSideBearing)
+		maxExtent := max(int16(binary.BigEndian.Uint16(hhea[16:])), fhhea.XMaxExtent)
+		binary.BigEndian.PutUint16(hhea[12:], uint16(minLSB))
+		binary.BigEndian.PutUint16(hhea[14:], uint16(minRSB))
+		binary.BigEndian.PutUint16(hhea[16:], uint16(maxExtent))
+	}
+	binary.BigEndian.PutUint16(hhea[34:], uint16(m.nBase+m.nFallback))
+	return nil
+}
+
+// mergeHead creates table 'head' of the base font, with the bounding box
+// enclosing the glyphs of both fonts and long 'loca' offsets.
+func (m *merger) mergeHead() {
+	head := m.table("head", 54)
+	fhead := m.fallback.FontHead()
+	for i, v := range []int16{fhead.XMin, fhead.YMin} {
+		if at := 36 + 2*i; v < int16(binary.BigEndian.Uint16(head[at:])) {
+			binary.BigEndian.PutUint16(head[at:], uint16(v))
+		}
+	}
+	for i, v := range []int16{fhead.XMax, fhead.YMax} {
+		if at := 40 + 2*i; v > int16(binary.BigEndian.Uint16(head[at:])) {
+			binary.BigEndian.PutUint16(head[at:], uint16(v))
+		}
+	}
+	if m.tables[ot.T("loca")] != nil {
+		binary.BigEndian.PutUint16(head[50:], 1)
+	}
+}
+
+// mergeMaxP creates table 'maxp' of the base font with the glyph count of the
+// merged font. For fonts with TrueType outlines, the maxima of the outline
+// statistics are updated.
+func (m *merger) mergeMaxP() {
+	maxp := m.table("maxp", 6)
+	binary.BigEndian.PutUint16(maxp[4:], uint16(m.nBase+m.nFallback))
+	if binary.BigEndian.Uint32(maxp) != 0x00010000 || len(maxp) < 32 {
+		return
+	}
+	fields := []struct {
+		at int
+		v  uint16
+	}{
+		{6, m.outlines.points}, {8, m.outlines.contours},
+		{10, m.outlines.compositePoints}, {12, m.outlines.compositeContours},
+		{28, m.outlines.componentElements}, {30, m.outlines.componentDepth},
+	}
+	for _, f := range fields {
+		binary.BigEndian.PutUint16(maxp[f.at:], max(binary.BigEndian.Uint16(maxp[f.at:]), f.v))
+	}
+}
+
+// mergePost creates table 'post' of the base font as version 3.0, i.e.,
+// without glyph names.
+func (m *merger) mergePost() {
+	if m.base.Table(ot.T("post")) == nil {
+		return
+	}
+	post := m.table("post", 32)[:32]
+	binary.BigEndian.PutUint32(post, 0x00030000)
+	m.tables[ot.T("post")] = post
+}
+
+// mergeOS2 creates table 'OS/2' of the base font, with Unicode and code page
+// ranges of both fonts, the character range of the merged cmap, and the more
+// restrictive embedding permissions.
+func (m *merger) mergeOS2() {
+	os2 := m.table("OS/2", 68)
+	if os2 == nil {
+		return
+	}
+	fos2 := m.fallback.Table(ot.T("OS/2"))
+	var f []byte
+	if fos2 != nil {
+		f = fos2.Binary()
+	}
+	if len(f) >= 10 {
+		binary.BigEndian.PutUint16(os2[8:], embeddingPermissions(
+			binary.BigEndian.Uint16(os2[8:]), binary.BigEndian.Uint16(f[8:])))
+	}
+	or32 := func(at int) {
+		if len(f) >= at+4 && len(os2) >= at+4 {
+			v := binary.BigEndian.Uint32(os2[at:]) | binary.BigEndian.Uint32(f[at:])
+			binary.BigEndian.PutUint32(os2[at:], v)
+		}
+	}
+	for _, at := range []int{42, 46, 50, 54, 78, 82} { // ulUnicodeRange1–4, ulCodePageRange1–2
+		or32(at)
+	}
+	first, last := rune(0xffff), rune(0)
+	for r := range m.cmap {
+		first, last = min(first, r), max(last, r)
+	}
+	if last > 0 {
+		binary.BigEndian.PutUint16(os2[64:], uint16(min(first, 0xffff)))
+		binary.BigEndian.PutUint16(os2[66:], uint16(min(last, 0xffff)))
+	}
+	if len(f) >= 96 && len(os2) >= 96 { // usMaxContext, version 2 and later
+		binary.BigEndian.PutUint16(os2[94:], max(binary.BigEndian.Uint16(os2[94:]), binary.BigEndian.Uint16(f[94:])))
+	}
+}
+
+// embeddingPermissions returns the more restrictive of the OS/2 fsType values
+// a and b.
+func embeddingPermissions(a, b uint16) uint16 {
+	const (
+		restricted = 0x0002
+		preview    = 0x0004
+		editable   = 0x0008
+		usage      = 0x000f
+	)
+	flags := (a | b) &^ usage
+	for _, p := range []uint16{restricted, preview, editable} {
+		if a&p != 0 || b&p != 0 {
+			return flags | p
+		}
+	}
+	return flags
+}
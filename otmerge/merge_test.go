@@ -0,0 +1,204 @@
+package otmerge
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otfea"
+	"github.com/npillmayer/opentype/otlayout"
+)
+
+var (
+	baseGlyphs     = []string{".notdef", "f", "i", "f_i", "acutecomb"}
+	fallbackGlyphs = []string{".notdef", "alpha", "alpha.alt", "tonos", "f"}
+)
+
+// testFont builds a font with glyphs names, mapped to the code-points runes
+// (0 for unmapped glyphs), and features fea.
+func testFont(t *testing.T, names []string, runes []rune, fea string) *ot.Font {
+	t.Helper()
+	b := testfont.New(len(names))
+	for g, name := range names[1:] {
+		b.Name(ot.GlyphIndex(g+1), name)
+		if r := runes[g]; r != 0 {
+			b.Map(r, ot.GlyphIndex(g+1))
+		}
+		b.Advance(ot.GlyphIndex(g+1), uint16(100*(g+1)))
+	}
+	if err := b.Features(fea); err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func mergedTestFont(t *testing.T) *ot.Font {
+	t.Helper()
+	base := testFont(t, baseGlyphs, []rune{'f', 'i', 0, 0x301}, `
+languagesystem latn dflt;
+table GDEF {
+    GlyphClassDef [f i], [f_i], [acutecomb], ;
+} GDEF;
+feature liga {
+    lookupflag UseMarkFilteringSet [acutecomb];
+    sub f i by f_i;
+} liga;
+`)
+	fallback := testFont(t, fallbackGlyphs, []rune{'α', 0, 0x384, 'f'}, `
+languagesystem grek dflt;
+languagesystem latn dflt;
+table GDEF {
+    GlyphClassDef [alpha alpha.alt f], , [tonos], ;
+} GDEF;
+feature salt {
+    lookupflag MarkAttachmentType [tonos] UseMarkFilteringSet [tonos];
+    sub alpha by alpha.alt;
+} salt;
+`)
+	data, err := Merge(base, fallback)
+	if err != nil {
+		t.Fatalf("cannot merge fonts: %v", err)
+	}
+	otf, err := ot.Parse(data)
+	if err != nil {
+		t.Fatalf("cannot parse merged font: %v", err)
+	}
+	return otf
+}
+
+func TestMergeGlyphs(t *testing.T) {
+	otf := mergedTestFont(t)
+	if n := numGlyphs(otf); n != len(baseGlyphs)+len(fallbackGlyphs) {
+		t.Fatalf("expected merged font to have %d glyphs, has %d", len(baseGlyphs)+len(fallbackGlyphs), n)
+	}
+	for r, g := range map[rune]ot.GlyphIndex{'f': 1, 'i': 2, 0x301: 4, 'α': 6, 0x384: 8} {
+		if have := otf.CMapTable().GlyphIndexMap.Lookup(r); have != g {
+			t.Errorf("expected %U to map to glyph %d, have %d", r, g, have)
+		}
+	}
+	for g, adv := range map[ot.GlyphIndex]uint16{1: 100, 4: 400, 6: 100, 9: 400} {
+		if have, _, _ := otf.HorizontalMetrics().HMetrics(g); have != adv {
+			t.Errorf("expected advance %d for glyph %d, have %d", adv, g, have)
+		}
+	}
+	gdef := otf.Layout.GDef
+	for g, class := range map[ot.GlyphIndex]ot.GlyphClassDefEnum{1: ot.BaseGlyph, 3: ot.LigatureGlyph,
+		4: ot.MarkGlyph, 6: ot.BaseGlyph, 8: ot.MarkGlyph} {
+		if have := ot.GlyphClassDefEnum(gdef.GlyphClassDef.Lookup(g)); have != class {
+			t.Errorf("expected glyph class %d for glyph %d, have %d", class, g, have)
+		}
+	}
+}
+
+func TestMergeLayout(t *testing.T) {
+	otf := mergedTestFont(t)
+	var sb strings.Builder
+	names := append(baseGlyphs, fallbackGlyphs...)
+	if err := otfea.Decompile(otf, &sb, func(g ot.GlyphIndex) string { return names[g] }); err != nil {
+		t.Fatalf("cannot decompile merged features: %v", err)
+	}
+	for _, rule := range []string{
+		"@GDEF_MarkAttachClass1 = [tonos];",
+		"@GDEF_MarkGlyphSet1 = [tonos];",
+		"lookupflag UseMarkFilteringSet @GDEF_MarkGlyphSet0;\n    sub f i by f_i;",
+		"lookupflag MarkAttachmentType @GDEF_MarkAttachClass1 UseMarkFilteringSet @GDEF_MarkGlyphSet1;\n    sub alpha by alpha.alt;",
+		"feature salt {\n    script grek;\n    language dflt;\n        lookup GSUB_1;\n    script latn;",
+	} {
+		if !strings.Contains(sb.String(), rule) {
+			t.Errorf("expected merged features to contain %q, have:\n%s", rule, sb.String())
+		}
+	}
+	// the ligature of the base font and the substitution of the fallback font
+	// apply to the glyphs of the merged font
+	st := otlayout.NewBufferState(otlayout.GlyphBuffer{1, 2, 6}, nil)
+	if _, ok := otlayout.ApplyLookup(otf, otlayout.GSubFeatureType, 0, st, 0); !ok {
+		t.Errorf("expected ligature lookup to apply")
+	}
+	st.Index = 1
+	if _, ok := otlayout.ApplyLookup(otf, otlayout.GSubFeatureType, 1, st, 0); !ok {
+		t.Errorf("expected lookup of fallback font to apply")
+	}
+	if want := (otlayout.GlyphBuffer{3, 7}); !slices.Equal(st.Glyphs, want) {
+		t.Errorf("expected glyphs %v, have %v", want, st.Glyphs)
+	}
+}
+
+func TestMergeRealFonts(t *testing.T) {
+	base, fallback := loadFont(t, "Calibri.ttf"), loadFont(t, "GentiumPlus-R.ttf")
+	data, err := Merge(base, fallback)
+	if err != nil {
+		t.Fatalf("cannot merge fonts: %v", err)
+	}
+	otf, err := ot.Parse(data)
+	if err != nil {
+		t.Fatalf("cannot parse merged font: %v", err)
+	}
+	nBase := numGlyphs(base)
+	if n := numGlyphs(otf); n != nBase+numGlyphs(fallback) {
+		t.Errorf("expected %d glyphs, have %d", nBase+numGlyphs(fallback), n)
+	}
+	// 'A' is taken from Calibri, U+A78B LATIN CAPITAL LETTER SALTILLO from Gentium
+	if g, want := otf.CMapTable().GlyphIndexMap.Lookup('A'), base.CMapTable().GlyphIndexMap.Lookup('A'); g != want {
+		t.Errorf("expected 'A' to map to glyph %d of base font, have %d", want, g)
+	}
+	fg := fallback.CMapTable().GlyphIndexMap.Lookup(0xa78b)
+	if fg == 0 || base.CMapTable().GlyphIndexMap.Lookup(0xa78b) != 0 {
+		t.Fatalf("test assumes U+A78B to be mapped by the fallback font only")
+	}
+	g := otf.CMapTable().GlyphIndexMap.Lookup(0xa78b)
+	if g != ot.GlyphIndex(nBase)+fg {
+		t.Errorf("expected U+A78B to map to glyph %d, have %d", ot.GlyphIndex(nBase)+fg, g)
+	}
+	want, _, _ := fallback.HorizontalMetrics().HMetrics(fg)
+	if adv, _, _ := otf.HorizontalMetrics().HMetrics(g); adv != want {
+		t.Errorf("expected advance %d for U+A78B, have %d", want, adv)
+	}
+}
+
+func TestMergeErrors(t *testing.T) {
+	b := testfont.New(3)
+	b.UnitsPerEm = 2048
+	upm, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	plain, err := testfont.New(3).Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	cff := loadFont(t, "Go-Regular.otf", ot.IsTestfont)
+	calibri := loadFont(t, "Calibri.ttf")
+	for _, c := range []struct {
+		name           string
+		base, fallback *ot.Font
+	}{
+		{"units per em", plain, upm},
+		{"CFF", cff, plain},
+		{"outlines", calibri, upm},
+		{"nil", plain, nil},
+	} {
+		if _, err := Merge(c.base, c.fallback); err == nil {
+			t.Errorf("%s: expected merging to fail", c.name)
+		}
+	}
+}
+
+func loadFont(t *testing.T, name string, options ...ot.ParseOption) *ot.Font {
+	t.Helper()
+	data, err := os.ReadFile("../testdata/fonts/" + name)
+	if err != nil {
+		t.Fatalf("cannot read font: %v", err)
+	}
+	otf, err := ot.Parse(data, options...)
+	if err != nil {
+		t.Fatalf("cannot parse font %s: %v", name, err)
+	}
+	return otf
+}
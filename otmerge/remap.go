@@ -0,0 +1,446 @@
+package otmerge
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// remapper re-maps the glyph IDs and the lookup indices of sequence lookup
+// records of lookup subtables of the fallback font in place.
+// Structures shared between subtables are re-mapped only once.
+//
+// Arrays are checked to be within bounds before they are modified, so an array
+// is either re-mapped completely or not at all.
+type remapper struct {
+	data  blob
+	gpos  bool
+	shift layoutShift
+	done  map[int]bool // offsets of re-mapped structures
+}
+
+func newRemapper(data blob, gpos bool, shift layoutShift) *remapper {
+	return &remapper{data: data, gpos: gpos, shift: shift, done: make(map[int]bool)}
+}
+
+// once reports whether the structure at offset at has to be re-mapped, and
+// marks it as re-mapped.
+func (r *remapper) once(at int) bool {
+	if r.done[at] {
+		return false
+	}
+	r.done[at] = true
+	return true
+}
+
+// glyphs re-maps n consecutive glyph IDs at offset at.
+func (r *remapper) glyphs(at, n int) error {
+	return r.strided(at, n, 2)
+}
+
+// strided re-maps n glyph IDs at offset at, stride bytes apart.
+func (r *remapper) strided(at, n, stride int) error {
+	if n == 0 {
+		return nil
+	}
+	if err := r.data.check(at, (n-1)*stride+2); err != nil {
+		return err
+	}
+	for i := range n {
+		off := at + i*stride
+		g, _ := r.data.u16(off)
+		r.data.put16(off, g+uint16(r.shift.glyphs))
+	}
+	return nil
+}
+
+// lookupRecords re-maps the lookup indices of n sequence lookup records at
+// offset at.
+func (r *remapper) lookupRecords(at, n int) error {
+	if err := r.data.check(at, 4*n); err != nil {
+		return err
+	}
+	for i := range n {
+		off := at + 4*i + 2
+		inx, _ := r.data.u16(off)
+		r.data.put16(off, inx+uint16(r.shift.lookups))
+	}
+	return nil
+}
+
+// coverage re-maps the coverage table referenced by the offset at position
+// ref, relative to base.
+func (r *remapper) coverage(base, ref int) error {
+	at, err := r.data.offset(base, ref)
+	if err != nil || at == 0 || !r.once(at) {
+		return err
+	}
+	format, err := r.data.u16(at)
+	if err != nil {
+		return err
+	}
+	n, err := r.data.u16(at + 2)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case 1:
+		return r.glyphs(at+4, int(n))
+	case 2:
+		if err := r.data.check(at+4, 6*int(n)); err != nil {
+			return err
+		}
+		return r.ranges(at+4, int(n))
+	}
+	return fmt.Errorf("coverage format %d", format)
+}
+
+// ranges re-maps the start and end glyphs of n range records at offset at.
+func (r *remapper) ranges(at, n int) error {
+	if err := r.strided(at, n, 6); err != nil {
+		return err
+	}
+	return r.strided(at+2, n, 6)
+}
+
+// coverages re-maps n coverage tables, with their offsets at position at,
+// relative to base.
+func (r *remapper) coverages(base, at, n int) error {
+	for i := range n {
+		if err := r.coverage(base, at+2*i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// classDef re-maps the class definition table referenced by the offset at
+// position ref, relative to base.
+func (r *remapper) classDef(base, ref int) error {
+	at, err := r.data.offset(base, ref)
+	if err != nil || at == 0 || !r.once(at) {
+		return err
+	}
+	format, err := r.data.u16(at)
+	if err != nil {
+		return err
+	}
+	switch format {
+	case 1:
+		return r.glyphs(at+2, 1)
+	case 2:
+		n, err := r.data.u16(at + 2)
+		if err != nil {
+			return err
+		}
+		if err := r.data.check(at+4, 6*int(n)); err != nil {
+			return err
+		}
+		return r.ranges(at+4, int(n))
+	}
+	return fmt.Errorf("class definition format %d", format)
+}
+
+// offsets calls f for each of n offsets at position at, relative to base.
+func (r *remapper) offsets(base, at, n int, f func(int) error) error {
+	for i := range n {
+		off, err := r.data.offset(base, at+2*i)
+		if err != nil {
+			return err
+		}
+		if off == 0 || !r.once(off) {
+			continue
+		}
+		if err := f(off); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// remapLookup re-maps the subtables of lookup lu.
+func (r *remapper) remapLookup(lu *lookup) error {
+	for _, sub := range lu.subtables {
+		if !r.once(sub) {
+			continue
+		}
+		var err error
+		if r.gpos {
+			err = r.gposSubtable(lu.typ, sub)
+		} else {
+			err = r.gsubSubtable(lu.typ, sub)
+		}
+		if err != nil {
+			return fmt.Errorf("subtable of type %d: %w", lu.typ, err)
+		}
+	}
+	return nil
+}
+
+func (r *remapper) gsubSubtable(typ uint16, at int) error {
+	format, err := r.data.u16(at)
+	if err != nil {
+		return err
+	}
+	switch typ {
+	case 1: // single substitution
+		if err := r.coverage(at, at+2); err != nil || format != 2 {
+			return err
+		}
+		n, err := r.data.u16(at + 4)
+		if err != nil {
+			return err
+		}
+		return r.glyphs(at+6, int(n))
+	case 2, 3: // multiple and alternate substitution
+		if err := r.coverage(at, at+2); err != nil {
+			return err
+		}
+		n, err := r.data.u16(at + 4)
+		if err != nil {
+			return err
+		}
+		return r.offsets(at, at+6, int(n), func(seq int) error {
+			count, err := r.data.u16(seq)
+			if err != nil {
+				return err
+			}
+			return r.glyphs(seq+2, int(count))
+		})
+	case 4: // ligature substitution
+		if err := r.coverage(at, at+2); err != nil {
+			return err
+		}
+		n, err := r.data.u16(at + 4)
+		if err != nil {
+			return err
+		}
+		return r.offsets(at, at+6, int(n), func(set int) error {
+			count, err := r.data.u16(set)
+			if err != nil {
+				return err
+			}
+			return r.offsets(set, set+2, int(count), func(lig int) error {
+				comps, err := r.data.u16(lig + 2)
+				if err != nil || comps == 0 {
+					return err
+				}
+				if err := r.data.check(lig, 2+2*int(comps)); err != nil {
+					return err
+				}
+				if err := r.glyphs(lig, 1); err != nil {
+					return err
+				}
+				return r.glyphs(lig+4, int(comps)-1)
+			})
+		})
+	case 5:
+		return r.sequenceContext(format, at)
+	case 6:
+		return r.chainedSequenceContext(format, at)
+	case 8: // reverse chaining contextual single substitution
+		if err := r.coverage(at, at+2); err != nil {
+			return err
+		}
+		pos := at + 4
+		for range 2 { // backtrack and lookahead coverages
+			n, err := r.data.u16(pos)
+			if err != nil {
+				return err
+			}
+			if err := r.coverages(at, pos+2, int(n)); err != nil {
+				return err
+			}
+			pos += 2 + 2*int(n)
+		}
+		n, err := r.data.u16(pos)
+		if err != nil {
+			return err
+		}
+		return r.glyphs(pos+2, int(n))
+	}
+	return fmt.Errorf("unknown lookup type")
+}
+
+func (r *remapper) gposSubtable(typ uint16, at int) error {
+	format, err := r.data.u16(at)
+	if err != nil {
+		return err
+	}
+	switch typ {
+	case 1, 3: // single adjustment and cursive attachment
+		return r.coverage(at, at+2)
+	case 2: // pair adjustment
+		if err := r.coverage(at, at+2); err != nil {
+			return err
+		}
+		if format == 2 {
+			if err := r.classDef(at, at+8); err != nil {
+				return err
+			}
+			return r.classDef(at, at+10)
+		}
+		vf1, err := r.data.u16(at + 4)
+		if err != nil {
+			return err
+		}
+		vf2, err := r.data.u16(at + 6)
+		if err != nil {
+			return err
+		}
+		n, err := r.data.u16(at + 8)
+		if err != nil {
+			return err
+		}
+		stride := 2 + valueRecordSize(vf1) + valueRecordSize(vf2)
+		return r.offsets(at, at+10, int(n), func(set int) error {
+			count, err := r.data.u16(set)
+			if err != nil {
+				return err
+			}
+			return r.strided(set+2, int(count), stride)
+		})
+	case 4, 5, 6: // mark attachment
+		if err := r.coverage(at, at+2); err != nil {
+			return err
+		}
+		return r.coverage(at, at+4)
+	case 7:
+		return r.sequenceContext(format, at)
+	case 8:
+		return r.chainedSequenceContext(format, at)
+	}
+	return fmt.Errorf("unknown lookup type")
+}
+
+// valueRecordSize returns the size of a GPOS value record with format vf.
+func valueRecordSize(vf uint16) int {
+	return 2 * bits.OnesCount16(vf&0xff)
+}
+
+// sequenceContext re-maps a (GSUB or GPOS) sequence context subtable.
+func (r *remapper) sequenceContext(format uint16, at int) error {
+	switch format {
+	case 1, 2:
+		if err := r.coverage(at, at+2); err != nil {
+			return err
+		}
+		setsAt := at + 6
+		if format == 2 {
+			if err := r.classDef(at, at+4); err != nil {
+				return err
+			}
+			setsAt = at + 8
+		}
+		n, err := r.data.u16(setsAt - 2)
+		if err != nil {
+			return err
+		}
+		return r.offsets(at, setsAt, int(n), func(set int) error {
+			count, err := r.data.u16(set)
+			if err != nil {
+				return err
+			}
+			return r.offsets(set, set+2, int(count), func(rule int) error {
+				glyphs, err := r.data.u16(rule)
+				if err != nil {
+					return err
+				}
+				records, err := r.data.u16(rule + 2)
+				if err != nil {
+					return err
+				}
+				if format == 1 && glyphs > 0 {
+					if err := r.glyphs(rule+4, int(glyphs)-1); err != nil {
+						return err
+					}
+				}
+				return r.lookupRecords(rule+4+2*max(int(glyphs)-1, 0), int(records))
+			})
+		})
+	case 3:
+		glyphs, err := r.data.u16(at + 2)
+		if err != nil {
+			return err
+		}
+		records, err := r.data.u16(at + 4)
+		if err != nil {
+			return err
+		}
+		if err := r.coverages(at, at+6, int(glyphs)); err != nil {
+			return err
+		}
+		return r.lookupRecords(at+6+2*int(glyphs), int(records))
+	}
+	return fmt.Errorf("sequence context format %d", format)
+}
+
+// chainedSequenceContext re-maps a (GSUB or GPOS) chained sequence context
+// subtable.
+func (r *remapper) chainedSequenceContext(format uint16, at int) error {
+	switch format {
+	case 1, 2:
+		if err := r.coverage(at, at+2); err != nil {
+			return err
+		}
+		setsAt := at + 6
+		if format == 2 {
+			for i := range 3 { // backtrack, input and lookahead class definitions
+				if err := r.classDef(at, at+4+2*i); err != nil {
+					return err
+				}
+			}
+			setsAt = at + 12
+		}
+		n, err := r.data.u16(setsAt - 2)
+		if err != nil {
+			return err
+		}
+		return r.offsets(at, setsAt, int(n), func(set int) error {
+			count, err := r.data.u16(set)
+			if err != nil {
+				return err
+			}
+			return r.offsets(set, set+2, int(count), func(rule int) error {
+				pos := rule
+				for i := range 3 { // backtrack, input and lookahead sequences
+					n, err := r.data.u16(pos)
+					if err != nil {
+						return err
+					}
+					if i == 1 { // the first input glyph is given by the coverage
+						n = max(n, 1) - 1
+					}
+					if format == 1 {
+						if err := r.glyphs(pos+2, int(n)); err != nil {
+							return err
+						}
+					}
+					pos += 2 + 2*int(n)
+				}
+				records, err := r.data.u16(pos)
+				if err != nil {
+					return err
+				}
+				return r.lookupRecords(pos+2, int(records))
+			})
+		})
+	case 3:
+		pos := at + 2
+		for range 3 { // backtrack, input and lookahead coverages
+			n, err := r.data.u16(pos)
+			if err != nil {
+				return err
+			}
+			if err := r.coverages(at, pos+2, int(n)); err != nil {
+				return err
+			}
+			pos += 2 + 2*int(n)
+		}
+		records, err := r.data.u16(pos)
+		if err != nil {
+			return err
+		}
+		return r.lookupRecords(pos+2, int(records))
+	}
+	return fmt.Errorf("chained sequence context format %d", format)
+}
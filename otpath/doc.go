@@ -0,0 +1,37 @@
+/*
+Package otpath places the outlines of shaped glyphs, for renderers turning
+text into vector paths.
+
+Shape shapes a string with package otshape and returns an iterator over its
+glyphs, each with its outline, the transformation placing the outline in the
+run, and its input cluster. Outlines are taken from the font's 'glyf' or
+'CFF ' table:
+
+	glyphs, err := otpath.Shape(shaper, params, "Hello")
+	for g := range glyphs {
+		for _, seg := range g.Outline {
+			x, y := g.Transform.Apply(seg.Args[0].X, seg.Args[0].Y)
+			…
+		}
+	}
+
+Coordinates are font units, with the pen starting at the origin and y growing
+downwards. Outlines of glyph runs shaped elsewhere may be placed with Outlines.
+
+# License
+
+Governed by a 3-Clause BSD license. License file may be found in the root
+folder of this module.
+
+Copyright © Norbert Pillmayer <norbert@pillmayer.com>
+*/
+package otpath
+
+import (
+	"github.com/npillmayer/schuko/tracing"
+)
+
+// tracer writes to trace with key 'tyse.fonts'
+func tracer() tracing.Trace {
+	return tracing.Select("tyse.fonts")
+}
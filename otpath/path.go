@@ -0,0 +1,110 @@
+package otpath
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"github.com/npillmayer/opentype/otshape"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Glyph is the outline of a shaped glyph together with its placement.
+type Glyph struct {
+	GID       ot.GlyphIndex
+	Cluster   uint32        // input cluster of the glyph, see otshape.GlyphRecord
+	Outline   sfnt.Segments // outline in font units, y growing downwards; nil for glyphs without outline
+	Transform Transform     // places the outline in the run
+}
+
+// Transform is an affine transformation, mapping (x, y) to
+// (A*x + C*y + E, B*x + D*y + F).
+type Transform struct {
+	A, B, C, D, E, F float64
+}
+
+// Apply transforms a point of an outline.
+func (t Transform) Apply(x, y fixed.Int26_6) (float64, float64) {
+	fx, fy := float64(x)/64, float64(y)/64
+	return t.A*fx + t.C*fy + t.E, t.B*fx + t.D*fy + t.F
+}
+
+// transform returns the transformation placing the outline of a glyph with
+// origin (x, y). Glyphs scaled by a small-caps fallback are scaled, glyphs of a
+// synthetic oblique style are sheared; with y growing downwards, shearing moves
+// points by -Shear*y. Synthetic emboldening cannot be expressed by an affine
+// transformation and is left to the renderer, see otlayout.PosItem.Embolden.
+func transform(pos otlayout.PosItem, x, y float64) Transform {
+	s := 1.0
+	if pos.Scale != 0 {
+		s = float64(pos.Scale)
+	}
+	return Transform{A: s, C: -float64(pos.Shear) * s, D: s, E: x, F: y}
+}
+
+// Outlines returns an iterator over the outlines of glyphs, placed at their
+// shaped positions. glyphs are glyph records as produced by package otshape,
+// with advances including the glyphs' nominal advances.
+func Outlines(font *ot.Font, glyphs []otshape.GlyphRecord) (iter.Seq[Glyph], error) {
+	if font == nil {
+		return nil, errors.New("otpath: font is nil")
+	}
+	sf, err := sfnt.Parse(font.Binary())
+	if err != nil {
+		return nil, fmt.Errorf("otpath: cannot read glyph outlines: %w", err)
+	}
+	ppem := fixed.I(int(sf.UnitsPerEm()))
+	return func(yield func(Glyph) bool) {
+		var buf sfnt.Buffer
+		var penX, penY int32
+		for _, g := range glyphs {
+			x, y := penX+g.Pos.XOffset, penY-g.Pos.YOffset
+			penX += g.Pos.XAdvance
+			penY -= g.Pos.YAdvance
+			segs, err := sf.LoadGlyph(&buf, sfnt.GlyphIndex(g.GID), ppem, nil)
+			if err != nil {
+				tracer().Debugf("otpath: no outline for glyph %d: %v", g.GID, err)
+			}
+			out := Glyph{
+				GID:       g.GID,
+				Cluster:   g.Cluster,
+				Transform: transform(g.Pos, float64(x), float64(y)),
+			}
+			if len(segs) > 0 { // segs are valid until the next call of LoadGlyph only
+				out.Outline = slices.Clone(segs)
+			}
+			if !yield(out) {
+				return
+			}
+		}
+	}, nil
+}
+
+// Shape shapes text with shaper and returns an iterator over the outlines of
+// the resulting glyphs, placed at their shaped positions. Text is shaped as a
+// whole before the iterator is returned.
+func Shape(shaper *otshape.Shaper, params otshape.Params, text string) (iter.Seq[Glyph], error) {
+	if shaper == nil {
+		return nil, errors.New("otpath: shaper is nil")
+	}
+	var sink collector
+	if err := shaper.Shape(params, strings.NewReader(text), &sink, otshape.BufferOptions{}); err != nil {
+		return nil, err
+	}
+	return Outlines(params.Font, sink.glyphs)
+}
+
+// collector collects the glyph records of a shaping call.
+type collector struct {
+	glyphs []otshape.GlyphRecord
+}
+
+func (c *collector) WriteGlyph(g otshape.GlyphRecord) error {
+	c.glyphs = append(c.glyphs, g)
+	return nil
+}
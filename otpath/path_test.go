@@ -0,0 +1,76 @@
+package otpath
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/fontload"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otcore"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+func loadLocalFont(t *testing.T, fontFileName string) *ot.Font {
+	t.Helper()
+	path := filepath.Join("..", "testdata", "fonts", fontFileName)
+	f, err := fontload.LoadOpenTypeFont(path)
+	if err != nil {
+		t.Fatalf("cannot load test font %s: %s", fontFileName, err)
+	}
+	otf, err := ot.Parse(f.Binary, ot.IsTestfont) // Go fonts have no layout tables
+	if err != nil {
+		t.Fatalf("cannot decode test font %s: %s", fontFileName, err)
+	}
+	return otf
+}
+
+func TestShape(t *testing.T) {
+	for _, name := range []string{"Calibri.ttf", "Go-Regular.otf"} { // glyf and CFF outlines
+		otf := loadLocalFont(t, name)
+		params := otshape.Params{
+			Font:      otf,
+			Direction: bidi.LeftToRight,
+			Script:    language.MustParseScript("Latn"),
+			Language:  language.English,
+		}
+		glyphs, err := Shape(otshape.NewShaper(otcore.New()), params, "Ab c")
+		if err != nil {
+			t.Fatalf("%s: cannot shape: %v", name, err)
+		}
+		var all []Glyph
+		for g := range glyphs {
+			all = append(all, g)
+		}
+		if len(all) != 4 {
+			t.Fatalf("%s: expected 4 glyphs, have %d", name, len(all))
+		}
+		if all[2].Outline != nil || all[2].Cluster != 2 {
+			t.Errorf("%s: expected space without outline in cluster 2, have %+v", name, all[2])
+		}
+		advance := float64(otquery.GlyphMetrics(otf, all[0].GID).Advance)
+		if all[0].Transform != (Transform{A: 1, D: 1}) || all[1].Transform.E < advance {
+			t.Errorf("%s: expected glyphs at pen positions, have %+v and %+v", name, all[0].Transform, all[1].Transform)
+		}
+		if len(all[0].Outline) == 0 {
+			t.Fatalf("%s: expected outline for 'A'", name)
+		}
+		// the apex of 'A' is above the baseline, i.e. has negative y
+		_, y := all[0].Transform.Apply(all[0].Outline.Bounds().Min.X, all[0].Outline.Bounds().Min.Y)
+		if y >= 0 {
+			t.Errorf("%s: expected top of 'A' above the baseline, have y=%v", name, y)
+		}
+	}
+}
+
+func TestTransform(t *testing.T) {
+	var rec otshape.GlyphRecord
+	rec.Pos.Scale, rec.Pos.Shear = 0.5, 0.25
+	tr := transform(rec.Pos, 100, 0)
+	// a point 64 units above the baseline moves right by shear
+	if x, y := tr.Apply(0, -64*64); x != 108 || y != -32 {
+		t.Errorf("expected scaled and sheared point (108,-32), have (%v,%v)", x, y)
+	}
+}
@@ -0,0 +1,203 @@
+package otquery
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// ScriptSupport describes how a font supports a script/language combination in its
+// layout tables.
+type ScriptSupport struct {
+	Script, Lang ot.Tag
+	GSub, GPos   bool // font has a script table for Script (or for DFLT, if Fallback) in GSUB/GPOS
+	LangSys      bool // font has a dedicated language system for Lang
+	Fallback     bool // script is supported through script DFLT only
+}
+
+// Supported reports whether any layout table has a script table applicable to
+// the script.
+func (s ScriptSupport) Supported() bool {
+	return s.GSub || s.GPos
+}
+
+// SupportsScript checks how font otf supports OpenType script tag script and
+// OpenType language tag lang (0 for the default language).
+//
+// Support is determined from the script lists of GSUB and GPOS only. Use
+// CoversString to check if the font maps the characters of a text.
+func SupportsScript(otf *ot.Font, script, lang ot.Tag) ScriptSupport {
+	s := ScriptSupport{Script: script, Lang: lang}
+	if otf == nil {
+		return s
+	}
+	check := func(lt *ot.LayoutTable) (found bool, fallback bool, hasLang bool) {
+		if lt == nil || lt.ScriptGraph() == nil {
+			return
+		}
+		scr := lt.ScriptGraph().Script(script)
+		if scr == nil && script != ot.DFLT {
+			scr, fallback = lt.ScriptGraph().Script(ot.DFLT), true
+		}
+		if scr == nil {
+			return false, false, false
+		}
+		return true, fallback, lang != 0 && scr.LangSys(lang) != nil
+	}
+	gsubFound, gsubFallback, gsubLang := check(layoutTable(otf, "GSUB"))
+	gposFound, gposFallback, gposLang := check(layoutTable(otf, "GPOS"))
+	s.GSub, s.GPos = gsubFound, gposFound
+	s.LangSys = gsubLang || gposLang
+	s.Fallback = (!gsubFound || gsubFallback) && (!gposFound || gposFallback) && s.Supported()
+	return s
+}
+
+// CoverageResult is the result of a character coverage check.
+type CoverageResult struct {
+	Covered bool   // all characters are mapped to glyphs
+	Missing []rune // characters without a glyph, in order of first occurrence
+}
+
+// CoversString checks if font otf maps every character of s to a glyph.
+// White space and control characters are checked as well.
+func CoversString(otf *ot.Font, s string) CoverageResult {
+	var missing []rune
+	seen := make(map[rune]bool)
+	cmap := otf.CMapTable()
+	for _, r := range s {
+		if seen[r] {
+			continue
+		}
+		seen[r] = true
+		if cmap == nil || cmap.GlyphIndexMap == nil || cmap.GlyphIndexMap.Lookup(r) == 0 {
+			missing = append(missing, r)
+		}
+	}
+	return CoverageResult{Covered: len(missing) == 0, Missing: missing}
+}
+
+// FeatureSupport tells which layout tables of a font contain a feature.
+type FeatureSupport struct {
+	GSub, GPos bool
+}
+
+// Supported reports whether the feature is contained in any layout table.
+func (f FeatureSupport) Supported() bool {
+	return f.GSub || f.GPos
+}
+
+// HasFeature checks if font otf contains a feature with tag feature in its GSUB
+// or GPOS feature list. It does not check if the feature is reachable from
+// any script.
+func HasFeature(otf *ot.Font, feature ot.Tag) FeatureSupport {
+	has := func(lt *ot.LayoutTable) bool {
+		if lt == nil || lt.FeatureGraph() == nil {
+			return false
+		}
+		for tag := range lt.FeatureGraph().Range() {
+			if tag == feature {
+				return true
+			}
+		}
+		return false
+	}
+	return FeatureSupport{
+		GSub: has(layoutTable(otf, "GSUB")),
+		GPos: has(layoutTable(otf, "GPOS")),
+	}
+}
+
+// StylisticSets returns the numbers (1…20) of the stylistic sets 'ss01'…'ss20'
+// contained in the GSUB table of font otf, in ascending order.
+func StylisticSets(otf *ot.Font) []int {
+	var sets []int
+	for n := 1; n <= 20; n++ {
+		if HasFeature(otf, ot.T(fmt.Sprintf("ss%02d", n))).GSub {
+			sets = append(sets, n)
+		}
+	}
+	return sets
+}
+
+// AnnotationSupport tells which GSUB features for annotation glyph forms a font
+// contains, as used for East Asian ruby text.
+type AnnotationSupport struct {
+	Ruby       bool // 'ruby': ruby notation forms
+	Alternates bool // 'nalt': alternate annotation forms, e.g., circled digits
+	Fractions  bool // 'afrc': alternative, i.e., vertical or nut fractions
+}
+
+// AnnotationForms checks which features for annotation glyph forms font otf
+// contains in its GSUB feature list.
+func AnnotationForms(otf *ot.Font) AnnotationSupport {
+	return AnnotationSupport{
+		Ruby:       HasFeature(otf, ot.T("ruby")).GSub,
+		Alternates: HasFeature(otf, ot.T("nalt")).GSub,
+		Fractions:  HasFeature(otf, ot.T("afrc")).GSub,
+	}
+}
+
+// IsMonospaced reports whether font otf is monospaced. A font is considered
+// monospaced if table 'post' flags it as fixed-pitch, or if all glyphs with a
+// non-zero advance width have the same advance.
+func IsMonospaced(otf *ot.Font) bool {
+	if otf == nil {
+		return false
+	}
+	if post := otf.Table(ot.T("post")); post != nil {
+		if b := post.Binary(); len(b) >= 16 && binary.BigEndian.Uint32(b[12:16]) != 0 {
+			return true
+		}
+	}
+	hmtx := otf.HorizontalMetrics()
+	if hmtx == nil {
+		return false
+	}
+	var width uint16
+	for _, m := range hmtx.LongMetrics() {
+		if m.AdvanceWidth == 0 {
+			continue
+		}
+		if width != 0 && m.AdvanceWidth != width {
+			return false
+		}
+		width = m.AdvanceWidth
+	}
+	return width != 0
+}
+
+// IsVariable reports whether font otf is a variable font, i.e., contains a
+// font variations table 'fvar'.
+func IsVariable(otf *ot.Font) bool {
+	return otf != nil && otf.Table(ot.T("fvar")) != nil
+}
+
+// IsGraphite reports whether font otf is capable of SIL Graphite shaping, i.e.,
+// contains the Graphite tables 'Silf', 'Glat' and 'Gloc'. Clients may route such
+// fonts to a Graphite engine, as package otshape does not run Graphite rules.
+func IsGraphite(otf *ot.Font) bool {
+	if otf == nil {
+		return false
+	}
+	for _, tag := range []string{"Silf", "Glat", "Gloc"} {
+		if otf.Table(ot.T(tag)) == nil {
+			return false
+		}
+	}
+	return true
+}
+
+func layoutTable(otf *ot.Font, tag string) *ot.LayoutTable {
+	switch tag {
+	case "GSUB":
+		if gsub := otf.GSub(); gsub != nil {
+			return &gsub.LayoutTable
+		}
+	case "GPOS":
+		if gpos := otf.GPos(); gpos != nil {
+			return &gpos.LayoutTable
+		}
+	}
+	return nil
+}
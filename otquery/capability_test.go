@@ -0,0 +1,97 @@
+package otquery
+
+import (
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/schuko/tracing/gotestingadapter"
+)
+
+func TestCapabilitiesCalibri(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "tyse.fonts")
+	defer teardown()
+	//
+	otf := loadLocalFont(t, "Calibri.ttf")
+	if s := SupportsScript(otf, ot.T("latn"), ot.T("TRK ")); !s.Supported() || s.Fallback || !s.GSub || !s.GPos {
+		t.Errorf("expected Calibri to support script 'latn', have %+v", s)
+	}
+	if s := SupportsScript(otf, ot.T("arab"), 0); s.Supported() && !s.Fallback {
+		t.Errorf("did not expect Calibri to support script 'arab' directly, have %+v", s)
+	}
+	if c := CoversString(otf, "Hello, World!"); !c.Covered {
+		t.Errorf("expected Calibri to cover ASCII text, missing %q", c.Missing)
+	}
+	if c := CoversString(otf, "aببc"); c.Covered || len(c.Missing) != 1 || c.Missing[0] != 'ب' {
+		t.Errorf("expected Calibri to miss Arabic letter, have %+v", c)
+	}
+	if f := HasFeature(otf, ot.T("kern")); !f.GPos || f.GSub {
+		t.Errorf("expected 'kern' to be a GPOS feature, have %+v", f)
+	}
+	if HasFeature(otf, ot.T("xxxx")).Supported() {
+		t.Errorf("did not expect feature 'xxxx'")
+	}
+	if IsMonospaced(otf) {
+		t.Errorf("did not expect Calibri to be monospaced")
+	}
+	if IsVariable(otf) {
+		t.Errorf("did not expect Calibri to be a variable font")
+	}
+	if IsGraphite(otf) {
+		t.Errorf("did not expect Calibri to be a Graphite font")
+	}
+	t.Logf("Calibri stylistic sets: %v", StylisticSets(otf))
+}
+
+func TestCapabilitiesSynthetic(t *testing.T) {
+	b := testfont.New(4)
+	b.Map('a', 1).Map('b', 2)
+	gsub := b.GSUB()
+	ss := gsub.Lookup(ot.GSubLookupTypeSingle, 0, testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 3}))
+	gsub.Feature("ss03", ss)
+	gsub.Feature("ss01", ss)
+	gsub.Script("cyrl", "SRB ", 0, 1)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	if s := SupportsScript(otf, ot.T("cyrl"), ot.T("SRB ")); !s.GSub || !s.GPos || !s.LangSys || s.Fallback {
+		t.Errorf("unexpected support for 'cyrl'/'SRB ': %+v", s)
+	}
+	if s := SupportsScript(otf, ot.T("grek"), 0); !s.GPos || !s.Fallback {
+		t.Errorf("expected 'grek' to be supported by GPOS fallback only, have %+v", s)
+	}
+	if sets := StylisticSets(otf); len(sets) != 2 || sets[0] != 1 || sets[1] != 3 {
+		t.Errorf("expected stylistic sets [1 3], have %v", sets)
+	}
+	if !IsMonospaced(otf) {
+		t.Errorf("expected synthetic font with equal advances to be monospaced")
+	}
+	if c := CoversString(otf, "abc"); c.Covered || len(c.Missing) != 1 {
+		t.Errorf("expected 'c' to be missing, have %+v", c)
+	}
+}
+
+func TestIsGraphite(t *testing.T) {
+	b := testfont.New(4)
+	b.Map('a', 1)
+	b.Table("Silf", []byte{0, 3, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0})
+	b.Table("Glat", []byte{0, 1, 0, 0})
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	if IsGraphite(otf) {
+		t.Errorf("did not expect font without Gloc to be a Graphite font")
+	}
+	b.Table("Gloc", []byte{0, 1, 0, 0, 0, 0, 0, 0})
+	if otf, err = b.Parse(); err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	if !IsGraphite(otf) {
+		t.Errorf("expected font with Silf, Glat and Gloc to be a Graphite font")
+	}
+	if silf := otf.Table(ot.T("Silf")).Self().AsSilf(); silf == nil || silf.Version != 0x00030000 {
+		t.Errorf("expected Silf table of version 3, have %+v", silf)
+	}
+}
@@ -0,0 +1,74 @@
+package otquery
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"golang.org/x/image/font/sfnt"
+)
+
+// LigatureCarets returns the caret positions within ligature glyph gid, in
+// logical order. Carets are taken from the ligature caret list of table GDEF
+// or, for glyphs not covered by GDEF, from the AAT table 'lcar'. Caret values
+// of 'lcar' are converted to GDEF caret values: distances to format 1,
+// control points to format 2.
+//
+// If the font holds no carets for gid, nil is returned. Clients then usually
+// divide the advance of the ligature evenly among its components.
+func LigatureCarets(otf *ot.Font, gid ot.GlyphIndex) []ot.CaretValue {
+	if otf == nil {
+		return nil
+	}
+	if gdef := otf.GDef(); gdef != nil {
+		if carets := gdef.LigatureCarets(gid); carets != nil {
+			return carets
+		}
+	}
+	lcar, ok := ot.TableOf[*ot.LcarTable](otf)
+	if !ok {
+		return nil
+	}
+	values := lcar.Carets(gid)
+	if values == nil {
+		return nil
+	}
+	carets := make([]ot.CaretValue, len(values))
+	for i, v := range values {
+		if lcar.Format == ot.LcarControlPoints {
+			carets[i] = ot.CaretValue{Format: ot.CaretValueFormat2, PointIndex: uint16(v)}
+		} else {
+			carets[i] = ot.CaretValue{Format: ot.CaretValueFormat1, Coordinate: v}
+		}
+	}
+	return carets
+}
+
+// OpticalEdges describes how far a glyph may protrude into the margins of a
+// line of horizontal text for optical margin alignment, in font units.
+// Positive values move the glyph into the margin.
+type OpticalEdges struct {
+	Left  sfnt.Units // protrusion into the left margin, for glyphs starting a line
+	Right sfnt.Units // protrusion into the right margin, for glyphs ending a line
+}
+
+// OpticalEdgesOf returns the optical edges of glyph gid, taken from the AAT
+// table 'opbd'. If the font has no optical bounds for gid, or states them as
+// control points of the glyph outline, false is returned; clients then align
+// the glyph at its advance edges.
+func OpticalEdgesOf(otf *ot.Font, gid ot.GlyphIndex) (OpticalEdges, bool) {
+	if otf == nil {
+		return OpticalEdges{}, false
+	}
+	opbd, ok := ot.TableOf[*ot.OpbdTable](otf)
+	if !ok || opbd.Format != ot.OpbdDistances {
+		return OpticalEdges{}, false
+	}
+	bounds, ok := opbd.Bounds(gid)
+	if !ok {
+		return OpticalEdges{}, false
+	}
+	// deltas move edges rightwards, i.e. a positive left delta and a negative
+	// right delta shift the optical edges into the glyph
+	return OpticalEdges{
+		Left:  sfnt.Units(bounds.Left),
+		Right: -sfnt.Units(bounds.Right),
+	}, true
+}
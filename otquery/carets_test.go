@@ -0,0 +1,66 @@
+package otquery
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+func u16s(vs ...int) []byte {
+	var b []byte
+	for _, v := range vs {
+		b = binary.BigEndian.AppendUint16(b, uint16(v))
+	}
+	return b
+}
+
+// caretFont creates a font with ligature carets for glyph 3 in GDEF, for
+// glyphs 3 and 4 in 'lcar', and optical bounds for glyphs 1 and 2 in 'opbd'.
+func caretFont(t *testing.T) *ot.Font {
+	gdef := u16s(1, 0, 0, 0, 12, 0)        // header, version 1.0
+	gdef = append(gdef, u16s(6, 1, 12)...) // LigCaretList
+	gdef = append(gdef, testfont.Coverage(3)...)
+	gdef = append(gdef, u16s(1, 4, 1, 250)...) // LigGlyph, CaretValue format 1
+	lcar := append(u16s(1, 0, 0), u16s(8, 3, 2, 16, 20)...)
+	lcar = append(lcar, u16s(1, 111)...)
+	lcar = append(lcar, u16s(2, 200, 400)...)
+	opbd := append(u16s(1, 0, 0), u16s(8, 1, 2, 16, 24)...)
+	opbd = append(opbd, u16s(60, 0, 0, 0)...)
+	opbd = append(opbd, u16s(0, 0, -120, 0)...)
+	b := testfont.New(5)
+	b.Table("GDEF", gdef).Table("lcar", lcar).Table("opbd", opbd)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func TestLigatureCarets(t *testing.T) {
+	otf := caretFont(t)
+	if c := LigatureCarets(otf, 3); len(c) != 1 || c[0].Format != ot.CaretValueFormat1 || c[0].Coordinate != 250 {
+		t.Errorf("expected GDEF caret at 250 for glyph 3, have %+v", c)
+	}
+	c := LigatureCarets(otf, 4)
+	if len(c) != 2 || c[0].Coordinate != 200 || c[1].Coordinate != 400 || c[1].Format != ot.CaretValueFormat1 {
+		t.Errorf("expected 'lcar' carets at 200 and 400 for glyph 4, have %+v", c)
+	}
+	if c := LigatureCarets(otf, 1); c != nil {
+		t.Errorf("did not expect carets for glyph 1, have %+v", c)
+	}
+}
+
+func TestOpticalEdges(t *testing.T) {
+	otf := caretFont(t)
+	if e, ok := OpticalEdgesOf(otf, 1); !ok || e.Left != 60 || e.Right != 0 {
+		t.Errorf("expected glyph 1 to protrude 60 units to the left, have %+v, %v", e, ok)
+	}
+	if e, ok := OpticalEdgesOf(otf, 2); !ok || e.Left != 0 || e.Right != 120 {
+		t.Errorf("expected glyph 2 to protrude 120 units to the right, have %+v, %v", e, ok)
+	}
+	if _, ok := OpticalEdgesOf(otf, 3); ok {
+		t.Errorf("did not expect optical edges for glyph 3")
+	}
+}
@@ -9,6 +9,22 @@ Clients of this package will, amongst other, be:
 
 ▪︎ glyph rasterizers, such as FreeType (https://github.com/golang/freetype)
 
+# Capabilities
+
+For font selection, otquery answers questions about a font as a whole: SupportsScript,
+CoversString, HasFeature, StylisticSets, IsMonospaced, IsVariable and IsGraphite.
+OpticalSizeInfo tells the optical size a font has been designed for, which
+lets renderers pick the best-suited font of a family for a given point size.
+FeatureLabel returns the names fonts give to stylistic sets and character variants,
+for presentation in user interfaces.
+
+# Glyph Queries
+
+For line breaking and text editing, LigatureCarets returns caret positions within
+ligature glyphs, from table GDEF or the AAT table 'lcar'. OpticalEdgesOf tells how far
+a glyph may protrude into the margins for optical margin alignment, from the AAT
+table 'opbd'.
+
 # Status
 
 Work in progress. Handling fonts is fiddly and fonts have become complex software
@@ -16,7 +32,7 @@ applications in their own right. I often need a break from the vast desert of
 bytes (without any sign posts), which is what font data files are at their core. Breaks,
 where I talk to myself and ask, this is what you do in your spare time? Really?
 
-No font collections nor variable fonts are supported yet.
+No font collections are supported yet. For variable fonts, see package otvar.
 
 # License
 
@@ -0,0 +1,50 @@
+package otquery
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"golang.org/x/image/font/sfnt"
+)
+
+// FeatureLabel returns the user-interface label of GSUB feature tag in font
+// otf. Labels are defined for stylistic sets 'ss01'…'ss20' and character
+// variants 'cv01'…'cv99', by the feature parameters referencing table 'name'.
+// For other features, or if the font does not name the feature, false is
+// returned; UIs then fall back to a generic description of the feature tag.
+func FeatureLabel(otf *ot.Font, tag ot.Tag) (string, bool) {
+	lt := layoutTable(otf, "GSUB")
+	if lt == nil {
+		return "", false
+	}
+	f := lt.FeatureGraph().First(tag)
+	var nameID uint16
+	switch s := tag.String(); {
+	case len(s) == 4 && s[:2] == "ss":
+		params, ok := f.StylisticSetParams()
+		if !ok {
+			return "", false
+		}
+		nameID = params.UINameID
+	case len(s) == 4 && s[:2] == "cv":
+		params, ok := f.CharacterVariantParams()
+		if !ok {
+			return "", false
+		}
+		nameID = params.UINameID
+	default:
+		return "", false
+	}
+	return nameString(otf, sfnt.NameID(nameID))
+}
+
+// nameString returns the first string for name ID id of table 'name'.
+func nameString(otf *ot.Font, id sfnt.NameID) (string, bool) {
+	if id == 0 {
+		return "", false
+	}
+	for nameID, value := range NamesRange(otf) {
+		if nameID == id {
+			return value, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,56 @@
+package otquery
+
+import (
+	"testing"
+	"unicode/utf16"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// nameTable creates a 'name' table with Windows Unicode BMP names.
+func nameTable(names map[uint16]string) []byte {
+	ids := []uint16{}
+	for id := range names {
+		ids = append(ids, id)
+	}
+	var records, storage []byte
+	for _, id := range ids {
+		var s []byte
+		for _, u := range utf16.Encode([]rune(names[id])) {
+			s = append(s, byte(u>>8), byte(u))
+		}
+		records = append(records, u16s(3, 1, 0x0409, int(id), len(s), len(storage))...)
+		storage = append(storage, s...)
+	}
+	b := u16s(0, len(ids), 6+len(records))
+	return append(append(b, records...), storage...)
+}
+
+func TestFeatureLabel(t *testing.T) {
+	b := testfont.New(4)
+	gsub := b.GSUB()
+	subst := gsub.Lookup(ot.GSubLookupTypeSingle, 0, testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 2}))
+	ss := gsub.Feature("ss01", subst)
+	gsub.FeatureParams(ss, u16s(0, 256))
+	cv := gsub.Feature("cv01", subst)
+	gsub.FeatureParams(cv, u16s(0, 257, 0, 0, 0, 0, 0))
+	unnamed := gsub.Feature("ss02", subst)
+	gsub.FeatureParams(unnamed, u16s(0, 300))
+	gsub.Script("latn", testfont.DefaultLang, ss, cv, unnamed)
+	b.Table("name", nameTable(map[uint16]string{256: "Round dots", 257: "Single-storey g"}))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	for tag, want := range map[string]string{"ss01": "Round dots", "cv01": "Single-storey g"} {
+		if label, ok := FeatureLabel(otf, ot.T(tag)); !ok || label != want {
+			t.Errorf("expected label %q for feature '%s', have %q", want, tag, label)
+		}
+	}
+	for _, tag := range []string{"ss02", "ss03", "liga"} {
+		if label, ok := FeatureLabel(otf, ot.T(tag)); ok {
+			t.Errorf("did not expect a label for feature '%s', have %q", tag, label)
+		}
+	}
+}
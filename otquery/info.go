@@ -11,17 +11,20 @@ func FontType(otf *ot.Font) string {
 	}
 	typ := otf.Header.FontType
 	switch typ {
-	case 0x4f54544f: // OTTO
+	case ot.FontTypeCFF:
 		return "OpenType (outlines)"
-	case 0x00010000: // TrueType
+	case ot.FontTypeTrueType:
 		return "TrueType"
-	case 0x74727565: // true
+	case ot.FontTypeAppleTrue:
 		return "TrueType (Mac legacy)"
+	case ot.FontTypeAppleTyp1:
+		return "PostScript Type 1 (Mac legacy)"
 	}
 	return "<unknown>"
 }
 
-// LayoutTables returns a list of tag strings, one for each layout-table a font includes.
+// LayoutTables returns a list of tag strings, one for each layout-table a font includes,
+// in the order of the font's table directory, i.e. sorted by tag.
 //
 // From the spec:
 // OpenType Layout makes use of five tables: GSUB, GPOS, BASE, JSTF, and GDEF.
@@ -87,11 +90,10 @@ const (
 
 // ClassesForGlyph retrieves glyph class information for a given glyph index.
 func ClassesForGlyph(otf *ot.Font, gid ot.GlyphIndex) GlyphClasses {
-	t := otf.Table(ot.T("GDEF"))
-	if t == nil {
+	gdef := otf.GDef()
+	if gdef == nil {
 		return GlyphClasses{}
 	}
-	gdef := t.Self().AsGDef()
 	clz := GlyphClasses{
 		Class:          GlyphClass(gdef.GlyphClassDef.Lookup(gid)),
 		MarkAttachment: MarkAttachmentClass(gdef.MarkAttachmentClassDef.Lookup(gid)),
@@ -47,7 +47,7 @@ func (env *InfoTestEnviron) TestHeadInfo() {
 	h, ok := HeadInfo(env.otf)
 	env.Require().True(ok, "expected to decode table 'head'")
 
-	headTable := env.otf.Table(ot.T("head")).Self().AsHead()
+	headTable := env.otf.FontHead()
 	env.Require().NotNil(headTable, "expected parsed HeadTable")
 
 	env.Equal(headTable.Flags, h.Flags, "expected matching Flags")
@@ -60,7 +60,7 @@ func (env *InfoTestEnviron) TestMaxPInfo() {
 	m, ok := MaxPInfo(env.otf)
 	env.Require().True(ok, "expected to decode table 'maxp'")
 
-	maxpTable := env.otf.Table(ot.T("maxp")).Self().AsMaxP()
+	maxpTable, _ := ot.TableOf[*ot.MaxPTable](env.otf)
 	env.Require().NotNil(maxpTable, "expected parsed MaxPTable")
 
 	env.Equal(uint16(maxpTable.NumGlyphs), m.NumGlyphs, "expected matching numGlyphs")
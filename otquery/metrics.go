@@ -15,7 +15,7 @@ func FontSupportsScript(otf *ot.Font, scr ot.Tag, lang ot.Tag) (ot.Tag, ot.Tag)
 	if otf == nil {
 		return 0, 0
 	}
-	gsub := otf.Layout.GSub
+	gsub := otf.GSub()
 	if gsub == nil {
 		return ot.DFLT, ot.DFLT
 	}
@@ -38,33 +38,30 @@ func FontSupportsScript(otf *ot.Font, scr ot.Tag, lang ot.Tag) (ot.Tag, ot.Tag)
 // FontMetrics retrieves selected metrics of a font.
 func FontMetrics(otf *ot.Font) FontMetricsInfo {
 	metrics := FontMetricsInfo{}
-	if table := otf.Table(ot.T("hhea")); table != nil {
-		if hhea := table.Self().AsHHea(); hhea != nil {
-			metrics.Ascent = sfnt.Units(hhea.Ascender)
-			metrics.Descent = sfnt.Units(hhea.Descender)
-			metrics.LineGap = sfnt.Units(hhea.LineGap)
-			metrics.MaxAdvance = sfnt.Units(hhea.AdvanceWidthMax)
-		}
+	if hhea := otf.HorizontalHeader(); hhea != nil {
+		metrics.Ascent = sfnt.Units(hhea.Ascender)
+		metrics.Descent = sfnt.Units(hhea.Descender)
+		metrics.LineGap = sfnt.Units(hhea.LineGap)
+		metrics.MaxAdvance = sfnt.Units(hhea.AdvanceWidthMax)
 	}
 	if metrics.Ascent == 0 && metrics.Descent == 0 {
-		if table := otf.Table(ot.T("OS/2")); table != nil {
-			if os2 := table.Self().AsOS2(); os2 != nil {
-				tracer().Debugf("OS/2")
-				a := sfnt.Units(os2.TypoAscender)
-				if a > metrics.Ascent {
-					tracer().Debugf("override of ascent: %d -> %d", metrics.Ascent, a)
-					metrics.Ascent = a
-				}
-				d := sfnt.Units(os2.TypoDescender)
-				if d < metrics.Descent {
-					tracer().Debugf("override of descent: %d -> %d", metrics.Descent, d)
-					metrics.Descent = d
-				}
+		if os2 := otf.OS2Metrics(); os2 != nil {
+			tracer().Debugf("OS/2")
+			a := sfnt.Units(os2.TypoAscender)
+			if a > metrics.Ascent {
+				tracer().Debugf("override of ascent: %d -> %d", metrics.Ascent, a)
+				metrics.Ascent = a
+			}
+			d := sfnt.Units(os2.TypoDescender)
+			if d < metrics.Descent {
+				tracer().Debugf("override of descent: %d -> %d", metrics.Descent, d)
+				metrics.Descent = d
 			}
 		}
 	}
-	head := otf.Table(ot.T("head")).Self().AsHead() // Head is a required table
-	metrics.UnitsPerEm = sfnt.Units(head.UnitsPerEm)
+	if head := otf.FontHead(); head != nil { // head is a required table
+		metrics.UnitsPerEm = sfnt.Units(head.UnitsPerEm)
+	}
 	return metrics
 }
 
@@ -97,8 +94,7 @@ func GlyphMetrics(otf *ot.Font, gid ot.GlyphIndex) GlyphMetricsInfo {
 	metrics := GlyphMetricsInfo{}
 	//
 	// table HMtx: advance width and left side bearing
-	hmtx := otf.Table(ot.T("hmtx")).Self().AsHMtx() // required table in OpenType
-	if hmtx != nil {
+	if hmtx := otf.HorizontalMetrics(); hmtx != nil { // required table in OpenType
 		if aw, lsb, ok := hmtx.HMetrics(gid); ok {
 			metrics.Advance = sfnt.Units(aw)
 			metrics.LSB = sfnt.Units(lsb)
@@ -106,17 +102,12 @@ func GlyphMetrics(otf *ot.Font, gid ot.GlyphIndex) GlyphMetricsInfo {
 	}
 	//
 	// table glyf: bounding box
-	if glyf := otf.Table(ot.T("glyf")); glyf != nil {
-		if lo := otf.Table(ot.T("loca")); lo != nil {
-			loca := lo.Self().AsLoca()
-			loc := loca.IndexToLocation(gid)
-			b := glyf.Binary()[loc:]
-			metrics.BBox = BoundingBox{
-				MinX: sfnt.Units(i16(b[2:])),
-				MinY: sfnt.Units(i16(b[4:])),
-				MaxX: sfnt.Units(i16(b[6:])),
-				MaxY: sfnt.Units(i16(b[8:])),
-			}
+	if r, ok := otf.GlyphBBox(gid); ok {
+		metrics.BBox = BoundingBox{
+			MinX: sfnt.Units(r.MinX),
+			MinY: sfnt.Units(r.MinY),
+			MaxX: sfnt.Units(r.MaxX),
+			MaxY: sfnt.Units(r.MaxY),
 		}
 	}
 	// RSB calculation: rsb = aw - (lsb + xMax - xMin)
@@ -53,6 +53,12 @@ func (env *MetricsTestEnviron) TestGlyphMetrics() {
 	env.Equal(sfnt.Units(1185), m.Advance, "expected font.Advance for 'A' to be 1185 units")
 }
 
+func (env *MetricsTestEnviron) TestFontScaler() {
+	s := FontScaler(env.calibri, 12, 96, RoundNone)
+	env.Equal(sfnt.Units(2048), s.UnitsPerEm, "expected Calibri to have 2048 units per em")
+	env.Equal(9.2578125, s.Pixels(1185), "expected advance of 'A' to be 9.26 px at 16 ppem")
+}
+
 func (env *MetricsTestEnviron) TestLanguageMatch() {
 	script, lang := FontSupportsScript(env.calibri, ot.T("latn"), ot.T("TRK"))
 	env.Equal("latn", script.String(), "expected Latin script in test font")
@@ -0,0 +1,123 @@
+package otquery
+
+import (
+	"encoding/binary"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// OpticalSize describes the optical size a font has been designed for, in
+// points. Fonts of a family designed for different sizes share a subfamily
+// identifier and state disjoint size ranges.
+type OpticalSize struct {
+	DesignSize      float64 // size the font has been designed for, in points
+	SubfamilyID     uint16  // identifies the fonts of a family differing in optical size only; 0 if not set
+	SubfamilyNameID uint16  // name ID of the subfamily name in table 'name'; 0 if not set
+	RangeStart      float64 // sizes the font is intended for are > RangeStart …
+	RangeEnd        float64 // … and ≤ RangeEnd; both are 0 if not set
+}
+
+// Covers reports whether the font is intended for text set at size pt. If no
+// range is set, Covers reports whether pt equals the design size.
+func (o OpticalSize) Covers(pt float64) bool {
+	if o.RangeStart == 0 && o.RangeEnd == 0 {
+		return pt == o.DesignSize
+	}
+	return pt > o.RangeStart && pt <= o.RangeEnd
+}
+
+// OpticalSizeInfo returns the optical size of font otf. It is read from the
+// parameters of GPOS feature 'size'. For fonts without feature 'size', the
+// first axis value for axis 'opsz' of table 'STAT' is used: a nominal value and
+// range (format 2) or a single value (formats 1 and 3).
+//
+// For variable fonts with an 'opsz' axis, the optical size varies with the
+// design space coordinates; see package otvar.
+func OpticalSizeInfo(otf *ot.Font) (OpticalSize, bool) {
+	if size, ok := sizeFeature(otf); ok {
+		return size, true
+	}
+	return statOpticalSize(otf)
+}
+
+// sizeFeature reads the parameters of GPOS feature 'size', which state sizes
+// in decipoints.
+func sizeFeature(otf *ot.Font) (OpticalSize, bool) {
+	lt := layoutTable(otf, "GPOS")
+	if lt == nil {
+		return OpticalSize{}, false
+	}
+	params, ok := lt.FeatureGraph().First(ot.T("size")).SizeParams()
+	if !ok {
+		return OpticalSize{}, false
+	}
+	size := OpticalSize{
+		DesignSize:      float64(params.DesignSize) / 10,
+		SubfamilyID:     params.SubfamilyID,
+		SubfamilyNameID: params.SubfamilyNameID,
+		RangeStart:      float64(params.RangeStart) / 10,
+		RangeEnd:        float64(params.RangeEnd) / 10,
+	}
+	if size.SubfamilyID == 0 { // no range without a subfamily
+		size.SubfamilyNameID, size.RangeStart, size.RangeEnd = 0, 0, 0
+	}
+	return size, true
+}
+
+// statOpticalSize reads the first axis value table for axis 'opsz' of table
+// 'STAT'.
+func statOpticalSize(otf *ot.Font) (OpticalSize, bool) {
+	if otf == nil {
+		return OpticalSize{}, false
+	}
+	stat := otf.Table(ot.T("STAT"))
+	if stat == nil {
+		return OpticalSize{}, false
+	}
+	b := stat.Binary()
+	if len(b) < 18 {
+		return OpticalSize{}, false
+	}
+	axisSize, axisCount := int(u16(b[4:])), int(u16(b[6:]))
+	axesOffset := int(binary.BigEndian.Uint32(b[8:]))
+	valueCount := int(u16(b[12:]))
+	valuesOffset := int(binary.BigEndian.Uint32(b[14:]))
+	opsz := -1
+	for i := range axisCount {
+		at := axesOffset + i*axisSize
+		if axisSize < 8 || at+8 > len(b) {
+			return OpticalSize{}, false
+		}
+		if ot.Tag(binary.BigEndian.Uint32(b[at:])) == ot.T("opsz") {
+			opsz = i
+			break
+		}
+	}
+	if opsz < 0 || valuesOffset+2*valueCount > len(b) {
+		return OpticalSize{}, false
+	}
+	fixed := func(b []byte) float64 {
+		return ot.Fixed(binary.BigEndian.Uint32(b)).Float()
+	}
+	for i := range valueCount {
+		at := valuesOffset + int(u16(b[valuesOffset+2*i:]))
+		if at+12 > len(b) || int(u16(b[at+2:])) != opsz {
+			continue
+		}
+		v := b[at:]
+		switch u16(v) {
+		case 1, 3:
+			return OpticalSize{DesignSize: fixed(v[8:])}, true
+		case 2:
+			if len(v) < 20 {
+				continue
+			}
+			return OpticalSize{
+				DesignSize: fixed(v[8:]),
+				RangeStart: fixed(v[12:]),
+				RangeEnd:   fixed(v[16:]),
+			}, true
+		}
+	}
+	return OpticalSize{}, false
+}
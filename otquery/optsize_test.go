@@ -0,0 +1,77 @@
+package otquery
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestOpticalSizeFeature(t *testing.T) {
+	b := testfont.New(2)
+	gpos := b.GPOS()
+	size := gpos.Feature("size")
+	// design size 9pt, subfamily 1 with name ID 256, range (6pt, 10pt]
+	gpos.FeatureParams(size, []byte{0, 90, 0, 1, 1, 0, 0, 60, 0, 100})
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	o, ok := OpticalSizeInfo(otf)
+	if !ok {
+		t.Fatal("expected font to have an optical size")
+	}
+	want := OpticalSize{DesignSize: 9, SubfamilyID: 1, SubfamilyNameID: 256, RangeStart: 6, RangeEnd: 10}
+	if o != want {
+		t.Errorf("expected %+v, have %+v", want, o)
+	}
+	for pt, covered := range map[float64]bool{6: false, 6.5: true, 10: true, 12: false} {
+		if o.Covers(pt) != covered {
+			t.Errorf("expected Covers(%g) = %v", pt, covered)
+		}
+	}
+	if _, ok := OpticalSizeInfo(loadLocalFont(t, "Calibri.ttf")); ok {
+		t.Errorf("did not expect Calibri to have an optical size")
+	}
+}
+
+func TestOpticalSizeSTAT(t *testing.T) {
+	be := binary.BigEndian
+	stat := be.AppendUint16(nil, 1)
+	stat = be.AppendUint16(stat, 1)
+	stat = be.AppendUint16(stat, 8)  // design axis size
+	stat = be.AppendUint16(stat, 2)  // design axis count
+	stat = be.AppendUint32(stat, 20) // design axes offset
+	stat = be.AppendUint16(stat, 2)  // axis value count
+	stat = be.AppendUint32(stat, 36) // axis value offsets offset
+	stat = be.AppendUint16(stat, 2)  // elided fallback name ID
+	for _, tag := range []string{"wght", "opsz"} {
+		stat = be.AppendUint32(stat, uint32(ot.T(tag)))
+		stat = be.AppendUint16(stat, 256)
+		stat = be.AppendUint16(stat, 0)
+	}
+	stat = be.AppendUint16(stat, 4)  // weight value
+	stat = be.AppendUint16(stat, 16) // optical size value
+	stat = be.AppendUint16(stat, 1)
+	stat = be.AppendUint16(stat, 0) // axis index
+	stat = be.AppendUint16(stat, 0)
+	stat = be.AppendUint16(stat, 257)
+	stat = be.AppendUint32(stat, 400<<16)
+	stat = be.AppendUint16(stat, 2)
+	stat = be.AppendUint16(stat, 1) // axis index
+	stat = be.AppendUint16(stat, 0)
+	stat = be.AppendUint16(stat, 258)
+	stat = be.AppendUint32(stat, 18<<16)
+	stat = be.AppendUint32(stat, 12<<16|0x8000)
+	stat = be.AppendUint32(stat, 24<<16)
+	otf, err := testfont.New(2).Table("STAT", stat).Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	o, ok := OpticalSizeInfo(otf)
+	want := OpticalSize{DesignSize: 18, RangeStart: 12.5, RangeEnd: 24}
+	if !ok || o != want {
+		t.Errorf("expected %+v, have %+v (%v)", want, o, ok)
+	}
+}
@@ -0,0 +1,76 @@
+package otquery
+
+import (
+	"math"
+
+	"github.com/npillmayer/opentype/ot"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// RoundingMode selects how scaled values are snapped to the pixel grid.
+type RoundingMode int
+
+const (
+	RoundNone    RoundingMode = iota // keep fractional pixels (1/64 precision for 26.6)
+	RoundNearest                     // round to the nearest whole pixel, halves away from zero
+	RoundDown                        // round towards negative infinity
+	RoundUp                          // round towards positive infinity
+)
+
+// Scaler converts values in font units to pixels, for a given size in
+// pixels per em (ppem). Downstream layout code should use a Scaler for all
+// conversions tied to units-per-em, to get consistent results.
+type Scaler struct {
+	UnitsPerEm sfnt.Units   // design units per em of the font
+	PPEM       float64      // pixels per em
+	Rounding   RoundingMode // rounding applied to scaled values
+}
+
+// NewScaler creates a scaler for a font with upem units per em, set at a point
+// size of pointSize at a resolution of dpi dots per inch.
+func NewScaler(upem sfnt.Units, pointSize, dpi float64, mode RoundingMode) Scaler {
+	return Scaler{
+		UnitsPerEm: upem,
+		PPEM:       pointSize * dpi / 72,
+		Rounding:   mode,
+	}
+}
+
+// FontScaler creates a scaler for font otf, taking units per em from the font's
+// head table. See NewScaler.
+func FontScaler(otf *ot.Font, pointSize, dpi float64, mode RoundingMode) Scaler {
+	var upem sfnt.Units
+	if head := otf.FontHead(); head != nil {
+		upem = sfnt.Units(head.UnitsPerEm)
+	}
+	return NewScaler(upem, pointSize, dpi, mode)
+}
+
+// Pixels converts a value in font units to (possibly fractional) pixels.
+// For a scaler without valid units per em, 0 is returned.
+func (s Scaler) Pixels(u sfnt.Units) float64 {
+	if s.UnitsPerEm <= 0 {
+		return 0
+	}
+	return s.round(float64(u) * s.PPEM / float64(s.UnitsPerEm))
+}
+
+// Fixed converts a value in font units to 26.6 fixed-point pixels.
+// With RoundNone, the result is rounded to the nearest 1/64 of a pixel.
+func (s Scaler) Fixed(u sfnt.Units) fixed.Int26_6 {
+	px := s.Pixels(u)
+	return fixed.Int26_6(ot.Round(px * 64))
+}
+
+func (s Scaler) round(px float64) float64 {
+	switch s.Rounding {
+	case RoundNearest:
+		return float64(ot.Round(px))
+	case RoundDown:
+		return math.Floor(px)
+	case RoundUp:
+		return math.Ceil(px)
+	}
+	return px
+}
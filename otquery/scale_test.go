@@ -0,0 +1,37 @@
+package otquery
+
+import (
+	"testing"
+
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+func TestScalerRounding(t *testing.T) {
+	for _, c := range []struct {
+		mode  RoundingMode
+		units sfnt.Units
+		px    float64
+		fx    fixed.Int26_6
+	}{
+		{RoundNone, 1024, 8, fixed.I(8)},
+		{RoundNone, 100, 0.78125, 50},
+		{RoundNearest, 100, 1, fixed.I(1)},
+		{RoundDown, 100, 0, 0},
+		{RoundUp, 100, 1, fixed.I(1)},
+		{RoundNearest, -100, -1, fixed.I(-1)},
+		{RoundDown, -100, -1, fixed.I(-1)},
+		{RoundUp, -100, 0, 0},
+	} {
+		s := NewScaler(2048, 12, 96, c.mode) // 16 ppem
+		if px := s.Pixels(c.units); px != c.px {
+			t.Errorf("mode %d: expected %d units to be %g px, have %g", c.mode, c.units, c.px, px)
+		}
+		if fx := s.Fixed(c.units); fx != c.fx {
+			t.Errorf("mode %d: expected %d units to be %v (26.6), have %v", c.mode, c.units, c.fx, fx)
+		}
+	}
+	if px := (Scaler{PPEM: 16}).Pixels(100); px != 0 {
+		t.Errorf("expected scaler without units per em to yield 0, have %g", px)
+	}
+}
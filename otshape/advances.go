@@ -0,0 +1,30 @@
+package otshape
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+)
+
+// FinalAdvances returns the advance width of every glyph of a shaped run, in
+// font units, i.e. the advance of the glyph in the font with the XAdvance
+// adjustments of GPOS folded in. This is the horizontal advance a renderer
+// moves the pen by after drawing a glyph. Results are written to dst, which
+// is re-allocated if it is too small.
+//
+// Glyph records written by the shaper hold the advances of the default
+// instance of a font. For other instances of a variable font, advance returns
+// the advance widths of the instance, e.g. the Advance method of otvar.Advances;
+// GPOS adjustments are kept. A nil advance uses the font's advances.
+func FinalAdvances(font *ot.Font, glyphs []GlyphRecord, advance func(ot.GlyphIndex) int32, dst []int32) []int32 {
+	if cap(dst) < len(glyphs) {
+		dst = make([]int32, len(glyphs))
+	}
+	dst = dst[:len(glyphs)]
+	for i, g := range glyphs {
+		dst[i] = g.Pos.XAdvance
+		if advance != nil && font != nil {
+			dst[i] += advance(g.GID) - int32(otquery.GlyphMetrics(font, g.GID).Advance)
+		}
+	}
+	return dst
+}
@@ -0,0 +1,33 @@
+package otshape
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"github.com/npillmayer/opentype/otquery"
+)
+
+func TestFinalAdvances(t *testing.T) {
+	font := ligatureFont(t)
+	adv := func(gid ot.GlyphIndex) int32 { return int32(otquery.GlyphMetrics(font, gid).Advance) }
+	glyphs := []GlyphRecord{
+		{GID: 1, Pos: otlayout.PosItem{XAdvance: adv(1) - 30}}, // kerned by GPOS
+		{GID: 2, Pos: otlayout.PosItem{XAdvance: adv(2)}},
+		{GID: 6, Pos: otlayout.PosItem{XAdvance: 0}}, // zeroed mark
+	}
+	if got, want := FinalAdvances(font, glyphs, nil, nil), []int32{adv(1) - 30, adv(2), 0}; !slices.Equal(got, want) {
+		t.Errorf("expected advances %v, have %v", want, got)
+	}
+	// advances of another instance of the font keep GPOS adjustments
+	wider := func(gid ot.GlyphIndex) int32 { return adv(gid) + 10 }
+	dst := make([]int32, 0, 8)
+	got := FinalAdvances(font, glyphs, wider, dst)
+	if want := []int32{adv(1) - 20, adv(2) + 10, 10}; !slices.Equal(got, want) {
+		t.Errorf("expected advances %v, have %v", want, got)
+	}
+	if &got[0] != &dst[:1][0] {
+		t.Errorf("expected dst to be re-used")
+	}
+}
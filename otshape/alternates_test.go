@@ -0,0 +1,89 @@
+package otshape
+
+import (
+	"encoding/binary"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// characterVariantFont builds a font with a character variant feature 'cv01',
+// offering glyphs 2, 3 and 4 for 'a' (glyph 1). Its feature parameters name
+// two of the variants.
+func characterVariantFont(t *testing.T) *ot.Font {
+	t.Helper()
+	b := testfont.New(5)
+	b.Map('a', 1).Map('b', 2)
+	gsub := b.GSUB()
+	cv := gsub.Feature("cv01", gsub.Lookup(ot.GSubLookupTypeAlternate, 0, testfont.AlternateSubst(
+		map[ot.GlyphIndex][]ot.GlyphIndex{1: {2, 3, 4}})))
+	var params []byte
+	for _, v := range []uint16{0, 256, 0, 0, 2, 257, 0} {
+		params = binary.BigEndian.AppendUint16(params, v)
+	}
+	gsub.FeatureParams(cv, params)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func TestShapeCharacterVariantValues(t *testing.T) {
+	font := characterVariantFont(t)
+	for _, c := range []struct {
+		features []FeatureRange
+		want     []ot.GlyphIndex
+	}{
+		{nil, []ot.GlyphIndex{1, 1}},
+		{[]FeatureRange{{Feature: ot.T("cv01"), On: true}}, []ot.GlyphIndex{2, 2}},
+		{[]FeatureRange{{Feature: ot.T("cv01"), On: true, Arg: 2}}, []ot.GlyphIndex{3, 3}},
+		{[]FeatureRange{{Feature: ot.T("cv01"), On: true, Arg: 3}}, []ot.GlyphIndex{4, 4}},
+		{[]FeatureRange{{Feature: ot.T("cv01"), On: true, Arg: 4}}, []ot.GlyphIndex{1, 1}},
+		{ // values per range of the input
+			[]FeatureRange{
+				{Feature: ot.T("cv01"), On: true, Arg: 3, Start: 0, End: 1},
+				{Feature: ot.T("cv01"), On: true, Arg: 2, Start: 1, End: 2},
+			},
+			[]ot.GlyphIndex{4, 3},
+		},
+	} {
+		params := standardParams(font)
+		params.Features = c.features
+		sink := &collectSink{}
+		if err := NewShaper(plainShaper{}).Shape(params, StringSource("aa"), sink, BufferOptions{}); err != nil {
+			t.Fatalf("shaping failed: %v", err)
+		}
+		if len(sink.glyphs) != len(c.want) {
+			t.Fatalf("%v: expected %d glyphs, have %d", c.features, len(c.want), len(sink.glyphs))
+		}
+		for i, g := range sink.glyphs {
+			if g.GID != c.want[i] {
+				t.Errorf("%v: expected glyphs %v, have glyph %d at %d", c.features, c.want, g.GID, i)
+			}
+		}
+	}
+}
+
+func TestPlanCompileCharacterVariantNote(t *testing.T) {
+	font := characterVariantFont(t)
+	for arg, warn := range map[int]bool{2: false, 3: true} {
+		p, err := compile(planRequest{
+			Font:         font,
+			ScriptTag:    ot.T("latn"),
+			UserFeatures: []FeatureRange{{Feature: ot.T("cv01"), On: true, Arg: arg}},
+		})
+		if err != nil {
+			t.Fatalf("compile failed: %v", err)
+		}
+		found := false
+		for _, n := range p.Notes {
+			found = found || strings.Contains(n.Message, "named variants")
+		}
+		if found != warn {
+			t.Errorf("cv01=%d: expected note on named variants to be %v, have %v", arg, warn, p.Notes)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package otshape
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// stackedMarksFont builds a font with marks 'acutecomb', attaching to base
+// 'a', and 'gravecomb', attaching to 'acutecomb' only.
+func stackedMarksFont(t *testing.T) *ot.Font {
+	t.Helper()
+	b := testfont.New(4)
+	for i, name := range []string{"a", "acutecomb", "gravecomb"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('a', 1).Map(0x301, 2).Map(0x300, 3)
+	b.Advance(2, 0).Advance(3, 0)
+	err := b.Features(`
+languagesystem latn dflt;
+table GDEF {
+    GlyphClassDef [a], , [acutecomb gravecomb], ;
+} GDEF;
+markClass acutecomb <anchor 100 500> @TOP;
+markClass gravecomb <anchor 100 500> @MKMK;
+feature mark { pos base a <anchor 250 600> mark @TOP; } mark;
+feature mkmk { pos mark acutecomb <anchor 110 800> mark @MKMK; } mkmk;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+// markShaper shapes without normalization, as Unicode composes 'a' and U+0301,
+// for which the test font has no glyph.
+type markShaper struct{ plainShaper }
+
+func (markShaper) New() ShapingEngine                         { return markShaper{} }
+func (markShaper) NormalizationPreference() NormalizationMode { return NormalizationNone }
+func (markShaper) ApplyGPOS() bool                            { return true }
+
+func shapeMarks(t *testing.T, font *ot.Font, text string, opts BufferOptions) []GlyphRecord {
+	t.Helper()
+	var sink collectSink
+	if err := NewShaper(markShaper{}).Shape(standardParams(font), StringSource(text), &sink, opts); err != nil {
+		t.Fatalf("shape failed: %v", err)
+	}
+	return sink.glyphs
+}
+
+func TestStackedMarkPositions(t *testing.T) {
+	glyphs := shapeMarks(t, stackedMarksFont(t), "a\u0301\u0300", BufferOptions{})
+	if len(glyphs) != 3 {
+		t.Fatalf("expected 3 glyphs, have %d", len(glyphs))
+	}
+	// the anchor of the acute is placed on the anchor of the base, 500 units
+	// to the left of the pen position after the base
+	acute, grave := glyphs[1].Pos, glyphs[2].Pos
+	if acute.AttachTo != 0 || acute.XOffset != -350 || acute.YOffset != 100 {
+		t.Errorf("expected acute at (-350, 100), attached to 0, have (%d, %d), attached to %d",
+			acute.XOffset, acute.YOffset, acute.AttachTo)
+	}
+	// the grave is positioned relative to the acute, including its offsets
+	if grave.AttachTo != 1 || grave.XOffset != -340 || grave.YOffset != 400 {
+		t.Errorf("expected grave at (-340, 400), attached to 1, have (%d, %d), attached to %d",
+			grave.XOffset, grave.YOffset, grave.AttachTo)
+	}
+}
+
+func TestAttachmentIndicesAcrossFlushes(t *testing.T) {
+	// every flush emits one base with its marks, shaped as a run of its own
+	opts := BufferOptions{FlushBoundary: FlushOnClusterBoundary, HighWatermark: 6, LowWatermark: 3, MaxBuffer: 12}
+	glyphs := shapeMarks(t, stackedMarksFont(t), strings.Repeat("a\u0301\u0300", 4), opts)
+	if len(glyphs) != 12 {
+		t.Fatalf("expected 12 glyphs, have %d", len(glyphs))
+	}
+	for i, g := range glyphs {
+		want := int32(i - 1)
+		if g.GID == 1 {
+			want = -1
+		}
+		if g.Pos.AttachTo != want {
+			t.Errorf("expected glyph %d to be attached to glyph %d, is attached to %d", i, want, g.Pos.AttachTo)
+		}
+	}
+}
@@ -253,9 +253,10 @@ func (b Buffer) mapGlyphs(input string, otf *ot.Font, script ot.Tag, lang ot.Tag
 	var n int
 	for !iterInput.Done() { // now every character is Unicode-normalized NFC or NFD
 		codepoints := iterInput.Next() // get a sequence of code-points
-		tracer().Debugf("read codepoints '%s' (%v)", string(codepoints), codepoints)
 		glyphs := findRepresentation(codepoints, otf, buf, normFlag)
-		tracer().Debugf("glyphs = %v", glyphs)
+		if traceDebug() {
+			tracer().Debugf("read codepoints '%s' (%v) => glyphs %v", string(codepoints), codepoints, glyphs)
+		}
 		var glyph ot.GlyphIndex
 		for _, glyph = range glyphs {
 			b[n].Index = glyph
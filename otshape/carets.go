@@ -0,0 +1,68 @@
+package otshape
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// LigatureCaret is a caret position within a ligature glyph, between two of
+// its components.
+type LigatureCaret struct {
+	Cluster uint32 // input cluster of the component following the caret, in logical order
+	Offset  int32  // distance of the caret from the glyph origin, in font units
+}
+
+// LigatureCarets returns the caret positions between the components of the
+// ligature glyph g, in logical order. Editing clients use them to map caret
+// positions within a ligature to the input clusters of its components, e.g.,
+// for backspacing over single characters of a ligature.
+//
+// Offsets are taken from the ligature caret list of the font (see
+// [otquery.LigatureCarets]) if it holds a caret for every component boundary.
+// Otherwise, or if carets are given as contour points, the advance of g is
+// divided evenly among its components. For right-to-left text the first
+// component is the rightmost one.
+//
+// LigatureCarets returns nil if g is not a ligature of at least two components.
+func LigatureCarets(font *ot.Font, g GlyphRecord, dir bidi.Direction) []LigatureCaret {
+	n := len(g.Components)
+	if font == nil || n < 2 {
+		return nil
+	}
+	rtl := dir == bidi.RightToLeft
+	offsets := fontCaretOffsets(font, g.GID, n-1)
+	advance := int32(otquery.GlyphMetrics(font, g.GID).Advance)
+	carets := make([]LigatureCaret, n-1)
+	for i := range carets {
+		carets[i].Cluster = g.Components[i+1]
+		k := i // index of the caret from the left edge of the glyph
+		if rtl {
+			k = n - 2 - i
+		}
+		if offsets != nil {
+			carets[i].Offset = offsets[k]
+		} else {
+			carets[i].Offset = advance * int32(k+1) / int32(n)
+		}
+	}
+	return carets
+}
+
+// fontCaretOffsets returns the caret offsets of ligature glyph gid from left
+// to right, if the font holds count carets for gid, all of them given as
+// coordinates. Otherwise nil is returned.
+func fontCaretOffsets(font *ot.Font, gid ot.GlyphIndex, count int) []int32 {
+	carets := otquery.LigatureCarets(font, gid)
+	if len(carets) != count {
+		return nil
+	}
+	offsets := make([]int32, count)
+	for i, c := range carets {
+		if c.Format == ot.CaretValueFormat2 {
+			return nil
+		}
+		offsets[i] = int32(c.Coordinate)
+	}
+	return offsets
+}
@@ -0,0 +1,91 @@
+package otshape
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// ligatureFont builds a font with ligatures ff (4), ffi (5) and fi (7), where
+// ffi is formed from ff. GDEF holds a caret at 250 for fi only.
+func ligatureFont(t *testing.T) *ot.Font {
+	t.Helper()
+	var gdef []byte
+	for _, v := range []uint16{1, 0, 0, 0, 12, 0, 6, 1, 12} { // header, LigCaretList
+		gdef = binary.BigEndian.AppendUint16(gdef, v)
+	}
+	gdef = append(gdef, testfont.Coverage(7)...)
+	for _, v := range []uint16{1, 4, 1, 250} { // LigGlyph, CaretValue format 1
+		gdef = binary.BigEndian.AppendUint16(gdef, v)
+	}
+	b := testfont.New(8)
+	b.Map('f', 1).Map('i', 2).Map('x', 6)
+	b.Table("GDEF", gdef)
+	gsub := b.GSUB()
+	gsub.Feature("liga",
+		gsub.Lookup(ot.GSubLookupTypeLigature, 0, testfont.LigatureSubst(
+			testfont.Ligature{Components: []ot.GlyphIndex{1, 1}, Glyph: 4})),
+		gsub.Lookup(ot.GSubLookupTypeLigature, 0, testfont.LigatureSubst(
+			testfont.Ligature{Components: []ot.GlyphIndex{4, 2}, Glyph: 5},
+			testfont.Ligature{Components: []ot.GlyphIndex{1, 2}, Glyph: 7})))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func TestShapeLigatureComponents(t *testing.T) {
+	font := ligatureFont(t)
+	shaper := NewShaper(plainShaper{})
+	sink := &collectSink{}
+	opts := BufferOptions{LigatureComponents: true}
+	if err := shaper.Shape(standardParams(font), StringSource("xffixfi"), sink, opts); err != nil {
+		t.Fatalf("shaping failed: %v", err)
+	}
+	// components of earlier runs must not be overwritten by later runs
+	if err := shaper.Shape(standardParams(font), StringSource("fifffi"), &collectSink{}, opts); err != nil {
+		t.Fatalf("shaping failed: %v", err)
+	}
+	want := []struct {
+		gid        ot.GlyphIndex
+		cluster    uint32
+		components []uint32
+	}{
+		{6, 0, nil}, {5, 1, []uint32{1, 2, 3}}, {6, 4, nil}, {7, 5, []uint32{5, 6}},
+	}
+	if len(sink.glyphs) != len(want) {
+		t.Fatalf("expected %d glyphs, have %d: %+v", len(want), len(sink.glyphs), sink.glyphs)
+	}
+	for i, w := range want {
+		g := sink.glyphs[i]
+		if g.GID != w.gid || g.Cluster != w.cluster || !slices.Equal(g.Components, w.components) {
+			t.Errorf("glyph %d: expected %d/%d/%v, have %d/%d/%v", i, w.gid, w.cluster, w.components,
+				g.GID, g.Cluster, g.Components)
+		}
+	}
+}
+
+func TestLigatureCarets(t *testing.T) {
+	font := ligatureFont(t)
+	ffi := GlyphRecord{GID: 5, Cluster: 1, Components: []uint32{1, 2, 3}}
+	carets := LigatureCarets(font, ffi, bidi.LeftToRight)
+	if !slices.Equal(carets, []LigatureCaret{{Cluster: 2, Offset: 166}, {Cluster: 3, Offset: 333}}) {
+		t.Errorf("expected carets of ffi to divide its advance evenly, have %v", carets)
+	}
+	carets = LigatureCarets(font, ffi, bidi.RightToLeft)
+	if !slices.Equal(carets, []LigatureCaret{{Cluster: 2, Offset: 333}, {Cluster: 3, Offset: 166}}) {
+		t.Errorf("expected right-to-left carets of ffi to run leftwards, have %v", carets)
+	}
+	fi := GlyphRecord{GID: 7, Cluster: 5, Components: []uint32{5, 6}}
+	if carets = LigatureCarets(font, fi, bidi.LeftToRight); !slices.Equal(carets, []LigatureCaret{{Cluster: 6, Offset: 250}}) {
+		t.Errorf("expected caret of fi from GDEF, have %v", carets)
+	}
+	if carets = LigatureCarets(font, GlyphRecord{GID: 6}, bidi.LeftToRight); carets != nil {
+		t.Errorf("did not expect carets for a glyph which is not a ligature, have %v", carets)
+	}
+}
@@ -0,0 +1,107 @@
+package otshape
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// ccmpFont builds a font whose 'ccmp' feature decomposes precomposed
+// characters into base glyphs and marks, followed by features fea.
+func ccmpFont(t *testing.T, fea string) *ot.Font {
+	t.Helper()
+	names := []string{"u", "udieresis", "udieresismacron", "dieresiscomb", "macroncomb",
+		"macroncomb.low", "dieresiscomb.low"}
+	b := testfont.New(len(names) + 1)
+	for i, name := range names {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('u', 1).Map('ü', 2).Map('ǖ', 3).Map(0x308, 4).Map(0x304, 5)
+	err := b.Features(`
+languagesystem latn dflt;
+table GDEF {
+    GlyphClassDef [u udieresis udieresismacron], ,
+        [dieresiscomb macroncomb macroncomb.low dieresiscomb.low], ;
+} GDEF;
+lookup DECOMPOSE_MACRON { sub udieresismacron by udieresis macroncomb; } DECOMPOSE_MACRON;
+lookup DECOMPOSE_DIERESIS { sub udieresis by u dieresiscomb; } DECOMPOSE_DIERESIS;
+lookup LOW_MACRON { sub macroncomb by macroncomb.low; } LOW_MACRON;
+lookup LOW_DIERESIS { sub dieresiscomb by dieresiscomb.low; } LOW_DIERESIS;
+` + fea)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func TestCcmpDecomposition(t *testing.T) {
+	font := ccmpFont(t, `
+feature ccmp {
+    lookup DECOMPOSE_MACRON;
+    sub udieresis' lookup DECOMPOSE_DIERESIS macroncomb' lookup LOW_MACRON;
+    sub u dieresiscomb' lookup LOW_DIERESIS macroncomb.low;
+} ccmp;
+markClass [dieresiscomb.low macroncomb.low] <anchor 0 -100> @BOTTOM;
+feature mark {
+    pos base u <anchor 250 0> mark @BOTTOM;
+} mark;
+`)
+	glyphs, err := shapeWithLimits(font, "ǖü", ShapeLimits{})
+	if err != nil {
+		t.Fatalf("shape failed: %v", err)
+	}
+	var gids []ot.GlyphIndex
+	var clusters []uint32
+	for _, g := range glyphs {
+		gids = append(gids, g.GID)
+		clusters = append(clusters, g.Cluster)
+	}
+	// 'ǖ' is decomposed by the first lookup, then by the contextual lookup,
+	// which substitutes the macron after the glyphs it has inserted. The
+	// dieresis inserted is eligible for the second contextual lookup. 'ü' is
+	// not decomposed, as DECOMPOSE_DIERESIS is referenced from context only.
+	if want := []ot.GlyphIndex{1, 7, 6, 2}; !slices.Equal(gids, want) {
+		t.Fatalf("expected glyphs %v, have %v", want, gids)
+	}
+	if want := []uint32{0, 0, 0, 1}; !slices.Equal(clusters, want) {
+		t.Errorf("expected clusters %v, have %v", want, clusters)
+	}
+	for _, g := range glyphs[1:3] {
+		if g.Pos.AttachTo != 0 {
+			t.Errorf("expected inserted mark %d to be attached to glyph 0, is attached to %d", g.GID, g.Pos.AttachTo)
+		}
+	}
+}
+
+func TestCcmpGrowthLimit(t *testing.T) {
+	// the contextual lookup applies RECURSIVE twice at the same position,
+	// decomposing 'ǖ' and then the 'ü' inserted, which triples the run
+	font := ccmpFont(t, `
+lookup RECURSIVE {
+    sub udieresismacron' lookup DECOMPOSE_MACRON;
+    sub udieresis' lookup DECOMPOSE_DIERESIS;
+} RECURSIVE;
+feature ccmp {
+    sub [udieresismacron udieresis]' lookup RECURSIVE lookup RECURSIVE;
+} ccmp;
+`)
+	glyphs, err := shapeWithLimits(font, "ǖǖ", ShapeLimits{})
+	if err != nil {
+		t.Fatalf("shape failed: %v", err)
+	}
+	if len(glyphs) != 6 {
+		t.Errorf("expected recursive decomposition to 6 glyphs, have %d", len(glyphs))
+	}
+	_, err = shapeWithLimits(font, "ǖǖ", ShapeLimits{MaxGrowthFactor: 2})
+	var lerr *LimitError
+	if !errors.As(err, &lerr) || lerr.Limit != "MaxGrowthFactor" || lerr.Max != 2 {
+		t.Fatalf("expected MaxGrowthFactor limit error, have %v", err)
+	}
+}
@@ -11,6 +11,12 @@ explicit feature push/pop boundaries in-band with rune input.
 In this mode, [Params.Features] is restricted to global defaults
 (FeatureRange with Start==0 and End==0 only).
 
+[Shaper.ShapeContext] and [Shaper.ShapeEventsContext] accept a context.Context
+for cancellation and deadlines; [Shaper.ShapeAll] shapes independent runs
+concurrently. Clients shaping untrusted fonts or text may bound the work done
+per request with [BufferOptions.Limits]; exceeding a limit aborts shaping with
+a [*LimitError].
+
 The pipeline compiles a per-request plan, applies GSUB/GPOS lookups, and supports
 script-specific shaper engines through hook interfaces defined in this package.
 */
@@ -31,6 +37,12 @@ func tracer() tracing.Trace {
 	return tracing.Select("opentype.shaper")
 }
 
+// traceDebug reports whether debug-level tracing is enabled. Shaping checks it
+// before tracing, so disabled tracing does not format or box arguments.
+func traceDebug() bool {
+	return tracer().GetTraceLevel() >= tracing.LevelDebug
+}
+
 // errShaper wraps a message as a user-facing shaping error.
 func errShaper(x string) error {
 	return fmt.Errorf("OpenType text shaping: %s", x)
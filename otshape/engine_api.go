@@ -14,6 +14,7 @@ type SelectionContext struct {
 	Language  language.Tag
 	ScriptTag ot.Tag
 	LangTag   ot.Tag
+	Vertical  bool // vertical layout, see [Params]
 }
 
 // LayoutTable identifies one OpenType layout table.
@@ -48,6 +49,11 @@ const (
 	FeatureGlobalSearch
 	FeatureRandom
 	FeaturePerSyllable
+	// FeatureManualMask leaves the feature off for all glyphs, unless enabled
+	// by the client. It applies to the glyphs whose mask bit (see
+	// PlanContext.FeatureMask1) the engine sets in SetupMasks only, e.g. for
+	// joining forms (see JoiningMasks).
+	FeatureManualMask
 )
 
 // FeatureManualJoiners disables automatic skipping for both ZWJ and ZWNJ.
@@ -109,6 +115,7 @@ type RunContext interface {
 	SetMask(i int, mask uint32)
 	InsertGlyphs(index int, glyphs []ot.GlyphIndex)
 	InsertGlyphCopies(index int, source int, count int)
+	DeleteGlyphs(start, end int)
 	Swap(i, j int)
 }
 
@@ -1,6 +1,8 @@
 package otshape
 
 import (
+	"unicode/utf8"
+
 	"github.com/npillmayer/opentype/ot"
 	"github.com/npillmayer/opentype/otlayout"
 	"golang.org/x/text/unicode/norm"
@@ -135,6 +137,13 @@ func (rc runContext) InsertGlyphCopies(index int, source int, count int) {
 	rc.run.InsertGlyphCopies(index, source, count)
 }
 
+func (rc runContext) DeleteGlyphs(start, end int) {
+	if rc.run == nil {
+		return
+	}
+	rc.run.DeleteGlyphs(start, end)
+}
+
 func (rc runContext) Swap(i, j int) {
 	if rc.run == nil || i < 0 || j < 0 || i >= rc.run.Len() || j >= rc.run.Len() || i == j {
 		return
@@ -164,6 +173,9 @@ func (rc runContext) Swap(i, j int) {
 	if len(rc.run.Joiners) == rc.run.Len() {
 		rc.run.Joiners[i], rc.run.Joiners[j] = rc.run.Joiners[j], rc.run.Joiners[i]
 	}
+	if len(rc.run.Components) == rc.run.Len() {
+		rc.run.Components[i], rc.run.Components[j] = rc.run.Components[j], rc.run.Components[i]
+	}
 }
 
 // pauseContext is the internal adapter for pause callbacks.
@@ -238,10 +250,15 @@ func (nc normalizeContext) Selection() SelectionContext {
 }
 
 func (nc normalizeContext) ComposeUnicode(a, b rune) (rune, bool) {
-	s := norm.NFC.String(string([]rune{a, b}))
-	r := []rune(s)
-	if len(r) == 1 {
-		return r[0], true
+	if b < 0x0300 { // no canonical composition has a second character below U+0300
+		return 0, false
+	}
+	var pair [2 * utf8.UTFMax]byte
+	n := utf8.EncodeRune(pair[:], a)
+	n += utf8.EncodeRune(pair[n:], b)
+	s := norm.NFC.String(string(pair[:n]))
+	if r, size := utf8.DecodeRuneInString(s); size == len(s) {
+		return r, true
 	}
 	return 0, false
 }
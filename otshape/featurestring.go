@@ -0,0 +1,195 @@
+package otshape
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// ParseFeatureString parses a comma-separated list of feature settings in the
+// syntax of Harfbuzz (hb_feature_from_string, hb-shape --features), e.g.
+//
+//	"kern, +liga, -calt, ss01=2, aalt[3:5]=2"
+//
+// Each setting consists of
+//   - an optional prefix '+' (enable) or '-' (disable),
+//   - a feature tag of up to 4 characters, optionally quoted, padded with spaces,
+//   - an optional range of codepoint indices: "[start:end]" with end exclusive,
+//     "[start:]", "[:end]", "[]", or "[i]" for the single codepoint i,
+//   - an optional value "=n", where n is a non-negative integer, or one of
+//     "on", "off", "true", "false". A value of 0 disables the feature.
+//
+// Settings without a value enable the feature with value 1, unless prefixed
+// by '-'. Whitespace between the parts of a setting is allowed.
+func ParseFeatureString(s string) ([]FeatureRange, error) {
+	var features []FeatureRange
+	for _, item := range strings.Split(s, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		f, err := parseFeatureSetting(item)
+		if err != nil {
+			return nil, err
+		}
+		features = append(features, f)
+	}
+	return features, nil
+}
+
+// parseFeatureSetting parses a single feature setting of a feature string.
+func parseFeatureSetting(item string) (FeatureRange, error) {
+	p := featureScanner{s: item}
+	f := FeatureRange{On: true, Arg: 1}
+	p.skipSpace()
+	if p.consume('-') {
+		f.On, f.Arg = false, 0
+	} else {
+		p.consume('+')
+	}
+	tag, err := p.tag()
+	if err != nil {
+		return FeatureRange{}, fmt.Errorf("otshape: feature %q: %w", item, err)
+	}
+	f.Feature = tag
+	if p.consume('[') {
+		if f.Start, f.End, err = p.featureRange(); err != nil {
+			return FeatureRange{}, fmt.Errorf("otshape: feature %q: %w", item, err)
+		}
+	}
+	if p.consume('=') {
+		v, err := p.value()
+		if err != nil {
+			return FeatureRange{}, fmt.Errorf("otshape: feature %q: %w", item, err)
+		}
+		f.Arg, f.On = v, v != 0
+	}
+	if p.skipSpace(); !p.atEnd() {
+		return FeatureRange{}, fmt.Errorf("otshape: feature %q: unexpected %q", item, p.s[p.pos:])
+	}
+	return f, nil
+}
+
+// featureScanner is a tiny scanner for the parts of a feature setting.
+type featureScanner struct {
+	s   string
+	pos int
+}
+
+func (p *featureScanner) atEnd() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *featureScanner) skipSpace() {
+	for !p.atEnd() && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n') {
+		p.pos++
+	}
+}
+
+// consume skips leading whitespace and advances over c, if present.
+func (p *featureScanner) consume(c byte) bool {
+	p.skipSpace()
+	if !p.atEnd() && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// tag reads a feature tag, which may be quoted with single or double quotes.
+func (p *featureScanner) tag() (ot.Tag, error) {
+	p.skipSpace()
+	var quote byte
+	if !p.atEnd() && (p.s[p.pos] == '"' || p.s[p.pos] == '\'') {
+		quote = p.s[p.pos]
+		p.pos++
+	}
+	start := p.pos
+	for !p.atEnd() && isFeatureTagChar(p.s[p.pos], quote != 0) {
+		p.pos++
+	}
+	name := p.s[start:p.pos]
+	if quote != 0 && !p.consume(quote) {
+		return 0, fmt.Errorf("unterminated quoted tag")
+	}
+	if name == "" || len(name) > 4 {
+		return 0, fmt.Errorf("invalid feature tag %q", name)
+	}
+	return ot.T(name + strings.Repeat(" ", 4-len(name))), nil
+}
+
+// isFeatureTagChar reports whether c may be part of a feature tag. Quoted tags
+// may contain spaces.
+func isFeatureTagChar(c byte, quoted bool) bool {
+	switch {
+	case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9', c == '_':
+		return true
+	case quoted:
+		return c >= ' ' && c < 0x7f && c != '"' && c != '\''
+	}
+	return false
+}
+
+// featureRange reads the remainder of a range specification, after the
+// opening bracket. Open ends are returned as 0, as expected by FeatureRange.
+func (p *featureScanner) featureRange() (start, end int, err error) {
+	start, hasStart, err := p.number()
+	if err != nil {
+		return 0, 0, err
+	}
+	if p.consume(':') || p.consume(';') {
+		var hasEnd bool
+		if end, hasEnd, err = p.number(); err != nil {
+			return 0, 0, err
+		}
+		if hasEnd && end <= start {
+			return 0, 0, fmt.Errorf("empty feature range [%d:%d]", start, end)
+		}
+	} else if hasStart {
+		end = start + 1
+	}
+	if !p.consume(']') {
+		return 0, 0, fmt.Errorf("malformed feature range")
+	}
+	return start, end, nil
+}
+
+// number reads an optional non-negative decimal number.
+func (p *featureScanner) number() (int, bool, error) {
+	p.skipSpace()
+	start := p.pos
+	for !p.atEnd() && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	if start == p.pos {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(p.s[start:p.pos])
+	if err != nil {
+		return 0, false, err
+	}
+	return n, true, nil
+}
+
+// value reads a feature value, either a number or a boolean keyword.
+func (p *featureScanner) value() (int, error) {
+	n, ok, err := p.number()
+	if err != nil || ok {
+		return n, err
+	}
+	start := p.pos
+	for !p.atEnd() && isFeatureTagChar(p.s[p.pos], false) {
+		p.pos++
+	}
+	switch word := strings.ToLower(p.s[start:p.pos]); word {
+	case "on", "true":
+		return 1, nil
+	case "off", "false":
+		return 0, nil
+	case "":
+		return 0, fmt.Errorf("missing feature value")
+	default:
+		return 0, fmt.Errorf("invalid feature value %q", word)
+	}
+}
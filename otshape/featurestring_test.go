@@ -0,0 +1,49 @@
+package otshape
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestParseFeatureString(t *testing.T) {
+	features, err := ParseFeatureString("kern, +liga, -calt, ss01=2, aalt[3:5]=2, smcp[7], c2sc[2:], dlig[:4]=on, 'cv1' = off")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []FeatureRange{
+		{Feature: ot.T("kern"), Arg: 1, On: true},
+		{Feature: ot.T("liga"), Arg: 1, On: true},
+		{Feature: ot.T("calt"), Arg: 0, On: false},
+		{Feature: ot.T("ss01"), Arg: 2, On: true},
+		{Feature: ot.T("aalt"), Arg: 2, On: true, Start: 3, End: 5},
+		{Feature: ot.T("smcp"), Arg: 1, On: true, Start: 7, End: 8},
+		{Feature: ot.T("c2sc"), Arg: 1, On: true, Start: 2},
+		{Feature: ot.T("dlig"), Arg: 1, On: true, End: 4},
+		{Feature: ot.T("cv1 "), Arg: 0, On: false},
+	}
+	if !slices.Equal(features, expected) {
+		t.Errorf("expected\n  %v\ngot\n  %v", expected, features)
+	}
+}
+
+func TestParseFeatureStringErrors(t *testing.T) {
+	for _, s := range []string{
+		"kerning",
+		"+",
+		"liga=",
+		"liga=maybe",
+		"aalt[3:5",
+		"aalt[5:3]",
+		"liga kern",
+		"'liga",
+	} {
+		if _, err := ParseFeatureString(s); err == nil {
+			t.Errorf("expected %q to be rejected", s)
+		}
+	}
+	if features, err := ParseFeatureString(" , "); err != nil || len(features) != 0 {
+		t.Errorf("expected empty feature list, got %v, %v", features, err)
+	}
+}
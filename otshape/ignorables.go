@@ -0,0 +1,101 @@
+package otshape
+
+import (
+	"unicode"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+)
+
+// IgnorablePolicy selects how the shaper outputs characters with Unicode
+// property Default_Ignorable_Code_Point, e.g. soft hyphens, zero-width
+// joiners and spaces, bidi controls and variation selectors. Fonts often do
+// not map these characters, which would otherwise show as .notdef boxes.
+//
+// Default-ignorables take part in shaping as mapped by the font, as joiners
+// and variation selectors may influence lookups. Afterwards, glyphs which are
+// still the nominal glyphs of default-ignorables are handled according to the
+// policy. Glyphs substituted by lookups are kept.
+type IgnorablePolicy uint8
+
+const (
+	// IgnorablesHide replaces default-ignorables by the glyph of the space
+	// character, with zero advance and offsets. Fonts without a space glyph
+	// get default-ignorables removed.
+	IgnorablesHide IgnorablePolicy = iota
+	// IgnorablesRemove removes default-ignorables from the output. Clusters of
+	// removed glyphs are not represented in the output.
+	IgnorablesRemove
+	// IgnorablesShow outputs default-ignorables as mapped by the font,
+	// possibly as .notdef.
+	IgnorablesShow
+)
+
+// isDefaultIgnorable reports whether r has Unicode property
+// Default_Ignorable_Code_Point, derived as specified in DerivedCoreProperties.txt.
+func isDefaultIgnorable(r rune) bool {
+	switch {
+	case r < 0xAD: // soft hyphen is the first default-ignorable
+		return false
+	case unicode.Is(unicode.Other_Default_Ignorable_Code_Point, r), unicode.Is(unicode.Variation_Selector, r):
+		return true
+	case !unicode.Is(unicode.Cf, r):
+		return false
+	case r >= 0xFFF9 && r <= 0xFFFB, r >= 0x13430 && r <= 0x1343F:
+		// interlinear annotation and Egyptian hieroglyph format controls
+		return false
+	}
+	return !unicode.Is(unicode.Prepended_Concatenation_Mark, r)
+}
+
+// handleIgnorables hides or removes the nominal glyphs of default-ignorables
+// of the run, according to the policy of the current shaping call.
+func (e *planExecutor) handleIgnorables(pl *plan) {
+	if e.ignorables == IgnorablesShow || e.run == nil || pl == nil || pl.font == nil {
+		return
+	}
+	n := e.run.Len()
+	if len(e.run.Codepoints) != n {
+		return
+	}
+	space := NOTDEF
+	if e.ignorables == IgnorablesHide {
+		space = otquery.GlyphIndex(pl.font, ' ')
+	}
+	for i := n - 1; i >= 0; i-- {
+		cp := e.run.Codepoints[i]
+		if !isDefaultIgnorable(cp) || e.run.Glyphs[i] != otquery.GlyphIndex(pl.font, cp) {
+			continue
+		}
+		if space != NOTDEF {
+			e.hideGlyph(pl, i, space)
+			continue
+		}
+		e.removeGlyph(i)
+	}
+}
+
+// hideGlyph replaces glyph i of the run by glyph gid with zero advance.
+func (e *planExecutor) hideGlyph(pl *plan, i int, gid ot.GlyphIndex) {
+	e.run.EnsurePos()
+	e.run.Glyphs[i] = gid
+	advance := int32(otquery.GlyphMetrics(pl.font, gid).Advance)
+	pos := &e.run.Pos[i]
+	pos.XAdvance, pos.YAdvance = -advance, 0 // the nominal advance is added on output
+	pos.XOffset, pos.YOffset = 0, 0
+}
+
+// removeGlyph removes glyph i from the run and re-targets attachments to
+// glyphs following it.
+func (e *planExecutor) removeGlyph(i int) {
+	e.run.DeleteGlyphs(i, i+1)
+	for j := range e.run.Pos {
+		pos := &e.run.Pos[j]
+		switch {
+		case pos.AttachTo == int32(i):
+			pos.AttachTo = -1
+		case pos.AttachTo > int32(i):
+			pos.AttachTo--
+		}
+	}
+}
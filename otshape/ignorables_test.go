@@ -0,0 +1,118 @@
+package otshape
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestIsDefaultIgnorable(t *testing.T) {
+	for _, r := range []rune{'\u00AD', '\u034F', '\u061C', '\u200B', '\u200C', '\u200D', '\u200E', '\u202E',
+		'\u2066', '\u2060', '\uFE0F', '\uFEFF', '\U000E0001', '\U000E0100'} {
+		if !isDefaultIgnorable(r) {
+			t.Errorf("expected %U to be default-ignorable", r)
+		}
+	}
+	for _, r := range []rune{'a', ' ', '\u00A0', '\u0600', '\u0301', '\uFFF9', '\U00013430', '-'} {
+		if isDefaultIgnorable(r) {
+			t.Errorf("expected %U not to be default-ignorable", r)
+		}
+	}
+}
+
+func TestIgnorablePolicies(t *testing.T) {
+	build := func(withSpace bool) *ot.Font {
+		b := testfont.New(4)
+		b.Map('a', 1).Map('\u00AD', 3) // soft hyphen mapped to a hyphen glyph
+		b.Advance(1, 500).Advance(2, 250).Advance(3, 300)
+		if withSpace {
+			b.Map(' ', 2)
+		}
+		otf, err := b.Parse()
+		if err != nil {
+			t.Fatalf("cannot parse synthetic font: %v", err)
+		}
+		return otf
+	}
+	font := build(true)
+	const text = "a\u200Da\u00ADa\uFE0F"
+	tests := []struct {
+		name     string
+		font     *ot.Font
+		policy   IgnorablePolicy
+		gids     []ot.GlyphIndex
+		advances []int32
+		clusters []uint32
+	}{
+		{"hide", font, IgnorablesHide, []ot.GlyphIndex{1, 2, 1, 2, 1, 2},
+			[]int32{500, 0, 500, 0, 500, 0}, []uint32{0, 1, 2, 3, 4, 5}},
+		{"remove", font, IgnorablesRemove, []ot.GlyphIndex{1, 1, 1},
+			[]int32{500, 500, 500}, []uint32{0, 2, 4}},
+		{"show", font, IgnorablesShow, []ot.GlyphIndex{1, 0, 1, 3, 1, 0},
+			[]int32{500, 500, 500, 300, 500, 500}, []uint32{0, 1, 2, 3, 4, 5}},
+		{"hide without space", build(false), IgnorablesHide, []ot.GlyphIndex{1, 1, 1},
+			[]int32{500, 500, 500}, []uint32{0, 2, 4}},
+	}
+	for _, tt := range tests {
+		var sink collectSink
+		err := NewShaper(plainShaper{}).Shape(standardParams(tt.font), strings.NewReader(text), &sink,
+			BufferOptions{Ignorables: tt.policy})
+		if err != nil {
+			t.Fatalf("%s: shape failed: %v", tt.name, err)
+		}
+		var gids []ot.GlyphIndex
+		var advances []int32
+		var clusters []uint32
+		for _, g := range sink.glyphs {
+			gids = append(gids, g.GID)
+			advances = append(advances, g.Pos.XAdvance)
+			clusters = append(clusters, g.Cluster)
+		}
+		if !slices.Equal(gids, tt.gids) || !slices.Equal(advances, tt.advances) || !slices.Equal(clusters, tt.clusters) {
+			t.Errorf("%s: expected glyphs %v, advances %v and clusters %v, have %v, %v and %v",
+				tt.name, tt.gids, tt.advances, tt.clusters, gids, advances, clusters)
+		}
+	}
+	err := NewShaper(plainShaper{}).Shape(standardParams(font), strings.NewReader(text), &collectSink{},
+		BufferOptions{Ignorables: IgnorablesShow + 1})
+	if err == nil {
+		t.Errorf("expected invalid policy to be rejected")
+	}
+}
+
+func TestIgnorablePoliciesEvents(t *testing.T) {
+	b := testfont.New(3)
+	b.Map('a', 1).Map(' ', 2)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	const text = "a\u200Da\uFE0Fa"
+	tests := []struct {
+		policy IgnorablePolicy
+		gids   []ot.GlyphIndex
+	}{
+		{IgnorablesHide, []ot.GlyphIndex{1, 2, 1, 2, 1}},
+		{IgnorablesRemove, []ot.GlyphIndex{1, 1, 1}},
+		{IgnorablesShow, []ot.GlyphIndex{1, 0, 1, 0, 1}},
+	}
+	for _, tt := range tests {
+		var sink collectSink
+		src := NewInputEventSource(strings.NewReader(text))
+		err := NewShaper(plainShaper{}).ShapeEvents(standardParams(otf), src, &sink,
+			BufferOptions{Ignorables: tt.policy})
+		if err != nil {
+			t.Fatalf("policy %d: shape events failed: %v", tt.policy, err)
+		}
+		var gids []ot.GlyphIndex
+		for _, g := range sink.glyphs {
+			gids = append(gids, g.GID)
+		}
+		if !slices.Equal(gids, tt.gids) {
+			t.Errorf("policy %d: expected glyphs %v, have %v", tt.policy, tt.gids, gids)
+		}
+	}
+}
@@ -18,6 +18,18 @@ const (
 	InputEventPopFeatures
 )
 
+func (k InputEventKind) String() string {
+	switch k {
+	case InputEventRune:
+		return "rune"
+	case InputEventPushFeatures:
+		return "push-features"
+	case InputEventPopFeatures:
+		return "pop-features"
+	}
+	return fmt.Sprintf("InputEventKind(%d)", uint8(k))
+}
+
 // FeatureSetting is one feature assignment used by push events.
 type FeatureSetting struct {
 	Tag     ot.Tag // OpenType feature tag.
@@ -36,7 +48,8 @@ type InputEvent struct {
 	Push []FeatureSetting
 }
 
-// Validate checks that event payload matches its kind.
+// Validate checks that event payload matches its kind. Push events must not
+// carry null feature tags, nor conflicting settings for the same feature.
 func (ev InputEvent) Validate() error {
 	switch ev.Kind {
 	case InputEventRune:
@@ -47,6 +60,16 @@ func (ev InputEvent) Validate() error {
 		if len(ev.Push) == 0 {
 			return fmt.Errorf("otshape: push-features event must carry at least one setting")
 		}
+		for i, fs := range ev.Push {
+			if fs.Tag == 0 {
+				return fmt.Errorf("otshape: push-features setting #%d has no feature tag", i)
+			}
+			for _, prev := range ev.Push[:i] {
+				if prev.Tag == fs.Tag && prev != fs {
+					return fmt.Errorf("otshape: push-features event carries conflicting settings for '%s'", fs.Tag)
+				}
+			}
+		}
 	case InputEventPopFeatures:
 		if ev.Rune != 0 || ev.Size != 0 || len(ev.Push) != 0 {
 			return fmt.Errorf("otshape: pop-features event must not carry rune or push payload")
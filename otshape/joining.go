@@ -0,0 +1,110 @@
+package otshape
+
+import "github.com/npillmayer/opentype/ot"
+
+// JoiningForm is the positional form a character takes in a sequence of
+// joining characters, as in Arabic, Syriac or Mongolian script. Each form is
+// selected by an OpenType feature, see Feature.
+type JoiningForm int
+
+// Joining forms. Forms JoiningFinal2, JoiningFinal3 and JoiningMedial2 are used
+// for Syriac Alaph only.
+const (
+	NoJoiningForm   JoiningForm = iota - 1 // character does not change its form
+	JoiningIsolated                        // feature 'isol'
+	JoiningFinal                           // feature 'fina'
+	JoiningFinal2                          // feature 'fin2'
+	JoiningFinal3                          // feature 'fin3'
+	JoiningMedial                          // feature 'medi'
+	JoiningMedial2                         // feature 'med2'
+	JoiningInitial                         // feature 'init'
+	joiningFormCount
+)
+
+var joiningFormFeatures = [joiningFormCount]ot.Tag{
+	ot.T("isol"), ot.T("fina"), ot.T("fin2"), ot.T("fin3"), ot.T("medi"), ot.T("med2"), ot.T("init"),
+}
+
+// Feature returns the tag of the OpenType feature selecting form f, e.g. 'init'
+// for JoiningInitial. For NoJoiningForm, 0 is returned.
+func (f JoiningForm) Feature() ot.Tag {
+	if f < 0 || f >= joiningFormCount {
+		return 0
+	}
+	return joiningFormFeatures[f]
+}
+
+func (f JoiningForm) String() string {
+	if f < 0 || f >= joiningFormCount {
+		return "none"
+	}
+	return f.Feature().String()
+}
+
+// JoiningFormFeatures returns the tags of the features selecting joining forms,
+// in the order of the JoiningForm constants.
+func JoiningFormFeatures() []ot.Tag {
+	return append([]ot.Tag(nil), joiningFormFeatures[:]...)
+}
+
+// JoiningMasks holds the mask bits of the joining form features of a shaping
+// plan. Engines for joining scripts register the form features with flag
+// FeatureManualMask in CollectFeatures, create the masks in InitPlan and set
+// the form of each glyph in SetupMasks:
+//
+//	func (s *Shaper) InitPlan(plan otshape.PlanContext) {
+//		s.masks = otshape.NewJoiningMasks(plan)
+//	}
+//
+//	func (s *Shaper) SetupMasks(run otshape.RunContext) {
+//		for i, form := range s.forms(run) {
+//			s.masks.Set(run, i, form)
+//		}
+//	}
+//
+// Form features then apply to the glyphs the engine has selected them for
+// only. Glyphs inserted by substitutions inherit the mask of the glyph they
+// replace.
+type JoiningMasks struct {
+	forms [joiningFormCount]uint32
+	all   uint32
+}
+
+// NewJoiningMasks returns the mask bits of the joining form features of plan.
+// Features not selected for the plan have no mask bit.
+func NewJoiningMasks(plan PlanContext) JoiningMasks {
+	var m JoiningMasks
+	if plan == nil {
+		return m
+	}
+	for f, tag := range joiningFormFeatures {
+		m.forms[f] = plan.FeatureMask1(tag)
+		m.all |= m.forms[f]
+	}
+	return m
+}
+
+// Mask returns the mask bit of the feature selecting form f, or 0.
+func (m JoiningMasks) Mask(f JoiningForm) uint32 {
+	if f < 0 || f >= joiningFormCount {
+		return 0
+	}
+	return m.forms[f]
+}
+
+// All returns the mask bits of all joining form features.
+func (m JoiningMasks) All() uint32 {
+	return m.all
+}
+
+// Set enables the feature selecting form f for the glyph at position i of
+// run. Other joining form features are left as they are, so features the client
+// has requested for the glyph stay in effect.
+func (m JoiningMasks) Set(run RunContext, i int, f JoiningForm) {
+	if run == nil || i < 0 || i >= run.Len() {
+		return
+	}
+	if mask := m.Mask(f); mask != 0 {
+		run.SetMask(i, run.Mask(i)|mask)
+	}
+}
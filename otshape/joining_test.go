@@ -0,0 +1,111 @@
+package otshape
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// joiningProbe is an engine which joins all glyphs of a run: the first glyph
+// takes its initial form, the last glyph its final form, and the glyphs in
+// between their medial form.
+type joiningProbe struct {
+	masks JoiningMasks
+}
+
+func (*joiningProbe) Name() string                            { return "joining-probe" }
+func (*joiningProbe) Match(SelectionContext) ShaperConfidence { return ShaperConfidenceCertain }
+func (p *joiningProbe) New() ShapingEngine                    { return &joiningProbe{} }
+func (*joiningProbe) OverrideFeatures(FeaturePlanner)         {}
+
+func (*joiningProbe) CollectFeatures(plan FeaturePlanner, _ SelectionContext) {
+	for _, f := range []JoiningForm{JoiningInitial, JoiningMedial, JoiningFinal} {
+		plan.AddFeature(f.Feature(), FeatureManualMask, 1)
+	}
+}
+
+func (p *joiningProbe) InitPlan(plan PlanContext) {
+	p.masks = NewJoiningMasks(plan)
+}
+
+func (p *joiningProbe) SetupMasks(run RunContext) {
+	for i := range run.Len() {
+		form := JoiningMedial
+		switch {
+		case run.Len() == 1:
+			form = NoJoiningForm
+		case i == 0:
+			form = JoiningInitial
+		case i == run.Len()-1:
+			form = JoiningFinal
+		}
+		p.masks.Set(run, i, form)
+	}
+}
+
+func TestJoiningMasks(t *testing.T) {
+	b := testfont.New(5)
+	for i, name := range []string{"beh", "beh.init", "beh.medi", "beh.fina"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('b', 1)
+	if err := b.Features(`
+languagesystem latn dflt;
+feature init { sub beh by beh.init; } init;
+feature medi { sub beh by beh.medi; } medi;
+feature fina { sub beh by beh.fina; } fina;
+`); err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	font, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	shape := func(text string, features ...FeatureRange) []ot.GlyphIndex {
+		t.Helper()
+		params := standardParams(font)
+		params.Features = features
+		var sink collectSink
+		if err := NewShaper(&joiningProbe{}).Shape(params, StringSource(text), &sink, BufferOptions{}); err != nil {
+			t.Fatalf("shape failed: %v", err)
+		}
+		var gids []ot.GlyphIndex
+		for _, g := range sink.glyphs {
+			gids = append(gids, g.GID)
+		}
+		return gids
+	}
+	tests := []struct {
+		text     string
+		features []FeatureRange
+		want     []ot.GlyphIndex
+	}{
+		{"bbbb", nil, []ot.GlyphIndex{2, 3, 3, 4}},
+		{"bb", nil, []ot.GlyphIndex{2, 4}},
+		// form features are off for glyphs the engine has not enabled them for
+		{"b", nil, []ot.GlyphIndex{1}},
+		// features requested by the client stay in effect
+		{"b", []FeatureRange{{Feature: ot.T("fina"), On: true}}, []ot.GlyphIndex{4}},
+	}
+	for _, tt := range tests {
+		if gids := shape(tt.text, tt.features...); !slices.Equal(gids, tt.want) {
+			t.Errorf("%q %v: expected glyphs %v, have %v", tt.text, tt.features, tt.want, gids)
+		}
+	}
+}
+
+func TestJoiningFormFeatures(t *testing.T) {
+	tags := JoiningFormFeatures()
+	if len(tags) != 7 || tags[JoiningInitial] != ot.T("init") || JoiningFinal2.Feature() != ot.T("fin2") {
+		t.Errorf("unexpected joining form features %v", tags)
+	}
+	if NoJoiningForm.Feature() != 0 || NoJoiningForm.String() != "none" || JoiningMedial.String() != "medi" {
+		t.Errorf("unexpected tags or names of joining forms")
+	}
+	var m JoiningMasks
+	if m.Mask(JoiningInitial) != 0 || m.All() != 0 {
+		t.Errorf("expected zero masks to have no mask bits")
+	}
+}
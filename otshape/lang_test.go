@@ -2,9 +2,11 @@ package otshape
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/npillmayer/opentype/internal/fontload"
+	"github.com/npillmayer/opentype/internal/testfont"
 	"github.com/npillmayer/opentype/ot"
 	"github.com/npillmayer/schuko/tracing"
 	"github.com/npillmayer/schuko/tracing/gotestingadapter"
@@ -57,6 +59,93 @@ func (env *LanguageTestEnviron) TestLanguageTagForLanguage() {
 	}
 }
 
+func (env *LanguageTestEnviron) TestScriptTagCandidates() {
+	tags := scriptTagCandidates(0, language.MustParseScript("Deva"))
+	env.Equal([]ot.Tag{ot.T("dev2"), ot.T("deva"), ot.DFLT, ot.T("dflt"), ot.T("latn")}, tags)
+	tags = scriptTagCandidates(ot.T("latn"), language.MustParseScript("Latn"))
+	env.Equal([]ot.Tag{ot.T("latn"), ot.DFLT, ot.T("dflt")}, tags)
+}
+
+func (env *LanguageTestEnviron) TestScriptTagFallback() {
+	b := testfont.New(4)
+	gsub := b.GSUB()
+	lookup := gsub.Lookup(ot.GSubLookupTypeSingle, 0, testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 2}))
+	gsub.Script("deva", testfont.DefaultLang, gsub.Feature("blwf", lookup))
+	gsub.Script("latn", testfont.DefaultLang, gsub.Feature("liga", lookup))
+	otf, err := b.Parse()
+	env.Require().NoError(err)
+	for _, c := range []struct {
+		script  string
+		feature string
+	}{
+		{"Deva", "blwf"}, // font supports old tag 'deva' only
+		{"Cyrl", "liga"}, // fallback to 'latn'
+	} {
+		script := language.MustParseScript(c.script)
+		feats, err := fontFeaturesForTable(otf, planGSUB, langSysChain(scriptTagCandidates(ScriptTagForScript(script), script), nil))
+		env.Require().NoError(err)
+		env.Require().Len(feats, 2, "expected required feature slot and one feature for %s", c.script)
+		env.Equal(ot.T(c.feature), feats[1].Tag(), "script %s", c.script)
+	}
+}
+
+func (env *LanguageTestEnviron) TestLocalizedCyrillicForms() {
+	b := testfont.New(4)
+	b.Name(1, "be").Name(2, "be.srb").Name(3, "be.bgr")
+	b.Map('б', 1)
+	env.Require().NoError(b.Features(`languagesystem DFLT dflt;
+languagesystem cyrl dflt;
+languagesystem cyrl SRB;
+languagesystem cyrl BGR;
+feature locl {
+  script cyrl;
+  language SRB;
+  sub be by be.srb;
+  language BGR;
+  sub be by be.bgr;
+} locl;
+`))
+	otf, err := b.Parse()
+	env.Require().NoError(err)
+	cyrl := language.MustParseScript("Cyrl")
+	for _, c := range []struct {
+		lang string
+		gid  ot.GlyphIndex
+	}{
+		{"sr", 2},
+		{"sr-Cyrl-RS", 2},
+		{"mk", 2}, // falls back to Serbian forms, as the font lacks 'MKD '
+		{"bg", 3},
+		{"ru", 1}, // default language system
+		{"und", 1},
+	} {
+		params := Params{Font: otf, Script: cyrl, Language: language.Make(c.lang)}
+		var sink collectSink
+		env.Require().NoError(NewShaper(plainShaper{}).Shape(params, strings.NewReader("б"), &sink, BufferOptions{}))
+		env.Equal(c.gid, sink.glyphs[0].GID, "language %s", c.lang)
+	}
+	chain, selected := LangSysChain(Params{Font: otf, Script: cyrl, Language: language.Make("mk")}, LayoutGSUB)
+	env.Require().True(selected >= 0, "expected a language system to be selected")
+	env.Equal([]LangSys{
+		{ot.T("cyrl"), ot.T("MKD")}, {ot.T("cyrl"), ot.T("SRB")}, {ot.T("cyrl"), ot.T("dflt")},
+	}, chain[:3])
+	env.Equal(LangSys{ot.T("cyrl"), ot.T("SRB")}, chain[selected])
+}
+
+func (env *LanguageTestEnviron) TestLangSysFallbackToDFLTScript() {
+	b := testfont.New(3)
+	gsub := b.GSUB()
+	lookup := gsub.Lookup(ot.GSubLookupTypeSingle, 0, testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 2}))
+	gsub.Script("DFLT", testfont.DefaultLang, gsub.Feature("liga", lookup))
+	gsub.Script("cyrl", "SRB ", gsub.Feature("locl", lookup))
+	otf, err := b.Parse()
+	env.Require().NoError(err)
+	params := Params{Font: otf, Script: language.MustParseScript("Cyrl"), Language: language.Make("ru")}
+	chain, selected := LangSysChain(params, LayoutGSUB)
+	env.Require().True(selected >= 0, "expected a language system to be selected")
+	env.Equal(LangSys{ot.DFLT, ot.T("dflt")}, chain[selected])
+}
+
 // --- Helpers ---------------------------------------------------------------
 
 func loadLocalFont(t *testing.T, fontFileName string) *ot.Font {
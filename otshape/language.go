@@ -5,225 +5,166 @@ import (
 
 	"github.com/npillmayer/opentype/ot"
 	"golang.org/x/text/language"
-	"golang.org/x/text/language/display"
 )
 
-// see https://unicode.org/iso15924/iso15924-codes.html
-var script2opentype = map[string]string{
-	"Zzzz": "DFLT", // unknown
-	//
-	"Arab": "arab", // Arabic
-	"Armn": "armn", // Armenian
-	"Beng": "bng2", // Bengali
-	"Cyrl": "cyrl", // Cyrillic
-	"Deva": "dev2", // Devangari
-	"Geor": "geor", // Georgian
-	"Grek": "grek", // Greek
-	"Gujr": "gjr2", // Not gujr
-	"Guru": "gur2", // Not guru
-	"Hang": "hang", // Hanguli
-	"Hans": "hani", // Han (simplified)
-	"Hebr": "hebr", // Hebrew
-	"Hira": "hira", // Hiragana
-	"Knda": "knd2", // Kannada
-	"Kana": "kana", // Katakana
-	"Laoo": "laoo", // Lao
-	"Latn": "latn", // Latin
-	// TODO
-	"Malayalam":              "mlm2",
-	"Oriya":                  "ory2",
-	"Tamil":                  "tml2",
-	"Telugu":                 "tel2",
-	"Thai":                   "thai",
-	"Tibetan":                "tibt",
-	"Bopomofo":               "bopo",
-	"Braille":                "brai",
-	"Canadian_Syllabics":     "cans",
-	"Cherokee":               "cher",
-	"Ethiopic":               "ethi",
-	"Khmer":                  "khmr",
-	"Mongolian":              "mong",
-	"Myanmar":                "mym2", // Not mymr
-	"Ogham":                  "ogam",
-	"Runic":                  "runr",
-	"Sinhala":                "sinh",
-	"Syriac":                 "syrc",
-	"Thaana":                 "thaa",
-	"Yi":                     "yiii",
-	"Deseret":                "dsrt",
-	"Gothic":                 "goth",
-	"Old_Italic":             "ital",
-	"Buhid":                  "buhd",
-	"Hanunoo":                "hano",
-	"Tagalog":                "tglg",
-	"Tagbanwa":               "tagb",
-	"Cypriot":                "cprt",
-	"Limbu":                  "limb",
-	"Linear_B":               "linb",
-	"Osmanya":                "osma",
-	"Shavian":                "shaw",
-	"Tai_Le":                 "tale",
-	"Ugaritic":               "ugar",
-	"Buginese":               "bugi",
-	"Coptic":                 "copt",
-	"Glagolitic":             "glag",
-	"Kharoshthi":             "khar",
-	"New_Tai_Lue":            "talu",
-	"Old_Persian":            "xpeo",
-	"Syloti_Nagri":           "sylo",
-	"Tifinagh":               "tfng",
-	"Balinese":               "bali",
-	"Cuneiform":              "xsux",
-	"Nko":                    "nkoo",
-	"Phags_Pa":               "phag",
-	"Phoenician":             "phnx",
-	"Carian":                 "cari",
-	"Cham":                   "cham",
-	"Kayah_Li":               "kali",
-	"Lepcha":                 "lepc",
-	"Lycian":                 "lyci",
-	"Lydian":                 "lydi",
-	"Ol_Chiki":               "olck",
-	"Rejang":                 "rjng",
-	"Saurashtra":             "saur",
-	"Sundanese":              "sund",
-	"Vai":                    "vaii",
-	"Avestan":                "avst",
-	"Bamum":                  "bamu",
-	"Egyptian_Hieroglyphs":   "egyp",
-	"Imperial_Aramaic":       "armi",
-	"Inscriptional_Pahlavi":  "phli",
-	"Inscriptional_Parthian": "prti",
-	"Javanese":               "java",
-	"Kaithi":                 "kthi",
-	"Lisu":                   "lisu",
-	"Meetei_Mayek":           "mtei",
-	"Old_South_Arabian":      "sarb",
-	"Old_Turkic":             "orkh",
-	"Samaritan":              "samr",
-	"Tai_Tham":               "lana",
-	"Tai_Viet":               "tavt",
-	"Batak":                  "batk",
-	"Brahmi":                 "brah",
-	"Mandaic":                "mand",
-	"Chakma":                 "cakm",
-	"Meroitic_Cursive":       "merc",
-	"Meroitic_Hieroglyphs":   "mero",
-	"Miao":                   "plrd",
-	"Sharada":                "shrd",
-	"Sora_Sompeng":           "sora",
-	"Takri":                  "takr",
-	"Bassa_Vah":              "bass",
-	"Caucasian_Albanian":     "aghb",
-	"Duployan":               "dupl",
-	"Elbasan":                "elba",
-	"Grantha":                "gran",
-	"Khojki":                 "khoj",
-	"Khudawadi":              "sind",
-	"Linear_A":               "lina",
-	"Mahajani":               "mahj",
-	"Manichaean":             "mani",
-	"Mende_Kikakui":          "mend",
-	"Modi":                   "modi",
-	"Mro":                    "mroo",
-	"Nabataean":              "nbat",
-	"Old_North_Arabian":      "narb",
-	"Old_Permic":             "perm",
-	"Pahawh_Hmong":           "hmng",
-	"Palmyrene":              "palm",
-	"Pau_Cin_Hau":            "pauc",
-	"Psalter_Pahlavi":        "phlp",
-	"Siddham":                "sidd",
-	"Tirhuta":                "tirh",
-	"Warang_Citi":            "wara",
-	"Ahom":                   "ahom",
-	"Anatolian_Hieroglyphs":  "hluw",
-	"Hatran":                 "hatr",
-	"Multani":                "mult",
-	"Old_Hungarian":          "hung",
-	"Signwriting":            "sgnw",
-	"Adlam":                  "adlm",
-	"Bhaiksuki":              "bhks",
-	"Marchen":                "marc",
-	"Osage":                  "osge",
-	"Tangut":                 "tang",
-	"Newa":                   "newa",
-	"Masaram_Gondi":          "gonm",
-	"Nushu":                  "nshu",
-	"Soyombo":                "soyo",
-	"Zanabazar_Square":       "zanb",
-	"Dogra":                  "dogr",
-	"Gunjala_Gondi":          "gong",
-	"Hanifi_Rohingya":        "rohg",
-	"Makasar":                "maka",
-	"Medefaidrin":            "medf",
-	"Old_Sogdian":            "sogo",
-	"Sogdian":                "sogd",
-	"Elymaic":                "elym",
-	"Nandinagari":            "nand",
-	"Nyiakeng_Puachue_Hmong": "hmnp",
-	"Wancho":                 "wcho",
-	"Chorasmian":             "chrs",
-	"Dives_Akuru":            "diak",
-	"Khitan_Small_Script":    "kits",
-	"Yezidi":                 "yezi",
+// ScriptTagForScript returns the preferred OpenType script tag for a given ISO 15924
+// script code. It will return the DFLT-tag for unknown or unsupported scripts.
+// See also ot.ScriptTagsForUnicodeScript.
+func ScriptTagForScript(script language.Script) ot.Tag {
+	if tags := ot.ScriptTagsForUnicodeScript(script.String()); len(tags) > 0 {
+		return tags[0]
+	}
+	return ot.DFLT
+}
+
+// scriptTagCandidates returns the script tags to look for in a font, in order:
+// tag, the tags for script (see ot.ScriptTagsForUnicodeScript), 'DFLT', 'dflt'
+// and 'latn'. The last ones are fallbacks recommended by the OpenType
+// specification and in common use by fonts.
+func scriptTagCandidates(tag ot.Tag, script language.Script) []ot.Tag {
+	var tags []ot.Tag
+	add := func(t ot.Tag) {
+		if t != 0 && !slices.Contains(tags, t) {
+			tags = append(tags, t)
+		}
+	}
+	add(tag)
+	for _, t := range ot.ScriptTagsForUnicodeScript(script.String()) {
+		add(t)
+	}
+	add(ot.DFLT)
+	add(ot.T("dflt"))
+	add(ot.T("latn"))
+	return tags
 }
 
-// We do support this list of languages.
-var supportedLanguages = map[language.Tag]string{
-	language.Arabic:     "ARA",
-	language.Chinese:    "ZHS",
-	language.English:    "ENG",
-	language.Greek:      "ELL",
-	language.German:     "DEU",
-	language.Hebrew:     "IWR",
-	language.Japanese:   "JAN",
-	language.Portuguese: "PTG",
-	language.Romanian:   "ROM",
-	language.Russian:    "RUS",
-	language.Turkish:    "TRK",
+// LanguageTagForLanguage returns the appropriate OpenType language tag for a given
+// BCP 47 language tag (see ot.LanguageTag).
+// If the base language of lang cannot be determined with confidence of at least `conf`,
+// the DFLT-tag will be returned.
+func LanguageTagForLanguage(lang language.Tag, conf language.Confidence) ot.Tag {
+	base, c := lang.Base()
+	if traceDebug() {
+		tracer().Debugf("OpenType language for %s: base %s (%s)", lang, base, c)
+	}
+	if c < conf { // if confidence level is not high enough
+		return ot.DFLT
+	}
+	return ot.LanguageTag(lang.String())
 }
 
-// We will try to match user-preferred language against supported languages.
-var supportedLanguagesMatcher language.Matcher
+// languageSystemFallbacks lists language system tags to look for in a font,
+// in order, for languages without a language system of their own or with
+// conventions fonts often implement for a related language only. Keys are
+// BCP 47 primary language subtags.
+//
+//   - Macedonian ('MKD ') shares the italic and cursive Cyrillic letter forms
+//     of Serbian, which many fonts implement for 'SRB ' only.
+//   - Montenegrin has no language system tag and follows Serbian conventions.
+//
+// Bulgarian ('BGR ') and Serbian ('SRB ') forms differ from each other and
+// from the Russian forms fonts use by default; they have no fallbacks.
+var languageSystemFallbacks = map[string][]ot.Tag{
+	"mk":  {ot.T("SRB")},
+	"cnr": {ot.T("SRB")},
+}
 
-func init() {
-	// prepare the language matcher with our list of supported languages
-	langs := make([]language.Tag, len(supportedLanguages))
-	i := 0
-	for l := range supportedLanguages {
-		langs[i] = l
-		i++
+// languageTagCandidates returns the language system tags to look for in a
+// font for lang, in order: the tag of LanguageTagForLanguage, followed by the
+// fallbacks of languageSystemFallbacks. For undetermined languages it returns
+// nil, which selects the default language systems of scripts.
+func languageTagCandidates(lang language.Tag, conf language.Confidence) []ot.Tag {
+	var tags []ot.Tag
+	if tag := LanguageTagForLanguage(lang, conf); tag != ot.DFLT {
+		tags = append(tags, tag)
+	}
+	base, c := lang.Base()
+	if c < conf {
+		return tags
 	}
-	supportedLanguagesMatcher = language.NewMatcher(langs)
+	for _, tag := range languageSystemFallbacks[base.String()] {
+		if !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
 }
 
-// ScriptTagForScript returns the appropriate OpenType script tag for a given ISO 15924
-// script code. It will return the DFLT-tag for unknown or unsupported scripts.
-func ScriptTagForScript(script language.Script) ot.Tag {
-	s := script.String()
-	if otScr, ok := script2opentype[s]; ok {
-		return ot.T(otScr)
+// LangSys identifies a language system of a layout table by its script and
+// language tags. Language tag 'dflt' denotes the default language system of
+// the script.
+type LangSys struct {
+	Script   ot.Tag
+	Language ot.Tag
+}
+
+func (ls LangSys) String() string {
+	return ls.Script.String() + "/" + ls.Language.String()
+}
+
+// langSysChain returns the language systems to look for in a font, in order:
+// for every script tag, the language tags, then the default language system.
+// The first language system present in a font is selected for shaping, i.e.,
+// the default language system of a script is preferred to the language
+// systems of the script tags following it, such as 'DFLT'.
+func langSysChain(scriptTags, langTags []ot.Tag) []LangSys {
+	chain := make([]LangSys, 0, len(scriptTags)*(len(langTags)+1))
+	for _, script := range scriptTags {
+		for _, lang := range langTags {
+			chain = append(chain, LangSys{Script: script, Language: lang})
+		}
+		chain = append(chain, LangSys{Script: script, Language: ot.T("dflt")})
 	}
-	return ot.DFLT
+	return chain
 }
 
-// LanguageTagForLanguage returns the appropriate OpenType language tag for a given
-// BCP 47 language tag.
-// If there is no supported language, that can be matched with confidence of at least `conf`,
-// the DFLT-tag will be returned.
-func LanguageTagForLanguage(lang language.Tag, conf language.Confidence) ot.Tag {
-	l, _, c := supportedLanguagesMatcher.Match(lang)
-	tracer().Debugf("OpenType language matched %s (%s) : %s", display.English.Tags().Name(l),
-		display.Self.Name(l), c)
-	if c < conf { // if matcher's confidence level is not high enough
-		return ot.DFLT
+// LangSysChain returns the language systems looked for in table of
+// params.Font when shaping text with params, in order of preference, and the
+// index of the one selected for shaping, or -1 if the font supports none of
+// them. Script and language tags are derived from params.Script (see
+// ScriptTagForScript) and params.Language (see LanguageTagForLanguage, with
+// fallbacks for related languages); language tag 'dflt' denotes the default
+// language system of a script.
+//
+// LangSysChain is intended for debugging the selection of localized forms.
+func LangSysChain(params Params, table LayoutTable) (chain []LangSys, selected int) {
+	scriptTags := scriptTagCandidates(ScriptTagForScript(params.Script), params.Script)
+	chain = langSysChain(scriptTags, languageTagCandidates(params.Language, language.Low))
+	t := planGSUB
+	if table == LayoutGPOS {
+		t = planGPOS
 	}
-	base, _ := language.Compose(l.Base()) // re-package l to cleanly match base language constant
-	if ltag, ok := supportedLanguages[base]; ok {
-		return ot.T(ltag)
+	lyt := layoutTableOf(params.Font, t)
+	if lyt == nil {
+		return chain, -1
 	}
-	return ot.DFLT
+	_, selected = selectLangSys(lyt, chain)
+	return chain, selected
+}
+
+// selectLangSys returns the first language system of chain present in lyt and
+// its index in chain, or nil and -1.
+func selectLangSys(lyt *ot.LayoutTable, chain []LangSys) (*ot.LangSys, int) {
+	sg := lyt.ScriptGraph()
+	if sg == nil {
+		return nil, -1
+	}
+	for i, ls := range chain {
+		scr := sg.Script(ls.Script)
+		if scr == nil {
+			continue
+		}
+		var lsys *ot.LangSys
+		if ls.Language == ot.T("dflt") {
+			lsys = scr.DefaultLangSys()
+		} else {
+			lsys = scr.LangSys(ls.Language)
+		}
+		if lsys != nil {
+			return lsys, i
+		}
+	}
+	return nil, -1
 }
 
 // For some script/language combinations the Unicde de-composed (NFD) is the preferred
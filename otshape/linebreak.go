@@ -0,0 +1,343 @@
+package otshape
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// LineBreak describes a break of a shaped run into two lines, see [Shaper.Break].
+type LineBreak struct {
+	Index  int    // rune index of the first rune of the second line
+	Hyphen []rune // text appended to the first line, e.g. a hyphen; may be nil
+}
+
+// Break breaks a run into two lines at lb.Index, given the text of the run
+// and glyphs, the result of shaping text with params. Other than shaping the
+// two lines from scratch, Break re-shapes only a window of text around the
+// break and takes the glyphs outside of the window from glyphs. This is a
+// building block for line breakers, which try many break positions for every
+// paragraph.
+//
+// The window is derived from the extent of the lookups of the font, i.e., the
+// number of glyphs a lookup looks at, and is widened to boundaries of clusters
+// which are safe to break (see [GlyphRecord].UnsafeFlags). If the re-shaped
+// glyphs at the edges of the window differ from glyphs, the window is widened
+// until they agree, up to re-shaping both lines completely.
+//
+// Glyphs of both lines keep clusters which are rune indices into text; glyphs
+// of lb.Hyphen get clusters from lb.Index on. glyphs is not modified.
+func (s *Shaper) Break(params Params, text []rune, glyphs []GlyphRecord, lb LineBreak, bufOpts BufferOptions) (
+	first, second []GlyphRecord, err error) {
+	//
+	brk := lb.Index
+	if brk <= 0 || brk >= len(text) {
+		return nil, nil, fmt.Errorf("otshape: break index %d out of range for text of length %d", brk, len(text))
+	}
+	extent, err := s.contextExtent(params)
+	if err != nil {
+		return nil, nil, err
+	}
+	cuts := newClusterCuts(glyphs)
+	g0 := cuts.breakGlyph(brk)
+	for e := max(1, extent); ; e *= 2 {
+		gstart, gend := cuts.window(g0, e)
+		start, end := cuts.runeAt(gstart, len(text)), cuts.runeAt(gend, len(text))
+		start, end = min(start, brk), max(end, brk)
+		full := gstart == 0 && gend == len(glyphs)
+		//
+		// first line: re-shape text[start:brk] with e runes of preceding context
+		from := max(0, start-e)
+		head := slices.Concat(text[from:brk], lb.Hyphen)
+		left, err := s.shapeFragment(params, head, from, bufOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		k, ok := clusterCut(left, uint32(start))
+		if ok && start > 0 {
+			ok = sameCluster(left[k:], glyphs[gstart:])
+		}
+		// second line: re-shape text[brk:end] with e runes of following context
+		to := min(len(text), end+e)
+		right, err := s.shapeFragment(params, text[brk:to], brk, bufOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+		m, okRight := clusterCut(right, uint32(end))
+		if okRight && end < len(text) {
+			okRight = sameClusterReverse(right[:m], glyphs[:gend])
+		}
+		if (!ok || !okRight) && !full {
+			continue
+		}
+		first = slices.Concat(glyphs[:gstart], rebaseAttachments(left[k:], gstart-k, gstart))
+		second = slices.Concat(rebaseAttachments(right[:m], 0, 0), rebaseAttachments(glyphs[gend:], m-gend, m))
+		return first, second, nil
+	}
+}
+
+// BreakWindow returns the range [start:end) of runes of text which Break
+// re-shapes at least when breaking a run at rune index brk, given glyphs, the
+// result of shaping text with params.
+func (s *Shaper) BreakWindow(params Params, glyphs []GlyphRecord, brk int) (start, end int, err error) {
+	extent, err := s.contextExtent(params)
+	if err != nil {
+		return 0, 0, err
+	}
+	n := 0
+	for _, g := range glyphs {
+		n = max(n, int(g.Cluster)+1)
+	}
+	cuts := newClusterCuts(glyphs)
+	gstart, gend := cuts.window(cuts.breakGlyph(brk), max(1, extent))
+	start, end = cuts.runeAt(gstart, n), cuts.runeAt(gend, n)
+	return min(start, brk), max(end, brk), nil
+}
+
+// shapeFragment shapes text, whose first rune has index offset within the text
+// of the run, and returns the glyphs with clusters relative to the run.
+func (s *Shaper) shapeFragment(params Params, text []rune, offset int, bufOpts BufferOptions) ([]GlyphRecord, error) {
+	sink := &glyphSliceSink{}
+	if err := s.Shape(params, NewRuneSliceSource(text), sink, bufOpts); err != nil {
+		return nil, err
+	}
+	for i := range sink.glyphs {
+		g := &sink.glyphs[i]
+		g.Cluster += uint32(offset)
+		for j := range g.Components {
+			g.Components[j] += uint32(offset)
+		}
+	}
+	return sink.glyphs, nil
+}
+
+// contextExtent returns the extent of the lookups of the plan for params, see
+// plan.contextExtent.
+func (s *Shaper) contextExtent(params Params) (int, error) {
+	if params.Font == nil {
+		return 0, ErrNilFont
+	}
+	cfg, err := resolveStreamingConfig(BufferOptions{})
+	if err != nil {
+		return 0, err
+	}
+	sess, err := s.acquireSession(params, cfg, false)
+	if err != nil {
+		return 0, err
+	}
+	if sess.extent < 0 {
+		sess.extent = sess.plan.contextExtent()
+	}
+	extent := sess.extent
+	s.releaseSession(sess)
+	return extent, nil
+}
+
+// clusterCut returns the index of the first glyph with a cluster of at least
+// cluster. ok is false if glyphs do not split into glyphs of clusters below
+// and glyphs of clusters from cluster on at this index, e.g. after reordering.
+func clusterCut(glyphs []GlyphRecord, cluster uint32) (k int, ok bool) {
+	k = slices.IndexFunc(glyphs, func(g GlyphRecord) bool { return g.Cluster >= cluster })
+	if k < 0 {
+		return len(glyphs), true
+	}
+	for _, g := range glyphs[k:] {
+		if g.Cluster < cluster {
+			return k, false
+		}
+	}
+	return k, true
+}
+
+// sameCluster reports whether the glyphs of the first cluster of a and b are
+// equal in glyph and positioning.
+func sameCluster(a, b []GlyphRecord) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	for i := range a {
+		if a[i].Cluster != a[0].Cluster {
+			break
+		}
+		if i >= len(b) || !sameGlyph(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameClusterReverse reports whether the glyphs of the last cluster of a and b
+// are equal in glyph and positioning.
+func sameClusterReverse(a, b []GlyphRecord) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return len(a) == len(b)
+	}
+	for i, j := len(a)-1, len(b)-1; i >= 0; i, j = i-1, j-1 {
+		if a[i].Cluster != a[len(a)-1].Cluster {
+			break
+		}
+		if j < 0 || !sameGlyph(a[i], b[j]) {
+			return false
+		}
+	}
+	return true
+}
+
+// sameGlyph compares glyph records, except for attachment indices, which are
+// relative to different runs.
+func sameGlyph(a, b GlyphRecord) bool {
+	return a.GID == b.GID && a.Cluster == b.Cluster &&
+		a.Pos.XAdvance == b.Pos.XAdvance && a.Pos.YAdvance == b.Pos.YAdvance &&
+		a.Pos.XOffset == b.Pos.XOffset && a.Pos.YOffset == b.Pos.YOffset &&
+		a.Pos.AttachKind == b.Pos.AttachKind && a.Pos.Scale == b.Pos.Scale &&
+		a.Pos.Embolden == b.Pos.Embolden && a.Pos.Shear == b.Pos.Shear
+}
+
+// rebaseAttachments returns a copy of glyphs, to be placed at index at of a
+// line, with attachment indices shifted by shift. Attachments to glyphs
+// outside of the line are dropped.
+func rebaseAttachments(glyphs []GlyphRecord, shift, at int) []GlyphRecord {
+	out := slices.Clone(glyphs)
+	for i := range out {
+		pos := &out[i].Pos
+		if pos.AttachTo < 0 {
+			continue
+		}
+		if pos.AttachTo += int32(shift); pos.AttachTo < 0 || int(pos.AttachTo) >= at+len(out) {
+			pos.AttachTo = -1
+		}
+	}
+	return out
+}
+
+// clusterCuts tells where a shaped run may be cut into two sequences of glyphs
+// without splitting clusters or unsafe spans.
+type clusterCuts struct {
+	glyphs []GlyphRecord
+	maxPre []uint32 // maxPre[i] is the maximum cluster of glyphs[:i+1]
+	minSuf []uint32 // minSuf[i] is the minimum cluster of glyphs[i:]
+}
+
+func newClusterCuts(glyphs []GlyphRecord) clusterCuts {
+	n := len(glyphs)
+	cc := clusterCuts{glyphs: glyphs, maxPre: make([]uint32, n), minSuf: make([]uint32, n)}
+	for i, g := range glyphs {
+		cc.maxPre[i] = g.Cluster
+		if i > 0 {
+			cc.maxPre[i] = max(cc.maxPre[i], cc.maxPre[i-1])
+		}
+	}
+	for i := n - 1; i >= 0; i-- {
+		cc.minSuf[i] = glyphs[i].Cluster
+		if i < n-1 {
+			cc.minSuf[i] = min(cc.minSuf[i], cc.minSuf[i+1])
+		}
+	}
+	return cc
+}
+
+// safe reports whether the run may be cut before glyph i.
+func (cc clusterCuts) safe(i int) bool {
+	if i <= 0 || i >= len(cc.glyphs) {
+		return true
+	}
+	if cc.maxPre[i-1] >= cc.minSuf[i] {
+		return false
+	}
+	left := cc.glyphs[i-1].UnsafeFlags & unsafeCutMask
+	right := cc.glyphs[i].UnsafeFlags & unsafeCutMask
+	return left == 0 || right == 0
+}
+
+// breakGlyph returns the index of the first glyph of clusters from brk on.
+func (cc clusterCuts) breakGlyph(brk int) int {
+	for i := range cc.glyphs {
+		if cc.minSuf[i] >= uint32(brk) {
+			return i
+		}
+	}
+	return len(cc.glyphs)
+}
+
+// window returns the glyphs [gstart:gend) around glyph g0, widened by extent
+// glyphs to either side and to safe cuts.
+func (cc clusterCuts) window(g0, extent int) (gstart, gend int) {
+	gstart, gend = max(0, g0-extent), min(len(cc.glyphs), g0+extent)
+	for !cc.safe(gstart) {
+		gstart--
+	}
+	for !cc.safe(gend) {
+		gend++
+	}
+	return gstart, gend
+}
+
+// runeAt returns the first rune of the glyphs from index i on, or n at the end
+// of the run.
+func (cc clusterCuts) runeAt(i, n int) int {
+	if i >= len(cc.glyphs) {
+		return n
+	}
+	return int(cc.minSuf[i])
+}
+
+// --- Lookup extents ----------------------------------------------------------
+
+// contextExtent returns the largest number of glyphs, minus one, which a
+// lookup of p looks at when applied to a glyph, i.e., the distance up to which
+// glyphs may influence each other within a single lookup. Lookups skipping
+// glyphs, e.g. marks, may reach further.
+func (p *plan) contextExtent() int {
+	extent := 0
+	if gsub := p.font.GSub(); gsub != nil {
+		for _, op := range p.GSUB.Lookups {
+			extent = max(extent, lookupExtent(gsub.LookupGraph().Lookup(int(op.LookupIndex))))
+		}
+	}
+	if gpos := p.font.GPos(); gpos != nil {
+		for _, op := range p.GPOS.Lookups {
+			extent = max(extent, lookupExtent(gpos.LookupGraph().Lookup(int(op.LookupIndex))))
+		}
+	}
+	return extent
+}
+
+// lookupExtent returns the extent of a single lookup, see plan.contextExtent.
+func lookupExtent(lookup *ot.LookupTable) int {
+	extent := 0
+	for _, node := range lookup.Range() {
+		if node == nil {
+			continue
+		}
+		if sc, ok := node.SequenceContext(); ok {
+			for _, r := range sc.Rules {
+				n := max(len(r.Backtrack), len(r.BacktrackClasses), len(r.BacktrackCoverages)) +
+					max(len(r.Input), len(r.InputClasses), len(r.InputCoverages)) +
+					max(len(r.Lookahead), len(r.LookaheadClasses), len(r.LookaheadCoverages))
+				extent = max(extent, n-1)
+			}
+			continue
+		}
+		if p := node.GSubPayload(); p != nil {
+			switch {
+			case p.LigatureFmt1 != nil:
+				for _, set := range p.LigatureFmt1.LigatureSets {
+					for _, lig := range set {
+						extent = max(extent, len(lig.Components))
+					}
+				}
+			case p.ReverseChainingFmt1 != nil:
+				q := p.ReverseChainingFmt1
+				extent = max(extent, len(q.BacktrackCoverages)+len(q.LookaheadCoverages))
+			}
+			continue
+		}
+		switch ot.GPosLookupType(node.LookupType) {
+		case ot.GPosLookupTypePair, ot.GPosLookupTypeCursive, ot.GPosLookupTypeMarkToBase,
+			ot.GPosLookupTypeMarkToLigature, ot.GPosLookupTypeMarkToMark:
+			extent = max(extent, 1)
+		}
+	}
+	return extent
+}
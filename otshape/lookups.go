@@ -1,6 +1,8 @@
 package otshape
 
 import (
+	"fmt"
+
 	"github.com/npillmayer/opentype/ot"
 	"github.com/npillmayer/opentype/otlayout"
 )
@@ -9,6 +11,8 @@ type planLookupFeature struct {
 	tag       ot.Tag
 	typ       otlayout.LayoutTagType
 	lookupInx int
+	lookups   *ot.LookupListGraph // lookup list of the feature's layout table
+	value     maskSpec            // mask bits holding the feature value of glyphs
 }
 
 func (f planLookupFeature) Tag() ot.Tag {
@@ -30,17 +34,30 @@ func (f planLookupFeature) LookupIndex(i int) int {
 	return f.lookupInx
 }
 
+func (f planLookupFeature) AlternateCount(g ot.GlyphIndex) int {
+	return otlayout.AlternateCount(f.lookups, f, g)
+}
+
+// alternate returns the alternate index for the glyph at position inx of the
+// run. Following OpenType conventions for features like 'cvXX' and 'salt', a
+// feature value n > 1 selects the n-th alternate; otherwise alt is returned.
+// A negative alt, i.e. a random alternate, is kept as well.
+func (e *planExecutor) alternate(alt, inx int) int {
+	spec := e.feat.value
+	if alt < 0 || spec.Mask == 0 || inx < 0 || inx >= len(e.run.Masks) {
+		return alt
+	}
+	if v := (e.run.Masks[inx] & spec.Mask) >> spec.Shift; v > 1 {
+		return int(v) - 1
+	}
+	return alt
+}
+
 func (e *planExecutor) ensureRunMasks(pl *plan) {
 	assert(e != nil, "executor is nil")
 	assert(e.run != nil, "run buffer is nil")
 	assert(pl != nil, "plan is nil")
-	n := e.run.Len()
-	if e.run.Masks == nil {
-		e.run.Masks = make([]uint32, n)
-	}
-	if len(e.run.Masks) != n {
-		e.run.Masks = resizeUint32(e.run.Masks, n)
-	}
+	e.run.EnsureMasks()
 	for i := range e.run.Masks {
 		e.run.Masks[i] = pl.Masks.GlobalMask
 	}
@@ -69,7 +86,13 @@ func (e *planExecutor) realignSideArrays(pl *plan, st *otlayout.BufferState) {
 	if e.run.Joiners != nil && len(e.run.Joiners) != e.run.Len() {
 		e.run.Joiners = resizeUint8(e.run.Joiners, e.run.Len())
 	}
-	e.ensureRunMasks(pl)
+	if e.run.Components != nil && len(e.run.Components) != e.run.Len() {
+		e.run.Components = resizeComponents(e.run.Components, e.run.Len())
+	}
+	if len(e.run.Masks) != e.run.Len() {
+		// masks mirrored from edits are kept, as they carry engine masks
+		e.ensureRunMasks(pl)
+	}
 }
 
 func (e *planExecutor) applyLookups(pl *plan, table planTable, lookups []lookupOp) error {
@@ -82,20 +105,45 @@ func (e *planExecutor) applyLookups(pl *plan, table planTable, lookups []lookupO
 	}
 
 	fType := otlayout.GSubFeatureType
+	var graph *ot.LookupListGraph
 	if table == planGPOS {
 		fType = otlayout.GPosFeatureType
+		if gpos := pl.font.GPos(); gpos != nil {
+			graph = gpos.LookupGraph()
+		}
+	} else if gsub := pl.font.GSub(); gsub != nil {
+		graph = gsub.LookupGraph()
 	}
 
-	st := otlayout.NewBufferState(e.run.Glyphs, e.run.Pos)
+	st := &e.state
+	e.edits = e.edits[:0]
+	*st = otlayout.BufferState{Glyphs: e.run.Glyphs, Pos: e.run.Pos, Budget: e.lookupBudget(), Log: &e.edits}
+	if st.Pos != nil && len(st.Pos) != len(st.Glyphs) {
+		st.Pos = st.Pos.ResizeLike(st.Glyphs)
+	}
 	for _, op := range lookups {
+		// feat points into the executor, so passing it as an interface does not allocate
+		value, _ := pl.maskForFeature(op.FeatureTag)
+		e.feat = planLookupFeature{
+			tag:       op.FeatureTag,
+			typ:       fType,
+			lookupInx: int(op.LookupIndex),
+			lookups:   graph,
+			value:     value,
+		}
+		feat := &e.feat
+		if !otlayout.FeatureMayApply(pl.font, feat, st.Glyphs) {
+			continue
+		}
 		alt := 0
 		if op.Flags.has(lookupRandom) {
 			alt = -1
 		}
-		feat := planLookupFeature{
-			tag:       op.FeatureTag,
-			typ:       fType,
-			lookupInx: int(op.LookupIndex),
+		if table == planGSUB && isReverseLookup(pl.font, op) {
+			if err := e.applyLookupReverse(pl, op, feat, st); err != nil {
+				return err
+			}
+			continue
 		}
 		if op.Flags.has(lookupPerSyllable) && table == planGSUB {
 			if err := e.applyLookupPerSyllable(pl, op, feat, st, alt); err != nil {
@@ -116,7 +164,7 @@ func (e *planExecutor) applyLookups(pl *plan, table planTable, lookups []lookupO
 func (e *planExecutor) applyLookupPerSyllable(
 	pl *plan,
 	op lookupOp,
-	feat planLookupFeature,
+	feat *planLookupFeature,
 	st *otlayout.BufferState,
 	alt int,
 ) error {
@@ -137,7 +185,7 @@ func (e *planExecutor) applyLookupPerSyllable(
 func (e *planExecutor) applyLookupIsolatedSpan(
 	pl *plan,
 	op lookupOp,
-	feat planLookupFeature,
+	feat *planLookupFeature,
 	st *otlayout.BufferState,
 	alt int,
 	start int,
@@ -158,6 +206,8 @@ func (e *planExecutor) applyLookupIsolatedSpan(
 		subPos = append(otlayout.PosBuffer(nil), st.Pos[start:end]...)
 	}
 	sub := otlayout.NewBufferState(subGlyphs, subPos)
+	sub.Budget = st.Budget
+	sub.Log = st.Log
 	if _, err := e.applyLookupSpan(pl, op, feat, sub, alt, 0, sub.Len(), start); err != nil {
 		return start, err
 	}
@@ -179,7 +229,7 @@ func (e *planExecutor) applyLookupIsolatedSpan(
 func (e *planExecutor) applyLookupSpan(
 	pl *plan,
 	op lookupOp,
-	feat planLookupFeature,
+	feat *planLookupFeature,
 	st *otlayout.BufferState,
 	alt int,
 	start int,
@@ -197,13 +247,23 @@ func (e *planExecutor) applyLookupSpan(
 	}
 	st.Index = start
 	for st.Index < end && st.Index < st.Len() {
+		if err := e.stepCancelled(); err != nil {
+			return end, err
+		}
 		if !e.lookupIndexEnabled(pl, op, st, st.Index, indexBase) {
 			st.Index++
 			continue
 		}
 		prevIndex := st.Index
 		prevLen := st.Len()
-		_, applied := otlayout.ApplyFeature(pl.font, feat, st, alt)
+		_, applied := otlayout.ApplyFeature(pl.font, feat, st, e.alternate(alt, indexBase+st.Index))
+		if st.Err != nil {
+			return end, fmt.Errorf("%w: %w", ErrInternal, st.Err)
+		}
+		if err := e.budgetExceeded(); err != nil {
+			return end, err
+		}
+		e.mirrorEdits(indexBase)
 		if !applied && st.Index == prevIndex {
 			st.Index++
 			continue
@@ -213,11 +273,17 @@ func (e *planExecutor) applyLookupSpan(
 		}
 		if st.Len() != prevLen {
 			delta := st.Len() - prevLen
+			if err := e.grown(delta); err != nil {
+				return end, err
+			}
 			end += delta
 			if end < st.Index {
 				end = st.Index
 			}
-			e.realignSideArrays(pl, st)
+			if st == &e.state {
+				// side arrays of isolated spans are realigned by the caller
+				e.realignSideArrays(pl, st)
+			}
 			if end > st.Len() {
 				end = st.Len()
 			}
@@ -226,6 +292,58 @@ func (e *planExecutor) applyLookupSpan(
 	return end, nil
 }
 
+// mirrorEdits mirrors the edits logged while applying a lookup onto the side
+// arrays of the run, which keeps clusters and ligature components aligned with
+// the glyphs. Positions of logged edits are relative to indexBase.
+func (e *planExecutor) mirrorEdits(indexBase int) {
+	for _, edit := range e.edits {
+		edit.From += indexBase
+		edit.To += indexBase
+		e.run.MirrorEdit(edit, e.components)
+	}
+	e.edits = e.edits[:0]
+}
+
+// applyLookupReverse applies a GSUB reverse chaining lookup in a pass of its
+// own, from the end of the run to its start, as required by the OpenType spec.
+// Substitutions made during the pass are visible as lookahead context for the
+// glyphs before them. The pass covers the whole run: reverse chaining lookups
+// replace single glyphs, so there are no syllable boundaries to protect.
+func (e *planExecutor) applyLookupReverse(
+	pl *plan,
+	op lookupOp,
+	feat *planLookupFeature,
+	st *otlayout.BufferState,
+) error {
+	for i := st.Len() - 1; i >= 0; i-- {
+		if err := e.stepCancelled(); err != nil {
+			return err
+		}
+		if !e.lookupIndexEnabled(pl, op, st, i, 0) {
+			continue
+		}
+		st.Index = i
+		otlayout.ApplyFeatureReverse(pl.font, feat, st)
+		if st.Err != nil {
+			return fmt.Errorf("%w: %w", ErrInternal, st.Err)
+		}
+		if err := e.budgetExceeded(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isReverseLookup reports whether the GSUB lookup of op is a reverse chaining
+// lookup (GSUB type 8).
+func isReverseLookup(font *ot.Font, op lookupOp) bool {
+	gsub := font.GSub()
+	if gsub == nil {
+		return false
+	}
+	return gsub.LookupGraph().Lookup(int(op.LookupIndex)).IsReverse()
+}
+
 func (e *planExecutor) lookupIndexEnabled(pl *plan, op lookupOp, st *otlayout.BufferState, inx int, indexBase int) bool {
 	if inx < 0 || inx >= st.Len() {
 		return false
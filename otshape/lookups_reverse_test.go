@@ -0,0 +1,66 @@
+package otshape
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// nastaliqStyleFont builds a font with a cascading reverse chaining lookup, as
+// used by Nastaliq fonts to raise a sequence of connected letters step by step
+// from its end: 'b' is replaced by its raised form 'B' if followed by 'n' or by
+// an already raised 'B'.
+func nastaliqStyleFont(t *testing.T, extension bool) *ot.Font {
+	t.Helper()
+	b := testfont.New(5)
+	b.Map('b', 1).Map('n', 2).Map('x', 4)
+	gsub := b.GSUB()
+	sub := testfont.ReverseChainSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 3}, nil, [][]ot.GlyphIndex{{2, 3}})
+	typ := ot.GSubLookupTypeReverseChaining
+	if extension {
+		sub = testfont.Extension(typ, sub)
+		typ = ot.GSubLookupTypeExtensionSubs
+	}
+	gsub.Feature("calt", gsub.Lookup(typ, 0, sub))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func TestShapeReverseChainingCascade(t *testing.T) {
+	for _, c := range []struct {
+		text      string
+		extension bool
+		features  []FeatureRange
+		want      []ot.GlyphIndex
+	}{
+		{text: "bbbn", want: []ot.GlyphIndex{3, 3, 3, 2}},
+		{text: "bbbn", extension: true, want: []ot.GlyphIndex{3, 3, 3, 2}},
+		{text: "bbxbn", want: []ot.GlyphIndex{1, 1, 4, 3, 2}},
+		{text: "bbb", want: []ot.GlyphIndex{1, 1, 1}},
+		{ // the masked glyph breaks the cascade
+			text:     "bbbn",
+			features: []FeatureRange{{Feature: ot.T("calt"), On: false, Start: 2, End: 3}},
+			want:     []ot.GlyphIndex{1, 1, 1, 2},
+		},
+	} {
+		params := standardParams(nastaliqStyleFont(t, c.extension))
+		params.Features = c.features
+		sink := &collectSink{}
+		if err := NewShaper(plainShaper{}).Shape(params, StringSource(c.text), sink, BufferOptions{}); err != nil {
+			t.Fatalf("%q: shaping failed: %v", c.text, err)
+		}
+		var glyphs []ot.GlyphIndex
+		for _, g := range sink.glyphs {
+			glyphs = append(glyphs, g.GID)
+		}
+		if !slices.Equal(glyphs, c.want) {
+			t.Errorf("%q (extension=%v, features=%v): expected glyphs %v, have %v",
+				c.text, c.extension, c.features, c.want, glyphs)
+		}
+	}
+}
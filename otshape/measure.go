@@ -0,0 +1,207 @@
+package otshape
+
+import (
+	"context"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+)
+
+// MeasureOptions configures [Measure].
+type MeasureOptions struct {
+	Params                      // font, segment metadata and features of the text
+	Shaper    *Shaper           // shaper for the text; its cached plan and buffers are re-used between calls
+	WidthOnly bool              // only the width is needed; ascent and descent are reported as 0
+	SmallCaps SmallCapsFallback // small-caps synthesis, as for shaping with BufferOptions.SmallCaps
+	Style     SyntheticStyle    // synthetic bold and oblique, as for shaping with BufferOptions.Style
+}
+
+// Measure shapes text and returns its advance width and its extent above
+// (ascent) and below (descent) the baseline, in font units. Ascent and descent
+// are taken from the bounding boxes of the glyphs in table 'glyf', shifted by
+// their GPOS offsets and adjusted for synthesized glyphs (see
+// [SmallCapsFallback] and [SyntheticStyle]); descent is positive for glyphs
+// reaching below the baseline. Glyphs without an outline bounding box do not
+// contribute.
+//
+// Measure aggregates the metrics while shaping, without materializing glyph
+// records. It is intended for line breaking and other measurement-heavy tasks,
+// which shape many short strings with identical options; they should use one
+// Shaper for measurement only, as Shaper caches a single plan.
+//
+// If opts.WidthOnly is set, Measure skips lookups which cannot change advances:
+// GSUB single and alternate substitutions whose substitutes have the same
+// advance and glyph class as the glyphs they replace, and GPOS mark
+// attachments. This assumes that later lookups treat substitutes like the
+// glyphs they replace, which holds for most fonts, but not for fonts kerning
+// a glyph differently from its same-width variants.
+//
+// Measure returns zero metrics if opts.Shaper or opts.Font is nil, or if
+// shaping fails.
+func Measure(text string, opts MeasureOptions) (width, ascent, descent int) {
+	if opts.Shaper == nil || opts.Font == nil {
+		return 0, 0, 0
+	}
+	m := measurement{font: opts.Font, widthOnly: opts.WidthOnly}
+	err := opts.Shaper.shapeStream(context.Background(), opts.Params, StringSource(text), BufferOptions{SmallCaps: opts.SmallCaps, Style: opts.Style},
+		opts.WidthOnly, m.add)
+	if err != nil {
+		tracer().Errorf("cannot measure text: %v", err)
+		return 0, 0, 0
+	}
+	return m.width, m.ascent, m.descent
+}
+
+// measurement aggregates the metrics of shaped glyphs for Measure.
+type measurement struct {
+	font                   *ot.Font
+	widthOnly              bool
+	width, ascent, descent int
+}
+
+// add aggregates the metrics of the first end glyphs of run.
+func (m *measurement) add(run *runBuffer, end int) error {
+	hasPos := len(run.Pos) == run.Len()
+	for i, gid := range run.Glyphs[:end] {
+		metrics := otquery.GlyphMetrics(m.font, gid)
+		var pos, stroke int
+		scale := float32(1)
+		if hasPos {
+			m.width += int(run.Pos[i].XAdvance)
+			pos = int(run.Pos[i].YOffset)
+			if run.Pos[i].Scale != 0 {
+				scale = run.Pos[i].Scale
+			}
+			stroke = int(run.Pos[i].Embolden) / 2
+		}
+		m.width += int(metrics.Advance)
+		if m.widthOnly || metrics.BBox.IsEmpty() {
+			continue
+		}
+		maxY := ot.Round(float64(scale) * float64(metrics.BBox.MaxY))
+		minY := ot.Round(float64(scale) * float64(metrics.BBox.MinY))
+		m.ascent = max(m.ascent, maxY+pos+stroke)
+		m.descent = max(m.descent, -minY-pos+stroke)
+	}
+	return nil
+}
+
+// --- Width-only plans -------------------------------------------------------
+
+// widthOnly returns a copy of p without the lookups which cannot change the
+// advances of glyphs, see Measure. Stages are kept, as pause hooks of shaping
+// engines may rely on them.
+func (p *plan) widthOnly() *plan {
+	q := *p
+	q.GSUB = p.GSUB.without(func(op lookupOp) bool { return p.keepsAdvances(planGSUB, op) })
+	q.GPOS = p.GPOS.without(func(op lookupOp) bool { return p.keepsAdvances(planGPOS, op) })
+	return &q
+}
+
+// without returns a copy of tp without the lookups for which skip is true.
+func (tp tableProgram) without(skip func(lookupOp) bool) tableProgram {
+	out := tp
+	out.Lookups = make([]lookupOp, 0, len(tp.Lookups))
+	out.Stages = make([]stage, len(tp.Stages))
+	for i, st := range tp.Stages {
+		out.Stages[i] = stage{FirstLookup: len(out.Lookups), Pause: st.Pause}
+		for _, op := range tp.Lookups[st.FirstLookup:st.LastLookup] {
+			if !skip(op) {
+				out.Lookups = append(out.Lookups, op)
+			}
+		}
+		out.Stages[i].LastLookup = len(out.Lookups)
+	}
+	return out
+}
+
+// keepsAdvances reports whether applying lookup op of table cannot change the
+// advance of any glyph. GPOS mark attachments are kept if marks are zeroed, as
+// zeroing recognizes marks by their attachment.
+func (p *plan) keepsAdvances(table planTable, op lookupOp) bool {
+	if table == planGPOS {
+		gpos := p.font.GPos()
+		if gpos == nil || p.Policy.ZeroMarks {
+			return false
+		}
+		lookup := gpos.LookupGraph().Lookup(int(op.LookupIndex))
+		if lookup == nil || lookup.Error() != nil {
+			return false
+		}
+		for _, node := range lookup.Range() {
+			if node == nil || node.Error() != nil {
+				return false
+			}
+			switch ot.GPosLookupType(node.LookupType) {
+			case ot.GPosLookupTypeMarkToBase, ot.GPosLookupTypeMarkToLigature, ot.GPosLookupTypeMarkToMark:
+			default:
+				return false
+			}
+		}
+		return true
+	}
+	gsub := p.font.GSub()
+	if gsub == nil {
+		return false
+	}
+	lookup := gsub.LookupGraph().Lookup(int(op.LookupIndex))
+	if lookup == nil || lookup.Error() != nil {
+		return false
+	}
+	for _, node := range lookup.Range() {
+		if node == nil || node.Error() != nil || !p.substitutesKeepAdvances(node) {
+			return false
+		}
+	}
+	return true
+}
+
+// substitutesKeepAdvances reports whether node is a single or alternate
+// substitution whose substitutes have the advances and glyph classes of the
+// glyphs they replace.
+func (p *plan) substitutesKeepAdvances(node *ot.LookupNode) bool {
+	payload := node.GSubPayload()
+	typ := ot.GSubLookupType(node.LookupType)
+	if payload == nil || (typ != ot.GSubLookupTypeSingle && typ != ot.GSubLookupTypeAlternate) {
+		return false
+	}
+	hmtx, gdef := p.font.HorizontalMetrics(), p.font.GDef()
+	if hmtx == nil {
+		return false
+	}
+	same := func(g, subst ot.GlyphIndex) bool {
+		if hmtx.Advance(g) != hmtx.Advance(subst) {
+			return false
+		}
+		return gdef == nil || gdef.GlyphClassDef.Lookup(g) == gdef.GlyphClassDef.Lookup(subst)
+	}
+	inx := 0
+	for g := range node.Coverage.Glyphs() {
+		switch typ {
+		case ot.GSubLookupTypeSingle:
+			switch {
+			case payload.SingleFmt1 != nil:
+				if !same(g, ot.GlyphIndex(int(g)+int(payload.SingleFmt1.DeltaGlyphID))) {
+					return false
+				}
+			case payload.SingleFmt2 != nil && inx < len(payload.SingleFmt2.SubstituteGlyphIDs):
+				if !same(g, payload.SingleFmt2.SubstituteGlyphIDs[inx]) {
+					return false
+				}
+			default:
+				return false
+			}
+		case ot.GSubLookupTypeAlternate:
+			if payload.AlternateFmt1 == nil || inx >= len(payload.AlternateFmt1.Alternates) {
+				return false
+			}
+			for _, alt := range payload.AlternateFmt1.Alternates[inx] {
+				if !same(g, alt) {
+					return false
+				}
+			}
+		}
+		inx++
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+package otshape
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// measureFont builds a font with glyphs a (1), a.alt (2), b (3), b.wide (4)
+// and acute (5). Feature salt substitutes glyphs of equal advance, swsh
+// substitutes a wider glyph.
+func measureFont(t *testing.T) *ot.Font {
+	t.Helper()
+	b := testfont.New(6)
+	for i, name := range []string{"a", "a.alt", "b", "b.wide", "acute"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('a', 1).Map('b', 3).Map('́', 5)
+	b.Advance(1, 500).Advance(2, 500).Advance(3, 500).Advance(4, 700).Advance(5, 0)
+	err := b.Features(`languagesystem latn dflt;
+table GDEF { GlyphClassDef [a a.alt b b.wide], , [acute], ; } GDEF;
+feature salt { sub a by a.alt; } salt;
+feature swsh { sub b by b.wide; } swsh;
+feature kern { pos a b -40; } kern;
+markClass acute <anchor 0 500> @TOP;
+feature mark { pos base [a a.alt b b.wide] <anchor 250 600> mark @TOP; } mark;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func TestPlanWidthOnly(t *testing.T) {
+	font := measureFont(t)
+	p, err := compile(planRequest{
+		Font:      font,
+		ScriptTag: ot.T("latn"),
+		UserFeatures: []FeatureRange{
+			{Feature: ot.T("salt"), On: true},
+			{Feature: ot.T("swsh"), On: true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("compile failed: %v", err)
+	}
+	tags := func(tp tableProgram) []ot.Tag {
+		var tags []ot.Tag
+		for _, op := range tp.Lookups {
+			tags = append(tags, op.FeatureTag)
+		}
+		slices.Sort(tags)
+		return tags
+	}
+	q := p.widthOnly()
+	if gsub := tags(q.GSUB); !slices.Equal(gsub, []ot.Tag{ot.T("swsh")}) {
+		t.Errorf("expected GSUB lookups of swsh only, have %v", gsub)
+	}
+	if gpos := tags(q.GPOS); !slices.Equal(gpos, []ot.Tag{ot.T("kern")}) {
+		t.Errorf("expected GPOS lookups of kern only, have %v", gpos)
+	}
+	if len(q.GSUB.Stages) != len(p.GSUB.Stages) || q.validate() != nil {
+		t.Errorf("expected width-only plan to keep its stages")
+	}
+	if len(tags(p.GSUB)) != 2 || len(tags(p.GPOS)) != 2 {
+		t.Errorf("expected original plan to be unchanged")
+	}
+}
+
+func TestMeasureWidthOnly(t *testing.T) {
+	font := measureFont(t)
+	params := standardParams(font)
+	params.Features = []FeatureRange{{Feature: ot.T("salt"), On: true}, {Feature: ot.T("swsh"), On: true}}
+	opts := MeasureOptions{Params: params, Shaper: NewShaper(plainShaper{})}
+	for _, widthOnly := range []bool{false, true} {
+		opts.WidthOnly = widthOnly
+		// a.alt b.wide acute, kerning does not apply to the substitutes
+		if w, _, _ := Measure("ab́", opts); w != 1200 {
+			t.Errorf("width-only=%v: expected width 1200, have %d", widthOnly, w)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+/*
+Package otaat provides a shaping engine for package otshape which applies the
+glyph metamorphosis table 'morx' of Apple Advanced Typography (AAT).
+
+Many fonts shipped with macOS carry their glyph transformations in 'morx'
+instead of GSUB. For such fonts, the base pipeline would apply no substitutions
+at all. The AAT engine runs the finite state machines of the rearrangement,
+contextual and ligature subtables of 'morx', as well as its noncontextual
+subtables, whenever a font has no GSUB table (or a GSUB table without
+lookups). For fonts with GSUB lookups, it behaves like the core engine.
+
+Chains are applied with their default flags; AAT feature selection and
+insertion subtables are not supported.
+*/
+package otaat
+
+import (
+	"github.com/npillmayer/schuko/tracing"
+)
+
+// tracer returns a trace sink for the otshape package namespace.
+func tracer() tracing.Trace {
+	return tracing.Select("opentype.shaper")
+}
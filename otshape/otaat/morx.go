@@ -0,0 +1,293 @@
+package otaat
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otshape"
+)
+
+// deletedGlyph marks glyphs removed by ligature subtables. State tables assign
+// it class AATClassDeletedGlyph; it is removed from the run after all
+// subtables have been applied.
+const deletedGlyph = ot.GlyphIndex(0xffff)
+
+// maxStuck bounds the number of consecutive transitions which do not advance
+// to the next glyph, protecting against state tables which loop forever.
+const maxStuck = 64
+
+// Entry flags of 'morx' state tables. dontAdvance has the same meaning for all
+// types of subtables, other flags depend on the type.
+const (
+	flagDontAdvance   = 0x4000
+	flagMarkFirst     = 0x8000 // rearrangement
+	flagMarkLast      = 0x2000 // rearrangement
+	verbMask          = 0x000f // rearrangement
+	flagSetMark       = 0x8000 // contextual
+	flagSetComponent  = 0x8000 // ligature
+	flagPerformAction = 0x2000 // ligature
+)
+
+// Flags and offset of ligature actions.
+const (
+	ligActionLast   = 0x80000000
+	ligActionStore  = 0x40000000
+	ligActionOffset = 0x3fffffff
+)
+
+// noIndex is the substitution table index of contextual entries which do not
+// substitute the marked or current glyph.
+const noIndex = 0xffff
+
+// maxContext bounds the length of glyph ranges to rearrange.
+const maxContext = 64
+
+// rearrangement holds the moves of rearrangement verbs 0…15: the high nibble
+// is the number of glyphs moved from the start of the marked range to its
+// end, the low nibble the number moved from its end to its start. A value of
+// 3 denotes 2 glyphs, which are reversed when moved.
+var rearrangement = [16]uint8{
+	0x00, // no change
+	0x10, // Ax ⇒ xA
+	0x01, // xD ⇒ Dx
+	0x11, // AxD ⇒ DxA
+	0x20, // ABx ⇒ xAB
+	0x30, // ABx ⇒ xBA
+	0x02, // xCD ⇒ CDx
+	0x03, // xCD ⇒ DCx
+	0x12, // AxCD ⇒ CDxA
+	0x13, // AxCD ⇒ DCxA
+	0x21, // ABxD ⇒ DxAB
+	0x31, // ABxD ⇒ DxBA
+	0x22, // ABxCD ⇒ CDxAB
+	0x32, // ABxCD ⇒ CDxBA
+	0x23, // ABxCD ⇒ DCxAB
+	0x33, // ABxCD ⇒ DCxBA
+}
+
+// machine is the state of a state machine while processing a subtable.
+type machine struct {
+	start, end int  // marked range of rearrangement subtables
+	mark       int  // marked glyph of contextual subtables
+	markSet    bool // mark is valid
+}
+
+// ligatureStack holds the positions of ligature components. It wraps around,
+// i.e., only the most recent components are kept.
+type ligatureStack struct {
+	pos [maxContext]int
+	n   int
+}
+
+func (stack *ligatureStack) at(i int) int {
+	return stack.pos[i%maxContext]
+}
+
+// applySubtable applies one 'morx' subtable to run.
+func (s *Shaper) applySubtable(sub *morxSubtable, run otshape.RunContext) {
+	reverse := sub.Coverage&ot.MorxDescending != 0
+	if sub.Coverage&ot.MorxLogicalOrder == 0 && s.plan.rtl {
+		reverse = !reverse
+	}
+	if reverse {
+		reverseRange(run, 0, run.Len())
+	}
+	if sub.Type == ot.MorxNoncontextual {
+		substitute(sub, run)
+	} else {
+		s.drive(sub, run)
+	}
+	if reverse {
+		reverseRange(run, 0, run.Len())
+	}
+}
+
+// substitute applies a noncontextual subtable.
+func substitute(sub *morxSubtable, run otshape.RunContext) {
+	lookup, ok := sub.NoncontextualLookup()
+	if !ok {
+		return
+	}
+	for i := range run.Len() {
+		if g := run.Glyph(i); g != deletedGlyph {
+			if v, ok := lookup.Lookup(g); ok {
+				run.SetGlyph(i, ot.GlyphIndex(v))
+			}
+		}
+	}
+}
+
+// drive runs the state machine of a subtable over run, starting in state 0
+// (start of text) and ending with a transition for the end-of-text class.
+// Subtables do not change the length of run, as deleted glyphs are marked only.
+func (s *Shaper) drive(sub *morxSubtable, run otshape.RunContext) {
+	m := machine{}
+	s.lig.n = 0
+	n, state, stuck := run.Len(), uint16(0), 0
+	for i := 0; i <= n; {
+		class := uint16(ot.AATClassEndOfText)
+		if i < n {
+			class = sub.states.Class(run.Glyph(i))
+		}
+		entry, ok := sub.states.Entry(state, class)
+		if !ok {
+			tracer().Debugf("AAT shaper: no entry for state %d and class %d", state, class)
+			return
+		}
+		switch sub.Type {
+		case ot.MorxRearrangement:
+			m.rearrange(run, i, entry)
+		case ot.MorxContextual:
+			m.contextual(sub, run, i, entry)
+		case ot.MorxLigature:
+			s.lig.ligature(sub, run, i, entry)
+		}
+		state = entry.NewState
+		if i == n {
+			break
+		}
+		if entry.Flags&flagDontAdvance == 0 || stuck >= maxStuck {
+			i, stuck = i+1, 0
+		} else {
+			stuck++
+		}
+	}
+}
+
+// rearrange performs the transition of a rearrangement subtable at glyph i.
+func (m *machine) rearrange(run otshape.RunContext, i int, entry ot.AATStateEntry) {
+	if entry.Flags&flagMarkFirst != 0 {
+		m.start = i
+	}
+	if entry.Flags&flagMarkLast != 0 {
+		m.end = min(i+1, run.Len())
+	}
+	verb := entry.Flags & verbMask
+	if verb == 0 || m.start >= m.end {
+		return
+	}
+	move := rearrangement[verb]
+	l, r := min(2, int(move>>4)), min(2, int(move&0x0f))
+	if m.end-m.start < l+r || m.end-m.start > maxContext {
+		return
+	}
+	run.MergeClusters(m.start, m.end)
+	// [L | x | R] ⇒ [R | x | L]
+	reverseRange(run, m.start, m.end)
+	reverseRange(run, m.start, m.start+r)
+	reverseRange(run, m.start+r, m.end-l)
+	reverseRange(run, m.end-l, m.end)
+	if move>>4 == 3 {
+		run.Swap(m.end-2, m.end-1)
+	}
+	if move&0x0f == 3 {
+		run.Swap(m.start, m.start+1)
+	}
+}
+
+// contextual performs the transition of a contextual subtable at glyph i.
+func (m *machine) contextual(sub *morxSubtable, run otshape.RunContext, i int, entry ot.AATStateEntry) {
+	n := run.Len()
+	if i == n && !m.markSet {
+		return
+	}
+	if entry.Args[0] != noIndex && m.markSet {
+		replace(sub, run, min(m.mark, n-1), entry.Args[0])
+	}
+	if entry.Args[1] != noIndex && n > 0 {
+		replace(sub, run, min(i, n-1), entry.Args[1])
+	}
+	if entry.Flags&flagSetMark != 0 {
+		m.mark, m.markSet = i, true
+	}
+}
+
+// replace substitutes glyph i by substitution table index of a contextual
+// subtable.
+func replace(sub *morxSubtable, run otshape.RunContext, i int, index uint16) {
+	if g := run.Glyph(i); g != deletedGlyph {
+		if repl, ok := sub.ContextualSubstitution(index, g); ok {
+			run.SetGlyph(i, repl)
+		}
+	}
+}
+
+// ligature performs the transition of a ligature subtable at glyph i.
+// Components are pushed onto the stack; ligature actions pop them, accumulate
+// an index into the ligature table from their glyphs and replace them by a
+// ligature glyph.
+func (stack *ligatureStack) ligature(sub *morxSubtable, run otshape.RunContext, i int, entry ot.AATStateEntry) {
+	n := run.Len()
+	if entry.Flags&flagSetComponent != 0 {
+		if stack.n > 0 && stack.at(stack.n-1) == i { // re-visit after dontAdvance
+			stack.n--
+		}
+		stack.pos[stack.n%maxContext] = i
+		stack.n++
+	}
+	if entry.Flags&flagPerformAction == 0 || stack.n == 0 || i >= n {
+		return
+	}
+	cursor, action, ligIndex := stack.n, int(entry.Args[0]), 0
+	for {
+		if cursor == 0 { // stack underflow
+			stack.n = 0
+			return
+		}
+		cursor--
+		pos := stack.at(cursor)
+		a, ok := sub.LigatureAction(action)
+		if !ok || pos >= n {
+			stack.n = 0
+			return
+		}
+		offset := a & ligActionOffset
+		if offset&0x20000000 != 0 { // sign-extend 30-bit offset
+			offset |= 0xc0000000
+		}
+		component, ok := sub.LigatureComponent(int(run.Glyph(pos)) + int(int32(offset)))
+		if !ok {
+			stack.n = 0
+			return
+		}
+		ligIndex += int(component)
+		if a&(ligActionStore|ligActionLast) != 0 {
+			lig, ok := sub.LigatureGlyph(ligIndex)
+			if !ok {
+				stack.n = 0
+				return
+			}
+			run.SetGlyph(pos, lig)
+			end := stack.at(stack.n-1) + 1
+			for stack.n-1 > cursor {
+				stack.n--
+				run.SetGlyph(stack.at(stack.n), deletedGlyph)
+			}
+			run.MergeClusters(pos, end)
+		}
+		action++
+		if a&ligActionLast != 0 {
+			return
+		}
+	}
+}
+
+// reverseRange reverses the order of glyphs start…end-1.
+func reverseRange(run otshape.RunContext, start, end int) {
+	for i, j := start, end-1; i < j; i, j = i+1, j-1 {
+		run.Swap(i, j)
+	}
+}
+
+// removeDeletedGlyphs removes glyphs marked as deleted from run.
+func removeDeletedGlyphs(run otshape.RunContext) {
+	for end := run.Len(); end > 0; end-- {
+		if run.Glyph(end-1) != deletedGlyph {
+			continue
+		}
+		start := end - 1
+		for start > 0 && run.Glyph(start-1) == deletedGlyph {
+			start--
+		}
+		run.DeleteGlyphs(start, end)
+		end = start + 1
+	}
+}
@@ -0,0 +1,137 @@
+package otaat
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otshape"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// morxSubtable is a 'morx' subtable selected by the default flags of its
+// chain, together with its state table (if any).
+type morxSubtable struct {
+	ot.MorxSubtable
+	states *ot.AATStateTable
+}
+
+type shaperPlanState struct {
+	rtl       bool
+	subtables []morxSubtable
+}
+
+// Shaper is the AAT shaping engine.
+//
+// It applies the 'morx' table of fonts without GSUB before the (empty) GSUB
+// stage of the shared otshape pipeline.
+type Shaper struct {
+	plan shaperPlanState
+	lig  ligatureStack
+}
+
+var _ otshape.ShapingEngine = (*Shaper)(nil)
+var _ otshape.ShapingEnginePolicy = (*Shaper)(nil)
+var _ otshape.ShapingEnginePlanHooks = (*Shaper)(nil)
+var _ otshape.ShapingEnginePreGSUBHook = (*Shaper)(nil)
+
+// New returns a new AAT shaping engine instance.
+func New() otshape.ShapingEngine {
+	return &Shaper{}
+}
+
+// Name returns the stable engine name.
+func (Shaper) Name() string {
+	return "aat"
+}
+
+// Match reports how suitable this engine is for ctx.
+//
+// Engine selection cannot inspect the font, and 'morx' tables are not specific
+// to a script. Match therefore returns high confidence for horizontal
+// segments, which outvotes the core engine, but not engines for scripts
+// requiring script-specific shaping. Clients add the AAT engine to the
+// candidates when shaping with fonts which may lack GSUB.
+func (Shaper) Match(ctx otshape.SelectionContext) otshape.ShaperConfidence {
+	if ctx.Direction != bidi.LeftToRight && ctx.Direction != bidi.RightToLeft {
+		return otshape.ShaperConfidenceNone
+	}
+	return otshape.ShaperConfidenceHigh
+}
+
+// New returns a new independent AAT engine instance.
+func (Shaper) New() otshape.ShapingEngine {
+	return &Shaper{}
+}
+
+// NormalizationPreference reports the engine's normalization policy.
+func (Shaper) NormalizationPreference() otshape.NormalizationMode {
+	return otshape.NormalizationAuto
+}
+
+// ApplyGPOS reports whether the engine wants GPOS applied.
+func (Shaper) ApplyGPOS() bool {
+	return true
+}
+
+// CollectFeatures is a no-op; 'morx' subtables are selected by chain flags.
+func (s *Shaper) CollectFeatures(plan otshape.FeaturePlanner, ctx otshape.SelectionContext) {}
+
+// OverrideFeatures is a no-op.
+func (s *Shaper) OverrideFeatures(plan otshape.FeaturePlanner) {}
+
+// InitPlan selects the 'morx' subtables to apply, if the font of plan has a
+// 'morx' table but no GSUB lookups.
+func (s *Shaper) InitPlan(plan otshape.PlanContext) {
+	s.plan = shaperPlanState{rtl: plan.Selection().Direction == bidi.RightToLeft}
+	font := plan.Font()
+	if font == nil || hasGSUB(font) {
+		return
+	}
+	morx, ok := ot.TableOf[*ot.MorxTable](font)
+	if !ok || morx.Error() != nil {
+		return
+	}
+	for _, chain := range morx.Chains() {
+		flags := chain.DefaultFlags
+		for _, sub := range chain.Subtables {
+			if sub.SubFeatureFlags&flags == 0 {
+				continue
+			}
+			if sub.Coverage&ot.MorxVertical != 0 && sub.Coverage&ot.MorxAnyOrientation == 0 {
+				continue
+			}
+			st := morxSubtable{MorxSubtable: sub}
+			switch sub.Type {
+			case ot.MorxRearrangement, ot.MorxContextual, ot.MorxLigature:
+				states, err := sub.StateTable()
+				if err != nil {
+					tracer().Infof("AAT shaper skips damaged morx subtable: %v", err)
+					continue
+				}
+				st.states = states
+			case ot.MorxNoncontextual:
+			default:
+				tracer().Debugf("AAT shaper skips morx subtable of type %d", sub.Type)
+				continue
+			}
+			s.plan.subtables = append(s.plan.subtables, st)
+		}
+	}
+}
+
+// hasGSUB reports whether font has a GSUB table with lookups. Fonts converted
+// from AAT fonts may carry an empty GSUB table, as package ot requires one
+// unless parsing is relaxed.
+func hasGSUB(font *ot.Font) bool {
+	gsub := font.GSub()
+	return gsub != nil && gsub.LookupGraph().Len() > 0
+}
+
+// PrepareGSUB applies the selected 'morx' subtables to run.
+func (s *Shaper) PrepareGSUB(run otshape.RunContext) {
+	if len(s.plan.subtables) == 0 {
+		return
+	}
+	for i := range s.plan.subtables {
+		s.applySubtable(&s.plan.subtables[i], run)
+	}
+	removeDeletedGlyphs(run)
+}
@@ -0,0 +1,324 @@
+package otaat_test
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otaat"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// Glyphs of the test fonts.
+const (
+	gA ot.GlyphIndex = iota + 1
+	gB
+	gC
+	gD
+	gF
+	gI
+	gX
+	gFI
+)
+
+func be16(b []byte, v ...uint16) []byte {
+	for _, x := range v {
+		b = binary.BigEndian.AppendUint16(b, x)
+	}
+	return b
+}
+
+func be32(b []byte, v ...uint32) []byte {
+	for _, x := range v {
+		b = binary.BigEndian.AppendUint32(b, x)
+	}
+	return b
+}
+
+// trimmedArray builds an AAT lookup table of format 8 for glyphs 0…gFI, with
+// value unmapped(g) for glyphs not contained in values.
+func trimmedArray(values map[ot.GlyphIndex]uint16, unmapped func(ot.GlyphIndex) uint16) []byte {
+	b := be16(nil, 8, 0, uint16(gFI)+1)
+	for g := ot.GlyphIndex(0); g <= gFI; g++ {
+		v, ok := values[g]
+		if !ok {
+			v = unmapped(g)
+		}
+		b = be16(b, v)
+	}
+	return b
+}
+
+// classLookup builds the class lookup table of a state table.
+func classLookup(classes map[ot.GlyphIndex]uint16) []byte {
+	return trimmedArray(classes, func(ot.GlyphIndex) uint16 { return ot.AATClassOutOfBounds })
+}
+
+// glyphLookup builds a substitution lookup table.
+func glyphLookup(subst map[ot.GlyphIndex]uint16) []byte {
+	return trimmedArray(subst, func(g ot.GlyphIndex) uint16 { return uint16(g) })
+}
+
+// stateTable describes an extended state table, followed by subtable-specific
+// tables, the offsets of which are appended to the state table header.
+type stateTable struct {
+	classes  map[ot.GlyphIndex]uint16
+	nClasses int
+	states   [][]uint16 // entry index by state and class
+	entries  [][]uint16 // new state, flags and arguments
+	extra    [][]byte
+}
+
+func (st stateTable) bytes() []byte {
+	classes := classLookup(st.classes)
+	var states, entries []byte
+	for _, row := range st.states {
+		states = be16(states, row...)
+	}
+	for _, e := range st.entries {
+		entries = be16(entries, e...)
+	}
+	at := uint32(16 + 4*len(st.extra))
+	b := be32(nil, uint32(st.nClasses), at, at+uint32(len(classes)),
+		at+uint32(len(classes)+len(states)))
+	at += uint32(len(classes) + len(states) + len(entries))
+	for _, x := range st.extra {
+		b = be32(b, at)
+		at += uint32(len(x))
+	}
+	b = append(append(append(b, classes...), states...), entries...)
+	for _, x := range st.extra {
+		b = append(b, x...)
+	}
+	return b
+}
+
+type subtable struct {
+	typ      ot.MorxSubtableType
+	coverage uint32 // coverage flags, see ot.MorxVertical etc.
+	flags    uint32
+	body     []byte
+}
+
+// morxFont builds a font without GSUB and with a 'morx' table of a single
+// chain with default flags 1.
+func morxFont(t *testing.T, withGSUB bool, subtables ...subtable) *ot.Font {
+	t.Helper()
+	chain := be32(nil, 1, 0, 0, uint32(len(subtables)))
+	for _, sub := range subtables {
+		chain = be32(chain, uint32(12+len(sub.body)), sub.coverage|uint32(sub.typ), sub.flags)
+		chain = append(chain, sub.body...)
+	}
+	binary.BigEndian.PutUint32(chain[4:], uint32(len(chain)))
+	morx := append(be32(be16(nil, 2, 0), 1), chain...)
+
+	b := testfont.New(int(gFI) + 1)
+	b.Map('a', gA).Map('b', gB).Map('c', gC).Map('d', gD)
+	b.Map('f', gF).Map('i', gI).Map('x', gX)
+	b.Table("morx", morx)
+	if withGSUB {
+		gsub := b.GSUB()
+		gsub.Feature("ccmp", gsub.Lookup(ot.GSubLookupTypeSingle, 0,
+			testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{gC: gD})))
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+// rearrangementAxCD moves 'a' after a following 'd' and the 2 glyphs before
+// 'd' to the front (verb 8, AxCD ⇒ CDxA).
+var rearrangementAxCD = subtable{typ: ot.MorxRearrangement, flags: 1, body: stateTable{
+	classes:  map[ot.GlyphIndex]uint16{gA: 4, gB: 5, gC: 5, gD: 6},
+	nClasses: 7,
+	states: [][]uint16{
+		{0, 0, 0, 0, 1, 0, 0},
+		{0, 0, 0, 0, 1, 3, 2},
+	},
+	entries: [][]uint16{
+		{0, 0},
+		{1, 0x8000},     // mark first
+		{0, 0x2000 | 8}, // mark last, verb 8
+		{1, 0},
+	},
+}.bytes()}
+
+// contextualAB replaces 'a' by 'x' if followed by 'b'.
+var contextualAB = subtable{typ: ot.MorxContextual, flags: 1, body: stateTable{
+	classes:  map[ot.GlyphIndex]uint16{gA: 4, gB: 5},
+	nClasses: 6,
+	states: [][]uint16{
+		{0, 0, 0, 0, 1, 0},
+		{0, 0, 0, 0, 1, 2},
+	},
+	entries: [][]uint16{
+		{0, 0, 0xffff, 0xffff},
+		{1, 0x8000, 0xffff, 0xffff}, // set mark
+		{0, 0, 0, 0xffff},           // substitute marked glyph
+	},
+	extra: [][]byte{append(be32(nil, 4), glyphLookup(map[ot.GlyphIndex]uint16{gA: uint16(gX)})...)},
+}.bytes()}
+
+// ligatureFI forms ligature 'fi'.
+var ligatureFI = subtable{typ: ot.MorxLigature, flags: 1, body: stateTable{
+	classes:  map[ot.GlyphIndex]uint16{gF: 4, gI: 5},
+	nClasses: 6,
+	states: [][]uint16{
+		{0, 0, 0, 0, 1, 0},
+		{0, 0, 0, 0, 1, 2},
+	},
+	entries: [][]uint16{
+		{0, 0, 0},
+		{1, 0x8000, 0},          // set component
+		{0, 0x8000 | 0x2000, 0}, // set component, perform action
+	},
+	extra: [][]byte{
+		be32(nil, 0, 0x80000000|0x3ffffffc), // 'i': offset 0; 'f': last, offset -gF+1
+		be16(nil, 0, 0, 0, 0, 0, 0, 1),      // component 'i' ⇒ 1
+		be16(nil, 0, uint16(gFI)),
+	},
+}.bytes()}
+
+// noncontextualBC replaces 'b' by 'c', but is disabled by the chain flags.
+var noncontextualBC = subtable{typ: ot.MorxNoncontextual, flags: 2,
+	body: glyphLookup(map[ot.GlyphIndex]uint16{gB: uint16(gC)})}
+
+// noncontextualDX replaces 'd' by 'x'.
+var noncontextualDX = subtable{typ: ot.MorxNoncontextual, flags: 1,
+	body: glyphLookup(map[ot.GlyphIndex]uint16{gD: uint16(gX)})}
+
+type glyphCollector struct {
+	glyphs []otshape.GlyphRecord
+}
+
+func (c *glyphCollector) WriteGlyph(g otshape.GlyphRecord) error {
+	c.glyphs = append(c.glyphs, g)
+	return nil
+}
+
+func shape(t *testing.T, otf *ot.Font, dir bidi.Direction, text string) ([]ot.GlyphIndex, []uint32) {
+	t.Helper()
+	params := otshape.Params{
+		Font:      otf,
+		Direction: dir,
+		Script:    language.MustParseScript("Latn"),
+		Language:  language.English,
+	}
+	sink := &glyphCollector{}
+	err := otshape.NewShaper(otaat.New()).Shape(params, otshape.StringSource(text), sink, otshape.BufferOptions{})
+	if err != nil {
+		t.Fatalf("%q: shaping failed: %v", text, err)
+	}
+	var glyphs []ot.GlyphIndex
+	var clusters []uint32
+	for _, g := range sink.glyphs {
+		glyphs = append(glyphs, g.GID)
+		clusters = append(clusters, g.Cluster)
+	}
+	return glyphs, clusters
+}
+
+func TestShapeMorx(t *testing.T) {
+	for _, c := range []struct {
+		name      string
+		subtables []subtable
+		withGSUB  bool
+		text      string
+		want      []ot.GlyphIndex
+		clusters  []uint32
+	}{
+		{
+			name:      "rearrangement",
+			subtables: []subtable{rearrangementAxCD},
+			text:      "xabcd",
+			want:      []ot.GlyphIndex{gX, gC, gD, gB, gA},
+			clusters:  []uint32{0, 1, 1, 1, 1},
+		},
+		{
+			name:      "contextual",
+			subtables: []subtable{contextualAB},
+			text:      "abaac",
+			want:      []ot.GlyphIndex{gX, gB, gA, gA, gC},
+			clusters:  []uint32{0, 1, 2, 3, 4},
+		},
+		{
+			name:      "ligature",
+			subtables: []subtable{ligatureFI},
+			text:      "ffix",
+			want:      []ot.GlyphIndex{gF, gFI, gX},
+			clusters:  []uint32{0, 1, 3},
+		},
+		{
+			name:      "noncontextual with chain flags",
+			subtables: []subtable{noncontextualBC, noncontextualDX},
+			text:      "bd",
+			want:      []ot.GlyphIndex{gB, gX},
+			clusters:  []uint32{0, 1},
+		},
+		{
+			name:      "subtables in order",
+			subtables: []subtable{contextualAB, noncontextualDX, ligatureFI},
+			text:      "abdfi",
+			want:      []ot.GlyphIndex{gX, gB, gX, gFI},
+			clusters:  []uint32{0, 1, 2, 3},
+		},
+		{
+			name:      "font with GSUB",
+			subtables: []subtable{contextualAB},
+			withGSUB:  true,
+			text:      "abc",
+			want:      []ot.GlyphIndex{gA, gB, gD},
+			clusters:  []uint32{0, 1, 2},
+		},
+	} {
+		otf := morxFont(t, c.withGSUB, c.subtables...)
+		glyphs, clusters := shape(t, otf, bidi.LeftToRight, c.text)
+		if !slices.Equal(glyphs, c.want) || !slices.Equal(clusters, c.clusters) {
+			t.Errorf("%s: %q shaped to glyphs %v with clusters %v, want %v with %v",
+				c.name, c.text, glyphs, clusters, c.want, c.clusters)
+		}
+	}
+}
+
+func TestShapeMorxDirection(t *testing.T) {
+	// subtables process right-to-left runs from their end, unless their
+	// coverage says otherwise
+	for _, c := range []struct {
+		coverage uint32
+		dir      bidi.Direction
+		want     []ot.GlyphIndex
+	}{
+		{coverage: 0, dir: bidi.LeftToRight, want: []ot.GlyphIndex{gB, gA}},
+		{coverage: ot.MorxDescending, dir: bidi.LeftToRight, want: []ot.GlyphIndex{gB, gX}},
+		{coverage: 0, dir: bidi.RightToLeft, want: []ot.GlyphIndex{gB, gX}},
+		{coverage: ot.MorxDescending, dir: bidi.RightToLeft, want: []ot.GlyphIndex{gB, gA}},
+		{coverage: ot.MorxLogicalOrder, dir: bidi.RightToLeft, want: []ot.GlyphIndex{gB, gA}},
+		{coverage: ot.MorxVertical, dir: bidi.LeftToRight, want: []ot.GlyphIndex{gB, gA}},
+	} {
+		sub := contextualAB
+		sub.coverage = c.coverage
+		glyphs, _ := shape(t, morxFont(t, false, sub), c.dir, "ba")
+		if !slices.Equal(glyphs, c.want) {
+			t.Errorf("coverage 0x%08x, direction %v: glyphs %v, want %v", c.coverage, c.dir, glyphs, c.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	s := otaat.New()
+	if s.Name() != "aat" {
+		t.Errorf("Name() = %q, want %q", s.Name(), "aat")
+	}
+	if got := s.Match(otshape.SelectionContext{Direction: bidi.LeftToRight}); got != otshape.ShaperConfidenceHigh {
+		t.Errorf("left-to-right match = %d, want %d", got, otshape.ShaperConfidenceHigh)
+	}
+	if got := s.Match(otshape.SelectionContext{Direction: bidi.Mixed}); got != otshape.ShaperConfidenceNone {
+		t.Errorf("mixed-direction match = %d, want %d", got, otshape.ShaperConfidenceNone)
+	}
+}
@@ -1,7 +1,17 @@
 /*
-Package otarabic provides the Arabic/Syriac shaping engine for package otshape.
+Package otarabic provides the shaping engine for joining scripts for package
+otshape: Arabic, Syriac, Mongolian and Phags-pa.
 
 It implements Arabic feature staging, joining-form mask setup, mark reordering,
 and postprocessing steps used by the shared otshape pipeline.
+
+Joining types and groups are taken from a table generated from the Unicode
+Character Database (see Joining). Clients may compute the joining forms of a
+rune sequence independently of shaping with JoiningForms. Mongolian free
+variation selectors take the joining form of the letter they follow, so that
+lookups for a form may match the letter together with its variation selector.
+
+Glyphs decomposed by feature 'stch' are stretched to a requested width by the
+post-shaping pass StretchGlyphs.
 */
 package otarabic
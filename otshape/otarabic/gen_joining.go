@@ -0,0 +1,169 @@
+//go:build ignore
+
+// gen_joining generates joining_table.go from the Unicode Character Database
+// file ArabicShaping.txt:
+//
+//	go run gen_joining.go -ucd ArabicShaping.txt
+//
+// Only code points of the blocks handled by the joining-script shaper are
+// included. ArabicShaping.txt of UCD version 15.1.0, which joining_table.go has
+// been generated from, may be downloaded from
+// https://www.unicode.org/Public/15.1.0/ucd/ArabicShaping.txt.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// blocks are the Unicode blocks included in the table, plus ZWJ.
+var blocks = [][2]rune{
+	{0x0600, 0x06FF}, // Arabic
+	{0x0700, 0x074F}, // Syriac
+	{0x0750, 0x077F}, // Arabic Supplement
+	{0x0860, 0x086F}, // Syriac Supplement
+	{0x0870, 0x089F}, // Arabic Extended-B
+	{0x08A0, 0x08FF}, // Arabic Extended-A
+	{0x1800, 0x18AF}, // Mongolian
+	{0xA840, 0xA87F}, // Phags-pa
+	{0x200D, 0x200D}, // ZWJ
+}
+
+var joiningTypes = map[string]string{
+	"U": "NonJoining",
+	"R": "RightJoining",
+	"L": "LeftJoining",
+	"D": "DualJoining",
+	"C": "JoinCausing",
+	"T": "Transparent",
+}
+
+type entry struct {
+	lo, hi rune
+	typ    string
+	group  string
+}
+
+func main() {
+	ucd := flag.String("ucd", "ArabicShaping.txt", "path of UCD file ArabicShaping.txt")
+	out := flag.String("o", "joining_table.go", "output file")
+	flag.Parse()
+	f, err := os.Open(*ucd)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	version := "ArabicShaping.txt"
+	versionRE := regexp.MustCompile(`^#\s*(ArabicShaping-[0-9.]+\.txt)`)
+	var entries []entry
+	groups := map[string]bool{"No_Joining_Group": true}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := versionRE.FindStringSubmatch(line); m != nil {
+			version = m[1]
+		}
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) < 4 {
+			continue
+		}
+		cp, err := strconv.ParseUint(strings.TrimSpace(fields[0]), 16, 32)
+		if err != nil {
+			log.Fatalf("invalid code point in line %q", line)
+		}
+		r := rune(cp)
+		if !slices.ContainsFunc(blocks, func(b [2]rune) bool { return r >= b[0] && r <= b[1] }) {
+			continue
+		}
+		typ, ok := joiningTypes[strings.TrimSpace(fields[2])]
+		if !ok {
+			log.Fatalf("invalid joining type in line %q", line)
+		}
+		group := groupName(strings.TrimSpace(fields[3]))
+		groups[group] = true
+		if n := len(entries); n > 0 && entries[n-1].hi == r-1 &&
+			entries[n-1].typ == typ && entries[n-1].group == group {
+			entries[n-1].hi = r
+			continue
+		}
+		entries = append(entries, entry{lo: r, hi: r, typ: typ, group: group})
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return int(a.lo - b.lo) })
+	names := make([]string, 0, len(groups))
+	for g := range groups {
+		if g != "No_Joining_Group" {
+			names = append(names, g)
+		}
+	}
+	slices.Sort(names)
+	names = append([]string{"No_Joining_Group"}, names...)
+	//
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_joining.go from %s; DO NOT EDIT.\n\n", version)
+	fmt.Fprintf(&b, "package otarabic\n\n")
+	fmt.Fprintf(&b, "// Joining groups of Arabic and Syriac characters (Unicode property Joining_Group).\n")
+	fmt.Fprintf(&b, "const (\n")
+	for i, g := range names {
+		if i == 0 {
+			fmt.Fprintf(&b, "\t%s JoiningGroup = iota\n", groupIdent(g))
+		} else {
+			fmt.Fprintf(&b, "\t%s\n", groupIdent(g))
+		}
+	}
+	fmt.Fprintf(&b, ")\n\n")
+	fmt.Fprintf(&b, "var joiningGroupNames = [...]string{\n")
+	for _, g := range names {
+		fmt.Fprintf(&b, "\t%s: %q,\n", groupIdent(g), g)
+	}
+	fmt.Fprintf(&b, "}\n\n")
+	fmt.Fprintf(&b, "// joiningTable holds the code points with an explicitly assigned joining type,\n")
+	fmt.Fprintf(&b, "// as ranges sorted by code point.\n")
+	fmt.Fprintf(&b, "var joiningTable = [...]joiningRange{\n")
+	for _, e := range entries {
+		fmt.Fprintf(&b, "\t{0x%04X, 0x%04X, %s, %s},\n", e.lo, e.hi, e.typ, groupIdent(e.group))
+	}
+	fmt.Fprintf(&b, "}\n")
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// groupName converts a joining group of ArabicShaping.txt ("TEH MARBUTA GOAL")
+// to its property value alias ("Teh_Marbuta_Goal").
+func groupName(s string) string {
+	if s == "" || strings.EqualFold(s, "No_Joining_Group") {
+		return "No_Joining_Group"
+	}
+	words := strings.Fields(strings.ReplaceAll(s, "_", " "))
+	for i, w := range words {
+		words[i] = strings.ToUpper(w[:1]) + strings.ToLower(w[1:])
+	}
+	return strings.Join(words, "_")
+}
+
+// groupIdent returns the Go identifier for a joining group.
+func groupIdent(g string) string {
+	if g == "No_Joining_Group" {
+		return "NoJoiningGroup"
+	}
+	return "Group" + strings.ReplaceAll(g, "_", "")
+}
@@ -0,0 +1,213 @@
+package otarabic
+
+import (
+	"slices"
+	"unicode"
+
+	"github.com/npillmayer/opentype/otshape"
+)
+
+//go:generate go run gen_joining.go -ucd ArabicShaping.txt
+
+// JoiningType is the Unicode property Joining_Type of a character, which
+// determines how the character connects to its neighbours in cursive scripts.
+type JoiningType uint8
+
+// Joining types, as defined in UCD file ArabicShaping.txt.
+const (
+	NonJoining   JoiningType = iota // U: does not join, e.g. hamza
+	RightJoining                    // R: joins to the preceding character only, e.g. alef
+	LeftJoining                     // L: joins to the following character only
+	DualJoining                     // D: joins on both sides, e.g. beh
+	JoinCausing                     // C: makes its neighbours join, e.g. tatweel or ZWJ
+	Transparent                     // T: skipped when determining joining, e.g. marks
+)
+
+func (jt JoiningType) String() string {
+	if int(jt) >= len("URLDCT") {
+		return "?"
+	}
+	return "URLDCT"[jt : jt+1]
+}
+
+// JoiningGroup is the Unicode property Joining_Group of a character, which
+// groups characters sharing the same basic shape, such as all the characters
+// based on beh.
+type JoiningGroup uint8
+
+func (jg JoiningGroup) String() string {
+	if int(jg) >= len(joiningGroupNames) {
+		return "?"
+	}
+	return joiningGroupNames[jg]
+}
+
+// joiningRange is a range of code points with the same joining properties.
+type joiningRange struct {
+	lo, hi rune
+	typ    JoiningType
+	group  JoiningGroup
+}
+
+// Joining returns the joining type and joining group of character r.
+//
+// Joining properties are taken from a table generated from the Unicode Character
+// Database, covering the Arabic, Syriac, Mongolian and Phags-pa blocks. Other characters are
+// transparent if they are non-spacing marks, enclosing marks or format controls
+// (with the exception of ZWNJ), and non-joining otherwise.
+func Joining(r rune) (JoiningType, JoiningGroup) {
+	i, found := slices.BinarySearchFunc(joiningTable[:], r, func(e joiningRange, r rune) int {
+		switch {
+		case e.hi < r:
+			return -1
+		case e.lo > r:
+			return 1
+		}
+		return 0
+	})
+	if found {
+		return joiningTable[i].typ, joiningTable[i].group
+	}
+	if r != '\u200C' && unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf) {
+		return Transparent, NoJoiningGroup
+	}
+	return NonJoining, NoJoiningGroup
+}
+
+// JoiningForm is the positional form a character takes in a sequence of
+// joining characters. Each form is selected by an OpenType feature, see Feature.
+type JoiningForm = otshape.JoiningForm
+
+// Joining forms. Forms Final2, Final3 and Medial2 are used for Syriac Alaph only.
+const (
+	NoForm   = otshape.NoJoiningForm // character does not change its form
+	Isolated = otshape.JoiningIsolated
+	Final    = otshape.JoiningFinal
+	Final2   = otshape.JoiningFinal2
+	Final3   = otshape.JoiningFinal3
+	Medial   = otshape.JoiningMedial
+	Medial2  = otshape.JoiningMedial2
+	Initial  = otshape.JoiningInitial
+)
+
+// JoiningForms returns the positional form of each character of a rune
+// sequence, following the joining rules of the Unicode standard (section 9.2)
+// and the rules for Syriac Alaph of the OpenType Syriac script specification.
+// Transparent characters are skipped when looking for joining partners and
+// keep NoForm, except for Mongolian free variation selectors, which take the
+// form of the preceding character: fonts select variants by lookups matching
+// a letter together with its variation selector, which requires both to have
+// the form feature enabled.
+//
+// Callers may use the result to enable features 'isol', 'fina', 'medi', 'init'
+// etc. per character, e.g. as feature masks of a glyph run.
+func JoiningForms(runes []rune) []JoiningForm {
+	forms := resolveJoiningForms(runes)
+	out := make([]JoiningForm, len(forms))
+	for i, f := range forms {
+		out[i] = JoiningForm(f)
+	}
+	return out
+}
+
+// Joining classes of the joining state machine.
+const (
+	jcNonJoining = iota
+	jcLeft
+	jcRight
+	jcDual
+	jcAlaph
+	jcDalathRish
+	jcTransparent
+)
+
+// joiningAction is a transition of the joining state machine: the form to set
+// for the previous joining character, the form to set for the current one and
+// the next state.
+type joiningAction struct {
+	prev, curr int
+	next       int
+}
+
+// joiningStates is the joining state machine, indexed by state and by joining
+// class of the current character (without jcTransparent). It is modeled after
+// the state machine of HarfBuzz.
+var joiningStates = [7][6]joiningAction{
+	// 0: previous character is non-joining
+	{{formNone, formNone, 0}, {formNone, formIsol, 2}, {formNone, formIsol, 1},
+		{formNone, formIsol, 2}, {formNone, formIsol, 1}, {formNone, formIsol, 6}},
+	// 1: previous character is right-joining or an isolated Alaph
+	{{formNone, formNone, 0}, {formNone, formIsol, 2}, {formNone, formIsol, 1},
+		{formNone, formIsol, 2}, {formNone, formFin2, 5}, {formNone, formIsol, 6}},
+	// 2: previous character is left- or dual-joining, in isolated form
+	{{formNone, formNone, 0}, {formNone, formIsol, 2}, {formInit, formFina, 1},
+		{formInit, formFina, 3}, {formInit, formFina, 4}, {formInit, formFina, 6}},
+	// 3: previous character is dual-joining, in final form
+	{{formNone, formNone, 0}, {formNone, formIsol, 2}, {formMedi, formFina, 1},
+		{formMedi, formFina, 3}, {formMedi, formFina, 4}, {formMedi, formFina, 6}},
+	// 4: previous character is Alaph in final form
+	{{formNone, formNone, 0}, {formNone, formIsol, 2}, {formMed2, formIsol, 1},
+		{formMed2, formIsol, 2}, {formMed2, formFin2, 5}, {formMed2, formIsol, 6}},
+	// 5: previous character is Alaph in form fin2 or fin3
+	{{formNone, formNone, 0}, {formNone, formIsol, 2}, {formIsol, formIsol, 1},
+		{formIsol, formIsol, 2}, {formIsol, formFin2, 5}, {formIsol, formIsol, 6}},
+	// 6: previous character is Dalath or Rish
+	{{formNone, formNone, 0}, {formNone, formIsol, 2}, {formNone, formIsol, 1},
+		{formNone, formIsol, 2}, {formNone, formFin3, 5}, {formNone, formIsol, 6}},
+}
+
+// joiningClass maps a character to its class in the joining state machine.
+// Join-causing characters behave like dual-joining ones.
+func joiningClass(cp rune) int {
+	if cp == 0 {
+		return jcNonJoining
+	}
+	jt, jg := Joining(cp)
+	switch {
+	case jg == GroupAlaph:
+		return jcAlaph
+	case jg == GroupDalathRish:
+		return jcDalathRish
+	}
+	switch jt {
+	case LeftJoining:
+		return jcLeft
+	case RightJoining:
+		return jcRight
+	case DualJoining, JoinCausing:
+		return jcDual
+	case Transparent:
+		return jcTransparent
+	}
+	return jcNonJoining
+}
+
+func resolveJoiningForms(cps []rune) []int {
+	forms := make([]int, len(cps))
+	prev, state := -1, 0
+	for i, cp := range cps {
+		forms[i] = formNone
+		class := joiningClass(cp)
+		if class == jcTransparent {
+			continue
+		}
+		action := joiningStates[state][class]
+		if action.prev != formNone && prev >= 0 {
+			forms[prev] = action.prev
+		}
+		forms[i] = action.curr
+		prev, state = i, action.next
+	}
+	for i := 1; i < len(cps); i++ {
+		if isMongolianFVS(cps[i]) {
+			forms[i] = forms[i-1]
+		}
+	}
+	return forms
+}
+
+// isMongolianFVS reports whether cp is one of the Mongolian free variation
+// selectors FVS1 to FVS4.
+func isMongolianFVS(cp rune) bool {
+	return (cp >= 0x180B && cp <= 0x180D) || cp == 0x180F
+}
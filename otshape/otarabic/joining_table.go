@@ -0,0 +1,367 @@
+// Code generated by gen_joining.go from ArabicShaping-15.1.0.txt; DO NOT EDIT.
+
+package otarabic
+
+// Joining groups of Arabic and Syriac characters (Unicode property Joining_Group).
+const (
+	NoJoiningGroup JoiningGroup = iota
+	GroupAfricanFeh
+	GroupAfricanNoon
+	GroupAfricanQaf
+	GroupAin
+	GroupAlaph
+	GroupAlef
+	GroupBeh
+	GroupBeth
+	GroupBurushaskiYehBarree
+	GroupDal
+	GroupDalathRish
+	GroupE
+	GroupFarsiYeh
+	GroupFe
+	GroupFeh
+	GroupFinalSemkath
+	GroupGaf
+	GroupGamal
+	GroupHah
+	GroupHe
+	GroupHeh
+	GroupHehGoal
+	GroupHeth
+	GroupKaf
+	GroupKaph
+	GroupKhaph
+	GroupKnottedHeh
+	GroupLam
+	GroupLamadh
+	GroupMalayalamBha
+	GroupMalayalamJa
+	GroupMalayalamLla
+	GroupMalayalamLlla
+	GroupMalayalamNga
+	GroupMalayalamNna
+	GroupMalayalamNnna
+	GroupMalayalamNya
+	GroupMalayalamRa
+	GroupMalayalamSsa
+	GroupMalayalamTta
+	GroupMeem
+	GroupMim
+	GroupNoon
+	GroupNun
+	GroupNya
+	GroupPe
+	GroupQaf
+	GroupQaph
+	GroupReh
+	GroupReversedPe
+	GroupRohingyaYeh
+	GroupSad
+	GroupSadhe
+	GroupSeen
+	GroupSemkath
+	GroupShin
+	GroupStraightWaw
+	GroupSwashKaf
+	GroupSyriacWaw
+	GroupTah
+	GroupTaw
+	GroupTehMarbuta
+	GroupTehMarbutaGoal
+	GroupTeth
+	GroupThinYeh
+	GroupVerticalTail
+	GroupWaw
+	GroupYeh
+	GroupYehBarree
+	GroupYehWithTail
+	GroupYudh
+	GroupYudhHe
+	GroupZain
+	GroupZhain
+)
+
+var joiningGroupNames = [...]string{
+	NoJoiningGroup:           "No_Joining_Group",
+	GroupAfricanFeh:          "African_Feh",
+	GroupAfricanNoon:         "African_Noon",
+	GroupAfricanQaf:          "African_Qaf",
+	GroupAin:                 "Ain",
+	GroupAlaph:               "Alaph",
+	GroupAlef:                "Alef",
+	GroupBeh:                 "Beh",
+	GroupBeth:                "Beth",
+	GroupBurushaskiYehBarree: "Burushaski_Yeh_Barree",
+	GroupDal:                 "Dal",
+	GroupDalathRish:          "Dalath_Rish",
+	GroupE:                   "E",
+	GroupFarsiYeh:            "Farsi_Yeh",
+	GroupFe:                  "Fe",
+	GroupFeh:                 "Feh",
+	GroupFinalSemkath:        "Final_Semkath",
+	GroupGaf:                 "Gaf",
+	GroupGamal:               "Gamal",
+	GroupHah:                 "Hah",
+	GroupHe:                  "He",
+	GroupHeh:                 "Heh",
+	GroupHehGoal:             "Heh_Goal",
+	GroupHeth:                "Heth",
+	GroupKaf:                 "Kaf",
+	GroupKaph:                "Kaph",
+	GroupKhaph:               "Khaph",
+	GroupKnottedHeh:          "Knotted_Heh",
+	GroupLam:                 "Lam",
+	GroupLamadh:              "Lamadh",
+	GroupMalayalamBha:        "Malayalam_Bha",
+	GroupMalayalamJa:         "Malayalam_Ja",
+	GroupMalayalamLla:        "Malayalam_Lla",
+	GroupMalayalamLlla:       "Malayalam_Llla",
+	GroupMalayalamNga:        "Malayalam_Nga",
+	GroupMalayalamNna:        "Malayalam_Nna",
+	GroupMalayalamNnna:       "Malayalam_Nnna",
+	GroupMalayalamNya:        "Malayalam_Nya",
+	GroupMalayalamRa:         "Malayalam_Ra",
+	GroupMalayalamSsa:        "Malayalam_Ssa",
+	GroupMalayalamTta:        "Malayalam_Tta",
+	GroupMeem:                "Meem",
+	GroupMim:                 "Mim",
+	GroupNoon:                "Noon",
+	GroupNun:                 "Nun",
+	GroupNya:                 "Nya",
+	GroupPe:                  "Pe",
+	GroupQaf:                 "Qaf",
+	GroupQaph:                "Qaph",
+	GroupReh:                 "Reh",
+	GroupReversedPe:          "Reversed_Pe",
+	GroupRohingyaYeh:         "Rohingya_Yeh",
+	GroupSad:                 "Sad",
+	GroupSadhe:               "Sadhe",
+	GroupSeen:                "Seen",
+	GroupSemkath:             "Semkath",
+	GroupShin:                "Shin",
+	GroupStraightWaw:         "Straight_Waw",
+	GroupSwashKaf:            "Swash_Kaf",
+	GroupSyriacWaw:           "Syriac_Waw",
+	GroupTah:                 "Tah",
+	GroupTaw:                 "Taw",
+	GroupTehMarbuta:          "Teh_Marbuta",
+	GroupTehMarbutaGoal:      "Teh_Marbuta_Goal",
+	GroupTeth:                "Teth",
+	GroupThinYeh:             "Thin_Yeh",
+	GroupVerticalTail:        "Vertical_Tail",
+	GroupWaw:                 "Waw",
+	GroupYeh:                 "Yeh",
+	GroupYehBarree:           "Yeh_Barree",
+	GroupYehWithTail:         "Yeh_With_Tail",
+	GroupYudh:                "Yudh",
+	GroupYudhHe:              "Yudh_He",
+	GroupZain:                "Zain",
+	GroupZhain:               "Zhain",
+}
+
+// joiningTable holds the code points with an explicitly assigned joining type,
+// as ranges sorted by code point.
+var joiningTable = [...]joiningRange{
+	{0x0600, 0x0605, NonJoining, NoJoiningGroup},
+	{0x0608, 0x0608, NonJoining, NoJoiningGroup},
+	{0x060B, 0x060B, NonJoining, NoJoiningGroup},
+	{0x0620, 0x0620, DualJoining, GroupYeh},
+	{0x0621, 0x0621, NonJoining, NoJoiningGroup},
+	{0x0622, 0x0623, RightJoining, GroupAlef},
+	{0x0624, 0x0624, RightJoining, GroupWaw},
+	{0x0625, 0x0625, RightJoining, GroupAlef},
+	{0x0626, 0x0626, DualJoining, GroupYeh},
+	{0x0627, 0x0627, RightJoining, GroupAlef},
+	{0x0628, 0x0628, DualJoining, GroupBeh},
+	{0x0629, 0x0629, RightJoining, GroupTehMarbuta},
+	{0x062A, 0x062B, DualJoining, GroupBeh},
+	{0x062C, 0x062E, DualJoining, GroupHah},
+	{0x062F, 0x0630, RightJoining, GroupDal},
+	{0x0631, 0x0632, RightJoining, GroupReh},
+	{0x0633, 0x0634, DualJoining, GroupSeen},
+	{0x0635, 0x0636, DualJoining, GroupSad},
+	{0x0637, 0x0638, DualJoining, GroupTah},
+	{0x0639, 0x063A, DualJoining, GroupAin},
+	{0x063B, 0x063C, DualJoining, GroupGaf},
+	{0x063D, 0x063F, DualJoining, GroupFarsiYeh},
+	{0x0640, 0x0640, JoinCausing, NoJoiningGroup},
+	{0x0641, 0x0641, DualJoining, GroupFeh},
+	{0x0642, 0x0642, DualJoining, GroupQaf},
+	{0x0643, 0x0643, DualJoining, GroupKaf},
+	{0x0644, 0x0644, DualJoining, GroupLam},
+	{0x0645, 0x0645, DualJoining, GroupMeem},
+	{0x0646, 0x0646, DualJoining, GroupNoon},
+	{0x0647, 0x0647, DualJoining, GroupHeh},
+	{0x0648, 0x0648, RightJoining, GroupWaw},
+	{0x0649, 0x064A, DualJoining, GroupYeh},
+	{0x066E, 0x066E, DualJoining, GroupBeh},
+	{0x066F, 0x066F, DualJoining, GroupQaf},
+	{0x0671, 0x0673, RightJoining, GroupAlef},
+	{0x0674, 0x0674, NonJoining, NoJoiningGroup},
+	{0x0675, 0x0675, RightJoining, GroupAlef},
+	{0x0676, 0x0677, RightJoining, GroupWaw},
+	{0x0678, 0x0678, DualJoining, GroupYeh},
+	{0x0679, 0x0680, DualJoining, GroupBeh},
+	{0x0681, 0x0687, DualJoining, GroupHah},
+	{0x0688, 0x0690, RightJoining, GroupDal},
+	{0x0691, 0x0699, RightJoining, GroupReh},
+	{0x069A, 0x069C, DualJoining, GroupSeen},
+	{0x069D, 0x069E, DualJoining, GroupSad},
+	{0x069F, 0x069F, DualJoining, GroupTah},
+	{0x06A0, 0x06A0, DualJoining, GroupAin},
+	{0x06A1, 0x06A6, DualJoining, GroupFeh},
+	{0x06A7, 0x06A8, DualJoining, GroupQaf},
+	{0x06A9, 0x06A9, DualJoining, GroupGaf},
+	{0x06AA, 0x06AA, DualJoining, GroupSwashKaf},
+	{0x06AB, 0x06AB, DualJoining, GroupGaf},
+	{0x06AC, 0x06AE, DualJoining, GroupKaf},
+	{0x06AF, 0x06B4, DualJoining, GroupGaf},
+	{0x06B5, 0x06B8, DualJoining, GroupLam},
+	{0x06B9, 0x06BC, DualJoining, GroupNoon},
+	{0x06BD, 0x06BD, DualJoining, GroupNya},
+	{0x06BE, 0x06BE, DualJoining, GroupKnottedHeh},
+	{0x06BF, 0x06BF, DualJoining, GroupHah},
+	{0x06C0, 0x06C0, RightJoining, GroupTehMarbuta},
+	{0x06C1, 0x06C2, DualJoining, GroupHehGoal},
+	{0x06C3, 0x06C3, RightJoining, GroupTehMarbutaGoal},
+	{0x06C4, 0x06CB, RightJoining, GroupWaw},
+	{0x06CC, 0x06CC, DualJoining, GroupFarsiYeh},
+	{0x06CD, 0x06CD, RightJoining, GroupYehWithTail},
+	{0x06CE, 0x06CE, DualJoining, GroupFarsiYeh},
+	{0x06CF, 0x06CF, RightJoining, GroupWaw},
+	{0x06D0, 0x06D1, DualJoining, GroupYeh},
+	{0x06D2, 0x06D3, RightJoining, GroupYehBarree},
+	{0x06D5, 0x06D5, RightJoining, GroupTehMarbuta},
+	{0x06DD, 0x06DD, NonJoining, NoJoiningGroup},
+	{0x06EE, 0x06EE, RightJoining, GroupDal},
+	{0x06EF, 0x06EF, RightJoining, GroupReh},
+	{0x06FA, 0x06FA, DualJoining, GroupSeen},
+	{0x06FB, 0x06FB, DualJoining, GroupSad},
+	{0x06FC, 0x06FC, DualJoining, GroupAin},
+	{0x06FF, 0x06FF, DualJoining, GroupKnottedHeh},
+	{0x0710, 0x0710, RightJoining, GroupAlaph},
+	{0x0712, 0x0712, DualJoining, GroupBeth},
+	{0x0713, 0x0714, DualJoining, GroupGamal},
+	{0x0715, 0x0716, RightJoining, GroupDalathRish},
+	{0x0717, 0x0717, RightJoining, GroupHe},
+	{0x0718, 0x0718, RightJoining, GroupSyriacWaw},
+	{0x0719, 0x0719, RightJoining, GroupZain},
+	{0x071A, 0x071A, DualJoining, GroupHeth},
+	{0x071B, 0x071C, DualJoining, GroupTeth},
+	{0x071D, 0x071D, DualJoining, GroupYudh},
+	{0x071E, 0x071E, RightJoining, GroupYudhHe},
+	{0x071F, 0x071F, DualJoining, GroupKaph},
+	{0x0720, 0x0720, DualJoining, GroupLamadh},
+	{0x0721, 0x0721, DualJoining, GroupMim},
+	{0x0722, 0x0722, DualJoining, GroupNun},
+	{0x0723, 0x0723, DualJoining, GroupSemkath},
+	{0x0724, 0x0724, DualJoining, GroupFinalSemkath},
+	{0x0725, 0x0725, DualJoining, GroupE},
+	{0x0726, 0x0726, DualJoining, GroupPe},
+	{0x0727, 0x0727, DualJoining, GroupReversedPe},
+	{0x0728, 0x0728, RightJoining, GroupSadhe},
+	{0x0729, 0x0729, DualJoining, GroupQaph},
+	{0x072A, 0x072A, RightJoining, GroupDalathRish},
+	{0x072B, 0x072B, DualJoining, GroupShin},
+	{0x072C, 0x072C, RightJoining, GroupTaw},
+	{0x072D, 0x072D, DualJoining, GroupBeth},
+	{0x072E, 0x072E, DualJoining, GroupGamal},
+	{0x072F, 0x072F, RightJoining, GroupDalathRish},
+	{0x074D, 0x074D, RightJoining, GroupZhain},
+	{0x074E, 0x074E, DualJoining, GroupKhaph},
+	{0x074F, 0x074F, DualJoining, GroupFe},
+	{0x0750, 0x0756, DualJoining, GroupBeh},
+	{0x0757, 0x0758, DualJoining, GroupHah},
+	{0x0759, 0x075A, RightJoining, GroupDal},
+	{0x075B, 0x075B, RightJoining, GroupReh},
+	{0x075C, 0x075C, DualJoining, GroupSeen},
+	{0x075D, 0x075F, DualJoining, GroupAin},
+	{0x0760, 0x0761, DualJoining, GroupFeh},
+	{0x0762, 0x0764, DualJoining, GroupGaf},
+	{0x0765, 0x0766, DualJoining, GroupMeem},
+	{0x0767, 0x0769, DualJoining, GroupNoon},
+	{0x076A, 0x076A, DualJoining, GroupLam},
+	{0x076B, 0x076C, RightJoining, GroupReh},
+	{0x076D, 0x076D, DualJoining, GroupSeen},
+	{0x076E, 0x076F, DualJoining, GroupHah},
+	{0x0770, 0x0770, DualJoining, GroupSeen},
+	{0x0771, 0x0771, RightJoining, GroupReh},
+	{0x0772, 0x0772, DualJoining, GroupHah},
+	{0x0773, 0x0774, RightJoining, GroupAlef},
+	{0x0775, 0x0776, DualJoining, GroupFarsiYeh},
+	{0x0777, 0x0777, DualJoining, GroupYeh},
+	{0x0778, 0x0779, RightJoining, GroupWaw},
+	{0x077A, 0x077B, DualJoining, GroupBurushaskiYehBarree},
+	{0x077C, 0x077C, DualJoining, GroupHah},
+	{0x077D, 0x077E, DualJoining, GroupSeen},
+	{0x077F, 0x077F, DualJoining, GroupGaf},
+	{0x0860, 0x0860, DualJoining, GroupMalayalamNga},
+	{0x0861, 0x0861, NonJoining, GroupMalayalamJa},
+	{0x0862, 0x0862, DualJoining, GroupMalayalamNya},
+	{0x0863, 0x0863, DualJoining, GroupMalayalamTta},
+	{0x0864, 0x0864, DualJoining, GroupMalayalamNna},
+	{0x0865, 0x0865, DualJoining, GroupMalayalamNnna},
+	{0x0866, 0x0866, NonJoining, GroupMalayalamBha},
+	{0x0867, 0x0867, RightJoining, GroupMalayalamRa},
+	{0x0868, 0x0868, DualJoining, GroupMalayalamLla},
+	{0x0869, 0x0869, RightJoining, GroupMalayalamLlla},
+	{0x086A, 0x086A, RightJoining, GroupMalayalamSsa},
+	{0x0870, 0x0882, RightJoining, GroupAlef},
+	{0x0883, 0x0885, JoinCausing, NoJoiningGroup},
+	{0x0886, 0x0886, DualJoining, GroupThinYeh},
+	{0x0887, 0x0888, NonJoining, NoJoiningGroup},
+	{0x0889, 0x0889, DualJoining, GroupNoon},
+	{0x088A, 0x088A, DualJoining, GroupHah},
+	{0x088B, 0x088C, DualJoining, GroupTah},
+	{0x088D, 0x088D, DualJoining, GroupGaf},
+	{0x088E, 0x088E, RightJoining, GroupVerticalTail},
+	{0x0890, 0x0891, NonJoining, NoJoiningGroup},
+	{0x08A0, 0x08A1, DualJoining, GroupBeh},
+	{0x08A2, 0x08A2, DualJoining, GroupHah},
+	{0x08A3, 0x08A3, DualJoining, GroupTah},
+	{0x08A4, 0x08A4, DualJoining, GroupAfricanFeh},
+	{0x08A5, 0x08A5, DualJoining, GroupAfricanQaf},
+	{0x08A6, 0x08A6, DualJoining, GroupLam},
+	{0x08A7, 0x08A7, DualJoining, GroupMeem},
+	{0x08A8, 0x08A9, DualJoining, GroupYeh},
+	{0x08AA, 0x08AA, RightJoining, GroupReh},
+	{0x08AB, 0x08AB, RightJoining, GroupWaw},
+	{0x08AC, 0x08AC, RightJoining, GroupRohingyaYeh},
+	{0x08AD, 0x08AD, NonJoining, NoJoiningGroup},
+	{0x08AE, 0x08AE, RightJoining, GroupDal},
+	{0x08AF, 0x08AF, DualJoining, GroupSad},
+	{0x08B0, 0x08B0, DualJoining, GroupGaf},
+	{0x08B1, 0x08B1, RightJoining, GroupStraightWaw},
+	{0x08B2, 0x08B2, RightJoining, GroupReh},
+	{0x08B3, 0x08B3, DualJoining, GroupAin},
+	{0x08B4, 0x08B4, DualJoining, GroupKaf},
+	{0x08B5, 0x08B5, DualJoining, GroupQaf},
+	{0x08B6, 0x08B8, DualJoining, GroupBeh},
+	{0x08B9, 0x08B9, RightJoining, GroupReh},
+	{0x08BA, 0x08BA, DualJoining, GroupYeh},
+	{0x08BB, 0x08BB, DualJoining, GroupAfricanFeh},
+	{0x08BC, 0x08BC, DualJoining, GroupAfricanQaf},
+	{0x08BD, 0x08BD, DualJoining, GroupAfricanNoon},
+	{0x08BE, 0x08C0, DualJoining, GroupBeh},
+	{0x08C1, 0x08C1, DualJoining, GroupHah},
+	{0x08C2, 0x08C2, DualJoining, GroupGaf},
+	{0x08C3, 0x08C3, DualJoining, GroupAin},
+	{0x08C4, 0x08C4, DualJoining, GroupAfricanQaf},
+	{0x08C5, 0x08C6, DualJoining, GroupHah},
+	{0x08C7, 0x08C7, DualJoining, GroupLam},
+	{0x08C8, 0x08C8, DualJoining, GroupGaf},
+	{0x08E2, 0x08E2, NonJoining, NoJoiningGroup},
+	{0x1806, 0x1806, NonJoining, NoJoiningGroup},
+	{0x1807, 0x1807, DualJoining, NoJoiningGroup},
+	{0x180A, 0x180A, JoinCausing, NoJoiningGroup},
+	{0x180E, 0x180E, NonJoining, NoJoiningGroup},
+	{0x1820, 0x1878, DualJoining, NoJoiningGroup},
+	{0x1880, 0x1884, NonJoining, NoJoiningGroup},
+	{0x1885, 0x1886, Transparent, NoJoiningGroup},
+	{0x1887, 0x18A8, DualJoining, NoJoiningGroup},
+	{0x18AA, 0x18AA, DualJoining, NoJoiningGroup},
+	{0x200D, 0x200D, JoinCausing, NoJoiningGroup},
+	{0xA840, 0xA871, DualJoining, NoJoiningGroup},
+	{0xA872, 0xA872, LeftJoining, NoJoiningGroup},
+	{0xA873, 0xA873, NonJoining, NoJoiningGroup},
+}
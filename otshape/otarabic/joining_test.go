@@ -1,6 +1,11 @@
 package otarabic
 
-import "testing"
+import (
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+)
 
 func TestResolveJoiningFormsBasic(t *testing.T) {
 	// beh + beh + beh
@@ -50,3 +55,84 @@ func TestResolveJoiningFormsNonArabicAreNone(t *testing.T) {
 		t.Fatalf("latin forms = %v, want [%d %d]", forms, formNone, formNone)
 	}
 }
+
+func TestJoiningLookup(t *testing.T) {
+	cases := []struct {
+		r     rune
+		typ   JoiningType
+		group JoiningGroup
+	}{
+		{'\u0628', DualJoining, GroupBeh},
+		{'\u0627', RightJoining, GroupAlef},
+		{'\u0621', NonJoining, NoJoiningGroup},
+		{'\u0640', JoinCausing, NoJoiningGroup},
+		{'\u200D', JoinCausing, NoJoiningGroup},
+		{'\u064E', Transparent, NoJoiningGroup},
+		{'\u0301', Transparent, NoJoiningGroup},
+		{'\u0717', RightJoining, GroupHe},
+		{'\u0710', RightJoining, GroupAlaph},
+		{'\u200C', NonJoining, NoJoiningGroup},
+		{'A', NonJoining, NoJoiningGroup},
+	}
+	for _, c := range cases {
+		typ, group := Joining(c.r)
+		if typ != c.typ || group != c.group {
+			t.Errorf("Joining(%U) = (%s, %s), want (%s, %s)", c.r, typ, group, c.typ, c.group)
+		}
+	}
+}
+
+func TestJoiningFormsSyriacAlaph(t *testing.T) {
+	cases := []struct {
+		text  []rune
+		forms []JoiningForm
+	}{
+		{[]rune{'\u0712', '\u0710'}, []JoiningForm{Initial, Final}},   // beth alaph
+		{[]rune{'\u0718', '\u0710'}, []JoiningForm{Isolated, Final2}}, // waw alaph
+		{[]rune{'\u0715', '\u0710'}, []JoiningForm{Isolated, Final3}}, // dalath alaph
+		{[]rune{'\u0712', '\u0710', '\u0712'}, []JoiningForm{Initial, Medial2, Isolated}},
+		{[]rune{'\u0628', '\u0640', '\u0628'}, []JoiningForm{Initial, Medial, Final}}, // tatweel joins
+		{[]rune{'\u0628', '\u200C', '\u0628'}, []JoiningForm{Isolated, NoForm, Isolated}},
+	}
+	for _, c := range cases {
+		forms := JoiningForms(c.text)
+		if !slices.Equal(forms, c.forms) {
+			t.Errorf("JoiningForms(%U) = %v, want %v", c.text, forms, c.forms)
+		}
+	}
+}
+
+func TestJoiningFormFeature(t *testing.T) {
+	if tag := Initial.Feature(); tag != ot.T("init") {
+		t.Errorf("Initial.Feature() = %s, want init", tag)
+	}
+	if tag := Final3.Feature(); tag != ot.T("fin3") {
+		t.Errorf("Final3.Feature() = %s, want fin3", tag)
+	}
+	if tag := NoForm.Feature(); tag != 0 {
+		t.Errorf("NoForm.Feature() = %s, want 0", tag)
+	}
+}
+
+func TestJoiningFormsMongolianAndPhagsPa(t *testing.T) {
+	cases := []struct {
+		text  []rune
+		forms []JoiningForm
+	}{
+		{[]rune{'ᠠ', 'ᠡ', 'ᠢ'}, []JoiningForm{Initial, Medial, Final}},
+		// free variation selectors take the form of the preceding letter
+		{[]rune{'ᠠ', 'ᠡ', '᠋', 'ᠢ', '᠌'}, []JoiningForm{Initial, Medial, Medial, Final, Final}},
+		{[]rune{'ᠠ', '᠏'}, []JoiningForm{Isolated, Isolated}},
+		// the vowel separator does not join
+		{[]rune{'ᠨ', '᠎', 'ᠠ'}, []JoiningForm{Isolated, NoForm, Isolated}},
+		{[]rune{'ꡀ', 'ꡁ', 'ꡂ'}, []JoiningForm{Initial, Medial, Final}},
+		// superfixed ra joins to the following letter only
+		{[]rune{'ꡀ', 'ꡲ', 'ꡁ'}, []JoiningForm{Isolated, Initial, Final}},
+	}
+	for _, c := range cases {
+		forms := JoiningForms(c.text)
+		if !slices.Equal(forms, c.forms) {
+			t.Errorf("JoiningForms(%U) = %v, want %v", c.text, forms, c.forms)
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package otarabic_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otarabic"
+	"github.com/npillmayer/opentype/otshape/otcore"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+func TestShapeMongolianVariationSelectors(t *testing.T) {
+	b := testfont.New(7)
+	for i, name := range []string{"a", "a.init", "a.medi", "a.fina", "fvs1", "a.medi.fvs1"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('ᠠ', 1).Map('᠋', 5)
+	err := b.Features(`languagesystem mong dflt;
+feature init { sub a by a.init; } init;
+feature medi { sub a fvs1 by a.medi.fvs1; sub a by a.medi; } medi;
+feature fina { sub a by a.fina; } fina;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	font, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	params := otshape.Params{
+		Font:      font,
+		Direction: bidi.LeftToRight,
+		Script:    language.MustParseScript("Mong"),
+		Language:  language.Make("mn"),
+	}
+	shaper := otshape.NewShaper(otarabic.New(), otcore.New())
+	sink := &glyphCollector{}
+	if err := shaper.Shape(params, strings.NewReader("ᠠᠠ᠋ᠠ"), sink, otshape.BufferOptions{}); err != nil {
+		t.Fatalf("shape failed: %v", err)
+	}
+	var gids []ot.GlyphIndex
+	for _, g := range sink.glyphs {
+		gids = append(gids, g.GID)
+	}
+	if want := []ot.GlyphIndex{2, 6, 4}; !slices.Equal(gids, want) {
+		t.Errorf("expected glyphs %v (init, medi with FVS1, fina), have %v", want, gids)
+	}
+}
+
+func TestShapeArabicRequiredLigatureAfterJoiningForms(t *testing.T) {
+	b := testfont.New(5)
+	for i, name := range []string{"beh", "beh.init", "beh.fina", "beh_beh"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('ب', 1)
+	err := b.Features(`languagesystem arab dflt;
+feature rlig { sub beh.init beh.fina by beh_beh; } rlig;
+feature init { sub beh by beh.init; } init;
+feature fina { sub beh by beh.fina; } fina;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	font, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	params := otshape.Params{
+		Font:      font,
+		Direction: bidi.RightToLeft,
+		Script:    language.MustParseScript("Arab"),
+		Language:  language.Arabic,
+	}
+	sink := &glyphCollector{}
+	if err := otshape.NewShaper(otarabic.New()).Shape(params, strings.NewReader("بب"), sink, otshape.BufferOptions{}); err != nil {
+		t.Fatalf("shape failed: %v", err)
+	}
+	if len(sink.glyphs) != 1 || sink.glyphs[0].GID != 4 {
+		t.Errorf("expected required ligature of joining forms, have %v", sink.glyphs)
+	}
+}
@@ -2,6 +2,7 @@ package otarabic
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"unicode"
@@ -16,67 +17,46 @@ import (
 )
 
 var (
-	arabicScript = language.MustParseScript("Arab")
-	syriacScript = language.MustParseScript("Syrc")
+	arabicScript    = language.MustParseScript("Arab")
+	syriacScript    = language.MustParseScript("Syrc")
+	mongolianScript = language.MustParseScript("Mong")
+	phagsPaScript   = language.MustParseScript("Phag")
 )
 
 var (
 	tagStch = ot.T("stch")
-	tagCCMP = ot.T("ccmp")
 	tagLocl = ot.T("locl")
-	tagIsol = ot.T("isol")
-	tagFina = ot.T("fina")
-	tagFin2 = ot.T("fin2")
-	tagFin3 = ot.T("fin3")
-	tagMedi = ot.T("medi")
-	tagMed2 = ot.T("med2")
 	tagInit = ot.T("init")
 	tagRlig = ot.T("rlig")
-	tagCalt = ot.T("calt")
-	tagRclt = ot.T("rclt")
-	tagLiga = ot.T("liga")
-	tagClig = ot.T("clig")
-	tagMset = ot.T("mset")
 )
 
-var arabicFormFeatureTags = [...]ot.Tag{
-	tagIsol, tagFina, tagFin2, tagFin3, tagMedi, tagMed2, tagInit,
-}
-
-const (
-	formNone  = -1
-	formIsol  = 0
-	formFina  = 1
-	formFin2  = 2
-	formFin3  = 3
-	formMedi  = 4
-	formMed2  = 5
-	formInit  = 6
-	formCount = 7
-)
-
-type joiningType uint8
+var arabicFormFeatureTags = otshape.JoiningFormFeatures()
 
+// Joining forms as plain integers, as used by the joining state machine.
 const (
-	joiningTypeU joiningType = iota
-	joiningTypeR
-	joiningTypeD
-	joiningTypeT
-	joiningTypeC
+	formNone  = int(otshape.NoJoiningForm)
+	formIsol  = int(otshape.JoiningIsolated)
+	formFina  = int(otshape.JoiningFinal)
+	formFin2  = int(otshape.JoiningFinal2)
+	formFin3  = int(otshape.JoiningFinal3)
+	formMedi  = int(otshape.JoiningMedial)
+	formMed2  = int(otshape.JoiningMedial2)
+	formInit  = int(otshape.JoiningInitial)
+	formCount = formInit + 1
 )
 
 type shaperPlanState struct {
 	font              *ot.Font
 	script            language.Script
-	maskArray         [formCount]uint32
-	formMask          uint32
+	masks             otshape.JoiningMasks
 	hasStch           bool
 	stchMask          uint32
 	hasNotdefFallback bool
 	fallbackGlyph     map[rune]glyphForms
 }
 
-// Shaper is the Arabic/Syriac shaping engine.
+// Shaper is the shaping engine for Arabic, Syriac and other joining scripts,
+// i.e., Mongolian and Phags-pa.
 //
 // This step ports plan-time Arabic feature staging and runtime form-mask
 // assignment. Joining details are intentionally conservative and may be
@@ -96,7 +76,7 @@ var _ otshape.ShapingEngineReorderHook = (*Shaper)(nil)
 var _ otshape.ShapingEngineMaskHook = (*Shaper)(nil)
 var _ otshape.ShapingEnginePostprocessHook = (*Shaper)(nil)
 
-// New returns a new Arabic/Syriac shaping engine instance.
+// New returns a new shaping engine instance for joining scripts.
 func New() otshape.ShapingEngine {
 	return &Shaper{}
 }
@@ -108,8 +88,10 @@ func (Shaper) Name() string {
 
 // Match reports how suitable this engine is for ctx.
 //
-// It supports Arabic and Syriac scripts in left-to-right or right-to-left
-// segment directions and returns a confidence score used for engine selection.
+// It supports Arabic, Syriac, Mongolian and Phags-pa scripts in left-to-right
+// or right-to-left segment directions and returns a confidence score used for
+// engine selection. Mongolian and Phags-pa, written vertically, are shaped
+// left-to-right, like rotated horizontal text.
 func (Shaper) Match(ctx otshape.SelectionContext) otshape.ShaperConfidence {
 	if ctx.Direction != bidi.LeftToRight && ctx.Direction != bidi.RightToLeft {
 		return otshape.ShaperConfidenceNone
@@ -120,10 +102,14 @@ func (Shaper) Match(ctx otshape.SelectionContext) otshape.ShaperConfidence {
 	if ctx.Script == syriacScript || ctx.ScriptTag == ot.T("syrc") {
 		return otshape.ShaperConfidenceHigh
 	}
+	if ctx.Script == mongolianScript || ctx.ScriptTag == ot.T("mong") ||
+		ctx.Script == phagsPaScript || ctx.ScriptTag == ot.T("phag") {
+		return otshape.ShaperConfidenceHigh
+	}
 	return otshape.ShaperConfidenceNone
 }
 
-// New returns a new independent Arabic/Syriac engine instance.
+// New returns a new independent engine instance for joining scripts.
 func (Shaper) New() otshape.ShapingEngine {
 	return &Shaper{}
 }
@@ -146,40 +132,48 @@ func noPauseHook(otshape.PauseContext) error {
 	return nil
 }
 
-// CollectFeatures registers Arabic/Syriac GSUB feature stages for ctx.
+// CollectFeatures registers the GSUB feature stages of joining scripts for ctx.
 //
-// It defines feature order, lookup flags, and pause boundaries that are later
-// compiled into the plan program.
+// Features are taken in the order of the default features of the script (see
+// otshape.DefaultScriptFeatures), with lookup flags and pause boundaries of
+// joining scripts. Joining forms missing from the defaults are added, as masks
+// are set up for them.
 func (s *Shaper) CollectFeatures(plan otshape.FeaturePlanner, ctx otshape.SelectionContext) {
-	plan.AddFeature(tagStch, otshape.FeatureNone, 1)
-	plan.AddGSUBPause(noPauseHook)
-
-	plan.AddFeature(tagCCMP, otshape.FeatureManualZWJ, 1)
-	plan.AddFeature(tagLocl, otshape.FeatureManualZWJ, 1)
-	plan.AddGSUBPause(noPauseHook)
-
+	for _, tag := range otshape.DefaultScriptFeatures(ctx.Script).GSUB {
+		plan.AddFeature(tag, joiningFeatureFlags(tag, ctx), 1)
+		switch {
+		case tag == tagStch, tag == tagLocl, isFormFeature(tag):
+			plan.AddGSUBPause(noPauseHook)
+		case tag == tagRlig && ctx.Script == arabicScript:
+			plan.AddGSUBPause(noPauseHook)
+		}
+	}
 	for _, tag := range arabicFormFeatureTags {
-		flags := otshape.FeatureManualZWJ
-		if ctx.Script == arabicScript && !featureIsSyriac(tag) {
-			flags |= otshape.FeatureHasFallback
+		if !plan.HasFeature(tag) {
+			plan.AddFeature(tag, joiningFeatureFlags(tag, ctx), 1)
 		}
-		plan.AddFeature(tag, flags, 1)
-		plan.AddGSUBPause(noPauseHook)
 	}
+}
 
-	plan.AddFeature(tagRlig, otshape.FeatureManualZWJ|otshape.FeatureHasFallback, 1)
-	if ctx.Script == arabicScript {
-		plan.AddGSUBPause(noPauseHook)
+// joiningFeatureFlags returns the flags of GSUB feature tag for joining
+// scripts. Joining forms apply to the glyphs enabled in SetupMasks only. Arabic
+// has fallback shaping for joining forms and required ligatures.
+func joiningFeatureFlags(tag ot.Tag, ctx otshape.SelectionContext) otshape.FeatureFlags {
+	if tag == tagStch {
+		return otshape.FeatureNone
 	}
-
-	plan.AddFeature(tagCalt, otshape.FeatureManualZWJ, 1)
-	if !plan.HasFeature(tagRclt) {
-		plan.AddGSUBPause(noPauseHook)
-		plan.AddFeature(tagRclt, otshape.FeatureManualZWJ, 1)
+	flags := otshape.FeatureManualZWJ
+	if isFormFeature(tag) {
+		flags |= otshape.FeatureManualMask
+	}
+	if tag == tagRlig || isFormFeature(tag) && ctx.Script == arabicScript && !featureIsSyriac(tag) {
+		flags |= otshape.FeatureHasFallback
 	}
-	plan.AddFeature(tagLiga, otshape.FeatureManualZWJ, 1)
-	plan.AddFeature(tagClig, otshape.FeatureManualZWJ, 1)
-	plan.AddFeature(tagMset, otshape.FeatureManualZWJ, 1)
+	return flags
+}
+
+func isFormFeature(tag ot.Tag) bool {
+	return slices.Contains(arabicFormFeatureTags, tag)
 }
 
 // OverrideFeatures allows a shaper to force feature toggles after collection.
@@ -200,11 +194,7 @@ func (s *Shaper) InitPlan(plan otshape.PlanContext) {
 		stchMask:          plan.FeatureMask1(tagStch),
 		hasNotdefFallback: planNeedsArabicFallback(plan),
 	}
-	for i, tag := range arabicFormFeatureTags {
-		m := plan.FeatureMask1(tag)
-		s.plan.maskArray[i] = m
-		s.plan.formMask |= m
-	}
+	s.plan.masks = otshape.NewJoiningMasks(plan)
 	if s.plan.hasNotdefFallback && hasUsableCMap(s.plan.font) {
 		s.plan.fallbackGlyph = buildFallbackGlyphMap(s.plan.font)
 	}
@@ -313,9 +303,9 @@ func (s *Shaper) ReorderMarks(run otshape.RunContext, start, end int) {
 	}
 }
 
-// SetupMasks writes Arabic joining-form masks into run glyph masks.
+// SetupMasks enables the joining form feature of each glyph of run.
 func (s *Shaper) SetupMasks(run otshape.RunContext) {
-	if s.plan.formMask == 0 {
+	if s.plan.masks.All() == 0 {
 		return
 	}
 	n := run.Len()
@@ -328,26 +318,16 @@ func (s *Shaper) SetupMasks(run otshape.RunContext) {
 		forms = resolveJoiningForms(cps)
 	}
 	for i := 0; i < n; i++ {
-		m := run.Mask(i) &^ s.plan.formMask
-		mask := s.maskForForm(forms[i])
-		if mask != 0 {
-			m |= mask
-		}
-		run.SetMask(i, m)
+		s.plan.masks.Set(run, i, JoiningForm(forms[i]))
 	}
 }
 
-func (s *Shaper) maskForForm(form int) uint32 {
-	if form < 0 || form >= len(s.plan.maskArray) {
-		return 0
-	}
-	return s.plan.maskArray[form]
-}
-
 // PostprocessRun applies post-GSUB Arabic adjustments to run.
 //
 // This includes optional tatweel expansion for "stch" and strict ".notdef"
-// replacement for recoverable Arabic fallback cases.
+// replacement for recoverable Arabic fallback cases. Stretching of glyphs
+// decomposed by feature "stch" to a target width is left to the post-shaping
+// pass StretchGlyphs.
 func (s *Shaper) PostprocessRun(run otshape.RunContext) {
 	defer func() {
 		if s.preparedForm != nil {
@@ -396,113 +376,6 @@ func (s *Shaper) PostprocessRun(run otshape.RunContext) {
 	}
 }
 
-func resolveJoiningForms(cps []rune) []int {
-	n := len(cps)
-	forms := make([]int, n)
-	if n == 0 {
-		return forms
-	}
-	for i := range forms {
-		forms[i] = formNone
-	}
-	types := make([]joiningType, n)
-	for i, cp := range cps {
-		types[i] = classifyJoiningType(cp)
-	}
-	for i := 0; i < n; i++ {
-		t := types[i]
-		if t != joiningTypeD && t != joiningTypeR {
-			continue
-		}
-
-		prev := previousJoinType(types, i)
-		next := nextJoinType(types, i)
-
-		joinPrev := prev >= 0 && canJoinFollowing(types[prev]) && canJoinPreceding(t)
-		joinNext := next >= 0 && canJoinFollowing(t) && canJoinPreceding(types[next])
-
-		switch {
-		case joinPrev && joinNext:
-			forms[i] = formMedi
-		case joinPrev:
-			forms[i] = formFina
-		case joinNext:
-			forms[i] = formInit
-		default:
-			forms[i] = formIsol
-		}
-	}
-	return forms
-}
-
-func previousJoinType(types []joiningType, i int) int {
-	for j := i - 1; j >= 0; j-- {
-		if types[j] != joiningTypeT {
-			return j
-		}
-	}
-	return -1
-}
-
-func nextJoinType(types []joiningType, i int) int {
-	for j := i + 1; j < len(types); j++ {
-		if types[j] != joiningTypeT {
-			return j
-		}
-	}
-	return -1
-}
-
-func canJoinPreceding(t joiningType) bool {
-	return t == joiningTypeD || t == joiningTypeR || t == joiningTypeC
-}
-
-func canJoinFollowing(t joiningType) bool {
-	return t == joiningTypeD || t == joiningTypeC
-}
-
-func classifyJoiningType(cp rune) joiningType {
-	if cp == 0 {
-		return joiningTypeU
-	}
-	if cp == '\u200C' { // ZWNJ explicitly breaks joining.
-		return joiningTypeU
-	}
-	if cp == '\u200D' || cp == '\u0640' { // ZWJ, Tatweel
-		return joiningTypeC
-	}
-	if unicode.Is(unicode.M, cp) {
-		return joiningTypeT
-	}
-	if isRightJoining(cp) {
-		return joiningTypeR
-	}
-	if isArabicJoiningLetter(cp) {
-		return joiningTypeD
-	}
-	return joiningTypeU
-}
-
-func isArabicJoiningLetter(cp rune) bool {
-	if unicode.IsLetter(cp) && (unicode.In(cp, unicode.Arabic) || unicode.In(cp, unicode.Syriac)) {
-		return true
-	}
-	return false
-}
-
-var rightJoiningRunes = map[rune]struct{}{
-	'\u0622': {}, '\u0623': {}, '\u0624': {}, '\u0625': {}, '\u0627': {}, '\u0629': {},
-	'\u062F': {}, '\u0630': {}, '\u0631': {}, '\u0632': {}, '\u0648': {},
-	'\u0671': {}, '\u0672': {}, '\u0673': {}, '\u0675': {}, '\u0676': {}, '\u0677': {},
-	'\u0688': {}, '\u0689': {}, '\u0691': {}, '\u06C0': {}, '\u06C3': {}, '\u06C4': {}, '\u06C5': {}, '\u06C6': {}, '\u06C7': {}, '\u06C8': {}, '\u06C9': {}, '\u06CA': {}, '\u06CB': {}, '\u06CD': {},
-	'\u0710': {}, '\u0715': {}, '\u0716': {}, '\u0718': {}, '\u0719': {}, '\u071A': {}, '\u071D': {}, '\u072A': {}, '\u072B': {}, '\u072C': {}, '\u072D': {}, '\u072E': {}, '\u072F': {},
-}
-
-func isRightJoining(cp rune) bool {
-	_, ok := rightJoiningRunes[cp]
-	return ok
-}
-
 func isModifierCombiningMark(cp rune) bool {
 	_, ok := modifierCombiningMarks[cp]
 	return ok
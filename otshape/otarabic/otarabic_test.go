@@ -28,6 +28,15 @@ func TestShaperMatchArabicAndSyriac(t *testing.T) {
 		t.Fatalf("expected Syriac match, got %d", got)
 	}
 
+	for _, script := range []string{"Mong", "Phag"} {
+		if got := s.Match(otshape.SelectionContext{
+			Script:    language.MustParseScript(script),
+			Direction: bidi.LeftToRight,
+		}); got <= otshape.ShaperConfidenceNone {
+			t.Fatalf("expected %s match, got %d", script, got)
+		}
+	}
+
 	if got := s.Match(otshape.SelectionContext{
 		Script:    language.MustParseScript("Arab"),
 		Direction: bidi.Mixed,
@@ -207,6 +216,9 @@ func (r *runProbe) InsertGlyphs(index int, glyphs []ot.GlyphIndex) {
 func (r *runProbe) InsertGlyphCopies(index int, source int, count int) {
 	_, _, _ = index, source, count
 }
+func (r *runProbe) DeleteGlyphs(start, end int) {
+	_, _ = start, end
+}
 func (r *runProbe) Swap(i, j int) {
 	r.codepoints[i], r.codepoints[j] = r.codepoints[j], r.codepoints[i]
 	r.masks[i], r.masks[j] = r.masks[j], r.masks[i]
@@ -89,6 +89,11 @@ func (r *postRun) InsertGlyphCopies(index int, source int, count int) {
 	r.cps = append(r.cps[:index:index], append(cps, r.cps[index:]...)...)
 	r.masks = append(r.masks[:index:index], append(masks, r.masks[index:]...)...)
 }
+func (r *postRun) DeleteGlyphs(start, end int) {
+	r.glyphs = append(r.glyphs[:start], r.glyphs[end:]...)
+	r.cps = append(r.cps[:start], r.cps[end:]...)
+	r.masks = append(r.masks[:start], r.masks[end:]...)
+}
 func (r *postRun) Swap(i, j int) {
 	r.glyphs[i], r.glyphs[j] = r.glyphs[j], r.glyphs[i]
 	r.cps[i], r.cps[j] = r.cps[j], r.cps[i]
@@ -264,3 +269,50 @@ func TestExpandTatweelForStchHonorsMaskGate(t *testing.T) {
 		t.Fatalf("glyph length=%d, want 1", len(run.glyphs))
 	}
 }
+
+func TestStretchGlyphsRepeatsRepeatingParts(t *testing.T) {
+	rec := func(gid ot.GlyphIndex, cluster uint32, adv int32) otshape.GlyphRecord {
+		return otshape.GlyphRecord{GID: gid, Cluster: cluster, Pos: otlayout.PosItem{XAdvance: adv, AttachTo: -1}}
+	}
+	glyphs := []otshape.GlyphRecord{
+		rec(1, 0, 300),
+		rec(5, 1, 100), rec(6, 1, 50), rec(7, 1, 100), // fixed, repeating, fixed
+		rec(2, 2, 300),
+	}
+	out := StretchGlyphs(nil, glyphs, 1, 425)
+	wantGIDs := []ot.GlyphIndex{1, 5, 6, 6, 6, 6, 6, 7, 2}
+	if len(out) != len(wantGIDs) {
+		t.Fatalf("stretched length = %d, want %d", len(out), len(wantGIDs))
+	}
+	var width int32
+	for i, g := range out {
+		if g.GID != wantGIDs[i] {
+			t.Fatalf("glyph[%d] = %d, want %d", i, g.GID, wantGIDs[i])
+		}
+		if g.GID == 6 && g.Cluster != 1 {
+			t.Fatalf("copy %d has cluster %d, want 1", i, g.Cluster)
+		}
+		if g.Cluster == 1 {
+			width += g.Pos.XAdvance
+		}
+	}
+	if width != 425 {
+		t.Fatalf("stretched width = %d, want 425", width)
+	}
+	if len(glyphs) != 5 {
+		t.Fatalf("input has been modified")
+	}
+}
+
+func TestStretchGlyphsKeepsWideEnoughSequence(t *testing.T) {
+	glyphs := []otshape.GlyphRecord{
+		{GID: 5, Pos: otlayout.PosItem{XAdvance: 100}},
+		{GID: 6, Pos: otlayout.PosItem{XAdvance: 50}},
+	}
+	if out := StretchGlyphs(nil, glyphs, 0, 120); len(out) != 2 {
+		t.Fatalf("stretched length = %d, want 2", len(out))
+	}
+	if out := StretchGlyphs(nil, glyphs, 7, 500); len(out) != 2 {
+		t.Fatalf("stretched unknown cluster to length %d, want 2", len(out))
+	}
+}
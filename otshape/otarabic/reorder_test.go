@@ -72,6 +72,9 @@ func (r *reorderRun) InsertGlyphs(index int, glyphs []ot.GlyphIndex) {
 func (r *reorderRun) InsertGlyphCopies(index int, source int, count int) {
 	_, _, _ = index, source, count
 }
+func (r *reorderRun) DeleteGlyphs(start, end int) {
+	_, _ = start, end
+}
 func (r *reorderRun) Swap(i, j int) {
 	r.glyphs[i], r.glyphs[j] = r.glyphs[j], r.glyphs[i]
 	r.cps[i], r.cps[j] = r.cps[j], r.cps[i]
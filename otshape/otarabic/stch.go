@@ -0,0 +1,87 @@
+package otarabic
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+	"github.com/npillmayer/opentype/otshape"
+)
+
+// StretchGlyphs is a post-shaping pass for feature 'stch' (stretching glyph
+// decomposition), used e.g. for the Syriac Abbreviation Mark U+070F.
+//
+// Feature 'stch' decomposes a stretching glyph into a sequence of components,
+// alternating between fixed and repeating parts and starting with a fixed part.
+// StretchGlyphs looks for the glyphs of cluster in glyphs and repeats the
+// repeating parts until the sequence spans width font units. Repeated parts
+// are slightly overlapped to meet width exactly; this is invisible for the
+// connecting strokes fonts use as repeating parts.
+//
+// Component widths are taken from the advances of font, or from the advances
+// of the glyph records if font is nil. Advances of the components in the
+// result are set to their (possibly overlapped) widths, with all other
+// positioning of a component retained for its copies.
+//
+// If cluster is not found, consists of a single glyph only, or is at least
+// width units wide already, glyphs is returned unchanged. Otherwise a new slice
+// is returned.
+func StretchGlyphs(font *ot.Font, glyphs []otshape.GlyphRecord, cluster uint32, width int32) []otshape.GlyphRecord {
+	start, end := clusterRange(glyphs, cluster)
+	if end-start < 2 {
+		return glyphs
+	}
+	comps := glyphs[start:end]
+	advance := func(g otshape.GlyphRecord) int32 {
+		if font == nil {
+			return g.Pos.XAdvance
+		}
+		return int32(otquery.GlyphMetrics(font, g.GID).Advance)
+	}
+	var wFixed, wRepeat int32
+	for i, g := range comps {
+		if i%2 == 0 {
+			wFixed += advance(g)
+		} else {
+			wRepeat += advance(g)
+		}
+	}
+	if wRepeat <= 0 || width <= wFixed+wRepeat {
+		return glyphs
+	}
+	rest := width - wFixed
+	n := (rest + wRepeat - 1) / wRepeat // copies of each repeating part
+	overlap := n*wRepeat - rest
+	repeating := n * int32(len(comps)/2) // total number of repeating glyphs
+	out := make([]otshape.GlyphRecord, 0, len(glyphs)-len(comps)+int(n)*len(comps))
+	out = append(out, glyphs[:start]...)
+	k := int32(0)
+	for i, g := range comps {
+		g.Pos.XAdvance = advance(g)
+		if i%2 == 0 {
+			out = append(out, g)
+			continue
+		}
+		for range n {
+			c := g
+			c.Pos.XAdvance -= overlap / repeating
+			if k < overlap%repeating {
+				c.Pos.XAdvance--
+			}
+			k++
+			out = append(out, c)
+		}
+	}
+	return append(out, glyphs[end:]...)
+}
+
+// clusterRange returns the range of consecutive glyphs belonging to cluster.
+func clusterRange(glyphs []otshape.GlyphRecord, cluster uint32) (int, int) {
+	start := 0
+	for start < len(glyphs) && glyphs[start].Cluster != cluster {
+		start++
+	}
+	end := start
+	for end < len(glyphs) && glyphs[end].Cluster == cluster {
+		end++
+	}
+	return start, end
+}
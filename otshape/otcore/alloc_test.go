@@ -0,0 +1,17 @@
+//go:build !race
+
+package otcore_test
+
+import "testing"
+
+// TestShapeLatinZeroAlloc is excluded from race-enabled builds, as the race
+// detector instruments code with allocations of its own.
+func TestShapeLatinZeroAlloc(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping allocation test in short mode")
+	}
+	shape := latinShapeFunc(t)
+	if n := testing.AllocsPerRun(20, shape); n != 0 {
+		t.Errorf("expected warmed-up Latin shaping to be allocation-free, have %.1f allocs/run", n)
+	}
+}
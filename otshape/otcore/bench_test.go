@@ -0,0 +1,40 @@
+package otcore_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otcore"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+const latinSample = "Hello, World! Office affine."
+
+type discardSink struct{ n int }
+
+func (s *discardSink) WriteGlyph(otshape.GlyphRecord) error { s.n++; return nil }
+
+// latinShapeFunc returns a function which shapes latinSample with a re-used shaper.
+func latinShapeFunc(t testing.TB) func() {
+	font := loadRootOTFont(t, "GentiumPlus-R.ttf")
+	params := otshape.Params{Font: font, Direction: bidi.LeftToRight, Script: language.MustParseScript("Latn"), Language: language.English}
+	shaper := otshape.NewShaper(otcore.New())
+	src := strings.NewReader("")
+	sink := &discardSink{}
+	return func() {
+		src.Reset(latinSample)
+		if err := shaper.Shape(params, src, sink, otshape.BufferOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkShapeLatin(b *testing.B) {
+	shape := latinShapeFunc(b)
+	b.ReportAllocs()
+	for b.Loop() {
+		shape()
+	}
+}
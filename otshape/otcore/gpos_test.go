@@ -472,10 +472,8 @@ func maxGlyphCount(font *ot.Font) int {
 	if font == nil {
 		return ot.MaxGlyphCount
 	}
-	if maxp := font.Table(ot.T("maxp")); maxp != nil {
-		if t := maxp.Self().AsMaxP(); t != nil && t.NumGlyphs > 0 {
-			return t.NumGlyphs
-		}
+	if maxp, ok := ot.TableOf[*ot.MaxPTable](font); ok && maxp.NumGlyphs > 0 {
+		return maxp.NumGlyphs
 	}
 	return ot.MaxGlyphCount
 }
@@ -506,7 +504,7 @@ func assertPosDelta(t *testing.T, got otlayout.PosItem, want otlayout.PosItem) {
 	}
 }
 
-func loadRootOTFont(t *testing.T, filename string) *ot.Font {
+func loadRootOTFont(t testing.TB, filename string) *ot.Font {
 	t.Helper()
 	path := filepath.Join("..", "..", "testdata", "fonts", filename)
 	sf, err := fontload.LoadOpenTypeFont(path)
@@ -0,0 +1,58 @@
+package otcore_test
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otcore"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+func TestBreakMatchesShapingLines(t *testing.T) {
+	font := loadRootOTFont(t, "GentiumPlus-R.ttf")
+	params := otshape.Params{Font: font, Direction: bidi.LeftToRight, Script: language.MustParseScript("Latn"), Language: language.English}
+	text := []rune("Official Waltz of the fluffy AVATAR affine Tyrant, Yoke office.")
+	shaper := otshape.NewShaper(otcore.New())
+	shape := func(runes []rune, offset int) []otshape.GlyphRecord {
+		var sink collectingSink
+		if err := shaper.Shape(params, strings.NewReader(string(runes)), &sink, otshape.BufferOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		for i := range sink.glyphs {
+			sink.glyphs[i].Cluster += uint32(offset)
+		}
+		return sink.glyphs
+	}
+	glyphs := shape(text, 0)
+	orig := slices.Clone(glyphs)
+	for brk := 1; brk < len(text); brk++ {
+		for _, hyphen := range [][]rune{nil, []rune("-")} {
+			first, second, err := shaper.Break(params, text, glyphs, otshape.LineBreak{Index: brk, Hyphen: hyphen}, otshape.BufferOptions{})
+			if err != nil {
+				t.Fatalf("break at %d: %v", brk, err)
+			}
+			wantFirst := shape(slices.Concat(text[:brk], hyphen), 0)
+			wantSecond := shape(text[brk:], brk)
+			if !reflect.DeepEqual(first, wantFirst) || !reflect.DeepEqual(second, wantSecond) {
+				t.Errorf("break at %d (hyphen %q): lines differ from lines shaped from scratch", brk, string(hyphen))
+			}
+		}
+	}
+	if !reflect.DeepEqual(glyphs, orig) {
+		t.Errorf("expected glyphs of run to be unchanged")
+	}
+	start, end, err := shaper.BreakWindow(params, glyphs, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if start > 30 || end < 30 || end-start >= len(text)/2 {
+		t.Errorf("expected a small window around the break, have [%d:%d)", start, end)
+	}
+	if _, _, err := shaper.Break(params, text, glyphs, otshape.LineBreak{Index: len(text)}, otshape.BufferOptions{}); err == nil {
+		t.Errorf("expected break at end of text to be rejected")
+	}
+}
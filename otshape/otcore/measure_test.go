@@ -0,0 +1,46 @@
+package otcore_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/otquery"
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otcore"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+func TestMeasure(t *testing.T) {
+	font := loadRootOTFont(t, "GentiumPlus-R.ttf")
+	params := otshape.Params{Font: font, Direction: bidi.LeftToRight, Script: language.MustParseScript("Latn"), Language: language.English}
+	measurer := otshape.NewShaper(otcore.New())
+	for _, text := range []string{latinSample, "Tyrant AVATAR", "fig. ÅÇ", "Waltz ǵ"} {
+		var sink collectingSink
+		if err := otshape.NewShaper(otcore.New()).Shape(params, strings.NewReader(text), &sink, otshape.BufferOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		var wantWidth, wantAscent, wantDescent int
+		for _, g := range sink.glyphs {
+			wantWidth += int(g.Pos.XAdvance)
+			if bbox := otquery.GlyphMetrics(font, g.GID).BBox; !bbox.IsEmpty() {
+				wantAscent = max(wantAscent, int(bbox.MaxY)+int(g.Pos.YOffset))
+				wantDescent = max(wantDescent, -int(bbox.MinY)-int(g.Pos.YOffset))
+			}
+		}
+		opts := otshape.MeasureOptions{Params: params, Shaper: measurer}
+		width, ascent, descent := otshape.Measure(text, opts)
+		if width != wantWidth || ascent != wantAscent || descent != wantDescent {
+			t.Errorf("%q: expected metrics %d/%d/%d, have %d/%d/%d", text,
+				wantWidth, wantAscent, wantDescent, width, ascent, descent)
+		}
+		opts.WidthOnly = true
+		width, ascent, descent = otshape.Measure(text, opts)
+		if width != wantWidth || ascent != 0 || descent != 0 {
+			t.Errorf("%q: expected width-only metrics %d/0/0, have %d/%d/%d", text, wantWidth, width, ascent, descent)
+		}
+	}
+	if w, a, d := otshape.Measure("text", otshape.MeasureOptions{Params: params}); w != 0 || a != 0 || d != 0 {
+		t.Errorf("expected zero metrics without a shaper")
+	}
+}
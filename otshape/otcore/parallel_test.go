@@ -0,0 +1,48 @@
+package otcore_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otcore"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// TestShapeAllSharedFont shapes runs concurrently with a freshly loaded font,
+// whose lookups are parsed lazily while shaping. Run with -race.
+func TestShapeAllSharedFont(t *testing.T) {
+	font := loadRootOTFont(t, "GentiumPlus-R.ttf")
+	params := otshape.Params{Font: font, Direction: bidi.LeftToRight, Script: language.MustParseScript("Latn"), Language: language.English}
+	words := strings.Fields("Office affine fluffy Waltz AVATAR Tyrant flipped Yoke")
+	var runs []otshape.Run
+	for range 4 {
+		for _, w := range words {
+			runs = append(runs, otshape.Run{Params: params, Text: []rune(w)})
+		}
+	}
+	shaper := otshape.NewShaper(otcore.New())
+	results, err := shaper.ShapeAll(context.Background(), runs, 8)
+	if err != nil {
+		t.Fatalf("ShapeAll failed: %v", err)
+	}
+	for i, run := range runs {
+		var sink collectingSink
+		if err := shaper.Shape(run.Params, strings.NewReader(string(run.Text)), &sink, otshape.BufferOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(results[i], sink.glyphs) {
+			t.Errorf("run #%d %q: parallel output differs from sequential output", i, string(run.Text))
+		}
+	}
+}
+
+type collectingSink struct{ glyphs []otshape.GlyphRecord }
+
+func (s *collectingSink) WriteGlyph(g otshape.GlyphRecord) error {
+	s.glyphs = append(s.glyphs, g)
+	return nil
+}
@@ -145,6 +145,9 @@ func (p *runProbe) InsertGlyphs(index int, glyphs []ot.GlyphIndex) {
 func (p *runProbe) InsertGlyphCopies(index int, source int, count int) {
 	_, _, _ = index, source, count
 }
+func (p *runProbe) DeleteGlyphs(start, end int) {
+	_, _ = start, end
+}
 func (p *runProbe) Swap(i, j int) {
 	p.codepoints[i], p.codepoints[j] = p.codepoints[j], p.codepoints[i]
 	p.clusters[i], p.clusters[j] = p.clusters[j], p.clusters[i]
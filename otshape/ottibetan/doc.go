@@ -0,0 +1,15 @@
+/*
+Package ottibetan provides the Tibetan script shaping engine for package otshape.
+
+Tibetan does not reorder characters, but stacks consonants vertically: a
+syllable's base letter is followed by subjoined letters and by vowel signs and
+other marks. The engine merges the clusters of each stack, so that the
+per-syllable features 'ccmp', 'locl', 'abvs' and 'blws' apply to one stack at a
+time, and stages 'ccmp' before the above-base and below-base substitutions.
+Vowel sign u is sorted before vowel signs above the base, as fonts expect.
+
+The engine is tested with synthetic fonts only. It has not yet been validated
+against a real Tibetan font such as Noto Serif Tibetan, as the test data of
+this module does not contain one.
+*/
+package ottibetan
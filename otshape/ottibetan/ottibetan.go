@@ -0,0 +1,180 @@
+package ottibetan
+
+import (
+	"slices"
+	"unicode"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otshape"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+var tibetanScript = language.MustParseScript("Tibt")
+
+// stackFeatures are the GSUB features forming Tibetan stacks.
+var stackFeatures = []ot.Tag{ot.T("locl"), ot.T("ccmp"), ot.T("blws"), ot.T("abvs")}
+
+// Modified combining classes of Tibetan vowel signs. Vowel sign u (Unicode
+// class 132) is sorted before vowel signs above the base (class 130).
+const (
+	mccSignU     = 131
+	mccSignAbove = 132
+)
+
+// Shaper is the Tibetan shaping engine.
+//
+// It forms clusters of consonant stacks, stages the stack-forming
+// substitutions and reorders vowel signs on top of the shared otshape
+// pipeline.
+type Shaper struct{}
+
+var _ otshape.ShapingEngine = Shaper{}
+var _ otshape.ShapingEnginePolicy = Shaper{}
+var _ otshape.ShapingEnginePlanHooks = Shaper{}
+var _ otshape.ShapingEngineReorderHook = Shaper{}
+var _ otshape.ShapingEnginePreGSUBHook = Shaper{}
+
+// New returns a new Tibetan shaping engine instance.
+func New() otshape.ShapingEngine {
+	return Shaper{}
+}
+
+// Name returns the stable engine name.
+func (Shaper) Name() string {
+	return "tibetan"
+}
+
+// Match reports how suitable this engine is for ctx.
+//
+// It returns certain confidence for Tibetan script and no confidence otherwise.
+func (Shaper) Match(ctx otshape.SelectionContext) otshape.ShaperConfidence {
+	if ctx.Script == tibetanScript || ctx.ScriptTag == ot.T("tibt") {
+		return otshape.ShaperConfidenceCertain
+	}
+	return otshape.ShaperConfidenceNone
+}
+
+// New returns a new independent Tibetan engine instance.
+func (Shaper) New() otshape.ShapingEngine {
+	return Shaper{}
+}
+
+// NormalizationPreference reports the engine's normalization policy.
+func (Shaper) NormalizationPreference() otshape.NormalizationMode {
+	return otshape.NormalizationAuto
+}
+
+// ApplyGPOS reports whether GPOS should be applied for Tibetan shaping.
+func (Shaper) ApplyGPOS() bool {
+	return true
+}
+
+// CollectFeatures registers the GSUB features of Tibetan for ctx.
+//
+// Every feature is a stage of its own, ordered by the default features of the
+// script (see otshape.DefaultScriptFeatures): 'locl' and 'ccmp' come first,
+// then 'blws' forms the below-base part of stacks, which 'abvs' may rely on
+// when substituting above-base glyphs. These features apply to one stack at a
+// time.
+func (Shaper) CollectFeatures(plan otshape.FeaturePlanner, ctx otshape.SelectionContext) {
+	for _, tag := range otshape.DefaultScriptFeatures(ctx.Script).GSUB {
+		flags := otshape.FeatureNone
+		if slices.Contains(stackFeatures, tag) {
+			flags = otshape.FeatureManualZWJ | otshape.FeaturePerSyllable
+		}
+		plan.AddFeature(tag, flags, 1)
+	}
+}
+
+// OverrideFeatures allows a shaper to force feature toggles after collection.
+//
+// The Tibetan engine does not override user or collected features.
+func (Shaper) OverrideFeatures(plan otshape.FeaturePlanner) {
+	_ = plan
+}
+
+// InitPlan initializes shaper-local plan state. The Tibetan engine keeps none.
+func (Shaper) InitPlan(plan otshape.PlanContext) {
+	_ = plan
+}
+
+// ReorderMarks sorts Tibetan vowel signs in run[start:end] by their modified
+// combining classes.
+func (Shaper) ReorderMarks(run otshape.RunContext, start, end int) {
+	tibetanReorderMarks(run, start, end)
+}
+
+// PrepareGSUB merges the clusters of every consonant stack of run, i.e., of a
+// base character and the combining marks following it.
+func (Shaper) PrepareGSUB(run otshape.RunContext) {
+	if run == nil {
+		return
+	}
+	n := run.Len()
+	for start := 0; start < n; {
+		end := start + 1
+		for end < n && isStackMark(run.Codepoint(end)) {
+			end++
+		}
+		if end-start > 1 {
+			run.MergeClusters(start, end)
+		}
+		start = end
+	}
+}
+
+func tibetanReorderMarks(run otshape.RunContext, start, end int) {
+	if run == nil {
+		return
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > run.Len() {
+		end = run.Len()
+	}
+	// insertion sort of sequences of marks, which keeps marks of equal class
+	// in order
+	for i := start + 1; i < end; i++ {
+		c := tibetanModifiedCombiningClass(run.Codepoint(i))
+		if c == 0 {
+			continue
+		}
+		j := i
+		for j > start {
+			p := tibetanModifiedCombiningClass(run.Codepoint(j - 1))
+			if p == 0 || p <= c {
+				break
+			}
+			j--
+		}
+		if j == i {
+			continue
+		}
+		run.MergeClusters(j, i+1)
+		for k := i; k > j; k-- {
+			run.Swap(k-1, k)
+		}
+	}
+}
+
+func tibetanModifiedCombiningClass(cp rune) uint8 {
+	ccc := norm.NFD.PropertiesString(string(cp)).CCC()
+	if cp < 0x0F00 || cp > 0x0FFF {
+		return ccc
+	}
+	switch ccc {
+	case 130:
+		return mccSignAbove
+	case 132:
+		return mccSignU
+	}
+	return ccc
+}
+
+// isStackMark reports whether cp continues a stack: subjoined consonants,
+// vowel signs and other combining marks.
+func isStackMark(cp rune) bool {
+	return cp != 0 && unicode.Is(unicode.M, cp)
+}
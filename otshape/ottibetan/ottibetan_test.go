@@ -0,0 +1,109 @@
+package ottibetan_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otshape"
+	"github.com/npillmayer/opentype/otshape/otcore"
+	"github.com/npillmayer/opentype/otshape/ottibetan"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+func TestShaperMatchTibetan(t *testing.T) {
+	var s = ottibetan.Shaper{}
+
+	if got := s.Match(otshape.SelectionContext{Script: language.MustParseScript("Tibt")}); got <= otshape.ShaperConfidenceNone {
+		t.Fatalf("expected Tibetan match, got %d", got)
+	}
+	if got := s.Match(otshape.SelectionContext{Script: language.MustParseScript("Deva")}); got != otshape.ShaperConfidenceNone {
+		t.Fatalf("expected Devanagari non-match, got %d", got)
+	}
+	if got := ottibetan.New().Name(); got != "tibetan" {
+		t.Fatalf("New().Name() = %q, want %q", got, "tibetan")
+	}
+}
+
+type glyphCollector struct {
+	glyphs []otshape.GlyphRecord
+}
+
+func (c *glyphCollector) WriteGlyph(g otshape.GlyphRecord) error {
+	c.glyphs = append(c.glyphs, g)
+	return nil
+}
+
+// tibetanFont builds a font with letters ka and ra, subjoined ra, vowel signs
+// i and u, stacks ka+ra and ka+ra+i and a ligature of two ka. There is no real
+// Tibetan font in the test data; results for real fonts, e.g. Noto Serif
+// Tibetan, are not covered by the tests.
+func tibetanFont(t *testing.T) *ot.Font {
+	t.Helper()
+	b := testfont.New(10)
+	for i, name := range []string{"ka", "ra", "ra.sub", "i", "u", "ka_ra", "ka_ra_i", "ka_ka", "ra.alt"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('ཀ', 1).Map('ར', 2).Map('ྲ', 3).Map('ི', 4).Map('ུ', 5)
+	b.Advance(3, 0).Advance(4, 0).Advance(5, 0)
+	// lookups of 'abvs' precede those of 'blws', which must nevertheless
+	// form stacks first
+	err := b.Features(`languagesystem tibt dflt;
+table GDEF { GlyphClassDef [ka ra ka_ra ka_ra_i ka_ka ra.alt], , [ra.sub i u], ; } GDEF;
+feature abvs { sub ka_ra i by ka_ra_i; sub ka ka by ka_ka; } abvs;
+feature blws { sub ka ra.sub by ka_ra; } blws;
+feature ccmp { sub ra by ra.alt; } ccmp;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	font, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return font
+}
+
+func TestShapeTibetanStacks(t *testing.T) {
+	font := tibetanFont(t)
+	params := otshape.Params{
+		Font:      font,
+		Direction: bidi.LeftToRight,
+		Script:    language.MustParseScript("Tibt"),
+		Language:  language.Make("bo"),
+	}
+	shaper := otshape.NewShaper(otcore.New(), ottibetan.New())
+	tests := []struct {
+		name     string
+		input    string
+		gids     []ot.GlyphIndex
+		clusters []uint32
+	}{
+		{"stack", "ཀྲ", []ot.GlyphIndex{6}, []uint32{0}},
+		{"stack with vowel above", "ཀྲི", []ot.GlyphIndex{7}, []uint32{0}},
+		// vowel sign u is moved before vowel sign i
+		{"vowels below and above", "ཀྲིུ", []ot.GlyphIndex{6, 5, 4}, []uint32{0, 0, 0}},
+		// stack features do not apply across stacks
+		{"two stacks", "ཀཀ", []ot.GlyphIndex{1, 1}, []uint32{0, 1}},
+		{"ccmp", "ཀར", []ot.GlyphIndex{1, 9}, []uint32{0, 1}},
+	}
+	for _, tt := range tests {
+		sink := &glyphCollector{}
+		if err := shaper.Shape(params, strings.NewReader(tt.input), sink, otshape.BufferOptions{}); err != nil {
+			t.Fatalf("%s: shape failed: %v", tt.name, err)
+		}
+		var gids []ot.GlyphIndex
+		var clusters []uint32
+		for _, g := range sink.glyphs {
+			gids = append(gids, g.GID)
+			clusters = append(clusters, g.Cluster)
+		}
+		if !slices.Equal(gids, tt.gids) || !slices.Equal(clusters, tt.clusters) {
+			t.Errorf("%s: expected glyphs %v with clusters %v, have %v with %v",
+				tt.name, tt.gids, tt.clusters, gids, clusters)
+		}
+	}
+}
@@ -16,12 +16,24 @@ type RuneSource interface {
 }
 
 // GlyphRecord is one shaped output glyph in array-of-struct form.
+//
+// Pos.AttachTo is the attachment parent of a glyph attached by GPOS, i.e., the
+// index of the glyph it is attached to among the records written by a shaping
+// call, or -1. Offsets of marks are relative to the pen position of the mark;
+// for marks attached to marks, they include the offsets of the complete
+// attachment chain.
+//
+// If requested by [BufferOptions].LigatureComponents, Components holds the
+// input clusters of the components of ligature glyphs formed by GSUB, in
+// logical order, letting editing clients place carets within ligatures (see
+// [LigatureCarets]).
 type GlyphRecord struct {
 	GID         ot.GlyphIndex    // GID is the shaped glyph ID in the selected font.
 	Pos         otlayout.PosItem // Pos holds final output positioning and attachment data.
 	Cluster     uint32           // Cluster is the input cluster ID associated with this glyph.
 	Mask        uint32           // Mask is the final feature mask used during lookup filtering.
 	UnsafeFlags uint16           // UnsafeFlags carries break/concat safety hints for boundaries.
+	Components  []uint32         // Components are the input clusters of ligature components, or nil.
 }
 
 // GlyphSink is the output side of the shaping pipeline.
@@ -60,4 +72,21 @@ type BufferOptions struct {
 	// MaxBuffer is a hard cap used for forced progress when no safe cut appears.
 	// If zero, an internal default is used.
 	MaxBuffer int
+	// Limits bounds the work done for applying lookups. The zero value
+	// imposes no limits.
+	Limits ShapeLimits
+	// LigatureComponents enables recording the components of ligatures in
+	// [GlyphRecord].Components, for clients which place carets within
+	// ligatures. It costs allocations for runs containing ligatures.
+	LigatureComponents bool
+	// SmallCaps configures the synthesis of small capitals for fonts without
+	// features 'smcp' or 'c2sc'. The zero value disables synthesis.
+	SmallCaps SmallCapsFallback
+	// Style configures synthetic bold and oblique styles, for fonts lacking a
+	// bold or italic face. The zero value shapes the regular style.
+	Style SyntheticStyle
+	// Ignorables selects the output of default-ignorable characters, e.g.
+	// joiners, bidi controls or variation selectors. The zero value hides
+	// them behind zero-width glyphs.
+	Ignorables IgnorablePolicy
 }
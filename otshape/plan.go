@@ -1,9 +1,11 @@
 package otshape
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"math/bits"
+	"slices"
 	"sort"
 	"unicode"
 
@@ -55,6 +57,7 @@ type segmentProps struct {
 	Direction bidi.Direction
 	Script    language.Script
 	Language  language.Tag
+	Vertical  bool
 }
 
 type maskSpec struct {
@@ -212,6 +215,8 @@ type plan struct {
 
 	featureRanges    []FeatureRange          // preserved for runtime mask setup
 	joinerGlyphClass map[ot.GlyphIndex]uint8 // GSUB-time joiner annotation by glyph
+	engine           string                  // name of the shaping engine, for diagnostics
+	fallback         []ot.Tag                // features emulated by the engine, for diagnostics
 }
 
 func (p *plan) table(t planTable) *tableProgram {
@@ -262,26 +267,6 @@ type planRequest struct {
 	Hooks        planHookSet
 }
 
-var defaultGSUBFeatures = []ot.Tag{
-	ot.T("locl"),
-	ot.T("ccmp"),
-	ot.T("rlig"),
-	ot.T("rclt"),
-	ot.T("calt"),
-	ot.T("clig"),
-	ot.T("liga"),
-}
-
-var defaultGPOSFeatures = []ot.Tag{
-	ot.T("abvm"),
-	ot.T("blwm"),
-	ot.T("mark"),
-	ot.T("mkmk"),
-	ot.T("curs"),
-	ot.T("dist"),
-	ot.T("kern"),
-}
-
 var manualJoinerBothFeatures = map[ot.Tag]struct{}{
 	ot.T("mark"): {},
 	ot.T("mkmk"): {},
@@ -386,6 +371,7 @@ type compiledFeature struct {
 	tag     ot.Tag
 	typ     otlayout.LayoutTagType
 	lookups []int
+	graph   *ot.LookupListGraph
 }
 
 func (f compiledFeature) Tag() ot.Tag                  { return f.tag }
@@ -397,36 +383,49 @@ func (f compiledFeature) LookupIndex(i int) int {
 	}
 	return f.lookups[i]
 }
+func (f compiledFeature) AlternateCount(g ot.GlyphIndex) int {
+	return otlayout.AlternateCount(f.graph, f, g)
+}
+
+// layoutTableOf returns the GSUB or GPOS table of font, or nil.
+func layoutTableOf(font *ot.Font, table planTable) *ot.LayoutTable {
+	if font == nil {
+		return nil
+	}
+	switch table {
+	case planGSUB:
+		if gsub := font.GSub(); gsub != nil {
+			return &gsub.LayoutTable
+		}
+	case planGPOS:
+		if gpos := font.GPos(); gpos != nil {
+			return &gpos.LayoutTable
+		}
+	}
+	return nil
+}
 
-func fontFeaturesForTable(font *ot.Font, table planTable, scriptTag ot.Tag, langTag ot.Tag) ([]otlayout.Feature, error) {
+// fontFeaturesForTable collects the features of the first language system of
+// chain the font supports (see langSysChain).
+func fontFeaturesForTable(font *ot.Font, table planTable, chain []LangSys) ([]otlayout.Feature, error) {
 	if font == nil {
 		return nil, errShaper("font is nil")
 	}
 	var (
 		tag ot.Tag
 		typ otlayout.LayoutTagType
-		lyt *ot.LayoutTable
 	)
 	switch table {
 	case planGSUB:
 		tag = ot.T("GSUB")
 		typ = otlayout.GSubFeatureType
-		if t := font.Table(tag); t != nil {
-			if gsub := t.Self().AsGSub(); gsub != nil {
-				lyt = &gsub.LayoutTable
-			}
-		}
 	case planGPOS:
 		tag = ot.T("GPOS")
 		typ = otlayout.GPosFeatureType
-		if t := font.Table(tag); t != nil {
-			if gpos := t.Self().AsGPos(); gpos != nil {
-				lyt = &gpos.LayoutTable
-			}
-		}
 	default:
 		return nil, errShaper("invalid plan table")
 	}
+	lyt := layoutTableOf(font, table)
 	if lyt == nil {
 		return nil, errShaper(fmt.Sprintf("font has no %s table", tag))
 	}
@@ -435,25 +434,14 @@ func fontFeaturesForTable(font *ot.Font, table planTable, scriptTag ot.Tag, lang
 	if sg == nil || fg == nil {
 		return nil, errShaper(fmt.Sprintf("%s has no script or feature graph", tag))
 	}
-	if scriptTag == 0 {
-		scriptTag = ot.DFLT
-	}
-	scr := sg.Script(scriptTag)
-	if scr == nil && scriptTag != ot.DFLT {
-		scr = sg.Script(ot.DFLT)
-	}
-	if scr == nil {
-		return []otlayout.Feature{}, nil
-	}
-	var lsys *ot.LangSys
-	if langTag != 0 {
-		lsys = scr.LangSys(langTag)
-	}
+	lsys, _ := selectLangSys(lyt, chain)
 	if lsys == nil {
-		lsys = scr.DefaultLangSys()
-	}
-	if lsys == nil {
-		return nil, errShaper(fmt.Sprintf("%s has no language system for script %s", tag, scriptTag))
+		for _, ls := range chain {
+			if sg.Script(ls.Script) != nil {
+				return nil, errShaper(fmt.Sprintf("%s has no language system for script %s", tag, ls.Script))
+			}
+		}
+		return []otlayout.Feature{}, nil
 	}
 	featureByPtr := make(map[*ot.Feature]ot.Tag, fg.Len())
 	for featureTag, cf := range fg.Range() {
@@ -466,7 +454,7 @@ func fontFeaturesForTable(font *ot.Font, table planTable, scriptTag ot.Tag, lang
 	if reqInx, ok := lsys.RequiredFeatureIndex(); ok {
 		cf, reqTag := featureAtConcreteIndex(fg, int(reqInx))
 		if cf != nil && reqTag != 0 {
-			out = append(out, wrapCompiledFeature(cf, reqTag, typ))
+			out = append(out, wrapCompiledFeature(cf, reqTag, typ, lyt.LookupGraph()))
 		} else {
 			out = append(out, nil)
 		}
@@ -483,12 +471,13 @@ func fontFeaturesForTable(font *ot.Font, table planTable, scriptTag ot.Tag, lang
 			out = append(out, nil)
 			continue
 		}
-		out = append(out, wrapCompiledFeature(cf, featureTag, typ))
+		out = append(out, wrapCompiledFeature(cf, featureTag, typ, lyt.LookupGraph()))
 	}
 	return out, nil
 }
 
-func wrapCompiledFeature(cf *ot.Feature, tag ot.Tag, typ otlayout.LayoutTagType) otlayout.Feature {
+func wrapCompiledFeature(cf *ot.Feature, tag ot.Tag, typ otlayout.LayoutTagType,
+	graph *ot.LookupListGraph) otlayout.Feature {
 	lookups := make([]int, 0, cf.LookupCount())
 	for i := 0; i < cf.LookupCount(); i++ {
 		lookups = append(lookups, cf.LookupIndex(i))
@@ -497,6 +486,7 @@ func wrapCompiledFeature(cf *ot.Feature, tag ot.Tag, typ otlayout.LayoutTagType)
 		tag:     tag,
 		typ:     typ,
 		lookups: lookups,
+		graph:   graph,
 	}
 }
 
@@ -609,6 +599,32 @@ func compileUserFeatureMasks(features []FeatureRange) (maskLayout, error) {
 	return layout, nil
 }
 
+// characterVariantNotes checks the values requested for character variant
+// features 'cvXX', where value n selects the n-th alternate of a glyph,
+// against the number of variants named by the feature parameters.
+func characterVariantNotes(font *ot.Font, features []FeatureRange) []planNote {
+	gsub := font.GSub()
+	if gsub == nil {
+		return nil
+	}
+	var notes []planNote
+	for _, f := range features {
+		if !f.On || f.Arg <= 1 || f.Feature&0xffff0000 != ot.T("cv__")&0xffff0000 {
+			continue
+		}
+		params, ok := gsub.FeatureGraph().First(f.Feature).CharacterVariantParams()
+		if !ok || params.NumNamedParameters == 0 || f.Arg <= int(params.NumNamedParameters) {
+			continue
+		}
+		notes = append(notes, planNote{
+			Level: planNoteWarning,
+			Message: fmt.Sprintf("value %d of feature %s exceeds its %d named variants",
+				f.Arg, f.Feature, params.NumNamedParameters),
+		})
+	}
+	return notes
+}
+
 func compileTableProgram(
 	features []otlayout.Feature,
 	table planTable,
@@ -831,8 +847,12 @@ func compile(req planRequest) (*plan, error) {
 			Language:  req.Props.Language,
 			ScriptTag: scriptTag,
 			LangTag:   langTag,
+			Vertical:  req.Props.Vertical,
 		}
 	}
+	if selection.Vertical {
+		req.UserFeatures = verticalFeatureRanges(req.UserFeatures)
+	}
 	planner := newPlanFeaturePlanner(req.Font, selection, &hooks, req.UserFeatures)
 	if engineHooks, ok := req.Engine.(ShapingEnginePlanHooks); ok {
 		engineHooks.CollectFeatures(planner, selection)
@@ -854,7 +874,12 @@ func compile(req planRequest) (*plan, error) {
 		gposFeats []otlayout.Feature
 		notes     []planNote
 	)
-	gsubFeats, err = fontFeaturesForTable(req.Font, planGSUB, scriptTag, langTag)
+	langTags := languageTagCandidates(req.Props.Language, language.Low)
+	if langTag != ot.DFLT && !slices.Contains(langTags, langTag) {
+		langTags = slices.Insert(langTags, 0, langTag)
+	}
+	chain := langSysChain(scriptTagCandidates(scriptTag, req.Props.Script), langTags)
+	gsubFeats, err = fontFeaturesForTable(req.Font, planGSUB, chain)
 	if err != nil {
 		if policy.Strict {
 			return nil, errShaper(err.Error())
@@ -864,7 +889,7 @@ func compile(req planRequest) (*plan, error) {
 			Message: fmt.Sprintf("GSUB feature extraction failed: %s", err),
 		})
 	}
-	gposFeats, err = fontFeaturesForTable(req.Font, planGPOS, scriptTag, langTag)
+	gposFeats, err = fontFeaturesForTable(req.Font, planGPOS, chain)
 	if err != nil {
 		if policy.Strict && policy.ApplyGPOS {
 			return nil, errShaper(err.Error())
@@ -888,6 +913,7 @@ func compile(req planRequest) (*plan, error) {
 		return nil, err
 	}
 	notes = append(notes, gsubNotes...)
+	notes = append(notes, characterVariantNotes(req.Font, req.UserFeatures)...)
 	gposProg, gposNotes, err := compileTableProgram(
 		gposFeats,
 		planGPOS,
@@ -927,6 +953,15 @@ func compile(req planRequest) (*plan, error) {
 		featureRanges:    maskFeatures,
 		joinerGlyphClass: compileJoinerGlyphClass(req.Font),
 	}
+	if req.Engine != nil {
+		p.engine = req.Engine.Name()
+	}
+	for tag, needed := range planFallbackNeeds {
+		if needed {
+			p.fallback = append(p.fallback, tag)
+		}
+	}
+	slices.Sort(p.fallback)
 	if planHooks, ok := req.Engine.(ShapingEnginePlanHooks); ok {
 		pc := newPlanContext(req.Font, selection)
 		for tag, ms := range p.Masks.ByFeature {
@@ -966,7 +1001,55 @@ func compileJoinerGlyphClass(font *ot.Font) map[ot.GlyphIndex]uint8 {
 // --- Executing Plans --------------------------------------------------
 
 type planExecutor struct {
-	run *runBuffer
+	run   *runBuffer
+	feat  planLookupFeature    // single-lookup feature currently being applied
+	state otlayout.BufferState // buffer state handed to otlayout
+	edits otlayout.EditLog     // edits of the buffer state, not yet mirrored onto the run
+	ctx   context.Context      // context of the current shaping call, or nil
+	steps int                  // lookup application steps since the last cancellation check
+
+	components bool                  // record ligature components in the current shaping call
+	smallCaps  SmallCapsFallback     // small-caps synthesis of the current shaping call
+	synthetic  []uint32              // clusters of synthesized small capitals of the current run
+	style      SyntheticStyle        // synthetic style of the current shaping call
+	ignorables IgnorablePolicy       // output of default-ignorables of the current shaping call
+	limits     ShapeLimits           // limits of the current shaping call
+	budget     otlayout.LookupBudget // lookup budget of the current shaping call
+	runLen     int                   // length of the run at the start of applying a plan
+	growth     int                   // change of the run length since then
+}
+
+// configure prepares the executor for a shaping call with context ctx and
+// buffer options bufOpts.
+func (e *planExecutor) configure(ctx context.Context, bufOpts BufferOptions) {
+	e.ctx = ctx
+	e.setLimits(bufOpts.Limits)
+	e.components = bufOpts.LigatureComponents
+	e.smallCaps = bufOpts.SmallCaps
+	e.style = bufOpts.Style
+	e.ignorables = bufOpts.Ignorables
+}
+
+// cancelCheckInterval is the number of lookup application steps between
+// checks for cancellation of the shaping context.
+const cancelCheckInterval = 256
+
+// cancelled reports the error of the executor's context, if it is done.
+func (e *planExecutor) cancelled() error {
+	if e.ctx == nil {
+		return nil
+	}
+	e.steps = 0
+	return e.ctx.Err()
+}
+
+// stepCancelled counts a lookup application step and checks for
+// cancellation every cancelCheckInterval steps.
+func (e *planExecutor) stepCancelled() error {
+	if e.steps++; e.steps < cancelCheckInterval {
+		return nil
+	}
+	return e.cancelled()
 }
 
 func (e *planExecutor) acquireBuffer(run *runBuffer) {
@@ -995,7 +1078,12 @@ func (e *planExecutor) owns() bool {
 
 func (e *planExecutor) apply(pl *plan) error {
 	assert(e.owns(), "plan executor does not own run buffer")
-	e.ensureRunMasks(pl)
+	e.runLen, e.growth = e.run.Len(), 0
+	if len(e.run.Masks) != e.run.Len() {
+		// keep masks set up by the shaping engine
+		e.ensureRunMasks(pl)
+	}
+	e.synthesizeSmallCaps(pl)
 	if err := e.applyGSUB(pl); err != nil {
 		return err
 	}
@@ -1007,6 +1095,12 @@ func (e *planExecutor) apply(pl *plan) error {
 		appliedGPOS = true
 	}
 	e.applyPositionPolicies(pl, appliedGPOS)
+	if appliedGPOS {
+		e.resolveAttachments(pl)
+	}
+	e.adjustFallbackSpaces(pl)
+	e.scaleSmallCaps(pl)
+	e.applySyntheticStyle(pl)
 	return nil
 }
 
@@ -1031,6 +1125,9 @@ func (e *planExecutor) applyTable(pl *plan, table planTable) error {
 		e.run.EnsurePos()
 	}
 	for _, st := range prog.Stages {
+		if err := e.cancelled(); err != nil {
+			return err
+		}
 		if st.FirstLookup < 0 || st.LastLookup < st.FirstLookup || st.LastLookup > len(prog.Lookups) {
 			return errShaper("plan stage has invalid lookup bounds")
 		}
@@ -1068,6 +1165,23 @@ func (e *planExecutor) applyPositionPolicies(pl *plan, appliedGPOS bool) {
 	}
 }
 
+// resolveAttachments makes the offsets of marks attached by GPOS relative to
+// their own origin, following chains of marks attached to marks. Advances are
+// the advances of the output records, i.e., including the glyph advances.
+func (e *planExecutor) resolveAttachments(pl *plan) {
+	if e == nil || e.run == nil || len(e.run.Pos) != e.run.Len() {
+		return
+	}
+	advance := func(i int) (int32, int32) {
+		x := e.run.Pos[i].XAdvance
+		if pl.font != nil {
+			x += int32(otquery.GlyphMetrics(pl.font, e.run.Glyphs[i]).Advance)
+		}
+		return x, e.run.Pos[i].YAdvance
+	}
+	e.run.Pos.ResolveAttachments(advance, pl.Props.Direction == bidi.RightToLeft)
+}
+
 func (e *planExecutor) zeroMarkAdvances(pl *plan, adjustOffsets bool) {
 	if e == nil || e.run == nil {
 		return
@@ -1132,8 +1246,8 @@ func (e *planExecutor) isMarkByClass(pl *plan, inx int) bool {
 		return false
 	}
 	gid := e.run.Glyphs[inx]
-	if pl != nil && pl.font != nil && pl.font.Layout.GDef != nil {
-		if ot.GlyphClassDefEnum(pl.font.Layout.GDef.GlyphClassDef.Lookup(gid)) == ot.MarkGlyph {
+	if pl != nil && pl.font.GDef() != nil {
+		if ot.GlyphClassDefEnum(pl.font.GDef().GlyphClassDef.Lookup(gid)) == ot.MarkGlyph {
 			return true
 		}
 	}
@@ -0,0 +1,160 @@
+package otshape
+
+import (
+	"encoding/json"
+
+	"github.com/npillmayer/opentype/internal/guard"
+	"github.com/npillmayer/schuko/tracing"
+	"golang.org/x/text/unicode/bidi"
+)
+
+// PlanDescription describes a compiled shaping plan: the script and language
+// selected, the features and lookups of the GSUB and GPOS programs, and the
+// decisions of the shaping engine. It helps to diagnose why a feature did or
+// did not apply, and may be serialized to JSON.
+//
+// Plans are described at trace level Info when they are compiled; see also
+// [Shaper.DescribePlan].
+type PlanDescription struct {
+	Engine    string           `json:"engine,omitempty"`   // name of the shaping engine
+	Direction string           `json:"direction"`          // "ltr" or "rtl"
+	Vertical  bool             `json:"vertical,omitempty"` // vertical layout
+	Script    string           `json:"script"`             // OpenType script tag
+	Language  string           `json:"language"`           // OpenType language tag
+	GSUB      TableDescription `json:"gsub"`
+	GPOS      TableDescription `json:"gpos"`
+	ApplyGPOS bool             `json:"applyGPOS"`          // GPOS program is applied
+	ZeroMarks bool             `json:"zeroMarks"`          // advances of marks are zeroed
+	Fallback  []string         `json:"fallback,omitempty"` // features emulated by the engine
+	Notes     []string         `json:"notes,omitempty"`    // warnings and remarks of compilation
+}
+
+// TableDescription describes the program of a plan for one layout table.
+type TableDescription struct {
+	FoundScript bool                 `json:"foundScript"` // font has a script table for the plan's script
+	Features    []FeatureDescription `json:"features,omitempty"`
+	Stages      []StageDescription   `json:"stages,omitempty"`
+}
+
+// FeatureDescription describes a font feature bound to a plan.
+type FeatureDescription struct {
+	Tag          string `json:"tag"`
+	FeatureIndex int    `json:"featureIndex"` // index into the font's feature list
+	Stage        int    `json:"stage"`
+	Mask         uint32 `json:"mask"` // glyph mask bits enabling the feature
+	Required     bool   `json:"required,omitempty"`
+}
+
+// StageDescription describes a stage of a plan: lookups applied in sequence,
+// followed by an optional pause of the shaping engine.
+type StageDescription struct {
+	Lookups []LookupDescription `json:"lookups,omitempty"`
+	Pause   bool                `json:"pause,omitempty"`
+}
+
+// LookupDescription describes a lookup applied by a plan.
+type LookupDescription struct {
+	Index   int      `json:"index"`   // index into the font's lookup list
+	Feature string   `json:"feature"` // tag of the feature the lookup is applied for
+	Mask    uint32   `json:"mask"`
+	Flags   []string `json:"flags,omitempty"` // "auto-zwnj", "auto-zwj", "random" and "per-syllable"
+}
+
+// Describe returns a description of p.
+func (p *plan) Describe() PlanDescription {
+	d := PlanDescription{
+		Engine:    p.engine,
+		Direction: "ltr",
+		Script:    p.ScriptTag.String(),
+		Language:  p.LangTag.String(),
+		GSUB:      p.GSUB.describe(),
+		GPOS:      p.GPOS.describe(),
+		Vertical:  p.Props.Vertical,
+		ApplyGPOS: p.Policy.ApplyGPOS,
+		ZeroMarks: p.Policy.ZeroMarks,
+	}
+	if p.Props.Direction == bidi.RightToLeft {
+		d.Direction = "rtl"
+	}
+	for _, tag := range p.fallback {
+		d.Fallback = append(d.Fallback, tag.String())
+	}
+	for _, note := range p.Notes {
+		msg := note.Message
+		if note.Level == planNoteWarning {
+			msg = "warning: " + msg
+		}
+		d.Notes = append(d.Notes, msg)
+	}
+	return d
+}
+
+func (tp tableProgram) describe() TableDescription {
+	d := TableDescription{FoundScript: tp.FoundScript}
+	for _, fb := range tp.FeatureBinds {
+		d.Features = append(d.Features, FeatureDescription{
+			Tag:          fb.Tag.String(),
+			FeatureIndex: int(fb.FeatureIndex),
+			Stage:        fb.Stage,
+			Mask:         fb.Mask,
+			Required:     fb.Required,
+		})
+	}
+	for _, st := range tp.Stages {
+		sd := StageDescription{Pause: st.Pause != noPauseHook}
+		for _, op := range tp.Lookups[st.FirstLookup:st.LastLookup] {
+			sd.Lookups = append(sd.Lookups, LookupDescription{
+				Index:   int(op.LookupIndex),
+				Feature: op.FeatureTag.String(),
+				Mask:    op.Mask,
+				Flags:   op.Flags.names(),
+			})
+		}
+		d.Stages = append(d.Stages, sd)
+	}
+	return d
+}
+
+// names returns the names of the flags set in f.
+func (f lookupRunFlags) names() []string {
+	var names []string
+	for i, name := range []string{"auto-zwnj", "auto-zwj", "random", "per-syllable"} {
+		if f.has(1 << i) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// traceCompiledPlan traces the description of a newly compiled plan at level
+// Info, once per plan instead of tracing during application of its lookups.
+func traceCompiledPlan(p *plan) {
+	if tracer().GetTraceLevel() < tracing.LevelInfo {
+		return
+	}
+	desc, err := json.Marshal(p.Describe())
+	if err != nil {
+		tracer().Errorf("cannot describe shaping plan: %v", err)
+		return
+	}
+	tracer().Infof("compiled shaping plan: %s", desc)
+}
+
+// DescribePlan compiles the plan [Shaper.Shape] would use for params and
+// returns its description, without shaping any text.
+func (s *Shaper) DescribePlan(params Params) (desc PlanDescription, err error) {
+	defer guard.Recover(&err, tracer(), "otshape.DescribePlan", ErrInternal)
+	if params.Font == nil {
+		return PlanDescription{}, ErrNilFont
+	}
+	ctx := selectionContextFromParams(params)
+	engine, err := selectShapingEngine(s.Engines, ctx)
+	if err != nil {
+		return PlanDescription{}, err
+	}
+	pl, err := newPlanCompiler(params, ctx, engine).compileDefault()
+	if err != nil {
+		return PlanDescription{}, err
+	}
+	return pl.Describe(), nil
+}
@@ -0,0 +1,89 @@
+package otshape
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+	"golang.org/x/text/unicode/bidi"
+)
+
+func TestPlanDescribe(t *testing.T) {
+	otf := loadLocalFont(t, "Calibri.ttf")
+	probe := &fallbackProbe{tag: ot.T("init"), fallbackFlag: true}
+	req := planRequest{
+		Font:         otf,
+		ScriptTag:    ot.T("latn"),
+		LangTag:      ot.T("ENG"),
+		Props:        segmentProps{Direction: bidi.LeftToRight},
+		Engine:       probe,
+		Policy:       planPolicy{ApplyGPOS: true},
+		UserFeatures: []FeatureRange{{Feature: ot.T("zzzz"), On: true}},
+	}
+	p, err := compile(req)
+	if err != nil {
+		t.Fatalf("compile failed: %v", err)
+	}
+	desc := p.Describe()
+	if desc.Engine != "fallback-probe" || desc.Script != "latn" || desc.Direction != "ltr" || !desc.ApplyGPOS {
+		t.Errorf("unexpected plan description header %+v", desc)
+	}
+	if !slices.Equal(desc.Fallback, []string{"init"}) {
+		t.Errorf("expected fallback for feature init, have %v", desc.Fallback)
+	}
+	if !slices.ContainsFunc(desc.Notes, func(n string) bool { return n == "warning: feature zzzz ignored in GSUB (not available)" }) {
+		t.Errorf("expected note for unavailable feature zzzz, have %v", desc.Notes)
+	}
+	for _, table := range []struct {
+		name string
+		desc TableDescription
+		prog tableProgram
+	}{{"GSUB", desc.GSUB, p.GSUB}, {"GPOS", desc.GPOS, p.GPOS}} {
+		if !table.desc.FoundScript || len(table.desc.Stages) != len(table.prog.Stages) {
+			t.Errorf("%s: expected %d stages for script latn, have %+v", table.name, len(table.prog.Stages), table.desc)
+		}
+		n := 0
+		for _, st := range table.desc.Stages {
+			for _, lu := range st.Lookups {
+				if op := table.prog.Lookups[n]; lu.Index != int(op.LookupIndex) || lu.Feature != op.FeatureTag.String() {
+					t.Errorf("%s: lookup %d described as %+v", table.name, n, lu)
+				}
+				n++
+			}
+		}
+		if n != len(table.prog.Lookups) {
+			t.Errorf("%s: expected %d lookups to be described, have %d", table.name, len(table.prog.Lookups), n)
+		}
+	}
+	if !slices.ContainsFunc(desc.GPOS.Features, func(f FeatureDescription) bool { return f.Tag == "kern" }) {
+		t.Errorf("expected GPOS feature kern to be described, have %+v", desc.GPOS.Features)
+	}
+	b, err := json.Marshal(desc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back PlanDescription
+	if err := json.Unmarshal(b, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back.Engine != desc.Engine || len(back.GSUB.Stages) != len(desc.GSUB.Stages) || len(back.GPOS.Features) != len(desc.GPOS.Features) {
+		t.Errorf("plan description does not survive JSON round trip: %s", b)
+	}
+}
+
+func TestShaperDescribePlan(t *testing.T) {
+	otf := loadLocalFont(t, "Calibri.ttf")
+	params := standardParams(otf)
+	params.Direction = bidi.RightToLeft
+	desc, err := NewShaper(plainShaper{}).DescribePlan(params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if desc.Engine != "plain" || desc.Direction != "rtl" || len(desc.GSUB.Stages) == 0 {
+		t.Errorf("unexpected plan description %+v", desc)
+	}
+	if _, err := NewShaper(plainShaper{}).DescribePlan(Params{}); err != ErrNilFont {
+		t.Errorf("expected ErrNilFont, have %v", err)
+	}
+}
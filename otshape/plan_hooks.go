@@ -24,6 +24,7 @@ type planFeaturePlanner struct {
 	flagsByTable   map[planTable]map[ot.Tag]FeatureFlags
 	maskValues     map[ot.Tag]uint32
 	baseMaskValues map[ot.Tag]struct{}
+	manualMasks    map[ot.Tag]struct{} // features added with FeatureManualMask
 	gsubPauseHooks []pauseHookID
 }
 
@@ -39,16 +40,21 @@ func newPlanFeaturePlanner(
 			baseMaskValues[f.Feature] = struct{}{}
 		}
 	}
+	defaults := DefaultScriptFeatures(selection.Script)
+	if selection.Vertical {
+		defaults = defaults.vertical()
+	}
 	return &planFeaturePlanner{
 		font:           font,
 		selection:      selection,
 		hooks:          hooks,
-		gsubDefaults:   append([]ot.Tag(nil), defaultGSUBFeatures...),
-		gposDefaults:   append([]ot.Tag(nil), defaultGPOSFeatures...),
+		gsubDefaults:   defaults.GSUB,
+		gposDefaults:   defaults.GPOS,
 		togglesByTag:   collectUserFeatureToggles(userFeatures),
 		flagsByTable:   map[planTable]map[ot.Tag]FeatureFlags{planGSUB: {}, planGPOS: {}},
 		maskValues:     make(map[ot.Tag]uint32),
 		baseMaskValues: baseMaskValues,
+		manualMasks:    make(map[ot.Tag]struct{}),
 	}
 }
 
@@ -74,6 +80,9 @@ func (p *planFeaturePlanner) AddFeature(tag ot.Tag, flags FeatureFlags, value ui
 		arg = int(value)
 		p.maskValues[tag] = value
 	}
+	if flags&FeatureManualMask != 0 {
+		p.manualMasks[tag] = struct{}{}
+	}
 	p.togglesByTag[tag] = userFeatureToggle{
 		on:        true,
 		arg:       arg,
@@ -161,12 +170,16 @@ func (p *planFeaturePlanner) maskFeatures() []FeatureRange {
 		if hasToggle && !toggle.on {
 			continue
 		}
+		// features with manual masks get mask bits, but are off by default
+		_, manual := p.manualMasks[tag]
 		features = append(features, FeatureRange{
 			Feature: tag,
-			On:      true,
+			On:      !manual,
 			Arg:     int(v),
 		})
 	}
+	// mask bits are assigned in this order, which must not depend on map iteration
+	sort.Slice(features, func(i, j int) bool { return features[i].Feature < features[j].Feature })
 	return features
 }
 
@@ -469,8 +482,8 @@ func applyFeatureFlags(base lookupRunFlags, flags FeatureFlags, table planTable)
 }
 
 func inferFeatureTargets(tag ot.Tag) featureTarget {
-	if typ, ok := otlayout.RegisteredFeatureTags[tag]; ok {
-		switch typ {
+	if info, ok := otlayout.FeatureInfo(tag); ok && !info.EitherTable {
+		switch info.Type {
 		case otlayout.GSubFeatureType:
 			return targetGSUB
 		case otlayout.GPosFeatureType:
@@ -8,8 +8,12 @@ import (
 )
 
 var (
-	errPlanStackUnderflow = errors.New("otshape: feature-plan stack underflow")
-	errPlanStackUnclosed  = errors.New("otshape: feature-plan stack not closed at end of stream")
+	// ErrFeatureStackUnderflow indicates a pop-features event without a
+	// matching push-features event.
+	ErrFeatureStackUnderflow = errors.New("otshape: feature-plan stack underflow")
+	// ErrFeatureStackUnclosed indicates push-features events without matching
+	// pop-features events at the end of the input stream.
+	ErrFeatureStackUnclosed = errors.New("otshape: feature-plan stack not closed at end of stream")
 )
 
 type featureAssignment struct {
@@ -90,6 +94,13 @@ type planFrame struct {
 	id       uint16
 	features featureSet
 	plan     *plan
+	origin   eventPos // position of the push event which opened the frame
+}
+
+// eventPos locates an event in an input event stream.
+type eventPos struct {
+	index    int // 0-based index of the event
+	position int // number of runes preceding the event
 }
 
 type planStack struct {
@@ -134,10 +145,15 @@ func (s *planStack) currentPlanID() uint16 {
 }
 
 func (s *planStack) push(settings []FeatureSetting, build func([]FeatureRange) (*plan, error)) (uint16, error) {
+	return s.pushAt(eventPos{}, settings, build)
+}
+
+// pushAt pushes a frame for a push-features event located at pos.
+func (s *planStack) pushAt(pos eventPos, settings []FeatureSetting, build func([]FeatureRange) (*plan, error)) (uint16, error) {
 	assert(s != nil, "plan stack is nil")
 	assert(build != nil, "plan stack build callback is nil")
 	if len(s.frames) == 0 {
-		return 0, errPlanStackUnderflow
+		return 0, ErrFeatureStackUnderflow
 	}
 	nextFeatures := s.current().features.applyPush(settings)
 	nextPlan, err := build(nextFeatures.asGlobalFeatureRanges())
@@ -156,6 +172,7 @@ func (s *planStack) push(settings []FeatureSetting, build func([]FeatureRange) (
 		id:       id,
 		features: nextFeatures,
 		plan:     nextPlan,
+		origin:   pos,
 	})
 	return id, nil
 }
@@ -163,16 +180,35 @@ func (s *planStack) push(settings []FeatureSetting, build func([]FeatureRange) (
 func (s *planStack) pop() error {
 	assert(s != nil, "plan stack is nil")
 	if len(s.frames) <= 1 {
-		return errPlanStackUnderflow
+		return ErrFeatureStackUnderflow
 	}
 	s.frames = s.frames[:len(s.frames)-1]
 	return nil
 }
 
+// ensureClosed checks that all pushed frames have been popped. Otherwise it
+// reports the innermost unclosed push event.
 func (s *planStack) ensureClosed() error {
 	assert(s != nil, "plan stack is nil")
 	if len(s.frames) != 1 {
-		return errPlanStackUnclosed
+		top := s.current()
+		return &EventError{
+			Index:    top.origin.index,
+			Position: top.origin.position,
+			Kind:     InputEventPushFeatures,
+			Depth:    len(s.frames) - 1,
+			Err:      ErrFeatureStackUnclosed,
+		}
 	}
 	return nil
 }
+
+// state returns a snapshot of the active feature set.
+func (s *planStack) state(pos eventPos) FeatureStackState {
+	return FeatureStackState{
+		Index:    pos.index,
+		Position: pos.position,
+		Depth:    len(s.frames) - 1,
+		Features: s.current().features.asGlobalFeatureRanges(),
+	}
+}
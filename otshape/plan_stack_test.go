@@ -79,8 +79,8 @@ func TestPlanStackPushPopAndClose(t *testing.T) {
 	if stack.currentPlan() == nil {
 		t.Fatalf("current plan is nil")
 	}
-	if err := stack.ensureClosed(); !errors.Is(err, errPlanStackUnclosed) {
-		t.Fatalf("ensureClosed error=%v, want errPlanStackUnclosed", err)
+	if err := stack.ensureClosed(); !errors.Is(err, ErrFeatureStackUnclosed) {
+		t.Fatalf("ensureClosed error=%v, want ErrFeatureStackUnclosed", err)
 	}
 	if err := stack.pop(); err != nil {
 		t.Fatalf("pop failed: %v", err)
@@ -96,7 +96,7 @@ func TestPlanStackPushPopAndClose(t *testing.T) {
 func TestPlanStackPopUnderflow(t *testing.T) {
 	stack := newPlanStack(nil, &plan{})
 	err := stack.pop()
-	if !errors.Is(err, errPlanStackUnderflow) {
-		t.Fatalf("pop error=%v, want errPlanStackUnderflow", err)
+	if !errors.Is(err, ErrFeatureStackUnderflow) {
+		t.Fatalf("pop error=%v, want ErrFeatureStackUnderflow", err)
 	}
 }
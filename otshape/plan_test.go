@@ -510,9 +510,10 @@ type fakeFeature struct {
 	lookups []int
 }
 
-func (f fakeFeature) Tag() ot.Tag                  { return f.tag }
-func (f fakeFeature) Type() otlayout.LayoutTagType { return f.typ }
-func (f fakeFeature) LookupCount() int             { return len(f.lookups) }
+func (f fakeFeature) Tag() ot.Tag                      { return f.tag }
+func (f fakeFeature) Type() otlayout.LayoutTagType     { return f.typ }
+func (f fakeFeature) LookupCount() int                 { return len(f.lookups) }
+func (f fakeFeature) AlternateCount(ot.GlyphIndex) int { return 0 }
 func (f fakeFeature) LookupIndex(i int) int {
 	if i < 0 || i >= len(f.lookups) {
 		return -1
@@ -0,0 +1,37 @@
+package otshape
+
+import "github.com/npillmayer/opentype/ot"
+
+// Annotation selects glyph forms for annotation text, e.g., East Asian ruby
+// set above or beside base text. Use [otquery.AnnotationForms] to check which
+// of the forms a font provides.
+type Annotation struct {
+	Ruby      bool // ruby notation forms, feature 'ruby'
+	Alternate int  // alternate annotation form, feature 'nalt'; 0 for none
+	Fractions bool // alternative fractions, feature 'afrc'
+}
+
+var (
+	tagRuby = ot.T("ruby")
+	tagNalt = ot.T("nalt")
+	tagAfrc = ot.T("afrc")
+)
+
+// Features returns the feature settings selecting the forms of a, to be added
+// to [Params].Features. The settings apply to the whole run; set Start and End
+// to restrict them to the annotation text. Alternate is passed to 'nalt' as
+// the number of the alternate, as fonts often offer several annotation forms,
+// e.g., circled and parenthesized digits.
+func (a Annotation) Features() []FeatureRange {
+	var features []FeatureRange
+	if a.Ruby {
+		features = append(features, FeatureRange{Feature: tagRuby, On: true})
+	}
+	if a.Alternate > 0 {
+		features = append(features, FeatureRange{Feature: tagNalt, On: true, Arg: a.Alternate})
+	}
+	if a.Fractions {
+		features = append(features, FeatureRange{Feature: tagAfrc, On: true})
+	}
+	return features
+}
@@ -0,0 +1,49 @@
+package otshape
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+)
+
+func TestAnnotationFeatures(t *testing.T) {
+	b := testfont.New(6)
+	b.Name(1, "ka").Name(2, "ka.ruby").Name(3, "one").Name(4, "one.circled").Name(5, "one.paren")
+	b.Map('か', 1).Map('1', 3)
+	if err := b.Features(`languagesystem kana dflt;
+languagesystem DFLT dflt;
+feature ruby { sub ka by ka.ruby; } ruby;
+feature nalt { sub one from [one.circled one.paren]; } nalt;
+`); err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	font, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	if forms := otquery.AnnotationForms(font); !forms.Ruby || !forms.Alternates || forms.Fractions {
+		t.Errorf("expected font to provide ruby and alternate annotation forms, have %+v", forms)
+	}
+	for _, tc := range []struct {
+		annotation Annotation
+		want       []ot.GlyphIndex
+	}{
+		{Annotation{}, []ot.GlyphIndex{1, 3}},
+		{Annotation{Ruby: true}, []ot.GlyphIndex{2, 3}},
+		{Annotation{Ruby: true, Alternate: 1}, []ot.GlyphIndex{2, 4}},
+		{Annotation{Alternate: 2, Fractions: true}, []ot.GlyphIndex{1, 5}},
+	} {
+		params := standardParams(font)
+		params.Features = tc.annotation.Features()
+		var sink collectSink
+		if err := NewShaper(plainShaper{}).Shape(params, strings.NewReader("か1"), &sink, BufferOptions{}); err != nil {
+			t.Fatalf("shape failed: %v", err)
+		}
+		if len(sink.glyphs) != 2 || sink.glyphs[0].GID != tc.want[0] || sink.glyphs[1].GID != tc.want[1] {
+			t.Errorf("%+v: expected glyphs %v, have %+v", tc.annotation, tc.want, sink.glyphs)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package otshape
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/npillmayer/opentype/ot"
@@ -96,3 +97,62 @@ func TestRunContextInsertGlyphCopiesReplicatesSourceRecord(t *testing.T) {
 		}
 	}
 }
+
+func TestRunContextDeleteGlyphsAlignsSideArrays(t *testing.T) {
+	run := newRunBuffer(0)
+	run.Glyphs = append(run.Glyphs, 10, 20, 30, 40)
+	run.Pos = otlayout.NewPosBuffer(4)
+	run.Codepoints = []rune{'a', 'b', 'c', 'd'}
+	run.Clusters = []uint32{0, 1, 2, 3}
+	run.Masks = []uint32{0x11, 0x22, 0x33, 0x44}
+
+	rc := newRunContext(run)
+	rc.DeleteGlyphs(1, 3)
+
+	wantGlyphs := []ot.GlyphIndex{10, 40}
+	if run.Len() != len(wantGlyphs) {
+		t.Fatalf("run length = %d, want %d", run.Len(), len(wantGlyphs))
+	}
+	for i, w := range wantGlyphs {
+		if run.Glyphs[i] != w {
+			t.Fatalf("glyph[%d]=%d, want %d", i, run.Glyphs[i], w)
+		}
+	}
+	if len(run.Pos) != 2 || len(run.Codepoints) != 2 || len(run.Clusters) != 2 || len(run.Masks) != 2 {
+		t.Fatalf("side-array lengths not aligned after delete")
+	}
+	if run.Codepoints[1] != 'd' || run.Clusters[1] != 3 || run.Masks[1] != 0x44 {
+		t.Fatalf("record after deleted span = {%U,%d,0x%X}, want {'d',3,0x44}",
+			run.Codepoints[1], run.Clusters[1], run.Masks[1])
+	}
+	rc.DeleteGlyphs(1, 10) // clamped to the end of the run
+	if run.Len() != 1 || run.Glyphs[0] != 10 {
+		t.Fatalf("glyphs after clamped delete = %v, want [10]", run.Glyphs)
+	}
+}
+
+func TestRunBufferMirrorEdit(t *testing.T) {
+	run := newRunBuffer(0)
+	run.Glyphs = append(run.Glyphs, 10, 20, 30, 40)
+	run.Codepoints = []rune{'a', 'b', 'c', 'd'}
+	run.Clusters = []uint32{0, 1, 2, 3}
+
+	// ligature of glyphs 0 and 2, skipping glyph 1
+	run.Glyphs = otlayout.GlyphBuffer{50, 40}
+	run.MirrorEdit(otlayout.EditSpan{From: 0, To: 3, Len: 1, Components: 0b101}, true)
+	if len(run.Clusters) != 2 || run.Clusters[0] != 0 || run.Clusters[1] != 3 || run.Codepoints[1] != 'd' {
+		t.Fatalf("clusters after ligature = %v, want [0 3]", run.Clusters)
+	}
+	if len(run.Components) != 2 || !slices.Equal(run.Components[0], []uint32{0, 2}) || run.Components[1] != nil {
+		t.Fatalf("components after ligature = %v, want [[0 2] []]", run.Components)
+	}
+	// multiple substitution of the last glyph
+	run.Glyphs = otlayout.GlyphBuffer{50, 41, 42, 43}
+	run.MirrorEdit(otlayout.EditSpan{From: 1, To: 2, Len: 3}, true)
+	if !slices.Equal(run.Clusters, []uint32{0, 3, 3, 3}) || !slices.Equal(run.Codepoints, []rune{'a', 'd', 'd', 'd'}) {
+		t.Fatalf("clusters after multiple substitution = %v, want [0 3 3 3]", run.Clusters)
+	}
+	if len(run.Components) != 4 || run.Components[0] == nil || run.Components[3] != nil {
+		t.Fatalf("components not aligned after multiple substitution: %v", run.Components)
+	}
+}
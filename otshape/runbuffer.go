@@ -1,6 +1,8 @@
 package otshape
 
 import (
+	"slices"
+
 	"github.com/npillmayer/opentype/ot"
 	"github.com/npillmayer/opentype/otlayout"
 )
@@ -16,13 +18,33 @@ type runBuffer struct {
 	Glyphs otlayout.GlyphBuffer
 	Pos    otlayout.PosBuffer // optional until positioning becomes necessary
 
-	Codepoints  []rune   // optional codepoint alignment for normalization/reorder hooks
-	Clusters    []uint32 // optional rune->glyph mapping
-	PlanIDs     []uint16 // optional plan-boundary marker: active plan id per glyph
-	Masks       []uint32 // optional feature/shaping flags
-	UnsafeFlags []uint16 // optional line-break/concat safety flags
-	Syllables   []uint16 // optional pre-segmented syllable ids (contiguous runs)
-	Joiners     []uint8  // optional joiner classes aligned to glyph indices
+	Codepoints  []rune     // optional codepoint alignment for normalization/reorder hooks
+	Clusters    []uint32   // optional rune->glyph mapping
+	PlanIDs     []uint16   // optional plan-boundary marker: active plan id per glyph
+	Masks       []uint32   // optional feature/shaping flags
+	UnsafeFlags []uint16   // optional line-break/concat safety flags
+	Syllables   []uint16   // optional pre-segmented syllable ids (contiguous runs)
+	Joiners     []uint8    // optional joiner classes aligned to glyph indices
+	Components  [][]uint32 // optional input clusters of ligature components, nil for other glyphs
+
+	// written is the number of glyphs written to the sink by the shaping call
+	// before the glyphs of the buffer, to make attachment indices of output
+	// records indices into the output.
+	written int
+
+	spare      spareArrays // storage of deactivated side-arrays, kept for re-use
+	components []uint32    // storage of the slices of Components, owned by output records
+}
+
+// spareArrays keeps the backing storage of deactivated side-arrays, so that
+// re-activating them for the next run does not allocate.
+type spareArrays struct {
+	pos         otlayout.PosBuffer
+	masks       []uint32
+	unsafeFlags []uint16
+	syllables   []uint16
+	joiners     []uint8
+	components  [][]uint32
 }
 
 const (
@@ -79,6 +101,11 @@ func (rb *runBuffer) Reset() {
 	if rb.Joiners != nil {
 		rb.Joiners = rb.Joiners[:0]
 	}
+	if rb.Components != nil {
+		clear(rb.Components)
+		rb.Components = rb.Components[:0]
+	}
+	rb.components = nil // handed out to glyph records
 }
 
 // PrepareForMappedRun resets rb for rune->glyph mapping.
@@ -96,11 +123,12 @@ func (rb *runBuffer) PrepareForMappedRun(withPlanIDs bool, reserve int) {
 
 	// Mapping starts with rune-derived metadata only; shaped-state arrays are
 	// lazily enabled by later pipeline stages.
-	rb.Pos = nil
-	rb.Masks = nil
-	rb.UnsafeFlags = nil
-	rb.Syllables = nil
-	rb.Joiners = nil
+	rb.Pos = park(&rb.spare.pos, rb.Pos)
+	rb.Masks = park(&rb.spare.masks, rb.Masks)
+	rb.UnsafeFlags = park(&rb.spare.unsafeFlags, rb.UnsafeFlags)
+	rb.Syllables = park(&rb.spare.syllables, rb.Syllables)
+	rb.Joiners = park(&rb.spare.joiners, rb.Joiners)
+	rb.Components = park(&rb.spare.components, rb.Components)
 
 	rb.UseCodepoints()
 	rb.UseClusters()
@@ -120,7 +148,13 @@ func (rb *runBuffer) EnsurePos() {
 		return
 	}
 	if rb.Pos == nil {
-		rb.Pos = otlayout.NewPosBuffer(rb.Len())
+		if rb.Pos = reclaim(&rb.spare.pos, rb.Len()); rb.Pos == nil {
+			rb.Pos = otlayout.NewPosBuffer(rb.Len())
+			return
+		}
+		for i := range rb.Pos {
+			rb.Pos[i].AttachTo = -1
+		}
 		return
 	}
 	if len(rb.Pos) != rb.Len() {
@@ -176,7 +210,9 @@ func (rb *runBuffer) EnsureMasks() {
 		return
 	}
 	if rb.Masks == nil {
-		rb.Masks = make([]uint32, rb.Len())
+		if rb.Masks = reclaim(&rb.spare.masks, rb.Len()); rb.Masks == nil {
+			rb.Masks = make([]uint32, rb.Len())
+		}
 		return
 	}
 	if len(rb.Masks) != rb.Len() {
@@ -190,7 +226,9 @@ func (rb *runBuffer) EnsureUnsafeFlags() {
 		return
 	}
 	if rb.UnsafeFlags == nil {
-		rb.UnsafeFlags = make([]uint16, rb.Len())
+		if rb.UnsafeFlags = reclaim(&rb.spare.unsafeFlags, rb.Len()); rb.UnsafeFlags == nil {
+			rb.UnsafeFlags = make([]uint16, rb.Len())
+		}
 		return
 	}
 	if len(rb.UnsafeFlags) != rb.Len() {
@@ -204,7 +242,9 @@ func (rb *runBuffer) EnsureSyllables() {
 		return
 	}
 	if rb.Syllables == nil {
-		rb.Syllables = make([]uint16, rb.Len())
+		if rb.Syllables = reclaim(&rb.spare.syllables, rb.Len()); rb.Syllables == nil {
+			rb.Syllables = make([]uint16, rb.Len())
+		}
 		return
 	}
 	if len(rb.Syllables) != rb.Len() {
@@ -218,7 +258,9 @@ func (rb *runBuffer) EnsureJoiners() {
 		return
 	}
 	if rb.Joiners == nil {
-		rb.Joiners = make([]uint8, rb.Len())
+		if rb.Joiners = reclaim(&rb.spare.joiners, rb.Len()); rb.Joiners == nil {
+			rb.Joiners = make([]uint8, rb.Len())
+		}
 		return
 	}
 	if len(rb.Joiners) != rb.Len() {
@@ -268,6 +310,10 @@ func (rb *runBuffer) ReserveGlyphs(extra int) {
 		assert(len(rb.Joiners) == n, "run buffer alignment violated for Joiners")
 		rb.Joiners = reserveUint8(rb.Joiners, need)
 	}
+	if rb.Components != nil {
+		assert(len(rb.Components) == n, "run buffer alignment violated for Components")
+		rb.Components = slices.Grow(rb.Components, need-n)
+	}
 }
 
 // UsePos activates per-glyph positioning storage.
@@ -377,6 +423,20 @@ func (rb *runBuffer) UseJoiners() {
 	rb.Joiners = make([]uint8, n, maxInt(cap(rb.Glyphs), n))
 }
 
+// UseComponents activates per-glyph storage of ligature components.
+func (rb *runBuffer) UseComponents() {
+	if rb == nil {
+		return
+	}
+	if rb.Components != nil {
+		if len(rb.Components) != rb.Len() {
+			rb.Components = resizeComponents(rb.Components, rb.Len())
+		}
+		return
+	}
+	rb.Components = rb.newComponents(rb.Len())
+}
+
 // AppendGlyph appends one glyph record and default values for active side arrays.
 func (rb *runBuffer) AppendGlyph(gid ot.GlyphIndex) int {
 	assert(rb != nil, "run buffer is nil")
@@ -414,6 +474,10 @@ func (rb *runBuffer) AppendGlyph(gid ot.GlyphIndex) int {
 		assert(len(rb.Joiners) == n, "run buffer alignment violated for Joiners")
 		rb.Joiners = append(rb.Joiners, 0)
 	}
+	if rb.Components != nil {
+		assert(len(rb.Components) == n, "run buffer alignment violated for Components")
+		rb.Components = append(rb.Components, nil)
+	}
 	return n
 }
 
@@ -473,6 +537,9 @@ func (rb *runBuffer) AppendRun(src *runBuffer) {
 	if len(src.Joiners) == srcLen {
 		rb.UseJoiners()
 	}
+	if len(src.Components) == srcLen {
+		rb.UseComponents()
+	}
 	rb.ReserveGlyphs(srcLen)
 	for i := 0; i < srcLen; i++ {
 		j := rb.AppendGlyph(src.Glyphs[i])
@@ -500,6 +567,9 @@ func (rb *runBuffer) AppendRun(src *runBuffer) {
 		if len(src.Joiners) == srcLen && len(rb.Joiners) == rb.Len() {
 			rb.Joiners[j] = src.Joiners[i]
 		}
+		if len(src.Components) == srcLen && len(rb.Components) == rb.Len() && src.Components[i] != nil {
+			rb.Components[j] = rb.storeComponents(src.Components[i]...)
+		}
 	}
 }
 
@@ -537,6 +607,90 @@ func (rb *runBuffer) ApplyEdit(edit *otlayout.EditSpan) {
 	if rb.Joiners != nil {
 		rb.Joiners = applyEditUint8(rb.Joiners, edit)
 	}
+	if rb.Components != nil {
+		rb.Components = applyEditComponents(rb.Components, edit)
+	}
+}
+
+// MirrorEdit mirrors an edit of otlayout, which has already been applied to
+// Glyphs and Pos, onto the other active side arrays. Glyphs replacing a range
+// of glyphs inherit the data of its first glyph and the lowest cluster of the
+// range; inserted glyphs inherit the data of the glyph before them. For a
+// ligature, the input clusters of its components are recorded in Components,
+// if withComponents is set. Side arrays not covering the edited range are left
+// untouched.
+func (rb *runBuffer) MirrorEdit(edit otlayout.EditSpan, withComponents bool) {
+	if rb == nil || edit.From < 0 || edit.To < edit.From || edit.Len < 0 {
+		return
+	}
+	src := edit.From
+	if edit.To == edit.From && edit.From > 0 {
+		src = edit.From - 1
+	}
+	var components []uint32
+	cluster, hasCluster := uint32(0), src < len(rb.Clusters) && edit.To <= len(rb.Clusters)
+	if hasCluster {
+		cluster = rb.Clusters[src]
+		for i := edit.From; i < edit.To; i++ {
+			cluster = min(cluster, rb.Clusters[i])
+		}
+		if edit.Components != 0 && withComponents {
+			if rb.Components == nil {
+				// side arrays may be ahead of Glyphs while mirroring edits
+				rb.Components = rb.newComponents(len(rb.Clusters))
+			}
+			components = rb.ligatureComponents(edit)
+		}
+	}
+	rb.Codepoints = mirrorSideArray(rb.Codepoints, edit, src)
+	rb.Clusters = mirrorSideArray(rb.Clusters, edit, src)
+	rb.PlanIDs = mirrorSideArray(rb.PlanIDs, edit, src)
+	rb.Masks = mirrorSideArray(rb.Masks, edit, src)
+	rb.UnsafeFlags = mirrorSideArray(rb.UnsafeFlags, edit, src)
+	rb.Syllables = mirrorSideArray(rb.Syllables, edit, src)
+	rb.Joiners = mirrorSideArray(rb.Joiners, edit, src)
+	rb.Components = mirrorSideArray(rb.Components, edit, src)
+	if hasCluster {
+		for i := edit.From; i < edit.From+edit.Len; i++ {
+			rb.Clusters[i] = cluster
+		}
+	}
+	if components != nil && edit.Len > 0 && edit.From < len(rb.Components) {
+		rb.Components[edit.From] = components
+	}
+}
+
+// ligatureComponents collects the input clusters of the components of the
+// ligature formed by edit. Components which are ligatures themselves
+// contribute their components.
+func (rb *runBuffer) ligatureComponents(edit otlayout.EditSpan) []uint32 {
+	start := len(rb.components)
+	for i := edit.From; i < edit.To; i++ {
+		if k := i - edit.From; k >= 64 || edit.Components&(1<<k) == 0 {
+			continue
+		}
+		if i < len(rb.Components) && rb.Components[i] != nil {
+			rb.components = append(rb.components, rb.Components[i]...)
+		} else {
+			rb.components = append(rb.components, rb.Clusters[i])
+		}
+	}
+	return rb.components[start:len(rb.components):len(rb.components)]
+}
+
+// storeComponents copies ligature components into the storage of rb.
+func (rb *runBuffer) storeComponents(components ...uint32) []uint32 {
+	start := len(rb.components)
+	rb.components = append(rb.components, components...)
+	return rb.components[start:len(rb.components):len(rb.components)]
+}
+
+// newComponents returns storage for the ligature components of n glyphs.
+func (rb *runBuffer) newComponents(n int) [][]uint32 {
+	if c := reclaim(&rb.spare.components, n); c != nil {
+		return c
+	}
+	return make([][]uint32, n, maxInt(cap(rb.Glyphs), n))
 }
 
 // InsertGlyphs inserts glyphs at index and keeps all active side arrays aligned.
@@ -684,6 +838,23 @@ func (rb *runBuffer) InsertGlyphCopies(index int, source int, count int) (int, i
 	return start, end
 }
 
+// DeleteGlyphs removes glyphs start…end-1 and keeps all active side arrays aligned.
+func (rb *runBuffer) DeleteGlyphs(start, end int) {
+	if rb == nil {
+		return
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > rb.Len() {
+		end = rb.Len()
+	}
+	if start >= end {
+		return
+	}
+	rb.ApplyEdit(&otlayout.EditSpan{From: start, To: end, Len: 0})
+}
+
 func applyEditUint32(s []uint32, edit *otlayout.EditSpan) []uint32 {
 	repl := make([]uint32, edit.Len)
 	out := append(s[:edit.From:edit.From], repl...)
@@ -705,6 +876,37 @@ func applyEditUint8(s []uint8, edit *otlayout.EditSpan) []uint8 {
 	return out
 }
 
+func applyEditComponents(s [][]uint32, edit *otlayout.EditSpan) [][]uint32 {
+	repl := make([][]uint32, edit.Len)
+	out := append(s[:edit.From:edit.From], repl...)
+	out = append(out, s[edit.To:]...)
+	return out
+}
+
+// mirrorSideArray replaces the range [edit.From:edit.To) of side array s by
+// edit.Len copies of s[src], re-using the storage of s. If s does not cover
+// the range, it is returned unchanged.
+func mirrorSideArray[S ~[]E, E any](s S, edit otlayout.EditSpan, src int) S {
+	if s == nil || edit.To > len(s) {
+		return s
+	}
+	var seed E
+	if src >= 0 && src < len(s) {
+		seed = s[src]
+	}
+	n, removed := len(s), edit.To-edit.From
+	if k := edit.Len - removed; k > 0 {
+		s = slices.Grow(s, k)[:n+k]
+		copy(s[edit.To+k:], s[edit.To:n])
+	} else if k < 0 {
+		s = slices.Delete(s, edit.From+edit.Len, edit.To)
+	}
+	for i := edit.From; i < edit.From+edit.Len; i++ {
+		s[i] = seed
+	}
+	return s
+}
+
 func applyEditRunes(s []rune, edit *otlayout.EditSpan) []rune {
 	repl := make([]rune, edit.Len)
 	out := append(s[:edit.From:edit.From], repl...)
@@ -793,6 +995,16 @@ func resizeUint8(s []uint8, n int) []uint8 {
 	return out
 }
 
+func resizeComponents(s [][]uint32, n int) [][]uint32 {
+	if n <= len(s) {
+		clear(s[n:])
+		return s[:n]
+	}
+	out := make([][]uint32, n)
+	copy(out, s)
+	return out
+}
+
 func resizeRunes(s []rune, n int) []rune {
 	if n <= len(s) {
 		return s[:n]
@@ -802,6 +1014,27 @@ func resizeRunes(s []rune, n int) []rune {
 	return out
 }
 
+// park moves a side-array into spare storage and returns nil, the value for a
+// deactivated side-array. Inactive (nil) arrays leave the spare untouched.
+func park[S ~[]E, E any](spare *S, s S) S {
+	if s != nil {
+		*spare = s[:0]
+	}
+	return nil
+}
+
+// reclaim returns a zeroed slice of length n from spare storage, or nil if the
+// spare storage is too small.
+func reclaim[S ~[]E, E any](spare *S, n int) S {
+	if *spare == nil || cap(*spare) < n {
+		return nil
+	}
+	s := (*spare)[:n]
+	clear(s)
+	*spare = nil
+	return s
+}
+
 func maxInt(a, b int) int {
 	if a >= b {
 		return a
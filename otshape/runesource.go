@@ -0,0 +1,155 @@
+package otshape
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"unicode/utf8"
+)
+
+// --- RuneSource adapters -------------------------------------------------
+
+// StringSource returns a RuneSource reading the runes of s.
+func StringSource(s string) RuneSource {
+	return strings.NewReader(s)
+}
+
+// BytesSource returns a RuneSource reading the UTF-8 encoded runes of b.
+// Invalid UTF-8 sequences are read as U+FFFD, one byte at a time.
+func BytesSource(b []byte) RuneSource {
+	return bytes.NewReader(b)
+}
+
+// ReaderSource returns a RuneSource reading UTF-8 encoded runes from r. If r
+// already implements io.RuneReader, it is returned unchanged; otherwise r is
+// wrapped into a bufio.Reader.
+func ReaderSource(r io.Reader) RuneSource {
+	if r == nil {
+		return nil
+	}
+	if rr, ok := r.(io.RuneReader); ok {
+		return rr
+	}
+	return bufio.NewReader(r)
+}
+
+// RuneSliceSource is a RuneSource reading from a slice of runes.
+type RuneSliceSource struct {
+	runes []rune
+	pos   int
+}
+
+// NewRuneSliceSource returns a RuneSource reading the runes of text.
+// text is not copied and must not be modified while reading.
+func NewRuneSliceSource(text []rune) *RuneSliceSource {
+	return &RuneSliceSource{runes: text}
+}
+
+// ReadRune returns the next rune and its size in UTF-8 encoding, or io.EOF
+// after the last rune.
+func (s *RuneSliceSource) ReadRune() (rune, int, error) {
+	if s.pos >= len(s.runes) {
+		return 0, 0, io.EOF
+	}
+	r := s.runes[s.pos]
+	s.pos++
+	return r, runeLen(r), nil
+}
+
+// runeLen returns the UTF-8 size of r, counting invalid runes as U+FFFD.
+func runeLen(r rune) int {
+	if n := utf8.RuneLen(r); n > 0 {
+		return n
+	}
+	return utf8.RuneLen(utf8.RuneError)
+}
+
+// --- Paragraph segmentation -----------------------------------------------
+
+// ParagraphReader splits a stream of runes into paragraphs, for shaping text
+// paragraph by paragraph. Paragraphs are terminated by paragraph separators
+// in the sense of the Unicode bidi algorithm (bidi class B): LF, CR, CR+LF,
+// NEL (U+0085), PARAGRAPH SEPARATOR (U+2029), and the information separators
+// U+001C–U+001E. The separator is included at the end of the paragraph.
+//
+// Typical usage:
+//
+//	paras := otshape.NewParagraphReader(otshape.ReaderSource(file))
+//	for {
+//	    text, err := paras.Next()
+//	    if err == io.EOF {
+//	        break
+//	    }
+//	    …
+//	    err = shaper.Shape(params, otshape.NewRuneSliceSource(text), sink, bufOpts)
+//	}
+type ParagraphReader struct {
+	MaxLen int // if > 0, paragraphs longer than MaxLen runes are split into chunks
+	src    io.RuneReader
+	buf    []rune
+	err    error
+	peeked rune // rune read ahead after CR, or -1
+}
+
+// NewParagraphReader creates a ParagraphReader reading from src.
+func NewParagraphReader(src io.RuneReader) *ParagraphReader {
+	return &ParagraphReader{src: src, peeked: -1}
+}
+
+// Next returns the next paragraph. The returned slice is valid until the next
+// call to Next. After the last paragraph, Next returns io.EOF. Read errors of
+// the underlying source other than io.EOF are returned after the runes read
+// before the error have been delivered.
+func (p *ParagraphReader) Next() ([]rune, error) {
+	p.buf = p.buf[:0]
+	for p.MaxLen <= 0 || len(p.buf) < p.MaxLen {
+		r, ok := p.read()
+		if !ok {
+			break
+		}
+		p.buf = append(p.buf, r)
+		if r == '\r' {
+			if r, ok := p.read(); ok && r == '\n' {
+				p.buf = append(p.buf, r)
+			} else if ok {
+				p.peeked = r
+			}
+			return p.buf, nil
+		}
+		if isParagraphSeparator(r) {
+			return p.buf, nil
+		}
+	}
+	if len(p.buf) > 0 {
+		return p.buf, nil
+	}
+	return nil, p.err
+}
+
+// read returns the next rune, either a rune read ahead or one from the source.
+func (p *ParagraphReader) read() (rune, bool) {
+	if p.peeked >= 0 {
+		r := p.peeked
+		p.peeked = -1
+		return r, true
+	}
+	if p.err != nil {
+		return 0, false
+	}
+	r, _, err := p.src.ReadRune()
+	if err != nil {
+		p.err = err
+		return 0, false
+	}
+	return r, true
+}
+
+// isParagraphSeparator reports whether r has bidi class B.
+func isParagraphSeparator(r rune) bool {
+	switch r {
+	case '\n', '\r', 0x1c, 0x1d, 0x1e, 0x85, 0x2029:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,88 @@
+package otshape
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func readAllRunes(t *testing.T, src RuneSource) string {
+	t.Helper()
+	var sb strings.Builder
+	for {
+		r, _, err := src.ReadRune()
+		if err == io.EOF {
+			return sb.String()
+		}
+		if err != nil {
+			t.Fatalf("unexpected read error: %v", err)
+		}
+		sb.WriteRune(r)
+	}
+}
+
+func TestRuneSourceAdapters(t *testing.T) {
+	const text = "Grüße, 世界 😀"
+	sources := map[string]RuneSource{
+		"string": StringSource(text),
+		"bytes":  BytesSource([]byte(text)),
+		"reader": ReaderSource(iotest.OneByteReader(strings.NewReader(text))),
+		"runes":  NewRuneSliceSource([]rune(text)),
+	}
+	for name, src := range sources {
+		if got := readAllRunes(t, src); got != text {
+			t.Errorf("%s source: read %q, want %q", name, got, text)
+		}
+	}
+	src := NewRuneSliceSource([]rune{'a', 'ü', '世', '😀'})
+	for _, want := range []int{1, 2, 3, 4} {
+		if _, size, _ := src.ReadRune(); size != want {
+			t.Errorf("rune slice source: size %d, want %d", size, want)
+		}
+	}
+}
+
+func TestParagraphReader(t *testing.T) {
+	paras := NewParagraphReader(strings.NewReader("one\ntwo\r\nthree\rfour \r\rfive"))
+	want := []string{"one\n", "two\r\n", "three\r", "four ", "\r", "\r", "five"}
+	for i := 0; ; i++ {
+		text, err := paras.Next()
+		if err == io.EOF {
+			if i != len(want) {
+				t.Fatalf("got %d paragraphs, want %d", i, len(want))
+			}
+			break
+		}
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if i >= len(want) || string(text) != want[i] {
+			t.Fatalf("paragraph #%d = %q", i, string(text))
+		}
+	}
+}
+
+func TestParagraphReaderMaxLenAndErrors(t *testing.T) {
+	paras := NewParagraphReader(strings.NewReader("abcdefg\nhi"))
+	paras.MaxLen = 3
+	var got []string
+	for {
+		text, err := paras.Next()
+		if err != nil {
+			break
+		}
+		got = append(got, string(text))
+	}
+	if strings.Join(got, "|") != "abc|def|g\n|hi" {
+		t.Errorf("chunks = %q", got)
+	}
+	failing := NewParagraphReader(ReaderSource(iotest.TimeoutReader(strings.NewReader("ab"))).(io.RuneReader))
+	if text, err := failing.Next(); err != nil || string(text) != "ab" {
+		t.Errorf("expected runes before error, got %q, %v", string(text), err)
+	}
+	if _, err := failing.Next(); !errors.Is(err, iotest.ErrTimeout) {
+		t.Errorf("expected timeout error, got %v", err)
+	}
+}
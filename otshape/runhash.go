@@ -0,0 +1,32 @@
+package otshape
+
+import (
+	"encoding/binary"
+	"hash/fnv"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// HashGlyphRun computes a stable 64-bit digest of a shaped glyph run, suitable as
+// a key for glyph-run caches of renderers. The digest covers the identity of the
+// font (see ot.Font.UniqueID), and glyph IDs, advances and offsets of all glyphs,
+// in order, hashed with 64-bit FNV-1a. Clusters, masks and flags are not part
+// of the digest, as they do not influence rendering.
+//
+// The digest does not depend on the platform or on the Go version and may
+// therefore be persisted.
+func HashGlyphRun(font *ot.Font, glyphs []GlyphRecord) uint64 {
+	h := fnv.New64a()
+	buf := binary.LittleEndian.AppendUint64(make([]byte, 0, 20), font.UniqueID())
+	h.Write(binary.LittleEndian.AppendUint32(buf, uint32(len(glyphs))))
+	for i := range glyphs {
+		g := &glyphs[i]
+		buf = binary.LittleEndian.AppendUint32(buf[:0], uint32(g.GID))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Pos.XAdvance))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Pos.YAdvance))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Pos.XOffset))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Pos.YOffset))
+		h.Write(buf)
+	}
+	return h.Sum64()
+}
@@ -0,0 +1,53 @@
+package otshape
+
+import (
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+)
+
+func TestHashGlyphRun(t *testing.T) {
+	calibri := loadLocalFont(t, "Calibri.ttf")
+	gentium := loadLocalFont(t, "GentiumPlus-R.ttf")
+	run := func() []GlyphRecord {
+		return []GlyphRecord{
+			{GID: 4, Pos: otlayout.PosItem{XAdvance: 1185}, Cluster: 0},
+			{GID: 258, Pos: otlayout.PosItem{XAdvance: 1000, XOffset: -20}, Cluster: 1},
+		}
+	}
+	h := HashGlyphRun(calibri, run())
+	if h != HashGlyphRun(calibri, run()) {
+		t.Fatalf("expected hash of identical runs to be identical")
+	}
+	if h == HashGlyphRun(gentium, run()) {
+		t.Errorf("expected hash to depend on font identity")
+	}
+	r := run()
+	r[1].Cluster, r[1].Mask = 7, 0xff
+	if h != HashGlyphRun(calibri, r) {
+		t.Errorf("expected hash not to depend on clusters and masks")
+	}
+	for i, modify := range []func(r []GlyphRecord){
+		func(r []GlyphRecord) { r[0].GID = 5 },
+		func(r []GlyphRecord) { r[1].Pos.XOffset = 0 },
+		func(r []GlyphRecord) { r[1].Pos.YAdvance = 1 },
+		func(r []GlyphRecord) { r[0], r[1] = r[1], r[0] },
+	} {
+		r := run()
+		modify(r)
+		if h == HashGlyphRun(calibri, r) {
+			t.Errorf("modification #%d: expected hash to change", i)
+		}
+	}
+	if HashGlyphRun(calibri, nil) == h {
+		t.Errorf("expected empty run to hash differently")
+	}
+	other, err := ot.Parse(calibri.Binary())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.UniqueID() != calibri.UniqueID() {
+		t.Errorf("expected font identity to be stable across parses")
+	}
+}
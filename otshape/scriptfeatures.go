@@ -0,0 +1,181 @@
+package otshape
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/npillmayer/opentype/ot"
+	"golang.org/x/text/language"
+)
+
+// ScriptFeatures lists the features the plan builder turns on by default for
+// a script. GSUB features are applied stage by stage, in list order; the
+// lookups of GPOS features are applied in lookup order.
+//
+// Shaping engines may add features of their own (see FeaturePlanner), which
+// are applied after the listed ones, and clients may switch listed features
+// off with [Params].Features.
+type ScriptFeatures struct {
+	GSUB []ot.Tag
+	GPOS []ot.Tag
+}
+
+func (f ScriptFeatures) clone() ScriptFeatures {
+	return ScriptFeatures{GSUB: slices.Clone(f.GSUB), GPOS: slices.Clone(f.GPOS)}
+}
+
+// commonFeatures are turned on for scripts without features of their own.
+var commonFeatures = ScriptFeatures{
+	GSUB: []ot.Tag{
+		ot.T("locl"),
+		ot.T("ccmp"),
+		ot.T("rlig"),
+		ot.T("rclt"),
+		ot.T("calt"),
+		ot.T("clig"),
+		ot.T("liga"),
+	},
+	GPOS: []ot.Tag{
+		ot.T("abvm"),
+		ot.T("blwm"),
+		ot.T("mark"),
+		ot.T("mkmk"),
+		ot.T("curs"),
+		ot.T("dist"),
+		ot.T("kern"),
+	},
+}
+
+// joiningFeatures are turned on for joining scripts: the joining forms come
+// before required ligatures and contextual alternates, which may match them.
+var joiningFeatures = ScriptFeatures{
+	GSUB: []ot.Tag{
+		ot.T("stch"),
+		ot.T("locl"),
+		ot.T("ccmp"),
+		ot.T("isol"),
+		ot.T("fina"),
+		ot.T("fin2"),
+		ot.T("fin3"),
+		ot.T("medi"),
+		ot.T("med2"),
+		ot.T("init"),
+		ot.T("rlig"),
+		ot.T("calt"),
+		ot.T("rclt"),
+		ot.T("liga"),
+		ot.T("clig"),
+		ot.T("mset"),
+	},
+	GPOS: commonFeatures.GPOS,
+}
+
+// tibetanFeatures stack consonants before the horizontal features apply.
+var tibetanFeatures = ScriptFeatures{
+	GSUB: []ot.Tag{
+		ot.T("locl"),
+		ot.T("ccmp"),
+		ot.T("blws"),
+		ot.T("abvs"),
+		ot.T("rlig"),
+		ot.T("rclt"),
+		ot.T("calt"),
+		ot.T("clig"),
+		ot.T("liga"),
+	},
+	GPOS: commonFeatures.GPOS,
+}
+
+var scriptFeatures = struct {
+	sync.RWMutex
+	byScript map[language.Script]ScriptFeatures
+}{byScript: map[language.Script]ScriptFeatures{
+	language.MustParseScript("Latn"): commonFeatures,
+	language.MustParseScript("Arab"): joiningFeatures,
+	language.MustParseScript("Syrc"): joiningFeatures,
+	language.MustParseScript("Mong"): joiningFeatures,
+	language.MustParseScript("Phag"): joiningFeatures,
+	language.MustParseScript("Tibt"): tibetanFeatures,
+}}
+
+// DefaultScriptFeatures returns the features turned on by default for script.
+// Scripts without features of their own get a common set of features. The
+// result is a copy, which clients may modify and register with
+// RegisterScriptFeatures.
+func DefaultScriptFeatures(script language.Script) ScriptFeatures {
+	scriptFeatures.RLock()
+	defer scriptFeatures.RUnlock()
+	if f, ok := scriptFeatures.byScript[script]; ok {
+		return f.clone()
+	}
+	return commonFeatures.clone()
+}
+
+// RegisterScriptFeatures sets the features turned on by default for script,
+// replacing the built-in ones. Registering a zero ScriptFeatures makes script
+// use the common set of features.
+//
+// Registration affects plans compiled afterwards, but not shapers which
+// already have shaped text of script; clients usually register features from
+// an init function. RegisterScriptFeatures is safe for concurrent use.
+func RegisterScriptFeatures(script language.Script, f ScriptFeatures) {
+	scriptFeatures.Lock()
+	defer scriptFeatures.Unlock()
+	if f.GSUB == nil && f.GPOS == nil {
+		delete(scriptFeatures.byScript, script)
+		return
+	}
+	scriptFeatures.byScript[script] = f.clone()
+}
+
+// verticalVariants maps features to the variants which replace them in
+// vertical layout.
+var verticalVariants = map[ot.Tag]ot.Tag{
+	ot.T("kern"): ot.T("vkrn"),
+	ot.T("palt"): ot.T("vpal"),
+	ot.T("halt"): ot.T("vhal"),
+	ot.T("chws"): ot.T("vchw"),
+}
+
+// horizontalFeatures are not turned on by default in vertical layout.
+var horizontalFeatures = []ot.Tag{
+	ot.T("calt"), ot.T("clig"), ot.T("curs"), ot.T("dist"), ot.T("liga"), ot.T("rclt"),
+}
+
+// vertical returns the features of f for vertical layout: horizontal features
+// are dropped, features with vertical variants are replaced by them, and
+// GSUB feature 'vert' is added.
+func (f ScriptFeatures) vertical() ScriptFeatures {
+	convert := func(tags []ot.Tag) []ot.Tag {
+		out := make([]ot.Tag, 0, len(tags)+1)
+		for _, tag := range tags {
+			if slices.Contains(horizontalFeatures, tag) {
+				continue
+			}
+			if v, ok := verticalVariants[tag]; ok {
+				tag = v
+			}
+			if !slices.Contains(out, tag) {
+				out = append(out, tag)
+			}
+		}
+		return out
+	}
+	v := ScriptFeatures{GSUB: convert(f.GSUB), GPOS: convert(f.GPOS)}
+	if !slices.Contains(v.GSUB, ot.T("vert")) {
+		v.GSUB = append(v.GSUB, ot.T("vert"))
+	}
+	return v
+}
+
+// verticalFeatureRanges returns features with tags replaced by their vertical
+// variants. Features without a vertical variant are kept.
+func verticalFeatureRanges(features []FeatureRange) []FeatureRange {
+	out := slices.Clone(features)
+	for i, f := range out {
+		if v, ok := verticalVariants[f.Feature]; ok {
+			out[i].Feature = v
+		}
+	}
+	return out
+}
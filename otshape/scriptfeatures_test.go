@@ -0,0 +1,147 @@
+package otshape
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/bidi"
+)
+
+func TestDefaultScriptFeatures(t *testing.T) {
+	arab := DefaultScriptFeatures(language.MustParseScript("Arab"))
+	if i, j := slices.Index(arab.GSUB, ot.T("init")), slices.Index(arab.GSUB, ot.T("rlig")); i < 0 || j < i {
+		t.Errorf("expected Arabic joining forms before required ligatures, have %v", arab.GSUB)
+	}
+	common := DefaultScriptFeatures(language.MustParseScript("Cher"))
+	if !slices.Contains(common.GSUB, ot.T("liga")) || !slices.Contains(common.GPOS, ot.T("kern")) {
+		t.Errorf("expected common features for a script without features of its own, have %v", common)
+	}
+	common.GSUB[0] = ot.T("smcp")
+	if DefaultScriptFeatures(language.MustParseScript("Cher")).GSUB[0] == ot.T("smcp") {
+		t.Errorf("expected DefaultScriptFeatures to return a copy")
+	}
+}
+
+func TestRegisterScriptFeatures(t *testing.T) {
+	b := testfont.New(3)
+	b.Name(1, "a").Name(2, "a.sc")
+	b.Map('a', 1)
+	if err := b.Features(`languagesystem latn dflt;
+feature smcp { sub a by a.sc; } smcp;
+`); err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	font, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	shape := func() ot.GlyphIndex {
+		t.Helper()
+		var sink collectSink
+		if err := NewShaper(plainShaper{}).Shape(standardParams(font), strings.NewReader("a"), &sink, BufferOptions{}); err != nil {
+			t.Fatalf("shape failed: %v", err)
+		}
+		return sink.glyphs[0].GID
+	}
+	if gid := shape(); gid != 1 {
+		t.Fatalf("expected smcp to be off by default, have glyph %d", gid)
+	}
+	latn := language.MustParseScript("Latn")
+	builtin := DefaultScriptFeatures(latn)
+	custom := DefaultScriptFeatures(latn)
+	custom.GSUB = append(custom.GSUB, ot.T("smcp"))
+	RegisterScriptFeatures(latn, custom)
+	defer RegisterScriptFeatures(latn, builtin)
+	if gid := shape(); gid != 2 {
+		t.Errorf("expected registered feature smcp to be on by default, have glyph %d", gid)
+	}
+}
+
+func TestVerticalScriptFeatures(t *testing.T) {
+	v := DefaultScriptFeatures(language.MustParseScript("Latn")).vertical()
+	if slices.Contains(v.GSUB, ot.T("liga")) || !slices.Contains(v.GSUB, ot.T("vert")) {
+		t.Errorf("expected vertical GSUB features without liga and with vert, have %v", v.GSUB)
+	}
+	if slices.Contains(v.GPOS, ot.T("kern")) || !slices.Contains(v.GPOS, ot.T("vkrn")) {
+		t.Errorf("expected vertical GPOS feature vkrn to replace kern, have %v", v.GPOS)
+	}
+}
+
+func TestVerticalSpacingFeatures(t *testing.T) {
+	b := testfont.New(4)
+	b.Name(1, "uni3001").Name(2, "uni3001.vert").Name(3, "uni4E00")
+	b.Map('、', 1).Map('一', 3)
+	b.Advance(1, 1000).Advance(2, 1000).Advance(3, 1000)
+	if err := b.Features(`languagesystem hani dflt;
+feature vert { sub uni3001 by uni3001.vert; } vert;
+feature palt { pos uni4E00 <-100 0 -200 0>; } palt;
+feature halt { pos uni4E00 <0 0 -500 0>; } halt;
+feature vpal { pos uni4E00 <0 -100 0 -200>; } vpal;
+feature vhal { pos uni4E00 <0 0 0 -500>; } vhal;
+`); err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	font, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	params := Params{
+		Font:      font,
+		Direction: bidi.LeftToRight,
+		Script:    language.MustParseScript("Hani"),
+		Language:  language.Japanese,
+	}
+	shape := func(params Params) []GlyphRecord {
+		t.Helper()
+		var sink collectSink
+		if err := NewShaper(plainShaper{}).Shape(params, strings.NewReader("一、"), &sink, BufferOptions{}); err != nil {
+			t.Fatalf("shape failed: %v", err)
+		}
+		return sink.glyphs
+	}
+	tags := func(params Params) []string {
+		t.Helper()
+		desc, err := NewShaper(plainShaper{}).DescribePlan(params)
+		if err != nil {
+			t.Fatalf("cannot describe plan: %v", err)
+		}
+		var tags []string
+		for _, f := range append(desc.GSUB.Features, desc.GPOS.Features...) {
+			tags = append(tags, f.Tag)
+		}
+		return tags
+	}
+	for _, tc := range []struct {
+		vertical bool
+		feature  string
+		want     []string
+		pos      otlayout.PosItem // positioning of uni4E00
+		comma    ot.GlyphIndex
+	}{
+		{false, "palt", []string{"palt"}, otlayout.PosItem{XOffset: -100, XAdvance: 800}, 1},
+		{false, "halt", []string{"halt"}, otlayout.PosItem{XAdvance: 500}, 1},
+		{true, "palt", []string{"vert", "vpal"}, otlayout.PosItem{YOffset: -100, XAdvance: 1000, YAdvance: -200}, 2},
+		{true, "halt", []string{"vert", "vhal"}, otlayout.PosItem{XAdvance: 1000, YAdvance: -500}, 2},
+	} {
+		params := params
+		params.Vertical = tc.vertical
+		params.Features = []FeatureRange{{Feature: ot.T(tc.feature), On: true}}
+		if have := tags(params); !slices.Equal(have, tc.want) {
+			t.Errorf("vertical=%v, %s: expected features %v, have %v", tc.vertical, tc.feature, tc.want, have)
+		}
+		glyphs := shape(params)
+		if len(glyphs) != 2 || glyphs[1].GID != tc.comma {
+			t.Fatalf("vertical=%v, %s: expected comma glyph %d, have %+v", tc.vertical, tc.feature, tc.comma, glyphs)
+		}
+		pos := glyphs[0].Pos
+		if pos.XAdvance != tc.pos.XAdvance || pos.YAdvance != tc.pos.YAdvance ||
+			pos.XOffset != tc.pos.XOffset || pos.YOffset != tc.pos.YOffset {
+			t.Errorf("vertical=%v, %s: expected position %+v, have %+v", tc.vertical, tc.feature, tc.pos, pos)
+		}
+	}
+}
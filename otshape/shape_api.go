@@ -1,8 +1,11 @@
 package otshape
 
 import (
+	"context"
 	"errors"
+	"sync"
 
+	"github.com/npillmayer/opentype/internal/guard"
 	"github.com/npillmayer/opentype/ot"
 	"github.com/npillmayer/opentype/otquery"
 	"golang.org/x/text/language"
@@ -22,13 +25,26 @@ var (
 	ErrNilGlyphSink = errors.New("otshape: nil glyph sink")
 	// ErrFlushExplicitUnsupported indicates that FlushExplicit is not yet implemented.
 	ErrFlushExplicitUnsupported = errors.New("otshape: FlushExplicit is not supported yet")
+	// ErrInternal indicates that shaping has been aborted by an internal error,
+	// e.g. a failed assertion or a runtime error caused by a malformed font.
+	ErrInternal = errors.New("otshape: internal error")
 )
 
 // Shaper is the injectable top-level shaping orchestrator.
 //
 // It intentionally has no global registry; callers provide candidate shapers.
+//
+// A Shaper caches the compiled shaping plan and the buffers of its most recent
+// [Shaper.Shape] call. Shaping many short strings with identical [Params] through
+// one long-lived Shaper is therefore the fast path: after warm-up, shaping a
+// short Latin string does not allocate on the heap (given a sink that does not
+// allocate). A Shaper is safe for concurrent use; concurrent calls simply do not
+// share cached state.
 type Shaper struct {
 	Engines []ShapingEngine
+
+	mu   sync.Mutex
+	idle *shapeSession // session parked by the most recent successful Shape call
 }
 
 // NewShaper creates a shaper from explicit candidate engines.
@@ -55,7 +71,22 @@ func NewShaper(engines ...ShapingEngine) *Shaper {
 //
 // Returns nil on success, or an error for invalid inputs, source/sink failures,
 // missing/invalid shaper selection, plan compilation failure, or pipeline failure.
+//
+// Shape does not panic, not even for malformed fonts: panics of the shaping
+// pipeline, including panics of src and sink, are recovered and reported as
+// errors matching [ErrInternal]. Building with tag 'otassert' disables
+// recovering, to debug internal errors.
 func (s *Shaper) Shape(params Params, src RuneSource, sink GlyphSink, bufOpts BufferOptions) error {
+	return s.ShapeContext(context.Background(), params, src, sink, bufOpts)
+}
+
+// ShapeContext is like [Shaper.Shape], but may be cancelled by ctx, e.g. to
+// bound the time spent on extremely long inputs or pathological fonts.
+// Cancellation is checked between reads from src, between the stages of
+// feature application, and periodically while lookups are applied. If ctx is
+// done, ShapeContext returns ctx.Err(); glyphs already written to sink remain
+// written.
+func (s *Shaper) ShapeContext(ctx context.Context, params Params, src RuneSource, sink GlyphSink, bufOpts BufferOptions) error {
 	if params.Font == nil {
 		return ErrNilFont
 	}
@@ -68,48 +99,60 @@ func (s *Shaper) Shape(params Params, src RuneSource, sink GlyphSink, bufOpts Bu
 	if bufOpts.FlushBoundary == FlushExplicit {
 		return ErrFlushExplicitUnsupported
 	}
-	ctx := selectionContextFromParams(params)
-	engine, err := selectShapingEngine(s.Engines, ctx)
-	if err != nil {
-		return err
-	}
-	compiler := newPlanCompiler(params, ctx, engine)
+	return s.shapeStream(ctx, params, src, bufOpts, false, func(run *runBuffer, end int) error {
+		return writeRunBufferPrefixToSinkWithFont(run, sink, params.Font, bufOpts.FlushBoundary, end)
+	})
+}
 
-	plan, err := compiler.compileDefault()
+// shapeStream runs the streaming shaping loop of [Shaper.ShapeContext] and hands
+// every flushable prefix of shaped glyphs to emit. Sessions for width-only
+// measurement (see [Measure]) use a plan without lookups which cannot change
+// advances.
+func (s *Shaper) shapeStream(ctx context.Context, params Params, src RuneSource, bufOpts BufferOptions,
+	widthOnly bool, emit func(run *runBuffer, end int) error) (err error) {
+	defer guard.Recover(&err, tracer(), "otshape.Shape", ErrInternal)
+	cfg, err := resolveStreamingConfig(bufOpts)
 	if err != nil {
 		return err
 	}
-	cfg, err := resolveStreamingConfig(bufOpts)
+	sess, err := s.acquireSession(params, cfg, widthOnly)
 	if err != nil {
 		return err
 	}
-	ing := newStreamIngestor(cfg)
+	selCtx, engine, plan := sess.ctx, sess.engine, sess.plan
+	ing, ws := sess.ing, sess.ws
 	strState := ing.state()
-	ws := newShapeWorkspace(cfg.maxBuffer)
+	ws.exec.configure(ctx, bufOpts)
 
+	written := 0 // number of glyphs emitted
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if _, err := ing.fillRunes(src); err != nil {
 			return err
 		}
 		if len(strState.rawRunes) == 0 {
 			if strState.eof {
+				s.releaseSession(sess)
 				return nil
 			}
 			continue
 		}
 
 		runes, clusters := ws.copyRaw(strState)
-		runes, clusters = ws.normalize(runes, clusters, params.Font, ctx, engine, plan)
+		runes, clusters = ws.normalize(runes, clusters, params.Font, selCtx, engine, plan)
 		run := ws.mapMain(runes, clusters, nil, params.Font)
 		if run.Len() == 0 {
 			ing.compact(len(strState.rawRunes))
 			if strState.eof {
+				s.releaseSession(sess)
 				return nil
 			}
 			continue
 		}
 
-		if err := shapeMappedRun(run, engine, plan); err != nil {
+		if err := shapeMappedRun(&ws.exec, run, engine, plan); err != nil {
 			return err
 		}
 		cut := findFlushCut(run, strState)
@@ -128,19 +171,22 @@ func (s *Shaper) Shape(params Params, src RuneSource, sink GlyphSink, bufOpts Bu
 			}
 			continue
 		}
-		if err := writeRunBufferPrefixToSinkWithFont(run, sink, params.Font, bufOpts.FlushBoundary, cut.glyphCut); err != nil {
+		run.written = written
+		if err := emit(run, cut.glyphCut); err != nil {
 			return err
 		}
+		written += cut.glyphCut
 		ing.compact(cut.rawFlush)
 		if strState.eof {
 			if len(strState.rawRunes) == 0 {
+				s.releaseSession(sess)
 				return nil
 			}
 		}
 	}
 }
 
-func shapeMappedRun(run *runBuffer, engine ShapingEngine, pl *plan) error {
+func shapeMappedRun(exec *planExecutor, run *runBuffer, engine ShapingEngine, pl *plan) error {
 	if run == nil || run.Len() == 0 {
 		return nil
 	}
@@ -155,7 +201,6 @@ func shapeMappedRun(run *runBuffer, engine ShapingEngine, pl *plan) error {
 		hook.PrepareGSUB(rc)
 	}
 
-	exec := &planExecutor{}
 	exec.acquireBuffer(run)
 	defer exec.releaseBuffer()
 
@@ -169,6 +214,7 @@ func shapeMappedRun(run *runBuffer, engine ShapingEngine, pl *plan) error {
 	if hook, ok := engine.(ShapingEnginePostprocessHook); ok {
 		hook.PostprocessRun(rc)
 	}
+	exec.handleIgnorables(pl)
 	return nil
 }
 
@@ -222,6 +268,16 @@ func selectionContextFromParams(params Params) SelectionContext {
 		Language:  params.Language,
 		ScriptTag: scriptTag,
 		LangTag:   langTag,
+		Vertical:  params.Vertical,
+	}
+}
+
+func segmentPropsFromParams(params Params) segmentProps {
+	return segmentProps{
+		Direction: params.Direction,
+		Script:    params.Script,
+		Language:  params.Language,
+		Vertical:  params.Vertical,
 	}
 }
 
@@ -265,7 +321,7 @@ func compileShapePlanWithFeatures(params Params, ctx SelectionContext, engine Sh
 	}
 	req := planRequest{
 		Font:      params.Font,
-		Props:     segmentProps{Direction: params.Direction, Script: params.Script, Language: params.Language},
+		Props:     segmentPropsFromParams(params),
 		ScriptTag: ctx.ScriptTag,
 		LangTag:   ctx.LangTag,
 		Selection: ctx,
@@ -273,7 +329,11 @@ func compileShapePlanWithFeatures(params Params, ctx SelectionContext, engine Sh
 		Policy:    policy,
 	}
 	req.UserFeatures = append(req.UserFeatures, features...)
-	return compile(req)
+	pl, err := compile(req)
+	if err == nil {
+		traceCompiledPlan(pl)
+	}
+	return pl, err
 }
 
 func mapRunesToRunBuffer(runes []rune, clusters []uint32, font *ot.Font) *runBuffer {
@@ -292,6 +352,9 @@ func mapRunesToRunBufferInto(run *runBuffer, runes []rune, clusters []uint32, pl
 	run.PrepareForMappedRun(withPlanIDs, len(runes))
 	for i, r := range runes {
 		gid := otquery.GlyphIndex(font, r)
+		if gid == NOTDEF {
+			gid = mapSpaceFallback(font, r)
+		}
 		cluster := uint32(i)
 		if len(clusters) == len(runes) {
 			cluster = clusters[i]
@@ -575,6 +638,9 @@ func materializeGlyphRecord(
 	record := GlyphRecord{GID: run.Glyphs[inx]}
 	if hasPos {
 		record.Pos = run.Pos[inx]
+		if record.Pos.AttachTo >= 0 {
+			record.Pos.AttachTo += int32(run.written)
+		}
 	}
 	if font != nil {
 		record.Pos.XAdvance += int32(otquery.GlyphMetrics(font, record.GID).Advance)
@@ -588,5 +654,8 @@ func materializeGlyphRecord(
 	if hasUnsafe {
 		record.UnsafeFlags = run.UnsafeFlags[inx]
 	}
+	if len(run.Components) == len(run.Glyphs) {
+		record.Components = run.Components[inx]
+	}
 	return record
 }
@@ -0,0 +1,70 @@
+package otshape
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+type cancellingSink struct {
+	cancel context.CancelFunc
+	n      int
+}
+
+func (s *cancellingSink) WriteGlyph(GlyphRecord) error {
+	s.n++
+	s.cancel()
+	return nil
+}
+
+func TestShapeContextCancelledMidStream(t *testing.T) {
+	font := loadMiniOTFont(t, "gpos3_font1.otf")
+	params := standardParams(font)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sink := &cancellingSink{cancel: cancel}
+	text := strings.Repeat(string([]rune{0x12, 0x13}), 64)
+	opts := BufferOptions{FlushBoundary: FlushOnClusterBoundary, HighWatermark: 4, LowWatermark: 2, MaxBuffer: 8}
+	err := NewShaper(plainShaper{}).ShapeContext(ctx, params, StringSource(text), sink, opts)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("ShapeContext error=%v, want %v", err, context.Canceled)
+	}
+	if sink.n == 0 || sink.n >= 128 {
+		t.Errorf("expected shaping to stop early, sink received %d glyphs", sink.n)
+	}
+}
+
+func TestShapeEventsContextDeadline(t *testing.T) {
+	font := loadMiniOTFont(t, "gpos3_font1.otf")
+	params := standardParams(font)
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	src := NewInputEventSource(StringSource("ab"))
+	err := NewShaper(plainShaper{}).ShapeEventsContext(ctx, params, src, &collectSink{}, singleBufOpts)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("ShapeEventsContext error=%v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestPlanExecutorChecksCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	e := &planExecutor{ctx: ctx}
+	for range 2 * cancelCheckInterval {
+		if err := e.stepCancelled(); err != nil {
+			t.Fatalf("unexpected cancellation: %v", err)
+		}
+	}
+	cancel()
+	var err error
+	for i := 0; i < cancelCheckInterval && err == nil; i++ {
+		err = e.stepCancelled()
+	}
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected cancellation within %d steps, got %v", cancelCheckInterval, err)
+	}
+	if err := (&planExecutor{}).stepCancelled(); err != nil {
+		t.Errorf("executor without context reports %v", err)
+	}
+}
@@ -1,9 +1,12 @@
 package otshape
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
+
+	"github.com/npillmayer/opentype/internal/guard"
 )
 
 var (
@@ -14,6 +17,42 @@ var (
 	ErrEventIndexedFeatureRange = errors.New("otshape: ShapeEvents requires global-only FeatureRange values")
 )
 
+// EventError reports an invalid event of an input event stream, together with
+// its position in the stream. Errors of the feature stack wrap one of
+// [ErrFeatureStackUnderflow] or [ErrFeatureStackUnclosed], which may be tested
+// for with errors.Is.
+type EventError struct {
+	Index    int            // 0-based index of the offending event in the stream
+	Position int            // number of runes read before the offending event
+	Kind     InputEventKind // kind of the offending event
+	Depth    int            // nesting depth of pushed feature frames at the event
+	Err      error          // underlying error
+}
+
+func (e *EventError) Error() string {
+	return fmt.Sprintf("%v (%s event #%d at rune %d, depth %d)", e.Err, e.Kind, e.Index, e.Position, e.Depth)
+}
+
+func (e *EventError) Unwrap() error {
+	return e.Err
+}
+
+// FeatureStackState is a snapshot of the feature set active during
+// [ShapeEvents], reported to a [FeatureStackObserver].
+type FeatureStackState struct {
+	Index    int            // index of the push or pop event which led to this state
+	Position int            // number of runes read before the event
+	Depth    int            // nesting depth of pushed feature frames; 0 is the root
+	Features []FeatureRange // effective global features, sorted by tag
+}
+
+// FeatureStackObserver may be implemented by an [InputEventSource] to observe
+// the active feature set while shaping, e.g. for debugging. ShapeEvents calls
+// FeatureStackChanged after each push or pop event has been applied.
+type FeatureStackObserver interface {
+	FeatureStackChanged(state FeatureStackState)
+}
+
 // ShapeEventsRequest bundles all inputs required by [ShapeEvents].
 //
 // Deprecated: use [ShapeEvents] or [Shaper.ShapeEvents] directly with
@@ -39,7 +78,17 @@ type ShapeEventsRequest struct {
 // In ShapeEvents, params.Features is restricted to global defaults only:
 // each FeatureRange must have Start==0 and End==0. Feature scoping is performed
 // exclusively via InputEventPushFeatures/InputEventPopFeatures events.
+//
+// Invalid events, unbalanced pops and pushes left open at the end of the
+// stream are reported as [*EventError], locating the offending event. Like
+// Shape, ShapeEvents reports internal errors as errors matching [ErrInternal].
 func (s *Shaper) ShapeEvents(params Params, src InputEventSource, sink GlyphSink, bufOpts BufferOptions) error {
+	return s.ShapeEventsContext(context.Background(), params, src, sink, bufOpts)
+}
+
+// ShapeEventsContext is like [Shaper.ShapeEvents], but may be cancelled by
+// ctx, with the same semantics as [Shaper.ShapeContext].
+func (s *Shaper) ShapeEventsContext(ctx context.Context, params Params, src InputEventSource, sink GlyphSink, bufOpts BufferOptions) (err error) {
 	if params.Font == nil {
 		return ErrNilFont
 	}
@@ -55,13 +104,14 @@ func (s *Shaper) ShapeEvents(params Params, src InputEventSource, sink GlyphSink
 	if err := validateEventModeFeatures(params.Features); err != nil {
 		return err
 	}
+	defer guard.Recover(&err, tracer(), "otshape.ShapeEvents", ErrInternal)
 
-	ctx := selectionContextFromParams(params)
-	engine, err := selectShapingEngine(s.Engines, ctx)
+	selCtx := selectionContextFromParams(params)
+	engine, err := selectShapingEngine(s.Engines, selCtx)
 	if err != nil {
 		return err
 	}
-	compiler := newPlanCompiler(params, ctx, engine)
+	compiler := newPlanCompiler(params, selCtx, engine)
 
 	rootFeatures := newFeatureSet(params.Features).asGlobalFeatureRanges()
 	rootPlan, err := compiler.compile(rootFeatures)
@@ -75,7 +125,9 @@ func (s *Shaper) ShapeEvents(params Params, src InputEventSource, sink GlyphSink
 	ing := newStreamIngestor(cfg)
 	st := ing.state()
 	ws := newShapeWorkspace(cfg.maxBuffer)
+	ws.exec.configure(ctx, bufOpts)
 	stack := newPlanStack(rootFeatures, rootPlan)
+	written := 0 // number of glyphs written to sink
 	plansByID := map[uint16]*plan{
 		stack.currentPlanID(): rootPlan,
 	}
@@ -84,6 +136,9 @@ func (s *Shaper) ShapeEvents(params Params, src InputEventSource, sink GlyphSink
 	}
 
 	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 		if _, err := ing.fillEvents(src, stack, plansByID, build); err != nil {
 			return err
 		}
@@ -94,7 +149,7 @@ func (s *Shaper) ShapeEvents(params Params, src InputEventSource, sink GlyphSink
 			continue
 		}
 
-		run, err := shapeEventCarry(ws, st, params, ctx, engine, plansByID)
+		run, err := shapeEventCarry(ws, st, params, selCtx, engine, plansByID)
 		if err != nil {
 			return err
 		}
@@ -121,9 +176,11 @@ func (s *Shaper) ShapeEvents(params Params, src InputEventSource, sink GlyphSink
 			}
 			continue
 		}
+		run.written = written
 		if err := writeRunBufferPrefixToSinkWithFont(run, sink, params.Font, bufOpts.FlushBoundary, cut.glyphCut); err != nil {
 			return err
 		}
+		written += cut.glyphCut
 		ing.compact(cut.rawFlush)
 		if st.eof && len(st.rawRunes) == 0 {
 			return stack.ensureClosed()
@@ -132,12 +189,13 @@ func (s *Shaper) ShapeEvents(params Params, src InputEventSource, sink GlyphSink
 }
 
 func validateEventModeFeatures(features []FeatureRange) error {
-	for _, f := range features {
+	for i, f := range features {
 		if f.Feature == 0 {
 			continue
 		}
 		if f.Start != 0 || f.End != 0 {
-			return ErrEventIndexedFeatureRange
+			return fmt.Errorf("%w: feature #%d '%s' has range [%d:%d]",
+				ErrEventIndexedFeatureRange, i, f.Feature, f.Start, f.End)
 		}
 	}
 	return nil
@@ -180,8 +238,14 @@ func fillEventsUntilBufferLimit(
 		if err != nil {
 			return read, err
 		}
+		pos := eventPos{index: st.nextEvent, position: int(st.nextCluster)}
+		st.nextEvent++
+		eventError := func(err error) error {
+			return &EventError{Index: pos.index, Position: pos.position, Kind: ev.Kind,
+				Depth: stack.depth() - 1, Err: err}
+		}
 		if err := ev.Validate(); err != nil {
-			return read, err
+			return read, eventError(err)
 		}
 		switch ev.Kind {
 		case InputEventRune:
@@ -190,18 +254,20 @@ func fillEventsUntilBufferLimit(
 			st.rawPlanIDs = append(st.rawPlanIDs, stack.currentPlanID())
 			st.nextCluster++
 			read++
+			continue
 		case InputEventPushFeatures:
-			id, err := stack.push(ev.Push, build)
+			id, err := stack.pushAt(pos, ev.Push, build)
 			if err != nil {
-				return read, err
+				return read, eventError(err)
 			}
 			plansByID[id] = stack.currentPlan()
 		case InputEventPopFeatures:
 			if err := stack.pop(); err != nil {
-				return read, err
+				return read, eventError(err)
 			}
-		default:
-			return read, fmt.Errorf("otshape: unknown input event kind %d", ev.Kind)
+		}
+		if obs, ok := src.(FeatureStackObserver); ok {
+			obs.FeatureStackChanged(stack.state(pos))
 		}
 	}
 	st.assertInvariants()
@@ -247,7 +313,7 @@ func shapeEventCarry(
 		}
 		segPlanIDs := ws.spanPlanIDsFor(pid, len(segRunes))
 		segRun := ws.mapSegment(segRunes, segClusters, segPlanIDs, params.Font)
-		if err := shapeMappedRun(segRun, engine, pl); err != nil {
+		if err := shapeMappedRun(&ws.exec, segRun, engine, pl); err != nil {
 			return nil, err
 		}
 		out.AppendRun(segRun)
@@ -78,8 +78,8 @@ func TestShapeEventsPopUnderflowIsError(t *testing.T) {
 	eventSink := &collectSink{}
 	shaper := NewShaper([]ShapingEngine{&hookProbeShaper{}}...)
 	err := shaper.ShapeEvents(params, evsource, eventSink, singleBufOpts)
-	if !errors.Is(err, errPlanStackUnderflow) {
-		t.Fatalf("ShapeEvents error=%v, want %v", err, errPlanStackUnderflow)
+	if !errors.Is(err, ErrFeatureStackUnderflow) {
+		t.Fatalf("ShapeEvents error=%v, want %v", err, ErrFeatureStackUnderflow)
 	}
 }
 
@@ -98,8 +98,8 @@ func TestShapeEventsUnclosedStackAtEOFIsError(t *testing.T) {
 	eventSink := &collectSink{}
 	shaper := NewShaper([]ShapingEngine{&hookProbeShaper{}}...)
 	err := shaper.ShapeEvents(params, evsource, eventSink, singleBufOpts)
-	if !errors.Is(err, errPlanStackUnclosed) {
-		t.Fatalf("ShapeEvents error=%v, want %v", err, errPlanStackUnclosed)
+	if !errors.Is(err, ErrFeatureStackUnclosed) {
+		t.Fatalf("ShapeEvents error=%v, want %v", err, ErrFeatureStackUnclosed)
 	}
 }
 
@@ -152,3 +152,102 @@ func TestFillEventsStoresPlanIDsInCarryBuffer(t *testing.T) {
 		t.Fatalf("raw buffers not aligned: runes=%d clusters=%d", len(st.rawRunes), len(st.rawClusters))
 	}
 }
+
+type observingEventSource struct {
+	sliceEventSource
+	states []FeatureStackState
+}
+
+func (s *observingEventSource) FeatureStackChanged(state FeatureStackState) {
+	s.states = append(s.states, state)
+}
+
+func TestShapeEventsErrorPositions(t *testing.T) {
+	font := loadMiniOTFont(t, "gpos3_font1.otf")
+	params := standardParams(font)
+	push := InputEvent{
+		Kind: InputEventPushFeatures,
+		Push: []FeatureSetting{{Tag: ot.T("liga"), Enabled: false}},
+	}
+	a := InputEvent{Kind: InputEventRune, Rune: 0x12, Size: 1}
+	pop := InputEvent{Kind: InputEventPopFeatures}
+	tests := []struct {
+		name   string
+		events []InputEvent
+		want   error
+		index  int
+		pos    int
+		kind   InputEventKind
+	}{
+		{"underflow", []InputEvent{a, push, a, pop, a, pop}, ErrFeatureStackUnderflow, 5, 3, InputEventPopFeatures},
+		{"unclosed", []InputEvent{a, push, a, pop, push, a, push, a, pop}, ErrFeatureStackUnclosed, 4, 2, InputEventPushFeatures},
+	}
+	for _, tt := range tests {
+		src := &sliceEventSource{events: tt.events}
+		shaper := NewShaper([]ShapingEngine{&hookProbeShaper{}}...)
+		err := shaper.ShapeEvents(params, src, &collectSink{}, singleBufOpts)
+		var evErr *EventError
+		if !errors.Is(err, tt.want) || !errors.As(err, &evErr) {
+			t.Fatalf("%s: ShapeEvents error=%v, want %v", tt.name, err, tt.want)
+		}
+		if evErr.Index != tt.index || evErr.Position != tt.pos || evErr.Kind != tt.kind {
+			t.Errorf("%s: error located at event #%d, rune %d, kind %s; want #%d, rune %d, kind %s",
+				tt.name, evErr.Index, evErr.Position, evErr.Kind, tt.index, tt.pos, tt.kind)
+		}
+	}
+}
+
+func TestShapeEventsRejectsConflictingPush(t *testing.T) {
+	font := loadMiniOTFont(t, "gpos3_font1.otf")
+	params := standardParams(font)
+	src := &sliceEventSource{
+		events: []InputEvent{
+			{
+				Kind: InputEventPushFeatures,
+				Push: []FeatureSetting{
+					{Tag: ot.T("liga"), Enabled: false},
+					{Tag: ot.T("liga"), Enabled: true},
+				},
+			},
+		},
+	}
+	shaper := NewShaper([]ShapingEngine{&hookProbeShaper{}}...)
+	err := shaper.ShapeEvents(params, src, &collectSink{}, singleBufOpts)
+	var evErr *EventError
+	if !errors.As(err, &evErr) || evErr.Kind != InputEventPushFeatures {
+		t.Fatalf("ShapeEvents error=%v, want EventError for push event", err)
+	}
+}
+
+func TestShapeEventsObserveFeatureStack(t *testing.T) {
+	font := loadMiniOTFont(t, "gpos3_font1.otf")
+	params := standardParams(font)
+	params.Features = []FeatureRange{{Feature: ot.T("kern"), On: true}}
+	src := &observingEventSource{sliceEventSource: sliceEventSource{
+		events: []InputEvent{
+			{Kind: InputEventRune, Rune: 0x12, Size: 1},
+			{
+				Kind: InputEventPushFeatures,
+				Push: []FeatureSetting{{Tag: ot.T("liga"), Enabled: false}},
+			},
+			{Kind: InputEventRune, Rune: 0x13, Size: 1},
+			{Kind: InputEventPopFeatures},
+		},
+	}}
+	shaper := NewShaper([]ShapingEngine{&hookProbeShaper{}}...)
+	if err := shaper.ShapeEvents(params, src, &collectSink{}, singleBufOpts); err != nil {
+		t.Fatalf("ShapeEvents failed: %v", err)
+	}
+	want := []FeatureStackState{
+		{Index: 1, Position: 1, Depth: 1, Features: []FeatureRange{
+			{Feature: ot.T("kern"), Arg: 1, On: true},
+			{Feature: ot.T("liga"), Arg: 0, On: false},
+		}},
+		{Index: 3, Position: 2, Depth: 0, Features: []FeatureRange{
+			{Feature: ot.T("kern"), Arg: 1, On: true},
+		}},
+	}
+	if !reflect.DeepEqual(src.states, want) {
+		t.Fatalf("observed states = %+v, want %+v", src.states, want)
+	}
+}
@@ -0,0 +1,107 @@
+package otshape
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/npillmayer/opentype/otlayout"
+)
+
+// ErrLimitExceeded indicates that shaping has been aborted because it hit one
+// of the [ShapeLimits] of the shaping request. Errors returned for exceeded
+// limits are of type [*LimitError] and wrap ErrLimitExceeded.
+var ErrLimitExceeded = errors.New("otshape: shaping limit exceeded")
+
+// ShapeLimits bounds the work done for a single shaping request, protecting
+// clients which shape untrusted fonts or text from pathological lookups.
+// Parsing a font is bounded by limits of package ot already; ShapeLimits
+// complement these for applying the lookups of a font.
+//
+// Zero values disable a limit. Independent of MaxNesting, sequence lookups are
+// never nested deeper than [otlayout.DefaultMaxNesting], but deeper nested
+// lookups are skipped silently unless MaxNesting is set. Likewise, multiple
+// substitutions never grow a buffer of glyphs by more than
+// [otlayout.DefaultMaxGrowthFactor]; substitutions beyond are skipped silently
+// unless MaxGrowthFactor is set.
+type ShapeLimits struct {
+	// MaxLookups is the maximum number of lookup applications per call of
+	// Shape, i.e., attempts to apply a lookup at a glyph position, including
+	// lookups nested in contextual lookups.
+	MaxLookups int
+	// MaxGrowthFactor is the maximum factor by which substitutions may grow a
+	// run of glyphs, relative to the number of glyphs mapped from its input.
+	// It bounds recursive decompositions by contextual lookups as well.
+	MaxGrowthFactor int
+	// MaxNesting is the maximum nesting depth of lookups invoked from
+	// contextual or chaining contextual lookups.
+	MaxNesting int
+}
+
+func (l ShapeLimits) validate() error {
+	if l.MaxLookups < 0 || l.MaxGrowthFactor < 0 || l.MaxNesting < 0 {
+		return fmt.Errorf("otshape: shaping limits must be >= 0")
+	}
+	return nil
+}
+
+// LimitError reports which of the [ShapeLimits] of a shaping request has been
+// exceeded. It wraps [ErrLimitExceeded].
+type LimitError struct {
+	Limit string // name of the exceeded limit, e.g. "MaxLookups"
+	Max   int    // configured value of the limit
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("%v (%s = %d)", ErrLimitExceeded, e.Limit, e.Max)
+}
+
+func (e *LimitError) Unwrap() error {
+	return ErrLimitExceeded
+}
+
+// setLimits configures the limits of the next shaping call and resets the
+// lookup budget. Without limits, lookups are applied without a budget.
+func (e *planExecutor) setLimits(limits ShapeLimits) {
+	e.limits = limits
+	e.budget = otlayout.LookupBudget{
+		MaxLookups:      limits.MaxLookups,
+		MaxNesting:      limits.MaxNesting,
+		MaxGrowthFactor: limits.MaxGrowthFactor,
+	}
+}
+
+// lookupBudget returns the budget to hand to otlayout, or nil if no limits are
+// set.
+func (e *planExecutor) lookupBudget() *otlayout.LookupBudget {
+	if e.limits == (ShapeLimits{}) {
+		return nil
+	}
+	return &e.budget
+}
+
+// budgetExceeded reports an error if applying a lookup has hit a limit of the
+// lookup budget.
+func (e *planExecutor) budgetExceeded() error {
+	switch {
+	case e.budget.LookupsExceeded:
+		return &LimitError{Limit: "MaxLookups", Max: e.limits.MaxLookups}
+	case e.budget.NestingExceeded && e.limits.MaxNesting > 0:
+		return &LimitError{Limit: "MaxNesting", Max: e.limits.MaxNesting}
+	case e.budget.GrowthExceeded && e.limits.MaxGrowthFactor > 0:
+		return &LimitError{Limit: "MaxGrowthFactor", Max: e.limits.MaxGrowthFactor}
+	}
+	return nil
+}
+
+// grown accounts for a change of the run length by delta glyphs and reports an
+// error if the run has grown beyond the configured growth factor.
+func (e *planExecutor) grown(delta int) error {
+	e.growth += delta
+	if e.limits.MaxGrowthFactor == 0 {
+		return nil
+	}
+	if e.runLen+e.growth > e.limits.MaxGrowthFactor*max(e.runLen, 1) {
+		return &LimitError{Limit: "MaxGrowthFactor", Max: e.limits.MaxGrowthFactor}
+	}
+	return nil
+}
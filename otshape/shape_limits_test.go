@@ -0,0 +1,86 @@
+package otshape
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// limitsFont builds a font with a single 'calt' lookup, given as a subtable of
+// type typ. Glyph 1 is mapped from 'a'.
+func limitsFont(t *testing.T, typ ot.LayoutTableLookupType, sub []byte) *ot.Font {
+	t.Helper()
+	b := testfont.New(3)
+	b.Map('a', 1)
+	gsub := b.GSUB()
+	gsub.Feature("calt", gsub.Lookup(typ, 0, sub))
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func shapeWithLimits(font *ot.Font, text string, limits ShapeLimits) ([]GlyphRecord, error) {
+	sink := &collectSink{}
+	err := NewShaper(plainShaper{}).Shape(standardParams(font), StringSource(text), sink,
+		BufferOptions{Limits: limits})
+	return sink.glyphs, err
+}
+
+func TestShapeLimitsNesting(t *testing.T) {
+	// lookup 0 calls itself for every 'a'
+	recursive := testfont.ChainContext(nil, [][]ot.GlyphIndex{{1}}, nil,
+		ot.SequenceLookupRecord{SequenceIndex: 0, LookupListIndex: 0})
+	font := limitsFont(t, ot.GSubLookupTypeChainingContext, recursive)
+	glyphs, err := shapeWithLimits(font, "aa", ShapeLimits{})
+	if err != nil {
+		t.Fatalf("expected recursion to be cut silently without limits, have %v", err)
+	}
+	if len(glyphs) != 2 {
+		t.Errorf("expected 2 glyphs, have %d", len(glyphs))
+	}
+	_, err = shapeWithLimits(font, "aa", ShapeLimits{MaxNesting: 8})
+	var lerr *LimitError
+	if !errors.As(err, &lerr) || lerr.Limit != "MaxNesting" || lerr.Max != 8 {
+		t.Fatalf("expected MaxNesting limit error, have %v", err)
+	}
+	if !errors.Is(err, ErrLimitExceeded) {
+		t.Errorf("expected error to wrap ErrLimitExceeded")
+	}
+}
+
+func TestShapeLimitsGrowth(t *testing.T) {
+	font := limitsFont(t, ot.GSubLookupTypeMultiple,
+		testfont.MultipleSubst(map[ot.GlyphIndex][]ot.GlyphIndex{1: {2, 2, 2, 2}}))
+	glyphs, err := shapeWithLimits(font, "aa", ShapeLimits{MaxGrowthFactor: 4})
+	if err != nil {
+		t.Fatalf("expected growth by factor 4 to be allowed, have %v", err)
+	}
+	if len(glyphs) != 8 {
+		t.Errorf("expected 8 glyphs, have %d", len(glyphs))
+	}
+	_, err = shapeWithLimits(font, "aa", ShapeLimits{MaxGrowthFactor: 3})
+	var lerr *LimitError
+	if !errors.As(err, &lerr) || lerr.Limit != "MaxGrowthFactor" {
+		t.Fatalf("expected MaxGrowthFactor limit error, have %v", err)
+	}
+}
+
+func TestShapeLimitsLookups(t *testing.T) {
+	font := limitsFont(t, ot.GSubLookupTypeSingle,
+		testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 2}))
+	if _, err := shapeWithLimits(font, "aaaa", ShapeLimits{MaxLookups: 100}); err != nil {
+		t.Fatalf("expected shaping within budget to succeed, have %v", err)
+	}
+	_, err := shapeWithLimits(font, "aaaa", ShapeLimits{MaxLookups: 2})
+	var lerr *LimitError
+	if !errors.As(err, &lerr) || lerr.Limit != "MaxLookups" || lerr.Max != 2 {
+		t.Fatalf("expected MaxLookups limit error, have %v", err)
+	}
+	if _, err := shapeWithLimits(font, "a", ShapeLimits{MaxLookups: -1}); err == nil {
+		t.Errorf("expected negative limit to be rejected")
+	}
+}
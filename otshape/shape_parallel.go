@@ -0,0 +1,93 @@
+package otshape
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"sync"
+)
+
+// Run is one independently shaped text run, e.g. a script/direction run of a
+// paragraph, as input to [Shaper.ShapeAll].
+type Run struct {
+	Params  Params        // Params selects font, segment metadata and features of the run.
+	Text    []rune        // Text is the run's text; it is not modified.
+	Options BufferOptions // Options configures buffering; the zero value is fine for most runs.
+}
+
+// ShapeAll shapes runs concurrently, using up to workers goroutines. If
+// workers is <= 0, GOMAXPROCS goroutines are used. Runs may share fonts, as
+// an *ot.Font is safe for concurrent use after parsing (lazily parsed lookup
+// subtables and derived tables are guarded internally).
+//
+// ShapeAll returns the glyphs of each run, indexed like runs. Cluster values
+// of glyphs are rune indices into the text of their run.
+//
+// Shaping stops at the first failing run, whose error is returned, annotated
+// with the run index. If ctx is cancelled, ShapeAll stops shaping, within a
+// run as well as between runs, and returns ctx.Err().
+func (s *Shaper) ShapeAll(ctx context.Context, runs []Run, workers int) ([][]GlyphRecord, error) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(runs))
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	results := make([][]GlyphRecord, len(runs))
+	next := make(chan int)
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// every worker has a Shaper of its own, to re-use its cached session
+			shaper := NewShaper(s.Engines...)
+			for i := range next {
+				sink := &glyphSliceSink{}
+				src := NewRuneSliceSource(runs[i].Text)
+				if err := shaper.ShapeContext(ctx, runs[i].Params, src, sink, runs[i].Options); err != nil {
+					errOnce.Do(func() {
+						if ctx.Err() == nil {
+							firstErr = fmt.Errorf("otshape: run #%d: %w", i, err)
+						}
+						cancel()
+					})
+					continue
+				}
+				results[i] = sink.glyphs
+			}
+		}()
+	}
+	func() {
+		defer close(next)
+		for i := range runs {
+			select {
+			case next <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// glyphSliceSink collects shaped glyphs.
+type glyphSliceSink struct {
+	glyphs []GlyphRecord
+}
+
+func (s *glyphSliceSink) WriteGlyph(g GlyphRecord) error {
+	s.glyphs = append(s.glyphs, g)
+	return nil
+}
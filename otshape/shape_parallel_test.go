@@ -0,0 +1,66 @@
+package otshape
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// plainShaper is a stateless engine, as hookProbeShaper records calls and is
+// therefore not suitable for concurrent shaping.
+type plainShaper struct{}
+
+func (plainShaper) Name() string                            { return "plain" }
+func (plainShaper) Match(SelectionContext) ShaperConfidence { return ShaperConfidenceCertain }
+func (plainShaper) New() ShapingEngine                      { return plainShaper{} }
+
+func TestShapeAllMatchesSequentialShaping(t *testing.T) {
+	font := loadMiniOTFont(t, "gpos3_font1.otf")
+	params := standardParams(font)
+	runs := make([]Run, 16)
+	for i := range runs {
+		text := make([]rune, 0, i+1)
+		for j := 0; j <= i; j++ {
+			text = append(text, rune(0x12+j%2))
+		}
+		runs[i] = Run{Params: params, Text: text}
+	}
+	shaper := NewShaper(plainShaper{})
+	results, err := shaper.ShapeAll(context.Background(), runs, 4)
+	if err != nil {
+		t.Fatalf("ShapeAll failed: %v", err)
+	}
+	if len(results) != len(runs) {
+		t.Fatalf("got %d results, want %d", len(results), len(runs))
+	}
+	for i, run := range runs {
+		sink := &collectSink{}
+		if err := shaper.Shape(run.Params, NewRuneSliceSource(run.Text), sink, run.Options); err != nil {
+			t.Fatalf("Shape of run #%d failed: %v", i, err)
+		}
+		if !reflect.DeepEqual(results[i], sink.glyphs) {
+			t.Errorf("run #%d: parallel output differs:\nparallel=%v\nsequential=%v", i, results[i], sink.glyphs)
+		}
+	}
+}
+
+func TestShapeAllErrorsAndCancellation(t *testing.T) {
+	font := loadMiniOTFont(t, "gpos3_font1.otf")
+	runs := []Run{
+		{Params: standardParams(font), Text: []rune{0x12}},
+		{Params: Params{}, Text: []rune{0x12}},
+	}
+	shaper := NewShaper(plainShaper{})
+	if _, err := shaper.ShapeAll(context.Background(), runs, 2); !errors.Is(err, ErrNilFont) {
+		t.Errorf("ShapeAll error=%v, want %v", err, ErrNilFont)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := shaper.ShapeAll(ctx, runs[:1], 1); !errors.Is(err, context.Canceled) {
+		t.Errorf("ShapeAll error=%v, want %v", err, context.Canceled)
+	}
+	if results, err := shaper.ShapeAll(context.Background(), nil, 0); err != nil || len(results) != 0 {
+		t.Errorf("ShapeAll of no runs = %v, %v", results, err)
+	}
+}
@@ -0,0 +1,37 @@
+package otshape
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/guard"
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+type panickingSink struct{}
+
+func (panickingSink) WriteGlyph(GlyphRecord) error {
+	panic("sink failure")
+}
+
+func TestShapeRecoversPanics(t *testing.T) {
+	if guard.Panics {
+		t.Skip("built with tag otassert")
+	}
+	font := limitsFont(t, ot.GSubLookupTypeSingle,
+		testfont.SingleSubst(map[ot.GlyphIndex]ot.GlyphIndex{1: 2}))
+	shaper := NewShaper(plainShaper{})
+	err := shaper.Shape(standardParams(font), StringSource("aa"), panickingSink{}, BufferOptions{})
+	if !errors.Is(err, ErrInternal) {
+		t.Fatalf("expected panic to be reported as internal error, have %v", err)
+	}
+	// the shaper remains usable
+	sink := &collectSink{}
+	if err := shaper.Shape(standardParams(font), StringSource("aa"), sink, BufferOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if len(sink.glyphs) != 2 || sink.glyphs[0].GID != 2 {
+		t.Errorf("expected 2 substituted glyphs, have %v", sink.glyphs)
+	}
+}
@@ -68,6 +68,12 @@ func (in *streamIngestor) fillEventsLimit(
 	return fillEventsUntilBufferLimit(src, in.st, stack, plansByID, build, limit)
 }
 
+// reset prepares the ingestor for a new input stream, keeping its buffers.
+func (in *streamIngestor) reset(cfg streamingConfig) {
+	assert(in != nil, "stream ingestor is nil")
+	in.st.reset(cfg)
+}
+
 func (in *streamIngestor) compact(flushedCodepoints int) {
 	assert(in != nil, "stream ingestor is nil")
 	compactCarry(in.st, flushedCodepoints)
@@ -85,6 +91,7 @@ type shapeWorkspace struct {
 	normRunesB  []rune
 	normClusA   []uint32
 	normClusB   []uint32
+	exec        planExecutor
 }
 
 func newShapeWorkspace(capHint int) *shapeWorkspace {
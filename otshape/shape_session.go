@@ -0,0 +1,94 @@
+package otshape
+
+import (
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// shapeSession bundles the per-request state of [Shaper.Shape]: the selected
+// engine instance, the compiled plan and the streaming buffers.
+//
+// A session is owned by exactly one Shape call at a time. After a successful
+// call it is parked in the Shaper and handed to the next call with matching
+// parameters, which then shapes without compiling a plan or allocating buffers.
+type shapeSession struct {
+	font     *ot.Font
+	props    segmentProps
+	features []FeatureRange
+	measure  bool // plan is pruned for width-only measurement
+	ctx      SelectionContext
+	engine   ShapingEngine
+	plan     *plan
+	extent   int // context extent of the lookups of plan, or -1 if not yet known
+	ing      *streamIngestor
+	ws       *shapeWorkspace
+}
+
+func (sess *shapeSession) matches(params Params, widthOnly bool) bool {
+	return sess.font == params.Font && sess.measure == widthOnly &&
+		sess.props == segmentPropsFromParams(params) &&
+		slices.Equal(sess.features, params.Features)
+}
+
+func newShapeSession(engines []ShapingEngine, params Params, cfg streamingConfig, widthOnly bool) (*shapeSession, error) {
+	ctx := selectionContextFromParams(params)
+	engine, err := selectShapingEngine(engines, ctx)
+	if err != nil {
+		return nil, err
+	}
+	pl, err := newPlanCompiler(params, ctx, engine).compileDefault()
+	if err != nil {
+		return nil, err
+	}
+	if widthOnly {
+		pl = pl.widthOnly()
+	}
+	return &shapeSession{
+		font:     params.Font,
+		props:    segmentPropsFromParams(params),
+		features: slices.Clone(params.Features),
+		measure:  widthOnly,
+		ctx:      ctx,
+		engine:   engine,
+		plan:     pl,
+		extent:   -1,
+		ing:      newStreamIngestor(cfg),
+		ws:       newShapeWorkspace(cfg.maxBuffer),
+	}, nil
+}
+
+// acquireSession returns a session for params, either by taking over the parked
+// session of s or by creating a new one.
+func (s *Shaper) acquireSession(params Params, cfg streamingConfig, widthOnly bool) (*shapeSession, error) {
+	s.mu.Lock()
+	sess := s.idle
+	if sess != nil && sess.matches(params, widthOnly) {
+		s.idle = nil
+	} else {
+		sess = nil
+	}
+	s.mu.Unlock()
+	if sess == nil {
+		return newShapeSession(s.Engines, params, cfg, widthOnly)
+	}
+	sess.ing.reset(cfg)
+	return sess, nil
+}
+
+// releaseSession parks sess for re-use by the next call of Shape.
+func (s *Shaper) releaseSession(sess *shapeSession) {
+	sess.ws.exec.ctx = nil // do not retain the context of the finished call
+	s.mu.Lock()
+	s.idle = sess
+	s.mu.Unlock()
+}
+
+// Reset drops all state cached by s between calls of [Shaper.Shape], i.e.,
+// compiled shaping plans and pooled buffers. Clients have to call Reset after
+// modifying s.Engines or mutating a font previously used with s.
+func (s *Shaper) Reset() {
+	s.mu.Lock()
+	s.idle = nil
+	s.mu.Unlock()
+}
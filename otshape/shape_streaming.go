@@ -32,6 +32,18 @@ func resolveStreamingConfig(opts BufferOptions) (streamingConfig, error) {
 	if opts.HighWatermark < 0 || opts.LowWatermark < 0 || opts.MaxBuffer < 0 {
 		return streamingConfig{}, fmt.Errorf("otshape: streaming watermarks must be >= 0")
 	}
+	if err := opts.Limits.validate(); err != nil {
+		return streamingConfig{}, err
+	}
+	if err := opts.SmallCaps.validate(); err != nil {
+		return streamingConfig{}, err
+	}
+	if err := opts.Style.validate(); err != nil {
+		return streamingConfig{}, err
+	}
+	if opts.Ignorables > IgnorablesShow {
+		return streamingConfig{}, fmt.Errorf("otshape: invalid policy for default-ignorables %d", opts.Ignorables)
+	}
 	cfg := streamingConfig{
 		highWatermark: defaultHighWatermark,
 		lowWatermark:  defaultLowWatermark,
@@ -62,6 +74,7 @@ type streamingState struct {
 	rawClusters []uint32
 	rawPlanIDs  []uint16
 	nextCluster uint32
+	nextEvent   int // index of the next input event, for error reporting
 	eof         bool
 	cfg         streamingConfig
 }
@@ -79,6 +92,18 @@ func newStreamingState(cfg streamingConfig) *streamingState {
 	}
 }
 
+// reset clears st for a new input stream, retaining allocated capacity.
+func (st *streamingState) reset(cfg streamingConfig) {
+	assert(cfg.valid(), "invalid streaming config")
+	st.rawRunes = st.rawRunes[:0]
+	st.rawClusters = st.rawClusters[:0]
+	st.rawPlanIDs = st.rawPlanIDs[:0]
+	st.nextCluster = 0
+	st.nextEvent = 0
+	st.eof = false
+	st.cfg = cfg
+}
+
 func (st *streamingState) assertInvariants() {
 	assert(st != nil, "streaming state is nil")
 	assert(st.cfg.valid(), "invalid streaming config in state")
@@ -14,8 +14,16 @@ type Params struct {
 	Script    language.Script // Script is the ISO 15924 script for shaper selection.
 	Language  language.Tag    // Language is the BCP 47 language tag for language-system lookup.
 	Features  []FeatureRange  // Features requests per-feature on/off state and optional ranges.
+	Vertical  bool            // Vertical selects vertical layout, see below.
 }
 
+// Vertical layout selects the vertical variants of features: 'vert' is turned
+// on instead of horizontal features like 'liga' and 'calt', and requests for
+// 'kern', 'palt', 'halt' and 'chws' turn on 'vkrn', 'vpal', 'vhal' and 'vchw'.
+// Glyphs still are output with horizontal advances from table 'hmtx', plus
+// the horizontal and vertical adjustments of GPOS; clients set glyphs using
+// vertical metrics of their own.
+
 // FeatureRange toggles one OpenType feature for an optional codepoint span.
 type FeatureRange struct {
 	Feature ot.Tag // Feature is the 4-byte OpenType feature tag.
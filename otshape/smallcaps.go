@@ -0,0 +1,144 @@
+package otshape
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"unicode"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+)
+
+// DefaultSmallCapsScale is the scale of synthesized small capitals relative to
+// capitals, if [SmallCapsFallback].Scale is 0.
+const DefaultSmallCapsScale = 0.7
+
+// SmallCapsFallback configures the synthesis of small capitals for fonts
+// lacking features 'smcp' (lowercase to small capitals) or 'c2sc' (capitals to
+// small capitals). If synthesis is enabled and one of the features is
+// requested in [Params].Features, but the font does not implement it, the
+// shaper substitutes the cmap glyphs of the capitals for lowercase letters
+// ('smcp') and scales these and, for 'c2sc', capital letters by Scale.
+//
+// Substitution happens before GSUB, so ligatures and kerning of capitals apply
+// to synthesized small capitals as well. Scaling happens after GPOS: advances
+// and offsets of synthesized glyphs are scaled, and [otlayout.PosItem].Scale
+// records the scale for renderers, which have to scale the outlines.
+type SmallCapsFallback struct {
+	Enabled bool    // synthesize small capitals for fonts lacking 'smcp' or 'c2sc'
+	Scale   float32 // scale of small capitals relative to capitals; 0 means DefaultSmallCapsScale
+}
+
+func (f SmallCapsFallback) validate() error {
+	if f.Scale < 0 || f.Scale > 1 || math.IsNaN(float64(f.Scale)) {
+		return fmt.Errorf("otshape: small-caps scale must be within [0,1]")
+	}
+	return nil
+}
+
+func (f SmallCapsFallback) scale() float32 {
+	if f.Scale == 0 {
+		return DefaultSmallCapsScale
+	}
+	return f.Scale
+}
+
+var (
+	tagSmcp = ot.T("smcp")
+	tagC2sc = ot.T("c2sc")
+)
+
+// synthesizeSmallCaps substitutes capitals for lowercase letters for which
+// small capitals have to be synthesized, and records the clusters of all
+// glyphs to be scaled by scaleSmallCaps. Clusters, unlike glyph indices,
+// survive GSUB edits.
+func (e *planExecutor) synthesizeSmallCaps(pl *plan) {
+	e.synthetic = e.synthetic[:0]
+	if !e.smallCaps.Enabled || pl == nil || pl.font == nil || e.run == nil {
+		return
+	}
+	n := e.run.Len()
+	smcp := pl.fallbackFeatureRange(tagSmcp, n)
+	c2sc := pl.fallbackFeatureRange(tagC2sc, n)
+	if smcp == nil && c2sc == nil {
+		return
+	}
+	hasCodepoints := len(e.run.Codepoints) == n
+	for i := range n {
+		var cp rune
+		if hasCodepoints {
+			cp = e.run.Codepoints[i]
+		} else {
+			cp = otquery.CodePointForGlyph(pl.font, e.run.Glyphs[i])
+		}
+		switch {
+		case smcp != nil && smcp[i] && unicode.IsLower(cp):
+			upper := unicode.ToUpper(cp)
+			if upper == cp {
+				continue
+			}
+			gid := otquery.GlyphIndex(pl.font, upper)
+			if gid == NOTDEF {
+				continue
+			}
+			e.run.Glyphs[i] = gid
+		case c2sc != nil && c2sc[i] && unicode.IsUpper(cp):
+		default:
+			continue
+		}
+		if len(e.run.Clusters) == n {
+			e.synthetic = append(e.synthetic, e.run.Clusters[i])
+		}
+	}
+	slices.Sort(e.synthetic)
+}
+
+// scaleSmallCaps scales advances and offsets of the glyphs of the clusters
+// recorded by synthesizeSmallCaps.
+func (e *planExecutor) scaleSmallCaps(pl *plan) {
+	if len(e.synthetic) == 0 || e.run == nil || len(e.run.Clusters) != e.run.Len() {
+		return
+	}
+	e.run.EnsurePos()
+	scale := e.smallCaps.scale()
+	scaled := func(x int32) int32 {
+		return int32(ot.Round(float64(scale) * float64(x)))
+	}
+	for i, cluster := range e.run.Clusters {
+		if _, ok := slices.BinarySearch(e.synthetic, cluster); !ok {
+			continue
+		}
+		pos := &e.run.Pos[i]
+		advance := int32(otquery.GlyphMetrics(pl.font, e.run.Glyphs[i]).Advance)
+		pos.XAdvance = scaled(advance+pos.XAdvance) - advance
+		pos.YAdvance = scaled(pos.YAdvance)
+		pos.XOffset = scaled(pos.XOffset)
+		pos.YOffset = scaled(pos.YOffset)
+		pos.Scale = scale
+	}
+}
+
+// fallbackFeatureRange reports for each of the n glyphs of a run whether
+// feature tag is requested for it, provided that the plan has no lookups for
+// tag. It returns nil if no fallback is needed. Feature ranges are evaluated
+// like for feature masks, see applyFeatureRangesToMasks.
+func (p *plan) fallbackFeatureRange(tag ot.Tag, n int) []bool {
+	if slices.ContainsFunc(p.GSUB.Lookups, func(op lookupOp) bool { return op.FeatureTag == tag }) {
+		return nil
+	}
+	var on []bool
+	for _, r := range p.featureRanges {
+		if r.Feature != tag || (on == nil && !r.On) {
+			continue
+		}
+		start, end := normalizeMaskRange(r.Start, r.End, n)
+		if on == nil {
+			on = make([]bool, n)
+		}
+		for i := start; i < end; i++ {
+			on[i] = r.On
+		}
+	}
+	return on
+}
@@ -0,0 +1,108 @@
+package otshape
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+// smallCapsFont builds a font with glyphs a (1), A (2) and b (3), kerning
+// pairs of capitals, and optionally feature smcp substituting a.sc (4) for a.
+func smallCapsFont(t *testing.T, withSmcp bool) *ot.Font {
+	t.Helper()
+	b := testfont.New(5)
+	for i, name := range []string{"a", "A", "b", "a.sc"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('a', 1).Map('A', 2).Map('b', 3)
+	b.Advance(1, 500).Advance(2, 600).Advance(3, 500).Advance(4, 450)
+	fea := `languagesystem latn dflt;
+feature kern { pos A A -20; } kern;
+`
+	if withSmcp {
+		fea += "feature smcp { sub a by a.sc; } smcp;\n"
+	}
+	if err := b.Features(fea); err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	return otf
+}
+
+func TestSmallCapsFallback(t *testing.T) {
+	font := smallCapsFont(t, false)
+	shape := func(font *ot.Font, opts BufferOptions, features ...FeatureRange) []GlyphRecord {
+		t.Helper()
+		params := standardParams(font)
+		params.Features = features
+		var sink collectSink
+		if err := NewShaper(plainShaper{}).Shape(params, strings.NewReader("aAb"), &sink, opts); err != nil {
+			t.Fatalf("shape failed: %v", err)
+		}
+		return sink.glyphs
+	}
+	summary := func(glyphs []GlyphRecord) (gids []ot.GlyphIndex, advances []int32, scales []float32) {
+		for _, g := range glyphs {
+			gids = append(gids, g.GID)
+			advances = append(advances, g.Pos.XAdvance)
+			scales = append(scales, g.Pos.Scale)
+		}
+		return
+	}
+	smcp := FeatureRange{Feature: ot.T("smcp"), On: true}
+	c2sc := FeatureRange{Feature: ot.T("c2sc"), On: true}
+	fallback := BufferOptions{SmallCaps: SmallCapsFallback{Enabled: true}}
+	tests := []struct {
+		name     string
+		font     *ot.Font
+		opts     BufferOptions
+		features []FeatureRange
+		gids     []ot.GlyphIndex
+		advances []int32
+		scales   []float32
+	}{
+		{"disabled", font, BufferOptions{}, []FeatureRange{smcp},
+			[]ot.GlyphIndex{1, 2, 3}, []int32{500, 600, 500}, []float32{0, 0, 0}},
+		{"not requested", font, fallback, nil,
+			[]ot.GlyphIndex{1, 2, 3}, []int32{500, 600, 500}, []float32{0, 0, 0}},
+		// A A kerned by -20, then scaled: round(0.7 * 580) = 406
+		{"smcp", font, fallback, []FeatureRange{smcp},
+			[]ot.GlyphIndex{2, 2, 3}, []int32{406, 600, 500}, []float32{0.7, 0, 0}},
+		{"smcp and c2sc", font, fallback, []FeatureRange{smcp, c2sc},
+			[]ot.GlyphIndex{2, 2, 3}, []int32{406, 420, 500}, []float32{0.7, 0.7, 0}},
+		{"custom scale", font, BufferOptions{SmallCaps: SmallCapsFallback{Enabled: true, Scale: 0.5}},
+			[]FeatureRange{{Feature: ot.T("smcp"), On: true, End: 1}},
+			[]ot.GlyphIndex{2, 2, 3}, []int32{290, 600, 500}, []float32{0.5, 0, 0}},
+		{"implemented by font", smallCapsFont(t, true), fallback, []FeatureRange{smcp},
+			[]ot.GlyphIndex{4, 2, 3}, []int32{450, 600, 500}, []float32{0, 0, 0}},
+	}
+	for _, tt := range tests {
+		gids, advances, scales := summary(shape(tt.font, tt.opts, tt.features...))
+		if !slices.Equal(gids, tt.gids) || !slices.Equal(advances, tt.advances) || !slices.Equal(scales, tt.scales) {
+			t.Errorf("%s: expected glyphs %v with advances %v and scales %v, have %v, %v, %v",
+				tt.name, tt.gids, tt.advances, tt.scales, gids, advances, scales)
+		}
+	}
+	params := standardParams(font)
+	params.Features = []FeatureRange{smcp}
+	var sink collectSink
+	if err := NewShaper(plainShaper{}).ShapeEvents(params, NewInputEventSource(strings.NewReader("aAb")),
+		&sink, fallback); err != nil {
+		t.Fatalf("shape events failed: %v", err)
+	}
+	if gids, advances, scales := summary(sink.glyphs); !slices.Equal(gids, []ot.GlyphIndex{2, 2, 3}) ||
+		!slices.Equal(advances, []int32{406, 600, 500}) || !slices.Equal(scales, []float32{0.7, 0, 0}) {
+		t.Errorf("events: expected small capitals to be synthesized, have %v, %v, %v", gids, advances, scales)
+	}
+	err := NewShaper(plainShaper{}).Shape(standardParams(font), strings.NewReader("a"), &collectSink{},
+		BufferOptions{SmallCaps: SmallCapsFallback{Enabled: true, Scale: 2}})
+	if err == nil {
+		t.Errorf("expected scale > 1 to be rejected")
+	}
+}
@@ -0,0 +1,142 @@
+package otshape
+
+import (
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+)
+
+// spaceWidth classifies typographic spaces by their conventional width, for
+// synthesizing spaces missing from a font.
+type spaceWidth uint8
+
+const (
+	notSpace         spaceWidth = iota
+	spaceRegular                // width of U+0020
+	spaceEm                     // 1 em
+	spaceEm2                    // 1/2 em
+	spaceEm3                    // 1/3 em
+	spaceEm4                    // 1/4 em
+	spaceEm5                    // 1/5 em
+	spaceEm6                    // 1/6 em
+	spaceEm16                   // 1/16 em
+	space4Em18                  // 4/18 em
+	spaceNarrow                 // 1/2 of the width of U+0020
+	spaceFigure                 // width of a digit
+	spacePunctuation            // width of a period
+)
+
+// spaceWidthOf returns the conventional width of space character r, or
+// notSpace if r is not a space which may be synthesized.
+func spaceWidthOf(r rune) spaceWidth {
+	switch r {
+	case 0x00A0: // no-break space
+		return spaceRegular
+	case 0x2000, 0x2002: // en quad, en space
+		return spaceEm2
+	case 0x2001, 0x2003, 0x3000: // em quad, em space, ideographic space
+		return spaceEm
+	case 0x2004: // three-per-em space
+		return spaceEm3
+	case 0x2005: // four-per-em space
+		return spaceEm4
+	case 0x2006: // six-per-em space
+		return spaceEm6
+	case 0x2007: // figure space
+		return spaceFigure
+	case 0x2008: // punctuation space
+		return spacePunctuation
+	case 0x2009: // thin space
+		return spaceEm5
+	case 0x200A: // hair space
+		return spaceEm16
+	case 0x202F: // narrow no-break space
+		return spaceNarrow
+	case 0x205F: // medium mathematical space
+		return space4Em18
+	}
+	return notSpace
+}
+
+// mapSpaceFallback returns the glyph of U+0020 for a space character r which
+// the font does not map, or NOTDEF if r is no such space.
+func mapSpaceFallback(font *ot.Font, r rune) ot.GlyphIndex {
+	if spaceWidthOf(r) == notSpace {
+		return NOTDEF
+	}
+	return otquery.GlyphIndex(font, ' ')
+}
+
+// adjustFallbackSpaces sets the advances of spaces which have been mapped to
+// the glyph of U+0020 in place of missing glyphs (see mapSpaceFallback) to
+// their conventional widths.
+func (e *planExecutor) adjustFallbackSpaces(pl *plan) {
+	if e.run == nil || pl == nil || pl.font == nil || len(e.run.Codepoints) != e.run.Len() {
+		return
+	}
+	var space ot.GlyphIndex
+	for i, cp := range e.run.Codepoints {
+		width := spaceWidthOf(cp)
+		if width == notSpace {
+			continue
+		}
+		if space == NOTDEF {
+			if space = otquery.GlyphIndex(pl.font, ' '); space == NOTDEF {
+				return
+			}
+		}
+		if e.run.Glyphs[i] != space || otquery.GlyphIndex(pl.font, cp) != NOTDEF {
+			continue
+		}
+		advance := int32(otquery.GlyphMetrics(pl.font, space).Advance)
+		e.run.EnsurePos()
+		e.run.Pos[i].XAdvance = fallbackSpaceAdvance(pl.font, width, advance) - advance
+	}
+}
+
+// fallbackSpaceAdvance returns the advance of a synthesized space of the given
+// width, given the advance of U+0020.
+func fallbackSpaceAdvance(font *ot.Font, width spaceWidth, space int32) int32 {
+	var upem int32
+	if head := font.FontHead(); head != nil {
+		upem = int32(head.UnitsPerEm)
+	}
+	advanceOf := func(runes ...rune) int32 {
+		for _, r := range runes {
+			if gid := otquery.GlyphIndex(font, r); gid != NOTDEF {
+				return int32(otquery.GlyphMetrics(font, gid).Advance)
+			}
+		}
+		return space
+	}
+	em := func(num, den int32) int32 { // rounded fraction of an em
+		if upem == 0 {
+			return space
+		}
+		return (num*upem + den/2) / den
+	}
+	switch width {
+	case spaceEm:
+		return em(1, 1)
+	case spaceEm2:
+		return em(1, 2)
+	case spaceEm3:
+		return em(1, 3)
+	case spaceEm4:
+		return em(1, 4)
+	case spaceEm5:
+		return em(1, 5)
+	case spaceEm6:
+		return em(1, 6)
+	case spaceEm16:
+		return em(1, 16)
+	case space4Em18:
+		return em(4, 18)
+	case spaceNarrow:
+		return space / 2
+	case spaceFigure:
+		return advanceOf('0', '1', '2', '3', '4', '5', '6', '7', '8', '9')
+	case spacePunctuation:
+		return advanceOf('.', ',')
+	}
+	return space
+}
@@ -0,0 +1,37 @@
+package otshape
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestSpaceFallback(t *testing.T) {
+	b := testfont.New(5)
+	b.Map(' ', 1).Map('0', 2).Map('.', 3).Map('\u2002', 4)
+	b.Advance(1, 250).Advance(2, 550).Advance(3, 200).Advance(4, 480)
+	font, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	// space, no-break, thin, em, figure, punctuation, narrow no-break, en (mapped by the font), hair
+	const text = " \u00A0\u2009\u2003\u2007\u2008\u202F\u2002\u200A"
+	var sink collectSink
+	if err := NewShaper(plainShaper{}).Shape(standardParams(font), strings.NewReader(text), &sink, BufferOptions{}); err != nil {
+		t.Fatalf("shape failed: %v", err)
+	}
+	var gids []ot.GlyphIndex
+	var advances []int32
+	for _, g := range sink.glyphs {
+		gids = append(gids, g.GID)
+		advances = append(advances, g.Pos.XAdvance)
+	}
+	wantGIDs := []ot.GlyphIndex{1, 1, 1, 1, 1, 1, 1, 4, 1}
+	wantAdvances := []int32{250, 250, 200, 1000, 550, 200, 125, 480, 63}
+	if !slices.Equal(gids, wantGIDs) || !slices.Equal(advances, wantAdvances) {
+		t.Errorf("expected glyphs %v with advances %v, have %v and %v", wantGIDs, wantAdvances, gids, advances)
+	}
+}
@@ -0,0 +1,58 @@
+package otshape
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otquery"
+)
+
+// SyntheticStyle configures the emulation of bold and oblique styles for fonts
+// lacking a bold or italic face. Shaping adjusts the metrics of the glyphs and
+// records the style in [otlayout.PosItem].Embolden and Shear of every glyph,
+// telling renderers to embolden and shear the outlines.
+//
+// Emboldening widens the outline of a glyph by Embolden font units, half of it
+// at either side, and the advance of every glyph with a non-zero advance by
+// Embolden. Shearing moves points of the outline by Shear times their height to
+// the right; offsets of glyphs are sheared alike, which keeps raised or lowered
+// marks in place over their slanted bases.
+type SyntheticStyle struct {
+	Embolden int32   // stroke delta in font units, e.g. 2% of the em; 0 for no emboldening
+	Shear    float32 // horizontal shear, e.g. 0.2 for a slant of about 11°; 0 for upright
+}
+
+func (s SyntheticStyle) validate() error {
+	if s.Embolden < 0 {
+		return fmt.Errorf("otshape: synthetic bold stroke delta must be >= 0")
+	}
+	if s.Shear < -1 || s.Shear > 1 || math.IsNaN(float64(s.Shear)) {
+		return fmt.Errorf("otshape: synthetic oblique shear must be within [-1,1]")
+	}
+	return nil
+}
+
+// applySyntheticStyle adjusts advances and offsets of the glyphs of the run
+// for the synthetic style of the current shaping call, and records the style
+// in the position buffer.
+func (e *planExecutor) applySyntheticStyle(pl *plan) {
+	if e.style == (SyntheticStyle{}) || e.run == nil || pl == nil || pl.font == nil {
+		return
+	}
+	e.run.EnsurePos()
+	for i := range e.run.Pos {
+		pos := &e.run.Pos[i]
+		if e.style.Embolden != 0 {
+			advance := int32(otquery.GlyphMetrics(pl.font, e.run.Glyphs[i]).Advance)
+			if advance+pos.XAdvance != 0 {
+				pos.XAdvance += e.style.Embolden
+			}
+			pos.Embolden = e.style.Embolden
+		}
+		if e.style.Shear != 0 {
+			pos.XOffset += int32(ot.Round(float64(e.style.Shear) * float64(pos.YOffset)))
+			pos.Shear = e.style.Shear
+		}
+	}
+}
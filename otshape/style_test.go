@@ -0,0 +1,86 @@
+package otshape
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestSyntheticStyle(t *testing.T) {
+	b := testfont.New(4)
+	for i, name := range []string{"a", "b", "acute"} {
+		b.Name(ot.GlyphIndex(i+1), name)
+	}
+	b.Map('a', 1).Map('b', 2).Map('́', 3)
+	b.Advance(1, 500).Advance(2, 500).Advance(3, 0)
+	err := b.Features(`languagesystem latn dflt;
+table GDEF { GlyphClassDef [a b], , [acute], ; } GDEF;
+feature kern { pos a b -40; pos acute <10 300 0 0>; } kern;
+`)
+	if err != nil {
+		t.Fatalf("cannot compile features: %v", err)
+	}
+	font, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	shape := func(style SyntheticStyle) []GlyphRecord {
+		t.Helper()
+		var sink collectSink
+		if err := NewShaper(plainShaper{}).Shape(standardParams(font), strings.NewReader("ab́"), &sink,
+			BufferOptions{Style: style}); err != nil {
+			t.Fatalf("shape failed: %v", err)
+		}
+		return sink.glyphs
+	}
+	regular := shape(SyntheticStyle{})
+	tests := []struct {
+		style    SyntheticStyle
+		advances []int32
+		xoffsets []int32
+	}{
+		{SyntheticStyle{}, []int32{460, 500, 0}, []int32{0, 0, 10}},
+		{SyntheticStyle{Embolden: 20}, []int32{480, 520, 0}, []int32{0, 0, 10}},
+		{SyntheticStyle{Shear: 0.2}, []int32{460, 500, 0}, []int32{0, 0, 70}},
+	}
+	for _, tt := range tests {
+		glyphs := shape(tt.style)
+		var advances, xoffsets []int32
+		for i, g := range glyphs {
+			advances = append(advances, g.Pos.XAdvance)
+			xoffsets = append(xoffsets, g.Pos.XOffset)
+			if g.GID != regular[i].GID || g.Pos.YOffset != regular[i].Pos.YOffset {
+				t.Errorf("%+v: expected glyph %d to be shaped like the regular style", tt.style, i)
+			}
+			if g.Pos.Embolden != tt.style.Embolden || g.Pos.Shear != tt.style.Shear {
+				t.Errorf("%+v: expected style to be recorded for glyph %d, have %d/%g",
+					tt.style, i, g.Pos.Embolden, g.Pos.Shear)
+			}
+		}
+		if !slices.Equal(advances, tt.advances) || !slices.Equal(xoffsets, tt.xoffsets) {
+			t.Errorf("%+v: expected advances %v and x-offsets %v, have %v and %v",
+				tt.style, tt.advances, tt.xoffsets, advances, xoffsets)
+		}
+		var sink collectSink
+		if err := NewShaper(plainShaper{}).ShapeEvents(standardParams(font),
+			NewInputEventSource(strings.NewReader("ab\u0301")), &sink, BufferOptions{Style: tt.style}); err != nil {
+			t.Fatalf("shape events failed: %v", err)
+		}
+		if !reflect.DeepEqual(sink.glyphs, glyphs) {
+			t.Errorf("%+v: expected ShapeEvents to agree with Shape, have %v", tt.style, sink.glyphs)
+		}
+		opts := MeasureOptions{Params: standardParams(font), Shaper: NewShaper(plainShaper{}), Style: tt.style}
+		if w, _, _ := Measure("ab́", opts); w != int(tt.advances[0]+tt.advances[1]) {
+			t.Errorf("%+v: expected Measure to agree with shaping, have width %d", tt.style, w)
+		}
+	}
+	err = NewShaper(plainShaper{}).Shape(standardParams(font), strings.NewReader("a"), &collectSink{},
+		BufferOptions{Style: SyntheticStyle{Embolden: -1}})
+	if err == nil {
+		t.Errorf("expected negative stroke delta to be rejected")
+	}
+}
@@ -0,0 +1,194 @@
+package otshape
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// ToUnicodeMap collects the text represented by the glyphs of a font, gathered
+// from shaped glyph runs. It is suitable for generating ToUnicode CMaps of PDF
+// files, which enable text extraction from glyph-based PDF content.
+//
+// A glyph is mapped to the text of the cluster it has been shaped from. Ligature
+// glyphs are therefore mapped to more than one code-point. As a ToUnicode CMap
+// assigns a single text to each glyph, clusters which cannot be represented by
+// it are reported as [ActualTextSpan]s, for which PDF producers should emit
+// marked content with an /ActualText entry.
+type ToUnicodeMap struct {
+	font *ot.Font
+	text map[ot.GlyphIndex][]rune
+}
+
+// ActualTextSpan is a range of glyphs of a run, which has to be tagged with its
+// text explicitly, as the ToUnicode mapping of its glyphs does not reproduce
+// the text.
+type ActualTextSpan struct {
+	Start, End int    // range [Start:End) of glyph indices in the run
+	Text       []rune // text of the glyphs, in logical order
+}
+
+// NewToUnicodeMap creates an empty glyph-to-Unicode mapping for font.
+func NewToUnicodeMap(font *ot.Font) *ToUnicodeMap {
+	return &ToUnicodeMap{
+		font: font,
+		text: make(map[ot.GlyphIndex][]rune),
+	}
+}
+
+// AddRun adds the mappings of a shaped glyph run, which has been shaped from
+// text. Cluster values of glyphs are indices into text, as produced by the
+// shaper for text shaped as a whole.
+//
+// A cluster consisting of a single glyph maps the glyph to the text of the
+// cluster. For clusters of more than one glyph, the reverse cmap of the font is
+// used, if it accounts for the text of the cluster; otherwise the first glyph
+// of the cluster is mapped to the text. AddRun returns the clusters for which
+// the mapping does not reproduce the text, either because of this or because
+// a glyph has already been mapped to another text.
+func (m *ToUnicodeMap) AddRun(text []rune, glyphs []GlyphRecord) []ActualTextSpan {
+	if m == nil || len(glyphs) == 0 {
+		return nil
+	}
+	starts := make([]uint32, 0, len(glyphs))
+	for _, g := range glyphs {
+		starts = append(starts, g.Cluster)
+	}
+	slices.Sort(starts)
+	starts = slices.Compact(starts)
+	var spans []ActualTextSpan
+	for start := 0; start < len(glyphs); {
+		end := start + 1
+		for end < len(glyphs) && glyphs[end].Cluster == glyphs[start].Cluster {
+			end++
+		}
+		txt := clusterText(text, starts, glyphs[start].Cluster)
+		if len(txt) > 0 && !m.addCluster(glyphs[start:end], txt) {
+			spans = append(spans, ActualTextSpan{Start: start, End: end, Text: txt})
+		}
+		start = end
+	}
+	return spans
+}
+
+// clusterText returns the text of the cluster starting at text position c.
+// The cluster extends up to the start of the next cluster.
+func clusterText(text []rune, starts []uint32, c uint32) []rune {
+	if int(c) >= len(text) {
+		return nil
+	}
+	end := len(text)
+	if i, _ := slices.BinarySearch(starts, c+1); i < len(starts) && int(starts[i]) < end {
+		end = int(starts[i])
+	}
+	return text[c:end]
+}
+
+// addCluster maps the glyphs of a cluster to the cluster's text. It returns
+// false if the mapping does not reproduce txt.
+func (m *ToUnicodeMap) addCluster(glyphs []GlyphRecord, txt []rune) bool {
+	if len(glyphs) == 1 {
+		return m.assign(glyphs[0].GID, txt)
+	}
+	if runes, ok := m.reverseMapped(glyphs, txt); ok {
+		ok := true
+		for i, g := range glyphs {
+			ok = m.assign(g.GID, runes[i:i+1]) && ok
+		}
+		return ok
+	}
+	m.assign(glyphs[0].GID, txt)
+	return false
+}
+
+// reverseMapped returns a code-point for each glyph, using the reverse cmap of
+// the font, if these code-points are exactly the code-points of txt.
+func (m *ToUnicodeMap) reverseMapped(glyphs []GlyphRecord, txt []rune) ([]rune, bool) {
+	if len(glyphs) != len(txt) || m.font == nil {
+		return nil, false
+	}
+	cmap := m.font.CMapTable()
+	runes := make([]rune, len(glyphs))
+	for i, g := range glyphs {
+		r, ok := cmap.RuneFor(g.GID)
+		if !ok {
+			return nil, false
+		}
+		runes[i] = r
+	}
+	sorted, want := slices.Clone(runes), slices.Clone(txt)
+	slices.Sort(sorted)
+	slices.Sort(want)
+	return runes, slices.Equal(sorted, want)
+}
+
+// assign maps gid to txt, unless gid is .notdef or already mapped to a
+// different text.
+func (m *ToUnicodeMap) assign(gid ot.GlyphIndex, txt []rune) bool {
+	if gid == 0 {
+		return false
+	}
+	if have, ok := m.text[gid]; ok {
+		return slices.Equal(have, txt)
+	}
+	m.text[gid] = slices.Clone(txt)
+	return true
+}
+
+// Lookup returns the text glyph gid has been mapped to.
+func (m *ToUnicodeMap) Lookup(gid ot.GlyphIndex) ([]rune, bool) {
+	if m == nil {
+		return nil, false
+	}
+	txt, ok := m.text[gid]
+	return txt, ok
+}
+
+// Len returns the number of mapped glyphs.
+func (m *ToUnicodeMap) Len() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.text)
+}
+
+// WriteCMap writes the mapping as a PDF ToUnicode CMap stream, for fonts
+// embedded with encoding Identity-H, i.e. with glyph IDs as 2-byte codes.
+func (m *ToUnicodeMap) WriteCMap(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	bw.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	bw.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	bw.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	var gids []ot.GlyphIndex
+	if m != nil {
+		for gid := range m.text {
+			gids = append(gids, gid)
+		}
+	}
+	slices.Sort(gids)
+	const maxEntries = 100 // per bfchar section, as limited by PDF
+	for len(gids) > 0 {
+		n := min(len(gids), maxEntries)
+		fmt.Fprintf(bw, "%d beginbfchar\n", n)
+		for _, gid := range gids[:n] {
+			fmt.Fprintf(bw, "<%04X> <", uint16(gid))
+			for _, r := range m.text[gid] {
+				if r > 0xffff { // UTF-16 surrogate pair
+					r -= 0x10000
+					fmt.Fprintf(bw, "%04X%04X", 0xd800+(r>>10), 0xdc00+(r&0x3ff))
+				} else {
+					fmt.Fprintf(bw, "%04X", r)
+				}
+			}
+			bw.WriteString(">\n")
+		}
+		bw.WriteString("endbfchar\n")
+		gids = gids[n:]
+	}
+	bw.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+	return bw.Flush()
+}
@@ -0,0 +1,52 @@
+package otshape
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+)
+
+func TestToUnicodeMap(t *testing.T) {
+	b := testfont.New(8)
+	b.Map('f', 1).Map('i', 2).Map('l', 3).Map('e', 5).Map('\u0301', 6)
+	otf, err := b.Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	m := NewToUnicodeMap(otf)
+	run := func(gids []ot.GlyphIndex, clusters []uint32) []GlyphRecord {
+		glyphs := make([]GlyphRecord, len(gids))
+		for i := range gids {
+			glyphs[i] = GlyphRecord{GID: gids[i], Cluster: clusters[i]}
+		}
+		return glyphs
+	}
+	// ligature 'fi' and decomposed 'é', both as a single cluster
+	text := []rune("file\u0301\U0001D400")
+	spans := m.AddRun(text, run([]ot.GlyphIndex{4, 3, 5, 6, 7}, []uint32{0, 2, 3, 3, 5}))
+	if len(spans) != 0 {
+		t.Errorf("expected no ActualText spans, have %v", spans)
+	}
+	for gid, want := range map[ot.GlyphIndex]string{4: "fi", 3: "l", 5: "e", 6: "\u0301", 7: "\U0001D400"} {
+		if txt, ok := m.Lookup(gid); !ok || string(txt) != want {
+			t.Errorf("glyph %d: expected %q, have %q", gid, want, string(txt))
+		}
+	}
+	// glyph 4 used for 'fl' conflicts with 'fi'; 'ff' as two glyphs not in the cmap
+	spans = m.AddRun([]rune("flff"), run([]ot.GlyphIndex{4, 0, 0}, []uint32{0, 2, 2}))
+	if len(spans) != 2 || spans[0].End != 1 || string(spans[0].Text) != "fl" ||
+		spans[1].Start != 1 || string(spans[1].Text) != "ff" {
+		t.Errorf("expected ActualText spans for 'fl' and 'ff', have %v", spans)
+	}
+	var cmap strings.Builder
+	if err := m.WriteCMap(&cmap); err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range []string{"5 beginbfchar", "<0004> <00660069>", "<0007> <D835DC00>"} {
+		if !strings.Contains(cmap.String(), entry) {
+			t.Errorf("expected CMap to contain %q:\n%s", entry, cmap.String())
+		}
+	}
+}
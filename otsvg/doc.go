@@ -0,0 +1,33 @@
+/*
+Package otsvg renders shaped glyph runs to SVG, for debugging shapers.
+
+Much like HarfBuzz's hb-view, Render draws the outlines of glyphs at the
+positions computed by package otshape. Outlines are taken from the font's
+'glyf' or 'CFF ' table. Coordinates of the SVG are font units, with the pen
+starting at the origin and y growing downwards, as usual for SVG; the baseline
+is drawn as a thin line.
+
+	err := otsvg.Render(w, font, glyphs, otsvg.Options{ClusterColors: true, Anchors: true})
+
+Glyphs may be colored by cluster, which makes ligatures and decompositions
+easy to spot, and attachments of marks and cursive glyphs may be marked. Each
+glyph path carries its glyph ID and cluster as attributes 'data-gid' and
+'data-cluster', which lets tests inspect the output.
+
+# License
+
+Governed by a 3-Clause BSD license. License file may be found in the root
+folder of this module.
+
+Copyright © Norbert Pillmayer <norbert@pillmayer.com>
+*/
+package otsvg
+
+import (
+	"github.com/npillmayer/schuko/tracing"
+)
+
+// tracer writes to trace with key 'tyse.fonts'
+func tracer() tracing.Trace {
+	return tracing.Select("tyse.fonts")
+}
@@ -0,0 +1,178 @@
+package otsvg
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"github.com/npillmayer/opentype/otshape"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+)
+
+// Options control the rendering of a glyph run.
+type Options struct {
+	ClusterColors bool  // fill glyphs with a color per cluster instead of black
+	Anchors       bool  // mark attachment points of marks and cursive glyphs
+	Margin        int32 // margin around the run, in font units; 0 selects 1/10 em
+}
+
+// clusterPalette holds the fill colors of clusters, used in turn.
+var clusterPalette = []string{
+	"#1f77b4", "#d62728", "#2ca02c", "#9467bd", "#ff7f0e", "#8c564b", "#e377c2", "#17becf",
+}
+
+// glyphPath is a glyph outline placed at its shaped position.
+type glyphPath struct {
+	record otshape.GlyphRecord
+	x, y   int32 // origin of the glyph, y growing downwards
+	d      string
+}
+
+// Render writes an SVG document to w, showing the outlines of glyphs at their
+// shaped positions. glyphs are glyph records as produced by package otshape,
+// with advances including the glyphs' nominal advances. Glyphs without an
+// outline, such as spaces, take up their advance only.
+func Render(w io.Writer, font *ot.Font, glyphs []otshape.GlyphRecord, opts Options) error {
+	if font == nil {
+		return errors.New("otsvg: font is nil")
+	}
+	sf, err := sfnt.Parse(font.Binary())
+	if err != nil {
+		return fmt.Errorf("otsvg: cannot read glyph outlines: %w", err)
+	}
+	upem := int32(sf.UnitsPerEm())
+	if upem <= 0 {
+		return errors.New("otsvg: invalid units-per-em")
+	}
+	margin := opts.Margin
+	if margin <= 0 {
+		margin = upem / 10
+	}
+	paths := make([]glyphPath, len(glyphs))
+	var buf sfnt.Buffer
+	var penX, penY int32
+	box := bounds{minX: 0, maxX: 0, minY: -upem * 8 / 10, maxY: upem * 2 / 10} // ascent/descent guess
+	for i, g := range glyphs {
+		p := &paths[i]
+		p.record = g
+		p.x, p.y = penX+g.Pos.XOffset, penY-g.Pos.YOffset
+		penX += g.Pos.XAdvance
+		penY -= g.Pos.YAdvance
+		box.add(penX, penY)
+		segs, err := sf.LoadGlyph(&buf, sfnt.GlyphIndex(g.GID), fixed.I(int(upem)), nil)
+		if err != nil {
+			tracer().Debugf("otsvg: no outline for glyph %d: %v", g.GID, err)
+			continue
+		}
+		if len(segs) > 0 {
+			b := segs.Bounds()
+			box.add(p.x+int32(b.Min.X.Floor()), p.y+int32(b.Min.Y.Floor()))
+			box.add(p.x+int32(b.Max.X.Ceil()), p.y+int32(b.Max.Y.Ceil()))
+		}
+		p.d = pathData(segs)
+	}
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" viewBox="%d %d %d %d">`+"\n",
+		box.minX-margin, box.minY-margin, box.maxX-box.minX+2*margin, box.maxY-box.minY+2*margin)
+	stroke := max(1, upem/200)
+	fmt.Fprintf(bw, `<line class="baseline" x1="%d" y1="0" x2="%d" y2="0" stroke="#999" stroke-width="%d"/>`+"\n",
+		box.minX-margin, box.maxX+margin, stroke)
+	clusters := 0
+	for i, p := range paths {
+		if i > 0 && p.record.Cluster != paths[i-1].record.Cluster {
+			clusters++
+		}
+		if p.d == "" {
+			continue
+		}
+		fill := "#000"
+		if opts.ClusterColors {
+			fill = clusterPalette[clusters%len(clusterPalette)]
+		}
+		fmt.Fprintf(bw, `<path data-gid="%d" data-cluster="%d" transform="translate(%d %d)" fill="%s" d="%s"/>`+"\n",
+			p.record.GID, p.record.Cluster, p.x, p.y, fill, p.d)
+	}
+	if opts.Anchors {
+		for _, p := range paths {
+			writeAnchor(bw, paths, p, upem/40, stroke)
+		}
+	}
+	fmt.Fprintln(bw, "</svg>")
+	return bw.Flush()
+}
+
+// writeAnchor marks the attachment of glyph p by a circle at its origin,
+// connected to the origin of the glyph it is attached to.
+func writeAnchor(w io.Writer, paths []glyphPath, p glyphPath, r, stroke int32) {
+	if p.record.Pos.AttachKind == otlayout.AttachNone {
+		return
+	}
+	to := p.record.Pos.AttachTo
+	if to < 0 || int(to) >= len(paths) {
+		return
+	}
+	base := paths[to]
+	fmt.Fprintf(w, `<line class="attachment" x1="%d" y1="%d" x2="%d" y2="%d" stroke="#e00" stroke-width="%d"/>`+"\n",
+		base.x, base.y, p.x, p.y, stroke)
+	fmt.Fprintf(w, `<circle class="anchor" cx="%d" cy="%d" r="%d" fill="none" stroke="#e00" stroke-width="%d"/>`+"\n",
+		p.x, p.y, r, stroke)
+}
+
+// pathData converts glyph segments, scaled to font units, to SVG path data.
+func pathData(segs sfnt.Segments) string {
+	var sb strings.Builder
+	for i, seg := range segs {
+		if seg.Op == sfnt.SegmentOpMoveTo && i > 0 {
+			sb.WriteString("Z ")
+		}
+		switch seg.Op {
+		case sfnt.SegmentOpMoveTo:
+			sb.WriteString("M")
+			writePoints(&sb, seg.Args[:1])
+		case sfnt.SegmentOpLineTo:
+			sb.WriteString("L")
+			writePoints(&sb, seg.Args[:1])
+		case sfnt.SegmentOpQuadTo:
+			sb.WriteString("Q")
+			writePoints(&sb, seg.Args[:2])
+		case sfnt.SegmentOpCubeTo:
+			sb.WriteString("C")
+			writePoints(&sb, seg.Args[:3])
+		}
+	}
+	if len(segs) > 0 {
+		sb.WriteString("Z")
+	}
+	return sb.String()
+}
+
+func writePoints(sb *strings.Builder, pts []fixed.Point26_6) {
+	for _, pt := range pts {
+		fmt.Fprintf(sb, "%s %s ", coord(pt.X), coord(pt.Y))
+	}
+}
+
+// coord formats a 26.6 fixed-point coordinate with up to two decimals.
+func coord(v fixed.Int26_6) string {
+	f := math.Round(float64(v)/64*100) / 100
+	if f == 0 {
+		return "0"
+	}
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", f), "0"), ".")
+}
+
+// bounds is a bounding box in font units, y growing downwards.
+type bounds struct {
+	minX, minY, maxX, maxY int32
+}
+
+func (b *bounds) add(x, y int32) {
+	b.minX, b.maxX = min(b.minX, x), max(b.maxX, x)
+	b.minY, b.maxY = min(b.minY, y), max(b.maxY, y)
+}
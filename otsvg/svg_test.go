@@ -0,0 +1,83 @@
+package otsvg
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/fontload"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/opentype/otlayout"
+	"github.com/npillmayer/opentype/otquery"
+	"github.com/npillmayer/opentype/otshape"
+)
+
+func loadLocalFont(t *testing.T, fontFileName string) *ot.Font {
+	t.Helper()
+	path := filepath.Join("..", "testdata", "fonts", fontFileName)
+	f, err := fontload.LoadOpenTypeFont(path)
+	if err != nil {
+		t.Fatalf("cannot load test font %s: %s", fontFileName, err)
+	}
+	otf, err := ot.Parse(f.Binary, ot.IsTestfont) // Go fonts have no layout tables
+	if err != nil {
+		t.Fatalf("cannot decode test font %s: %s", fontFileName, err)
+	}
+	return otf
+}
+
+// run creates glyph records for text, one cluster per rune, as a shaper
+// without any features would.
+func run(otf *ot.Font, text string) []otshape.GlyphRecord {
+	var glyphs []otshape.GlyphRecord
+	for i, r := range []rune(text) {
+		gid := otquery.GlyphIndex(otf, r)
+		glyphs = append(glyphs, otshape.GlyphRecord{
+			GID:     gid,
+			Cluster: uint32(i),
+			Pos: otlayout.PosItem{
+				XAdvance: int32(otquery.GlyphMetrics(otf, gid).Advance),
+				AttachTo: -1,
+			},
+		})
+	}
+	return glyphs
+}
+
+func TestRender(t *testing.T) {
+	for _, name := range []string{"Calibri.ttf", "Go-Regular.otf"} { // glyf and CFF outlines
+		otf := loadLocalFont(t, name)
+		glyphs := run(otf, "Ab c")
+		var sb strings.Builder
+		if err := Render(&sb, otf, glyphs, Options{ClusterColors: true}); err != nil {
+			t.Fatalf("%s: cannot render: %v", name, err)
+		}
+		svg := sb.String()
+		if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" viewBox=`) || !strings.HasSuffix(svg, "</svg>\n") {
+			t.Errorf("%s: not an SVG document:\n%s", name, svg)
+		}
+		if n := strings.Count(svg, "<path "); n != 3 { // space has no outline
+			t.Errorf("%s: expected 3 glyph paths, have %d", name, n)
+		}
+		if !strings.Contains(svg, `data-cluster="3"`) || strings.Contains(svg, `data-cluster="2"`) {
+			t.Errorf("%s: expected paths for clusters 0, 1 and 3", name)
+		}
+		if !strings.Contains(svg, clusterPalette[1]) {
+			t.Errorf("%s: expected glyphs to be colored by cluster", name)
+		}
+	}
+}
+
+func TestRenderAnchors(t *testing.T) {
+	otf := loadLocalFont(t, "Calibri.ttf")
+	glyphs := run(otf, "á")
+	glyphs[1].Pos.AttachKind, glyphs[1].Pos.AttachTo = otlayout.AttachMarkToBase, 0
+	glyphs[1].Pos.XOffset, glyphs[1].Pos.XAdvance = -glyphs[0].Pos.XAdvance, 0
+	var sb strings.Builder
+	if err := Render(&sb, otf, glyphs, Options{Anchors: true}); err != nil {
+		t.Fatalf("cannot render: %v", err)
+	}
+	if svg := sb.String(); strings.Count(svg, `class="anchor"`) != 1 || strings.Count(svg, `class="attachment"`) != 1 {
+		t.Errorf("expected one anchor marker for the attached mark:\n%s", svg)
+	}
+}
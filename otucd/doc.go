@@ -0,0 +1,27 @@
+/*
+Package otucd provides Unicode character properties needed for the layout of
+East Asian text: East Asian Width (UAX #11) and Line Break classes (UAX #14).
+
+Justification and line breaking of Chinese, Japanese and Korean text depend on
+these properties, e.g. to tell ideographs, which may be broken between and
+spaced apart, from opening and closing punctuation, which may not:
+
+	if otucd.LineBreak(r) == otucd.ID && otucd.Width(r).IsWide() {
+		…
+	}
+
+Properties are taken from tables generated from the Unicode Character
+Database, see gen_tables.go. The tables are generated from UCD version 14.0.0,
+whereas other generated tables of this module, e.g. the joining table of
+package otarabic, are of version 15.1.0. Characters added in Unicode 15.0 and
+15.1 may therefore have outdated properties, until the tables are regenerated
+from the files of version 15.1.0.
+
+# License
+
+Governed by a 3-Clause BSD license. License file may be found in the root
+folder of this module.
+
+Copyright © Norbert Pillmayer <norbert@pillmayer.com>
+*/
+package otucd
@@ -0,0 +1,137 @@
+//go:build ignore
+
+// gen_tables generates tables.go from the Unicode Character Database files
+// EastAsianWidth.txt and LineBreak.txt, found in directory -ucd:
+//
+//	go run gen_tables.go -ucd .
+//
+// The UCD files are not part of the repository. The files of version 15.1.0,
+// matching the other generated tables of this module, may be downloaded from
+// https://www.unicode.org/Public/15.1.0/ucd/.
+//
+// The tables.go checked in has been generated from version 14.0.0 instead, from
+// property dumps in the format of the UCD files written with Perl's
+// Unicode::UCD (prop_invmap), as the UCD files could not be downloaded then.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var widths = []string{"N", "A", "H", "F", "Na", "W"}
+
+var lineBreakClasses = []string{
+	"XX", "AI", "AL", "B2", "BA", "BB", "BK", "CB", "CJ", "CL", "CM", "CP", "CR", "EB", "EM", "EX", "GL",
+	"H2", "H3", "HL", "HY", "ID", "IN", "IS", "JL", "JT", "JV", "LF", "NL", "NS", "NU", "OP", "PO", "PR",
+	"QU", "RI", "SA", "SG", "SP", "SY", "WJ", "ZW", "ZWJ",
+}
+
+type entry struct {
+	lo, hi rune
+	value  int
+}
+
+func main() {
+	ucd := flag.String("ucd", ".", "directory of UCD files EastAsianWidth.txt and LineBreak.txt")
+	out := flag.String("o", "tables.go", "output file")
+	flag.Parse()
+	width, widthVersion := readProperty(filepath.Join(*ucd, "EastAsianWidth.txt"), widths)
+	lb, lbVersion := readProperty(filepath.Join(*ucd, "LineBreak.txt"), lineBreakClasses)
+	//
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by gen_tables.go from %s and %s; DO NOT EDIT.\n\n", widthVersion, lbVersion)
+	fmt.Fprintf(&b, "package otucd\n\n")
+	writeTable(&b, "widthTable", "East Asian widths other than Neutral", width, func(v int) string {
+		return [...]string{"Neutral", "Ambiguous", "Halfwidth", "Fullwidth", "Narrow", "Wide"}[v]
+	})
+	writeTable(&b, "lineBreakTable", "line break classes other than XX", lb, func(v int) string {
+		return lineBreakClasses[v]
+	})
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// readProperty reads a UCD file with lines of a code point or a range of code
+// points and a property value, e.g. "4E00..9FFF;ID". Values are indexes into
+// values; code points with value values[0] are omitted. Adjacent ranges with
+// equal values are merged.
+func readProperty(path string, values []string) ([]entry, string) {
+	f, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer f.Close()
+	version := filepath.Base(path)
+	versionRE := regexp.MustCompile(`^#\s*(\w+-[0-9.]+\.txt)`)
+	var entries []entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if m := versionRE.FindStringSubmatch(line); m != nil {
+			version = m[1]
+		}
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Split(line, ";")
+		if len(fields) < 2 {
+			continue
+		}
+		lo, hi, ok := strings.Cut(strings.TrimSpace(fields[0]), "..")
+		if !ok {
+			hi = lo
+		}
+		from, err1 := strconv.ParseUint(lo, 16, 32)
+		to, err2 := strconv.ParseUint(hi, 16, 32)
+		if err1 != nil || err2 != nil || from > to {
+			log.Fatalf("%s: invalid code points in line %q", path, line)
+		}
+		value := slices.Index(values, strings.TrimSpace(fields[1]))
+		if value < 0 {
+			log.Fatalf("%s: unknown property value in line %q", path, line)
+		}
+		if value == 0 {
+			continue
+		}
+		entries = append(entries, entry{lo: rune(from), hi: rune(to), value: value})
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return int(a.lo - b.lo) })
+	merged := entries[:0]
+	for _, e := range entries {
+		if n := len(merged); n > 0 && merged[n-1].hi+1 == e.lo && merged[n-1].value == e.value {
+			merged[n-1].hi = e.hi
+			continue
+		}
+		merged = append(merged, e)
+	}
+	return merged, version
+}
+
+func writeTable(b *bytes.Buffer, name, desc string, entries []entry, ident func(int) string) {
+	fmt.Fprintf(b, "// %s holds the code points with %s,\n", name, desc)
+	fmt.Fprintf(b, "// as ranges sorted by code point.\n")
+	fmt.Fprintf(b, "var %s = [...]propertyRange{\n", name)
+	for _, e := range entries {
+		fmt.Fprintf(b, "\t{0x%04X, 0x%04X, uint8(%s)},\n", e.lo, e.hi, ident(e.value))
+	}
+	fmt.Fprintf(b, "}\n\n")
+}
@@ -0,0 +1,3118 @@
+// Code generated by gen_tables.go from EastAsianWidth-14.0.0.txt and LineBreak-14.0.0.txt; DO NOT EDIT.
+
+package otucd
+
+// widthTable holds the code points with East Asian widths other than Neutral,
+// as ranges sorted by code point.
+var widthTable = [...]propertyRange{
+	{0x0020, 0x007E, uint8(Narrow)},
+	{0x00A1, 0x00A1, uint8(Ambiguous)},
+	{0x00A2, 0x00A3, uint8(Narrow)},
+	{0x00A4, 0x00A4, uint8(Ambiguous)},
+	{0x00A5, 0x00A6, uint8(Narrow)},
+	{0x00A7, 0x00A8, uint8(Ambiguous)},
+	{0x00AA, 0x00AA, uint8(Ambiguous)},
+	{0x00AC, 0x00AC, uint8(Narrow)},
+	{0x00AD, 0x00AE, uint8(Ambiguous)},
+	{0x00AF, 0x00AF, uint8(Narrow)},
+	{0x00B0, 0x00B4, uint8(Ambiguous)},
+	{0x00B6, 0x00BA, uint8(Ambiguous)},
+	{0x00BC, 0x00BF, uint8(Ambiguous)},
+	{0x00C6, 0x00C6, uint8(Ambiguous)},
+	{0x00D0, 0x00D0, uint8(Ambiguous)},
+	{0x00D7, 0x00D8, uint8(Ambiguous)},
+	{0x00DE, 0x00E1, uint8(Ambiguous)},
+	{0x00E6, 0x00E6, uint8(Ambiguous)},
+	{0x00E8, 0x00EA, uint8(Ambiguous)},
+	{0x00EC, 0x00ED, uint8(Ambiguous)},
+	{0x00F0, 0x00F0, uint8(Ambiguous)},
+	{0x00F2, 0x00F3, uint8(Ambiguous)},
+	{0x00F7, 0x00FA, uint8(Ambiguous)},
+	{0x00FC, 0x00FC, uint8(Ambiguous)},
+	{0x00FE, 0x00FE, uint8(Ambiguous)},
+	{0x0101, 0x0101, uint8(Ambiguous)},
+	{0x0111, 0x0111, uint8(Ambiguous)},
+	{0x0113, 0x0113, uint8(Ambiguous)},
+	{0x011B, 0x011B, uint8(Ambiguous)},
+	{0x0126, 0x0127, uint8(Ambiguous)},
+	{0x012B, 0x012B, uint8(Ambiguous)},
+	{0x0131, 0x0133, uint8(Ambiguous)},
+	{0x0138, 0x0138, uint8(Ambiguous)},
+	{0x013F, 0x0142, uint8(Ambiguous)},
+	{0x0144, 0x0144, uint8(Ambiguous)},
+	{0x0148, 0x014B, uint8(Ambiguous)},
+	{0x014D, 0x014D, uint8(Ambiguous)},
+	{0x0152, 0x0153, uint8(Ambiguous)},
+	{0x0166, 0x0167, uint8(Ambiguous)},
+	{0x016B, 0x016B, uint8(Ambiguous)},
+	{0x01CE, 0x01CE, uint8(Ambiguous)},
+	{0x01D0, 0x01D0, uint8(Ambiguous)},
+	{0x01D2, 0x01D2, uint8(Ambiguous)},
+	{0x01D4, 0x01D4, uint8(Ambiguous)},
+	{0x01D6, 0x01D6, uint8(Ambiguous)},
+	{0x01D8, 0x01D8, uint8(Ambiguous)},
+	{0x01DA, 0x01DA, uint8(Ambiguous)},
+	{0x01DC, 0x01DC, uint8(Ambiguous)},
+	{0x0251, 0x0251, uint8(Ambiguous)},
+	{0x0261, 0x0261, uint8(Ambiguous)},
+	{0x02C4, 0x02C4, uint8(Ambiguous)},
+	{0x02C7, 0x02C7, uint8(Ambiguous)},
+	{0x02C9, 0x02CB, uint8(Ambiguous)},
+	{0x02CD, 0x02CD, uint8(Ambiguous)},
+	{0x02D0, 0x02D0, uint8(Ambiguous)},
+	{0x02D8, 0x02DB, uint8(Ambiguous)},
+	{0x02DD, 0x02DD, uint8(Ambiguous)},
+	{0x02DF, 0x02DF, uint8(Ambiguous)},
+	{0x0300, 0x036F, uint8(Ambiguous)},
+	{0x0391, 0x03A1, uint8(Ambiguous)},
+	{0x03A3, 0x03A9, uint8(Ambiguous)},
+	{0x03B1, 0x03C1, uint8(Ambiguous)},
+	{0x03C3, 0x03C9, uint8(Ambiguous)},
+	{0x0401, 0x0401, uint8(Ambiguous)},
+	{0x0410, 0x044F, uint8(Ambiguous)},
+	{0x0451, 0x0451, uint8(Ambiguous)},
+	{0x1100, 0x115F, uint8(Wide)},
+	{0x2010, 0x2010, uint8(Ambiguous)},
+	{0x2013, 0x2016, uint8(Ambiguous)},
+	{0x2018, 0x2019, uint8(Ambiguous)},
+	{0x201C, 0x201D, uint8(Ambiguous)},
+	{0x2020, 0x2022, uint8(Ambiguous)},
+	{0x2024, 0x2027, uint8(Ambiguous)},
+	{0x2030, 0x2030, uint8(Ambiguous)},
+	{0x2032, 0x2033, uint8(Ambiguous)},
+	{0x2035, 0x2035, uint8(Ambiguous)},
+	{0x203B, 0x203B, uint8(Ambiguous)},
+	{0x203E, 0x203E, uint8(Ambiguous)},
+	{0x2074, 0x2074, uint8(Ambiguous)},
+	{0x207F, 0x207F, uint8(Ambiguous)},
+	{0x2081, 0x2084, uint8(Ambiguous)},
+	{0x20A9, 0x20A9, uint8(Halfwidth)},
+	{0x20AC, 0x20AC, uint8(Ambiguous)},
+	{0x2103, 0x2103, uint8(Ambiguous)},
+	{0x2105, 0x2105, uint8(Ambiguous)},
+	{0x2109, 0x2109, uint8(Ambiguous)},
+	{0x2113, 0x2113, uint8(Ambiguous)},
+	{0x2116, 0x2116, uint8(Ambiguous)},
+	{0x2121, 0x2122, uint8(Ambiguous)},
+	{0x2126, 0x2126, uint8(Ambiguous)},
+	{0x212B, 0x212B, uint8(Ambiguous)},
+	{0x2153, 0x2154, uint8(Ambiguous)},
+	{0x215B, 0x215E, uint8(Ambiguous)},
+	{0x2160, 0x216B, uint8(Ambiguous)},
+	{0x2170, 0x2179, uint8(Ambiguous)},
+	{0x2189, 0x2189, uint8(Ambiguous)},
+	{0x2190, 0x2199, uint8(Ambiguous)},
+	{0x21B8, 0x21B9, uint8(Ambiguous)},
+	{0x21D2, 0x21D2, uint8(Ambiguous)},
+	{0x21D4, 0x21D4, uint8(Ambiguous)},
+	{0x21E7, 0x21E7, uint8(Ambiguous)},
+	{0x2200, 0x2200, uint8(Ambiguous)},
+	{0x2202, 0x2203, uint8(Ambiguous)},
+	{0x2207, 0x2208, uint8(Ambiguous)},
+	{0x220B, 0x220B, uint8(Ambiguous)},
+	{0x220F, 0x220F, uint8(Ambiguous)},
+	{0x2211, 0x2211, uint8(Ambiguous)},
+	{0x2215, 0x2215, uint8(Ambiguous)},
+	{0x221A, 0x221A, uint8(Ambiguous)},
+	{0x221D, 0x2220, uint8(Ambiguous)},
+	{0x2223, 0x2223, uint8(Ambiguous)},
+	{0x2225, 0x2225, uint8(Ambiguous)},
+	{0x2227, 0x222C, uint8(Ambiguous)},
+	{0x222E, 0x222E, uint8(Ambiguous)},
+	{0x2234, 0x2237, uint8(Ambiguous)},
+	{0x223C, 0x223D, uint8(Ambiguous)},
+	{0x2248, 0x2248, uint8(Ambiguous)},
+	{0x224C, 0x224C, uint8(Ambiguous)},
+	{0x2252, 0x2252, uint8(Ambiguous)},
+	{0x2260, 0x2261, uint8(Ambiguous)},
+	{0x2264, 0x2267, uint8(Ambiguous)},
+	{0x226A, 0x226B, uint8(Ambiguous)},
+	{0x226E, 0x226F, uint8(Ambiguous)},
+	{0x2282, 0x2283, uint8(Ambiguous)},
+	{0x2286, 0x2287, uint8(Ambiguous)},
+	{0x2295, 0x2295, uint8(Ambiguous)},
+	{0x2299, 0x2299, uint8(Ambiguous)},
+	{0x22A5, 0x22A5, uint8(Ambiguous)},
+	{0x22BF, 0x22BF, uint8(Ambiguous)},
+	{0x2312, 0x2312, uint8(Ambiguous)},
+	{0x231A, 0x231B, uint8(Wide)},
+	{0x2329, 0x232A, uint8(Wide)},
+	{0x23E9, 0x23EC, uint8(Wide)},
+	{0x23F0, 0x23F0, uint8(Wide)},
+	{0x23F3, 0x23F3, uint8(Wide)},
+	{0x2460, 0x24E9, uint8(Ambiguous)},
+	{0x24EB, 0x254B, uint8(Ambiguous)},
+	{0x2550, 0x2573, uint8(Ambiguous)},
+	{0x2580, 0x258F, uint8(Ambiguous)},
+	{0x2592, 0x2595, uint8(Ambiguous)},
+	{0x25A0, 0x25A1, uint8(Ambiguous)},
+	{0x25A3, 0x25A9, uint8(Ambiguous)},
+	{0x25B2, 0x25B3, uint8(Ambiguous)},
+	{0x25B6, 0x25B7, uint8(Ambiguous)},
+	{0x25BC, 0x25BD, uint8(Ambiguous)},
+	{0x25C0, 0x25C1, uint8(Ambiguous)},
+	{0x25C6, 0x25C8, uint8(Ambiguous)},
+	{0x25CB, 0x25CB, uint8(Ambiguous)},
+	{0x25CE, 0x25D1, uint8(Ambiguous)},
+	{0x25E2, 0x25E5, uint8(Ambiguous)},
+	{0x25EF, 0x25EF, uint8(Ambiguous)},
+	{0x25FD, 0x25FE, uint8(Wide)},
+	{0x2605, 0x2606, uint8(Ambiguous)},
+	{0x2609, 0x2609, uint8(Ambiguous)},
+	{0x260E, 0x260F, uint8(Ambiguous)},
+	{0x2614, 0x2615, uint8(Wide)},
+	{0x261C, 0x261C, uint8(Ambiguous)},
+	{0x261E, 0x261E, uint8(Ambiguous)},
+	{0x2640, 0x2640, uint8(Ambiguous)},
+	{0x2642, 0x2642, uint8(Ambiguous)},
+	{0x2648, 0x2653, uint8(Wide)},
+	{0x2660, 0x2661, uint8(Ambiguous)},
+	{0x2663, 0x2665, uint8(Ambiguous)},
+	{0x2667, 0x266A, uint8(Ambiguous)},
+	{0x266C, 0x266D, uint8(Ambiguous)},
+	{0x266F, 0x266F, uint8(Ambiguous)},
+	{0x267F, 0x267F, uint8(Wide)},
+	{0x2693, 0x2693, uint8(Wide)},
+	{0x269E, 0x269F, uint8(Ambiguous)},
+	{0x26A1, 0x26A1, uint8(Wide)},
+	{0x26AA, 0x26AB, uint8(Wide)},
+	{0x26BD, 0x26BE, uint8(Wide)},
+	{0x26BF, 0x26BF, uint8(Ambiguous)},
+	{0x26C4, 0x26C5, uint8(Wide)},
+	{0x26C6, 0x26CD, uint8(Ambiguous)},
+	{0x26CE, 0x26CE, uint8(Wide)},
+	{0x26CF, 0x26D3, uint8(Ambiguous)},
+	{0x26D4, 0x26D4, uint8(Wide)},
+	{0x26D5, 0x26E1, uint8(Ambiguous)},
+	{0x26E3, 0x26E3, uint8(Ambiguous)},
+	{0x26E8, 0x26E9, uint8(Ambiguous)},
+	{0x26EA, 0x26EA, uint8(Wide)},
+	{0x26EB, 0x26F1, uint8(Ambiguous)},
+	{0x26F2, 0x26F3, uint8(Wide)},
+	{0x26F4, 0x26F4, uint8(Ambiguous)},
+	{0x26F5, 0x26F5, uint8(Wide)},
+	{0x26F6, 0x26F9, uint8(Ambiguous)},
+	{0x26FA, 0x26FA, uint8(Wide)},
+	{0x26FB, 0x26FC, uint8(Ambiguous)},
+	{0x26FD, 0x26FD, uint8(Wide)},
+	{0x26FE, 0x26FF, uint8(Ambiguous)},
+	{0x2705, 0x2705, uint8(Wide)},
+	{0x270A, 0x270B, uint8(Wide)},
+	{0x2728, 0x2728, uint8(Wide)},
+	{0x273D, 0x273D, uint8(Ambiguous)},
+	{0x274C, 0x274C, uint8(Wide)},
+	{0x274E, 0x274E, uint8(Wide)},
+	{0x2753, 0x2755, uint8(Wide)},
+	{0x2757, 0x2757, uint8(Wide)},
+	{0x2776, 0x277F, uint8(Ambiguous)},
+	{0x2795, 0x2797, uint8(Wide)},
+	{0x27B0, 0x27B0, uint8(Wide)},
+	{0x27BF, 0x27BF, uint8(Wide)},
+	{0x27E6, 0x27ED, uint8(Narrow)},
+	{0x2985, 0x2986, uint8(Narrow)},
+	{0x2B1B, 0x2B1C, uint8(Wide)},
+	{0x2B50, 0x2B50, uint8(Wide)},
+	{0x2B55, 0x2B55, uint8(Wide)},
+	{0x2B56, 0x2B59, uint8(Ambiguous)},
+	{0x2E80, 0x2E99, uint8(Wide)},
+	{0x2E9B, 0x2EF3, uint8(Wide)},
+	{0x2F00, 0x2FD5, uint8(Wide)},
+	{0x2FF0, 0x2FFB, uint8(Wide)},
+	{0x3000, 0x3000, uint8(Fullwidth)},
+	{0x3001, 0x303E, uint8(Wide)},
+	{0x3041, 0x3096, uint8(Wide)},
+	{0x3099, 0x30FF, uint8(Wide)},
+	{0x3105, 0x312F, uint8(Wide)},
+	{0x3131, 0x318E, uint8(Wide)},
+	{0x3190, 0x31E3, uint8(Wide)},
+	{0x31F0, 0x321E, uint8(Wide)},
+	{0x3220, 0x3247, uint8(Wide)},
+	{0x3248, 0x324F, uint8(Ambiguous)},
+	{0x3250, 0x4DBF, uint8(Wide)},
+	{0x4E00, 0xA48C, uint8(Wide)},
+	{0xA490, 0xA4C6, uint8(Wide)},
+	{0xA960, 0xA97C, uint8(Wide)},
+	{0xAC00, 0xD7A3, uint8(Wide)},
+	{0xE000, 0xF8FF, uint8(Ambiguous)},
+	{0xF900, 0xFAFF, uint8(Wide)},
+	{0xFE00, 0xFE0F, uint8(Ambiguous)},
+	{0xFE10, 0xFE19, uint8(Wide)},
+	{0xFE30, 0xFE52, uint8(Wide)},
+	{0xFE54, 0xFE66, uint8(Wide)},
+	{0xFE68, 0xFE6B, uint8(Wide)},
+	{0xFF01, 0xFF60, uint8(Fullwidth)},
+	{0xFF61, 0xFFBE, uint8(Halfwidth)},
+	{0xFFC2, 0xFFC7, uint8(Halfwidth)},
+	{0xFFCA, 0xFFCF, uint8(Halfwidth)},
+	{0xFFD2, 0xFFD7, uint8(Halfwidth)},
+	{0xFFDA, 0xFFDC, uint8(Halfwidth)},
+	{0xFFE0, 0xFFE6, uint8(Fullwidth)},
+	{0xFFE8, 0xFFEE, uint8(Halfwidth)},
+	{0xFFFD, 0xFFFD, uint8(Ambiguous)},
+	{0x16FE0, 0x16FE4, uint8(Wide)},
+	{0x16FF0, 0x16FF1, uint8(Wide)},
+	{0x17000, 0x187F7, uint8(Wide)},
+	{0x18800, 0x18CD5, uint8(Wide)},
+	{0x18D00, 0x18D08, uint8(Wide)},
+	{0x1AFF0, 0x1AFF3, uint8(Wide)},
+	{0x1AFF5, 0x1AFFB, uint8(Wide)},
+	{0x1AFFD, 0x1AFFE, uint8(Wide)},
+	{0x1B000, 0x1B122, uint8(Wide)},
+	{0x1B150, 0x1B152, uint8(Wide)},
+	{0x1B164, 0x1B167, uint8(Wide)},
+	{0x1B170, 0x1B2FB, uint8(Wide)},
+	{0x1F004, 0x1F004, uint8(Wide)},
+	{0x1F0CF, 0x1F0CF, uint8(Wide)},
+	{0x1F100, 0x1F10A, uint8(Ambiguous)},
+	{0x1F110, 0x1F12D, uint8(Ambiguous)},
+	{0x1F130, 0x1F169, uint8(Ambiguous)},
+	{0x1F170, 0x1F18D, uint8(Ambiguous)},
+	{0x1F18E, 0x1F18E, uint8(Wide)},
+	{0x1F18F, 0x1F190, uint8(Ambiguous)},
+	{0x1F191, 0x1F19A, uint8(Wide)},
+	{0x1F19B, 0x1F1AC, uint8(Ambiguous)},
+	{0x1F200, 0x1F202, uint8(Wide)},
+	{0x1F210, 0x1F23B, uint8(Wide)},
+	{0x1F240, 0x1F248, uint8(Wide)},
+	{0x1F250, 0x1F251, uint8(Wide)},
+	{0x1F260, 0x1F265, uint8(Wide)},
+	{0x1F300, 0x1F320, uint8(Wide)},
+	{0x1F32D, 0x1F335, uint8(Wide)},
+	{0x1F337, 0x1F37C, uint8(Wide)},
+	{0x1F37E, 0x1F393, uint8(Wide)},
+	{0x1F3A0, 0x1F3CA, uint8(Wide)},
+	{0x1F3CF, 0x1F3D3, uint8(Wide)},
+	{0x1F3E0, 0x1F3F0, uint8(Wide)},
+	{0x1F3F4, 0x1F3F4, uint8(Wide)},
+	{0x1F3F8, 0x1F43E, uint8(Wide)},
+	{0x1F440, 0x1F440, uint8(Wide)},
+	{0x1F442, 0x1F4FC, uint8(Wide)},
+	{0x1F4FF, 0x1F53D, uint8(Wide)},
+	{0x1F54B, 0x1F54E, uint8(Wide)},
+	{0x1F550, 0x1F567, uint8(Wide)},
+	{0x1F57A, 0x1F57A, uint8(Wide)},
+	{0x1F595, 0x1F596, uint8(Wide)},
+	{0x1F5A4, 0x1F5A4, uint8(Wide)},
+	{0x1F5FB, 0x1F64F, uint8(Wide)},
+	{0x1F680, 0x1F6C5, uint8(Wide)},
+	{0x1F6CC, 0x1F6CC, uint8(Wide)},
+	{0x1F6D0, 0x1F6D2, uint8(Wide)},
+	{0x1F6D5, 0x1F6D7, uint8(Wide)},
+	{0x1F6DD, 0x1F6DF, uint8(Wide)},
+	{0x1F6EB, 0x1F6EC, uint8(Wide)},
+	{0x1F6F4, 0x1F6FC, uint8(Wide)},
+	{0x1F7E0, 0x1F7EB, uint8(Wide)},
+	{0x1F7F0, 0x1F7F0, uint8(Wide)},
+	{0x1F90C, 0x1F93A, uint8(Wide)},
+	{0x1F93C, 0x1F945, uint8(Wide)},
+	{0x1F947, 0x1F9FF, uint8(Wide)},
+	{0x1FA70, 0x1FA74, uint8(Wide)},
+	{0x1FA78, 0x1FA7C, uint8(Wide)},
+	{0x1FA80, 0x1FA86, uint8(Wide)},
+	{0x1FA90, 0x1FAAC, uint8(Wide)},
+	{0x1FAB0, 0x1FABA, uint8(Wide)},
+	{0x1FAC0, 0x1FAC5, uint8(Wide)},
+	{0x1FAD0, 0x1FAD9, uint8(Wide)},
+	{0x1FAE0, 0x1FAE7, uint8(Wide)},
+	{0x1FAF0, 0x1FAF6, uint8(Wide)},
+	{0x20000, 0x2FFFD, uint8(Wide)},
+	{0x30000, 0x3FFFD, uint8(Wide)},
+	{0xE0100, 0xE01EF, uint8(Ambiguous)},
+	{0xF0000, 0xFFFFD, uint8(Ambiguous)},
+	{0x100000, 0x10FFFD, uint8(Ambiguous)},
+}
+
+// lineBreakTable holds the code points with line break classes other than XX,
+// as ranges sorted by code point.
+var lineBreakTable = [...]propertyRange{
+	{0x0000, 0x0008, uint8(CM)},
+	{0x0009, 0x0009, uint8(BA)},
+	{0x000A, 0x000A, uint8(LF)},
+	{0x000B, 0x000C, uint8(BK)},
+	{0x000D, 0x000D, uint8(CR)},
+	{0x000E, 0x001F, uint8(CM)},
+	{0x0020, 0x0020, uint8(SP)},
+	{0x0021, 0x0021, uint8(EX)},
+	{0x0022, 0x0022, uint8(QU)},
+	{0x0023, 0x0023, uint8(AL)},
+	{0x0024, 0x0024, uint8(PR)},
+	{0x0025, 0x0025, uint8(PO)},
+	{0x0026, 0x0026, uint8(AL)},
+	{0x0027, 0x0027, uint8(QU)},
+	{0x0028, 0x0028, uint8(OP)},
+	{0x0029, 0x0029, uint8(CP)},
+	{0x002A, 0x002A, uint8(AL)},
+	{0x002B, 0x002B, uint8(PR)},
+	{0x002C, 0x002C, uint8(IS)},
+	{0x002D, 0x002D, uint8(HY)},
+	{0x002E, 0x002E, uint8(IS)},
+	{0x002F, 0x002F, uint8(SY)},
+	{0x0030, 0x0039, uint8(NU)},
+	{0x003A, 0x003B, uint8(IS)},
+	{0x003C, 0x003E, uint8(AL)},
+	{0x003F, 0x003F, uint8(EX)},
+	{0x0040, 0x005A, uint8(AL)},
+	{0x005B, 0x005B, uint8(OP)},
+	{0x005C, 0x005C, uint8(PR)},
+	{0x005D, 0x005D, uint8(CP)},
+	{0x005E, 0x007A, uint8(AL)},
+	{0x007B, 0x007B, uint8(OP)},
+	{0x007C, 0x007C, uint8(BA)},
+	{0x007D, 0x007D, uint8(CL)},
+	{0x007E, 0x007E, uint8(AL)},
+	{0x007F, 0x0084, uint8(CM)},
+	{0x0085, 0x0085, uint8(NL)},
+	{0x0086, 0x009F, uint8(CM)},
+	{0x00A0, 0x00A0, uint8(GL)},
+	{0x00A1, 0x00A1, uint8(OP)},
+	{0x00A2, 0x00A2, uint8(PO)},
+	{0x00A3, 0x00A5, uint8(PR)},
+	{0x00A6, 0x00A6, uint8(AL)},
+	{0x00A7, 0x00A8, uint8(AI)},
+	{0x00A9, 0x00A9, uint8(AL)},
+	{0x00AA, 0x00AA, uint8(AI)},
+	{0x00AB, 0x00AB, uint8(QU)},
+	{0x00AC, 0x00AC, uint8(AL)},
+	{0x00AD, 0x00AD, uint8(BA)},
+	{0x00AE, 0x00AF, uint8(AL)},
+	{0x00B0, 0x00B0, uint8(PO)},
+	{0x00B1, 0x00B1, uint8(PR)},
+	{0x00B2, 0x00B3, uint8(AI)},
+	{0x00B4, 0x00B4, uint8(BB)},
+	{0x00B5, 0x00B5, uint8(AL)},
+	{0x00B6, 0x00BA, uint8(AI)},
+	{0x00BB, 0x00BB, uint8(QU)},
+	{0x00BC, 0x00BE, uint8(AI)},
+	{0x00BF, 0x00BF, uint8(OP)},
+	{0x00C0, 0x00D6, uint8(AL)},
+	{0x00D7, 0x00D7, uint8(AI)},
+	{0x00D8, 0x00F6, uint8(AL)},
+	{0x00F7, 0x00F7, uint8(AI)},
+	{0x00F8, 0x02C6, uint8(AL)},
+	{0x02C7, 0x02C7, uint8(AI)},
+	{0x02C8, 0x02C8, uint8(BB)},
+	{0x02C9, 0x02CB, uint8(AI)},
+	{0x02CC, 0x02CC, uint8(BB)},
+	{0x02CD, 0x02CD, uint8(AI)},
+	{0x02CE, 0x02CF, uint8(AL)},
+	{0x02D0, 0x02D0, uint8(AI)},
+	{0x02D1, 0x02D7, uint8(AL)},
+	{0x02D8, 0x02DB, uint8(AI)},
+	{0x02DC, 0x02DC, uint8(AL)},
+	{0x02DD, 0x02DD, uint8(AI)},
+	{0x02DE, 0x02DE, uint8(AL)},
+	{0x02DF, 0x02DF, uint8(BB)},
+	{0x02E0, 0x02FF, uint8(AL)},
+	{0x0300, 0x034E, uint8(CM)},
+	{0x034F, 0x034F, uint8(GL)},
+	{0x0350, 0x035B, uint8(CM)},
+	{0x035C, 0x0362, uint8(GL)},
+	{0x0363, 0x036F, uint8(CM)},
+	{0x0370, 0x0377, uint8(AL)},
+	{0x037A, 0x037D, uint8(AL)},
+	{0x037E, 0x037E, uint8(IS)},
+	{0x037F, 0x037F, uint8(AL)},
+	{0x0384, 0x038A, uint8(AL)},
+	{0x038C, 0x038C, uint8(AL)},
+	{0x038E, 0x03A1, uint8(AL)},
+	{0x03A3, 0x0482, uint8(AL)},
+	{0x0483, 0x0489, uint8(CM)},
+	{0x048A, 0x052F, uint8(AL)},
+	{0x0531, 0x0556, uint8(AL)},
+	{0x0559, 0x0588, uint8(AL)},
+	{0x0589, 0x0589, uint8(IS)},
+	{0x058A, 0x058A, uint8(BA)},
+	{0x058D, 0x058E, uint8(AL)},
+	{0x058F, 0x058F, uint8(PR)},
+	{0x0591, 0x05BD, uint8(CM)},
+	{0x05BE, 0x05BE, uint8(BA)},
+	{0x05BF, 0x05BF, uint8(CM)},
+	{0x05C0, 0x05C0, uint8(AL)},
+	{0x05C1, 0x05C2, uint8(CM)},
+	{0x05C3, 0x05C3, uint8(AL)},
+	{0x05C4, 0x05C5, uint8(CM)},
+	{0x05C6, 0x05C6, uint8(EX)},
+	{0x05C7, 0x05C7, uint8(CM)},
+	{0x05D0, 0x05EA, uint8(HL)},
+	{0x05EF, 0x05F2, uint8(HL)},
+	{0x05F3, 0x05F4, uint8(AL)},
+	{0x0600, 0x0608, uint8(AL)},
+	{0x0609, 0x060B, uint8(PO)},
+	{0x060C, 0x060D, uint8(IS)},
+	{0x060E, 0x060F, uint8(AL)},
+	{0x0610, 0x061A, uint8(CM)},
+	{0x061B, 0x061B, uint8(EX)},
+	{0x061C, 0x061C, uint8(CM)},
+	{0x061D, 0x061F, uint8(EX)},
+	{0x0620, 0x064A, uint8(AL)},
+	{0x064B, 0x065F, uint8(CM)},
+	{0x0660, 0x0669, uint8(NU)},
+	{0x066A, 0x066A, uint8(PO)},
+	{0x066B, 0x066C, uint8(NU)},
+	{0x066D, 0x066F, uint8(AL)},
+	{0x0670, 0x0670, uint8(CM)},
+	{0x0671, 0x06D3, uint8(AL)},
+	{0x06D4, 0x06D4, uint8(EX)},
+	{0x06D5, 0x06D5, uint8(AL)},
+	{0x06D6, 0x06DC, uint8(CM)},
+	{0x06DD, 0x06DE, uint8(AL)},
+	{0x06DF, 0x06E4, uint8(CM)},
+	{0x06E5, 0x06E6, uint8(AL)},
+	{0x06E7, 0x06E8, uint8(CM)},
+	{0x06E9, 0x06E9, uint8(AL)},
+	{0x06EA, 0x06ED, uint8(CM)},
+	{0x06EE, 0x06EF, uint8(AL)},
+	{0x06F0, 0x06F9, uint8(NU)},
+	{0x06FA, 0x070D, uint8(AL)},
+	{0x070F, 0x0710, uint8(AL)},
+	{0x0711, 0x0711, uint8(CM)},
+	{0x0712, 0x072F, uint8(AL)},
+	{0x0730, 0x074A, uint8(CM)},
+	{0x074D, 0x07A5, uint8(AL)},
+	{0x07A6, 0x07B0, uint8(CM)},
+	{0x07B1, 0x07B1, uint8(AL)},
+	{0x07C0, 0x07C9, uint8(NU)},
+	{0x07CA, 0x07EA, uint8(AL)},
+	{0x07EB, 0x07F3, uint8(CM)},
+	{0x07F4, 0x07F7, uint8(AL)},
+	{0x07F8, 0x07F8, uint8(IS)},
+	{0x07F9, 0x07F9, uint8(EX)},
+	{0x07FA, 0x07FA, uint8(AL)},
+	{0x07FD, 0x07FD, uint8(CM)},
+	{0x07FE, 0x07FF, uint8(PR)},
+	{0x0800, 0x0815, uint8(AL)},
+	{0x0816, 0x0819, uint8(CM)},
+	{0x081A, 0x081A, uint8(AL)},
+	{0x081B, 0x0823, uint8(CM)},
+	{0x0824, 0x0824, uint8(AL)},
+	{0x0825, 0x0827, uint8(CM)},
+	{0x0828, 0x0828, uint8(AL)},
+	{0x0829, 0x082D, uint8(CM)},
+	{0x0830, 0x083E, uint8(AL)},
+	{0x0840, 0x0858, uint8(AL)},
+	{0x0859, 0x085B, uint8(CM)},
+	{0x085E, 0x085E, uint8(AL)},
+	{0x0860, 0x086A, uint8(AL)},
+	{0x0870, 0x088E, uint8(AL)},
+	{0x0890, 0x0891, uint8(AL)},
+	{0x0898, 0x089F, uint8(CM)},
+	{0x08A0, 0x08C9, uint8(AL)},
+	{0x08CA, 0x08E1, uint8(CM)},
+	{0x08E2, 0x08E2, uint8(AL)},
+	{0x08E3, 0x0903, uint8(CM)},
+	{0x0904, 0x0939, uint8(AL)},
+	{0x093A, 0x093C, uint8(CM)},
+	{0x093D, 0x093D, uint8(AL)},
+	{0x093E, 0x094F, uint8(CM)},
+	{0x0950, 0x0950, uint8(AL)},
+	{0x0951, 0x0957, uint8(CM)},
+	{0x0958, 0x0961, uint8(AL)},
+	{0x0962, 0x0963, uint8(CM)},
+	{0x0964, 0x0965, uint8(BA)},
+	{0x0966, 0x096F, uint8(NU)},
+	{0x0970, 0x0980, uint8(AL)},
+	{0x0981, 0x0983, uint8(CM)},
+	{0x0985, 0x098C, uint8(AL)},
+	{0x098F, 0x0990, uint8(AL)},
+	{0x0993, 0x09A8, uint8(AL)},
+	{0x09AA, 0x09B0, uint8(AL)},
+	{0x09B2, 0x09B2, uint8(AL)},
+	{0x09B6, 0x09B9, uint8(AL)},
+	{0x09BC, 0x09BC, uint8(CM)},
+	{0x09BD, 0x09BD, uint8(AL)},
+	{0x09BE, 0x09C4, uint8(CM)},
+	{0x09C7, 0x09C8, uint8(CM)},
+	{0x09CB, 0x09CD, uint8(CM)},
+	{0x09CE, 0x09CE, uint8(AL)},
+	{0x09D7, 0x09D7, uint8(CM)},
+	{0x09DC, 0x09DD, uint8(AL)},
+	{0x09DF, 0x09E1, uint8(AL)},
+	{0x09E2, 0x09E3, uint8(CM)},
+	{0x09E6, 0x09EF, uint8(NU)},
+	{0x09F0, 0x09F1, uint8(AL)},
+	{0x09F2, 0x09F3, uint8(PO)},
+	{0x09F4, 0x09F8, uint8(AL)},
+	{0x09F9, 0x09F9, uint8(PO)},
+	{0x09FA, 0x09FA, uint8(AL)},
+	{0x09FB, 0x09FB, uint8(PR)},
+	{0x09FC, 0x09FD, uint8(AL)},
+	{0x09FE, 0x09FE, uint8(CM)},
+	{0x0A01, 0x0A03, uint8(CM)},
+	{0x0A05, 0x0A0A, uint8(AL)},
+	{0x0A0F, 0x0A10, uint8(AL)},
+	{0x0A13, 0x0A28, uint8(AL)},
+	{0x0A2A, 0x0A30, uint8(AL)},
+	{0x0A32, 0x0A33, uint8(AL)},
+	{0x0A35, 0x0A36, uint8(AL)},
+	{0x0A38, 0x0A39, uint8(AL)},
+	{0x0A3C, 0x0A3C, uint8(CM)},
+	{0x0A3E, 0x0A42, uint8(CM)},
+	{0x0A47, 0x0A48, uint8(CM)},
+	{0x0A4B, 0x0A4D, uint8(CM)},
+	{0x0A51, 0x0A51, uint8(CM)},
+	{0x0A59, 0x0A5C, uint8(AL)},
+	{0x0A5E, 0x0A5E, uint8(AL)},
+	{0x0A66, 0x0A6F, uint8(NU)},
+	{0x0A70, 0x0A71, uint8(CM)},
+	{0x0A72, 0x0A74, uint8(AL)},
+	{0x0A75, 0x0A75, uint8(CM)},
+	{0x0A76, 0x0A76, uint8(AL)},
+	{0x0A81, 0x0A83, uint8(CM)},
+	{0x0A85, 0x0A8D, uint8(AL)},
+	{0x0A8F, 0x0A91, uint8(AL)},
+	{0x0A93, 0x0AA8, uint8(AL)},
+	{0x0AAA, 0x0AB0, uint8(AL)},
+	{0x0AB2, 0x0AB3, uint8(AL)},
+	{0x0AB5, 0x0AB9, uint8(AL)},
+	{0x0ABC, 0x0ABC, uint8(CM)},
+	{0x0ABD, 0x0ABD, uint8(AL)},
+	{0x0ABE, 0x0AC5, uint8(CM)},
+	{0x0AC7, 0x0AC9, uint8(CM)},
+	{0x0ACB, 0x0ACD, uint8(CM)},
+	{0x0AD0, 0x0AD0, uint8(AL)},
+	{0x0AE0, 0x0AE1, uint8(AL)},
+	{0x0AE2, 0x0AE3, uint8(CM)},
+	{0x0AE6, 0x0AEF, uint8(NU)},
+	{0x0AF0, 0x0AF0, uint8(AL)},
+	{0x0AF1, 0x0AF1, uint8(PR)},
+	{0x0AF9, 0x0AF9, uint8(AL)},
+	{0x0AFA, 0x0AFF, uint8(CM)},
+	{0x0B01, 0x0B03, uint8(CM)},
+	{0x0B05, 0x0B0C, uint8(AL)},
+	{0x0B0F, 0x0B10, uint8(AL)},
+	{0x0B13, 0x0B28, uint8(AL)},
+	{0x0B2A, 0x0B30, uint8(AL)},
+	{0x0B32, 0x0B33, uint8(AL)},
+	{0x0B35, 0x0B39, uint8(AL)},
+	{0x0B3C, 0x0B3C, uint8(CM)},
+	{0x0B3D, 0x0B3D, uint8(AL)},
+	{0x0B3E, 0x0B44, uint8(CM)},
+	{0x0B47, 0x0B48, uint8(CM)},
+	{0x0B4B, 0x0B4D, uint8(CM)},
+	{0x0B55, 0x0B57, uint8(CM)},
+	{0x0B5C, 0x0B5D, uint8(AL)},
+	{0x0B5F, 0x0B61, uint8(AL)},
+	{0x0B62, 0x0B63, uint8(CM)},
+	{0x0B66, 0x0B6F, uint8(NU)},
+	{0x0B70, 0x0B77, uint8(AL)},
+	{0x0B82, 0x0B82, uint8(CM)},
+	{0x0B83, 0x0B83, uint8(AL)},
+	{0x0B85, 0x0B8A, uint8(AL)},
+	{0x0B8E, 0x0B90, uint8(AL)},
+	{0x0B92, 0x0B95, uint8(AL)},
+	{0x0B99, 0x0B9A, uint8(AL)},
+	{0x0B9C, 0x0B9C, uint8(AL)},
+	{0x0B9E, 0x0B9F, uint8(AL)},
+	{0x0BA3, 0x0BA4, uint8(AL)},
+	{0x0BA8, 0x0BAA, uint8(AL)},
+	{0x0BAE, 0x0BB9, uint8(AL)},
+	{0x0BBE, 0x0BC2, uint8(CM)},
+	{0x0BC6, 0x0BC8, uint8(CM)},
+	{0x0BCA, 0x0BCD, uint8(CM)},
+	{0x0BD0, 0x0BD0, uint8(AL)},
+	{0x0BD7, 0x0BD7, uint8(CM)},
+	{0x0BE6, 0x0BEF, uint8(NU)},
+	{0x0BF0, 0x0BF8, uint8(AL)},
+	{0x0BF9, 0x0BF9, uint8(PR)},
+	{0x0BFA, 0x0BFA, uint8(AL)},
+	{0x0C00, 0x0C04, uint8(CM)},
+	{0x0C05, 0x0C0C, uint8(AL)},
+	{0x0C0E, 0x0C10, uint8(AL)},
+	{0x0C12, 0x0C28, uint8(AL)},
+	{0x0C2A, 0x0C39, uint8(AL)},
+	{0x0C3C, 0x0C3C, uint8(CM)},
+	{0x0C3D, 0x0C3D, uint8(AL)},
+	{0x0C3E, 0x0C44, uint8(CM)},
+	{0x0C46, 0x0C48, uint8(CM)},
+	{0x0C4A, 0x0C4D, uint8(CM)},
+	{0x0C55, 0x0C56, uint8(CM)},
+	{0x0C58, 0x0C5A, uint8(AL)},
+	{0x0C5D, 0x0C5D, uint8(AL)},
+	{0x0C60, 0x0C61, uint8(AL)},
+	{0x0C62, 0x0C63, uint8(CM)},
+	{0x0C66, 0x0C6F, uint8(NU)},
+	{0x0C77, 0x0C77, uint8(BB)},
+	{0x0C78, 0x0C80, uint8(AL)},
+	{0x0C81, 0x0C83, uint8(CM)},
+	{0x0C84, 0x0C84, uint8(BB)},
+	{0x0C85, 0x0C8C, uint8(AL)},
+	{0x0C8E, 0x0C90, uint8(AL)},
+	{0x0C92, 0x0CA8, uint8(AL)},
+	{0x0CAA, 0x0CB3, uint8(AL)},
+	{0x0CB5, 0x0CB9, uint8(AL)},
+	{0x0CBC, 0x0CBC, uint8(CM)},
+	{0x0CBD, 0x0CBD, uint8(AL)},
+	{0x0CBE, 0x0CC4, uint8(CM)},
+	{0x0CC6, 0x0CC8, uint8(CM)},
+	{0x0CCA, 0x0CCD, uint8(CM)},
+	{0x0CD5, 0x0CD6, uint8(CM)},
+	{0x0CDD, 0x0CDE, uint8(AL)},
+	{0x0CE0, 0x0CE1, uint8(AL)},
+	{0x0CE2, 0x0CE3, uint8(CM)},
+	{0x0CE6, 0x0CEF, uint8(NU)},
+	{0x0CF1, 0x0CF2, uint8(AL)},
+	{0x0D00, 0x0D03, uint8(CM)},
+	{0x0D04, 0x0D0C, uint8(AL)},
+	{0x0D0E, 0x0D10, uint8(AL)},
+	{0x0D12, 0x0D3A, uint8(AL)},
+	{0x0D3B, 0x0D3C, uint8(CM)},
+	{0x0D3D, 0x0D3D, uint8(AL)},
+	{0x0D3E, 0x0D44, uint8(CM)},
+	{0x0D46, 0x0D48, uint8(CM)},
+	{0x0D4A, 0x0D4D, uint8(CM)},
+	{0x0D4E, 0x0D4F, uint8(AL)},
+	{0x0D54, 0x0D56, uint8(AL)},
+	{0x0D57, 0x0D57, uint8(CM)},
+	{0x0D58, 0x0D61, uint8(AL)},
+	{0x0D62, 0x0D63, uint8(CM)},
+	{0x0D66, 0x0D6F, uint8(NU)},
+	{0x0D70, 0x0D78, uint8(AL)},
+	{0x0D79, 0x0D79, uint8(PO)},
+	{0x0D7A, 0x0D7F, uint8(AL)},
+	{0x0D81, 0x0D83, uint8(CM)},
+	{0x0D85, 0x0D96, uint8(AL)},
+	{0x0D9A, 0x0DB1, uint8(AL)},
+	{0x0DB3, 0x0DBB, uint8(AL)},
+	{0x0DBD, 0x0DBD, uint8(AL)},
+	{0x0DC0, 0x0DC6, uint8(AL)},
+	{0x0DCA, 0x0DCA, uint8(CM)},
+	{0x0DCF, 0x0DD4, uint8(CM)},
+	{0x0DD6, 0x0DD6, uint8(CM)},
+	{0x0DD8, 0x0DDF, uint8(CM)},
+	{0x0DE6, 0x0DEF, uint8(NU)},
+	{0x0DF2, 0x0DF3, uint8(CM)},
+	{0x0DF4, 0x0DF4, uint8(AL)},
+	{0x0E01, 0x0E3A, uint8(SA)},
+	{0x0E3F, 0x0E3F, uint8(PR)},
+	{0x0E40, 0x0E4E, uint8(SA)},
+	{0x0E4F, 0x0E4F, uint8(AL)},
+	{0x0E50, 0x0E59, uint8(NU)},
+	{0x0E5A, 0x0E5B, uint8(BA)},
+	{0x0E81, 0x0E82, uint8(SA)},
+	{0x0E84, 0x0E84, uint8(SA)},
+	{0x0E86, 0x0E8A, uint8(SA)},
+	{0x0E8C, 0x0EA3, uint8(SA)},
+	{0x0EA5, 0x0EA5, uint8(SA)},
+	{0x0EA7, 0x0EBD, uint8(SA)},
+	{0x0EC0, 0x0EC4, uint8(SA)},
+	{0x0EC6, 0x0EC6, uint8(SA)},
+	{0x0EC8, 0x0ECD, uint8(SA)},
+	{0x0ED0, 0x0ED9, uint8(NU)},
+	{0x0EDC, 0x0EDF, uint8(SA)},
+	{0x0F00, 0x0F00, uint8(AL)},
+	{0x0F01, 0x0F04, uint8(BB)},
+	{0x0F05, 0x0F05, uint8(AL)},
+	{0x0F06, 0x0F07, uint8(BB)},
+	{0x0F08, 0x0F08, uint8(GL)},
+	{0x0F09, 0x0F0A, uint8(BB)},
+	{0x0F0B, 0x0F0B, uint8(BA)},
+	{0x0F0C, 0x0F0C, uint8(GL)},
+	{0x0F0D, 0x0F11, uint8(EX)},
+	{0x0F12, 0x0F12, uint8(GL)},
+	{0x0F13, 0x0F13, uint8(AL)},
+	{0x0F14, 0x0F14, uint8(EX)},
+	{0x0F15, 0x0F17, uint8(AL)},
+	{0x0F18, 0x0F19, uint8(CM)},
+	{0x0F1A, 0x0F1F, uint8(AL)},
+	{0x0F20, 0x0F29, uint8(NU)},
+	{0x0F2A, 0x0F33, uint8(AL)},
+	{0x0F34, 0x0F34, uint8(BA)},
+	{0x0F35, 0x0F35, uint8(CM)},
+	{0x0F36, 0x0F36, uint8(AL)},
+	{0x0F37, 0x0F37, uint8(CM)},
+	{0x0F38, 0x0F38, uint8(AL)},
+	{0x0F39, 0x0F39, uint8(CM)},
+	{0x0F3A, 0x0F3A, uint8(OP)},
+	{0x0F3B, 0x0F3B, uint8(CL)},
+	{0x0F3C, 0x0F3C, uint8(OP)},
+	{0x0F3D, 0x0F3D, uint8(CL)},
+	{0x0F3E, 0x0F3F, uint8(CM)},
+	{0x0F40, 0x0F47, uint8(AL)},
+	{0x0F49, 0x0F6C, uint8(AL)},
+	{0x0F71, 0x0F7E, uint8(CM)},
+	{0x0F7F, 0x0F7F, uint8(BA)},
+	{0x0F80, 0x0F84, uint8(CM)},
+	{0x0F85, 0x0F85, uint8(BA)},
+	{0x0F86, 0x0F87, uint8(CM)},
+	{0x0F88, 0x0F8C, uint8(AL)},
+	{0x0F8D, 0x0F97, uint8(CM)},
+	{0x0F99, 0x0FBC, uint8(CM)},
+	{0x0FBE, 0x0FBF, uint8(BA)},
+	{0x0FC0, 0x0FC5, uint8(AL)},
+	{0x0FC6, 0x0FC6, uint8(CM)},
+	{0x0FC7, 0x0FCC, uint8(AL)},
+	{0x0FCE, 0x0FCF, uint8(AL)},
+	{0x0FD0, 0x0FD1, uint8(BB)},
+	{0x0FD2, 0x0FD2, uint8(BA)},
+	{0x0FD3, 0x0FD3, uint8(BB)},
+	{0x0FD4, 0x0FD8, uint8(AL)},
+	{0x0FD9, 0x0FDA, uint8(GL)},
+	{0x1000, 0x103F, uint8(SA)},
+	{0x1040, 0x1049, uint8(NU)},
+	{0x104A, 0x104B, uint8(BA)},
+	{0x104C, 0x104F, uint8(AL)},
+	{0x1050, 0x108F, uint8(SA)},
+	{0x1090, 0x1099, uint8(NU)},
+	{0x109A, 0x109F, uint8(SA)},
+	{0x10A0, 0x10C5, uint8(AL)},
+	{0x10C7, 0x10C7, uint8(AL)},
+	{0x10CD, 0x10CD, uint8(AL)},
+	{0x10D0, 0x10FF, uint8(AL)},
+	{0x1100, 0x115F, uint8(JL)},
+	{0x1160, 0x11A7, uint8(JV)},
+	{0x11A8, 0x11FF, uint8(JT)},
+	{0x1200, 0x1248, uint8(AL)},
+	{0x124A, 0x124D, uint8(AL)},
+	{0x1250, 0x1256, uint8(AL)},
+	{0x1258, 0x1258, uint8(AL)},
+	{0x125A, 0x125D, uint8(AL)},
+	{0x1260, 0x1288, uint8(AL)},
+	{0x128A, 0x128D, uint8(AL)},
+	{0x1290, 0x12B0, uint8(AL)},
+	{0x12B2, 0x12B5, uint8(AL)},
+	{0x12B8, 0x12BE, uint8(AL)},
+	{0x12C0, 0x12C0, uint8(AL)},
+	{0x12C2, 0x12C5, uint8(AL)},
+	{0x12C8, 0x12D6, uint8(AL)},
+	{0x12D8, 0x1310, uint8(AL)},
+	{0x1312, 0x1315, uint8(AL)},
+	{0x1318, 0x135A, uint8(AL)},
+	{0x135D, 0x135F, uint8(CM)},
+	{0x1360, 0x1360, uint8(AL)},
+	{0x1361, 0x1361, uint8(BA)},
+	{0x1362, 0x137C, uint8(AL)},
+	{0x1380, 0x1399, uint8(AL)},
+	{0x13A0, 0x13F5, uint8(AL)},
+	{0x13F8, 0x13FD, uint8(AL)},
+	{0x1400, 0x1400, uint8(BA)},
+	{0x1401, 0x167F, uint8(AL)},
+	{0x1680, 0x1680, uint8(BA)},
+	{0x1681, 0x169A, uint8(AL)},
+	{0x169B, 0x169B, uint8(OP)},
+	{0x169C, 0x169C, uint8(CL)},
+	{0x16A0, 0x16EA, uint8(AL)},
+	{0x16EB, 0x16ED, uint8(BA)},
+	{0x16EE, 0x16F8, uint8(AL)},
+	{0x1700, 0x1711, uint8(AL)},
+	{0x1712, 0x1715, uint8(CM)},
+	{0x171F, 0x1731, uint8(AL)},
+	{0x1732, 0x1734, uint8(CM)},
+	{0x1735, 0x1736, uint8(BA)},
+	{0x1740, 0x1751, uint8(AL)},
+	{0x1752, 0x1753, uint8(CM)},
+	{0x1760, 0x176C, uint8(AL)},
+	{0x176E, 0x1770, uint8(AL)},
+	{0x1772, 0x1773, uint8(CM)},
+	{0x1780, 0x17D3, uint8(SA)},
+	{0x17D4, 0x17D5, uint8(BA)},
+	{0x17D6, 0x17D6, uint8(NS)},
+	{0x17D7, 0x17D7, uint8(SA)},
+	{0x17D8, 0x17D8, uint8(BA)},
+	{0x17D9, 0x17D9, uint8(AL)},
+	{0x17DA, 0x17DA, uint8(BA)},
+	{0x17DB, 0x17DB, uint8(PR)},
+	{0x17DC, 0x17DD, uint8(SA)},
+	{0x17E0, 0x17E9, uint8(NU)},
+	{0x17F0, 0x17F9, uint8(AL)},
+	{0x1800, 0x1801, uint8(AL)},
+	{0x1802, 0x1803, uint8(EX)},
+	{0x1804, 0x1805, uint8(BA)},
+	{0x1806, 0x1806, uint8(BB)},
+	{0x1807, 0x1807, uint8(AL)},
+	{0x1808, 0x1809, uint8(EX)},
+	{0x180A, 0x180A, uint8(AL)},
+	{0x180B, 0x180D, uint8(CM)},
+	{0x180E, 0x180E, uint8(GL)},
+	{0x180F, 0x180F, uint8(CM)},
+	{0x1810, 0x1819, uint8(NU)},
+	{0x1820, 0x1878, uint8(AL)},
+	{0x1880, 0x1884, uint8(AL)},
+	{0x1885, 0x1886, uint8(CM)},
+	{0x1887, 0x18A8, uint8(AL)},
+	{0x18A9, 0x18A9, uint8(CM)},
+	{0x18AA, 0x18AA, uint8(AL)},
+	{0x18B0, 0x18F5, uint8(AL)},
+	{0x1900, 0x191E, uint8(AL)},
+	{0x1920, 0x192B, uint8(CM)},
+	{0x1930, 0x193B, uint8(CM)},
+	{0x1940, 0x1940, uint8(AL)},
+	{0x1944, 0x1945, uint8(EX)},
+	{0x1946, 0x194F, uint8(NU)},
+	{0x1950, 0x196D, uint8(SA)},
+	{0x1970, 0x1974, uint8(SA)},
+	{0x1980, 0x19AB, uint8(SA)},
+	{0x19B0, 0x19C9, uint8(SA)},
+	{0x19D0, 0x19D9, uint8(NU)},
+	{0x19DA, 0x19DA, uint8(SA)},
+	{0x19DE, 0x19DF, uint8(SA)},
+	{0x19E0, 0x1A16, uint8(AL)},
+	{0x1A17, 0x1A1B, uint8(CM)},
+	{0x1A1E, 0x1A1F, uint8(AL)},
+	{0x1A20, 0x1A5E, uint8(SA)},
+	{0x1A60, 0x1A7C, uint8(SA)},
+	{0x1A7F, 0x1A7F, uint8(CM)},
+	{0x1A80, 0x1A89, uint8(NU)},
+	{0x1A90, 0x1A99, uint8(NU)},
+	{0x1AA0, 0x1AAD, uint8(SA)},
+	{0x1AB0, 0x1ACE, uint8(CM)},
+	{0x1B00, 0x1B04, uint8(CM)},
+	{0x1B05, 0x1B33, uint8(AL)},
+	{0x1B34, 0x1B44, uint8(CM)},
+	{0x1B45, 0x1B4C, uint8(AL)},
+	{0x1B50, 0x1B59, uint8(NU)},
+	{0x1B5A, 0x1B5B, uint8(BA)},
+	{0x1B5C, 0x1B5C, uint8(AL)},
+	{0x1B5D, 0x1B60, uint8(BA)},
+	{0x1B61, 0x1B6A, uint8(AL)},
+	{0x1B6B, 0x1B73, uint8(CM)},
+	{0x1B74, 0x1B7C, uint8(AL)},
+	{0x1B7D, 0x1B7E, uint8(BA)},
+	{0x1B80, 0x1B82, uint8(CM)},
+	{0x1B83, 0x1BA0, uint8(AL)},
+	{0x1BA1, 0x1BAD, uint8(CM)},
+	{0x1BAE, 0x1BAF, uint8(AL)},
+	{0x1BB0, 0x1BB9, uint8(NU)},
+	{0x1BBA, 0x1BE5, uint8(AL)},
+	{0x1BE6, 0x1BF3, uint8(CM)},
+	{0x1BFC, 0x1C23, uint8(AL)},
+	{0x1C24, 0x1C37, uint8(CM)},
+	{0x1C3B, 0x1C3F, uint8(BA)},
+	{0x1C40, 0x1C49, uint8(NU)},
+	{0x1C4D, 0x1C4F, uint8(AL)},
+	{0x1C50, 0x1C59, uint8(NU)},
+	{0x1C5A, 0x1C7D, uint8(AL)},
+	{0x1C7E, 0x1C7F, uint8(BA)},
+	{0x1C80, 0x1C88, uint8(AL)},
+	{0x1C90, 0x1CBA, uint8(AL)},
+	{0x1CBD, 0x1CC7, uint8(AL)},
+	{0x1CD0, 0x1CD2, uint8(CM)},
+	{0x1CD3, 0x1CD3, uint8(AL)},
+	{0x1CD4, 0x1CE8, uint8(CM)},
+	{0x1CE9, 0x1CEC, uint8(AL)},
+	{0x1CED, 0x1CED, uint8(CM)},
+	{0x1CEE, 0x1CF3, uint8(AL)},
+	{0x1CF4, 0x1CF4, uint8(CM)},
+	{0x1CF5, 0x1CF6, uint8(AL)},
+	{0x1CF7, 0x1CF9, uint8(CM)},
+	{0x1CFA, 0x1CFA, uint8(AL)},
+	{0x1D00, 0x1DBF, uint8(AL)},
+	{0x1DC0, 0x1DFF, uint8(CM)},
+	{0x1E00, 0x1F15, uint8(AL)},
+	{0x1F18, 0x1F1D, uint8(AL)},
+	{0x1F20, 0x1F45, uint8(AL)},
+	{0x1F48, 0x1F4D, uint8(AL)},
+	{0x1F50, 0x1F57, uint8(AL)},
+	{0x1F59, 0x1F59, uint8(AL)},
+	{0x1F5B, 0x1F5B, uint8(AL)},
+	{0x1F5D, 0x1F5D, uint8(AL)},
+	{0x1F5F, 0x1F7D, uint8(AL)},
+	{0x1F80, 0x1FB4, uint8(AL)},
+	{0x1FB6, 0x1FC4, uint8(AL)},
+	{0x1FC6, 0x1FD3, uint8(AL)},
+	{0x1FD6, 0x1FDB, uint8(AL)},
+	{0x1FDD, 0x1FEF, uint8(AL)},
+	{0x1FF2, 0x1FF4, uint8(AL)},
+	{0x1FF6, 0x1FFC, uint8(AL)},
+	{0x1FFD, 0x1FFD, uint8(BB)},
+	{0x1FFE, 0x1FFE, uint8(AL)},
+	{0x2000, 0x2006, uint8(BA)},
+	{0x2007, 0x2007, uint8(GL)},
+	{0x2008, 0x200A, uint8(BA)},
+	{0x200B, 0x200B, uint8(ZW)},
+	{0x200C, 0x200C, uint8(CM)},
+	{0x200D, 0x200D, uint8(ZWJ)},
+	{0x200E, 0x200F, uint8(CM)},
+	{0x2010, 0x2010, uint8(BA)},
+	{0x2011, 0x2011, uint8(GL)},
+	{0x2012, 0x2013, uint8(BA)},
+	{0x2014, 0x2014, uint8(B2)},
+	{0x2015, 0x2016, uint8(AI)},
+	{0x2017, 0x2017, uint8(AL)},
+	{0x2018, 0x2019, uint8(QU)},
+	{0x201A, 0x201A, uint8(OP)},
+	{0x201B, 0x201D, uint8(QU)},
+	{0x201E, 0x201E, uint8(OP)},
+	{0x201F, 0x201F, uint8(QU)},
+	{0x2020, 0x2021, uint8(AI)},
+	{0x2022, 0x2023, uint8(AL)},
+	{0x2024, 0x2026, uint8(IN)},
+	{0x2027, 0x2027, uint8(BA)},
+	{0x2028, 0x2029, uint8(BK)},
+	{0x202A, 0x202E, uint8(CM)},
+	{0x202F, 0x202F, uint8(GL)},
+	{0x2030, 0x2037, uint8(PO)},
+	{0x2038, 0x2038, uint8(AL)},
+	{0x2039, 0x203A, uint8(QU)},
+	{0x203B, 0x203B, uint8(AI)},
+	{0x203C, 0x203D, uint8(NS)},
+	{0x203E, 0x2043, uint8(AL)},
+	{0x2044, 0x2044, uint8(IS)},
+	{0x2045, 0x2045, uint8(OP)},
+	{0x2046, 0x2046, uint8(CL)},
+	{0x2047, 0x2049, uint8(NS)},
+	{0x204A, 0x2055, uint8(AL)},
+	{0x2056, 0x2056, uint8(BA)},
+	{0x2057, 0x2057, uint8(AL)},
+	{0x2058, 0x205B, uint8(BA)},
+	{0x205C, 0x205C, uint8(AL)},
+	{0x205D, 0x205F, uint8(BA)},
+	{0x2060, 0x2060, uint8(WJ)},
+	{0x2061, 0x2064, uint8(AL)},
+	{0x2066, 0x206F, uint8(CM)},
+	{0x2070, 0x2071, uint8(AL)},
+	{0x2074, 0x2074, uint8(AI)},
+	{0x2075, 0x207C, uint8(AL)},
+	{0x207D, 0x207D, uint8(OP)},
+	{0x207E, 0x207E, uint8(CL)},
+	{0x207F, 0x207F, uint8(AI)},
+	{0x2080, 0x2080, uint8(AL)},
+	{0x2081, 0x2084, uint8(AI)},
+	{0x2085, 0x208C, uint8(AL)},
+	{0x208D, 0x208D, uint8(OP)},
+	{0x208E, 0x208E, uint8(CL)},
+	{0x2090, 0x209C, uint8(AL)},
+	{0x20A0, 0x20A6, uint8(PR)},
+	{0x20A7, 0x20A7, uint8(PO)},
+	{0x20A8, 0x20B5, uint8(PR)},
+	{0x20B6, 0x20B6, uint8(PO)},
+	{0x20B7, 0x20BA, uint8(PR)},
+	{0x20BB, 0x20BB, uint8(PO)},
+	{0x20BC, 0x20BD, uint8(PR)},
+	{0x20BE, 0x20BE, uint8(PO)},
+	{0x20BF, 0x20BF, uint8(PR)},
+	{0x20C0, 0x20C0, uint8(PO)},
+	{0x20C1, 0x20CF, uint8(PR)},
+	{0x20D0, 0x20F0, uint8(CM)},
+	{0x2100, 0x2102, uint8(AL)},
+	{0x2103, 0x2103, uint8(PO)},
+	{0x2104, 0x2104, uint8(AL)},
+	{0x2105, 0x2105, uint8(AI)},
+	{0x2106, 0x2108, uint8(AL)},
+	{0x2109, 0x2109, uint8(PO)},
+	{0x210A, 0x2112, uint8(AL)},
+	{0x2113, 0x2113, uint8(AI)},
+	{0x2114, 0x2115, uint8(AL)},
+	{0x2116, 0x2116, uint8(PR)},
+	{0x2117, 0x2120, uint8(AL)},
+	{0x2121, 0x2122, uint8(AI)},
+	{0x2123, 0x212A, uint8(AL)},
+	{0x212B, 0x212B, uint8(AI)},
+	{0x212C, 0x2153, uint8(AL)},
+	{0x2154, 0x2155, uint8(AI)},
+	{0x2156, 0x215A, uint8(AL)},
+	{0x215B, 0x215B, uint8(AI)},
+	{0x215C, 0x215D, uint8(AL)},
+	{0x215E, 0x215E, uint8(AI)},
+	{0x215F, 0x215F, uint8(AL)},
+	{0x2160, 0x216B, uint8(AI)},
+	{0x216C, 0x216F, uint8(AL)},
+	{0x2170, 0x2179, uint8(AI)},
+	{0x217A, 0x2188, uint8(AL)},
+	{0x2189, 0x2189, uint8(AI)},
+	{0x218A, 0x218B, uint8(AL)},
+	{0x2190, 0x2199, uint8(AI)},
+	{0x219A, 0x21D1, uint8(AL)},
+	{0x21D2, 0x21D2, uint8(AI)},
+	{0x21D3, 0x21D3, uint8(AL)},
+	{0x21D4, 0x21D4, uint8(AI)},
+	{0x21D5, 0x21FF, uint8(AL)},
+	{0x2200, 0x2200, uint8(AI)},
+	{0x2201, 0x2201, uint8(AL)},
+	{0x2202, 0x2203, uint8(AI)},
+	{0x2204, 0x2206, uint8(AL)},
+	{0x2207, 0x2208, uint8(AI)},
+	{0x2209, 0x220A, uint8(AL)},
+	{0x220B, 0x220B, uint8(AI)},
+	{0x220C, 0x220E, uint8(AL)},
+	{0x220F, 0x220F, uint8(AI)},
+	{0x2210, 0x2210, uint8(AL)},
+	{0x2211, 0x2211, uint8(AI)},
+	{0x2212, 0x2213, uint8(PR)},
+	{0x2214, 0x2214, uint8(AL)},
+	{0x2215, 0x2215, uint8(AI)},
+	{0x2216, 0x2219, uint8(AL)},
+	{0x221A, 0x221A, uint8(AI)},
+	{0x221B, 0x221C, uint8(AL)},
+	{0x221D, 0x2220, uint8(AI)},
+	{0x2221, 0x2222, uint8(AL)},
+	{0x2223, 0x2223, uint8(AI)},
+	{0x2224, 0x2224, uint8(AL)},
+	{0x2225, 0x2225, uint8(AI)},
+	{0x2226, 0x2226, uint8(AL)},
+	{0x2227, 0x222C, uint8(AI)},
+	{0x222D, 0x222D, uint8(AL)},
+	{0x222E, 0x222E, uint8(AI)},
+	{0x222F, 0x2233, uint8(AL)},
+	{0x2234, 0x2237, uint8(AI)},
+	{0x2238, 0x223B, uint8(AL)},
+	{0x223C, 0x223D, uint8(AI)},
+	{0x223E, 0x2247, uint8(AL)},
+	{0x2248, 0x2248, uint8(AI)},
+	{0x2249, 0x224B, uint8(AL)},
+	{0x224C, 0x224C, uint8(AI)},
+	{0x224D, 0x2251, uint8(AL)},
+	{0x2252, 0x2252, uint8(AI)},
+	{0x2253, 0x225F, uint8(AL)},
+	{0x2260, 0x2261, uint8(AI)},
+	{0x2262, 0x2263, uint8(AL)},
+	{0x2264, 0x2267, uint8(AI)},
+	{0x2268, 0x2269, uint8(AL)},
+	{0x226A, 0x226B, uint8(AI)},
+	{0x226C, 0x226D, uint8(AL)},
+	{0x226E, 0x226F, uint8(AI)},
+	{0x2270, 0x2281, uint8(AL)},
+	{0x2282, 0x2283, uint8(AI)},
+	{0x2284, 0x2285, uint8(AL)},
+	{0x2286, 0x2287, uint8(AI)},
+	{0x2288, 0x2294, uint8(AL)},
+	{0x2295, 0x2295, uint8(AI)},
+	{0x2296, 0x2298, uint8(AL)},
+	{0x2299, 0x2299, uint8(AI)},
+	{0x229A, 0x22A4, uint8(AL)},
+	{0x22A5, 0x22A5, uint8(AI)},
+	{0x22A6, 0x22BE, uint8(AL)},
+	{0x22BF, 0x22BF, uint8(AI)},
+	{0x22C0, 0x22EE, uint8(AL)},
+	{0x22EF, 0x22EF, uint8(IN)},
+	{0x22F0, 0x2307, uint8(AL)},
+	{0x2308, 0x2308, uint8(OP)},
+	{0x2309, 0x2309, uint8(CL)},
+	{0x230A, 0x230A, uint8(OP)},
+	{0x230B, 0x230B, uint8(CL)},
+	{0x230C, 0x2311, uint8(AL)},
+	{0x2312, 0x2312, uint8(AI)},
+	{0x2313, 0x2319, uint8(AL)},
+	{0x231A, 0x231B, uint8(ID)},
+	{0x231C, 0x2328, uint8(AL)},
+	{0x2329, 0x2329, uint8(OP)},
+	{0x232A, 0x232A, uint8(CL)},
+	{0x232B, 0x23EF, uint8(AL)},
+	{0x23F0, 0x23F3, uint8(ID)},
+	{0x23F4, 0x2426, uint8(AL)},
+	{0x2440, 0x244A, uint8(AL)},
+	{0x2460, 0x24FE, uint8(AI)},
+	{0x24FF, 0x24FF, uint8(AL)},
+	{0x2500, 0x254B, uint8(AI)},
+	{0x254C, 0x254F, uint8(AL)},
+	{0x2550, 0x2574, uint8(AI)},
+	{0x2575, 0x257F, uint8(AL)},
+	{0x2580, 0x258F, uint8(AI)},
+	{0x2590, 0x2591, uint8(AL)},
+	{0x2592, 0x2595, uint8(AI)},
+	{0x2596, 0x259F, uint8(AL)},
+	{0x25A0, 0x25A1, uint8(AI)},
+	{0x25A2, 0x25A2, uint8(AL)},
+	{0x25A3, 0x25A9, uint8(AI)},
+	{0x25AA, 0x25B1, uint8(AL)},
+	{0x25B2, 0x25B3, uint8(AI)},
+	{0x25B4, 0x25B5, uint8(AL)},
+	{0x25B6, 0x25B7, uint8(AI)},
+	{0x25B8, 0x25BB, uint8(AL)},
+	{0x25BC, 0x25BD, uint8(AI)},
+	{0x25BE, 0x25BF, uint8(AL)},
+	{0x25C0, 0x25C1, uint8(AI)},
+	{0x25C2, 0x25C5, uint8(AL)},
+	{0x25C6, 0x25C8, uint8(AI)},
+	{0x25C9, 0x25CA, uint8(AL)},
+	{0x25CB, 0x25CB, uint8(AI)},
+	{0x25CC, 0x25CD, uint8(AL)},
+	{0x25CE, 0x25D1, uint8(AI)},
+	{0x25D2, 0x25E1, uint8(AL)},
+	{0x25E2, 0x25E5, uint8(AI)},
+	{0x25E6, 0x25EE, uint8(AL)},
+	{0x25EF, 0x25EF, uint8(AI)},
+	{0x25F0, 0x25FF, uint8(AL)},
+	{0x2600, 0x2603, uint8(ID)},
+	{0x2604, 0x2604, uint8(AL)},
+	{0x2605, 0x2606, uint8(AI)},
+	{0x2607, 0x2608, uint8(AL)},
+	{0x2609, 0x2609, uint8(AI)},
+	{0x260A, 0x260D, uint8(AL)},
+	{0x260E, 0x260F, uint8(AI)},
+	{0x2610, 0x2613, uint8(AL)},
+	{0x2614, 0x2615, uint8(ID)},
+	{0x2616, 0x2617, uint8(AI)},
+	{0x2618, 0x2618, uint8(ID)},
+	{0x2619, 0x2619, uint8(AL)},
+	{0x261A, 0x261C, uint8(ID)},
+	{0x261D, 0x261D, uint8(EB)},
+	{0x261E, 0x261F, uint8(ID)},
+	{0x2620, 0x2638, uint8(AL)},
+	{0x2639, 0x263B, uint8(ID)},
+	{0x263C, 0x263F, uint8(AL)},
+	{0x2640, 0x2640, uint8(AI)},
+	{0x2641, 0x2641, uint8(AL)},
+	{0x2642, 0x2642, uint8(AI)},
+	{0x2643, 0x265F, uint8(AL)},
+	{0x2660, 0x2661, uint8(AI)},
+	{0x2662, 0x2662, uint8(AL)},
+	{0x2663, 0x2665, uint8(AI)},
+	{0x2666, 0x2666, uint8(AL)},
+	{0x2667, 0x2667, uint8(AI)},
+	{0x2668, 0x2668, uint8(ID)},
+	{0x2669, 0x266A, uint8(AI)},
+	{0x266B, 0x266B, uint8(AL)},
+	{0x266C, 0x266D, uint8(AI)},
+	{0x266E, 0x266E, uint8(AL)},
+	{0x266F, 0x266F, uint8(AI)},
+	{0x2670, 0x267E, uint8(AL)},
+	{0x267F, 0x267F, uint8(ID)},
+	{0x2680, 0x269D, uint8(AL)},
+	{0x269E, 0x269F, uint8(AI)},
+	{0x26A0, 0x26BC, uint8(AL)},
+	{0x26BD, 0x26C8, uint8(ID)},
+	{0x26C9, 0x26CC, uint8(AI)},
+	{0x26CD, 0x26CD, uint8(ID)},
+	{0x26CE, 0x26CE, uint8(AL)},
+	{0x26CF, 0x26D1, uint8(ID)},
+	{0x26D2, 0x26D2, uint8(AI)},
+	{0x26D3, 0x26D4, uint8(ID)},
+	{0x26D5, 0x26D7, uint8(AI)},
+	{0x26D8, 0x26D9, uint8(ID)},
+	{0x26DA, 0x26DB, uint8(AI)},
+	{0x26DC, 0x26DC, uint8(ID)},
+	{0x26DD, 0x26DE, uint8(AI)},
+	{0x26DF, 0x26E1, uint8(ID)},
+	{0x26E2, 0x26E2, uint8(AL)},
+	{0x26E3, 0x26E3, uint8(AI)},
+	{0x26E4, 0x26E7, uint8(AL)},
+	{0x26E8, 0x26E9, uint8(AI)},
+	{0x26EA, 0x26EA, uint8(ID)},
+	{0x26EB, 0x26F0, uint8(AI)},
+	{0x26F1, 0x26F5, uint8(ID)},
+	{0x26F6, 0x26F6, uint8(AI)},
+	{0x26F7, 0x26F8, uint8(ID)},
+	{0x26F9, 0x26F9, uint8(EB)},
+	{0x26FA, 0x26FA, uint8(ID)},
+	{0x26FB, 0x26FC, uint8(AI)},
+	{0x26FD, 0x2704, uint8(ID)},
+	{0x2705, 0x2707, uint8(AL)},
+	{0x2708, 0x2709, uint8(ID)},
+	{0x270A, 0x270D, uint8(EB)},
+	{0x270E, 0x2756, uint8(AL)},
+	{0x2757, 0x2757, uint8(AI)},
+	{0x2758, 0x275A, uint8(AL)},
+	{0x275B, 0x2760, uint8(QU)},
+	{0x2761, 0x2761, uint8(AL)},
+	{0x2762, 0x2763, uint8(EX)},
+	{0x2764, 0x2764, uint8(ID)},
+	{0x2765, 0x2767, uint8(AL)},
+	{0x2768, 0x2768, uint8(OP)},
+	{0x2769, 0x2769, uint8(CL)},
+	{0x276A, 0x276A, uint8(OP)},
+	{0x276B, 0x276B, uint8(CL)},
+	{0x276C, 0x276C, uint8(OP)},
+	{0x276D, 0x276D, uint8(CL)},
+	{0x276E, 0x276E, uint8(OP)},
+	{0x276F, 0x276F, uint8(CL)},
+	{0x2770, 0x2770, uint8(OP)},
+	{0x2771, 0x2771, uint8(CL)},
+	{0x2772, 0x2772, uint8(OP)},
+	{0x2773, 0x2773, uint8(CL)},
+	{0x2774, 0x2774, uint8(OP)},
+	{0x2775, 0x2775, uint8(CL)},
+	{0x2776, 0x2793, uint8(AI)},
+	{0x2794, 0x27C4, uint8(AL)},
+	{0x27C5, 0x27C5, uint8(OP)},
+	{0x27C6, 0x27C6, uint8(CL)},
+	{0x27C7, 0x27E5, uint8(AL)},
+	{0x27E6, 0x27E6, uint8(OP)},
+	{0x27E7, 0x27E7, uint8(CL)},
+	{0x27E8, 0x27E8, uint8(OP)},
+	{0x27E9, 0x27E9, uint8(CL)},
+	{0x27EA, 0x27EA, uint8(OP)},
+	{0x27EB, 0x27EB, uint8(CL)},
+	{0x27EC, 0x27EC, uint8(OP)},
+	{0x27ED, 0x27ED, uint8(CL)},
+	{0x27EE, 0x27EE, uint8(OP)},
+	{0x27EF, 0x27EF, uint8(CL)},
+	{0x27F0, 0x2982, uint8(AL)},
+	{0x2983, 0x2983, uint8(OP)},
+	{0x2984, 0x2984, uint8(CL)},
+	{0x2985, 0x2985, uint8(OP)},
+	{0x2986, 0x2986, uint8(CL)},
+	{0x2987, 0x2987, uint8(OP)},
+	{0x2988, 0x2988, uint8(CL)},
+	{0x2989, 0x2989, uint8(OP)},
+	{0x298A, 0x298A, uint8(CL)},
+	{0x298B, 0x298B, uint8(OP)},
+	{0x298C, 0x298C, uint8(CL)},
+	{0x298D, 0x298D, uint8(OP)},
+	{0x298E, 0x298E, uint8(CL)},
+	{0x298F, 0x298F, uint8(OP)},
+	{0x2990, 0x2990, uint8(CL)},
+	{0x2991, 0x2991, uint8(OP)},
+	{0x2992, 0x2992, uint8(CL)},
+	{0x2993, 0x2993, uint8(OP)},
+	{0x2994, 0x2994, uint8(CL)},
+	{0x2995, 0x2995, uint8(OP)},
+	{0x2996, 0x2996, uint8(CL)},
+	{0x2997, 0x2997, uint8(OP)},
+	{0x2998, 0x2998, uint8(CL)},
+	{0x2999, 0x29D7, uint8(AL)},
+	{0x29D8, 0x29D8, uint8(OP)},
+	{0x29D9, 0x29D9, uint8(CL)},
+	{0x29DA, 0x29DA, uint8(OP)},
+	{0x29DB, 0x29DB, uint8(CL)},
+	{0x29DC, 0x29FB, uint8(AL)},
+	{0x29FC, 0x29FC, uint8(OP)},
+	{0x29FD, 0x29FD, uint8(CL)},
+	{0x29FE, 0x2B54, uint8(AL)},
+	{0x2B55, 0x2B59, uint8(AI)},
+	{0x2B5A, 0x2B73, uint8(AL)},
+	{0x2B76, 0x2B95, uint8(AL)},
+	{0x2B97, 0x2CEE, uint8(AL)},
+	{0x2CEF, 0x2CF1, uint8(CM)},
+	{0x2CF2, 0x2CF3, uint8(AL)},
+	{0x2CF9, 0x2CF9, uint8(EX)},
+	{0x2CFA, 0x2CFC, uint8(BA)},
+	{0x2CFD, 0x2CFD, uint8(AL)},
+	{0x2CFE, 0x2CFE, uint8(EX)},
+	{0x2CFF, 0x2CFF, uint8(BA)},
+	{0x2D00, 0x2D25, uint8(AL)},
+	{0x2D27, 0x2D27, uint8(AL)},
+	{0x2D2D, 0x2D2D, uint8(AL)},
+	{0x2D30, 0x2D67, uint8(AL)},
+	{0x2D6F, 0x2D6F, uint8(AL)},
+	{0x2D70, 0x2D70, uint8(BA)},
+	{0x2D7F, 0x2D7F, uint8(CM)},
+	{0x2D80, 0x2D96, uint8(AL)},
+	{0x2DA0, 0x2DA6, uint8(AL)},
+	{0x2DA8, 0x2DAE, uint8(AL)},
+	{0x2DB0, 0x2DB6, uint8(AL)},
+	{0x2DB8, 0x2DBE, uint8(AL)},
+	{0x2DC0, 0x2DC6, uint8(AL)},
+	{0x2DC8, 0x2DCE, uint8(AL)},
+	{0x2DD0, 0x2DD6, uint8(AL)},
+	{0x2DD8, 0x2DDE, uint8(AL)},
+	{0x2DE0, 0x2DFF, uint8(CM)},
+	{0x2E00, 0x2E0D, uint8(QU)},
+	{0x2E0E, 0x2E15, uint8(BA)},
+	{0x2E16, 0x2E16, uint8(AL)},
+	{0x2E17, 0x2E17, uint8(BA)},
+	{0x2E18, 0x2E18, uint8(OP)},
+	{0x2E19, 0x2E19, uint8(BA)},
+	{0x2E1A, 0x2E1B, uint8(AL)},
+	{0x2E1C, 0x2E1D, uint8(QU)},
+	{0x2E1E, 0x2E1F, uint8(AL)},
+	{0x2E20, 0x2E21, uint8(QU)},
+	{0x2E22, 0x2E22, uint8(OP)},
+	{0x2E23, 0x2E23, uint8(CL)},
+	{0x2E24, 0x2E24, uint8(OP)},
+	{0x2E25, 0x2E25, uint8(CL)},
+	{0x2E26, 0x2E26, uint8(OP)},
+	{0x2E27, 0x2E27, uint8(CL)},
+	{0x2E28, 0x2E28, uint8(OP)},
+	{0x2E29, 0x2E29, uint8(CL)},
+	{0x2E2A, 0x2E2D, uint8(BA)},
+	{0x2E2E, 0x2E2E, uint8(EX)},
+	{0x2E2F, 0x2E2F, uint8(AL)},
+	{0x2E30, 0x2E31, uint8(BA)},
+	{0x2E32, 0x2E32, uint8(AL)},
+	{0x2E33, 0x2E34, uint8(BA)},
+	{0x2E35, 0x2E39, uint8(AL)},
+	{0x2E3A, 0x2E3B, uint8(B2)},
+	{0x2E3C, 0x2E3E, uint8(BA)},
+	{0x2E3F, 0x2E3F, uint8(AL)},
+	{0x2E40, 0x2E41, uint8(BA)},
+	{0x2E42, 0x2E42, uint8(OP)},
+	{0x2E43, 0x2E4A, uint8(BA)},
+	{0x2E4B, 0x2E4B, uint8(AL)},
+	{0x2E4C, 0x2E4C, uint8(BA)},
+	{0x2E4D, 0x2E4D, uint8(AL)},
+	{0x2E4E, 0x2E4F, uint8(BA)},
+	{0x2E50, 0x2E52, uint8(AL)},
+	{0x2E53, 0x2E54, uint8(EX)},
+	{0x2E55, 0x2E55, uint8(OP)},
+	{0x2E56, 0x2E56, uint8(CL)},
+	{0x2E57, 0x2E57, uint8(OP)},
+	{0x2E58, 0x2E58, uint8(CL)},
+	{0x2E59, 0x2E59, uint8(OP)},
+	{0x2E5A, 0x2E5A, uint8(CL)},
+	{0x2E5B, 0x2E5B, uint8(OP)},
+	{0x2E5C, 0x2E5C, uint8(CL)},
+	{0x2E5D, 0x2E5D, uint8(BA)},
+	{0x2E80, 0x2E99, uint8(ID)},
+	{0x2E9B, 0x2EF3, uint8(ID)},
+	{0x2F00, 0x2FD5, uint8(ID)},
+	{0x2FF0, 0x2FFB, uint8(ID)},
+	{0x3000, 0x3000, uint8(BA)},
+	{0x3001, 0x3002, uint8(CL)},
+	{0x3003, 0x3004, uint8(ID)},
+	{0x3005, 0x3005, uint8(NS)},
+	{0x3006, 0x3007, uint8(ID)},
+	{0x3008, 0x3008, uint8(OP)},
+	{0x3009, 0x3009, uint8(CL)},
+	{0x300A, 0x300A, uint8(OP)},
+	{0x300B, 0x300B, uint8(CL)},
+	{0x300C, 0x300C, uint8(OP)},
+	{0x300D, 0x300D, uint8(CL)},
+	{0x300E, 0x300E, uint8(OP)},
+	{0x300F, 0x300F, uint8(CL)},
+	{0x3010, 0x3010, uint8(OP)},
+	{0x3011, 0x3011, uint8(CL)},
+	{0x3012, 0x3013, uint8(ID)},
+	{0x3014, 0x3014, uint8(OP)},
+	{0x3015, 0x3015, uint8(CL)},
+	{0x3016, 0x3016, uint8(OP)},
+	{0x3017, 0x3017, uint8(CL)},
+	{0x3018, 0x3018, uint8(OP)},
+	{0x3019, 0x3019, uint8(CL)},
+	{0x301A, 0x301A, uint8(OP)},
+	{0x301B, 0x301B, uint8(CL)},
+	{0x301C, 0x301C, uint8(NS)},
+	{0x301D, 0x301D, uint8(OP)},
+	{0x301E, 0x301F, uint8(CL)},
+	{0x3020, 0x3029, uint8(ID)},
+	{0x302A, 0x302F, uint8(CM)},
+	{0x3030, 0x3034, uint8(ID)},
+	{0x3035, 0x3035, uint8(CM)},
+	{0x3036, 0x303A, uint8(ID)},
+	{0x303B, 0x303C, uint8(NS)},
+	{0x303D, 0x303F, uint8(ID)},
+	{0x3041, 0x3041, uint8(CJ)},
+	{0x3042, 0x3042, uint8(ID)},
+	{0x3043, 0x3043, uint8(CJ)},
+	{0x3044, 0x3044, uint8(ID)},
+	{0x3045, 0x3045, uint8(CJ)},
+	{0x3046, 0x3046, uint8(ID)},
+	{0x3047, 0x3047, uint8(CJ)},
+	{0x3048, 0x3048, uint8(ID)},
+	{0x3049, 0x3049, uint8(CJ)},
+	{0x304A, 0x3062, uint8(ID)},
+	{0x3063, 0x3063, uint8(CJ)},
+	{0x3064, 0x3082, uint8(ID)},
+	{0x3083, 0x3083, uint8(CJ)},
+	{0x3084, 0x3084, uint8(ID)},
+	{0x3085, 0x3085, uint8(CJ)},
+	{0x3086, 0x3086, uint8(ID)},
+	{0x3087, 0x3087, uint8(CJ)},
+	{0x3088, 0x308D, uint8(ID)},
+	{0x308E, 0x308E, uint8(CJ)},
+	{0x308F, 0x3094, uint8(ID)},
+	{0x3095, 0x3096, uint8(CJ)},
+	{0x3099, 0x309A, uint8(CM)},
+	{0x309B, 0x309E, uint8(NS)},
+	{0x309F, 0x309F, uint8(ID)},
+	{0x30A0, 0x30A0, uint8(NS)},
+	{0x30A1, 0x30A1, uint8(CJ)},
+	{0x30A2, 0x30A2, uint8(ID)},
+	{0x30A3, 0x30A3, uint8(CJ)},
+	{0x30A4, 0x30A4, uint8(ID)},
+	{0x30A5, 0x30A5, uint8(CJ)},
+	{0x30A6, 0x30A6, uint8(ID)},
+	{0x30A7, 0x30A7, uint8(CJ)},
+	{0x30A8, 0x30A8, uint8(ID)},
+	{0x30A9, 0x30A9, uint8(CJ)},
+	{0x30AA, 0x30C2, uint8(ID)},
+	{0x30C3, 0x30C3, uint8(CJ)},
+	{0x30C4, 0x30E2, uint8(ID)},
+	{0x30E3, 0x30E3, uint8(CJ)},
+	{0x30E4, 0x30E4, uint8(ID)},
+	{0x30E5, 0x30E5, uint8(CJ)},
+	{0x30E6, 0x30E6, uint8(ID)},
+	{0x30E7, 0x30E7, uint8(CJ)},
+	{0x30E8, 0x30ED, uint8(ID)},
+	{0x30EE, 0x30EE, uint8(CJ)},
+	{0x30EF, 0x30F4, uint8(ID)},
+	{0x30F5, 0x30F6, uint8(CJ)},
+	{0x30F7, 0x30FA, uint8(ID)},
+	{0x30FB, 0x30FB, uint8(NS)},
+	{0x30FC, 0x30FC, uint8(CJ)},
+	{0x30FD, 0x30FE, uint8(NS)},
+	{0x30FF, 0x30FF, uint8(ID)},
+	{0x3105, 0x312F, uint8(ID)},
+	{0x3131, 0x318E, uint8(ID)},
+	{0x3190, 0x31E3, uint8(ID)},
+	{0x31F0, 0x31FF, uint8(CJ)},
+	{0x3200, 0x321E, uint8(ID)},
+	{0x3220, 0x3247, uint8(ID)},
+	{0x3248, 0x324F, uint8(AI)},
+	{0x3250, 0x4DBF, uint8(ID)},
+	{0x4DC0, 0x4DFF, uint8(AL)},
+	{0x4E00, 0xA014, uint8(ID)},
+	{0xA015, 0xA015, uint8(NS)},
+	{0xA016, 0xA48C, uint8(ID)},
+	{0xA490, 0xA4C6, uint8(ID)},
+	{0xA4D0, 0xA4FD, uint8(AL)},
+	{0xA4FE, 0xA4FF, uint8(BA)},
+	{0xA500, 0xA60C, uint8(AL)},
+	{0xA60D, 0xA60D, uint8(BA)},
+	{0xA60E, 0xA60E, uint8(EX)},
+	{0xA60F, 0xA60F, uint8(BA)},
+	{0xA610, 0xA61F, uint8(AL)},
+	{0xA620, 0xA629, uint8(NU)},
+	{0xA62A, 0xA62B, uint8(AL)},
+	{0xA640, 0xA66E, uint8(AL)},
+	{0xA66F, 0xA672, uint8(CM)},
+	{0xA673, 0xA673, uint8(AL)},
+	{0xA674, 0xA67D, uint8(CM)},
+	{0xA67E, 0xA69D, uint8(AL)},
+	{0xA69E, 0xA69F, uint8(CM)},
+	{0xA6A0, 0xA6EF, uint8(AL)},
+	{0xA6F0, 0xA6F1, uint8(CM)},
+	{0xA6F2, 0xA6F2, uint8(AL)},
+	{0xA6F3, 0xA6F7, uint8(BA)},
+	{0xA700, 0xA7CA, uint8(AL)},
+	{0xA7D0, 0xA7D1, uint8(AL)},
+	{0xA7D3, 0xA7D3, uint8(AL)},
+	{0xA7D5, 0xA7D9, uint8(AL)},
+	{0xA7F2, 0xA801, uint8(AL)},
+	{0xA802, 0xA802, uint8(CM)},
+	{0xA803, 0xA805, uint8(AL)},
+	{0xA806, 0xA806, uint8(CM)},
+	{0xA807, 0xA80A, uint8(AL)},
+	{0xA80B, 0xA80B, uint8(CM)},
+	{0xA80C, 0xA822, uint8(AL)},
+	{0xA823, 0xA827, uint8(CM)},
+	{0xA828, 0xA82B, uint8(AL)},
+	{0xA82C, 0xA82C, uint8(CM)},
+	{0xA830, 0xA837, uint8(AL)},
+	{0xA838, 0xA838, uint8(PO)},
+	{0xA839, 0xA839, uint8(AL)},
+	{0xA840, 0xA873, uint8(AL)},
+	{0xA874, 0xA875, uint8(BB)},
+	{0xA876, 0xA877, uint8(EX)},
+	{0xA880, 0xA881, uint8(CM)},
+	{0xA882, 0xA8B3, uint8(AL)},
+	{0xA8B4, 0xA8C5, uint8(CM)},
+	{0xA8CE, 0xA8CF, uint8(BA)},
+	{0xA8D0, 0xA8D9, uint8(NU)},
+	{0xA8E0, 0xA8F1, uint8(CM)},
+	{0xA8F2, 0xA8FB, uint8(AL)},
+	{0xA8FC, 0xA8FC, uint8(BB)},
+	{0xA8FD, 0xA8FE, uint8(AL)},
+	{0xA8FF, 0xA8FF, uint8(CM)},
+	{0xA900, 0xA909, uint8(NU)},
+	{0xA90A, 0xA925, uint8(AL)},
+	{0xA926, 0xA92D, uint8(CM)},
+	{0xA92E, 0xA92F, uint8(BA)},
+	{0xA930, 0xA946, uint8(AL)},
+	{0xA947, 0xA953, uint8(CM)},
+	{0xA95F, 0xA95F, uint8(AL)},
+	{0xA960, 0xA97C, uint8(JL)},
+	{0xA980, 0xA983, uint8(CM)},
+	{0xA984, 0xA9B2, uint8(AL)},
+	{0xA9B3, 0xA9C0, uint8(CM)},
+	{0xA9C1, 0xA9C6, uint8(AL)},
+	{0xA9C7, 0xA9C9, uint8(BA)},
+	{0xA9CA, 0xA9CD, uint8(AL)},
+	{0xA9CF, 0xA9CF, uint8(AL)},
+	{0xA9D0, 0xA9D9, uint8(NU)},
+	{0xA9DE, 0xA9DF, uint8(AL)},
+	{0xA9E0, 0xA9EF, uint8(SA)},
+	{0xA9F0, 0xA9F9, uint8(NU)},
+	{0xA9FA, 0xA9FE, uint8(SA)},
+	{0xAA00, 0xAA28, uint8(AL)},
+	{0xAA29, 0xAA36, uint8(CM)},
+	{0xAA40, 0xAA42, uint8(AL)},
+	{0xAA43, 0xAA43, uint8(CM)},
+	{0xAA44, 0xAA4B, uint8(AL)},
+	{0xAA4C, 0xAA4D, uint8(CM)},
+	{0xAA50, 0xAA59, uint8(NU)},
+	{0xAA5C, 0xAA5C, uint8(AL)},
+	{0xAA5D, 0xAA5F, uint8(BA)},
+	{0xAA60, 0xAAC2, uint8(SA)},
+	{0xAADB, 0xAADF, uint8(SA)},
+	{0xAAE0, 0xAAEA, uint8(AL)},
+	{0xAAEB, 0xAAEF, uint8(CM)},
+	{0xAAF0, 0xAAF1, uint8(BA)},
+	{0xAAF2, 0xAAF4, uint8(AL)},
+	{0xAAF5, 0xAAF6, uint8(CM)},
+	{0xAB01, 0xAB06, uint8(AL)},
+	{0xAB09, 0xAB0E, uint8(AL)},
+	{0xAB11, 0xAB16, uint8(AL)},
+	{0xAB20, 0xAB26, uint8(AL)},
+	{0xAB28, 0xAB2E, uint8(AL)},
+	{0xAB30, 0xAB6B, uint8(AL)},
+	{0xAB70, 0xABE2, uint8(AL)},
+	{0xABE3, 0xABEA, uint8(CM)},
+	{0xABEB, 0xABEB, uint8(BA)},
+	{0xABEC, 0xABED, uint8(CM)},
+	{0xABF0, 0xABF9, uint8(NU)},
+	{0xAC00, 0xAC00, uint8(H2)},
+	{0xAC01, 0xAC1B, uint8(H3)},
+	{0xAC1C, 0xAC1C, uint8(H2)},
+	{0xAC1D, 0xAC37, uint8(H3)},
+	{0xAC38, 0xAC38, uint8(H2)},
+	{0xAC39, 0xAC53, uint8(H3)},
+	{0xAC54, 0xAC54, uint8(H2)},
+	{0xAC55, 0xAC6F, uint8(H3)},
+	{0xAC70, 0xAC70, uint8(H2)},
+	{0xAC71, 0xAC8B, uint8(H3)},
+	{0xAC8C, 0xAC8C, uint8(H2)},
+	{0xAC8D, 0xACA7, uint8(H3)},
+	{0xACA8, 0xACA8, uint8(H2)},
+	{0xACA9, 0xACC3, uint8(H3)},
+	{0xACC4, 0xACC4, uint8(H2)},
+	{0xACC5, 0xACDF, uint8(H3)},
+	{0xACE0, 0xACE0, uint8(H2)},
+	{0xACE1, 0xACFB, uint8(H3)},
+	{0xACFC, 0xACFC, uint8(H2)},
+	{0xACFD, 0xAD17, uint8(H3)},
+	{0xAD18, 0xAD18, uint8(H2)},
+	{0xAD19, 0xAD33, uint8(H3)},
+	{0xAD34, 0xAD34, uint8(H2)},
+	{0xAD35, 0xAD4F, uint8(H3)},
+	{0xAD50, 0xAD50, uint8(H2)},
+	{0xAD51, 0xAD6B, uint8(H3)},
+	{0xAD6C, 0xAD6C, uint8(H2)},
+	{0xAD6D, 0xAD87, uint8(H3)},
+	{0xAD88, 0xAD88, uint8(H2)},
+	{0xAD89, 0xADA3, uint8(H3)},
+	{0xADA4, 0xADA4, uint8(H2)},
+	{0xADA5, 0xADBF, uint8(H3)},
+	{0xADC0, 0xADC0, uint8(H2)},
+	{0xADC1, 0xADDB, uint8(H3)},
+	{0xADDC, 0xADDC, uint8(H2)},
+	{0xADDD, 0xADF7, uint8(H3)},
+	{0xADF8, 0xADF8, uint8(H2)},
+	{0xADF9, 0xAE13, uint8(H3)},
+	{0xAE14, 0xAE14, uint8(H2)},
+	{0xAE15, 0xAE2F, uint8(H3)},
+	{0xAE30, 0xAE30, uint8(H2)},
+	{0xAE31, 0xAE4B, uint8(H3)},
+	{0xAE4C, 0xAE4C, uint8(H2)},
+	{0xAE4D, 0xAE67, uint8(H3)},
+	{0xAE68, 0xAE68, uint8(H2)},
+	{0xAE69, 0xAE83, uint8(H3)},
+	{0xAE84, 0xAE84, uint8(H2)},
+	{0xAE85, 0xAE9F, uint8(H3)},
+	{0xAEA0, 0xAEA0, uint8(H2)},
+	{0xAEA1, 0xAEBB, uint8(H3)},
+	{0xAEBC, 0xAEBC, uint8(H2)},
+	{0xAEBD, 0xAED7, uint8(H3)},
+	{0xAED8, 0xAED8, uint8(H2)},
+	{0xAED9, 0xAEF3, uint8(H3)},
+	{0xAEF4, 0xAEF4, uint8(H2)},
+	{0xAEF5, 0xAF0F, uint8(H3)},
+	{0xAF10, 0xAF10, uint8(H2)},
+	{0xAF11, 0xAF2B, uint8(H3)},
+	{0xAF2C, 0xAF2C, uint8(H2)},
+	{0xAF2D, 0xAF47, uint8(H3)},
+	{0xAF48, 0xAF48, uint8(H2)},
+	{0xAF49, 0xAF63, uint8(H3)},
+	{0xAF64, 0xAF64, uint8(H2)},
+	{0xAF65, 0xAF7F, uint8(H3)},
+	{0xAF80, 0xAF80, uint8(H2)},
+	{0xAF81, 0xAF9B, uint8(H3)},
+	{0xAF9C, 0xAF9C, uint8(H2)},
+	{0xAF9D, 0xAFB7, uint8(H3)},
+	{0xAFB8, 0xAFB8, uint8(H2)},
+	{0xAFB9, 0xAFD3, uint8(H3)},
+	{0xAFD4, 0xAFD4, uint8(H2)},
+	{0xAFD5, 0xAFEF, uint8(H3)},
+	{0xAFF0, 0xAFF0, uint8(H2)},
+	{0xAFF1, 0xB00B, uint8(H3)},
+	{0xB00C, 0xB00C, uint8(H2)},
+	{0xB00D, 0xB027, uint8(H3)},
+	{0xB028, 0xB028, uint8(H2)},
+	{0xB029, 0xB043, uint8(H3)},
+	{0xB044, 0xB044, uint8(H2)},
+	{0xB045, 0xB05F, uint8(H3)},
+	{0xB060, 0xB060, uint8(H2)},
+	{0xB061, 0xB07B, uint8(H3)},
+	{0xB07C, 0xB07C, uint8(H2)},
+	{0xB07D, 0xB097, uint8(H3)},
+	{0xB098, 0xB098, uint8(H2)},
+	{0xB099, 0xB0B3, uint8(H3)},
+	{0xB0B4, 0xB0B4, uint8(H2)},
+	{0xB0B5, 0xB0CF, uint8(H3)},
+	{0xB0D0, 0xB0D0, uint8(H2)},
+	{0xB0D1, 0xB0EB, uint8(H3)},
+	{0xB0EC, 0xB0EC, uint8(H2)},
+	{0xB0ED, 0xB107, uint8(H3)},
+	{0xB108, 0xB108, uint8(H2)},
+	{0xB109, 0xB123, uint8(H3)},
+	{0xB124, 0xB124, uint8(H2)},
+	{0xB125, 0xB13F, uint8(H3)},
+	{0xB140, 0xB140, uint8(H2)},
+	{0xB141, 0xB15B, uint8(H3)},
+	{0xB15C, 0xB15C, uint8(H2)},
+	{0xB15D, 0xB177, uint8(H3)},
+	{0xB178, 0xB178, uint8(H2)},
+	{0xB179, 0xB193, uint8(H3)},
+	{0xB194, 0xB194, uint8(H2)},
+	{0xB195, 0xB1AF, uint8(H3)},
+	{0xB1B0, 0xB1B0, uint8(H2)},
+	{0xB1B1, 0xB1CB, uint8(H3)},
+	{0xB1CC, 0xB1CC, uint8(H2)},
+	{0xB1CD, 0xB1E7, uint8(H3)},
+	{0xB1E8, 0xB1E8, uint8(H2)},
+	{0xB1E9, 0xB203, uint8(H3)},
+	{0xB204, 0xB204, uint8(H2)},
+	{0xB205, 0xB21F, uint8(H3)},
+	{0xB220, 0xB220, uint8(H2)},
+	{0xB221, 0xB23B, uint8(H3)},
+	{0xB23C, 0xB23C, uint8(H2)},
+	{0xB23D, 0xB257, uint8(H3)},
+	{0xB258, 0xB258, uint8(H2)},
+	{0xB259, 0xB273, uint8(H3)},
+	{0xB274, 0xB274, uint8(H2)},
+	{0xB275, 0xB28F, uint8(H3)},
+	{0xB290, 0xB290, uint8(H2)},
+	{0xB291, 0xB2AB, uint8(H3)},
+	{0xB2AC, 0xB2AC, uint8(H2)},
+	{0xB2AD, 0xB2C7, uint8(H3)},
+	{0xB2C8, 0xB2C8, uint8(H2)},
+	{0xB2C9, 0xB2E3, uint8(H3)},
+	{0xB2E4, 0xB2E4, uint8(H2)},
+	{0xB2E5, 0xB2FF, uint8(H3)},
+	{0xB300, 0xB300, uint8(H2)},
+	{0xB301, 0xB31B, uint8(H3)},
+	{0xB31C, 0xB31C, uint8(H2)},
+	{0xB31D, 0xB337, uint8(H3)},
+	{0xB338, 0xB338, uint8(H2)},
+	{0xB339, 0xB353, uint8(H3)},
+	{0xB354, 0xB354, uint8(H2)},
+	{0xB355, 0xB36F, uint8(H3)},
+	{0xB370, 0xB370, uint8(H2)},
+	{0xB371, 0xB38B, uint8(H3)},
+	{0xB38C, 0xB38C, uint8(H2)},
+	{0xB38D, 0xB3A7, uint8(H3)},
+	{0xB3A8, 0xB3A8, uint8(H2)},
+	{0xB3A9, 0xB3C3, uint8(H3)},
+	{0xB3C4, 0xB3C4, uint8(H2)},
+	{0xB3C5, 0xB3DF, uint8(H3)},
+	{0xB3E0, 0xB3E0, uint8(H2)},
+	{0xB3E1, 0xB3FB, uint8(H3)},
+	{0xB3FC, 0xB3FC, uint8(H2)},
+	{0xB3FD, 0xB417, uint8(H3)},
+	{0xB418, 0xB418, uint8(H2)},
+	{0xB419, 0xB433, uint8(H3)},
+	{0xB434, 0xB434, uint8(H2)},
+	{0xB435, 0xB44F, uint8(H3)},
+	{0xB450, 0xB450, uint8(H2)},
+	{0xB451, 0xB46B, uint8(H3)},
+	{0xB46C, 0xB46C, uint8(H2)},
+	{0xB46D, 0xB487, uint8(H3)},
+	{0xB488, 0xB488, uint8(H2)},
+	{0xB489, 0xB4A3, uint8(H3)},
+	{0xB4A4, 0xB4A4, uint8(H2)},
+	{0xB4A5, 0xB4BF, uint8(H3)},
+	{0xB4C0, 0xB4C0, uint8(H2)},
+	{0xB4C1, 0xB4DB, uint8(H3)},
+	{0xB4DC, 0xB4DC, uint8(H2)},
+	{0xB4DD, 0xB4F7, uint8(H3)},
+	{0xB4F8, 0xB4F8, uint8(H2)},
+	{0xB4F9, 0xB513, uint8(H3)},
+	{0xB514, 0xB514, uint8(H2)},
+	{0xB515, 0xB52F, uint8(H3)},
+	{0xB530, 0xB530, uint8(H2)},
+	{0xB531, 0xB54B, uint8(H3)},
+	{0xB54C, 0xB54C, uint8(H2)},
+	{0xB54D, 0xB567, uint8(H3)},
+	{0xB568, 0xB568, uint8(H2)},
+	{0xB569, 0xB583, uint8(H3)},
+	{0xB584, 0xB584, uint8(H2)},
+	{0xB585, 0xB59F, uint8(H3)},
+	{0xB5A0, 0xB5A0, uint8(H2)},
+	{0xB5A1, 0xB5BB, uint8(H3)},
+	{0xB5BC, 0xB5BC, uint8(H2)},
+	{0xB5BD, 0xB5D7, uint8(H3)},
+	{0xB5D8, 0xB5D8, uint8(H2)},
+	{0xB5D9, 0xB5F3, uint8(H3)},
+	{0xB5F4, 0xB5F4, uint8(H2)},
+	{0xB5F5, 0xB60F, uint8(H3)},
+	{0xB610, 0xB610, uint8(H2)},
+	{0xB611, 0xB62B, uint8(H3)},
+	{0xB62C, 0xB62C, uint8(H2)},
+	{0xB62D, 0xB647, uint8(H3)},
+	{0xB648, 0xB648, uint8(H2)},
+	{0xB649, 0xB663, uint8(H3)},
+	{0xB664, 0xB664, uint8(H2)},
+	{0xB665, 0xB67F, uint8(H3)},
+	{0xB680, 0xB680, uint8(H2)},
+	{0xB681, 0xB69B, uint8(H3)},
+	{0xB69C, 0xB69C, uint8(H2)},
+	{0xB69D, 0xB6B7, uint8(H3)},
+	{0xB6B8, 0xB6B8, uint8(H2)},
+	{0xB6B9, 0xB6D3, uint8(H3)},
+	{0xB6D4, 0xB6D4, uint8(H2)},
+	{0xB6D5, 0xB6EF, uint8(H3)},
+	{0xB6F0, 0xB6F0, uint8(H2)},
+	{0xB6F1, 0xB70B, uint8(H3)},
+	{0xB70C, 0xB70C, uint8(H2)},
+	{0xB70D, 0xB727, uint8(H3)},
+	{0xB728, 0xB728, uint8(H2)},
+	{0xB729, 0xB743, uint8(H3)},
+	{0xB744, 0xB744, uint8(H2)},
+	{0xB745, 0xB75F, uint8(H3)},
+	{0xB760, 0xB760, uint8(H2)},
+	{0xB761, 0xB77B, uint8(H3)},
+	{0xB77C, 0xB77C, uint8(H2)},
+	{0xB77D, 0xB797, uint8(H3)},
+	{0xB798, 0xB798, uint8(H2)},
+	{0xB799, 0xB7B3, uint8(H3)},
+	{0xB7B4, 0xB7B4, uint8(H2)},
+	{0xB7B5, 0xB7CF, uint8(H3)},
+	{0xB7D0, 0xB7D0, uint8(H2)},
+	{0xB7D1, 0xB7EB, uint8(H3)},
+	{0xB7EC, 0xB7EC, uint8(H2)},
+	{0xB7ED, 0xB807, uint8(H3)},
+	{0xB808, 0xB808, uint8(H2)},
+	{0xB809, 0xB823, uint8(H3)},
+	{0xB824, 0xB824, uint8(H2)},
+	{0xB825, 0xB83F, uint8(H3)},
+	{0xB840, 0xB840, uint8(H2)},
+	{0xB841, 0xB85B, uint8(H3)},
+	{0xB85C, 0xB85C, uint8(H2)},
+	{0xB85D, 0xB877, uint8(H3)},
+	{0xB878, 0xB878, uint8(H2)},
+	{0xB879, 0xB893, uint8(H3)},
+	{0xB894, 0xB894, uint8(H2)},
+	{0xB895, 0xB8AF, uint8(H3)},
+	{0xB8B0, 0xB8B0, uint8(H2)},
+	{0xB8B1, 0xB8CB, uint8(H3)},
+	{0xB8CC, 0xB8CC, uint8(H2)},
+	{0xB8CD, 0xB8E7, uint8(H3)},
+	{0xB8E8, 0xB8E8, uint8(H2)},
+	{0xB8E9, 0xB903, uint8(H3)},
+	{0xB904, 0xB904, uint8(H2)},
+	{0xB905, 0xB91F, uint8(H3)},
+	{0xB920, 0xB920, uint8(H2)},
+	{0xB921, 0xB93B, uint8(H3)},
+	{0xB93C, 0xB93C, uint8(H2)},
+	{0xB93D, 0xB957, uint8(H3)},
+	{0xB958, 0xB958, uint8(H2)},
+	{0xB959, 0xB973, uint8(H3)},
+	{0xB974, 0xB974, uint8(H2)},
+	{0xB975, 0xB98F, uint8(H3)},
+	{0xB990, 0xB990, uint8(H2)},
+	{0xB991, 0xB9AB, uint8(H3)},
+	{0xB9AC, 0xB9AC, uint8(H2)},
+	{0xB9AD, 0xB9C7, uint8(H3)},
+	{0xB9C8, 0xB9C8, uint8(H2)},
+	{0xB9C9, 0xB9E3, uint8(H3)},
+	{0xB9E4, 0xB9E4, uint8(H2)},
+	{0xB9E5, 0xB9FF, uint8(H3)},
+	{0xBA00, 0xBA00, uint8(H2)},
+	{0xBA01, 0xBA1B, uint8(H3)},
+	{0xBA1C, 0xBA1C, uint8(H2)},
+	{0xBA1D, 0xBA37, uint8(H3)},
+	{0xBA38, 0xBA38, uint8(H2)},
+	{0xBA39, 0xBA53, uint8(H3)},
+	{0xBA54, 0xBA54, uint8(H2)},
+	{0xBA55, 0xBA6F, uint8(H3)},
+	{0xBA70, 0xBA70, uint8(H2)},
+	{0xBA71, 0xBA8B, uint8(H3)},
+	{0xBA8C, 0xBA8C, uint8(H2)},
+	{0xBA8D, 0xBAA7, uint8(H3)},
+	{0xBAA8, 0xBAA8, uint8(H2)},
+	{0xBAA9, 0xBAC3, uint8(H3)},
+	{0xBAC4, 0xBAC4, uint8(H2)},
+	{0xBAC5, 0xBADF, uint8(H3)},
+	{0xBAE0, 0xBAE0, uint8(H2)},
+	{0xBAE1, 0xBAFB, uint8(H3)},
+	{0xBAFC, 0xBAFC, uint8(H2)},
+	{0xBAFD, 0xBB17, uint8(H3)},
+	{0xBB18, 0xBB18, uint8(H2)},
+	{0xBB19, 0xBB33, uint8(H3)},
+	{0xBB34, 0xBB34, uint8(H2)},
+	{0xBB35, 0xBB4F, uint8(H3)},
+	{0xBB50, 0xBB50, uint8(H2)},
+	{0xBB51, 0xBB6B, uint8(H3)},
+	{0xBB6C, 0xBB6C, uint8(H2)},
+	{0xBB6D, 0xBB87, uint8(H3)},
+	{0xBB88, 0xBB88, uint8(H2)},
+	{0xBB89, 0xBBA3, uint8(H3)},
+	{0xBBA4, 0xBBA4, uint8(H2)},
+	{0xBBA5, 0xBBBF, uint8(H3)},
+	{0xBBC0, 0xBBC0, uint8(H2)},
+	{0xBBC1, 0xBBDB, uint8(H3)},
+	{0xBBDC, 0xBBDC, uint8(H2)},
+	{0xBBDD, 0xBBF7, uint8(H3)},
+	{0xBBF8, 0xBBF8, uint8(H2)},
+	{0xBBF9, 0xBC13, uint8(H3)},
+	{0xBC14, 0xBC14, uint8(H2)},
+	{0xBC15, 0xBC2F, uint8(H3)},
+	{0xBC30, 0xBC30, uint8(H2)},
+	{0xBC31, 0xBC4B, uint8(H3)},
+	{0xBC4C, 0xBC4C, uint8(H2)},
+	{0xBC4D, 0xBC67, uint8(H3)},
+	{0xBC68, 0xBC68, uint8(H2)},
+	{0xBC69, 0xBC83, uint8(H3)},
+	{0xBC84, 0xBC84, uint8(H2)},
+	{0xBC85, 0xBC9F, uint8(H3)},
+	{0xBCA0, 0xBCA0, uint8(H2)},
+	{0xBCA1, 0xBCBB, uint8(H3)},
+	{0xBCBC, 0xBCBC, uint8(H2)},
+	{0xBCBD, 0xBCD7, uint8(H3)},
+	{0xBCD8, 0xBCD8, uint8(H2)},
+	{0xBCD9, 0xBCF3, uint8(H3)},
+	{0xBCF4, 0xBCF4, uint8(H2)},
+	{0xBCF5, 0xBD0F, uint8(H3)},
+	{0xBD10, 0xBD10, uint8(H2)},
+	{0xBD11, 0xBD2B, uint8(H3)},
+	{0xBD2C, 0xBD2C, uint8(H2)},
+	{0xBD2D, 0xBD47, uint8(H3)},
+	{0xBD48, 0xBD48, uint8(H2)},
+	{0xBD49, 0xBD63, uint8(H3)},
+	{0xBD64, 0xBD64, uint8(H2)},
+	{0xBD65, 0xBD7F, uint8(H3)},
+	{0xBD80, 0xBD80, uint8(H2)},
+	{0xBD81, 0xBD9B, uint8(H3)},
+	{0xBD9C, 0xBD9C, uint8(H2)},
+	{0xBD9D, 0xBDB7, uint8(H3)},
+	{0xBDB8, 0xBDB8, uint8(H2)},
+	{0xBDB9, 0xBDD3, uint8(H3)},
+	{0xBDD4, 0xBDD4, uint8(H2)},
+	{0xBDD5, 0xBDEF, uint8(H3)},
+	{0xBDF0, 0xBDF0, uint8(H2)},
+	{0xBDF1, 0xBE0B, uint8(H3)},
+	{0xBE0C, 0xBE0C, uint8(H2)},
+	{0xBE0D, 0xBE27, uint8(H3)},
+	{0xBE28, 0xBE28, uint8(H2)},
+	{0xBE29, 0xBE43, uint8(H3)},
+	{0xBE44, 0xBE44, uint8(H2)},
+	{0xBE45, 0xBE5F, uint8(H3)},
+	{0xBE60, 0xBE60, uint8(H2)},
+	{0xBE61, 0xBE7B, uint8(H3)},
+	{0xBE7C, 0xBE7C, uint8(H2)},
+	{0xBE7D, 0xBE97, uint8(H3)},
+	{0xBE98, 0xBE98, uint8(H2)},
+	{0xBE99, 0xBEB3, uint8(H3)},
+	{0xBEB4, 0xBEB4, uint8(H2)},
+	{0xBEB5, 0xBECF, uint8(H3)},
+	{0xBED0, 0xBED0, uint8(H2)},
+	{0xBED1, 0xBEEB, uint8(H3)},
+	{0xBEEC, 0xBEEC, uint8(H2)},
+	{0xBEED, 0xBF07, uint8(H3)},
+	{0xBF08, 0xBF08, uint8(H2)},
+	{0xBF09, 0xBF23, uint8(H3)},
+	{0xBF24, 0xBF24, uint8(H2)},
+	{0xBF25, 0xBF3F, uint8(H3)},
+	{0xBF40, 0xBF40, uint8(H2)},
+	{0xBF41, 0xBF5B, uint8(H3)},
+	{0xBF5C, 0xBF5C, uint8(H2)},
+	{0xBF5D, 0xBF77, uint8(H3)},
+	{0xBF78, 0xBF78, uint8(H2)},
+	{0xBF79, 0xBF93, uint8(H3)},
+	{0xBF94, 0xBF94, uint8(H2)},
+	{0xBF95, 0xBFAF, uint8(H3)},
+	{0xBFB0, 0xBFB0, uint8(H2)},
+	{0xBFB1, 0xBFCB, uint8(H3)},
+	{0xBFCC, 0xBFCC, uint8(H2)},
+	{0xBFCD, 0xBFE7, uint8(H3)},
+	{0xBFE8, 0xBFE8, uint8(H2)},
+	{0xBFE9, 0xC003, uint8(H3)},
+	{0xC004, 0xC004, uint8(H2)},
+	{0xC005, 0xC01F, uint8(H3)},
+	{0xC020, 0xC020, uint8(H2)},
+	{0xC021, 0xC03B, uint8(H3)},
+	{0xC03C, 0xC03C, uint8(H2)},
+	{0xC03D, 0xC057, uint8(H3)},
+	{0xC058, 0xC058, uint8(H2)},
+	{0xC059, 0xC073, uint8(H3)},
+	{0xC074, 0xC074, uint8(H2)},
+	{0xC075, 0xC08F, uint8(H3)},
+	{0xC090, 0xC090, uint8(H2)},
+	{0xC091, 0xC0AB, uint8(H3)},
+	{0xC0AC, 0xC0AC, uint8(H2)},
+	{0xC0AD, 0xC0C7, uint8(H3)},
+	{0xC0C8, 0xC0C8, uint8(H2)},
+	{0xC0C9, 0xC0E3, uint8(H3)},
+	{0xC0E4, 0xC0E4, uint8(H2)},
+	{0xC0E5, 0xC0FF, uint8(H3)},
+	{0xC100, 0xC100, uint8(H2)},
+	{0xC101, 0xC11B, uint8(H3)},
+	{0xC11C, 0xC11C, uint8(H2)},
+	{0xC11D, 0xC137, uint8(H3)},
+	{0xC138, 0xC138, uint8(H2)},
+	{0xC139, 0xC153, uint8(H3)},
+	{0xC154, 0xC154, uint8(H2)},
+	{0xC155, 0xC16F, uint8(H3)},
+	{0xC170, 0xC170, uint8(H2)},
+	{0xC171, 0xC18B, uint8(H3)},
+	{0xC18C, 0xC18C, uint8(H2)},
+	{0xC18D, 0xC1A7, uint8(H3)},
+	{0xC1A8, 0xC1A8, uint8(H2)},
+	{0xC1A9, 0xC1C3, uint8(H3)},
+	{0xC1C4, 0xC1C4, uint8(H2)},
+	{0xC1C5, 0xC1DF, uint8(H3)},
+	{0xC1E0, 0xC1E0, uint8(H2)},
+	{0xC1E1, 0xC1FB, uint8(H3)},
+	{0xC1FC, 0xC1FC, uint8(H2)},
+	{0xC1FD, 0xC217, uint8(H3)},
+	{0xC218, 0xC218, uint8(H2)},
+	{0xC219, 0xC233, uint8(H3)},
+	{0xC234, 0xC234, uint8(H2)},
+	{0xC235, 0xC24F, uint8(H3)},
+	{0xC250, 0xC250, uint8(H2)},
+	{0xC251, 0xC26B, uint8(H3)},
+	{0xC26C, 0xC26C, uint8(H2)},
+	{0xC26D, 0xC287, uint8(H3)},
+	{0xC288, 0xC288, uint8(H2)},
+	{0xC289, 0xC2A3, uint8(H3)},
+	{0xC2A4, 0xC2A4, uint8(H2)},
+	{0xC2A5, 0xC2BF, uint8(H3)},
+	{0xC2C0, 0xC2C0, uint8(H2)},
+	{0xC2C1, 0xC2DB, uint8(H3)},
+	{0xC2DC, 0xC2DC, uint8(H2)},
+	{0xC2DD, 0xC2F7, uint8(H3)},
+	{0xC2F8, 0xC2F8, uint8(H2)},
+	{0xC2F9, 0xC313, uint8(H3)},
+	{0xC314, 0xC314, uint8(H2)},
+	{0xC315, 0xC32F, uint8(H3)},
+	{0xC330, 0xC330, uint8(H2)},
+	{0xC331, 0xC34B, uint8(H3)},
+	{0xC34C, 0xC34C, uint8(H2)},
+	{0xC34D, 0xC367, uint8(H3)},
+	{0xC368, 0xC368, uint8(H2)},
+	{0xC369, 0xC383, uint8(H3)},
+	{0xC384, 0xC384, uint8(H2)},
+	{0xC385, 0xC39F, uint8(H3)},
+	{0xC3A0, 0xC3A0, uint8(H2)},
+	{0xC3A1, 0xC3BB, uint8(H3)},
+	{0xC3BC, 0xC3BC, uint8(H2)},
+	{0xC3BD, 0xC3D7, uint8(H3)},
+	{0xC3D8, 0xC3D8, uint8(H2)},
+	{0xC3D9, 0xC3F3, uint8(H3)},
+	{0xC3F4, 0xC3F4, uint8(H2)},
+	{0xC3F5, 0xC40F, uint8(H3)},
+	{0xC410, 0xC410, uint8(H2)},
+	{0xC411, 0xC42B, uint8(H3)},
+	{0xC42C, 0xC42C, uint8(H2)},
+	{0xC42D, 0xC447, uint8(H3)},
+	{0xC448, 0xC448, uint8(H2)},
+	{0xC449, 0xC463, uint8(H3)},
+	{0xC464, 0xC464, uint8(H2)},
+	{0xC465, 0xC47F, uint8(H3)},
+	{0xC480, 0xC480, uint8(H2)},
+	{0xC481, 0xC49B, uint8(H3)},
+	{0xC49C, 0xC49C, uint8(H2)},
+	{0xC49D, 0xC4B7, uint8(H3)},
+	{0xC4B8, 0xC4B8, uint8(H2)},
+	{0xC4B9, 0xC4D3, uint8(H3)},
+	{0xC4D4, 0xC4D4, uint8(H2)},
+	{0xC4D5, 0xC4EF, uint8(H3)},
+	{0xC4F0, 0xC4F0, uint8(H2)},
+	{0xC4F1, 0xC50B, uint8(H3)},
+	{0xC50C, 0xC50C, uint8(H2)},
+	{0xC50D, 0xC527, uint8(H3)},
+	{0xC528, 0xC528, uint8(H2)},
+	{0xC529, 0xC543, uint8(H3)},
+	{0xC544, 0xC544, uint8(H2)},
+	{0xC545, 0xC55F, uint8(H3)},
+	{0xC560, 0xC560, uint8(H2)},
+	{0xC561, 0xC57B, uint8(H3)},
+	{0xC57C, 0xC57C, uint8(H2)},
+	{0xC57D, 0xC597, uint8(H3)},
+	{0xC598, 0xC598, uint8(H2)},
+	{0xC599, 0xC5B3, uint8(H3)},
+	{0xC5B4, 0xC5B4, uint8(H2)},
+	{0xC5B5, 0xC5CF, uint8(H3)},
+	{0xC5D0, 0xC5D0, uint8(H2)},
+	{0xC5D1, 0xC5EB, uint8(H3)},
+	{0xC5EC, 0xC5EC, uint8(H2)},
+	{0xC5ED, 0xC607, uint8(H3)},
+	{0xC608, 0xC608, uint8(H2)},
+	{0xC609, 0xC623, uint8(H3)},
+	{0xC624, 0xC624, uint8(H2)},
+	{0xC625, 0xC63F, uint8(H3)},
+	{0xC640, 0xC640, uint8(H2)},
+	{0xC641, 0xC65B, uint8(H3)},
+	{0xC65C, 0xC65C, uint8(H2)},
+	{0xC65D, 0xC677, uint8(H3)},
+	{0xC678, 0xC678, uint8(H2)},
+	{0xC679, 0xC693, uint8(H3)},
+	{0xC694, 0xC694, uint8(H2)},
+	{0xC695, 0xC6AF, uint8(H3)},
+	{0xC6B0, 0xC6B0, uint8(H2)},
+	{0xC6B1, 0xC6CB, uint8(H3)},
+	{0xC6CC, 0xC6CC, uint8(H2)},
+	{0xC6CD, 0xC6E7, uint8(H3)},
+	{0xC6E8, 0xC6E8, uint8(H2)},
+	{0xC6E9, 0xC703, uint8(H3)},
+	{0xC704, 0xC704, uint8(H2)},
+	{0xC705, 0xC71F, uint8(H3)},
+	{0xC720, 0xC720, uint8(H2)},
+	{0xC721, 0xC73B, uint8(H3)},
+	{0xC73C, 0xC73C, uint8(H2)},
+	{0xC73D, 0xC757, uint8(H3)},
+	{0xC758, 0xC758, uint8(H2)},
+	{0xC759, 0xC773, uint8(H3)},
+	{0xC774, 0xC774, uint8(H2)},
+	{0xC775, 0xC78F, uint8(H3)},
+	{0xC790, 0xC790, uint8(H2)},
+	{0xC791, 0xC7AB, uint8(H3)},
+	{0xC7AC, 0xC7AC, uint8(H2)},
+	{0xC7AD, 0xC7C7, uint8(H3)},
+	{0xC7C8, 0xC7C8, uint8(H2)},
+	{0xC7C9, 0xC7E3, uint8(H3)},
+	{0xC7E4, 0xC7E4, uint8(H2)},
+	{0xC7E5, 0xC7FF, uint8(H3)},
+	{0xC800, 0xC800, uint8(H2)},
+	{0xC801, 0xC81B, uint8(H3)},
+	{0xC81C, 0xC81C, uint8(H2)},
+	{0xC81D, 0xC837, uint8(H3)},
+	{0xC838, 0xC838, uint8(H2)},
+	{0xC839, 0xC853, uint8(H3)},
+	{0xC854, 0xC854, uint8(H2)},
+	{0xC855, 0xC86F, uint8(H3)},
+	{0xC870, 0xC870, uint8(H2)},
+	{0xC871, 0xC88B, uint8(H3)},
+	{0xC88C, 0xC88C, uint8(H2)},
+	{0xC88D, 0xC8A7, uint8(H3)},
+	{0xC8A8, 0xC8A8, uint8(H2)},
+	{0xC8A9, 0xC8C3, uint8(H3)},
+	{0xC8C4, 0xC8C4, uint8(H2)},
+	{0xC8C5, 0xC8DF, uint8(H3)},
+	{0xC8E0, 0xC8E0, uint8(H2)},
+	{0xC8E1, 0xC8FB, uint8(H3)},
+	{0xC8FC, 0xC8FC, uint8(H2)},
+	{0xC8FD, 0xC917, uint8(H3)},
+	{0xC918, 0xC918, uint8(H2)},
+	{0xC919, 0xC933, uint8(H3)},
+	{0xC934, 0xC934, uint8(H2)},
+	{0xC935, 0xC94F, uint8(H3)},
+	{0xC950, 0xC950, uint8(H2)},
+	{0xC951, 0xC96B, uint8(H3)},
+	{0xC96C, 0xC96C, uint8(H2)},
+	{0xC96D, 0xC987, uint8(H3)},
+	{0xC988, 0xC988, uint8(H2)},
+	{0xC989, 0xC9A3, uint8(H3)},
+	{0xC9A4, 0xC9A4, uint8(H2)},
+	{0xC9A5, 0xC9BF, uint8(H3)},
+	{0xC9C0, 0xC9C0, uint8(H2)},
+	{0xC9C1, 0xC9DB, uint8(H3)},
+	{0xC9DC, 0xC9DC, uint8(H2)},
+	{0xC9DD, 0xC9F7, uint8(H3)},
+	{0xC9F8, 0xC9F8, uint8(H2)},
+	{0xC9F9, 0xCA13, uint8(H3)},
+	{0xCA14, 0xCA14, uint8(H2)},
+	{0xCA15, 0xCA2F, uint8(H3)},
+	{0xCA30, 0xCA30, uint8(H2)},
+	{0xCA31, 0xCA4B, uint8(H3)},
+	{0xCA4C, 0xCA4C, uint8(H2)},
+	{0xCA4D, 0xCA67, uint8(H3)},
+	{0xCA68, 0xCA68, uint8(H2)},
+	{0xCA69, 0xCA83, uint8(H3)},
+	{0xCA84, 0xCA84, uint8(H2)},
+	{0xCA85, 0xCA9F, uint8(H3)},
+	{0xCAA0, 0xCAA0, uint8(H2)},
+	{0xCAA1, 0xCABB, uint8(H3)},
+	{0xCABC, 0xCABC, uint8(H2)},
+	{0xCABD, 0xCAD7, uint8(H3)},
+	{0xCAD8, 0xCAD8, uint8(H2)},
+	{0xCAD9, 0xCAF3, uint8(H3)},
+	{0xCAF4, 0xCAF4, uint8(H2)},
+	{0xCAF5, 0xCB0F, uint8(H3)},
+	{0xCB10, 0xCB10, uint8(H2)},
+	{0xCB11, 0xCB2B, uint8(H3)},
+	{0xCB2C, 0xCB2C, uint8(H2)},
+	{0xCB2D, 0xCB47, uint8(H3)},
+	{0xCB48, 0xCB48, uint8(H2)},
+	{0xCB49, 0xCB63, uint8(H3)},
+	{0xCB64, 0xCB64, uint8(H2)},
+	{0xCB65, 0xCB7F, uint8(H3)},
+	{0xCB80, 0xCB80, uint8(H2)},
+	{0xCB81, 0xCB9B, uint8(H3)},
+	{0xCB9C, 0xCB9C, uint8(H2)},
+	{0xCB9D, 0xCBB7, uint8(H3)},
+	{0xCBB8, 0xCBB8, uint8(H2)},
+	{0xCBB9, 0xCBD3, uint8(H3)},
+	{0xCBD4, 0xCBD4, uint8(H2)},
+	{0xCBD5, 0xCBEF, uint8(H3)},
+	{0xCBF0, 0xCBF0, uint8(H2)},
+	{0xCBF1, 0xCC0B, uint8(H3)},
+	{0xCC0C, 0xCC0C, uint8(H2)},
+	{0xCC0D, 0xCC27, uint8(H3)},
+	{0xCC28, 0xCC28, uint8(H2)},
+	{0xCC29, 0xCC43, uint8(H3)},
+	{0xCC44, 0xCC44, uint8(H2)},
+	{0xCC45, 0xCC5F, uint8(H3)},
+	{0xCC60, 0xCC60, uint8(H2)},
+	{0xCC61, 0xCC7B, uint8(H3)},
+	{0xCC7C, 0xCC7C, uint8(H2)},
+	{0xCC7D, 0xCC97, uint8(H3)},
+	{0xCC98, 0xCC98, uint8(H2)},
+	{0xCC99, 0xCCB3, uint8(H3)},
+	{0xCCB4, 0xCCB4, uint8(H2)},
+	{0xCCB5, 0xCCCF, uint8(H3)},
+	{0xCCD0, 0xCCD0, uint8(H2)},
+	{0xCCD1, 0xCCEB, uint8(H3)},
+	{0xCCEC, 0xCCEC, uint8(H2)},
+	{0xCCED, 0xCD07, uint8(H3)},
+	{0xCD08, 0xCD08, uint8(H2)},
+	{0xCD09, 0xCD23, uint8(H3)},
+	{0xCD24, 0xCD24, uint8(H2)},
+	{0xCD25, 0xCD3F, uint8(H3)},
+	{0xCD40, 0xCD40, uint8(H2)},
+	{0xCD41, 0xCD5B, uint8(H3)},
+	{0xCD5C, 0xCD5C, uint8(H2)},
+	{0xCD5D, 0xCD77, uint8(H3)},
+	{0xCD78, 0xCD78, uint8(H2)},
+	{0xCD79, 0xCD93, uint8(H3)},
+	{0xCD94, 0xCD94, uint8(H2)},
+	{0xCD95, 0xCDAF, uint8(H3)},
+	{0xCDB0, 0xCDB0, uint8(H2)},
+	{0xCDB1, 0xCDCB, uint8(H3)},
+	{0xCDCC, 0xCDCC, uint8(H2)},
+	{0xCDCD, 0xCDE7, uint8(H3)},
+	{0xCDE8, 0xCDE8, uint8(H2)},
+	{0xCDE9, 0xCE03, uint8(H3)},
+	{0xCE04, 0xCE04, uint8(H2)},
+	{0xCE05, 0xCE1F, uint8(H3)},
+	{0xCE20, 0xCE20, uint8(H2)},
+	{0xCE21, 0xCE3B, uint8(H3)},
+	{0xCE3C, 0xCE3C, uint8(H2)},
+	{0xCE3D, 0xCE57, uint8(H3)},
+	{0xCE58, 0xCE58, uint8(H2)},
+	{0xCE59, 0xCE73, uint8(H3)},
+	{0xCE74, 0xCE74, uint8(H2)},
+	{0xCE75, 0xCE8F, uint8(H3)},
+	{0xCE90, 0xCE90, uint8(H2)},
+	{0xCE91, 0xCEAB, uint8(H3)},
+	{0xCEAC, 0xCEAC, uint8(H2)},
+	{0xCEAD, 0xCEC7, uint8(H3)},
+	{0xCEC8, 0xCEC8, uint8(H2)},
+	{0xCEC9, 0xCEE3, uint8(H3)},
+	{0xCEE4, 0xCEE4, uint8(H2)},
+	{0xCEE5, 0xCEFF, uint8(H3)},
+	{0xCF00, 0xCF00, uint8(H2)},
+	{0xCF01, 0xCF1B, uint8(H3)},
+	{0xCF1C, 0xCF1C, uint8(H2)},
+	{0xCF1D, 0xCF37, uint8(H3)},
+	{0xCF38, 0xCF38, uint8(H2)},
+	{0xCF39, 0xCF53, uint8(H3)},
+	{0xCF54, 0xCF54, uint8(H2)},
+	{0xCF55, 0xCF6F, uint8(H3)},
+	{0xCF70, 0xCF70, uint8(H2)},
+	{0xCF71, 0xCF8B, uint8(H3)},
+	{0xCF8C, 0xCF8C, uint8(H2)},
+	{0xCF8D, 0xCFA7, uint8(H3)},
+	{0xCFA8, 0xCFA8, uint8(H2)},
+	{0xCFA9, 0xCFC3, uint8(H3)},
+	{0xCFC4, 0xCFC4, uint8(H2)},
+	{0xCFC5, 0xCFDF, uint8(H3)},
+	{0xCFE0, 0xCFE0, uint8(H2)},
+	{0xCFE1, 0xCFFB, uint8(H3)},
+	{0xCFFC, 0xCFFC, uint8(H2)},
+	{0xCFFD, 0xD017, uint8(H3)},
+	{0xD018, 0xD018, uint8(H2)},
+	{0xD019, 0xD033, uint8(H3)},
+	{0xD034, 0xD034, uint8(H2)},
+	{0xD035, 0xD04F, uint8(H3)},
+	{0xD050, 0xD050, uint8(H2)},
+	{0xD051, 0xD06B, uint8(H3)},
+	{0xD06C, 0xD06C, uint8(H2)},
+	{0xD06D, 0xD087, uint8(H3)},
+	{0xD088, 0xD088, uint8(H2)},
+	{0xD089, 0xD0A3, uint8(H3)},
+	{0xD0A4, 0xD0A4, uint8(H2)},
+	{0xD0A5, 0xD0BF, uint8(H3)},
+	{0xD0C0, 0xD0C0, uint8(H2)},
+	{0xD0C1, 0xD0DB, uint8(H3)},
+	{0xD0DC, 0xD0DC, uint8(H2)},
+	{0xD0DD, 0xD0F7, uint8(H3)},
+	{0xD0F8, 0xD0F8, uint8(H2)},
+	{0xD0F9, 0xD113, uint8(H3)},
+	{0xD114, 0xD114, uint8(H2)},
+	{0xD115, 0xD12F, uint8(H3)},
+	{0xD130, 0xD130, uint8(H2)},
+	{0xD131, 0xD14B, uint8(H3)},
+	{0xD14C, 0xD14C, uint8(H2)},
+	{0xD14D, 0xD167, uint8(H3)},
+	{0xD168, 0xD168, uint8(H2)},
+	{0xD169, 0xD183, uint8(H3)},
+	{0xD184, 0xD184, uint8(H2)},
+	{0xD185, 0xD19F, uint8(H3)},
+	{0xD1A0, 0xD1A0, uint8(H2)},
+	{0xD1A1, 0xD1BB, uint8(H3)},
+	{0xD1BC, 0xD1BC, uint8(H2)},
+	{0xD1BD, 0xD1D7, uint8(H3)},
+	{0xD1D8, 0xD1D8, uint8(H2)},
+	{0xD1D9, 0xD1F3, uint8(H3)},
+	{0xD1F4, 0xD1F4, uint8(H2)},
+	{0xD1F5, 0xD20F, uint8(H3)},
+	{0xD210, 0xD210, uint8(H2)},
+	{0xD211, 0xD22B, uint8(H3)},
+	{0xD22C, 0xD22C, uint8(H2)},
+	{0xD22D, 0xD247, uint8(H3)},
+	{0xD248, 0xD248, uint8(H2)},
+	{0xD249, 0xD263, uint8(H3)},
+	{0xD264, 0xD264, uint8(H2)},
+	{0xD265, 0xD27F, uint8(H3)},
+	{0xD280, 0xD280, uint8(H2)},
+	{0xD281, 0xD29B, uint8(H3)},
+	{0xD29C, 0xD29C, uint8(H2)},
+	{0xD29D, 0xD2B7, uint8(H3)},
+	{0xD2B8, 0xD2B8, uint8(H2)},
+	{0xD2B9, 0xD2D3, uint8(H3)},
+	{0xD2D4, 0xD2D4, uint8(H2)},
+	{0xD2D5, 0xD2EF, uint8(H3)},
+	{0xD2F0, 0xD2F0, uint8(H2)},
+	{0xD2F1, 0xD30B, uint8(H3)},
+	{0xD30C, 0xD30C, uint8(H2)},
+	{0xD30D, 0xD327, uint8(H3)},
+	{0xD328, 0xD328, uint8(H2)},
+	{0xD329, 0xD343, uint8(H3)},
+	{0xD344, 0xD344, uint8(H2)},
+	{0xD345, 0xD35F, uint8(H3)},
+	{0xD360, 0xD360, uint8(H2)},
+	{0xD361, 0xD37B, uint8(H3)},
+	{0xD37C, 0xD37C, uint8(H2)},
+	{0xD37D, 0xD397, uint8(H3)},
+	{0xD398, 0xD398, uint8(H2)},
+	{0xD399, 0xD3B3, uint8(H3)},
+	{0xD3B4, 0xD3B4, uint8(H2)},
+	{0xD3B5, 0xD3CF, uint8(H3)},
+	{0xD3D0, 0xD3D0, uint8(H2)},
+	{0xD3D1, 0xD3EB, uint8(H3)},
+	{0xD3EC, 0xD3EC, uint8(H2)},
+	{0xD3ED, 0xD407, uint8(H3)},
+	{0xD408, 0xD408, uint8(H2)},
+	{0xD409, 0xD423, uint8(H3)},
+	{0xD424, 0xD424, uint8(H2)},
+	{0xD425, 0xD43F, uint8(H3)},
+	{0xD440, 0xD440, uint8(H2)},
+	{0xD441, 0xD45B, uint8(H3)},
+	{0xD45C, 0xD45C, uint8(H2)},
+	{0xD45D, 0xD477, uint8(H3)},
+	{0xD478, 0xD478, uint8(H2)},
+	{0xD479, 0xD493, uint8(H3)},
+	{0xD494, 0xD494, uint8(H2)},
+	{0xD495, 0xD4AF, uint8(H3)},
+	{0xD4B0, 0xD4B0, uint8(H2)},
+	{0xD4B1, 0xD4CB, uint8(H3)},
+	{0xD4CC, 0xD4CC, uint8(H2)},
+	{0xD4CD, 0xD4E7, uint8(H3)},
+	{0xD4E8, 0xD4E8, uint8(H2)},
+	{0xD4E9, 0xD503, uint8(H3)},
+	{0xD504, 0xD504, uint8(H2)},
+	{0xD505, 0xD51F, uint8(H3)},
+	{0xD520, 0xD520, uint8(H2)},
+	{0xD521, 0xD53B, uint8(H3)},
+	{0xD53C, 0xD53C, uint8(H2)},
+	{0xD53D, 0xD557, uint8(H3)},
+	{0xD558, 0xD558, uint8(H2)},
+	{0xD559, 0xD573, uint8(H3)},
+	{0xD574, 0xD574, uint8(H2)},
+	{0xD575, 0xD58F, uint8(H3)},
+	{0xD590, 0xD590, uint8(H2)},
+	{0xD591, 0xD5AB, uint8(H3)},
+	{0xD5AC, 0xD5AC, uint8(H2)},
+	{0xD5AD, 0xD5C7, uint8(H3)},
+	{0xD5C8, 0xD5C8, uint8(H2)},
+	{0xD5C9, 0xD5E3, uint8(H3)},
+	{0xD5E4, 0xD5E4, uint8(H2)},
+	{0xD5E5, 0xD5FF, uint8(H3)},
+	{0xD600, 0xD600, uint8(H2)},
+	{0xD601, 0xD61B, uint8(H3)},
+	{0xD61C, 0xD61C, uint8(H2)},
+	{0xD61D, 0xD637, uint8(H3)},
+	{0xD638, 0xD638, uint8(H2)},
+	{0xD639, 0xD653, uint8(H3)},
+	{0xD654, 0xD654, uint8(H2)},
+	{0xD655, 0xD66F, uint8(H3)},
+	{0xD670, 0xD670, uint8(H2)},
+	{0xD671, 0xD68B, uint8(H3)},
+	{0xD68C, 0xD68C, uint8(H2)},
+	{0xD68D, 0xD6A7, uint8(H3)},
+	{0xD6A8, 0xD6A8, uint8(H2)},
+	{0xD6A9, 0xD6C3, uint8(H3)},
+	{0xD6C4, 0xD6C4, uint8(H2)},
+	{0xD6C5, 0xD6DF, uint8(H3)},
+	{0xD6E0, 0xD6E0, uint8(H2)},
+	{0xD6E1, 0xD6FB, uint8(H3)},
+	{0xD6FC, 0xD6FC, uint8(H2)},
+	{0xD6FD, 0xD717, uint8(H3)},
+	{0xD718, 0xD718, uint8(H2)},
+	{0xD719, 0xD733, uint8(H3)},
+	{0xD734, 0xD734, uint8(H2)},
+	{0xD735, 0xD74F, uint8(H3)},
+	{0xD750, 0xD750, uint8(H2)},
+	{0xD751, 0xD76B, uint8(H3)},
+	{0xD76C, 0xD76C, uint8(H2)},
+	{0xD76D, 0xD787, uint8(H3)},
+	{0xD788, 0xD788, uint8(H2)},
+	{0xD789, 0xD7A3, uint8(H3)},
+	{0xD7B0, 0xD7C6, uint8(JV)},
+	{0xD7CB, 0xD7FB, uint8(JT)},
+	{0xD800, 0xDFFF, uint8(SG)},
+	{0xF900, 0xFAFF, uint8(ID)},
+	{0xFB00, 0xFB06, uint8(AL)},
+	{0xFB13, 0xFB17, uint8(AL)},
+	{0xFB1D, 0xFB1D, uint8(HL)},
+	{0xFB1E, 0xFB1E, uint8(CM)},
+	{0xFB1F, 0xFB28, uint8(HL)},
+	{0xFB29, 0xFB29, uint8(AL)},
+	{0xFB2A, 0xFB36, uint8(HL)},
+	{0xFB38, 0xFB3C, uint8(HL)},
+	{0xFB3E, 0xFB3E, uint8(HL)},
+	{0xFB40, 0xFB41, uint8(HL)},
+	{0xFB43, 0xFB44, uint8(HL)},
+	{0xFB46, 0xFB4F, uint8(HL)},
+	{0xFB50, 0xFBC2, uint8(AL)},
+	{0xFBD3, 0xFD3D, uint8(AL)},
+	{0xFD3E, 0xFD3E, uint8(CL)},
+	{0xFD3F, 0xFD3F, uint8(OP)},
+	{0xFD40, 0xFD8F, uint8(AL)},
+	{0xFD92, 0xFDC7, uint8(AL)},
+	{0xFDCF, 0xFDCF, uint8(AL)},
+	{0xFDF0, 0xFDFB, uint8(AL)},
+	{0xFDFC, 0xFDFC, uint8(PO)},
+	{0xFDFD, 0xFDFF, uint8(AL)},
+	{0xFE00, 0xFE0F, uint8(CM)},
+	{0xFE10, 0xFE10, uint8(IS)},
+	{0xFE11, 0xFE12, uint8(CL)},
+	{0xFE13, 0xFE14, uint8(IS)},
+	{0xFE15, 0xFE16, uint8(EX)},
+	{0xFE17, 0xFE17, uint8(OP)},
+	{0xFE18, 0xFE18, uint8(CL)},
+	{0xFE19, 0xFE19, uint8(IN)},
+	{0xFE20, 0xFE2F, uint8(CM)},
+	{0xFE30, 0xFE34, uint8(ID)},
+	{0xFE35, 0xFE35, uint8(OP)},
+	{0xFE36, 0xFE36, uint8(CL)},
+	{0xFE37, 0xFE37, uint8(OP)},
+	{0xFE38, 0xFE38, uint8(CL)},
+	{0xFE39, 0xFE39, uint8(OP)},
+	{0xFE3A, 0xFE3A, uint8(CL)},
+	{0xFE3B, 0xFE3B, uint8(OP)},
+	{0xFE3C, 0xFE3C, uint8(CL)},
+	{0xFE3D, 0xFE3D, uint8(OP)},
+	{0xFE3E, 0xFE3E, uint8(CL)},
+	{0xFE3F, 0xFE3F, uint8(OP)},
+	{0xFE40, 0xFE40, uint8(CL)},
+	{0xFE41, 0xFE41, uint8(OP)},
+	{0xFE42, 0xFE42, uint8(CL)},
+	{0xFE43, 0xFE43, uint8(OP)},
+	{0xFE44, 0xFE44, uint8(CL)},
+	{0xFE45, 0xFE46, uint8(ID)},
+	{0xFE47, 0xFE47, uint8(OP)},
+	{0xFE48, 0xFE48, uint8(CL)},
+	{0xFE49, 0xFE4F, uint8(ID)},
+	{0xFE50, 0xFE50, uint8(CL)},
+	{0xFE51, 0xFE51, uint8(ID)},
+	{0xFE52, 0xFE52, uint8(CL)},
+	{0xFE54, 0xFE55, uint8(NS)},
+	{0xFE56, 0xFE57, uint8(EX)},
+	{0xFE58, 0xFE58, uint8(ID)},
+	{0xFE59, 0xFE59, uint8(OP)},
+	{0xFE5A, 0xFE5A, uint8(CL)},
+	{0xFE5B, 0xFE5B, uint8(OP)},
+	{0xFE5C, 0xFE5C, uint8(CL)},
+	{0xFE5D, 0xFE5D, uint8(OP)},
+	{0xFE5E, 0xFE5E, uint8(CL)},
+	{0xFE5F, 0xFE66, uint8(ID)},
+	{0xFE68, 0xFE68, uint8(ID)},
+	{0xFE69, 0xFE69, uint8(PR)},
+	{0xFE6A, 0xFE6A, uint8(PO)},
+	{0xFE6B, 0xFE6B, uint8(ID)},
+	{0xFE70, 0xFE74, uint8(AL)},
+	{0xFE76, 0xFEFC, uint8(AL)},
+	{0xFEFF, 0xFEFF, uint8(WJ)},
+	{0xFF01, 0xFF01, uint8(EX)},
+	{0xFF02, 0xFF03, uint8(ID)},
+	{0xFF04, 0xFF04, uint8(PR)},
+	{0xFF05, 0xFF05, uint8(PO)},
+	{0xFF06, 0xFF07, uint8(ID)},
+	{0xFF08, 0xFF08, uint8(OP)},
+	{0xFF09, 0xFF09, uint8(CL)},
+	{0xFF0A, 0xFF0B, uint8(ID)},
+	{0xFF0C, 0xFF0C, uint8(CL)},
+	{0xFF0D, 0xFF0D, uint8(ID)},
+	{0xFF0E, 0xFF0E, uint8(CL)},
+	{0xFF0F, 0xFF19, uint8(ID)},
+	{0xFF1A, 0xFF1B, uint8(NS)},
+	{0xFF1C, 0xFF1E, uint8(ID)},
+	{0xFF1F, 0xFF1F, uint8(EX)},
+	{0xFF20, 0xFF3A, uint8(ID)},
+	{0xFF3B, 0xFF3B, uint8(OP)},
+	{0xFF3C, 0xFF3C, uint8(ID)},
+	{0xFF3D, 0xFF3D, uint8(CL)},
+	{0xFF3E, 0xFF5A, uint8(ID)},
+	{0xFF5B, 0xFF5B, uint8(OP)},
+	{0xFF5C, 0xFF5C, uint8(ID)},
+	{0xFF5D, 0xFF5D, uint8(CL)},
+	{0xFF5E, 0xFF5E, uint8(ID)},
+	{0xFF5F, 0xFF5F, uint8(OP)},
+	{0xFF60, 0xFF61, uint8(CL)},
+	{0xFF62, 0xFF62, uint8(OP)},
+	{0xFF63, 0xFF64, uint8(CL)},
+	{0xFF65, 0xFF65, uint8(NS)},
+	{0xFF66, 0xFF66, uint8(ID)},
+	{0xFF67, 0xFF70, uint8(CJ)},
+	{0xFF71, 0xFF9D, uint8(ID)},
+	{0xFF9E, 0xFF9F, uint8(NS)},
+	{0xFFA0, 0xFFBE, uint8(ID)},
+	{0xFFC2, 0xFFC7, uint8(ID)},
+	{0xFFCA, 0xFFCF, uint8(ID)},
+	{0xFFD2, 0xFFD7, uint8(ID)},
+	{0xFFDA, 0xFFDC, uint8(ID)},
+	{0xFFE0, 0xFFE0, uint8(PO)},
+	{0xFFE1, 0xFFE1, uint8(PR)},
+	{0xFFE2, 0xFFE4, uint8(ID)},
+	{0xFFE5, 0xFFE6, uint8(PR)},
+	{0xFFE8, 0xFFEE, uint8(AL)},
+	{0xFFF9, 0xFFFB, uint8(CM)},
+	{0xFFFC, 0xFFFC, uint8(CB)},
+	{0xFFFD, 0xFFFD, uint8(AI)},
+	{0x10000, 0x1000B, uint8(AL)},
+	{0x1000D, 0x10026, uint8(AL)},
+	{0x10028, 0x1003A, uint8(AL)},
+	{0x1003C, 0x1003D, uint8(AL)},
+	{0x1003F, 0x1004D, uint8(AL)},
+	{0x10050, 0x1005D, uint8(AL)},
+	{0x10080, 0x100FA, uint8(AL)},
+	{0x10100, 0x10102, uint8(BA)},
+	{0x10107, 0x10133, uint8(AL)},
+	{0x10137, 0x1018E, uint8(AL)},
+	{0x10190, 0x1019C, uint8(AL)},
+	{0x101A0, 0x101A0, uint8(AL)},
+	{0x101D0, 0x101FC, uint8(AL)},
+	{0x101FD, 0x101FD, uint8(CM)},
+	{0x10280, 0x1029C, uint8(AL)},
+	{0x102A0, 0x102D0, uint8(AL)},
+	{0x102E0, 0x102E0, uint8(CM)},
+	{0x102E1, 0x102FB, uint8(AL)},
+	{0x10300, 0x10323, uint8(AL)},
+	{0x1032D, 0x1034A, uint8(AL)},
+	{0x10350, 0x10375, uint8(AL)},
+	{0x10376, 0x1037A, uint8(CM)},
+	{0x10380, 0x1039D, uint8(AL)},
+	{0x1039F, 0x1039F, uint8(BA)},
+	{0x103A0, 0x103C3, uint8(AL)},
+	{0x103C8, 0x103CF, uint8(AL)},
+	{0x103D0, 0x103D0, uint8(BA)},
+	{0x103D1, 0x103D5, uint8(AL)},
+	{0x10400, 0x1049D, uint8(AL)},
+	{0x104A0, 0x104A9, uint8(NU)},
+	{0x104B0, 0x104D3, uint8(AL)},
+	{0x104D8, 0x104FB, uint8(AL)},
+	{0x10500, 0x10527, uint8(AL)},
+	{0x10530, 0x10563, uint8(AL)},
+	{0x1056F, 0x1057A, uint8(AL)},
+	{0x1057C, 0x1058A, uint8(AL)},
+	{0x1058C, 0x10592, uint8(AL)},
+	{0x10594, 0x10595, uint8(AL)},
+	{0x10597, 0x105A1, uint8(AL)},
+	{0x105A3, 0x105B1, uint8(AL)},
+	{0x105B3, 0x105B9, uint8(AL)},
+	{0x105BB, 0x105BC, uint8(AL)},
+	{0x10600, 0x10736, uint8(AL)},
+	{0x10740, 0x10755, uint8(AL)},
+	{0x10760, 0x10767, uint8(AL)},
+	{0x10780, 0x10785, uint8(AL)},
+	{0x10787, 0x107B0, uint8(AL)},
+	{0x107B2, 0x107BA, uint8(AL)},
+	{0x10800, 0x10805, uint8(AL)},
+	{0x10808, 0x10808, uint8(AL)},
+	{0x1080A, 0x10835, uint8(AL)},
+	{0x10837, 0x10838, uint8(AL)},
+	{0x1083C, 0x1083C, uint8(AL)},
+	{0x1083F, 0x10855, uint8(AL)},
+	{0x10857, 0x10857, uint8(BA)},
+	{0x10858, 0x1089E, uint8(AL)},
+	{0x108A7, 0x108AF, uint8(AL)},
+	{0x108E0, 0x108F2, uint8(AL)},
+	{0x108F4, 0x108F5, uint8(AL)},
+	{0x108FB, 0x1091B, uint8(AL)},
+	{0x1091F, 0x1091F, uint8(BA)},
+	{0x10920, 0x10939, uint8(AL)},
+	{0x1093F, 0x1093F, uint8(AL)},
+	{0x10980, 0x109B7, uint8(AL)},
+	{0x109BC, 0x109CF, uint8(AL)},
+	{0x109D2, 0x10A00, uint8(AL)},
+	{0x10A01, 0x10A03, uint8(CM)},
+	{0x10A05, 0x10A06, uint8(CM)},
+	{0x10A0C, 0x10A0F, uint8(CM)},
+	{0x10A10, 0x10A13, uint8(AL)},
+	{0x10A15, 0x10A17, uint8(AL)},
+	{0x10A19, 0x10A35, uint8(AL)},
+	{0x10A38, 0x10A3A, uint8(CM)},
+	{0x10A3F, 0x10A3F, uint8(CM)},
+	{0x10A40, 0x10A48, uint8(AL)},
+	{0x10A50, 0x10A57, uint8(BA)},
+	{0x10A58, 0x10A58, uint8(AL)},
+	{0x10A60, 0x10A9F, uint8(AL)},
+	{0x10AC0, 0x10AE4, uint8(AL)},
+	{0x10AE5, 0x10AE6, uint8(CM)},
+	{0x10AEB, 0x10AEF, uint8(AL)},
+	{0x10AF0, 0x10AF5, uint8(BA)},
+	{0x10AF6, 0x10AF6, uint8(IN)},
+	{0x10B00, 0x10B35, uint8(AL)},
+	{0x10B39, 0x10B3F, uint8(BA)},
+	{0x10B40, 0x10B55, uint8(AL)},
+	{0x10B58, 0x10B72, uint8(AL)},
+	{0x10B78, 0x10B91, uint8(AL)},
+	{0x10B99, 0x10B9C, uint8(AL)},
+	{0x10BA9, 0x10BAF, uint8(AL)},
+	{0x10C00, 0x10C48, uint8(AL)},
+	{0x10C80, 0x10CB2, uint8(AL)},
+	{0x10CC0, 0x10CF2, uint8(AL)},
+	{0x10CFA, 0x10D23, uint8(AL)},
+	{0x10D24, 0x10D27, uint8(CM)},
+	{0x10D30, 0x10D39, uint8(NU)},
+	{0x10E60, 0x10E7E, uint8(AL)},
+	{0x10E80, 0x10EA9, uint8(AL)},
+	{0x10EAB, 0x10EAC, uint8(CM)},
+	{0x10EAD, 0x10EAD, uint8(BA)},
+	{0x10EB0, 0x10EB1, uint8(AL)},
+	{0x10F00, 0x10F27, uint8(AL)},
+	{0x10F30, 0x10F45, uint8(AL)},
+	{0x10F46, 0x10F50, uint8(CM)},
+	{0x10F51, 0x10F59, uint8(AL)},
+	{0x10F70, 0x10F81, uint8(AL)},
+	{0x10F82, 0x10F85, uint8(CM)},
+	{0x10F86, 0x10F89, uint8(AL)},
+	{0x10FB0, 0x10FCB, uint8(AL)},
+	{0x10FE0, 0x10FF6, uint8(AL)},
+	{0x11000, 0x11002, uint8(CM)},
+	{0x11003, 0x11037, uint8(AL)},
+	{0x11038, 0x11046, uint8(CM)},
+	{0x11047, 0x11048, uint8(BA)},
+	{0x11049, 0x1104D, uint8(AL)},
+	{0x11052, 0x11065, uint8(AL)},
+	{0x11066, 0x1106F, uint8(NU)},
+	{0x11070, 0x11070, uint8(CM)},
+	{0x11071, 0x11072, uint8(AL)},
+	{0x11073, 0x11074, uint8(CM)},
+	{0x11075, 0x11075, uint8(AL)},
+	{0x1107F, 0x11082, uint8(CM)},
+	{0x11083, 0x110AF, uint8(AL)},
+	{0x110B0, 0x110BA, uint8(CM)},
+	{0x110BB, 0x110BD, uint8(AL)},
+	{0x110BE, 0x110C1, uint8(BA)},
+	{0x110C2, 0x110C2, uint8(CM)},
+	{0x110CD, 0x110CD, uint8(AL)},
+	{0x110D0, 0x110E8, uint8(AL)},
+	{0x110F0, 0x110F9, uint8(NU)},
+	{0x11100, 0x11102, uint8(CM)},
+	{0x11103, 0x11126, uint8(AL)},
+	{0x11127, 0x11134, uint8(CM)},
+	{0x11136, 0x1113F, uint8(NU)},
+	{0x11140, 0x11143, uint8(BA)},
+	{0x11144, 0x11144, uint8(AL)},
+	{0x11145, 0x11146, uint8(CM)},
+	{0x11147, 0x11147, uint8(AL)},
+	{0x11150, 0x11172, uint8(AL)},
+	{0x11173, 0x11173, uint8(CM)},
+	{0x11174, 0x11174, uint8(AL)},
+	{0x11175, 0x11175, uint8(BB)},
+	{0x11176, 0x11176, uint8(AL)},
+	{0x11180, 0x11182, uint8(CM)},
+	{0x11183, 0x111B2, uint8(AL)},
+	{0x111B3, 0x111C0, uint8(CM)},
+	{0x111C1, 0x111C4, uint8(AL)},
+	{0x111C5, 0x111C6, uint8(BA)},
+	{0x111C7, 0x111C7, uint8(AL)},
+	{0x111C8, 0x111C8, uint8(BA)},
+	{0x111C9, 0x111CC, uint8(CM)},
+	{0x111CD, 0x111CD, uint8(AL)},
+	{0x111CE, 0x111CF, uint8(CM)},
+	{0x111D0, 0x111D9, uint8(NU)},
+	{0x111DA, 0x111DA, uint8(AL)},
+	{0x111DB, 0x111DB, uint8(BB)},
+	{0x111DC, 0x111DC, uint8(AL)},
+	{0x111DD, 0x111DF, uint8(BA)},
+	{0x111E1, 0x111F4, uint8(AL)},
+	{0x11200, 0x11211, uint8(AL)},
+	{0x11213, 0x1122B, uint8(AL)},
+	{0x1122C, 0x11237, uint8(CM)},
+	{0x11238, 0x11239, uint8(BA)},
+	{0x1123A, 0x1123A, uint8(AL)},
+	{0x1123B, 0x1123C, uint8(BA)},
+	{0x1123D, 0x1123D, uint8(AL)},
+	{0x1123E, 0x1123E, uint8(CM)},
+	{0x11280, 0x11286, uint8(AL)},
+	{0x11288, 0x11288, uint8(AL)},
+	{0x1128A, 0x1128D, uint8(AL)},
+	{0x1128F, 0x1129D, uint8(AL)},
+	{0x1129F, 0x112A8, uint8(AL)},
+	{0x112A9, 0x112A9, uint8(BA)},
+	{0x112B0, 0x112DE, uint8(AL)},
+	{0x112DF, 0x112EA, uint8(CM)},
+	{0x112F0, 0x112F9, uint8(NU)},
+	{0x11300, 0x11303, uint8(CM)},
+	{0x11305, 0x1130C, uint8(AL)},
+	{0x1130F, 0x11310, uint8(AL)},
+	{0x11313, 0x11328, uint8(AL)},
+	{0x1132A, 0x11330, uint8(AL)},
+	{0x11332, 0x11333, uint8(AL)},
+	{0x11335, 0x11339, uint8(AL)},
+	{0x1133B, 0x1133C, uint8(CM)},
+	{0x1133D, 0x1133D, uint8(AL)},
+	{0x1133E, 0x11344, uint8(CM)},
+	{0x11347, 0x11348, uint8(CM)},
+	{0x1134B, 0x1134D, uint8(CM)},
+	{0x11350, 0x11350, uint8(AL)},
+	{0x11357, 0x11357, uint8(CM)},
+	{0x1135D, 0x11361, uint8(AL)},
+	{0x11362, 0x11363, uint8(CM)},
+	{0x11366, 0x1136C, uint8(CM)},
+	{0x11370, 0x11374, uint8(CM)},
+	{0x11400, 0x11434, uint8(AL)},
+	{0x11435, 0x11446, uint8(CM)},
+	{0x11447, 0x1144A, uint8(AL)},
+	{0x1144B, 0x1144E, uint8(BA)},
+	{0x1144F, 0x1144F, uint8(AL)},
+	{0x11450, 0x11459, uint8(NU)},
+	{0x1145A, 0x1145B, uint8(BA)},
+	{0x1145D, 0x1145D, uint8(AL)},
+	{0x1145E, 0x1145E, uint8(CM)},
+	{0x1145F, 0x11461, uint8(AL)},
+	{0x11480, 0x114AF, uint8(AL)},
+	{0x114B0, 0x114C3, uint8(CM)},
+	{0x114C4, 0x114C7, uint8(AL)},
+	{0x114D0, 0x114D9, uint8(NU)},
+	{0x11580, 0x115AE, uint8(AL)},
+	{0x115AF, 0x115B5, uint8(CM)},
+	{0x115B8, 0x115C0, uint8(CM)},
+	{0x115C1, 0x115C1, uint8(BB)},
+	{0x115C2, 0x115C3, uint8(BA)},
+	{0x115C4, 0x115C5, uint8(EX)},
+	{0x115C6, 0x115C8, uint8(AL)},
+	{0x115C9, 0x115D7, uint8(BA)},
+	{0x115D8, 0x115DB, uint8(AL)},
+	{0x115DC, 0x115DD, uint8(CM)},
+	{0x11600, 0x1162F, uint8(AL)},
+	{0x11630, 0x11640, uint8(CM)},
+	{0x11641, 0x11642, uint8(BA)},
+	{0x11643, 0x11644, uint8(AL)},
+	{0x11650, 0x11659, uint8(NU)},
+	{0x11660, 0x1166C, uint8(BB)},
+	{0x11680, 0x116AA, uint8(AL)},
+	{0x116AB, 0x116B7, uint8(CM)},
+	{0x116B8, 0x116B9, uint8(AL)},
+	{0x116C0, 0x116C9, uint8(NU)},
+	{0x11700, 0x1171A, uint8(SA)},
+	{0x1171D, 0x1172B, uint8(SA)},
+	{0x11730, 0x11739, uint8(NU)},
+	{0x1173A, 0x1173B, uint8(SA)},
+	{0x1173C, 0x1173E, uint8(BA)},
+	{0x1173F, 0x11746, uint8(SA)},
+	{0x11800, 0x1182B, uint8(AL)},
+	{0x1182C, 0x1183A, uint8(CM)},
+	{0x1183B, 0x1183B, uint8(AL)},
+	{0x118A0, 0x118DF, uint8(AL)},
+	{0x118E0, 0x118E9, uint8(NU)},
+	{0x118EA, 0x118F2, uint8(AL)},
+	{0x118FF, 0x11906, uint8(AL)},
+	{0x11909, 0x11909, uint8(AL)},
+	{0x1190C, 0x11913, uint8(AL)},
+	{0x11915, 0x11916, uint8(AL)},
+	{0x11918, 0x1192F, uint8(AL)},
+	{0x11930, 0x11935, uint8(CM)},
+	{0x11937, 0x11938, uint8(CM)},
+	{0x1193B, 0x1193E, uint8(CM)},
+	{0x1193F, 0x1193F, uint8(AL)},
+	{0x11940, 0x11940, uint8(CM)},
+	{0x11941, 0x11941, uint8(AL)},
+	{0x11942, 0x11943, uint8(CM)},
+	{0x11944, 0x11946, uint8(BA)},
+	{0x11950, 0x11959, uint8(NU)},
+	{0x119A0, 0x119A7, uint8(AL)},
+	{0x119AA, 0x119D0, uint8(AL)},
+	{0x119D1, 0x119D7, uint8(CM)},
+	{0x119DA, 0x119E0, uint8(CM)},
+	{0x119E1, 0x119E1, uint8(AL)},
+	{0x119E2, 0x119E2, uint8(BB)},
+	{0x119E3, 0x119E3, uint8(AL)},
+	{0x119E4, 0x119E4, uint8(CM)},
+	{0x11A00, 0x11A00, uint8(AL)},
+	{0x11A01, 0x11A0A, uint8(CM)},
+	{0x11A0B, 0x11A32, uint8(AL)},
+	{0x11A33, 0x11A39, uint8(CM)},
+	{0x11A3A, 0x11A3A, uint8(AL)},
+	{0x11A3B, 0x11A3E, uint8(CM)},
+	{0x11A3F, 0x11A3F, uint8(BB)},
+	{0x11A40, 0x11A40, uint8(AL)},
+	{0x11A41, 0x11A44, uint8(BA)},
+	{0x11A45, 0x11A45, uint8(BB)},
+	{0x11A46, 0x11A46, uint8(AL)},
+	{0x11A47, 0x11A47, uint8(CM)},
+	{0x11A50, 0x11A50, uint8(AL)},
+	{0x11A51, 0x11A5B, uint8(CM)},
+	{0x11A5C, 0x11A89, uint8(AL)},
+	{0x11A8A, 0x11A99, uint8(CM)},
+	{0x11A9A, 0x11A9C, uint8(BA)},
+	{0x11A9D, 0x11A9D, uint8(AL)},
+	{0x11A9E, 0x11AA0, uint8(BB)},
+	{0x11AA1, 0x11AA2, uint8(BA)},
+	{0x11AB0, 0x11AF8, uint8(AL)},
+	{0x11C00, 0x11C08, uint8(AL)},
+	{0x11C0A, 0x11C2E, uint8(AL)},
+	{0x11C2F, 0x11C36, uint8(CM)},
+	{0x11C38, 0x11C3F, uint8(CM)},
+	{0x11C40, 0x11C40, uint8(AL)},
+	{0x11C41, 0x11C45, uint8(BA)},
+	{0x11C50, 0x11C59, uint8(NU)},
+	{0x11C5A, 0x11C6C, uint8(AL)},
+	{0x11C70, 0x11C70, uint8(BB)},
+	{0x11C71, 0x11C71, uint8(EX)},
+	{0x11C72, 0x11C8F, uint8(AL)},
+	{0x11C92, 0x11CA7, uint8(CM)},
+	{0x11CA9, 0x11CB6, uint8(CM)},
+	{0x11D00, 0x11D06, uint8(AL)},
+	{0x11D08, 0x11D09, uint8(AL)},
+	{0x11D0B, 0x11D30, uint8(AL)},
+	{0x11D31, 0x11D36, uint8(CM)},
+	{0x11D3A, 0x11D3A, uint8(CM)},
+	{0x11D3C, 0x11D3D, uint8(CM)},
+	{0x11D3F, 0x11D45, uint8(CM)},
+	{0x11D46, 0x11D46, uint8(AL)},
+	{0x11D47, 0x11D47, uint8(CM)},
+	{0x11D50, 0x11D59, uint8(NU)},
+	{0x11D60, 0x11D65, uint8(AL)},
+	{0x11D67, 0x11D68, uint8(AL)},
+	{0x11D6A, 0x11D89, uint8(AL)},
+	{0x11D8A, 0x11D8E, uint8(CM)},
+	{0x11D90, 0x11D91, uint8(CM)},
+	{0x11D93, 0x11D97, uint8(CM)},
+	{0x11D98, 0x11D98, uint8(AL)},
+	{0x11DA0, 0x11DA9, uint8(NU)},
+	{0x11EE0, 0x11EF2, uint8(AL)},
+	{0x11EF3, 0x11EF6, uint8(CM)},
+	{0x11EF7, 0x11EF8, uint8(AL)},
+	{0x11FB0, 0x11FB0, uint8(AL)},
+	{0x11FC0, 0x11FDC, uint8(AL)},
+	{0x11FDD, 0x11FE0, uint8(PO)},
+	{0x11FE1, 0x11FF1, uint8(AL)},
+	{0x11FFF, 0x11FFF, uint8(BA)},
+	{0x12000, 0x12399, uint8(AL)},
+	{0x12400, 0x1246E, uint8(AL)},
+	{0x12470, 0x12474, uint8(BA)},
+	{0x12480, 0x12543, uint8(AL)},
+	{0x12F90, 0x12FF2, uint8(AL)},
+	{0x13000, 0x13257, uint8(AL)},
+	{0x13258, 0x1325A, uint8(OP)},
+	{0x1325B, 0x1325D, uint8(CL)},
+	{0x1325E, 0x13281, uint8(AL)},
+	{0x13282, 0x13282, uint8(CL)},
+	{0x13283, 0x13285, uint8(AL)},
+	{0x13286, 0x13286, uint8(OP)},
+	{0x13287, 0x13287, uint8(CL)},
+	{0x13288, 0x13288, uint8(OP)},
+	{0x13289, 0x13289, uint8(CL)},
+	{0x1328A, 0x13378, uint8(AL)},
+	{0x13379, 0x13379, uint8(OP)},
+	{0x1337A, 0x1337B, uint8(CL)},
+	{0x1337C, 0x1342E, uint8(AL)},
+	{0x13430, 0x13436, uint8(GL)},
+	{0x13437, 0x13437, uint8(OP)},
+	{0x13438, 0x13438, uint8(CL)},
+	{0x14400, 0x145CD, uint8(AL)},
+	{0x145CE, 0x145CE, uint8(OP)},
+	{0x145CF, 0x145CF, uint8(CL)},
+	{0x145D0, 0x14646, uint8(AL)},
+	{0x16800, 0x16A38, uint8(AL)},
+	{0x16A40, 0x16A5E, uint8(AL)},
+	{0x16A60, 0x16A69, uint8(NU)},
+	{0x16A6E, 0x16A6F, uint8(BA)},
+	{0x16A70, 0x16ABE, uint8(AL)},
+	{0x16AC0, 0x16AC9, uint8(NU)},
+	{0x16AD0, 0x16AED, uint8(AL)},
+	{0x16AF0, 0x16AF4, uint8(CM)},
+	{0x16AF5, 0x16AF5, uint8(BA)},
+	{0x16B00, 0x16B2F, uint8(AL)},
+	{0x16B30, 0x16B36, uint8(CM)},
+	{0x16B37, 0x16B39, uint8(BA)},
+	{0x16B3A, 0x16B43, uint8(AL)},
+	{0x16B44, 0x16B44, uint8(BA)},
+	{0x16B45, 0x16B45, uint8(AL)},
+	{0x16B50, 0x16B59, uint8(NU)},
+	{0x16B5B, 0x16B61, uint8(AL)},
+	{0x16B63, 0x16B77, uint8(AL)},
+	{0x16B7D, 0x16B8F, uint8(AL)},
+	{0x16E40, 0x16E96, uint8(AL)},
+	{0x16E97, 0x16E98, uint8(BA)},
+	{0x16E99, 0x16E9A, uint8(AL)},
+	{0x16F00, 0x16F4A, uint8(AL)},
+	{0x16F4F, 0x16F4F, uint8(CM)},
+	{0x16F50, 0x16F50, uint8(AL)},
+	{0x16F51, 0x16F87, uint8(CM)},
+	{0x16F8F, 0x16F92, uint8(CM)},
+	{0x16F93, 0x16F9F, uint8(AL)},
+	{0x16FE0, 0x16FE3, uint8(NS)},
+	{0x16FE4, 0x16FE4, uint8(GL)},
+	{0x16FF0, 0x16FF1, uint8(CM)},
+	{0x17000, 0x187F7, uint8(ID)},
+	{0x18800, 0x18AFF, uint8(ID)},
+	{0x18B00, 0x18CD5, uint8(AL)},
+	{0x18D00, 0x18D08, uint8(ID)},
+	{0x1AFF0, 0x1AFF3, uint8(AL)},
+	{0x1AFF5, 0x1AFFB, uint8(AL)},
+	{0x1AFFD, 0x1AFFE, uint8(AL)},
+	{0x1B000, 0x1B122, uint8(ID)},
+	{0x1B150, 0x1B152, uint8(CJ)},
+	{0x1B164, 0x1B167, uint8(CJ)},
+	{0x1B170, 0x1B2FB, uint8(ID)},
+	{0x1BC00, 0x1BC6A, uint8(AL)},
+	{0x1BC70, 0x1BC7C, uint8(AL)},
+	{0x1BC80, 0x1BC88, uint8(AL)},
+	{0x1BC90, 0x1BC99, uint8(AL)},
+	{0x1BC9C, 0x1BC9C, uint8(AL)},
+	{0x1BC9D, 0x1BC9E, uint8(CM)},
+	{0x1BC9F, 0x1BC9F, uint8(BA)},
+	{0x1BCA0, 0x1BCA3, uint8(CM)},
+	{0x1CF00, 0x1CF2D, uint8(CM)},
+	{0x1CF30, 0x1CF46, uint8(CM)},
+	{0x1CF50, 0x1CFC3, uint8(AL)},
+	{0x1D000, 0x1D0F5, uint8(AL)},
+	{0x1D100, 0x1D126, uint8(AL)},
+	{0x1D129, 0x1D164, uint8(AL)},
+	{0x1D165, 0x1D169, uint8(CM)},
+	{0x1D16A, 0x1D16C, uint8(AL)},
+	{0x1D16D, 0x1D182, uint8(CM)},
+	{0x1D183, 0x1D184, uint8(AL)},
+	{0x1D185, 0x1D18B, uint8(CM)},
+	{0x1D18C, 0x1D1A9, uint8(AL)},
+	{0x1D1AA, 0x1D1AD, uint8(CM)},
+	{0x1D1AE, 0x1D1EA, uint8(AL)},
+	{0x1D200, 0x1D241, uint8(AL)},
+	{0x1D242, 0x1D244, uint8(CM)},
+	{0x1D245, 0x1D245, uint8(AL)},
+	{0x1D2E0, 0x1D2F3, uint8(AL)},
+	{0x1D300, 0x1D356, uint8(AL)},
+	{0x1D360, 0x1D378, uint8(AL)},
+	{0x1D400, 0x1D454, uint8(AL)},
+	{0x1D456, 0x1D49C, uint8(AL)},
+	{0x1D49E, 0x1D49F, uint8(AL)},
+	{0x1D4A2, 0x1D4A2, uint8(AL)},
+	{0x1D4A5, 0x1D4A6, uint8(AL)},
+	{0x1D4A9, 0x1D4AC, uint8(AL)},
+	{0x1D4AE, 0x1D4B9, uint8(AL)},
+	{0x1D4BB, 0x1D4BB, uint8(AL)},
+	{0x1D4BD, 0x1D4C3, uint8(AL)},
+	{0x1D4C5, 0x1D505, uint8(AL)},
+	{0x1D507, 0x1D50A, uint8(AL)},
+	{0x1D50D, 0x1D514, uint8(AL)},
+	{0x1D516, 0x1D51C, uint8(AL)},
+	{0x1D51E, 0x1D539, uint8(AL)},
+	{0x1D53B, 0x1D53E, uint8(AL)},
+	{0x1D540, 0x1D544, uint8(AL)},
+	{0x1D546, 0x1D546, uint8(AL)},
+	{0x1D54A, 0x1D550, uint8(AL)},
+	{0x1D552, 0x1D6A5, uint8(AL)},
+	{0x1D6A8, 0x1D7CB, uint8(AL)},
+	{0x1D7CE, 0x1D7FF, uint8(NU)},
+	{0x1D800, 0x1D9FF, uint8(AL)},
+	{0x1DA00, 0x1DA36, uint8(CM)},
+	{0x1DA37, 0x1DA3A, uint8(AL)},
+	{0x1DA3B, 0x1DA6C, uint8(CM)},
+	{0x1DA6D, 0x1DA74, uint8(AL)},
+	{0x1DA75, 0x1DA75, uint8(CM)},
+	{0x1DA76, 0x1DA83, uint8(AL)},
+	{0x1DA84, 0x1DA84, uint8(CM)},
+	{0x1DA85, 0x1DA86, uint8(AL)},
+	{0x1DA87, 0x1DA8A, uint8(BA)},
+	{0x1DA8B, 0x1DA8B, uint8(AL)},
+	{0x1DA9B, 0x1DA9F, uint8(CM)},
+	{0x1DAA1, 0x1DAAF, uint8(CM)},
+	{0x1DF00, 0x1DF1E, uint8(AL)},
+	{0x1E000, 0x1E006, uint8(CM)},
+	{0x1E008, 0x1E018, uint8(CM)},
+	{0x1E01B, 0x1E021, uint8(CM)},
+	{0x1E023, 0x1E024, uint8(CM)},
+	{0x1E026, 0x1E02A, uint8(CM)},
+	{0x1E100, 0x1E12C, uint8(AL)},
+	{0x1E130, 0x1E136, uint8(CM)},
+	{0x1E137, 0x1E13D, uint8(AL)},
+	{0x1E140, 0x1E149, uint8(NU)},
+	{0x1E14E, 0x1E14F, uint8(AL)},
+	{0x1E290, 0x1E2AD, uint8(AL)},
+	{0x1E2AE, 0x1E2AE, uint8(CM)},
+	{0x1E2C0, 0x1E2EB, uint8(AL)},
+	{0x1E2EC, 0x1E2EF, uint8(CM)},
+	{0x1E2F0, 0x1E2F9, uint8(NU)},
+	{0x1E2FF, 0x1E2FF, uint8(PR)},
+	{0x1E7E0, 0x1E7E6, uint8(AL)},
+	{0x1E7E8, 0x1E7EB, uint8(AL)},
+	{0x1E7ED, 0x1E7EE, uint8(AL)},
+	{0x1E7F0, 0x1E7FE, uint8(AL)},
+	{0x1E800, 0x1E8C4, uint8(AL)},
+	{0x1E8C7, 0x1E8CF, uint8(AL)},
+	{0x1E8D0, 0x1E8D6, uint8(CM)},
+	{0x1E900, 0x1E943, uint8(AL)},
+	{0x1E944, 0x1E94A, uint8(CM)},
+	{0x1E94B, 0x1E94B, uint8(AL)},
+	{0x1E950, 0x1E959, uint8(NU)},
+	{0x1E95E, 0x1E95F, uint8(OP)},
+	{0x1EC71, 0x1ECAB, uint8(AL)},
+	{0x1ECAC, 0x1ECAC, uint8(PO)},
+	{0x1ECAD, 0x1ECAF, uint8(AL)},
+	{0x1ECB0, 0x1ECB0, uint8(PO)},
+	{0x1ECB1, 0x1ECB4, uint8(AL)},
+	{0x1ED01, 0x1ED3D, uint8(AL)},
+	{0x1EE00, 0x1EE03, uint8(AL)},
+	{0x1EE05, 0x1EE1F, uint8(AL)},
+	{0x1EE21, 0x1EE22, uint8(AL)},
+	{0x1EE24, 0x1EE24, uint8(AL)},
+	{0x1EE27, 0x1EE27, uint8(AL)},
+	{0x1EE29, 0x1EE32, uint8(AL)},
+	{0x1EE34, 0x1EE37, uint8(AL)},
+	{0x1EE39, 0x1EE39, uint8(AL)},
+	{0x1EE3B, 0x1EE3B, uint8(AL)},
+	{0x1EE42, 0x1EE42, uint8(AL)},
+	{0x1EE47, 0x1EE47, uint8(AL)},
+	{0x1EE49, 0x1EE49, uint8(AL)},
+	{0x1EE4B, 0x1EE4B, uint8(AL)},
+	{0x1EE4D, 0x1EE4F, uint8(AL)},
+	{0x1EE51, 0x1EE52, uint8(AL)},
+	{0x1EE54, 0x1EE54, uint8(AL)},
+	{0x1EE57, 0x1EE57, uint8(AL)},
+	{0x1EE59, 0x1EE59, uint8(AL)},
+	{0x1EE5B, 0x1EE5B, uint8(AL)},
+	{0x1EE5D, 0x1EE5D, uint8(AL)},
+	{0x1EE5F, 0x1EE5F, uint8(AL)},
+	{0x1EE61, 0x1EE62, uint8(AL)},
+	{0x1EE64, 0x1EE64, uint8(AL)},
+	{0x1EE67, 0x1EE6A, uint8(AL)},
+	{0x1EE6C, 0x1EE72, uint8(AL)},
+	{0x1EE74, 0x1EE77, uint8(AL)},
+	{0x1EE79, 0x1EE7C, uint8(AL)},
+	{0x1EE7E, 0x1EE7E, uint8(AL)},
+	{0x1EE80, 0x1EE89, uint8(AL)},
+	{0x1EE8B, 0x1EE9B, uint8(AL)},
+	{0x1EEA1, 0x1EEA3, uint8(AL)},
+	{0x1EEA5, 0x1EEA9, uint8(AL)},
+	{0x1EEAB, 0x1EEBB, uint8(AL)},
+	{0x1EEF0, 0x1EEF1, uint8(AL)},
+	{0x1F000, 0x1F0FF, uint8(ID)},
+	{0x1F100, 0x1F10C, uint8(AI)},
+	{0x1F10D, 0x1F10F, uint8(ID)},
+	{0x1F110, 0x1F12D, uint8(AI)},
+	{0x1F12E, 0x1F12F, uint8(AL)},
+	{0x1F130, 0x1F169, uint8(AI)},
+	{0x1F16A, 0x1F16C, uint8(AL)},
+	{0x1F16D, 0x1F16F, uint8(ID)},
+	{0x1F170, 0x1F1AC, uint8(AI)},
+	{0x1F1AD, 0x1F1E5, uint8(ID)},
+	{0x1F1E6, 0x1F1FF, uint8(RI)},
+	{0x1F200, 0x1F384, uint8(ID)},
+	{0x1F385, 0x1F385, uint8(EB)},
+	{0x1F386, 0x1F39B, uint8(ID)},
+	{0x1F39C, 0x1F39D, uint8(AL)},
+	{0x1F39E, 0x1F3B4, uint8(ID)},
+	{0x1F3B5, 0x1F3B6, uint8(AL)},
+	{0x1F3B7, 0x1F3BB, uint8(ID)},
+	{0x1F3BC, 0x1F3BC, uint8(AL)},
+	{0x1F3BD, 0x1F3C1, uint8(ID)},
+	{0x1F3C2, 0x1F3C4, uint8(EB)},
+	{0x1F3C5, 0x1F3C6, uint8(ID)},
+	{0x1F3C7, 0x1F3C7, uint8(EB)},
+	{0x1F3C8, 0x1F3C9, uint8(ID)},
+	{0x1F3CA, 0x1F3CC, uint8(EB)},
+	{0x1F3CD, 0x1F3FA, uint8(ID)},
+	{0x1F3FB, 0x1F3FF, uint8(EM)},
+	{0x1F400, 0x1F441, uint8(ID)},
+	{0x1F442, 0x1F443, uint8(EB)},
+	{0x1F444, 0x1F445, uint8(ID)},
+	{0x1F446, 0x1F450, uint8(EB)},
+	{0x1F451, 0x1F465, uint8(ID)},
+	{0x1F466, 0x1F478, uint8(EB)},
+	{0x1F479, 0x1F47B, uint8(ID)},
+	{0x1F47C, 0x1F47C, uint8(EB)},
+	{0x1F47D, 0x1F480, uint8(ID)},
+	{0x1F481, 0x1F483, uint8(EB)},
+	{0x1F484, 0x1F484, uint8(ID)},
+	{0x1F485, 0x1F487, uint8(EB)},
+	{0x1F488, 0x1F48E, uint8(ID)},
+	{0x1F48F, 0x1F48F, uint8(EB)},
+	{0x1F490, 0x1F490, uint8(ID)},
+	{0x1F491, 0x1F491, uint8(EB)},
+	{0x1F492, 0x1F49F, uint8(ID)},
+	{0x1F4A0, 0x1F4A0, uint8(AL)},
+	{0x1F4A1, 0x1F4A1, uint8(ID)},
+	{0x1F4A2, 0x1F4A2, uint8(AL)},
+	{0x1F4A3, 0x1F4A3, uint8(ID)},
+	{0x1F4A4, 0x1F4A4, uint8(AL)},
+	{0x1F4A5, 0x1F4A9, uint8(ID)},
+	{0x1F4AA, 0x1F4AA, uint8(EB)},
+	{0x1F4AB, 0x1F4AE, uint8(ID)},
+	{0x1F4AF, 0x1F4AF, uint8(AL)},
+	{0x1F4B0, 0x1F4B0, uint8(ID)},
+	{0x1F4B1, 0x1F4B2, uint8(AL)},
+	{0x1F4B3, 0x1F4FF, uint8(ID)},
+	{0x1F500, 0x1F506, uint8(AL)},
+	{0x1F507, 0x1F516, uint8(ID)},
+	{0x1F517, 0x1F524, uint8(AL)},
+	{0x1F525, 0x1F531, uint8(ID)},
+	{0x1F532, 0x1F549, uint8(AL)},
+	{0x1F54A, 0x1F573, uint8(ID)},
+	{0x1F574, 0x1F575, uint8(EB)},
+	{0x1F576, 0x1F579, uint8(ID)},
+	{0x1F57A, 0x1F57A, uint8(EB)},
+	{0x1F57B, 0x1F58F, uint8(ID)},
+	{0x1F590, 0x1F590, uint8(EB)},
+	{0x1F591, 0x1F594, uint8(ID)},
+	{0x1F595, 0x1F596, uint8(EB)},
+	{0x1F597, 0x1F5D3, uint8(ID)},
+	{0x1F5D4, 0x1F5DB, uint8(AL)},
+	{0x1F5DC, 0x1F5F3, uint8(ID)},
+	{0x1F5F4, 0x1F5F9, uint8(AL)},
+	{0x1F5FA, 0x1F644, uint8(ID)},
+	{0x1F645, 0x1F647, uint8(EB)},
+	{0x1F648, 0x1F64A, uint8(ID)},
+	{0x1F64B, 0x1F64F, uint8(EB)},
+	{0x1F650, 0x1F675, uint8(AL)},
+	{0x1F676, 0x1F678, uint8(QU)},
+	{0x1F679, 0x1F67B, uint8(NS)},
+	{0x1F67C, 0x1F67F, uint8(AL)},
+	{0x1F680, 0x1F6A2, uint8(ID)},
+	{0x1F6A3, 0x1F6A3, uint8(EB)},
+	{0x1F6A4, 0x1F6B3, uint8(ID)},
+	{0x1F6B4, 0x1F6B6, uint8(EB)},
+	{0x1F6B7, 0x1F6BF, uint8(ID)},
+	{0x1F6C0, 0x1F6C0, uint8(EB)},
+	{0x1F6C1, 0x1F6CB, uint8(ID)},
+	{0x1F6CC, 0x1F6CC, uint8(EB)},
+	{0x1F6CD, 0x1F6FF, uint8(ID)},
+	{0x1F700, 0x1F773, uint8(AL)},
+	{0x1F774, 0x1F77F, uint8(ID)},
+	{0x1F780, 0x1F7D4, uint8(AL)},
+	{0x1F7D5, 0x1F7FF, uint8(ID)},
+	{0x1F800, 0x1F80B, uint8(AL)},
+	{0x1F80C, 0x1F80F, uint8(ID)},
+	{0x1F810, 0x1F847, uint8(AL)},
+	{0x1F848, 0x1F84F, uint8(ID)},
+	{0x1F850, 0x1F859, uint8(AL)},
+	{0x1F85A, 0x1F85F, uint8(ID)},
+	{0x1F860, 0x1F887, uint8(AL)},
+	{0x1F888, 0x1F88F, uint8(ID)},
+	{0x1F890, 0x1F8AD, uint8(AL)},
+	{0x1F8AE, 0x1F8FF, uint8(ID)},
+	{0x1F900, 0x1F90B, uint8(AL)},
+	{0x1F90C, 0x1F90C, uint8(EB)},
+	{0x1F90D, 0x1F90E, uint8(ID)},
+	{0x1F90F, 0x1F90F, uint8(EB)},
+	{0x1F910, 0x1F917, uint8(ID)},
+	{0x1F918, 0x1F91F, uint8(EB)},
+	{0x1F920, 0x1F925, uint8(ID)},
+	{0x1F926, 0x1F926, uint8(EB)},
+	{0x1F927, 0x1F92F, uint8(ID)},
+	{0x1F930, 0x1F939, uint8(EB)},
+	{0x1F93A, 0x1F93B, uint8(ID)},
+	{0x1F93C, 0x1F93E, uint8(EB)},
+	{0x1F93F, 0x1F976, uint8(ID)},
+	{0x1F977, 0x1F977, uint8(EB)},
+	{0x1F978, 0x1F9B4, uint8(ID)},
+	{0x1F9B5, 0x1F9B6, uint8(EB)},
+	{0x1F9B7, 0x1F9B7, uint8(ID)},
+	{0x1F9B8, 0x1F9B9, uint8(EB)},
+	{0x1F9BA, 0x1F9BA, uint8(ID)},
+	{0x1F9BB, 0x1F9BB, uint8(EB)},
+	{0x1F9BC, 0x1F9CC, uint8(ID)},
+	{0x1F9CD, 0x1F9CF, uint8(EB)},
+	{0x1F9D0, 0x1F9D0, uint8(ID)},
+	{0x1F9D1, 0x1F9DD, uint8(EB)},
+	{0x1F9DE, 0x1F9FF, uint8(ID)},
+	{0x1FA00, 0x1FA53, uint8(AL)},
+	{0x1FA54, 0x1FAC2, uint8(ID)},
+	{0x1FAC3, 0x1FAC5, uint8(EB)},
+	{0x1FAC6, 0x1FAEF, uint8(ID)},
+	{0x1FAF0, 0x1FAF6, uint8(EB)},
+	{0x1FAF7, 0x1FAFF, uint8(ID)},
+	{0x1FB00, 0x1FB92, uint8(AL)},
+	{0x1FB94, 0x1FBCA, uint8(AL)},
+	{0x1FBF0, 0x1FBF9, uint8(NU)},
+	{0x1FC00, 0x1FFFD, uint8(ID)},
+	{0x20000, 0x2FFFD, uint8(ID)},
+	{0x30000, 0x3FFFD, uint8(ID)},
+	{0xE0001, 0xE0001, uint8(CM)},
+	{0xE0020, 0xE007F, uint8(CM)},
+	{0xE0100, 0xE01EF, uint8(CM)},
+}
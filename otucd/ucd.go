@@ -0,0 +1,138 @@
+package otucd
+
+import "slices"
+
+//go:generate go run gen_tables.go -ucd .
+
+// EastAsianWidth is the Unicode property East_Asian_Width of a character.
+type EastAsianWidth uint8
+
+// East Asian widths, as defined in UCD file EastAsianWidth.txt.
+const (
+	Neutral   EastAsianWidth = iota // N: characters not used in East Asian typography
+	Ambiguous                       // A: wide or narrow, depending on context
+	Halfwidth                       // H: halfwidth forms, e.g. halfwidth katakana
+	Fullwidth                       // F: fullwidth forms, e.g. fullwidth Latin letters
+	Narrow                          // Na: narrow characters, e.g. ASCII
+	Wide                            // W: wide characters, e.g. ideographs and kana
+)
+
+var widthNames = [...]string{"N", "A", "H", "F", "Na", "W"}
+
+func (w EastAsianWidth) String() string {
+	if int(w) >= len(widthNames) {
+		return "?"
+	}
+	return widthNames[w]
+}
+
+// IsWide reports whether w is Wide or Fullwidth, i.e. characters of width w
+// take up a full em in East Asian typography.
+func (w EastAsianWidth) IsWide() bool {
+	return w == Wide || w == Fullwidth
+}
+
+// LineBreakClass is the Unicode property Line_Break of a character. Classes
+// are named by their short property value aliases, as used in UAX #14.
+type LineBreakClass uint8
+
+// Line break classes, as defined in UCD file LineBreak.txt.
+const (
+	XX  LineBreakClass = iota // unknown
+	AI                        // ambiguous (alphabetic or ideographic)
+	AL                        // alphabetic
+	B2                        // break opportunity before and after
+	BA                        // break after
+	BB                        // break before
+	BK                        // mandatory break
+	CB                        // contingent break opportunity
+	CJ                        // conditional Japanese starter, e.g. small kana
+	CL                        // close punctuation
+	CM                        // combining mark
+	CP                        // close parenthesis
+	CR                        // carriage return
+	EB                        // emoji base
+	EM                        // emoji modifier
+	EX                        // exclamation/interrogation
+	GL                        // non-breaking ("glue")
+	H2                        // Hangul LV syllable
+	H3                        // Hangul LVT syllable
+	HL                        // Hebrew letter
+	HY                        // hyphen
+	ID                        // ideographic
+	IN                        // inseparable
+	IS                        // infix numeric separator
+	JL                        // Hangul L jamo
+	JT                        // Hangul T jamo
+	JV                        // Hangul V jamo
+	LF                        // line feed
+	NL                        // next line
+	NS                        // non-starter, e.g. iteration marks
+	NU                        // numeric
+	OP                        // open punctuation
+	PO                        // postfix numeric
+	PR                        // prefix numeric
+	QU                        // quotation
+	RI                        // regional indicator
+	SA                        // complex context dependent (South East Asian)
+	SG                        // surrogate
+	SP                        // space
+	SY                        // symbols allowing break after
+	WJ                        // word joiner
+	ZW                        // zero width space
+	ZWJ                       // zero width joiner
+	lineBreakClassCount
+)
+
+var lineBreakNames = [...]string{
+	"XX", "AI", "AL", "B2", "BA", "BB", "BK", "CB", "CJ", "CL", "CM", "CP", "CR", "EB", "EM", "EX", "GL",
+	"H2", "H3", "HL", "HY", "ID", "IN", "IS", "JL", "JT", "JV", "LF", "NL", "NS", "NU", "OP", "PO", "PR",
+	"QU", "RI", "SA", "SG", "SP", "SY", "WJ", "ZW", "ZWJ",
+}
+
+func (c LineBreakClass) String() string {
+	if c >= lineBreakClassCount {
+		return "?"
+	}
+	return lineBreakNames[c]
+}
+
+// propertyRange is a range of code points with the same property value.
+type propertyRange struct {
+	lo, hi rune
+	value  uint8
+}
+
+// lookup returns the value of the range of table containing r, or 0.
+func lookup(table []propertyRange, r rune) uint8 {
+	i, found := slices.BinarySearchFunc(table, r, func(e propertyRange, r rune) int {
+		switch {
+		case r < e.lo:
+			return 1
+		case r > e.hi:
+			return -1
+		}
+		return 0
+	})
+	if !found {
+		return 0
+	}
+	return table[i].value
+}
+
+// Width returns the East Asian Width of character r. Characters not listed in
+// EastAsianWidth.txt are Neutral.
+func Width(r rune) EastAsianWidth {
+	return EastAsianWidth(lookup(widthTable[:], r))
+}
+
+// LineBreak returns the line break class of character r. Characters not
+// listed in LineBreak.txt have class XX. Unassigned code points of the blocks
+// of ideographs are listed with class ID.
+//
+// Classes are returned as assigned by the Unicode Character Database; UAX #14
+// resolves some of them, e.g. AI, SG and XX, to AL and SA to AL or CM, and CJ
+// to NS or ID depending on the strictness of line breaking.
+func LineBreak(r rune) LineBreakClass {
+	return LineBreakClass(lookup(lineBreakTable[:], r))
+}
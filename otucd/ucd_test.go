@@ -0,0 +1,46 @@
+package otucd
+
+import "testing"
+
+func TestProperties(t *testing.T) {
+	for _, tc := range []struct {
+		r     rune
+		width EastAsianWidth
+		class LineBreakClass
+	}{
+		{'A', Narrow, AL},
+		{' ', Narrow, SP},
+		{'\n', Neutral, LF},
+		{'α', Ambiguous, AL},
+		{'一', Wide, ID},
+		{'。', Wide, CL},
+		{'「', Wide, OP},
+		{'ぁ', Wide, CJ},
+		{'々', Wide, NS},
+		{'가', Wide, H2},
+		{'Ａ', Fullwidth, ID},
+		{'ｱ', Halfwidth, ID},
+		{0x2FFFD, Wide, ID}, // unassigned code point in a block of ideographs
+		{0x10FFFF, Neutral, XX},
+	} {
+		if w, c := Width(tc.r), LineBreak(tc.r); w != tc.width || c != tc.class {
+			t.Errorf("%U: expected %v/%v, have %v/%v", tc.r, tc.width, tc.class, w, c)
+		}
+	}
+	if !Width('一').IsWide() || !Width('Ａ').IsWide() || Width('ｱ').IsWide() {
+		t.Errorf("expected ideographs and fullwidth forms only to be wide")
+	}
+	if ZWJ.String() != "ZWJ" || Narrow.String() != "Na" {
+		t.Errorf("unexpected names %q and %q", ZWJ, Narrow)
+	}
+}
+
+func TestTablesSorted(t *testing.T) {
+	for name, table := range map[string][]propertyRange{"width": widthTable[:], "line break": lineBreakTable[:]} {
+		for i, e := range table {
+			if e.lo > e.hi || i > 0 && e.lo <= table[i-1].hi {
+				t.Errorf("%s table: range %d %U..%U is not sorted", name, i, e.lo, e.hi)
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package otvar
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Advances provides the advance widths of the glyphs of a variable font at a
+// fixed position of its design space, without instantiating the font. Deltas
+// are taken from table 'HVAR' or, if the font has none, from the phantom points
+// of table 'gvar', as done by Instantiate.
+//
+// Shapers report advances of the default instance of a font; renderers of other
+// instances correct them by Delta. Advances is safe for concurrent use.
+type Advances struct {
+	hmtx *ot.HMtxTable
+	inst *instancer
+	hvar *hvarTable
+
+	// phantom point deltas, decoded on demand from 'gvar'
+	gvar      *gvarTable
+	glyf      []byte
+	locations []uint32
+	mu        sync.Mutex
+	deltas    map[ot.GlyphIndex]float64
+}
+
+// NewAdvances prepares the advance widths of variable font otf at user-space
+// coordinates coords (see Normalize). It returns an error if otf is not a
+// variable font or if coords contains a tag which is not an axis of the font.
+func NewAdvances(otf *ot.Font, coords map[ot.Tag]float64) (*Advances, error) {
+	fv, err := parseFVar(otf)
+	if err != nil {
+		return nil, err
+	}
+	norm, err := fv.normalize(otf, coords)
+	if err != nil {
+		return nil, err
+	}
+	hmtx := otf.HorizontalMetrics()
+	if hmtx == nil {
+		return nil, errors.New("font has no table hmtx")
+	}
+	adv := &Advances{
+		hmtx: hmtx,
+		inst: &instancer{otf: otf, axisCount: len(fv.axes), coords: norm},
+	}
+	if adv.hvar, err = adv.inst.parseHVar(); err != nil || adv.hvar != nil {
+		return adv, err
+	}
+	if err := adv.prepareGVar(); err != nil {
+		return nil, err
+	}
+	return adv, nil
+}
+
+// prepareGVar reads the tables needed for deltas of phantom points. Fonts
+// without 'gvar', e.g. with CFF2 outlines, have no advance variations.
+func (adv *Advances) prepareGVar() error {
+	otf := adv.inst.otf
+	maxp, head, loca, glyf := otf.Table(ot.T("maxp")), otf.FontHead(), otf.Table(ot.T("loca")), otf.Table(ot.T("glyf"))
+	if maxp == nil || head == nil || loca == nil || glyf == nil {
+		return nil
+	}
+	numGlyphs := maxp.Self().AsMaxP().NumGlyphs
+	gvar, err := adv.inst.parseGVar(numGlyphs)
+	if err != nil || gvar == nil {
+		return err
+	}
+	if adv.locations, err = readLoca(loca.Binary(), numGlyphs, head.IndexToLocFormat); err != nil {
+		return err
+	}
+	adv.gvar, adv.glyf = gvar, glyf.Binary()
+	adv.deltas = make(map[ot.GlyphIndex]float64)
+	return nil
+}
+
+// Delta returns the difference between the advance width of glyph gid at the
+// position of the design space and its advance width in table 'hmtx', in font
+// units. Glyphs without variation data have a delta of 0.
+func (adv *Advances) Delta(gid ot.GlyphIndex) float64 {
+	if adv == nil {
+		return 0
+	}
+	if adv.hvar != nil {
+		idx := adv.hvar.maps.Advance.Index(int(gid))
+		return adv.hvar.store.Delta(idx.Outer, idx.Inner, adv.inst.coords)
+	}
+	if adv.gvar == nil || int(gid)+1 >= len(adv.locations) {
+		return 0
+	}
+	adv.mu.Lock()
+	defer adv.mu.Unlock()
+	if d, ok := adv.deltas[gid]; ok {
+		return d
+	}
+	d := adv.phantomDelta(int(gid))
+	adv.deltas[gid] = d
+	return d
+}
+
+// phantomDelta returns the variation of the advance width of glyph gid from the
+// deltas of its phantom points. Damaged glyph data results in a delta of 0.
+func (adv *Advances) phantomDelta(gid int) float64 {
+	start, end := adv.locations[gid], adv.locations[gid+1]
+	if start > end || int(end) > len(adv.glyf) {
+		return 0
+	}
+	g, err := decodeGlyph(adv.glyf[start:end])
+	if err != nil {
+		tracer().Errorf("glyph %d: %v", gid, err)
+		return 0
+	}
+	tvs, err := adv.gvar.variations(gid, g)
+	if err != nil {
+		tracer().Errorf("glyph %d: %v", gid, err)
+		return 0
+	}
+	var phantom [4]point
+	adv.inst.applyGlyphDeltas(g, &phantom, tvs)
+	return phantom[1].x - phantom[0].x
+}
+
+// Advance returns the advance width of glyph gid at the position of the design
+// space, rounded to font units. It never returns a negative advance.
+func (adv *Advances) Advance(gid ot.GlyphIndex) int32 {
+	if adv == nil {
+		return 0
+	}
+	return int32(max(0, ot.Round(float64(adv.hmtx.Advance(gid))+adv.Delta(gid))))
+}
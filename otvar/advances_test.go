@@ -0,0 +1,79 @@
+package otvar
+
+import (
+	"testing"
+
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/schuko/tracing/gotestingadapter"
+)
+
+func TestAdvancesPhantomPoints(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := makeVariableFont(t, nil)
+	o := otf.CMapTable().GlyphIndexMap.Lookup('o')
+	a := otf.CMapTable().GlyphIndexMap.Lookup('a')
+	hmtx := otf.HorizontalMetrics()
+	for _, c := range []struct {
+		wght  float64
+		delta float64
+	}{
+		{wght: 900, delta: 20},
+		{wght: 650, delta: 15},
+		{wght: 400, delta: 0},
+	} {
+		adv, err := NewAdvances(otf, map[ot.Tag]float64{ot.T("wght"): c.wght})
+		if err != nil {
+			t.Fatalf("wght=%g: %v", c.wght, err)
+		}
+		if d := adv.Delta(o); d != c.delta {
+			t.Errorf("wght=%g: expected delta %g for 'o', have %g", c.wght, c.delta, d)
+		}
+		if w, want := adv.Advance(o), int32(hmtx.Advance(o))+int32(c.delta); w != want {
+			t.Errorf("wght=%g: expected advance %d for 'o', have %d", c.wght, want, w)
+		}
+		if w := adv.Advance(a); w != int32(hmtx.Advance(a)) {
+			t.Errorf("wght=%g: expected advance of 'a' to be unchanged, have %d", c.wght, w)
+		}
+	}
+}
+
+func TestAdvancesHVAR(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	calibri := loadCalibri(t)
+	o := calibri.CMapTable().GlyphIndexMap.Lookup('o')
+	numGlyphs := calibri.Table(ot.T("maxp")).Self().AsMaxP().NumGlyphs
+	otf := makeVariableFont(t, map[ot.Tag][]byte{
+		ot.T("HVAR"): buildHVAR(numGlyphs, int(o), 30),
+	})
+	adv, err := NewAdvances(otf, map[ot.Tag]float64{ot.T("wght"): 900})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// advance is taken from HVAR instead of the phantom points
+	if d := adv.Delta(o); d != 30 {
+		t.Errorf("expected delta 30 from HVAR, have %g", d)
+	}
+	if w, want := adv.Advance(o), int32(calibri.HorizontalMetrics().Advance(o))+30; w != want {
+		t.Errorf("expected advance %d, have %d", want, w)
+	}
+}
+
+func TestAdvancesErrors(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	if _, err := NewAdvances(loadCalibri(t), nil); err == nil {
+		t.Errorf("expected static font to be rejected")
+	}
+	if _, err := NewAdvances(makeVariableFont(t, nil), map[ot.Tag]float64{ot.T("opsz"): 12}); err == nil {
+		t.Errorf("expected unknown axis to be rejected")
+	}
+	var adv *Advances
+	if adv.Advance(1) != 0 || adv.Delta(1) != 0 {
+		t.Errorf("expected nil advances to return 0")
+	}
+}
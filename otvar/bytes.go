@@ -0,0 +1,34 @@
+package otvar
+
+import (
+	"fmt"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// newReader creates a reader for table data b, positioned at byte offset pos.
+// Reads are bounds-checked by ot.Reader, with errors being sticky.
+func newReader(b []byte, pos int) *ot.Reader {
+	r := ot.NewReader(b)
+	r.Seek(pos)
+	return r
+}
+
+// readF2Dot14 reads a signed 2.14 fixed-point number.
+func readF2Dot14(r *ot.Reader) float64 {
+	return ot.F2Dot14(r.ReadI16()).Float()
+}
+
+// readFixed reads a signed 16.16 fixed-point number.
+func readFixed(r *ot.Reader) float64 {
+	return ot.Fixed(r.ReadI32()).Float()
+}
+
+// readError wraps the sticky error of r, if any, with a description of the
+// structure being read.
+func readError(r *ot.Reader, format string, args ...any) error {
+	if r.Err() == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", fmt.Sprintf(format, args...), r.Err())
+}
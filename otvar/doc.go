@@ -0,0 +1,41 @@
+/*
+Package otvar handles OpenType variable fonts.
+
+Variable fonts define a design space spanned by variation axes, such as weight
+('wght') or width ('wdth'), in table 'fvar'. Glyph outlines vary through deltas
+stored in table 'gvar', advance widths through table 'HVAR', and global font
+metrics through table 'MVAR'. Table 'avar' remaps normalized axis coordinates,
+in version 2 depending on the coordinates of all axes. Variation deltas are
+addressed through delta set index maps; see DeltaSetIndexMap.
+
+Package otvar reads the design space of a font and creates static instances,
+i.e., fonts without variations, at fixed positions of the design space:
+
+	data, err := otvar.Instantiate(otf, map[ot.Tag]float64{ot.T("wght"): 650})
+	…
+	static, err := ot.Parse(data)
+
+# Status
+
+Only fonts with TrueType outlines ('glyf') can be instantiated; CFF2 fonts are
+rejected. Variation data of the layout tables (GDEF item variation store and
+device tables with variation indices) and of vertical metrics (VVAR) are not
+applied.
+
+# License
+
+Governed by a 3-Clause BSD license. License file may be found in the root
+folder of this module.
+
+Copyright © Norbert Pillmayer <norbert@pillmayer.com>
+*/
+package otvar
+
+import (
+	"github.com/npillmayer/schuko/tracing"
+)
+
+// tracer writes to trace with key 'font.opentype'
+func tracer() tracing.Trace {
+	return tracing.Select("font.opentype")
+}
@@ -0,0 +1,317 @@
+package otvar
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Axis is a variation axis of a variable font, as defined in table 'fvar'.
+type Axis struct {
+	Tag     ot.Tag  // axis tag, e.g. 'wght'
+	Min     float64 // minimum coordinate value of the axis
+	Default float64 // default coordinate value of the axis
+	Max     float64 // maximum coordinate value of the axis
+	Hidden  bool    // axis should not be exposed in user interfaces
+	NameID  uint16  // name ID of the axis name in table 'name'
+}
+
+// NamedInstance is a predefined position in the design space of a variable font,
+// as defined in table 'fvar'.
+type NamedInstance struct {
+	SubfamilyNameID  uint16             // name ID of the instance's subfamily name
+	PostScriptNameID uint16             // name ID of the PostScript name; 0 if not present
+	Coords           map[ot.Tag]float64 // user-space coordinates for all axes
+}
+
+// fvar holds the contents of table 'fvar'.
+type fvar struct {
+	axes      []Axis
+	instances []NamedInstance
+}
+
+// IsVariable reports whether font otf contains a font variations table 'fvar'.
+func IsVariable(otf *ot.Font) bool {
+	return otf != nil && otf.Table(ot.T("fvar")) != nil
+}
+
+// Axes returns the variation axes of font otf, in the order of table 'fvar'.
+func Axes(otf *ot.Font) ([]Axis, error) {
+	fv, err := parseFVar(otf)
+	if err != nil {
+		return nil, err
+	}
+	return fv.axes, nil
+}
+
+// NamedInstances returns the named instances of font otf, in the order of
+// table 'fvar'.
+func NamedInstances(otf *ot.Font) ([]NamedInstance, error) {
+	fv, err := parseFVar(otf)
+	if err != nil {
+		return nil, err
+	}
+	return fv.instances, nil
+}
+
+func parseFVar(otf *ot.Font) (*fvar, error) {
+	if !IsVariable(otf) {
+		return nil, errors.New("font is not a variable font")
+	}
+	b := otf.Table(ot.T("fvar")).Binary()
+	r := newReader(b, 0)
+	if major := r.ReadU16(); r.Err() == nil && major != 1 {
+		return nil, fmt.Errorf("unsupported fvar version %d", major)
+	}
+	r.ReadU16() // minor version
+	axesOffset := int(r.ReadU16())
+	r.ReadU16() // reserved
+	axisCount, axisSize := int(r.ReadU16()), int(r.ReadU16())
+	instanceCount, instanceSize := int(r.ReadU16()), int(r.ReadU16())
+	if err := readError(r, "fvar header"); err != nil {
+		return nil, err
+	}
+	if axisSize < 20 || instanceSize < 4+4*axisCount {
+		return nil, fmt.Errorf("fvar: invalid record sizes %d/%d", axisSize, instanceSize)
+	}
+	fv := &fvar{axes: make([]Axis, axisCount)}
+	for i := range fv.axes {
+		r = newReader(b, axesOffset+i*axisSize)
+		a := &fv.axes[i]
+		a.Tag = ot.Tag(r.ReadU32())
+		a.Min, a.Default, a.Max = readFixed(r), readFixed(r), readFixed(r)
+		a.Hidden = r.ReadU16()&0x0001 != 0
+		a.NameID = r.ReadU16()
+		if err := readError(r, "fvar axis #%d", i); err != nil {
+			return nil, err
+		}
+		if a.Min > a.Default || a.Default > a.Max {
+			return nil, fmt.Errorf("fvar axis %s: invalid range %g…%g…%g", a.Tag, a.Min, a.Default, a.Max)
+		}
+	}
+	instancesOffset := axesOffset + axisCount*axisSize
+	for i := range instanceCount {
+		r = newReader(b, instancesOffset+i*instanceSize)
+		inst := NamedInstance{Coords: make(map[ot.Tag]float64, axisCount)}
+		inst.SubfamilyNameID = r.ReadU16()
+		r.ReadU16() // flags, reserved
+		for _, a := range fv.axes {
+			inst.Coords[a.Tag] = readFixed(r)
+		}
+		if instanceSize >= 6+4*axisCount {
+			inst.PostScriptNameID = r.ReadU16()
+		}
+		if err := readError(r, "fvar instance #%d", i); err != nil {
+			return nil, err
+		}
+		fv.instances = append(fv.instances, inst)
+	}
+	return fv, nil
+}
+
+// OpticalSizeCoord returns the coordinate of axis 'opsz' for text set at
+// pointSize points, i.e. pointSize clamped to the range of the axis. Axis
+// 'opsz' is specified in points, so renderers may set it automatically:
+//
+//	if opsz, ok := otvar.OpticalSizeCoord(otf, 9); ok {
+//		coords[ot.T("opsz")] = opsz
+//	}
+//
+// OpticalSizeCoord returns false if otf has no axis 'opsz'.
+func OpticalSizeCoord(otf *ot.Font, pointSize float64) (float64, bool) {
+	if !IsVariable(otf) {
+		return 0, false
+	}
+	fv, err := parseFVar(otf)
+	if err != nil {
+		return 0, false
+	}
+	i := fv.axis(ot.T("opsz"))
+	if i < 0 {
+		return 0, false
+	}
+	return max(fv.axes[i].Min, min(pointSize, fv.axes[i].Max)), true
+}
+
+// Normalize maps user-space coordinates to normalized coordinates in the range
+// −1 … 1, as needed for applying variation deltas. Axes not contained in coords
+// are set to their default value (normalized 0), and values outside of an axis'
+// range are clamped. The result holds one coordinate per axis of the font, in
+// the order of table 'fvar'. If present, the segment maps of table 'avar' are
+// applied, followed by the axis variations of 'avar' version 2.
+//
+// It is an error to pass coordinates for an axis the font does not have.
+func Normalize(otf *ot.Font, coords map[ot.Tag]float64) ([]float64, error) {
+	fv, err := parseFVar(otf)
+	if err != nil {
+		return nil, err
+	}
+	return fv.normalize(otf, coords)
+}
+
+func (fv *fvar) normalize(otf *ot.Font, coords map[ot.Tag]float64) ([]float64, error) {
+	for tag := range coords {
+		if fv.axis(tag) < 0 {
+			return nil, fmt.Errorf("font has no variation axis %s", tag)
+		}
+	}
+	norm := make([]float64, len(fv.axes))
+	for i, a := range fv.axes {
+		v, ok := coords[a.Tag]
+		if !ok {
+			continue
+		}
+		v = max(a.Min, min(a.Max, v))
+		switch {
+		case v < a.Default:
+			norm[i] = (v - a.Default) / (a.Default - a.Min)
+		case v > a.Default:
+			norm[i] = (v - a.Default) / (a.Max - a.Default)
+		}
+		norm[i] = ot.F2Dot14From(norm[i]).Float()
+	}
+	if t := otf.Table(ot.T("avar")); t != nil {
+		avar, err := parseAVar(t.Binary(), len(fv.axes))
+		if err != nil {
+			return nil, err
+		}
+		norm = avar.apply(norm)
+	}
+	tracer().Debugf("normalized variation coordinates %v", norm)
+	return norm, nil
+}
+
+// axis returns the index of the axis with tag tag, or -1.
+func (fv *fvar) axis(tag ot.Tag) int {
+	for i, a := range fv.axes {
+		if a.Tag == tag {
+			return i
+		}
+	}
+	return -1
+}
+
+// avarTable holds the contents of table 'avar'. Version 2 tables add an item
+// variation store, which varies the normalized coordinate of each axis depending
+// on the coordinates of all axes.
+type avarTable struct {
+	maps         []segmentMap
+	axisIndexMap DeltaSetIndexMap       // maps axis indexes to delta sets of store
+	store        *ot.ItemVariationStore // nil for version 1
+}
+
+// segmentMap is the piecewise linear mapping of normalized coordinates for one
+// axis, as defined in table 'avar'.
+type segmentMap []struct{ from, to float64 }
+
+// parseAVar reads table 'avar', version 1 or 2.
+func parseAVar(b []byte, axisCount int) (*avarTable, error) {
+	r := newReader(b, 0)
+	major := r.ReadU16()
+	r.ReadU16() // minor version
+	r.ReadU16() // reserved
+	n := int(r.ReadU16())
+	if err := readError(r, "avar header"); err != nil {
+		return nil, err
+	}
+	if major != 1 && major != 2 {
+		return nil, fmt.Errorf("unsupported avar version %d", major)
+	}
+	if n != axisCount {
+		return nil, fmt.Errorf("avar: axis count %d does not match fvar axis count %d", n, axisCount)
+	}
+	avar := &avarTable{maps: make([]segmentMap, n)}
+	for i := range avar.maps {
+		cnt := int(r.ReadU16())
+		m := make(segmentMap, cnt)
+		for j := range m {
+			m[j].from, m[j].to = readF2Dot14(r), readF2Dot14(r)
+		}
+		if err := readError(r, "avar segment map #%d", i); err != nil {
+			return nil, err
+		}
+		avar.maps[i] = m
+	}
+	if major == 1 {
+		return avar, nil
+	}
+	mapOffset, storeOffset := int(r.ReadU32()), int(r.ReadU32())
+	if err := readError(r, "avar version 2 header"); err != nil {
+		return nil, err
+	}
+	var err error
+	if mapOffset != 0 {
+		if avar.axisIndexMap, err = ParseDeltaSetIndexMap(b, mapOffset); err != nil {
+			return nil, fmt.Errorf("avar: %w", err)
+		}
+	}
+	if storeOffset != 0 {
+		if avar.store, err = parseItemVariationStore(b, storeOffset); err != nil {
+			return nil, fmt.Errorf("avar: %w", err)
+		}
+	}
+	return avar, nil
+}
+
+// apply maps normalized coordinates norm in place. The deltas of a version 2
+// table are computed from the coordinates after applying the segment maps, and
+// are given in units of 2.14 fixed-point numbers.
+func (avar *avarTable) apply(norm []float64) []float64 {
+	for i, m := range avar.maps {
+		norm[i] = ot.F2Dot14From(m.apply(norm[i])).Float()
+	}
+	if avar.store == nil {
+		return norm
+	}
+	mapped := slices.Clone(norm)
+	for i := range norm {
+		idx := avar.axisIndexMap.Index(i)
+		d := avar.store.Delta(idx.Outer, idx.Inner, mapped)
+		norm[i] = max(-1, min(1, norm[i]+float64(ot.Round(d))/(1<<14)))
+	}
+	return norm
+}
+
+// AxisIndexMap returns the delta set index map of table 'avar' version 2 of
+// font otf, which maps axis indexes (in the order of table 'fvar') to delta sets
+// of the table's item variation store. It returns nil if the font has no 'avar'
+// table of version 2 or if the table uses the implicit mapping.
+func AxisIndexMap(otf *ot.Font) (DeltaSetIndexMap, error) {
+	fv, err := parseFVar(otf)
+	if err != nil {
+		return nil, err
+	}
+	t := otf.Table(ot.T("avar"))
+	if t == nil {
+		return nil, nil
+	}
+	avar, err := parseAVar(t.Binary(), len(fv.axes))
+	if err != nil {
+		return nil, err
+	}
+	return avar.axisIndexMap, nil
+}
+
+// apply maps a normalized coordinate. Maps with less than three entries (which
+// do not contain the required mappings −1→−1, 0→0, 1→1) are treated as identity.
+func (m segmentMap) apply(v float64) float64 {
+	if len(m) < 3 {
+		return v
+	}
+	if v <= m[0].from {
+		return m[0].to + v - m[0].from
+	}
+	for k := 1; k < len(m); k++ {
+		if v == m[k].from {
+			return m[k].to
+		}
+		if v < m[k].from {
+			from0, to0 := m[k-1].from, m[k-1].to
+			return to0 + (m[k].to-to0)*(v-from0)/(m[k].from-from0)
+		}
+	}
+	last := m[len(m)-1]
+	return last.to + v - last.from
+}
@@ -0,0 +1,304 @@
+package otvar
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Flags of simple glyph outlines.
+const (
+	flagOnCurve       = 0x01
+	flagXShort        = 0x02
+	flagYShort        = 0x04
+	flagRepeat        = 0x08
+	flagXSameOrPos    = 0x10
+	flagYSameOrPos    = 0x20
+	flagOverlapSimple = 0x40
+)
+
+// Flags of composite glyph components.
+const (
+	compArgsAreWords     = 0x0001
+	compArgsAreXY        = 0x0002
+	compHaveScale        = 0x0008
+	compMoreComponents   = 0x0020
+	compHaveXYScale      = 0x0040
+	compHaveTwoByTwo     = 0x0080
+	compHaveInstructions = 0x0100
+	compOverlapCompound  = 0x0400
+)
+
+// point is a point of a glyph outline. Coordinates are floats while deltas are
+// being applied, and are rounded when the glyph is encoded.
+type point struct {
+	x, y float64
+}
+
+// glyph is a decoded 'glyf' entry.
+type glyph struct {
+	contours     int         // number of contours; -1 for composite glyphs
+	endPoints    []int       // last point of each contour
+	points       []point     // outline points of simple glyphs
+	onCurve      []bool      // on-curve flags of simple glyphs
+	components   []component // components of composite glyphs
+	instructions []byte      // TrueType hinting instructions
+	xMin, xMax   int16       // bounding box as stored in the font
+}
+
+// component is a component of a composite glyph.
+type component struct {
+	flags     uint16
+	glyph     uint16
+	dx, dy    float64    // offset, if compArgsAreXY, else point numbers to match
+	transform []byte     // raw scale or 2×2 matrix, as stored in the font
+	matrix    [4]float64 // transform as xx, xy, yx, yy
+}
+
+// isEmpty reports whether g has no outline, as, e.g., the space glyph.
+func (g *glyph) isEmpty() bool {
+	return g.contours == 0
+}
+
+// isComposite reports whether g is assembled from components.
+func (g *glyph) isComposite() bool {
+	return g.contours < 0
+}
+
+// decodeGlyph decodes the 'glyf' data of a single glyph. Empty data results in
+// an empty glyph.
+func decodeGlyph(b []byte) (*glyph, error) {
+	g := &glyph{}
+	if len(b) == 0 {
+		return g, nil
+	}
+	r := newReader(b, 0)
+	g.contours = int(r.ReadI16())
+	g.xMin = r.ReadI16()
+	r.ReadI16() // yMin
+	g.xMax = r.ReadI16()
+	r.ReadI16() // yMax
+	if g.contours < 0 {
+		return g, decodeComposite(r, b, g)
+	}
+	g.endPoints = make([]int, g.contours)
+	last := -1
+	for i := range g.endPoints {
+		g.endPoints[i] = int(r.ReadU16())
+		if r.Err() == nil && g.endPoints[i] <= last {
+			return nil, fmt.Errorf("glyph contour end points not increasing")
+		}
+		last = g.endPoints[i]
+	}
+	g.instructions = r.ReadBytes(int(r.ReadU16()))
+	n := last + 1
+	flags := make([]byte, 0, n)
+	for len(flags) < n && r.Err() == nil {
+		f := r.ReadU8()
+		flags = append(flags, f)
+		if f&flagRepeat != 0 {
+			for k := int(r.ReadU8()); k > 0 && len(flags) < n; k-- {
+				flags = append(flags, f)
+			}
+		}
+	}
+	g.points = make([]point, n)
+	g.onCurve = make([]bool, n)
+	x, y := 0, 0
+	for i, f := range flags {
+		x += readCoord(r, f, flagXShort, flagXSameOrPos)
+		g.points[i].x = float64(x)
+		g.onCurve[i] = f&flagOnCurve != 0
+	}
+	for i, f := range flags {
+		y += readCoord(r, f, flagYShort, flagYSameOrPos)
+		g.points[i].y = float64(y)
+	}
+	return g, readError(r, "simple glyph")
+}
+
+// readCoord reads a single delta-encoded coordinate of a simple glyph.
+func readCoord(r *ot.Reader, flag byte, short, sameOrPos byte) int {
+	switch {
+	case flag&short != 0 && flag&sameOrPos != 0:
+		return int(r.ReadU8())
+	case flag&short != 0:
+		return -int(r.ReadU8())
+	case flag&sameOrPos != 0:
+		return 0
+	}
+	return int(r.ReadI16())
+}
+
+func decodeComposite(r *ot.Reader, b []byte, g *glyph) error {
+	for {
+		c := component{flags: r.ReadU16(), glyph: r.ReadU16()}
+		if c.flags&compArgsAreWords != 0 {
+			if c.flags&compArgsAreXY != 0 {
+				c.dx, c.dy = float64(r.ReadI16()), float64(r.ReadI16())
+			} else {
+				c.dx, c.dy = float64(r.ReadU16()), float64(r.ReadU16())
+			}
+		} else {
+			if c.flags&compArgsAreXY != 0 {
+				c.dx, c.dy = float64(int8(r.ReadU8())), float64(int8(r.ReadU8()))
+			} else {
+				c.dx, c.dy = float64(r.ReadU8()), float64(r.ReadU8())
+			}
+		}
+		c.matrix = [4]float64{1, 0, 0, 1}
+		start := r.Pos()
+		switch {
+		case c.flags&compHaveScale != 0:
+			s := readF2Dot14(r)
+			c.matrix = [4]float64{s, 0, 0, s}
+		case c.flags&compHaveXYScale != 0:
+			c.matrix[0], c.matrix[3] = readF2Dot14(r), readF2Dot14(r)
+		case c.flags&compHaveTwoByTwo != 0:
+			c.matrix = [4]float64{readF2Dot14(r), readF2Dot14(r), readF2Dot14(r), readF2Dot14(r)}
+		}
+		if r.Err() != nil {
+			break
+		}
+		c.transform = b[start:r.Pos()]
+		g.components = append(g.components, c)
+		if c.flags&compMoreComponents == 0 {
+			break
+		}
+	}
+	if r.Err() == nil && g.components[len(g.components)-1].flags&compHaveInstructions != 0 {
+		g.instructions = r.ReadBytes(int(r.ReadU16()))
+	}
+	return readError(r, "composite glyph")
+}
+
+// bbox is a bounding box in font units.
+type bbox struct {
+	xMin, yMin, xMax, yMax int
+	empty                  bool
+}
+
+func emptyBBox() bbox {
+	return bbox{empty: true}
+}
+
+func (bb *bbox) add(x, y int) {
+	if bb.empty {
+		*bb = bbox{xMin: x, yMin: y, xMax: x, yMax: y}
+		return
+	}
+	bb.xMin, bb.yMin = min(bb.xMin, x), min(bb.yMin, y)
+	bb.xMax, bb.yMax = max(bb.xMax, x), max(bb.yMax, y)
+}
+
+func (bb *bbox) union(other bbox) {
+	if !other.empty {
+		bb.add(other.xMin, other.yMin)
+		bb.add(other.xMax, other.yMax)
+	}
+}
+
+// encode encodes glyph g. For composite glyphs, bb is the bounding box of the
+// flattened outline; for simple glyphs, it is calculated from the points.
+// Overlap flags are set, as instantiated outlines will commonly contain
+// overlapping contours.
+func (g *glyph) encode(bb bbox) []byte {
+	if g.isEmpty() {
+		return nil
+	}
+	var out []byte
+	put16 := func(v int) { out = binary.BigEndian.AppendUint16(out, uint16(v)) }
+	put16(g.contours)
+	put16(bb.xMin)
+	put16(bb.yMin)
+	put16(bb.xMax)
+	put16(bb.yMax)
+	if g.isComposite() {
+		for i, c := range g.components {
+			flags := c.flags
+			if i == 0 {
+				flags |= compOverlapCompound
+			}
+			dx, dy := int(c.dx), int(c.dy)
+			if flags&compArgsAreXY != 0 {
+				dx, dy = ot.Round(c.dx), ot.Round(c.dy)
+				if fitsInt8(dx) && fitsInt8(dy) {
+					flags &^= compArgsAreWords
+				} else {
+					flags |= compArgsAreWords
+				}
+			}
+			put16(int(flags))
+			put16(int(c.glyph))
+			if flags&compArgsAreWords != 0 {
+				put16(dx)
+				put16(dy)
+			} else {
+				out = append(out, byte(dx), byte(dy))
+			}
+			out = append(out, c.transform...)
+		}
+		if len(g.instructions) > 0 {
+			put16(len(g.instructions))
+			out = append(out, g.instructions...)
+		}
+		return out
+	}
+	for _, e := range g.endPoints {
+		put16(e)
+	}
+	put16(len(g.instructions))
+	out = append(out, g.instructions...)
+	flags := make([]byte, len(g.points))
+	var xs, ys []byte
+	px, py := 0, 0
+	for i, p := range g.points {
+		x, y := ot.Round(p.x), ot.Round(p.y)
+		var f byte
+		if g.onCurve[i] {
+			f |= flagOnCurve
+		}
+		if i == 0 {
+			f |= flagOverlapSimple
+		}
+		xs, f = appendCoord(xs, f, x-px, flagXShort, flagXSameOrPos)
+		ys, f = appendCoord(ys, f, y-py, flagYShort, flagYSameOrPos)
+		flags[i] = f
+		px, py = x, y
+	}
+	for i := 0; i < len(flags); {
+		f := flags[i]
+		run := 1
+		for i+run < len(flags) && flags[i+run] == f && run < 256 {
+			run++
+		}
+		if run > 1 {
+			out = append(out, f|flagRepeat, byte(run-1))
+		} else {
+			out = append(out, f)
+		}
+		i += run
+	}
+	out = append(out, xs...)
+	out = append(out, ys...)
+	return out
+}
+
+// appendCoord appends a delta-encoded coordinate and sets the flags for it.
+func appendCoord(b []byte, flag byte, d int, short, sameOrPos byte) ([]byte, byte) {
+	switch {
+	case d == 0:
+		return b, flag | sameOrPos
+	case d > 0 && d < 256:
+		return append(b, byte(d)), flag | short | sameOrPos
+	case d < 0 && d > -256:
+		return append(b, byte(-d)), flag | short
+	}
+	return binary.BigEndian.AppendUint16(b, uint16(int16(d))), flag
+}
+
+func fitsInt8(v int) bool {
+	return v >= math.MinInt8 && v <= math.MaxInt8
+}
@@ -0,0 +1,658 @@
+package otvar
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// variationTables are dropped from static instances. A digital signature
+// becomes invalid with any modification of the font and is dropped as well.
+var variationTables = []string{"fvar", "avar", "gvar", "cvar", "HVAR", "VVAR", "MVAR", "STAT", "DSIG"}
+
+// Instantiate creates a static font from variable font otf, with all variation
+// axes pinned to the user-space coordinates in coords (e.g., 'wght' → 650). Axes
+// not contained in coords are pinned to their default value. The result is the
+// binary of the static font, which may be parsed with ot.Parse.
+//
+// Instantiate applies the deltas of tables 'gvar' to the glyph outlines, 'HVAR'
+// (or, if missing, the phantom points of 'gvar') to the advance widths, 'cvar' to
+// the control value table and 'MVAR' to global font metrics. Bounding boxes and
+// metrics derived from the outlines are re-calculated, and OS/2 weight and width
+// classes are set from axes 'wght' and 'wdth', if given. Variation tables are
+// dropped from the result.
+//
+// Instantiate returns an error if otf is not a variable font, if coords contains
+// a tag which is not an axis of the font, or if the font has CFF2 outlines.
+func Instantiate(otf *ot.Font, coords map[ot.Tag]float64) ([]byte, error) {
+	fv, err := parseFVar(otf)
+	if err != nil {
+		return nil, err
+	}
+	if otf.Table(ot.T("CFF2")) != nil {
+		return nil, errors.New("instancing of fonts with CFF2 outlines is not supported")
+	}
+	norm, err := fv.normalize(otf, coords)
+	if err != nil {
+		return nil, err
+	}
+	inst := &instancer{otf: otf, axisCount: len(fv.axes), coords: norm, tables: make(map[ot.Tag][]byte)}
+	for _, tag := range variationTables {
+		if otf.Table(ot.T(tag)) != nil {
+			inst.tables[ot.T(tag)] = nil
+		}
+	}
+	if otf.Table(ot.T("glyf")) != nil {
+		if err := inst.instantiateGlyphs(); err != nil {
+			return nil, err
+		}
+	}
+	if err := inst.instantiateCVT(); err != nil {
+		return nil, err
+	}
+	if err := inst.instantiateMetrics(); err != nil {
+		return nil, err
+	}
+	if os2 := inst.table("OS/2"); len(os2) >= 8 {
+		if wght, ok := coords[ot.T("wght")]; ok {
+			binary.BigEndian.PutUint16(os2[4:], uint16(max(1, min(1000, ot.Round(wght)))))
+		}
+		if wdth, ok := coords[ot.T("wdth")]; ok {
+			binary.BigEndian.PutUint16(os2[6:], widthClass(wdth))
+		}
+	}
+	return otf.Rebuild(inst.tables)
+}
+
+// instancer holds the state of an instantiation.
+type instancer struct {
+	otf       *ot.Font
+	axisCount int
+	coords    []float64         // normalized coordinates, one per axis
+	tables    map[ot.Tag][]byte // replaced tables; nil entries are dropped
+}
+
+// table returns a writable copy of table tag, which will replace the original
+// table in the static font. It returns nil if the font does not contain the table.
+func (inst *instancer) table(tag string) []byte {
+	t := ot.T(tag)
+	if b, ok := inst.tables[t]; ok {
+		return b
+	}
+	orig := inst.otf.Table(t)
+	if orig == nil {
+		return nil
+	}
+	b := slices.Clone(orig.Binary())
+	inst.tables[t] = b
+	return b
+}
+
+// --- Glyphs ----------------------------------------------------------------
+
+// glyphMetrics holds the horizontal metrics of an instantiated glyph.
+type glyphMetrics struct {
+	advance int
+	lsb     int
+	bb      bbox
+}
+
+// instantiateGlyphs applies 'gvar' and 'HVAR' deltas and replaces tables 'glyf',
+// 'loca' and 'hmtx', and the metrics in 'head' and 'hhea' depending on them.
+func (inst *instancer) instantiateGlyphs() error {
+	otf := inst.otf
+	maxp, head, hmtx := otf.Table(ot.T("maxp")), otf.FontHead(), otf.HorizontalMetrics()
+	if maxp == nil || head == nil || hmtx == nil || otf.Table(ot.T("loca")) == nil {
+		return errors.New("font with 'glyf' outlines lacks one of 'maxp', 'head', 'hmtx' or 'loca'")
+	}
+	numGlyphs := maxp.Self().AsMaxP().NumGlyphs
+	locations, err := readLoca(otf.Table(ot.T("loca")).Binary(), numGlyphs, head.IndexToLocFormat)
+	if err != nil {
+		return err
+	}
+	glyf := otf.Table(ot.T("glyf")).Binary()
+	gvar, err := inst.parseGVar(numGlyphs)
+	if err != nil {
+		return err
+	}
+	hvar, err := inst.parseHVar()
+	if err != nil {
+		return err
+	}
+	glyphs := make([]*glyph, numGlyphs)
+	metrics := make([]glyphMetrics, numGlyphs)
+	for gid := range numGlyphs {
+		start, end := locations[gid], locations[gid+1]
+		if start > end || int(end) > len(glyf) {
+			return fmt.Errorf("glyph %d: invalid location %d…%d", gid, start, end)
+		}
+		g, err := decodeGlyph(glyf[start:end])
+		if err != nil {
+			return fmt.Errorf("glyph %d: %w", gid, err)
+		}
+		adv, lsb, _ := hmtx.HMetrics(ot.GlyphIndex(gid))
+		left := float64(g.xMin) - float64(lsb)
+		phantom := [4]point{{x: left}, {x: left + float64(adv)}, {}, {}}
+		if gvar != nil {
+			tvs, err := gvar.variations(gid, g)
+			if err != nil {
+				return fmt.Errorf("glyph %d: %w", gid, err)
+			}
+			inst.applyGlyphDeltas(g, &phantom, tvs)
+		}
+		glyphs[gid] = g
+		metrics[gid].advance = ot.Round(phantom[1].x - phantom[0].x)
+		if hvar != nil {
+			idx := hvar.maps.Advance.Index(gid)
+			metrics[gid].advance = ot.Round(float64(adv) + hvar.store.Delta(idx.Outer, idx.Inner, inst.coords))
+		}
+		metrics[gid].advance = max(0, metrics[gid].advance)
+		metrics[gid].lsb = ot.Round(-phantom[0].x) // corrected by xMin below
+	}
+	glyfOut := make([]byte, 0, len(glyf))
+	locaOut := make([]uint32, 0, numGlyphs+1)
+	flattened := make(map[int][]point)
+	fontBBox := emptyBBox()
+	for gid, g := range glyphs {
+		bb := emptyBBox()
+		for _, p := range flatten(glyphs, gid, flattened, 0) {
+			bb.add(int(p.x), int(p.y))
+		}
+		locaOut = append(locaOut, uint32(len(glyfOut)))
+		glyfOut = append(glyfOut, g.encode(bb)...)
+		for len(glyfOut)%4 != 0 {
+			glyfOut = append(glyfOut, 0)
+		}
+		if !bb.empty {
+			metrics[gid].lsb += bb.xMin
+			fontBBox.union(bb)
+		}
+		metrics[gid].bb = bb
+	}
+	locaOut = append(locaOut, uint32(len(glyfOut)))
+	inst.tables[ot.T("glyf")] = glyfOut
+	longLoca := len(glyfOut)/2 > 0xffff
+	inst.tables[ot.T("loca")] = writeLoca(locaOut, longLoca)
+	if h := inst.table("head"); len(h) >= 54 {
+		if !fontBBox.empty {
+			for i, v := range []int{fontBBox.xMin, fontBBox.yMin, fontBBox.xMax, fontBBox.yMax} {
+				binary.BigEndian.PutUint16(h[36+2*i:], uint16(v))
+			}
+		}
+		indexToLocFormat := uint16(0)
+		if longLoca {
+			indexToLocFormat = 1
+		}
+		binary.BigEndian.PutUint16(h[50:], indexToLocFormat)
+	}
+	inst.writeHorizontalMetrics(metrics)
+	return nil
+}
+
+// applyGlyphDeltas applies the tuple variations of a glyph to its points (or
+// component offsets) and to its phantom points.
+func (inst *instancer) applyGlyphDeltas(g *glyph, phantom *[4]point, tvs []tupleVariation) {
+	n := len(g.points)
+	if g.isComposite() {
+		n = len(g.components)
+	}
+	dx, dy := make([]float64, n+4), make([]float64, n+4)
+	for _, tv := range tvs {
+		s := tv.scalar(inst.coords)
+		if s == 0 {
+			continue
+		}
+		if tv.points == nil {
+			for i := range min(len(dx), len(tv.x), len(tv.y)) {
+				dx[i] += s * float64(tv.x[i])
+				dy[i] += s * float64(tv.y[i])
+			}
+			continue
+		}
+		tx, ty := make([]float64, n+4), make([]float64, n+4)
+		touched := make([]bool, n+4)
+		for k, p := range tv.points {
+			if p < len(touched) && k < len(tv.x) && k < len(tv.y) {
+				tx[p], ty[p], touched[p] = float64(tv.x[k]), float64(tv.y[k]), true
+			}
+		}
+		if !g.isComposite() {
+			iup(g.points, g.endPoints, touched, tx, ty)
+		}
+		for i := range dx {
+			dx[i] += s * tx[i]
+			dy[i] += s * ty[i]
+		}
+	}
+	if g.isComposite() {
+		for i := range g.components {
+			if g.components[i].flags&compArgsAreXY != 0 {
+				g.components[i].dx += dx[i]
+				g.components[i].dy += dy[i]
+			}
+		}
+	} else {
+		for i := range g.points {
+			g.points[i].x += dx[i]
+			g.points[i].y += dy[i]
+		}
+	}
+	for i := range phantom {
+		phantom[i].x += dx[n+i]
+		phantom[i].y += dy[n+i]
+	}
+}
+
+// iup interpolates the deltas of untouched points of a simple glyph, contour by
+// contour ("interpolate untouched points", as the TrueType instruction IUP).
+// Contours without touched points remain unchanged.
+func iup(points []point, endPoints []int, touched []bool, dx, dy []float64) {
+	start := 0
+	for _, end := range endPoints {
+		if end >= len(points) {
+			break
+		}
+		var refs []int
+		for i := start; i <= end; i++ {
+			if touched[i] {
+				refs = append(refs, i)
+			}
+		}
+		if len(refs) > 0 && len(refs) <= end-start {
+			for k, r1 := range refs {
+				r2 := refs[(k+1)%len(refs)]
+				for i := r1 + 1; ; i++ { // untouched points between r1 and r2, cyclically
+					if i > end {
+						i = start
+					}
+					if i == r2 {
+						break
+					}
+					dx[i] = iupDelta(points[i].x, points[r1].x, points[r2].x, dx[r1], dx[r2])
+					dy[i] = iupDelta(points[i].y, points[r1].y, points[r2].y, dy[r1], dy[r2])
+				}
+			}
+		}
+		start = end + 1
+	}
+}
+
+// iupDelta interpolates the delta for coordinate c between reference
+// coordinates c1 and c2 with deltas d1 and d2.
+func iupDelta(c, c1, c2, d1, d2 float64) float64 {
+	if c1 == c2 {
+		if d1 == d2 {
+			return d1
+		}
+		return 0
+	}
+	if c1 > c2 {
+		c1, c2, d1, d2 = c2, c1, d2, d1
+	}
+	switch {
+	case c <= c1:
+		return d1
+	case c >= c2:
+		return d2
+	}
+	return d1 + (c-c1)*(d2-d1)/(c2-c1)
+}
+
+// maxComponentDepth limits the nesting of composite glyphs.
+const maxComponentDepth = 16
+
+// flatten returns the rounded outline points of glyph gid, with composite
+// glyphs resolved. Results are memoized in cache.
+func flatten(glyphs []*glyph, gid int, cache map[int][]point, depth int) []point {
+	if pts, ok := cache[gid]; ok {
+		return pts
+	}
+	g := glyphs[gid]
+	var pts []point
+	if !g.isComposite() {
+		pts = make([]point, len(g.points))
+		for i, p := range g.points {
+			pts[i] = point{float64(ot.Round(p.x)), float64(ot.Round(p.y))}
+		}
+	} else if depth < maxComponentDepth {
+		for _, c := range g.components {
+			if int(c.glyph) >= len(glyphs) {
+				continue
+			}
+			child := flatten(glyphs, int(c.glyph), cache, depth+1)
+			m := c.matrix
+			dx, dy := float64(ot.Round(c.dx)), float64(ot.Round(c.dy))
+			if c.flags&compArgsAreXY == 0 { // match points of parent and child
+				parent, childPt := int(c.dx), int(c.dy)
+				if parent >= len(pts) || childPt >= len(child) {
+					continue
+				}
+				cp := child[childPt]
+				dx = pts[parent].x - (m[0]*cp.x + m[2]*cp.y)
+				dy = pts[parent].y - (m[1]*cp.x + m[3]*cp.y)
+			}
+			for _, p := range child {
+				pts = append(pts, point{
+					x: float64(ot.Round(m[0]*p.x + m[2]*p.y + dx)),
+					y: float64(ot.Round(m[1]*p.x + m[3]*p.y + dy)),
+				})
+			}
+		}
+	}
+	cache[gid] = pts
+	return pts
+}
+
+// readLoca reads the numGlyphs+1 glyph locations of table 'loca'.
+func readLoca(b []byte, numGlyphs int, format uint16) ([]uint32, error) {
+	locations := make([]uint32, numGlyphs+1)
+	r := newReader(b, 0)
+	for i := range locations {
+		if format == 0 {
+			locations[i] = uint32(r.ReadU16()) * 2
+		} else {
+			locations[i] = r.ReadU32()
+		}
+	}
+	return locations, readError(r, "loca")
+}
+
+func writeLoca(locations []uint32, long bool) []byte {
+	var b []byte
+	for _, loc := range locations {
+		if long {
+			b = binary.BigEndian.AppendUint32(b, loc)
+		} else {
+			b = binary.BigEndian.AppendUint16(b, uint16(loc/2))
+		}
+	}
+	return b
+}
+
+// writeHorizontalMetrics replaces table 'hmtx' and updates the metrics in
+// 'hhea' and OS/2.xAvgCharWidth.
+func (inst *instancer) writeHorizontalMetrics(metrics []glyphMetrics) {
+	numLong := len(metrics)
+	for numLong > 1 && metrics[numLong-1].advance == metrics[numLong-2].advance {
+		numLong--
+	}
+	var hmtx []byte
+	for i, m := range metrics {
+		if i < numLong {
+			hmtx = binary.BigEndian.AppendUint16(hmtx, uint16(m.advance))
+		}
+		hmtx = binary.BigEndian.AppendUint16(hmtx, uint16(int16(m.lsb)))
+	}
+	inst.tables[ot.T("hmtx")] = hmtx
+	advMax, minLSB, minRSB, maxExtent := 0, math.MaxInt16, math.MaxInt16, math.MinInt16
+	sum, cnt := 0, 0
+	for _, m := range metrics {
+		advMax = max(advMax, m.advance)
+		if m.advance > 0 {
+			sum, cnt = sum+m.advance, cnt+1
+		}
+		if m.bb.empty {
+			continue
+		}
+		extent := m.lsb + m.bb.xMax - m.bb.xMin
+		minLSB, minRSB = min(minLSB, m.lsb), min(minRSB, m.advance-extent)
+		maxExtent = max(maxExtent, extent)
+	}
+	if hhea := inst.table("hhea"); len(hhea) >= 36 {
+		binary.BigEndian.PutUint16(hhea[10:], uint16(advMax))
+		if maxExtent != math.MinInt16 {
+			binary.BigEndian.PutUint16(hhea[12:], uint16(int16(minLSB)))
+			binary.BigEndian.PutUint16(hhea[14:], uint16(int16(minRSB)))
+			binary.BigEndian.PutUint16(hhea[16:], uint16(int16(maxExtent)))
+		}
+		binary.BigEndian.PutUint16(hhea[34:], uint16(numLong))
+	}
+	if os2 := inst.table("OS/2"); len(os2) >= 4 && cnt > 0 {
+		binary.BigEndian.PutUint16(os2[2:], uint16(ot.Round(float64(sum)/float64(cnt))))
+	}
+}
+
+// --- gvar ------------------------------------------------------------------
+
+// gvarTable gives access to the glyph variation data of table 'gvar'.
+type gvarTable struct {
+	b         []byte
+	axisCount int
+	shared    [][]float64 // shared peak tuples
+	offsets   []int       // offsets of glyph variation data, numGlyphs+1 entries
+}
+
+func (inst *instancer) parseGVar(numGlyphs int) (*gvarTable, error) {
+	t := inst.otf.Table(ot.T("gvar"))
+	if t == nil {
+		return nil, nil
+	}
+	gv := &gvarTable{b: t.Binary(), axisCount: inst.axisCount}
+	r := newReader(gv.b, 0)
+	if major := r.ReadU16(); r.Err() == nil && major != 1 {
+		return nil, fmt.Errorf("unsupported gvar version %d", major)
+	}
+	r.ReadU16() // minor version
+	axisCount, sharedCount := int(r.ReadU16()), int(r.ReadU16())
+	sharedOffset := int(r.ReadU32())
+	glyphCount, flags := int(r.ReadU16()), r.ReadU16()
+	dataOffset := int(r.ReadU32())
+	if err := readError(r, "gvar header"); err != nil {
+		return nil, err
+	}
+	if axisCount != inst.axisCount {
+		return nil, fmt.Errorf("gvar: axis count %d does not match fvar axis count %d", axisCount, inst.axisCount)
+	}
+	if glyphCount != numGlyphs {
+		return nil, fmt.Errorf("gvar: glyph count %d does not match maxp.numGlyphs %d", glyphCount, numGlyphs)
+	}
+	gv.offsets = make([]int, glyphCount+1)
+	for i := range gv.offsets {
+		if flags&0x0001 != 0 {
+			gv.offsets[i] = dataOffset + int(r.ReadU32())
+		} else {
+			gv.offsets[i] = dataOffset + 2*int(r.ReadU16())
+		}
+	}
+	if err := readError(r, "gvar glyph variation data offsets"); err != nil {
+		return nil, err
+	}
+	r = newReader(gv.b, sharedOffset)
+	gv.shared = make([][]float64, sharedCount)
+	for i := range gv.shared {
+		gv.shared[i] = make([]float64, axisCount)
+		for k := range gv.shared[i] {
+			gv.shared[i][k] = readF2Dot14(r)
+		}
+	}
+	return gv, readError(r, "gvar shared tuples")
+}
+
+// variations returns the tuple variations for glyph gid.
+func (gv *gvarTable) variations(gid int, g *glyph) ([]tupleVariation, error) {
+	start, end := gv.offsets[gid], gv.offsets[gid+1]
+	if start >= end {
+		return nil, nil
+	}
+	if end > len(gv.b) {
+		return nil, errors.New("glyph variation data out of bounds")
+	}
+	n := len(g.points)
+	if g.isComposite() {
+		n = len(g.components)
+	}
+	return parseTupleVariations(gv.b[start:end], 0, gv.axisCount, gv.shared, n+4, true)
+}
+
+// --- HVAR ------------------------------------------------------------------
+
+// hvarTable holds the advance width variations of table 'HVAR'.
+type hvarTable struct {
+	store *ot.ItemVariationStore
+	maps  MetricsIndexMaps
+}
+
+func (inst *instancer) parseHVar() (*hvarTable, error) {
+	t := inst.otf.Table(ot.T("HVAR"))
+	if t == nil {
+		return nil, nil
+	}
+	b := t.Binary()
+	r := newReader(b, 4) // skip version
+	storeOffset := int(r.ReadU32())
+	if err := readError(r, "HVAR header"); err != nil {
+		return nil, err
+	}
+	hv := &hvarTable{}
+	var err error
+	if hv.store, err = parseItemVariationStore(b, storeOffset); err != nil {
+		return nil, fmt.Errorf("HVAR: %w", err)
+	}
+	if hv.maps, err = parseMetricsIndexMaps(b, false); err != nil {
+		return nil, fmt.Errorf("HVAR: %w", err)
+	}
+	return hv, nil
+}
+
+// --- cvar ------------------------------------------------------------------
+
+// instantiateCVT applies the deltas of table 'cvar' to the control value table.
+func (inst *instancer) instantiateCVT() error {
+	cvar := inst.otf.Table(ot.T("cvar"))
+	if cvar == nil || inst.otf.Table(ot.T("cvt ")) == nil {
+		return nil
+	}
+	cvt := inst.table("cvt ")
+	n := len(cvt) / 2
+	tvs, err := parseTupleVariations(cvar.Binary(), 4, inst.axisCount, nil, n, false)
+	if err != nil {
+		return fmt.Errorf("cvar: %w", err)
+	}
+	deltas := make([]float64, n)
+	for _, tv := range tvs {
+		s := tv.scalar(inst.coords)
+		if s == 0 {
+			continue
+		}
+		for k, d := range tv.x {
+			i := k
+			if tv.points != nil {
+				i = tv.points[k]
+			}
+			if i < n {
+				deltas[i] += s * float64(d)
+			}
+		}
+	}
+	for i, d := range deltas {
+		v := int16(binary.BigEndian.Uint16(cvt[2*i:]))
+		binary.BigEndian.PutUint16(cvt[2*i:], uint16(ot.FWordFrom(float64(v)+d)))
+	}
+	return nil
+}
+
+// --- MVAR ------------------------------------------------------------------
+
+// mvarTarget is a font metric which may be varied by table 'MVAR'.
+type mvarTarget struct {
+	table    string
+	offset   int
+	unsigned bool
+}
+
+// mvarTargets maps MVAR value tags to the fields they vary.
+var mvarTargets = map[string]mvarTarget{
+	"hasc": {"OS/2", 68, false}, // sTypoAscender
+	"hdsc": {"OS/2", 70, false}, // sTypoDescender
+	"hlgp": {"OS/2", 72, false}, // sTypoLineGap
+	"hcla": {"OS/2", 74, true},  // usWinAscent
+	"hcld": {"OS/2", 76, true},  // usWinDescent
+	"xhgt": {"OS/2", 86, false}, // sxHeight
+	"cpht": {"OS/2", 88, false}, // sCapHeight
+	"sbxs": {"OS/2", 10, false}, // ySubscriptXSize
+	"sbys": {"OS/2", 12, false}, // ySubscriptYSize
+	"sbxo": {"OS/2", 14, false}, // ySubscriptXOffset
+	"sbyo": {"OS/2", 16, false}, // ySubscriptYOffset
+	"spxs": {"OS/2", 18, false}, // ySuperscriptXSize
+	"spys": {"OS/2", 20, false}, // ySuperscriptYSize
+	"spxo": {"OS/2", 22, false}, // ySuperscriptXOffset
+	"spyo": {"OS/2", 24, false}, // ySuperscriptYOffset
+	"strs": {"OS/2", 26, false}, // yStrikeoutSize
+	"stro": {"OS/2", 28, false}, // yStrikeoutPosition
+	"hcrs": {"hhea", 18, false}, // caretSlopeRise
+	"hcrn": {"hhea", 20, false}, // caretSlopeRun
+	"hcof": {"hhea", 22, false}, // caretOffset
+	"undo": {"post", 8, false},  // underlinePosition
+	"unds": {"post", 10, false}, // underlineThickness
+	"vasc": {"vhea", 4, false},  // ascent
+	"vdsc": {"vhea", 6, false},  // descent
+	"vlgp": {"vhea", 8, false},  // lineGap
+}
+
+// instantiateMetrics applies the deltas of table 'MVAR' to global font metrics.
+// Value tags for fields not known or not present in the font are ignored.
+func (inst *instancer) instantiateMetrics() error {
+	mvar := inst.otf.Table(ot.T("MVAR"))
+	if mvar == nil {
+		return nil
+	}
+	b := mvar.Binary()
+	r := newReader(b, 6) // skip version and reserved field
+	recordSize, recordCount := int(r.ReadU16()), int(r.ReadU16())
+	storeOffset := int(r.ReadU16())
+	if err := readError(r, "MVAR header"); err != nil {
+		return err
+	}
+	if storeOffset == 0 || recordCount == 0 {
+		return nil
+	}
+	if recordSize < 8 {
+		return fmt.Errorf("MVAR: invalid value record size %d", recordSize)
+	}
+	store, err := parseItemVariationStore(b, storeOffset)
+	if err != nil {
+		return fmt.Errorf("MVAR: %w", err)
+	}
+	for i := range recordCount {
+		r = newReader(b, 12+i*recordSize)
+		tag, outer, inner := ot.Tag(r.ReadU32()), r.ReadU16(), r.ReadU16()
+		if err := readError(r, "MVAR value record #%d", i); err != nil {
+			return err
+		}
+		target, ok := mvarTargets[tag.String()]
+		if !ok {
+			tracer().Debugf("MVAR: ignoring value tag %s", tag)
+			continue
+		}
+		t := inst.table(target.table)
+		if len(t) < target.offset+2 {
+			continue
+		}
+		d := store.Delta(outer, inner, inst.coords)
+		v := binary.BigEndian.Uint16(t[target.offset:])
+		if target.unsigned {
+			v = uint16(ot.UFWordFrom(float64(v) + d))
+		} else {
+			v = uint16(ot.FWordFrom(float64(int16(v)) + d))
+		}
+		binary.BigEndian.PutUint16(t[target.offset:], v)
+	}
+	return nil
+}
+
+// widthClass maps a 'wdth' axis value (percent of normal width) to the nearest
+// OS/2 width class.
+func widthClass(wdth float64) uint16 {
+	percent := []float64{50, 62.5, 75, 87.5, 100, 112.5, 125, 150, 200}
+	best := 0
+	for i, p := range percent {
+		if math.Abs(p-wdth) < math.Abs(percent[best]-wdth) {
+			best = i
+		}
+	}
+	return uint16(best + 1)
+}
@@ -0,0 +1,508 @@
+package otvar
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/npillmayer/opentype/internal/fontload"
+	"github.com/npillmayer/opentype/internal/testfont"
+	"github.com/npillmayer/opentype/ot"
+	"github.com/npillmayer/schuko/tracing/gotestingadapter"
+)
+
+func TestNormalize(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := makeVariableFont(t, nil)
+	axes, err := Axes(otf)
+	if err != nil || len(axes) != 1 || axes[0].Tag != ot.T("wght") || axes[0].Default != 400 {
+		t.Fatalf("unexpected axes %v, error %v", axes, err)
+	}
+	instances, err := NamedInstances(otf)
+	if err != nil || len(instances) != 1 || instances[0].Coords[ot.T("wght")] != 700 {
+		t.Fatalf("unexpected named instances %v, error %v", instances, err)
+	}
+	for _, c := range []struct{ wght, norm float64 }{
+		{400, 0}, {100, -1}, {250, -0.5}, {650, 0.75}, {900, 1}, {1000, 1}, {50, -1},
+	} {
+		norm, err := Normalize(otf, map[ot.Tag]float64{ot.T("wght"): c.wght})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if norm[0] != c.norm {
+			t.Errorf("expected wght=%g to be normalized to %g, have %g", c.wght, c.norm, norm[0])
+		}
+	}
+	if _, err := Normalize(otf, map[ot.Tag]float64{ot.T("wdth"): 100}); err == nil {
+		t.Errorf("expected error for unknown axis")
+	}
+	if _, err := Normalize(loadCalibri(t), nil); err == nil {
+		t.Errorf("expected error for static font")
+	}
+}
+
+func TestNormalizeAVar2(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	// identity segment map; axis 0 is mapped to delta set (0, 1), which moves
+	// the coordinate by −0.25 at wght=900
+	var avar []byte
+	avar = be16(avar, 2)
+	avar = be16(avar, 0)
+	avar = be16(avar, 0)
+	avar = be16(avar, 1)      // axis count
+	avar = be16(avar, 0)      // position map count
+	avar = be32(avar, 18)     // axis index map offset
+	avar = be32(avar, 18+5)   // item variation store offset
+	avar = append(avar, 0, 0) // format 0, 1-byte entries with 1 inner bit
+	avar = be16(avar, 1)
+	avar = append(avar, 1)
+	avar = append(avar, buildItemVariationStore([]int{0, -1 << 12})...)
+	otf := makeVariableFont(t, map[ot.Tag][]byte{ot.T("avar"): avar})
+	for _, c := range []struct{ wght, norm float64 }{
+		{400, 0}, {100, -1}, {650, 0.375}, {900, 0.75},
+	} {
+		norm, err := Normalize(otf, map[ot.Tag]float64{ot.T("wght"): c.wght})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if norm[0] != c.norm {
+			t.Errorf("expected wght=%g to be normalized to %g, have %g", c.wght, c.norm, norm[0])
+		}
+	}
+	m, err := AxisIndexMap(otf)
+	if err != nil || len(m) != 1 || m.Index(0) != (DeltaSetIndex{0, 1}) {
+		t.Errorf("unexpected axis index map %v, error %v", m, err)
+	}
+}
+
+func TestMetricsMaps(t *testing.T) {
+	calibri := loadCalibri(t)
+	numGlyphs := calibri.Table(ot.T("maxp")).Self().AsMaxP().NumGlyphs
+	otf := makeVariableFont(t, map[ot.Tag][]byte{ot.T("HVAR"): buildHVAR(numGlyphs, 5, 30)})
+	maps, err := MetricsMaps(otf, ot.T("HVAR"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(maps.Advance) != numGlyphs || maps.Advance.Index(5) != (DeltaSetIndex{0, 1}) ||
+		maps.Advance.Index(4) != (DeltaSetIndex{0, 0}) {
+		t.Errorf("unexpected advance width mapping %v", maps.Advance[:6])
+	}
+	if maps.Start != nil || maps.End != nil || maps.Origin != nil {
+		t.Errorf("expected no side bearing mappings")
+	}
+	if _, err := MetricsMaps(otf, ot.T("VVAR")); err == nil {
+		t.Errorf("expected error for missing table VVAR")
+	}
+	if _, err := MetricsMaps(otf, ot.T("MVAR")); err == nil {
+		t.Errorf("expected error for table MVAR")
+	}
+}
+
+func TestInstantiate(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := makeVariableFont(t, nil)
+	o := int(otf.CMapTable().GlyphIndexMap.Lookup('o'))
+	a := int(otf.CMapTable().GlyphIndexMap.Lookup('a'))
+	orig, origA := decodeGlyphOf(t, otf, o), decodeGlyphOf(t, otf, a)
+	advO, lsbO, _ := otf.HorizontalMetrics().HMetrics(ot.GlyphIndex(o))
+	for _, c := range []struct {
+		wght      float64
+		dx        []float64 // expected delta per contour
+		dAdvance  int
+		weightCls uint16
+	}{
+		{wght: 900, dx: []float64{10, 10}, dAdvance: 20, weightCls: 900},
+		{wght: 650, dx: []float64{8, 8}, dAdvance: 15, weightCls: 650},
+		{wght: 100, dx: []float64{8, 0}, dAdvance: 0, weightCls: 100},
+	} {
+		data, err := Instantiate(otf, map[ot.Tag]float64{ot.T("wght"): c.wght})
+		if err != nil {
+			t.Fatalf("wght=%g: %v", c.wght, err)
+		}
+		static, err := ot.Parse(data, ot.VerifyChecksums)
+		if err != nil {
+			t.Fatalf("wght=%g: cannot parse static instance: %v", c.wght, err)
+		}
+		for _, tag := range []string{"fvar", "avar", "gvar", "MVAR"} {
+			if static.Table(ot.T(tag)) != nil {
+				t.Errorf("wght=%g: expected table %s to be dropped", c.wght, tag)
+			}
+		}
+		if m := static.VerifyChecksums(); len(m) != 0 {
+			t.Errorf("wght=%g: checksum mismatches %v", c.wght, m)
+		}
+		g := decodeGlyphOf(t, static, o)
+		contour := 0
+		for i, p := range g.points {
+			if i > orig.endPoints[contour] {
+				contour++
+			}
+			if p.x != orig.points[i].x+c.dx[contour] || p.y != orig.points[i].y {
+				t.Errorf("wght=%g: point %d of 'o' is at %v, expected %v shifted by %g",
+					c.wght, i, p, orig.points[i], c.dx[contour])
+				break
+			}
+		}
+		if g.xMin != orig.xMin+int16(c.dx[0]) {
+			t.Errorf("wght=%g: expected xMin %d, have %d", c.wght, orig.xMin+int16(c.dx[0]), g.xMin)
+		}
+		adv, lsb, _ := static.HorizontalMetrics().HMetrics(ot.GlyphIndex(o))
+		if int(adv) != int(advO)+c.dAdvance || lsb != lsbO+int16(c.dx[0]) {
+			t.Errorf("wght=%g: expected advance %d and lsb %d, have %d and %d",
+				c.wght, int(advO)+c.dAdvance, lsbO+int16(c.dx[0]), adv, lsb)
+		}
+		if gA := decodeGlyphOf(t, static, a); len(gA.points) != len(origA.points) || gA.points[0] != origA.points[0] {
+			t.Errorf("wght=%g: expected glyph 'a' to be unchanged", c.wght)
+		}
+		if cls := binary.BigEndian.Uint16(static.Table(ot.T("OS/2")).Binary()[4:]); cls != c.weightCls {
+			t.Errorf("wght=%g: expected weight class %d, have %d", c.wght, c.weightCls, cls)
+		}
+	}
+}
+
+func TestInstantiateDefault(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	otf := makeVariableFont(t, nil)
+	data, err := Instantiate(otf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	static, err := ot.Parse(data)
+	if err != nil {
+		t.Fatalf("cannot parse default instance: %v", err)
+	}
+	// outlines and bounding boxes of all glyphs, including composites, are unchanged
+	numGlyphs := static.Table(ot.T("maxp")).Self().AsMaxP().NumGlyphs
+	for gid := range numGlyphs {
+		g, orig := decodeGlyphOf(t, static, gid), decodeGlyphOf(t, otf, gid)
+		if g.contours != orig.contours || g.xMin != orig.xMin || g.xMax != orig.xMax ||
+			len(g.points) != len(orig.points) || len(g.components) != len(orig.components) {
+			t.Fatalf("glyph %d changed in default instance", gid)
+		}
+		a1, l1, _ := otf.HorizontalMetrics().HMetrics(ot.GlyphIndex(gid))
+		a2, l2, _ := static.HorizontalMetrics().HMetrics(ot.GlyphIndex(gid))
+		if a1 != a2 || l1 != l2 {
+			t.Fatalf("metrics of glyph %d changed in default instance: %d/%d → %d/%d", gid, a1, l1, a2, l2)
+		}
+	}
+	x1, y1, x2, y2 := otf.FontHead().BoundingBox()
+	if bx1, by1, bx2, by2 := static.FontHead().BoundingBox(); x1 != bx1 || y1 != by1 || x2 != bx2 || y2 != by2 {
+		t.Errorf("expected font bounding box to be unchanged")
+	}
+}
+
+func TestInstantiateVariationTables(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	calibri := loadCalibri(t)
+	o := int(calibri.CMapTable().GlyphIndexMap.Lookup('o'))
+	numGlyphs := calibri.Table(ot.T("maxp")).Self().AsMaxP().NumGlyphs
+	otf := makeVariableFont(t, map[ot.Tag][]byte{
+		ot.T("HVAR"): buildHVAR(numGlyphs, o, 30),
+		ot.T("MVAR"): buildMVAR("xhgt", 15),
+		ot.T("cvar"): buildCVAR(3, -4),
+	})
+	data, err := Instantiate(otf, map[ot.Tag]float64{ot.T("wght"): 900})
+	if err != nil {
+		t.Fatal(err)
+	}
+	static, err := ot.Parse(data)
+	if err != nil {
+		t.Fatalf("cannot parse static instance: %v", err)
+	}
+	if static.Table(ot.T("HVAR")) != nil || static.Table(ot.T("cvar")) != nil {
+		t.Errorf("expected variation tables to be dropped")
+	}
+	// advance is taken from HVAR instead of the phantom points
+	if adv, want := static.HorizontalMetrics().Advance(ot.GlyphIndex(o)), calibri.HorizontalMetrics().Advance(ot.GlyphIndex(o))+30; adv != want {
+		t.Errorf("expected advance %d from HVAR, have %d", want, adv)
+	}
+	xhgt := func(f *ot.Font) int16 { return int16(binary.BigEndian.Uint16(f.Table(ot.T("OS/2")).Binary()[86:])) }
+	if xhgt(static) != xhgt(calibri)+15 {
+		t.Errorf("expected x-height %d from MVAR, have %d", xhgt(calibri)+15, xhgt(static))
+	}
+	cvt := func(f *ot.Font, i int) int16 {
+		return int16(binary.BigEndian.Uint16(f.Table(ot.T("cvt ")).Binary()[2*i:]))
+	}
+	if cvt(static, 3) != cvt(calibri, 3)-4 || cvt(static, 2) != cvt(calibri, 2) {
+		t.Errorf("expected CVT entry 3 to be changed by cvar, have %d → %d", cvt(calibri, 3), cvt(static, 3))
+	}
+}
+
+func TestInstantiateErrors(t *testing.T) {
+	teardown := gotestingadapter.QuickConfig(t, "font.opentype")
+	defer teardown()
+	//
+	if _, err := Instantiate(loadCalibri(t), nil); err == nil {
+		t.Errorf("expected static font to be rejected")
+	}
+	otf := makeVariableFont(t, nil)
+	if _, err := Instantiate(otf, map[ot.Tag]float64{ot.T("opsz"): 12}); err == nil {
+		t.Errorf("expected unknown axis to be rejected")
+	}
+}
+
+// --- Helpers ---------------------------------------------------------------
+
+func loadCalibri(t *testing.T) *ot.Font {
+	t.Helper()
+	f, err := fontload.LoadOpenTypeFont(filepath.Join("..", "testdata", "fonts", "Calibri.ttf"))
+	if err != nil {
+		t.Fatalf("cannot load test font: %v", err)
+	}
+	otf, err := ot.Parse(f.Binary)
+	if err != nil {
+		t.Fatalf("cannot parse test font: %v", err)
+	}
+	return otf
+}
+
+func decodeGlyphOf(t *testing.T, otf *ot.Font, gid int) *glyph {
+	t.Helper()
+	numGlyphs := otf.Table(ot.T("maxp")).Self().AsMaxP().NumGlyphs
+	loca, err := readLoca(otf.Table(ot.T("loca")).Binary(), numGlyphs, otf.FontHead().IndexToLocFormat)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g, err := decodeGlyph(otf.Table(ot.T("glyf")).Binary()[loca[gid]:loca[gid+1]])
+	if err != nil {
+		t.Fatalf("cannot decode glyph %d: %v", gid, err)
+	}
+	return g
+}
+
+// makeVariableFont turns Calibri into a variable font with a single axis
+// 'wght' (100…400…900). Glyph 'o' has two variations:
+//
+//   - at wght=900 (peak 1.0), all points move 10 units to the right, and the
+//     advance width grows by 20 units
+//   - at wght=100 (peak −1.0), point 0 moves 8 units to the right, which moves
+//     the whole first contour by interpolation of untouched points
+//
+// Table 'avar' maps normalized 0.5 to 0.75.
+func makeVariableFont(t *testing.T, extra map[ot.Tag][]byte) *ot.Font {
+	t.Helper()
+	calibri := loadCalibri(t)
+	numGlyphs := calibri.Table(ot.T("maxp")).Self().AsMaxP().NumGlyphs
+	o := int(calibri.CMapTable().GlyphIndexMap.Lookup('o'))
+	n := len(decodeGlyphOf(t, calibri, o).points)
+	//
+	dx := make([]int, n+4)
+	for i := range n {
+		dx[i] = 10
+	}
+	dx[n+1] = 20
+	var gvd []byte // glyph variation data for 'o'
+	gvd = be16(gvd, 2)
+	gvd = be16(gvd, 4+2*6) // data offset: after two headers with embedded peaks
+	bold := packedDeltas(nil, dx)
+	bold = packedDeltas(bold, make([]int, n+4))
+	light := packedPoints(nil, []int{0})
+	light = packedDeltas(light, []int{8})
+	light = packedDeltas(light, []int{0})
+	gvd = be16(gvd, len(bold))
+	gvd = be16(gvd, tupleEmbeddedPeak)
+	gvd = be16(gvd, 1<<14)
+	gvd = be16(gvd, len(light))
+	gvd = be16(gvd, tupleEmbeddedPeak|tuplePrivatePointNumbers)
+	gvd = be16(gvd, -1<<14)
+	gvd = append(gvd, bold...)
+	gvd = append(gvd, light...)
+	for len(gvd)%4 != 0 {
+		gvd = append(gvd, 0)
+	}
+	var gvar []byte
+	gvar = be16(gvar, 1)
+	gvar = be16(gvar, 0)
+	gvar = be16(gvar, 1) // axis count
+	gvar = be16(gvar, 0) // shared tuples
+	gvar = be32(gvar, 20+4*(numGlyphs+1))
+	gvar = be16(gvar, numGlyphs)
+	gvar = be16(gvar, 1) // long offsets
+	gvar = be32(gvar, 20+4*(numGlyphs+1))
+	for gid := range numGlyphs + 1 {
+		gvar = be32(gvar, map[bool]int{false: 0, true: len(gvd)}[gid > o])
+	}
+	gvar = append(gvar, gvd...)
+	//
+	var fvar []byte
+	fvar = be16(fvar, 1)
+	fvar = be16(fvar, 0)
+	fvar = be16(fvar, 16) // axes offset
+	fvar = be16(fvar, 2)
+	fvar = be16(fvar, 1)  // axis count
+	fvar = be16(fvar, 20) // axis size
+	fvar = be16(fvar, 1)  // instance count
+	fvar = be16(fvar, 8)  // instance size
+	fvar = be32(fvar, int(ot.T("wght")))
+	fvar = be32(fvar, 100<<16)
+	fvar = be32(fvar, 400<<16)
+	fvar = be32(fvar, 900<<16)
+	fvar = be16(fvar, 0)
+	fvar = be16(fvar, 256)
+	fvar = be16(fvar, 257)
+	fvar = be16(fvar, 0)
+	fvar = be32(fvar, 700<<16)
+	//
+	var avar []byte
+	avar = be16(avar, 1)
+	avar = be16(avar, 0)
+	avar = be16(avar, 0)
+	avar = be16(avar, 1) // axis count
+	avar = be16(avar, 4) // position map count
+	for _, m := range [][2]float64{{-1, -1}, {0, 0}, {0.5, 0.75}, {1, 1}} {
+		avar = be16(avar, int(m[0]*(1<<14)))
+		avar = be16(avar, int(m[1]*(1<<14)))
+	}
+	tables := map[ot.Tag][]byte{ot.T("fvar"): fvar, ot.T("avar"): avar, ot.T("gvar"): gvar}
+	for tag, b := range extra {
+		tables[tag] = b
+	}
+	data, err := calibri.Rebuild(tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otf, err := ot.Parse(data)
+	if err != nil {
+		t.Fatalf("cannot parse variable test font: %v", err)
+	}
+	return otf
+}
+
+// itemVariationStore creates a store with a single region (wght peak 1.0) and
+// a single item variation data subtable with the given deltas.
+func buildItemVariationStore(deltas []int) []byte {
+	var b []byte
+	b = be16(b, 1)
+	b = be32(b, 12) // region list offset
+	b = be16(b, 1)
+	b = be32(b, 12+10) // item variation data offset
+	b = be16(b, 1)     // region list: axis count
+	b = be16(b, 1)     // region count
+	b = be16(b, 0)
+	b = be16(b, 1<<14)
+	b = be16(b, 1<<14)
+	b = be16(b, len(deltas)) // item count
+	b = be16(b, 1)           // word delta count
+	b = be16(b, 1)           // region index count
+	b = be16(b, 0)
+	for _, d := range deltas {
+		b = be16(b, d)
+	}
+	return b
+}
+
+func buildHVAR(numGlyphs, gid, delta int) []byte {
+	var b []byte
+	b = be16(b, 1)
+	b = be16(b, 0)
+	b = be32(b, 20)           // item variation store offset
+	b = be32(b, 20+12+10+8+4) // advance width mapping offset, after the store
+	b = be32(b, 0)
+	b = be32(b, 0)
+	b = append(b, buildItemVariationStore([]int{0, delta})...)
+	b = append(b, 0, 0x00) // format 0, 1-byte entries with 1 inner bit
+	b = be16(b, numGlyphs)
+	for i := range numGlyphs {
+		b = append(b, map[bool]byte{false: 0, true: 1}[i == gid])
+	}
+	return b
+}
+
+func buildMVAR(tag string, delta int) []byte {
+	var b []byte
+	b = be16(b, 1)
+	b = be16(b, 0)
+	b = be16(b, 0)
+	b = be16(b, 8)  // value record size
+	b = be16(b, 1)  // value record count
+	b = be16(b, 20) // item variation store offset
+	b = be32(b, int(ot.T(tag)))
+	b = be16(b, 0)
+	b = be16(b, 0)
+	return append(b, buildItemVariationStore([]int{delta})...)
+}
+
+func buildCVAR(entry, delta int) []byte {
+	data := packedPoints(nil, []int{entry})
+	data = packedDeltas(data, []int{delta})
+	var b []byte
+	b = be16(b, 1)
+	b = be16(b, 0)
+	b = be16(b, 1)
+	b = be16(b, 14) // data offset
+	b = be16(b, len(data))
+	b = be16(b, tupleEmbeddedPeak|tuplePrivatePointNumbers)
+	b = be16(b, 1<<14)
+	return append(b, data...)
+}
+
+func packedPoints(b []byte, points []int) []byte {
+	b = append(b, byte(len(points)))
+	b = append(b, pointsAreWords|byte(len(points)-1))
+	last := 0
+	for _, p := range points {
+		b = be16(b, p-last)
+		last = p
+	}
+	return b
+}
+
+func packedDeltas(b []byte, deltas []int) []byte {
+	for len(deltas) > 0 {
+		run := min(len(deltas), deltaRunCountMask+1)
+		b = append(b, deltasAreWords|byte(run-1))
+		for _, d := range deltas[:run] {
+			b = be16(b, d)
+		}
+		deltas = deltas[run:]
+	}
+	return b
+}
+
+func be16(b []byte, v int) []byte {
+	return binary.BigEndian.AppendUint16(b, uint16(v))
+}
+
+func be32(b []byte, v int) []byte {
+	return binary.BigEndian.AppendUint32(b, uint32(v))
+}
+
+func TestOpticalSizeCoord(t *testing.T) {
+	var fvar []byte
+	fvar = be16(fvar, 1)
+	fvar = be16(fvar, 0)
+	fvar = be16(fvar, 16) // axes offset
+	fvar = be16(fvar, 2)
+	fvar = be16(fvar, 1)  // axis count
+	fvar = be16(fvar, 20) // axis size
+	fvar = be16(fvar, 0)  // instance count
+	fvar = be16(fvar, 8)  // instance size
+	fvar = be32(fvar, int(ot.T("opsz")))
+	fvar = be32(fvar, 6<<16)
+	fvar = be32(fvar, 12<<16)
+	fvar = be32(fvar, 72<<16)
+	fvar = be16(fvar, 0)
+	fvar = be16(fvar, 256)
+	otf, err := testfont.New(2).Table("fvar", fvar).Parse()
+	if err != nil {
+		t.Fatalf("cannot parse synthetic font: %v", err)
+	}
+	for _, c := range []struct{ pt, opsz float64 }{{9, 9}, {4, 6}, {100, 72}} {
+		if opsz, ok := OpticalSizeCoord(otf, c.pt); !ok || opsz != c.opsz {
+			t.Errorf("expected opsz=%g for %gpt, have %g (%v)", c.opsz, c.pt, opsz, ok)
+		}
+	}
+	if _, ok := OpticalSizeCoord(makeVariableFont(t, nil), 9); ok {
+		t.Errorf("did not expect font without axis 'opsz' to have an optical size coordinate")
+	}
+}
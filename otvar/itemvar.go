@@ -0,0 +1,79 @@
+package otvar
+
+import (
+	"fmt"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// parseItemVariationStore reads an item variation store located at offset
+// off within table data b.
+func parseItemVariationStore(b []byte, off int) (*ot.ItemVariationStore, error) {
+	if off < 0 || off >= len(b) {
+		return nil, fmt.Errorf("item variation store offset %d out of bounds", off)
+	}
+	return ot.ParseItemVariationStore(b[off:])
+}
+
+// DeltaSetIndex addresses a delta set of an item variation store: Outer selects
+// an item variation data subtable, Inner a delta set within it.
+type DeltaSetIndex struct {
+	Outer, Inner uint16
+}
+
+// DeltaSetIndexMap maps glyph IDs (or other item numbers, such as the axis
+// indexes of table 'avar' version 2) to delta set indexes of an item variation
+// store. A nil map is the implicit mapping with outer index 0 and inner index
+// equal to the item number.
+type DeltaSetIndexMap []DeltaSetIndex
+
+// ParseDeltaSetIndexMap reads a delta set index map located at offset off
+// within table data b. Both map formats (16-bit and 32-bit map counts) and all
+// entry formats (1 to 4 bytes per entry, 1 to 16 bits for the inner index) are
+// supported.
+func ParseDeltaSetIndexMap(b []byte, off int) (DeltaSetIndexMap, error) {
+	r := newReader(b, off)
+	format := r.ReadU8()
+	entryFormat := r.ReadU8()
+	var count int
+	switch format {
+	case 0:
+		count = int(r.ReadU16())
+	case 1:
+		count = int(r.ReadU32())
+	default:
+		return nil, fmt.Errorf("unsupported delta set index map format %d", format)
+	}
+	entrySize := int(entryFormat>>4&0x3) + 1
+	innerBits := uint(entryFormat&0xf) + 1
+	m := make(DeltaSetIndexMap, 0, min(count, len(b)))
+	for range count {
+		var entry uint32
+		for range entrySize {
+			entry = entry<<8 | uint32(r.ReadU8())
+		}
+		if r.Err() != nil {
+			break
+		}
+		m = append(m, DeltaSetIndex{
+			Outer: uint16(entry >> innerBits),
+			Inner: uint16(entry & (1<<innerBits - 1)),
+		})
+	}
+	if err := readError(r, "delta set index map"); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Index returns the delta set index for item i. Items beyond the end of the
+// map use the last entry.
+func (m DeltaSetIndexMap) Index(i int) DeltaSetIndex {
+	if m == nil {
+		return DeltaSetIndex{Inner: uint16(i)}
+	}
+	if len(m) == 0 {
+		return DeltaSetIndex{}
+	}
+	return m[min(i, len(m)-1)]
+}
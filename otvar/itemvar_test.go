@@ -0,0 +1,28 @@
+package otvar
+
+import (
+	"testing"
+)
+
+func TestDeltaSetIndexMap(t *testing.T) {
+	// format 1, 2-byte entries with 4 inner bits, 3 entries
+	b := []byte{0xff, 1, 0x13, 0, 0, 0, 3, 0x00, 0x12, 0x00, 0xff, 0x01, 0x00}
+	m, err := ParseDeltaSetIndexMap(b, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, expected := range []DeltaSetIndex{{1, 2}, {15, 15}, {16, 0}, {16, 0}} {
+		if idx := m.Index(i); idx != expected {
+			t.Errorf("expected delta set index %v for item %d, have %v", expected, i, idx)
+		}
+	}
+	if idx := DeltaSetIndexMap(nil).Index(7); idx != (DeltaSetIndex{0, 7}) {
+		t.Errorf("expected implicit delta set index {0 7}, have %v", idx)
+	}
+	if _, err := ParseDeltaSetIndexMap(b[:len(b)-1], 1); err == nil {
+		t.Errorf("expected error for truncated map")
+	}
+	if _, err := ParseDeltaSetIndexMap([]byte{2, 0, 0, 0}, 0); err == nil {
+		t.Errorf("expected error for unknown map format")
+	}
+}
@@ -0,0 +1,68 @@
+package otvar
+
+import (
+	"fmt"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// MetricsIndexMaps holds the delta set index maps of a metrics variations table,
+// 'HVAR' or 'VVAR', which map glyph IDs to delta sets of the table's item
+// variation store.
+//
+// A nil Advance map is the implicit mapping (see DeltaSetIndexMap). For the
+// side bearings and vertical origins, a nil map means that the table does not
+// contain variations of these metrics.
+type MetricsIndexMaps struct {
+	Advance DeltaSetIndexMap // advance widths ('HVAR') or heights ('VVAR')
+	Start   DeltaSetIndexMap // left ('HVAR') or top ('VVAR') side bearings
+	End     DeltaSetIndexMap // right ('HVAR') or bottom ('VVAR') side bearings
+	Origin  DeltaSetIndexMap // vertical origins; 'VVAR' only
+}
+
+// MetricsMaps returns the delta set index maps of metrics variations table
+// 'HVAR' or 'VVAR' of font otf. It returns an error if tag is neither of them
+// or if the font does not contain the table.
+func MetricsMaps(otf *ot.Font, tag ot.Tag) (MetricsIndexMaps, error) {
+	if tag != ot.T("HVAR") && tag != ot.T("VVAR") {
+		return MetricsIndexMaps{}, fmt.Errorf("%s is not a metrics variations table", tag)
+	}
+	t := otf.Table(tag)
+	if t == nil {
+		return MetricsIndexMaps{}, fmt.Errorf("font has no table %s", tag)
+	}
+	maps, err := parseMetricsIndexMaps(t.Binary(), tag == ot.T("VVAR"))
+	if err != nil {
+		return MetricsIndexMaps{}, fmt.Errorf("%s: %w", tag, err)
+	}
+	return maps, nil
+}
+
+// parseMetricsIndexMaps reads the delta set index maps referenced from the
+// header of table 'HVAR' or (if vertical is set) 'VVAR'.
+func parseMetricsIndexMaps(b []byte, vertical bool) (MetricsIndexMaps, error) {
+	r := newReader(b, 8) // skip version and item variation store offset
+	var maps MetricsIndexMaps
+	targets := []*DeltaSetIndexMap{&maps.Advance, &maps.Start, &maps.End}
+	if vertical {
+		targets = append(targets, &maps.Origin)
+	}
+	offsets := make([]int, len(targets))
+	for i := range offsets {
+		offsets[i] = int(r.ReadU32())
+	}
+	if err := readError(r, "header"); err != nil {
+		return MetricsIndexMaps{}, err
+	}
+	for i, off := range offsets {
+		if off == 0 {
+			continue
+		}
+		m, err := ParseDeltaSetIndexMap(b, off)
+		if err != nil {
+			return MetricsIndexMaps{}, err
+		}
+		*targets[i] = m
+	}
+	return maps, nil
+}
@@ -0,0 +1,186 @@
+package otvar
+
+import (
+	"fmt"
+
+	"github.com/npillmayer/opentype/ot"
+)
+
+// Flags of tuple variation headers.
+const (
+	tupleEmbeddedPeak        = 0x8000
+	tupleIntermediateRegion  = 0x4000
+	tuplePrivatePointNumbers = 0x2000
+	tupleIndexMask           = 0x0fff
+	tupleSharedPointNumbers  = 0x8000 // flag of tupleVariationCount
+	tupleCountMask           = 0x0fff
+	pointsAreWords           = 0x80
+	pointRunCountMask        = 0x7f
+	deltasAreZero            = 0x80
+	deltasAreWords           = 0x40
+	deltaRunCountMask        = 0x3f
+	deltasAreLongs           = deltasAreZero | deltasAreWords
+)
+
+// tupleVariation is one set of deltas of a tuple variation store, as used in
+// tables 'gvar' and 'cvar'. A tuple variation applies to a region of the
+// design space, given by a peak tuple and optional intermediate start and end
+// tuples.
+type tupleVariation struct {
+	peak       []float64 // peak coordinates, one per axis
+	start, end []float64 // intermediate region; nil if implied by peak
+	points     []int     // point numbers the deltas apply to; nil means all points
+	x, y       []int32   // deltas; y is nil for 'cvar'
+}
+
+// scalar returns the factor with which the deltas of tv are to be applied for
+// normalized coordinates coords.
+func (tv *tupleVariation) scalar(coords []float64) float64 {
+	s := 1.0
+	for i, peak := range tv.peak {
+		if peak == 0 {
+			continue
+		}
+		v := coords[i]
+		if v == peak {
+			continue
+		}
+		if tv.start == nil {
+			if v == 0 || v < min(0, peak) || v > max(0, peak) {
+				return 0
+			}
+			s *= v / peak
+			continue
+		}
+		start, end := tv.start[i], tv.end[i]
+		if start > peak || peak > end || (start < 0 && end > 0) {
+			continue // invalid region for this axis is ignored
+		}
+		if v <= start || v >= end {
+			return 0
+		}
+		if v < peak {
+			s *= (v - start) / (peak - start)
+		} else {
+			s *= (end - v) / (end - peak)
+		}
+	}
+	return s
+}
+
+// parseTupleVariations reads a tuple variation store. b is the data which
+// offsets are relative to, pos is the position of field tupleVariationCount.
+// pointCount is the number of points (glyph points including phantom points, or
+// CVT entries) deltas may refer to; withY is false for stores with a single
+// delta per point ('cvar').
+func parseTupleVariations(b []byte, pos int, axisCount int, shared [][]float64,
+	pointCount int, withY bool) ([]tupleVariation, error) {
+	//
+	r := newReader(b, pos)
+	count := r.ReadU16()
+	dataOffset := int(r.ReadU16())
+	if err := readError(r, "tuple variation store header"); err != nil {
+		return nil, err
+	}
+	data := newReader(b, dataOffset)
+	var sharedPoints []int
+	if count&tupleSharedPointNumbers != 0 {
+		sharedPoints = readPackedPoints(data)
+	}
+	n := int(count & tupleCountMask)
+	tvs := make([]tupleVariation, 0, n)
+	for i := range n {
+		size := int(r.ReadU16())
+		index := r.ReadU16()
+		tv := tupleVariation{points: sharedPoints}
+		readTuple := func() []float64 {
+			t := make([]float64, axisCount)
+			for k := range t {
+				t[k] = readF2Dot14(r)
+			}
+			return t
+		}
+		if index&tupleEmbeddedPeak != 0 {
+			tv.peak = readTuple()
+		} else if k := int(index & tupleIndexMask); k < len(shared) {
+			tv.peak = shared[k]
+		} else {
+			return nil, fmt.Errorf("tuple variation #%d: shared tuple index %d out of range", i, k)
+		}
+		if index&tupleIntermediateRegion != 0 {
+			tv.start, tv.end = readTuple(), readTuple()
+		}
+		if err := readError(r, "tuple variation header #%d", i); err != nil {
+			return nil, err
+		}
+		chunk := data.ReadBytes(size)
+		if err := readError(data, "tuple variation data #%d", i); err != nil {
+			return nil, err
+		}
+		d := newReader(chunk, 0)
+		if index&tuplePrivatePointNumbers != 0 {
+			tv.points = readPackedPoints(d)
+		}
+		deltaCount := pointCount
+		if tv.points != nil {
+			deltaCount = len(tv.points)
+		}
+		tv.x = readPackedDeltas(d, deltaCount)
+		if withY {
+			tv.y = readPackedDeltas(d, deltaCount)
+		}
+		if err := readError(d, "tuple variation deltas #%d", i); err != nil {
+			return nil, err
+		}
+		tvs = append(tvs, tv)
+	}
+	return tvs, nil
+}
+
+// readPackedPoints reads packed point numbers. It returns nil if the deltas
+// apply to all points.
+func readPackedPoints(r *ot.Reader) []int {
+	n := int(r.ReadU8())
+	if n&pointsAreWords != 0 {
+		n = (n&pointRunCountMask)<<8 | int(r.ReadU8())
+	}
+	if n == 0 {
+		return nil
+	}
+	points := make([]int, 0, n)
+	p := 0
+	for len(points) < n && r.Err() == nil {
+		ctl := r.ReadU8()
+		for range int(ctl&pointRunCountMask) + 1 {
+			if ctl&pointsAreWords != 0 {
+				p += int(r.ReadU16())
+			} else {
+				p += int(r.ReadU8())
+			}
+			points = append(points, p)
+		}
+	}
+	return points[:min(n, len(points))]
+}
+
+// readPackedDeltas reads n packed deltas.
+func readPackedDeltas(r *ot.Reader, n int) []int32 {
+	deltas := make([]int32, 0, n)
+	for len(deltas) < n && r.Err() == nil {
+		ctl := r.ReadU8()
+		for range int(ctl&deltaRunCountMask) + 1 {
+			var d int32
+			switch ctl & deltasAreLongs {
+			case deltasAreZero:
+			case deltasAreWords:
+				d = int32(r.ReadI16())
+			case deltasAreLongs:
+				d = r.ReadI32()
+			default:
+				d = int32(int8(r.ReadU8()))
+			}
+			deltas = append(deltas, d)
+		}
+	}
+	return deltas[:min(n, len(deltas))]
+}
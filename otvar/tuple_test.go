@@ -0,0 +1,54 @@
+package otvar
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPackedPointsAndDeltas(t *testing.T) {
+	// 4 points in two runs: bytes (1, 3) and words (300, 301)
+	r := newReader([]byte{4, 0x01, 1, 2, 0x81, 0x01, 0x29, 0x00, 0x01}, 0)
+	if points := readPackedPoints(r); !slices.Equal(points, []int{1, 3, 300, 301}) || r.Err() != nil {
+		t.Errorf("unexpected point numbers %v, error %v", points, r.Err())
+	}
+	if points := readPackedPoints(newReader([]byte{0}, 0)); points != nil {
+		t.Errorf("expected count 0 to denote all points, have %v", points)
+	}
+	// 7 deltas: two bytes, three zeros, one word, one long
+	r = newReader([]byte{0x01, 5, 0xfb, 0x82, 0x40, 0x01, 0x00, 0xc0, 0, 1, 0, 0}, 0)
+	if deltas := readPackedDeltas(r, 7); !slices.Equal(deltas, []int32{5, -5, 0, 0, 0, 256, 65536}) || r.Err() != nil {
+		t.Errorf("unexpected deltas %v, error %v", deltas, r.Err())
+	}
+	r = newReader([]byte{0x03, 1, 2}, 0)
+	readPackedDeltas(r, 4)
+	if r.Err() == nil {
+		t.Errorf("expected error for truncated deltas")
+	}
+}
+
+func TestTupleScalar(t *testing.T) {
+	tv := tupleVariation{peak: []float64{1, 0}}
+	for _, c := range []struct{ coord, scalar float64 }{{0, 0}, {0.5, 0.5}, {1, 1}, {-0.5, 0}} {
+		if s := tv.scalar([]float64{c.coord, 0.3}); s != c.scalar {
+			t.Errorf("expected scalar %g at %g, have %g", c.scalar, c.coord, s)
+		}
+	}
+	tv = tupleVariation{peak: []float64{0.5}, start: []float64{0.25}, end: []float64{1}}
+	for _, c := range []struct{ coord, scalar float64 }{{0.25, 0}, {0.375, 0.5}, {0.5, 1}, {0.75, 0.5}, {1, 0}} {
+		if s := tv.scalar([]float64{c.coord}); s != c.scalar {
+			t.Errorf("intermediate: expected scalar %g at %g, have %g", c.scalar, c.coord, s)
+		}
+	}
+}
+
+func TestIUP(t *testing.T) {
+	// a square contour with points 0 and 2 touched
+	points := []point{{0, 0}, {50, 0}, {100, 0}, {150, 0}, {0, 100}}
+	touched := []bool{true, false, true, false, false}
+	dx := []float64{10, 0, 20, 0, 0}
+	dy := make([]float64, 5)
+	iup(points, []int{3, 4}, touched, dx, dy)
+	if !slices.Equal(dx, []float64{10, 15, 20, 20, 0}) {
+		t.Errorf("unexpected interpolated deltas %v", dx)
+	}
+}